@@ -18,6 +18,10 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -62,8 +66,12 @@ var (
 	compressPaths           = flag.Bool("compress_paths", false, "If set to true, the schema's paths are compressed, according to OpenConfig YANG module conventions. Path structs generation currently only supports compressed paths.")
 
 	// Common flags used for GoStruct and PathStruct generation.
-	yangPaths                            = flag.String("path", "", "Comma separated list of paths to be recursively searched for included modules or submodules within the defined YANG modules.")
+	yangPaths                            = flag.String("path", "", "Comma separated list of paths to be recursively searched for included modules or submodules within the defined YANG modules. Each path may be a local directory; a local tar (.tar, .tar.gz, .tgz) or zip (.zip) archive; an HTTP or HTTPS URL of such an archive or of a single YANG module; or a git repository specified as git+<url>[#<ref>]. Sources other than local directories are fetched and cached in the directory specified by path_cache_dir.")
+	pathCacheDir                         = flag.String("path_cache_dir", "", "The directory in which sources specified by path that are not local directories are cached. If unset, a directory within the user's cache directory is used.")
 	excludeModules                       = flag.String("exclude_modules", "", "Comma separated set of module names that should be excluded from code generation this can be used to ensure overlapping namespaces can be ignored.")
+	excludePaths                         = flag.String("exclude_path", "", "Comma separated set of patterns of the schema paths of data nodes that should be excluded, along with their descendants, from code generation. Patterns are matched against uncompressed paths, which do not include choice and case nodes, element by element; each element may contain the wildcards of Go's path.Match, or be ** to match any number of elements, e.g., /interfaces/*/hold-time or /**/state.")
+	features                             = flag.String("features", "", "Comma separated set of features, named either module:feature or feature, that are supported. Data nodes whose if-feature statements are not satisfied by the supported features are excluded from code generation. If unset, all features are supported, except for those in exclude_features.")
+	excludeFeatures                      = flag.String("exclude_features", "", "Comma separated set of features, named either module:feature or feature, that are not supported, such that data nodes that are conditional on them are excluded from code generation.")
 	packageName                          = flag.String("package_name", "ocstructs", "The name of the Go package that should be generated. For path struct generation, if split_pathstructs_by_module=true, this is the name of fake root package.")
 	ignoreCircDeps                       = flag.Bool("ignore_circdeps", false, "If set to true, circular dependencies between submodules are ignored.")
 	fakeRootName                         = flag.String("fakeroot_name", "", "The name of the fake root entity.")
@@ -82,12 +90,21 @@ var (
 	ignoreDeviateNotsupported            = flag.Bool("ignore_deviate_notsupported", false, "If set to true, 'deviate not-supported' YANG statements are ignored, thus target nodes are retained in the generated code.")
 	skipDeprecated                       = flag.Bool("skip_deprecated", false, "If set to true, YANG fields with status 'deprecated' are excluded from the generated code.")
 	skipObsolete                         = flag.Bool("skip_obsolete", false, "If set to true, YANG fields with status 'obsolete' are excluded from the generated code.")
+	flattenEnumUnions                    = flag.Bool("flatten_enum_unions", false, "If set to true, unions whose members are all enumerations are represented by a single enumerated type containing the values of each member, named as per an enumeration leaf, or after the typedef that defines the union.")
+	typedefUnionNames                    = flag.Bool("typedef_union_names", false, "If set to true, the types generated for unions defined by a typedef are named after the typedef and its defining module rather than after the path of each leaf using it, such that the leaves share a single type.")
+	lintReportFile                       = flag.String("lint_report", "", "If set, the input YANG modules are checked against the OpenConfig style guidelines, and the findings are written to the specified file. The report is written as JSON if the file name ends in .json, and as text otherwise. Specify \"-\" for stdout.")
+
+	// Flags used for profiling the generator.
+	profileMode       = flag.String("profile", "", "If set to one of cpu, mem or trace, the corresponding profile of the generator is collected and written to the file specified by profile_output_file.")
+	profileOutputFile = flag.String("profile_output_file", "", "The file that the profile specified by the profile flag should be written to.")
+	reportTimings     = flag.Bool("report_phase_timings", false, "If set to true, a summary of the time spent in each phase of generation (parse, IR, codegen, write) is written to stderr.")
 
 	// Flags used for GoStruct generation only.
 	generateFakeRoot        = flag.Bool("generate_fakeroot", false, "If set to true, a fake element at the root of the data tree is generated. By default the fake root entity is named Device, its name can be controlled with the fakeroot_name flag.")
 	generateSchema          = flag.Bool("include_schema", true, "If set to true, the YANG schema will be encoded as JSON and stored in the generated code artefact.")
 	ytypesImportPath        = flag.String("ytypes_path", genutil.GoDefaultYtypesImportPath, "The import path to use for ytypes.")
 	goyangImportPath        = flag.String("goyang_path", genutil.GoDefaultGoyangImportPath, "The import path to use for goyang's yang package.")
+	yrestconfImportPath     = flag.String("yrestconf_path", genutil.GoDefaultYRESTCONFImportPath, "The import path to use for yrestconf.")
 	generateRename          = flag.Bool("generate_rename", false, "If set to true, rename methods are generated for lists within the Go code.")
 	addAnnotations          = flag.Bool("annotations", false, "If set to true, metadata annotations are added within the generated structs.")
 	annotationPrefix        = flag.String("annotation_prefix", gogen.DefaultAnnotationPrefix, "String to be appended to each metadata field within the generated structs if annoations is set to true.")
@@ -99,9 +116,22 @@ var (
 	generateLeafSetters     = flag.Bool("generate_leaf_setters", false, "If set to true, setters for YANG leaves are generated within the Go code.")
 	generateSimpleUnions    = flag.Bool("generate_simple_unions", false, "If set to true, then generated typedefs will be used to represent union subtypes within Go code instead of wrapper struct types.")
 	includeModelData        = flag.Bool("include_model_data", false, "If set to true, a slice of gNMI ModelData messages are included in the generated Go code containing the details of the input schemas from which the code was generated.")
+	includeYANGLibrary      = flag.Bool("include_yang_library", false, "If set to true, the ietf-yang-library (RFC 8525) description of the input schemas is included in the generated Go code, such that it can be served using ygot.NewYANGLibrary.")
+	generateRESTCONF        = flag.Bool("generate_restconf_handler", false, "If set to true, a NewRESTCONFHandler function is included in the generated Go code, which returns an HTTP handler serving the data tree of the fake root over RESTCONF (RFC 8040), stored using a user-supplied yrestconf.Store. Requires include_schema=true and generate_fakeroot=true.")
 	generatePopulateDefault = flag.Bool("generate_populate_defaults", false, "If set to true, a PopulateDefault method will be generated for all GoStructs which recursively populates default values.")
 	generateValidateFnName  = flag.String("validate_fn_name", "Validate", "The Name of the proxy function for the Validate functionality.")
 	generateOrderedMaps     = flag.Bool("generate_ordered_maps", true, "If set to true, ordered map structures satisfying the interface ygot.GoOrderedMap will be generated for `ordered-by user` lists instead of Go built-in maps.")
+	presenceBitmaps         = flag.Bool("presence_bitmaps", false, "If set to true, scalar leaves other than list keys are stored as values rather than pointers within the generated Go structs, with whether each leaf is set being recorded in a presence bitmap, and IsSet, Set and Clear methods are generated for each such leaf.")
+	emptyLeafPresence       = flag.Bool("empty_leaf_presence", false, "If set to true, whether each leaf of type empty is present is recorded in a presence bitmap within its generated Go struct, such that an absent leaf can be distinguished from one whose value is false, and IsSet, Set and Clear methods are generated for each such leaf.")
+	unionLeafListAccessors  = flag.Bool("generate_union_leaflist_accessors", false, "If set to true, and generate_simple_unions is set to true, methods are generated that return each member of a leaf-list of union type as each of the types of the union, e.g., GetPortAsString and GetPortAsUint16 for the leaf-list port.")
+	generateKeyedLists      = flag.Bool("generate_keyed_lists", false, "If set to true, keyed YANG lists other than those generated as ordered maps are represented using the generic ygot.KeyedList type rather than Go maps, which provides Get, GetOrCreate, Delete, Len and sorted iteration methods.")
+	enumDescriptions        = flag.Bool("generate_enum_descriptions", false, "If set to true, the description and status of each value of the generated enumerated types are output within the ΛEnum map, as the Description and Status fields of the ygot.EnumDefinition of each value.")
+	generateLeafConstraints = flag.Bool("generate_leaf_constraints", false, "If set to true, exported constants are generated for the minimum and maximum values, minimum and maximum lengths, and patterns of the leaves of each generated Go struct.")
+	generateDocComments     = flag.Bool("generate_doc_comments", true, "If set to true, the description and reference statements of YANG nodes are output as doc comments on the generated Go structs, their fields, and enumerated types.")
+	docCommentMaxLength     = flag.Int("doc_comment_max_length", 0, "The maximum length of the YANG description output within a generated Go doc comment, longer descriptions being truncated at a word boundary. If zero, descriptions are not truncated.")
+	extensionPoints         = flag.Bool("generate_extension_points", false, "If set to true, each generated Go struct has a ΛExtensions field, to which the extension structs of packages generated separately for modules that augment the schema are attached at runtime.")
+	extensionModules        = flag.String("extension_modules", "", "Comma separated list of the modules whose nodes augment the package at extended_package_path. If set, the Go structs for the nodes of other modules are generated as extension structs that hold only the fields of the nodes of these modules, and register themselves with the struct of the same name within the extended package.")
+	extendedPackagePath     = flag.String("extended_package_path", "", "The Go import path of the package, generated with generate_extension_points, that is extended by the extension structs generated for extension_modules.")
 
 	// Flags used for PathStruct generation only.
 	schemaStructPath        = flag.String("schema_struct_path", "", "The Go import path for the schema structs package. This should be specified if and only if schema structs are not being generated at the same time as path structs.")
@@ -230,29 +260,80 @@ func splitCodeByFileN(goCode *gogen.GeneratedCode, fileN int) (map[string]string
 
 // writeFiles creates or truncates files in a given base directory and writes
 // to them. Keys of the contents map are file names, and values are the
-// contents to be written. An error is returned if the base directory does not
-// exist. If a file cannot be written, the function aborts with the error,
-// leaving an unspecified set of the other input files written with their given
+// contents to be written. Files whose contents are unchanged are not
+// rewritten. An error is returned if the base directory does not exist. If a
+// file cannot be written, the function aborts with the error, leaving an
+// unspecified set of the other input files written with their given
 // contents.
 func writeFiles(dir string, out map[string]string) error {
 	for filename, contents := range out {
 		if len(contents) == 0 {
 			continue
 		}
-		fh := genutil.OpenFile(filepath.Join(dir, filename))
-		if fh == nil {
-			return fmt.Errorf("could not open file %q", filename)
-		}
-		if _, err := fh.WriteString(contents); err != nil {
+		if err := writeOutput(filepath.Join(dir, filename), []byte(contents)); err != nil {
 			return err
 		}
-		// flush & close written files before function finishes.
-		defer genutil.SyncFile(fh)
 	}
 
 	return nil
 }
 
+// writeOutput writes contents to the file fn, or to os.Stdout if fn is "-".
+// If the file already has the supplied contents it is not rewritten, such
+// that its modification time is retained, and builds that depend upon it
+// are not invalidated.
+func writeOutput(fn string, contents []byte) error {
+	if fn == "-" {
+		_, err := os.Stdout.Write(contents)
+		return err
+	}
+	if existing, err := os.ReadFile(fn); err == nil && bytes.Equal(existing, contents) {
+		return nil
+	}
+	fh, err := os.Create(fn)
+	if err != nil {
+		return fmt.Errorf("could not open file %q: %v", fn, err)
+	}
+	if _, err := fh.Write(contents); err != nil {
+		fh.Close()
+		return err
+	}
+	if err := fh.Sync(); err != nil {
+		fh.Close()
+		return err
+	}
+	return fh.Close()
+}
+
+// writeLintReport writes the lint findings to w, as JSON if asJSON is true,
+// and as text otherwise.
+func writeLintReport(w io.Writer, findings []*ygen.LintFinding, asJSON bool) error {
+	if !asJSON {
+		return ygen.WriteLintReport(w, findings)
+	}
+	if findings == nil {
+		findings = []*ygen.LintFinding{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(findings)
+}
+
+// writeLintReportFile writes the lint findings to the file fn, or to
+// os.Stdout if fn is "-". The findings are written as JSON if fn ends in
+// .json.
+func writeLintReportFile(fn string, findings []*ygen.LintFinding) error {
+	if fn == "-" {
+		return writeLintReport(os.Stdout, findings, false)
+	}
+	fh := genutil.OpenFile(fn)
+	if fh == nil {
+		return fmt.Errorf("could not open file %q", fn)
+	}
+	defer genutil.SyncFile(fh)
+	return writeLintReport(fh, findings, filepath.Ext(fn) == ".json")
+}
+
 // processFlags does some minimal processing of flags where otherwise
 // inconvenient before they're passed to the code generators.
 func processFlags() {
@@ -269,39 +350,132 @@ func processFlags() {
 func main() {
 	flag.Parse()
 	processFlags()
+	if err := run(); err != nil {
+		log.Exit(err)
+	}
+}
+
+// run performs code generation as specified by the command-line flags. The
+// profile and phase timing summary, where enabled, are written before it
+// returns, including when code generation fails.
+func run() error {
+	stopProfile, err := genutil.StartProfile(genutil.ProfileMode(*profileMode), *profileOutputFile)
+	if err != nil {
+		return fmt.Errorf("Error: cannot start profiling: %v", err)
+	}
+	var timer *genutil.PhaseTimer
+	if *reportTimings {
+		timer = genutil.NewPhaseTimer()
+	}
+	// This is deferred first such that it runs after all output files have
+	// been synced and closed.
+	defer func() {
+		if err := stopProfile(); err != nil {
+			log.Errorf("Error: cannot write profile: %v", err)
+		}
+		if timer != nil {
+			timer.WriteSummary(os.Stderr)
+		}
+	}()
+
 	// Extract the set of modules that code is to be generated for,
 	// throwing an error if the set is empty.
 	generateModules := flag.Args()
 	if len(generateModules) == 0 {
-		log.Exitln("Error: no input modules specified")
+		return errors.New("Error: no input modules specified")
 	}
 
 	if !*generateGoStructs && !*generatePathStructs {
-		log.Exitf("Error: Neither schema structs nor path structs generation is enabled.")
+		return errors.New("Error: Neither schema structs nor path structs generation is enabled.")
 	}
 
 	if *generatePathStructs {
 		if *generateGoStructs && *schemaStructPath != "" {
-			log.Exitf("Error: provided non-empty schema_struct_path for import by path structs file(s), but schema structs are also to be generated within the same package.")
+			return errors.New("Error: provided non-empty schema_struct_path for import by path structs file(s), but schema structs are also to be generated within the same package.")
 		}
 		if !*generateGoStructs && *schemaStructPath == "" {
-			log.Exitf("Error: need to provide schema_struct_path for import by path structs file(s) when schema structs are not being generated at the same time.")
+			return errors.New("Error: need to provide schema_struct_path for import by path structs file(s) when schema structs are not being generated at the same time.")
+		}
+		if *splitByModule && *baseImportPath == "" && *goModulePath == "" {
+			return errors.New("Error: when splitting path structs by module, base_import_path needs to be set.")
+		}
+	}
+
+	if *generateGoStructs {
+		generateGoStructsSingleFile := *ocStructsOutputFile != ""
+		generateGoStructsMultipleFiles := *outputDir != ""
+		if generateGoStructsSingleFile && generateGoStructsMultipleFiles {
+			return fmt.Errorf("Error: cannot specify both output_file (%s) and output_dir (%s)", *ocStructsOutputFile, *outputDir)
 		}
-		if *splitByModule && *baseImportPath == "" {
-			log.Exitf("Error: when splitting path structs by module, base_import_path needs to be set.")
+		if !generateGoStructsSingleFile && !generateGoStructsMultipleFiles {
+			return errors.New("Error: Go struct generation requires a specified output file or output directory.")
 		}
 	}
 
+	if *generatePathStructs {
+		if !*compressPaths {
+			return errors.New("Error: path struct generation not supported for uncompressed paths. Please use compressed paths or remove output file flag for path struct generation.")
+		}
+		generatePathStructsSingleFile := *ocPathStructsOutputFile != ""
+		generatePathStructsMultipleFiles := *outputDir != ""
+		if !generatePathStructsSingleFile && !generatePathStructsMultipleFiles {
+			return errors.New("Error: path struct generation requires a specified output file or directory.")
+		}
+		if !*splitByModule && generatePathStructsSingleFile && generatePathStructsMultipleFiles {
+			return fmt.Errorf("Error: cannot specify both path_structs_output_file (%s) and output_dir (%s)", *ocPathStructsOutputFile, *outputDir)
+		}
+		if *splitByModule && *goModulePath == "" && (!generatePathStructsSingleFile || !generatePathStructsMultipleFiles) {
+			return errors.New("Error: when splitting path structs by module, both output_dir and path_structs_output_file need to be set.")
+		}
+	}
+
+	if *goModulePath != "" {
+		if *outputDir == "" || *ocStructsOutputFile != "" || *ocPathStructsOutputFile != "" {
+			return errors.New("Error: when writing a Go module, output_dir must be set, and output_file and path_structs_output_file must not be set.")
+		}
+		if !*generateGoStructs || *schemaStructPath != "" {
+			return errors.New("Error: when writing a Go module, schema structs must be generated within the module.")
+		}
+	}
+
+	if *uncompressedPkg != "" {
+		if *goModulePath == "" || !*compressPaths {
+			return errors.New("Error: uncompressed_package_name can only be set when writing a Go module with compressed paths.")
+		}
+		if *uncompressedPkg == *packageName || *uncompressedPkg == *packageName+*packageSuffix {
+			return fmt.Errorf("Error: uncompressed_package_name (%s) must differ from the names of the other generated packages.", *uncompressedPkg)
+		}
+	}
+
+	if *verifyCompile && (*ocStructsOutputFile == "-" || *ocPathStructsOutputFile == "-") {
+		return errors.New("Error: generated code cannot be verified when it is written to stdout.")
+	}
+
+	if *watch && (*ocStructsOutputFile == "-" || *ocPathStructsOutputFile == "-") {
+		return errors.New("Error: watch mode cannot be used when writing generated code to stdout.")
+	}
+
 	// Determine the set of paths that should be searched for included
 	// modules. This is supplied by the user as a set of comma-separated
-	// paths, so we split the string. Additionally, for each path
-	// specified, we append "..." to ensure that the directory is
-	// recursively searched.
+	// paths, so we split the string. Each path is resolved to a local
+	// directory, fetching it if it is remote or an archive. Additionally,
+	// for each path specified, we append "..." to ensure that the directory
+	// is recursively searched. Only local directories are watched, since
+	// fetched sources are not expected to change.
+	yangDirs := []string{}
 	includePaths := []string{}
 	if len(*yangPaths) > 0 {
+		fetcher := &genutil.SourceFetcher{CacheDir: *pathCacheDir}
 		pathParts := strings.Split(*yangPaths, ",")
 		for _, path := range pathParts {
-			includePaths = append(includePaths, filepath.Join(path, "..."))
+			dir, err := fetcher.Resolve(context.Background(), path)
+			if err != nil {
+				return fmt.Errorf("Error: %v", err)
+			}
+			if genutil.IsLocalSource(path) {
+				yangDirs = append(yangDirs, dir)
+			}
+			includePaths = append(includePaths, filepath.Join(dir, "..."))
 		}
 	}
 
@@ -315,134 +489,248 @@ func main() {
 		}
 	}
 
-	if *generateGoStructs {
-		generateGoStructsSingleFile := *ocStructsOutputFile != ""
-		generateGoStructsMultipleFiles := *outputDir != ""
-		if generateGoStructsSingleFile && generateGoStructsMultipleFiles {
-			log.Exitf("Error: cannot specify both output_file (%s) and output_dir (%s)", *ocStructsOutputFile, *outputDir)
-		}
-		if !generateGoStructsSingleFile && !generateGoStructsMultipleFiles {
-			log.Exitf("Error: Go struct generation requires a specified output file or output directory.")
+	if err := generate(generateModules, includePaths, modsExcluded, timer); err != nil {
+		return err
+	}
+
+	if *watch {
+		w := &watcher{
+			files:    generateModules,
+			dirs:     yangDirs,
+			interval: *watchInterval,
+			debounce: *watchDebounce,
 		}
+		w.run(func(changed []string) {
+			log.Infof("Regenerating code, changed files: %s", strings.Join(changed, ", "))
+			if err := generate(generateModules, includePaths, modsExcluded, timer); err != nil {
+				log.Errorf("Error: %v", err)
+				return
+			}
+			log.Infof("Regenerated code.")
+		})
+	}
+	return nil
+}
 
+// generate generates code for the YANG modules in generateModules, with
+// their imports and includes searched for in includePaths, excluding the
+// modules in modsExcluded, and writes it to the outputs specified by the
+// command-line flags, which must have been validated. Outputs whose content
+// has not changed are not rewritten.
+func generate(generateModules, includePaths, modsExcluded []string, timer *genutil.PhaseTimer) error {
+	// out is the set of generated files, keyed by the name of the file
+	// that they are to be written to.
+	out := map[string]string{}
+	// ir is the IR from which the schema structs are generated, which is
+	// supplied to plugins.
+	var ir *ygen.IR
+	if *generateGoStructs {
 		compressBehaviour, err := genutil.TranslateToCompressBehaviour(*compressPaths, *excludeState, *preferOperationalState)
 		if err != nil {
-			log.Exitf("ERROR Generating Code: %v\n", err)
+			return fmt.Errorf("ERROR Generating Code: %v", err)
 		}
 
 		// Perform the code generation.
-		cg := gogen.New(
-			"",
-			ygen.IROptions{
-				ParseOptions: ygen.ParseOpts{
-					IgnoreUnsupportedStatements: *ignoreUnsupportedStatements,
-					ExcludeModules:              modsExcluded,
-					YANGParseOptions: yang.Options{
-						IgnoreSubmoduleCircularDependencies: *ignoreCircDeps,
-						DeviateOptions: yang.DeviateOptions{
-							IgnoreDeviateNotSupported: *ignoreDeviateNotsupported,
-						},
-					},
-				},
-				TransformationOptions: ygen.TransformationOpts{
-					CompressBehaviour:                    compressBehaviour,
-					GenerateFakeRoot:                     *generateFakeRoot,
-					FakeRootName:                         *fakeRootName,
-					SkipEnumDeduplication:                *skipEnumDedup,
-					ShortenEnumLeafNames:                 *shortenEnumLeafNames,
-					EnumOrgPrefixesToTrim:                enumOrgPrefixesToTrim,
-					UseDefiningModuleForTypedefEnumNames: *useDefiningModuleForTypedefEnumNames,
-					EnumerationsUseUnderscores:           true,
-					SkipDeprecated:                       *skipDeprecated,
-					SkipObsolete:                         *skipObsolete,
-				},
-			},
-			gogen.GoOpts{
-				PackageName:                         *packageName,
-				GenerateJSONSchema:                  *generateSchema,
-				IncludeDescriptions:                 *includeDescriptions,
-				YgotImportPath:                      *ygotImportPath,
-				YtypesImportPath:                    *ytypesImportPath,
-				GoyangImportPath:                    *goyangImportPath,
-				GenerateRenameMethod:                *generateRename,
-				AddAnnotationFields:                 *addAnnotations,
-				AnnotationPrefix:                    *annotationPrefix,
-				AddYangPresence:                     *addYangPresence,
-				GenerateGetters:                     *generateGetters,
-				GenerateDeleteMethod:                *generateDelete,
-				GenerateAppendMethod:                *generateAppend,
-				GenerateLeafGetters:                 *generateLeafGetters,
-				GenerateLeafSetters:                 *generateLeafSetters,
-				GeneratePopulateDefault:             *generatePopulateDefault,
-				ValidateFunctionName:                *generateValidateFnName,
-				GenerateSimpleUnions:                *generateSimpleUnions,
-				IncludeModelData:                    *includeModelData,
-				AppendEnumSuffixForSimpleUnionEnums: *appendEnumSuffixForSimpleUnionEnums,
-				IgnoreShadowSchemaPaths:             *ignoreShadowSchemaPaths,
-				GenerateOrderedListsAsUnorderedMaps: !*generateOrderedMaps,
-			},
-		)
+		cg := newGoCodeGenerator(compressBehaviour, *packageName, modsExcluded, timer)
 
 		generatedGoCode, errs := cg.Generate(generateModules, includePaths)
 		if errs != nil {
-			log.Exitf("ERROR Generating GoStruct Code: %v\n", errs)
+			return fmt.Errorf("ERROR Generating GoStruct Code: %v", errs)
 		}
 
-		switch {
-		case generateGoStructsSingleFile:
-			var outfh *os.File
-			switch *ocStructsOutputFile {
-			case "-":
-				// If "-" is the output file name, we output to os.Stdout, otherwise
-				// we write to the specified file.
-				outfh = os.Stdout
-			default:
-				// Assign the newly created filehandle to the outfh, and ensure
-				// that it is synced and closed before exit of main.
-				outfh = genutil.OpenFile(*ocStructsOutputFile)
-				defer genutil.SyncFile(outfh)
+		if *lintReportFile != "" {
+			if err := writeLintReportFile(*lintReportFile, generatedGoCode.LintFindings); err != nil {
+				return fmt.Errorf("ERROR writing lint report: %v", err)
 			}
+		}
 
-			if err := writeGoCodeSingleFile(outfh, generatedGoCode); err != nil {
-				log.Exitf("ERROR writing GoStruct Code to single file: %v\n", err)
+		ir = generatedGoCode.IR
+		switch {
+		case *goModulePath != "":
+			var uncompressedCode *gogen.GeneratedCode
+			if *uncompressedPkg != "" {
+				uncompressedBehaviour, err := genutil.TranslateToCompressBehaviour(false, *excludeState, false)
+				if err != nil {
+					return fmt.Errorf("ERROR Generating Code: %v", err)
+				}
+				code, errs := newGoCodeGenerator(uncompressedBehaviour, *uncompressedPkg, modsExcluded, timer).Generate(generateModules, includePaths)
+				if errs != nil {
+					return fmt.Errorf("ERROR Generating uncompressed GoStruct Code: %v", errs)
+				}
+				uncompressedCode = code
 			}
-		case generateGoStructsMultipleFiles:
+			files, err := newGoModuleLayout().structFiles(generatedGoCode, uncompressedCode, *structsFileN)
+			if err != nil {
+				return fmt.Errorf("ERROR writing Go module: %v", err)
+			}
+			for fn, contents := range files {
+				out[filepath.Join(*outputDir, fn)] = contents
+			}
+		case *ocStructsOutputFile != "":
+			var b strings.Builder
+			if err := writeGoCodeSingleFile(&b, generatedGoCode); err != nil {
+				return fmt.Errorf("ERROR writing GoStruct Code to single file: %v", err)
+			}
+			out[*ocStructsOutputFile] = b.String()
+		default:
 			// Write the Go code to a series of output files.
-			out, err := splitCodeByFileN(generatedGoCode, *structsFileN)
+			files, err := splitCodeByFileN(generatedGoCode, *structsFileN)
 			if err != nil {
-				log.Exitf("ERROR writing split GoStruct Code: %v\n", err)
+				return fmt.Errorf("ERROR writing split GoStruct Code: %v", err)
 			}
-			if err := writeFiles(*outputDir, out); err != nil {
-				log.Exitf("Error while writing schema struct files: %v", err)
+			for fn, contents := range files {
+				out[filepath.Join(*outputDir, fn)] = contents
 			}
 		}
 	}
 
 	// Generate PathStructs.
-	if !*generatePathStructs {
-		return
+	if *generatePathStructs {
+		if err := generatePathStructCode(generateModules, includePaths, modsExcluded, timer, out); err != nil {
+			return err
+		}
 	}
-	if !*compressPaths {
-		log.Exitf("Error: path struct generation not supported for uncompressed paths. Please use compressed paths or remove output file flag for path struct generation.")
+
+	if *goModulePath != "" {
+		var err error
+		if out, err = newGoModuleLayout().finalize(*outputDir, out); err != nil {
+			return fmt.Errorf("ERROR writing Go module: %v", err)
+		}
 	}
 
-	generatePathStructsSingleFile := *ocPathStructsOutputFile != ""
-	generatePathStructsMultipleFiles := *outputDir != ""
-	if !generatePathStructsSingleFile && !generatePathStructsMultipleFiles {
-		log.Exitf("Error: path struct generation requires a specified output file or directory.")
+	out, err := runPlugins(ir, out)
+	if err != nil {
+		return err
 	}
-	if !*splitByModule && generatePathStructsSingleFile && generatePathStructsMultipleFiles {
-		log.Exitf("Error: cannot specify both path_structs_output_file (%s) and output_dir (%s)", *ocPathStructsOutputFile, *outputDir)
+
+	if *statsReportFile != "" {
+		if err := writeStatsReportFile(*statsReportFile, computeStats(ir, out)); err != nil {
+			return fmt.Errorf("ERROR writing statistics report: %v", err)
+		}
 	}
-	if *splitByModule && (!generatePathStructsSingleFile || !generatePathStructsMultipleFiles) {
-		log.Exitf("Error: when splitting path structs by module, both output_dir and path_structs_output_file need to be set.")
+
+	if *verifyCompile {
+		modDir := ""
+		if *goModulePath != "" {
+			modDir = *outputDir
+		}
+		if err := verifyGoCode(out, modDir); err != nil {
+			return fmt.Errorf("ERROR generated code does not compile, no files were written: %v", err)
+		}
+	}
+
+	defer timer.Start(genutil.PhaseWrite)()
+	if *goModulePath != "" {
+		if err := createDirs(out); err != nil {
+			return err
+		}
+	}
+	if err := writeFiles("", out); err != nil {
+		return fmt.Errorf("Error while writing generated code: %v", err)
+	}
+	return nil
+}
+
+// newGoCodeGenerator returns the generator of the schema structs package
+// named pkgName, with the schema compressed according to compressBehaviour,
+// excluding the modules in modsExcluded, configured according to the
+// command-line flags.
+func newGoCodeGenerator(compressBehaviour genutil.CompressBehaviour, pkgName string, modsExcluded []string, timer *genutil.PhaseTimer) *gogen.CodeGenerator {
+	return gogen.New(
+		"",
+		ygen.IROptions{
+			ParseOptions: ygen.ParseOpts{
+				IgnoreUnsupportedStatements: *ignoreUnsupportedStatements,
+				ExcludeModules:              modsExcluded,
+				ExcludePaths:                splitList(*excludePaths),
+				Features:                    splitList(*features),
+				ExcludeFeatures:             splitList(*excludeFeatures),
+				Lint:                        *lintReportFile != "",
+				YANGParseOptions: yang.Options{
+					IgnoreSubmoduleCircularDependencies: *ignoreCircDeps,
+					DeviateOptions: yang.DeviateOptions{
+						IgnoreDeviateNotSupported: *ignoreDeviateNotsupported,
+					},
+				},
+			},
+			TransformationOptions: ygen.TransformationOpts{
+				CompressBehaviour:                    compressBehaviour,
+				GenerateFakeRoot:                     *generateFakeRoot,
+				FakeRootName:                         *fakeRootName,
+				SkipEnumDeduplication:                *skipEnumDedup,
+				ShortenEnumLeafNames:                 *shortenEnumLeafNames,
+				EnumOrgPrefixesToTrim:                enumOrgPrefixesToTrim,
+				UseDefiningModuleForTypedefEnumNames: *useDefiningModuleForTypedefEnumNames,
+				EnumerationsUseUnderscores:           true,
+				SkipDeprecated:                       *skipDeprecated,
+				SkipObsolete:                         *skipObsolete,
+				FlattenEnumerationUnions:             *flattenEnumUnions,
+				TypedefUnionNames:                    *typedefUnionNames,
+			},
+			PhaseTimer: timer,
+		},
+		gogen.GoOpts{
+			PackageName:                         pkgName,
+			GenerateJSONSchema:                  *generateSchema,
+			IncludeDescriptions:                 *includeDescriptions,
+			YgotImportPath:                      *ygotImportPath,
+			YtypesImportPath:                    *ytypesImportPath,
+			GoyangImportPath:                    *goyangImportPath,
+			YRESTCONFImportPath:                 *yrestconfImportPath,
+			GenerateRenameMethod:                *generateRename,
+			AddAnnotationFields:                 *addAnnotations,
+			AnnotationPrefix:                    *annotationPrefix,
+			AddYangPresence:                     *addYangPresence,
+			GenerateGetters:                     *generateGetters,
+			GenerateDeleteMethod:                *generateDelete,
+			GenerateAppendMethod:                *generateAppend,
+			GenerateLeafGetters:                 *generateLeafGetters,
+			GenerateLeafSetters:                 *generateLeafSetters,
+			GeneratePopulateDefault:             *generatePopulateDefault,
+			ValidateFunctionName:                *generateValidateFnName,
+			GenerateSimpleUnions:                *generateSimpleUnions,
+			IncludeModelData:                    *includeModelData,
+			IncludeYANGLibrary:                  *includeYANGLibrary,
+			GenerateRESTCONFHandler:             *generateRESTCONF,
+			AppendEnumSuffixForSimpleUnionEnums: *appendEnumSuffixForSimpleUnionEnums,
+			IgnoreShadowSchemaPaths:             *ignoreShadowSchemaPaths,
+			GenerateOrderedListsAsUnorderedMaps: !*generateOrderedMaps,
+			PresenceBitmaps:                     *presenceBitmaps,
+			EmptyLeafPresence:                   *emptyLeafPresence,
+			GenerateLeafConstraints:             *generateLeafConstraints,
+			GenerateUnionLeafListAccessors:      *unionLeafListAccessors,
+			GenerateKeyedLists:                  *generateKeyedLists,
+			GenerateEnumDescriptions:            *enumDescriptions,
+			GenerateDocComments:                 *generateDocComments,
+			DocCommentMaxLength:                 *docCommentMaxLength,
+			GenerateExtensionPoints:             *extensionPoints,
+			ExtensionModules:                    splitList(*extensionModules),
+			ExtendedPackagePath:                 *extendedPackagePath,
+		},
+	)
+}
+
+// generatePathStructCode generates the path struct code for the YANG
+// modules in generateModules, with their imports and includes searched for
+// in includePaths, excluding the modules in modsExcluded. The generated code
+// is added to out, keyed by the name of the file that it is to be written
+// to.
+func generatePathStructCode(generateModules, includePaths, modsExcluded []string, timer *genutil.PhaseTimer, out map[string]string) error {
+
+	pkgName, schemaPkgPath, baseImport := *packageName, *schemaStructPath, *baseImportPath
+	var layout *goModuleLayout
+	if *goModulePath != "" {
+		// The path structs are a separate package of the module, which
+		// imports the schema structs package.
+		layout = newGoModuleLayout()
+		pkgName, schemaPkgPath, baseImport = layout.pathPkg, layout.importPath(layout.structPkg), layout.importPath(layout.pathPkg)
 	}
 
 	// Perform the code generation.
 	pcg := &ypathgen.GenConfig{
-		PackageName: *packageName,
+		PackageName: pkgName,
 		GoImports: ypathgen.GoImports{
-			SchemaStructPkgPath: *schemaStructPath,
+			SchemaStructPkgPath: schemaPkgPath,
 			YgotImportPath:      *ygotImportPath,
 		},
 		PreferOperationalState:               *preferOperationalState,
@@ -452,9 +740,14 @@ func main() {
 		EnumOrgPrefixesToTrim:                enumOrgPrefixesToTrim,
 		UseDefiningModuleForTypedefEnumNames: *useDefiningModuleForTypedefEnumNames,
 		AppendEnumSuffixForSimpleUnionEnums:  *appendEnumSuffixForSimpleUnionEnums,
+		FlattenEnumerationUnions:             *flattenEnumUnions,
+		TypedefUnionNames:                    *typedefUnionNames,
 		FakeRootName:                         *fakeRootName,
 		PathStructSuffix:                     *pathStructSuffix,
 		ExcludeModules:                       modsExcluded,
+		ExcludePaths:                         splitList(*excludePaths),
+		Features:                             splitList(*features),
+		ExcludeFeatures:                      splitList(*excludeFeatures),
 		IgnoreUnsupportedStatements:          *ignoreUnsupportedStatements,
 		YANGParseOptions: yang.Options{
 			IgnoreSubmoduleCircularDependencies: *ignoreCircDeps,
@@ -465,16 +758,33 @@ func main() {
 		SimplifyWildcardPaths:   *simplifyWildcardPaths,
 		TrimPackagePrefix:       *trimPathPackagePrefix,
 		SplitByModule:           *splitByModule,
-		BaseImportPath:          *baseImportPath,
+		BaseImportPath:          baseImport,
 		PackageSuffix:           *packageSuffix,
+		PhaseTimer:              timer,
 	}
 
 	pathCode, _, errs := pcg.GeneratePathCode(generateModules, includePaths)
 	if errs != nil {
-		log.Exitf("ERROR Generating PathStruct Code: %s\n", errs)
+		return fmt.Errorf("ERROR Generating PathStruct Code: %s", errs)
 	}
 
 	switch {
+	case layout != nil:
+		// Each package is written to its own directory beneath that of
+		// the path structs package.
+		for name, code := range pathCode {
+			dir := filepath.Join(*outputDir, layout.pathPkg)
+			if name != pcg.PackageName {
+				dir = filepath.Join(dir, name)
+			}
+			if *pathStructsFileN <= 1 {
+				out[filepath.Join(dir, fmt.Sprintf("%s.go", name))] = code.String()
+				continue
+			}
+			if err := addPathPackage(out, pathCode, name, dir); err != nil {
+				return err
+			}
+		}
 	case *splitByModule:
 		for packageName, code := range pathCode {
 			// The fake root package is written to ocPathStructsOutputFile.
@@ -482,56 +792,47 @@ func main() {
 			path := *ocPathStructsOutputFile
 			if packageName != pcg.PackageName {
 				if err := os.MkdirAll(filepath.Join(*outputDir, packageName), 0755); err != nil {
-					log.Exitf("failed to create directory for package %q: %v", packageName, err)
+					return fmt.Errorf("failed to create directory for package %q: %v", packageName, err)
 				}
 				path = filepath.Join(*outputDir, packageName, fmt.Sprintf("%s.go", packageName))
 			}
 			if *pathStructsFileN <= 1 || packageName == pcg.PackageName {
-				outfh := genutil.OpenFile(path)
-				defer genutil.SyncFile(outfh)
-				err := writeGoPathCodeSingleFile(outfh, code)
-				if err != nil {
-					log.Exitf("Error while writing path struct file: %v", err)
-				}
+				out[path] = code.String()
 			} else {
-				if err := writePathPackage(pathCode, packageName, filepath.Join(*outputDir, packageName)); err != nil {
-					log.Errorln(err)
+				if err := addPathPackage(out, pathCode, packageName, filepath.Join(*outputDir, packageName)); err != nil {
+					return err
 				}
 			}
 		}
-	case generatePathStructsSingleFile:
-		var outfh *os.File
-		switch *ocPathStructsOutputFile {
-		case "-":
-			// If "-" is the output file name, we output to os.Stdout, otherwise
-			// we write to the specified file.
-			outfh = os.Stdout
-		default:
-			// Assign the newly created filehandle to the outfh, and ensure
-			// that it is synced and closed before exit of main.
-			outfh = genutil.OpenFile(*ocPathStructsOutputFile)
-			defer genutil.SyncFile(outfh)
-		}
-		writeGoPathCodeSingleFile(outfh, pathCode[pcg.PackageName])
-	case generatePathStructsMultipleFiles:
-		if err := writePathPackage(pathCode, pcg.PackageName, *outputDir); err != nil {
-			log.Exit(err)
+	case *ocPathStructsOutputFile != "":
+		out[*ocPathStructsOutputFile] = pathCode[pcg.PackageName].String()
+	default:
+		if err := addPathPackage(out, pathCode, pcg.PackageName, *outputDir); err != nil {
+			return err
 		}
 	}
+	return nil
 }
 
-func writePathPackage(pathCode map[string]*ypathgen.GeneratedPathCode, pkgName, dir string) error {
-	out := map[string]string{}
+// addPathPackage adds the path struct code for the package pkgName within
+// pathCode to out, split into files within dir.
+func addPathPackage(out map[string]string, pathCode map[string]*ypathgen.GeneratedPathCode, pkgName, dir string) error {
 	// Split the path struct code into files.
 	files, err := pathCode[pkgName].SplitFiles(*pathStructsFileN)
 	if err != nil {
-		return fmt.Errorf("error while splitting path structs code into %d files: %w", pathStructsFileN, err)
+		return fmt.Errorf("error while splitting path structs code into %d files: %w", *pathStructsFileN, err)
 	}
 	for i, file := range files {
-		out[fmt.Sprintf(pathStructsFileFmt, i)] = file
-	}
-	if err := writeFiles(dir, out); err != nil {
-		return fmt.Errorf("Error while writing path struct files: %w", err)
+		out[filepath.Join(dir, fmt.Sprintf(pathStructsFileFmt, i))] = file
 	}
 	return nil
 }
+
+// splitList returns the elements of the comma separated list s, or nil if s
+// is empty.
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
@@ -0,0 +1,574 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/ygot/gogen"
+)
+
+var (
+	goModulePath      = flag.String("go_module_path", "", "If set, the generated code is written to output_dir as a Go module with the specified module path, comprising a go.mod file, the schema structs package in the subdirectory named by package_name, the path structs package, if generated, in the subdirectory named by package_name followed by path_struct_package_suffix, and an internal package of the enumerated types that is shared between them. Each package has a doc.go file.")
+	goModuleGoVersion = flag.String("go_module_go_version", "", "The Go version of the go.mod file written when go_module_path is set. If unset, the language version of the Go toolchain that built the generator is used.")
+	uncompressedPkg   = flag.String("uncompressed_package_name", "", "If set when go_module_path is set and compress_paths=true, schema structs with uncompressed paths are additionally generated within the package of the Go module with the specified name. The enumerated types of both schema structs packages are defined within the shared internal package of enumerated types, such that enumerated types that are generated identically for both, such as those of identities and typedefs, are the same Go type in both packages.")
+)
+
+const (
+	// goModFn is the name of the go.mod file of the generated Go module.
+	goModFn = "go.mod"
+	// docFn is the name of the file containing the documentation of each
+	// package of the generated Go module.
+	docFn = "doc.go"
+	// enumPkgDir is the directory, relative to the root of the generated
+	// Go module, of the package of enumerated types.
+	enumPkgDir = "internal/enums"
+)
+
+// goVersionRE matches the language version within a Go toolchain version,
+// e.g., 1.22 within go1.22.3.
+var goVersionRE = regexp.MustCompile(`^go(\d+\.\d+)`)
+
+// goModuleLayout describes the layout of the generated code as a Go module.
+type goModuleLayout struct {
+	// modulePath is the module path of the Go module.
+	modulePath string
+	// structPkg is the name of the schema structs package, which is also
+	// its directory relative to the root of the module.
+	structPkg string
+	// pathPkg is the name of the path structs package, which is also its
+	// directory relative to the root of the module.
+	pathPkg string
+	// uncompressedPkg is the name of the package of schema structs with
+	// uncompressed paths, which is also its directory relative to the root
+	// of the module. It is empty if these structs are not generated.
+	uncompressedPkg string
+}
+
+// newGoModuleLayout returns the layout of the Go module specified by the
+// command-line flags.
+func newGoModuleLayout() *goModuleLayout {
+	return &goModuleLayout{
+		modulePath:      *goModulePath,
+		structPkg:       *packageName,
+		pathPkg:         *packageName + *packageSuffix,
+		uncompressedPkg: *uncompressedPkg,
+	}
+}
+
+// importPath returns the import path of the package in the directory dir,
+// relative to the root of the module.
+func (l *goModuleLayout) importPath(dir string) string {
+	return path.Join(l.modulePath, filepath.ToSlash(dir))
+}
+
+// structFiles returns the files of the schema structs package and the
+// package of enumerated types for goCode, keyed by their paths relative to
+// the root of the module. If uncompressedCode is non-nil, the files of the
+// package of schema structs with uncompressed paths that it contains are
+// also returned. The structs are split into fileN files within each package.
+//
+// The enumerated types are defined within an internal package such that
+// they can be shared by the packages of the module without creating import
+// cycles, and are aliased within each schema structs package, such that the
+// API of the schema structs packages is unchanged. Enumerated types that are
+// generated identically for both schema structs packages are defined once.
+func (l *goModuleLayout) structFiles(goCode, uncompressedCode *gogen.GeneratedCode, fileN int) (map[string]string, error) {
+	if fileN < 1 {
+		fileN = 1
+	}
+	pkgs := []string{l.structPkg}
+	codes := []*gogen.GeneratedCode{goCode}
+	if uncompressedCode != nil {
+		pkgs = append(pkgs, l.uncompressedPkg)
+		codes = append(codes, uncompressedCode)
+	}
+
+	enumPkg := path.Base(enumPkgDir)
+	var enumCode strings.Builder
+	fmt.Fprintf(&enumCode, "package %s\n\nimport (\n\t%q\n)\n", enumPkg, *ygotImportPath)
+	// definedBy is the package whose code first defined each enumerated
+	// type, and seen is the set of enumerated types and methods that have
+	// been written to the package of enumerated types.
+	definedBy := map[string]string{}
+	seen := map[string]bool{}
+	var enumMaps []string
+	out := map[string]string{}
+	for i, code := range codes {
+		pkg := pkgs[i]
+		files, err := splitCodeByFileN(code, fileN)
+		if err != nil {
+			return nil, err
+		}
+
+		var types, consts []string
+		for _, e := range code.Enums {
+			t, c, err := enumNames(fmt.Sprintf("package %s\n%s", enumPkg, e))
+			if err != nil {
+				return nil, fmt.Errorf("cannot parse generated enumerated types: %v", err)
+			}
+			types = append(types, t...)
+			consts = append(consts, c...)
+			if seen[e] {
+				continue
+			}
+			seen[e] = true
+			for _, n := range t {
+				if other, ok := definedBy[n]; ok {
+					return nil, fmt.Errorf("enumerated type %s is generated differently for packages %s and %s", n, other, pkg)
+				}
+				definedBy[n] = pkg
+			}
+			enumCode.WriteString(e)
+			enumCode.WriteString("\n")
+		}
+
+		// The union interfaces that are implemented by enumerated types are
+		// implemented by methods, which must be defined within the package of
+		// the enumerated types.
+		union, methods, err := moveEnumMethods(files[interfaceFn], types)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse generated union code: %v", err)
+		}
+		files[interfaceFn] = union
+		for _, m := range methods {
+			if seen[m] {
+				continue
+			}
+			seen[m] = true
+			enumCode.WriteString("\n")
+			enumCode.WriteString(m)
+			enumCode.WriteString("\n")
+		}
+		enumMaps = append(enumMaps, code.EnumMap)
+
+		// The map of the enumerated types of each leaf refers to the schema,
+		// and so remains within the schema structs package.
+		files[enumMapFn] = ""
+		if code.EnumTypeMap != "" {
+			files[enumMapFn] = code.CommonHeader + code.EnumTypeMap
+		}
+		files[enumFn] = enumAliases(pkg, l.importPath(enumPkgDir), enumPkg, types, consts)
+		for fn, contents := range files {
+			out[filepath.Join(pkg, fn)] = contents
+		}
+	}
+
+	enumMap, err := mergeEnumMaps(enumMaps)
+	if err != nil {
+		return nil, fmt.Errorf("cannot merge generated enumerated type maps: %v", err)
+	}
+	out[filepath.Join(enumPkgDir, enumFn)] = enumCode.String()
+	out[filepath.Join(enumPkgDir, enumMapFn)] = fmt.Sprintf("package %s\n\nimport (\n\t%q\n)\n%s", enumPkg, *ygotImportPath, enumMap)
+	out[filepath.Join(enumPkgDir, docFn)] = enumDoc(enumPkg, pkgs)
+	return out, nil
+}
+
+// enumDoc returns the doc.go file of the package of enumerated types enumPkg,
+// which are shared by the schema structs packages pkgs.
+func enumDoc(enumPkg string, pkgs []string) string {
+	if len(pkgs) == 1 {
+		return fmt.Sprintf(`// Package %s contains the enumerated types of the generated package %s,
+// which are shared by the packages of the module. They are aliased within
+// package %s, which should be used instead.
+package %s
+`, enumPkg, pkgs[0], pkgs[0], enumPkg)
+	}
+	names := strings.Join(pkgs, " and ")
+	return fmt.Sprintf(`// Package %s contains the enumerated types of the generated packages
+// %s, which are shared by the packages of the module. They are
+// aliased within packages %s, which should be used instead.
+package %s
+`, enumPkg, names, names, enumPkg)
+}
+
+// enumNames returns the names of the types and of the constants declared
+// within the Go source src.
+func enumNames(src string) ([]string, []string, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), "", src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, nil, err
+	}
+	var types, consts []string
+	for _, d := range f.Decls {
+		gd, ok := d.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, s := range gd.Specs {
+			switch s := s.(type) {
+			case *ast.TypeSpec:
+				types = append(types, s.Name.Name)
+			case *ast.ValueSpec:
+				if gd.Tok == token.CONST {
+					for _, n := range s.Names {
+						consts = append(consts, n.Name)
+					}
+				}
+			}
+		}
+	}
+	return types, consts, nil
+}
+
+// moveEnumMethods removes the methods whose receivers are one of types from
+// the Go source src, returning the remaining source, and the source of each
+// of the removed methods along with its documentation.
+func moveEnumMethods(src string, types []string) (string, []string, error) {
+	if src == "" {
+		return "", nil, nil
+	}
+	isEnum := map[string]bool{}
+	for _, t := range types {
+		isEnum[t] = true
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return "", nil, err
+	}
+	var rest strings.Builder
+	var moved []string
+	last := 0
+	for _, d := range f.Decls {
+		fd, ok := d.(*ast.FuncDecl)
+		if !ok || fd.Recv == nil || len(fd.Recv.List) != 1 {
+			continue
+		}
+		if id, ok := fd.Recv.List[0].Type.(*ast.Ident); !ok || !isEnum[id.Name] {
+			continue
+		}
+		start := fd.Pos()
+		if fd.Doc != nil {
+			start = fd.Doc.Pos()
+		}
+		from, to := fset.Position(start).Offset, fset.Position(fd.End()).Offset
+		rest.WriteString(src[last:from])
+		moved = append(moved, src[from:to])
+		last = to
+	}
+	rest.WriteString(src[last:])
+	return rest.String(), moved, nil
+}
+
+// enumAliases returns the source of a file of the package pkg that aliases
+// the types and constants of the enumerated types package enumPkg, whose
+// import path is enumImportPath.
+func enumAliases(pkg, enumImportPath, enumPkg string, types, consts []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "package %s\n\nimport (\n\t%q\n)\n", pkg, enumImportPath)
+	if len(types) != 0 {
+		b.WriteString("\n// Enumerated types, which are defined within the internal package of\n// enumerated types of the module.\ntype (\n")
+		for _, t := range types {
+			fmt.Fprintf(&b, "\t%s = %s.%s\n", t, enumPkg, t)
+		}
+		b.WriteString(")\n")
+	}
+	if len(consts) != 0 {
+		b.WriteString("\n// Values of the enumerated types.\nconst (\n")
+		for _, c := range consts {
+			fmt.Fprintf(&b, "\t%s = %s.%s\n", c, enumPkg, c)
+		}
+		b.WriteString(")\n")
+	}
+	fmt.Fprintf(&b, "\n// ΛEnum is the map of the values of each of the enumerated types of the\n// package, as defined within the internal package of enumerated types.\nvar ΛEnum = %s.ΛEnum\n", enumPkg)
+	return b.String()
+}
+
+// mergeEnumMaps returns the source of the declaration of the ΛEnum map that
+// contains the entries of each of the generated ΛEnum map declarations maps.
+// The entry of an enumerated type that is within more than one of maps is
+// included once, and must be the same within each.
+func mergeEnumMaps(maps []string) (string, error) {
+	if len(maps) == 1 {
+		return maps[0], nil
+	}
+	var header string
+	var keys []string
+	entries := map[string]string{}
+	for _, m := range maps {
+		if m == "" {
+			continue
+		}
+		src := "package p\n" + m
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, "", src, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return "", err
+		}
+		var lit *ast.CompositeLit
+		ast.Inspect(f, func(n ast.Node) bool {
+			if cl, ok := n.(*ast.CompositeLit); ok && lit == nil {
+				lit = cl
+			}
+			return lit == nil
+		})
+		if lit == nil {
+			return "", fmt.Errorf("no map literal within %q", m)
+		}
+		offset := func(p token.Pos) int { return fset.Position(p).Offset }
+		if header == "" {
+			header = src[len("package p\n") : offset(lit.Lbrace)+1]
+		}
+		for _, e := range lit.Elts {
+			kv, ok := e.(*ast.KeyValueExpr)
+			if !ok {
+				return "", fmt.Errorf("unexpected map element %s", src[offset(e.Pos()):offset(e.End())])
+			}
+			key := src[offset(kv.Key.Pos()):offset(kv.Key.End())]
+			entry := src[offset(kv.Pos()):offset(kv.End())]
+			if prev, ok := entries[key]; ok {
+				if prev != entry {
+					return "", fmt.Errorf("values of enumerated type %s differ", key)
+				}
+				continue
+			}
+			entries[key] = entry
+			keys = append(keys, key)
+		}
+	}
+	if header == "" {
+		return "", nil
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	b.WriteString(header)
+	b.WriteString("\n")
+	for _, k := range keys {
+		fmt.Fprintf(&b, "\t%s,\n", entries[k])
+	}
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+// finalize completes the files of the Go module within out, whose paths
+// are relative to dir, the root of the module, adding the go.mod file and
+// the doc.go file of each package that does not have one. Since the header
+// of the generated code, which imports all of the packages used by the
+// generated code and documents the package, is included in each file, the
+// unused imports are removed from each Go file, and its package
+// documentation is moved to the doc.go file of its package.
+func (l *goModuleLayout) finalize(dir string, out map[string]string) (map[string]string, error) {
+	res := map[string]string{}
+	docs := map[string]string{}
+	pkgs := map[string]string{}
+	var importPaths []string
+	for fn, contents := range out {
+		rel, err := filepath.Rel(dir, fn)
+		if err != nil {
+			return nil, err
+		}
+		if filepath.Ext(fn) != ".go" || filepath.Base(fn) == docFn || contents == "" {
+			res[fn] = contents
+			continue
+		}
+		src, pkg, doc, imports, err := tidyGoFile(contents)
+		if err != nil {
+			return nil, fmt.Errorf("cannot process generated file %s: %v", rel, err)
+		}
+		pkgDir := filepath.Dir(fn)
+		pkgs[pkgDir] = pkg
+		if doc != "" && filepath.Base(fn) != docFn {
+			docs[pkgDir] = doc
+		}
+		importPaths = append(importPaths, imports...)
+		res[fn] = src
+	}
+
+	for pkgDir, pkg := range pkgs {
+		fn := filepath.Join(pkgDir, docFn)
+		if _, ok := res[fn]; ok {
+			continue
+		}
+		doc := docs[pkgDir]
+		if doc == "" {
+			doc = fmt.Sprintf("// Package %s is a generated package.\n", pkg)
+		}
+		res[fn] = fmt.Sprintf("%spackage %s\n", doc, pkg)
+	}
+
+	res[filepath.Join(dir, goModFn)] = l.goMod(importPaths)
+	return res, nil
+}
+
+// goMod returns the contents of the go.mod file of the module, which imports
+// the packages importPaths. The modules that provide these packages are
+// required at the versions with which the generator was built, where known.
+func (l *goModuleLayout) goMod(importPaths []string) string {
+	goVersion := *goModuleGoVersion
+	if goVersion == "" {
+		goVersion = "1.21"
+		if m := goVersionRE.FindStringSubmatch(runtime.Version()); m != nil {
+			goVersion = m[1]
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "module %s\n\ngo %s\n", l.modulePath, goVersion)
+	if reqs := moduleRequirements(importPaths); len(reqs) != 0 {
+		b.WriteString("\nrequire (\n")
+		for _, r := range reqs {
+			fmt.Fprintf(&b, "\t%s\n", r)
+		}
+		b.WriteString(")\n")
+	}
+	return b.String()
+}
+
+// moduleRequirements returns the requirements, of the form "<path>
+// <version>", of the modules that provide the packages importPaths, for those
+// modules whose versions are known from the build information of the
+// generator.
+func moduleRequirements(importPaths []string) []string {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	mods := append([]*debug.Module{&bi.Main}, bi.Deps...)
+	reqs := map[string]bool{}
+	for _, ip := range importPaths {
+		var best *debug.Module
+		for _, m := range mods {
+			if m.Replace != nil {
+				m = m.Replace
+			}
+			if (ip == m.Path || strings.HasPrefix(ip, m.Path+"/")) && (best == nil || len(m.Path) > len(best.Path)) {
+				best = m
+			}
+		}
+		if best != nil && best.Version != "" && best.Version != "(devel)" {
+			reqs[fmt.Sprintf("%s %s", best.Path, best.Version)] = true
+		}
+	}
+	var r []string
+	for req := range reqs {
+		r = append(r, req)
+	}
+	sort.Strings(r)
+	return r
+}
+
+// tidyGoFile removes the unused imports and the package documentation of
+// the Go source src, and formats it. It returns the resulting source, the
+// name of its package, its package documentation, and the import paths of
+// the packages that remain imported.
+func tidyGoFile(src string) (string, string, string, []string, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return "", "", "", nil, err
+	}
+
+	used := map[string]bool{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok {
+				used[id.Name] = true
+			}
+		}
+		return true
+	})
+
+	var imports []string
+	for _, d := range f.Decls {
+		gd, ok := d.(*ast.GenDecl)
+		if !ok || gd.Tok != token.IMPORT {
+			continue
+		}
+		var specs []ast.Spec
+		for _, s := range gd.Specs {
+			is := s.(*ast.ImportSpec)
+			ip, err := strconv.Unquote(is.Path.Value)
+			if err != nil {
+				return "", "", "", nil, err
+			}
+			name := importName(ip)
+			if is.Name != nil {
+				name = is.Name.Name
+			}
+			if name == "_" || used[name] {
+				specs = append(specs, s)
+				imports = append(imports, ip)
+			}
+		}
+		gd.Specs = specs
+	}
+
+	var doc string
+	if f.Doc != nil {
+		var b strings.Builder
+		for _, c := range f.Doc.List {
+			b.WriteString(c.Text)
+			b.WriteString("\n")
+		}
+		doc = b.String()
+		var comments []*ast.CommentGroup
+		for _, c := range f.Comments {
+			if c != f.Doc {
+				comments = append(comments, c)
+			}
+		}
+		f.Comments = comments
+		f.Doc = nil
+	}
+
+	var b bytes.Buffer
+	if err := format.Node(&b, fset, f); err != nil {
+		return "", "", "", nil, err
+	}
+	return b.String(), f.Name.Name, doc, imports, nil
+}
+
+// importName returns the default name of the package with import path ip,
+// which is the last element of the path, ignoring major version suffixes.
+func importName(ip string) string {
+	elems := strings.Split(ip, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && len(name) > 1 && name[0] == 'v' {
+		if _, err := strconv.Atoi(name[1:]); err == nil {
+			name = elems[len(elems)-2]
+		}
+	}
+	return name
+}
+
+// createDirs creates the parent directories of each of the files within
+// out.
+func createDirs(out map[string]string) error {
+	for fn := range out {
+		if fn == "-" {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(fn), 0755); err != nil {
+			return fmt.Errorf("cannot create directory for %s: %v", fn, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,344 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/gogen"
+	"github.com/openconfig/ygot/ygen"
+)
+
+func TestGoModuleLayout(t *testing.T) {
+	cg := gogen.New("", ygen.IROptions{
+		TransformationOptions: ygen.TransformationOpts{
+			CompressBehaviour:          genutil.PreferIntendedConfig,
+			GenerateFakeRoot:           true,
+			EnumerationsUseUnderscores: true,
+		},
+	}, gogen.GoOpts{
+		PackageName:          "oc",
+		GenerateJSONSchema:   true,
+		GenerateSimpleUnions: true,
+		YgotImportPath:       genutil.GoDefaultYgotImportPath,
+		YtypesImportPath:     genutil.GoDefaultYtypesImportPath,
+		GoyangImportPath:     genutil.GoDefaultGoyangImportPath,
+	})
+	goCode, errs := cg.Generate([]string{filepath.Join("..", "testdata", "modules", "enum-union.yang")}, nil)
+	if errs != nil {
+		t.Fatalf("Generate: got unexpected errors: %v", errs)
+	}
+
+	l := &goModuleLayout{modulePath: "example.com/models", structPkg: "oc", pathPkg: "ocpath"}
+	files, err := l.structFiles(goCode, nil, 0)
+	if err != nil {
+		t.Fatalf("structFiles: got unexpected error: %v", err)
+	}
+	out := map[string]string{}
+	for fn, contents := range files {
+		out[filepath.Join("out", fn)] = contents
+	}
+	got, err := l.finalize("out", out)
+	if err != nil {
+		t.Fatalf("finalize: got unexpected error: %v", err)
+	}
+
+	var gotFiles []string
+	for fn, contents := range got {
+		if contents != "" {
+			gotFiles = append(gotFiles, filepath.ToSlash(fn))
+		}
+	}
+	sort.Strings(gotFiles)
+	wantFiles := []string{
+		"out/go.mod",
+		"out/internal/enums/doc.go",
+		"out/internal/enums/enum.go",
+		"out/internal/enums/enum_map.go",
+		"out/oc/doc.go",
+		"out/oc/enum.go",
+		"out/oc/enum_map.go",
+		"out/oc/schema.go",
+		"out/oc/structs-0.go",
+		"out/oc/union.go",
+	}
+	if diff := cmp.Diff(wantFiles, gotFiles); diff != "" {
+		t.Fatalf("did not get expected files (-want, +got):\n%s", diff)
+	}
+
+	if !strings.HasPrefix(got[filepath.Join("out", "go.mod")], "module example.com/models\n\ngo ") {
+		t.Errorf("did not get expected go.mod, got:\n%s", got[filepath.Join("out", "go.mod")])
+	}
+
+	for fn, contents := range got {
+		if filepath.Ext(fn) != ".go" || contents == "" {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), fn, contents, parser.ParseComments)
+		if err != nil {
+			t.Errorf("%s: cannot parse generated code: %v", fn, err)
+			continue
+		}
+		// Only the doc.go file of each package documents it.
+		if hasDoc := f.Doc != nil; hasDoc != (filepath.Base(fn) == docFn) {
+			t.Errorf("%s: got package documentation %v, want %v", fn, hasDoc, !hasDoc)
+		}
+	}
+
+	checks := []struct {
+		file        string
+		wantContain []string
+		wantAbsent  []string
+	}{{
+		file: "out/oc/doc.go",
+		wantContain: []string{
+			"Package oc is a generated package",
+			"package oc\n",
+		},
+	}, {
+		file: "out/oc/enum.go",
+		wantContain: []string{
+			`"example.com/models/internal/enums"`,
+			"E_EnumUnion_WeekendDays_Enum = enums.E_EnumUnion_WeekendDays_Enum",
+			"var ΛEnum = enums.ΛEnum",
+		},
+	}, {
+		file:        "out/oc/union.go",
+		wantContain: []string{"func (UnionUint64) Documentation_for_Outer_Inner_Leaf1_Union() {}"},
+		wantAbsent:  []string{"func (E_", `"encoding/json"`},
+	}, {
+		file:        "out/internal/enums/enum.go",
+		wantContain: []string{"package enums", "type E_EnumUnion_WeekendDays_Enum int64", "func (E_EnumUnion_WeekendDays_Enum) Documentation_for_Outer_Inner_Leaf2_Union() {}"},
+	}, {
+		file:        "out/internal/enums/enum_map.go",
+		wantContain: []string{"var ΛEnum = map[string]map[int64]ygot.EnumDefinition{"},
+	}}
+	for _, c := range checks {
+		contents := got[filepath.FromSlash(c.file)]
+		for _, want := range c.wantContain {
+			if !strings.Contains(contents, want) {
+				t.Errorf("%s: did not contain %q, got:\n%s", c.file, want, contents)
+			}
+		}
+		for _, absent := range c.wantAbsent {
+			if strings.Contains(contents, absent) {
+				t.Errorf("%s: unexpectedly contained %q, got:\n%s", c.file, absent, contents)
+			}
+		}
+	}
+}
+
+func TestGoModuleLayoutUncompressed(t *testing.T) {
+	generate := func(cb genutil.CompressBehaviour, pkg string) *gogen.GeneratedCode {
+		cg := gogen.New("", ygen.IROptions{
+			TransformationOptions: ygen.TransformationOpts{
+				CompressBehaviour:          cb,
+				GenerateFakeRoot:           true,
+				EnumerationsUseUnderscores: true,
+			},
+		}, gogen.GoOpts{
+			PackageName:          pkg,
+			GenerateJSONSchema:   true,
+			GenerateSimpleUnions: true,
+			YgotImportPath:       genutil.GoDefaultYgotImportPath,
+			YtypesImportPath:     genutil.GoDefaultYtypesImportPath,
+			GoyangImportPath:     genutil.GoDefaultGoyangImportPath,
+		})
+		goCode, errs := cg.Generate([]string{filepath.Join("..", "testdata", "modules", "enum-union.yang")}, nil)
+		if errs != nil {
+			t.Fatalf("Generate: got unexpected errors: %v", errs)
+		}
+		return goCode
+	}
+
+	l := &goModuleLayout{modulePath: "example.com/models", structPkg: "oc", pathPkg: "ocpath", uncompressedPkg: "ocu"}
+	got, err := l.structFiles(generate(genutil.PreferIntendedConfig, "oc"), generate(genutil.Uncompressed, "ocu"), 0)
+	if err != nil {
+		t.Fatalf("structFiles: got unexpected error: %v", err)
+	}
+
+	for _, fn := range []string{"ocu/enum.go", "ocu/enum_map.go", "ocu/schema.go", "ocu/structs-0.go", "ocu/union.go"} {
+		if _, ok := got[filepath.FromSlash(fn)]; !ok {
+			t.Errorf("did not get file %s", fn)
+		}
+	}
+
+	checks := []struct {
+		file        string
+		wantContain []string
+		wantCount   map[string]int
+	}{{
+		file: "oc/enum.go",
+		wantContain: []string{
+			"E_EnumUnion_Inner_Leaf1 = enums.E_EnumUnion_Inner_Leaf1",
+			"E_EnumUnion_WeekendDays_Enum = enums.E_EnumUnion_WeekendDays_Enum",
+		},
+	}, {
+		file: "ocu/enum.go",
+		wantContain: []string{
+			"package ocu",
+			"E_EnumUnion_Outer_Inner_Config_Leaf1 = enums.E_EnumUnion_Outer_Inner_Config_Leaf1",
+			"E_EnumUnion_WeekendDays_Enum = enums.E_EnumUnion_WeekendDays_Enum",
+		},
+	}, {
+		file:        "internal/enums/doc.go",
+		wantContain: []string{"oc and ocu"},
+	}, {
+		file: "internal/enums/enum.go",
+		wantCount: map[string]int{
+			"type E_EnumUnion_Inner_Leaf1 int64":              1,
+			"type E_EnumUnion_Outer_Inner_Config_Leaf1 int64": 1,
+			"type E_EnumUnion_WeekendDays_Enum int64":         1,
+		},
+	}, {
+		file: "internal/enums/enum_map.go",
+		wantCount: map[string]int{
+			"var ΛEnum =":                               1,
+			`"E_EnumUnion_Inner_Leaf1": {`:              1,
+			`"E_EnumUnion_Outer_Inner_Config_Leaf1": {`: 1,
+			`"E_EnumUnion_WeekendDays_Enum": {`:         1,
+		},
+	}}
+	for _, c := range checks {
+		contents := got[filepath.FromSlash(c.file)]
+		for _, want := range c.wantContain {
+			if !strings.Contains(contents, want) {
+				t.Errorf("%s: did not contain %q, got:\n%s", c.file, want, contents)
+			}
+		}
+		for s, want := range c.wantCount {
+			if n := strings.Count(contents, s); n != want {
+				t.Errorf("%s: got %d occurrences of %q, want %d, got:\n%s", c.file, n, s, want, contents)
+			}
+		}
+	}
+
+	// The enumerated types package must declare each method once.
+	if _, err := parser.ParseFile(token.NewFileSet(), "enum.go", got[filepath.Join(enumPkgDir, enumFn)], 0); err != nil {
+		t.Errorf("cannot parse enumerated types package: %v", err)
+	}
+}
+
+func TestMergeEnumMaps(t *testing.T) {
+	tests := []struct {
+		desc    string
+		in      []string
+		want    string
+		wantErr bool
+	}{{
+		desc: "single map",
+		in:   []string{"var ΛEnum = map[string]int{\n\t\"b\": 1,\n}\n"},
+		want: "var ΛEnum = map[string]int{\n\t\"b\": 1,\n}\n",
+	}, {
+		desc: "merged maps",
+		in: []string{
+			"// ΛEnum is a map.\nvar ΛEnum = map[string]int{\n\t\"b\": 1,\n\t\"c\": 2,\n}\n",
+			"// ΛEnum is a map.\nvar ΛEnum = map[string]int{\n\t\"a\": 3,\n\t\"b\": 1,\n}\n",
+		},
+		want: "// ΛEnum is a map.\nvar ΛEnum = map[string]int{\n\t\"a\": 3,\n\t\"b\": 1,\n\t\"c\": 2,\n}\n",
+	}, {
+		desc: "empty map",
+		in:   []string{"", "var ΛEnum = map[string]int{\n\t\"a\": 1,\n}\n"},
+		want: "var ΛEnum = map[string]int{\n\t\"a\": 1,\n}\n",
+	}, {
+		desc: "differing entries",
+		in: []string{
+			"var ΛEnum = map[string]int{\n\t\"a\": 1,\n}\n",
+			"var ΛEnum = map[string]int{\n\t\"a\": 2,\n}\n",
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := mergeEnumMaps(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("mergeEnumMaps: got error %v, want error %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mergeEnumMaps: (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTidyGoFile(t *testing.T) {
+	in := `/*
+Package foo is documented.
+*/
+package foo
+
+import (
+	"fmt"
+	"reflect"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// F uses fmt and gpb.
+func F(p *gpb.Path) string { return fmt.Sprint(p) }
+`
+	wantSrc := `package foo
+
+import (
+	"fmt"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// F uses fmt and gpb.
+func F(p *gpb.Path) string { return fmt.Sprint(p) }
+`
+	src, pkg, doc, imports, err := tidyGoFile(in)
+	if err != nil {
+		t.Fatalf("tidyGoFile: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(wantSrc, src); diff != "" {
+		t.Errorf("tidyGoFile: did not get expected source (-want, +got):\n%s", diff)
+	}
+	if pkg != "foo" {
+		t.Errorf("tidyGoFile: got package %q, want foo", pkg)
+	}
+	if want := "/*\nPackage foo is documented.\n*/\n"; doc != want {
+		t.Errorf("tidyGoFile: got documentation %q, want %q", doc, want)
+	}
+	if diff := cmp.Diff([]string{"fmt", "github.com/openconfig/gnmi/proto/gnmi"}, imports); diff != "" {
+		t.Errorf("tidyGoFile: did not get expected imports (-want, +got):\n%s", diff)
+	}
+}
+
+func TestImportName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "fmt", want: "fmt"},
+		{in: "github.com/openconfig/ygot/ygot", want: "ygot"},
+		{in: "example.com/foo/v2", want: "foo"},
+		{in: "example.com/v", want: "v"},
+	}
+	for _, tt := range tests {
+		if got := importName(tt.in); got != tt.want {
+			t.Errorf("importName(%q): got %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/openconfig/ygot/ygen"
+)
+
+var (
+	// Flags used for post-processing plugins.
+	plugins = flag.String("plugins", "", "Comma separated list of plugin commands that post-process the generated code before it is written. Each plugin is run in turn, receiving a JSON document containing the IR and the generated files on its standard input, and writing a JSON document containing the files to be added, replaced or removed to its standard output. See docs/generator-plugins.md for details of the protocol.")
+)
+
+// pluginRequest is the JSON document that is written to the standard input
+// of each plugin.
+type pluginRequest struct {
+	// PackageName is the name of the generated Go package.
+	PackageName string `json:"package_name"`
+	// IR is the intermediate representation from which the schema structs
+	// were generated. It is omitted if schema structs are not generated.
+	IR *ygen.IR `json:"ir,omitempty"`
+	// Files is the set of generated files, keyed by the name of the file
+	// that they are to be written to, including the modifications made by
+	// preceding plugins.
+	Files map[string]string `json:"files"`
+}
+
+// pluginResponse is the JSON document that is read from the standard
+// output of each plugin.
+type pluginResponse struct {
+	// Files is the set of files that are added or replaced, keyed by the
+	// name of the file. A file whose value is null is removed. Files that
+	// are not included are unchanged.
+	Files map[string]*string `json:"files"`
+}
+
+// runPlugins runs each of the plugins specified by the plugins flag in
+// turn, supplying the IR and the set of generated files, out, which is
+// keyed by file name. It returns the set of files after it has been
+// modified by the plugins.
+func runPlugins(ir *ygen.IR, out map[string]string) (map[string]string, error) {
+	if *plugins == "" {
+		return out, nil
+	}
+	for _, p := range strings.Split(*plugins, ",") {
+		var err error
+		if out, err = runPlugin(p, &pluginRequest{PackageName: *packageName, IR: ir, Files: out}); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// runPlugin runs the plugin command, writing req to its standard input, and
+// returns the set of files in req after the changes returned by the plugin
+// have been applied. The standard error of the plugin is passed through to
+// that of the generator.
+func runPlugin(command string, req *pluginRequest) (map[string]string, error) {
+	in, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot serialise request for plugin %s: %v", command, err)
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.Command(command)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("plugin %s failed: %v", command, err)
+	}
+
+	resp := &pluginResponse{}
+	if err := json.Unmarshal(stdout.Bytes(), resp); err != nil {
+		return nil, fmt.Errorf("invalid response from plugin %s: %v", command, err)
+	}
+
+	out := make(map[string]string, len(req.Files))
+	for fn, contents := range req.Files {
+		out[fn] = contents
+	}
+	for fn, contents := range resp.Files {
+		if contents == nil {
+			delete(out, fn)
+			continue
+		}
+		out[fn] = *contents
+	}
+	return out, nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/ygen"
+)
+
+// testPluginEnv is the environment variable that causes the test binary to
+// act as the plugin specified by its value.
+const testPluginEnv = "GENERATOR_TEST_PLUGIN"
+
+func TestMain(m *testing.M) {
+	if p := os.Getenv(testPluginEnv); p != "" {
+		testPlugin(p)
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// testPlugin implements the plugins used by the tests.
+func testPlugin(name string) {
+	req := &pluginRequest{}
+	if err := json.NewDecoder(os.Stdin).Decode(req); err != nil {
+		fmt.Fprintf(os.Stderr, "cannot decode request: %v", err)
+		os.Exit(1)
+	}
+	str := func(s string) *string { return &s }
+	resp := &pluginResponse{Files: map[string]*string{}}
+	switch name {
+	case "edit":
+		resp.Files["a.go"] = str("//go:build tag\n\n" + req.Files["a.go"])
+		resp.Files["b.go"] = nil
+		resp.Files["c.go"] = str(fmt.Sprintf("package %s\n\n// %d directories\n", req.PackageName, len(req.IR.Directories)))
+	case "append":
+		resp.Files["c.go"] = str(req.Files["c.go"] + "// appended\n")
+	case "fail":
+		os.Exit(1)
+	case "invalid":
+		fmt.Print("{")
+		return
+	}
+	json.NewEncoder(os.Stdout).Encode(resp)
+}
+
+func TestRunPlugins(t *testing.T) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatalf("cannot determine test executable: %v", err)
+	}
+	ir := &ygen.IR{Directories: map[string]*ygen.ParsedDirectory{
+		"/device":   {Name: "Device", Path: "/device"},
+		"/device/a": {Name: "A", Path: "/device/a"},
+	}}
+
+	tests := []struct {
+		desc             string
+		inPlugins        []string
+		want             map[string]string
+		wantErrSubstring string
+	}{{
+		desc:      "edit",
+		inPlugins: []string{"edit"},
+		want: map[string]string{
+			"a.go": "//go:build tag\n\npackage oc\n",
+			"c.go": "package oc\n\n// 2 directories\n",
+		},
+	}, {
+		desc:      "plugins run in turn",
+		inPlugins: []string{"edit", "append"},
+		want: map[string]string{
+			"a.go": "//go:build tag\n\npackage oc\n",
+			"c.go": "package oc\n\n// 2 directories\n// appended\n",
+		},
+	}, {
+		desc:             "plugin fails",
+		inPlugins:        []string{"fail"},
+		wantErrSubstring: "failed",
+	}, {
+		desc:             "invalid response",
+		inPlugins:        []string{"invalid"},
+		wantErrSubstring: "invalid response",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			// Each plugin is run as the test executable, with the plugin
+			// that it acts as selected by a wrapper script.
+			var cmds []string
+			dir := t.TempDir()
+			for i, p := range tt.inPlugins {
+				fn := fmt.Sprintf("%s/plugin-%d", dir, i)
+				script := fmt.Sprintf("#!/bin/sh\n%s=%s exec %q\n", testPluginEnv, p, exe)
+				if err := os.WriteFile(fn, []byte(script), 0o755); err != nil {
+					t.Fatalf("cannot write plugin: %v", err)
+				}
+				cmds = append(cmds, fn)
+			}
+			defer func(old string) { *plugins = old }(*plugins)
+			*plugins = strings.Join(cmds, ",")
+			defer func(old string) { *packageName = old }(*packageName)
+			*packageName = "oc"
+
+			got, err := runPlugins(ir, map[string]string{
+				"a.go": "package oc\n",
+				"b.go": "package oc\n",
+			})
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("runPlugins: did not get expected error, %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("runPlugins: (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
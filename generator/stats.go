@@ -0,0 +1,282 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/ygen"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var (
+	// Flags used for the generation statistics report.
+	statsReportFile = flag.String("stats_report", "", "If set, a report of statistics about the generated code, including the number of structs and enums, the estimated memory footprint of each struct, and the largest lists, is written to the specified file. The report is written as JSON if the file name ends in .json, and as a human-readable summary otherwise. Specify \"-\" for stdout.")
+)
+
+const (
+	// statsSummaryN is the number of structs and lists that are listed in
+	// the human-readable summary of the statistics report.
+	statsSummaryN = 10
+
+	// The estimated sizes, in bytes, of the types used for the fields of
+	// generated structs on a 64-bit platform.
+	pointerSize   = 8
+	sliceSize     = 24
+	interfaceSize = 16
+)
+
+// generationStats is the set of statistics about the generated code that is
+// written by the stats_report flag.
+type generationStats struct {
+	// Structs, Containers and Lists are the number of generated structs,
+	// and the number of those that represent YANG containers and lists.
+	Structs    int `json:"structs"`
+	Containers int `json:"containers"`
+	Lists      int `json:"lists"`
+	// Leaves is the number of fields of generated structs that represent
+	// YANG leaves or leaf-lists.
+	Leaves int `json:"leaves"`
+	// Enums is the number of generated enumerated types.
+	Enums int `json:"enums"`
+	// TotalBytes is the total size of the generated files.
+	TotalBytes int `json:"total_bytes"`
+	// Files is the set of generated files, in order of name.
+	Files []*fileStats `json:"files"`
+	// StructSizes is the estimated size of each generated struct, in
+	// decreasing order of size.
+	StructSizes []*structStats `json:"struct_sizes"`
+	// LargestLists is the set of lists, in decreasing order of the
+	// estimated size of each of their entries.
+	LargestLists []*listStats `json:"largest_lists"`
+}
+
+// fileStats is the statistics of a single generated file.
+type fileStats struct {
+	Name  string `json:"name"`
+	Bytes int    `json:"bytes"`
+}
+
+// structStats is the statistics of a single generated struct.
+type structStats struct {
+	// Name is the name of the struct.
+	Name string `json:"name"`
+	// Path is the YANG schema path of the struct.
+	Path string `json:"path"`
+	// Fields is the number of fields of the struct.
+	Fields int `json:"fields"`
+	// EstimatedBytes is the estimated size of the struct itself, excluding
+	// the values referenced by its fields, on a 64-bit platform.
+	EstimatedBytes int `json:"estimated_bytes"`
+}
+
+// listStats is the statistics of a single YANG list.
+type listStats struct {
+	// Name is the name of the struct representing each list entry.
+	Name string `json:"name"`
+	// Path is the YANG schema path of the list.
+	Path string `json:"path"`
+	// DescendantStructs is the number of structs beneath the list,
+	// including the struct representing each entry.
+	DescendantStructs int `json:"descendant_structs"`
+	// DescendantLeaves is the number of leaves and leaf-lists beneath the
+	// list.
+	DescendantLeaves int `json:"descendant_leaves"`
+	// EstimatedEntryBytes is the estimated size of each entry of the list,
+	// including the structs of its descendant containers when they are
+	// populated, but excluding the entries of descendant lists and the
+	// values of leaves.
+	EstimatedEntryBytes int `json:"estimated_entry_bytes"`
+}
+
+// computeStats returns the statistics of the code generated from ir, which
+// may be nil if schema structs were not generated, and written to files,
+// which is keyed by file name.
+func computeStats(ir *ygen.IR, files map[string]string) *generationStats {
+	s := &generationStats{Files: []*fileStats{}, StructSizes: []*structStats{}, LargestLists: []*listStats{}}
+	for fn, contents := range files {
+		s.Files = append(s.Files, &fileStats{Name: fn, Bytes: len(contents)})
+		s.TotalBytes += len(contents)
+	}
+	sort.Slice(s.Files, func(i, j int) bool { return s.Files[i].Name < s.Files[j].Name })
+
+	if ir == nil {
+		return s
+	}
+	s.Enums = len(ir.Enums)
+	for _, p := range ir.OrderedDirectoryPaths() {
+		dir := ir.Directories[p]
+		s.Structs++
+		switch dir.Type {
+		case ygen.Container:
+			s.Containers++
+		case ygen.List, ygen.OrderedList:
+			s.Lists++
+			structs, leaves := descendantCounts(ir, dir)
+			s.LargestLists = append(s.LargestLists, &listStats{
+				Name:                dir.Name,
+				Path:                dir.Path,
+				DescendantStructs:   structs,
+				DescendantLeaves:    leaves,
+				EstimatedEntryBytes: entryBytes(ir, dir),
+			})
+		}
+		for _, f := range dir.Fields {
+			if f.Type == ygen.LeafNode || f.Type == ygen.LeafListNode {
+				s.Leaves++
+			}
+		}
+		s.StructSizes = append(s.StructSizes, &structStats{
+			Name:           dir.Name,
+			Path:           dir.Path,
+			Fields:         len(dir.Fields),
+			EstimatedBytes: structBytes(ir, dir),
+		})
+	}
+	sort.SliceStable(s.StructSizes, func(i, j int) bool { return s.StructSizes[i].EstimatedBytes > s.StructSizes[j].EstimatedBytes })
+	sort.SliceStable(s.LargestLists, func(i, j int) bool {
+		return s.LargestLists[i].EstimatedEntryBytes > s.LargestLists[j].EstimatedEntryBytes
+	})
+	return s
+}
+
+// structBytes returns the estimated size of the struct generated for dir,
+// excluding the values referenced by its fields, on a 64-bit platform.
+func structBytes(ir *ygen.IR, dir *ygen.ParsedDirectory) int {
+	var n int
+	for _, f := range dir.Fields {
+		switch f.Type {
+		case ygen.LeafNode:
+			switch {
+			case f.LangType == nil:
+				n += pointerSize
+			case len(f.LangType.UnionTypes) > 1:
+				n += interfaceSize
+			case f.LangType.NativeType == ygot.BinaryTypeName:
+				n += sliceSize
+			default:
+				// Enumerated values are represented by an int64, and all
+				// other leaves by a pointer, both of which are the size
+				// of a pointer.
+				n += pointerSize
+			}
+		case ygen.LeafListNode:
+			n += sliceSize
+		case ygen.ListNode:
+			// Keyless lists are represented by a slice, and keyed lists
+			// by a map, or a pointer to an ordered map.
+			if child, ok := ir.Directories[f.YANGDetails.Path]; ok && len(child.ListKeys) == 0 {
+				n += sliceSize
+			} else {
+				n += pointerSize
+			}
+		default:
+			n += pointerSize
+		}
+	}
+	return n
+}
+
+// entryBytes returns the estimated size of an instance of the struct
+// generated for dir, including the structs of its descendant containers,
+// but excluding the entries of descendant lists.
+func entryBytes(ir *ygen.IR, dir *ygen.ParsedDirectory) int {
+	n := structBytes(ir, dir)
+	for _, f := range dir.Fields {
+		if f.Type != ygen.ContainerNode {
+			continue
+		}
+		if child, ok := ir.Directories[f.YANGDetails.Path]; ok {
+			n += entryBytes(ir, child)
+		}
+	}
+	return n
+}
+
+// descendantCounts returns the number of structs, including that of dir,
+// and the number of leaves and leaf-lists, within the subtree rooted at
+// dir.
+func descendantCounts(ir *ygen.IR, dir *ygen.ParsedDirectory) (int, int) {
+	structs, leaves := 1, 0
+	for _, f := range dir.Fields {
+		switch f.Type {
+		case ygen.LeafNode, ygen.LeafListNode:
+			leaves++
+		case ygen.ContainerNode, ygen.ListNode:
+			if child, ok := ir.Directories[f.YANGDetails.Path]; ok {
+				s, l := descendantCounts(ir, child)
+				structs += s
+				leaves += l
+			}
+		}
+	}
+	return structs, leaves
+}
+
+// writeStatsReport writes the statistics to w, as JSON if asJSON is true,
+// and as a human-readable summary otherwise.
+func writeStatsReport(w io.Writer, s *generationStats, asJSON bool) error {
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(s)
+	}
+
+	if _, err := fmt.Fprintf(w, "Generated %d structs (%d containers, %d lists) with %d leaves, and %d enums, in %d files (%d bytes).\n",
+		s.Structs, s.Containers, s.Lists, s.Leaves, s.Enums, len(s.Files), s.TotalBytes); err != nil {
+		return err
+	}
+	if len(s.StructSizes) != 0 {
+		fmt.Fprintf(w, "\nLargest structs (estimated size of each instance):\n")
+		for _, st := range s.StructSizes[:min(statsSummaryN, len(s.StructSizes))] {
+			fmt.Fprintf(w, "  %8d bytes  %s (%d fields) %s\n", st.EstimatedBytes, st.Name, st.Fields, st.Path)
+		}
+	}
+	if len(s.LargestLists) != 0 {
+		fmt.Fprintf(w, "\nLargest lists (estimated size of each entry, including descendant containers):\n")
+		for _, l := range s.LargestLists[:min(statsSummaryN, len(s.LargestLists))] {
+			fmt.Fprintf(w, "  %8d bytes  %s (%d structs, %d leaves) %s\n", l.EstimatedEntryBytes, l.Name, l.DescendantStructs, l.DescendantLeaves, l.Path)
+		}
+	}
+	if len(s.Files) != 0 {
+		fmt.Fprintf(w, "\nFiles:\n")
+		for _, f := range s.Files {
+			fmt.Fprintf(w, "  %8d bytes  %s\n", f.Bytes, f.Name)
+		}
+	}
+	return nil
+}
+
+// writeStatsReportFile writes the statistics to the file fn, or to
+// os.Stdout if fn is "-". The statistics are written as JSON if fn ends in
+// .json.
+func writeStatsReportFile(fn string, s *generationStats) error {
+	if fn == "-" {
+		return writeStatsReport(os.Stdout, s, false)
+	}
+	fh := genutil.OpenFile(fn)
+	if fh == nil {
+		return fmt.Errorf("could not open file %q", fn)
+	}
+	defer genutil.SyncFile(fh)
+	return writeStatsReport(fh, s, filepath.Ext(fn) == ".json")
+}
@@ -0,0 +1,163 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/ygen"
+)
+
+// statsTestIR returns an IR with a root container, containing a keyed list
+// whose entries contain a container and a keyless list.
+func statsTestIR() *ygen.IR {
+	field := func(name, path string, t ygen.NodeType, lt *ygen.MappedType) *ygen.NodeDetails {
+		return &ygen.NodeDetails{
+			Name:        name,
+			Type:        t,
+			LangType:    lt,
+			YANGDetails: ygen.YANGNodeDetails{Name: name, Path: path},
+		}
+	}
+	return &ygen.IR{
+		Directories: map[string]*ygen.ParsedDirectory{
+			"/root": {
+				Name: "Root",
+				Type: ygen.Container,
+				Path: "/root",
+				Fields: map[string]*ygen.NodeDetails{
+					"list": field("List", "/root/list", ygen.ListNode, nil),
+				},
+			},
+			"/root/list": {
+				Name: "Root_List",
+				Type: ygen.List,
+				Path: "/root/list",
+				Fields: map[string]*ygen.NodeDetails{
+					"name":      field("Name", "/root/list/name", ygen.LeafNode, &ygen.MappedType{NativeType: "string"}),
+					"value":     field("Value", "/root/list/value", ygen.LeafNode, &ygen.MappedType{NativeType: "Root_List_Value_Union", UnionTypes: map[string]ygen.MappedUnionSubtype{"string": {}, "int8": {}}}),
+					"data":      field("Data", "/root/list/data", ygen.LeafNode, &ygen.MappedType{NativeType: "Binary"}),
+					"tags":      field("Tags", "/root/list/tags", ygen.LeafListNode, &ygen.MappedType{NativeType: "string"}),
+					"state":     field("State", "/root/list/state", ygen.ContainerNode, nil),
+					"unkeyed":   field("Unkeyed", "/root/list/unkeyed", ygen.ListNode, nil),
+					"enumerate": field("Enumerate", "/root/list/enumerate", ygen.LeafNode, &ygen.MappedType{NativeType: "E_Enum", IsEnumeratedValue: true}),
+				},
+				ListKeys: map[string]*ygen.ListKey{"name": {Name: "Name"}},
+			},
+			"/root/list/state": {
+				Name: "Root_List_State",
+				Type: ygen.Container,
+				Path: "/root/list/state",
+				Fields: map[string]*ygen.NodeDetails{
+					"counter": field("Counter", "/root/list/state/counter", ygen.LeafNode, &ygen.MappedType{NativeType: "uint64"}),
+				},
+			},
+			"/root/list/unkeyed": {
+				Name: "Root_List_Unkeyed",
+				Type: ygen.List,
+				Path: "/root/list/unkeyed",
+				Fields: map[string]*ygen.NodeDetails{
+					"a": field("A", "/root/list/unkeyed/a", ygen.LeafNode, &ygen.MappedType{NativeType: "string"}),
+					"b": field("B", "/root/list/unkeyed/b", ygen.LeafNode, &ygen.MappedType{NativeType: "string"}),
+				},
+			},
+		},
+		Enums: map[string]*ygen.EnumeratedYANGType{
+			"/root/list/enumerate": {Name: "Enum"},
+		},
+	}
+}
+
+func TestComputeStats(t *testing.T) {
+	got := computeStats(statsTestIR(), map[string]string{
+		"b.go": "package b",
+		"a.go": "package a\n",
+	})
+	want := &generationStats{
+		Structs:    4,
+		Containers: 2,
+		Lists:      2,
+		Leaves:     8,
+		Enums:      1,
+		TotalBytes: 19,
+		Files: []*fileStats{
+			{Name: "a.go", Bytes: 10},
+			{Name: "b.go", Bytes: 9},
+		},
+		StructSizes: []*structStats{
+			// name (8), value (16), data (24), tags (24), state (8),
+			// unkeyed (24), enumerate (8).
+			{Name: "Root_List", Path: "/root/list", Fields: 7, EstimatedBytes: 112},
+			{Name: "Root_List_Unkeyed", Path: "/root/list/unkeyed", Fields: 2, EstimatedBytes: 16},
+			{Name: "Root", Path: "/root", Fields: 1, EstimatedBytes: 8},
+			{Name: "Root_List_State", Path: "/root/list/state", Fields: 1, EstimatedBytes: 8},
+		},
+		LargestLists: []*listStats{
+			{Name: "Root_List", Path: "/root/list", DescendantStructs: 3, DescendantLeaves: 8, EstimatedEntryBytes: 120},
+			{Name: "Root_List_Unkeyed", Path: "/root/list/unkeyed", DescendantStructs: 1, DescendantLeaves: 2, EstimatedEntryBytes: 16},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("computeStats: did not get expected statistics, (-want, +got):\n%s", diff)
+	}
+}
+
+func TestComputeStatsNoIR(t *testing.T) {
+	got := computeStats(nil, map[string]string{"path.go": "package path\n"})
+	want := &generationStats{
+		TotalBytes:   13,
+		Files:        []*fileStats{{Name: "path.go", Bytes: 13}},
+		StructSizes:  []*structStats{},
+		LargestLists: []*listStats{},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("computeStats: did not get expected statistics, (-want, +got):\n%s", diff)
+	}
+}
+
+func TestWriteStatsReport(t *testing.T) {
+	s := computeStats(statsTestIR(), map[string]string{"a.go": "package a\n"})
+
+	var text bytes.Buffer
+	if err := writeStatsReport(&text, s, false); err != nil {
+		t.Fatalf("writeStatsReport: cannot write summary: %v", err)
+	}
+	for _, want := range []string{
+		"Generated 4 structs (2 containers, 2 lists) with 8 leaves, and 1 enums, in 1 files (10 bytes).",
+		"112 bytes  Root_List (7 fields) /root/list",
+		"120 bytes  Root_List (3 structs, 8 leaves) /root/list",
+		"10 bytes  a.go",
+	} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("writeStatsReport: summary does not contain %q, got:\n%s", want, text.String())
+		}
+	}
+
+	var js bytes.Buffer
+	if err := writeStatsReport(&js, s, true); err != nil {
+		t.Fatalf("writeStatsReport: cannot write JSON: %v", err)
+	}
+	got := &generationStats{}
+	if err := json.Unmarshal(js.Bytes(), got); err != nil {
+		t.Fatalf("writeStatsReport: invalid JSON: %v", err)
+	}
+	if diff := cmp.Diff(s, got); diff != "" {
+		t.Errorf("writeStatsReport: JSON did not round trip, (-want, +got):\n%s", diff)
+	}
+}
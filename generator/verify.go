@@ -0,0 +1,155 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var (
+	verifyCompile = flag.Bool("verify_compile", false, "If set to true, the generated code is built using go build before it is written, and no files are written if it fails to build, such that existing generated code is not overwritten by code that does not compile. The generated packages are built as if their files were at their output paths, and so the packages that they import must be resolvable from there. When go_module_path is set, the module is instead built within a temporary directory, after running go mod tidy, which may download its dependencies. Note that each file written to output_dir without go_module_path imports all of the packages used by the generated code, such that it does not build until it is processed by goimports.")
+)
+
+// goTool is the go command used to verify that the generated code compiles.
+var goTool = "go"
+
+// verifyGoCode checks that the Go files within out, which maps the names of
+// generated files to their contents, compile, without writing them to their
+// output paths. If modDir is not empty, out contains a complete Go module
+// rooted at modDir, which is built in isolation.
+func verifyGoCode(out map[string]string, modDir string) error {
+	tmp, err := os.MkdirTemp("", "ygot-verify-")
+	if err != nil {
+		return fmt.Errorf("cannot create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	if modDir != "" {
+		return verifyGoModule(out, modDir, tmp)
+	}
+	return verifyGoPackages(out, tmp)
+}
+
+// verifyGoPackages builds the packages of the Go files within out using an
+// overlay, such that the go command treats the files as being at their
+// output paths, and so resolves the imports of their packages from there.
+// The files are written to the directory tmp.
+func verifyGoPackages(out map[string]string, tmp string) error {
+	replace := map[string]string{}
+	// names maps the name of each temporary file to its output path, such
+	// that errors can be reported against the output path.
+	names := map[string]string{}
+	dirs := map[string]bool{}
+	for fn, contents := range out {
+		if fn == "-" || filepath.Ext(fn) != ".go" || contents == "" {
+			continue
+		}
+		abs, err := filepath.Abs(fn)
+		if err != nil {
+			return err
+		}
+		tf := filepath.Join(tmp, fmt.Sprintf("%d.go", len(replace)))
+		if err := os.WriteFile(tf, []byte(contents), 0644); err != nil {
+			return err
+		}
+		replace[abs] = tf
+		names[tf] = fn
+		dirs[filepath.Dir(abs)] = true
+	}
+	if len(dirs) == 0 {
+		return nil
+	}
+
+	b, err := json.Marshal(struct{ Replace map[string]string }{replace})
+	if err != nil {
+		return err
+	}
+	overlay := filepath.Join(tmp, "overlay.json")
+	if err := os.WriteFile(overlay, b, 0644); err != nil {
+		return err
+	}
+
+	var pkgs []string
+	for d := range dirs {
+		pkgs = append(pkgs, d)
+	}
+	sort.Strings(pkgs)
+	// The go command is run within the nearest existing directory to the
+	// output, such that it uses the module that the output is within.
+	wd := pkgs[0]
+	for {
+		if fi, err := os.Stat(wd); err == nil && fi.IsDir() {
+			break
+		}
+		parent := filepath.Dir(wd)
+		if parent == wd {
+			break
+		}
+		wd = parent
+	}
+	return runGoTool(wd, names, append([]string{"build", "-overlay=" + overlay}, pkgs...)...)
+}
+
+// verifyGoModule builds the Go module within out, rooted at modDir, by
+// copying it to the directory tmp.
+func verifyGoModule(out map[string]string, modDir, tmp string) error {
+	for fn, contents := range out {
+		rel, err := filepath.Rel(modDir, fn)
+		if err != nil || !filepath.IsLocal(rel) {
+			// Files outside of the module, such as reports, are not
+			// part of the build.
+			continue
+		}
+		dst := filepath.Join(tmp, rel)
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dst, []byte(contents), 0644); err != nil {
+			return err
+		}
+	}
+	names := map[string]string{tmp: modDir}
+	if err := runGoTool(tmp, names, "mod", "tidy"); err != nil {
+		return err
+	}
+	return runGoTool(tmp, names, "build", "./...")
+}
+
+// runGoTool runs the go command with args within the directory dir,
+// returning an error containing its output if it fails. Occurrences of the
+// keys of names within the output are replaced with their values.
+func runGoTool(dir string, names map[string]string, args ...string) error {
+	var output bytes.Buffer
+	cmd := exec.Command(goTool, args...)
+	cmd.Dir = dir
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(output.String())
+		for from, to := range names {
+			msg = strings.ReplaceAll(msg, from, to)
+		}
+		return fmt.Errorf("go %s failed: %v\n%s", args[0], err, msg)
+	}
+	return nil
+}
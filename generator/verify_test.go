@@ -0,0 +1,87 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+func TestVerifyGoCode(t *testing.T) {
+	// The packages are within this module, but are not written to disk.
+	pkgDir := filepath.Join("verifytest", "pkg")
+	modDir := filepath.Join(t.TempDir(), "mod")
+
+	tests := []struct {
+		desc             string
+		inOut            map[string]string
+		inModDir         string
+		wantErrSubstring string
+	}{{
+		desc: "valid packages",
+		inOut: map[string]string{
+			filepath.Join(pkgDir, "a.go"):        "package pkg\n\nimport \"github.com/openconfig/ygot/ygot\"\n\nvar A = ygot.BinaryTypeName\n",
+			filepath.Join(pkgDir, "b.go"):        "package pkg\n\nvar B = A\n",
+			filepath.Join(pkgDir, "sub", "c.go"): "package sub\n\nimport \"github.com/openconfig/ygot/generator/verifytest/pkg\"\n\nvar C = pkg.B\n",
+			filepath.Join(pkgDir, "report.txt"):  "not Go",
+			"-":                                  "package stdout\n\nundefined\n",
+		},
+	}, {
+		desc: "invalid package",
+		inOut: map[string]string{
+			filepath.Join(pkgDir, "a.go"): "package pkg\n\nvar A = undefinedName\n",
+		},
+		wantErrSubstring: filepath.Join(pkgDir, "a.go") + ":3:9: undefined: undefinedName",
+	}, {
+		desc: "no Go files",
+		inOut: map[string]string{
+			filepath.Join(pkgDir, "report.txt"): "not Go",
+		},
+	}, {
+		desc: "valid module",
+		inOut: map[string]string{
+			filepath.Join(modDir, "go.mod"):         "module example.com/mod\n\ngo 1.21\n",
+			filepath.Join(modDir, "a", "a.go"):      "package a\n\nimport \"fmt\"\n\nvar A = fmt.Sprint(1)\n",
+			filepath.Join(modDir, "b", "b.go"):      "package b\n\nimport \"example.com/mod/a\"\n\nvar B = a.A\n",
+			filepath.Join(t.TempDir(), "stats.txt"): "outside of the module",
+		},
+		inModDir: modDir,
+	}, {
+		desc: "invalid module",
+		inOut: map[string]string{
+			filepath.Join(modDir, "go.mod"):    "module example.com/mod\n\ngo 1.21\n",
+			filepath.Join(modDir, "a", "a.go"): "package a\n\nvar A = undefinedName\n",
+		},
+		inModDir:         modDir,
+		wantErrSubstring: "undefined: undefinedName",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := verifyGoCode(tt.inOut, tt.inModDir)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("verifyGoCode: %s", diff)
+			}
+			for fn := range tt.inOut {
+				if _, err := os.Stat(fn); err == nil {
+					t.Errorf("verifyGoCode: file %s was written", fn)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	log "github.com/golang/glog"
+)
+
+var (
+	// Flags used for watch mode.
+	watch         = flag.Bool("watch", false, "If set to true, the generator does not exit after generating code, but watches the input modules and the YANG files beneath the directories specified by path, and regenerates code when they change. Generated files whose contents are unchanged are not rewritten.")
+	watchInterval = flag.Duration("watch_interval", time.Second, "The interval at which the YANG files are polled for changes when watch=true.")
+	watchDebounce = flag.Duration("watch_debounce", 300*time.Millisecond, "The period for which the YANG files must be unchanged before code is regenerated when watch=true, such that a series of changes results in a single regeneration.")
+)
+
+// fileState is the state of a file that is used to detect changes to it.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// watcher polls a set of YANG files for changes.
+type watcher struct {
+	// files is the set of files that are watched.
+	files []string
+	// dirs is the set of directories beneath which all files with the
+	// ".yang" extension are watched.
+	dirs []string
+	// interval is the interval at which the files are polled.
+	interval time.Duration
+	// debounce is the period for which the files must be unchanged
+	// before changes are reported.
+	debounce time.Duration
+	// stop, if non-nil, causes run to return when it is closed.
+	stop <-chan struct{}
+}
+
+// snapshot returns the state of each of the watched files that exists,
+// keyed by file name.
+func (w *watcher) snapshot() map[string]fileState {
+	s := map[string]fileState{}
+	add := func(fn string, fi fs.FileInfo) {
+		s[fn] = fileState{modTime: fi.ModTime(), size: fi.Size()}
+	}
+	for _, dir := range w.dirs {
+		// Errors are ignored such that files within directories that
+		// cannot be read, or that are removed during the walk, are
+		// treated as not existing.
+		filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || filepath.Ext(path) != ".yang" {
+				return nil
+			}
+			if fi, err := d.Info(); err == nil {
+				add(path, fi)
+			}
+			return nil
+		})
+	}
+	for _, fn := range w.files {
+		if fi, err := os.Stat(fn); err == nil {
+			add(fn, fi)
+		}
+	}
+	return s
+}
+
+// changedFiles returns the names of the files that have been created,
+// modified or removed between the snapshots old and new, in sorted order.
+func changedFiles(old, new map[string]fileState) []string {
+	var changed []string
+	for fn, n := range new {
+		if o, ok := old[fn]; !ok || !o.modTime.Equal(n.modTime) || o.size != n.size {
+			changed = append(changed, fn)
+		}
+	}
+	for fn := range old {
+		if _, ok := new[fn]; !ok {
+			changed = append(changed, fn)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// run polls the watched files, calling fn with the names of the files that
+// have changed once they have been unchanged for the debounce period. It
+// returns only when w.stop is closed.
+func (w *watcher) run(fn func(changed []string)) {
+	log.Infof("Watching for changes to YANG files.")
+	prev := w.snapshot()
+	for {
+		if !w.sleep(w.interval) {
+			return
+		}
+		cur := w.snapshot()
+		changed := map[string]bool{}
+		for _, f := range changedFiles(prev, cur) {
+			changed[f] = true
+		}
+		if len(changed) == 0 {
+			continue
+		}
+
+		// Wait for the files to settle, such that a series of changes,
+		// e.g., by a version control checkout, results in a single
+		// regeneration.
+		for {
+			if !w.sleep(w.debounce) {
+				return
+			}
+			next := w.snapshot()
+			more := changedFiles(cur, next)
+			cur = next
+			if len(more) == 0 {
+				break
+			}
+			for _, f := range more {
+				changed[f] = true
+			}
+		}
+
+		var names []string
+		for f := range changed {
+			names = append(names, f)
+		}
+		sort.Strings(names)
+		fn(names)
+		prev = cur
+	}
+}
+
+// sleep waits for d, returning false if w.stop is closed in the meantime.
+func (w *watcher) sleep(d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-w.stop:
+		return false
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestChangedFiles(t *testing.T) {
+	t0 := time.Unix(100, 0)
+	t1 := time.Unix(200, 0)
+	old := map[string]fileState{
+		"same.yang":     {modTime: t0, size: 1},
+		"modified.yang": {modTime: t0, size: 1},
+		"resized.yang":  {modTime: t0, size: 1},
+		"removed.yang":  {modTime: t0, size: 1},
+	}
+	new := map[string]fileState{
+		"same.yang":     {modTime: t0, size: 1},
+		"modified.yang": {modTime: t1, size: 1},
+		"resized.yang":  {modTime: t0, size: 2},
+		"created.yang":  {modTime: t1, size: 1},
+	}
+	want := []string{"created.yang", "modified.yang", "removed.yang", "resized.yang"}
+	if diff := cmp.Diff(want, changedFiles(old, new)); diff != "" {
+		t.Errorf("changedFiles: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestWatcherRun(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "sub")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatalf("cannot create directory: %v", err)
+	}
+	input := filepath.Join(t.TempDir(), "input.yang")
+	write := func(fn, contents string) {
+		t.Helper()
+		if err := os.WriteFile(fn, []byte(contents), 0o644); err != nil {
+			t.Fatalf("cannot write %s: %v", fn, err)
+		}
+	}
+	write(input, "module input {}")
+	write(filepath.Join(dir, "ignored.txt"), "")
+
+	stop := make(chan struct{})
+	calls := make(chan []string, 10)
+	w := &watcher{
+		files:    []string{input},
+		dirs:     []string{dir},
+		interval: 10 * time.Millisecond,
+		debounce: 50 * time.Millisecond,
+		stop:     stop,
+	}
+	done := make(chan struct{})
+	go func() {
+		w.run(func(changed []string) { calls <- changed })
+		close(done)
+	}()
+	// Allow the initial snapshot to be taken.
+	time.Sleep(30 * time.Millisecond)
+
+	// A series of changes within the debounce period results in a single
+	// call.
+	write(input, "module input { prefix i; }")
+	write(filepath.Join(sub, "dep.yang"), "module dep {}")
+	write(filepath.Join(dir, "ignored.txt"), "changed")
+	select {
+	case got := <-calls:
+		want := []string{input, filepath.Join(sub, "dep.yang")}
+		sort.Strings(want)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("changed files: (-want, +got):\n%s", diff)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("did not get call for changed files")
+	}
+	select {
+	case got := <-calls:
+		t.Errorf("got unexpected second call with changed files %v", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(stop)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("run did not return when stopped")
+	}
+}
+
+func TestWriteOutput(t *testing.T) {
+	fn := filepath.Join(t.TempDir(), "out.go")
+	if err := writeOutput(fn, []byte("package a\n")); err != nil {
+		t.Fatalf("writeOutput: got unexpected error: %v", err)
+	}
+	old := time.Unix(100, 0)
+	if err := os.Chtimes(fn, old, old); err != nil {
+		t.Fatalf("cannot set modification time: %v", err)
+	}
+
+	if err := writeOutput(fn, []byte("package a\n")); err != nil {
+		t.Fatalf("writeOutput of unchanged contents: got unexpected error: %v", err)
+	}
+	fi, err := os.Stat(fn)
+	if err != nil {
+		t.Fatalf("cannot stat output: %v", err)
+	}
+	if !fi.ModTime().Equal(old) {
+		t.Errorf("writeOutput of unchanged contents: file was rewritten")
+	}
+
+	if err := writeOutput(fn, []byte("package b\n")); err != nil {
+		t.Fatalf("writeOutput of changed contents: got unexpected error: %v", err)
+	}
+	if b, err := os.ReadFile(fn); err != nil || string(b) != "package b\n" {
+		t.Errorf("writeOutput of changed contents: got (%q, %v), want new contents", b, err)
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genutil
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	// PhaseParse is the name of the phase in which the input YANG modules
+	// are parsed and processed by goyang.
+	PhaseParse = "parse"
+	// PhaseIR is the name of the phase in which the ygen intermediate
+	// representation is built from the parsed schema.
+	PhaseIR = "IR"
+	// PhaseCodegen is the name of the phase in which language-specific
+	// code is generated from the IR.
+	PhaseCodegen = "codegen"
+	// PhaseWrite is the name of the phase in which generated code is
+	// written to its output.
+	PhaseWrite = "write"
+)
+
+// PhaseTimer records the wall-clock time spent in each named phase of code
+// generation. A nil *PhaseTimer is valid and records nothing, such that
+// callers can unconditionally instrument their code. It is safe for
+// concurrent use.
+type PhaseTimer struct {
+	mu sync.Mutex
+	// order stores the names of the phases in the order that they were
+	// first started.
+	order []string
+	// durations stores the cumulative duration of each phase.
+	durations map[string]time.Duration
+}
+
+// NewPhaseTimer returns a new, empty PhaseTimer.
+func NewPhaseTimer() *PhaseTimer {
+	return &PhaseTimer{durations: map[string]time.Duration{}}
+}
+
+// Start begins timing the phase with the supplied name, and returns a
+// function that must be called when the phase is complete. If a phase is
+// started more than once, the durations are summed.
+func (p *PhaseTimer) Start(phase string) func() {
+	if p == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		p.add(phase, time.Since(start))
+	}
+}
+
+// add adds d to the duration recorded for the named phase.
+func (p *PhaseTimer) add(phase string, d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.durations[phase]; !ok {
+		p.order = append(p.order, phase)
+	}
+	p.durations[phase] += d
+}
+
+// Duration returns the total duration recorded for the named phase.
+func (p *PhaseTimer) Duration(phase string) time.Duration {
+	if p == nil {
+		return 0
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.durations[phase]
+}
+
+// Phases returns the names of the phases that have been recorded, in the
+// order in which they were first started.
+func (p *PhaseTimer) Phases() []string {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string{}, p.order...)
+}
+
+// WriteSummary writes a human-readable table of the recorded phases and
+// their durations, followed by the total, to w.
+func (p *PhaseTimer) WriteSummary(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	var total time.Duration
+	for _, phase := range p.Phases() {
+		d := p.Duration(phase)
+		total += d
+		fmt.Fprintf(tw, "%s\t%v\n", phase, d.Round(time.Microsecond))
+	}
+	fmt.Fprintf(tw, "total\t%v\n", total.Round(time.Microsecond))
+	return tw.Flush()
+}
+
+// ProfileMode specifies the type of profile that should be collected.
+type ProfileMode string
+
+const (
+	// NoProfile indicates that no profile should be collected.
+	NoProfile ProfileMode = ""
+	// CPUProfile indicates that a pprof CPU profile should be collected.
+	CPUProfile ProfileMode = "cpu"
+	// MemProfile indicates that a pprof heap profile should be written
+	// when profiling stops.
+	MemProfile ProfileMode = "mem"
+	// TraceProfile indicates that a runtime execution trace should be
+	// collected.
+	TraceProfile ProfileMode = "trace"
+)
+
+// StartProfile begins collecting the profile specified by mode, writing it
+// to the file named fn. The returned function must be called to stop
+// profiling and flush the profile to the file. If mode is NoProfile, the
+// returned function is a no-op.
+func StartProfile(mode ProfileMode, fn string) (func() error, error) {
+	if mode == NoProfile {
+		return func() error { return nil }, nil
+	}
+	if fn == "" {
+		return nil, fmt.Errorf("an output file must be specified for %s profile", mode)
+	}
+
+	var stop func() error
+	switch mode {
+	case CPUProfile, MemProfile, TraceProfile:
+	default:
+		return nil, fmt.Errorf("unknown profile mode %q, must be one of cpu, mem or trace", mode)
+	}
+
+	f, err := os.Create(fn)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create profile output file: %v", err)
+	}
+
+	switch mode {
+	case CPUProfile:
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("cannot start CPU profile: %v", err)
+		}
+		stop = func() error {
+			pprof.StopCPUProfile()
+			return f.Close()
+		}
+	case MemProfile:
+		stop = func() error {
+			// Run a GC such that the heap profile reflects up-to-date
+			// allocation statistics.
+			runtime.GC()
+			if err := pprof.WriteHeapProfile(f); err != nil {
+				f.Close()
+				return fmt.Errorf("cannot write memory profile: %v", err)
+			}
+			return f.Close()
+		}
+	case TraceProfile:
+		if err := trace.Start(f); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("cannot start trace: %v", err)
+		}
+		stop = func() error {
+			trace.Stop()
+			return f.Close()
+		}
+	}
+	return stop, nil
+}
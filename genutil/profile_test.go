@@ -0,0 +1,124 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+func TestPhaseTimer(t *testing.T) {
+	timer := NewPhaseTimer()
+	timer.Start(PhaseParse)()
+	timer.Start(PhaseIR)()
+	timer.Start(PhaseParse)()
+	timer.Start(PhaseWrite)()
+
+	if diff := cmp.Diff([]string{PhaseParse, PhaseIR, PhaseWrite}, timer.Phases()); diff != "" {
+		t.Errorf("Phases(): did not get expected phases (-want, +got):\n%s", diff)
+	}
+
+	var b strings.Builder
+	if err := timer.WriteSummary(&b); err != nil {
+		t.Fatalf("WriteSummary(): got unexpected error: %v", err)
+	}
+	var gotPhases []string
+	for _, line := range strings.Split(strings.TrimSpace(b.String()), "\n") {
+		gotPhases = append(gotPhases, strings.Fields(line)[0])
+	}
+	if diff := cmp.Diff([]string{PhaseParse, PhaseIR, PhaseWrite, "total"}, gotPhases); diff != "" {
+		t.Errorf("WriteSummary(): did not get expected phases in summary (-want, +got):\n%s", diff)
+	}
+}
+
+func TestNilPhaseTimer(t *testing.T) {
+	var timer *PhaseTimer
+	timer.Start(PhaseCodegen)()
+	if got := timer.Duration(PhaseCodegen); got != 0 {
+		t.Errorf("Duration(): got %v, want 0", got)
+	}
+	if got := timer.Phases(); got != nil {
+		t.Errorf("Phases(): got %v, want nil", got)
+	}
+}
+
+func TestStartProfile(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		desc             string
+		inMode           ProfileMode
+		inFile           string
+		wantErrSubstring string
+		wantFile         bool
+	}{{
+		desc:   "no profile",
+		inMode: NoProfile,
+	}, {
+		desc:     "cpu profile",
+		inMode:   CPUProfile,
+		inFile:   filepath.Join(dir, "cpu.prof"),
+		wantFile: true,
+	}, {
+		desc:     "memory profile",
+		inMode:   MemProfile,
+		inFile:   filepath.Join(dir, "mem.prof"),
+		wantFile: true,
+	}, {
+		desc:     "trace",
+		inMode:   TraceProfile,
+		inFile:   filepath.Join(dir, "trace.out"),
+		wantFile: true,
+	}, {
+		desc:             "unknown mode",
+		inMode:           "block",
+		inFile:           filepath.Join(dir, "block.prof"),
+		wantErrSubstring: "unknown profile mode",
+	}, {
+		desc:             "missing output file",
+		inMode:           CPUProfile,
+		wantErrSubstring: "an output file must be specified",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			stop, err := StartProfile(tt.inMode, tt.inFile)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("StartProfile(%q, %q): did not get expected error, %s", tt.inMode, tt.inFile, diff)
+			}
+			if err != nil {
+				return
+			}
+			if err := stop(); err != nil {
+				t.Fatalf("StartProfile(%q, %q): cannot stop profile: %v", tt.inMode, tt.inFile, err)
+			}
+			if !tt.wantFile {
+				return
+			}
+			fi, err := os.Stat(tt.inFile)
+			if err != nil {
+				t.Fatalf("StartProfile(%q, %q): cannot stat output file: %v", tt.inMode, tt.inFile, err)
+			}
+			if fi.Size() == 0 {
+				t.Errorf("StartProfile(%q, %q): output file is empty", tt.inMode, tt.inFile)
+			}
+		})
+	}
+}
@@ -0,0 +1,356 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genutil
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// gitSourcePrefix is the prefix of sources that are git repositories.
+	gitSourcePrefix = "git+"
+)
+
+// SourceFetcher resolves the sources of YANG modules that are supplied to
+// the generators to local directories. A source is one of:
+//   - a local directory, which is used as is.
+//   - a local tar (optionally gzipped, with the extension .tar, .tar.gz or
+//     .tgz) or zip (.zip) archive, which is extracted.
+//   - an HTTP or HTTPS URL of such an archive, which is downloaded and
+//     extracted, or of a single YANG module (.yang), which is downloaded.
+//   - a git repository, specified as "git+" followed by the URL of the
+//     repository and, optionally, "#" followed by the ref (e.g., a branch,
+//     tag or commit) to be checked out, e.g.,
+//     git+https://github.com/openconfig/public#v4.0.0. The repository's
+//     default branch is used if no ref is specified.
+//
+// Sources other than local directories are fetched into a cache directory,
+// keyed by the source, and are reused by subsequent calls without being
+// fetched again. Since the contents of a URL, or of a git branch, may change,
+// the cached copy of the source should be removed from the cache directory
+// for it to be refreshed.
+type SourceFetcher struct {
+	// CacheDir is the directory in which fetched sources are cached. If
+	// it is empty, the "ygot/yang-sources" directory within the user's
+	// cache directory is used.
+	CacheDir string
+	// Client is the HTTP client used to download sources. If it is nil,
+	// http.DefaultClient is used.
+	Client *http.Client
+	// Git is the git command used to fetch git repositories. If it is
+	// empty, "git" is used.
+	Git string
+}
+
+// IsLocalSource reports whether src is a local directory, which is used
+// as is by Resolve rather than being fetched.
+func IsLocalSource(src string) bool {
+	return !strings.HasPrefix(src, gitSourcePrefix) && !isURL(src) && archiveExt(src) == ""
+}
+
+// Resolve returns the local directory that contains the YANG modules of the
+// source src, fetching it if it is not already cached.
+func (f *SourceFetcher) Resolve(ctx context.Context, src string) (string, error) {
+	if IsLocalSource(src) {
+		return src, nil
+	}
+
+	cacheDir := f.CacheDir
+	if cacheDir == "" {
+		d, err := os.UserCacheDir()
+		if err != nil {
+			return "", fmt.Errorf("cannot determine cache directory for source %s: %v", src, err)
+		}
+		cacheDir = filepath.Join(d, "ygot", "yang-sources")
+	}
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return "", fmt.Errorf("cannot create cache directory %s: %v", cacheDir, err)
+	}
+
+	key := src
+	if !isURL(src) && !strings.HasPrefix(src, gitSourcePrefix) {
+		// Local archives are cached by their contents, such that a
+		// modified archive is extracted again.
+		digest, err := fileDigest(src)
+		if err != nil {
+			return "", err
+		}
+		key = fmt.Sprintf("%s@%s", filepath.Base(src), digest)
+	}
+	sum := sha256.Sum256([]byte(key))
+	dir := filepath.Join(cacheDir, hex.EncodeToString(sum[:]))
+	if fi, err := os.Stat(dir); err == nil && fi.IsDir() {
+		return dir, nil
+	}
+
+	// Sources are fetched into a temporary directory that is renamed
+	// once it is complete, such that an interrupted fetch is not used.
+	tmp, err := os.MkdirTemp(cacheDir, ".fetch-")
+	if err != nil {
+		return "", fmt.Errorf("cannot create temporary directory for source %s: %v", src, err)
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := f.fetch(ctx, src, tmp); err != nil {
+		return "", fmt.Errorf("cannot fetch source %s: %v", src, err)
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		// A concurrent caller may have fetched the same source.
+		if fi, serr := os.Stat(dir); serr == nil && fi.IsDir() {
+			return dir, nil
+		}
+		return "", fmt.Errorf("cannot cache source %s: %v", src, err)
+	}
+	return dir, nil
+}
+
+// fetch fetches the source src into the directory dir.
+func (f *SourceFetcher) fetch(ctx context.Context, src, dir string) error {
+	switch {
+	case strings.HasPrefix(src, gitSourcePrefix):
+		return f.fetchGit(ctx, strings.TrimPrefix(src, gitSourcePrefix), dir)
+	case isURL(src):
+		u, err := url.Parse(src)
+		if err != nil {
+			return err
+		}
+		isArchive := archiveExt(u.Path) != ""
+		if !isArchive && path.Ext(u.Path) != ".yang" {
+			return fmt.Errorf("URL is neither an archive nor a YANG module")
+		}
+		b, err := f.download(ctx, src)
+		if err != nil {
+			return err
+		}
+		if !isArchive {
+			return os.WriteFile(filepath.Join(dir, path.Base(u.Path)), b, 0644)
+		}
+		return extractArchive(u.Path, b, dir)
+	default:
+		b, err := os.ReadFile(src)
+		if err != nil {
+			return err
+		}
+		return extractArchive(src, b, dir)
+	}
+}
+
+// download returns the contents of the URL u.
+func (f *SourceFetcher) download(ctx context.Context, u string) ([]byte, error) {
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// fetchGit fetches the git repository repo, which is a URL optionally
+// followed by "#" and a ref, into dir. Only the specified commit is fetched,
+// without its history.
+func (f *SourceFetcher) fetchGit(ctx context.Context, repo, dir string) error {
+	ref := "HEAD"
+	if i := strings.LastIndex(repo, "#"); i != -1 {
+		repo, ref = repo[:i], repo[i+1:]
+	}
+	if repo == "" || ref == "" {
+		return fmt.Errorf("invalid git source, must be of the form git+<url>[#<ref>]")
+	}
+	git := f.Git
+	if git == "" {
+		git = "git"
+	}
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"fetch", "--quiet", "--depth", "1", repo, ref},
+		{"checkout", "--quiet", "FETCH_HEAD"},
+	} {
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, git, args...)
+		cmd.Dir = dir
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("git %s failed: %v: %s", args[0], err, strings.TrimSpace(stderr.String()))
+		}
+	}
+	return nil
+}
+
+// isURL reports whether src is an HTTP or HTTPS URL.
+func isURL(src string) bool {
+	return strings.HasPrefix(src, "https://") || strings.HasPrefix(src, "http://")
+}
+
+// archiveExt returns the archive extension of the file name fn, or the
+// empty string if it is not an archive.
+func archiveExt(fn string) string {
+	for _, ext := range []string{".tar.gz", ".tgz", ".tar", ".zip"} {
+		if strings.HasSuffix(fn, ext) {
+			return ext
+		}
+	}
+	return ""
+}
+
+// fileDigest returns the hex encoded SHA-256 digest of the file fn.
+func fileDigest(fn string) (string, error) {
+	fh, err := os.Open(fn)
+	if err != nil {
+		return "", err
+	}
+	defer fh.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, fh); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// extractArchive extracts the archive b, whose type is determined by the
+// extension of the file name fn, into dir. Only regular files and
+// directories are extracted.
+func extractArchive(fn string, b []byte, dir string) error {
+	switch archiveExt(fn) {
+	case ".zip":
+		return extractZip(b, dir)
+	case ".tar":
+		return extractTar(bytes.NewReader(b), dir)
+	case ".tar.gz", ".tgz":
+		zr, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		return extractTar(zr, dir)
+	default:
+		return fmt.Errorf("unsupported archive %s", fn)
+	}
+}
+
+// extractTar extracts the tar archive read from r into dir.
+func extractTar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if _, err := extractPath(dir, hdr.Name, true); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			fn, err := extractPath(dir, hdr.Name, false)
+			if err != nil {
+				return err
+			}
+			if err := writeExtractedFile(fn, tr); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// extractZip extracts the zip archive b into dir.
+func extractZip(b []byte, dir string) error {
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return err
+	}
+	for _, zf := range zr.File {
+		switch mode := zf.Mode(); {
+		case mode.IsDir():
+			if _, err := extractPath(dir, zf.Name, true); err != nil {
+				return err
+			}
+		case mode.IsRegular():
+			fn, err := extractPath(dir, zf.Name, false)
+			if err != nil {
+				return err
+			}
+			rc, err := zf.Open()
+			if err != nil {
+				return err
+			}
+			err = writeExtractedFile(fn, rc)
+			rc.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// extractPath returns the path within dir of the archive entry name,
+// creating it if isDir is true, or its parent directory otherwise. It
+// returns an error if name is not within dir.
+func extractPath(dir, name string, isDir bool) (string, error) {
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("archive entry %s is outside of the archive", name)
+	}
+	p := filepath.Join(dir, name)
+	mk := p
+	if !isDir {
+		mk = filepath.Dir(p)
+	}
+	if err := os.MkdirAll(mk, 0755); err != nil {
+		return "", err
+	}
+	return p, nil
+}
+
+// writeExtractedFile writes the contents read from r to the file fn.
+func writeExtractedFile(fn string, r io.Reader) error {
+	fh, err := os.OpenFile(fn, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(fh, r); err != nil {
+		fh.Close()
+		return err
+	}
+	return fh.Close()
+}
@@ -0,0 +1,354 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genutil
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+// testArchiveFiles is the set of files within the test archives.
+var testArchiveFiles = map[string]string{
+	"public/release/models/a.yang": "module a {}",
+	"public/third_party/b.yang":    "module b {}",
+}
+
+func tarArchive(t *testing.T, files map[string]string, gzipped bool) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var zw *gzip.Writer
+	tw := tar.NewWriter(&buf)
+	if gzipped {
+		zw = gzip.NewWriter(&buf)
+		tw = tar.NewWriter(zw)
+	}
+	var names []string
+	for n := range files {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		if err := tw.WriteHeader(&tar.Header{Name: n, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(files[n]))}); err != nil {
+			t.Fatalf("cannot write tar header: %v", err)
+		}
+		if _, err := tw.Write([]byte(files[n])); err != nil {
+			t.Fatalf("cannot write tar file: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("cannot close tar: %v", err)
+	}
+	if zw != nil {
+		if err := zw.Close(); err != nil {
+			t.Fatalf("cannot close gzip: %v", err)
+		}
+	}
+	return buf.Bytes()
+}
+
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for n, c := range files {
+		w, err := zw.Create(n)
+		if err != nil {
+			t.Fatalf("cannot create zip file: %v", err)
+		}
+		if _, err := w.Write([]byte(c)); err != nil {
+			t.Fatalf("cannot write zip file: %v", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("cannot close zip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// readTree returns the contents of the regular files beneath dir, keyed by
+// their slash separated path relative to dir.
+func readTree(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	got := map[string]string{}
+	err := filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			if fi.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		got[filepath.ToSlash(rel)] = string(b)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("cannot read directory %s: %v", dir, err)
+	}
+	return got
+}
+
+func TestResolveLocal(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name      string
+		inArchive string
+		inBytes   []byte
+	}{{
+		name:      "tar",
+		inArchive: "models.tar",
+		inBytes:   tarArchive(t, testArchiveFiles, false),
+	}, {
+		name:      "tar.gz",
+		inArchive: "models.tar.gz",
+		inBytes:   tarArchive(t, testArchiveFiles, true),
+	}, {
+		name:      "tgz",
+		inArchive: "models.tgz",
+		inBytes:   tarArchive(t, testArchiveFiles, true),
+	}, {
+		name:      "zip",
+		inArchive: "models.zip",
+		inBytes:   zipArchive(t, testArchiveFiles),
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn := filepath.Join(dir, tt.inArchive)
+			if err := os.WriteFile(fn, tt.inBytes, 0644); err != nil {
+				t.Fatalf("cannot write archive: %v", err)
+			}
+			f := &SourceFetcher{CacheDir: t.TempDir()}
+			got, err := f.Resolve(context.Background(), fn)
+			if err != nil {
+				t.Fatalf("Resolve(%s): got unexpected error: %v", fn, err)
+			}
+			if diff := cmp.Diff(testArchiveFiles, readTree(t, got)); diff != "" {
+				t.Errorf("Resolve(%s): did not get expected files, (-want, +got):\n%s", fn, diff)
+			}
+
+			again, err := f.Resolve(context.Background(), fn)
+			if err != nil {
+				t.Fatalf("Resolve(%s): got unexpected error for cached archive: %v", fn, err)
+			}
+			if again != got {
+				t.Errorf("Resolve(%s): cached archive was not reused, got: %s, want: %s", fn, again, got)
+			}
+
+			// A modified archive is extracted again.
+			if err := os.WriteFile(fn, zipOrTar(t, tt.inArchive, map[string]string{"c.yang": "module c {}"}), 0644); err != nil {
+				t.Fatalf("cannot write archive: %v", err)
+			}
+			modified, err := f.Resolve(context.Background(), fn)
+			if err != nil {
+				t.Fatalf("Resolve(%s): got unexpected error for modified archive: %v", fn, err)
+			}
+			if diff := cmp.Diff(map[string]string{"c.yang": "module c {}"}, readTree(t, modified)); diff != "" {
+				t.Errorf("Resolve(%s): did not get expected files for modified archive, (-want, +got):\n%s", fn, diff)
+			}
+		})
+	}
+
+	t.Run("directory", func(t *testing.T) {
+		f := &SourceFetcher{CacheDir: t.TempDir()}
+		got, err := f.Resolve(context.Background(), dir)
+		if err != nil {
+			t.Fatalf("Resolve(%s): got unexpected error: %v", dir, err)
+		}
+		if got != dir {
+			t.Errorf("Resolve(%s): got %s, want the directory unchanged", dir, got)
+		}
+	})
+}
+
+func zipOrTar(t *testing.T, fn string, files map[string]string) []byte {
+	switch archiveExt(fn) {
+	case ".zip":
+		return zipArchive(t, files)
+	case ".tar":
+		return tarArchive(t, files, false)
+	default:
+		return tarArchive(t, files, true)
+	}
+}
+
+func TestResolveURL(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		switch r.URL.Path {
+		case "/models.tar.gz":
+			w.Write(tarArchive(t, testArchiveFiles, true))
+		case "/models/a.yang":
+			w.Write([]byte("module a {}"))
+		case "/evil.tar":
+			w.Write(tarArchive(t, map[string]string{"../evil.yang": "module evil {}"}, false))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name             string
+		inURL            string
+		want             map[string]string
+		wantErrSubstring string
+	}{{
+		name:  "archive",
+		inURL: ts.URL + "/models.tar.gz",
+		want:  testArchiveFiles,
+	}, {
+		name:  "module",
+		inURL: ts.URL + "/models/a.yang",
+		want:  map[string]string{"a.yang": "module a {}"},
+	}, {
+		name:             "not found",
+		inURL:            ts.URL + "/missing.zip",
+		wantErrSubstring: "404",
+	}, {
+		name:             "not a module",
+		inURL:            ts.URL + "/models/a.txt",
+		wantErrSubstring: "neither an archive nor a YANG module",
+	}, {
+		name:             "entry outside of archive",
+		inURL:            ts.URL + "/evil.tar",
+		wantErrSubstring: "outside of the archive",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &SourceFetcher{CacheDir: t.TempDir(), Client: ts.Client()}
+			got, err := f.Resolve(context.Background(), tt.inURL)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("Resolve(%s): %s", tt.inURL, diff)
+			}
+			if err != nil {
+				entries, err := os.ReadDir(f.CacheDir)
+				if err != nil {
+					t.Fatalf("cannot read cache directory: %v", err)
+				}
+				if len(entries) != 0 {
+					t.Errorf("Resolve(%s): failed fetch left entries in the cache: %v", tt.inURL, entries)
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.want, readTree(t, got)); diff != "" {
+				t.Errorf("Resolve(%s): did not get expected files, (-want, +got):\n%s", tt.inURL, diff)
+			}
+
+			before := atomic.LoadInt32(&requests)
+			if _, err := f.Resolve(context.Background(), tt.inURL); err != nil {
+				t.Fatalf("Resolve(%s): got unexpected error for cached source: %v", tt.inURL, err)
+			}
+			if after := atomic.LoadInt32(&requests); after != before {
+				t.Errorf("Resolve(%s): cached source was fetched again", tt.inURL)
+			}
+		})
+	}
+}
+
+func TestResolveGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+	repo := t.TempDir()
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com", "GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	commit := func(fn, contents string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, fn), []byte(contents), 0644); err != nil {
+			t.Fatalf("cannot write file: %v", err)
+		}
+		run("add", fn)
+		run("commit", "--quiet", "-m", fn)
+	}
+	run("init", "--quiet")
+	commit("a.yang", "module a {}")
+	run("tag", "v1")
+	commit("b.yang", "module b {}")
+
+	tests := []struct {
+		name             string
+		inSource         string
+		want             map[string]string
+		wantErrSubstring string
+	}{{
+		name:     "default branch",
+		inSource: "git+file://" + repo,
+		want:     map[string]string{"a.yang": "module a {}", "b.yang": "module b {}"},
+	}, {
+		name:     "tag",
+		inSource: "git+file://" + repo + "#v1",
+		want:     map[string]string{"a.yang": "module a {}"},
+	}, {
+		name:             "missing ref",
+		inSource:         "git+file://" + repo + "#v2",
+		wantErrSubstring: "git fetch failed",
+	}, {
+		name:             "empty ref",
+		inSource:         "git+file://" + repo + "#",
+		wantErrSubstring: "invalid git source",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &SourceFetcher{CacheDir: t.TempDir()}
+			got, err := f.Resolve(context.Background(), tt.inSource)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("Resolve(%s): %s", tt.inSource, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, readTree(t, got)); diff != "" {
+				t.Errorf("Resolve(%s): did not get expected files, (-want, +got):\n%s", tt.inSource, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/openconfig/ygot/gnmidiff"
+	"github.com/openconfig/ygot/gnmidiff/gnmiparse"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func newNotifsDiffCmd() *cobra.Command {
+	notifsdiff := &cobra.Command{
+		Use:   "notifs",
+		RunE:  notifsDiff,
+		Short: "Diffs the Notifications (either from Get or Subscribe) collected from two runs against a device.",
+		Args:  cobra.MinimumNArgs(2),
+	}
+
+	notifsdiff.Flags().Bool("full", false, "Whether diff shows common values.")
+	notifsdiff.Flags().StringSlice("ignore", nil, "gNMI paths whose leaves, and those of their descendants, are ignored, e.g. /interfaces/interface[name=*]/state/counters. Element names and key values may be the \"*\" wildcard, and unspecified keys match any value.")
+
+	return notifsdiff
+}
+
+func notifsDiff(cmd *cobra.Command, args []string) error {
+	format := gnmidiff.Format{
+		Full: viper.GetBool("full"),
+	}
+
+	notifsA, err := gnmiparse.NotifsFromFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	notifsB, err := gnmiparse.NotifsFromFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	diff, err := gnmidiff.DiffNotifications(notifsA, notifsB, viper.GetStringSlice("ignore"), nil)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, diff.Format(format))
+	return nil
+}
@@ -43,6 +43,7 @@ func RootCmd() *cobra.Command {
 
 	rootCmd.AddCommand(newSetRequestDiffCmd())
 	rootCmd.AddCommand(newSetToNotifsDiffCmd())
+	rootCmd.AddCommand(newNotifsDiffCmd())
 
 	return rootCmd
 }
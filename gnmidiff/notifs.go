@@ -0,0 +1,180 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmidiff
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// NotifsDiff contains the difference between the leaves described by two
+// sequences of Notifications, such as those collected from a target by two
+// separate Get or Subscribe runs.
+type NotifsDiff UpdateDiff
+
+// Format outputs the NotifsDiff in human-readable format.
+//
+// NOTE: Do not depend on the output of this being stable.
+func (diff NotifsDiff) Format(f Format) string {
+	f.title = "NotifsDiff"
+	f.aName = "A"
+	f.bName = "B"
+	return StructuredDiff{UpdateDiff: UpdateDiff(diff)}.Format(f)
+}
+
+// DiffNotifications returns a diff between the final state of the leaves
+// described by two sequences of Notifications a and b. Each sequence is
+// applied in order, such that later updates to a leaf overwrite earlier ones,
+// and deletes remove all leaves at or beneath the deleted path.
+//
+// ignore is a set of gNMI path strings whose leaves, along with those of
+// their descendants, are excluded from the diff. Within these paths, a "*"
+// element name or key value matches any name or key value, and keys that are
+// not specified match any key value. This allows leaves that are expected to
+// differ between collection runs, such as counters or timestamps, to be
+// ignored.
+//
+// schema is intended to be provided via the function defined in generated
+// ygot code (e.g. exampleoc.Schema).
+// If schema is not supplied, then any input JSON values MUST conform to the OpenConfig
+// YANG style guidelines. See the following for checking compliance.
+// * https://github.com/openconfig/oc-pyang
+// * https://github.com/openconfig/public/blob/master/doc/openconfig_style_guide.md
+func DiffNotifications(a, b []*gpb.Notification, ignore []string, schema *ytypes.Schema) (NotifsDiff, error) {
+	var masks []*gpb.Path
+	for _, p := range ignore {
+		m, err := ygot.StringToStructuredPath(p)
+		if err != nil {
+			return NotifsDiff{}, fmt.Errorf("gnmidiff: invalid ignore path %q: %v", p, err)
+		}
+		masks = append(masks, m)
+	}
+
+	leavesA, err := notifsLeaves(a, masks, schema)
+	if err != nil {
+		return NotifsDiff{}, fmt.Errorf("DiffNotifications on a: %v", err)
+	}
+	leavesB, err := notifsLeaves(b, masks, schema)
+	if err != nil {
+		return NotifsDiff{}, fmt.Errorf("DiffNotifications on b: %v", err)
+	}
+
+	diff := NotifsDiff{
+		MissingUpdates:    map[string]interface{}{},
+		ExtraUpdates:      map[string]interface{}{},
+		CommonUpdates:     map[string]interface{}{},
+		MismatchedUpdates: map[string]MismatchedUpdate{},
+	}
+	for path, vA := range leavesA {
+		vB, ok := leavesB[path]
+		switch {
+		case !ok:
+			diff.MissingUpdates[path] = vA
+		case reflect.DeepEqual(vA, vB): // leaf-lists cannot be compared directly.
+			diff.CommonUpdates[path] = vA
+		default:
+			diff.MismatchedUpdates[path] = MismatchedUpdate{A: vA, B: vB}
+		}
+	}
+	for path, vB := range leavesB {
+		if _, ok := leavesA[path]; !ok {
+			diff.ExtraUpdates[path] = vB
+		}
+	}
+	return diff, nil
+}
+
+// notifsLeaves returns the leaves that result from applying notifs in order,
+// keyed by their path, excluding those that match any of masks.
+func notifsLeaves(notifs []*gpb.Notification, masks []*gpb.Path, schema *ytypes.Schema) (map[string]interface{}, error) {
+	intent := setRequestIntent{
+		Deletes: map[string]struct{}{},
+		Updates: map[string]interface{}{},
+	}
+	for _, notif := range notifs {
+		prefix, err := prefixStr(notif.Prefix)
+		if err != nil {
+			return nil, fmt.Errorf("gnmidiff: %v", err)
+		}
+		// Deletes within a Notification are processed before its updates.
+		for _, del := range notif.Delete {
+			path, err := fullPathStr(prefix, del)
+			if err != nil {
+				return nil, err
+			}
+			for leaf := range intent.Updates {
+				if leaf == path || strings.HasPrefix(leaf, path+"/") {
+					delete(intent.Updates, leaf)
+				}
+			}
+		}
+		for _, upd := range notif.Update {
+			path, err := fullPathStr(prefix, upd.Path)
+			if err != nil {
+				return nil, err
+			}
+			if err := intent.populateUpdate(path, upd.Val, schema, false); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(masks) == 0 {
+		return intent.Updates, nil
+	}
+	for leaf := range intent.Updates {
+		p, err := ygot.StringToStructuredPath(leaf)
+		if err != nil {
+			return nil, fmt.Errorf("gnmidiff: cannot parse leaf path %q: %v", leaf, err)
+		}
+		for _, m := range masks {
+			if pathMatchesMask(p, m) {
+				delete(intent.Updates, leaf)
+				break
+			}
+		}
+	}
+	return intent.Updates, nil
+}
+
+// pathMatchesMask returns true if path is at or beneath mask, where the
+// element names and key values of mask may be the "*" wildcard, and keys that
+// are not specified in mask match any key value.
+func pathMatchesMask(path, mask *gpb.Path) bool {
+	if len(mask.GetElem()) > len(path.GetElem()) {
+		return false
+	}
+	for i, me := range mask.GetElem() {
+		pe := path.GetElem()[i]
+		if me.GetName() != "*" && me.GetName() != pe.GetName() {
+			return false
+		}
+		for k, mv := range me.GetKey() {
+			if mv == "*" {
+				continue
+			}
+			if pv, ok := pe.GetKey()[k]; !ok || pv != mv {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,178 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmidiff
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// notifUpdate returns a Notification that updates the leaf at path to the
+// string value v.
+func notifUpdate(t *testing.T, path, v string) *gpb.Notification {
+	t.Helper()
+	p, err := ygot.StringToStructuredPath(path)
+	if err != nil {
+		t.Fatalf("cannot parse path %q: %v", path, err)
+	}
+	return &gpb.Notification{
+		Update: []*gpb.Update{{
+			Path: p,
+			Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: v}},
+		}},
+	}
+}
+
+// notifDelete returns a Notification that deletes path.
+func notifDelete(t *testing.T, path string) *gpb.Notification {
+	t.Helper()
+	p, err := ygot.StringToStructuredPath(path)
+	if err != nil {
+		t.Fatalf("cannot parse path %q: %v", path, err)
+	}
+	return &gpb.Notification{Delete: []*gpb.Path{p}}
+}
+
+func TestDiffNotifications(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inA              []*gpb.Notification
+		inB              []*gpb.Notification
+		inIgnore         []string
+		want             NotifsDiff
+		wantErrSubstring string
+	}{{
+		desc: "later updates and deletes are applied",
+		inA: []*gpb.Notification{
+			notifUpdate(t, "/system/state/hostname", "a"),
+			notifUpdate(t, "/system/state/hostname", "b"),
+			notifUpdate(t, "/interfaces/interface[name=eth0]/state/description", "up"),
+			notifUpdate(t, "/interfaces/interface[name=eth1]/state/description", "down"),
+		},
+		inB: []*gpb.Notification{
+			notifUpdate(t, "/system/state/hostname", "c"),
+			notifUpdate(t, "/interfaces/interface[name=eth0]/state/description", "up"),
+			notifUpdate(t, "/interfaces/interface[name=eth1]/state/description", "down"),
+			notifDelete(t, "/interfaces/interface[name=eth1]"),
+			notifUpdate(t, "/interfaces/interface[name=eth2]/state/description", "new"),
+		},
+		want: NotifsDiff{
+			MissingUpdates: map[string]interface{}{
+				"/interfaces/interface[name=eth1]/state/description": "down",
+			},
+			ExtraUpdates: map[string]interface{}{
+				"/interfaces/interface[name=eth2]/state/description": "new",
+			},
+			CommonUpdates: map[string]interface{}{
+				"/interfaces/interface[name=eth0]/state/description": "up",
+			},
+			MismatchedUpdates: map[string]MismatchedUpdate{
+				"/system/state/hostname": {A: "b", B: "c"},
+			},
+		},
+	}, {
+		desc: "ignored paths with wildcards",
+		inA: []*gpb.Notification{
+			notifUpdate(t, "/system/state/hostname", "a"),
+			notifUpdate(t, "/system/state/boot-time", "1"),
+			notifUpdate(t, "/interfaces/interface[name=eth0]/state/counters/in-pkts", "10"),
+			notifUpdate(t, "/interfaces/interface[name=eth0]/state/description", "up"),
+		},
+		inB: []*gpb.Notification{
+			notifUpdate(t, "/system/state/hostname", "a"),
+			notifUpdate(t, "/system/state/boot-time", "2"),
+			notifUpdate(t, "/interfaces/interface[name=eth0]/state/counters/in-pkts", "20"),
+			notifUpdate(t, "/interfaces/interface[name=eth1]/state/counters/in-pkts", "5"),
+			notifUpdate(t, "/interfaces/interface[name=eth0]/state/description", "down"),
+		},
+		inIgnore: []string{
+			"/system/*/boot-time",
+			"/interfaces/interface[name=*]/state/counters",
+		},
+		want: NotifsDiff{
+			MissingUpdates: map[string]interface{}{},
+			ExtraUpdates:   map[string]interface{}{},
+			CommonUpdates: map[string]interface{}{
+				"/system/state/hostname": "a",
+			},
+			MismatchedUpdates: map[string]MismatchedUpdate{
+				"/interfaces/interface[name=eth0]/state/description": {A: "up", B: "down"},
+			},
+		},
+	}, {
+		desc: "ignored path with specific key",
+		inA: []*gpb.Notification{
+			notifUpdate(t, "/interfaces/interface[name=eth0]/state/description", "up"),
+			notifUpdate(t, "/interfaces/interface[name=eth1]/state/description", "up"),
+		},
+		inB: []*gpb.Notification{
+			notifUpdate(t, "/interfaces/interface[name=eth0]/state/description", "down"),
+			notifUpdate(t, "/interfaces/interface[name=eth1]/state/description", "down"),
+		},
+		inIgnore: []string{"/interfaces/interface[name=eth0]"},
+		want: NotifsDiff{
+			MissingUpdates: map[string]interface{}{},
+			ExtraUpdates:   map[string]interface{}{},
+			CommonUpdates:  map[string]interface{}{},
+			MismatchedUpdates: map[string]MismatchedUpdate{
+				"/interfaces/interface[name=eth1]/state/description": {A: "up", B: "down"},
+			},
+		},
+	}, {
+		desc:             "invalid ignore path",
+		inIgnore:         []string{"/interfaces/interface[name=eth0"},
+		wantErrSubstring: "invalid ignore path",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := DiffNotifications(tt.inA, tt.inB, tt.inIgnore, nil)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("DiffNotifications: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("DiffNotifications (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNotifsDiffFormat(t *testing.T) {
+	diff := NotifsDiff{
+		MissingUpdates: map[string]interface{}{
+			"/system/telnet-server/state/enable": false,
+		},
+		MismatchedUpdates: map[string]MismatchedUpdate{
+			"/system/state/hostname": {A: "a", B: "b"},
+		},
+	}
+	want := `NotifsDiff(-A, +B):
+- /system/telnet-server/state/enable: false
+m /system/state/hostname:
+  - "a"
+  + "b"
+`
+	if diff := cmp.Diff(want, diff.Format(Format{})); diff != "" {
+		t.Errorf("Format (-want, +got):\n%s", diff)
+	}
+}
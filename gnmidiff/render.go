@@ -0,0 +1,383 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmidiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/ygot/ygot"
+)
+
+// DiffGoStructs returns the StructuredDiff between the leaves of a and b,
+// which must be GoStructs of the same type. Only the UpdateDiff of the
+// returned diff is populated: leaves that are set only in a are
+// MissingUpdates, those set only in b are ExtraUpdates, and those that are
+// set in both are either CommonUpdates or MismatchedUpdates.
+func DiffGoStructs(a, b ygot.GoStruct) (StructuredDiff, error) {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return StructuredDiff{}, fmt.Errorf("gnmidiff: cannot diff GoStructs of different types %T and %T", a, b)
+	}
+	aLeaves, err := goStructLeaves(a)
+	if err != nil {
+		return StructuredDiff{}, err
+	}
+	bLeaves, err := goStructLeaves(b)
+	if err != nil {
+		return StructuredDiff{}, err
+	}
+
+	diff := StructuredDiff{UpdateDiff: UpdateDiff{
+		MissingUpdates:    map[string]interface{}{},
+		ExtraUpdates:      map[string]interface{}{},
+		CommonUpdates:     map[string]interface{}{},
+		MismatchedUpdates: map[string]MismatchedUpdate{},
+	}}
+	for path, av := range aLeaves {
+		bv, ok := bLeaves[path]
+		switch {
+		case !ok:
+			diff.MissingUpdates[path] = av
+		case reflect.DeepEqual(av, bv):
+			diff.CommonUpdates[path] = av
+		default:
+			diff.MismatchedUpdates[path] = MismatchedUpdate{A: av, B: bv}
+		}
+	}
+	for path, bv := range bLeaves {
+		if _, ok := aLeaves[path]; !ok {
+			diff.ExtraUpdates[path] = bv
+		}
+	}
+	return diff, nil
+}
+
+// goStructLeaves returns the leaves of s, keyed by their path, with their
+// values represented as per RFC7951.
+func goStructLeaves(s ygot.GoStruct) (map[string]interface{}, error) {
+	j, err := ygot.ConstructIETFJSON(s, nil)
+	if err != nil {
+		return nil, fmt.Errorf("gnmidiff: cannot render %T as JSON: %v", s, err)
+	}
+	b, err := json.Marshal(j)
+	if err != nil {
+		return nil, fmt.Errorf("gnmidiff: cannot marshal %T as JSON: %v", s, err)
+	}
+	return flattenOCJSON(b, false)
+}
+
+// RenderFormat specifies how a StructuredDiff is rendered by Unified and
+// HTML.
+type RenderFormat struct {
+	// AName and BName are the names of the two sides of the diff, which
+	// default to "A" and "B".
+	AName, BName string
+	// Context is the number of unchanged lines that are output before and
+	// after each change by Unified.
+	Context int
+}
+
+// names returns the names of the two sides of the diff, with defaults
+// applied.
+func (f RenderFormat) names() (string, string) {
+	a, b := f.AName, f.BName
+	if a == "" {
+		a = "A"
+	}
+	if b == "" {
+		b = "B"
+	}
+	return a, b
+}
+
+// diffLine is a single line of a rendered diff.
+type diffLine struct {
+	// op is ' ' for unchanged lines, '-' for lines only in A, and '+' for
+	// lines only in B.
+	op rune
+	// path is the path that the line relates to.
+	path string
+	// text is the rendered value of the line.
+	text string
+}
+
+// lines returns the lines of the diff, ordered by path. A mismatched
+// update is represented by a '-' line followed by a '+' line, and deletes
+// are represented with the value "<deleted>".
+func (diff StructuredDiff) lines() []*diffLine {
+	var out []*diffLine
+	addUpdates := func(updates map[string]interface{}, op rune) {
+		for path, v := range updates {
+			out = append(out, &diffLine{op: op, path: path, text: fmt.Sprint(formatJSONValue(v))})
+		}
+	}
+	addDeletes := func(deletes map[string]struct{}, op rune) {
+		for path := range deletes {
+			out = append(out, &diffLine{op: op, path: path, text: "<deleted>"})
+		}
+	}
+	addUpdates(diff.CommonUpdates, ' ')
+	addUpdates(diff.MissingUpdates, '-')
+	addUpdates(diff.ExtraUpdates, '+')
+	for path, m := range diff.MismatchedUpdates {
+		out = append(out,
+			&diffLine{op: '-', path: path, text: fmt.Sprint(formatJSONValue(m.A))},
+			&diffLine{op: '+', path: path, text: fmt.Sprint(formatJSONValue(m.B))})
+	}
+	addDeletes(diff.CommonDeletes, ' ')
+	addDeletes(diff.MissingDeletes, '-')
+	addDeletes(diff.ExtraDeletes, '+')
+
+	// Lines for the same path are ordered '-' before '+', such that
+	// mismatched updates are rendered in the conventional order.
+	rank := map[rune]int{' ': 0, '-': 1, '+': 2}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].path != out[j].path {
+			return out[i].path < out[j].path
+		}
+		return rank[out[i].op] < rank[out[j].op]
+	})
+	return out
+}
+
+// Unified renders the diff in the unified diff format, treating each side
+// of the diff as a text file with one "path: value" line for each of its
+// leaves, ordered by path. Changed lines are output in hunks, along with
+// f.Context unchanged lines before and after each change. It returns the
+// empty string if there are no changes.
+//
+// NOTE: Do not depend on the output of this being stable.
+func (diff StructuredDiff) Unified(f RenderFormat) string {
+	aName, bName := f.names()
+	lines := diff.lines()
+
+	var changed []int
+	for i, l := range lines {
+		if l.op != ' ' {
+			changed = append(changed, i)
+		}
+	}
+	if len(changed) == 0 {
+		return ""
+	}
+	ctx := f.Context
+	if ctx < 0 {
+		ctx = 0
+	}
+
+	// aLine and bLine are the numbers of the lines of each side that
+	// precede each line of the diff.
+	aLine, bLine := make([]int, len(lines)+1), make([]int, len(lines)+1)
+	for i, l := range lines {
+		aLine[i+1], bLine[i+1] = aLine[i], bLine[i]
+		if l.op != '+' {
+			aLine[i+1]++
+		}
+		if l.op != '-' {
+			bLine[i+1]++
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", aName, bName)
+	for i := 0; i < len(changed); {
+		start := max(changed[i]-ctx, 0)
+		end := min(changed[i]+ctx+1, len(lines))
+		// Changes whose context overlaps are within the same hunk.
+		for i++; i < len(changed) && changed[i]-ctx <= end; i++ {
+			end = min(changed[i]+ctx+1, len(lines))
+		}
+		aCount, bCount := aLine[end]-aLine[start], bLine[end]-bLine[start]
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(aLine[start], aCount), hunkRange(bLine[start], bCount))
+		for _, l := range lines[start:end] {
+			fmt.Fprintf(&b, "%c%s: %s\n", l.op, l.path, l.text)
+		}
+	}
+	return b.String()
+}
+
+// hunkRange returns the range of a hunk within a unified diff, given the
+// number of lines that precede it and the number of lines within it.
+func hunkRange(before, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", before)
+	}
+	return fmt.Sprintf("%d,%d", before+1, count)
+}
+
+// htmlNode is a node of the tree that is rendered by HTML.
+type htmlNode struct {
+	// name is the path element of the node, including its keys.
+	name string
+	// children are the child nodes, keyed by name.
+	children map[string]*htmlNode
+	// lines are the lines of the diff for the node, if it is a leaf.
+	lines []*diffLine
+	// changed is true if the node, or one of its descendants, has
+	// changed.
+	changed bool
+	// leaves is the number of leaves beneath the node.
+	leaves int
+}
+
+// child returns the child of n named name, creating it if required.
+func (n *htmlNode) child(name string) *htmlNode {
+	if n.children == nil {
+		n.children = map[string]*htmlNode{}
+	}
+	c, ok := n.children[name]
+	if !ok {
+		c = &htmlNode{name: name}
+		n.children[name] = c
+	}
+	return c
+}
+
+// htmlStyle is the style sheet that is included within the output of HTML.
+const htmlStyle = `.gnmidiff ul{list-style:none;padding-left:1.2em;margin:0}
+.gnmidiff{font-family:monospace}
+.gnmidiff .added{background:#e6ffec}
+.gnmidiff .removed{background:#ffebe9}
+.gnmidiff .modified{background:#fff8c5}
+.gnmidiff .unchanged{color:#57606a}
+.gnmidiff del{color:#cf222e}
+.gnmidiff ins{color:#1a7f37;text-decoration:none}
+`
+
+// HTML renders the diff as an HTML fragment containing a tree view of the
+// leaves of the diff, in which branches that contain changes are expanded,
+// and branches that do not are collapsed, using the HTML details element.
+// Added, removed and modified leaves are marked with the added, removed and
+// modified classes, and unchanged leaves with the unchanged class, which
+// are styled by a style sheet within the fragment.
+//
+// NOTE: Do not depend on the output of this being stable.
+func (diff StructuredDiff) HTML(f RenderFormat) string {
+	aName, bName := f.names()
+	root := &htmlNode{}
+	for _, l := range diff.lines() {
+		n := root
+		for _, e := range splitDiffPath(l.path) {
+			n = n.child(e)
+		}
+		n.lines = append(n.lines, l)
+	}
+	countHTMLNode(root)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<div class=\"gnmidiff\">\n<style>\n%s</style>\n", htmlStyle)
+	fmt.Fprintf(&b, "<div>%s</div>\n", html.EscapeString(fmt.Sprintf("(-%s, +%s)", aName, bName)))
+	writeHTMLChildren(&b, root)
+	b.WriteString("</div>\n")
+	return b.String()
+}
+
+// countHTMLNode populates the changed and leaves fields of n and its
+// descendants.
+func countHTMLNode(n *htmlNode) {
+	if len(n.lines) != 0 {
+		n.leaves = 1
+	}
+	for _, l := range n.lines {
+		if l.op != ' ' {
+			n.changed = true
+		}
+	}
+	for _, c := range n.children {
+		countHTMLNode(c)
+		n.leaves += c.leaves
+		n.changed = n.changed || c.changed
+	}
+}
+
+// writeHTMLChildren writes the children of n to b as an HTML list.
+func writeHTMLChildren(b *strings.Builder, n *htmlNode) {
+	var names []string
+	for name := range n.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	b.WriteString("<ul>\n")
+	for _, name := range names {
+		c := n.children[name]
+		esc := html.EscapeString(name)
+		if len(c.lines) != 0 {
+			writeHTMLLeaf(b, esc, c.lines)
+		}
+		if len(c.children) == 0 {
+			continue
+		}
+		if c.changed {
+			fmt.Fprintf(b, "<li><details open><summary>%s</summary>\n", esc)
+		} else {
+			fmt.Fprintf(b, "<li class=\"unchanged\"><details><summary>%s (%d unchanged)</summary>\n", esc, c.leaves)
+		}
+		writeHTMLChildren(b, c)
+		b.WriteString("</details></li>\n")
+	}
+	b.WriteString("</ul>\n")
+}
+
+// writeHTMLLeaf writes the leaf named name, whose diff is lines, to b.
+func writeHTMLLeaf(b *strings.Builder, name string, lines []*diffLine) {
+	var del, ins, same *diffLine
+	for _, l := range lines {
+		switch l.op {
+		case '-':
+			del = l
+		case '+':
+			ins = l
+		default:
+			same = l
+		}
+	}
+	switch {
+	case del != nil && ins != nil:
+		fmt.Fprintf(b, "<li class=\"modified\">%s: <del>%s</del> <ins>%s</ins></li>\n", name, html.EscapeString(del.text), html.EscapeString(ins.text))
+	case del != nil:
+		fmt.Fprintf(b, "<li class=\"removed\">- %s: <del>%s</del></li>\n", name, html.EscapeString(del.text))
+	case ins != nil:
+		fmt.Fprintf(b, "<li class=\"added\">+ %s: <ins>%s</ins></li>\n", name, html.EscapeString(ins.text))
+	case same != nil:
+		fmt.Fprintf(b, "<li class=\"unchanged\">%s: %s</li>\n", name, html.EscapeString(same.text))
+	}
+}
+
+// splitDiffPath splits the string form of a path into its elements,
+// including their keys. Paths that cannot be parsed are split on "/".
+func splitDiffPath(path string) []string {
+	p, err := ygot.StringToStructuredPath(path)
+	if err != nil {
+		return strings.Split(strings.TrimPrefix(path, "/"), "/")
+	}
+	var out []string
+	for _, e := range p.GetElem() {
+		s := e.GetName()
+		var keys []string
+		for k := range e.GetKey() {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			s += fmt.Sprintf("[%s=%s]", k, e.GetKey()[k])
+		}
+		out = append(out, s)
+	}
+	return out
+}
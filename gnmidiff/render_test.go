@@ -0,0 +1,167 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmidiff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// renderTestDevices returns two devices that differ in the MTU of eth0, and
+// in eth1, which is only present in the second.
+func renderTestDevices() (*exampleoc.Device, *exampleoc.Device) {
+	a, b := &exampleoc.Device{}, &exampleoc.Device{}
+	for _, d := range []*exampleoc.Device{a, b} {
+		d.GetOrCreateInterface("eth0").Description = ygot.String("<uplink>")
+		d.GetOrCreateSystem().Hostname = ygot.String("dev")
+	}
+	a.GetInterface("eth0").Mtu = ygot.Uint16(1500)
+	b.GetInterface("eth0").Mtu = ygot.Uint16(9000)
+	b.GetOrCreateInterface("eth1")
+	return a, b
+}
+
+func TestDiffGoStructs(t *testing.T) {
+	a, b := renderTestDevices()
+	got, err := DiffGoStructs(a, b)
+	if err != nil {
+		t.Fatalf("DiffGoStructs: got unexpected error: %v", err)
+	}
+	want := StructuredDiff{UpdateDiff: UpdateDiff{
+		MissingUpdates: map[string]interface{}{},
+		ExtraUpdates: map[string]interface{}{
+			"/interfaces/interface[name=eth1]/config/name": "eth1",
+			"/interfaces/interface[name=eth1]/name":        "eth1",
+		},
+		CommonUpdates: map[string]interface{}{
+			"/interfaces/interface[name=eth0]/config/description": "<uplink>",
+			"/interfaces/interface[name=eth0]/config/name":        "eth0",
+			"/interfaces/interface[name=eth0]/name":               "eth0",
+			"/system/config/hostname":                             "dev",
+		},
+		MismatchedUpdates: map[string]MismatchedUpdate{
+			"/interfaces/interface[name=eth0]/config/mtu": {A: float64(1500), B: float64(9000)},
+		},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DiffGoStructs: (-want, +got):\n%s", diff)
+	}
+
+	if _, err := DiffGoStructs(a, &exampleoc.Interface{}); err == nil {
+		t.Errorf("DiffGoStructs of different types: got nil error, want error")
+	}
+}
+
+func TestUnified(t *testing.T) {
+	a, b := renderTestDevices()
+	goStructDiff, err := DiffGoStructs(a, b)
+	if err != nil {
+		t.Fatalf("DiffGoStructs: got unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		desc     string
+		inDiff   StructuredDiff
+		inFormat RenderFormat
+		want     string
+	}{{
+		desc:     "context",
+		inDiff:   goStructDiff,
+		inFormat: RenderFormat{AName: "running", BName: "candidate", Context: 1},
+		want: `--- running
++++ candidate
+@@ -1,5 +1,7 @@
+ /interfaces/interface[name=eth0]/config/description: "<uplink>"
+-/interfaces/interface[name=eth0]/config/mtu: 1500
++/interfaces/interface[name=eth0]/config/mtu: 9000
+ /interfaces/interface[name=eth0]/config/name: "eth0"
+ /interfaces/interface[name=eth0]/name: "eth0"
++/interfaces/interface[name=eth1]/config/name: "eth1"
++/interfaces/interface[name=eth1]/name: "eth1"
+ /system/config/hostname: "dev"
+`,
+	}, {
+		desc:   "separate hunks without context",
+		inDiff: goStructDiff,
+		want: `--- A
++++ B
+@@ -2,1 +2,1 @@
+-/interfaces/interface[name=eth0]/config/mtu: 1500
++/interfaces/interface[name=eth0]/config/mtu: 9000
+@@ -4,0 +5,2 @@
++/interfaces/interface[name=eth1]/config/name: "eth1"
++/interfaces/interface[name=eth1]/name: "eth1"
+`,
+	}, {
+		desc: "deletes",
+		inDiff: StructuredDiff{
+			DeleteDiff: DeleteDiff{
+				MissingDeletes: map[string]struct{}{"/system/config/hostname": {}},
+			},
+			UpdateDiff: UpdateDiff{
+				ExtraUpdates: map[string]interface{}{"/system/config/domain-name": "example.com"},
+			},
+		},
+		want: `--- A
++++ B
+@@ -1,1 +1,1 @@
++/system/config/domain-name: "example.com"
+-/system/config/hostname: <deleted>
+`,
+	}, {
+		desc: "no changes",
+		inDiff: StructuredDiff{UpdateDiff: UpdateDiff{
+			CommonUpdates: map[string]interface{}{"/system/config/hostname": "dev"},
+		}},
+		want: "",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.inDiff.Unified(tt.inFormat)); diff != "" {
+				t.Errorf("Unified: (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHTML(t *testing.T) {
+	a, b := renderTestDevices()
+	d, err := DiffGoStructs(a, b)
+	if err != nil {
+		t.Fatalf("DiffGoStructs: got unexpected error: %v", err)
+	}
+	got := d.HTML(RenderFormat{AName: "running", BName: "candidate"})
+
+	for _, want := range []string{
+		"(-running, +candidate)",
+		`<li><details open><summary>interface[name=eth0]</summary>`,
+		`<li class="modified">mtu: <del>1500</del> <ins>9000</ins></li>`,
+		`<li class="unchanged">description: &#34;&lt;uplink&gt;&#34;</li>`,
+		`<li class="added">+ name: <ins>&#34;eth1&#34;</ins></li>`,
+		`<li class="unchanged"><details><summary>system (1 unchanged)</summary>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("HTML: did not contain %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "<uplink>") {
+		t.Errorf("HTML: contains unescaped value, got:\n%s", got)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/openconfig/ygot/genutil"
 	"github.com/openconfig/ygot/internal/igenutil"
 	"github.com/openconfig/ygot/util"
 	"github.com/openconfig/ygot/ygen"
@@ -50,6 +51,9 @@ type GoOpts struct {
 	// YtypesImportPath specifies the path to ytypes library that should be used
 	// in the generated code.
 	YtypesImportPath string
+	// YRESTCONFImportPath specifies the path to the yrestconf library that
+	// should be used in the generated code.
+	YRESTCONFImportPath string
 	// GenerateRenameMethod specifies whether methods for renaming list entries
 	// should be generated in the output Go code.
 	GenerateRenameMethod bool
@@ -105,6 +109,17 @@ type GoOpts struct {
 	// IncludeModelData specifies whether gNMI ModelData messages should be generated
 	// in the output code.
 	IncludeModelData bool
+	// IncludeYANGLibrary specifies whether the ietf-yang-library (RFC 8525)
+	// description of the input modules should be generated in the output
+	// code, as the ΓYANGLibraryModules variable, which can be supplied to
+	// ygot.NewYANGLibrary.
+	IncludeYANGLibrary bool
+	// GenerateRESTCONFHandler specifies whether a NewRESTCONFHandler
+	// function should be generated in the output code, which returns a
+	// yrestconf.Handler that serves the data tree of the fake root over
+	// RESTCONF (RFC 8040), storing it using a user-supplied yrestconf.Store.
+	// It requires GenerateJSONSchema and a fake root.
+	GenerateRESTCONFHandler bool
 	// AppendEnumSuffixForSimpleUnionEnums appends an "Enum" suffix to the
 	// enumeration name for simple (i.e. non-typedef) leaves which are
 	// unions with an enumeration inside. This makes all inlined
@@ -123,6 +138,76 @@ type GoOpts struct {
 	// marked `ordered-by user` will be represented using built-in Go maps
 	// instead of an ordered map Go structure.
 	GenerateOrderedListsAsUnorderedMaps bool
+	// PresenceBitmaps specifies that scalar leaves, other than list keys,
+	// should be stored as values rather than pointers within the generated
+	// structs, with whether each leaf is set being recorded in a bitmap
+	// field of its struct. IsSet, Set and Clear methods are generated for
+	// each such leaf, since the field alone cannot indicate whether the
+	// leaf is set.
+	PresenceBitmaps bool
+	// EmptyLeafPresence specifies that the presence of leaves of type
+	// empty should be recorded in a bitmap field of their struct, in the
+	// same way as leaves covered by PresenceBitmaps. This allows a leaf
+	// that is absent to be distinguished from one whose value is false,
+	// such that only leaves that are set are rendered, and unset leaves
+	// are reported as deleted when structs are diffed.
+	EmptyLeafPresence bool
+	// GenerateLeafConstraints specifies whether exported constants should
+	// be generated for the range, length and pattern restrictions of the
+	// leaves of each struct, such that they can be enforced by application
+	// code without the schema being parsed at runtime.
+	GenerateLeafConstraints bool
+	// GenerateUnionLeafListAccessors specifies whether methods should be
+	// generated that return each member of a leaf-list of union type as
+	// each of the types of the union. It is only used when
+	// GenerateSimpleUnions is set to true.
+	GenerateUnionLeafListAccessors bool
+	// GenerateKeyedLists specifies whether keyed lists, other than
+	// `ordered-by user` lists that are generated as ordered maps, should be
+	// represented using the ygot.KeyedList type rather than Go maps, such
+	// that they have consistent helper methods.
+	GenerateKeyedLists bool
+	// GenerateEnumDescriptions specifies whether the description and
+	// status statements of each value of the enumerated types should be
+	// output within the ΛEnum map, such that they are available as the
+	// Description and Status fields of the ygot.EnumDefinition of each
+	// value.
+	GenerateEnumDescriptions bool
+	// GenerateExtensionPoints specifies whether each generated struct should
+	// have a field of type ygot.Extensions, such that the extension structs
+	// of packages generated separately for modules that augment the schema
+	// can be attached to it at runtime.
+	GenerateExtensionPoints bool
+	// ExtensionModules specifies the modules whose nodes augment the schema
+	// of the package at ExtendedPackagePath, which was generated with
+	// extension points. When it is set, structs for the nodes of other
+	// modules are generated as extension structs, which hold only the
+	// fields for the nodes of ExtensionModules and register themselves as
+	// extending the struct of the same name within the extended package,
+	// and are omitted if they have no such fields.
+	ExtensionModules []string
+	// ExtendedPackagePath is the import path of the package extended by the
+	// extension structs generated for ExtensionModules.
+	ExtendedPackagePath string
+	// GenerateDocComments specifies whether the description and reference
+	// statements of YANG nodes should be output as doc comments on the
+	// generated structs, their fields, and enumerated types.
+	GenerateDocComments bool
+	// DocCommentMaxLength specifies the maximum length of the YANG
+	// description that is output within a generated doc comment, longer
+	// descriptions being truncated at a word boundary. If it is zero,
+	// descriptions are not truncated.
+	DocCommentMaxLength int
+}
+
+// docComment returns the doc comment generated from the YANG description and
+// reference supplied, or the empty string if doc comments are not to be
+// generated.
+func (o GoOpts) docComment(description, reference, indent string) string {
+	if !o.GenerateDocComments {
+		return ""
+	}
+	return docComment(description, reference, o.DocCommentMaxLength, indent)
 }
 
 // GeneratedCode contains generated code snippets that can be processed by the calling
@@ -159,6 +244,13 @@ type GeneratedCode struct {
 	RawJSONSchema []byte
 	// EnumTypeMap is a Go map that allows YANG schemapaths to be mapped to reflect.Type values.
 	EnumTypeMap string
+	// LintFindings are the findings of checking the input YANG modules
+	// against the OpenConfig style guidelines, if requested by the Lint
+	// parse option.
+	LintFindings []*ygen.LintFinding
+	// IR is the intermediate representation from which the code was
+	// generated.
+	IR *ygen.IR
 }
 
 // New returns a new instance of the CodeGenerator
@@ -210,6 +302,7 @@ func (cg *CodeGenerator) Generate(yangFiles, includePaths []string) (*GeneratedC
 		NestedDirectories:                   false,
 		AbsoluteMapPaths:                    false,
 		AppendEnumSuffixForSimpleUnionEnums: cg.GoOptions.AppendEnumSuffixForSimpleUnionEnums,
+		PhaseTimer:                          cg.IROptions.PhaseTimer,
 	}
 
 	var codegenErr util.Errors
@@ -217,6 +310,15 @@ func (cg *CodeGenerator) Generate(yangFiles, includePaths []string) (*GeneratedC
 	if err != nil {
 		return nil, util.AppendErr(codegenErr, err)
 	}
+	defer opts.PhaseTimer.Start(genutil.PhaseCodegen)()
+
+	extModules := map[string]bool{}
+	for _, m := range cg.GoOptions.ExtensionModules {
+		extModules[m] = true
+	}
+	if len(extModules) != 0 && (!cg.GoOptions.GenerateJSONSchema || cg.GoOptions.ExtendedPackagePath == "") {
+		return nil, util.AppendErr(codegenErr, fmt.Errorf("generating extension structs requires the schema to be generated and the extended package path to be set"))
+	}
 
 	var rootName string
 	if cg.IROptions.TransformationOptions.GenerateFakeRoot {
@@ -226,9 +328,23 @@ func (cg *CodeGenerator) Generate(yangFiles, includePaths []string) (*GeneratedC
 		}
 		if r, ok := ir.Directories[fmt.Sprintf("/%s", rootName)]; ok {
 			rootName = r.Name
+			// The fake root is omitted from an extension package unless
+			// the extension modules have nodes at the root.
+			if d, _ := extensionDirectory(r, extModules); len(extModules) != 0 && d == nil {
+				rootName = ""
+			}
+		}
+	}
+	if cg.GoOptions.GenerateRESTCONFHandler && (!cg.GoOptions.GenerateJSONSchema || rootName == "") {
+		return nil, util.AppendErr(codegenErr, fmt.Errorf("generating a RESTCONF handler requires the schema and a fake root to be generated"))
+	}
+	var yangLibrary []*ygot.YANGLibraryModule
+	if cg.GoOptions.IncludeYANGLibrary {
+		if yangLibrary, err = ir.YANGLibrary(); err != nil {
+			return nil, util.AppendErr(codegenErr, err)
 		}
 	}
-	commonHeader, oneoffHeader, err := writeGoHeader(yangFiles, includePaths, cg, rootName, ir.ModelData)
+	commonHeader, oneoffHeader, err := writeGoHeader(yangFiles, includePaths, cg, rootName, ir.ModelData, yangLibrary)
 	if err != nil {
 		return nil, util.AppendErr(codegenErr, err)
 	}
@@ -253,6 +369,16 @@ func (cg *CodeGenerator) Generate(yangFiles, includePaths []string) (*GeneratedC
 	for _, directoryPath := range ir.OrderedDirectoryPathsByName() {
 		dir := ir.Directories[directoryPath]
 
+		// When generating extension structs, the structs for nodes that
+		// do not belong to the extension modules hold only the fields
+		// that do.
+		var isExtension bool
+		if len(extModules) != 0 {
+			if dir, isExtension = extensionDirectory(dir, extModules); dir == nil {
+				continue
+			}
+		}
+
 		// Generate structs.
 		if errs := checkForBinaryKeys(dir); len(errs) != 0 {
 			codegenErr = util.AppendErrs(codegenErr, errs)
@@ -263,6 +389,14 @@ func (cg *CodeGenerator) Generate(yangFiles, includePaths []string) (*GeneratedC
 			codegenErr = util.AppendErrs(codegenErr, errs)
 			continue
 		}
+		if isExtension {
+			var buf bytes.Buffer
+			if err := generateExtensionStruct(&buf, structOut.StructName); err != nil {
+				codegenErr = util.AppendErr(codegenErr, err)
+				continue
+			}
+			structOut.Methods += buf.String()
+		}
 		structSnippets = append(structSnippets, structOut)
 
 		// Record down all the enum types we encounter in each field.
@@ -282,7 +416,10 @@ func (cg *CodeGenerator) Generate(yangFiles, includePaths []string) (*GeneratedC
 				usedEnumeratedTypes[field.LangType.NativeType] = true
 				enumTypeMap[schemaPath] = []string{field.LangType.NativeType}
 			case len(field.LangType.UnionTypes) > 1:
-				if definedUnionTypes[field.LangType.NativeType] {
+				// Unions named after their typedef are shared between
+				// fields, so their enumerated types must be recorded for
+				// the schema path of each field.
+				if definedUnionTypes[field.LangType.NativeType] && !opts.TransformationOptions.TypedefUnionNames {
 					continue
 				}
 				definedUnionTypes[field.LangType.NativeType] = true
@@ -310,7 +447,7 @@ func (cg *CodeGenerator) Generate(yangFiles, includePaths []string) (*GeneratedC
 		}
 	}
 
-	processedEnums, err := genGoEnumeratedTypes(ir.Enums)
+	processedEnums, err := genGoEnumeratedTypes(ir.Enums, cg.GoOptions)
 	if err != nil {
 		return nil, append(codegenErr, err)
 	}
@@ -355,6 +492,8 @@ func (cg *CodeGenerator) Generate(yangFiles, includePaths []string) (*GeneratedC
 		JSONSchemaCode: jsonSchema,
 		RawJSONSchema:  rawSchema,
 		EnumTypeMap:    enumTypeMapCode,
+		LintFindings:   ir.LintFindings,
+		IR:             ir,
 	}, nil
 }
 
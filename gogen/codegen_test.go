@@ -294,6 +294,63 @@ func TestSimpleStructs(t *testing.T) {
 			},
 		},
 		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-withlist.formatted-txt"),
+	}, {
+		name:    "presence bitmaps",
+		inFiles: []string{filepath.Join(datapath, "presence-bitmaps.yang")},
+		inConfig: CodeGenerator{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					ShortenEnumLeafNames:                 true,
+					UseDefiningModuleForTypedefEnumNames: true,
+					EnumerationsUseUnderscores:           true,
+				},
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions:    true,
+				GenerateLeafGetters:     true,
+				GenerateLeafSetters:     true,
+				GeneratePopulateDefault: true,
+				PresenceBitmaps:         true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/presence-bitmaps.formatted-txt"),
+	}, {
+		name:    "doc comments",
+		inFiles: []string{filepath.Join(datapath, "doc-comments.yang")},
+		inConfig: CodeGenerator{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					ShortenEnumLeafNames:                 true,
+					UseDefiningModuleForTypedefEnumNames: true,
+					EnumerationsUseUnderscores:           true,
+				},
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+				GenerateDocComments:  true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/doc-comments.formatted-txt"),
+	}, {
+		name:    "bits",
+		inFiles: []string{filepath.Join(datapath, "bits.yang")},
+		inConfig: CodeGenerator{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					ShortenEnumLeafNames:                 true,
+					UseDefiningModuleForTypedefEnumNames: true,
+					EnumerationsUseUnderscores:           true,
+				},
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions:    true,
+				GenerateGetters:         true,
+				GenerateLeafGetters:     true,
+				GenerateLeafSetters:     true,
+				GeneratePopulateDefault: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/bits.formatted-txt"),
 	}, {
 		name:    "OpenConfig schema test - list and associated method (rename, new) - using operational state",
 		inFiles: []string{filepath.Join(datapath, "openconfig-withlist.yang")},
@@ -331,6 +388,27 @@ func TestSimpleStructs(t *testing.T) {
 			},
 		},
 		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-withlist-unordered.formatted-txt"),
+	}, {
+		name:    "OpenConfig schema test - list and associated methods - using keyed lists",
+		inFiles: []string{filepath.Join(datapath, "openconfig-withlist.yang")},
+		inConfig: CodeGenerator{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					CompressBehaviour:                    genutil.PreferIntendedConfig,
+					ShortenEnumLeafNames:                 true,
+					UseDefiningModuleForTypedefEnumNames: true,
+					EnumerationsUseUnderscores:           true,
+				},
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+				GenerateGetters:      true,
+				GenerateDeleteMethod: true,
+				GenerateAppendMethod: true,
+				GenerateKeyedLists:   true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-withlist-keyed.formatted-txt"),
 	}, {
 		name:    "OpenConfig schema test - multi-keyed list key struct name conflict and associated method (rename, new)",
 		inFiles: []string{filepath.Join(datapath, "openconfig-multikey-list-name-conflict.yang")},
@@ -506,6 +584,42 @@ func TestSimpleStructs(t *testing.T) {
 		},
 		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/schema/openconfig-options-compress-fakeroot.formatted-txt"),
 		wantSchemaFile:      filepath.Join(TestRoot, "testdata/schema/openconfig-options-compress-fakeroot-schema.json"),
+	}, {
+		name:    "schema test with fakeroot and RESTCONF handler",
+		inFiles: []string{filepath.Join(TestRoot, "testdata/schema/openconfig-options.yang")},
+		inConfig: CodeGenerator{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					CompressBehaviour:                    genutil.PreferIntendedConfig,
+					GenerateFakeRoot:                     true,
+					ShortenEnumLeafNames:                 true,
+					UseDefiningModuleForTypedefEnumNames: true,
+					EnumerationsUseUnderscores:           true,
+				},
+			},
+			GoOptions: GoOpts{
+				GenerateJSONSchema:      true,
+				GenerateSimpleUnions:    true,
+				GenerateRESTCONFHandler: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/schema/openconfig-options-compress-fakeroot-restconf.formatted-txt"),
+	}, {
+		name:    "RESTCONF handler without fakeroot",
+		inFiles: []string{filepath.Join(TestRoot, "testdata/schema/openconfig-options.yang")},
+		inConfig: CodeGenerator{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					EnumerationsUseUnderscores: true,
+				},
+			},
+			GoOptions: GoOpts{
+				GenerateJSONSchema:      true,
+				GenerateSimpleUnions:    true,
+				GenerateRESTCONFHandler: true,
+			},
+		},
+		wantErrSubstring: "generating a RESTCONF handler requires the schema and a fake root",
 	}, {
 		name:    "schema test with fakeroot and no compression",
 		inFiles: []string{filepath.Join(TestRoot, "testdata/schema/openconfig-options.yang")},
@@ -649,6 +763,85 @@ func TestSimpleStructs(t *testing.T) {
 		name:                "module with empty leaf",
 		inFiles:             []string{filepath.Join(datapath, "empty.yang")},
 		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/empty.formatted-txt"),
+	}, {
+		name:    "module with empty leaf with presence",
+		inFiles: []string{filepath.Join(datapath, "empty.yang")},
+		inConfig: CodeGenerator{
+			GoOptions: GoOpts{
+				GenerateLeafGetters: true,
+				GenerateLeafSetters: true,
+				EmptyLeafPresence:   true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/empty-presence.formatted-txt"),
+	}, {
+		name:    "module with leaf constraints",
+		inFiles: []string{filepath.Join(datapath, "leaf-constraints.yang")},
+		inConfig: CodeGenerator{
+			GoOptions: GoOpts{
+				GenerateLeafConstraints: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/leaf-constraints.formatted-txt"),
+	}, {
+		name:    "module with union leaf-list accessors",
+		inFiles: []string{filepath.Join(datapath, "union-leaflist.yang")},
+		inConfig: CodeGenerator{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions:           true,
+				GenerateUnionLeafListAccessors: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/union-leaflist.formatted-txt"),
+	}, {
+		name:    "module with enum value descriptions",
+		inFiles: []string{filepath.Join(datapath, "enum-descriptions.yang")},
+		inConfig: CodeGenerator{
+			GoOptions: GoOpts{
+				GenerateEnumDescriptions: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/enum-descriptions.formatted-txt"),
+	}, {
+		name:    "module with extension points",
+		inFiles: []string{filepath.Join(datapath, "extension-base.yang")},
+		inConfig: CodeGenerator{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					GenerateFakeRoot: true,
+				},
+			},
+			GoOptions: GoOpts{
+				GenerateExtensionPoints: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/extension-base.formatted-txt"),
+	}, {
+		name:    "module generated as extension structs",
+		inFiles: []string{filepath.Join(datapath, "extension-base.yang"), filepath.Join(datapath, "extension-vendor.yang")},
+		inConfig: CodeGenerator{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					GenerateFakeRoot: true,
+				},
+			},
+			GoOptions: GoOpts{
+				GenerateJSONSchema:  true,
+				ExtensionModules:    []string{"extension-vendor"},
+				ExtendedPackagePath: "example.com/base",
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/extension-vendor.formatted-txt"),
+	}, {
+		name:    "extension structs without the extended package",
+		inFiles: []string{filepath.Join(datapath, "extension-base.yang"), filepath.Join(datapath, "extension-vendor.yang")},
+		inConfig: CodeGenerator{
+			GoOptions: GoOpts{
+				GenerateJSONSchema: true,
+				ExtensionModules:   []string{"extension-vendor"},
+			},
+		},
+		wantErrSubstring: "generating extension structs requires the schema to be generated and the extended package path to be set",
 	}, {
 		name:             "module with excluded modules",
 		inFiles:          []string{filepath.Join(datapath, "excluded-module.yang")},
@@ -1018,6 +1211,22 @@ func TestSimpleStructs(t *testing.T) {
 			},
 		},
 		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-versioned-mod.formatted-txt"),
+	}, {
+		name:    "with yang library",
+		inFiles: []string{filepath.Join(datapath, "yang-library-mod.yang"), filepath.Join(datapath, "yang-library-deviations.yang")},
+		inConfig: CodeGenerator{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					GenerateFakeRoot:           true,
+					EnumerationsUseUnderscores: true,
+				},
+			},
+			GoOptions: GoOpts{
+				IncludeYANGLibrary:   true,
+				GenerateSimpleUnions: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "yang-library.formatted-txt"),
 	}, {
 		name:    "model with deduplicated enums",
 		inFiles: []string{filepath.Join(datapath, "enum-duplication.yang")},
@@ -1137,6 +1346,23 @@ func TestSimpleStructs(t *testing.T) {
 			},
 		},
 		wantErrSubstring: "did not find type for key obsolete-key",
+	}, {
+		name:    "module with flattened enumeration unions and typedef union names",
+		inFiles: []string{filepath.Join(datapath, "union-naming.yang")},
+		inConfig: CodeGenerator{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					FlattenEnumerationUnions:   true,
+					TypedefUnionNames:          true,
+					EnumerationsUseUnderscores: true,
+				},
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions:    true,
+				GeneratePopulateDefault: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/union-naming.formatted-txt"),
 	}}
 
 	for _, tt := range tests {
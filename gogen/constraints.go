@@ -0,0 +1,166 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gogen
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygen"
+)
+
+// generatedLeafConstraint is used to represent the parameters required to
+// generate a constant describing a restriction on the values of a leaf.
+type generatedLeafConstraint struct {
+	// Name is the name of the constant.
+	Name string
+	// Type is the Go type of the constant, or the empty string if the
+	// constant is untyped.
+	Type string
+	// Value is the Go literal that the constant is set to.
+	Value string
+	// Description describes the restriction that the constant represents,
+	// e.g., "minimum value".
+	Description string
+	// Leaf is the name of the field that represents the leaf.
+	Leaf string
+	// Receiver is the name of the struct that the leaf is a member of.
+	Receiver string
+}
+
+var (
+	// goLeafConstraintsTemplate defines a template for the constants that
+	// describe the range, length and pattern restrictions of the leaves of
+	// a struct.
+	goLeafConstraintsTemplate = mustMakeTemplate("leafConstraints", `
+const (
+{{- range $i, $c := . }}
+{{- if $i }}
+{{ end }}
+	// {{ $c.Name }} is the {{ $c.Description }} of the leaf {{ $c.Leaf }}
+	// in the {{ $c.Receiver }} struct.
+	{{ $c.Name }} {{ with $c.Type }}{{ . }} {{ end }}= {{ $c.Value }}
+{{- end }}
+)
+`)
+
+	// rangeConstantTypes is the set of Go types that are used for the
+	// constants describing the range of a leaf. Leaves that are mapped to
+	// other types, such as wrapper types, have untyped constants.
+	rangeConstantTypes = map[string]bool{
+		"int8":    true,
+		"int16":   true,
+		"int32":   true,
+		"int64":   true,
+		"uint8":   true,
+		"uint16":  true,
+		"uint32":  true,
+		"uint64":  true,
+		"float64": true,
+	}
+)
+
+// leafConstraintConstants returns the constants that describe the
+// restrictions on the values of the leaf field, which is named fieldName
+// within the struct named receiver. The constants are named
+// <receiver>_<fieldName>_<restriction>, where restriction is one of Min and
+// Max for the bounds of its range, MinLength and MaxLength for the bounds of
+// its length, and Pattern for its pattern. A leaf with multiple patterns has
+// a constant for each, numbered from Pattern1.
+func leafConstraintConstants(receiver, fieldName string, field *ygen.NodeDetails) []*generatedLeafConstraint {
+	c := field.YANGDetails.Constraints
+	if c == nil {
+		return nil
+	}
+
+	var consts []*generatedLeafConstraint
+	add := func(suffix, typ, value, desc string) {
+		consts = append(consts, &generatedLeafConstraint{
+			Name:        fmt.Sprintf("%s_%s_%s", receiver, fieldName, suffix),
+			Type:        typ,
+			Value:       value,
+			Description: desc,
+			Leaf:        fieldName,
+			Receiver:    receiver,
+		})
+	}
+
+	if len(c.Range) != 0 {
+		var typ string
+		if field.LangType != nil && rangeConstantTypes[field.LangType.NativeType] {
+			typ = field.LangType.NativeType
+		}
+		lo, hi := rangeBounds(c.Range)
+		add("Min", typ, lo.String(), "minimum value")
+		add("Max", typ, hi.String(), "maximum value")
+	}
+
+	if len(c.Length) != 0 {
+		lo, hi := rangeBounds(c.Length)
+		add("MinLength", "", lo.String(), "minimum length")
+		// A length that is unbounded has a maximum of the largest uint64,
+		// which is not usable as an untyped integer constant, and hence is
+		// omitted.
+		if !hi.Equal(yang.FromUint(math.MaxUint64)) {
+			add("MaxLength", "", hi.String(), "maximum length")
+		}
+	}
+
+	for i, p := range c.Patterns {
+		suffix := "Pattern"
+		if len(c.Patterns) > 1 {
+			suffix = fmt.Sprintf("Pattern%d", i+1)
+		}
+		add(suffix, "", goStringLiteral(p), "pattern")
+	}
+
+	return consts
+}
+
+// rangeBounds returns the smallest and largest values within the set of
+// ranges r, which must be non-empty.
+func rangeBounds(r yang.YangRange) (yang.Number, yang.Number) {
+	lo, hi := r[0].Min, r[0].Max
+	for _, v := range r[1:] {
+		if v.Min.Less(lo) {
+			lo = v.Min
+		}
+		if hi.Less(v.Max) {
+			hi = v.Max
+		}
+	}
+	return lo, hi
+}
+
+// goStringLiteral returns s as a Go string literal, using a raw string
+// literal where possible such that regular expressions remain readable.
+func goStringLiteral(s string) string {
+	if !strconv.CanBackquote(s) {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
+
+// generateLeafConstraints generates the constants describing the
+// restrictions on the values of the leaves of a struct.
+func generateLeafConstraints(buf *bytes.Buffer, consts []*generatedLeafConstraint) error {
+	if len(consts) == 0 {
+		return nil
+	}
+	return goLeafConstraintsTemplate.Execute(buf, consts)
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gogen
+
+import (
+	"bytes"
+
+	"github.com/openconfig/ygot/ygen"
+)
+
+var (
+	// goExtensionStructTemplate defines the template for the method that
+	// returns the schema of the node extended by an extension struct, and
+	// for the registration of the extension struct with the struct of the
+	// same name in the extended package.
+	goExtensionStructTemplate = mustMakeTemplate("extensionStruct", `
+// ΛExtensionSchema returns the schema of the node that {{ .StructName }}
+// extends, including the nodes that augment it.
+func (*{{ .StructName }}) ΛExtensionSchema() *yang.Entry {
+	return SchemaTree["{{ .StructName }}"]
+}
+
+func init() {
+	ygot.RegisterExtension(&extended.{{ .StructName }}{}, &{{ .StructName }}{})
+}
+`)
+)
+
+// extensionDirectory returns the directory for which code should be
+// generated in place of dir when generating extension structs for the
+// modules in extModules. If dir belongs to one of extModules, it is
+// returned unmodified. Otherwise, a copy of dir that has only the fields
+// that belong to extModules is returned, with isExtension set to true, or
+// nil if dir has no such fields. The list keys of the copy are removed,
+// since they are fields of the extended struct.
+func extensionDirectory(dir *ygen.ParsedDirectory, extModules map[string]bool) (ext *ygen.ParsedDirectory, isExtension bool) {
+	if extModules[dir.BelongingModule] {
+		return dir, false
+	}
+	fields := map[string]*ygen.NodeDetails{}
+	for name, f := range dir.Fields {
+		if extModules[f.YANGDetails.BelongingModule] {
+			fields[name] = f
+		}
+	}
+	if len(fields) == 0 {
+		return nil, false
+	}
+	d := *dir
+	d.Fields = fields
+	d.ListKeys = nil
+	d.ListKeyYANGNames = nil
+	return &d, true
+}
+
+// generateExtensionStruct generates the methods of the extension struct
+// named structName into buf.
+func generateExtensionStruct(buf *bytes.Buffer, structName string) error {
+	return goExtensionStructTemplate.Execute(buf, struct{ StructName string }{structName})
+}
@@ -117,10 +117,11 @@ func TestGenerateIR(t *testing.T) {
 					IsFakeRoot: true,
 				},
 				"/openconfig-simple/parent": {
-					Name:       "Parent",
-					Type:       ygen.Container,
-					Path:       "/openconfig-simple/parent",
-					SchemaPath: "/parent",
+					Name:        "Parent",
+					Type:        ygen.Container,
+					Path:        "/openconfig-simple/parent",
+					SchemaPath:  "/parent",
+					Description: "I am a parent container\nthat has 4 children.",
 					Fields: map[string]*ygen.NodeDetails{
 						"child": {
 							Name: "Child",
@@ -413,10 +414,11 @@ func TestGenerateIR(t *testing.T) {
 					IsFakeRoot: true,
 				},
 				"/openconfig-simple/parent": {
-					Name:       "Parent",
-					Type:       ygen.Container,
-					Path:       "/openconfig-simple/parent",
-					SchemaPath: "/parent",
+					Name:        "Parent",
+					Type:        ygen.Container,
+					Path:        "/openconfig-simple/parent",
+					SchemaPath:  "/parent",
+					Description: "I am a parent container\nthat has 4 children.",
 					Fields: map[string]*ygen.NodeDetails{
 						"child": {
 							Name: "Child",
@@ -709,10 +711,11 @@ func TestGenerateIR(t *testing.T) {
 					IsFakeRoot: true,
 				},
 				"/openconfig-simple/parent": {
-					Name:       "OpenconfigSimple_Parent",
-					Type:       ygen.Container,
-					Path:       "/openconfig-simple/parent",
-					SchemaPath: "/parent",
+					Name:        "OpenconfigSimple_Parent",
+					Type:        ygen.Container,
+					Path:        "/openconfig-simple/parent",
+					SchemaPath:  "/parent",
+					Description: "I am a parent container\nthat has 4 children.",
 					Fields: map[string]*ygen.NodeDetails{
 						"child": {
 							Name: "Child",
@@ -1433,6 +1436,7 @@ func TestGenerateIR(t *testing.T) {
 								ShadowSchemaPath:  "/model/a/single-key/state/dates",
 								LeafrefTargetPath: "",
 								Description:       "",
+								Constraints:       &ygen.LeafConstraints{Range: yang.YangRange{{Min: yang.FromInt(1), Max: yang.FromInt(5)}}},
 							},
 							Type:                    ygen.LeafListNode,
 							LangType:                &ygen.MappedType{NativeType: "uint8", ZeroValue: "0", DefaultValue: ygot.String("[]uint8{5}")},
@@ -1454,6 +1458,7 @@ func TestGenerateIR(t *testing.T) {
 								ShadowSchemaPath:  "/model/a/single-key/state/dates-with-defaults",
 								LeafrefTargetPath: "",
 								Description:       "",
+								Constraints:       &ygen.LeafConstraints{Range: yang.YangRange{{Min: yang.FromInt(1), Max: yang.FromInt(5)}}},
 							},
 							Type:                    ygen.LeafListNode,
 							LangType:                &ygen.MappedType{NativeType: "uint8", ZeroValue: "0", DefaultValue: ygot.String("[]uint8{1, 2}")},
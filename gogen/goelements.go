@@ -35,6 +35,9 @@ const (
 	// Go code, such that an enumeration's name is of the form
 	//   <goEnumPrefix><EnumName>
 	goEnumPrefix string = "E_"
+	// goBitsTypeName is the name of the type that is used for YANG bits
+	// fields in the output Go code.
+	goBitsTypeName string = "ygot." + ygot.BitsTypeName
 )
 
 // unionConversionSpec stores snippets that convert primitive Go types to
@@ -103,6 +106,7 @@ var (
 		"interface{}":       "nil",
 		ygot.BinaryTypeName: "nil",
 		ygot.EmptyTypeName:  "false",
+		goBitsTypeName:      "nil",
 	}
 
 	// unionConversionSnippets stores the valid primitive types that the Go
@@ -255,7 +259,7 @@ func (s *GoLangMapper) FieldName(e *yang.Entry) (string, error) {
 // LeafType maps the input leaf entry to a MappedType object containing the
 // type information about the field.
 func (s *GoLangMapper) LeafType(e *yang.Entry, opts ygen.IROptions) (*ygen.MappedType, error) {
-	mtype, err := s.yangTypeToGoType(resolveTypeArgs{yangType: e.Type, contextEntry: e}, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), opts.TransformationOptions.SkipEnumDeduplication, opts.TransformationOptions.ShortenEnumLeafNames, opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames, opts.TransformationOptions.EnumOrgPrefixesToTrim)
+	mtype, err := s.yangTypeToGoType(resolveTypeArgs{yangType: e.Type, contextEntry: e}, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), opts.TransformationOptions.SkipEnumDeduplication, opts.TransformationOptions.ShortenEnumLeafNames, opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames, opts.TransformationOptions.TypedefUnionNames, opts.TransformationOptions.EnumOrgPrefixesToTrim)
 	if err != nil {
 		return nil, err
 	}
@@ -272,7 +276,7 @@ func (s *GoLangMapper) LeafType(e *yang.Entry, opts ygen.IROptions) (*ygen.Mappe
 // LeafType maps the input list key entry to a MappedType object containing the
 // type information about the key field.
 func (s *GoLangMapper) KeyLeafType(e *yang.Entry, opts ygen.IROptions) (*ygen.MappedType, error) {
-	return s.yangTypeToGoType(resolveTypeArgs{yangType: e.Type, contextEntry: e}, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), opts.TransformationOptions.SkipEnumDeduplication, opts.TransformationOptions.ShortenEnumLeafNames, opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames, opts.TransformationOptions.EnumOrgPrefixesToTrim)
+	return s.yangTypeToGoType(resolveTypeArgs{yangType: e.Type, contextEntry: e}, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), opts.TransformationOptions.SkipEnumDeduplication, opts.TransformationOptions.ShortenEnumLeafNames, opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames, opts.TransformationOptions.TypedefUnionNames, opts.TransformationOptions.EnumOrgPrefixesToTrim)
 }
 
 // PackageName is not used by Go generation.
@@ -291,7 +295,7 @@ func (s *GoLangMapper) PackageName(*yang.Entry, genutil.CompressBehaviour, bool)
 // The skipEnumDedup argument specifies whether leaves of type enumeration that are
 // used more than once in the schema should share a common type. By default, a single
 // type for each leaf is created.
-func (s *GoLangMapper) yangTypeToGoType(args resolveTypeArgs, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames bool, enumOrgPrefixesToTrim []string) (*ygen.MappedType, error) {
+func (s *GoLangMapper) yangTypeToGoType(args resolveTypeArgs, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, typedefUnionNames bool, enumOrgPrefixesToTrim []string) (*ygen.MappedType, error) {
 	defVal := genutil.TypeDefaultValue(args.yangType)
 	// Handle the case of a typedef which is actually an enumeration.
 	typedefName, _, isTypedef, err := s.EnumeratedTypedefTypeName(args.yangType, args.contextEntry, goEnumPrefix, false, useDefiningModuleForTypedefEnumNames)
@@ -344,7 +348,7 @@ func (s *GoLangMapper) yangTypeToGoType(args resolveTypeArgs, compressOCPaths, s
 	case yang.Yunion:
 		// A YANG Union is a leaf that can take multiple values - its subtypes need
 		// to be extracted.
-		return s.goUnionType(args, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim)
+		return s.goUnionType(args, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, typedefUnionNames, enumOrgPrefixesToTrim)
 	case yang.Yenum:
 		// Enumeration types need to be resolved to a particular data path such
 		// that a created enumerated Go type can be used to set their value. Hand
@@ -388,7 +392,7 @@ func (s *GoLangMapper) yangTypeToGoType(args resolveTypeArgs, compressOCPaths, s
 		if err != nil {
 			return nil, err
 		}
-		mtype, err := s.yangTypeToGoType(resolveTypeArgs{yangType: target.Type, contextEntry: target}, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim)
+		mtype, err := s.yangTypeToGoType(resolveTypeArgs{yangType: target.Type, contextEntry: target}, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, typedefUnionNames, enumOrgPrefixesToTrim)
 		if err != nil {
 			return nil, err
 		}
@@ -398,11 +402,13 @@ func (s *GoLangMapper) yangTypeToGoType(args resolveTypeArgs, compressOCPaths, s
 		// this is used to ensure that we can distinguish a binary field from
 		// a leaf-list of uint8s which is not possible if mapping to []byte.
 		return &ygen.MappedType{NativeType: ygot.BinaryTypeName, ZeroValue: goZeroValues[ygot.BinaryTypeName], DefaultValue: defVal}, nil
+	case yang.Ybits:
+		// Map bits fields to the Bits type defined in the ygot package,
+		// which stores the set of the names of the bits that are set.
+		return &ygen.MappedType{NativeType: goBitsTypeName, ZeroValue: goZeroValues[goBitsTypeName], DefaultValue: defVal}, nil
 	default:
 		// Return an empty interface for the types that we do not currently
 		// support. Back-end validation is required for these types.
-		// TODO(robjs): Missing types currently bits. These
-		// should be added.
 		return &ygen.MappedType{NativeType: "interface{}", ZeroValue: goZeroValues["interface{}"]}, nil
 	}
 }
@@ -441,8 +447,36 @@ func (s *GoLangMapper) yangTypeToGoType(args resolveTypeArgs, compressOCPaths, s
 // to use a common type for enumerations that are logically defined once in the schema
 // but used in multiple places.
 //
+// The typedefUnionNames argument specifies that a union defined by a typedef
+// is named after the typedef, e.g., Module_Typedef_Union, such that all leaves
+// using the typedef share a type. Where the union has members whose mapped
+// types may differ between the leaves that use it, i.e., leafrefs, or inline
+// enumerations that are not named after the typedef, the name is derived from
+// the path of the element.
+//
+// Where the union consists only of enumerations that were merged into a single
+// enumerated type by the FlattenEnumerationUnions transformation, the merged
+// type is returned.
+//
 // goUnionType returns an error if mapping is not possible.
-func (s *GoLangMapper) goUnionType(args resolveTypeArgs, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames bool, enumOrgPrefixesToTrim []string) (*ygen.MappedType, error) {
+func (s *GoLangMapper) goUnionType(args resolveTypeArgs, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, typedefUnionNames bool, enumOrgPrefixesToTrim []string) (*ygen.MappedType, error) {
+	if args.contextEntry != nil && args.yangType == args.contextEntry.Type {
+		n, _, ok, err := s.FlattenedEnumUnionName(args.contextEntry, compressOCPaths, false, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			nativeType := fmt.Sprintf("%s%s", goEnumPrefix, n)
+			return &ygen.MappedType{
+				NativeType:        nativeType,
+				UnionTypes:        map[string]ygen.MappedUnionSubtype{nativeType: {Index: 0}},
+				IsEnumeratedValue: true,
+				ZeroValue:         "0",
+				DefaultValue:      genutil.TypeDefaultValue(args.yangType),
+			}, nil
+		}
+	}
+
 	var errs []error
 	unionMappedTypes := make(map[int]*ygen.MappedType)
 
@@ -452,15 +486,20 @@ func (s *GoLangMapper) goUnionType(args resolveTypeArgs, compressOCPaths, skipEn
 	// check, rather than iterating the slice of strings.
 	unionTypes := make(map[string]ygen.MappedUnionSubtype)
 	for _, subtype := range args.yangType.Type {
-		errs = append(errs, s.goUnionSubTypes(subtype, args.contextEntry, unionTypes, unionMappedTypes, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim)...)
+		errs = append(errs, s.goUnionSubTypes(subtype, args.contextEntry, unionTypes, unionMappedTypes, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, typedefUnionNames, enumOrgPrefixesToTrim)...)
 	}
 
 	if errs != nil {
 		return nil, fmt.Errorf("errors mapping element: %v", errs)
 	}
 
+	unionName := pathToCamelCaseName(args.contextEntry, compressOCPaths)
+	if typedefUnionNames && isSharedTypedefUnion(args.yangType, useDefiningModuleForTypedefEnumNames) {
+		unionName = fmt.Sprintf("%s_%s", genutil.ParentModulePrettyName(args.yangType.Base), yang.CamelCase(args.yangType.Name))
+	}
+
 	resolvedType := &ygen.MappedType{
-		NativeType: fmt.Sprintf("%s_Union", pathToCamelCaseName(args.contextEntry, compressOCPaths)),
+		NativeType: fmt.Sprintf("%s_Union", unionName),
 		// Zero value is set to nil, other than in cases where there is
 		// a single type in the union.
 		ZeroValue:    "nil",
@@ -476,6 +515,26 @@ func (s *GoLangMapper) goUnionType(args resolveTypeArgs, compressOCPaths, skipEn
 	return resolvedType, nil
 }
 
+// isSharedTypedefUnion reports whether t is a union defined by a typedef whose
+// members are mapped to the same Go types for every leaf that uses it, such
+// that the leaves can share a single union type named after the typedef.
+// Inline enumerations are only named after the typedef, rather than the leaf,
+// when useDefiningModuleForTypedefEnumNames is set.
+func isSharedTypedefUnion(t *yang.YangType, useDefiningModuleForTypedefEnumNames bool) bool {
+	if util.IsYANGBaseType(t) || t.Base == nil {
+		return false
+	}
+	for _, m := range util.FlattenedTypes(t.Type) {
+		switch {
+		case m.Kind == yang.Yleafref:
+			return false
+		case m.Kind == yang.Yenum && util.IsYANGBaseType(m) && !useDefiningModuleForTypedefEnumNames:
+			return false
+		}
+	}
+	return true
+}
+
 // goUnionSubTypes extracts all the possible subtypes of a YANG union leaf,
 // returning any errors that occur. In case of nested unions, the entire union
 // is flattened, and identical types are de-duped. currentTypes keeps track of
@@ -486,13 +545,13 @@ func (s *GoLangMapper) goUnionType(args resolveTypeArgs, compressOCPaths, skipEn
 // The skipEnumDedup argument specifies whether the current code generation is
 // de-duplicating enumerations where they are used in more than one place in
 // the schema.
-func (s *GoLangMapper) goUnionSubTypes(subtype *yang.YangType, ctx *yang.Entry, currentTypes map[string]ygen.MappedUnionSubtype, unionMappedTypes map[int]*ygen.MappedType, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames bool, enumOrgPrefixesToTrim []string) []error {
+func (s *GoLangMapper) goUnionSubTypes(subtype *yang.YangType, ctx *yang.Entry, currentTypes map[string]ygen.MappedUnionSubtype, unionMappedTypes map[int]*ygen.MappedType, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, typedefUnionNames bool, enumOrgPrefixesToTrim []string) []error {
 	var errs []error
 	// If subtype.Type is not empty then this means that this type is defined to
 	// be a union itself.
 	if subtype.Type != nil {
 		for _, st := range subtype.Type {
-			errs = append(errs, s.goUnionSubTypes(st, ctx, currentTypes, unionMappedTypes, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim)...)
+			errs = append(errs, s.goUnionSubTypes(st, ctx, currentTypes, unionMappedTypes, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, typedefUnionNames, enumOrgPrefixesToTrim)...)
 		}
 		return errs
 	}
@@ -501,6 +560,10 @@ func (s *GoLangMapper) goUnionSubTypes(subtype *yang.YangType, ctx *yang.Entry,
 
 	var mtype *ygen.MappedType
 	switch subtype.Kind {
+	case yang.Ybits:
+		// Bits are not supported as union subtypes, and hence are mapped
+		// to the type used for unsupported types.
+		mtype = &ygen.MappedType{NativeType: "interface{}", ZeroValue: goZeroValues["interface{}"]}
 	case yang.Yidentityref:
 		// Handle the specific case that the context entry is now not the correct entry
 		// to map enumerated types to their module. This occurs in the case that the subtype
@@ -520,7 +583,7 @@ func (s *GoLangMapper) goUnionSubTypes(subtype *yang.YangType, ctx *yang.Entry,
 	default:
 		var err error
 
-		mtype, err = s.yangTypeToGoType(resolveTypeArgs{yangType: contextType, contextEntry: ctx}, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim)
+		mtype, err = s.yangTypeToGoType(resolveTypeArgs{yangType: contextType, contextEntry: ctx}, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, typedefUnionNames, enumOrgPrefixesToTrim)
 		if err != nil {
 			errs = append(errs, err)
 			return errs
@@ -703,6 +766,18 @@ func (s *GoLangMapper) yangDefaultValueToGo(value string, args resolveTypeArgs,
 		}
 		value := fmt.Sprintf(ygot.BinaryTypeName+"(%q)", value)
 		return value, ykind, nil
+	case yang.Ybits:
+		bits := ygot.ParseBits(value)
+		for _, name := range bits {
+			if !args.yangType.Bit.IsDefined(name) {
+				return "", yang.Ynone, fmt.Errorf("default value conversion: bit %q not found in bits with type name %q", name, args.yangType.Name)
+			}
+		}
+		var quoted []string
+		for _, name := range bits {
+			quoted = append(quoted, strconv.Quote(name))
+		}
+		return fmt.Sprintf("%s{%s}", goBitsTypeName, strings.Join(quoted, ", ")), ykind, nil
 	case yang.Ystring:
 		if err := ytypes.ValidateStringRestrictions(args.yangType, value); err != nil {
 			return "", yang.Ynone, fmt.Errorf("default value conversion: %q doesn't match string restrictions: %v", value, err)
@@ -762,6 +837,25 @@ func (s *GoLangMapper) yangDefaultValueToGo(value string, args resolveTypeArgs,
 		}
 		return s.yangDefaultValueToGo(value, resolveTypeArgs{yangType: target.Type, contextEntry: target}, isSingletonUnion, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim)
 	case yang.Yunion:
+		// A union of enumerations that was flattened is converted to the
+		// value of the merged enumerated type.
+		if args.contextEntry != nil && args.yangType == args.contextEntry.Type {
+			n, _, ok, err := s.FlattenedEnumUnionName(args.contextEntry, compressOCPaths, false, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim)
+			if err != nil {
+				return "", yang.Ynone, err
+			}
+			if ok {
+				if strings.Contains(value, ":") {
+					value = strings.Split(value, ":")[1]
+				}
+				for _, t := range util.FlattenedTypes(args.yangType.Type) {
+					if t.Enum.IsDefined(value) {
+						return enumDefaultValue(n, value, ""), yang.Yenum, nil
+					}
+				}
+				return "", yang.Ynone, fmt.Errorf("default value conversion: enum value %q not found in union of enumerations with type name %q", value, args.yangType.Name)
+			}
+		}
 		// Try to convert to each type in order, but try the enumerated types first.
 		for _, t := range util.FlattenedTypes(args.yangType.Type) {
 			snippet, convertedKind, err := s.yangDefaultValueToGo(value, resolveTypeArgs{yangType: t, contextEntry: args.contextEntry}, isSingletonUnion, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim)
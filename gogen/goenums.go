@@ -30,6 +30,7 @@ type goEnumeratedType struct {
 	Name       string
 	CodeValues map[int64]string
 	YANGValues map[int64]ygot.EnumDefinition
+	Doc        string
 }
 
 // enumGeneratedCode contains generated Go code for enumerated types.
@@ -39,8 +40,9 @@ type enumGeneratedCode struct {
 }
 
 // genGoEnumeratedTypes converts the input map of EnumeratedYANGType objects to
-// another intermediate representation suitable for Go code generation.
-func genGoEnumeratedTypes(enums map[string]*ygen.EnumeratedYANGType) (map[string]*goEnumeratedType, error) {
+// another intermediate representation suitable for Go code generation. The
+// doc comments of the enumerated types are generated according to goOpts.
+func genGoEnumeratedTypes(enums map[string]*ygen.EnumeratedYANGType, goOpts GoOpts) (map[string]*goEnumeratedType, error) {
 	et := map[string]*goEnumeratedType{}
 	for _, e := range enums {
 		// initialised to be UNSET, such that it is possible to determine that the enumerated value
@@ -61,6 +63,9 @@ func genGoEnumeratedTypes(enums map[string]*ygen.EnumeratedYANGType) (map[string
 		case ygen.IdentityType, ygen.SimpleEnumerationType, ygen.DerivedEnumerationType, ygen.UnionEnumerationType, ygen.DerivedUnionEnumerationType:
 			for _, v := range e.ValToYANGDetails {
 				values[int64(v.Value)+1] = safeGoEnumeratedValueName(v.Name)
+				if !goOpts.GenerateEnumDescriptions {
+					v.Description, v.Status = "", ""
+				}
 				origValues[int64(v.Value)+1] = v
 			}
 		default:
@@ -71,6 +76,7 @@ func genGoEnumeratedTypes(enums map[string]*ygen.EnumeratedYANGType) (map[string
 			Name:       e.Name,
 			CodeValues: values,
 			YANGValues: origValues,
+			Doc:        goOpts.docComment(e.Description, e.Reference, ""),
 		}
 	}
 	return et, nil
@@ -125,6 +131,7 @@ func writeGoEnum(inputEnum *goEnumeratedType) (string, error) {
 	if err := goEnumDefinitionTemplate.Execute(&buf, generatedGoEnumeration{
 		EnumerationPrefix: inputEnum.Name,
 		Values:            inputEnum.CodeValues,
+		Doc:               inputEnum.Doc,
 	}); err != nil {
 		return "", err
 	}
@@ -107,7 +107,7 @@ func TestGenGoEnumeratedTypes(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := genGoEnumeratedTypes(tt.in)
+			got, err := genGoEnumeratedTypes(tt.in, GoOpts{})
 			if err != nil {
 				t.Errorf("%s: genGoEnumeratedTypes(%v): got unexpected error: %v",
 					tt.name, tt.in, err)
@@ -47,6 +47,9 @@ const (
 	// annotationFieldType defines the type that should be used for the
 	// annotation/metadata fields within each struct when they are generated.
 	annotationFieldType string = "[]ygot.Annotation"
+	// presenceWordBits is the number of bits within each element of the
+	// presence bitmap field of a struct generated with presence bitmaps.
+	presenceWordBits = 64
 )
 
 // The methods in this file take the structs that have been generated by
@@ -188,6 +191,27 @@ type goStructField struct {
 	// in templates to determine whether GetXXX methods should be created using
 	// the base template.
 	IsYANGList bool
+	// Doc is the doc comment of the field, generated from the description
+	// and reference of its YANG node.
+	Doc string
+}
+
+// generatedPresenceLeaf is used to represent the parameters required to
+// generate the IsSet, Set and Clear methods of a leaf whose presence is
+// recorded in the presence bitmap of its struct.
+type generatedPresenceLeaf struct {
+	// Name is the name of the field.
+	Name string
+	// Type is the type of the field.
+	Type string
+	// Receiver is the name of the struct that the field is a member of.
+	Receiver string
+	// Word is the index of the element of the presence bitmap that stores
+	// the presence of the leaf.
+	Word int
+	// Bit is the index of the bit within Word that records the presence
+	// of the leaf.
+	Bit int
 }
 
 // goUnionInterface contains a definition of an interface that should
@@ -209,6 +233,7 @@ type generatedGoStruct struct {
 	YANGPath        string           // YANGPath is the schema path of the struct being output.
 	Fields          []*goStructField // Fields is the slice of fields of the struct, described as goStructField structs.
 	BelongingModule string           // BelongingModule is the module in which namespace the GoStruct belongs.
+	Doc             string           // Doc is the doc comment generated from the description and reference of the struct's YANG node.
 }
 
 // yangFieldMap maps a YANG identifier to its Go identifier.
@@ -236,6 +261,9 @@ type generatedGoEnumeration struct {
 	// enumerated type. The numeric value may be explicitly assigned by the schema,
 	// or populated by goyang during the parsing of the module.
 	Values map[int64]string
+	// Doc is the doc comment generated from the description and reference
+	// of the YANG node that defines the enumerated type.
+	Doc string
 }
 
 // generatedLeafGetter is used to represent the parameters required to generate a
@@ -254,6 +282,10 @@ type generatedLeafGetter struct {
 	// IsPtr stores whether the value is a pointer, such that it can be checked
 	// against nil, or against the zero value.
 	IsPtr bool
+	// HasPresence stores whether the presence of the leaf is recorded in the
+	// presence bitmap of its struct, such that it is checked using the
+	// generated IsSet method.
+	HasPresence bool
 	// Receiver is the name of the receiver for the getter method.
 	Receiver string
 }
@@ -268,6 +300,10 @@ type generatedLeafSetter struct {
 	Type string
 	// IsPtr stores whether the value is a pointer.
 	IsPtr bool
+	// HasPresence stores whether the presence of the leaf is recorded in the
+	// presence bitmap of its struct, such that its setter is generated along
+	// with its other presence methods.
+	HasPresence bool
 	// Receiver is the name of the receiver for the setter method.
 	Receiver string
 }
@@ -322,6 +358,9 @@ import (
 	"reflect"
 
 	"{{ .GoOptions.YgotImportPath }}"
+{{- if .GoOptions.ExtendedPackagePath }}
+	extended "{{ .GoOptions.ExtendedPackagePath }}"
+{{- end }}
 
 {{- if .GenerateSchema }}
 	"{{ .GoOptions.GoyangImportPath }}"
@@ -330,7 +369,16 @@ import (
 {{- if .GoOptions.IncludeModelData }}
 	gpb "{{ .GoOptions.GNMIProtoPath }}"
 {{- end }}
+{{- if .GoOptions.GenerateRESTCONFHandler }}
+	"{{ .GoOptions.YRESTCONFImportPath }}"
+{{- end }}
 )
+{{- if .GoOptions.ExtendedPackagePath }}
+
+// The extended package is referenced such that it is imported by each file
+// of the package, regardless of whether the file has extension structs.
+var _ extended.{{ .EmptyTypeName }}
+{{- end }}
 `)
 
 	// goOneOffHeaderTemplate defines the template for package code that should
@@ -419,6 +467,21 @@ func Schema() (*ytypes.Schema, error) {
 	}, nil
 }
 
+{{- if .GoOptions.GenerateRESTCONFHandler }}
+
+// NewRESTCONFHandler returns a yrestconf.Handler, which is an http.Handler,
+// that serves the data tree of the generated schema as the RESTCONF (RFC 8040)
+// datastore. The data tree is loaded from, and committed to, store, which is
+// implemented by the user.
+func NewRESTCONFHandler(store yrestconf.Store, opts ...yrestconf.HandlerOpt) (*yrestconf.Handler, error) {
+	s, err := Schema()
+	if err != nil {
+		return nil, err
+	}
+	return yrestconf.NewHandler(s, store, opts...)
+}
+{{- end }}
+
 // UnzipSchema unzips the zipped schema and returns a map of yang.Entry nodes,
 // keyed by the name of the struct that the yang.Entry describes the schema for.
 func UnzipSchema() (map[string]*yang.Entry, error) {
@@ -468,6 +531,38 @@ var ΓModelData = []*gpb.ModelData{
 {{- end }}
 }
 {{- end }}
+
+{{- if .GoOptions.IncludeYANGLibrary }}
+// ΓYANGLibraryModules contains the ietf-yang-library (RFC 8525) description of
+// the modules for which Go code was generated. It can be supplied to
+// ygot.NewYANGLibrary to construct the YANG library served by a device.
+var ΓYANGLibraryModules = []*ygot.YANGLibraryModule{
+{{- range $idx, $module := .YANGLibrary }}
+	{
+		Name: ygot.String({{ printf "%q" $module.Name }}),
+		{{- with $module.Namespace }}
+		Namespace: ygot.String({{ printf "%q" . }}),
+		{{- end }}
+		{{- with $module.Revision }}
+		Revision: ygot.String({{ printf "%q" . }}),
+		{{- end }}
+		{{- with $module.Feature }}
+		Feature: []string{ {{- range $i, $f := . }}{{ if $i }}, {{ end }}{{ printf "%q" $f }}{{ end -}} },
+		{{- end }}
+		{{- with $module.Deviation }}
+		Deviation: []string{ {{- range $i, $d := . }}{{ if $i }}, {{ end }}{{ printf "%q" $d }}{{ end -}} },
+		{{- end }}
+		{{- with $module.Submodule }}
+		Submodule: map[string]*ygot.YANGLibrarySubmodule{
+		{{- range $sub := . }}
+			{{ printf "%q" $sub.Name }}: {Name: ygot.String({{ printf "%q" $sub.Name }}){{ with $sub.Revision }}, Revision: ygot.String({{ printf "%q" . }}){{ end }}},
+		{{- end }}
+		},
+		{{- end }}
+	},
+{{- end }}
+}
+{{- end }}
 `)
 
 	// goStructTemplate takes an input generatedGoStruct, which contains a definition of
@@ -479,8 +574,15 @@ var ΓModelData = []*gpb.ModelData{
 	// structs; and containers are mapped into structs.
 	goStructTemplate = mustMakeTemplate("struct", `
 // {{ .StructName }} represents the {{ .YANGPath }} YANG schema element.
+{{- if .Doc }}
+//
+{{ .Doc }}
+{{- end }}
 type {{ .StructName }} struct {
 {{- range $idx, $field := .Fields }}
+	{{- if $field.Doc }}
+	{{ $field.Doc }}
+	{{- end }}
 	{{- if $field.IsScalarField }}
 	{{ $field.Name }}	*{{ $field.Type }}	`+"`"+`{{ $field.Tags }}`+"`"+`
 	{{- else }}
@@ -555,6 +657,10 @@ func (t *{{ .StructName }}) GetOrCreate{{ .Field.Name }}() {{ .Field.Type }} {
 // {{ .EnumerationPrefix }}_UNSET is added to the enumeration which is used as
 // the nil value, indicating that the enumeration was not explicitly set by
 // the program importing the generated structures.
+{{- if .Doc }}
+//
+{{ .Doc }}
+{{- end }}
 type E_{{ .EnumerationPrefix }} int64
 
 // IsYANGGoEnum ensures that {{ .EnumerationPrefix }} implements the yang.GoEnum
@@ -590,9 +696,13 @@ const (
 // unset. If the caller explicitly does not care if {{ .Name }} is set, it can
 // safely use t.Get{{ .Name }}() to retrieve the value. In the case that the
 // caller has different actions based on whether the leaf is set or unset, it
-// should use 'if t.{{ .Name }} == nil' before retrieving the leaf's value.
+// should use {{ if .HasPresence -}} 'if !t.IsSet{{ .Name }}()' {{- else -}} 'if t.{{ .Name }} == nil' {{- end }} before retrieving the leaf's value.
 func (t *{{ .Receiver }}) Get{{ .Name }}() {{ .Type }} {
+	{{- if .HasPresence }}
+	if !t.IsSet{{ .Name }}() {
+	{{- else }}
 	if t == nil || t.{{ .Name }} == {{ if .IsPtr -}} nil {{- else }} {{ .Zero }} {{- end }} {
+	{{- end }}
 		{{- if .Default }}
 		return {{ .Default }}
 		{{- else }}
@@ -611,6 +721,32 @@ func (t *{{ .Receiver }}) Get{{ .Name }}() {{ .Type }} {
 func (t *{{ .Receiver }}) Set{{ .Name }}(v {{ .Type }}) {
 	t.{{ .Name }} = {{ if .IsPtr -}} & {{- end -}} v
 }
+`)
+
+	// goPresenceLeafTemplate defines a template for the methods that, for a
+	// particular leaf whose presence is recorded in the presence bitmap of
+	// its struct, check whether the leaf is set, set it, and clear it.
+	goPresenceLeafTemplate = mustMakeTemplate("presenceLeaf", `
+// IsSet{{ .Name }} returns true if the leaf {{ .Name }} of the {{ .Receiver }}
+// struct is set.
+func (t *{{ .Receiver }}) IsSet{{ .Name }}() bool {
+	return t != nil && t.ΛPresence[{{ .Word }}]&(1<<{{ .Bit }}) != 0
+}
+
+// Set{{ .Name }} sets the value of the leaf {{ .Name }} in the {{ .Receiver }}
+// struct.
+func (t *{{ .Receiver }}) Set{{ .Name }}(v {{ .Type }}) {
+	t.{{ .Name }} = v
+	t.ΛPresence[{{ .Word }}] |= 1 << {{ .Bit }}
+}
+
+// Clear{{ .Name }} unsets the leaf {{ .Name }} in the {{ .Receiver }} struct,
+// resetting its value to the zero value.
+func (t *{{ .Receiver }}) Clear{{ .Name }}() {
+	var v {{ .Type }}
+	t.{{ .Name }} = v
+	t.ΛPresence[{{ .Word }}] &^= 1 << {{ .Bit }}
+}
 `)
 
 	// goDefaultMethodTemplate is a template for generating a PopulateDefaults method
@@ -627,6 +763,11 @@ func (t *{{ .Receiver }}) PopulateDefaults() {
 
 	{{- range $Leaf := .Leaves }}
 	{{- if $Leaf.Default }}
+	{{- if $Leaf.HasPresence }}
+	if !t.IsSet{{ $Leaf.Name }}() {
+		t.Set{{ $Leaf.Name }}({{ $Leaf.Default }})
+	}
+	{{- else }}
 	if t.{{ $Leaf.Name }} == {{ if $Leaf.IsPtr -}} nil {{- else }} {{ $Leaf.Zero }} {{- end }} {
 		{{- if $Leaf.IsPtr }}
 		var v {{ $Leaf.Type }} = {{ $Leaf.Default }}
@@ -637,6 +778,7 @@ func (t *{{ .Receiver }}) PopulateDefaults() {
 	}
 	{{- end }}
 	{{- end }}
+	{{- end }}
 	{{- range $containerName := .ChildContainerNames }}
 	t.{{ $containerName }}.PopulateDefaults()
 	{{- end }}
@@ -670,6 +812,12 @@ var ΛEnum = map[string]map[int64]ygot.EnumDefinition{
 			{{- if ne $valDef.DefiningModule "" -}}
 				, DefiningModule: "{{ $valDef.DefiningModule }}"
 			{{- end -}}
+			{{- if ne $valDef.Description "" -}}
+				, Description: {{ printf "%q" $valDef.Description }}
+			{{- end -}}
+			{{- if ne $valDef.Status "" -}}
+				, Status: "{{ $valDef.Status }}"
+			{{- end -}}
 		},
 		{{- end }}
 	},
@@ -856,7 +1004,7 @@ func (t *{{ .ParentReceiver }}) To_{{ .Name }}(i interface{}) ({{ .Name }}, erro
 // The header returned is split into two strings, the common header is a header that
 // should be used for all files within the output package. The one off header should
 // be included in only one file of the package.
-func writeGoHeader(yangFiles, includePaths []string, cfg *CodeGenerator, rootName string, modelData []*gpb.ModelData) (string, string, error) {
+func writeGoHeader(yangFiles, includePaths []string, cfg *CodeGenerator, rootName string, modelData []*gpb.ModelData, yangLibrary []*ygot.YANGLibraryModule) (string, string, error) {
 	// Determine the running binary's name.
 	if cfg.Caller == "" {
 		cfg.Caller = genutil.CallerName()
@@ -878,21 +1026,25 @@ func writeGoHeader(yangFiles, includePaths []string, cfg *CodeGenerator, rootNam
 	if cfg.GoOptions.GNMIProtoPath == "" {
 		cfg.GoOptions.GNMIProtoPath = genutil.GoDefaultGNMIImportPath
 	}
+	if cfg.GoOptions.YRESTCONFImportPath == "" {
+		cfg.GoOptions.YRESTCONFImportPath = genutil.GoDefaultYRESTCONFImportPath
+	}
 
 	// Build input to the header template which stores parameters which are included
 	// in the header of generated code.
 	s := struct {
-		PackageName      string           // PackgeName is the name of the package to be generated.
-		YANGFiles        []string         // YANGFiles contains the list of input YANG source files for code generation.
-		IncludePaths     []string         // IncludePaths contains the list of paths that included modules were searched for in.
-		CompressEnabled  bool             // CompressEnabled indicates whether compression is enabled.
-		GeneratingBinary string           // GeneratingBinary is the name of the binary generating the code.
-		GenerateSchema   bool             // GenerateSchema stores whether the generator requested that the schema was to be stored with the output code.
-		GoOptions        GoOpts           // GoOptions stores additional Go-specific options for the output code, including package paths.
-		BinaryTypeName   string           // BinaryTypeName is the name of the type used for YANG binary types.
-		EmptyTypeName    string           // EmptyTypeName is the name of the type used for YANG empty types.
-		FakeRootName     string           // FakeRootName is the name of the fake root struct in the YANG type
-		ModelData        []*gpb.ModelData // ModelData contains the gNMI ModelData definition for the input types.
+		PackageName      string               // PackgeName is the name of the package to be generated.
+		YANGFiles        []string             // YANGFiles contains the list of input YANG source files for code generation.
+		IncludePaths     []string             // IncludePaths contains the list of paths that included modules were searched for in.
+		CompressEnabled  bool                 // CompressEnabled indicates whether compression is enabled.
+		GeneratingBinary string               // GeneratingBinary is the name of the binary generating the code.
+		GenerateSchema   bool                 // GenerateSchema stores whether the generator requested that the schema was to be stored with the output code.
+		GoOptions        GoOpts               // GoOptions stores additional Go-specific options for the output code, including package paths.
+		BinaryTypeName   string               // BinaryTypeName is the name of the type used for YANG binary types.
+		EmptyTypeName    string               // EmptyTypeName is the name of the type used for YANG empty types.
+		FakeRootName     string               // FakeRootName is the name of the fake root struct in the YANG type
+		ModelData        []*gpb.ModelData     // ModelData contains the gNMI ModelData definition for the input types.
+		YANGLibrary      []*yangLibraryModule // YANGLibrary contains the ietf-yang-library description of the input modules.
 	}{
 		PackageName:      cfg.GoOptions.PackageName,
 		YANGFiles:        yangFiles,
@@ -904,6 +1056,7 @@ func writeGoHeader(yangFiles, includePaths []string, cfg *CodeGenerator, rootNam
 		BinaryTypeName:   ygot.BinaryTypeName,
 		EmptyTypeName:    ygot.EmptyTypeName,
 		ModelData:        modelData,
+		YANGLibrary:      yangLibraryTemplateInput(yangLibrary),
 	}
 
 	s.FakeRootName = "nil"
@@ -924,6 +1077,43 @@ func writeGoHeader(yangFiles, includePaths []string, cfg *CodeGenerator, rootNam
 	return common.String(), oneoff.String(), nil
 }
 
+// yangLibraryModule is the input to the header template that describes a
+// single module within the ietf-yang-library description of the input
+// modules.
+type yangLibraryModule struct {
+	Name, Namespace, Revision string
+	Feature, Deviation        []string
+	Submodule                 []*yangLibrarySubmodule
+}
+
+// yangLibrarySubmodule is the input to the header template that describes a
+// submodule of a yangLibraryModule.
+type yangLibrarySubmodule struct {
+	Name, Revision string
+}
+
+// yangLibraryTemplateInput converts the supplied ietf-yang-library module
+// descriptions to the input to the header template, such that they can be
+// output deterministically.
+func yangLibraryTemplateInput(modules []*ygot.YANGLibraryModule) []*yangLibraryModule {
+	var out []*yangLibraryModule
+	for _, m := range modules {
+		tm := &yangLibraryModule{
+			Name:      m.GetName(),
+			Namespace: m.GetNamespace(),
+			Revision:  m.GetRevision(),
+			Feature:   m.Feature,
+			Deviation: m.Deviation,
+		}
+		for n, sm := range m.Submodule {
+			tm.Submodule = append(tm.Submodule, &yangLibrarySubmodule{Name: n, Revision: sm.GetRevision()})
+		}
+		sort.Slice(tm.Submodule, func(i, j int) bool { return tm.Submodule[i].Name < tm.Submodule[j].Name })
+		out = append(out, tm)
+	}
+	return out
+}
+
 // IsScalarField determines which fields should be converted to pointers when
 // outputting structs; this is done to allow checks against nil.
 func IsScalarField(field *ygen.NodeDetails) bool {
@@ -937,8 +1127,8 @@ func IsScalarField(field *ygen.NodeDetails) bool {
 	// an enumerated value shouldn't be a pointer either since its has an UNSET value;
 	case field.LangType.IsEnumeratedValue:
 		return false
-	// an unmapped type (interface{}), byte slice, or a leaflist can also use nil already, so they should also not be pointers.
-	case field.LangType.NativeType == ygot.BinaryTypeName, field.LangType.NativeType == ygot.EmptyTypeName, field.LangType.NativeType == "interface{}":
+	// an unmapped type (interface{}), byte slice, bits, or a leaflist can also use nil already, so they should also not be pointers.
+	case field.LangType.NativeType == ygot.BinaryTypeName, field.LangType.NativeType == ygot.EmptyTypeName, field.LangType.NativeType == goBitsTypeName, field.LangType.NativeType == "interface{}":
 		return false
 	}
 	return true
@@ -975,6 +1165,7 @@ func writeGoStruct(targetStruct *ygen.ParsedDirectory, goStructElements map[stri
 		StructName:      targetStruct.Name,
 		YANGPath:        targetStruct.Path,
 		BelongingModule: targetStruct.BelongingModule,
+		Doc:             goOpts.docComment(targetStruct.Description, targetStruct.Reference, ""),
 	}
 
 	// associatedListKeyStructs is a slice containing the key structures for any multi-keyed
@@ -998,6 +1189,17 @@ func writeGoStruct(targetStruct *ygen.ParsedDirectory, goStructElements map[stri
 	// to generated for the struct.
 	var associatedLeafSetters []*generatedLeafSetter
 
+	// associatedPresenceLeaves is a slice of structs which define the leaves
+	// whose presence is recorded in the presence bitmap of the struct, when
+	// presence bitmaps are being generated.
+	var associatedPresenceLeaves []*generatedPresenceLeaf
+	// associatedLeafConstraints stores the constants describing the
+	// restrictions on the values of the leaves of the struct.
+	var associatedLeafConstraints []*generatedLeafConstraint
+	// associatedUnionLeafListAccessors stores the accessors for the members
+	// of the leaf-lists of union type of the struct.
+	var associatedUnionLeafListAccessors []*generatedUnionLeafListAccessor
+
 	associatedDefaultMethod := generatedDefaultMethod{
 		Receiver: targetStruct.Name,
 	}
@@ -1035,6 +1237,9 @@ func writeGoStruct(targetStruct *ygen.ParsedDirectory, goStructElements map[stri
 		// the corresponding type. fieldDef is used to store the definition of the field (name
 		// and type) that are calculated.
 		var fieldDef *goStructField
+		// presence is used to store the details of the field's bit within
+		// the presence bitmap of the struct, where it has one.
+		var presence *generatedPresenceLeaf
 
 		field := targetStruct.Fields[fName]
 		fieldName := goFieldNameMap[fName]
@@ -1045,7 +1250,7 @@ func writeGoStruct(targetStruct *ygen.ParsedDirectory, goStructElements map[stri
 			// If the field within the struct is a list, then generate code for this list. This
 			// includes extracting any new types that are required to represent the key of a
 			// list that has multiple keys.
-			fieldType, multiKeyListKey, listMethods, orderedMapSpec, listErr := yangListFieldToGoType(field, fieldName, targetStruct, goStructElements, !goOpts.GenerateOrderedListsAsUnorderedMaps)
+			fieldType, multiKeyListKey, listMethods, orderedMapSpec, listErr := yangListFieldToGoType(field, fieldName, targetStruct, goStructElements, !goOpts.GenerateOrderedListsAsUnorderedMaps, goOpts.GenerateKeyedLists)
 			if listErr != nil {
 				errs = append(errs, listErr)
 			}
@@ -1164,28 +1369,58 @@ func writeGoStruct(targetStruct *ygen.ParsedDirectory, goStructElements map[stri
 
 			definedNameMap[fName].IsPtr = scalarField
 
+			// When presence bitmaps are being generated, scalar leaves other
+			// than list keys are stored as values, with their presence being
+			// recorded in the bitmap. List keys remain pointers such that the
+			// list helper methods are unchanged. Empty leaves, which are
+			// always stored as values, may also have their presence recorded.
+			_, isKey := targetStruct.ListKeys[fName]
+			isEmptyLeaf := field.Type == ygen.LeafNode && field.LangType.NativeType == ygot.EmptyTypeName
+			if (goOpts.PresenceBitmaps && scalarField && !isKey) || (goOpts.EmptyLeafPresence && isEmptyLeaf) {
+				bit := len(associatedPresenceLeaves)
+				presence = &generatedPresenceLeaf{
+					Name:     fieldName,
+					Type:     fType,
+					Receiver: targetStruct.Name,
+					Word:     bit / presenceWordBits,
+					Bit:      bit % presenceWordBits,
+				}
+				associatedPresenceLeaves = append(associatedPresenceLeaves, presence)
+				scalarField = false
+			}
+
 			// If we are generating leaf getters, then append the relevant information
 			// to the associatedLeafGetters slice to be generated along with other
 			// associated methods.
 			associatedLeafGetters = append(associatedLeafGetters, &generatedLeafGetter{
-				Name:     fieldName,
-				Type:     fType,
-				Zero:     zeroValue,
-				IsPtr:    scalarField,
-				Receiver: targetStruct.Name,
-				Default:  field.LangType.DefaultValue,
+				Name:        fieldName,
+				Type:        fType,
+				Zero:        zeroValue,
+				IsPtr:       scalarField,
+				HasPresence: presence != nil,
+				Receiver:    targetStruct.Name,
+				Default:     field.LangType.DefaultValue,
 			})
 
 			// If we are generating leaf setters, then append the relevant information
 			// to the associatedLeafSetters slice to be generated along with other
 			// associated methods.
 			associatedLeafSetters = append(associatedLeafSetters, &generatedLeafSetter{
-				Name:     fieldName,
-				Type:     fType,
-				IsPtr:    scalarField,
-				Receiver: targetStruct.Name,
+				Name:        fieldName,
+				Type:        fType,
+				IsPtr:       scalarField,
+				HasPresence: presence != nil,
+				Receiver:    targetStruct.Name,
 			})
 
+			if goOpts.GenerateLeafConstraints {
+				associatedLeafConstraints = append(associatedLeafConstraints, leafConstraintConstants(targetStruct.Name, fieldName, field)...)
+			}
+
+			if goOpts.GenerateSimpleUnions && goOpts.GenerateUnionLeafListAccessors {
+				associatedUnionLeafListAccessors = append(associatedUnionLeafListAccessors, unionLeafListAccessors(targetStruct.Name, fieldName, field)...)
+			}
+
 			fieldDef = &goStructField{
 				Name:          fieldName,
 				Type:          fType,
@@ -1247,6 +1482,16 @@ func writeGoStruct(targetStruct *ygen.ParsedDirectory, goStructElements map[stri
 
 		metadataTagBuf.WriteString(` ygotAnnotation:"true"`)
 
+		if presence != nil {
+			tagBuf.WriteString(fmt.Sprintf(` presence:"%d"`, presence.Word*presenceWordBits+presence.Bit))
+		}
+
+		// Leaf-lists that are ordered-by user are tagged such that their
+		// order is preserved when they are merged.
+		if field.Type == ygen.LeafListNode && field.YANGDetails.OrderedByUser {
+			tagBuf.WriteString(` orderedByUser:"true"`)
+		}
+
 		if goOpts.AddYangPresence {
 			if field.Type == ygen.ContainerNode && field.YANGDetails.PresenceStatement != nil {
 				tagBuf.WriteString(` yangPresence:"true"`)
@@ -1254,6 +1499,7 @@ func writeGoStruct(targetStruct *ygen.ParsedDirectory, goStructElements map[stri
 		}
 
 		fieldDef.Tags = tagBuf.String()
+		fieldDef.Doc = goOpts.docComment(field.YANGDetails.Description, field.YANGDetails.Reference, "\t")
 
 		// Append the generated field definition to the set of fields of the struct.
 		structDef.Fields = append(structDef.Fields, fieldDef)
@@ -1269,6 +1515,26 @@ func writeGoStruct(targetStruct *ygen.ParsedDirectory, goStructElements map[stri
 		}
 	}
 
+	if len(associatedPresenceLeaves) != 0 {
+		// Append the bitmap recording the presence of the leaves that are
+		// stored as values.
+		structDef.Fields = append(structDef.Fields, &goStructField{
+			Name: util.PresenceFieldName,
+			Type: fmt.Sprintf("[%d]uint64", (len(associatedPresenceLeaves)+presenceWordBits-1)/presenceWordBits),
+			Tags: `ygotPresence:"true"`,
+		})
+	}
+
+	if goOpts.GenerateExtensionPoints && len(goOpts.ExtensionModules) == 0 {
+		// Append the field holding the extension structs that are attached
+		// to the struct at runtime.
+		structDef.Fields = append(structDef.Fields, &goStructField{
+			Name: util.ExtensionsFieldName,
+			Type: "ygot.Extensions",
+			Tags: `ygotExtensions:"true"`,
+		})
+	}
+
 	// structBuf is used to store the code associated with the struct defined for
 	// the target YANG entity.
 	var structBuf bytes.Buffer
@@ -1342,6 +1608,20 @@ func writeGoStruct(targetStruct *ygen.ParsedDirectory, goStructElements map[stri
 		}
 	}
 
+	for _, l := range associatedPresenceLeaves {
+		if err := goPresenceLeafTemplate.Execute(&methodBuf, l); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := generateUnionLeafListAccessors(&methodBuf, associatedUnionLeafListAccessors); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := generateLeafConstraints(&methodBuf, associatedLeafConstraints); err != nil {
+		errs = append(errs, err)
+	}
+
 	for _, s := range associatedOrderedMapStructs {
 		if err := generateOrderedMapParentMethods(&methodBuf, s); err != nil {
 			errs = append(errs, err)
@@ -1546,6 +1826,10 @@ func generateLeafGetters(buf *bytes.Buffer, leaves []*generatedLeafGetter) error
 func generateLeafSetters(buf *bytes.Buffer, leaves []*generatedLeafSetter) error {
 	var errs errlist.List
 	for _, l := range leaves {
+		if l.HasPresence {
+			// The setter is generated with the presence methods of the leaf.
+			continue
+		}
 		if err := goLeafSetterTemplate.Execute(buf, l); err != nil {
 			errs.Add(err)
 		}
@@ -475,6 +475,67 @@ func (t *InputStruct) ΛValidate(opts ...ygot.ValidationOption) error {
 // that are included in the generated code.
 func (t *InputStruct) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
 
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of InputStruct.
+func (*InputStruct) ΛBelongingModule() string {
+	return "exmod"
+}
+`,
+		},
+	}, {
+		name: "struct with ordered-by user leaf-list",
+		inStructToMap: &ygen.ParsedDirectory{
+			Name: "InputStruct",
+			Type: ygen.Container,
+			Fields: map[string]*ygen.NodeDetails{
+				"members": {
+					Name: "Members",
+					YANGDetails: ygen.YANGNodeDetails{
+						Name:              "members",
+						RootElementModule: "exmod",
+						Path:              "/root-module/input-struct/members",
+						OrderedByUser:     true,
+					},
+					Type: ygen.LeafListNode,
+					LangType: &ygen.MappedType{
+						NativeType: "string",
+						ZeroValue:  `""`,
+					},
+					MappedPaths:       [][]string{{"members"}},
+					MappedPathModules: [][]string{{"exmod"}},
+				},
+			},
+			Path:            "/root-module/input-struct",
+			BelongingModule: "exmod",
+		},
+		inGoOpts: GoOpts{
+			GenerateJSONSchema: true,
+		},
+		want: wantGoStructOut{
+			structs: `
+// InputStruct represents the /root-module/input-struct YANG schema element.
+type InputStruct struct {
+	Members	[]string	` + "`" + `path:"members" module:"exmod" orderedByUser:"true"` + "`" + `
+}
+
+// IsYANGGoStruct ensures that InputStruct implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*InputStruct) IsYANGGoStruct() {}
+`,
+			methods: `
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *InputStruct) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["InputStruct"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *InputStruct) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
 // ΛBelongingModule returns the name of the module that defines the namespace
 // of InputStruct.
 func (*InputStruct) ΛBelongingModule() string {
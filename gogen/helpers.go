@@ -55,3 +55,55 @@ func enumDefaultValue(baseName, defVal, prefix string) string {
 
 	return fmt.Sprintf("%s_%s", baseName, defVal)
 }
+
+// docCommentWidth is the maximum width, excluding indentation, of the lines of
+// doc comments generated from YANG descriptions.
+const docCommentWidth = 80
+
+// docComment returns a Go comment containing the YANG description and
+// reference supplied, or the empty string if both are empty. The whitespace
+// within each paragraph of the description is normalised, and the paragraphs
+// are wrapped to docCommentWidth. If maxLen is greater than zero, the
+// description is truncated at the last word boundary before maxLen
+// characters. Lines after the first are prefixed with indent, such that the
+// comment can be used to document a struct field.
+func docComment(description, reference string, maxLen int, indent string) string {
+	var paras []string
+	for _, p := range strings.Split(strings.TrimSpace(description), "\n\n") {
+		if p = strings.Join(strings.Fields(p), " "); p != "" {
+			paras = append(paras, p)
+		}
+	}
+	desc := strings.Join(paras, "\n\n")
+	if maxLen > 0 && len(desc) > maxLen {
+		desc = desc[:maxLen]
+		if i := strings.LastIndexAny(desc, " \n"); i > 0 {
+			desc = desc[:i]
+		}
+		desc = strings.TrimSpace(desc) + "..."
+	}
+	paras = nil
+	if desc != "" {
+		paras = strings.Split(desc, "\n\n")
+	}
+	if ref := strings.Join(strings.Fields(reference), " "); ref != "" {
+		paras = append(paras, fmt.Sprintf("Reference: %s", ref))
+	}
+
+	var lines []string
+	for i, p := range paras {
+		if i != 0 {
+			lines = append(lines, "//")
+		}
+		line := "//"
+		for _, w := range strings.Fields(p) {
+			if line != "//" && len(line)+len(w)+1 > docCommentWidth {
+				lines = append(lines, line)
+				line = "//"
+			}
+			line += " " + w
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n"+indent)
+}
@@ -30,3 +30,54 @@ func TestSafeGoEnumeratedValueName(t *testing.T) {
 		}
 	}
 }
+
+// TestDocComment tests the generation of Go doc comments from YANG
+// descriptions and references.
+func TestDocComment(t *testing.T) {
+	tests := []struct {
+		desc          string
+		inDescription string
+		inReference   string
+		inMaxLen      int
+		inIndent      string
+		want          string
+	}{{
+		desc: "empty",
+	}, {
+		desc:          "whitespace is normalised",
+		inDescription: "  The name\n      of the\tinterface. ",
+		want:          "// The name of the interface.",
+	}, {
+		desc:          "paragraphs and reference",
+		inDescription: "First paragraph.\n\n    Second paragraph.",
+		inReference:   "RFC 1234",
+		inIndent:      "\t",
+		want:          "// First paragraph.\n\t//\n\t// Second paragraph.\n\t//\n\t// Reference: RFC 1234",
+	}, {
+		desc:        "reference only",
+		inReference: "RFC 1234",
+		want:        "// Reference: RFC 1234",
+	}, {
+		desc:          "long description is wrapped",
+		inDescription: "The quick brown fox jumps over the lazy dog, and then the quick brown fox jumps over the lazy dog again.",
+		want:          "// The quick brown fox jumps over the lazy dog, and then the quick brown fox\n// jumps over the lazy dog again.",
+	}, {
+		desc:          "truncated at word boundary",
+		inDescription: "The quick brown fox jumps over the lazy dog.",
+		inMaxLen:      18,
+		want:          "// The quick brown...",
+	}, {
+		desc:          "short description is not truncated",
+		inDescription: "The quick brown fox.",
+		inMaxLen:      20,
+		want:          "// The quick brown fox.",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := docComment(tt.inDescription, tt.inReference, tt.inMaxLen, tt.inIndent); got != tt.want {
+				t.Errorf("docComment(%q, %q, %d, %q): got %q, want %q", tt.inDescription, tt.inReference, tt.inMaxLen, tt.inIndent, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gogen
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygen"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// generatedUnionLeafListAccessor is used to represent the parameters
+// required to generate a method that returns a member of a leaf-list of
+// union type as one of the types of the union.
+type generatedUnionLeafListAccessor struct {
+	// Name is the name of the leaf-list field.
+	Name string
+	// Suffix is the suffix of the name of the method, which identifies
+	// the type that the member is returned as, e.g., "String".
+	Suffix string
+	// UnionType is the type that implements the union interface for the
+	// members of the type, e.g., "UnionString".
+	UnionType string
+	// Type is the Go type that the member is returned as, e.g., "string".
+	Type string
+	// Receiver is the name of the struct that the leaf-list is a member of.
+	Receiver string
+}
+
+// goUnionLeafListAccessorTemplate defines a template for a method that
+// returns a member of a leaf-list of union type as one of the types of the
+// union.
+var goUnionLeafListAccessorTemplate = mustMakeTemplate("unionLeafListAccessor", `
+// Get{{ .Name }}As{{ .Suffix }} returns the member at index i of the leaf-list
+// {{ .Name }} as type {{ .Type }}, and whether the member is of that type. If
+// i is outside of the leaf-list, or the receiver is nil, false is returned.
+func (t *{{ .Receiver }}) Get{{ .Name }}As{{ .Suffix }}(i int) ({{ .Type }}, bool) {
+	var v {{ .UnionType }}
+	var ok bool
+	if t != nil && i >= 0 && i < len(t.{{ .Name }}) {
+		v, ok = t.{{ .Name }}[i].({{ .UnionType }})
+	}
+	{{- if eq .Type .UnionType }}
+	return v, ok
+	{{- else }}
+	return {{ .Type }}(v), ok
+	{{- end }}
+}
+`)
+
+// unionLeafListAccessors returns the accessors that are generated for the
+// leaf-list field, named fieldName within the struct named receiver, when
+// it is of a union type that is represented using simple union types. An
+// accessor is generated for each type of the union other than unsupported
+// types, named Get<fieldName>As<suffix>, where suffix is the name of the
+// builtin Go type in camel case, e.g., "String" or "Uint32", the name of
+// the enumerated type without its E_ prefix, "Binary", or "Empty".
+func unionLeafListAccessors(receiver, fieldName string, field *ygen.NodeDetails) []*generatedUnionLeafListAccessor {
+	if field.Type != ygen.LeafListNode || field.LangType == nil || len(field.LangType.UnionTypes) < 2 {
+		return nil
+	}
+
+	var types []string
+	for t := range field.LangType.UnionTypes {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	var accessors []*generatedUnionLeafListAccessor
+	for _, t := range types {
+		a := &generatedUnionLeafListAccessor{
+			Name:      fieldName,
+			UnionType: t,
+			Type:      t,
+			Receiver:  receiver,
+		}
+		switch t {
+		case "interface{}":
+			continue
+		case ygot.BinaryTypeName:
+			a.Suffix = ygot.BinaryTypeName
+			a.Type = "[]byte"
+		case ygot.EmptyTypeName:
+			a.Suffix = "Empty"
+			a.Type = "bool"
+		default:
+			if ut, ok := ygot.SimpleUnionBuiltinGoTypes[t]; ok {
+				a.Suffix = yang.CamelCase(t)
+				a.UnionType = ut
+			} else {
+				a.Suffix = strings.TrimPrefix(t, "E_")
+			}
+		}
+		accessors = append(accessors, a)
+	}
+	return accessors
+}
+
+// generateUnionLeafListAccessors generates the accessors for the members of
+// the leaf-lists of union type of a struct.
+func generateUnionLeafListAccessors(buf *bytes.Buffer, accessors []*generatedUnionLeafListAccessor) error {
+	for _, a := range accessors {
+		if err := goUnionLeafListAccessorTemplate.Execute(buf, a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -627,10 +627,13 @@ func UnorderedMapTypeName(listYANGPath, listFieldName, parentName string, goStru
 //   - If the list has "ordered-by user", then for the single and multiple key
 //     cases, a struct that represents an ordered map keyed by the same key
 //     type as the unordered map representation described above.
+//   - If generateKeyedLists is true, then for the single and multiple key
+//     cases other than ordered maps, a ygot.KeyedList keyed by the same key
+//     type as the map described above is returned instead of the map.
 //
 // In the case that the list has multiple keys, the type generated as the key of the list is returned.
 // If errors are encountered during the type generation for the list, the error is returned.
-func yangListFieldToGoType(listField *ygen.NodeDetails, listFieldName string, parent *ygen.ParsedDirectory, goStructElements map[string]*ygen.ParsedDirectory, generateOrderedMaps bool) (string, *generatedGoMultiKeyListStruct, *generatedGoListMethod, *generatedOrderedMapStruct, error) {
+func yangListFieldToGoType(listField *ygen.NodeDetails, listFieldName string, parent *ygen.ParsedDirectory, goStructElements map[string]*ygen.ParsedDirectory, generateOrderedMaps, generateKeyedLists bool) (string, *generatedGoMultiKeyListStruct, *generatedGoListMethod, *generatedOrderedMapStruct, error) {
 	// The list itself, since it is a container, has a struct associated with it. Retrieve
 	// this from the set of Directory structs for which code (a Go struct) will be
 	//  generated such that additional details can be used in the code generation.
@@ -715,6 +718,9 @@ func yangListFieldToGoType(listField *ygen.NodeDetails, listFieldName string, pa
 			YANGPath:         listField.YANGDetails.Path,
 		}
 	} else {
+		if generateKeyedLists {
+			listType = fmt.Sprintf("ygot.KeyedList[%s, *%s]", keyType, listElem.Name)
+		}
 		// Generate the specification for the methods that should be generated for this
 		// list, such that this can be handed to the relevant templates to generate code.
 		listMethodSpec = &generatedGoListMethod{
@@ -19,6 +19,7 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -53,6 +54,11 @@ var (
 	preferOperationalState = flag.Bool("prefer_operational_state", false, "If set to true, state (config false) fields in the YANG schema are preferred over intended config leaves in the generated messages with compressed schema paths. This flag is only valid for compress_paths=true and exclude_state=false.")
 	skipEnumDedup          = flag.Bool("skip_enum_deduplication", false, "If set to true, all leaves of type enumeration will have a unique enum output for them, rather than sharing a common type (default behaviour).")
 	goPackageBase          = flag.String("go_package_base", "", "Base name for the Go packages that are to be generated - this value is included in the go_package option of the generated protobufs - and has generated packages' names appended to it.")
+	profileMode            = flag.String("profile", "", "If set to one of cpu, mem or trace, the corresponding profile of the generator is collected and written to the file specified by profile_output_file.")
+	profileOutputFile      = flag.String("profile_output_file", "", "The file that the profile specified by the profile flag should be written to.")
+	fieldNumberLock        = flag.String("field_number_lock", "", "If set, the file that records the field numbers assigned to the fields of generated messages. Fields whose numbers are recorded in the file keep them, such that regenerating after changes to the YANG schema does not renumber existing fields. The numbers assigned to new fields are added to the file, which is created if it does not exist.")
+	generateServices       = flag.Bool("generate_services", false, "If set to true, a gRPC service is generated for each YANG module, with Get and Set RPCs that read and replace the subtree of each of its top-level nodes using the generated messages.")
+	reportTimings          = flag.Bool("report_phase_timings", false, "If set to true, a summary of the time spent in each phase of generation (parse, IR, codegen, write) is written to stderr.")
 )
 
 // main parses command-line flags to determine the set of YANG modules for
@@ -61,15 +67,43 @@ var (
 // to the specified file.
 func main() {
 	flag.Parse()
+	if err := run(); err != nil {
+		log.Exit(err)
+	}
+}
+
+// run performs code generation as specified by the command-line flags. The
+// profile and phase timing summary, where enabled, are written before it
+// returns, including when code generation fails.
+func run() error {
+	stopProfile, err := genutil.StartProfile(genutil.ProfileMode(*profileMode), *profileOutputFile)
+	if err != nil {
+		return fmt.Errorf("Error: cannot start profiling: %v", err)
+	}
+	var timer *genutil.PhaseTimer
+	if *reportTimings {
+		timer = genutil.NewPhaseTimer()
+	}
+	// This is deferred first such that it runs after all output files have
+	// been closed.
+	defer func() {
+		if err := stopProfile(); err != nil {
+			log.Errorf("Error: cannot write profile: %v", err)
+		}
+		if timer != nil {
+			timer.WriteSummary(os.Stderr)
+		}
+	}()
+
 	// Extract the set of modules that code is to be generated for,
 	// throwing an error if the set is empty.
 	generateModules := flag.Args()
 	if len(generateModules) == 0 {
-		log.Exitln("Error: no input modules specified")
+		return errors.New("Error: no input modules specified")
 	}
 
 	if *outputDir == "" {
-		log.Exitln("Error: an output directory must be specified")
+		return errors.New("Error: an output directory must be specified")
 	}
 
 	// Determine the set of paths that should be searched for included
@@ -97,7 +131,14 @@ func main() {
 
 	compressBehaviour, err := genutil.TranslateToCompressBehaviour(*compressPaths, *excludeState, *preferOperationalState)
 	if err != nil {
-		log.Exitf("ERROR Generating Proto Code: %s\n", err)
+		return fmt.Errorf("ERROR Generating Proto Code: %s", err)
+	}
+
+	var fieldNumbers *protogen.FieldNumberLock
+	if *fieldNumberLock != "" {
+		if fieldNumbers, err = readFieldNumberLock(*fieldNumberLock); err != nil {
+			return fmt.Errorf("ERROR Generating Proto Code: %v", err)
+		}
 	}
 
 	// Perform the code generation.
@@ -116,6 +157,7 @@ func main() {
 				FakeRootName:          *fakeRootName,
 				SkipEnumDeduplication: *skipEnumDedup,
 			},
+			PhaseTimer: timer,
 		},
 		protogen.ProtoOpts{
 			PackageName:         *packageName,
@@ -127,23 +169,31 @@ func main() {
 			NestedMessages:      !*packageHierarchy,
 			EnumPackageName:     *enumPackageName,
 			GoPackageBase:       *goPackageBase,
+			FieldNumbers:        fieldNumbers,
+			GenerateServices:    *generateServices,
 		},
 	)
 
 	generatedProtoCode, errs := cg.Generate(generateModules, includePaths)
 	if errs != nil {
-		log.Exitf("%v\n", errs)
+		return fmt.Errorf("%v", errs)
 	}
 
+	defer timer.Start(genutil.PhaseWrite)()
+	if fieldNumbers != nil {
+		if err := writeFieldNumberLock(*fieldNumberLock, fieldNumbers); err != nil {
+			return fmt.Errorf("ERROR Generating Proto Code: %v", err)
+		}
+	}
 	for _, p := range generatedProtoCode.Packages {
 		fp := filepath.Join(append([]string{*outputDir}, p.FilePath[:len(p.FilePath)-1]...)...)
 		if err := os.MkdirAll(fp, 0755); err != nil {
-			log.Exitf("could not create directory %v, got error: %v", fp, err)
+			return fmt.Errorf("could not create directory %v, got error: %v", fp, err)
 		}
 
 		f, err := os.Create(filepath.Join(fp, p.FilePath[len(p.FilePath)-1]))
 		if err != nil {
-			log.Exitf("could not create file %v, got error: %v", fp, err)
+			return fmt.Errorf("could not create file %v, got error: %v", fp, err)
 		}
 		defer f.Close()
 
@@ -154,6 +204,39 @@ func main() {
 		for _, e := range p.Enums {
 			f.WriteString(e)
 		}
+		for _, s := range p.Services {
+			f.WriteString(s)
+		}
 		f.Sync()
 	}
+	return nil
+}
+
+// readFieldNumberLock reads the field number lock from the file fn, returning
+// an empty lock if the file does not exist.
+func readFieldNumberLock(fn string) (*protogen.FieldNumberLock, error) {
+	b, err := os.ReadFile(fn)
+	switch {
+	case os.IsNotExist(err):
+		return protogen.NewFieldNumberLock(), nil
+	case err != nil:
+		return nil, fmt.Errorf("cannot read field number lock %s: %v", fn, err)
+	}
+	l, err := protogen.ParseFieldNumberLock(b)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse field number lock %s: %v", fn, err)
+	}
+	return l, nil
+}
+
+// writeFieldNumberLock writes the field number lock l to the file fn.
+func writeFieldNumberLock(fn string, l *protogen.FieldNumberLock) error {
+	b, err := l.Marshal()
+	if err != nil {
+		return fmt.Errorf("cannot serialise field number lock: %v", err)
+	}
+	if err := os.WriteFile(fn, b, 0644); err != nil {
+		return fmt.Errorf("cannot write field number lock %s: %v", fn, err)
+	}
+	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/openconfig/ygot/genutil"
 	"github.com/openconfig/ygot/util"
 	"github.com/openconfig/ygot/ygen"
 )
@@ -63,6 +64,16 @@ type ProtoOpts struct {
 	// package identifiers are appended to the go_package - such that
 	// the format <base>/<path>/<to>/<package> is used.
 	GoPackageBase string
+	// FieldNumbers, if non-nil, is the lock that is used to assign the
+	// numbers of the fields of generated messages, such that they are not
+	// changed by changes to the schema. Numbers that are assigned to new
+	// fields are added to it during generation. See FieldNumberLock.
+	FieldNumbers *FieldNumberLock
+	// GenerateServices specifies whether a gRPC service is generated for
+	// each YANG module, with RPCs that get and set the subtree of each of
+	// its top-level nodes using the generated messages. This allows the
+	// modelled data to be served over plain gRPC, without gNMI.
+	GenerateServices bool
 }
 
 // New returns a new instance of the CodeGenerator
@@ -91,6 +102,7 @@ type Proto3Package struct {
 	Enums              []string // Enums is a slice of string containing the generated set of enumerations within the package.
 	UsesYwrapperImport bool     // UsesYwrapperImport indicates whether the ywrapper proto package is used within the generated package.
 	UsesYextImport     bool     // UsesYextImport indicates whether the yext proto package is used within the generated package.
+	Services           []string // Services is a slice of strings containing the generated data access services, and their messages, within the package.
 }
 
 // Generate generates Protobuf 3 code for the input set of YANG files.
@@ -124,12 +136,14 @@ func (cg *CodeGenerator) Generate(yangFiles, includePaths []string) (*GeneratedC
 		NestedDirectories:                   cg.ProtoOptions.NestedMessages,
 		AbsoluteMapPaths:                    true,
 		AppendEnumSuffixForSimpleUnionEnums: true,
+		PhaseTimer:                          cg.IROptions.PhaseTimer,
 	}
 
 	ir, err := ygen.GenerateIR(yangFiles, includePaths, NewProtoLangMapper(basePackageName, enumPackageName), opts)
 	if err != nil {
 		return nil, util.NewErrs(err)
 	}
+	defer opts.PhaseTimer.Start(genutil.PhaseCodegen)()
 
 	protoEnums, err := writeProtoEnums(ir.Enums, cg.ProtoOptions.AnnotateEnumNames)
 	if err != nil {
@@ -173,6 +187,7 @@ func (cg *CodeGenerator) Generate(yangFiles, includePaths []string) (*GeneratedC
 			annotateSchemaPaths: cg.ProtoOptions.AnnotateSchemaPaths,
 			annotateEnumNames:   cg.ProtoOptions.AnnotateEnumNames,
 			nestedMessages:      cg.ProtoOptions.NestedMessages,
+			fieldNumbers:        cg.ProtoOptions.FieldNumbers,
 		})
 
 		if errs != nil {
@@ -219,6 +234,18 @@ func (cg *CodeGenerator) Generate(yangFiles, includePaths []string) (*GeneratedC
 		genProto.Packages[genMsg.PackageName] = tp
 	}
 
+	if cg.ProtoOptions.GenerateServices {
+		svcs, err := writeProtoServices(ir, basePackageName, cg.IROptions.TransformationOptions.CompressBehaviour.CompressEnabled(), cg.ProtoOptions.NestedMessages)
+		if err != nil {
+			yerr = util.AppendErr(yerr, err)
+		}
+		for n, code := range svcs {
+			pkg := genProto.Packages[n]
+			pkg.Services = code
+			genProto.Packages[n] = pkg
+		}
+	}
+
 	for n, pkg := range genProto.Packages {
 		var gpn string
 		if cg.ProtoOptions.GoPackageBase != "" {
@@ -395,6 +395,27 @@ func TestGenerateProto3(t *testing.T) {
 		wantOutputFiles: map[string]string{
 			"openconfig": filepath.Join(TestRoot, "testdata", "proto", "fakeroot-multimod.formatted-txt"),
 		},
+	}, {
+		name: "multimod with fakeroot, nested and services",
+		inFiles: []string{
+			filepath.Join(TestRoot, "testdata", "proto", "fakeroot-multimod-one.yang"),
+			filepath.Join(TestRoot, "testdata", "proto", "fakeroot-multimod-two.yang"),
+		},
+		inConfig: CodeGenerator{
+			IROptions: ygen.IROptions{
+				TransformationOptions: ygen.TransformationOpts{
+					GenerateFakeRoot:  true,
+					CompressBehaviour: genutil.PreferIntendedConfig,
+				},
+			},
+			ProtoOptions: ProtoOpts{
+				NestedMessages:   true,
+				GenerateServices: true,
+			},
+		},
+		wantOutputFiles: map[string]string{
+			"openconfig": filepath.Join(TestRoot, "testdata", "proto", "fakeroot-multimod.services.formatted-txt"),
+		},
 	}}
 
 	for _, tt := range tests {
@@ -476,6 +497,10 @@ func TestGenerateProto3(t *testing.T) {
 					fmt.Fprintf(&gotCodeBuf, "%s", gotEnum)
 				}
 
+				for _, gotSvc := range gotPkg.Services {
+					fmt.Fprintf(&gotCodeBuf, "%s", gotSvc)
+				}
+
 				wantCode := string(wantCodeBytes)
 
 				allCode.WriteString(gotCodeBuf.String())
@@ -511,6 +536,9 @@ func TestGenerateProto3(t *testing.T) {
 					for _, gotEnum := range gotPkg.Enums {
 						fmt.Fprintf(&gotCodeBuf, "%s", gotEnum)
 					}
+					for _, gotSvc := range gotPkg.Services {
+						fmt.Fprintf(&gotCodeBuf, "%s", gotSvc)
+					}
 				}
 
 				if diff := cmp.Diff(gotCodeBuf.String(), allCode.String()); diff != "" {
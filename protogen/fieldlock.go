@@ -0,0 +1,140 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protogen
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// FieldNumberLock is a persisted mapping of the field numbers that have been
+// assigned to the fields of generated protobuf messages, which guarantees
+// that regenerating protobufs after changes to the YANG schema never
+// renumbers existing fields, and hence never breaks wire compatibility.
+//
+// Without a lock, the number of each field is calculated from a hash of its
+// schema path. With a lock, a field whose number is recorded in the lock is
+// always given that number. Any other field is given the number calculated
+// from its hash, unless that number is already recorded for another field of
+// the same message, including a field that has since been removed from the
+// schema, in which case a new number is calculated. Newly assigned numbers
+// are added to the lock, such that the lock should be persisted after
+// generation. Since the calculated numbers are used where there is no
+// conflict, adopting a lock for existing generated protobufs does not change
+// their field numbers.
+//
+// The fields of the messages that represent the keys of YANG lists are
+// numbered according to the order of the list's keys, and are not recorded
+// in the lock, with the exception of the fields for union-typed keys.
+//
+// A FieldNumberLock is safe for concurrent use.
+type FieldNumberLock struct {
+	mu sync.Mutex
+	// Messages is the set of assigned field numbers, keyed by the schema
+	// path of the message, and then by the schema path of the field. The
+	// fields of a oneof that represents a YANG union are keyed by the
+	// schema path of the union with the name of the type appended.
+	Messages map[string]map[string]uint32 `json:"messages"`
+}
+
+// NewFieldNumberLock returns an empty FieldNumberLock.
+func NewFieldNumberLock() *FieldNumberLock {
+	return &FieldNumberLock{Messages: map[string]map[string]uint32{}}
+}
+
+// ParseFieldNumberLock parses the JSON serialised FieldNumberLock in b, as
+// written by Marshal. It returns an error if b is invalid, or if it records
+// an invalid field number, or the same number for two fields of the same
+// message.
+func ParseFieldNumberLock(b []byte) (*FieldNumberLock, error) {
+	l := NewFieldNumberLock()
+	if err := json.Unmarshal(b, l); err != nil {
+		return nil, fmt.Errorf("invalid field number lock: %v", err)
+	}
+	if l.Messages == nil {
+		l.Messages = map[string]map[string]uint32{}
+	}
+	for msg, fields := range l.Messages {
+		seen := map[uint32]string{}
+		for field, n := range fields {
+			if !validFieldNumber(n) {
+				return nil, fmt.Errorf("invalid field number lock: invalid number %d for field %s of message %s", n, field, msg)
+			}
+			if other, ok := seen[n]; ok {
+				return nil, fmt.Errorf("invalid field number lock: number %d is used by both field %s and field %s of message %s", n, field, other, msg)
+			}
+			seen[n] = field
+		}
+	}
+	return l, nil
+}
+
+// Marshal returns the JSON serialisation of the lock, which is ordered
+// deterministically such that it is suitable to be checked into version
+// control.
+func (l *FieldNumberLock) Marshal() ([]byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// fieldNumber returns the number of the field identified by key within the
+// message identified by msg, assigning it as described for FieldNumberLock.
+// If l is nil, the number calculated from key is returned.
+func (l *FieldNumberLock) fieldNumber(msg, key string) (uint32, error) {
+	if l == nil {
+		return fieldTag(key)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	fields := l.Messages[msg]
+	if n, ok := fields[key]; ok {
+		return n, nil
+	}
+	used := map[uint32]bool{}
+	for _, n := range fields {
+		used[n] = true
+	}
+	for s := key; ; s += "_" {
+		n, err := fieldTag(s)
+		if err != nil {
+			return 0, err
+		}
+		if used[n] {
+			continue
+		}
+		if fields == nil {
+			if l.Messages == nil {
+				l.Messages = map[string]map[string]uint32{}
+			}
+			fields = map[string]uint32{}
+			l.Messages[msg] = fields
+		}
+		fields[key] = n
+		return n, nil
+	}
+}
+
+// validFieldNumber reports whether n can be used as the number of a
+// protobuf field.
+func validFieldNumber(n uint32) bool {
+	return n >= 1 && n <= 0x1fffffff && (n < 19000 || n > 19999)
+}
@@ -0,0 +1,227 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protogen
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/ygen"
+)
+
+func mustFieldTag(t *testing.T, s string) uint32 {
+	t.Helper()
+	n, err := fieldTag(s)
+	if err != nil {
+		t.Fatalf("cannot calculate tag for %s: %v", s, err)
+	}
+	return n
+}
+
+func TestFieldNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		inLock   *FieldNumberLock
+		inMsg    string
+		inKey    string
+		want     uint32
+		wantLock *FieldNumberLock
+	}{{
+		name:  "nil lock",
+		inMsg: "/a",
+		inKey: "/a/b",
+		want:  mustFieldTag(t, "/a/b"),
+	}, {
+		name:   "new field",
+		inLock: NewFieldNumberLock(),
+		inMsg:  "/a",
+		inKey:  "/a/b",
+		want:   mustFieldTag(t, "/a/b"),
+		wantLock: &FieldNumberLock{Messages: map[string]map[string]uint32{
+			"/a": {"/a/b": mustFieldTag(t, "/a/b")},
+		}},
+	}, {
+		name: "locked field",
+		inLock: &FieldNumberLock{Messages: map[string]map[string]uint32{
+			"/a": {"/a/b": 1234},
+		}},
+		inMsg: "/a",
+		inKey: "/a/b",
+		want:  1234,
+		wantLock: &FieldNumberLock{Messages: map[string]map[string]uint32{
+			"/a": {"/a/b": 1234},
+		}},
+	}, {
+		name: "number used by removed field",
+		inLock: &FieldNumberLock{Messages: map[string]map[string]uint32{
+			"/a": {"/a/removed": mustFieldTag(t, "/a/b")},
+		}},
+		inMsg: "/a",
+		inKey: "/a/b",
+		want:  mustFieldTag(t, "/a/b_"),
+		wantLock: &FieldNumberLock{Messages: map[string]map[string]uint32{
+			"/a": {
+				"/a/removed": mustFieldTag(t, "/a/b"),
+				"/a/b":       mustFieldTag(t, "/a/b_"),
+			},
+		}},
+	}, {
+		name: "number used in another message",
+		inLock: &FieldNumberLock{Messages: map[string]map[string]uint32{
+			"/c": {"/c/d": mustFieldTag(t, "/a/b")},
+		}},
+		inMsg: "/a",
+		inKey: "/a/b",
+		want:  mustFieldTag(t, "/a/b"),
+		wantLock: &FieldNumberLock{Messages: map[string]map[string]uint32{
+			"/a": {"/a/b": mustFieldTag(t, "/a/b")},
+			"/c": {"/c/d": mustFieldTag(t, "/a/b")},
+		}},
+	}, {
+		name:   "lock without messages",
+		inLock: &FieldNumberLock{},
+		inMsg:  "/a",
+		inKey:  "/a/b",
+		want:   mustFieldTag(t, "/a/b"),
+		wantLock: &FieldNumberLock{Messages: map[string]map[string]uint32{
+			"/a": {"/a/b": mustFieldTag(t, "/a/b")},
+		}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.inLock.fieldNumber(tt.inMsg, tt.inKey)
+			if err != nil {
+				t.Fatalf("fieldNumber(%s, %s): got unexpected error: %v", tt.inMsg, tt.inKey, err)
+			}
+			if got != tt.want {
+				t.Errorf("fieldNumber(%s, %s): got %d, want %d", tt.inMsg, tt.inKey, got, tt.want)
+			}
+			if tt.inLock == nil {
+				return
+			}
+			if diff := cmp.Diff(tt.wantLock.Messages, tt.inLock.Messages); diff != "" {
+				t.Errorf("fieldNumber(%s, %s): did not get expected lock, (-want, +got):\n%s", tt.inMsg, tt.inKey, diff)
+			}
+		})
+	}
+}
+
+func TestParseFieldNumberLock(t *testing.T) {
+	tests := []struct {
+		name             string
+		in               string
+		want             map[string]map[string]uint32
+		wantErrSubstring string
+	}{{
+		name: "valid",
+		in:   `{"messages": {"/a": {"/a/b": 1234, "/a/c": 5678}}}`,
+		want: map[string]map[string]uint32{"/a": {"/a/b": 1234, "/a/c": 5678}},
+	}, {
+		name: "empty",
+		in:   `{}`,
+		want: map[string]map[string]uint32{},
+	}, {
+		name:             "invalid JSON",
+		in:               `{`,
+		wantErrSubstring: "invalid field number lock",
+	}, {
+		name:             "reserved number",
+		in:               `{"messages": {"/a": {"/a/b": 19500}}}`,
+		wantErrSubstring: "invalid number 19500",
+	}, {
+		name:             "zero number",
+		in:               `{"messages": {"/a": {"/a/b": 0}}}`,
+		wantErrSubstring: "invalid number 0",
+	}, {
+		name:             "duplicate number",
+		in:               `{"messages": {"/a": {"/a/b": 1234, "/a/c": 1234}}}`,
+		wantErrSubstring: "number 1234 is used by both",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFieldNumberLock([]byte(tt.in))
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("ParseFieldNumberLock: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got.Messages); diff != "" {
+				t.Errorf("ParseFieldNumberLock: did not get expected lock, (-want, +got):\n%s", diff)
+			}
+
+			b, err := got.Marshal()
+			if err != nil {
+				t.Fatalf("Marshal: got unexpected error: %v", err)
+			}
+			rt, err := ParseFieldNumberLock(b)
+			if err != nil {
+				t.Fatalf("ParseFieldNumberLock: cannot parse marshalled lock: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, rt.Messages); diff != "" {
+				t.Errorf("Marshal: lock did not round trip, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateProto3FieldNumberLock(t *testing.T) {
+	generate := func(lock *FieldNumberLock) string {
+		t.Helper()
+		cg := New("", ygen.IROptions{
+			TransformationOptions: ygen.TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+			},
+		}, ProtoOpts{FieldNumbers: lock})
+		got, errs := cg.Generate([]string{filepath.Join(TestRoot, "testdata", "proto", "proto-test-a.yang")}, nil)
+		if errs != nil {
+			t.Fatalf("Generate: got unexpected errors: %v", errs)
+		}
+		var b strings.Builder
+		for _, pkg := range []string{"openconfig", "openconfig.parent"} {
+			p, ok := got.Packages[pkg]
+			if !ok {
+				t.Fatalf("Generate: did not find package %s", pkg)
+			}
+			b.WriteString(strings.Join(p.Messages, "\n"))
+		}
+		return b.String()
+	}
+
+	unlocked := generate(nil)
+	lock := NewFieldNumberLock()
+	if diff := cmp.Diff(unlocked, generate(lock)); diff != "" {
+		t.Errorf("Generate with empty lock: did not get the same messages as without a lock, (-want, +got):\n%s", diff)
+	}
+	if got, want := lock.Messages["/proto-test-a/parent"]["/proto-test-a/parent/child"], uint32(85413199); got != want {
+		t.Errorf("Generate with empty lock: did not record expected number for child, got: %d, want: %d", got, want)
+	}
+	if _, ok := lock.Messages["/proto-test-a/parent/child"]["/proto-test-a/parent/child/config/uleaf_string"]; !ok {
+		t.Errorf("Generate with empty lock: did not record number for union field, got: %v", lock.Messages["/proto-test-a/parent/child"])
+	}
+
+	// Simulate a number that was assigned before a change in the schema.
+	lock.Messages["/proto-test-a/parent"]["/proto-test-a/parent/child"] = 1001
+	got := generate(lock)
+	if !strings.Contains(got, "parent.Child child = 1001;") {
+		t.Errorf("Generate with lock: did not use locked field number, got:\n%s", got)
+	}
+}
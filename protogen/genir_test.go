@@ -22,6 +22,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/openconfig/gnmi/errdiff"
 	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/genutil"
 	"github.com/openconfig/ygot/ygen"
 	"github.com/openconfig/ygot/ygot"
@@ -236,6 +237,7 @@ func protoIR(nestedDirectories bool) *ygen.IR {
 							ShadowSchemaPath:  "/model/a/single-key/state/dates",
 							LeafrefTargetPath: "",
 							Description:       "",
+							Constraints:       &ygen.LeafConstraints{Range: yang.YangRange{{Min: yang.FromInt(1), Max: yang.FromInt(5)}}},
 						},
 						Type: ygen.LeafListNode,
 						LangType: &ygen.MappedType{
@@ -261,6 +263,7 @@ func protoIR(nestedDirectories bool) *ygen.IR {
 							ShadowSchemaPath:  "/model/a/single-key/state/dates-with-defaults",
 							LeafrefTargetPath: "",
 							Description:       "",
+							Constraints:       &ygen.LeafConstraints{Range: yang.YangRange{{Min: yang.FromInt(1), Max: yang.FromInt(5)}}},
 						},
 						Type: ygen.LeafListNode,
 						LangType: &ygen.MappedType{
@@ -1101,10 +1104,11 @@ func TestGenerateIR(t *testing.T) {
 					IsFakeRoot: true,
 				},
 				"/openconfig-simple/parent": {
-					Name:       "Parent",
-					Type:       ygen.Container,
-					Path:       "/openconfig-simple/parent",
-					SchemaPath: "/parent",
+					Name:        "Parent",
+					Type:        ygen.Container,
+					Path:        "/openconfig-simple/parent",
+					SchemaPath:  "/parent",
+					Description: "I am a parent container\nthat has 4 children.",
 					Fields: map[string]*ygen.NodeDetails{
 						"child": {
 							Name: "child",
@@ -321,6 +321,8 @@ type protoMsgConfig struct {
 	annotateSchemaPaths bool   // annotateSchemaPaths uses the yext protobuf field extensions to annotate the paths from the schema into the output protobuf.
 	annotateEnumNames   bool   // annotateEnumNames uses the yext protobuf enum value extensions to annoate the original YANG name for an enum into the output protobuf.
 	nestedMessages      bool   // nestedMessages indicates whether nested messages should be output for the protobuf schema.
+	// fieldNumbers, if non-nil, is the lock that is used to assign field numbers.
+	fieldNumbers *FieldNumberLock
 }
 
 // writeProto3Message outputs the generated Protobuf3 code for a particular protobuf message. It takes:
@@ -587,7 +589,7 @@ func genProto3Msg(msg *ygen.ParsedDirectory, ir *ygen.IR, cfg *protoMsgConfig, p
 			Name: genutil.MakeNameUnique(field.Name, definedFieldNames),
 		}
 
-		t, err := protoTagForEntry(field.YANGDetails)
+		t, err := cfg.fieldNumbers.fieldNumber(msg.Path, field.YANGDetails.Path)
 		if err != nil {
 			errs = append(errs, fmt.Errorf("proto: could not generate tag for field %s: %v", field.Name, err))
 			continue
@@ -996,7 +998,7 @@ func protoLeafDefinition(leafName string, args *protoDefinitionArgs) (*protoDefi
 	case protoType.IsEnumeratedValue:
 		d.globalEnum = true
 	case protoType.UnionTypes != nil:
-		u, err := unionFieldToOneOf(leafName, args.field, args.field.YANGDetails.Path, protoType, args.ir.Enums, args.cfg.annotateEnumNames, args.cfg.annotateSchemaPaths)
+		u, err := unionFieldToOneOf(leafName, args.field, args.field.YANGDetails.Path, protoType, args.ir.Enums, args.cfg.annotateEnumNames, args.cfg.annotateSchemaPaths, args.cfg.fieldNumbers, args.directory.Path)
 		if err != nil {
 			return nil, err
 		}
@@ -1047,11 +1049,6 @@ func safeProtoIdentifierName(name string) string {
 	return disallowedInProtoIDRegexp.ReplaceAllLiteralString(name, "_")
 }
 
-// protoTagForEntry returns a protobuf tag value for the entry e.
-func protoTagForEntry(n ygen.YANGNodeDetails) (uint32, error) {
-	return fieldTag(n.Path)
-}
-
 // fieldTag takes an input string and calculates a FNV hash for the value. If the
 // hash is in the range 19,000-19,999 or 1-1,000, the input string has _ appended to
 // it and the hash is calculated.
@@ -1143,7 +1140,7 @@ func genListKeyProto(listPackage string, listName string, args *protoDefinitionA
 				// (https://github.com/openconfig/ygot/pull/610#discussion_r781510037).
 				path = kf.YANGDetails.Path
 			}
-			u, err := unionFieldToOneOf(fd.Name, kf, path, scalarType, args.ir.Enums, args.cfg.annotateEnumNames, args.cfg.annotateSchemaPaths)
+			u, err := unionFieldToOneOf(fd.Name, kf, path, scalarType, args.ir.Enums, args.cfg.annotateEnumNames, args.cfg.annotateSchemaPaths, args.cfg.fieldNumbers, km.YANGPath)
 			if err != nil {
 				return nil, fmt.Errorf("error generating type for union list key %s in list %s", k, args.field.YANGDetails.Path)
 			}
@@ -1231,8 +1228,10 @@ type protoUnionField struct {
 // definition, a path argument used to compute the field tag numbers, and a ygen.MappedType
 // containing the proto type that the entry has been mapped to, and returns a definition of a union
 // field within the protobuf message. If the annotateEnumNames boolean is set, then any enumerated types
-// within the union have their original names within the YANG schema appended.
-func unionFieldToOneOf(fieldName string, field *ygen.NodeDetails, path string, mtype *ygen.MappedType, Enums map[string]*ygen.EnumeratedYANGType, annotateEnumNames, annotateSchemaPaths bool) (*protoUnionField, error) {
+// within the union have their original names within the YANG schema appended. The tag numbers
+// are assigned using the lock fieldNumbers, which may be nil, for the message with the schema
+// path msgPath that contains the oneof, or for the message generated for a leaf-list.
+func unionFieldToOneOf(fieldName string, field *ygen.NodeDetails, path string, mtype *ygen.MappedType, Enums map[string]*ygen.EnumeratedYANGType, annotateEnumNames, annotateSchemaPaths bool, fieldNumbers *FieldNumberLock, msgPath string) (*protoUnionField, error) {
 	enums, err := enumInProtoUnionField(fieldName, field, Enums, annotateEnumNames)
 	if err != nil {
 		return nil, err
@@ -1244,6 +1243,11 @@ func unionFieldToOneOf(fieldName string, field *ygen.NodeDetails, path string, m
 	}
 	sort.Strings(typeNames)
 
+	// The fields of a leaf-list of unions are within a separate message.
+	if field.Type == ygen.LeafListNode {
+		msgPath = field.YANGDetails.Path
+	}
+
 	var importGlobalEnums bool
 	var oofs []*protoMsgField
 	for _, t := range typeNames {
@@ -1260,7 +1264,7 @@ func unionFieldToOneOf(fieldName string, field *ygen.NodeDetails, path string, m
 		// such that we have unique inputs for each option. We make the name lower-case
 		// as it is conventional that protobuf field names are lowercase separated by
 		// underscores.
-		ft, err := fieldTag(fmt.Sprintf("%s_%s", path, strings.ToLower(tn)))
+		ft, err := fieldNumbers.fieldNumber(msgPath, fmt.Sprintf("%s_%s", path, strings.ToLower(tn)))
 		if err != nil {
 			return nil, fmt.Errorf("could not calculate tag number for %s, type %s in oneof", field.YANGDetails.Path, tn)
 		}
@@ -0,0 +1,152 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protogen
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygen"
+)
+
+// protoServiceTemplate is the template used to generate the data access
+// service of a YANG module, along with the request and response messages of
+// its RPCs.
+var protoServiceTemplate = mustMakeTemplate("service", `
+// {{ .Name }} provides access to the data of the {{ .Module }} YANG module.
+service {{ .Name }} {
+{{- range $i, $rpc := .RPCs }}
+{{- if ne $i 0 }}
+{{ end }}
+  // Get{{ $rpc.Message }} returns the {{ $rpc.YANGPath }} subtree.
+  rpc Get{{ $rpc.Message }}(Get{{ $rpc.Message }}Request) returns (Get{{ $rpc.Message }}Response);
+  // Set{{ $rpc.Message }} replaces the {{ $rpc.YANGPath }} subtree with the
+  // contents of the request.
+  rpc Set{{ $rpc.Message }}(Set{{ $rpc.Message }}Request) returns (Set{{ $rpc.Message }}Response);
+{{- end }}
+}
+{{ range $rpc := .RPCs }}
+message Get{{ $rpc.Message }}Request {}
+
+message Get{{ $rpc.Message }}Response {
+  {{ if $rpc.IsList }}repeated {{ end }}{{ $rpc.Type }} {{ $rpc.Field }} = 1;
+}
+
+message Set{{ $rpc.Message }}Request {
+  {{ if $rpc.IsList }}repeated {{ end }}{{ $rpc.Type }} {{ $rpc.Field }} = 1;
+}
+
+message Set{{ $rpc.Message }}Response {}
+{{ end -}}
+`)
+
+// protoService describes the data access service that is generated for a
+// YANG module.
+type protoService struct {
+	Name   string      // Name is the name of the service.
+	Module string      // Module is the name of the YANG module whose data is accessed.
+	RPCs   []*protoRPC // RPCs is the set of top-level nodes of the module that are accessed.
+}
+
+// protoRPC describes the pair of RPCs that get and set the subtree of a
+// top-level node of a YANG module.
+type protoRPC struct {
+	Message  string // Message is the name of the message that represents the node, which names the RPCs.
+	Type     string // Type is the type of the field of the request and response messages that contains the node.
+	Field    string // Field is the name of the field of the request and response messages that contains the node.
+	YANGPath string // YANGPath is the schema path of the node.
+	IsList   bool   // IsList indicates whether the node is a list, such that the field is repeated.
+}
+
+// writeProtoServices returns the code of the data access services of the
+// modules within the IR, keyed by the name of the package that they are
+// within. A service is generated for each module, within each package that
+// contains the messages of its top-level nodes, with a Get and a Set RPC for
+// each of those nodes. Since the keys of a keyed list are not within the
+// message of its entries, such lists are represented by the key message
+// that is generated within the fake root, which must therefore exist.
+func writeProtoServices(ir *ygen.IR, basePackageName string, compressPaths, nestedMessages bool) (map[string][]string, error) {
+	var fakeRoot *ygen.ParsedDirectory
+	for _, d := range ir.Directories {
+		if d.IsFakeRoot {
+			fakeRoot = d
+		}
+	}
+	pkgName := func(d *ygen.ParsedDirectory) string {
+		if d.PackageName == "" {
+			return basePackageName
+		}
+		return fmt.Sprintf("%s.%s", basePackageName, d.PackageName)
+	}
+
+	type svcKey struct{ pkg, module string }
+	svcs := map[svcKey]*protoService{}
+	for _, p := range ir.OrderedDirectoryPaths() {
+		d := ir.Directories[p]
+		if d.IsFakeRoot || !outputNestedMessage(d, compressPaths) {
+			continue
+		}
+		rpc := &protoRPC{
+			Message:  d.Name,
+			Type:     d.Name,
+			Field:    safeProtoIdentifierName(p[strings.LastIndex(p, "/")+1:]),
+			YANGPath: d.SchemaPath,
+			IsList:   d.Type == ygen.List || d.Type == ygen.OrderedList,
+		}
+		pkg := pkgName(d)
+		if len(d.ListKeys) != 0 {
+			if fakeRoot == nil {
+				return nil, fmt.Errorf("cannot generate service for keyed list %s without a fake root", d.SchemaPath)
+			}
+			rpc.Type = fmt.Sprintf("%s%s", d.Name, protoListKeyMessageSuffix)
+			if nestedMessages {
+				rpc.Type = fmt.Sprintf("%s.%s", fakeRoot.Name, rpc.Type)
+			}
+			pkg = pkgName(fakeRoot)
+		}
+		k := svcKey{pkg: pkg, module: d.BelongingModule}
+		if svcs[k] == nil {
+			svcs[k] = &protoService{
+				Name:   fmt.Sprintf("%sService", yang.CamelCase(d.BelongingModule)),
+				Module: d.BelongingModule,
+			}
+		}
+		svcs[k].RPCs = append(svcs[k].RPCs, rpc)
+	}
+
+	var keys []svcKey
+	for k := range svcs {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].pkg != keys[j].pkg {
+			return keys[i].pkg < keys[j].pkg
+		}
+		return keys[i].module < keys[j].module
+	})
+
+	code := map[string][]string{}
+	for _, k := range keys {
+		var b bytes.Buffer
+		if err := protoServiceTemplate.Execute(&b, svcs[k]); err != nil {
+			return nil, fmt.Errorf("cannot generate service for module %s: %v", k.module, err)
+		}
+		code[k.pkg] = append(code[k.pkg], b.String())
+	}
+	return code, nil
+}
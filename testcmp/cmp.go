@@ -21,13 +21,17 @@ package testcmp
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 
 	log "github.com/golang/glog"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/gnmi/value"
 	"github.com/openconfig/ygot/testutil"
 	"github.com/openconfig/ygot/ygot"
 	"github.com/openconfig/ygot/ytypes"
+	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 
@@ -167,3 +171,77 @@ func unmarshalStruct(v *gnmipb.TypedValue, dst ygot.GoStruct, ufn ytypes.Unmarsh
 	}
 	return nil
 }
+
+// GoStructDiff returns a human-readable description of the differences
+// between the GoStructs want and got, which must be of the same type, or the
+// empty string if they are equal. Each leaf that differs is described on its
+// own line by its path, prefixed by "-" along with its value in want, and by
+// "+" along with its value in got, such that test failures identify the
+// leaves that caused them. The supplied DiffOpts are used when calculating
+// the differences.
+//
+// NOTE: Do not depend on the output of this being stable.
+func GoStructDiff(want, got ygot.GoStruct, opts ...ygot.DiffOpt) (string, error) {
+	fwd, err := ygot.Diff(want, got, opts...)
+	if err != nil {
+		return "", fmt.Errorf("cannot diff want and got: %v", err)
+	}
+	inv, err := ygot.InverseDiff(want, got, opts...)
+	if err != nil {
+		return "", fmt.Errorf("cannot diff got and want: %v", err)
+	}
+
+	wantVals, gotVals := map[string]string{}, map[string]string{}
+	var paths []string
+	addLeaf := func(m map[string]string, p *gnmipb.Path, v *gnmipb.TypedValue) error {
+		ps, err := ygot.PathToString(p)
+		if err != nil {
+			return fmt.Errorf("cannot convert path %v to string: %v", p, err)
+		}
+		if _, ok := wantVals[ps]; !ok {
+			if _, ok := gotVals[ps]; !ok {
+				paths = append(paths, ps)
+			}
+		}
+		m[ps] = formatTypedValue(v)
+		return nil
+	}
+	for _, u := range inv.GetUpdate() {
+		if err := addLeaf(wantVals, u.GetPath(), u.GetVal()); err != nil {
+			return "", err
+		}
+	}
+	for _, u := range fwd.GetUpdate() {
+		if err := addLeaf(gotVals, u.GetPath(), u.GetVal()); err != nil {
+			return "", err
+		}
+	}
+	if len(paths) == 0 {
+		return "", nil
+	}
+	sort.Strings(paths)
+
+	var b strings.Builder
+	for _, p := range paths {
+		if v, ok := wantVals[p]; ok {
+			b.WriteString(fmt.Sprintf("- %s: %s\n", p, v))
+		}
+		if v, ok := gotVals[p]; ok {
+			b.WriteString(fmt.Sprintf("+ %s: %s\n", p, v))
+		}
+	}
+	return b.String(), nil
+}
+
+// formatTypedValue returns a human-readable representation of the value
+// within the TypedValue v.
+func formatTypedValue(v *gnmipb.TypedValue) string {
+	s, err := value.ToScalar(v)
+	if err != nil {
+		return prototext.MarshalOptions{}.Format(v)
+	}
+	if str, ok := s.(string); ok {
+		return fmt.Sprintf("%q", str)
+	}
+	return fmt.Sprintf("%v", s)
+}
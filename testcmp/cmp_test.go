@@ -404,3 +404,55 @@ func TestIntegration(t *testing.T) {
 		})
 	}
 }
+
+func TestGoStructDiff(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inWant           ygot.GoStruct
+		inGot            ygot.GoStruct
+		want             string
+		wantErrSubstring string
+	}{{
+		desc: "equal structs",
+		inWant: &exampleoc.System{
+			Hostname: ygot.String("box42"),
+		},
+		inGot: &exampleoc.System{
+			Hostname: ygot.String("box42"),
+		},
+	}, {
+		desc: "changed, missing and extra leaves",
+		inWant: &exampleoc.System{
+			Hostname:   ygot.String("box42"),
+			DomainName: ygot.String("example.com"),
+			MotdBanner: ygot.String("hello"),
+		},
+		inGot: &exampleoc.System{
+			Hostname:   ygot.String("box84"),
+			MotdBanner: ygot.String("hello"),
+			BootTime:   ygot.Uint64(42),
+		},
+		want: `- /config/domain-name: "example.com"
+- /config/hostname: "box42"
++ /config/hostname: "box84"
++ /state/boot-time: 42
+`,
+	}, {
+		desc:             "different types",
+		inWant:           &exampleoc.System{},
+		inGot:            &exampleoc.Interface{},
+		wantErrSubstring: "cannot diff want and got",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := GoStructDiff(tt.inWant, tt.inGot)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("GoStructDiff: %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GoStructDiff: did not get expected output, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package golden contains a harness for regression testing code generators
+// against golden files. For each of a set of YANG fixtures, the harness
+// generates code into a temporary directory, and compares its contents
+// against a golden directory. When tests are run with the -update flag, the
+// golden directories are instead replaced with the generated code.
+//
+// The package is separate from testutil such that the -update flag is only
+// registered within the tests that use the harness.
+package golden
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/openconfig/ygot/testutil"
+)
+
+// update specifies whether the golden directories should be replaced with
+// the generated code, rather than being compared against it.
+var update = flag.Bool("update", false, "If set to true, golden directories are replaced with the generated code rather than being compared against it.")
+
+// Fixture describes a set of YANG modules for which code is generated.
+type Fixture struct {
+	// Name is the name of the fixture, which is used as the name of the
+	// subtest in which it is tested, and of its golden directory.
+	Name string
+	// YANGFiles is the set of YANG files for which code is generated.
+	YANGFiles []string
+	// IncludePaths is the set of paths that are searched for the modules
+	// imported or included by YANGFiles.
+	IncludePaths []string
+}
+
+// GenerateFunc generates the code for the fixture f, writing it to the
+// directory dir, which exists and is empty.
+type GenerateFunc func(f *Fixture, dir string) error
+
+// Harness tests a code generator against golden directories.
+type Harness struct {
+	// GoldenDir is the directory that contains the golden directory of
+	// each fixture, named by the fixture's name.
+	GoldenDir string
+	// Generate is the function that generates code for a fixture.
+	Generate GenerateFunc
+	// Update specifies that the golden directories should be replaced with
+	// the generated code, as when the -update flag is set.
+	Update bool
+}
+
+// Run runs a subtest of t for each of the fixtures, in which code is
+// generated for the fixture and compared against its golden directory, or
+// written to its golden directory if the golden directories are being
+// updated.
+func (h *Harness) Run(t *testing.T, fixtures []*Fixture) {
+	t.Helper()
+	for _, f := range fixtures {
+		t.Run(f.Name, func(t *testing.T) {
+			gotDir := t.TempDir()
+			if err := h.Generate(f, gotDir); err != nil {
+				t.Fatalf("cannot generate code for fixture %s: %v", f.Name, err)
+			}
+
+			wantDir := filepath.Join(h.GoldenDir, f.Name)
+			if h.Update || *update {
+				if err := replaceDir(wantDir, gotDir); err != nil {
+					t.Fatalf("cannot update golden directory %s: %v", wantDir, err)
+				}
+				return
+			}
+
+			diff, err := DiffDirs(wantDir, gotDir)
+			if err != nil {
+				t.Fatalf("cannot compare against golden directory %s: %v", wantDir, err)
+			}
+			if diff != "" {
+				t.Errorf("generated code for fixture %s does not match golden directory %s, run with -update to update it:\n%s", f.Name, wantDir, diff)
+			}
+		})
+	}
+}
+
+// DiffDirs compares the regular files within the directory trees want and
+// got, returning a human-readable description of the files that are missing
+// from got, that are unexpected in got, and the unified diff of those whose
+// contents differ, or the empty string if the trees are the same.
+func DiffDirs(want, got string) (string, error) {
+	wantFiles, err := readDir(want)
+	if err != nil {
+		return "", err
+	}
+	gotFiles, err := readDir(got)
+	if err != nil {
+		return "", err
+	}
+
+	names := map[string]bool{}
+	for n := range wantFiles {
+		names[n] = true
+	}
+	for n := range gotFiles {
+		names[n] = true
+	}
+	var ordered []string
+	for n := range names {
+		ordered = append(ordered, n)
+	}
+	sort.Strings(ordered)
+
+	var b strings.Builder
+	for _, n := range ordered {
+		w, wok := wantFiles[n]
+		g, gok := gotFiles[n]
+		switch {
+		case !gok:
+			b.WriteString(fmt.Sprintf("missing file: %s\n", n))
+		case !wok:
+			b.WriteString(fmt.Sprintf("unexpected file: %s\n", n))
+		case w != g:
+			diff, err := testutil.GenerateUnifiedDiff(w, g)
+			if err != nil {
+				return "", fmt.Errorf("cannot diff file %s: %v", n, err)
+			}
+			b.WriteString(fmt.Sprintf("file %s differs (-want, +got):\n%s", n, diff))
+		}
+	}
+	return b.String(), nil
+}
+
+// readDir returns the contents of the regular files within the directory
+// tree dir, keyed by their slash-separated path relative to dir.
+func readDir(dir string) (map[string]string, error) {
+	files := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(b)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot read directory %s: %v", dir, err)
+	}
+	return files, nil
+}
+
+// replaceDir replaces the directory tree dst with a copy of the regular files
+// within the directory tree src.
+func replaceDir(dst, src string) error {
+	files, err := readDir(src)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dst); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+	for n, contents := range files {
+		path := filepath.Join(dst, filepath.FromSlash(n))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/gogen"
+	"github.com/openconfig/ygot/ygen"
+)
+
+// generateGo generates Go code for the fixture f using gogen, writing the
+// structs and enumerated types that are generated to separate files.
+func generateGo(f *Fixture, dir string) error {
+	cg := gogen.New("golden_test", ygen.IROptions{
+		TransformationOptions: ygen.TransformationOpts{
+			ShortenEnumLeafNames:                 true,
+			UseDefiningModuleForTypedefEnumNames: true,
+			EnumerationsUseUnderscores:           true,
+		},
+	}, gogen.GoOpts{
+		GenerateSimpleUnions: true,
+		GenerateDocComments:  true,
+	})
+	code, errs := cg.Generate(f.YANGFiles, f.IncludePaths)
+	if errs != nil {
+		return errs
+	}
+
+	var structs strings.Builder
+	for _, s := range code.Structs {
+		structs.WriteString(s.String())
+	}
+	if err := os.WriteFile(filepath.Join(dir, "structs.txt"), []byte(structs.String()), 0644); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "enums"), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "enums", "enums.txt"), []byte(strings.Join(code.Enums, "")), 0644)
+}
+
+func TestHarness(t *testing.T) {
+	fixtures := []*Fixture{{
+		Name:      "doc-comments",
+		YANGFiles: []string{filepath.Join("..", "..", "testdata", "modules", "doc-comments.yang")},
+	}}
+
+	(&Harness{
+		GoldenDir: "testdata",
+		Generate:  generateGo,
+	}).Run(t, fixtures)
+
+	// Updating golden directories replaces their contents with the generated
+	// code, such that they subsequently match.
+	goldenDir := t.TempDir()
+	stale := filepath.Join(goldenDir, "doc-comments", "stale.txt")
+	if err := os.MkdirAll(filepath.Dir(stale), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(stale, []byte("stale"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	(&Harness{
+		GoldenDir: goldenDir,
+		Generate:  generateGo,
+		Update:    true,
+	}).Run(t, fixtures)
+
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("stale golden file was not removed, got err: %v", err)
+	}
+	diff, err := DiffDirs(filepath.Join("testdata", "doc-comments"), filepath.Join(goldenDir, "doc-comments"))
+	if err != nil {
+		t.Fatalf("DiffDirs: %v", err)
+	}
+	if diff != "" {
+		t.Errorf("updated golden directory does not match generated code:\n%s", diff)
+	}
+}
+
+func TestDiffDirs(t *testing.T) {
+	writeFiles := func(t *testing.T, files map[string]string) string {
+		dir := t.TempDir()
+		for n, contents := range files {
+			path := filepath.Join(dir, filepath.FromSlash(n))
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return dir
+	}
+
+	tests := []struct {
+		desc   string
+		inWant map[string]string
+		inGot  map[string]string
+		want   string
+	}{{
+		desc:   "equal directories",
+		inWant: map[string]string{"a.txt": "a\n", "sub/b.txt": "b\n"},
+		inGot:  map[string]string{"a.txt": "a\n", "sub/b.txt": "b\n"},
+	}, {
+		desc:   "missing and unexpected files",
+		inWant: map[string]string{"a.txt": "a\n", "sub/b.txt": "b\n"},
+		inGot:  map[string]string{"a.txt": "a\n", "c.txt": "c\n"},
+		want:   "unexpected file: c.txt\nmissing file: sub/b.txt\n",
+	}, {
+		desc:   "differing file",
+		inWant: map[string]string{"a.txt": "a"},
+		inGot:  map[string]string{"a.txt": "b"},
+		want:   "file a.txt differs (-want, +got):\n--- want\n+++ got\n@@ -1 +1 @@\n-a\n+b\n",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := DiffDirs(writeFiles(t, tt.inWant), writeFiles(t, tt.inGot))
+			if err != nil {
+				t.Fatalf("DiffDirs: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("DiffDirs: did not get expected output, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+
+	if _, err := DiffDirs(filepath.Join(t.TempDir(), "missing"), t.TempDir()); err == nil {
+		t.Errorf("DiffDirs with missing directory: did not get expected error")
+	}
+}
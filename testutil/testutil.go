@@ -54,6 +54,28 @@ func hasIgnoreTimestamp(opts []ComparerOpt) bool {
 	return false
 }
 
+// IgnorePrefix is a comparison option that ignores differences in the way
+// that the paths of gNMI notifications are split between their prefix and
+// the paths of their updates and deletes. When it is specified, the origin
+// and elements of the prefix of each notification are prepended to the paths
+// of its updates and deletes before notifications are compared, such that
+// only the target of the prefix is compared directly.
+type IgnorePrefix struct{}
+
+// IsComparerOpt marks IgnorePrefix as a ComparerOpt.
+func (IgnorePrefix) IsComparerOpt() {}
+
+// hasIgnorePrefix determines whether the opt slice contains at least one
+// instance of the IgnorePrefix option.
+func hasIgnorePrefix(opts []ComparerOpt) bool {
+	for _, o := range opts {
+		if _, ok := o.(IgnorePrefix); ok {
+			return true
+		}
+	}
+	return false
+}
+
 // CustomComparer allows for a comparer for a particular type to be
 // overloaded such that an external caller can inject a new way to
 // compare a specific field of a gNMI message. It is a map, keyed by
@@ -72,13 +94,7 @@ func (CustomComparer) IsComparerOpt() {}
 // with those in any CustomComparer that is found within the opts slice.
 func comparers(opts []ComparerOpt) []cmp.Option {
 	cmps := map[reflect.Type]cmp.Option{
-		reflect.TypeOf(&gnmipb.TypedValue{}): protocmp.FilterMessage(&gnmipb.TypedValue{},
-			cmp.Comparer(func(a, b protocmp.Message) bool {
-				if _, ok := a["json_ietf_val"]; ok {
-					return JSONIETFComparer(a["json_ietf_val"].([]byte), b["json_ietf_val"].([]byte))
-				}
-				return cmp.Equal(a, b)
-			})),
+		reflect.TypeOf(&gnmipb.TypedValue{}): TypedValueComparer(),
 	}
 
 	for _, o := range opts {
@@ -140,6 +156,9 @@ func SubscribeResponseSetEqual(a, b []*gnmipb.SubscribeResponse) bool {
 // of a and b.
 func NotificationSetEqual(a, b []*gnmipb.Notification, opts ...ComparerOpt) bool {
 	ignoreTS := hasIgnoreTimestamp(opts)
+	if hasIgnorePrefix(opts) {
+		a, b = mergePrefixes(a), mergePrefixes(b)
+	}
 	cmps := comparers(opts)
 	cmps = append(cmps, []cmp.Option{cmpopts.SortSlices(UpdateLess), cmpopts.EquateEmpty(), protocmp.Transform()}...)
 
@@ -186,6 +205,78 @@ func NotificationComparer() cmp.Option {
 	})
 }
 
+// mergePrefixes returns a copy of the notifications ns in which the origin
+// and elements of the prefix of each notification have been prepended to the
+// paths of its updates and deletes, retaining only the target of the prefix.
+func mergePrefixes(ns []*gnmipb.Notification) []*gnmipb.Notification {
+	merged := make([]*gnmipb.Notification, 0, len(ns))
+	for _, n := range ns {
+		m := proto.Clone(n).(*gnmipb.Notification)
+		if pfx := m.GetPrefix(); pfx != nil {
+			for _, u := range m.GetUpdate() {
+				u.Path = mergePrefix(pfx, u.GetPath())
+			}
+			for i, d := range m.GetDelete() {
+				m.Delete[i] = mergePrefix(pfx, d)
+			}
+			m.Prefix = nil
+			if pfx.GetTarget() != "" {
+				m.Prefix = &gnmipb.Path{Target: pfx.GetTarget()}
+			}
+		}
+		merged = append(merged, m)
+	}
+	return merged
+}
+
+// mergePrefix returns the path formed by prepending the origin and elements
+// of the prefix pfx to the path p.
+func mergePrefix(pfx, p *gnmipb.Path) *gnmipb.Path {
+	origin := p.GetOrigin()
+	if origin == "" {
+		origin = pfx.GetOrigin()
+	}
+	return &gnmipb.Path{
+		Origin: origin,
+		Elem:   append(append([]*gnmipb.PathElem{}, pfx.GetElem()...), p.GetElem()...),
+	}
+}
+
+// TypedValueComparer returns a cmp.Option that compares gnmipb.TypedValue
+// messages such that JSON and JSON IETF values are considered equal if they
+// contain the same JSON document, regardless of its formatting and of the
+// order of its object members. It must be used alongside protocmp.Transform.
+func TypedValueComparer() cmp.Option {
+	return protocmp.FilterMessage(&gnmipb.TypedValue{},
+		cmp.Comparer(func(a, b protocmp.Message) bool {
+			for _, f := range []string{"json_ietf_val", "json_val"} {
+				av, aok := a[f].([]byte)
+				bv, bok := b[f].([]byte)
+				if aok && bok {
+					return JSONIETFComparer(av, bv)
+				}
+			}
+			return cmp.Equal(a, b)
+		}))
+}
+
+// TypedValueSetComparer returns a cmp.Option that compares slices of
+// gnmipb.TypedValue messages, such as the elements of a leaf-list, without
+// regard to their order. It must be used alongside protocmp.Transform.
+func TypedValueSetComparer() cmp.Option {
+	return cmp.Options{
+		cmpopts.SortSlices(TypedValueLess),
+		TypedValueComparer(),
+	}
+}
+
+// TypedValueLess compares the value of the gNMI TypedValues a and b,
+// returning true if a is less than b. It can be used with cmpopts.SortSlices
+// to sort slices of TypedValues.
+func TypedValueLess(a, b *gnmipb.TypedValue) bool {
+	return typedValueLess(a, b)
+}
+
 // JSONIETFComparer compares the two provided JSON IETF TypedValues to
 // determine whether their contents are the same. If either value is
 // invalid JSON, the function returns false.
@@ -681,6 +681,58 @@ func TestNotificationSetEqual(t *testing.T) {
 			}},
 		}},
 		want: true,
+	}, {
+		name: "unequal sets: different prefixes",
+		inA: []*gnmipb.Notification{{
+			Timestamp: 42,
+			Prefix:    mustPath("/system"),
+			Update: []*gnmipb.Update{{
+				Path: mustPath("config/hostname"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{"box42"}},
+			}},
+		}},
+		inB: []*gnmipb.Notification{{
+			Timestamp: 42,
+			Update: []*gnmipb.Update{{
+				Path: mustPath("/system/config/hostname"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{"box42"}},
+			}},
+		}},
+		want: false,
+	}, {
+		name: "equal sets: different prefixes ignored",
+		inA: []*gnmipb.Notification{{
+			Timestamp: 42,
+			Prefix:    &gnmipb.Path{Target: "dut", Elem: mustPath("/system").Elem},
+			Update: []*gnmipb.Update{{
+				Path: mustPath("config/hostname"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{"box42"}},
+			}},
+			Delete: []*gnmipb.Path{mustPath("config/domain-name")},
+		}},
+		inB: []*gnmipb.Notification{{
+			Timestamp: 42,
+			Prefix:    &gnmipb.Path{Target: "dut"},
+			Update: []*gnmipb.Update{{
+				Path: mustPath("/system/config/hostname"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{"box42"}},
+			}},
+			Delete: []*gnmipb.Path{mustPath("/system/config/domain-name")},
+		}},
+		inOpts: []ComparerOpt{IgnorePrefix{}},
+		want:   true,
+	}, {
+		name: "unequal sets: different targets with prefixes ignored",
+		inA: []*gnmipb.Notification{{
+			Timestamp: 42,
+			Prefix:    &gnmipb.Path{Target: "dut1"},
+		}},
+		inB: []*gnmipb.Notification{{
+			Timestamp: 42,
+			Prefix:    &gnmipb.Path{Target: "dut2"},
+		}},
+		inOpts: []ComparerOpt{IgnorePrefix{}},
+		want:   false,
 	}}
 
 	for _, tt := range tests {
@@ -1967,3 +2019,56 @@ func TestTypedValueLess(t *testing.T) {
 		})
 	}
 }
+
+func TestTypedValueComparer(t *testing.T) {
+	tests := []struct {
+		name string
+		inA  []*gnmipb.TypedValue
+		inB  []*gnmipb.TypedValue
+		want bool
+	}{{
+		name: "equal JSON IETF values with different formatting",
+		inA:  []*gnmipb.TypedValue{jsonIETF(`{"a": 1, "b": "two"}`)},
+		inB:  []*gnmipb.TypedValue{jsonIETF(`{"b":"two","a":1}`)},
+		want: true,
+	}, {
+		name: "equal JSON values with different formatting",
+		inA:  []*gnmipb.TypedValue{{Value: &gnmipb.TypedValue_JsonVal{[]byte(`{"a": 1}`)}}},
+		inB:  []*gnmipb.TypedValue{{Value: &gnmipb.TypedValue_JsonVal{[]byte(`{ "a":1 }`)}}},
+		want: true,
+	}, {
+		name: "unequal JSON IETF values",
+		inA:  []*gnmipb.TypedValue{jsonIETF(`{"a": 1}`)},
+		inB:  []*gnmipb.TypedValue{jsonIETF(`{"a": 2}`)},
+		want: false,
+	}, {
+		name: "JSON IETF and JSON values",
+		inA:  []*gnmipb.TypedValue{jsonIETF(`{"a": 1}`)},
+		inB:  []*gnmipb.TypedValue{{Value: &gnmipb.TypedValue_JsonVal{[]byte(`{"a": 1}`)}}},
+		want: false,
+	}, {
+		name: "equal scalar values in different order",
+		inA: []*gnmipb.TypedValue{
+			{Value: &gnmipb.TypedValue_StringVal{"a"}},
+			{Value: &gnmipb.TypedValue_UintVal{42}},
+		},
+		inB: []*gnmipb.TypedValue{
+			{Value: &gnmipb.TypedValue_UintVal{42}},
+			{Value: &gnmipb.TypedValue_StringVal{"a"}},
+		},
+		want: true,
+	}, {
+		name: "unequal scalar values",
+		inA:  []*gnmipb.TypedValue{{Value: &gnmipb.TypedValue_UintVal{42}}},
+		inB:  []*gnmipb.TypedValue{{Value: &gnmipb.TypedValue_UintVal{84}}},
+		want: false,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cmp.Equal(tt.inA, tt.inB, TypedValueSetComparer(), protocmp.Transform()); got != tt.want {
+				t.Errorf("cmp.Equal(%v, %v, TypedValueSetComparer()): got %v, want %v", tt.inA, tt.inB, got, tt.want)
+			}
+		})
+	}
+}
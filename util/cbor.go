@@ -0,0 +1,424 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// CBOR major types, as per RFC8949 section 3.1.
+const (
+	cborUint   byte = 0
+	cborNint   byte = 1
+	cborBytes  byte = 2
+	cborText   byte = 3
+	cborArray  byte = 4
+	cborMap    byte = 5
+	cborTag    byte = 6
+	cborSimple byte = 7
+)
+
+// maxCBORDepth is the maximum nesting depth of the data items that are
+// decoded by UnmarshalCBOR.
+const maxCBORDepth = 1024
+
+// CBORTag is a tagged CBOR data item, as per RFC8949 section 3.4.
+type CBORTag struct {
+	// Number is the tag number.
+	Number uint64
+	// Content is the data item that is enclosed by the tag.
+	Content any
+}
+
+// MarshalCBOR returns the CBOR encoding of v, using the deterministic
+// encoding requirements of RFC8949 section 4.2.1. v, and the values that it
+// contains, must be one of the following types:
+//   - nil, which is encoded as the simple value null.
+//   - bool.
+//   - int, int64, uint or uint64, which are encoded as unsigned or negative
+//     integers.
+//   - float64, which is encoded as a double-precision float.
+//   - string, which is encoded as a text string.
+//   - []byte, which is encoded as a byte string.
+//   - []any, which is encoded as an array.
+//   - map[any]any, which is encoded as a map whose members are sorted by the
+//     bytewise order of the encodings of their keys.
+//   - CBORTag, which is encoded as a tagged data item.
+func MarshalCBOR(v any) ([]byte, error) {
+	return appendCBOR(nil, v)
+}
+
+// appendCBOR appends the CBOR encoding of v to b.
+func appendCBOR(b []byte, v any) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return append(b, cborSimple<<5|22), nil
+	case bool:
+		if v {
+			return append(b, cborSimple<<5|21), nil
+		}
+		return append(b, cborSimple<<5|20), nil
+	case int:
+		return appendCBORInt(b, int64(v)), nil
+	case int64:
+		return appendCBORInt(b, v), nil
+	case uint:
+		return appendCBORHead(b, cborUint, uint64(v)), nil
+	case uint64:
+		return appendCBORHead(b, cborUint, v), nil
+	case float64:
+		b = append(b, cborSimple<<5|27)
+		return binary.BigEndian.AppendUint64(b, math.Float64bits(v)), nil
+	case string:
+		return append(appendCBORHead(b, cborText, uint64(len(v))), v...), nil
+	case []byte:
+		return append(appendCBORHead(b, cborBytes, uint64(len(v))), v...), nil
+	case []any:
+		b = appendCBORHead(b, cborArray, uint64(len(v)))
+		for _, e := range v {
+			var err error
+			if b, err = appendCBOR(b, e); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case map[any]any:
+		members := make([][]byte, 0, len(v))
+		for k, e := range v {
+			m, err := appendCBOR(nil, k)
+			if err != nil {
+				return nil, err
+			}
+			keyLen := len(m)
+			if m, err = appendCBOR(m, e); err != nil {
+				return nil, err
+			}
+			members = append(members, m[:keyLen:keyLen], m[keyLen:])
+		}
+		// Members are sorted by their encoded keys, which are held at the
+		// even indices of members.
+		idx := make([]int, len(v))
+		for i := range idx {
+			idx[i] = 2 * i
+		}
+		sort.Slice(idx, func(i, j int) bool { return bytes.Compare(members[idx[i]], members[idx[j]]) < 0 })
+		b = appendCBORHead(b, cborMap, uint64(len(v)))
+		for _, i := range idx {
+			b = append(append(b, members[i]...), members[i+1]...)
+		}
+		return b, nil
+	case CBORTag:
+		return appendCBOR(appendCBORHead(b, cborTag, v.Number), v.Content)
+	}
+	return nil, fmt.Errorf("cannot encode value of type %T as CBOR", v)
+}
+
+// appendCBORInt appends the CBOR encoding of the integer i to b.
+func appendCBORInt(b []byte, i int64) []byte {
+	if i < 0 {
+		return appendCBORHead(b, cborNint, uint64(-1-i))
+	}
+	return appendCBORHead(b, cborUint, uint64(i))
+}
+
+// appendCBORHead appends the initial byte, and any following argument bytes,
+// of a data item of the major type major with the argument n to b, using the
+// shortest possible form.
+func appendCBORHead(b []byte, major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return append(b, major<<5|byte(n))
+	case n <= math.MaxUint8:
+		return append(b, major<<5|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, major<<5|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, major<<5|26), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(b, major<<5|27), n)
+}
+
+// UnmarshalCBOR decodes the single CBOR data item that is contained within
+// data. Data items are decoded to the types that are accepted by
+// MarshalCBOR, with unsigned integers being decoded to uint64 and negative
+// integers being decoded to int64, and floats of any precision being decoded
+// to float64. Indefinite-length strings, arrays and maps are decoded to the
+// same types as their definite-length forms. Simple values other than false,
+// true and null are not supported. Map keys must be integers or text
+// strings.
+func UnmarshalCBOR(data []byte) (any, error) {
+	d := &cborDecoder{data: data}
+	v, err := d.decode(0)
+	if err != nil {
+		return nil, err
+	}
+	if d.off != len(d.data) {
+		return nil, fmt.Errorf("unexpected %d trailing bytes after CBOR data item", len(d.data)-d.off)
+	}
+	return v, nil
+}
+
+// cborDecoder decodes the CBOR data items within data.
+type cborDecoder struct {
+	// data is the input that is being decoded.
+	data []byte
+	// off is the offset in data of the next byte to be decoded.
+	off int
+}
+
+// decode decodes the data item at the current offset, which is nested
+// within depth other data items.
+func (d *cborDecoder) decode(depth int) (any, error) {
+	if depth > maxCBORDepth {
+		return nil, fmt.Errorf("CBOR data items are nested more than %d deep", maxCBORDepth)
+	}
+	if d.off >= len(d.data) {
+		return nil, fmt.Errorf("unexpected end of CBOR data at offset %d", d.off)
+	}
+	major, info := d.data[d.off]>>5, d.data[d.off]&0x1f
+	switch {
+	case major == cborSimple:
+		return d.simple(info)
+	case info == 31 && major >= cborBytes && major <= cborMap:
+		return d.indefinite(major, depth)
+	}
+	n, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case cborUint:
+		return n, nil
+	case cborNint:
+		if n > math.MaxInt64 {
+			return nil, fmt.Errorf("negative integer -1-%d overflows int64", n)
+		}
+		return -1 - int64(n), nil
+	case cborBytes, cborText:
+		s, err := d.take(n)
+		if err != nil {
+			return nil, err
+		}
+		if major == cborText {
+			return string(s), nil
+		}
+		return append([]byte{}, s...), nil
+	case cborArray:
+		// Each data item is at least one byte long, which bounds the number
+		// of items that can be present.
+		if n > uint64(len(d.data)-d.off) {
+			return nil, fmt.Errorf("CBOR array of %d items exceeds the remaining data", n)
+		}
+		arr := make([]any, 0, n)
+		for i := uint64(0); i < n; i++ {
+			e, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, e)
+		}
+		return arr, nil
+	case cborMap:
+		if n > uint64(len(d.data)-d.off)/2 {
+			return nil, fmt.Errorf("CBOR map of %d members exceeds the remaining data", n)
+		}
+		m := make(map[any]any, n)
+		for i := uint64(0); i < n; i++ {
+			if err := d.member(m, depth); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	}
+	// The remaining major type is a tag.
+	c, err := d.decode(depth + 1)
+	if err != nil {
+		return nil, err
+	}
+	return CBORTag{Number: n, Content: c}, nil
+}
+
+// member decodes the key and value of the map member at the current offset,
+// and adds them to m, which is nested within depth other data items.
+func (d *cborDecoder) member(m map[any]any, depth int) error {
+	k, err := d.decode(depth + 1)
+	if err != nil {
+		return err
+	}
+	switch k.(type) {
+	case uint64, int64, string:
+	default:
+		return fmt.Errorf("unsupported CBOR map key of type %T", k)
+	}
+	if _, ok := m[k]; ok {
+		return fmt.Errorf("duplicate CBOR map key %v", k)
+	}
+	if m[k], err = d.decode(depth + 1); err != nil {
+		return err
+	}
+	return nil
+}
+
+// indefinite decodes the indefinite-length string, array or map of the major
+// type major at the current offset, which is nested within depth other data
+// items. Its items are terminated by the break stop code.
+func (d *cborDecoder) indefinite(major byte, depth int) (any, error) {
+	if depth+1 > maxCBORDepth {
+		return nil, fmt.Errorf("CBOR data items are nested more than %d deep", maxCBORDepth)
+	}
+	d.off++
+	var s []byte
+	arr := []any{}
+	m := map[any]any{}
+	for {
+		if d.off >= len(d.data) {
+			return nil, fmt.Errorf("unexpected end of CBOR data at offset %d", d.off)
+		}
+		if d.data[d.off] == cborSimple<<5|31 {
+			d.off++
+			break
+		}
+		switch major {
+		case cborBytes, cborText:
+			// The chunks of an indefinite-length string are definite-length
+			// strings of the same major type.
+			if d.data[d.off]>>5 != major || d.data[d.off]&0x1f == 31 {
+				return nil, fmt.Errorf("invalid chunk of indefinite-length CBOR string at offset %d", d.off)
+			}
+			n, err := d.head()
+			if err != nil {
+				return nil, err
+			}
+			c, err := d.take(n)
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, c...)
+		case cborArray:
+			e, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, e)
+		case cborMap:
+			if err := d.member(m, depth); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	switch major {
+	case cborBytes:
+		return append([]byte{}, s...), nil
+	case cborText:
+		return string(s), nil
+	case cborArray:
+		return arr, nil
+	}
+	return m, nil
+}
+
+// head decodes the initial byte, and any following argument bytes, of the
+// data item at the current offset, and returns its argument.
+func (d *cborDecoder) head() (uint64, error) {
+	info := d.data[d.off] & 0x1f
+	d.off++
+	if info < 24 {
+		return uint64(info), nil
+	}
+	if info > 27 {
+		return 0, fmt.Errorf("unsupported CBOR additional information %d at offset %d", info, d.off-1)
+	}
+	b, err := d.take(1 << (info - 24))
+	if err != nil {
+		return 0, err
+	}
+	var n uint64
+	for _, c := range b {
+		n = n<<8 | uint64(c)
+	}
+	return n, nil
+}
+
+// simple decodes the simple value or float at the current offset, whose
+// initial byte has the additional information info.
+func (d *cborDecoder) simple(info byte) (any, error) {
+	d.off++
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22:
+		return nil, nil
+	case 25:
+		b, err := d.take(2)
+		if err != nil {
+			return nil, err
+		}
+		return halfToFloat64(binary.BigEndian.Uint16(b)), nil
+	case 26:
+		b, err := d.take(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), nil
+	case 27:
+		b, err := d.take(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+	}
+	return nil, fmt.Errorf("unsupported CBOR simple value %d at offset %d", info, d.off-1)
+}
+
+// take returns the next n bytes of the data, and advances the offset past
+// them.
+func (d *cborDecoder) take(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.off) {
+		return nil, fmt.Errorf("unexpected end of CBOR data at offset %d", d.off)
+	}
+	b := d.data[d.off : d.off+int(n)]
+	d.off += int(n)
+	return b, nil
+}
+
+// halfToFloat64 returns the value of the IEEE 754 half-precision float h.
+func halfToFloat64(h uint16) float64 {
+	exp, mant := int(h>>10)&0x1f, float64(h&0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 0x1f:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		return -f
+	}
+	return f
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+// TestCBOR checks encoding and decoding against the examples of RFC8949
+// Appendix A.
+func TestCBOR(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   any
+		// want is the hex encoding of in.
+		want string
+		// wantDecoded is the value that want is decoded to, if it differs
+		// from in.
+		wantDecoded any
+	}{
+		{desc: "zero", in: uint64(0), want: "00"},
+		{desc: "largest single byte integer", in: uint64(23), want: "17"},
+		{desc: "one byte argument", in: 24, want: "1818", wantDecoded: uint64(24)},
+		{desc: "two byte argument", in: uint64(1000), want: "1903e8"},
+		{desc: "four byte argument", in: uint64(1000000), want: "1a000f4240"},
+		{desc: "largest uint64", in: uint64(math.MaxUint64), want: "1bffffffffffffffff"},
+		{desc: "negative integer", in: int64(-1), want: "20"},
+		{desc: "negative two byte argument", in: int64(-1000), want: "3903e7"},
+		{desc: "smallest int64", in: int64(math.MinInt64), want: "3b7fffffffffffffff"},
+		{desc: "double", in: 1.1, want: "fb3ff199999999999a"},
+		{desc: "false", in: false, want: "f4"},
+		{desc: "true", in: true, want: "f5"},
+		{desc: "null", in: nil, want: "f6"},
+		{desc: "tag", in: CBORTag{Number: 4, Content: []any{int64(-2), uint64(27315)}}, want: "c48221196ab3"},
+		{desc: "empty byte string", in: []byte{}, want: "40"},
+		{desc: "byte string", in: []byte{1, 2, 3, 4}, want: "4401020304"},
+		{desc: "text string", in: "IETF", want: "6449455446"},
+		{desc: "unicode text string", in: "ü", want: "62c3bc"},
+		{desc: "empty array", in: []any{}, want: "80"},
+		{desc: "nested array", in: []any{uint64(1), []any{uint64(2), uint64(3)}}, want: "8201820203"},
+		{desc: "empty map", in: map[any]any{}, want: "a0"},
+		{desc: "map with sorted keys", in: map[any]any{"b": []any{uint64(2)}, "a": uint64(1), uint64(10): "c"}, want: "a30a616361610161628102"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := MarshalCBOR(tt.in)
+			if err != nil {
+				t.Fatalf("MarshalCBOR(%v): got unexpected error: %v", tt.in, err)
+			}
+			if gotHex := hex.EncodeToString(got); gotHex != tt.want {
+				t.Errorf("MarshalCBOR(%v): got %s, want %s", tt.in, gotHex, tt.want)
+			}
+
+			want := tt.wantDecoded
+			if want == nil {
+				want = tt.in
+			}
+			decoded, err := UnmarshalCBOR(got)
+			if err != nil {
+				t.Fatalf("UnmarshalCBOR(%x): got unexpected error: %v", got, err)
+			}
+			if diff := cmp.Diff(want, decoded); diff != "" {
+				t.Errorf("UnmarshalCBOR(%x): did not get expected value, diff(-want, +got):\n%s", got, diff)
+			}
+		})
+	}
+}
+
+func TestUnmarshalCBOR(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               string
+		want             any
+		wantErrSubstring string
+	}{{
+		desc: "half-precision float",
+		in:   "f93e00",
+		want: 1.5,
+	}, {
+		desc: "half-precision subnormal float",
+		in:   "f90001",
+		want: 5.960464477539063e-8,
+	}, {
+		desc: "negative half-precision float",
+		in:   "f9c400",
+		want: -4.0,
+	}, {
+		desc: "single-precision float",
+		in:   "fa47c35000",
+		want: 100000.0,
+	}, {
+		desc: "non-shortest argument",
+		in:   "1b0000000000000001",
+		want: uint64(1),
+	}, {
+		desc:             "truncated data",
+		in:               "1903",
+		wantErrSubstring: "unexpected end of CBOR data",
+	}, {
+		desc:             "trailing bytes",
+		in:               "0000",
+		wantErrSubstring: "unexpected 1 trailing bytes",
+	}, {
+		desc: "indefinite-length array",
+		in:   "9f01820203ff",
+		want: []any{uint64(1), []any{uint64(2), uint64(3)}},
+	}, {
+		desc: "indefinite-length map",
+		in:   "bf616101ff",
+		want: map[any]any{"a": uint64(1)},
+	}, {
+		desc: "indefinite-length strings",
+		in:   "9f7f61616162ff5f4101ff5fffff",
+		want: []any{"ab", []byte{1}, []byte{}},
+	}, {
+		desc:             "indefinite-length string with nested chunk",
+		in:               "7f7fffff",
+		wantErrSubstring: "invalid chunk of indefinite-length CBOR string",
+	}, {
+		desc:             "unterminated indefinite-length array",
+		in:               "9f01",
+		wantErrSubstring: "unexpected end of CBOR data",
+	}, {
+		desc:             "break outside indefinite-length item",
+		in:               "ff",
+		wantErrSubstring: "unsupported CBOR simple value 31",
+	}, {
+		desc:             "undefined",
+		in:               "f7",
+		wantErrSubstring: "unsupported CBOR simple value 23",
+	}, {
+		desc:             "byte string map key",
+		in:               "a14000",
+		wantErrSubstring: "unsupported CBOR map key",
+	}, {
+		desc:             "duplicate map key",
+		in:               "a201010102",
+		wantErrSubstring: "duplicate CBOR map key 1",
+	}, {
+		desc:             "negative integer overflow",
+		in:               "3bffffffffffffffff",
+		wantErrSubstring: "overflows int64",
+	}, {
+		desc:             "array longer than data",
+		in:               "9a00010000",
+		wantErrSubstring: "exceeds the remaining data",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			in, err := hex.DecodeString(tt.in)
+			if err != nil {
+				t.Fatalf("cannot decode test input: %v", err)
+			}
+			got, err := UnmarshalCBOR(in)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("UnmarshalCBOR(%s): %s", tt.in, diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UnmarshalCBOR(%s): did not get expected value, diff(-want, +got):\n%s", tt.in, diff)
+			}
+		})
+	}
+}
+
+func TestMarshalCBORUnsupportedType(t *testing.T) {
+	if _, err := MarshalCBOR(map[any]any{"a": struct{}{}}); err == nil {
+		t.Errorf("MarshalCBOR with unsupported type: got nil error, want error")
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"regexp"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// This file contains functions that check values against the restrictions of
+// a YANG type, as defined in RFC7950 Section 9. They are used by ytypes to
+// validate GoStructs, and can be used directly by consumers that hold values
+// that are not within a GoStruct, such that the same semantics are applied.
+// Errors returned by the functions are of kind ErrValidation, and those that
+// report a violated range, length or pattern restriction are also of the
+// corresponding constraint kind, e.g., ErrRangeConstraint.
+
+// ValueInRanges reports whether val falls within any of the ranges in yrs. It
+// always returns true if yrs is empty.
+func ValueInRanges(yrs yang.YangRange, val yang.Number) bool {
+	if len(yrs) == 0 {
+		return true
+	}
+	for _, yr := range yrs {
+		if (val.Less(yr.Max) || val.Equal(yr.Max)) && (yr.Min.Less(val) || yr.Min.Equal(val)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateIntRange checks that the signed integer val satisfies the range
+// restrictions of t, if any.
+func ValidateIntRange(t *yang.YangType, val int64) error {
+	if !ValueInRanges(t.Range, yang.FromInt(val)) {
+		return ConstraintErrorf(ErrRangeConstraint, "signed integer value %v is outside specified ranges", val)
+	}
+	return nil
+}
+
+// ValidateUintRange checks that the unsigned integer val satisfies the range
+// restrictions of t, if any.
+func ValidateUintRange(t *yang.YangType, val uint64) error {
+	if !ValueInRanges(t.Range, yang.FromUint(val)) {
+		return ConstraintErrorf(ErrRangeConstraint, "unsigned integer value %v is outside specified ranges", val)
+	}
+	return nil
+}
+
+// ValidateDecimalRange checks that the decimal val satisfies the range
+// restrictions of t, if any.
+func ValidateDecimalRange(t *yang.YangType, val float64) error {
+	if !ValueInRanges(t.Range, yang.FromFloat(val)) {
+		return ConstraintErrorf(ErrRangeConstraint, "decimal value %v is outside specified ranges", val)
+	}
+	return nil
+}
+
+// ValidateFractionDigits checks that the string representation of the
+// decimal64 value s has no more fractional digits than are allowed by the
+// fraction-digits statement of t, returning the value parsed from s.
+func ValidateFractionDigits(t *yang.YangType, s string) (yang.Number, error) {
+	n, err := yang.ParseDecimal(s, uint8(t.FractionDigits))
+	if err != nil {
+		return yang.Number{}, KindErrorf(ErrValidation, "invalid decimal64 value %q with fraction-digits %d: %v", s, t.FractionDigits, err)
+	}
+	return n, nil
+}
+
+// ValidateDecimalString checks that the string representation of the
+// decimal64 value s satisfies the fraction-digits and range restrictions of
+// t.
+func ValidateDecimalString(t *yang.YangType, s string) error {
+	n, err := ValidateFractionDigits(t, s)
+	if err != nil {
+		return err
+	}
+	if !ValueInRanges(t.Range, n) {
+		return ConstraintErrorf(ErrRangeConstraint, "decimal value %s is outside specified ranges", s)
+	}
+	return nil
+}
+
+// ValidateLength checks that length satisfies the length restrictions of t,
+// if any.
+func ValidateLength(t *yang.YangType, length uint64) error {
+	if !ValueInRanges(t.Length, yang.FromUint(length)) {
+		return ConstraintErrorf(ErrLengthConstraint, "length %d is outside range %v", length, t.Length)
+	}
+	return nil
+}
+
+// ValidateStringLength checks that the number of characters in s satisfies
+// the length restrictions of t, if any.
+func ValidateStringLength(t *yang.YangType, s string) error {
+	return ValidateLength(t, uint64(utf8.RuneCountInString(s)))
+}
+
+// ValidatePattern checks that s matches each of the pattern restrictions of
+// t, if any. POSIX patterns are used if they are specified within t,
+// otherwise the W3C XML Schema patterns of t are used, as per
+// SanitizedPattern.
+func ValidatePattern(t *yang.YangType, s string) error {
+	patterns, isPOSIX := SanitizedPattern(t)
+	return matchPatterns(patterns, isPOSIX, s)
+}
+
+// ValidateW3CPattern checks that s matches each of the supplied W3C XML
+// Schema regular expressions, as used by the YANG pattern statement.
+func ValidateW3CPattern(patterns []string, s string) error {
+	var fixed []string
+	for _, p := range patterns {
+		fixed = append(fixed, fixYangRegexp(p))
+	}
+	return matchPatterns(fixed, false, s)
+}
+
+// ValidatePOSIXPattern checks that s matches each of the supplied POSIX
+// regular expressions, as used by the openconfig-extensions posix-pattern
+// statement.
+func ValidatePOSIXPattern(patterns []string, s string) error {
+	return matchPatterns(patterns, true, s)
+}
+
+// ValidateStringRestrictions checks that s satisfies the length and pattern
+// restrictions of t, if any.
+func ValidateStringRestrictions(t *yang.YangType, s string) error {
+	if err := ValidateStringLength(t, s); err != nil {
+		return err
+	}
+	return ValidatePattern(t, s)
+}
+
+// matchPatterns checks that s matches each of the Go regular expressions in
+// patterns, which are compiled using POSIX semantics if isPOSIX is set.
+func matchPatterns(patterns []string, isPOSIX bool, s string) error {
+	for _, p := range patterns {
+		r, err := CompilePattern(p, isPOSIX)
+		if err != nil {
+			return err
+		}
+		if !r.MatchString(s) {
+			return ConstraintErrorf(ErrPatternConstraint, "%q does not match regular expression pattern %q", s, r)
+		}
+	}
+	return nil
+}
+
+// reCache is the global regexp cache used for speeding up the validation of
+// pattern-restricted strings.
+var reCache = newRegexpCache()
+
+// regexpCache stores previously-compiled Regexp objects.
+// This helps the performance of validation of, say, a large prefix
+// list that have the same pattern specification.
+//
+// # Concurrency Requirements
+//
+// Only the regexp cache map has to be protected by mutexes, since
+// a Regexp is safe for concurrent use by multiple goroutines:
+// https://golang.org/src/regexp/regexp.go
+type regexpCache struct {
+	posixMu sync.RWMutex
+	posix   map[string]*regexp.Regexp
+
+	re2Mu sync.RWMutex
+	re2   map[string]*regexp.Regexp
+}
+
+// newRegexpCache returns a regexpCache with all fields in a useable, empty
+// state.
+func newRegexpCache() *regexpCache {
+	return &regexpCache{
+		posix: map[string]*regexp.Regexp{},
+		re2:   map[string]*regexp.Regexp{},
+	}
+}
+
+// CompilePattern returns the compiled Go regular expression for pattern,
+// using POSIX semantics if isPOSIX is set. Compiled expressions are cached,
+// such that repeated validation against the same pattern is fast.
+func CompilePattern(pattern string, isPOSIX bool) (*regexp.Regexp, error) {
+	return reCache.compilePattern(pattern, isPOSIX)
+}
+
+// compilePattern returns the compiled regex for the given regex
+// pattern. It caches previous look-ups for faster performance.
+// Go's regexp implementation might be relatively slow compared to other
+// languages: https://github.com/golang/go/issues/11646
+func (c *regexpCache) compilePattern(pattern string, isPOSIX bool) (*regexp.Regexp, error) {
+	regexCache := c.re2
+	regexMutex := &c.re2Mu
+	regexCompile := regexp.Compile
+	if isPOSIX {
+		regexCache = c.posix
+		regexMutex = &c.posixMu
+		regexCompile = regexp.CompilePOSIX
+	}
+
+	// Attempt to read a previously cached regexp.Regexp.
+	if re := func() *regexp.Regexp {
+		regexMutex.RLock()
+		defer regexMutex.RUnlock()
+		return regexCache[pattern]
+	}(); re != nil {
+		return re, nil
+	}
+
+	// Read unsuccessful (cache-miss). Compile and populate the cache.
+	re, err := regexCompile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	// Multiple unsuccessful readers might try to populate their own
+	// compiled regexp.Regexp objects into the same cache entry.
+	// This is ok, as since any regexp.Regexp value for the same cache
+	// entry is equivalent, it does not matter which compiled instance is
+	// introduced into the map, or returned to the caller.
+	regexMutex.Lock()
+	defer regexMutex.Unlock()
+	regexCache[pattern] = re
+	return re, nil
+}
@@ -0,0 +1,203 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+func TestValidateRanges(t *testing.T) {
+	intType := &yang.YangType{
+		Kind:  yang.Yint32,
+		Range: yang.YangRange{{Min: yang.FromInt(-10), Max: yang.FromInt(-1)}, {Min: yang.FromInt(10), Max: yang.FromInt(20)}},
+	}
+	uintType := &yang.YangType{
+		Kind:  yang.Yuint8,
+		Range: yang.YangRange{{Min: yang.FromInt(1), Max: yang.FromInt(100)}},
+	}
+	decType := &yang.YangType{
+		Kind:           yang.Ydecimal64,
+		FractionDigits: 2,
+		Range:          yang.YangRange{{Min: mustParseDecimal("0.5", 2), Max: mustParseDecimal("2.25", 2)}},
+	}
+
+	tests := []struct {
+		desc             string
+		inFn             func() error
+		wantErrSubstring string
+		wantConstraint   error
+	}{{
+		desc: "int in first range",
+		inFn: func() error { return ValidateIntRange(intType, -5) },
+	}, {
+		desc: "int at range boundary",
+		inFn: func() error { return ValidateIntRange(intType, 20) },
+	}, {
+		desc:             "int between ranges",
+		inFn:             func() error { return ValidateIntRange(intType, 0) },
+		wantErrSubstring: "signed integer value 0 is outside specified ranges",
+		wantConstraint:   ErrRangeConstraint,
+	}, {
+		desc: "int with no range",
+		inFn: func() error { return ValidateIntRange(&yang.YangType{Kind: yang.Yint64}, 42) },
+	}, {
+		desc: "uint in range",
+		inFn: func() error { return ValidateUintRange(uintType, 100) },
+	}, {
+		desc:             "uint outside range",
+		inFn:             func() error { return ValidateUintRange(uintType, 0) },
+		wantErrSubstring: "unsigned integer value 0 is outside specified ranges",
+		wantConstraint:   ErrRangeConstraint,
+	}, {
+		desc: "decimal in range",
+		inFn: func() error { return ValidateDecimalRange(decType, 1.5) },
+	}, {
+		desc:             "decimal outside range",
+		inFn:             func() error { return ValidateDecimalRange(decType, 2.5) },
+		wantErrSubstring: "decimal value 2.5 is outside specified ranges",
+		wantConstraint:   ErrRangeConstraint,
+	}, {
+		desc: "decimal string in range",
+		inFn: func() error { return ValidateDecimalString(decType, "2.25") },
+	}, {
+		desc:             "decimal string outside range",
+		inFn:             func() error { return ValidateDecimalString(decType, "0.25") },
+		wantErrSubstring: "decimal value 0.25 is outside specified ranges",
+		wantConstraint:   ErrRangeConstraint,
+	}, {
+		desc:             "decimal string with too many fraction digits",
+		inFn:             func() error { return ValidateDecimalString(decType, "1.125") },
+		wantErrSubstring: `invalid decimal64 value "1.125" with fraction-digits 2`,
+	}, {
+		desc:             "invalid decimal string",
+		inFn:             func() error { return ValidateDecimalString(decType, "one") },
+		wantErrSubstring: `invalid decimal64 value "one"`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.inFn()
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("did not get expected error, %s", diff)
+			}
+			if err != nil && !errors.Is(err, ErrValidation) {
+				t.Errorf("got error %v, want error of kind ErrValidation", err)
+			}
+			if tt.wantConstraint != nil && !errors.Is(err, tt.wantConstraint) {
+				t.Errorf("got error %v, want error of kind %v", err, tt.wantConstraint)
+			}
+		})
+	}
+}
+
+func TestValidateStringRestrictions(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inType           *yang.YangType
+		inValue          string
+		wantErrSubstring string
+	}{{
+		desc:    "no restrictions",
+		inType:  &yang.YangType{Kind: yang.Ystring},
+		inValue: "anything",
+	}, {
+		desc: "length in range counts characters",
+		inType: &yang.YangType{
+			Kind:   yang.Ystring,
+			Length: yang.YangRange{{Min: yang.FromInt(1), Max: yang.FromInt(3)}},
+		},
+		inValue: "äöü",
+	}, {
+		desc: "too long",
+		inType: &yang.YangType{
+			Kind:   yang.Ystring,
+			Length: yang.YangRange{{Min: yang.FromInt(1), Max: yang.FromInt(3)}},
+		},
+		inValue:          "abcd",
+		wantErrSubstring: "length 4 is outside range 1..3",
+	}, {
+		desc:    "W3C pattern is anchored",
+		inType:  &yang.YangType{Kind: yang.Ystring, Pattern: []string{"[a-z]+"}},
+		inValue: "abc",
+	}, {
+		desc:             "W3C pattern does not match substring",
+		inType:           &yang.YangType{Kind: yang.Ystring, Pattern: []string{"[a-z]+"}},
+		inValue:          "abc1",
+		wantErrSubstring: `"abc1" does not match regular expression pattern "^([a-z]+)$"`,
+	}, {
+		desc: "POSIX pattern is preferred",
+		inType: &yang.YangType{
+			Kind:         yang.Ystring,
+			Pattern:      []string{"[a-z]+"},
+			POSIXPattern: []string{"^[0-9]+$"},
+		},
+		inValue: "123",
+	}, {
+		desc:             "invalid pattern",
+		inType:           &yang.YangType{Kind: yang.Ystring, POSIXPattern: []string{"^[0-9+$"}},
+		inValue:          "123",
+		wantErrSubstring: "missing closing ]",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := ValidateStringRestrictions(tt.inType, tt.inValue)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Errorf("ValidateStringRestrictions(%q): did not get expected error, %s", tt.inValue, diff)
+			}
+		})
+	}
+}
+
+func TestValidatePatternVariants(t *testing.T) {
+	if err := ValidateW3CPattern([]string{`\d{3}`}, "123"); err != nil {
+		t.Errorf("ValidateW3CPattern: got unexpected error: %v", err)
+	}
+	if err := ValidateW3CPattern([]string{`\d{3}`}, "1234"); err == nil {
+		t.Errorf("ValidateW3CPattern: did not get expected error for unanchored match")
+	}
+	// POSIX patterns are used as specified, and hence are not anchored.
+	if err := ValidatePOSIXPattern([]string{`[0-9]{3}`}, "1234"); err != nil {
+		t.Errorf("ValidatePOSIXPattern: got unexpected error: %v", err)
+	}
+	if err := ValidatePOSIXPattern([]string{`^[0-9]{3}$`}, "1234"); err == nil {
+		t.Errorf("ValidatePOSIXPattern: did not get expected error for anchored pattern")
+	}
+}
+
+func TestCompilePatternCache(t *testing.T) {
+	r1, err := CompilePattern("^a+$", true)
+	if err != nil {
+		t.Fatalf("CompilePattern: got unexpected error: %v", err)
+	}
+	r2, err := CompilePattern("^a+$", true)
+	if err != nil {
+		t.Fatalf("CompilePattern: got unexpected error: %v", err)
+	}
+	if r1 != r2 {
+		t.Errorf("CompilePattern: compiled pattern was not cached")
+	}
+	r3, err := CompilePattern("^a+$", false)
+	if err != nil {
+		t.Fatalf("CompilePattern: got unexpected error: %v", err)
+	}
+	if r1 == r3 {
+		t.Errorf("CompilePattern: POSIX and RE2 patterns share a cache entry")
+	}
+}
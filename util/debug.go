@@ -27,7 +27,8 @@ var (
 	// debugLibrary controls the debugging output from the library data tree
 	// traversal. Since this setting causes global variables to be manipulated
 	// controlling the output of the library, it MUST NOT be used in a setting
-	// whereby thread-safety is required.
+	// whereby thread-safety is required. SetTracer should be used to observe
+	// the library in such settings.
 	debugLibrary = false
 	// debugSchema controls the debugging output from the library from schema
 	// matching code. Generates lots of output, so this should be used
@@ -42,11 +43,20 @@ var (
 
 // DbgPrint prints v if the package global variable debugLibrary is set.
 // v has the same format as Printf. A trailing newline is added to the output.
+// The message is also emitted to the Tracer registered using SetTracer, if
+// any.
 func DbgPrint(v ...interface{}) {
-	if !debugLibrary {
+	tracing := TracingEnabled()
+	if !debugLibrary && !tracing {
 		return
 	}
 	out := fmt.Sprintf(v[0].(string), v[1:]...)
+	if tracing {
+		traceMessage(out)
+	}
+	if !debugLibrary {
+		return
+	}
 	if len(out) > maxCharsPerLine {
 		out = out[:maxCharsPerLine]
 	}
@@ -61,9 +71,13 @@ func DbgSchema(v ...interface{}) {
 	}
 }
 
-// DbgErr DbgPrints err and returns it.
+// DbgErr DbgPrints err and returns it. err is emitted to the Tracer
+// registered using SetTracer, if any.
 func DbgErr(err error) error {
-	DbgPrint("ERR: " + err.Error())
+	traceError(err)
+	if debugLibrary {
+		DbgPrint("ERR: " + err.Error())
+	}
 	return err
 }
 
@@ -95,12 +109,13 @@ func ResetIndent() {
 }
 
 // ValueStrDebug returns "<not calculated>" if the package global variable
-// debugLibrary is not set. Otherwise, it is the same as ValueStr.
+// debugLibrary is not set and no Tracer is registered. Otherwise, it is the
+// same as ValueStr.
 // Use this function instead of ValueStr for debugging purpose, e.g. when the
 // output is passed to DbgPrint, because ValueStr calls can be the bottleneck
 // for large input.
 func ValueStrDebug(value interface{}) string {
-	if !debugLibrary {
+	if !debugLibrary && !TracingEnabled() {
 		return "<not calculated>"
 	}
 	return ValueStr(value)
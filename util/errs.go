@@ -15,9 +15,112 @@
 package util
 
 import (
+	"errors"
 	"fmt"
 )
 
+// Sentinel errors describing the kind of an error returned by the ygot, ytypes,
+// util and pathtranslate packages. Callers can determine the kind of an error
+// using errors.Is, rather than matching the error's message, e.g.:
+//
+//	if errors.Is(err, util.ErrPathNotFound) { ... }
+//
+// The message of an error is not changed by it having a kind.
+var (
+	// ErrPathNotFound indicates that a path does not exist within a data
+	// or schema tree.
+	ErrPathNotFound = errors.New("path not found")
+	// ErrInvalidPath indicates that a path is malformed.
+	ErrInvalidPath = errors.New("invalid path")
+	// ErrInvalidKey indicates that a list key is missing, or that its value
+	// is invalid.
+	ErrInvalidKey = errors.New("invalid list key")
+	// ErrUnknownField indicates that input data contains a field that does
+	// not exist within the schema.
+	ErrUnknownField = errors.New("unknown field")
+	// ErrValidation indicates that a data tree does not conform to its
+	// schema.
+	ErrValidation = errors.New("validation failed")
+)
+
+// Sentinel errors describing the kind of YANG constraint that a validation
+// error reports as being violated. Errors of these kinds are also of kind
+// ErrValidation.
+var (
+	// ErrRangeConstraint indicates that a numeric value is outside of the
+	// ranges specified by a range statement.
+	ErrRangeConstraint = errors.New("range constraint violated")
+	// ErrLengthConstraint indicates that the length of a value is outside
+	// of the ranges specified by a length statement.
+	ErrLengthConstraint = errors.New("length constraint violated")
+	// ErrPatternConstraint indicates that a string value does not match a
+	// pattern statement.
+	ErrPatternConstraint = errors.New("pattern constraint violated")
+	// ErrLeafrefConstraint indicates that the value of a leafref does not
+	// match the value of any node at its path.
+	ErrLeafrefConstraint = errors.New("leafref constraint violated")
+	// ErrElementsConstraint indicates that the number of entries of a list
+	// or leaf-list violates a min-elements or max-elements statement.
+	ErrElementsConstraint = errors.New("elements constraint violated")
+)
+
+// KindError is an error of a particular kind, which is one of the sentinel
+// errors defined in this package. Both the kind and the underlying error are
+// matched by errors.Is and errors.As, such that status codes of gRPC errors
+// are preserved.
+type KindError struct {
+	// Kind is the kind of the error.
+	Kind error
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the error#Error method, returning the message of the
+// underlying error.
+func (e *KindError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the kind and the underlying error of e.
+func (e *KindError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// WithKind returns err annotated with kind. If err is nil, nil is returned,
+// and if err is already of the supplied kind it is returned unchanged.
+func WithKind(kind, err error) error {
+	if err == nil || errors.Is(err, kind) {
+		return err
+	}
+	return &KindError{Kind: kind, Err: err}
+}
+
+// KindErrorf returns an error of the supplied kind with a message formatted
+// according to format, as per fmt.Errorf.
+func KindErrorf(kind error, format string, a ...any) error {
+	return WithKind(kind, fmt.Errorf(format, a...))
+}
+
+// ConstraintErrorf returns an error of kind ErrValidation, that is also of
+// the supplied constraint kind, such as ErrRangeConstraint, with a message
+// formatted according to format, as per fmt.Errorf.
+func ConstraintErrorf(constraint error, format string, a ...any) error {
+	return WithKind(constraint, KindErrorf(ErrValidation, format, a...))
+}
+
+// ErrsWithKind returns errs with each of its errors annotated with kind. It
+// returns nil if errs is empty.
+func ErrsWithKind(kind error, errs Errors) Errors {
+	if len(errs) == 0 {
+		return nil
+	}
+	out := make(Errors, 0, len(errs))
+	for _, err := range errs {
+		out = append(out, WithKind(kind, err))
+	}
+	return out
+}
+
 // Errors is a slice of error.
 type Errors []error
 
@@ -31,6 +134,12 @@ func (e Errors) String() string {
 	return e.Error()
 }
 
+// Unwrap returns the errors within e, such that errors.Is and errors.As
+// match any of them.
+func (e Errors) Unwrap() []error {
+	return e
+}
+
 // NewErrs returns a slice of error with a single element err.
 // If err is nil, returns nil.
 func NewErrs(err error) Errors {
@@ -88,7 +197,7 @@ func ToString(errors []error) string {
 func PrefixErrors(errs Errors, pfx string) Errors {
 	var nerr Errors
 	for _, err := range errs {
-		nerr = append(nerr, fmt.Errorf("%s: %s", pfx, err))
+		nerr = append(nerr, fmt.Errorf("%s: %w", pfx, err))
 	}
 	return nerr
 }
@@ -201,3 +201,49 @@ func TestUniqueErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestErrorKinds(t *testing.T) {
+	base := errors.New("leaf foo not found")
+	err := WithKind(ErrPathNotFound, base)
+
+	if got, want := err.Error(), base.Error(); got != want {
+		t.Errorf("WithKind: did not get expected message, got: %s, want: %s", got, want)
+	}
+	if !errors.Is(err, ErrPathNotFound) {
+		t.Errorf("WithKind: errors.Is(%v, ErrPathNotFound) = false, want true", err)
+	}
+	if !errors.Is(err, base) {
+		t.Errorf("WithKind: errors.Is(%v, base) = false, want true", err)
+	}
+	if errors.Is(err, ErrInvalidKey) {
+		t.Errorf("WithKind: errors.Is(%v, ErrInvalidKey) = true, want false", err)
+	}
+	var ke *KindError
+	if !errors.As(err, &ke) || ke.Kind != ErrPathNotFound {
+		t.Errorf("WithKind: errors.As(%v) did not return KindError with kind ErrPathNotFound, got: %v", err, ke)
+	}
+
+	if got := WithKind(ErrPathNotFound, err); got != err {
+		t.Errorf("WithKind: error of the same kind was rewrapped, got: %#v", got)
+	}
+	if got := WithKind(ErrPathNotFound, nil); got != nil {
+		t.Errorf("WithKind(nil): got %v, want nil", got)
+	}
+
+	errs := Errors{errors.New("one"), KindErrorf(ErrInvalidKey, "bad key %s", "k")}
+	if !errors.Is(errs, ErrInvalidKey) {
+		t.Errorf("errors.Is(%v, ErrInvalidKey) = false, want true", errs)
+	}
+	if errors.Is(errs, ErrValidation) {
+		t.Errorf("errors.Is(%v, ErrValidation) = true, want false", errs)
+	}
+	if !errors.Is(ErrsWithKind(ErrValidation, errs), ErrValidation) {
+		t.Errorf("ErrsWithKind: errors did not have kind ErrValidation")
+	}
+	if got := ErrsWithKind(ErrValidation, nil); got != nil {
+		t.Errorf("ErrsWithKind(nil): got %v, want nil", got)
+	}
+	if !errors.Is(PrefixErrors(errs, "prefix"), ErrInvalidKey) {
+		t.Errorf("PrefixErrors: kind of prefixed errors was not retained")
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// ExtensionsFieldName is the name of the field of a GoStruct generated with
+// extension points that holds the extension structs attached to it. An
+// extension struct is a GoStruct of a separately generated package that
+// holds the fields of the nodes that augment the schema node of the
+// GoStruct. The field is a map, keyed by the name of the type of each
+// extension struct, whose values are pointers to the extension structs.
+const ExtensionsFieldName = "ΛExtensions"
+
+// IsYgotExtensions reports whether struct field s is the field holding the
+// extension structs of a GoStruct generated with extension points.
+func IsYgotExtensions(s reflect.StructField) bool {
+	_, ok := s.Tag.Lookup("ygotExtensions")
+	return ok
+}
+
+// extensionStruct is implemented by extension structs, which return the
+// schema of the node that they extend, as it is augmented by the nodes of
+// their fields.
+type extensionStruct interface {
+	ΛExtensionSchema() *yang.Entry
+}
+
+// AttachedExtension is an extension struct attached to a GoStruct.
+type AttachedExtension struct {
+	// Name is the name of the type of the extension struct, by which it
+	// is keyed within the extensions field of the GoStruct.
+	Name string
+	// Value is the pointer to the extension struct.
+	Value reflect.Value
+	// Schema is the schema of the node that is extended, including the
+	// nodes that augment it, which is the parent schema of the fields of
+	// the extension struct.
+	Schema *yang.Entry
+}
+
+// AttachedExtensions returns the extension structs attached to the struct,
+// or pointer to a struct, v, sorted by their name. Extension structs that are
+// nil or that do not supply their schema are not returned.
+func AttachedExtensions(v reflect.Value) []AttachedExtension {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	sf, ok := v.Type().FieldByName(ExtensionsFieldName)
+	if !ok || !IsYgotExtensions(sf) {
+		return nil
+	}
+	ev := v.FieldByIndex(sf.Index)
+	if ev.Kind() != reflect.Map || ev.Len() == 0 {
+		return nil
+	}
+	var exts []AttachedExtension
+	iter := ev.MapRange()
+	for iter.Next() {
+		ext := iter.Value()
+		if ext.Kind() == reflect.Interface {
+			ext = ext.Elem()
+		}
+		if !IsValuePtr(ext) || ext.IsNil() {
+			continue
+		}
+		es, ok := ext.Interface().(extensionStruct)
+		if !ok {
+			continue
+		}
+		exts = append(exts, AttachedExtension{
+			Name:   iter.Key().String(),
+			Value:  ext,
+			Schema: es.ΛExtensionSchema(),
+		})
+	}
+	sort.Slice(exts, func(i, j int) bool { return exts[i].Name < exts[j].Name })
+	return exts
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+const (
+	// OpenConfigExtensionsModule is the name of the module that defines
+	// the OpenConfig YANG extensions.
+	OpenConfigExtensionsModule = "openconfig-extensions"
+	// OpenConfigVersionExtension is the name of the extension that
+	// specifies the semantic version of an OpenConfig module.
+	OpenConfigVersionExtension = "openconfig-version"
+)
+
+// Extension is an instance of a YANG extension statement (RFC7950 Section
+// 7.19) that is used within a schema entry, e.g.,
+// "oc-ext:openconfig-version "1.0.0";".
+type Extension struct {
+	// Prefix is the prefix used to refer to the module that defines the
+	// extension.
+	Prefix string
+	// Module is the name of the module that defines the extension. It is
+	// empty if the prefix cannot be resolved, as is the case when the
+	// schema entry was not produced directly by parsing YANG, e.g., when it
+	// was unmarshalled from the JSON schema stored in generated code.
+	Module string
+	// Name is the name of the extension.
+	Name string
+	// Argument is the argument of the extension statement, if any.
+	Argument string
+	// HasArgument indicates whether the extension statement has an
+	// argument.
+	HasArgument bool
+	// Statement is the parsed statement that the extension was read from.
+	Statement *yang.Statement
+}
+
+// EntryExtensions returns the extension statements that are used within the
+// schema entry e, in the order in which they are specified.
+func EntryExtensions(e *yang.Entry) []*Extension {
+	if e == nil {
+		return nil
+	}
+	var exts []*Extension
+	for _, s := range e.Exts {
+		if s == nil {
+			continue
+		}
+		ext := &Extension{
+			Name:        s.Keyword,
+			Argument:    s.Argument,
+			HasArgument: s.HasArgument,
+			Statement:   s,
+		}
+		if p, n, ok := strings.Cut(s.Keyword, ":"); ok {
+			ext.Prefix, ext.Name = p, n
+		}
+		if e.Node != nil {
+			if m := yang.FindModuleByPrefix(e.Node, ext.Prefix); m != nil {
+				ext.Module = m.Name
+			}
+		}
+		exts = append(exts, ext)
+	}
+	return exts
+}
+
+// FindExtensions returns the extension statements named name that are used
+// within the schema entry e, and are defined by module. module may either be
+// the name of the defining module, or the prefix that is used to refer to it.
+// When the defining module of an extension cannot be resolved, module is
+// compared only to the prefix used.
+func FindExtensions(e *yang.Entry, module, name string) []*Extension {
+	var out []*Extension
+	for _, ext := range EntryExtensions(e) {
+		if ext.Name != name {
+			continue
+		}
+		if ext.Module == module || ext.Prefix == module {
+			out = append(out, ext)
+		}
+	}
+	return out
+}
+
+// FindExtension returns the first extension statement named name, defined by
+// module, that is used within the schema entry e, as per FindExtensions. The
+// bool return value indicates whether such an extension was found.
+func FindExtension(e *yang.Entry, module, name string) (*Extension, bool) {
+	exts := FindExtensions(e, module, name)
+	if len(exts) == 0 {
+		return nil, false
+	}
+	return exts[0], true
+}
+
+// HasExtension reports whether the extension named name, defined by module,
+// is used within the schema entry e.
+func HasExtension(e *yang.Entry, module, name string) bool {
+	_, ok := FindExtension(e, module, name)
+	return ok
+}
+
+// OpenConfigVersion returns the semantic version of the module with schema
+// entry mod, as specified by the openconfig-version extension. The bool
+// return value indicates whether the module specifies a version.
+func OpenConfigVersion(mod *yang.Entry) (string, bool) {
+	for _, ext := range EntryExtensions(mod) {
+		// The extension is matched on its name where the prefix cannot
+		// be resolved, since the prefix used for openconfig-extensions
+		// differs between modules.
+		if ext.Name == OpenConfigVersionExtension && (ext.Module == "" || ext.Module == OpenConfigExtensionsModule) && ext.HasArgument {
+			return ext.Argument, true
+		}
+	}
+	return "", false
+}
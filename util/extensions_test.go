@@ -0,0 +1,163 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+const extensionsTestDefs = `
+module openconfig-extensions {
+  prefix "oc-ext";
+  namespace "urn:oc-ext";
+
+  extension openconfig-version { argument "semver"; }
+  extension telemetry-on-change;
+}
+`
+
+const extensionsTestModule = `
+module device {
+  prefix "dev";
+  namespace "urn:device";
+
+  import openconfig-extensions { prefix "oce"; }
+
+  oce:openconfig-version "1.2.3";
+
+  extension secret;
+
+  container system {
+    leaf password {
+      type string;
+      dev:secret;
+      oce:telemetry-on-change;
+    }
+    leaf hostname { type string; }
+  }
+}
+`
+
+// extensionsTestSchema returns the schema entry for the extensionsTestModule
+// module.
+func extensionsTestSchema(t *testing.T) *yang.Entry {
+	t.Helper()
+	ms := yang.NewModules()
+	for name, in := range map[string]string{"openconfig-extensions.yang": extensionsTestDefs, "device.yang": extensionsTestModule} {
+		if err := ms.Parse(in, name); err != nil {
+			t.Fatalf("cannot parse test module %s: %v", name, err)
+		}
+	}
+	if errs := ms.Process(); len(errs) != 0 {
+		t.Fatalf("cannot process test modules: %v", errs)
+	}
+	m, err := ms.GetModule("device")
+	if err != nil {
+		t.Fatalf("cannot find test module: %v", err)
+	}
+	return m
+}
+
+func TestEntryExtensions(t *testing.T) {
+	mod := extensionsTestSchema(t)
+	password := mod.Dir["system"].Dir["password"]
+
+	exts := EntryExtensions(password)
+	if len(exts) != 2 {
+		t.Fatalf("EntryExtensions: got %d extensions, want 2", len(exts))
+	}
+	if got := exts[1]; got.Prefix != "oce" || got.Module != OpenConfigExtensionsModule || got.Name != "telemetry-on-change" || got.HasArgument {
+		t.Errorf("EntryExtensions: did not get expected extension, got: %+v", got)
+	}
+
+	tests := []struct {
+		desc     string
+		inEntry  *yang.Entry
+		inModule string
+		inName   string
+		want     bool
+	}{{
+		desc:     "extension defined in the same module, by name",
+		inEntry:  password,
+		inModule: "device",
+		inName:   "secret",
+		want:     true,
+	}, {
+		desc:     "extension defined in the same module, by prefix",
+		inEntry:  password,
+		inModule: "dev",
+		inName:   "secret",
+		want:     true,
+	}, {
+		desc:     "imported extension, by module name",
+		inEntry:  password,
+		inModule: OpenConfigExtensionsModule,
+		inName:   "telemetry-on-change",
+		want:     true,
+	}, {
+		desc:     "imported extension, by local prefix",
+		inEntry:  password,
+		inModule: "oce",
+		inName:   "telemetry-on-change",
+		want:     true,
+	}, {
+		desc:     "prefix of defining module is not its local prefix",
+		inEntry:  password,
+		inModule: "oc-ext",
+		inName:   "telemetry-on-change",
+	}, {
+		desc:     "extension not used",
+		inEntry:  mod.Dir["system"].Dir["hostname"],
+		inModule: "device",
+		inName:   "secret",
+	}, {
+		desc:     "nil entry",
+		inModule: "device",
+		inName:   "secret",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := HasExtension(tt.inEntry, tt.inModule, tt.inName); got != tt.want {
+				t.Errorf("HasExtension(%s, %s): got %v, want %v", tt.inModule, tt.inName, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenConfigVersion(t *testing.T) {
+	if got, ok := OpenConfigVersion(extensionsTestSchema(t)); !ok || got != "1.2.3" {
+		t.Errorf("OpenConfigVersion: got %q, %v, want 1.2.3, true", got, ok)
+	}
+
+	// Schema entries without a Node, such as those unmarshalled from JSON,
+	// are matched on the extension name.
+	e := &yang.Entry{
+		Name: "m",
+		Exts: []*yang.Statement{{Keyword: "oc-ext:openconfig-version", Argument: "0.1.0", HasArgument: true}},
+	}
+	if got, ok := OpenConfigVersion(e); !ok || got != "0.1.0" {
+		t.Errorf("OpenConfigVersion: got %q, %v for entry without node, want 0.1.0, true", got, ok)
+	}
+	if ext, ok := FindExtension(e, "oc-ext", OpenConfigVersionExtension); !ok || ext.Module != "" {
+		t.Errorf("FindExtension: got %+v, %v for entry without node, want extension with no module", ext, ok)
+	}
+
+	if _, ok := OpenConfigVersion(&yang.Entry{Name: "m"}); ok {
+		t.Errorf("OpenConfigVersion: got true for module without version, want false")
+	}
+}
@@ -17,7 +17,9 @@ package util
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/openconfig/goyang/pkg/yang"
 	"google.golang.org/protobuf/proto"
@@ -103,6 +105,69 @@ func PathElemSlicesEqual(a, b []*gpb.PathElem) bool {
 	return true
 }
 
+// EqualPathElems reports whether the PathElem slices a and b are equal. If
+// ignoreKeys is true, only the names of the elements are compared, otherwise
+// they are compared as per PathElemSlicesEqual. Nil and empty key maps are
+// considered equal, as are nil and empty slices.
+func EqualPathElems(a, b []*gpb.PathElem, ignoreKeys bool) bool {
+	if !ignoreKeys {
+		return PathElemSlicesEqual(a, b)
+	}
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] == nil || b[i] == nil {
+			if a[i] != b[i] {
+				return false
+			}
+			continue
+		}
+		if a[i].Name != b[i].Name {
+			return false
+		}
+	}
+	return true
+}
+
+// PathHasPrefix reports whether prefix is a prefix of elems. Elements are
+// compared including their keys, such that a wildcard key in elems must also
+// be a wildcard in prefix. An empty prefix is a prefix of all slices.
+func PathHasPrefix(elems, prefix []*gpb.PathElem) bool {
+	if len(elems) < len(prefix) {
+		return false
+	}
+	return PathElemSlicesEqual(elems[:len(prefix)], prefix)
+}
+
+// TrimPathPrefix returns elems with prefix removed, and true if prefix was a
+// prefix of elems as determined by PathHasPrefix. If prefix does not match,
+// elems is returned unmodified along with false. The returned slice shares
+// its elements with elems.
+func TrimPathPrefix(elems, prefix []*gpb.PathElem) ([]*gpb.PathElem, bool) {
+	if !PathHasPrefix(elems, prefix) {
+		return elems, false
+	}
+	return elems[len(prefix):], true
+}
+
+// JoinPathElems returns a new slice containing the elements of each of the
+// supplied slices in turn. Unlike append, the returned slice never shares a
+// backing array with any of the inputs, such that modifying it does not
+// modify the caller's slices. The PathElem messages themselves are not
+// copied.
+func JoinPathElems(elems ...[]*gpb.PathElem) []*gpb.PathElem {
+	var n int
+	for _, e := range elems {
+		n += len(e)
+	}
+	joined := make([]*gpb.PathElem, 0, n)
+	for _, e := range elems {
+		joined = append(joined, e...)
+	}
+	return joined
+}
+
 // PathMatchesPathElemPrefix checks whether prefix is a prefix of path. Both paths
 // must use the gNMI >=0.4.0 PathElem path format.
 // Note: Paths must match exactly, that is if path has a wildcard key,
@@ -121,23 +186,45 @@ func PathMatchesPathElemPrefix(path, prefix *gpb.Path) bool {
 }
 
 // PathMatchesQuery returns whether query is prefix of path.
-// Only the query may contain wildcard name or keys.
-// TODO: Multilevel wildcards ("...") not supported.
+// Only the query may contain wildcards: an element name of "*" matches any
+// single element, a key value of "*" matches any value of the key, and keys
+// that are not specified in the query match any value. An element named "..."
+// is a multi-level wildcard that matches zero or more elements; since query
+// is matched as a prefix of path, a trailing "..." matches any descendant.
 // If either path and query contain nil elements func returns false.
 // Both paths must use the gNMI >=0.4.0 PathElem path format.
+//
+// PathMatchesQuery should be used wherever a concrete path is checked against
+// a wildcarded path, such that the same semantics are applied throughout.
 func PathMatchesQuery(path, query *gpb.Path) bool {
-	if len(path.GetElem()) < len(query.GetElem()) {
-		return false
-	}
 	// Unset Origin fields can match "openconfig", see https://github.com/openconfig/reference/blob/master/rpc/gnmi/mixed-schema.md#special-values-of-origin.
-	if path.Origin != query.Origin && !(path.Origin == "" && query.Origin == "openconfig" || path.Origin == "openconfig" && query.Origin == "") {
+	if path.GetOrigin() != query.GetOrigin() && !(path.GetOrigin() == "" && query.GetOrigin() == "openconfig" || path.GetOrigin() == "openconfig" && query.GetOrigin() == "") {
 		return false
 	}
-	for i, queryElem := range query.Elem {
-		pathElem := path.Elem[i]
-		if queryElem == nil || pathElem == nil {
+	return pathElemsMatchQuery(path.GetElem(), query.GetElem())
+}
+
+// pathElemsMatchQuery returns whether the query elements are a prefix of the
+// path elements, using the wildcard semantics of PathMatchesQuery.
+func pathElemsMatchQuery(path, query []*gpb.PathElem) bool {
+	for i, queryElem := range query {
+		if queryElem == nil {
+			return false
+		}
+		if queryElem.Name == "..." {
+			// Try each number of elements that the multi-level
+			// wildcard could consume.
+			for j := i; j <= len(path); j++ {
+				if pathElemsMatchQuery(path[j:], query[i+1:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if i >= len(path) || path[i] == nil {
 			return false
 		}
+		pathElem := path[i]
 		if queryElem.Name != "*" && queryElem.Name != pathElem.Name {
 			return false
 		}
@@ -404,3 +491,271 @@ func comparePathElem(a, b *gpb.PathElem) CompareRelation {
 
 	return setRelation
 }
+
+// ComparePathOrder returns an integer comparing two gNMI paths in a canonical
+// order. The result is 0 if a and b are equal, -1 if a sorts before b, and +1
+// if a sorts after b. Paths are ordered by their origin, then their target,
+// and then element-wise using ComparePathElemOrder, such that a path sorts
+// before any path that it is a prefix of. A nil path is treated as an empty
+// path.
+func ComparePathOrder(a, b *gpb.Path) int {
+	if c := strings.Compare(a.GetOrigin(), b.GetOrigin()); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.GetTarget(), b.GetTarget()); c != 0 {
+		return c
+	}
+	ae, be := a.GetElem(), b.GetElem()
+	for i := 0; i != min(len(ae), len(be)); i++ {
+		if c := ComparePathElemOrder(ae[i], be[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(ae), len(be))
+}
+
+// ComparePathElemOrder returns an integer comparing two gNMI path elements in
+// a canonical order. The result is 0 if a and b are equal, -1 if a sorts
+// before b, and +1 if a sorts after b. Elements are ordered by name, and then
+// by their keys, which are compared in the order of their sorted key names.
+// Key values that can be parsed as integers sort before those that cannot,
+// and are compared numerically, such that list entries keyed by integers are
+// ordered by value rather than lexically. Other key values, and integers of
+// equal value that are written differently, are compared lexically. An
+// element with a subset of another's keys sorts
+// before it. A nil element sorts before any non-nil element.
+func ComparePathElemOrder(a, b *gpb.PathElem) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	if c := strings.Compare(a.Name, b.Name); c != 0 {
+		return c
+	}
+
+	aKeys, bKeys := sortedKeyNames(a.Key), sortedKeyNames(b.Key)
+	for i := 0; i != min(len(aKeys), len(bKeys)); i++ {
+		if c := strings.Compare(aKeys[i], bKeys[i]); c != 0 {
+			return c
+		}
+		if c := compareKeyValues(a.Key[aKeys[i]], b.Key[bKeys[i]]); c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(aKeys), len(bKeys))
+}
+
+// SortPaths sorts the supplied slice of gNMI paths in place, in the order
+// defined by ComparePathOrder. The sort is stable.
+func SortPaths(paths []*gpb.Path) {
+	sort.SliceStable(paths, func(i, j int) bool {
+		return ComparePathOrder(paths[i], paths[j]) < 0
+	})
+}
+
+// sortedKeyNames returns the names of the keys within the supplied key map,
+// in lexical order.
+func sortedKeyNames(keys map[string]string) []string {
+	names := make([]string, 0, len(keys))
+	for k := range keys {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// compareKeyValues compares two path element key values in a total order.
+// Values that can be parsed as integers sort before those that cannot, and
+// are compared numerically. Values that cannot be parsed as integers, and
+// integers of equal value such as "010" and "10", are compared lexically.
+func compareKeyValues(a, b string) int {
+	if a == b {
+		return 0
+	}
+	an, aok := parseKeyInt(a)
+	bn, bok := parseKeyInt(b)
+	switch {
+	case aok && !bok:
+		return -1
+	case !aok && bok:
+		return 1
+	case aok && bok:
+		if c := an.compare(bn); c != 0 {
+			return c
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+// keyInt is the integer value of a path element key.
+type keyInt struct {
+	// neg indicates that the value is negative, in which case it is held
+	// in i, otherwise it is held in u.
+	neg bool
+	i   int64
+	u   uint64
+}
+
+// parseKeyInt parses the key value s as a decimal integer, returning false
+// if it cannot be parsed as a 64-bit signed or unsigned integer.
+func parseKeyInt(s string) (keyInt, bool) {
+	if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return keyInt{u: u}, true
+	}
+	i, err := strconv.ParseInt(s, 10, 64)
+	switch {
+	case err != nil:
+		return keyInt{}, false
+	case i < 0:
+		return keyInt{neg: true, i: i}, true
+	}
+	return keyInt{u: uint64(i)}, true
+}
+
+// compare returns -1, 0 or +1 depending on whether k is less than, equal to,
+// or greater than o.
+func (k keyInt) compare(o keyInt) int {
+	switch {
+	case k.neg && o.neg:
+		return compareInts(k.i, o.i)
+	case k.neg:
+		return -1
+	case o.neg:
+		return 1
+	}
+	return compareInts(k.u, o.u)
+}
+
+// compareInts returns -1, 0 or +1 depending on whether a is less than, equal
+// to, or greater than b.
+func compareInts[T int | int64 | uint64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// pathReservedChars are the characters that cannot appear within the name of
+// a path element or key, since they are used as delimiters when a path is
+// represented as a string.
+const pathReservedChars = "/[]="
+
+// ValidateGNMIPath checks that the gNMI path p is well-formed, such that it
+// can be used at an API boundary without further checks. A path is
+// well-formed if:
+//   - it does not populate both the deprecated Element field and the Elem
+//     field.
+//   - none of its elements are nil, or have an empty name.
+//   - none of its element names or key names are empty or contain
+//     whitespace or the reserved characters "/", "[", "]" and "=". The
+//     names within deprecated elements, which are of the form
+//     name[key=value], are checked after their keys are parsed.
+//   - its multi-level wildcard ("...") elements have no keys.
+//
+// The returned error is of kind ErrInvalidPath, and identifies the index of
+// the malformed element.
+func ValidateGNMIPath(p *gpb.Path) error {
+	if p == nil {
+		return KindErrorf(ErrInvalidPath, "invalid nil path")
+	}
+	if len(p.GetElement()) != 0 && len(p.GetElem()) != 0 {
+		return KindErrorf(ErrInvalidPath, "invalid path %v: both the deprecated element and elem fields are populated", p)
+	}
+	for i, e := range p.GetElement() {
+		if err := validatePathElement(e); err != nil {
+			return KindErrorf(ErrInvalidPath, "invalid path %v: element %d: %v", p, i, err)
+		}
+	}
+	for i, e := range p.GetElem() {
+		if err := validatePathElem(e); err != nil {
+			return KindErrorf(ErrInvalidPath, "invalid path %v: elem %d: %v", p, i, err)
+		}
+	}
+	return nil
+}
+
+// validatePathElem checks that the PathElem e is well-formed as per
+// ValidateGNMIPath.
+func validatePathElem(e *gpb.PathElem) error {
+	if e == nil {
+		return fmt.Errorf("nil elem")
+	}
+	if err := validatePathName(e.Name); err != nil {
+		return err
+	}
+	if e.Name == "..." && len(e.Key) != 0 {
+		return fmt.Errorf("multi-level wildcard cannot have keys")
+	}
+	for _, k := range sortedKeyNames(e.Key) {
+		if err := validatePathName(k); err != nil {
+			return fmt.Errorf("key %q: %v", k, err)
+		}
+	}
+	return nil
+}
+
+// validatePathElement checks that the deprecated element string e, which is
+// of the form name[key=value]..., is well-formed as per ValidateGNMIPath.
+// Within a key value, the characters "]" and "\" must be escaped with a
+// preceding "\".
+func validatePathElement(e string) error {
+	name, rest := e, ""
+	if i := strings.Index(e, "["); i != -1 {
+		name, rest = e[:i], e[i:]
+	}
+	if err := validatePathName(name); err != nil {
+		return err
+	}
+	for len(rest) != 0 {
+		if rest[0] != '[' {
+			return fmt.Errorf("element %q has unexpected characters %q after a key", e, rest)
+		}
+		k, v, ok := strings.Cut(rest[1:], "=")
+		if !ok {
+			return fmt.Errorf("element %q has a key without a value", e)
+		}
+		if err := validatePathName(k); err != nil {
+			return fmt.Errorf("key %q: %v", k, err)
+		}
+		end := -1
+		for i := 0; i < len(v); i++ {
+			if v[i] == '\\' {
+				i++
+				continue
+			}
+			if v[i] == ']' {
+				end = i
+				break
+			}
+		}
+		if end == -1 {
+			return fmt.Errorf("element %q has an unterminated key", e)
+		}
+		rest = v[end+1:]
+	}
+	return nil
+}
+
+// validatePathName checks that name is a valid element or key name as per
+// ValidateGNMIPath.
+func validatePathName(name string) error {
+	if name == "" {
+		return fmt.Errorf("empty name")
+	}
+	if i := strings.IndexAny(name, pathReservedChars); i != -1 {
+		return fmt.Errorf("name %q contains reserved character %q at position %d", name, name[i], i)
+	}
+	for i, r := range name {
+		if unicode.IsSpace(r) {
+			return fmt.Errorf("name %q contains whitespace at position %d", name, i)
+		}
+	}
+	return nil
+}
@@ -15,6 +15,8 @@
 package util_test
 
 import (
+	"errors"
+	"math/rand"
 	"strings"
 	"testing"
 
@@ -517,6 +519,137 @@ func TestPathElemSlicesEqual(t *testing.T) {
 	}
 }
 
+func TestEqualPathElems(t *testing.T) {
+	tests := []struct {
+		desc         string
+		a, b         []*gpb.PathElem
+		inIgnoreKeys bool
+		want         bool
+	}{{
+		desc: "nil and empty slices",
+		a:    nil,
+		b:    []*gpb.PathElem{},
+		want: true,
+	}, {
+		desc: "nil and empty key maps",
+		a:    []*gpb.PathElem{{Name: "a", Key: nil}},
+		b:    []*gpb.PathElem{{Name: "a", Key: map[string]string{}}},
+		want: true,
+	}, {
+		desc: "different keys",
+		a:    []*gpb.PathElem{{Name: "a", Key: map[string]string{"k": "1"}}},
+		b:    []*gpb.PathElem{{Name: "a", Key: map[string]string{"k": "2"}}},
+		want: false,
+	}, {
+		desc:         "different keys, ignoring keys",
+		a:            []*gpb.PathElem{{Name: "a", Key: map[string]string{"k": "1"}}},
+		b:            []*gpb.PathElem{{Name: "a"}},
+		inIgnoreKeys: true,
+		want:         true,
+	}, {
+		desc:         "different names, ignoring keys",
+		a:            []*gpb.PathElem{{Name: "a"}},
+		b:            []*gpb.PathElem{{Name: "b"}},
+		inIgnoreKeys: true,
+		want:         false,
+	}, {
+		desc:         "nil element",
+		a:            []*gpb.PathElem{nil},
+		b:            []*gpb.PathElem{{Name: "a"}},
+		inIgnoreKeys: true,
+		want:         false,
+	}, {
+		desc: "different lengths",
+		a:    []*gpb.PathElem{{Name: "a"}},
+		b:    []*gpb.PathElem{{Name: "a"}, {Name: "b"}},
+		want: false,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := util.EqualPathElems(tt.a, tt.b, tt.inIgnoreKeys); got != tt.want {
+				t.Errorf("EqualPathElems(%v, %v, %v): got %v, want %v", tt.a, tt.b, tt.inIgnoreKeys, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrimPathPrefix(t *testing.T) {
+	tests := []struct {
+		desc       string
+		inElems    []*gpb.PathElem
+		inPrefix   []*gpb.PathElem
+		want       []*gpb.PathElem
+		wantPrefix bool
+	}{{
+		desc:       "empty prefix",
+		inElems:    mustStringToPath(t, "/a/b").Elem,
+		want:       mustStringToPath(t, "/a/b").Elem,
+		wantPrefix: true,
+	}, {
+		desc:       "keyed prefix",
+		inElems:    mustStringToPath(t, "/a/b[k=1]/c").Elem,
+		inPrefix:   mustStringToPath(t, "/a/b[k=1]").Elem,
+		want:       mustStringToPath(t, "/c").Elem,
+		wantPrefix: true,
+	}, {
+		desc:     "key mismatch",
+		inElems:  mustStringToPath(t, "/a/b[k=1]/c").Elem,
+		inPrefix: mustStringToPath(t, "/a/b[k=2]").Elem,
+		want:     mustStringToPath(t, "/a/b[k=1]/c").Elem,
+	}, {
+		desc:     "wildcard in prefix does not match concrete key",
+		inElems:  mustStringToPath(t, "/a/b[k=1]/c").Elem,
+		inPrefix: mustStringToPath(t, "/a/b[k=*]").Elem,
+		want:     mustStringToPath(t, "/a/b[k=1]/c").Elem,
+	}, {
+		desc:       "whole path",
+		inElems:    mustStringToPath(t, "/a/b").Elem,
+		inPrefix:   mustStringToPath(t, "/a/b").Elem,
+		want:       []*gpb.PathElem{},
+		wantPrefix: true,
+	}, {
+		desc:     "prefix longer than path",
+		inElems:  mustStringToPath(t, "/a").Elem,
+		inPrefix: mustStringToPath(t, "/a/b").Elem,
+		want:     mustStringToPath(t, "/a").Elem,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := util.PathHasPrefix(tt.inElems, tt.inPrefix); got != tt.wantPrefix {
+				t.Errorf("PathHasPrefix(%v, %v): got %v, want %v", tt.inElems, tt.inPrefix, got, tt.wantPrefix)
+			}
+			got, gotPrefix := util.TrimPathPrefix(tt.inElems, tt.inPrefix)
+			if gotPrefix != tt.wantPrefix {
+				t.Errorf("TrimPathPrefix(%v, %v): got prefix match %v, want %v", tt.inElems, tt.inPrefix, gotPrefix, tt.wantPrefix)
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("TrimPathPrefix(%v, %v): did not get expected result (-want, +got):\n%s", tt.inElems, tt.inPrefix, diff)
+			}
+		})
+	}
+}
+
+func TestJoinPathElems(t *testing.T) {
+	a := make([]*gpb.PathElem, 1, 4)
+	a[0] = &gpb.PathElem{Name: "a"}
+	b := []*gpb.PathElem{{Name: "b"}}
+	c := []*gpb.PathElem{{Name: "c"}}
+
+	ab := util.JoinPathElems(a, b)
+	ac := util.JoinPathElems(a, c)
+	if diff := cmp.Diff([]*gpb.PathElem{{Name: "a"}, {Name: "b"}}, ab, protocmp.Transform()); diff != "" {
+		t.Errorf("JoinPathElems(a, b): did not get expected result (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]*gpb.PathElem{{Name: "a"}, {Name: "c"}}, ac, protocmp.Transform()); diff != "" {
+		t.Errorf("JoinPathElems(a, c): did not get expected result (-want, +got):\n%s", diff)
+	}
+	if got := util.JoinPathElems(); len(got) != 0 {
+		t.Errorf("JoinPathElems(): got %v, want empty slice", got)
+	}
+}
+
 func TestPathMatchesPathElemPrefix(t *testing.T) {
 	tests := []struct {
 		desc     string
@@ -829,6 +962,131 @@ func TestPathMatchesQuery(t *testing.T) {
 				Key:  map[string]string{"seven": "*"},
 			}},
 		},
+	}, {
+		desc: "valid query with trailing multi-level wildcard",
+		inPath: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "two",
+			}, {
+				Name: "three",
+			}},
+		},
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "...",
+			}},
+		},
+		want: true,
+	}, {
+		desc: "valid query with multi-level wildcard matching no elements",
+		inPath: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "two",
+			}},
+		},
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "...",
+			}, {
+				Name: "two",
+			}},
+		},
+		want: true,
+	}, {
+		desc: "valid query with multi-level wildcard matching several elements",
+		inPath: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "interfaces",
+			}, {
+				Name: "interface",
+				Key:  map[string]string{"name": "eth0"},
+			}, {
+				Name: "state",
+			}, {
+				Name: "counters",
+			}, {
+				Name: "in-pkts",
+			}},
+		},
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "...",
+			}, {
+				Name: "state",
+			}, {
+				Name: "*",
+			}, {
+				Name: "in-pkts",
+			}},
+		},
+		want: true,
+	}, {
+		desc: "valid query with multi-level wildcard and partial keys",
+		inPath: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+				Key:  map[string]string{"a": "1", "b": "2"},
+			}, {
+				Name: "two",
+			}, {
+				Name: "three",
+				Key:  map[string]string{"c": "3"},
+			}},
+		},
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+				Key:  map[string]string{"b": "2"},
+			}, {
+				Name: "...",
+			}, {
+				Name: "three",
+				Key:  map[string]string{"c": "*"},
+			}},
+		},
+		want: true,
+	}, {
+		desc: "invalid multi-level wildcard followed by unmatched element",
+		inPath: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "two",
+			}},
+		},
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "...",
+			}, {
+				Name: "three",
+			}},
+		},
+	}, {
+		desc: "invalid multi-level wildcard with mismatched keys",
+		inPath: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "one",
+			}, {
+				Name: "two",
+				Key:  map[string]string{"a": "1"},
+			}},
+		},
+		inQuery: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "...",
+			}, {
+				Name: "two",
+				Key:  map[string]string{"a": "2"},
+			}},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
@@ -1480,3 +1738,328 @@ func TestComparePaths(t *testing.T) {
 		})
 	}
 }
+
+func TestComparePathOrder(t *testing.T) {
+	tests := []struct {
+		desc string
+		a, b *gpb.Path
+		want int
+	}{{
+		desc: "equal paths",
+		a:    mustStringToPath(t, "/a/b[k=1]/c"),
+		b:    mustStringToPath(t, "/a/b[k=1]/c"),
+		want: 0,
+	}, {
+		desc: "nil and empty paths are equal",
+		a:    nil,
+		b:    &gpb.Path{},
+		want: 0,
+	}, {
+		desc: "origin ordered first",
+		a:    &gpb.Path{Origin: "b", Elem: []*gpb.PathElem{{Name: "a"}}},
+		b:    &gpb.Path{Origin: "a", Elem: []*gpb.PathElem{{Name: "z"}}},
+		want: 1,
+	}, {
+		desc: "target ordered before elements",
+		a:    &gpb.Path{Target: "dut1", Elem: []*gpb.PathElem{{Name: "z"}}},
+		b:    &gpb.Path{Target: "dut2", Elem: []*gpb.PathElem{{Name: "a"}}},
+		want: -1,
+	}, {
+		desc: "prefix sorts first",
+		a:    mustStringToPath(t, "/a/b"),
+		b:    mustStringToPath(t, "/a/b/c"),
+		want: -1,
+	}, {
+		desc: "element names",
+		a:    mustStringToPath(t, "/a/c"),
+		b:    mustStringToPath(t, "/a/b/c"),
+		want: 1,
+	}, {
+		desc: "integer keys compared numerically",
+		a:    mustStringToPath(t, "/a/b[k=10]"),
+		b:    mustStringToPath(t, "/a/b[k=9]"),
+		want: 1,
+	}, {
+		desc: "string keys compared lexically",
+		a:    mustStringToPath(t, "/a/b[k=eth10]"),
+		b:    mustStringToPath(t, "/a/b[k=eth9]"),
+		want: -1,
+	}, {
+		desc: "keys compared in key name order",
+		a:    mustStringToPath(t, "/a/b[y=1][x=2]"),
+		b:    mustStringToPath(t, "/a/b[x=1][y=2]"),
+		want: 1,
+	}, {
+		desc: "fewer keys sorts first",
+		a:    mustStringToPath(t, "/a/b[x=1][y=2]"),
+		b:    mustStringToPath(t, "/a/b[x=1]"),
+		want: 1,
+	}, {
+		desc: "different key names",
+		a:    mustStringToPath(t, "/a/b[x=1]"),
+		b:    mustStringToPath(t, "/a/b[y=1]"),
+		want: -1,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := util.ComparePathOrder(tt.a, tt.b); got != tt.want {
+				t.Errorf("ComparePathOrder(%v, %v): got %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := util.ComparePathOrder(tt.b, tt.a); got != -tt.want {
+				t.Errorf("ComparePathOrder(%v, %v): got %d, want %d", tt.b, tt.a, got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestComparePathElemOrder(t *testing.T) {
+	tests := []struct {
+		desc string
+		a, b *gpb.PathElem
+		want int
+	}{{
+		desc: "both nil",
+		want: 0,
+	}, {
+		desc: "nil sorts first",
+		b:    &gpb.PathElem{Name: "a"},
+		want: -1,
+	}, {
+		desc: "nil and empty key maps are equal",
+		a:    &gpb.PathElem{Name: "a"},
+		b:    &gpb.PathElem{Name: "a", Key: map[string]string{}},
+		want: 0,
+	}, {
+		desc: "negative integer keys",
+		a:    &gpb.PathElem{Name: "a", Key: map[string]string{"k": "-5"}},
+		b:    &gpb.PathElem{Name: "a", Key: map[string]string{"k": "3"}},
+		want: -1,
+	}, {
+		desc: "large unsigned integer keys",
+		a:    &gpb.PathElem{Name: "a", Key: map[string]string{"k": "18446744073709551615"}},
+		b:    &gpb.PathElem{Name: "a", Key: map[string]string{"k": "9223372036854775808"}},
+		want: 1,
+	}, {
+		desc: "mixed integer and string keys",
+		a:    &gpb.PathElem{Name: "a", Key: map[string]string{"k": "10"}},
+		b:    &gpb.PathElem{Name: "a", Key: map[string]string{"k": "a"}},
+		want: -1,
+	}, {
+		desc: "integer keys sort before keys with a numeric prefix",
+		a:    &gpb.PathElem{Name: "a", Key: map[string]string{"k": "1a"}},
+		b:    &gpb.PathElem{Name: "a", Key: map[string]string{"k": "9"}},
+		want: 1,
+	}, {
+		desc: "equal integer keys compared lexically",
+		a:    &gpb.PathElem{Name: "a", Key: map[string]string{"k": "010"}},
+		b:    &gpb.PathElem{Name: "a", Key: map[string]string{"k": "10"}},
+		want: -1,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := util.ComparePathElemOrder(tt.a, tt.b); got != tt.want {
+				t.Errorf("ComparePathElemOrder(%v, %v): got %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComparePathElemOrderTotal(t *testing.T) {
+	vals := []string{"9", "10", "010", "+10", "1a", "a", "-1", "-01", "", "18446744073709551615", "9223372036854775808", "-9223372036854775808"}
+	elems := make([]*gpb.PathElem, len(vals))
+	for i, v := range vals {
+		elems[i] = &gpb.PathElem{Name: "a", Key: map[string]string{"k": v}}
+	}
+
+	for _, a := range elems {
+		for _, b := range elems {
+			ab, ba := util.ComparePathElemOrder(a, b), util.ComparePathElemOrder(b, a)
+			if ab != -ba {
+				t.Errorf("ComparePathElemOrder is not antisymmetric for %v and %v: got %d and %d", a, b, ab, ba)
+			}
+			if (ab == 0) != (a == b) {
+				t.Errorf("ComparePathElemOrder(%v, %v): got %d for distinct elements", a, b, ab)
+			}
+			for _, c := range elems {
+				if ab < 0 && util.ComparePathElemOrder(b, c) < 0 && util.ComparePathElemOrder(a, c) >= 0 {
+					t.Errorf("ComparePathElemOrder is not transitive for %v < %v < %v", a, b, c)
+				}
+			}
+		}
+	}
+}
+
+func TestSortPaths(t *testing.T) {
+	in := []*gpb.Path{
+		mustStringToPath(t, "/interfaces/interface[name=eth1]/state/counters"),
+		mustStringToPath(t, "/interfaces/interface[name=eth1]"),
+		mustStringToPath(t, "/system/config/hostname"),
+		mustStringToPath(t, "/interfaces/interface[name=eth0]/config/mtu"),
+		mustStringToPath(t, "/network-instances/network-instance[name=default]/protocols/protocol[identifier=BGP][name=10]"),
+		mustStringToPath(t, "/network-instances/network-instance[name=default]/protocols/protocol[identifier=BGP][name=2]"),
+		nil,
+	}
+	want := []*gpb.Path{
+		nil,
+		mustStringToPath(t, "/interfaces/interface[name=eth0]/config/mtu"),
+		mustStringToPath(t, "/interfaces/interface[name=eth1]"),
+		mustStringToPath(t, "/interfaces/interface[name=eth1]/state/counters"),
+		mustStringToPath(t, "/network-instances/network-instance[name=default]/protocols/protocol[identifier=BGP][name=2]"),
+		mustStringToPath(t, "/network-instances/network-instance[name=default]/protocols/protocol[identifier=BGP][name=10]"),
+		mustStringToPath(t, "/system/config/hostname"),
+	}
+
+	util.SortPaths(in)
+	if diff := cmp.Diff(want, in, protocmp.Transform()); diff != "" {
+		t.Errorf("SortPaths: did not get expected order (-want, +got):\n%s", diff)
+	}
+}
+
+func TestSortPathsShuffled(t *testing.T) {
+	want := []*gpb.Path{
+		mustStringToPath(t, "/a/b[k=-1]"),
+		mustStringToPath(t, "/a/b[k=9]"),
+		mustStringToPath(t, "/a/b[k=010]"),
+		mustStringToPath(t, "/a/b[k=10]"),
+		mustStringToPath(t, "/a/b[k=1a]"),
+		mustStringToPath(t, "/a/b[k=a]"),
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i != 50; i++ {
+		in := append([]*gpb.Path{}, want...)
+		r.Shuffle(len(in), func(i, j int) { in[i], in[j] = in[j], in[i] })
+		util.SortPaths(in)
+		if diff := cmp.Diff(want, in, protocmp.Transform()); diff != "" {
+			t.Fatalf("SortPaths: did not get expected order (-want, +got):\n%s", diff)
+		}
+	}
+}
+
+func TestValidateGNMIPath(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               *gpb.Path
+		wantErrSubstring string
+	}{{
+		desc: "valid path",
+		in: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "interfaces",
+			}, {
+				Name: "interface",
+				Key:  map[string]string{"name": "Ethernet1/1[0]"},
+			}, {
+				Name: "oc-if:state",
+			}},
+		},
+	}, {
+		desc: "valid path with wildcards",
+		in: &gpb.Path{
+			Elem: []*gpb.PathElem{{
+				Name: "*",
+				Key:  map[string]string{"name": "*"},
+			}, {
+				Name: "...",
+			}},
+		},
+	}, {
+		desc: "valid path with deprecated elements",
+		in:   &gpb.Path{Element: []string{"a", "b"}},
+	}, {
+		desc:             "nil path",
+		wantErrSubstring: "invalid nil path",
+	}, {
+		desc: "both element and elem",
+		in: &gpb.Path{
+			Element: []string{"a"},
+			Elem:    []*gpb.PathElem{{Name: "a"}},
+		},
+		wantErrSubstring: "both the deprecated element and elem fields are populated",
+	}, {
+		desc: "nil elem",
+		in: &gpb.Path{
+			Elem: []*gpb.PathElem{{Name: "a"}, nil},
+		},
+		wantErrSubstring: "elem 1: nil elem",
+	}, {
+		desc: "empty name",
+		in: &gpb.Path{
+			Elem: []*gpb.PathElem{{Name: "a"}, {Name: "b"}, {Name: ""}},
+		},
+		wantErrSubstring: "elem 2: empty name",
+	}, {
+		desc: "reserved character in name",
+		in: &gpb.Path{
+			Elem: []*gpb.PathElem{{Name: "a/b"}},
+		},
+		wantErrSubstring: `elem 0: name "a/b" contains reserved character '/' at position 1`,
+	}, {
+		desc: "whitespace in name",
+		in: &gpb.Path{
+			Elem: []*gpb.PathElem{{Name: "a b"}},
+		},
+		wantErrSubstring: `elem 0: name "a b" contains whitespace at position 1`,
+	}, {
+		desc: "reserved character in key name",
+		in: &gpb.Path{
+			Elem: []*gpb.PathElem{{Name: "a", Key: map[string]string{"k=v": "x"}}},
+		},
+		wantErrSubstring: `elem 0: key "k=v": name "k=v" contains reserved character '='`,
+	}, {
+		desc: "empty key name",
+		in: &gpb.Path{
+			Elem: []*gpb.PathElem{{Name: "a", Key: map[string]string{"": "x"}}},
+		},
+		wantErrSubstring: `elem 0: key "": empty name`,
+	}, {
+		desc: "keyed multi-level wildcard",
+		in: &gpb.Path{
+			Elem: []*gpb.PathElem{{Name: "...", Key: map[string]string{"k": "v"}}},
+		},
+		wantErrSubstring: "elem 0: multi-level wildcard cannot have keys",
+	}, {
+		desc:             "empty deprecated element",
+		in:               &gpb.Path{Element: []string{"a", ""}},
+		wantErrSubstring: "element 1: empty name",
+	}, {
+		desc: "keyed deprecated elements",
+		in: &gpb.Path{
+			Element: []string{"interfaces", "interface[name=Ethernet1/1]", "subinterfaces", `subinterface[index=0][desc=a\]b=c]`},
+		},
+	}, {
+		desc:             "reserved character in deprecated element name",
+		in:               &gpb.Path{Element: []string{"a=b[k=v]"}},
+		wantErrSubstring: `element 0: name "a=b" contains reserved character`,
+	}, {
+		desc:             "deprecated element key without value",
+		in:               &gpb.Path{Element: []string{"a[k]"}},
+		wantErrSubstring: "element 0: element \"a[k]\" has a key without a value",
+	}, {
+		desc:             "empty key name in deprecated element",
+		in:               &gpb.Path{Element: []string{"a[=v]"}},
+		wantErrSubstring: `element 0: key "": empty name`,
+	}, {
+		desc:             "unterminated key in deprecated element",
+		in:               &gpb.Path{Element: []string{`a[k=v\]`}},
+		wantErrSubstring: "has an unterminated key",
+	}, {
+		desc:             "characters after key in deprecated element",
+		in:               &gpb.Path{Element: []string{"a[k=v]b"}},
+		wantErrSubstring: `has unexpected characters "b" after a key`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := util.ValidateGNMIPath(tt.in)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("ValidateGNMIPath(%v): did not get expected error, %s", tt.in, diff)
+			}
+			if err != nil && !errors.Is(err, util.ErrInvalidPath) {
+				t.Errorf("ValidateGNMIPath(%v): got error %v, want error of kind ErrInvalidPath", tt.in, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// IdentityDerivedFrom reports whether the identity id is derived, directly or
+// indirectly, from the identity base, implementing the semantics of the
+// derived-from() XPath function defined in RFC7950 Section 10.4.1. An
+// identity is not derived from itself.
+func IdentityDerivedFrom(id, base *yang.Identity) bool {
+	if id == nil || base == nil || sameIdentity(id, base) {
+		return false
+	}
+	// goyang populates the Values of an identity with all identities that
+	// are derived from it, including those that are derived indirectly.
+	for _, v := range base.Values {
+		if sameIdentity(v, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// IdentityDerivedFromOrSelf reports whether the identity id is the identity
+// base, or is derived from it, implementing the semantics of the
+// derived-from-or-self() XPath function defined in RFC7950 Section 10.4.2.
+func IdentityDerivedFromOrSelf(id, base *yang.Identity) bool {
+	if id == nil || base == nil {
+		return false
+	}
+	return sameIdentity(id, base) || IdentityDerivedFrom(id, base)
+}
+
+// sameIdentity reports whether a and b are the same identity. Identities that
+// are not the same object are compared by their module-qualified names.
+func sameIdentity(a, b *yang.Identity) bool {
+	return a == b || identityModule(a) == identityModule(b) && a.Name == b.Name
+}
+
+// identityModule returns the name of the module that defines i, or the empty
+// string if it cannot be determined.
+func identityModule(i *yang.Identity) string {
+	if i.Parent == nil {
+		return ""
+	}
+	if m := yang.RootNode(i); m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// IdentityHierarchy is an index of YANG identities by name, used to evaluate
+// the relationships between identities when only their names are known,
+// e.g., when checking the value of an identityref leaf of a GoStruct.
+type IdentityHierarchy struct {
+	// qualified maps the module-qualified and prefix-qualified names of
+	// each identity to the identity.
+	qualified map[string]*yang.Identity
+	// unqualified maps the name of each identity to the identities with
+	// that name, which may be defined in different modules.
+	unqualified map[string][]*yang.Identity
+}
+
+// NewIdentityHierarchy returns an IdentityHierarchy containing the supplied
+// identities, along with all identities derived from them.
+func NewIdentityHierarchy(ids ...*yang.Identity) *IdentityHierarchy {
+	h := &IdentityHierarchy{
+		qualified:   map[string]*yang.Identity{},
+		unqualified: map[string][]*yang.Identity{},
+	}
+	for _, i := range ids {
+		h.add(i)
+	}
+	return h
+}
+
+// IdentityHierarchyFromSchema returns an IdentityHierarchy containing the
+// base identities of each identityref within the schema tree rooted at
+// schema, along with all identities derived from them.
+func IdentityHierarchyFromSchema(schema *yang.Entry) *IdentityHierarchy {
+	h := NewIdentityHierarchy()
+	var addEntry func(*yang.Entry)
+	addEntry = func(e *yang.Entry) {
+		if e == nil {
+			return
+		}
+		if e.Type != nil {
+			for _, t := range FlattenedTypes([]*yang.YangType{e.Type}) {
+				if t.Kind == yang.Yidentityref {
+					h.add(t.IdentityBase)
+				}
+			}
+		}
+		for _, ch := range e.Dir {
+			addEntry(ch)
+		}
+	}
+	addEntry(schema)
+	return h
+}
+
+// add adds i and the identities derived from it to h.
+func (h *IdentityHierarchy) add(i *yang.Identity) {
+	if i == nil {
+		return
+	}
+	// The defining module of an identity may not be known, in which case it
+	// can only be referred to by its name.
+	key := i.Name
+	var prefixed string
+	if mod := identityModule(i); mod != "" {
+		key = mod + ":" + i.Name
+		if p := yang.RootNode(i).GetPrefix(); p != "" && p != mod {
+			prefixed = p + ":" + i.Name
+		}
+	}
+	if _, ok := h.qualified[key]; ok {
+		return
+	}
+	h.qualified[key] = i
+	if prefixed != "" {
+		h.qualified[prefixed] = i
+	}
+	h.unqualified[i.Name] = append(h.unqualified[i.Name], i)
+	for _, v := range i.Values {
+		h.add(v)
+	}
+}
+
+// Lookup returns the identity with the supplied name, which may be qualified
+// with the name or prefix of its defining module (e.g.,
+// "openconfig-platform-types:QSFP" or "oc-platform-types:QSFP"), or
+// unqualified if the name is not ambiguous.
+func (h *IdentityHierarchy) Lookup(name string) (*yang.Identity, error) {
+	if i, ok := h.qualified[name]; ok {
+		return i, nil
+	}
+	if strings.Contains(name, ":") {
+		return nil, fmt.Errorf("unknown identity %s", name)
+	}
+	switch ids := h.unqualified[name]; len(ids) {
+	case 0:
+		return nil, fmt.Errorf("unknown identity %s", name)
+	case 1:
+		return ids[0], nil
+	default:
+		var mods []string
+		for _, i := range ids {
+			mods = append(mods, identityModule(i))
+		}
+		sort.Strings(mods)
+		return nil, fmt.Errorf("ambiguous identity %s, defined in modules %v", name, mods)
+	}
+}
+
+// DerivedFrom reports whether the identity named id is derived from the
+// identity named base, as per IdentityDerivedFrom. Names are resolved using
+// Lookup.
+func (h *IdentityHierarchy) DerivedFrom(id, base string) (bool, error) {
+	i, b, err := h.lookupPair(id, base)
+	if err != nil {
+		return false, err
+	}
+	return IdentityDerivedFrom(i, b), nil
+}
+
+// DerivedFromOrSelf reports whether the identity named id is the identity
+// named base, or is derived from it, as per IdentityDerivedFromOrSelf. Names
+// are resolved using Lookup.
+func (h *IdentityHierarchy) DerivedFromOrSelf(id, base string) (bool, error) {
+	i, b, err := h.lookupPair(id, base)
+	if err != nil {
+		return false, err
+	}
+	return IdentityDerivedFromOrSelf(i, b), nil
+}
+
+// lookupPair resolves the identities named id and base.
+func (h *IdentityHierarchy) lookupPair(id, base string) (*yang.Identity, *yang.Identity, error) {
+	i, err := h.Lookup(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := h.Lookup(base)
+	if err != nil {
+		return nil, nil, err
+	}
+	return i, b, nil
+}
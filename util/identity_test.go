@@ -0,0 +1,156 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+const identityTestModule = `
+module transceivers {
+  prefix "xcvr";
+  namespace "urn:transceivers";
+
+  identity FORM_FACTOR;
+  identity QSFP { base FORM_FACTOR; }
+  identity QSFP28 { base QSFP; }
+  identity SFP { base FORM_FACTOR; }
+  identity OTHER;
+
+  container transceiver {
+    leaf form-factor {
+      type union {
+        type string;
+        type identityref { base FORM_FACTOR; }
+      }
+    }
+  }
+}
+`
+
+// identityTestSchema returns the schema entry for the identityTestModule
+// module.
+func identityTestSchema(t *testing.T) *yang.Entry {
+	t.Helper()
+	ms := yang.NewModules()
+	if err := ms.Parse(identityTestModule, "transceivers.yang"); err != nil {
+		t.Fatalf("cannot parse test module: %v", err)
+	}
+	if errs := ms.Process(); len(errs) != 0 {
+		t.Fatalf("cannot process test module: %v", errs)
+	}
+	m, err := ms.GetModule("transceivers")
+	if err != nil {
+		t.Fatalf("cannot find test module: %v", err)
+	}
+	return m
+}
+
+func TestIdentityDerivedFrom(t *testing.T) {
+	h := IdentityHierarchyFromSchema(identityTestSchema(t))
+
+	tests := []struct {
+		desc             string
+		inID             string
+		inBase           string
+		wantDerived      bool
+		wantOrSelf       bool
+		wantErrSubstring string
+	}{{
+		desc:        "directly derived",
+		inID:        "QSFP",
+		inBase:      "FORM_FACTOR",
+		wantDerived: true,
+		wantOrSelf:  true,
+	}, {
+		desc:        "indirectly derived",
+		inID:        "transceivers:QSFP28",
+		inBase:      "xcvr:FORM_FACTOR",
+		wantDerived: true,
+		wantOrSelf:  true,
+	}, {
+		desc:        "derived from intermediate base",
+		inID:        "QSFP28",
+		inBase:      "QSFP",
+		wantDerived: true,
+		wantOrSelf:  true,
+	}, {
+		desc:       "self",
+		inID:       "QSFP",
+		inBase:     "transceivers:QSFP",
+		wantOrSelf: true,
+	}, {
+		desc:   "sibling",
+		inID:   "SFP",
+		inBase: "QSFP",
+	}, {
+		desc:   "base is not derived from derived identity",
+		inID:   "FORM_FACTOR",
+		inBase: "QSFP28",
+	}, {
+		desc:             "identity not referenced by schema",
+		inID:             "OTHER",
+		inBase:           "FORM_FACTOR",
+		wantErrSubstring: "unknown identity OTHER",
+	}, {
+		desc:             "unknown module",
+		inID:             "QSFP",
+		inBase:           "oc-xcvr:FORM_FACTOR",
+		wantErrSubstring: "unknown identity oc-xcvr:FORM_FACTOR",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := h.DerivedFrom(tt.inID, tt.inBase)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("DerivedFrom(%s, %s): did not get expected error, %s", tt.inID, tt.inBase, diff)
+			}
+			if got != tt.wantDerived {
+				t.Errorf("DerivedFrom(%s, %s): got %v, want %v", tt.inID, tt.inBase, got, tt.wantDerived)
+			}
+			got, err = h.DerivedFromOrSelf(tt.inID, tt.inBase)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("DerivedFromOrSelf(%s, %s): did not get expected error, %s", tt.inID, tt.inBase, diff)
+			}
+			if got != tt.wantOrSelf {
+				t.Errorf("DerivedFromOrSelf(%s, %s): got %v, want %v", tt.inID, tt.inBase, got, tt.wantOrSelf)
+			}
+		})
+	}
+}
+
+func TestIdentityHierarchyLookup(t *testing.T) {
+	base := &yang.Identity{Name: "BASE"}
+	a := &yang.Identity{Name: "DERIVED", Parent: &yang.Module{Name: "mod-a"}}
+	b := &yang.Identity{Name: "DERIVED", Parent: &yang.Module{Name: "mod-b"}}
+	base.Values = []*yang.Identity{a, b}
+	h := NewIdentityHierarchy(base)
+
+	if _, err := h.Lookup("DERIVED"); err == nil {
+		t.Errorf("Lookup(DERIVED): did not get expected error for ambiguous name")
+	}
+	if got, err := h.Lookup("mod-b:DERIVED"); err != nil || got != b {
+		t.Errorf("Lookup(mod-b:DERIVED): got %v, %v, want %v", got, err, b)
+	}
+	if got, err := h.Lookup("BASE"); err != nil || got != base {
+		t.Errorf("Lookup(BASE): got %v, %v, want %v", got, err, base)
+	}
+	if IdentityDerivedFrom(nil, base) || IdentityDerivedFromOrSelf(a, nil) {
+		t.Errorf("got true for nil identity, want false")
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// The names of the operations that are observed by the registered
+// MetricsRecorder.
+const (
+	// OpUnmarshal is the unmarshalling of JSON into a GoStruct by
+	// ytypes.Unmarshal, including by the generated Unmarshal function.
+	OpUnmarshal = "Unmarshal"
+	// OpValidate is the validation of a GoStruct by ytypes.Validate,
+	// including by the generated Validate methods.
+	OpValidate = "Validate"
+	// OpDiff is the computation of the difference between two GoStructs
+	// by ygot.Diff, ygot.InverseDiff and ygot.DiffWithAtomic.
+	OpDiff = "Diff"
+	// OpSetNode is the setting of a node within a GoStruct by
+	// ytypes.SetNode.
+	OpSetNode = "SetNode"
+	// OpEmitJSON is the serialisation of a GoStruct to JSON by
+	// ygot.EmitJSON, ygot.EmitJSONWithOpts and ygot.EncodeJSON.
+	OpEmitJSON = "EmitJSON"
+)
+
+// OperationObservation describes a completed call to one of the operations
+// observed by the registered MetricsRecorder.
+type OperationObservation struct {
+	// Op is the name of the operation, e.g., OpUnmarshal.
+	Op string
+	// Duration is the time that the operation took to complete.
+	Duration time.Duration
+	// Err is the error returned by the operation, if any.
+	Err error
+}
+
+// MetricsRecorder is the interface implemented by receivers of observations
+// of the cost of the library's schema operations, allowing them to be
+// exported as counters and latency histograms to a monitoring system such
+// as Prometheus or OpenTelemetry. Only calls made by the application are
+// observed, rather than those made internally as part of an operation,
+// such that the count of each operation is the number of calls to it. An
+// operation that calls another, such as EmitJSON validating the GoStruct,
+// results in an observation of each.
+type MetricsRecorder interface {
+	// ObserveOperation is called when each operation completes. It may be
+	// called concurrently from multiple goroutines, and should not block.
+	ObserveOperation(OperationObservation)
+}
+
+// MetricsRecorderFunc is an adapter that allows an ordinary function to be
+// used as a MetricsRecorder.
+type MetricsRecorderFunc func(OperationObservation)
+
+// ObserveOperation calls f(o).
+func (f MetricsRecorderFunc) ObserveOperation(o OperationObservation) { f(o) }
+
+// metricsRecorderBox wraps the registered MetricsRecorder such that it can
+// be stored in an atomic.Value, which requires a consistent concrete type.
+type metricsRecorderBox struct {
+	r MetricsRecorder
+}
+
+// globalMetricsRecorder stores the metricsRecorderBox of the registered
+// MetricsRecorder.
+var globalMetricsRecorder atomic.Value
+
+// SetMetricsRecorder registers r as the receiver of observations of the
+// library's operations, replacing any previously registered
+// MetricsRecorder. Observation is disabled if r is nil.
+func SetMetricsRecorder(r MetricsRecorder) {
+	globalMetricsRecorder.Store(metricsRecorderBox{r: r})
+}
+
+// currentMetricsRecorder returns the registered MetricsRecorder, or nil if
+// there is none.
+func currentMetricsRecorder() MetricsRecorder {
+	b, ok := globalMetricsRecorder.Load().(metricsRecorderBox)
+	if !ok {
+		return nil
+	}
+	return b.r
+}
+
+// StartOperation starts timing a call to the operation op, and returns a
+// function that must be called with the error returned by the operation
+// when it completes, which reports the observation to the registered
+// MetricsRecorder. If no MetricsRecorder is registered, the returned
+// function does nothing.
+func StartOperation(op string) func(error) {
+	r := currentMetricsRecorder()
+	if r == nil {
+		return func(error) {}
+	}
+	start := time.Now()
+	return func(err error) {
+		r.ObserveOperation(OperationObservation{Op: op, Duration: time.Since(start), Err: err})
+	}
+}
+
+// OperationStat is the set of statistics accumulated by OperationStats for
+// an operation.
+type OperationStat struct {
+	// Count is the number of calls to the operation.
+	Count uint64
+	// Errors is the number of calls to the operation that returned an
+	// error.
+	Errors uint64
+	// TotalDuration is the sum of the durations of the calls.
+	TotalDuration time.Duration
+	// MaxDuration is the duration of the longest call.
+	MaxDuration time.Duration
+}
+
+// OperationStats is a MetricsRecorder that accumulates the count, error
+// count and duration of the calls to each operation, for applications that
+// poll the statistics rather than exporting each observation. The zero
+// value is ready to use.
+type OperationStats struct {
+	mu    sync.Mutex
+	stats map[string]OperationStat
+}
+
+// ObserveOperation adds the observation o to the statistics of its
+// operation.
+func (s *OperationStats) ObserveOperation(o OperationObservation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stats == nil {
+		s.stats = map[string]OperationStat{}
+	}
+	st := s.stats[o.Op]
+	st.Count++
+	if o.Err != nil {
+		st.Errors++
+	}
+	st.TotalDuration += o.Duration
+	if o.Duration > st.MaxDuration {
+		st.MaxDuration = o.Duration
+	}
+	s.stats[o.Op] = st
+}
+
+// Snapshot returns a copy of the statistics accumulated for each operation,
+// keyed by the name of the operation.
+func (s *OperationStats) Snapshot() map[string]OperationStat {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m := make(map[string]OperationStat, len(s.stats))
+	for op, st := range s.stats {
+		m[op] = st
+	}
+	return m
+}
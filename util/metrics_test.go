@@ -0,0 +1,70 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMetricsRecorder(t *testing.T) {
+	var got []OperationObservation
+	SetMetricsRecorder(MetricsRecorderFunc(func(o OperationObservation) { got = append(got, o) }))
+	defer SetMetricsRecorder(nil)
+
+	errTest := errors.New("test error")
+	done := StartOperation(OpUnmarshal)
+	time.Sleep(time.Millisecond)
+	done(errTest)
+	StartOperation(OpValidate)(nil)
+
+	if len(got) != 2 {
+		t.Fatalf("got %d observations, want 2: %v", len(got), got)
+	}
+	if got[0].Op != OpUnmarshal || got[0].Err != errTest || got[0].Duration < time.Millisecond {
+		t.Errorf("did not get expected observation of failed operation, got: %+v", got[0])
+	}
+	if got[1].Op != OpValidate || got[1].Err != nil {
+		t.Errorf("did not get expected observation of successful operation, got: %+v", got[1])
+	}
+
+	SetMetricsRecorder(nil)
+	StartOperation(OpDiff)(nil)
+	if len(got) != 2 {
+		t.Errorf("got observation with no MetricsRecorder registered: %v", got[2:])
+	}
+}
+
+func TestOperationStats(t *testing.T) {
+	s := &OperationStats{}
+	s.ObserveOperation(OperationObservation{Op: OpDiff, Duration: 2 * time.Millisecond})
+	s.ObserveOperation(OperationObservation{Op: OpDiff, Duration: 3 * time.Millisecond, Err: errors.New("failed")})
+	s.ObserveOperation(OperationObservation{Op: OpSetNode, Duration: time.Millisecond})
+
+	got := s.Snapshot()
+	want := map[string]OperationStat{
+		OpDiff:    {Count: 2, Errors: 1, TotalDuration: 5 * time.Millisecond, MaxDuration: 3 * time.Millisecond},
+		OpSetNode: {Count: 1, TotalDuration: time.Millisecond, MaxDuration: time.Millisecond},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Snapshot: got %v, want %v", got, want)
+	}
+	for op, w := range want {
+		if got[op] != w {
+			t.Errorf("Snapshot: got %+v for %s, want %+v", got[op], op, w)
+		}
+	}
+}
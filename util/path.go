@@ -19,12 +19,21 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 
 	"github.com/openconfig/goyang/pkg/yang"
 )
 
 // SchemaPaths returns all the paths in the path tag.
+//
+// The parsed paths are memoized, and hence the returned slices must not be
+// modified by the caller.
 func SchemaPaths(f reflect.StructField) ([][]string, error) {
+	key := pathTagCacheKey{tag: f.Tag, kind: schemaPathsTag}
+	if v, ok := pathTagCache.Load(key); ok {
+		return v.([][]string), nil
+	}
+
 	var out [][]string
 	pathTag, ok := f.Tag.Lookup("path")
 	if !ok || pathTag == "" {
@@ -33,14 +42,24 @@ func SchemaPaths(f reflect.StructField) ([][]string, error) {
 
 	ps := strings.Split(pathTag, "|")
 	for _, p := range ps {
-		out = append(out, stripModulePrefixes(strings.Split(p, "/")))
+		out = append(out, clipPath(stripModulePrefixes(strings.Split(p, "/"))))
 	}
+	out = out[:len(out):len(out)]
+	pathTagCache.Store(key, out)
 	return out, nil
 }
 
 // ShadowSchemaPaths returns all the paths in the shadow-path tag. If the tag
 // doesn't exist, a nil slice is returned.
+//
+// The parsed paths are memoized, and hence the returned slices must not be
+// modified by the caller.
 func ShadowSchemaPaths(f reflect.StructField) [][]string {
+	key := pathTagCacheKey{tag: f.Tag, kind: shadowSchemaPathsTag}
+	if v, ok := pathTagCache.Load(key); ok {
+		return v.([][]string)
+	}
+
 	var out [][]string
 	pathTag, ok := f.Tag.Lookup("shadow-path")
 	if !ok || pathTag == "" {
@@ -49,8 +68,10 @@ func ShadowSchemaPaths(f reflect.StructField) [][]string {
 
 	ps := strings.Split(pathTag, "|")
 	for _, p := range ps {
-		out = append(out, stripModulePrefixes(strings.Split(p, "/")))
+		out = append(out, clipPath(stripModulePrefixes(strings.Split(p, "/"))))
 	}
+	out = out[:len(out):len(out)]
+	pathTagCache.Store(key, out)
 	return out
 }
 
@@ -112,7 +133,29 @@ func RelativeSchemaPathPreferShadow(f reflect.StructField) ([]string, error) {
 // If preferShadowPath is true and the field has a "shadow-path" tag, then the
 // path values from the "shadow-path" tag are used; if the field doesn't have
 // the "shadow-path" tag, then the path values from the "path" tag are used.
+//
+// The returned path is memoized, and hence must not be modified by the
+// caller.
 func relativeSchemaPath(f reflect.StructField, preferShadowPath bool) ([]string, error) {
+	key := pathTagCacheKey{tag: f.Tag, kind: relativeSchemaPathTag}
+	if preferShadowPath {
+		key.kind = relativeShadowSchemaPathTag
+	}
+	if v, ok := pathTagCache.Load(key); ok {
+		return v.([]string), nil
+	}
+
+	p, err := parseRelativeSchemaPath(f, preferShadowPath)
+	if err != nil {
+		return nil, err
+	}
+	p = clipPath(p)
+	pathTagCache.Store(key, p)
+	return p, nil
+}
+
+// parseRelativeSchemaPath implements relativeSchemaPath without memoization.
+func parseRelativeSchemaPath(f reflect.StructField, preferShadowPath bool) ([]string, error) {
 	var pathTag string
 	var ok bool
 	if preferShadowPath {
@@ -142,6 +185,43 @@ func relativeSchemaPath(f reflect.StructField, preferShadowPath bool) ([]string,
 	return nil, fmt.Errorf("field %s had path tag %s with |, but no elements of form a/b", f.Name, pathTag)
 }
 
+// pathTagKind identifies which parsed form of a struct field's path tags is
+// stored in the path tag cache.
+type pathTagKind int
+
+const (
+	// schemaPathsTag indicates the value returned by SchemaPaths.
+	schemaPathsTag pathTagKind = iota
+	// shadowSchemaPathsTag indicates the value returned by ShadowSchemaPaths.
+	shadowSchemaPathsTag
+	// relativeSchemaPathTag indicates the value returned by
+	// RelativeSchemaPath.
+	relativeSchemaPathTag
+	// relativeShadowSchemaPathTag indicates the value returned by
+	// RelativeSchemaPathPreferShadow.
+	relativeShadowSchemaPathTag
+)
+
+// pathTagCacheKey is the key used to memoize the parsed path tags of a
+// struct field. Since reflect.StructField is not comparable, and the parsed
+// paths depend only on the field's tag, fields are keyed by their tag such
+// that all fields with identical tags share a cache entry.
+type pathTagCacheKey struct {
+	tag  reflect.StructTag
+	kind pathTagKind
+}
+
+// pathTagCache is a concurrency-safe cache, keyed by pathTagCacheKey, of
+// parsed struct field path tags. Struct tags are fixed at compile time, so
+// entries never need to be invalidated.
+var pathTagCache sync.Map
+
+// clipPath returns p with its capacity limited to its length, such that a
+// caller appending to a memoized path cannot modify the cached value.
+func clipPath(p []string) []string {
+	return p[:len(p):len(p)]
+}
+
 // SchemaTreePath returns the schema tree path of the supplied yang.Entry
 // skipping any nodes that are themselves not in the path (e.g., choice
 // and case). The path is returned as a string prefixed with the module
@@ -239,10 +319,10 @@ func removeXPATHPredicates(s string) (string, error) {
 			i += len(ss)
 		case si == -1 || ei == -1:
 			// This substring contained a mismatched pair of []s.
-			return "", fmt.Errorf("mismatched brackets within substring %s of %s, [ pos: %d, ] pos: %d", ss, s, si, ei)
+			return "", KindErrorf(ErrInvalidPath, "mismatched brackets within substring %s of %s, [ pos: %d, ] pos: %d", ss, s, si, ei)
 		case si > ei:
 			// This substring contained a ] before a [.
-			return "", fmt.Errorf("incorrect ordering of [] within substring %s of %s, [ pos: %d, ] pos: %d", ss, s, si, ei)
+			return "", KindErrorf(ErrInvalidPath, "incorrect ordering of [] within substring %s of %s, [ pos: %d, ] pos: %d", ss, s, si, ei)
 		default:
 			// This substring contained a matched set of []s.
 			b.WriteString(ss[0:si])
@@ -262,7 +342,7 @@ func removeXPATHPredicates(s string) (string, error) {
 //     root of the schema tree.
 func FindLeafRefSchema(schema *yang.Entry, pathStr string) (*yang.Entry, error) {
 	if pathStr == "" {
-		return nil, fmt.Errorf("leafref schema %s has empty path", schema.Name)
+		return nil, KindErrorf(ErrInvalidPath, "leafref schema %s has empty path", schema.Name)
 	}
 
 	refSchema := schema
@@ -281,12 +361,12 @@ func FindLeafRefSchema(schema *yang.Entry, pathStr string) (*yang.Entry, error)
 	for i := 0; i < len(path); i++ {
 		pe, err := stripModulePrefixWithCheck(path[i])
 		if err != nil {
-			return nil, fmt.Errorf("leafref schema %s path %s: %v", schema.Name, pathStr, err)
+			return nil, KindErrorf(ErrInvalidPath, "leafref schema %s path %s: %v", schema.Name, pathStr, err)
 		}
 
 		if pe == ".." {
 			if refSchema.Parent == nil {
-				return nil, fmt.Errorf("parent of %s is nil for leafref schema %s with path %s", refSchema.Name, schema.Name, pathStr)
+				return nil, KindErrorf(ErrPathNotFound, "parent of %s is nil for leafref schema %s with path %s", refSchema.Name, schema.Name, pathStr)
 			}
 			for refSchema = refSchema.Parent; IsChoiceOrCase(refSchema); refSchema = refSchema.Parent {
 			}
@@ -297,7 +377,7 @@ func FindLeafRefSchema(schema *yang.Entry, pathStr string) (*yang.Entry, error)
 			return nil, err
 		}
 		if entries[pe] == nil {
-			return nil, fmt.Errorf("schema node %s is nil for leafref schema %s with path %s", pe, schema.Name, pathStr)
+			return nil, KindErrorf(ErrPathNotFound, "schema node %s is nil for leafref schema %s with path %s", pe, schema.Name, pathStr)
 		}
 		refSchema = entries[pe]
 	}
@@ -333,7 +413,7 @@ func stripModulePrefixWithCheck(name string) (string, error) {
 	case 2:
 		return ps[1], nil
 	}
-	return "", fmt.Errorf("path element did not form a valid name (name, prefix:name): %v", name)
+	return "", KindErrorf(ErrInvalidPath, "path element did not form a valid name (name, prefix:name): %v", name)
 }
 
 // StripModulePrefix removes the prefix from a YANG path element, and
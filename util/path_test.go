@@ -17,6 +17,7 @@ package util
 import (
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -152,6 +153,90 @@ func TestSchemaPaths(t *testing.T) {
 	}
 }
 
+func TestPathTagMemoization(t *testing.T) {
+	pct := reflect.TypeOf(PathContainerType{})
+	ft, ok := pct.FieldByName("Both")
+	if !ok {
+		t.Fatal("could not find field Both")
+	}
+
+	wantSchemaPaths := [][]string{{"a"}, {"config", "a"}}
+	wantShadowPaths := [][]string{{"a"}, {"state", "a"}}
+	wantRelPath := []string{"config", "a"}
+	wantRelShadowPath := []string{"state", "a"}
+
+	var wg sync.WaitGroup
+	for i := 0; i != 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sp, err := SchemaPaths(ft)
+			if err != nil {
+				t.Errorf("SchemaPaths: unexpected error: %v", err)
+				return
+			}
+			if diff := cmp.Diff(wantSchemaPaths, sp); diff != "" {
+				t.Errorf("SchemaPaths (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(wantShadowPaths, ShadowSchemaPaths(ft)); diff != "" {
+				t.Errorf("ShadowSchemaPaths (-want, +got):\n%s", diff)
+			}
+			rp, err := RelativeSchemaPath(ft)
+			if err != nil {
+				t.Errorf("RelativeSchemaPath: unexpected error: %v", err)
+				return
+			}
+			if diff := cmp.Diff(wantRelPath, rp); diff != "" {
+				t.Errorf("RelativeSchemaPath (-want, +got):\n%s", diff)
+			}
+			rsp, err := RelativeSchemaPathPreferShadow(ft)
+			if err != nil {
+				t.Errorf("RelativeSchemaPathPreferShadow: unexpected error: %v", err)
+				return
+			}
+			if diff := cmp.Diff(wantRelShadowPath, rsp); diff != "" {
+				t.Errorf("RelativeSchemaPathPreferShadow (-want, +got):\n%s", diff)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Appending to a returned path must not modify the memoized value.
+	sp, err := SchemaPaths(ft)
+	if err != nil {
+		t.Fatalf("SchemaPaths: unexpected error: %v", err)
+	}
+	_ = append(sp[1], "b")
+	_ = append(sp, []string{"c"})
+	rp, err := RelativeSchemaPath(ft)
+	if err != nil {
+		t.Fatalf("RelativeSchemaPath: unexpected error: %v", err)
+	}
+	_ = append(rp, "b")
+
+	sp, err = SchemaPaths(ft)
+	if err != nil {
+		t.Fatalf("SchemaPaths: unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(wantSchemaPaths, sp); diff != "" {
+		t.Errorf("SchemaPaths after append (-want, +got):\n%s", diff)
+	}
+	if rp, _ = RelativeSchemaPath(ft); !cmp.Equal(wantRelPath, rp) {
+		t.Errorf("RelativeSchemaPath after append: got %v, want %v", rp, wantRelPath)
+	}
+
+	// Errors are not memoized, and hence still report the field name.
+	nf, ok := pct.FieldByName("NoPath")
+	if !ok {
+		t.Fatal("could not find field NoPath")
+	}
+	for i := 0; i != 2; i++ {
+		if _, err := SchemaPaths(nf); err == nil {
+			t.Errorf("SchemaPaths(NoPath): did not get expected error")
+		}
+	}
+}
+
 func TestSchemaTreePath(t *testing.T) {
 	tests := []struct {
 		name         string
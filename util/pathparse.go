@@ -0,0 +1,268 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// ParseGNMIPath parses the string path, which is an XPath-like path of the
+// form described in the gNMI path conventions
+// (https://github.com/openconfig/reference/blob/master/rpc/gnmi/gnmi-path-conventions.md),
+// into a gNMI Path using the PathElem message. Both absolute ("/a/b") and
+// relative ("../a") paths are accepted, and a trailing "/" is ignored.
+//
+// Each element may have any number of predicates of the form [key=value]. A
+// value is either:
+//   - unquoted, in which case it extends to the first unescaped "]", and may
+//     contain "/", "[" and "=" characters.
+//   - quoted using single or double quotes, in which case it extends to the
+//     matching unescaped quote, and may contain any character, including
+//     "]".
+//
+// A backslash escapes the following character in element names, key names
+// and values, such that "\/" may be used within an element name, and "\]"
+// or "\=" within an unquoted value. Key names and values are unescaped
+// exactly once, such that "\\" results in a single backslash. For
+// compatibility with earlier parsing, element names are unescaped a second
+// time, such that "\\\/" results in "/" rather than "\/". The second pass
+// only removes backslashes that remain after the first, such that an
+// element name that contained no escaped backslash is unchanged by it.
+//
+// Namespace prefixes of element and key names (e.g., "oc-if:interfaces") are
+// retained. StripGNMIPathModulePrefixes can be used to remove them.
+//
+// Errors returned are of kind ErrInvalidPath, and identify the position
+// within path at which parsing failed.
+func ParseGNMIPath(path string) (*gpb.Path, error) {
+	p := &pathParser{in: []rune(path)}
+	elems, err := p.parse()
+	if err != nil {
+		return nil, KindErrorf(ErrInvalidPath, "invalid path %q: %v at position %d", path, err, p.pos)
+	}
+	return &gpb.Path{Elem: elems}, nil
+}
+
+// StripGNMIPathModulePrefixes returns a copy of path with the namespace
+// prefixes removed from the name of each element and key.
+func StripGNMIPathModulePrefixes(path *gpb.Path) *gpb.Path {
+	if path == nil {
+		return nil
+	}
+	np := &gpb.Path{Origin: path.GetOrigin(), Target: path.GetTarget()}
+	for _, e := range path.GetElem() {
+		ne := &gpb.PathElem{Name: StripModulePrefix(e.GetName())}
+		for k, v := range e.GetKey() {
+			if ne.Key == nil {
+				ne.Key = map[string]string{}
+			}
+			ne.Key[StripModulePrefix(k)] = v
+		}
+		np.Elem = append(np.Elem, ne)
+	}
+	return np
+}
+
+// pathParser is a parser for gNMI string paths.
+type pathParser struct {
+	// in is the input path.
+	in []rune
+	// pos is the index of the next rune to be consumed from in.
+	pos int
+}
+
+// parse parses the input path, returning its elements.
+func (p *pathParser) parse() ([]*gpb.PathElem, error) {
+	var elems []*gpb.PathElem
+	if p.peek() == '/' {
+		p.pos++
+	}
+	for !p.done() {
+		e, err := p.parseElem()
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, e)
+		if p.done() {
+			break
+		}
+		// parseElem consumes all characters up to the next unescaped /.
+		p.pos++
+	}
+	return elems, nil
+}
+
+// done reports whether the entire input has been consumed.
+func (p *pathParser) done() bool {
+	return p.pos >= len(p.in)
+}
+
+// peek returns the next rune of the input without consuming it, or 0 if the
+// input has been consumed.
+func (p *pathParser) peek() rune {
+	if p.done() {
+		return 0
+	}
+	return p.in[p.pos]
+}
+
+// parseElem parses a single path element, consisting of its name and any
+// predicates, stopping at the / that terminates the element, or the end of
+// the input.
+func (p *pathParser) parseElem() (*gpb.PathElem, error) {
+	name, err := p.readUntil("/[]")
+	if err != nil {
+		return nil, err
+	}
+	// Escape characters within element names have historically been
+	// removed twice, once when splitting the path into elements, and again
+	// when parsing the element, such that `\\/` results in "/". This
+	// behaviour is retained for compatibility.
+	name = unescapePathString(name)
+	switch {
+	case p.peek() == ']':
+		return nil, fmt.Errorf("received an unescaped ] when not in a key for element %s", name)
+	case name == "" && p.peek() == '[':
+		return nil, fmt.Errorf("received a value when the element name was null")
+	case name == "":
+		return nil, fmt.Errorf("empty element name")
+	case strings.IndexFunc(name, unicode.IsSpace) != -1:
+		return nil, fmt.Errorf("invalid space character included in element name '%s'", name)
+	}
+
+	e := &gpb.PathElem{Name: name}
+	for p.peek() == '[' {
+		p.pos++
+		k, v, err := p.parsePredicate(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := e.Key[k]; ok {
+			return nil, fmt.Errorf("duplicate key %s in element %s", k, name)
+		}
+		if e.Key == nil {
+			e.Key = map[string]string{}
+		}
+		e.Key[k] = v
+	}
+
+	if !p.done() && p.peek() != '/' {
+		start := p.pos
+		garbage, err := p.readUntil("/")
+		if err != nil {
+			return nil, err
+		}
+		p.pos = start
+		return nil, fmt.Errorf("trailing garbage following keys in element %s, got: %s", name, garbage)
+	}
+	return e, nil
+}
+
+// parsePredicate parses the contents of a predicate of the element named
+// elem, following the opening [, and consumes the closing ].
+func (p *pathParser) parsePredicate(elem string) (string, string, error) {
+	k, err := p.readUntil("=[]")
+	if err != nil {
+		return "", "", err
+	}
+	switch {
+	case p.done():
+		return "", "", fmt.Errorf("unterminated predicate in element %s", elem)
+	case p.peek() == '[':
+		return "", "", fmt.Errorf("received an unescaped [ in key of element %s", elem)
+	case p.peek() == ']', k == "":
+		return "", "", fmt.Errorf("received null key name for element %s", elem)
+	case strings.IndexFunc(k, unicode.IsSpace) != -1:
+		return "", "", fmt.Errorf("received an invalid space in element %s key name '%s'", elem, k)
+	}
+	// Consume the =.
+	p.pos++
+
+	var v string
+	switch q := p.peek(); q {
+	case '\'', '"':
+		p.pos++
+		if v, err = p.readUntil(string(q)); err != nil {
+			return "", "", err
+		}
+		if p.done() {
+			return "", "", fmt.Errorf("unterminated quoted value for key %s of element %s", k, elem)
+		}
+		// Consume the closing quote.
+		p.pos++
+		if p.peek() != ']' {
+			return "", "", fmt.Errorf("unexpected characters following quoted value for key %s of element %s", k, elem)
+		}
+	default:
+		if v, err = p.readUntil("]"); err != nil {
+			return "", "", err
+		}
+		if v == "" {
+			return "", "", fmt.Errorf("received null value for key %s of element %s", k, elem)
+		}
+	}
+	if p.done() {
+		return "", "", fmt.Errorf("unterminated predicate in element %s", elem)
+	}
+	// Consume the ].
+	p.pos++
+	return k, v, nil
+}
+
+// readUntil consumes and returns the input up to, but not including, the
+// first unescaped rune within stop, or the end of the input. Escape
+// characters are removed from the returned string.
+func (p *pathParser) readUntil(stop string) (string, error) {
+	var b strings.Builder
+	for ; !p.done(); p.pos++ {
+		ch := p.in[p.pos]
+		switch {
+		case ch == '\\':
+			p.pos++
+			if p.done() {
+				return "", fmt.Errorf("trailing escape character")
+			}
+			b.WriteRune(p.in[p.pos])
+			continue
+		case strings.ContainsRune(stop, ch):
+			return b.String(), nil
+		}
+		b.WriteRune(ch)
+	}
+	return b.String(), nil
+}
+
+// unescapePathString removes escape characters from s, such that each
+// escaped character is replaced by the character itself.
+func unescapePathString(s string) string {
+	if !strings.ContainsRune(s, '\\') {
+		return s
+	}
+	var b strings.Builder
+	var inEscape bool
+	for _, ch := range s {
+		if ch == '\\' && !inEscape {
+			inEscape = true
+			continue
+		}
+		b.WriteRune(ch)
+		inEscape = false
+	}
+	return b.String()
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestParseGNMIPath(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               string
+		want             *gpb.Path
+		wantErrSubstring string
+	}{{
+		desc: "simple path",
+		in:   "/a/b",
+		want: &gpb.Path{Elem: []*gpb.PathElem{{Name: "a"}, {Name: "b"}}},
+	}, {
+		desc: "relative path with trailing slash",
+		in:   "../a/",
+		want: &gpb.Path{Elem: []*gpb.PathElem{{Name: ".."}, {Name: "a"}}},
+	}, {
+		desc: "root",
+		in:   "/",
+		want: &gpb.Path{},
+	}, {
+		desc: "multiple predicates",
+		in:   "/a/b[c=d][e=f]/g",
+		want: &gpb.Path{Elem: []*gpb.PathElem{
+			{Name: "a"},
+			{Name: "b", Key: map[string]string{"c": "d", "e": "f"}},
+			{Name: "g"},
+		}},
+	}, {
+		desc: "namespace prefixes",
+		in:   "/oc-if:interfaces/oc-if:interface[oc-if:name=eth0]/oc-if:state",
+		want: &gpb.Path{Elem: []*gpb.PathElem{
+			{Name: "oc-if:interfaces"},
+			{Name: "oc-if:interface", Key: map[string]string{"oc-if:name": "eth0"}},
+			{Name: "oc-if:state"},
+		}},
+	}, {
+		desc: "single-quoted value containing ] and /",
+		in:   "/interfaces/interface[name='Ethernet1/1]']/state",
+		want: &gpb.Path{Elem: []*gpb.PathElem{
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": "Ethernet1/1]"}},
+			{Name: "state"},
+		}},
+	}, {
+		desc: "double-quoted value containing [ and =",
+		in:   `/a/b[c="x[y=z]"]`,
+		want: &gpb.Path{Elem: []*gpb.PathElem{
+			{Name: "a"},
+			{Name: "b", Key: map[string]string{"c": "x[y=z]"}},
+		}},
+	}, {
+		desc: "quoted value with escaped quote",
+		in:   `/a[b='it\'s']`,
+		want: &gpb.Path{Elem: []*gpb.PathElem{
+			{Name: "a", Key: map[string]string{"b": "it's"}},
+		}},
+	}, {
+		desc: "escaped brackets in unquoted value",
+		in:   `/a[b=\[x\]/y]/c`,
+		want: &gpb.Path{Elem: []*gpb.PathElem{
+			{Name: "a", Key: map[string]string{"b": "[x]/y"}},
+			{Name: "c"},
+		}},
+	}, {
+		desc: "escaped ] and = in unquoted value",
+		in:   `/a[b=x\=y\]z]/c`,
+		want: &gpb.Path{Elem: []*gpb.PathElem{
+			{Name: "a", Key: map[string]string{"b": "x=y]z"}},
+			{Name: "c"},
+		}},
+	}, {
+		desc: "escaped backslash in value unescaped once",
+		in:   `/a[b=c\\\]d]`,
+		want: &gpb.Path{Elem: []*gpb.PathElem{
+			{Name: "a", Key: map[string]string{"b": `c\]d`}},
+		}},
+	}, {
+		desc: "escaped backslash in element name unescaped twice",
+		in:   `/a\\\/b/c`,
+		want: &gpb.Path{Elem: []*gpb.PathElem{
+			{Name: "a/b"},
+			{Name: "c"},
+		}},
+	}, {
+		desc: "slashes in unquoted value",
+		in:   "/interfaces/interface[name=Ethernet1/2/3]/state",
+		want: &gpb.Path{Elem: []*gpb.PathElem{
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": "Ethernet1/2/3"}},
+			{Name: "state"},
+		}},
+	}, {
+		desc:             "unterminated quoted value",
+		in:               "/a[b='c]/d",
+		wantErrSubstring: "unterminated quoted value for key b of element a at position 10",
+	}, {
+		desc:             "characters after quoted value",
+		in:               "/a[b='c'd]",
+		wantErrSubstring: "unexpected characters following quoted value for key b of element a at position 8",
+	}, {
+		desc:             "unterminated predicate",
+		in:               "/a[b=c",
+		wantErrSubstring: "unterminated predicate in element a",
+	}, {
+		desc:             "duplicate key",
+		in:               "/a[b=c][b=d]",
+		wantErrSubstring: "duplicate key b in element a",
+	}, {
+		desc:             "empty element",
+		in:               "/a//b",
+		wantErrSubstring: "empty element name at position 3",
+	}, {
+		desc:             "trailing garbage",
+		in:               "/a[b=c]d/e",
+		wantErrSubstring: "trailing garbage following keys in element a, got: d at position 7",
+	}, {
+		desc:             "trailing escape",
+		in:               `/a\`,
+		wantErrSubstring: "trailing escape character",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ParseGNMIPath(tt.in)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("ParseGNMIPath(%q): did not get expected error, %s", tt.in, diff)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalidPath) {
+					t.Errorf("ParseGNMIPath(%q): got error %v, want error of kind ErrInvalidPath", tt.in, err)
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ParseGNMIPath(%q): did not get expected path (-want, +got):\n%s", tt.in, diff)
+			}
+		})
+	}
+}
+
+func TestStripGNMIPathModulePrefixes(t *testing.T) {
+	in, err := ParseGNMIPath("/oc-if:interfaces/oc-if:interface[oc-if:name=a:b]/state")
+	if err != nil {
+		t.Fatalf("ParseGNMIPath: got unexpected error: %v", err)
+	}
+	want := &gpb.Path{Elem: []*gpb.PathElem{
+		{Name: "interfaces"},
+		{Name: "interface", Key: map[string]string{"name": "a:b"}},
+		{Name: "state"},
+	}}
+	if diff := cmp.Diff(want, StripGNMIPathModulePrefixes(in), protocmp.Transform()); diff != "" {
+		t.Errorf("StripGNMIPathModulePrefixes: did not get expected path (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// PresenceFieldName is the name of the field of a GoStruct generated with
+// presence bitmaps that records which of its scalar leaves are set. Such
+// GoStructs store scalar leaves as values rather than pointers, with the
+// field being a [N]uint64 array in which the bit whose index is given by the
+// presence tag of a leaf's field is set if the leaf is set.
+const PresenceFieldName = "ΛPresence"
+
+// IsYgotPresence reports whether struct field s is the presence bitmap of a
+// GoStruct generated with presence bitmaps.
+func IsYgotPresence(s reflect.StructField) bool {
+	_, ok := s.Tag.Lookup("ygotPresence")
+	return ok
+}
+
+// PresenceBit returns the index of the bit of the presence bitmap that
+// records whether the leaf stored in struct field s is set, and whether the
+// presence of the leaf is recorded in the bitmap.
+func PresenceBit(s reflect.StructField) (int, bool) {
+	t, ok := s.Tag.Lookup("presence")
+	if !ok {
+		return 0, false
+	}
+	bit, err := strconv.Atoi(t)
+	if err != nil || bit < 0 {
+		return 0, false
+	}
+	return bit, true
+}
+
+// presenceWord returns the element of the presence bitmap of the struct sv
+// that stores bit, along with the mask of bit within it.
+func presenceWord(sv reflect.Value, bit int) (reflect.Value, uint64, error) {
+	pv := sv.FieldByName(PresenceFieldName)
+	if !pv.IsValid() || pv.Kind() != reflect.Array || pv.Type().Elem().Kind() != reflect.Uint64 {
+		return reflect.Value{}, 0, fmt.Errorf("struct type %v does not have a valid %s field", sv.Type(), PresenceFieldName)
+	}
+	if bit/64 >= pv.Len() {
+		return reflect.Value{}, 0, fmt.Errorf("presence bit %d is out of range for struct type %v", bit, sv.Type())
+	}
+	return pv.Index(bit / 64), 1 << uint(bit%64), nil
+}
+
+// IsLeafSet reports whether the leaf stored in struct field sf of the struct
+// sv, whose presence is recorded in the presence bitmap of sv, is set.
+func IsLeafSet(sv reflect.Value, sf reflect.StructField) bool {
+	bit, ok := PresenceBit(sf)
+	if !ok {
+		return false
+	}
+	w, mask, err := presenceWord(sv, bit)
+	if err != nil {
+		return false
+	}
+	return w.Uint()&mask != 0
+}
+
+// SetLeafPresence records whether the leaf stored in struct field sf of the
+// struct sv, which must be addressable, is set. If the leaf is unset, its
+// field is reset to the zero value.
+func SetLeafPresence(sv reflect.Value, sf reflect.StructField, set bool) error {
+	bit, ok := PresenceBit(sf)
+	if !ok {
+		return fmt.Errorf("field %s of struct type %v does not have a presence bit", sf.Name, sv.Type())
+	}
+	w, mask, err := presenceWord(sv, bit)
+	if err != nil {
+		return err
+	}
+	if !w.CanSet() {
+		return fmt.Errorf("cannot set presence of field %s of unaddressable struct type %v", sf.Name, sv.Type())
+	}
+	if set {
+		w.SetUint(w.Uint() | mask)
+		return nil
+	}
+	w.SetUint(w.Uint() &^ mask)
+	sv.FieldByIndex(sf.Index).Set(reflect.Zero(sf.Type))
+	return nil
+}
+
+// FieldValue returns the value of the i'th field of the struct sv. Where
+// the presence of the leaf stored in the field is recorded in the presence
+// bitmap of sv, the value is returned as a pointer to the field, or as a nil
+// pointer if the leaf is unset, such that it can be handled in the same way
+// as the leaves of GoStructs that store scalar leaves as pointers.
+func FieldValue(sv reflect.Value, i int) reflect.Value {
+	fv := sv.Field(i)
+	sf := sv.Type().Field(i)
+	if _, ok := PresenceBit(sf); !ok {
+		return fv
+	}
+	if !IsLeafSet(sv, sf) {
+		return reflect.Zero(reflect.PtrTo(sf.Type))
+	}
+	if fv.CanAddr() {
+		return fv.Addr()
+	}
+	pv := reflect.New(sf.Type)
+	pv.Elem().Set(fv)
+	return pv
+}
+
+// ZeroFieldValue returns the zero value of the struct field sf, as it is
+// returned by FieldValue.
+func ZeroFieldValue(sf reflect.StructField) reflect.Value {
+	if _, ok := PresenceBit(sf); ok {
+		return reflect.Zero(reflect.PtrTo(sf.Type))
+	}
+	return reflect.Zero(sf.Type)
+}
+
+// SetFieldValue sets the i'th field of the struct sv, which must be
+// addressable, to v, which is in the form that is returned by FieldValue.
+// Where the presence of the leaf stored in the field is recorded in the
+// presence bitmap of sv, a nil v unsets the leaf.
+func SetFieldValue(sv reflect.Value, i int, v reflect.Value) error {
+	sf := sv.Type().Field(i)
+	if _, ok := PresenceBit(sf); !ok {
+		sv.Field(i).Set(v)
+		return nil
+	}
+	if !v.IsValid() || v.IsNil() {
+		return SetLeafPresence(sv, sf, false)
+	}
+	sv.Field(i).Set(v.Elem())
+	return SetLeafPresence(sv, sf, true)
+}
+
+// ClearField resets the i'th field of the struct sv, which must be
+// addressable, to its zero value, such that the node that it stores is
+// unset.
+func ClearField(sv reflect.Value, i int) error {
+	return SetFieldValue(sv, i, ZeroFieldValue(sv.Type().Field(i)))
+}
@@ -0,0 +1,192 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+// presenceStruct is a struct that stores scalar leaves as values, with
+// their presence recorded in a presence bitmap.
+type presenceStruct struct {
+	Name      string    `path:"name" presence:"0"`
+	Mtu       uint16    `path:"mtu" presence:"65"`
+	Ptr       *string   `path:"ptr"`
+	ΛPresence [2]uint64 `ygotPresence:"true"`
+}
+
+// noBitmapStruct is a struct that has a presence tag, but no presence
+// bitmap.
+type noBitmapStruct struct {
+	Name string `path:"name" presence:"0"`
+}
+
+func TestPresenceBit(t *testing.T) {
+	st := reflect.TypeOf(presenceStruct{})
+	tests := []struct {
+		desc    string
+		inField string
+		wantBit int
+		wantOK  bool
+	}{{
+		desc:    "leaf with presence bit",
+		inField: "Mtu",
+		wantBit: 65,
+		wantOK:  true,
+	}, {
+		desc:    "pointer leaf",
+		inField: "Ptr",
+	}, {
+		desc:    "presence bitmap",
+		inField: PresenceFieldName,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			sf, _ := st.FieldByName(tt.inField)
+			gotBit, gotOK := PresenceBit(sf)
+			if gotBit != tt.wantBit || gotOK != tt.wantOK {
+				t.Errorf("PresenceBit(%s): got (%d, %v), want (%d, %v)", tt.inField, gotBit, gotOK, tt.wantBit, tt.wantOK)
+			}
+			if got, want := IsYgotPresence(sf), tt.inField == PresenceFieldName; got != want {
+				t.Errorf("IsYgotPresence(%s): got %v, want %v", tt.inField, got, want)
+			}
+		})
+	}
+}
+
+func TestSetLeafPresence(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inStruct         interface{}
+		inField          string
+		inSet            bool
+		wantStruct       interface{}
+		wantErrSubstring string
+	}{{
+		desc:       "set leaf in second word",
+		inStruct:   &presenceStruct{Mtu: 1500},
+		inField:    "Mtu",
+		inSet:      true,
+		wantStruct: &presenceStruct{Mtu: 1500, ΛPresence: [2]uint64{0, 2}},
+	}, {
+		desc:       "unset leaf resets value",
+		inStruct:   &presenceStruct{Name: "eth0", ΛPresence: [2]uint64{1, 0}},
+		inField:    "Name",
+		wantStruct: &presenceStruct{},
+	}, {
+		desc:             "field without presence bit",
+		inStruct:         &presenceStruct{},
+		inField:          "Ptr",
+		inSet:            true,
+		wantErrSubstring: "does not have a presence bit",
+	}, {
+		desc:             "struct without presence bitmap",
+		inStruct:         &noBitmapStruct{},
+		inField:          "Name",
+		inSet:            true,
+		wantErrSubstring: "does not have a valid ΛPresence field",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			sv := reflect.ValueOf(tt.inStruct).Elem()
+			sf, _ := sv.Type().FieldByName(tt.inField)
+			err := SetLeafPresence(sv, sf, tt.inSet)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("SetLeafPresence: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.wantStruct, tt.inStruct); diff != "" {
+				t.Errorf("SetLeafPresence: (-want, +got):\n%s", diff)
+			}
+			if got := IsLeafSet(sv, sf); got != tt.inSet {
+				t.Errorf("IsLeafSet: got %v, want %v", got, tt.inSet)
+			}
+		})
+	}
+}
+
+func TestFieldValue(t *testing.T) {
+	name := "eth0"
+	s := &presenceStruct{Name: "eth0", Mtu: 1500, Ptr: &name, ΛPresence: [2]uint64{1, 0}}
+	sv := reflect.ValueOf(s).Elem()
+
+	// A set leaf is returned as a pointer to its field.
+	nv := FieldValue(sv, 0)
+	if nv.Kind() != reflect.Ptr || nv.Pointer() != reflect.ValueOf(&s.Name).Pointer() {
+		t.Errorf("FieldValue(Name): got %v, want pointer to field", nv)
+	}
+	// An unset leaf is returned as a nil pointer, despite its value.
+	if mv := FieldValue(sv, 1); mv.Kind() != reflect.Ptr || !mv.IsNil() {
+		t.Errorf("FieldValue(Mtu): got %v, want nil pointer", mv)
+	}
+	if pv := FieldValue(sv, 2); pv.Interface() != s.Ptr {
+		t.Errorf("FieldValue(Ptr): got %v, want %v", pv, s.Ptr)
+	}
+	// Copies of set leaves are returned for unaddressable structs.
+	if cv := FieldValue(reflect.ValueOf(*s), 0); cv.Kind() != reflect.Ptr || cv.Elem().String() != "eth0" {
+		t.Errorf("FieldValue(Name) of unaddressable struct: got %v, want pointer to eth0", cv)
+	}
+
+	mtu := uint16(9000)
+	if err := SetFieldValue(sv, 1, reflect.ValueOf(&mtu)); err != nil {
+		t.Fatalf("SetFieldValue(Mtu): %v", err)
+	}
+	if err := ClearField(sv, 0); err != nil {
+		t.Fatalf("ClearField(Name): %v", err)
+	}
+	if err := ClearField(sv, 2); err != nil {
+		t.Fatalf("ClearField(Ptr): %v", err)
+	}
+	want := &presenceStruct{Mtu: 9000, ΛPresence: [2]uint64{0, 2}}
+	if diff := cmp.Diff(want, s); diff != "" {
+		t.Errorf("did not get expected struct after setting fields, (-want, +got):\n%s", diff)
+	}
+}
+
+func TestInsertIntoPresenceField(t *testing.T) {
+	s := &presenceStruct{}
+	if err := InsertIntoStruct(s, "Mtu", uint16(1500)); err != nil {
+		t.Fatalf("InsertIntoStruct(Mtu): %v", err)
+	}
+	name := "eth0"
+	if err := InsertIntoStruct(s, "Name", &name); err != nil {
+		t.Fatalf("InsertIntoStruct(Name): %v", err)
+	}
+	want := &presenceStruct{Name: "eth0", Mtu: 1500, ΛPresence: [2]uint64{1, 2}}
+	if diff := cmp.Diff(want, s); diff != "" {
+		t.Errorf("InsertIntoStruct: (-want, +got):\n%s", diff)
+	}
+
+	var nilName *string
+	if err := InsertIntoStruct(s, "Name", nilName); err != nil {
+		t.Fatalf("InsertIntoStruct(Name, nil): %v", err)
+	}
+	want = &presenceStruct{Mtu: 1500, ΛPresence: [2]uint64{0, 2}}
+	if diff := cmp.Diff(want, s); diff != "" {
+		t.Errorf("InsertIntoStruct with nil value: (-want, +got):\n%s", diff)
+	}
+
+	if err := InsertIntoStruct(s, "Mtu", "1500"); err == nil {
+		t.Errorf("InsertIntoStruct(Mtu, string): did not get expected error")
+	}
+}
@@ -252,6 +252,10 @@ func InsertIntoStruct(parentStruct interface{}, fieldName string, fieldValue int
 		return fmt.Errorf("parent type %T does not have a field name %s", parentStruct, fieldName)
 	}
 
+	if _, ok := PresenceBit(ft); ok {
+		return insertIntoPresenceField(pv.Elem(), ft, v)
+	}
+
 	// YANG empty fields are represented as a derived bool value defined in the
 	// generated code. Here we cast the value to the type in the generated code.
 	if ft.Type.Kind() == reflect.Bool && t.Kind() == reflect.Bool {
@@ -291,6 +295,26 @@ func InsertIntoStruct(parentStruct interface{}, fieldName string, fieldValue int
 	return nil
 }
 
+// insertIntoPresenceField updates the field ft of the struct sv, which
+// stores a leaf whose presence is recorded in the presence bitmap of sv, with
+// the value v, which may be a pointer. If v is nil, the leaf is unset.
+func insertIntoPresenceField(sv reflect.Value, ft reflect.StructField, v reflect.Value) error {
+	if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return SetLeafPresence(sv, ft, false)
+	}
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Type() != ft.Type {
+		if v.Kind() != ft.Type.Kind() || !v.Type().ConvertibleTo(ft.Type) {
+			return fmt.Errorf("cannot assign value %v (type %v) to struct field %s (type %v) in struct %v", v, v.Type(), ft.Name, ft.Type, sv.Type())
+		}
+		v = v.Convert(ft.Type)
+	}
+	sv.FieldByIndex(ft.Index).Set(v)
+	return SetLeafPresence(sv, ft, true)
+}
+
 // InsertIntoSliceStructField inserts fieldValue into a field of type slice in
 // parentStruct called fieldName (which must exist, but may be nil).
 func InsertIntoSliceStructField(parentStruct interface{}, fieldName string, fieldValue interface{}) error {
@@ -861,44 +885,50 @@ func getNodesContainer(schema *yang.Entry, root interface{}, path *gpb.Path) ([]
 		return nil, nil, fmt.Errorf("getNodesContainer: root has type %T, expect struct ptr", root)
 	}
 
-	v := rv.Elem()
-
-	for i := 0; i < v.NumField(); i++ {
-		f := v.Field(i)
-		ft := v.Type().Field(i)
-
-		// Skip annotation fields, since they do not have a schema.
-		if IsYgotAnnotation(ft) {
-			continue
-		}
+	// The fields of extension structs are matched as fields of the struct,
+	// with the schema of the extended node as their parent.
+	structs := []AttachedExtension{{Value: rv, Schema: schema}}
+	structs = append(structs, AttachedExtensions(rv)...)
+	for _, st := range structs {
+		v := st.Value.Elem()
+		for i := 0; i < v.NumField(); i++ {
+			f := FieldValue(v, i)
+			ft := v.Type().Field(i)
+
+			// Skip annotation fields, presence bitmaps and extension
+			// structs, since they do not have a schema.
+			if IsYgotAnnotation(ft) || IsYgotPresence(ft) || IsYgotExtensions(ft) {
+				continue
+			}
 
-		cschema, err := ChildSchema(schema, ft)
-		if err != nil {
-			return nil, nil, fmt.Errorf("error for schema for type %T, field name %s: %s", root, ft.Name, err)
-		}
-		if cschema == nil {
-			return nil, nil, fmt.Errorf("could not find schema for type %T, field name %s", root, ft.Name)
-		}
+			cschema, err := ChildSchema(st.Schema, ft)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error for schema for type %T, field name %s: %s", root, ft.Name, err)
+			}
+			if cschema == nil {
+				return nil, nil, fmt.Errorf("could not find schema for type %T, field name %s", root, ft.Name)
+			}
 
-		ps, err := SchemaPaths(ft)
-		DbgPrint("check field name %s, paths %v", cschema.Name, ps)
-		if err != nil {
-			return nil, nil, err
-		}
-		for _, p := range ps {
-			if PathMatchesPrefix(path, p) {
-				// don't trim whole prefix  for keyed list since name and key
-				// are a in the same element.
-				to := len(p)
-				if IsTypeMap(ft.Type) {
-					to--
+			ps, err := SchemaPaths(ft)
+			DbgPrint("check field name %s, paths %v", cschema.Name, ps)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, p := range ps {
+				if PathMatchesPrefix(path, p) {
+					// don't trim whole prefix  for keyed list since name and key
+					// are a in the same element.
+					to := len(p)
+					if IsTypeMap(ft.Type) {
+						to--
+					}
+					return getNodesInternal(cschema, f.Interface(), TrimGNMIPathPrefix(path, p[0:to]))
 				}
-				return getNodesInternal(cschema, f.Interface(), TrimGNMIPathPrefix(path, p[0:to]))
 			}
 		}
 	}
 
-	return nil, nil, DbgErr(fmt.Errorf("could not find path in tree beyond schema node %s, (type %T), remaining path %v", schema.Name, root, path))
+	return nil, nil, DbgErr(KindErrorf(ErrPathNotFound, "could not find path in tree beyond schema node %s, (type %T), remaining path %v", schema.Name, root, path))
 }
 
 // getNodesList traverses the list root, which must be a map of struct
@@ -938,7 +968,7 @@ func getNodesList(schema *yang.Entry, root interface{}, path *gpb.Path) ([]inter
 				// Compare just the single value of the key represented as a string.
 				pathKey, ok := path.GetElem()[0].GetKey()[schema.Key]
 				if !ok {
-					return nil, nil, fmt.Errorf("gnmi path %v does not contain a map entry for the schema key field name %s, parent type %T",
+					return nil, nil, KindErrorf(ErrInvalidKey, "gnmi path %v does not contain a map entry for the schema key field name %s, parent type %T",
 						path, schema.Key, root)
 				}
 				kv, err := getKeyValue(ev.Elem(), schema.Key)
@@ -1026,6 +1056,9 @@ func pathStructTagKey(f reflect.StructField) string {
 func getKeyValue(structVal reflect.Value, key string) (interface{}, error) {
 	for i := 0; i < structVal.NumField(); i++ {
 		f := structVal.Type().Field(i)
+		if IsYgotPresence(f) || IsYgotExtensions(f) {
+			continue
+		}
 		p, err := RelativeSchemaPath(f)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,635 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// SchemaIRVersion is the version of the serialized schema representation
+// produced by NewSchemaIR. It is incremented whenever a change is made to
+// the representation that is not backwards compatible.
+const SchemaIRVersion = 1
+
+// SchemaIR is a stable, JSON-serializable representation of a YANG schema
+// tree. Unlike yang.Entry, it does not reference goyang's AST, and hence can
+// be cached on disk or sent between processes, and converted back to a
+// yang.Entry without re-parsing the YANG modules it was derived from.
+type SchemaIR struct {
+	// Version is the version of the representation, which must be equal
+	// to SchemaIRVersion for the IR to be converted to a yang.Entry.
+	Version int `json:"version"`
+	// Root is the root node of the schema tree.
+	Root *SchemaIRNode `json:"root"`
+}
+
+// Schema node kinds used within SchemaIRNode.
+const (
+	SchemaIRModule       = "module"
+	SchemaIRContainer    = "container"
+	SchemaIRList         = "list"
+	SchemaIRLeaf         = "leaf"
+	SchemaIRLeafList     = "leaf-list"
+	SchemaIRChoice       = "choice"
+	SchemaIRCase         = "case"
+	SchemaIRAnydata      = "anydata"
+	SchemaIRAnyxml       = "anyxml"
+	SchemaIRInput        = "input"
+	SchemaIROutput       = "output"
+	SchemaIRNotification = "notification"
+)
+
+// SchemaIRNode is the serializable representation of a single node of the
+// schema tree.
+type SchemaIRNode struct {
+	// Name is the name of the node.
+	Name string `json:"name"`
+	// Kind is the kind of the node, one of the SchemaIR* node kinds.
+	Kind string `json:"kind"`
+	// Description is the description of the node.
+	Description string `json:"description,omitempty"`
+	// Prefix is the prefix of the module in which the node is defined.
+	Prefix string `json:"prefix,omitempty"`
+	// Config is "true" or "false" if the config statement is explicitly
+	// set for the node, and empty if it is inherited from its parent.
+	Config string `json:"config,omitempty"`
+	// Mandatory is "true" or "false" if the mandatory statement is
+	// explicitly set for the node.
+	Mandatory string `json:"mandatory,omitempty"`
+	// Default is the set of default values of the node.
+	Default []string `json:"default,omitempty"`
+	// Units are the units of the node.
+	Units string `json:"units,omitempty"`
+	// Key is the set of key leaves of a list.
+	Key []string `json:"key,omitempty"`
+	// MinElements and MaxElements are the cardinality constraints of a list
+	// or leaf-list.
+	MinElements uint64 `json:"min-elements,omitempty"`
+	MaxElements uint64 `json:"max-elements,omitempty"`
+	// OrderedByUser specifies whether a list or leaf-list is ordered-by user.
+	OrderedByUser bool `json:"ordered-by-user,omitempty"`
+	// Presence is the argument of the presence statement of a container.
+	Presence string `json:"presence,omitempty"`
+	// IfFeature, When and Must are the arguments of the corresponding
+	// statements of the node.
+	IfFeature []string `json:"if-feature,omitempty"`
+	When      []string `json:"when,omitempty"`
+	Must      []string `json:"must,omitempty"`
+	// Extensions are the extension statements of the node.
+	Extensions []*SchemaIRExtension `json:"extensions,omitempty"`
+	// Type is the type of a leaf or leaf-list.
+	Type *SchemaIRType `json:"type,omitempty"`
+	// Annotation contains the annotations of the node, as set by ygot.
+	Annotation map[string]any `json:"annotation,omitempty"`
+	// Children are the child nodes of the node, sorted by name.
+	Children []*SchemaIRNode `json:"children,omitempty"`
+}
+
+// SchemaIRExtension is the serializable representation of a YANG extension
+// statement.
+type SchemaIRExtension struct {
+	// Keyword is the prefixed keyword of the extension, e.g.,
+	// "oc-ext:telemetry-on-change".
+	Keyword string `json:"keyword"`
+	// Argument is the argument of the extension, if any.
+	Argument string `json:"argument,omitempty"`
+}
+
+// SchemaIRType is the serializable representation of a YANG type.
+type SchemaIRType struct {
+	// Name is the name of the type, which is the typedef name for derived
+	// types.
+	Name string `json:"name"`
+	// Kind is the name of the built-in type that the type is based on,
+	// e.g., "int32".
+	Kind string `json:"kind"`
+	// Default is the default value of the type.
+	Default    string `json:"default,omitempty"`
+	HasDefault bool   `json:"has-default,omitempty"`
+	// Units are the units of the type.
+	Units string `json:"units,omitempty"`
+	// FractionDigits is the fraction-digits of a decimal64 type.
+	FractionDigits int `json:"fraction-digits,omitempty"`
+	// Range and Length are the range and length restrictions of the type.
+	Range  []*SchemaIRRange `json:"range,omitempty"`
+	Length []*SchemaIRRange `json:"length,omitempty"`
+	// Pattern and POSIXPattern are the pattern restrictions of a string.
+	Pattern      []string `json:"pattern,omitempty"`
+	POSIXPattern []string `json:"posix-pattern,omitempty"`
+	// Path is the path of a leafref.
+	Path string `json:"path,omitempty"`
+	// OptionalInstance is true if require-instance is false.
+	OptionalInstance bool `json:"optional-instance,omitempty"`
+	// Enum and Bits are the values of an enumeration or bits type.
+	Enum []*SchemaIREnumValue `json:"enum,omitempty"`
+	Bits []*SchemaIREnumValue `json:"bits,omitempty"`
+	// IdentityBase is the base identity of an identityref.
+	IdentityBase *SchemaIRIdentity `json:"identity-base,omitempty"`
+	// Union contains the member types of a union.
+	Union []*SchemaIRType `json:"union,omitempty"`
+}
+
+// SchemaIRRange is a single range of a range or length restriction. Min and
+// Max are decimal strings.
+type SchemaIRRange struct {
+	Min string `json:"min"`
+	Max string `json:"max"`
+}
+
+// SchemaIREnumValue is a single value of an enumeration or bits type.
+type SchemaIREnumValue struct {
+	Name  string `json:"name"`
+	Value int64  `json:"value"`
+}
+
+// SchemaIRIdentity is the serializable representation of a YANG identity.
+type SchemaIRIdentity struct {
+	// Name is the name of the identity.
+	Name string `json:"name"`
+	// Module is the name of the module that defines the identity, if
+	// known.
+	Module string `json:"module,omitempty"`
+	// Values are the identities derived from the identity.
+	Values []*SchemaIRIdentity `json:"values,omitempty"`
+}
+
+// NewSchemaIR returns the serializable representation of the schema tree
+// rooted at root. RPC entries are not included in the representation.
+func NewSchemaIR(root *yang.Entry) (*SchemaIR, error) {
+	if root == nil {
+		return nil, fmt.Errorf("ygot/util: cannot create schema IR from nil schema")
+	}
+	n, err := schemaIRNode(root)
+	if err != nil {
+		return nil, err
+	}
+	return &SchemaIR{Version: SchemaIRVersion, Root: n}, nil
+}
+
+// ParseSchemaIR unmarshals the JSON-encoded schema IR b, returning an error
+// if it cannot be unmarshalled or is of an unsupported version.
+func ParseSchemaIR(b []byte) (*SchemaIR, error) {
+	ir := &SchemaIR{}
+	if err := json.Unmarshal(b, ir); err != nil {
+		return nil, fmt.Errorf("ygot/util: cannot unmarshal schema IR: %v", err)
+	}
+	if ir.Version != SchemaIRVersion {
+		return nil, fmt.Errorf("ygot/util: unsupported schema IR version %d, want %d", ir.Version, SchemaIRVersion)
+	}
+	return ir, nil
+}
+
+// ToEntry converts the schema IR to a yang.Entry tree, with the Parent field
+// of each entry populated.
+func (ir *SchemaIR) ToEntry() (*yang.Entry, error) {
+	if ir.Version != SchemaIRVersion {
+		return nil, fmt.Errorf("ygot/util: unsupported schema IR version %d, want %d", ir.Version, SchemaIRVersion)
+	}
+	if ir.Root == nil {
+		return nil, fmt.Errorf("ygot/util: schema IR has no root")
+	}
+	return ir.Root.toEntry(nil)
+}
+
+// schemaIRNode converts e and its children to their SchemaIRNode
+// representation.
+func schemaIRNode(e *yang.Entry) (*SchemaIRNode, error) {
+	kind, err := schemaIRKind(e)
+	if err != nil {
+		return nil, err
+	}
+	n := &SchemaIRNode{
+		Name:        e.Name,
+		Kind:        kind,
+		Description: e.Description,
+		Config:      triStateString(e.Config),
+		Mandatory:   triStateString(e.Mandatory),
+		Default:     e.Default,
+		Units:       e.Units,
+		IfFeature:   extraValueNames(e, "if-feature"),
+		When:        extraValueNames(e, "when"),
+		Must:        extraValueNames(e, "must"),
+		Annotation:  e.Annotation,
+	}
+	if e.Prefix != nil {
+		n.Prefix = e.Prefix.Name
+	}
+	if e.Key != "" {
+		n.Key = strings.Fields(e.Key)
+	}
+	if p := extraValueNames(e, "presence"); len(p) != 0 {
+		n.Presence = p[0]
+	}
+	if e.ListAttr != nil {
+		n.MinElements = e.ListAttr.MinElements
+		n.MaxElements = e.ListAttr.MaxElements
+		n.OrderedByUser = e.ListAttr.OrderedByUser
+	}
+	for _, ext := range e.Exts {
+		n.Extensions = append(n.Extensions, &SchemaIRExtension{Keyword: ext.Keyword, Argument: ext.Argument})
+	}
+	if e.Type != nil {
+		if n.Type, err = schemaIRType(e.Type); err != nil {
+			return nil, fmt.Errorf("ygot/util: invalid type for %s: %v", e.Path(), err)
+		}
+	}
+
+	for _, name := range sortedEntryNames(e.Dir) {
+		ch := e.Dir[name]
+		if ch.RPC != nil {
+			continue
+		}
+		chn, err := schemaIRNode(ch)
+		if err != nil {
+			return nil, err
+		}
+		n.Children = append(n.Children, chn)
+	}
+	return n, nil
+}
+
+// schemaIRKind returns the SchemaIRNode kind of e.
+func schemaIRKind(e *yang.Entry) (string, error) {
+	switch e.Kind {
+	case yang.LeafEntry:
+		if e.ListAttr != nil {
+			return SchemaIRLeafList, nil
+		}
+		return SchemaIRLeaf, nil
+	case yang.DirectoryEntry:
+		switch {
+		case e.ListAttr != nil:
+			return SchemaIRList, nil
+		case e.Parent == nil:
+			if _, ok := e.Node.(*yang.Module); ok {
+				return SchemaIRModule, nil
+			}
+		}
+		return SchemaIRContainer, nil
+	case yang.ChoiceEntry:
+		return SchemaIRChoice, nil
+	case yang.CaseEntry:
+		return SchemaIRCase, nil
+	case yang.AnyDataEntry:
+		return SchemaIRAnydata, nil
+	case yang.AnyXMLEntry:
+		return SchemaIRAnyxml, nil
+	case yang.InputEntry:
+		return SchemaIRInput, nil
+	case yang.OutputEntry:
+		return SchemaIROutput, nil
+	case yang.NotificationEntry:
+		return SchemaIRNotification, nil
+	}
+	return "", fmt.Errorf("ygot/util: unsupported entry kind %v for %s", e.Kind, e.Path())
+}
+
+// schemaIRType converts t to its SchemaIRType representation.
+func schemaIRType(t *yang.YangType) (*SchemaIRType, error) {
+	st := &SchemaIRType{
+		Name:             t.Name,
+		Kind:             t.Kind.String(),
+		Default:          t.Default,
+		HasDefault:       t.HasDefault,
+		Units:            t.Units,
+		FractionDigits:   t.FractionDigits,
+		Range:            schemaIRRanges(t.Range),
+		Length:           schemaIRRanges(t.Length),
+		Pattern:          t.Pattern,
+		POSIXPattern:     t.POSIXPattern,
+		Path:             t.Path,
+		OptionalInstance: t.OptionalInstance,
+		Enum:             schemaIREnumValues(t.Enum),
+		Bits:             schemaIREnumValues(t.Bit),
+		IdentityBase:     schemaIRIdentity(t.IdentityBase),
+	}
+	for _, ut := range t.Type {
+		sut, err := schemaIRType(ut)
+		if err != nil {
+			return nil, err
+		}
+		st.Union = append(st.Union, sut)
+	}
+	return st, nil
+}
+
+// schemaIRRanges converts r to its SchemaIRRange representation.
+func schemaIRRanges(r yang.YangRange) []*SchemaIRRange {
+	var out []*SchemaIRRange
+	for _, yr := range r {
+		out = append(out, &SchemaIRRange{Min: yr.Min.String(), Max: yr.Max.String()})
+	}
+	return out
+}
+
+// schemaIREnumValues converts the values of e to their SchemaIREnumValue
+// representation, sorted by value.
+func schemaIREnumValues(e *yang.EnumType) []*SchemaIREnumValue {
+	if e == nil {
+		return nil
+	}
+	var out []*SchemaIREnumValue
+	for name, v := range e.NameMap() {
+		out = append(out, &SchemaIREnumValue{Name: name, Value: v})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Value != out[j].Value {
+			return out[i].Value < out[j].Value
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// schemaIRIdentity converts i to its SchemaIRIdentity representation.
+func schemaIRIdentity(i *yang.Identity) *SchemaIRIdentity {
+	if i == nil {
+		return nil
+	}
+	si := &SchemaIRIdentity{Name: i.Name}
+	if i.Parent != nil {
+		if m := yang.RootNode(i); m != nil {
+			si.Module = m.Name
+		}
+	}
+	for _, v := range i.Values {
+		si.Values = append(si.Values, schemaIRIdentity(v))
+	}
+	return si
+}
+
+// toEntry converts n and its children to a yang.Entry with the supplied
+// parent.
+func (n *SchemaIRNode) toEntry(parent *yang.Entry) (*yang.Entry, error) {
+	e := &yang.Entry{
+		Parent:      parent,
+		Name:        n.Name,
+		Description: n.Description,
+		Default:     n.Default,
+		Units:       n.Units,
+		Key:         strings.Join(n.Key, " "),
+		Annotation:  n.Annotation,
+		Extra:       map[string][]any{},
+	}
+
+	var err error
+	if e.Config, err = parseTriState(n.Config); err != nil {
+		return nil, fmt.Errorf("ygot/util: invalid config for %s: %v", n.Name, err)
+	}
+	if e.Mandatory, err = parseTriState(n.Mandatory); err != nil {
+		return nil, fmt.Errorf("ygot/util: invalid mandatory for %s: %v", n.Name, err)
+	}
+	if n.Prefix != "" {
+		e.Prefix = &yang.Value{Name: n.Prefix}
+	}
+
+	isDir := true
+	switch n.Kind {
+	case SchemaIRModule, SchemaIRContainer:
+		e.Kind = yang.DirectoryEntry
+	case SchemaIRList:
+		e.Kind = yang.DirectoryEntry
+		e.ListAttr = n.listAttr()
+	case SchemaIRLeaf:
+		e.Kind = yang.LeafEntry
+		isDir = false
+	case SchemaIRLeafList:
+		e.Kind = yang.LeafEntry
+		e.ListAttr = n.listAttr()
+		isDir = false
+	case SchemaIRChoice:
+		e.Kind = yang.ChoiceEntry
+	case SchemaIRCase:
+		e.Kind = yang.CaseEntry
+	case SchemaIRAnydata:
+		e.Kind = yang.AnyDataEntry
+		isDir = false
+	case SchemaIRAnyxml:
+		e.Kind = yang.AnyXMLEntry
+		isDir = false
+	case SchemaIRInput:
+		e.Kind = yang.InputEntry
+	case SchemaIROutput:
+		e.Kind = yang.OutputEntry
+	case SchemaIRNotification:
+		e.Kind = yang.NotificationEntry
+	default:
+		return nil, fmt.Errorf("ygot/util: unknown schema IR node kind %q for %s", n.Kind, n.Name)
+	}
+
+	for key, vals := range map[string][]string{
+		"if-feature": n.IfFeature,
+		"when":       n.When,
+		"must":       n.Must,
+	} {
+		for _, v := range vals {
+			e.Extra[key] = append(e.Extra[key], &yang.Value{Name: v})
+		}
+	}
+	if n.Presence != "" {
+		e.Extra["presence"] = []any{&yang.Value{Name: n.Presence}}
+	}
+	for _, ext := range n.Extensions {
+		e.Exts = append(e.Exts, &yang.Statement{Keyword: ext.Keyword, Argument: ext.Argument, HasArgument: ext.Argument != ""})
+	}
+
+	if n.Type != nil {
+		if e.Type, err = n.Type.toYangType(); err != nil {
+			return nil, fmt.Errorf("ygot/util: invalid type for %s: %v", n.Name, err)
+		}
+	}
+
+	if isDir {
+		e.Dir = map[string]*yang.Entry{}
+	}
+	for _, ch := range n.Children {
+		if !isDir {
+			return nil, fmt.Errorf("ygot/util: schema IR node %s of kind %s cannot have children", n.Name, n.Kind)
+		}
+		che, err := ch.toEntry(e)
+		if err != nil {
+			return nil, err
+		}
+		e.Dir[ch.Name] = che
+	}
+	return e, nil
+}
+
+// listAttr returns the yang.ListAttr corresponding to n.
+func (n *SchemaIRNode) listAttr() *yang.ListAttr {
+	return &yang.ListAttr{
+		MinElements:   n.MinElements,
+		MaxElements:   n.MaxElements,
+		OrderedByUser: n.OrderedByUser,
+	}
+}
+
+// toYangType converts t to a yang.YangType.
+func (t *SchemaIRType) toYangType() (*yang.YangType, error) {
+	kind, ok := yang.TypeKindFromName[t.Kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown type kind %q", t.Kind)
+	}
+	yt := &yang.YangType{
+		Name:             t.Name,
+		Kind:             kind,
+		Default:          t.Default,
+		HasDefault:       t.HasDefault,
+		Units:            t.Units,
+		FractionDigits:   t.FractionDigits,
+		Pattern:          t.Pattern,
+		POSIXPattern:     t.POSIXPattern,
+		Path:             t.Path,
+		OptionalInstance: t.OptionalInstance,
+		IdentityBase:     t.IdentityBase.toIdentity(),
+	}
+
+	var err error
+	if yt.Range, err = t.yangRange(t.Range); err != nil {
+		return nil, fmt.Errorf("invalid range: %v", err)
+	}
+	if yt.Length, err = t.yangRange(t.Length); err != nil {
+		return nil, fmt.Errorf("invalid length: %v", err)
+	}
+	if t.Enum != nil {
+		if yt.Enum, err = enumTypeFromValues(yang.NewEnumType(), t.Enum); err != nil {
+			return nil, fmt.Errorf("invalid enum: %v", err)
+		}
+	}
+	if t.Bits != nil {
+		if yt.Bit, err = enumTypeFromValues(yang.NewBitfield(), t.Bits); err != nil {
+			return nil, fmt.Errorf("invalid bits: %v", err)
+		}
+	}
+	for _, ut := range t.Union {
+		yut, err := ut.toYangType()
+		if err != nil {
+			return nil, err
+		}
+		yt.Type = append(yt.Type, yut)
+	}
+	return yt, nil
+}
+
+// yangRange converts rs to a yang.YangRange, using the fraction digits of t
+// to parse decimal values.
+func (t *SchemaIRType) yangRange(rs []*SchemaIRRange) (yang.YangRange, error) {
+	var out yang.YangRange
+	for _, r := range rs {
+		min, err := parseYANGNumber(r.Min, t.FractionDigits)
+		if err != nil {
+			return nil, err
+		}
+		max, err := parseYANGNumber(r.Max, t.FractionDigits)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, yang.YRange{Min: min, Max: max})
+	}
+	return out, nil
+}
+
+// parseYANGNumber parses the decimal string s into a yang.Number, as a
+// decimal64 value if fractionDigits is non-zero.
+func parseYANGNumber(s string, fractionDigits int) (yang.Number, error) {
+	if fractionDigits != 0 {
+		return yang.ParseDecimal(s, uint8(fractionDigits))
+	}
+	return yang.ParseInt(s)
+}
+
+// enumTypeFromValues sets each of vals within the enumerated type e.
+func enumTypeFromValues(e *yang.EnumType, vals []*SchemaIREnumValue) (*yang.EnumType, error) {
+	for _, v := range vals {
+		if err := e.Set(v.Name, v.Value); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// toIdentity converts i to a yang.Identity.
+func (i *SchemaIRIdentity) toIdentity() *yang.Identity {
+	if i == nil {
+		return nil
+	}
+	id := &yang.Identity{Name: i.Name}
+	if i.Module != "" {
+		id.Parent = &yang.Module{Name: i.Module}
+	}
+	for _, v := range i.Values {
+		id.Values = append(id.Values, v.toIdentity())
+	}
+	return id
+}
+
+// triStateString returns the string representation of a yang.TriState, with
+// an unset value being represented by the empty string.
+func triStateString(t yang.TriState) string {
+	switch t {
+	case yang.TSTrue:
+		return "true"
+	case yang.TSFalse:
+		return "false"
+	}
+	return ""
+}
+
+// parseTriState parses the string representation of a yang.TriState.
+func parseTriState(s string) (yang.TriState, error) {
+	switch s {
+	case "":
+		return yang.TSUnset, nil
+	case "true":
+		return yang.TSTrue, nil
+	case "false":
+		return yang.TSFalse, nil
+	}
+	return yang.TSUnset, fmt.Errorf("invalid boolean value %q", s)
+}
+
+// extraValueNames returns the arguments of the statements stored within the
+// Extra field of e under key. Both *yang.Value statements, as produced by
+// goyang, and their JSON-unmarshalled form, as found within schemas stored
+// in generated code, are supported.
+func extraValueNames(e *yang.Entry, key string) []string {
+	var out []string
+	for _, v := range e.Extra[key] {
+		switch v := v.(type) {
+		case *yang.Value:
+			out = append(out, v.Name)
+		case map[string]any:
+			if n, ok := v["Name"].(string); ok {
+				out = append(out, n)
+			}
+		}
+	}
+	return out
+}
+
+// sortedEntryNames returns the keys of the supplied map in lexical order.
+func sortedEntryNames(m map[string]*yang.Entry) []string {
+	names := make([]string, 0, len(m))
+	for k := range m {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
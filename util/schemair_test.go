@@ -0,0 +1,259 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// mustParseDecimal returns the decimal64 yang.Number for s, panicking if it
+// cannot be parsed.
+func mustParseDecimal(s string, fractionDigits uint8) yang.Number {
+	n, err := yang.ParseDecimal(s, fractionDigits)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// schemaIRTestSchema returns a schema tree used to test the schema IR.
+func schemaIRTestSchema() *yang.Entry {
+	enum := yang.NewEnumType()
+	enum.Set("UP", 1)
+	enum.Set("DOWN", 2)
+
+	root := &yang.Entry{
+		Name: "device",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"interfaces": {
+				Name:   "interfaces",
+				Kind:   yang.DirectoryEntry,
+				Prefix: &yang.Value{Name: "oc-if"},
+				Dir: map[string]*yang.Entry{
+					"interface": {
+						Name:     "interface",
+						Kind:     yang.DirectoryEntry,
+						Key:      "name",
+						ListAttr: &yang.ListAttr{MaxElements: 10, OrderedByUser: true},
+						Dir: map[string]*yang.Entry{
+							"name": {
+								Name:        "name",
+								Kind:        yang.LeafEntry,
+								Description: "The name of the interface.",
+								Type: &yang.YangType{
+									Name:    "string",
+									Kind:    yang.Ystring,
+									Length:  yang.YangRange{{Min: yang.FromInt(1), Max: yang.FromInt(64)}},
+									Pattern: []string{"[a-z]+"},
+								},
+							},
+							"mtu": {
+								Name:    "mtu",
+								Kind:    yang.LeafEntry,
+								Default: []string{"1500"},
+								Units:   "octets",
+								Type: &yang.YangType{
+									Name:  "uint16",
+									Kind:  yang.Yuint16,
+									Range: yang.YangRange{{Min: yang.FromInt(68), Max: yang.FromInt(9216)}},
+								},
+								Extra: map[string][]any{
+									"if-feature": {&yang.Value{Name: "jumbo"}},
+								},
+							},
+							"oper-status": {
+								Name:   "oper-status",
+								Kind:   yang.LeafEntry,
+								Config: yang.TSFalse,
+								Type:   &yang.YangType{Name: "enumeration", Kind: yang.Yenum, Enum: enum},
+								Exts:   []*yang.Statement{{Keyword: "oc-ext:telemetry-on-change"}},
+							},
+							"addresses": {
+								Name:     "addresses",
+								Kind:     yang.LeafEntry,
+								ListAttr: &yang.ListAttr{MinElements: 1},
+								Type: &yang.YangType{
+									Name: "union",
+									Kind: yang.Yunion,
+									Type: []*yang.YangType{
+										{Name: "string", Kind: yang.Ystring},
+										{
+											Name:           "decimal64",
+											Kind:           yang.Ydecimal64,
+											FractionDigits: 2,
+											Range:          yang.YangRange{{Min: mustParseDecimal("-1.5", 2), Max: mustParseDecimal("2.25", 2)}},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"system": {
+				Name:      "system",
+				Kind:      yang.DirectoryEntry,
+				Mandatory: yang.TSTrue,
+				Extra: map[string][]any{
+					"presence": {&yang.Value{Name: "system is configured"}},
+					"when":     {map[string]any{"Name": "../enabled = 'true'"}},
+				},
+				Dir: map[string]*yang.Entry{},
+			},
+		},
+	}
+	addParents(root)
+	return root
+}
+
+func TestSchemaIRRoundTrip(t *testing.T) {
+	ir, err := NewSchemaIR(schemaIRTestSchema())
+	if err != nil {
+		t.Fatalf("NewSchemaIR: got unexpected error: %v", err)
+	}
+
+	b, err := json.Marshal(ir)
+	if err != nil {
+		t.Fatalf("cannot marshal schema IR: %v", err)
+	}
+	parsed, err := ParseSchemaIR(b)
+	if err != nil {
+		t.Fatalf("ParseSchemaIR: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(ir, parsed); diff != "" {
+		t.Errorf("ParseSchemaIR: did not get expected IR (-want, +got):\n%s", diff)
+	}
+
+	e, err := parsed.ToEntry()
+	if err != nil {
+		t.Fatalf("ToEntry: got unexpected error: %v", err)
+	}
+
+	mtu := e.Dir["interfaces"].Dir["interface"].Dir["mtu"]
+	if got, want := mtu.Path(), "/device/interfaces/interface/mtu"; got != want {
+		t.Errorf("ToEntry: did not get expected path for mtu, got: %s, want: %s", got, want)
+	}
+	if got, want := mtu.Type.Range.String(), "68..9216"; got != want {
+		t.Errorf("ToEntry: did not get expected range for mtu, got: %s, want: %s", got, want)
+	}
+	if got := IfFeaturesSatisfied(mtu, map[string]bool{"jumbo": true}); !got {
+		t.Errorf("ToEntry: if-feature of mtu was not preserved")
+	}
+
+	list := e.Dir["interfaces"].Dir["interface"]
+	if !list.IsList() || list.Key != "name" || !list.ListAttr.OrderedByUser {
+		t.Errorf("ToEntry: did not get expected list attributes for %s, got: %v, key: %q", list.Path(), list.ListAttr, list.Key)
+	}
+
+	status := list.Dir["oper-status"]
+	if IsConfig(status) {
+		t.Errorf("ToEntry: %s is config true, want false", status.Path())
+	}
+	if got, want := status.Type.Enum.NameMap(), map[string]int64{"UP": 1, "DOWN": 2}; !cmp.Equal(got, want) {
+		t.Errorf("ToEntry: did not get expected enum values, got: %v, want: %v", got, want)
+	}
+
+	union := list.Dir["addresses"]
+	if !union.IsLeafList() {
+		t.Errorf("ToEntry: %s is not a leaf-list", union.Path())
+	}
+	if got, want := union.Type.Type[1].Range.String(), "-1.50..2.25"; got != want {
+		t.Errorf("ToEntry: did not get expected decimal range, got: %s, want: %s", got, want)
+	}
+
+	// Converting the reconstructed schema must produce the same IR.
+	again, err := NewSchemaIR(e)
+	if err != nil {
+		t.Fatalf("NewSchemaIR: got unexpected error for reconstructed schema: %v", err)
+	}
+	if diff := cmp.Diff(ir, again); diff != "" {
+		t.Errorf("NewSchemaIR: reconstructed schema did not produce the same IR (-want, +got):\n%s", diff)
+	}
+}
+
+func TestSchemaIRNode(t *testing.T) {
+	ir, err := NewSchemaIR(schemaIRTestSchema())
+	if err != nil {
+		t.Fatalf("NewSchemaIR: got unexpected error: %v", err)
+	}
+	want := &SchemaIRNode{
+		Name:      "system",
+		Kind:      SchemaIRContainer,
+		Mandatory: "true",
+		Presence:  "system is configured",
+		When:      []string{"../enabled = 'true'"},
+	}
+	if diff := cmp.Diff(want, ir.Root.Children[1]); diff != "" {
+		t.Errorf("NewSchemaIR: did not get expected node (-want, +got):\n%s", diff)
+	}
+}
+
+func TestSchemaIRErrors(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               string
+		wantErrSubstring string
+	}{{
+		desc:             "invalid JSON",
+		in:               "{",
+		wantErrSubstring: "cannot unmarshal schema IR",
+	}, {
+		desc:             "unsupported version",
+		in:               `{"version": 42, "root": {"name": "r", "kind": "container"}}`,
+		wantErrSubstring: "unsupported schema IR version 42",
+	}, {
+		desc:             "unknown kind",
+		in:               `{"version": 1, "root": {"name": "r", "kind": "grouping"}}`,
+		wantErrSubstring: `unknown schema IR node kind "grouping"`,
+	}, {
+		desc:             "unknown type",
+		in:               `{"version": 1, "root": {"name": "r", "kind": "leaf", "type": {"name": "t", "kind": "float"}}}`,
+		wantErrSubstring: `unknown type kind "float"`,
+	}, {
+		desc:             "invalid range",
+		in:               `{"version": 1, "root": {"name": "r", "kind": "leaf", "type": {"name": "t", "kind": "int8", "range": [{"min": "a", "max": "1"}]}}}`,
+		wantErrSubstring: "invalid range",
+	}, {
+		desc:             "leaf with children",
+		in:               `{"version": 1, "root": {"name": "r", "kind": "leaf", "children": [{"name": "c", "kind": "leaf"}]}}`,
+		wantErrSubstring: "cannot have children",
+	}, {
+		desc:             "invalid config",
+		in:               `{"version": 1, "root": {"name": "r", "kind": "container", "config": "maybe"}}`,
+		wantErrSubstring: "invalid config",
+	}, {
+		desc:             "missing root",
+		in:               `{"version": 1}`,
+		wantErrSubstring: "schema IR has no root",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ir, err := ParseSchemaIR([]byte(tt.in))
+			if err == nil {
+				_, err = ir.ToEntry()
+			}
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Errorf("did not get expected error, %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// SchemaVisitFunc is called by WalkSchema for each schema entry that is
+// selected by the filters of the walk. depth is the depth of e relative to
+// the root of the walk, which has depth 0. If DoNotIterateDescendants is
+// returned, the descendants of e are not walked.
+type SchemaVisitFunc func(e *yang.Entry, depth int) IterationAction
+
+// SchemaFilter determines how WalkSchema handles a schema entry at the
+// specified depth. visit reports whether the SchemaVisitFunc should be called
+// for e, and descend reports whether the children of e should be walked.
+type SchemaFilter func(e *yang.Entry, depth int) (visit, descend bool)
+
+// WalkSchema traverses the schema tree rooted at root in depth-first order,
+// calling visit for each entry that is selected by all of the supplied
+// filters. An entry's children are walked only if all of the filters allow
+// descending into it and visit does not return DoNotIterateDescendants for
+// it. Children are walked in lexical order of their names, such that the
+// traversal is deterministic. RPC entries are not walked.
+func WalkSchema(root *yang.Entry, visit SchemaVisitFunc, filters ...SchemaFilter) {
+	walkSchemaInternal(root, 0, visit, filters)
+}
+
+// walkSchemaInternal implements WalkSchema for the entry e at depth.
+func walkSchemaInternal(e *yang.Entry, depth int, visit SchemaVisitFunc, filters []SchemaFilter) {
+	if e == nil {
+		return
+	}
+	doVisit, descend := true, true
+	for _, f := range filters {
+		v, d := f(e, depth)
+		doVisit = doVisit && v
+		descend = descend && d
+	}
+	if doVisit && visit(e, depth) == DoNotIterateDescendants {
+		descend = false
+	}
+	if !descend {
+		return
+	}
+
+	children := Children(e)
+	sort.Slice(children, func(i, j int) bool { return children[i].Name < children[j].Name })
+	for _, ch := range children {
+		walkSchemaInternal(ch, depth+1, visit, filters)
+	}
+}
+
+// ConfigOnly returns a SchemaFilter that selects only configuration (config
+// true) entries. Since the descendants of a config false entry are always
+// config false, they are not walked.
+func ConfigOnly() SchemaFilter {
+	return func(e *yang.Entry, _ int) (bool, bool) {
+		c := IsConfig(e)
+		return c, c
+	}
+}
+
+// StateOnly returns a SchemaFilter that selects only state (config false)
+// entries. Configuration entries are walked, since they may contain state
+// entries, but are not visited.
+func StateOnly() SchemaFilter {
+	return func(e *yang.Entry, _ int) (bool, bool) {
+		return !IsConfig(e), true
+	}
+}
+
+// LeavesOnly returns a SchemaFilter that selects only leaf and leaf-list
+// entries.
+func LeavesOnly() SchemaFilter {
+	return func(e *yang.Entry, _ int) (bool, bool) {
+		return e.IsLeaf() || e.IsLeafList(), true
+	}
+}
+
+// MaxDepth returns a SchemaFilter that selects only entries at a depth of at
+// most n relative to the root of the walk.
+func MaxDepth(n int) SchemaFilter {
+	return func(_ *yang.Entry, depth int) (bool, bool) {
+		return depth <= n, depth < n
+	}
+}
+
+// EnabledFeatures returns a SchemaFilter that selects only entries whose
+// if-feature statements are satisfied by the supplied set of enabled
+// features. Feature names may be supplied with or without a module prefix;
+// prefixes within if-feature statements are ignored. YANG 1.1 if-feature
+// expressions using "not", "and", "or" and parentheses are supported. The
+// descendants of an entry that is not selected are not walked.
+func EnabledFeatures(features ...string) SchemaFilter {
+	enabled := map[string]bool{}
+	for _, f := range features {
+		enabled[StripModulePrefix(f)] = true
+	}
+	return func(e *yang.Entry, _ int) (bool, bool) {
+		ok := IfFeaturesSatisfied(e, enabled)
+		return ok, ok
+	}
+}
+
+// IfFeaturesSatisfied reports whether all if-feature statements of the entry
+// e evaluate to true given the set of enabled features, keyed by feature name
+// without a module prefix. An entry without if-feature statements is always
+// satisfied. An if-feature expression that cannot be parsed is treated as not
+// satisfied.
+func IfFeaturesSatisfied(e *yang.Entry, enabled map[string]bool) bool {
+	for _, v := range e.Extra["if-feature"] {
+		yv, ok := v.(*yang.Value)
+		if !ok {
+			continue
+		}
+		if !evalIfFeature(yv.Name, enabled) {
+			return false
+		}
+	}
+	return true
+}
+
+// evalIfFeature evaluates the YANG 1.1 if-feature-expr expr against the set
+// of enabled features, as defined in RFC7950 Section 7.20.2.
+func evalIfFeature(expr string, enabled map[string]bool) bool {
+	p := &ifFeatureParser{
+		tokens:  strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expr)),
+		enabled: enabled,
+	}
+	v, ok := p.parseOr()
+	return ok && p.pos == len(p.tokens) && v
+}
+
+// ifFeatureParser is a recursive descent parser for if-feature expressions.
+type ifFeatureParser struct {
+	tokens  []string
+	pos     int
+	enabled map[string]bool
+}
+
+// next returns the next token, or the empty string if there are no more
+// tokens.
+func (p *ifFeatureParser) next() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+// parseOr parses an if-feature-expr, returning its value and whether it was
+// parsed successfully.
+func (p *ifFeatureParser) parseOr() (bool, bool) {
+	v, ok := p.parseAnd()
+	for ok && p.next() == "or" {
+		p.pos++
+		var r bool
+		r, ok = p.parseAnd()
+		v = v || r
+	}
+	return v, ok
+}
+
+// parseAnd parses an if-feature-term.
+func (p *ifFeatureParser) parseAnd() (bool, bool) {
+	v, ok := p.parseFactor()
+	for ok && p.next() == "and" {
+		p.pos++
+		var r bool
+		r, ok = p.parseFactor()
+		v = v && r
+	}
+	return v, ok
+}
+
+// parseFactor parses an if-feature-factor.
+func (p *ifFeatureParser) parseFactor() (bool, bool) {
+	switch tok := p.next(); tok {
+	case "", "and", "or", ")":
+		return false, false
+	case "not":
+		p.pos++
+		v, ok := p.parseFactor()
+		return !v, ok
+	case "(":
+		p.pos++
+		v, ok := p.parseOr()
+		if !ok || p.next() != ")" {
+			return false, false
+		}
+		p.pos++
+		return v, true
+	default:
+		p.pos++
+		return p.enabled[StripModulePrefix(tok)], true
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// schemaWalkTestSchema returns a schema tree used to test WalkSchema.
+func schemaWalkTestSchema() *yang.Entry {
+	root := &yang.Entry{
+		Name: "root",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"system": {
+				Name: "system",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"config": {
+						Name: "config",
+						Kind: yang.DirectoryEntry,
+						Dir: map[string]*yang.Entry{
+							"hostname": {
+								Name: "hostname",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Ystring},
+							},
+							"domain": {
+								Name: "domain",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Ystring},
+								Extra: map[string][]any{
+									"if-feature": {&yang.Value{Name: "sys:dns"}},
+								},
+							},
+						},
+					},
+					"state": {
+						Name:   "state",
+						Kind:   yang.DirectoryEntry,
+						Config: yang.TSFalse,
+						Dir: map[string]*yang.Entry{
+							"hostname": {
+								Name: "hostname",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Ystring},
+							},
+							"boot-time": {
+								Name: "boot-time",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yuint64},
+								Extra: map[string][]any{
+									"if-feature": {&yang.Value{Name: "clock and not (legacy or sys:minimal)"}},
+								},
+							},
+						},
+					},
+				},
+			},
+			"ntp": {
+				Name: "ntp",
+				Kind: yang.DirectoryEntry,
+				Extra: map[string][]any{
+					"if-feature": {&yang.Value{Name: "ntp"}},
+				},
+				Dir: map[string]*yang.Entry{
+					"enabled": {
+						Name: "enabled",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Ybool},
+					},
+				},
+			},
+		},
+	}
+	addParents(root)
+	return root
+}
+
+func TestWalkSchema(t *testing.T) {
+	tests := []struct {
+		desc      string
+		inFilters []SchemaFilter
+		inStopAt  string
+		want      []string
+	}{{
+		desc: "no filters",
+		want: []string{
+			"/root",
+			"/root/ntp",
+			"/root/ntp/enabled",
+			"/root/system",
+			"/root/system/config",
+			"/root/system/config/domain",
+			"/root/system/config/hostname",
+			"/root/system/state",
+			"/root/system/state/boot-time",
+			"/root/system/state/hostname",
+		},
+	}, {
+		desc:      "config only",
+		inFilters: []SchemaFilter{ConfigOnly()},
+		want: []string{
+			"/root",
+			"/root/ntp",
+			"/root/ntp/enabled",
+			"/root/system",
+			"/root/system/config",
+			"/root/system/config/domain",
+			"/root/system/config/hostname",
+		},
+	}, {
+		desc:      "state only",
+		inFilters: []SchemaFilter{StateOnly()},
+		want: []string{
+			"/root/system/state",
+			"/root/system/state/boot-time",
+			"/root/system/state/hostname",
+		},
+	}, {
+		desc:      "config leaves only",
+		inFilters: []SchemaFilter{ConfigOnly(), LeavesOnly()},
+		want: []string{
+			"/root/ntp/enabled",
+			"/root/system/config/domain",
+			"/root/system/config/hostname",
+		},
+	}, {
+		desc:      "depth limit",
+		inFilters: []SchemaFilter{MaxDepth(1)},
+		want: []string{
+			"/root",
+			"/root/ntp",
+			"/root/system",
+		},
+	}, {
+		desc:      "no features enabled",
+		inFilters: []SchemaFilter{EnabledFeatures(), LeavesOnly()},
+		want: []string{
+			"/root/system/config/hostname",
+			"/root/system/state/hostname",
+		},
+	}, {
+		desc:      "features enabled",
+		inFilters: []SchemaFilter{EnabledFeatures("mod:dns", "ntp", "clock"), LeavesOnly()},
+		want: []string{
+			"/root/ntp/enabled",
+			"/root/system/config/domain",
+			"/root/system/config/hostname",
+			"/root/system/state/boot-time",
+			"/root/system/state/hostname",
+		},
+	}, {
+		desc:      "feature disabled by not expression",
+		inFilters: []SchemaFilter{EnabledFeatures("clock", "minimal"), StateOnly(), LeavesOnly()},
+		want: []string{
+			"/root/system/state/hostname",
+		},
+	}, {
+		desc:     "visitor skips descendants",
+		inStopAt: "system",
+		want: []string{
+			"/root",
+			"/root/ntp",
+			"/root/ntp/enabled",
+			"/root/system",
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var got []string
+			WalkSchema(schemaWalkTestSchema(), func(e *yang.Entry, depth int) IterationAction {
+				got = append(got, e.Path())
+				if e.Name == tt.inStopAt {
+					return DoNotIterateDescendants
+				}
+				return ContinueIteration
+			}, tt.inFilters...)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("WalkSchema: did not get expected visited entries (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWalkSchemaDepth(t *testing.T) {
+	got := map[string]int{}
+	WalkSchema(schemaWalkTestSchema(), func(e *yang.Entry, depth int) IterationAction {
+		got[e.Path()] = depth
+		return ContinueIteration
+	}, MaxDepth(2))
+	want := map[string]int{
+		"/root":               0,
+		"/root/ntp":           1,
+		"/root/ntp/enabled":   2,
+		"/root/system":        1,
+		"/root/system/config": 2,
+		"/root/system/state":  2,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("WalkSchema: did not get expected depths (-want, +got):\n%s", diff)
+	}
+}
+
+func TestIfFeaturesSatisfied(t *testing.T) {
+	tests := []struct {
+		desc      string
+		inExprs   []string
+		inEnabled map[string]bool
+		want      bool
+	}{{
+		desc: "no if-feature",
+		want: true,
+	}, {
+		desc:      "single feature enabled",
+		inExprs:   []string{"a"},
+		inEnabled: map[string]bool{"a": true},
+		want:      true,
+	}, {
+		desc:      "multiple statements are a conjunction",
+		inExprs:   []string{"a", "b"},
+		inEnabled: map[string]bool{"a": true},
+		want:      false,
+	}, {
+		desc:      "or expression",
+		inExprs:   []string{"x:a or x:b"},
+		inEnabled: map[string]bool{"b": true},
+		want:      true,
+	}, {
+		desc:      "and binds more tightly than or",
+		inExprs:   []string{"a or b and c"},
+		inEnabled: map[string]bool{"a": true},
+		want:      true,
+	}, {
+		desc:      "parentheses",
+		inExprs:   []string{"(a or b) and c"},
+		inEnabled: map[string]bool{"a": true},
+		want:      false,
+	}, {
+		desc:      "not",
+		inExprs:   []string{"not(a)"},
+		inEnabled: map[string]bool{},
+		want:      true,
+	}, {
+		desc:      "invalid expression",
+		inExprs:   []string{"a and"},
+		inEnabled: map[string]bool{"a": true},
+		want:      false,
+	}, {
+		desc:      "unbalanced parentheses",
+		inExprs:   []string{"(a"},
+		inEnabled: map[string]bool{"a": true},
+		want:      false,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			e := &yang.Entry{Name: "e", Extra: map[string][]any{}}
+			for _, x := range tt.inExprs {
+				e.Extra["if-feature"] = append(e.Extra["if-feature"], &yang.Value{Name: x})
+			}
+			if got := IfFeaturesSatisfied(e, tt.inEnabled); got != tt.want {
+				t.Errorf("IfFeaturesSatisfied(%v, %v): got %v, want %v", tt.inExprs, tt.inEnabled, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// TraceEventType is the type of a TraceEvent.
+type TraceEventType int
+
+const (
+	// TraceSpanStart indicates that an operation, such as unmarshalling or
+	// validating a node of the data tree, has started.
+	TraceSpanStart TraceEventType = iota
+	// TraceSpanEnd indicates that an operation has completed. The Err field
+	// of the event is set if the operation failed.
+	TraceSpanEnd
+	// TraceMessage is a debugging message emitted by the library.
+	TraceMessage
+	// TraceError indicates that the library encountered an error.
+	TraceError
+)
+
+// String returns the name of the TraceEventType.
+func (t TraceEventType) String() string {
+	switch t {
+	case TraceSpanStart:
+		return "start"
+	case TraceSpanEnd:
+		return "end"
+	case TraceMessage:
+		return "message"
+	case TraceError:
+		return "error"
+	}
+	return fmt.Sprintf("TraceEventType(%d)", int(t))
+}
+
+// TraceEvent is an event emitted by the library to the registered Tracer.
+type TraceEvent struct {
+	// Type is the type of the event.
+	Type TraceEventType
+	// Op is the name of the operation that the event relates to, e.g.,
+	// "Unmarshal" or "Validate". It is empty for messages that are not
+	// emitted within a span.
+	Op string
+	// Path is the schema path of the node that the event relates to, if
+	// known.
+	Path string
+	// Message is the debugging message for TraceMessage events.
+	Message string
+	// Err is the error for TraceError and failed TraceSpanEnd events.
+	Err error
+}
+
+// Tracer is the interface implemented by receivers of the library's trace
+// events. It allows the internal operation of unmarshalling and validation
+// to be observed without recompiling the library with debugging enabled,
+// by forwarding events to a logging library such as log/slog or zap.
+type Tracer interface {
+	// Trace is called for each event emitted by the library. It may be
+	// called concurrently from multiple goroutines.
+	Trace(TraceEvent)
+}
+
+// TracerFunc is an adapter that allows an ordinary function to be used as a
+// Tracer.
+type TracerFunc func(TraceEvent)
+
+// Trace calls f(ev).
+func (f TracerFunc) Trace(ev TraceEvent) { f(ev) }
+
+// tracerBox wraps the registered Tracer such that it can be stored in an
+// atomic.Value, which requires a consistent concrete type.
+type tracerBox struct {
+	t Tracer
+}
+
+// globalTracer stores the tracerBox of the registered Tracer.
+var globalTracer atomic.Value
+
+// SetTracer registers t as the receiver of trace events emitted by the
+// library, replacing any previously registered Tracer. Tracing is disabled
+// if t is nil. Since the set of events emitted is relatively verbose, and
+// formatting them is not free, a Tracer should only be registered whilst
+// debugging.
+func SetTracer(t Tracer) {
+	globalTracer.Store(tracerBox{t: t})
+}
+
+// currentTracer returns the registered Tracer, or nil if there is none.
+func currentTracer() Tracer {
+	b, ok := globalTracer.Load().(tracerBox)
+	if !ok {
+		return nil
+	}
+	return b.t
+}
+
+// TracingEnabled reports whether a Tracer is registered. It can be used to
+// avoid computing expensive arguments to trace calls.
+func TracingEnabled() bool {
+	return currentTracer() != nil
+}
+
+// TraceSpan is an in-progress operation within the library. A nil
+// *TraceSpan is valid, and discards all events, such that callers need not
+// check whether tracing is enabled.
+type TraceSpan struct {
+	tracer Tracer
+	op     string
+	path   string
+}
+
+// StartSpan starts a span for the operation op on the node described by
+// schema, emitting a TraceSpanStart event. It returns nil if tracing is not
+// enabled. The caller must call End on the returned span when the operation
+// completes.
+func StartSpan(op string, schema *yang.Entry) *TraceSpan {
+	t := currentTracer()
+	if t == nil {
+		return nil
+	}
+	s := &TraceSpan{tracer: t, op: op}
+	if schema != nil {
+		s.path = schema.Path()
+	}
+	t.Trace(TraceEvent{Type: TraceSpanStart, Op: s.op, Path: s.path})
+	return s
+}
+
+// Printf emits a TraceMessage event within the span s, with the message
+// formatted according to format.
+func (s *TraceSpan) Printf(format string, a ...interface{}) {
+	if s == nil {
+		return
+	}
+	s.tracer.Trace(TraceEvent{Type: TraceMessage, Op: s.op, Path: s.path, Message: fmt.Sprintf(format, a...)})
+}
+
+// End completes the span s, emitting a TraceSpanEnd event with the error that
+// the operation returned, if any.
+func (s *TraceSpan) End(err error) {
+	if s == nil {
+		return
+	}
+	s.tracer.Trace(TraceEvent{Type: TraceSpanEnd, Op: s.op, Path: s.path, Err: err})
+}
+
+// EndErrs completes the span s, emitting a TraceSpanEnd event with the errors
+// that the operation returned, if any.
+func (s *TraceSpan) EndErrs(errs Errors) {
+	if len(errs) == 0 {
+		s.End(nil)
+		return
+	}
+	s.End(errs)
+}
+
+// traceMessage emits a TraceMessage event that is not associated with a
+// span to the registered Tracer, if any.
+func traceMessage(msg string) {
+	if t := currentTracer(); t != nil {
+		t.Trace(TraceEvent{Type: TraceMessage, Message: msg})
+	}
+}
+
+// traceError emits a TraceError event that is not associated with a span to
+// the registered Tracer, if any.
+func traceError(err error) {
+	if t := currentTracer(); t != nil {
+		t.Trace(TraceEvent{Type: TraceError, Err: err})
+	}
+}
+
+// NewSlogTracer returns a Tracer that logs each event to l at debug level,
+// or error level for TraceError events. Events are only formatted if l is
+// enabled for the corresponding level.
+func NewSlogTracer(l *slog.Logger) Tracer {
+	return TracerFunc(func(ev TraceEvent) {
+		level := slog.LevelDebug
+		if ev.Type == TraceError {
+			level = slog.LevelError
+		}
+		ctx := context.Background()
+		if !l.Enabled(ctx, level) {
+			return
+		}
+		attrs := []slog.Attr{slog.String("event", ev.Type.String())}
+		if ev.Op != "" {
+			attrs = append(attrs, slog.String("op", ev.Op))
+		}
+		if ev.Path != "" {
+			attrs = append(attrs, slog.String("path", ev.Path))
+		}
+		if ev.Err != nil {
+			attrs = append(attrs, slog.String("error", ev.Err.Error()))
+		}
+		msg := ev.Message
+		if msg == "" {
+			msg = "ygot " + ev.Type.String()
+		}
+		l.LogAttrs(ctx, level, msg, attrs...)
+	})
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+func TestTracer(t *testing.T) {
+	var got []TraceEvent
+	SetTracer(TracerFunc(func(ev TraceEvent) { got = append(got, ev) }))
+	defer SetTracer(nil)
+
+	schema := &yang.Entry{Name: "leaf", Parent: &yang.Entry{Name: "root"}}
+	errTest := errors.New("test error")
+
+	span := StartSpan("Unmarshal", schema)
+	span.Printf("value %d", 42)
+	DbgPrint("debug %s", "message")
+	DbgErr(errTest)
+	span.End(errTest)
+	ok := StartSpan("Validate", schema)
+	ok.EndErrs(nil)
+
+	want := []TraceEvent{
+		{Type: TraceSpanStart, Op: "Unmarshal", Path: "/root/leaf"},
+		{Type: TraceMessage, Op: "Unmarshal", Path: "/root/leaf", Message: "value 42"},
+		{Type: TraceMessage, Message: "debug message"},
+		{Type: TraceError, Err: errTest},
+		{Type: TraceSpanEnd, Op: "Unmarshal", Path: "/root/leaf", Err: errTest},
+		{Type: TraceSpanStart, Op: "Validate", Path: "/root/leaf"},
+		{Type: TraceSpanEnd, Op: "Validate", Path: "/root/leaf"},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.EquateErrors()); diff != "" {
+		t.Errorf("did not get expected trace events (-want, +got):\n%s", diff)
+	}
+
+	if got := ValueStrDebug(struct{}{}); got != "{  }" {
+		t.Errorf("ValueStrDebug: got %q with tracing enabled, want value to be calculated", got)
+	}
+}
+
+func TestTracerDisabled(t *testing.T) {
+	SetTracer(nil)
+	if TracingEnabled() {
+		t.Fatalf("TracingEnabled: got true, want false")
+	}
+	span := StartSpan("Unmarshal", &yang.Entry{Name: "leaf"})
+	if span != nil {
+		t.Fatalf("StartSpan: got span %v with tracing disabled, want nil", span)
+	}
+	// Methods of a nil span must not panic.
+	span.Printf("message")
+	span.End(nil)
+	span.EndErrs(Errors{errors.New("err")})
+}
+
+func TestSlogTracer(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	SetTracer(NewSlogTracer(l))
+	defer SetTracer(nil)
+
+	span := StartSpan("Validate", &yang.Entry{Name: "leaf", Parent: &yang.Entry{Name: "root"}})
+	span.End(errors.New("bad value"))
+
+	out := buf.String()
+	for _, want := range []string{"event=start", "event=end", "op=Validate", "path=/root/leaf", `error="bad value"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("slog output does not contain %q, got:\n%s", want, out)
+		}
+	}
+}
@@ -221,40 +221,51 @@ func walkFieldInternal(visitor Visitor, node WalkNode, o *WalkOptions) {
 		}
 		fallthrough
 	case IsTypeStruct(t):
-		for i := 0; i < t.NumField(); i++ {
-			sf := t.Field(i)
+		walkStructFields := func(t reflect.Type, v reflect.Value, schema *yang.Entry) {
+			for i := 0; i < t.NumField(); i++ {
+				sf := t.Field(i)
 
-			// Do not handle annotation fields, since they have no schema.
-			if IsYgotAnnotation(sf) {
-				continue
-			}
+				// Do not handle annotation fields, presence bitmaps or
+				// extension structs, since they have no schema.
+				if IsYgotAnnotation(sf) || IsYgotPresence(sf) || IsYgotExtensions(sf) {
+					continue
+				}
 
-			nn := &NodeInfo{
-				Parent:      ni,
-				StructField: sf,
-			}
-			if !IsNilOrInvalidValue(v) {
-				nn.FieldValue = v.Field(i)
-			} else {
-				nn.FieldValue = reflect.Zero(sf.Type)
-			}
-			ps, err := SchemaPaths(nn.StructField)
-			if err != nil {
-				o.WalkErrors.Collect(err)
-				return
-			}
+				nn := &NodeInfo{
+					Parent:      ni,
+					StructField: sf,
+				}
+				if !IsNilOrInvalidValue(v) {
+					nn.FieldValue = FieldValue(v, i)
+				} else {
+					nn.FieldValue = ZeroFieldValue(sf)
+				}
+				ps, err := SchemaPaths(nn.StructField)
+				if err != nil {
+					o.WalkErrors.Collect(err)
+					return
+				}
 
-			for _, p := range ps {
-				nn.Schema = FirstChild(ni.Schema, p)
-				if nn.Schema == nil {
-					e := fmt.Errorf("forEachFieldInternal could not find child schema with path %v from schema name %s", p, ni.Schema.Name)
-					DbgPrint(e.Error())
-					// TODO(wenovus) Consider making this into an error.
-					log.Errorln(e)
-					continue
+				for _, p := range ps {
+					nn.Schema = FirstChild(schema, p)
+					if nn.Schema == nil {
+						e := fmt.Errorf("forEachFieldInternal could not find child schema with path %v from schema name %s", p, schema.Name)
+						DbgPrint(e.Error())
+						// TODO(wenovus) Consider making this into an error.
+						log.Errorln(e)
+						continue
+					}
+					nn.PathFromParent = p
+					walkFieldInternal(childVisitor, WalkNodeFromNodeInfo(nn), o)
 				}
-				nn.PathFromParent = p
-				walkFieldInternal(childVisitor, WalkNodeFromNodeInfo(nn), o)
+			}
+		}
+		walkStructFields(t, v, ni.Schema)
+		// The fields of extension structs are handled as fields of the
+		// struct, with the schema of the extended node as their parent.
+		if !IsNilOrInvalidValue(v) {
+			for _, ext := range AttachedExtensions(v) {
+				walkStructFields(ext.Value.Type().Elem(), ext.Value.Elem(), ext.Schema)
 			}
 		}
 	}
@@ -326,38 +337,48 @@ func walkDataFieldInternal(visitor Visitor, node WalkNode, o *WalkOptions) {
 		v = v.Elem()
 		fallthrough
 	case IsTypeStruct(t):
-		// Handle non-pointer structs by recursing into each field of the struct.
-		for i := 0; i < t.NumField(); i++ {
-			sf := t.Field(i)
-			nn := &NodeInfo{
-				Parent:      ni,
-				StructField: sf,
-				FieldValue:  reflect.Zero(sf.Type),
-			}
+		// Handle non-pointer structs by recursing into each field of the
+		// struct, and of each extension struct attached to it.
+		walkStructFields := func(t reflect.Type, v reflect.Value) {
+			for i := 0; i < t.NumField(); i++ {
+				sf := t.Field(i)
+				if IsYgotPresence(sf) || IsYgotExtensions(sf) {
+					continue
+				}
+				nn := &NodeInfo{
+					Parent:      ni,
+					StructField: sf,
+					FieldValue:  reflect.Zero(sf.Type),
+				}
 
-			nn.FieldValue = v.Field(i)
-			ps, err := SchemaPaths(nn.StructField)
-			if err != nil {
-				o.WalkErrors.Collect(err)
-				return
-			}
-			// In the case that the field expands to >1 different data tree path,
-			// i.e., SchemaPaths above returns more than one path, then we recurse
-			// for each schema path. This ensures that the iterator
-			// function runs for all expansions of the data tree as well as the GoStruct
-			// fields.
-			for _, p := range ps {
-				nn.PathFromParent = p
-				if IsTypeSlice(sf.Type) || IsTypeMap(sf.Type) {
-					// Since lists can have path compression - where the path contains more
-					// than one element, ensure that the schema path we received is only two
-					// elements long. This protects against compression errors where there are
-					// trailing spaces (e.g., a path tag of config/bar/).
-					nn.PathFromParent = p[0:1]
+				nn.FieldValue = FieldValue(v, i)
+				ps, err := SchemaPaths(nn.StructField)
+				if err != nil {
+					o.WalkErrors.Collect(err)
+					return
+				}
+				// In the case that the field expands to >1 different data tree path,
+				// i.e., SchemaPaths above returns more than one path, then we recurse
+				// for each schema path. This ensures that the iterator
+				// function runs for all expansions of the data tree as well as the GoStruct
+				// fields.
+				for _, p := range ps {
+					nn.PathFromParent = p
+					if IsTypeSlice(sf.Type) || IsTypeMap(sf.Type) {
+						// Since lists can have path compression - where the path contains more
+						// than one element, ensure that the schema path we received is only two
+						// elements long. This protects against compression errors where there are
+						// trailing spaces (e.g., a path tag of config/bar/).
+						nn.PathFromParent = p[0:1]
+					}
+					walkDataFieldInternal(childVisitor, WalkNodeFromNodeInfo(nn), o)
 				}
-				walkDataFieldInternal(childVisitor, WalkNodeFromNodeInfo(nn), o)
 			}
 		}
+		walkStructFields(t, v)
+		for _, ext := range AttachedExtensions(v) {
+			walkStructFields(ext.Value.Type().Elem(), ext.Value.Elem())
+		}
 	case IsTypeSlice(t):
 		// Only iterate in the data tree if the slice is of structs, otherwise
 		// for leaf-lists we only run once.
@@ -0,0 +1,262 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// ResolveSchemaXPath returns the schema entry addressed by the XPath location
+// path xpath, evaluated relative to the schema entry context. xpath may be
+// absolute ("/a/b"), or relative to context ("../config/name"), and may begin
+// with current(), which refers to context. "." elements, module prefixes and
+// predicates are ignored, such that the path of a leafref (e.g.,
+// "../../interface[name=current()/../ifname]/state/mtu") can be resolved to
+// the schema of its target. Choice and case nodes are not addressable and are
+// skipped when resolving the path.
+func ResolveSchemaXPath(context *yang.Entry, xpath string) (*yang.Entry, error) {
+	if context == nil {
+		return nil, fmt.Errorf("ygot/util: cannot resolve xpath %q against nil schema", xpath)
+	}
+	p, err := normalizeXPath(xpath)
+	if err != nil {
+		return nil, err
+	}
+	if len(p) == 0 {
+		return context, nil
+	}
+	return FindLeafRefSchema(context, strings.Join(p, "/"))
+}
+
+// ResolveDataXPath returns the data tree nodes addressed by the XPath location
+// path xpath, evaluated relative to the data tree node ni. The data tree that
+// ni is part of must have been traversed using ForEachField or Walk such that
+// the Parent of each NodeInfo is populated, since relative paths are resolved
+// by walking up the chain of NodeInfo parents.
+//
+// xpath has the same form as accepted by ResolveSchemaXPath, with the
+// exception that predicates are used to select list entries. Each predicate
+// must be of the form [key=value], where value is either a quoted literal, or
+// a path beginning with current() that is itself resolved relative to ni and
+// must address a single data node. Nil nodes are not included in the nodes
+// returned.
+func ResolveDataXPath(ni *NodeInfo, xpath string) ([]interface{}, error) {
+	if ni == nil || ni.Schema == nil {
+		return nil, fmt.Errorf("ygot/util: cannot resolve xpath %q against nil node", xpath)
+	}
+	p, err := normalizeXPath(xpath)
+	if err != nil {
+		return nil, err
+	}
+	if len(p) == 0 {
+		if IsNilOrInvalidValue(ni.FieldValue) {
+			return nil, nil
+		}
+		return []interface{}{ni.FieldValue.Interface()}, nil
+	}
+
+	root := ni
+	if p[0] == "" {
+		// Absolute path, start from the root of the data tree.
+		for root.Parent != nil {
+			root = root.Parent
+		}
+		p = p[1:]
+	} else {
+		for len(p) != 0 && p[0] == ".." {
+			if root.Parent == nil {
+				return nil, KindErrorf(ErrPathNotFound, "ygot/util: no parent for data node %s with remaining path %v", root.Schema.Path(), p)
+			}
+			if isListElementNode(root) {
+				// The elements of lists and leaf-lists have a NodeInfo
+				// distinct from that of the map or slice that contains
+				// them, despite being a single level in the YANG
+				// hierarchy, hence skip the additional level.
+				root = root.Parent
+				continue
+			}
+			p = removeParentPathPrefix(p, root.PathFromParent)
+			root = root.Parent
+		}
+	}
+
+	path := &gpb.Path{}
+	for _, e := range p {
+		pe, err := dataXPathElem(ni, e)
+		if err != nil {
+			return nil, err
+		}
+		path.Elem = append(path.Elem, pe)
+	}
+
+	if IsNilOrInvalidValue(root.FieldValue) {
+		return nil, nil
+	}
+	nodes, _, err := getNodesInternal(root.Schema, root.FieldValue.Interface(), path)
+	if err != nil {
+		return nil, err
+	}
+	var out []interface{}
+	for _, n := range nodes {
+		if !IsValueNil(n) {
+			out = append(out, n)
+		}
+	}
+	return out, nil
+}
+
+// isListElementNode reports whether ni is the NodeInfo of an element of a
+// list or leaf-list, whose parent is the NodeInfo of the map or slice that
+// contains it.
+func isListElementNode(ni *NodeInfo) bool {
+	p := ni.Parent
+	if p.Schema == nil || IsNilOrInvalidValue(p.FieldValue) {
+		return false
+	}
+	if _, ok := p.FieldValue.Interface().(goOrderedMap); ok && p.Schema.IsList() {
+		return true
+	}
+	return (p.Schema.IsList() && IsValueMap(p.FieldValue)) || (p.Schema.IsLeafList() && IsValueSlice(p.FieldValue))
+}
+
+// removeParentPathPrefix removes the leading ".." elements of path that are
+// consumed by moving from a node to its parent, where pathFromParent is the
+// schema path from the parent to the node. If the path from the parent
+// consists of more than one element, as is the case for compressed schemas,
+// the elements that are not consumed are restored to the start of the path.
+func removeParentPathPrefix(path []string, pathFromParent []string) []string {
+	plen := len(pathFromParent)
+	out := path
+	for len(out) > 0 && out[0] == ".." && plen > 0 {
+		out = out[1:]
+		plen--
+	}
+	return append(append([]string{}, pathFromParent[:plen]...), out...)
+}
+
+// dataXPathElem returns the gNMI PathElem corresponding to the XPath path
+// element e, resolving any predicate values that are paths relative to the
+// data node ni.
+func dataXPathElem(ni *NodeInfo, e string) (*gpb.PathElem, error) {
+	name, preds, err := splitXPathPredicates(e)
+	if err != nil {
+		return nil, err
+	}
+	pe := &gpb.PathElem{Name: StripModulePrefix(name)}
+	for _, pred := range preds {
+		k, v, ok := strings.Cut(pred, "=")
+		if !ok {
+			return nil, KindErrorf(ErrInvalidPath, "ygot/util: invalid predicate [%s] in path element %s, must be of the form [key=value]", pred, e)
+		}
+		k, v = StripModulePrefix(strings.TrimSpace(k)), strings.TrimSpace(v)
+		switch {
+		case len(v) >= 2 && (v[0] == '\'' || v[0] == '"') && v[len(v)-1] == v[0]:
+			v = v[1 : len(v)-1]
+		case strings.HasPrefix(v, "current()"):
+			ns, err := ResolveDataXPath(ni, v)
+			if err != nil {
+				return nil, fmt.Errorf("ygot/util: cannot resolve predicate [%s] in path element %s: %w", pred, e, err)
+			}
+			if len(ns) != 1 {
+				return nil, fmt.Errorf("ygot/util: predicate [%s] in path element %s must match a single node, got %d", pred, e, len(ns))
+			}
+			rv := reflect.ValueOf(ns[0])
+			if IsValuePtr(rv) {
+				rv = rv.Elem()
+			}
+			v = fmt.Sprint(rv.Interface())
+		default:
+			return nil, KindErrorf(ErrInvalidPath, "ygot/util: invalid predicate [%s] in path element %s, value must be quoted or begin with current()", pred, e)
+		}
+		if pe.Key == nil {
+			pe.Key = map[string]string{}
+		}
+		pe.Key[k] = v
+	}
+	return pe, nil
+}
+
+// normalizeXPath splits the XPath location path xpath into its elements,
+// removing a leading current() and any "." elements. An absolute path is
+// returned with an empty first element.
+func normalizeXPath(xpath string) ([]string, error) {
+	xpath = strings.TrimSpace(xpath)
+	if xpath == "" {
+		return nil, KindErrorf(ErrInvalidPath, "ygot/util: empty xpath")
+	}
+	if strings.HasPrefix(xpath, "current()") {
+		xpath = strings.TrimPrefix(strings.TrimPrefix(xpath, "current()"), "/")
+		if strings.HasPrefix(xpath, "/") {
+			return nil, KindErrorf(ErrInvalidPath, "ygot/util: invalid xpath %q", xpath)
+		}
+	}
+	var out []string
+	for i, e := range SplitPath(xpath) {
+		e = strings.TrimSpace(e)
+		switch {
+		case e == "" && i == 0:
+			// The path is absolute.
+		case e == "":
+			return nil, KindErrorf(ErrInvalidPath, "ygot/util: empty element in xpath %q", xpath)
+		case e == ".":
+			continue
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// splitXPathPredicates splits the XPath path element e into its name and the
+// contents of each of its predicates, ignoring brackets within quoted
+// strings.
+func splitXPathPredicates(e string) (string, []string, error) {
+	si := strings.Index(e, "[")
+	if si == -1 {
+		return e, nil, nil
+	}
+	name, rest := e[:si], e[si:]
+	var preds []string
+	for rest != "" {
+		if rest[0] != '[' {
+			return "", nil, KindErrorf(ErrInvalidPath, "ygot/util: unexpected characters %q after predicate in path element %s", rest, e)
+		}
+		var quote byte
+		end := -1
+		for i := 1; i < len(rest) && end == -1; i++ {
+			switch c := rest[i]; {
+			case quote != 0:
+				if c == quote {
+					quote = 0
+				}
+			case c == '\'' || c == '"':
+				quote = c
+			case c == ']':
+				end = i
+			}
+		}
+		if end == -1 {
+			return "", nil, KindErrorf(ErrInvalidPath, "ygot/util: unterminated predicate in path element %s", e)
+		}
+		preds = append(preds, rest[1:end])
+		rest = rest[end+1:]
+	}
+	return name, preds, nil
+}
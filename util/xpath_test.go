@@ -0,0 +1,279 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+type xpathRoot struct {
+	Interface map[string]*xpathInterface `path:"interfaces/interface"`
+	Ref       *xpathRef                  `path:"ref"`
+}
+
+func (*xpathRoot) IsYANGGoStruct() {}
+
+type xpathInterface struct {
+	Name *string `path:"name"`
+	Mtu  *uint16 `path:"mtu"`
+}
+
+func (*xpathInterface) IsYANGGoStruct() {}
+
+type xpathRef struct {
+	IfName *string `path:"ifname"`
+}
+
+func (*xpathRef) IsYANGGoStruct() {}
+
+// xpathTestSchema returns the schema corresponding to xpathRoot.
+func xpathTestSchema() *yang.Entry {
+	root := &yang.Entry{
+		Name: "root",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"interfaces": {
+				Name: "interfaces",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"interface": {
+						Name:     "interface",
+						Kind:     yang.DirectoryEntry,
+						Key:      "name",
+						ListAttr: &yang.ListAttr{},
+						Dir: map[string]*yang.Entry{
+							"name": {
+								Name: "name",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Ystring},
+							},
+							"mtu": {
+								Name: "mtu",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yuint16},
+							},
+						},
+					},
+				},
+			},
+			"ref": {
+				Name: "ref",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"ifname": {
+						Name: "ifname",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{
+							Kind: yang.Yleafref,
+							Path: "../../oc-if:interfaces/oc-if:interface/oc-if:name",
+						},
+					},
+				},
+			},
+		},
+	}
+	addParents(root)
+	return root
+}
+
+func TestResolveSchemaXPath(t *testing.T) {
+	schema := xpathTestSchema()
+	ifname := schema.Dir["ref"].Dir["ifname"]
+	mtu := schema.Dir["interfaces"].Dir["interface"].Dir["mtu"]
+
+	tests := []struct {
+		desc             string
+		inContext        *yang.Entry
+		inXPath          string
+		want             *yang.Entry
+		wantErrSubstring string
+	}{{
+		desc:      "relative path",
+		inContext: ifname,
+		inXPath:   "../../interfaces/interface/mtu",
+		want:      mtu,
+	}, {
+		desc:      "relative path with prefixes and predicates",
+		inContext: ifname,
+		inXPath:   "../../oc-if:interfaces/oc-if:interface[oc-if:name=current()/../ifname]/oc-if:mtu",
+		want:      mtu,
+	}, {
+		desc:      "path beginning with current()",
+		inContext: mtu,
+		inXPath:   "current()/../name",
+		want:      schema.Dir["interfaces"].Dir["interface"].Dir["name"],
+	}, {
+		desc:      "current() only",
+		inContext: mtu,
+		inXPath:   "current()",
+		want:      mtu,
+	}, {
+		desc:      "self elements",
+		inContext: ifname,
+		inXPath:   "./.././ifname",
+		want:      ifname,
+	}, {
+		desc:      "absolute path",
+		inContext: mtu,
+		inXPath:   "/ref/ifname",
+		want:      ifname,
+	}, {
+		desc:             "missing node",
+		inContext:        ifname,
+		inXPath:          "../missing",
+		wantErrSubstring: "schema node missing is nil",
+	}, {
+		desc:             "beyond the root",
+		inContext:        schema,
+		inXPath:          "..",
+		wantErrSubstring: "parent of root is nil",
+	}, {
+		desc:             "empty path",
+		inContext:        schema,
+		inXPath:          " ",
+		wantErrSubstring: "empty xpath",
+	}, {
+		desc:             "nil context",
+		inXPath:          "../a",
+		wantErrSubstring: "nil schema",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ResolveSchemaXPath(tt.inContext, tt.inXPath)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("ResolveSchemaXPath(%q): did not get expected error, %s", tt.inXPath, diff)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveSchemaXPath(%q): did not get expected entry, got: %v, want: %v", tt.inXPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveDataXPath(t *testing.T) {
+	schema := xpathTestSchema()
+	root := &xpathRoot{
+		Interface: map[string]*xpathInterface{
+			"eth0": {Name: toStringPtr("eth0"), Mtu: toUint16Ptr(1500)},
+			"eth1": {Name: toStringPtr("eth1"), Mtu: toUint16Ptr(9000)},
+		},
+		Ref: &xpathRef{IfName: toStringPtr("eth1")},
+	}
+
+	// Collect the NodeInfo of each leaf in the data tree, keyed by its
+	// schema name and the name of the interface it is within, if any.
+	nodes := map[string]*NodeInfo{}
+	if errs := ForEachField(schema, root, nil, nil, func(ni *NodeInfo, _, _ any) Errors {
+		if !ni.Schema.IsLeaf() || IsValueNil(ni.FieldValue.Interface()) {
+			return nil
+		}
+		key := ni.Schema.Name
+		if iface, ok := ni.Parent.FieldValue.Interface().(*xpathInterface); ok {
+			key = fmt.Sprintf("%s[%s]", key, *iface.Name)
+		}
+		nodes[key] = ni
+		return nil
+	}); errs != nil {
+		t.Fatalf("ForEachField: got unexpected errors: %v", errs)
+	}
+
+	tests := []struct {
+		desc             string
+		inNode           string
+		inXPath          string
+		want             []string
+		wantErrSubstring string
+	}{{
+		desc:    "leafref target with current() predicate",
+		inNode:  "ifname",
+		inXPath: "../../interfaces/interface[name=current()/../ifname]/mtu",
+		want:    []string{"9000"},
+	}, {
+		desc:    "literal predicate",
+		inNode:  "ifname",
+		inXPath: "../../interfaces/interface[name='eth0']/mtu",
+		want:    []string{"1500"},
+	}, {
+		desc:    "all list entries",
+		inNode:  "ifname",
+		inXPath: "/interfaces/interface/name",
+		want:    []string{"eth0", "eth1"},
+	}, {
+		desc:    "sibling within list entry",
+		inNode:  "mtu[eth0]",
+		inXPath: "../name",
+		want:    []string{"eth0"},
+	}, {
+		desc:    "out of list entry",
+		inNode:  "mtu[eth0]",
+		inXPath: "../../../ref/ifname",
+		want:    []string{"eth1"},
+	}, {
+		desc:    "current node",
+		inNode:  "mtu[eth1]",
+		inXPath: "current()",
+		want:    []string{"9000"},
+	}, {
+		desc:    "no matching entry",
+		inNode:  "ifname",
+		inXPath: "../../interfaces/interface[name='eth2']/mtu",
+	}, {
+		desc:             "invalid predicate",
+		inNode:           "ifname",
+		inXPath:          "../../interfaces/interface[name=eth0]/mtu",
+		wantErrSubstring: "value must be quoted or begin with current()",
+	}, {
+		desc:             "unterminated predicate",
+		inNode:           "ifname",
+		inXPath:          "../../interfaces/interface[name='eth0'/mtu",
+		wantErrSubstring: "unterminated predicate",
+	}, {
+		desc:             "beyond the root",
+		inNode:           "ifname",
+		inXPath:          "../../../interfaces",
+		wantErrSubstring: "no parent for data node",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ni, ok := nodes[tt.inNode]
+			if !ok {
+				t.Fatalf("no data node %s", tt.inNode)
+			}
+			got, err := ResolveDataXPath(ni, tt.inXPath)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("ResolveDataXPath(%s, %q): did not get expected error, %s", tt.inNode, tt.inXPath, diff)
+			}
+			var gotStrs []string
+			for _, n := range got {
+				gotStrs = append(gotStrs, fmt.Sprint(reflect.ValueOf(n).Elem().Interface()))
+			}
+			sort.Strings(gotStrs)
+			if diff := cmp.Diff(tt.want, gotStrs); diff != "" {
+				t.Errorf("ResolveDataXPath(%s, %q): did not get expected nodes (-want, +got):\n%s", tt.inNode, tt.inXPath, diff)
+			}
+		})
+	}
+}
+
+func toUint16Ptr(i uint16) *uint16 { return &i }
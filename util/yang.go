@@ -214,6 +214,13 @@ func IsYangPresence(s reflect.StructField) bool {
 	return ok
 }
 
+// IsOrderedByUser reports whether struct field s is a YANG leaf-list whose
+// order is significant, since it has the modifier "ordered-by user".
+func IsOrderedByUser(s reflect.StructField) bool {
+	_, ok := s.Tag.Lookup("orderedByUser")
+	return ok
+}
+
 // IsSimpleEnumerationType returns true when the type supplied is a simple
 // enumeration (i.e., a leaf that is defined as type enumeration { ... },
 // and is not a typedef that contains an enumeration, or a union that
@@ -582,6 +589,39 @@ func ResolveIfLeafRef(schema *yang.Entry) (*yang.Entry, error) {
 	return s, nil
 }
 
+// ResolveLeafrefTarget returns the schema entry that is ultimately referenced
+// by the supplied leafref schema, along with the absolute data tree path of
+// that entry (e.g., "/interfaces/interface/config/name"). The path statement
+// of the leafref may be relative (e.g., "../config/name") or absolute, and may
+// contain predicates, which are ignored. Since goyang resolves typedefs into
+// the type of the leaf, leafrefs defined via a chain of typedefs are handled,
+// with relative paths evaluated from the position of the supplied schema. If
+// the target is itself a leafref, it is followed until a non-leafref entry is
+// found.
+//
+// An error is returned if schema is not of type leafref, if a path cannot be
+// resolved within the schema tree, or if the leafrefs form a cycle.
+func ResolveLeafrefTarget(schema *yang.Entry) (*yang.Entry, string, error) {
+	if schema == nil || schema.Type == nil || schema.Type.Kind != yang.Yleafref {
+		return nil, "", fmt.Errorf("ygot/util: schema is not of type leafref")
+	}
+
+	seen := map[*yang.Entry]bool{}
+	s := schema
+	for s.Type != nil && s.Type.Kind == yang.Yleafref {
+		if seen[s] {
+			return nil, "", fmt.Errorf("ygot/util: leafref cycle detected at %s resolving leafref %s", SchemaTreePath(s), SchemaTreePath(schema))
+		}
+		seen[s] = true
+		ns, err := FindLeafRefSchema(s, s.Type.Path)
+		if err != nil {
+			return nil, "", err
+		}
+		s = ns
+	}
+	return s, SchemaTreePathNoModule(s), nil
+}
+
 // ListKeyFieldsMap returns a map[string]bool where the keys of the map
 // are the fields that are the keys of the list described by the supplied
 // yang.Entry. In the case the yang.Entry does not described a keyed list,
@@ -1019,6 +1019,33 @@ func TestIsYgotAnnotation(t *testing.T) {
 	}
 }
 
+func TestIsOrderedByUser(t *testing.T) {
+	type testStruct struct {
+		Yes []string `path:"yes" orderedByUser:"true"`
+		No  []string `path:"no"`
+	}
+
+	tests := []struct {
+		name string
+		in   reflect.StructField
+		want bool
+	}{{
+		name: "ordered-by user leaf-list",
+		in:   reflect.TypeOf(testStruct{}).Field(0),
+		want: true,
+	}, {
+		name: "ordered-by system leaf-list",
+		in:   reflect.TypeOf(testStruct{}).Field(1),
+		want: false,
+	}}
+
+	for _, tt := range tests {
+		if got := IsOrderedByUser(tt.in); got != tt.want {
+			t.Errorf("%s: IsOrderedByUser(%#v): did not get expected result, got: %v, want: %v", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
 func TestIsYangPresence(t *testing.T) {
 	type testStruct struct {
 		Yes *string `yangPresence:"true"`
@@ -2012,6 +2039,171 @@ func TestResolveIfLeafRef(t *testing.T) {
 	}
 }
 
+func TestResolveLeafrefTarget(t *testing.T) {
+	module := &yang.Entry{
+		Name: "module",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"interfaces": {
+				Name: "interfaces",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"interface": {
+						Name:     "interface",
+						Kind:     yang.DirectoryEntry,
+						ListAttr: yang.NewDefaultListAttr(),
+						Key:      "name",
+						Dir: map[string]*yang.Entry{
+							"name": {
+								Name: "name",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{
+									Kind: yang.Yleafref,
+									Path: "../config/name",
+								},
+							},
+							"config": {
+								Name: "config",
+								Kind: yang.DirectoryEntry,
+								Dir: map[string]*yang.Entry{
+									"name": {
+										Name: "name",
+										Kind: yang.LeafEntry,
+										Type: &yang.YangType{Kind: yang.Ystring},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"refs": {
+				Name: "refs",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"absolute": {
+						Name: "absolute",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{
+							Kind: yang.Yleafref,
+							Path: "/oc:interfaces/oc:interface/oc:config/oc:name",
+						},
+					},
+					"to-key": {
+						Name: "to-key",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{
+							Kind: yang.Yleafref,
+							Path: "../../interfaces/interface[name=current()/../absolute]/name",
+						},
+					},
+					"typedef": {
+						Name: "typedef",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{
+							Name: "interface-ref",
+							Kind: yang.Yleafref,
+							Path: "../absolute",
+							Base: &yang.Type{Name: "leafref"},
+						},
+					},
+					"cycle-a": {
+						Name: "cycle-a",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{
+							Kind: yang.Yleafref,
+							Path: "../cycle-b",
+						},
+					},
+					"cycle-b": {
+						Name: "cycle-b",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{
+							Kind: yang.Yleafref,
+							Path: "../cycle-a",
+						},
+					},
+					"bad-path": {
+						Name: "bad-path",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{
+							Kind: yang.Yleafref,
+							Path: "../missing",
+						},
+					},
+					"string": {
+						Name: "string",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Ystring},
+					},
+				},
+			},
+		},
+	}
+	addParents(module)
+
+	target := module.Dir["interfaces"].Dir["interface"].Dir["config"].Dir["name"]
+	refs := module.Dir["refs"]
+
+	tests := []struct {
+		desc             string
+		in               *yang.Entry
+		want             *yang.Entry
+		wantPath         string
+		wantErrSubstring string
+	}{{
+		desc:     "relative path",
+		in:       module.Dir["interfaces"].Dir["interface"].Dir["name"],
+		want:     target,
+		wantPath: "/interfaces/interface/config/name",
+	}, {
+		desc:     "absolute path with prefixes",
+		in:       refs.Dir["absolute"],
+		want:     target,
+		wantPath: "/interfaces/interface/config/name",
+	}, {
+		desc:     "leafref to leafref with predicate",
+		in:       refs.Dir["to-key"],
+		want:     target,
+		wantPath: "/interfaces/interface/config/name",
+	}, {
+		desc:     "leafref defined by typedef",
+		in:       refs.Dir["typedef"],
+		want:     target,
+		wantPath: "/interfaces/interface/config/name",
+	}, {
+		desc:             "cycle",
+		in:               refs.Dir["cycle-a"],
+		wantErrSubstring: "leafref cycle detected",
+	}, {
+		desc:             "unresolvable path",
+		in:               refs.Dir["bad-path"],
+		wantErrSubstring: "schema node missing is nil",
+	}, {
+		desc:             "not a leafref",
+		in:               refs.Dir["string"],
+		wantErrSubstring: "not of type leafref",
+	}, {
+		desc:             "nil",
+		wantErrSubstring: "not of type leafref",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, gotPath, err := ResolveLeafrefTarget(tt.in)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("ResolveLeafrefTarget: did not get expected error, %s", diff)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveLeafrefTarget: did not get expected entry, got: %v, want: %v", got, tt.want)
+			}
+			if gotPath != tt.wantPath {
+				t.Errorf("ResolveLeafrefTarget: did not get expected path, got: %s, want: %s", gotPath, tt.wantPath)
+			}
+		})
+	}
+}
+
 func TestListKeyFieldsMap(t *testing.T) {
 	tests := []struct {
 		desc  string
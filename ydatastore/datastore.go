@@ -0,0 +1,426 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ydatastore implements a set of named configuration datastores,
+// following the model of NETCONF (RFC6241), which hold the contents of a
+// ygot-generated GoStruct. The running datastore holds the active
+// configuration, and is only modified by committing the candidate datastore,
+// which is validated on commit. Commits may be confirmed, such that they are
+// reverted unless confirmed within a timeout, and earlier configurations
+// are retained as checkpoints such that they can be rolled back to.
+package ydatastore
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// Name is the name of a datastore.
+type Name string
+
+const (
+	// Running is the datastore holding the active configuration.
+	Running Name = "running"
+	// Candidate is the datastore holding the configuration that is being
+	// edited, which becomes the running configuration when it is committed.
+	Candidate Name = "candidate"
+	// Startup is the datastore holding the configuration that is used at
+	// initialisation.
+	Startup Name = "startup"
+)
+
+const (
+	// defaultMaxCheckpoints is the number of previous running
+	// configurations that are retained by default.
+	defaultMaxCheckpoints = 10
+)
+
+var (
+	// ErrConfirmedCommitPending is returned when an operation cannot be
+	// performed because a confirmed commit has not yet been confirmed.
+	ErrConfirmedCommitPending = errors.New("confirmed commit pending")
+	// ErrNoConfirmedCommit is returned when a confirmed commit is confirmed
+	// or cancelled, but there is no pending confirmed commit.
+	ErrNoConfirmedCommit = errors.New("no confirmed commit pending")
+)
+
+// DatastoreOpt is an interface implemented by options to the New function.
+type DatastoreOpt interface {
+	// IsDatastoreOpt is a marker method for each DatastoreOpt.
+	IsDatastoreOpt()
+}
+
+// MaxCheckpoints is a DatastoreOpt that specifies the number of previous
+// running configurations that are retained for rollback. It must not be
+// negative. By default, 10 checkpoints are retained.
+type MaxCheckpoints int
+
+// IsDatastoreOpt marks MaxCheckpoints as a valid DatastoreOpt.
+func (MaxCheckpoints) IsDatastoreOpt() {}
+
+// ValidationOpts is a DatastoreOpt that specifies the options that are used
+// when validating the candidate datastore.
+type ValidationOpts []ygot.ValidationOption
+
+// IsDatastoreOpt marks ValidationOpts as a valid DatastoreOpt.
+func (ValidationOpts) IsDatastoreOpt() {}
+
+// Datastore holds the running, candidate and startup datastores for a single
+// schema. It is safe for concurrent use. The contents of each datastore are
+// only accessed through copies, or by the function supplied to
+// EditCandidate, such that they cannot be modified other than through the
+// Datastore's methods.
+type Datastore struct {
+	// mu protects the fields of the Datastore.
+	mu sync.Mutex
+
+	// running is the contents of the running datastore.
+	running ygot.ValidatedGoStruct
+	// candidate is the contents of the candidate datastore. It is nil
+	// when the candidate has not been modified since the last commit, in
+	// which case its contents are those of running. The candidate is
+	// copied from running when it is first edited, and is then edited in
+	// place, such that the tree is copied once per candidate rather than
+	// on every edit.
+	candidate ygot.ValidatedGoStruct
+	// startup is the contents of the startup datastore.
+	startup ygot.ValidatedGoStruct
+
+	// checkpoints is the set of previous running configurations, in the
+	// order in which they were replaced.
+	checkpoints []ygot.ValidatedGoStruct
+	// maxCheckpoints is the maximum length of checkpoints.
+	maxCheckpoints int
+	// validationOpts are the options used when validating the candidate.
+	validationOpts []ygot.ValidationOption
+
+	// pending is the confirmed commit that is awaiting confirmation, if
+	// any.
+	pending *confirmedCommit
+}
+
+// confirmedCommit stores the state required to revert a confirmed commit.
+type confirmedCommit struct {
+	// prior is the running configuration before the confirmed commit.
+	prior ygot.ValidatedGoStruct
+	// priorCheckpoints is the number of checkpoints before the confirmed
+	// commit.
+	priorCheckpoints int
+	// timer reverts the commit when it expires.
+	timer *time.Timer
+}
+
+// New returns a Datastore with running and startup datastores holding a copy
+// of initial, which must be valid.
+func New(initial ygot.ValidatedGoStruct, opts ...DatastoreOpt) (*Datastore, error) {
+	d := &Datastore{maxCheckpoints: defaultMaxCheckpoints}
+	for _, o := range opts {
+		switch v := o.(type) {
+		case MaxCheckpoints:
+			if v < 0 {
+				return nil, fmt.Errorf("invalid number of checkpoints %d, must be non-negative", v)
+			}
+			d.maxCheckpoints = int(v)
+		case ValidationOpts:
+			d.validationOpts = v
+		}
+	}
+
+	if initial == nil {
+		return nil, fmt.Errorf("nil initial configuration")
+	}
+	if err := initial.Validate(d.validationOpts...); err != nil {
+		return nil, util.WithKind(util.ErrValidation, fmt.Errorf("invalid initial configuration: %v", err))
+	}
+	var err error
+	if d.running, err = copyStruct(initial); err != nil {
+		return nil, err
+	}
+	if d.startup, err = copyStruct(initial); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Get returns a copy of the contents of the datastore name.
+func (d *Datastore) Get(name Name) (ygot.ValidatedGoStruct, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	s, err := d.datastore(name)
+	if err != nil {
+		return nil, err
+	}
+	return copyStruct(s)
+}
+
+// datastore returns the contents of the datastore name. The caller must
+// hold d.mu.
+func (d *Datastore) datastore(name Name) (ygot.ValidatedGoStruct, error) {
+	switch name {
+	case Running:
+		return d.running, nil
+	case Candidate:
+		if d.candidate == nil {
+			return d.running, nil
+		}
+		return d.candidate, nil
+	case Startup:
+		return d.startup, nil
+	}
+	return nil, fmt.Errorf("unknown datastore %q", name)
+}
+
+// EditCandidate modifies the candidate datastore by calling fn with its
+// contents, which fn may modify in place. The contents of the running
+// datastore are copied to the candidate only when it is first edited after a
+// commit or discard, and subsequent edits modify the same copy. If fn returns
+// an error for the first edit of the candidate, the candidate is left
+// unchanged; otherwise any changes made by fn before it failed are retained,
+// and can be removed using DiscardCandidate. The candidate is not validated
+// until it is committed, or Validate is called.
+func (d *Datastore) EditCandidate(fn func(ygot.ValidatedGoStruct) error) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	c, created := d.candidate, false
+	if c == nil {
+		var err error
+		if c, err = copyStruct(d.running); err != nil {
+			return err
+		}
+		created = true
+	}
+	if err := fn(c); err != nil {
+		return err
+	}
+	if created {
+		d.candidate = c
+	}
+	return nil
+}
+
+// DiscardCandidate discards the changes made to the candidate datastore,
+// such that its contents are those of the running datastore.
+func (d *Datastore) DiscardCandidate() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.candidate = nil
+}
+
+// CopyConfig replaces the contents of the datastore dst with those of the
+// datastore src. The running datastore cannot be the destination, since it
+// is only modified by committing the candidate.
+func (d *Datastore) CopyConfig(dst, src Name) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	s, err := d.datastore(src)
+	if err != nil {
+		return err
+	}
+	c, err := copyStruct(s)
+	if err != nil {
+		return err
+	}
+	switch dst {
+	case Candidate:
+		d.candidate = c
+	case Startup:
+		d.startup = c
+	case Running:
+		return fmt.Errorf("cannot copy to the running datastore, the candidate must be committed")
+	default:
+		return fmt.Errorf("unknown datastore %q", dst)
+	}
+	return nil
+}
+
+// Validate validates the contents of the candidate datastore, returning an
+// error of kind util.ErrValidation if it is not valid.
+func (d *Datastore) Validate() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	c, _ := d.datastore(Candidate)
+	return d.validate(c)
+}
+
+// validate validates s using the options of the Datastore.
+func (d *Datastore) validate(s ygot.ValidatedGoStruct) error {
+	if err := s.Validate(d.validationOpts...); err != nil {
+		return util.WithKind(util.ErrValidation, fmt.Errorf("invalid candidate configuration: %v", err))
+	}
+	return nil
+}
+
+// Commit validates the candidate datastore and, if it is valid, makes it the
+// running configuration. The previous running configuration is retained as a
+// checkpoint. If a confirmed commit is pending, Commit confirms it, as per
+// RFC6241 Section 8.4.
+func (d *Datastore) Commit() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.commit(); err != nil {
+		return err
+	}
+	if d.pending != nil {
+		d.pending.timer.Stop()
+		d.pending = nil
+	}
+	return nil
+}
+
+// CommitConfirmed commits the candidate datastore as per Commit, but
+// reverts the running configuration to that before the commit unless the
+// commit is confirmed, using Confirm or Commit, within timeout. If a
+// confirmed commit is already pending, the timeout is restarted, and the
+// configuration that is reverted to remains that before the first confirmed
+// commit.
+func (d *Datastore) CommitConfirmed(timeout time.Duration) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	prior, priorCheckpoints := d.running, len(d.checkpoints)
+	if err := d.commit(); err != nil {
+		return err
+	}
+
+	if d.pending != nil {
+		d.pending.timer.Stop()
+	} else {
+		d.pending = &confirmedCommit{prior: prior, priorCheckpoints: priorCheckpoints}
+	}
+	p := d.pending
+	p.timer = time.AfterFunc(timeout, func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		// The commit may have been confirmed or cancelled while the
+		// timer was firing.
+		if d.pending == p {
+			d.revertPending()
+		}
+	})
+	return nil
+}
+
+// Confirm confirms the pending confirmed commit, such that it is no longer
+// reverted. It returns ErrNoConfirmedCommit if there is no pending confirmed
+// commit.
+func (d *Datastore) Confirm() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.pending == nil {
+		return ErrNoConfirmedCommit
+	}
+	d.pending.timer.Stop()
+	d.pending = nil
+	return nil
+}
+
+// CancelCommit reverts the pending confirmed commit immediately. It returns
+// ErrNoConfirmedCommit if there is no pending confirmed commit.
+func (d *Datastore) CancelCommit() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.pending == nil {
+		return ErrNoConfirmedCommit
+	}
+	d.pending.timer.Stop()
+	d.revertPending()
+	return nil
+}
+
+// ConfirmPending reports whether a confirmed commit is awaiting
+// confirmation.
+func (d *Datastore) ConfirmPending() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.pending != nil
+}
+
+// Checkpoints returns the number of previous running configurations that
+// are available to Rollback.
+func (d *Datastore) Checkpoints() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.checkpoints)
+}
+
+// Rollback replaces the contents of the candidate datastore with the n-th
+// most recent previous running configuration, such that it becomes the
+// running configuration when the candidate is committed. Rollback(0)
+// replaces the candidate with the current running configuration. Rollback
+// is not permitted while a confirmed commit is pending.
+func (d *Datastore) Rollback(n int) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.pending != nil {
+		return ErrConfirmedCommitPending
+	}
+	if n < 0 || n > len(d.checkpoints) {
+		return fmt.Errorf("invalid checkpoint %d, %d checkpoints available", n, len(d.checkpoints))
+	}
+	if n == 0 {
+		d.candidate = nil
+		return nil
+	}
+	c, err := copyStruct(d.checkpoints[len(d.checkpoints)-n])
+	if err != nil {
+		return err
+	}
+	d.candidate = c
+	return nil
+}
+
+// commit validates the candidate, and makes it the running configuration.
+// The caller must hold d.mu.
+func (d *Datastore) commit() error {
+	if d.candidate == nil {
+		return nil
+	}
+	if err := d.validate(d.candidate); err != nil {
+		return err
+	}
+	d.checkpoints = append(d.checkpoints, d.running)
+	if over := len(d.checkpoints) - d.maxCheckpoints; over > 0 {
+		d.checkpoints = d.checkpoints[over:]
+		if d.pending != nil {
+			d.pending.priorCheckpoints = max(d.pending.priorCheckpoints-over, 0)
+		}
+	}
+	d.running, d.candidate = d.candidate, nil
+	return nil
+}
+
+// revertPending restores the running configuration to that before the
+// pending confirmed commit. The caller must hold d.mu.
+func (d *Datastore) revertPending() {
+	d.running = d.pending.prior
+	d.checkpoints = d.checkpoints[:min(d.pending.priorCheckpoints, len(d.checkpoints))]
+	d.pending = nil
+}
+
+// copyStruct returns a deep copy of s.
+func copyStruct(s ygot.ValidatedGoStruct) (ygot.ValidatedGoStruct, error) {
+	c, err := ygot.DeepCopy(s)
+	if err != nil {
+		return nil, fmt.Errorf("cannot copy datastore contents: %v", err)
+	}
+	v, ok := c.(ygot.ValidatedGoStruct)
+	if !ok {
+		return nil, fmt.Errorf("copy of %T is not a ValidatedGoStruct", s)
+	}
+	return v, nil
+}
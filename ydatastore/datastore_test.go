@@ -0,0 +1,314 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ydatastore
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// device is a ValidatedGoStruct used as the contents of the datastores in
+// tests. It is invalid when Hostname is set to the empty string.
+type device struct {
+	Hostname *string `path:"hostname"`
+	Domain   *string `path:"domain"`
+}
+
+func (*device) IsYANGGoStruct()                         {}
+func (*device) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*device) ΛBelongingModule() string                { return "" }
+
+func (d *device) Validate(...ygot.ValidationOption) error {
+	if d.Hostname != nil && *d.Hostname == "" {
+		return fmt.Errorf("empty hostname")
+	}
+	return nil
+}
+
+func (d *device) GetHostname() string {
+	if d == nil || d.Hostname == nil {
+		return ""
+	}
+	return *d.Hostname
+}
+
+// hostname returns the hostname held in the datastore name of d.
+func hostname(t *testing.T, d *Datastore, name Name) string {
+	t.Helper()
+	s, err := d.Get(name)
+	if err != nil {
+		t.Fatalf("Get(%s): got unexpected error: %v", name, err)
+	}
+	return s.(*device).GetHostname()
+}
+
+// setHostname returns a function that sets the hostname of the candidate
+// to name, for use with EditCandidate.
+func setHostname(name string) func(ygot.ValidatedGoStruct) error {
+	return func(s ygot.ValidatedGoStruct) error {
+		s.(*device).Hostname = ygot.String(name)
+		return nil
+	}
+}
+
+func newTestDatastore(t *testing.T, opts ...DatastoreOpt) *Datastore {
+	t.Helper()
+	d, err := New(&device{Hostname: ygot.String("r1")}, opts...)
+	if err != nil {
+		t.Fatalf("New: got unexpected error: %v", err)
+	}
+	return d
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(&device{Hostname: ygot.String("")}); !errors.Is(err, util.ErrValidation) {
+		t.Errorf("New: got error %v for invalid configuration, want error of kind ErrValidation", err)
+	}
+	if _, err := New(nil); err == nil {
+		t.Errorf("New: did not get expected error for nil configuration")
+	}
+
+	initial := &device{Hostname: ygot.String("r1")}
+	d, err := New(initial)
+	if err != nil {
+		t.Fatalf("New: got unexpected error: %v", err)
+	}
+	initial.Hostname = ygot.String("modified")
+	for _, name := range []Name{Running, Candidate, Startup} {
+		if got := hostname(t, d, name); got != "r1" {
+			t.Errorf("Get(%s): got hostname %q, want r1", name, got)
+		}
+	}
+	if _, err := d.Get("intended"); err == nil {
+		t.Errorf("Get(intended): did not get expected error for unknown datastore")
+	}
+
+	if _, err := New(&device{Hostname: ygot.String("r1")}, MaxCheckpoints(-1)); err == nil {
+		t.Errorf("New: did not get expected error for negative MaxCheckpoints")
+	}
+}
+
+func TestEditAndCommit(t *testing.T) {
+	d := newTestDatastore(t)
+
+	// A failed first edit leaves the candidate unchanged.
+	if err := d.EditCandidate(func(s ygot.ValidatedGoStruct) error {
+		s.(*device).Hostname = ygot.String("r3")
+		return fmt.Errorf("edit failed")
+	}); err == nil {
+		t.Errorf("EditCandidate: did not get expected error")
+	}
+	if got := hostname(t, d, Candidate); got != "r1" {
+		t.Errorf("candidate hostname after failed edit: got %q, want r1", got)
+	}
+
+	if err := d.EditCandidate(setHostname("r2")); err != nil {
+		t.Fatalf("EditCandidate: got unexpected error: %v", err)
+	}
+	if got := hostname(t, d, Running); got != "r1" {
+		t.Errorf("running hostname before commit: got %q, want r1", got)
+	}
+	if got := hostname(t, d, Candidate); got != "r2" {
+		t.Errorf("candidate hostname: got %q, want r2", got)
+	}
+
+	if err := d.Commit(); err != nil {
+		t.Fatalf("Commit: got unexpected error: %v", err)
+	}
+	if got := hostname(t, d, Running); got != "r2" {
+		t.Errorf("running hostname after commit: got %q, want r2", got)
+	}
+	if got := hostname(t, d, Startup); got != "r1" {
+		t.Errorf("startup hostname after commit: got %q, want r1", got)
+	}
+	if err := d.CopyConfig(Startup, Running); err != nil {
+		t.Fatalf("CopyConfig: got unexpected error: %v", err)
+	}
+	if got := hostname(t, d, Startup); got != "r2" {
+		t.Errorf("startup hostname after copy: got %q, want r2", got)
+	}
+	if err := d.CopyConfig(Running, Startup); err == nil {
+		t.Errorf("CopyConfig: did not get expected error copying to running")
+	}
+
+	// An invalid candidate is not committed.
+	if err := d.EditCandidate(setHostname("")); err != nil {
+		t.Fatalf("EditCandidate: got unexpected error: %v", err)
+	}
+	if err := d.Validate(); !errors.Is(err, util.ErrValidation) {
+		t.Errorf("Validate: got error %v, want error of kind ErrValidation", err)
+	}
+	if err := d.Commit(); !errors.Is(err, util.ErrValidation) {
+		t.Errorf("Commit: got error %v, want error of kind ErrValidation", err)
+	}
+	if got := hostname(t, d, Running); got != "r2" {
+		t.Errorf("running hostname after invalid commit: got %q, want r2", got)
+	}
+	d.DiscardCandidate()
+	if got := hostname(t, d, Candidate); got != "r2" {
+		t.Errorf("candidate hostname after discard: got %q, want r2", got)
+	}
+}
+
+func TestEditCandidateCopyOnWrite(t *testing.T) {
+	d := newTestDatastore(t)
+
+	// The candidate is copied from running on its first edit, and is then
+	// edited in place.
+	var edited []ygot.ValidatedGoStruct
+	for _, h := range []string{"r2", "r3"} {
+		if err := d.EditCandidate(func(s ygot.ValidatedGoStruct) error {
+			edited = append(edited, s)
+			return setHostname(h)(s)
+		}); err != nil {
+			t.Fatalf("EditCandidate: got unexpected error: %v", err)
+		}
+	}
+	if edited[0] != edited[1] {
+		t.Errorf("EditCandidate: candidate was copied on each edit")
+	}
+	if got := hostname(t, d, Running); got != "r1" {
+		t.Errorf("running hostname after edits: got %q, want r1", got)
+	}
+
+	// After a commit, the next edit copies the new running configuration.
+	if err := d.Commit(); err != nil {
+		t.Fatalf("Commit: got unexpected error: %v", err)
+	}
+	if err := d.EditCandidate(func(s ygot.ValidatedGoStruct) error {
+		edited = append(edited, s)
+		return setHostname("r4")(s)
+	}); err != nil {
+		t.Fatalf("EditCandidate: got unexpected error: %v", err)
+	}
+	if edited[2] == edited[1] {
+		t.Errorf("EditCandidate: running configuration was edited in place after commit")
+	}
+	if got := hostname(t, d, Running); got != "r3" {
+		t.Errorf("running hostname after commit: got %q, want r3", got)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	d := newTestDatastore(t, MaxCheckpoints(2))
+	for _, h := range []string{"r2", "r3", "r4"} {
+		if err := d.EditCandidate(setHostname(h)); err != nil {
+			t.Fatalf("EditCandidate: got unexpected error: %v", err)
+		}
+		if err := d.Commit(); err != nil {
+			t.Fatalf("Commit: got unexpected error: %v", err)
+		}
+	}
+	if got := d.Checkpoints(); got != 2 {
+		t.Fatalf("Checkpoints: got %d, want 2", got)
+	}
+
+	if err := d.Rollback(2); err != nil {
+		t.Fatalf("Rollback(2): got unexpected error: %v", err)
+	}
+	if got := hostname(t, d, Candidate); got != "r2" {
+		t.Errorf("candidate hostname after rollback: got %q, want r2", got)
+	}
+	if got := hostname(t, d, Running); got != "r4" {
+		t.Errorf("running hostname after rollback: got %q, want r4", got)
+	}
+	if err := d.Commit(); err != nil {
+		t.Fatalf("Commit: got unexpected error: %v", err)
+	}
+	if got := hostname(t, d, Running); got != "r2" {
+		t.Errorf("running hostname after rollback commit: got %q, want r2", got)
+	}
+
+	if err := d.Rollback(3); err == nil {
+		t.Errorf("Rollback(3): did not get expected error for unavailable checkpoint")
+	}
+	if err := d.Rollback(0); err != nil {
+		t.Errorf("Rollback(0): got unexpected error: %v", err)
+	}
+}
+
+func TestCommitConfirmed(t *testing.T) {
+	t.Run("confirmed", func(t *testing.T) {
+		d := newTestDatastore(t)
+		if err := d.EditCandidate(setHostname("r2")); err != nil {
+			t.Fatalf("EditCandidate: got unexpected error: %v", err)
+		}
+		if err := d.CommitConfirmed(time.Hour); err != nil {
+			t.Fatalf("CommitConfirmed: got unexpected error: %v", err)
+		}
+		if err := d.Rollback(1); !errors.Is(err, ErrConfirmedCommitPending) {
+			t.Errorf("Rollback: got error %v, want ErrConfirmedCommitPending", err)
+		}
+		if err := d.Confirm(); err != nil {
+			t.Fatalf("Confirm: got unexpected error: %v", err)
+		}
+		if err := d.Confirm(); !errors.Is(err, ErrNoConfirmedCommit) {
+			t.Errorf("Confirm: got error %v, want ErrNoConfirmedCommit", err)
+		}
+		if got := hostname(t, d, Running); got != "r2" {
+			t.Errorf("running hostname after confirm: got %q, want r2", got)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		d := newTestDatastore(t)
+		for _, h := range []string{"r2", "r3"} {
+			if err := d.EditCandidate(setHostname(h)); err != nil {
+				t.Fatalf("EditCandidate: got unexpected error: %v", err)
+			}
+			if err := d.CommitConfirmed(time.Hour); err != nil {
+				t.Fatalf("CommitConfirmed: got unexpected error: %v", err)
+			}
+		}
+		if err := d.CancelCommit(); err != nil {
+			t.Fatalf("CancelCommit: got unexpected error: %v", err)
+		}
+		if got := hostname(t, d, Running); got != "r1" {
+			t.Errorf("running hostname after cancel: got %q, want r1", got)
+		}
+		if got := d.Checkpoints(); got != 0 {
+			t.Errorf("Checkpoints after cancel: got %d, want 0", got)
+		}
+		if err := d.CancelCommit(); !errors.Is(err, ErrNoConfirmedCommit) {
+			t.Errorf("CancelCommit: got error %v, want ErrNoConfirmedCommit", err)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		d := newTestDatastore(t)
+		if err := d.EditCandidate(setHostname("r2")); err != nil {
+			t.Fatalf("EditCandidate: got unexpected error: %v", err)
+		}
+		if err := d.CommitConfirmed(10 * time.Millisecond); err != nil {
+			t.Fatalf("CommitConfirmed: got unexpected error: %v", err)
+		}
+		deadline := time.Now().Add(5 * time.Second)
+		for d.ConfirmPending() {
+			if time.Now().After(deadline) {
+				t.Fatalf("confirmed commit was not reverted before deadline")
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		if got := hostname(t, d, Running); got != "r1" {
+			t.Errorf("running hostname after timeout: got %q, want r1", got)
+		}
+	})
+}
@@ -0,0 +1,564 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package yderive maintains derived state within a ygot-generated GoStruct
+// data tree. A derived leaf, such as the operational status of an interface
+// that is rolled up from its subinterfaces, or the rate of a counter, is
+// computed by a function from other nodes of the data tree, which are its
+// inputs.
+//
+// Derived leaves are re-evaluated when their inputs change. An Engine is an
+// ytypes.AuditSink, such that mutations that are made by ytypes.SetNode and
+// ytypes.DeleteNode with a ytypes.Audit option whose Sink is the Engine
+// cause the rules whose inputs they modify to be re-evaluated. The derived
+// leaves are themselves written with such an option, such that leaves that
+// are derived from other derived leaves are also re-evaluated. Rules whose
+// derived leaves are, directly or indirectly, inputs to themselves are
+// rejected when they are registered.
+package yderive
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Principal is the principal of the ytypes.AuditRecords of the mutations
+// that are made by an Engine when it writes derived leaves.
+const Principal = "yderive"
+
+// ComputeFunc computes the value of the derived leaf c.Target. It returns
+// either a *gpb.TypedValue, or a Go value, such as an enumerated value or a
+// pointer to a scalar, that can be encoded by ygot.EncodeTypedValue. A nil
+// value indicates that the leaf is deleted.
+type ComputeFunc func(c *Context) (any, error)
+
+// Rule derives the leaves at a path from a set of inputs.
+type Rule struct {
+	// Name is the name of the rule, which is used within errors.
+	Name string
+	// Target is the path of the derived leaves. Its list keys may be
+	// wildcards, in which case the rule derives a leaf within each list
+	// entry. Each wildcard must be bound by the inputs of the rule, such
+	// that the elements of Target up to, and including, the element
+	// containing the wildcard are also the leading elements of each input.
+	Target *gpb.Path
+	// Inputs are the paths of the nodes, which may contain wildcards,
+	// that the derived leaves are computed from. A change to an input, to
+	// a descendant of an input, or to an ancestor of an input causes the
+	// rule to be re-evaluated.
+	Inputs []*gpb.Path
+	// Compute computes the value of a derived leaf.
+	Compute ComputeFunc
+}
+
+// Context is the context within which a ComputeFunc is called.
+type Context struct {
+	// Root is the root of the data tree.
+	Root ygot.GoStruct
+	// Target is the path of the derived leaf that is computed, which does
+	// not contain wildcards.
+	Target *gpb.Path
+	// Change is the mutation that caused the derived leaf to be
+	// re-evaluated, or nil if it is being evaluated by Engine.Evaluate.
+	// Its Old and New fields hold the values of the changed node, such
+	// that, for example, the rate of a counter can be computed.
+	Change *ytypes.AuditRecord
+	// Time is the time of Change, or the time at which Engine.Evaluate
+	// was called.
+	Time time.Time
+
+	// engine is the engine that is evaluating the derived leaf.
+	engine *Engine
+}
+
+// Get returns the nodes within the data tree that match path, which may
+// contain wildcards. Unlike ytypes.GetNode, it is not an error for no nodes
+// to match path.
+func (c *Context) Get(path *gpb.Path) ([]*ytypes.TreeNode, error) {
+	return c.engine.get(path)
+}
+
+// Engine evaluates a set of rules against a data tree. It is not safe for
+// concurrent use, nor is the data tree that it writes to.
+type Engine struct {
+	// schema is the schema of the data tree.
+	schema *yang.Entry
+	// root is the root of the data tree.
+	root ygot.GoStruct
+	// rules are the registered rules, ordered such that each rule
+	// appears after the rules whose derived leaves are its inputs.
+	rules []*Rule
+	// pending is the set of mutations whose rules have not yet been
+	// re-evaluated.
+	pending []*ytypes.AuditRecord
+	// busy is true whilst pending is being processed, such that the
+	// mutations made when writing derived leaves are queued rather than
+	// processed recursively.
+	busy bool
+	// errs are the errors encountered whilst re-evaluating rules in
+	// response to mutations.
+	errs util.Errors
+}
+
+// New returns an Engine that maintains the derived leaves of the supplied
+// rules within root, whose schema is supplied. Derived leaves are not
+// evaluated until the inputs of their rule change, or Evaluate is called.
+func New(schema *ytypes.Schema, root ygot.GoStruct, rules ...*Rule) (*Engine, error) {
+	if schema == nil || schema.SchemaTree == nil || schema.Root == nil {
+		return nil, fmt.Errorf("invalid schema: not fully populated")
+	}
+	if root == nil {
+		return nil, fmt.Errorf("nil root")
+	}
+	e := &Engine{schema: schema.RootSchema(), root: root}
+	for _, r := range rules {
+		if err := e.Register(r); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// Register adds the rule r to the engine. It returns an error if r is
+// invalid, or if the derived leaves of r would, directly or indirectly, be
+// inputs to r.
+func (e *Engine) Register(r *Rule) error {
+	if err := e.validate(r); err != nil {
+		return fmt.Errorf("invalid rule %s: %v", r.Name, err)
+	}
+	rules, err := order(append(append([]*Rule{}, e.rules...), r))
+	if err != nil {
+		return err
+	}
+	e.rules = rules
+	return nil
+}
+
+// Audit returns a ytypes.Audit option which, when supplied to ytypes.SetNode
+// and ytypes.DeleteNode, causes the rules whose inputs are changed by the
+// mutation to be re-evaluated. principal is included within the records of
+// the mutations.
+func (e *Engine) Audit(principal string) *ytypes.Audit {
+	return &ytypes.Audit{Sink: e, Principal: principal}
+}
+
+// Record implements the ytypes.AuditSink interface. It re-evaluates the
+// rules whose inputs are changed by the mutation r. Errors that are
+// encountered are returned by Err.
+func (e *Engine) Record(r *ytypes.AuditRecord) {
+	e.pending = append(e.pending, r)
+	if e.busy {
+		return
+	}
+	e.busy = true
+	defer func() { e.busy = false }()
+	for len(e.pending) != 0 {
+		r := e.pending[0]
+		e.pending = e.pending[1:]
+		e.changed(r)
+	}
+}
+
+// Err returns the errors encountered whilst re-evaluating rules in response
+// to mutations since Err was last called, or nil if there were none.
+func (e *Engine) Err() error {
+	errs := e.errs
+	e.errs = nil
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Evaluate evaluates each derived leaf of each rule whose inputs exist
+// within the data tree, along with each existing derived leaf. Rules are
+// evaluated in dependency order.
+func (e *Engine) Evaluate() error {
+	var errs util.Errors
+	now := time.Now()
+	for _, r := range e.rules {
+		targets, err := e.expand(r, nil, nil)
+		if err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("rule %s: %v", r.Name, err))
+			continue
+		}
+		for _, t := range targets {
+			errs = util.AppendErr(errs, e.evaluate(r, t, nil, now))
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// changed re-evaluates the derived leaves that are affected by the mutation
+// c, in dependency order.
+func (e *Engine) changed(c *ytypes.AuditRecord) {
+	path := util.StripGNMIPathModulePrefixes(c.Path)
+	for _, r := range e.rules {
+		seen := map[string]bool{}
+		for _, in := range r.Inputs {
+			if !overlaps(path, in) {
+				continue
+			}
+			targets, err := e.expand(r, in, path)
+			if err != nil {
+				e.errs = util.AppendErr(e.errs, fmt.Errorf("rule %s: %v", r.Name, err))
+				continue
+			}
+			for _, t := range targets {
+				k := pathKey(t)
+				if seen[k] {
+					continue
+				}
+				seen[k] = true
+				e.errs = util.AppendErr(e.errs, e.evaluate(r, t, c, c.Time))
+			}
+		}
+	}
+}
+
+// expand returns the paths, without wildcards, of the derived leaves of the
+// rule r that are affected by a change at path to its input in. If in is
+// nil, the derived leaves for all inputs are returned. The wildcards of the
+// target of r are bound from path where possible, otherwise from the nodes
+// that match the input, and the existing derived leaves.
+func (e *Engine) expand(r *Rule, in, path *gpb.Path) ([]*gpb.Path, error) {
+	target := util.StripGNMIPathModulePrefixes(r.Target)
+	inputs := r.Inputs
+	if in != nil {
+		target = bind(target, in, path)
+		if !hasWildcards(target) {
+			return []*gpb.Path{target}, nil
+		}
+		inputs = []*gpb.Path{bind(in, in, path)}
+	}
+
+	var out []*gpb.Path
+	seen := map[string]bool{}
+	add := func(nodes []*ytypes.TreeNode, query *gpb.Path) {
+		for _, n := range nodes {
+			t := bind(target, query, n.Path)
+			if k := pathKey(t); !hasWildcards(t) && !seen[k] {
+				seen[k] = true
+				out = append(out, t)
+			}
+		}
+	}
+	for _, q := range inputs {
+		q = util.StripGNMIPathModulePrefixes(q)
+		nodes, err := e.get(q)
+		if err != nil {
+			return nil, err
+		}
+		add(nodes, q)
+	}
+	// Existing derived leaves are re-evaluated, since their inputs may
+	// have been deleted.
+	nodes, err := e.get(target)
+	if err != nil {
+		return nil, err
+	}
+	add(nodes, target)
+	sort.Slice(out, func(i, j int) bool { return pathKey(out[i]) < pathKey(out[j]) })
+	return out, nil
+}
+
+// evaluate computes and writes the derived leaf of the rule r at target,
+// in response to the change c, which occurred at time t.
+func (e *Engine) evaluate(r *Rule, target *gpb.Path, c *ytypes.AuditRecord, t time.Time) error {
+	v, err := r.Compute(&Context{Root: e.root, Target: target, Change: c, Time: t, engine: e})
+	if err != nil {
+		return fmt.Errorf("rule %s: cannot compute %s: %v", r.Name, pathKey(target), err)
+	}
+	cur, err := e.current(target)
+	if err != nil {
+		return fmt.Errorf("rule %s: cannot retrieve %s: %v", r.Name, pathKey(target), err)
+	}
+	if util.IsValueNil(v) {
+		if cur == nil {
+			return nil
+		}
+		if err := ytypes.DeleteNode(e.schema, e.root, target, &ytypes.Audit{Sink: e, Principal: Principal, Now: func() time.Time { return t }}); err != nil {
+			return fmt.Errorf("rule %s: cannot delete %s: %v", r.Name, pathKey(target), err)
+		}
+		return nil
+	}
+	// The mutations of derived leaves are recorded as occurring at the
+	// time of the change that caused them.
+	audit := &ytypes.Audit{Sink: e, Principal: Principal, Now: func() time.Time { return t }}
+	tv, ok := v.(*gpb.TypedValue)
+	if !ok {
+		if tv, err = ygot.EncodeTypedValue(v, gpb.Encoding_JSON_IETF); err != nil {
+			return fmt.Errorf("rule %s: cannot encode value %v for %s: %v", r.Name, v, pathKey(target), err)
+		}
+	}
+	// Unchanged values are not written, such that the leaves derived from
+	// them are not needlessly re-evaluated.
+	if proto.Equal(cur, tv) {
+		return nil
+	}
+	if err := ytypes.SetNode(e.schema, e.root, target, tv, &ytypes.InitMissingElements{}, audit); err != nil {
+		return fmt.Errorf("rule %s: cannot set %s: %v", r.Name, pathKey(target), err)
+	}
+	return nil
+}
+
+// current returns the value of the leaf at path, or nil if it does not
+// exist.
+func (e *Engine) current(path *gpb.Path) (*gpb.TypedValue, error) {
+	nodes, err := e.get(path)
+	if err != nil || len(nodes) != 1 || util.IsValueNil(nodes[0].Data) {
+		return nil, err
+	}
+	return ygot.EncodeTypedValue(nodes[0].Data, gpb.Encoding_JSON_IETF)
+}
+
+// get returns the nodes of the data tree that match path, which may contain
+// wildcards.
+func (e *Engine) get(path *gpb.Path) ([]*ytypes.TreeNode, error) {
+	nodes, err := ytypes.GetNode(e.schema, e.root, path, &ytypes.GetHandleWildcards{})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []*ytypes.TreeNode
+	for _, n := range nodes {
+		if !util.IsValueNil(n.Data) {
+			out = append(out, n)
+		}
+	}
+	return out, nil
+}
+
+// validate checks that the rule r is well-formed with respect to the schema
+// of the engine.
+func (e *Engine) validate(r *Rule) error {
+	if r == nil {
+		return fmt.Errorf("nil rule")
+	}
+	if r.Compute == nil {
+		return fmt.Errorf("no compute function")
+	}
+	if len(r.Inputs) == 0 {
+		return fmt.Errorf("no inputs")
+	}
+	target := util.StripGNMIPathModulePrefixes(r.Target)
+	ts, err := schemaOf(e.schema, target)
+	if err != nil {
+		return fmt.Errorf("target: %v", err)
+	}
+	if !ts.IsLeaf() && !ts.IsLeafList() {
+		return fmt.Errorf("target %s is not a leaf or leaf-list", pathKey(target))
+	}
+	var errs util.Errors
+	for _, in := range r.Inputs {
+		in = util.StripGNMIPathModulePrefixes(in)
+		if _, err := schemaOf(e.schema, in); err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("input: %v", err))
+			continue
+		}
+		for i, te := range target.GetElem() {
+			for k, v := range te.GetKey() {
+				if v == "*" && !sharesPrefix(target, in, i) {
+					errs = util.AppendErr(errs, fmt.Errorf("wildcard key %s of target element %s cannot be bound from input %s", k, te.GetName(), pathKey(in)))
+				}
+			}
+		}
+	}
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+// schemaOf returns the schema of the data node at path beneath the
+// directory root.
+func schemaOf(root *yang.Entry, path *gpb.Path) (*yang.Entry, error) {
+	if len(path.GetElem()) == 0 {
+		return nil, fmt.Errorf("empty path")
+	}
+	e := root
+	for _, pe := range path.GetElem() {
+		if !e.IsDir() {
+			return nil, fmt.Errorf("path %s is beneath leaf %s", pathKey(path), e.Name)
+		}
+		ch := childSchema(e, pe.GetName())
+		if ch == nil {
+			return nil, fmt.Errorf("path %s not found in schema: no child %s of %s", pathKey(path), pe.GetName(), e.Name)
+		}
+		e = ch
+	}
+	return e, nil
+}
+
+// childSchema returns the schema of the data node child of the directory e
+// named name, or nil if there is no such child.
+func childSchema(e *yang.Entry, name string) *yang.Entry {
+	for _, ch := range util.FindFirstNonChoiceOrCase(e) {
+		if ch.Name == name {
+			return ch
+		}
+	}
+	return nil
+}
+
+// order returns rules sorted such that each rule appears after the rules
+// whose targets are its inputs. It returns an error if there is a cycle.
+func order(rules []*Rule) ([]*Rule, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(rules))
+	var out []*Rule
+	var stack []string
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("cycle between rules: %s -> %s", strings.Join(stack, " -> "), rules[i].Name)
+		}
+		state[i] = visiting
+		stack = append(stack, rules[i].Name)
+		// Rules that rule i depends on are visited first.
+		for j, dep := range rules {
+			if dependsOn(rules[i], dep) {
+				if err := visit(j); err != nil {
+					return err
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[i] = visited
+		out = append(out, rules[i])
+		return nil
+	}
+	for i := range rules {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// dependsOn reports whether any input of the rule r is affected by changes
+// to the target of the rule dep.
+func dependsOn(r, dep *Rule) bool {
+	target := util.StripGNMIPathModulePrefixes(dep.Target)
+	for _, in := range r.Inputs {
+		if overlaps(target, util.StripGNMIPathModulePrefixes(in)) {
+			return true
+		}
+	}
+	return false
+}
+
+// overlaps reports whether the nodes at a and b, which may contain
+// wildcards, may be the same node, or whether one may be an ancestor of the
+// other.
+func overlaps(a, b *gpb.Path) bool {
+	n := len(a.GetElem())
+	if len(b.GetElem()) < n {
+		n = len(b.GetElem())
+	}
+	for i := 0; i < n; i++ {
+		ae, be := a.GetElem()[i], b.GetElem()[i]
+		if ae.GetName() != be.GetName() {
+			return false
+		}
+		for k, av := range ae.GetKey() {
+			if bv, ok := be.GetKey()[k]; ok && av != "*" && bv != "*" && av != bv {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// sharesPrefix reports whether the first n+1 elements of a and b have the
+// same names.
+func sharesPrefix(a, b *gpb.Path, n int) bool {
+	if len(a.GetElem()) <= n || len(b.GetElem()) <= n {
+		return false
+	}
+	for i := 0; i <= n; i++ {
+		if a.GetElem()[i].GetName() != b.GetElem()[i].GetName() {
+			return false
+		}
+	}
+	return true
+}
+
+// bind returns a copy of target in which the wildcard keys of the elements
+// that are shared by target and query are replaced by the corresponding
+// keys of path, which is a path that matches, or overlaps, query.
+func bind(target, query, path *gpb.Path) *gpb.Path {
+	out := proto.Clone(target).(*gpb.Path)
+	for i, te := range out.GetElem() {
+		if i >= len(query.GetElem()) || i >= len(path.GetElem()) {
+			break
+		}
+		qe, pe := query.GetElem()[i], path.GetElem()[i]
+		if te.GetName() != qe.GetName() || te.GetName() != pe.GetName() {
+			break
+		}
+		for k, v := range te.GetKey() {
+			if pv, ok := pe.GetKey()[k]; ok && v == "*" && pv != "*" {
+				te.Key[k] = pv
+			}
+		}
+	}
+	return out
+}
+
+// hasWildcards reports whether path contains a wildcard key.
+func hasWildcards(path *gpb.Path) bool {
+	for _, pe := range path.GetElem() {
+		for _, v := range pe.GetKey() {
+			if v == "*" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pathKey returns the string form of path, for use in errors and as a map
+// key.
+func pathKey(path *gpb.Path) string {
+	s, err := ygot.PathToString(path)
+	if err != nil {
+		return path.String()
+	}
+	return s
+}
@@ -0,0 +1,275 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yderive
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func mustPath(t *testing.T, s string) *gpb.Path {
+	t.Helper()
+	p, err := ygot.StringToStructuredPath(s)
+	if err != nil {
+		t.Fatalf("cannot parse path %s: %v", s, err)
+	}
+	return p
+}
+
+func testSchema() *ytypes.Schema {
+	return &ytypes.Schema{Root: &exampleoc.Device{}, SchemaTree: exampleoc.SchemaTree, Unmarshal: exampleoc.Unmarshal}
+}
+
+// operStatusRule returns a rule that derives the operational status of an
+// interface from those of its subinterfaces: it is UP if any subinterface
+// is UP, DOWN otherwise, and is deleted if there are no subinterfaces.
+func operStatusRule(t *testing.T) *Rule {
+	return &Rule{
+		Name:   "oper-status",
+		Target: mustPath(t, "/interfaces/interface[name=*]/state/oper-status"),
+		Inputs: []*gpb.Path{mustPath(t, "/interfaces/interface[name=*]/subinterfaces/subinterface[index=*]/state/oper-status")},
+		Compute: func(c *Context) (any, error) {
+			name := c.Target.GetElem()[1].GetKey()["name"]
+			nodes, err := c.Get(mustPath(t, fmt.Sprintf("/interfaces/interface[name=%s]/subinterfaces/subinterface[index=*]/state/oper-status", name)))
+			if err != nil {
+				return nil, err
+			}
+			if len(nodes) == 0 {
+				return nil, nil
+			}
+			for _, n := range nodes {
+				if n.Data.(exampleoc.E_Interface_OperStatus) == exampleoc.Interface_OperStatus_UP {
+					return exampleoc.Interface_OperStatus_UP, nil
+				}
+			}
+			return exampleoc.Interface_OperStatus_DOWN, nil
+		},
+	}
+}
+
+// lastChangeRule returns a rule that records the time at which the derived
+// operational status of an interface last changed.
+func lastChangeRule(t *testing.T) *Rule {
+	return &Rule{
+		Name:   "last-change",
+		Target: mustPath(t, "/interfaces/interface[name=*]/state/last-change"),
+		Inputs: []*gpb.Path{mustPath(t, "/interfaces/interface[name=*]/state/oper-status")},
+		Compute: func(c *Context) (any, error) {
+			return ygot.Uint64(uint64(c.Time.Unix())), nil
+		},
+	}
+}
+
+func TestRecord(t *testing.T) {
+	d := &exampleoc.Device{}
+	// last-change is registered first to check that rules are evaluated
+	// in dependency order.
+	e, err := New(testSchema(), d, lastChangeRule(t), operStatusRule(t))
+	if err != nil {
+		t.Fatalf("New: got unexpected error: %v", err)
+	}
+	now := time.Unix(100, 0)
+	audit := e.Audit("alice")
+	audit.Now = func() time.Time { return now }
+
+	set := func(path string, status string) {
+		t.Helper()
+		if err := ytypes.SetNode(e.schema, d, mustPath(t, path), &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: status}}, &ytypes.InitMissingElements{}, audit); err != nil {
+			t.Fatalf("SetNode(%s): got unexpected error: %v", path, err)
+		}
+		if err := e.Err(); err != nil {
+			t.Fatalf("SetNode(%s): got unexpected evaluation error: %v", path, err)
+		}
+	}
+
+	set("/interfaces/interface[name=eth0]/subinterfaces/subinterface[index=0]/state/oper-status", "DOWN")
+	i := d.GetInterface("eth0")
+	if got, want := i.GetOperStatus(), exampleoc.Interface_OperStatus_DOWN; got != want {
+		t.Errorf("after subinterface 0 DOWN: got oper-status %v, want %v", got, want)
+	}
+	if got, want := i.GetLastChange(), uint64(100); got != want {
+		t.Errorf("after subinterface 0 DOWN: got last-change %d, want %d", got, want)
+	}
+
+	now = time.Unix(200, 0)
+	set("/interfaces/interface[name=eth0]/subinterfaces/subinterface[index=1]/state/oper-status", "DOWN")
+	if got, want := i.GetLastChange(), uint64(100); got != want {
+		t.Errorf("after unchanged oper-status: got last-change %d, want %d", got, want)
+	}
+
+	now = time.Unix(300, 0)
+	set("/interfaces/interface[name=eth0]/subinterfaces/subinterface[index=1]/state/oper-status", "UP")
+	if got, want := i.GetOperStatus(), exampleoc.Interface_OperStatus_UP; got != want {
+		t.Errorf("after subinterface 1 UP: got oper-status %v, want %v", got, want)
+	}
+	if got, want := i.GetLastChange(), uint64(300); got != want {
+		t.Errorf("after subinterface 1 UP: got last-change %d, want %d", got, want)
+	}
+
+	set("/interfaces/interface[name=eth1]/subinterfaces/subinterface[index=0]/state/oper-status", "UP")
+	if got, want := d.GetInterface("eth1").GetOperStatus(), exampleoc.Interface_OperStatus_UP; got != want {
+		t.Errorf("eth1: got oper-status %v, want %v", got, want)
+	}
+	if got, want := i.GetLastChange(), uint64(300); got != want {
+		t.Errorf("eth0 after eth1 change: got last-change %d, want %d", got, want)
+	}
+
+	for _, path := range []string{
+		"/interfaces/interface[name=eth0]/subinterfaces/subinterface[index=0]",
+		"/interfaces/interface[name=eth0]/subinterfaces/subinterface[index=1]",
+	} {
+		if err := ytypes.DeleteNode(e.schema, d, mustPath(t, path), audit); err != nil {
+			t.Fatalf("DeleteNode(%s): got unexpected error: %v", path, err)
+		}
+		if err := e.Err(); err != nil {
+			t.Fatalf("DeleteNode(%s): got unexpected evaluation error: %v", path, err)
+		}
+	}
+	if got := i.GetOperStatus(); got != exampleoc.Interface_OperStatus_UNSET {
+		t.Errorf("after deleting subinterfaces: got oper-status %v, want unset", got)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	d := &exampleoc.Device{}
+	d.GetOrCreateInterface("eth0").GetOrCreateSubinterface(0).OperStatus = exampleoc.Interface_OperStatus_UP
+	d.GetOrCreateInterface("eth1").GetOrCreateSubinterface(0).OperStatus = exampleoc.Interface_OperStatus_DOWN
+	// eth2 has a stale derived value, which is deleted since it has no
+	// subinterfaces.
+	d.GetOrCreateInterface("eth2").OperStatus = exampleoc.Interface_OperStatus_UP
+
+	e, err := New(testSchema(), d, operStatusRule(t), lastChangeRule(t))
+	if err != nil {
+		t.Fatalf("New: got unexpected error: %v", err)
+	}
+	if err := e.Evaluate(); err != nil {
+		t.Fatalf("Evaluate: got unexpected error: %v", err)
+	}
+	for name, want := range map[string]exampleoc.E_Interface_OperStatus{
+		"eth0": exampleoc.Interface_OperStatus_UP,
+		"eth1": exampleoc.Interface_OperStatus_DOWN,
+		"eth2": exampleoc.Interface_OperStatus_UNSET,
+	} {
+		if got := d.GetInterface(name).GetOperStatus(); got != want {
+			t.Errorf("%s: got oper-status %v, want %v", name, got, want)
+		}
+	}
+	if d.GetInterface("eth0").LastChange == nil {
+		t.Errorf("eth0: got nil last-change, want derived value")
+	}
+}
+
+func TestComputeError(t *testing.T) {
+	d := &exampleoc.Device{}
+	r := operStatusRule(t)
+	r.Compute = func(*Context) (any, error) { return nil, fmt.Errorf("broken") }
+	e, err := New(testSchema(), d, r)
+	if err != nil {
+		t.Fatalf("New: got unexpected error: %v", err)
+	}
+	if err := ytypes.SetNode(e.schema, d, mustPath(t, "/interfaces/interface[name=eth0]/subinterfaces/subinterface[index=0]/state/oper-status"), &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "UP"}}, &ytypes.InitMissingElements{}, e.Audit("alice")); err != nil {
+		t.Fatalf("SetNode: got unexpected error: %v", err)
+	}
+	if err := e.Err(); err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("Err: got %v, want error containing broken", err)
+	}
+	if err := e.Err(); err != nil {
+		t.Errorf("Err after Err: got %v, want nil", err)
+	}
+}
+
+func TestRegisterErrors(t *testing.T) {
+	compute := func(*Context) (any, error) { return nil, nil }
+	tests := []struct {
+		desc             string
+		rules            []*Rule
+		wantErrSubstring string
+	}{{
+		desc: "cycle",
+		rules: []*Rule{{
+			Name:    "a",
+			Target:  mustPath(t, "/interfaces/interface[name=*]/state/oper-status"),
+			Inputs:  []*gpb.Path{mustPath(t, "/interfaces/interface[name=*]/state/admin-status")},
+			Compute: compute,
+		}, {
+			Name:    "b",
+			Target:  mustPath(t, "/interfaces/interface[name=*]/state/admin-status"),
+			Inputs:  []*gpb.Path{mustPath(t, "/interfaces/interface[name=*]/state/oper-status")},
+			Compute: compute,
+		}},
+		wantErrSubstring: "cycle between rules",
+	}, {
+		desc: "target within input",
+		rules: []*Rule{{
+			Name:    "a",
+			Target:  mustPath(t, "/interfaces/interface[name=*]/state/oper-status"),
+			Inputs:  []*gpb.Path{mustPath(t, "/interfaces/interface[name=*]/state")},
+			Compute: compute,
+		}},
+		wantErrSubstring: "cycle between rules: a -> a",
+	}, {
+		desc: "unbound wildcard",
+		rules: []*Rule{{
+			Name:    "a",
+			Target:  mustPath(t, "/interfaces/interface[name=*]/state/oper-status"),
+			Inputs:  []*gpb.Path{mustPath(t, "/system/state/hostname")},
+			Compute: compute,
+		}},
+		wantErrSubstring: "cannot be bound",
+	}, {
+		desc: "target not a leaf",
+		rules: []*Rule{{
+			Name:    "a",
+			Target:  mustPath(t, "/system/state"),
+			Inputs:  []*gpb.Path{mustPath(t, "/system/config/hostname")},
+			Compute: compute,
+		}},
+		wantErrSubstring: "not a leaf",
+	}, {
+		desc: "unknown input",
+		rules: []*Rule{{
+			Name:    "a",
+			Target:  mustPath(t, "/system/state/hostname"),
+			Inputs:  []*gpb.Path{mustPath(t, "/system/config/no-such-leaf")},
+			Compute: compute,
+		}},
+		wantErrSubstring: "not found in schema",
+	}, {
+		desc: "no compute function",
+		rules: []*Rule{{
+			Name:   "a",
+			Target: mustPath(t, "/system/state/hostname"),
+			Inputs: []*gpb.Path{mustPath(t, "/system/config/hostname")},
+		}},
+		wantErrSubstring: "no compute function",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := New(testSchema(), &exampleoc.Device{}, tt.rules...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErrSubstring) {
+				t.Errorf("New: got error %v, want error containing %q", err, tt.wantErrSubstring)
+			}
+		})
+	}
+}
@@ -0,0 +1,463 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ydeviate implements an engine that applies the deviations of a
+// device from a model as an overlay on the gNMI messages that are exchanged
+// with it, such that a single intent model can be used to manage devices
+// from different vendors. A set of deviations describes the paths that a
+// device does not support, the paths that it renames, and the values that
+// it represents differently. The deviations are applied to SetRequests
+// that are generated from the intent model before they are sent to a
+// device, and reversed on the Notifications that are received from it
+// before they are unmarshalled into the intent model.
+//
+// Deviations are applied to the path of each update, and to the values of
+// updates whose value is a scalar. JSON-encoded values of updates to
+// non-leaf nodes are not modified.
+package ydeviate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/proto"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Deviations describes the deviations of a device, or set of devices, from
+// the intent model. Paths are specified in the format parsed by
+// util.ParseGNMIPath, as paths of the intent model unless otherwise noted.
+type Deviations struct {
+	// Vendor is a human-readable identifier for the set of devices that
+	// the deviations apply to.
+	Vendor string `json:"vendor"`
+	// Unsupported is the set of paths that are not supported by the
+	// device. Updates to the paths, or their descendants, are removed
+	// from SetRequests, and from Notifications. The paths may contain
+	// wildcards, as per util.PathMatchesQuery.
+	Unsupported []string `json:"unsupported,omitempty"`
+	// Renames is the set of paths that the device represents using a
+	// different path.
+	Renames []*Rename `json:"renames,omitempty"`
+	// Transforms is the set of leaves whose values the device represents
+	// differently.
+	Transforms []*Transform `json:"transforms,omitempty"`
+}
+
+// Rename specifies that the node at a path of the intent model, and its
+// descendants, are represented at a different path on the device.
+type Rename struct {
+	// From is the path within the intent model.
+	From string `json:"from"`
+	// To is the corresponding path on the device. Elements of To that do
+	// not specify keys take the keys of the corresponding element of
+	// the path that is renamed.
+	To string `json:"to"`
+}
+
+// Transform specifies that the value of the leaves matching a path are
+// represented differently on the device. Exactly one of Map, Scale, or the
+// ToDevice and FromDevice functions, must be specified.
+type Transform struct {
+	// Path is the path of the leaves within the intent model, which may
+	// contain wildcards.
+	Path string `json:"path"`
+	// Map maps string values within the intent model to those used by
+	// the device, e.g., for enumerated values that are named differently.
+	// Values that are not within the map are not modified.
+	Map map[string]string `json:"map,omitempty"`
+	// Scale is the factor that numeric values within the intent model are
+	// multiplied by to give those used by the device, e.g., for leaves
+	// whose units differ.
+	Scale float64 `json:"scale,omitempty"`
+	// ToDevice and FromDevice are functions that transform values from
+	// the intent model to those used by the device, and vice versa. They
+	// cannot be specified using JSON.
+	ToDevice   func(*gpb.TypedValue) (*gpb.TypedValue, error) `json:"-"`
+	FromDevice func(*gpb.TypedValue) (*gpb.TypedValue, error) `json:"-"`
+}
+
+// Load reads JSON-encoded Deviations from r.
+func Load(r io.Reader) (*Deviations, error) {
+	d := &Deviations{}
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(d); err != nil {
+		return nil, fmt.Errorf("cannot decode deviations: %v", err)
+	}
+	return d, nil
+}
+
+// Engine applies a set of Deviations to gNMI messages.
+type Engine struct {
+	// unsupported is the set of parsed unsupported paths.
+	unsupported []*gpb.Path
+	// renames is the set of parsed renames.
+	renames []*rename
+	// transforms is the set of parsed transforms.
+	transforms []*transform
+}
+
+// rename is a parsed Rename.
+type rename struct {
+	from, to *gpb.Path
+}
+
+// transform is a parsed Transform.
+type transform struct {
+	path *gpb.Path
+	*Transform
+	// reverse is the inverse of Transform.Map.
+	reverse map[string]string
+}
+
+// New returns an Engine that applies the deviations d.
+func New(d *Deviations) (*Engine, error) {
+	e := &Engine{}
+	var errs util.Errors
+	for _, s := range d.Unsupported {
+		p, err := util.ParseGNMIPath(s)
+		if err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("unsupported path: %v", err))
+			continue
+		}
+		e.unsupported = append(e.unsupported, p)
+	}
+	for _, r := range d.Renames {
+		from, err := util.ParseGNMIPath(r.From)
+		if err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("rename from path: %v", err))
+			continue
+		}
+		to, err := util.ParseGNMIPath(r.To)
+		if err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("rename to path: %v", err))
+			continue
+		}
+		e.renames = append(e.renames, &rename{from: from, to: to})
+	}
+	for _, t := range d.Transforms {
+		p, err := util.ParseGNMIPath(t.Path)
+		if err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("transform path: %v", err))
+			continue
+		}
+		var n int
+		if t.Map != nil {
+			n++
+		}
+		if t.Scale != 0 {
+			n++
+		}
+		if t.ToDevice != nil || t.FromDevice != nil {
+			n++
+			if t.ToDevice == nil || t.FromDevice == nil {
+				errs = util.AppendErr(errs, fmt.Errorf("transform for %s must specify both ToDevice and FromDevice", t.Path))
+				continue
+			}
+		}
+		if n != 1 {
+			errs = util.AppendErr(errs, fmt.Errorf("transform for %s must specify exactly one of map, scale, or functions", t.Path))
+			continue
+		}
+		tr := &transform{path: p, Transform: t}
+		if t.Map != nil {
+			tr.reverse = map[string]string{}
+			for k, v := range t.Map {
+				if _, ok := tr.reverse[v]; ok {
+					errs = util.AppendErr(errs, fmt.Errorf("transform for %s maps more than one value to %s", t.Path, v))
+				}
+				tr.reverse[v] = k
+			}
+		}
+		e.transforms = append(e.transforms, tr)
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return e, nil
+}
+
+// ToDevice returns a copy of the SetRequest req, generated from the intent
+// model, with the deviations applied, such that it can be sent to the
+// device. Updates to unsupported paths are removed. The paths within the
+// returned SetRequest are absolute, and its prefix specifies only the
+// origin and target of req.
+func (e *Engine) ToDevice(req *gpb.SetRequest) (*gpb.SetRequest, error) {
+	out := &gpb.SetRequest{Extension: req.GetExtension()}
+	if pfx := req.GetPrefix(); pfx.GetOrigin() != "" || pfx.GetTarget() != "" {
+		out.Prefix = &gpb.Path{Origin: pfx.GetOrigin(), Target: pfx.GetTarget()}
+	}
+	var errs util.Errors
+	for _, p := range req.GetDelete() {
+		np, ok, err := e.pathToDevice(req.GetPrefix(), p)
+		switch {
+		case err != nil:
+			errs = util.AppendErr(errs, err)
+		case ok:
+			out.Delete = append(out.Delete, np)
+		}
+	}
+	for _, u := range req.GetReplace() {
+		nu, ok, err := e.updateToDevice(req.GetPrefix(), u)
+		switch {
+		case err != nil:
+			errs = util.AppendErr(errs, err)
+		case ok:
+			out.Replace = append(out.Replace, nu)
+		}
+	}
+	for _, u := range req.GetUpdate() {
+		nu, ok, err := e.updateToDevice(req.GetPrefix(), u)
+		switch {
+		case err != nil:
+			errs = util.AppendErr(errs, err)
+		case ok:
+			out.Update = append(out.Update, nu)
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return out, nil
+}
+
+// FromDevice returns a copy of the Notification n, received from the device,
+// with the deviations reversed, such that it can be unmarshalled into the
+// intent model. Updates to unsupported paths are removed. The paths within
+// the returned Notification are absolute, and its prefix specifies only the
+// origin and target of n.
+func (e *Engine) FromDevice(n *gpb.Notification) (*gpb.Notification, error) {
+	out := &gpb.Notification{Timestamp: n.GetTimestamp(), Atomic: n.GetAtomic()}
+	if pfx := n.GetPrefix(); pfx.GetOrigin() != "" || pfx.GetTarget() != "" {
+		out.Prefix = &gpb.Path{Origin: pfx.GetOrigin(), Target: pfx.GetTarget()}
+	}
+	var errs util.Errors
+	for _, p := range n.GetDelete() {
+		np, ok, err := e.pathFromDevice(n.GetPrefix(), p)
+		switch {
+		case err != nil:
+			errs = util.AppendErr(errs, err)
+		case ok:
+			out.Delete = append(out.Delete, np)
+		}
+	}
+	for _, u := range n.GetUpdate() {
+		np, ok, err := e.pathFromDevice(n.GetPrefix(), u.GetPath())
+		if err != nil {
+			errs = util.AppendErr(errs, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		nu := proto.Clone(u).(*gpb.Update)
+		nu.Path = np
+		if t := e.findTransform(np); t != nil {
+			if nu.Val, err = t.fromDevice(u.GetVal()); err != nil {
+				errs = util.AppendErr(errs, fmt.Errorf("cannot transform value of %s: %v", pathString(np), err))
+				continue
+			}
+		}
+		out.Update = append(out.Update, nu)
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return out, nil
+}
+
+// updateToDevice applies the deviations to the update u, whose path is
+// relative to prefix. The bool return value is false if the update should
+// be removed.
+func (e *Engine) updateToDevice(prefix *gpb.Path, u *gpb.Update) (*gpb.Update, bool, error) {
+	full, err := util.JoinPaths(prefix, u.GetPath())
+	if err != nil {
+		return nil, false, err
+	}
+	np, ok, err := e.pathToDevice(nil, full)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+	nu := proto.Clone(u).(*gpb.Update)
+	nu.Path = np
+	if t := e.findTransform(full); t != nil {
+		if nu.Val, err = t.toDevice(u.GetVal()); err != nil {
+			return nil, false, fmt.Errorf("cannot transform value of %s: %v", pathString(full), err)
+		}
+	}
+	return nu, true, nil
+}
+
+// pathToDevice returns the device path corresponding to the intent model
+// path p, relative to prefix. The bool return value is false if the path is
+// unsupported.
+func (e *Engine) pathToDevice(prefix, p *gpb.Path) (*gpb.Path, bool, error) {
+	full, err := util.JoinPaths(prefix, p)
+	if err != nil {
+		return nil, false, err
+	}
+	if e.isUnsupported(full) {
+		return nil, false, nil
+	}
+	for _, r := range e.renames {
+		if np, ok := renamePath(full, r.from, r.to); ok {
+			return np, true, nil
+		}
+	}
+	return stripPrefix(full), true, nil
+}
+
+// pathFromDevice returns the intent model path corresponding to the device
+// path p, relative to prefix. The bool return value is false if the path is
+// unsupported.
+func (e *Engine) pathFromDevice(prefix, p *gpb.Path) (*gpb.Path, bool, error) {
+	full, err := util.JoinPaths(prefix, p)
+	if err != nil {
+		return nil, false, err
+	}
+	np := stripPrefix(full)
+	for _, r := range e.renames {
+		if rp, ok := renamePath(full, r.to, r.from); ok {
+			np = rp
+			break
+		}
+	}
+	if e.isUnsupported(np) {
+		return nil, false, nil
+	}
+	return np, true, nil
+}
+
+// stripPrefix returns a copy of p with its origin and target removed, since
+// these are specified by the prefix of the message that contains it.
+func stripPrefix(p *gpb.Path) *gpb.Path {
+	return &gpb.Path{Elem: p.GetElem()}
+}
+
+// isUnsupported reports whether p, or an ancestor of p, is unsupported.
+func (e *Engine) isUnsupported(p *gpb.Path) bool {
+	for _, u := range e.unsupported {
+		if util.PathMatchesQuery(&gpb.Path{Elem: p.GetElem()}, u) {
+			return true
+		}
+	}
+	return false
+}
+
+// findTransform returns the transform that applies to the leaf at the intent
+// model path p, or nil if there is none.
+func (e *Engine) findTransform(p *gpb.Path) *transform {
+	for _, t := range e.transforms {
+		if len(t.path.GetElem()) == len(p.GetElem()) && util.PathMatchesQuery(&gpb.Path{Elem: p.GetElem()}, t.path) {
+			return t
+		}
+	}
+	return nil
+}
+
+// renamePath returns p with its prefix from replaced by to. The bool return
+// value is false if from is not a prefix of p.
+func renamePath(p, from, to *gpb.Path) (*gpb.Path, bool) {
+	elems := p.GetElem()
+	if len(from.GetElem()) > len(elems) || !util.PathMatchesQuery(&gpb.Path{Elem: elems}, from) {
+		return nil, false
+	}
+	np := &gpb.Path{}
+	for i, te := range to.GetElem() {
+		ne := proto.Clone(te).(*gpb.PathElem)
+		if len(ne.Key) == 0 && i < len(from.GetElem()) && len(elems[i].GetKey()) != 0 {
+			ne.Key = map[string]string{}
+			for k, v := range elems[i].GetKey() {
+				ne.Key[k] = v
+			}
+		}
+		np.Elem = append(np.Elem, ne)
+	}
+	for _, pe := range elems[len(from.GetElem()):] {
+		np.Elem = append(np.Elem, proto.Clone(pe).(*gpb.PathElem))
+	}
+	return np, true
+}
+
+// toDevice transforms the intent model value v to that used by the device.
+func (t *transform) toDevice(v *gpb.TypedValue) (*gpb.TypedValue, error) {
+	switch {
+	case t.ToDevice != nil:
+		return t.ToDevice(v)
+	case t.Map != nil:
+		return mapValue(v, t.Map), nil
+	}
+	return scaleValue(v, t.Scale)
+}
+
+// fromDevice transforms the device value v to that used by the intent
+// model.
+func (t *transform) fromDevice(v *gpb.TypedValue) (*gpb.TypedValue, error) {
+	switch {
+	case t.FromDevice != nil:
+		return t.FromDevice(v)
+	case t.reverse != nil:
+		return mapValue(v, t.reverse), nil
+	}
+	return scaleValue(v, 1/t.Scale)
+}
+
+// mapValue returns v with its value replaced according to m, if it is a
+// string value within m.
+func mapValue(v *gpb.TypedValue, m map[string]string) *gpb.TypedValue {
+	if s, ok := v.GetValue().(*gpb.TypedValue_StringVal); ok {
+		if nv, ok := m[s.StringVal]; ok {
+			return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: nv}}
+		}
+	}
+	return v
+}
+
+// scaleValue returns v multiplied by factor. Integer values are rounded to
+// the nearest integer.
+func scaleValue(v *gpb.TypedValue, factor float64) (*gpb.TypedValue, error) {
+	switch tv := v.GetValue().(type) {
+	case *gpb.TypedValue_IntVal:
+		f := math.Round(float64(tv.IntVal) * factor)
+		if f < math.MinInt64 || f >= math.MaxInt64 {
+			return nil, fmt.Errorf("scaled value %v overflows int64", f)
+		}
+		return &gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: int64(f)}}, nil
+	case *gpb.TypedValue_UintVal:
+		f := math.Round(float64(tv.UintVal) * factor)
+		if f < 0 || f >= math.MaxUint64 {
+			return nil, fmt.Errorf("scaled value %v overflows uint64", f)
+		}
+		return &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: uint64(f)}}, nil
+	case *gpb.TypedValue_DoubleVal:
+		return &gpb.TypedValue{Value: &gpb.TypedValue_DoubleVal{DoubleVal: tv.DoubleVal * factor}}, nil
+	}
+	return nil, fmt.Errorf("cannot scale value of type %T", v.GetValue())
+}
+
+// pathString returns a human-readable representation of p for use in error
+// messages.
+func pathString(p *gpb.Path) string {
+	s, err := ygot.PathToString(p)
+	if err != nil {
+		return p.String()
+	}
+	return s
+}
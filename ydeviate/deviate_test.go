@@ -0,0 +1,224 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ydeviate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/util"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const testDeviations = `{
+  "vendor": "acme",
+  "unsupported": ["/interfaces/interface[name=*]/config/loopback-mode"],
+  "renames": [{"from": "/system/config/motd-banner", "to": "/system/config/banner"}],
+  "transforms": [
+    {"path": "/interfaces/interface[name=*]/config/mtu", "scale": 0.5},
+    {"path": "/interfaces/interface[name=*]/config/type", "map": {"ethernetCsmacd": "ETHERNET"}}
+  ]
+}`
+
+func mustPath(t *testing.T, s string) *gpb.Path {
+	t.Helper()
+	p, err := util.ParseGNMIPath(s)
+	if err != nil {
+		t.Fatalf("cannot parse path %s: %v", s, err)
+	}
+	return p
+}
+
+func mustEngine(t *testing.T) *Engine {
+	t.Helper()
+	d, err := Load(strings.NewReader(testDeviations))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	e, err := New(d)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return e
+}
+
+func strVal(s string) *gpb.TypedValue {
+	return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: s}}
+}
+
+func uintVal(u uint64) *gpb.TypedValue {
+	return &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: u}}
+}
+
+func TestToDevice(t *testing.T) {
+	e := mustEngine(t)
+	in := &gpb.SetRequest{
+		Prefix: &gpb.Path{Target: "dut", Elem: mustPath(t, "/interfaces/interface[name=eth0]").Elem},
+		Delete: []*gpb.Path{mustPath(t, "/config/description")},
+		Update: []*gpb.Update{
+			{Path: mustPath(t, "/config/mtu"), Val: uintVal(1500)},
+			{Path: mustPath(t, "/config/type"), Val: strVal("ethernetCsmacd")},
+			{Path: mustPath(t, "/config/loopback-mode"), Val: strVal("FACILITY")},
+		},
+	}
+	want := &gpb.SetRequest{
+		Prefix: &gpb.Path{Target: "dut"},
+		Delete: []*gpb.Path{mustPath(t, "/interfaces/interface[name=eth0]/config/description")},
+		Update: []*gpb.Update{
+			{Path: mustPath(t, "/interfaces/interface[name=eth0]/config/mtu"), Val: uintVal(750)},
+			{Path: mustPath(t, "/interfaces/interface[name=eth0]/config/type"), Val: strVal("ETHERNET")},
+		},
+	}
+	got, err := e.ToDevice(in)
+	if err != nil {
+		t.Fatalf("ToDevice: %v", err)
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("ToDevice (-want, +got):\n%s", diff)
+	}
+
+	renamed, err := e.ToDevice(&gpb.SetRequest{Replace: []*gpb.Update{{Path: mustPath(t, "/system/config/motd-banner"), Val: strVal("hello")}}})
+	if err != nil {
+		t.Fatalf("ToDevice: %v", err)
+	}
+	if diff := cmp.Diff(&gpb.SetRequest{Replace: []*gpb.Update{{Path: mustPath(t, "/system/config/banner"), Val: strVal("hello")}}}, renamed, protocmp.Transform()); diff != "" {
+		t.Errorf("ToDevice rename (-want, +got):\n%s", diff)
+	}
+}
+
+func TestFromDevice(t *testing.T) {
+	e := mustEngine(t)
+	in := &gpb.Notification{
+		Timestamp: 42,
+		Prefix:    &gpb.Path{Target: "dut"},
+		Update: []*gpb.Update{
+			{Path: mustPath(t, "/interfaces/interface[name=eth0]/config/mtu"), Val: uintVal(750)},
+			{Path: mustPath(t, "/interfaces/interface[name=eth0]/config/type"), Val: strVal("ETHERNET")},
+			{Path: mustPath(t, "/interfaces/interface[name=eth0]/config/loopback-mode"), Val: strVal("NONE")},
+			{Path: mustPath(t, "/system/config/banner"), Val: strVal("hello")},
+		},
+		Delete: []*gpb.Path{mustPath(t, "/interfaces/interface[name=eth1]")},
+	}
+	want := &gpb.Notification{
+		Timestamp: 42,
+		Prefix:    &gpb.Path{Target: "dut"},
+		Update: []*gpb.Update{
+			{Path: mustPath(t, "/interfaces/interface[name=eth0]/config/mtu"), Val: uintVal(1500)},
+			{Path: mustPath(t, "/interfaces/interface[name=eth0]/config/type"), Val: strVal("ethernetCsmacd")},
+			{Path: mustPath(t, "/system/config/motd-banner"), Val: strVal("hello")},
+		},
+		Delete: []*gpb.Path{mustPath(t, "/interfaces/interface[name=eth1]")},
+	}
+	got, err := e.FromDevice(in)
+	if err != nil {
+		t.Fatalf("FromDevice: %v", err)
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("FromDevice (-want, +got):\n%s", diff)
+	}
+}
+
+func TestRenameKeys(t *testing.T) {
+	e, err := New(&Deviations{
+		Renames: []*Rename{{From: "/interfaces/interface[name=*]/config", To: "/ports/port/settings"}},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	got, err := e.ToDevice(&gpb.SetRequest{Update: []*gpb.Update{{Path: mustPath(t, "/interfaces/interface[name=eth0]/config/mtu"), Val: uintVal(1500)}}})
+	if err != nil {
+		t.Fatalf("ToDevice: %v", err)
+	}
+	if diff := cmp.Diff(mustPath(t, "/ports/port[name=eth0]/settings/mtu"), got.GetUpdate()[0].GetPath(), protocmp.Transform()); diff != "" {
+		t.Errorf("ToDevice path (-want, +got):\n%s", diff)
+	}
+}
+
+func TestFuncTransform(t *testing.T) {
+	e, err := New(&Deviations{
+		Transforms: []*Transform{{
+			Path: "/system/config/hostname",
+			ToDevice: func(v *gpb.TypedValue) (*gpb.TypedValue, error) {
+				return strVal(strings.ToUpper(v.GetStringVal())), nil
+			},
+			FromDevice: func(v *gpb.TypedValue) (*gpb.TypedValue, error) {
+				return strVal(strings.ToLower(v.GetStringVal())), nil
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	req, err := e.ToDevice(&gpb.SetRequest{Update: []*gpb.Update{{Path: mustPath(t, "/system/config/hostname"), Val: strVal("dut")}}})
+	if err != nil {
+		t.Fatalf("ToDevice: %v", err)
+	}
+	if got := req.GetUpdate()[0].GetVal().GetStringVal(); got != "DUT" {
+		t.Errorf("ToDevice value: got %s, want DUT", got)
+	}
+	n, err := e.FromDevice(&gpb.Notification{Update: req.GetUpdate()})
+	if err != nil {
+		t.Fatalf("FromDevice: %v", err)
+	}
+	if got := n.GetUpdate()[0].GetVal().GetStringVal(); got != "dut" {
+		t.Errorf("FromDevice value: got %s, want dut", got)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               *Deviations
+		wantErrSubstring string
+	}{{
+		desc:             "invalid unsupported path",
+		in:               &Deviations{Unsupported: []string{"/a[b"}},
+		wantErrSubstring: "unsupported path",
+	}, {
+		desc:             "transform with map and scale",
+		in:               &Deviations{Transforms: []*Transform{{Path: "/a", Scale: 2, Map: map[string]string{"x": "y"}}}},
+		wantErrSubstring: "exactly one of",
+	}, {
+		desc:             "transform with only one function",
+		in:               &Deviations{Transforms: []*Transform{{Path: "/a", ToDevice: func(v *gpb.TypedValue) (*gpb.TypedValue, error) { return v, nil }}}},
+		wantErrSubstring: "both ToDevice and FromDevice",
+	}, {
+		desc:             "non-invertible map",
+		in:               &Deviations{Transforms: []*Transform{{Path: "/a", Map: map[string]string{"x": "z", "y": "z"}}}},
+		wantErrSubstring: "more than one value",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := New(tt.in)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("New: %s", diff)
+			}
+		})
+	}
+
+	if _, err := Load(strings.NewReader(`{"vendor": "acme", "bogus": true}`)); err == nil {
+		t.Errorf("Load with unknown field: got nil error, want error")
+	}
+
+	e := mustEngine(t)
+	_, err := e.ToDevice(&gpb.SetRequest{Update: []*gpb.Update{{Path: mustPath(t, "/interfaces/interface[name=eth0]/config/mtu"), Val: strVal("big")}}})
+	if diff := errdiff.Substring(err, "cannot scale"); diff != "" {
+		t.Errorf("ToDevice with non-numeric scaled value: %s", diff)
+	}
+}
@@ -0,0 +1,575 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package yfuzz implements schema-aware fuzzing of ygot-generated data trees
+// and of the operations on them. A Generator produces random data trees that
+// are valid against a schema, near-valid data trees that violate a single
+// constraint of the schema, their RFC7951 JSON instance documents, and random
+// sequences of gNMI SetRequests.
+//
+// The generated inputs can be used for property-based testing, both of the
+// ytypes library itself, for which the CheckRoundTrip and CheckSetDelete
+// properties are provided, and of applications that consume ygot-generated
+// code. Generators are deterministic for a given seed, such that a failure can
+// be reproduced, and can be used to seed native Go fuzz tests.
+package yfuzz
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Opt is an option that can be supplied to New.
+type Opt interface {
+	// IsFuzzOpt is a marker method for each Opt.
+	IsFuzzOpt()
+}
+
+// MaxListEntries is an Opt that specifies the maximum number of entries
+// that are generated for each list. The default is 3.
+type MaxListEntries int
+
+// IsFuzzOpt implements the Opt interface.
+func (MaxListEntries) IsFuzzOpt() {}
+
+// MaxDepth is an Opt that specifies the maximum depth of lists and
+// containers beneath the root that are generated. The default is 8.
+type MaxDepth int
+
+// IsFuzzOpt implements the Opt interface.
+func (MaxDepth) IsFuzzOpt() {}
+
+// ConfigOnly is an Opt that specifies that only configuration (config true)
+// nodes are generated.
+type ConfigOnly struct{}
+
+// IsFuzzOpt implements the Opt interface.
+func (*ConfigOnly) IsFuzzOpt() {}
+
+// Generator generates random data trees and operations for a schema. A
+// Generator is not safe for concurrent use.
+type Generator struct {
+	// schema is the schema that data trees are generated for.
+	schema *ytypes.Schema
+	// rand is the source of randomness.
+	rand *rand.Rand
+	// maxEntries is the maximum number of entries of each list.
+	maxEntries int
+	// maxDepth is the maximum depth of generated nodes.
+	maxDepth int
+	// configOnly indicates that only config true nodes are generated.
+	configOnly bool
+}
+
+// New returns a Generator for the schema, whose randomness is derived from
+// seed. The schema root and schema tree must be populated.
+func New(schema *ytypes.Schema, seed int64, opts ...Opt) (*Generator, error) {
+	if schema == nil || schema.Root == nil || schema.RootSchema() == nil {
+		return nil, fmt.Errorf("invalid schema: root and its schema must be populated")
+	}
+	g := &Generator{
+		schema:     schema,
+		rand:       rand.New(rand.NewSource(seed)),
+		maxEntries: 3,
+		maxDepth:   8,
+	}
+	for _, o := range opts {
+		switch v := o.(type) {
+		case MaxListEntries:
+			g.maxEntries = int(v)
+		case MaxDepth:
+			g.maxDepth = int(v)
+		case *ConfigOnly:
+			g.configOnly = true
+		}
+	}
+	return g, nil
+}
+
+// Updates returns a random set of updates to the leaves and leaf-lists of a
+// data tree of the schema, whose paths are absolute and whose values are
+// valid for the types of the leaves. List keys that are leafrefs are set
+// consistently with the leaf that they reference, other leafrefs are not
+// generated. Leaves that cannot be represented by the GoStructs of the
+// schema, such as those that are removed by path compression, are not
+// generated.
+func (g *Generator) Updates() ([]*gpb.Update, error) {
+	w, err := g.walk()
+	if err != nil {
+		return nil, err
+	}
+	return w.updates, nil
+}
+
+// GoStruct returns a new random data tree of the schema, which is that
+// formed by applying the updates returned by Updates to an empty root.
+func (g *Generator) GoStruct() (ygot.GoStruct, error) {
+	w, err := g.walk()
+	if err != nil {
+		return nil, err
+	}
+	return w.root, nil
+}
+
+// Document returns the RFC7951 JSON instance document of a new random data
+// tree of the schema.
+func (g *Generator) Document() (string, error) {
+	gs, err := g.GoStruct()
+	if err != nil {
+		return "", err
+	}
+	return ygot.EmitJSON(gs, &ygot.EmitJSONConfig{Format: ygot.RFC7951, SkipValidation: true})
+}
+
+// walk holds the state of the generation of a set of updates.
+type walk struct {
+	// g is the Generator performing the walk.
+	g *Generator
+	// root is the data tree that the generated updates are applied to.
+	root ygot.GoStruct
+	// updates is the set of generated updates.
+	updates []*gpb.Update
+	// set is the set of the string forms of the paths that have been
+	// generated.
+	set map[string]bool
+}
+
+// walk generates a random set of updates from the root of the schema.
+func (g *Generator) walk() (*walk, error) {
+	w := &walk{g: g, root: g.schema.NewRoot(), set: map[string]bool{}}
+	if err := w.dir(g.schema.RootSchema(), nil, 0); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// add applies an update of path to val to the data tree of the walk, and
+// appends it to the generated updates, unless path has already been set or
+// is not represented by the GoStructs of the schema.
+func (w *walk) add(elems []*gpb.PathElem, val *gpb.TypedValue) error {
+	p := &gpb.Path{Elem: append([]*gpb.PathElem{}, elems...)}
+	k, err := ygot.PathToString(p)
+	if err != nil {
+		return err
+	}
+	if w.set[k] {
+		return nil
+	}
+	w.set[k] = true
+	rs := w.g.schema.RootSchema()
+	switch err := ytypes.SetNode(rs, w.root, p, val, &ytypes.InitMissingElements{}); {
+	case errors.Is(err, util.ErrPathNotFound):
+		return nil
+	case err != nil:
+		return fmt.Errorf("cannot set generated value %v at %s: %v", val, k, err)
+	}
+	// Values of shadow paths are accepted by SetNode, but are not stored.
+	if nodes, err := ytypes.GetNode(rs, w.root, p); err != nil || len(nodes) != 1 || util.IsValueNil(nodes[0].Data) {
+		return nil
+	}
+	w.updates = append(w.updates, &gpb.Update{Path: p, Val: val})
+	return nil
+}
+
+// dir generates the descendants of the directory entry e, whose data tree
+// path is elems, at depth.
+func (w *walk) dir(e *yang.Entry, elems []*gpb.PathElem, depth int) error {
+	g := w.g
+	children := util.Children(e)
+	sort.Slice(children, func(i, j int) bool { return children[i].Name < children[j].Name })
+	for _, ch := range children {
+		if g.configOnly && !util.IsConfig(ch) {
+			continue
+		}
+		chElems := append(append([]*gpb.PathElem{}, elems...), &gpb.PathElem{Name: ch.Name})
+		switch {
+		case ch.IsChoice():
+			cases := util.Children(ch)
+			if len(cases) == 0 {
+				continue
+			}
+			sort.Slice(cases, func(i, j int) bool { return cases[i].Name < cases[j].Name })
+			c := cases[g.rand.Intn(len(cases))]
+			if c.IsCase() {
+				if err := w.dir(c, elems, depth); err != nil {
+					return err
+				}
+				continue
+			}
+			// A shorthand case consisting of a single node.
+			if err := w.dir(&yang.Entry{Dir: map[string]*yang.Entry{c.Name: c}}, elems, depth); err != nil {
+				return err
+			}
+		case ch.IsLeaf():
+			if ch.Type == nil || ch.Type.Kind == yang.Yleafref || g.rand.Intn(10) >= 6 {
+				continue
+			}
+			if v, _, ok := g.value(ch, ch.Type); ok {
+				if err := w.add(chElems, v); err != nil {
+					return err
+				}
+			}
+		case ch.IsLeafList():
+			if ch.Type == nil || ch.Type.Kind == yang.Yleafref || g.rand.Intn(10) >= 4 {
+				continue
+			}
+			var vals []*gpb.TypedValue
+			seen := map[string]bool{}
+			for i := g.rand.Intn(3); i >= 0; i-- {
+				if v, ks, ok := g.value(ch, ch.Type); ok && !seen[ks] {
+					seen[ks] = true
+					vals = append(vals, v)
+				}
+			}
+			if len(vals) != 0 {
+				if err := w.add(chElems, &gpb.TypedValue{Value: &gpb.TypedValue_LeaflistVal{LeaflistVal: &gpb.ScalarArray{Element: vals}}}); err != nil {
+					return err
+				}
+			}
+		case ch.IsList():
+			if !util.IsKeyedList(ch) || depth >= g.maxDepth {
+				continue
+			}
+			for i := g.rand.Intn(g.maxEntries + 1); i > 0; i-- {
+				if err := w.listEntry(ch, chElems, depth); err != nil {
+					return err
+				}
+			}
+		case ch.IsContainer():
+			if depth >= g.maxDepth || g.rand.Intn(10) >= 7 {
+				continue
+			}
+			if err := w.dir(ch, chElems, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// listEntry generates an entry of the keyed list e, whose data tree path is
+// elems, with random keys.
+func (w *walk) listEntry(e *yang.Entry, elems []*gpb.PathElem, depth int) error {
+	keys := map[string]string{}
+	vals := map[string]*gpb.TypedValue{}
+	for _, k := range strings.Fields(e.Key) {
+		ke := e.Dir[k]
+		if ke == nil || ke.Type == nil {
+			return fmt.Errorf("cannot find schema of key %s of list %s", k, e.Name)
+		}
+		if !generatableKey(e, ke) {
+			return nil
+		}
+		v, ks, ok := w.g.value(ke, ke.Type)
+		if !ok {
+			// The key cannot be generated, so no entries are.
+			return nil
+		}
+		keys[k], vals[k] = ks, v
+	}
+	entry := append([]*gpb.PathElem{}, elems...)
+	entry[len(entry)-1] = &gpb.PathElem{Name: e.Name, Key: keys}
+	for _, k := range strings.Fields(e.Key) {
+		v := vals[k]
+		kElems := append(append([]*gpb.PathElem{}, entry...), &gpb.PathElem{Name: k})
+		if err := w.add(kElems, v); err != nil {
+			return err
+		}
+		// Where the key is a leafref, the referenced leaf is set to the
+		// same value, as is the convention for OpenConfig lists.
+		if t := e.Dir[k].Type; t.Kind == yang.Yleafref && !strings.HasPrefix(t.Path, "/") {
+			if target := relativePath(kElems, t.Path); target != nil {
+				if err := w.add(target, v); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return w.dir(e, entry, depth+1)
+}
+
+// generatableKey reports whether entries of the list e can be generated
+// with random values of its key ke. This is not the case where the key
+// refers, through a chain of leafrefs, to a leaf outside of the list entry,
+// since the referenced leaf would need to be populated, or where the key is
+// a union, since the type of the union member cannot be inferred from the
+// keys within a path.
+func generatableKey(e, ke *yang.Entry) bool {
+	for t := ke; ; {
+		switch t.Type.Kind {
+		case yang.Yunion:
+			return false
+		case yang.Yleafref:
+			target := t.Find(t.Type.Path)
+			if target == nil || target == t || target.Type == nil || !isDescendant(target, e) {
+				return false
+			}
+			t = target
+		default:
+			return true
+		}
+	}
+}
+
+// isDescendant reports whether the schema entry e is a descendant of
+// ancestor.
+func isDescendant(e, ancestor *yang.Entry) bool {
+	for n := e.Parent; n != nil; n = n.Parent {
+		if n == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// relativePath returns the path formed by resolving the relative schema
+// path rel against elems, or nil if rel cannot be resolved.
+func relativePath(elems []*gpb.PathElem, rel string) []*gpb.PathElem {
+	out := append([]*gpb.PathElem{}, elems...)
+	for _, c := range strings.Split(rel, "/") {
+		switch {
+		case c == "..":
+			if len(out) == 0 {
+				return nil
+			}
+			out = out[:len(out)-1]
+		case c == "" || c == ".":
+		case strings.Contains(c, "["):
+			// Predicates within the path are not supported.
+			return nil
+		default:
+			if _, n, ok := strings.Cut(c, ":"); ok {
+				c = n
+			}
+			out = append(out, &gpb.PathElem{Name: c})
+		}
+	}
+	return out
+}
+
+// value returns a random value of type t for the leaf e, along with its
+// string form as used within path keys. It returns false if no value can be
+// generated for the type.
+func (g *Generator) value(e *yang.Entry, t *yang.YangType) (*gpb.TypedValue, string, bool) {
+	switch t.Kind {
+	case yang.Ystring:
+		s, ok := g.stringValue(t)
+		if !ok {
+			return nil, "", false
+		}
+		return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: s}}, s, true
+	case yang.Ybool:
+		b := g.rand.Intn(2) == 0
+		return &gpb.TypedValue{Value: &gpb.TypedValue_BoolVal{BoolVal: b}}, strconv.FormatBool(b), true
+	case yang.Yempty:
+		return &gpb.TypedValue{Value: &gpb.TypedValue_BoolVal{BoolVal: true}}, "true", true
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yint64:
+		n := g.number(t.Range, builtinRange(t.Kind), false)
+		i, err := n.Int()
+		if err != nil {
+			return nil, "", false
+		}
+		return &gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: i}}, strconv.FormatInt(i, 10), true
+	case yang.Yuint8, yang.Yuint16, yang.Yuint32, yang.Yuint64:
+		n := g.number(t.Range, builtinRange(t.Kind), false)
+		return &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: n.Value}}, strconv.FormatUint(n.Value, 10), true
+	case yang.Ydecimal64:
+		// Decimal values are limited to those that are exactly
+		// represented by a float64.
+		n := g.number(t.Range, nil, true)
+		f, err := strconv.ParseFloat(n.String(), 64)
+		if err != nil {
+			return nil, "", false
+		}
+		return &gpb.TypedValue{Value: &gpb.TypedValue_DoubleVal{DoubleVal: f}}, n.String(), true
+	case yang.Yenum:
+		names := t.Enum.Names()
+		if len(names) == 0 {
+			return nil, "", false
+		}
+		sort.Strings(names)
+		s := names[g.rand.Intn(len(names))]
+		return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: s}}, s, true
+	case yang.Yidentityref:
+		if t.IdentityBase == nil || len(t.IdentityBase.Values) == 0 {
+			return nil, "", false
+		}
+		s := t.IdentityBase.Values[g.rand.Intn(len(t.IdentityBase.Values))].Name
+		return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: s}}, s, true
+	case yang.Ybinary:
+		b := make([]byte, 1+g.rand.Intn(8))
+		g.rand.Read(b)
+		return &gpb.TypedValue{Value: &gpb.TypedValue_BytesVal{BytesVal: b}}, string(b), true
+	case yang.Yunion:
+		if len(t.Type) == 0 {
+			return nil, "", false
+		}
+		var hasOther bool
+		// The JSON encodings of the members of a union overlap, such that
+		// values are only generated for the members that unmarshalling
+		// would resolve them to: the enumerated members, which are tried
+		// first, and the first of the other members.
+		var members []*yang.YangType
+		for _, m := range t.Type {
+			switch {
+			case m.Kind == yang.Yenum || m.Kind == yang.Yidentityref:
+				members = append(members, m)
+			case !hasOther:
+				members, hasOther = append(members, m), true
+			}
+		}
+		for _, i := range g.rand.Perm(len(members)) {
+			if v, s, ok := g.value(e, members[i]); ok {
+				return v, s, true
+			}
+		}
+	case yang.Yleafref:
+		if target := e.Find(t.Path); target != nil && target.Type != nil && target != e {
+			return g.value(target, target.Type)
+		}
+	}
+	return nil, "", false
+}
+
+// builtinRange returns the range of the built-in integer type kind.
+func builtinRange(kind yang.TypeKind) yang.YangRange {
+	switch kind {
+	case yang.Yint8:
+		return yang.Int8Range
+	case yang.Yint16:
+		return yang.Int16Range
+	case yang.Yint32:
+		return yang.Int32Range
+	case yang.Yint64:
+		return yang.Int64Range
+	case yang.Yuint8:
+		return yang.Uint8Range
+	case yang.Yuint16:
+		return yang.Uint16Range
+	case yang.Yuint32:
+		return yang.Uint32Range
+	case yang.Yuint64:
+		return yang.Uint64Range
+	}
+	return nil
+}
+
+// number returns a random number within a random sub-range of r, or of def
+// if r is empty. Where neither is populated, a number between 0 and 100 is
+// returned. If small is set, the number is always close to zero, or to the
+// lower bound of the sub-range if it does not contain zero.
+func (g *Generator) number(r, def yang.YangRange, small bool) yang.Number {
+	if len(r) == 0 {
+		r = def
+	}
+	if len(r) == 0 {
+		return yang.FromInt(int64(g.rand.Intn(101)))
+	}
+	yr := r[g.rand.Intn(len(r))]
+	lo, hi := yr.Min, yr.Max
+	fd := max(lo.FractionDigits, hi.FractionDigits)
+	// Values close to zero are preferred, such that generated data is
+	// legible.
+	span, near := g.rand.Uint64(), small || g.rand.Intn(4) != 0
+	if near {
+		span %= 1000
+	}
+	switch {
+	case !lo.Negative:
+		if d := hi.Value - lo.Value; d != math.MaxUint64 {
+			span %= d + 1
+		}
+		return yang.Number{Value: lo.Value + span, FractionDigits: fd}
+	default:
+		// The bounds of signed ranges fit within an int64.
+		l, h := signed(lo), signed(hi)
+		if near && h >= 0 {
+			l = max(l, -500)
+		}
+		if d := uint64(h - l); d != math.MaxUint64 {
+			span %= d + 1
+		}
+		v := l + int64(span)
+		if v < 0 {
+			return yang.Number{Value: uint64(-v), Negative: true, FractionDigits: fd}
+		}
+		return yang.Number{Value: uint64(v), FractionDigits: fd}
+	}
+}
+
+// signed returns the value of n, ignoring any fraction digits, as an int64.
+func signed(n yang.Number) int64 {
+	if n.Negative {
+		return -int64(n.Value)
+	}
+	return int64(n.Value)
+}
+
+// stringValue returns a random string that satisfies the length and pattern
+// restrictions of the string type t. It returns false if no such string is
+// found.
+func (g *Generator) stringValue(t *yang.YangType) (string, bool) {
+	minLen, maxLen := uint64(1), uint64(8)
+	if len(t.Length) != 0 {
+		l := t.Length[g.rand.Intn(len(t.Length))]
+		minLen, maxLen = l.Min.Value, min(l.Max.Value, l.Min.Value+8)
+	}
+	var patterns []*regexp.Regexp
+	for _, p := range t.POSIXPattern {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return "", false
+		}
+		patterns = append(patterns, re)
+	}
+	if len(patterns) == 0 && len(t.Pattern) != 0 {
+		// Only POSIX patterns can be checked, so strings with only XSD
+		// patterns are not generated.
+		return "", false
+	}
+
+	const alphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+	for attempt := 0; attempt < 32; attempt++ {
+		n := minLen + uint64(g.rand.Int63n(int64(maxLen-minLen+1)))
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = alphabet[g.rand.Intn(len(alphabet))]
+		}
+		s := string(b)
+		ok := true
+		for _, re := range patterns {
+			if !re.MatchString(s) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return s, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yfuzz
+
+import (
+	"testing"
+
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+)
+
+func exampleSchema() *ytypes.Schema {
+	return &ytypes.Schema{
+		Root:       &exampleoc.Device{},
+		SchemaTree: exampleoc.SchemaTree,
+		Unmarshal:  exampleoc.Unmarshal,
+	}
+}
+
+func TestGoStruct(t *testing.T) {
+	for seed := int64(0); seed < 5; seed++ {
+		g, err := New(exampleSchema(), seed, &ConfigOnly{}, MaxListEntries(2))
+		if err != nil {
+			t.Fatalf("New: got unexpected error: %v", err)
+		}
+		gs, err := g.GoStruct()
+		if err != nil {
+			t.Fatalf("seed %d: GoStruct: got unexpected error: %v", seed, err)
+		}
+		if err := gs.(*exampleoc.Device).Validate(); err != nil {
+			t.Errorf("seed %d: generated data tree is not valid: %v", seed, err)
+		}
+		if err := CheckRoundTrip(exampleSchema(), gs); err != nil {
+			t.Errorf("seed %d: CheckRoundTrip: %v", seed, err)
+		}
+		if err := CheckSetDelete(exampleSchema(), gs); err != nil {
+			t.Errorf("seed %d: CheckSetDelete: %v", seed, err)
+		}
+	}
+}
+
+func TestDeterminism(t *testing.T) {
+	docs := make([]string, 2)
+	for i := range docs {
+		g, err := New(exampleSchema(), 42)
+		if err != nil {
+			t.Fatalf("New: got unexpected error: %v", err)
+		}
+		if docs[i], err = g.Document(); err != nil {
+			t.Fatalf("Document: got unexpected error: %v", err)
+		}
+	}
+	if docs[0] != docs[1] {
+		t.Errorf("Document: got different documents for the same seed:\n%s\n%s", docs[0], docs[1])
+	}
+	if docs[0] == "{}" {
+		t.Errorf("Document: got empty document")
+	}
+}
+
+func TestNearValid(t *testing.T) {
+	var n int
+	for seed := int64(0); seed < 5; seed++ {
+		g, err := New(exampleSchema(), seed, &ConfigOnly{}, MaxListEntries(2))
+		if err != nil {
+			t.Fatalf("New: got unexpected error: %v", err)
+		}
+		gs, p, err := g.NearValid()
+		if err != nil {
+			// Not every generated data tree has a restricted leaf.
+			continue
+		}
+		n++
+		if err := gs.(*exampleoc.Device).Validate(); err == nil {
+			ps, _ := ygot.PathToString(p)
+			t.Errorf("seed %d: near-valid data tree with invalid leaf %s is valid", seed, ps)
+		}
+	}
+	if n == 0 {
+		t.Errorf("NearValid: got no near-valid data trees")
+	}
+}
+
+func TestSetRequests(t *testing.T) {
+	g, err := New(exampleSchema(), 7, &ConfigOnly{}, MaxListEntries(2))
+	if err != nil {
+		t.Fatalf("New: got unexpected error: %v", err)
+	}
+	reqs, err := g.SetRequests(10)
+	if err != nil {
+		t.Fatalf("SetRequests: got unexpected error: %v", err)
+	}
+	if len(reqs) != 10 {
+		t.Fatalf("SetRequests: got %d requests, want 10", len(reqs))
+	}
+	var deletes int
+	root := &exampleoc.Device{}
+	for i, req := range reqs {
+		deletes += len(req.GetDelete())
+		if err := Apply(exampleSchema(), root, req); err != nil {
+			t.Fatalf("Apply of request %d: got unexpected error: %v", i, err)
+		}
+	}
+	if deletes == 0 {
+		t.Errorf("SetRequests: got no deletes within %d requests", len(reqs))
+	}
+	if err := root.Validate(); err != nil {
+		t.Errorf("data tree resulting from SetRequests is not valid: %v", err)
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yfuzz
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// NearValid returns a new random data tree of the schema in which the value
+// of a single leaf violates a range, length or pattern restriction of its
+// type, along with the path of that leaf. Such data trees can be represented
+// by GoStructs, but are rejected by validation. An error is returned if no
+// generated leaf has a type with such restrictions.
+func (g *Generator) NearValid() (ygot.GoStruct, *gpb.Path, error) {
+	w, err := g.walk()
+	if err != nil {
+		return nil, nil, err
+	}
+	root, updates := w.root, w.updates
+	for _, i := range g.rand.Perm(len(updates)) {
+		p := updates[i].GetPath()
+		nodes, err := ytypes.GetNode(g.schema.RootSchema(), root, p)
+		if err != nil || len(nodes) != 1 {
+			continue
+		}
+		if invalidate(nodes[0].Schema, nodes[0].Data) {
+			return root, p, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("no generated leaf has a type with range, length or pattern restrictions")
+}
+
+// NearValidDocument returns the RFC7951 JSON instance document of a data tree
+// returned by NearValid, along with the path of its invalid leaf.
+func (g *Generator) NearValidDocument() (string, *gpb.Path, error) {
+	gs, p, err := g.NearValid()
+	if err != nil {
+		return "", nil, err
+	}
+	j, err := ygot.EmitJSON(gs, &ygot.EmitJSONConfig{Format: ygot.RFC7951, SkipValidation: true})
+	if err != nil {
+		return "", nil, err
+	}
+	return j, p, nil
+}
+
+// invalidate sets the leaf value data, which is a pointer to the field of a
+// GoStruct, to a value that violates a restriction of the type of the leaf
+// with schema e. It returns false if the value cannot be invalidated.
+func invalidate(e *yang.Entry, data interface{}) bool {
+	v := reflect.ValueOf(data)
+	if e == nil || e.Type == nil || !e.IsLeaf() || v.Kind() != reflect.Ptr || v.IsNil() {
+		return false
+	}
+	t, f := e.Type, v.Elem()
+	switch f.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if _, isEnum := data.(ygot.GoEnum); isEnum || len(t.Range) == 0 {
+			return false
+		}
+		lo, hi := signed(t.Range[0].Min), signed(t.Range[len(t.Range)-1].Max)
+		switch {
+		case hi < math.MaxInt64 && !f.OverflowInt(hi+1):
+			f.SetInt(hi + 1)
+		case lo > math.MinInt64 && !f.OverflowInt(lo-1):
+			f.SetInt(lo - 1)
+		default:
+			return false
+		}
+		return true
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if len(t.Range) == 0 {
+			return false
+		}
+		lo, hi := t.Range[0].Min.Value, t.Range[len(t.Range)-1].Max.Value
+		switch {
+		case hi < math.MaxUint64 && !f.OverflowUint(hi+1):
+			f.SetUint(hi + 1)
+		case lo > 0:
+			f.SetUint(lo - 1)
+		default:
+			return false
+		}
+		return true
+	case reflect.String:
+		if t.Kind != yang.Ystring {
+			return false
+		}
+		for _, p := range t.POSIXPattern {
+			re, err := regexp.Compile(p)
+			if err != nil {
+				return false
+			}
+			for _, s := range []string{"", "!", " invalid ", strings.Repeat("x", 256)} {
+				if !re.MatchString(s) {
+					f.SetString(s)
+					return true
+				}
+			}
+		}
+		if len(t.Length) != 0 {
+			lo, hi := t.Length[0].Min.Value, t.Length[len(t.Length)-1].Max.Value
+			switch {
+			case hi < 1024:
+				f.SetString(strings.Repeat("x", int(hi)+1))
+				return true
+			case lo > 0:
+				f.SetString(strings.Repeat("x", int(lo)-1))
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SetRequests returns a sequence of n random SetRequests that are applied to
+// an initially empty data tree of the schema. Each SetRequest contains
+// updates and replaces of leaves with valid values, and deletes of leaves
+// and list entries that exist within the data tree that results from the
+// preceding SetRequests. The paths within the SetRequests are absolute.
+func (g *Generator) SetRequests(n int) ([]*gpb.SetRequest, error) {
+	root := g.schema.NewRoot()
+	var reqs []*gpb.SetRequest
+	for i := 0; i < n; i++ {
+		req := &gpb.SetRequest{}
+		existing, err := leafUpdates(root)
+		if err != nil {
+			return nil, err
+		}
+		for j := g.rand.Intn(3); j > 0 && len(existing) != 0; j-- {
+			p := existing[g.rand.Intn(len(existing))].GetPath()
+			if isListKey(p) || g.rand.Intn(2) == 0 {
+				// Delete the innermost list entry containing the leaf.
+				for k := len(p.GetElem()) - 1; k >= 0; k-- {
+					if len(p.GetElem()[k].GetKey()) != 0 {
+						p = &gpb.Path{Elem: p.GetElem()[:k+1]}
+						break
+					}
+				}
+			}
+			req.Delete = append(req.Delete, p)
+		}
+		updates, err := g.Updates()
+		if err != nil {
+			return nil, err
+		}
+		for _, j := range g.rand.Perm(len(updates))[:min(len(updates), 1+g.rand.Intn(5))] {
+			if g.rand.Intn(4) == 0 {
+				req.Replace = append(req.Replace, updates[j])
+			} else {
+				req.Update = append(req.Update, updates[j])
+			}
+		}
+		if err := Apply(g.schema, root, req); err != nil {
+			return nil, fmt.Errorf("cannot apply generated SetRequest: %v", err)
+		}
+		reqs = append(reqs, req)
+	}
+	return reqs, nil
+}
+
+// Apply applies the SetRequest req, whose paths are absolute, to the data
+// tree root of the schema, processing deletes, then replaces, then updates,
+// as per the gNMI specification. Replaces are only supported for leaves.
+func Apply(schema *ytypes.Schema, root ygot.GoStruct, req *gpb.SetRequest) error {
+	rs := schema.RootSchema()
+	var errs util.Errors
+	for _, d := range req.GetDelete() {
+		errs = util.AppendErr(errs, ytypes.DeleteNode(rs, root, d))
+	}
+	for _, u := range append(append([]*gpb.Update{}, req.GetReplace()...), req.GetUpdate()...) {
+		errs = util.AppendErr(errs, ytypes.SetNode(rs, root, u.GetPath(), u.GetVal(), &ytypes.InitMissingElements{}))
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// leafUpdates returns the updates of the leaves that are set within gs, with
+// absolute paths, sorted by the string form of their path.
+func leafUpdates(gs ygot.GoStruct) ([]*gpb.Update, error) {
+	notifs, err := ygot.TogNMINotifications(gs, 0, ygot.GNMINotificationsConfig{UsePathElem: true})
+	if err != nil {
+		return nil, err
+	}
+	byKey := map[string]*gpb.Update{}
+	for _, n := range notifs {
+		for _, u := range n.GetUpdate() {
+			p, err := util.JoinPaths(n.GetPrefix(), u.GetPath())
+			if err != nil {
+				return nil, err
+			}
+			k, err := ygot.PathToString(p)
+			if err != nil {
+				return nil, err
+			}
+			byKey[k] = &gpb.Update{Path: p, Val: u.GetVal()}
+		}
+	}
+	keys := make([]string, 0, len(byKey))
+	for k := range byKey {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	updates := make([]*gpb.Update, 0, len(keys))
+	for _, k := range keys {
+		updates = append(updates, byKey[k])
+	}
+	return updates, nil
+}
+
+// isListKey reports whether the leaf at path p is a key of the innermost
+// list entry that contains it, or the leaf that such a key references, as
+// is the convention for OpenConfig lists, e.g., /a/b[name=x]/config/name.
+func isListKey(p *gpb.Path) bool {
+	elems := p.GetElem()
+	if len(elems) == 0 {
+		return false
+	}
+	name := elems[len(elems)-1].GetName()
+	for i := len(elems) - 2; i >= 0; i-- {
+		if keys := elems[i].GetKey(); len(keys) != 0 {
+			_, ok := keys[name]
+			return ok
+		}
+	}
+	return false
+}
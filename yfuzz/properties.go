@@ -0,0 +1,134 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yfuzz
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+	"google.golang.org/protobuf/proto"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// CheckRoundTrip checks that the data tree gs of the schema is unchanged by
+// emitting it as RFC7951 JSON and unmarshalling the JSON into a new root,
+// and that the JSON emitted for the new root is identical. An error that
+// describes the difference is returned if it is not.
+func CheckRoundTrip(schema *ytypes.Schema, gs ygot.GoStruct) error {
+	cfg := &ygot.EmitJSONConfig{Format: ygot.RFC7951, SkipValidation: true}
+	j, err := ygot.EmitJSON(gs, cfg)
+	if err != nil {
+		return fmt.Errorf("cannot emit JSON: %v", err)
+	}
+	if schema.Unmarshal == nil {
+		return fmt.Errorf("schema does not have an Unmarshal function")
+	}
+	nr := schema.NewRoot()
+	if err := schema.Unmarshal([]byte(j), nr); err != nil {
+		return fmt.Errorf("cannot unmarshal emitted JSON: %v\n%s", err, j)
+	}
+	n, err := ygot.Diff(gs, nr)
+	if err != nil {
+		return fmt.Errorf("cannot compare data trees: %v", err)
+	}
+	if len(n.GetUpdate())+len(n.GetDelete()) != 0 {
+		return fmt.Errorf("data tree changed by round trip:\n%s", ygot.FormatDiff(n))
+	}
+	j2, err := ygot.EmitJSON(nr, cfg)
+	if err != nil {
+		return fmt.Errorf("cannot emit JSON of unmarshalled data tree: %v", err)
+	}
+	if j != j2 {
+		return fmt.Errorf("JSON changed by round trip, got:\n%s\nwant:\n%s", j2, j)
+	}
+	return nil
+}
+
+// CheckSetDelete checks the invariants of ytypes.SetNode and
+// ytypes.DeleteNode for each leaf of the data tree gs of the schema:
+//   - once a leaf is set within a new root, retrieving it with
+//     ytypes.GetNode returns the value that was set.
+//   - once every leaf is set, the new root is equal to gs.
+//   - once a leaf is deleted, it is no longer set. Leaves that are list
+//     keys are not deleted, since this would leave the list entry without
+//     a key.
+//
+// An error that describes the first violated invariant is returned.
+func CheckSetDelete(schema *ytypes.Schema, gs ygot.GoStruct) error {
+	updates, err := leafUpdates(gs)
+	if err != nil {
+		return err
+	}
+
+	rs := schema.RootSchema()
+	nr := schema.NewRoot()
+	for _, u := range updates {
+		if err := ytypes.SetNode(rs, nr, u.GetPath(), u.GetVal(), &ytypes.InitMissingElements{}); err != nil {
+			return fmt.Errorf("cannot set %v to %v: %v", u.GetPath(), u.GetVal(), err)
+		}
+		got, err := leafValue(schema, nr, u.GetPath())
+		if err != nil {
+			return fmt.Errorf("cannot get %v after setting it: %v", u.GetPath(), err)
+		}
+		if !proto.Equal(got, u.GetVal()) {
+			return fmt.Errorf("got %v for %v after setting it to %v", got, u.GetPath(), u.GetVal())
+		}
+	}
+	n, err := ygot.Diff(gs, nr)
+	if err != nil {
+		return fmt.Errorf("cannot compare data trees: %v", err)
+	}
+	if len(n.GetUpdate())+len(n.GetDelete()) != 0 {
+		return fmt.Errorf("data tree formed by setting each leaf differs:\n%s", ygot.FormatDiff(n))
+	}
+
+	for _, u := range updates {
+		if isListKey(u.GetPath()) {
+			continue
+		}
+		if err := ytypes.DeleteNode(rs, nr, u.GetPath()); err != nil {
+			return fmt.Errorf("cannot delete %v: %v", u.GetPath(), err)
+		}
+		if got, err := leafValue(schema, nr, u.GetPath()); err == nil && got != nil {
+			return fmt.Errorf("%v is still set to %v after deleting it", u.GetPath(), got)
+		}
+	}
+	return nil
+}
+
+// leafValue returns the value of the leaf at path p within root as a
+// TypedValue, as it is encoded within gNMI notifications, or nil if the leaf
+// is not set.
+func leafValue(schema *ytypes.Schema, root ygot.GoStruct, p *gpb.Path) (*gpb.TypedValue, error) {
+	nodes, err := ytypes.GetNode(schema.RootSchema(), root, p)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) != 1 {
+		return nil, fmt.Errorf("got %d nodes, want 1", len(nodes))
+	}
+	// Deleted enumerated leaves are set to their zero value, and deleted
+	// leaf-lists may be empty, neither of which is included within gNMI
+	// notifications.
+	d := nodes[0].Data
+	if v := reflect.ValueOf(d); util.IsValueNilOrDefault(d) || (v.Kind() == reflect.Slice && v.Len() == 0) {
+		return nil, nil
+	}
+	return ygot.EncodeTypedValue(d, gpb.Encoding_JSON)
+}
@@ -88,10 +88,33 @@ type ParseOpts struct {
 	// code generation. This is due to the fact that some schemas (e.g., OpenConfig
 	// interfaces) currently result in overlapping entities (e.g., /interfaces).
 	ExcludeModules []string
+	// ExcludePaths specifies patterns of the schema paths of data nodes
+	// that are removed, along with their descendants, prior to code being
+	// generated. Patterns are matched against uncompressed paths, which do
+	// not include choice and case nodes, element by element. Each element
+	// of a pattern may contain the wildcards of path.Match, or be "**",
+	// which matches any number of elements, and is matched against the
+	// name of a node regardless of any prefix, e.g., "/interfaces/*/hold-time"
+	// or "/**/state".
+	ExcludePaths []string
+	// Features specifies the features, named either module:feature or
+	// feature, that are supported, such that data nodes whose if-feature
+	// statements are not satisfied are removed prior to code being
+	// generated. If it is empty, all features are supported except for
+	// those within ExcludeFeatures. The if-feature statements of uses
+	// statements are not evaluated.
+	Features []string
+	// ExcludeFeatures specifies features, named as per Features, that are
+	// not supported.
+	ExcludeFeatures []string
 	// YANGParseOptions provides the options that should be handed to the
 	// github.com/openconfig/goyang/pkg/yang library. These specify how the
 	// input YANG files should be parsed.
 	YANGParseOptions yang.Options
+	// Lint specifies whether the modules that code is generated for
+	// should be checked against the OpenConfig style guidelines. The
+	// findings are returned in the LintFindings field of the IR.
+	Lint bool
 }
 
 // TransformationOpts specifies transformations to the generated code with
@@ -160,6 +183,18 @@ type TransformationOpts struct {
 	// SkipObsolete specifies whether YANG fields with status "obsolete"
 	// should be excluded from the generated code output.
 	SkipObsolete bool
+	// FlattenEnumerationUnions specifies that unions whose members are all
+	// enumerations are represented by a single enumerated type, whose
+	// values are those of each of the members, rather than by a union of
+	// an enumerated type per member. The merged type is named as per an
+	// enumeration leaf, or after the typedef where the union is defined by
+	// a typedef.
+	FlattenEnumerationUnions bool
+	// TypedefUnionNames specifies that the types representing unions that
+	// are defined by a typedef are named after the typedef and its
+	// defining module, rather than after the path of each leaf that uses
+	// the typedef, such that a single type is shared by all such leaves.
+	TypedefUnionNames bool
 }
 
 // yangEnum represents an enumerated type in YANG that is to be output in the
@@ -251,6 +286,9 @@ type mappedYANGDefinitions struct {
 	// modelData stores the details of the set of modules that were parsed to produce
 	// the code. It is optionally returned in the generated code.
 	modelData []*gpb.ModelData
+	// lintFindings are the findings of checking the modules against the
+	// OpenConfig style guidelines, if requested.
+	lintFindings []*LintFinding
 }
 
 // mappedDefinitions finds the set of directory and enumeration entities
@@ -269,12 +307,37 @@ func mappedDefinitions(yangFiles, includePaths []string, opts IROptions) (*mappe
 		return nil, errs
 	}
 
+	// Data nodes that are not to have code generated for them are removed
+	// prior to any transformation of the schema.
+	pruner, err := newNodePruner(opts.ParseOptions)
+	if err != nil {
+		return nil, util.NewErrs(err)
+	}
+	if pruner != nil {
+		if errs := pruner.prune(modules); errs != nil {
+			return nil, errs
+		}
+	}
+
 	// Build a map of excluded modules to simplify lookup.
 	excluded := map[string]bool{}
 	for _, e := range opts.ParseOptions.ExcludeModules {
 		excluded[e] = true
 	}
 
+	// The modules are linted prior to being transformed, such that
+	// findings relate to the input schema.
+	var lintFindings []*LintFinding
+	if opts.ParseOptions.Lint {
+		var lms []*yang.Entry
+		for _, m := range modules {
+			if m != nil && !excluded[m.Name] {
+				lms = append(lms, m)
+			}
+		}
+		lintFindings = LintModules(lms)
+	}
+
 	// Extract the entities that are eligible to have code generated for
 	// them from the modules that are provided as an argument.
 	dirs := map[string]*yang.Entry{}
@@ -337,6 +400,7 @@ func mappedDefinitions(yangFiles, includePaths []string, opts IROptions) (*mappe
 		schematree:       st,
 		modules:          ms,
 		modelData:        modelData,
+		lintFindings:     lintFindings,
 	}, nil
 }
 
@@ -180,6 +180,8 @@ func getOrderedDirDetails(langMapper LangMapper, directory map[string]*Directory
 			DefiningModule:    definingModuleName,
 			RootElementModule: rootModule,
 			ConfigFalse:       !util.IsConfig(dir.Entry),
+			Description:       dir.Entry.Description,
+			Reference:         nodeReference(dir.Entry.Node),
 		}
 		switch {
 		case dir.Entry.IsList():
@@ -277,6 +279,7 @@ func getOrderedDirDetails(langMapper LangMapper, directory map[string]*Directory
 					SchemaPath:        util.SchemaTreePathNoModule(field),
 					LeafrefTargetPath: target.Path(),
 					Description:       field.Description,
+					Reference:         nodeReference(field.Node),
 					ConfigFalse:       !util.IsConfig(field),
 				},
 				MappedPaths:             mp,
@@ -315,6 +318,7 @@ func getOrderedDirDetails(langMapper LangMapper, directory map[string]*Directory
 
 				nd.Type = t
 				nd.LangType = mtype
+				nd.YANGDetails.Constraints = leafConstraints(field.Type)
 			case field.IsList():
 				nd.Type = ListNode
 				nd.YANGDetails.OrderedByUser = field.ListAttr.OrderedByUser
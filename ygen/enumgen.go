@@ -65,6 +65,11 @@ type enumSet struct {
 	// a module such as openconfig-bgp which defines /bgp and is also used at
 	// /network-instances/network-instance/protocols/protocol/bgp.
 	uniqueEnumeratedLeafNames map[string]string
+	// flattenedEnumUnions is the set of keys, as per those of
+	// uniqueEnumeratedLeafNames and uniqueEnumeratedTypedefNames, of the
+	// unions of enumerations that are represented by a single merged
+	// enumerated type.
+	flattenedEnumUnions map[string]bool
 }
 
 // newEnumSet initializes a new empty enumSet instance.
@@ -73,6 +78,7 @@ func newEnumSet() *enumSet {
 		uniqueIdentityNames:          map[string]string{},
 		uniqueEnumeratedTypedefNames: map[string]string{},
 		uniqueEnumeratedLeafNames:    map[string]string{},
+		flattenedEnumUnions:          map[string]bool{},
 	}
 }
 
@@ -160,6 +166,112 @@ func (s *enumSet) enumeratedUnionEntry(e *yang.Entry, compressPaths, noUnderscor
 	return es, nil
 }
 
+// isFlattenableEnumUnion reports whether t is a union type whose members,
+// including those of nested unions, are all enumerations, such that it can be
+// represented by a single merged enumerated type.
+func isFlattenableEnumUnion(t *yang.YangType) bool {
+	if !util.IsUnionType(t) {
+		return false
+	}
+	members := util.FlattenedTypes(t.Type)
+	if len(members) < 2 {
+		return false
+	}
+	for _, m := range members {
+		if m.Kind != yang.Yenum || m.Enum == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// flattenedEnumUnionKey returns the unique string key of the merged enumerated
+// type representing the union of enumerations that is the type of e. Inline
+// unions are keyed as per enumeration leaves, and those defined by a typedef
+// as per enumerated typedefs.
+func (s *enumSet) flattenedEnumUnionKey(e *yang.Entry, compressPaths, noUnderscores, skipDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames bool, enumOrgPrefixesToTrim []string) (string, error) {
+	if util.IsYANGBaseType(e.Type) {
+		key, _ := s.enumLeafKey(e, compressPaths, noUnderscores, skipDedup, shortenEnumLeafNames, false, enumOrgPrefixesToTrim)
+		return key, nil
+	}
+	key, _, err := s.enumeratedTypedefKey(resolveTypeArgs{contextEntry: e, yangType: e.Type}, noUnderscores, useDefiningModuleForTypedefEnumNames)
+	return key, err
+}
+
+// flattenedEnumUnionName retrieves the generated name of the merged enumerated
+// type that represents the union of enumerations that is the type of e, which
+// is the first value returned. The second value returned is a string key that
+// uniquely identifies this enumerated value among all possible enumerated
+// values in the input set of YANG files. The third value returned is false if
+// the union of e was not flattened.
+func (s *enumSet) flattenedEnumUnionName(e *yang.Entry, compressPaths, noUnderscores, skipDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames bool, enumOrgPrefixesToTrim []string) (string, string, bool, error) {
+	if !isFlattenableEnumUnion(e.Type) {
+		return "", "", false, nil
+	}
+	key, err := s.flattenedEnumUnionKey(e, compressPaths, noUnderscores, skipDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim)
+	if err != nil {
+		return "", "", false, err
+	}
+	if !s.flattenedEnumUnions[key] {
+		return "", "", false, nil
+	}
+	definedName, ok := s.uniqueEnumeratedLeafNames[key]
+	if !util.IsYANGBaseType(e.Type) {
+		definedName, ok = s.uniqueEnumeratedTypedefNames[key]
+	}
+	if !ok {
+		return "", "", false, fmt.Errorf("enumSet: cannot retrieve type name for flattened enumeration union without a name generated (was findEnumSet called?): %v", e.Path())
+	}
+	return definedName, key, true, nil
+}
+
+// flattenedEnumUnionEntry returns the yangEnum that should be generated for
+// the union of enumerations that is the type of e, where it was flattened. A
+// new yang.Entry is synthesised whose enumeration type has the values of each
+// member of the union, in the order in which they appear. Where a value name
+// appears in more than one member, only the first is retained.
+func (s *enumSet) flattenedEnumUnionEntry(e *yang.Entry, compressPaths, noUnderscores, skipDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames bool, enumOrgPrefixesToTrim []string) (*yangEnum, error) {
+	name, key, ok, err := s.flattenedEnumUnionName(e, compressPaths, noUnderscores, skipDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return nil, fmt.Errorf("enumSet: union of %s was not flattened", e.Path())
+	}
+
+	merged := yang.NewEnumType()
+	for _, t := range util.FlattenedTypes(e.Type.Type) {
+		for _, v := range t.Enum.Values() {
+			if n := t.Enum.Name(v); !merged.IsDefined(n) {
+				if err := merged.SetNext(n); err != nil {
+					return nil, fmt.Errorf("cannot merge enumerations of union %s: %v", e.Path(), err)
+				}
+			}
+		}
+	}
+
+	kind := UnionEnumerationType
+	if !util.IsYANGBaseType(e.Type) {
+		kind = DerivedUnionEnumerationType
+	}
+	return &yangEnum{
+		name: name,
+		entry: &yang.Entry{
+			Name:        e.Name,
+			Node:        e.Node,
+			Description: e.Description,
+			Type: &yang.YangType{
+				Name: e.Type.Name,
+				Kind: yang.Yenum,
+				Enum: merged,
+				Base: e.Type.Base,
+			},
+		},
+		kind: kind,
+		id:   key,
+	}, nil
+}
+
 // identityrefBaseTypeFromLeaf retrieves the mapped name of an identityref's
 // base such that it can be used in generated code. The first value returned is
 // the defining module name followed by the CamelCase-ified version of the
@@ -413,9 +525,11 @@ func enumIdentifier(e *yang.Entry, compressPaths bool) string {
 // not allowed in names. If skipEnumDedup is set to true, we do not attempt to
 // deduplicate enumerated leaves that are used more than once in the schema
 // into a common type.
+// If flattenEnumUnions is set to true, unions whose members are all
+// enumerations are represented by a single merged enumerated type.
 // The returned enumSet can be used to query for enum/identity names.
 // The returned map is the set of generated enums to be used for enum code generation.
-func findEnumSet(entries map[string]*yang.Entry, compressPaths, noUnderscores, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, appendEnumSuffixForSimpleUnionEnums bool, enumOrgPrefixesToTrim []string) (*enumSet, map[string]*yangEnum, []error) {
+func findEnumSet(entries map[string]*yang.Entry, compressPaths, noUnderscores, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, appendEnumSuffixForSimpleUnionEnums, flattenEnumUnions bool, enumOrgPrefixesToTrim []string) (*enumSet, map[string]*yangEnum, []error) {
 	validEnums := make(map[string]*yang.Entry)
 	var enumPaths []string
 	var errs []error
@@ -480,6 +594,12 @@ func findEnumSet(entries map[string]*yang.Entry, compressPaths, noUnderscores, s
 	for _, eP := range enumPaths {
 		e := validEnums[eP]
 		switch {
+		case flattenEnumUnions && isFlattenableEnumUnion(e.Type):
+			// Calculate the name of the single enumerated type that
+			// represents a union of enumerations.
+			if err := s.resolveFlattenedEnumUnion(e, compressPaths, noUnderscores, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim); err != nil {
+				errs = append(errs, err)
+			}
 		case e.Type.Name == "union", e.Type.Kind == yang.Yunion && !util.IsYANGBaseType(e.Type):
 			// Calculate any enumerated types that exist within a union, whether it
 			// is a directly defined union, or a non-builtin typedef.
@@ -529,6 +649,15 @@ func findEnumSet(entries map[string]*yang.Entry, compressPaths, noUnderscores, s
 		e := validEnums[eP]
 
 		switch {
+		case flattenEnumUnions && isFlattenableEnumUnion(e.Type):
+			en, err := s.enumSet.flattenedEnumUnionEntry(e, compressPaths, noUnderscores, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if _, ok := genEnums[en.name]; !ok {
+				genEnums[en.name] = en
+			}
 		case e.Type.Name == "union", len(e.Type.Type) > 0 && !util.IsYANGBaseType(e.Type):
 			// Calculate any enumerated types that exist within a union, whether it
 			// is a directly defined union, or a non-builtin typedef.
@@ -838,6 +967,25 @@ func (s *enumGenState) resolveEnumeratedUnionEntry(e *yang.Entry, compressPaths,
 	return nil
 }
 
+// resolveFlattenedEnumUnion takes an input yang.Entry whose type is a union
+// of enumerations and computes the name of the single merged enumerated type
+// that represents it in the generated code. Inline unions are named as per
+// enumeration leaves, and those defined by a typedef as per enumerated
+// typedefs.
+func (s *enumGenState) resolveFlattenedEnumUnion(e *yang.Entry, compressPaths, noUnderscores, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames bool, enumOrgPrefixesToTrim []string) error {
+	if util.IsYANGBaseType(e.Type) {
+		s.resolveEnumName(e, compressPaths, noUnderscores, skipEnumDedup, shortenEnumLeafNames, false, enumOrgPrefixesToTrim)
+	} else if err := s.resolveTypedefEnumeratedName(resolveTypeArgs{contextEntry: e, yangType: e.Type}, noUnderscores, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim); err != nil {
+		return err
+	}
+	key, err := s.enumSet.flattenedEnumUnionKey(e, compressPaths, noUnderscores, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim)
+	if err != nil {
+		return err
+	}
+	s.enumSet.flattenedEnumUnions[key] = true
+	return nil
+}
+
 // resolveIdentityRefBaseType calculates the mapped name of an identityref's
 // base such that it can be used in generated code. The value that is returned
 // is defining module name followed by the CamelCase-ified version of the
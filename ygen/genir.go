@@ -60,6 +60,10 @@ type IROptions struct {
 
 	// PathOriginName specifies the orign name for generated gNMI paths when producing the IR.
 	PathOriginName string
+
+	// PhaseTimer, if non-nil, records the time spent parsing the input
+	// YANG schema, and building the IR from it.
+	PhaseTimer *genutil.PhaseTimer
 }
 
 // GenerateIR creates the ygen intermediate representation for a set of
@@ -78,12 +82,16 @@ func GenerateIR(yangFiles, includePaths []string, langMapper LangMapper, opts IR
 	// Extract the entities to be mapped into structs and enumerations in the output
 	// Go code. Extract the schematree from the modules provided such that it can be
 	// used to reference entities within the tree.
+	donePhase := opts.PhaseTimer.Start(genutil.PhaseParse)
 	mdef, errs := mappedDefinitions(yangFiles, includePaths, opts)
+	donePhase()
 	if errs != nil {
 		return nil, errs
 	}
 
-	enumSet, genEnums, errs := findEnumSet(mdef.enumEntries, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), !opts.TransformationOptions.EnumerationsUseUnderscores, opts.TransformationOptions.SkipEnumDeduplication, opts.TransformationOptions.ShortenEnumLeafNames, opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames, opts.AppendEnumSuffixForSimpleUnionEnums, opts.TransformationOptions.EnumOrgPrefixesToTrim)
+	defer opts.PhaseTimer.Start(genutil.PhaseIR)()
+
+	enumSet, genEnums, errs := findEnumSet(mdef.enumEntries, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), !opts.TransformationOptions.EnumerationsUseUnderscores, opts.TransformationOptions.SkipEnumDeduplication, opts.TransformationOptions.ShortenEnumLeafNames, opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames, opts.AppendEnumSuffixForSimpleUnionEnums, opts.TransformationOptions.FlattenEnumerationUnions, opts.TransformationOptions.EnumOrgPrefixesToTrim)
 	if errs != nil {
 		return nil, errs
 	}
@@ -125,6 +133,7 @@ func GenerateIR(yangFiles, includePaths []string, langMapper LangMapper, opts IR
 		if defaultValue, ok := enum.entry.SingleDefaultValue(); ok {
 			et.TypeDefaultValue = defaultValue
 		}
+		et.Description, et.Reference = enumDocumentation(enum)
 
 		switch {
 		case len(enum.entry.Type.Type) != 0:
@@ -155,6 +164,8 @@ func GenerateIR(yangFiles, includePaths []string, langMapper LangMapper, opts IR
 					Name:           v,
 					DefiningModule: genutil.ParentModuleName(valLookup[v]),
 					Value:          i,
+					Description:    nodeDescription(valLookup[v]),
+					Status:         nodeStatus(valLookup[v]),
 				})
 			}
 		default:
@@ -167,10 +178,14 @@ func GenerateIR(yangFiles, includePaths []string, langMapper LangMapper, opts IR
 				values = append(values, int(v))
 			}
 			sort.Ints(values)
+			nodes := enumValueNodes(enum.entry, enum.entry.Type)
 			for _, v := range values {
+				name := valueMap[int64(v)]
 				et.ValToYANGDetails = append(et.ValToYANGDetails, ygot.EnumDefinition{
-					Name:  valueMap[int64(v)],
-					Value: v,
+					Name:        name,
+					Value:       v,
+					Description: nodeDescription(nodes[name]),
+					Status:      nodeStatus(nodes[name]),
 				})
 			}
 		}
@@ -188,6 +203,7 @@ func GenerateIR(yangFiles, includePaths []string, langMapper LangMapper, opts IR
 		Directories:   dirDets,
 		Enums:         enumDefinitionMap,
 		ModelData:     mdef.modelData,
+		LintFindings:  mdef.lintFindings,
 		opts:          opts,
 		fakeroot:      rootEntry,
 		parsedModules: mdef.modules,
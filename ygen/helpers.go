@@ -14,7 +14,12 @@
 
 package ygen
 
-import "github.com/openconfig/goyang/pkg/yang"
+import (
+	"reflect"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
 
 // resolveRootName resolves the name of the fakeroot by taking configuration
 // and the default values, along with a boolean indicating whether the fake
@@ -47,3 +52,150 @@ type resolveTypeArgs struct {
 	// can be established.
 	contextEntry *yang.Entry
 }
+
+// nodeReference returns the argument of the reference statement of the YANG
+// node n, or the empty string if it has none.
+func nodeReference(n yang.Node) string {
+	return nodeArgument(n, "reference")
+}
+
+// nodeDescription returns the argument of the description statement of the
+// YANG node n, or the empty string if it has none.
+func nodeDescription(n yang.Node) string {
+	return nodeArgument(n, "description")
+}
+
+// nodeStatus returns the argument of the status statement of the YANG node
+// n, or the empty string if it has none.
+func nodeStatus(n yang.Node) string {
+	return nodeArgument(n, "status")
+}
+
+// nodeArgument returns the argument of the substatement of the YANG node n
+// with the supplied keyword, or the empty string if it has none.
+func nodeArgument(n yang.Node, keyword string) string {
+	if n == nil || reflect.ValueOf(n).IsNil() {
+		return ""
+	}
+	s := n.Statement()
+	if s == nil {
+		return ""
+	}
+	for _, ss := range s.SubStatements() {
+		if ss.Keyword == keyword {
+			return ss.Argument
+		}
+	}
+	return ""
+}
+
+// enumValueNodes returns the enum statements that define the values of the
+// enumeration type t of the entry e, keyed by the name of the value. The
+// statements are found within the typedef of a derived enumeration, or
+// otherwise within the type statements of the leaf or leaf-list e.
+func enumValueNodes(e *yang.Entry, t *yang.YangType) map[string]*yang.Enum {
+	if t == nil || t.Enum == nil {
+		return nil
+	}
+	candidates := []*yang.Type{t.Base}
+	switch n := e.Node.(type) {
+	case *yang.Leaf:
+		candidates = append(candidates, n.Type)
+	case *yang.LeafList:
+		candidates = append(candidates, n.Type)
+	}
+	names := t.Enum.NameMap()
+	for len(candidates) > 0 {
+		ts := candidates[0]
+		candidates = candidates[1:]
+		if ts == nil {
+			continue
+		}
+		if len(ts.Enum) == len(names) {
+			m := make(map[string]*yang.Enum, len(ts.Enum))
+			for _, en := range ts.Enum {
+				if _, ok := names[en.Name]; ok {
+					m[en.Name] = en
+				}
+			}
+			if len(m) == len(names) {
+				return m
+			}
+		}
+		candidates = append(candidates, ts.Type...)
+	}
+	return nil
+}
+
+// enumDocumentation returns the description and reference of the YANG node
+// that defines the enumerated type enum: the base identity of an
+// identityref, the typedef of a derived enumeration, or otherwise the leaf
+// that contains the enumeration.
+func enumDocumentation(enum *yangEnum) (string, string) {
+	t := enum.entry.Type
+	switch enum.kind {
+	case IdentityType:
+		if t.IdentityBase != nil {
+			return nodeDescription(t.IdentityBase), nodeReference(t.IdentityBase)
+		}
+	case DerivedEnumerationType, DerivedUnionEnumerationType:
+		if t.Base == nil {
+			break
+		}
+		// The enumeration may be nested within union types of the typedef.
+		for n := t.Base.ParentNode(); n != nil; n = n.ParentNode() {
+			if td, ok := n.(*yang.Typedef); ok {
+				return nodeDescription(td), nodeReference(td)
+			}
+		}
+	}
+	return enum.entry.Description, nodeReference(enum.entry.Node)
+}
+
+// builtinRanges maps the integer YANG types to the range of values that they
+// may take when they are not restricted by a range statement.
+var builtinRanges = map[yang.TypeKind]yang.YangRange{
+	yang.Yint8:   yang.Int8Range,
+	yang.Yint16:  yang.Int16Range,
+	yang.Yint32:  yang.Int32Range,
+	yang.Yint64:  yang.Int64Range,
+	yang.Yuint8:  yang.Uint8Range,
+	yang.Yuint16: yang.Uint16Range,
+	yang.Yuint32: yang.Uint32Range,
+	yang.Yuint64: yang.Uint64Range,
+}
+
+// leafConstraints returns the restrictions on the values of a leaf of YANG
+// type t that are specified by range, length and pattern statements, or nil
+// if the values of the leaf are unrestricted. Union and leafref types are
+// not considered to be restricted, since their values may be of multiple
+// types.
+func leafConstraints(t *yang.YangType) *LeafConstraints {
+	if t == nil {
+		return nil
+	}
+	c := &LeafConstraints{}
+	switch t.Kind {
+	case yang.Ydecimal64:
+		// The range of an unrestricted decimal64 is that of an int64
+		// scaled by its fraction-digits.
+		if len(t.Range) != 0 && !(len(t.Range) == 1 && t.Range[0].Min.Negative && t.Range[0].Min.Value == yang.AbsMinInt64 && !t.Range[0].Max.Negative && t.Range[0].Max.Value == yang.MaxInt64) {
+			c.Range = t.Range
+		}
+	case yang.Ystring, yang.Ybinary:
+		if len(t.Length) != 0 && !t.Length.Equal(yang.Uint64Range) {
+			c.Length = t.Length
+		}
+		if t.Kind == yang.Ystring {
+			c.Patterns, _ = util.SanitizedPattern(t)
+		}
+	default:
+		if r, ok := builtinRanges[t.Kind]; ok && len(t.Range) != 0 && !t.Range.Equal(r) {
+			c.Range = t.Range
+		}
+	}
+	if c.Range == nil && c.Length == nil && len(c.Patterns) == 0 {
+		return nil
+	}
+	return c
+}
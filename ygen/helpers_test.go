@@ -16,6 +16,9 @@ package ygen
 
 import (
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/goyang/pkg/yang"
 )
 
 func TestResolveRootName(t *testing.T) {
@@ -47,3 +50,70 @@ func TestResolveRootName(t *testing.T) {
 		}
 	}
 }
+
+func TestLeafConstraints(t *testing.T) {
+	mustRange := func(s string) yang.YangRange {
+		r, err := yang.ParseRangesInt(s)
+		if err != nil {
+			t.Fatalf("cannot parse range %q: %v", s, err)
+		}
+		return r
+	}
+
+	tests := []struct {
+		desc string
+		in   *yang.YangType
+		want *LeafConstraints
+	}{{
+		desc: "nil type",
+	}, {
+		desc: "unrestricted integer",
+		in:   &yang.YangType{Kind: yang.Yuint16, Range: yang.Uint16Range},
+	}, {
+		desc: "restricted integer",
+		in:   &yang.YangType{Kind: yang.Yuint16, Range: mustRange("68..9216")},
+		want: &LeafConstraints{Range: mustRange("68..9216")},
+	}, {
+		desc: "unrestricted decimal64",
+		in: &yang.YangType{Kind: yang.Ydecimal64, FractionDigits: 2, Range: yang.YangRange{{
+			Min: yang.Number{Value: yang.AbsMinInt64, Negative: true, FractionDigits: 2},
+			Max: yang.Number{Value: yang.MaxInt64, FractionDigits: 2},
+		}}},
+	}, {
+		desc: "restricted decimal64",
+		in: &yang.YangType{Kind: yang.Ydecimal64, FractionDigits: 2, Range: yang.YangRange{{
+			Min: yang.Number{Value: 1050, Negative: true, FractionDigits: 2},
+			Max: yang.Number{Value: 1050, FractionDigits: 2},
+		}}},
+		want: &LeafConstraints{Range: yang.YangRange{{
+			Min: yang.Number{Value: 1050, Negative: true, FractionDigits: 2},
+			Max: yang.Number{Value: 1050, FractionDigits: 2},
+		}}},
+	}, {
+		desc: "unrestricted string",
+		in:   &yang.YangType{Kind: yang.Ystring},
+	}, {
+		desc: "string with length and pattern",
+		in:   &yang.YangType{Kind: yang.Ystring, Length: mustRange("1..64"), Pattern: []string{"[a-z]+"}},
+		want: &LeafConstraints{Length: mustRange("1..64"), Patterns: []string{"^([a-z]+)$"}},
+	}, {
+		desc: "string with posix-pattern",
+		in:   &yang.YangType{Kind: yang.Ystring, Pattern: []string{"[a-z]+"}, POSIXPattern: []string{"^[a-z]+$"}},
+		want: &LeafConstraints{Patterns: []string{"^[a-z]+$"}},
+	}, {
+		desc: "binary with length",
+		in:   &yang.YangType{Kind: yang.Ybinary, Length: mustRange("4..16")},
+		want: &LeafConstraints{Length: mustRange("4..16")},
+	}, {
+		desc: "union is not restricted",
+		in:   &yang.YangType{Kind: yang.Yunion, Type: []*yang.YangType{{Kind: yang.Yuint8, Range: mustRange("1..5")}}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, leafConstraints(tt.in)); diff != "" {
+				t.Errorf("leafConstraints: (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -163,7 +163,7 @@ func (s *LangMapperBase) setSchemaTree(st *yangschema.Tree) {
 // It returns an error if there is a failure to generate the enumerated values'
 // names.
 func (s *LangMapperBase) InjectEnumSet(entries map[string]*yang.Entry, compressPaths, noUnderscores, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, appendEnumSuffixForSimpleUnionEnums bool, enumOrgPrefixesToTrim []string) error {
-	enumSet, _, errs := findEnumSet(entries, compressPaths, noUnderscores, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, appendEnumSuffixForSimpleUnionEnums, enumOrgPrefixesToTrim)
+	enumSet, _, errs := findEnumSet(entries, compressPaths, noUnderscores, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, appendEnumSuffixForSimpleUnionEnums, false, enumOrgPrefixesToTrim)
 	if errs != nil {
 		return fmt.Errorf("%v", errs)
 	}
@@ -222,6 +222,17 @@ func (b *LangMapperBase) EnumName(e *yang.Entry, compressPaths, noUnderscores, s
 	return b.enumSet.enumName(e, compressPaths, noUnderscores, skipDedup, shortenEnumLeafNames, addEnumeratedUnionSuffix, enumOrgPrefixesToTrim)
 }
 
+// FlattenedEnumUnionName retrieves the type name of the merged enumerated
+// type that represents the union type of the input *yang.Entry where it was
+// flattened by the FlattenEnumerationUnions transformation, which is the
+// first returned value. The second value returned is a string key that
+// uniquely identifies this enumerated value among all possible enumerated
+// values in the input set of YANG files. If the union of the entry was not
+// flattened, the third returned value is false.
+func (b *LangMapperBase) FlattenedEnumUnionName(e *yang.Entry, compressPaths, noUnderscores, skipDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames bool, enumOrgPrefixesToTrim []string) (string, string, bool, error) {
+	return b.enumSet.flattenedEnumUnionName(e, compressPaths, noUnderscores, skipDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim)
+}
+
 // IdentityrefBaseTypeFromIdentity retrieves the generated type name of the
 // input *yang.Identity. The first value returned is the defining module
 // followed by the CamelCase-ified version of the identity's name. The second
@@ -302,6 +313,11 @@ type IR struct {
 	// ModelData stores the metadata extracted from the input YANG modules.
 	ModelData []*gpb.ModelData
 
+	// LintFindings are the findings of checking the input YANG modules
+	// against the OpenConfig style guidelines. It is populated only if
+	// linting is requested by the Lint parse option.
+	LintFindings []*LintFinding
+
 	// opts stores the IROptions that were used to generate the IR.
 	opts IROptions
 
@@ -468,6 +484,11 @@ type ParsedDirectory struct {
 	//
 	// https://github.com/openconfig/public/blob/master/release/models/openconfig-extensions.yang#L154
 	CompressedTelemetryAtomic bool
+	// Description contains the description of the directory's YANG node.
+	Description string
+	// Reference contains the argument of the reference statement of the
+	// directory's YANG node.
+	Reference string
 }
 
 // OrderedFieldNames returns the YANG name of all fields belonging to the
@@ -700,6 +721,9 @@ type YANGNodeDetails struct {
 	PresenceStatement *string
 	// Description contains the description of the node.
 	Description string
+	// Reference contains the argument of the reference statement of the
+	// node.
+	Reference string
 	// OrderedByUser indicates whether the node has the modifier
 	// "ordered-by user".
 	OrderedByUser bool
@@ -711,6 +735,28 @@ type YANGNodeDetails struct {
 	ConfigFalse bool
 	// Origin specifies the origin name for the generated gNMI path.
 	Origin string
+	// Constraints, if non-nil, contains the restrictions on the values
+	// of a leaf or leaf-list that are specified by the range, length
+	// and pattern statements of its type.
+	Constraints *LeafConstraints
+}
+
+// LeafConstraints stores the restrictions on the values of a leaf or
+// leaf-list that are specified by its YANG type.
+type LeafConstraints struct {
+	// Range is the set of ranges of values that an integer or decimal64
+	// leaf may take. It is populated only when the values of the leaf are
+	// restricted by a range statement.
+	Range yang.YangRange
+	// Length is the set of ranges of lengths that a string or binary
+	// leaf may take. It is populated only when the leaf is restricted by
+	// a length statement.
+	Length yang.YangRange
+	// Patterns are the regular expressions that the value of a string
+	// leaf must match, in the syntax of Go's regexp package. They are the
+	// values of the posix-pattern statements of the type where present,
+	// and otherwise its pattern statements with anchors added.
+	Patterns []string
 }
 
 // EnumeratedValueType is used to indicate the source YANG type
@@ -785,4 +831,11 @@ type EnumeratedYANGType struct {
 	// Specifically, this field is set by the
 	// LangMapperExt.PopulateEnumFlags function.
 	Flags map[string]string
+	// Description contains the description of the YANG node that defines
+	// the enumeration: the base identity of an identityref, the typedef of
+	// a derived enumeration, or otherwise the leaf that contains it.
+	Description string
+	// Reference contains the argument of the reference statement of the
+	// YANG node that defines the enumeration.
+	Reference string
 }
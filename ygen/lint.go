@@ -0,0 +1,318 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
+
+// LintSeverity is the severity of a LintFinding.
+type LintSeverity int
+
+const (
+	// LintInfo is the severity of findings that are informational.
+	LintInfo LintSeverity = iota
+	// LintWarning is the severity of findings that are deviations from
+	// the OpenConfig style guidelines that do not affect the structure of
+	// the data tree.
+	LintWarning
+	// LintError is the severity of findings that are deviations from the
+	// OpenConfig structure of the data tree.
+	LintError
+)
+
+// String returns the name of the severity.
+func (s LintSeverity) String() string {
+	switch s {
+	case LintInfo:
+		return "info"
+	case LintWarning:
+		return "warning"
+	case LintError:
+		return "error"
+	}
+	return fmt.Sprintf("LintSeverity(%d)", int(s))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, such that
+// the severity is encoded by its name.
+func (s LintSeverity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// The names of the rules that are checked by LintModules.
+const (
+	// LintRuleEnumNaming checks that the values of enumerations, and
+	// identities, are named in UPPER_SNAKE_CASE.
+	LintRuleEnumNaming = "enum-naming"
+	// LintRuleConfigState checks that leaves are within config and state
+	// containers, and that each config container has a corresponding
+	// state container.
+	LintRuleConfigState = "config-state-split"
+	// LintRuleListKey checks that each key leaf of a list is a leafref to
+	// the corresponding leaf within the config or state container of the
+	// list.
+	LintRuleListKey = "list-key"
+	// LintRuleListContainer checks that each list is the only child of
+	// its surrounding container.
+	LintRuleListContainer = "list-container"
+	// LintRuleDescription checks that each data node has a description.
+	LintRuleDescription = "description"
+)
+
+// LintFinding is a deviation of a YANG schema from the OpenConfig style
+// guidelines.
+type LintFinding struct {
+	// Rule is the name of the rule that the finding is reported by.
+	Rule string `json:"rule"`
+	// Severity is the severity of the finding.
+	Severity LintSeverity `json:"severity"`
+	// Module is the name of the module that contains the statement that
+	// the finding relates to.
+	Module string `json:"module"`
+	// Path is the schema path of the node that the finding relates to,
+	// or the name of the identity.
+	Path string `json:"path"`
+	// Location is the source location of the statement that the finding
+	// relates to, as file:line:column.
+	Location string `json:"location,omitempty"`
+	// Message describes the finding.
+	Message string `json:"message"`
+}
+
+// String returns a human-readable form of the finding.
+func (f *LintFinding) String() string {
+	loc := f.Location
+	if loc == "" {
+		loc = f.Module
+	}
+	return fmt.Sprintf("%s: %s: %s: %s [%s]", loc, f.Severity, f.Path, f.Message, f.Rule)
+}
+
+// WriteLintReport writes findings to w, one per line, in the form returned
+// by LintFinding.String.
+func WriteLintReport(w io.Writer, findings []*LintFinding) error {
+	for _, f := range findings {
+		if _, err := fmt.Fprintln(w, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// upperSnakeCase matches names in UPPER_SNAKE_CASE.
+var upperSnakeCase = regexp.MustCompile(`^[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$`)
+
+// linter checks the entries of a set of modules against the OpenConfig
+// style guidelines.
+type linter struct {
+	// findings are the findings that have been reported.
+	findings []*LintFinding
+	// seen is the set of statements, enumerated types and identities that
+	// have been checked by each rule, such that a statement within a
+	// grouping that is used several times is reported once.
+	seen map[lintKey]bool
+}
+
+// lintKey identifies a statement, enumerated type or identity that has been
+// checked by a rule.
+type lintKey struct {
+	rule string
+	v    any
+}
+
+// LintModules checks the data nodes of the supplied modules, which are
+// assumed to be the entries of YANG modules prior to any transformation
+// such as path compression, against the OpenConfig style guidelines. The
+// findings are returned sorted by module, path and rule.
+func LintModules(modules []*yang.Entry) []*LintFinding {
+	l := &linter{seen: map[lintKey]bool{}}
+	for _, m := range modules {
+		l.dir(m, false)
+	}
+	sort.SliceStable(l.findings, func(i, j int) bool {
+		a, b := l.findings[i], l.findings[j]
+		switch {
+		case a.Module != b.Module:
+			return a.Module < b.Module
+		case a.Path != b.Path:
+			return a.Path < b.Path
+		}
+		return a.Rule < b.Rule
+	})
+	return l.findings
+}
+
+// report records a finding of the rule for the entry e.
+func (l *linter) report(rule string, sev LintSeverity, e *yang.Entry, format string, args ...any) {
+	f := &LintFinding{
+		Rule:     rule,
+		Severity: sev,
+		Path:     util.SchemaTreePath(e),
+		Message:  fmt.Sprintf(format, args...),
+	}
+	if e.Node != nil {
+		f.Module = yang.RootNode(e.Node).Name
+		f.Location = yang.Source(e.Node)
+	}
+	l.findings = append(l.findings, f)
+}
+
+// once returns whether v has not been checked by the rule before,
+// recording that it has.
+func (l *linter) once(rule string, v any) bool {
+	k := lintKey{rule: rule, v: v}
+	if l.seen[k] {
+		return false
+	}
+	l.seen[k] = true
+	return true
+}
+
+// dir checks the children of the directory entry e. inConfigState is true
+// if e is a config or state container, or is beneath one.
+func (l *linter) dir(e *yang.Entry, inConfigState bool) {
+	children := util.FindFirstNonChoiceOrCase(e)
+	var names []string
+	byName := map[string]*yang.Entry{}
+	for _, ch := range children {
+		names = append(names, ch.Name)
+		byName[ch.Name] = ch
+	}
+	sort.Strings(names)
+
+	// Leaves of modules are not expected to be within config and state
+	// containers.
+	structural := !inConfigState && e.Parent != nil
+	for _, n := range names {
+		ch := byName[n]
+		l.description(ch)
+		switch {
+		case ch.IsLeaf(), ch.IsLeafList():
+			l.enums(ch)
+			if structural && !isListKey(e, ch.Name) {
+				l.report(LintRuleConfigState, LintError, ch, "leaf %s is not within a config or state container", ch.Name)
+			}
+		case ch.IsList():
+			l.list(e, ch)
+			l.dir(ch, inConfigState)
+		case ch.IsDir():
+			if !inConfigState && ch.Name == "config" && byName["state"] == nil {
+				l.report(LintRuleConfigState, LintWarning, ch, "config container has no corresponding state container")
+			}
+			l.dir(ch, inConfigState || ch.Name == "config" || ch.Name == "state")
+		}
+	}
+}
+
+// list checks the list e, whose parent is the directory entry parent.
+func (l *linter) list(parent, e *yang.Entry) {
+	if parent.Parent != nil && parent.IsContainer() && len(util.FindFirstNonChoiceOrCase(parent)) != 1 {
+		l.report(LintRuleListContainer, LintWarning, e, "list %s is not the only child of its surrounding container %s", e.Name, parent.Name)
+	} else if parent.Parent == nil || !parent.IsContainer() {
+		l.report(LintRuleListContainer, LintWarning, e, "list %s has no surrounding container", e.Name)
+	}
+	if !l.once(LintRuleListKey, e.Node) {
+		return
+	}
+	for _, k := range strings.Fields(e.Key) {
+		kl := e.Dir[k]
+		if kl == nil || kl.Type == nil {
+			continue
+		}
+		if kl.Type.Kind != yang.Yleafref || (kl.Type.Path != "../config/"+k && kl.Type.Path != "../state/"+k) {
+			l.report(LintRuleListKey, LintError, kl, "key leaf %s is not a leafref to ../config/%s or ../state/%s", k, k, k)
+		}
+	}
+}
+
+// description checks that the data node e has a description.
+func (l *linter) description(e *yang.Entry) {
+	if e.Node != nil && !l.once(LintRuleDescription, e.Node) {
+		return
+	}
+	if e.Description == "" {
+		l.report(LintRuleDescription, LintInfo, e, "%s has no description", e.Name)
+	}
+}
+
+// enums checks the names of the values of the enumerations and identities
+// that are the type, or within the union type, of the leaf e.
+func (l *linter) enums(e *yang.Entry) {
+	for _, t := range util.EnumeratedUnionTypes(flattenUnion(e.Type)) {
+		switch {
+		case t.Kind == yang.Yenum && t.Enum != nil:
+			if !l.once(LintRuleEnumNaming, t.Enum) {
+				continue
+			}
+			for _, n := range t.Enum.Names() {
+				if !upperSnakeCase.MatchString(n) {
+					l.report(LintRuleEnumNaming, LintWarning, e, "enumeration value %s is not in UPPER_SNAKE_CASE", n)
+				}
+			}
+		case t.Kind == yang.Yidentityref && t.IdentityBase != nil:
+			for _, id := range append([]*yang.Identity{t.IdentityBase}, t.IdentityBase.Values...) {
+				if !l.once(LintRuleEnumNaming, id) || upperSnakeCase.MatchString(id.Name) {
+					continue
+				}
+				l.findings = append(l.findings, &LintFinding{
+					Rule:     LintRuleEnumNaming,
+					Severity: LintWarning,
+					Module:   yang.RootNode(id).Name,
+					Path:     id.Name,
+					Location: yang.Source(id),
+					Message:  fmt.Sprintf("identity %s is not in UPPER_SNAKE_CASE", id.Name),
+				})
+			}
+		}
+	}
+}
+
+// flattenUnion returns the type t within a slice, or its member types if
+// it is a union.
+func flattenUnion(t *yang.YangType) []*yang.YangType {
+	if t == nil {
+		return nil
+	}
+	if t.Kind != yang.Yunion {
+		return []*yang.YangType{t}
+	}
+	var ts []*yang.YangType
+	for _, st := range t.Type {
+		ts = append(ts, flattenUnion(st)...)
+	}
+	return ts
+}
+
+// isListKey returns whether name is the name of a key leaf of e, which is a
+// list.
+func isListKey(e *yang.Entry, name string) bool {
+	if !e.IsList() {
+		return false
+	}
+	for _, k := range strings.Fields(e.Key) {
+		if k == name {
+			return true
+		}
+	}
+	return false
+}
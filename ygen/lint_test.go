@@ -0,0 +1,201 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+const lintTestModule = `
+module lint {
+	prefix "l";
+	namespace "urn:l";
+	description "A module used to test linting.";
+
+	identity BASE { description "Base identity."; }
+	identity good_name { base BASE; description "Derived identity."; }
+
+	grouping counters {
+		container counters {
+			description "Counters.";
+			leaf in-pkts {
+				type uint64;
+				description "Input packets.";
+			}
+		}
+	}
+
+	container good {
+		description "Container following the conventions.";
+		container things {
+			description "Surrounding container.";
+			list thing {
+				key "name";
+				description "A thing.";
+				leaf name {
+					type leafref { path "../config/name"; }
+					description "Key.";
+				}
+				container config {
+					description "Configuration.";
+					leaf name { type string; description "Name."; }
+					leaf kind {
+						type identityref { base BASE; }
+						description "Kind.";
+					}
+				}
+				container state {
+					config false;
+					description "State.";
+					leaf name { type string; description "Name."; }
+					uses counters;
+				}
+			}
+		}
+	}
+
+	container bad {
+		description "Container breaking the conventions.";
+		leaf direct {
+			type enumeration {
+				enum UP;
+				enum going-down;
+			}
+			description "Leaf that is not in config or state.";
+		}
+		container config {
+			leaf value { type string; description "Value."; }
+		}
+		list item {
+			key "id";
+			description "List without a surrounding container.";
+			leaf id { type string; description "Key."; }
+			container state {
+				config false;
+				description "State.";
+				uses counters;
+			}
+		}
+	}
+}
+`
+
+func TestLintModules(t *testing.T) {
+	ms := compileModules(t, map[string]string{"lint": lintTestModule})
+	got := LintModules([]*yang.Entry{findEntry(t, ms, "lint", "")})
+
+	want := []*LintFinding{{
+		Rule:     LintRuleEnumNaming,
+		Severity: LintWarning,
+		Module:   "lint",
+		Path:     "/lint/bad/direct",
+		Message:  "enumeration value going-down is not in UPPER_SNAKE_CASE",
+	}, {
+		Rule:     LintRuleConfigState,
+		Severity: LintError,
+		Module:   "lint",
+		Path:     "/lint/bad/direct",
+		Message:  "leaf direct is not within a config or state container",
+	}, {
+		Rule:     LintRuleConfigState,
+		Severity: LintWarning,
+		Module:   "lint",
+		Path:     "/lint/bad/config",
+		Message:  "config container has no corresponding state container",
+	}, {
+		Rule:     LintRuleDescription,
+		Severity: LintInfo,
+		Module:   "lint",
+		Path:     "/lint/bad/config",
+		Message:  "config has no description",
+	}, {
+		Rule:     LintRuleListContainer,
+		Severity: LintWarning,
+		Module:   "lint",
+		Path:     "/lint/bad/item",
+		Message:  "list item is not the only child of its surrounding container bad",
+	}, {
+		Rule:     LintRuleListKey,
+		Severity: LintError,
+		Module:   "lint",
+		Path:     "/lint/bad/item/id",
+		Message:  "key leaf id is not a leafref to ../config/id or ../state/id",
+	}, {
+		Rule:     LintRuleEnumNaming,
+		Severity: LintWarning,
+		Module:   "lint",
+		Path:     "good_name",
+		Message:  "identity good_name is not in UPPER_SNAKE_CASE",
+	}}
+	sortFindings := cmpopts.SortSlices(func(a, b *LintFinding) bool { return a.Path+a.Rule+a.Message < b.Path+b.Rule+b.Message })
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(LintFinding{}, "Location"), sortFindings); diff != "" {
+		t.Errorf("LintModules (-want, +got):\n%s", diff)
+	}
+	for _, f := range got {
+		if !strings.HasPrefix(f.Location, "lint:") {
+			t.Errorf("finding %v: got location %q, want within lint", f, f.Location)
+		}
+	}
+}
+
+func TestLintReport(t *testing.T) {
+	findings := []*LintFinding{{
+		Rule:     LintRuleDescription,
+		Severity: LintInfo,
+		Module:   "lint",
+		Path:     "/lint/bad/config",
+		Location: "lint:85:3",
+		Message:  "config has no description",
+	}, {
+		Rule:     LintRuleEnumNaming,
+		Severity: LintWarning,
+		Module:   "lint",
+		Path:     "good_name",
+		Message:  "identity good_name is not in UPPER_SNAKE_CASE",
+	}}
+	var buf bytes.Buffer
+	if err := WriteLintReport(&buf, findings); err != nil {
+		t.Fatalf("WriteLintReport: %v", err)
+	}
+	want := "lint:85:3: info: /lint/bad/config: config has no description [description]\n" +
+		"lint: warning: good_name: identity good_name is not in UPPER_SNAKE_CASE [enum-naming]\n"
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("WriteLintReport (-want, +got):\n%s", diff)
+	}
+}
+
+func TestMappedDefinitionsLint(t *testing.T) {
+	fn := filepath.Join(t.TempDir(), "lint.yang")
+	if err := os.WriteFile(fn, []byte(lintTestModule), 0o644); err != nil {
+		t.Fatalf("cannot write module: %v", err)
+	}
+	for _, lint := range []bool{false, true} {
+		mdef, errs := mappedDefinitions([]string{fn}, nil, IROptions{ParseOptions: ParseOpts{Lint: lint}})
+		if errs != nil {
+			t.Fatalf("mappedDefinitions(lint: %v): %v", lint, errs)
+		}
+		if got := len(mdef.lintFindings) != 0; got != lint {
+			t.Errorf("mappedDefinitions(lint: %v): got findings %v", lint, mdef.lintFindings)
+		}
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"github.com/openconfig/ygot/genutil"
+)
+
+// IROpt is an interface implemented by the options to NewIROptions. Each
+// option corresponds to a field of IROptions, and to a flag of the generator
+// binaries.
+type IROpt interface {
+	// IsIROpt is a marker method for each IROpt.
+	IsIROpt()
+}
+
+// IsIROpt marks ParseOpts as a valid IROpt, which replaces the parse options
+// set by the options that precede it.
+func (ParseOpts) IsIROpt() {}
+
+// IsIROpt marks TransformationOpts as a valid IROpt, which replaces the
+// transformation options set by the options that precede it.
+func (TransformationOpts) IsIROpt() {}
+
+// IgnoreUnsupportedStatements is an IROpt that specifies that unsupported
+// YANG statements are ignored when parsing.
+type IgnoreUnsupportedStatements struct{}
+
+// IsIROpt marks IgnoreUnsupportedStatements as a valid IROpt.
+func (*IgnoreUnsupportedStatements) IsIROpt() {}
+
+// ExcludeModules is an IROpt that specifies the modules that are excluded
+// from code generation.
+type ExcludeModules []string
+
+// IsIROpt marks ExcludeModules as a valid IROpt.
+func (ExcludeModules) IsIROpt() {}
+
+// ExcludePaths is an IROpt that specifies patterns of the schema paths of
+// the data nodes that are removed prior to code being generated, as per the
+// ExcludePaths field of ParseOpts.
+type ExcludePaths []string
+
+// IsIROpt marks ExcludePaths as a valid IROpt.
+func (ExcludePaths) IsIROpt() {}
+
+// Features is an IROpt that specifies the features that are supported, as per
+// the Features field of ParseOpts.
+type Features []string
+
+// IsIROpt marks Features as a valid IROpt.
+func (Features) IsIROpt() {}
+
+// ExcludeFeatures is an IROpt that specifies the features that are not
+// supported.
+type ExcludeFeatures []string
+
+// IsIROpt marks ExcludeFeatures as a valid IROpt.
+func (ExcludeFeatures) IsIROpt() {}
+
+// Lint is an IROpt that specifies that the modules that code is
+// generated for are checked against the OpenConfig style guidelines.
+type Lint struct{}
+
+// IsIROpt marks Lint as a valid IROpt.
+func (*Lint) IsIROpt() {}
+
+// IgnoreSubmoduleCircularDependencies is an IROpt that specifies that
+// circular dependencies between submodules are ignored when parsing.
+type IgnoreSubmoduleCircularDependencies struct{}
+
+// IsIROpt marks IgnoreSubmoduleCircularDependencies as a valid IROpt.
+func (*IgnoreSubmoduleCircularDependencies) IsIROpt() {}
+
+// IgnoreDeviateNotSupported is an IROpt that specifies that "deviate
+// not-supported" statements are ignored when parsing.
+type IgnoreDeviateNotSupported struct{}
+
+// IsIROpt marks IgnoreDeviateNotSupported as a valid IROpt.
+func (*IgnoreDeviateNotSupported) IsIROpt() {}
+
+// Compression is an IROpt that specifies whether compression is enabled, and
+// whether state fields are excluded, when determining the children of each
+// entry.
+type Compression genutil.CompressBehaviour
+
+// IsIROpt marks Compression as a valid IROpt.
+func (Compression) IsIROpt() {}
+
+// GenerateFakeRoot is an IROpt that specifies that an entity representing the
+// root of the YANG schema tree is generated, with the supplied name. If the
+// name is empty, the default name is used.
+type GenerateFakeRoot string
+
+// IsIROpt marks GenerateFakeRoot as a valid IROpt.
+func (GenerateFakeRoot) IsIROpt() {}
+
+// ExcludeState is an IROpt that specifies that config false values, and their
+// children, are excluded from the generated code.
+type ExcludeState struct{}
+
+// IsIROpt marks ExcludeState as a valid IROpt.
+func (*ExcludeState) IsIROpt() {}
+
+// SkipEnumDeduplication is an IROpt that specifies that enumeration leaves
+// that are used in multiple places in the schema do not share a common type.
+type SkipEnumDeduplication struct{}
+
+// IsIROpt marks SkipEnumDeduplication as a valid IROpt.
+func (*SkipEnumDeduplication) IsIROpt() {}
+
+// ShortenEnumLeafNames is an IROpt that specifies that the module name is
+// removed from the name of enumeration leaves.
+type ShortenEnumLeafNames struct{}
+
+// IsIROpt marks ShortenEnumLeafNames as a valid IROpt.
+func (*ShortenEnumLeafNames) IsIROpt() {}
+
+// EnumOrgPrefixesToTrim is an IROpt that specifies the organization names
+// that are trimmed from the module part of the name of enumeration leaves.
+type EnumOrgPrefixesToTrim []string
+
+// IsIROpt marks EnumOrgPrefixesToTrim as a valid IROpt.
+func (EnumOrgPrefixesToTrim) IsIROpt() {}
+
+// UseDefiningModuleForTypedefEnumNames is an IROpt that specifies that
+// typedef enumerated types are prefixed with the name of their defining
+// module.
+type UseDefiningModuleForTypedefEnumNames struct{}
+
+// IsIROpt marks UseDefiningModuleForTypedefEnumNames as a valid IROpt.
+func (*UseDefiningModuleForTypedefEnumNames) IsIROpt() {}
+
+// EnumerationsUseUnderscores is an IROpt that specifies that enumeration names
+// use underscores between path segments.
+type EnumerationsUseUnderscores struct{}
+
+// IsIROpt marks EnumerationsUseUnderscores as a valid IROpt.
+func (*EnumerationsUseUnderscores) IsIROpt() {}
+
+// SkipDeprecated is an IROpt that specifies that fields with status
+// "deprecated" are excluded from the generated code.
+type SkipDeprecated struct{}
+
+// IsIROpt marks SkipDeprecated as a valid IROpt.
+func (*SkipDeprecated) IsIROpt() {}
+
+// SkipObsolete is an IROpt that specifies that fields with status "obsolete"
+// are excluded from the generated code.
+type SkipObsolete struct{}
+
+// IsIROpt marks SkipObsolete as a valid IROpt.
+func (*SkipObsolete) IsIROpt() {}
+
+// FlattenEnumerationUnions is an IROpt that specifies that unions whose
+// members are all enumerations are represented by a single enumerated type.
+type FlattenEnumerationUnions struct{}
+
+// IsIROpt marks FlattenEnumerationUnions as a valid IROpt.
+func (*FlattenEnumerationUnions) IsIROpt() {}
+
+// TypedefUnionNames is an IROpt that specifies that the types representing
+// unions that are defined by a typedef are named after the typedef.
+type TypedefUnionNames struct{}
+
+// IsIROpt marks TypedefUnionNames as a valid IROpt.
+func (*TypedefUnionNames) IsIROpt() {}
+
+// NestedDirectories is an IROpt that specifies that the generated directories
+// are nested in the IR.
+type NestedDirectories struct{}
+
+// IsIROpt marks NestedDirectories as a valid IROpt.
+func (*NestedDirectories) IsIROpt() {}
+
+// AbsoluteMapPaths is an IROpt that specifies that the path annotation of each
+// field is an absolute path.
+type AbsoluteMapPaths struct{}
+
+// IsIROpt marks AbsoluteMapPaths as a valid IROpt.
+func (*AbsoluteMapPaths) IsIROpt() {}
+
+// PathOrigin is an IROpt that specifies the origin of the generated gNMI
+// paths. If it is empty, the name of the YANG module is used as the origin.
+type PathOrigin string
+
+// IsIROpt marks PathOrigin as a valid IROpt.
+func (PathOrigin) IsIROpt() {}
+
+// NewIROptions returns the IROptions that correspond to the supplied options.
+// Where an option is supplied more than once, the last instance takes
+// precedence.
+func NewIROptions(opts ...IROpt) IROptions {
+	var o IROptions
+	for _, opt := range opts {
+		p, t := &o.ParseOptions, &o.TransformationOptions
+		switch v := opt.(type) {
+		case ParseOpts:
+			*p = v
+		case TransformationOpts:
+			*t = v
+		case *IgnoreUnsupportedStatements:
+			p.IgnoreUnsupportedStatements = true
+		case ExcludeModules:
+			p.ExcludeModules = v
+		case ExcludePaths:
+			p.ExcludePaths = v
+		case Features:
+			p.Features = v
+		case ExcludeFeatures:
+			p.ExcludeFeatures = v
+		case *Lint:
+			p.Lint = true
+		case *IgnoreSubmoduleCircularDependencies:
+			p.YANGParseOptions.IgnoreSubmoduleCircularDependencies = true
+		case *IgnoreDeviateNotSupported:
+			p.YANGParseOptions.DeviateOptions.IgnoreDeviateNotSupported = true
+		case Compression:
+			t.CompressBehaviour = genutil.CompressBehaviour(v)
+		case GenerateFakeRoot:
+			t.GenerateFakeRoot = true
+			t.FakeRootName = string(v)
+		case *ExcludeState:
+			t.ExcludeState = true
+		case *SkipEnumDeduplication:
+			t.SkipEnumDeduplication = true
+		case *ShortenEnumLeafNames:
+			t.ShortenEnumLeafNames = true
+		case EnumOrgPrefixesToTrim:
+			t.EnumOrgPrefixesToTrim = v
+		case *UseDefiningModuleForTypedefEnumNames:
+			t.UseDefiningModuleForTypedefEnumNames = true
+		case *EnumerationsUseUnderscores:
+			t.EnumerationsUseUnderscores = true
+		case *SkipDeprecated:
+			t.SkipDeprecated = true
+		case *SkipObsolete:
+			t.SkipObsolete = true
+		case *FlattenEnumerationUnions:
+			t.FlattenEnumerationUnions = true
+		case *TypedefUnionNames:
+			t.TypedefUnionNames = true
+		case *NestedDirectories:
+			o.NestedDirectories = true
+		case *AbsoluteMapPaths:
+			o.AbsoluteMapPaths = true
+		case PathOrigin:
+			o.UseModuleNameAsPathOrigin = v == ""
+			o.PathOriginName = string(v)
+		}
+	}
+	return o
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/genutil"
+)
+
+func TestNewIROptions(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   []IROpt
+		want IROptions
+	}{{
+		desc: "no options",
+		want: IROptions{},
+	}, {
+		desc: "parse options",
+		in: []IROpt{
+			&IgnoreUnsupportedStatements{},
+			ExcludeModules{"ietf-interfaces"},
+			ExcludePaths{"/**/state"},
+			Features{"f1"},
+			ExcludeFeatures{"f2"},
+			&Lint{},
+			&IgnoreSubmoduleCircularDependencies{},
+			&IgnoreDeviateNotSupported{},
+		},
+		want: IROptions{
+			ParseOptions: ParseOpts{
+				IgnoreUnsupportedStatements: true,
+				ExcludeModules:              []string{"ietf-interfaces"},
+				ExcludePaths:                []string{"/**/state"},
+				Features:                    []string{"f1"},
+				ExcludeFeatures:             []string{"f2"},
+				Lint:                        true,
+				YANGParseOptions: yang.Options{
+					IgnoreSubmoduleCircularDependencies: true,
+					DeviateOptions: yang.DeviateOptions{
+						IgnoreDeviateNotSupported: true,
+					},
+				},
+			},
+		},
+	}, {
+		desc: "transformation options",
+		in: []IROpt{
+			Compression(genutil.PreferIntendedConfig),
+			GenerateFakeRoot("Device"),
+			&ExcludeState{},
+			&SkipEnumDeduplication{},
+			&ShortenEnumLeafNames{},
+			EnumOrgPrefixesToTrim{"openconfig"},
+			&UseDefiningModuleForTypedefEnumNames{},
+			&EnumerationsUseUnderscores{},
+			&SkipDeprecated{},
+			&SkipObsolete{},
+			&FlattenEnumerationUnions{},
+			&TypedefUnionNames{},
+		},
+		want: IROptions{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				GenerateFakeRoot:                     true,
+				FakeRootName:                         "Device",
+				ExcludeState:                         true,
+				SkipEnumDeduplication:                true,
+				ShortenEnumLeafNames:                 true,
+				EnumOrgPrefixesToTrim:                []string{"openconfig"},
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+				SkipDeprecated:                       true,
+				SkipObsolete:                         true,
+				FlattenEnumerationUnions:             true,
+				TypedefUnionNames:                    true,
+			},
+		},
+	}, {
+		desc: "IR options",
+		in:   []IROpt{&NestedDirectories{}, &AbsoluteMapPaths{}, PathOrigin("openconfig")},
+		want: IROptions{
+			NestedDirectories: true,
+			AbsoluteMapPaths:  true,
+			PathOriginName:    "openconfig",
+		},
+	}, {
+		desc: "module name as path origin",
+		in:   []IROpt{PathOrigin("")},
+		want: IROptions{
+			UseModuleNameAsPathOrigin: true,
+		},
+	}, {
+		desc: "later options take precedence",
+		in: []IROpt{
+			&SkipDeprecated{},
+			TransformationOpts{ExcludeState: true},
+			ExcludeModules{"a"},
+			ExcludeModules{"b"},
+		},
+		want: IROptions{
+			ParseOptions: ParseOpts{
+				ExcludeModules: []string{"b"},
+			},
+			TransformationOptions: TransformationOpts{
+				ExcludeState: true,
+			},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, NewIROptions(tt.in...)); diff != "" {
+				t.Errorf("NewIROptions: did not get expected IROptions, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
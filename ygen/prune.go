@@ -0,0 +1,310 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
+
+// nodePruner removes data nodes from the schema trees of modules, prior to
+// code being generated for them.
+type nodePruner struct {
+	// excludePaths is the set of patterns of the paths of data nodes that
+	// are removed, each of which is split into its elements.
+	excludePaths [][]string
+	// features is the set of features that are supported, keyed by
+	// module:feature or by feature alone, or nil if all features that are
+	// not in excludeFeatures are supported.
+	features map[string]bool
+	// excludeFeatures is the set of features that are not supported,
+	// keyed by module:feature or by feature alone.
+	excludeFeatures map[string]bool
+}
+
+// newNodePruner returns a nodePruner for the ExcludePaths, Features and
+// ExcludeFeatures of opts, or nil if no data nodes are to be removed.
+func newNodePruner(opts ParseOpts) (*nodePruner, error) {
+	if len(opts.ExcludePaths) == 0 && len(opts.Features) == 0 && len(opts.ExcludeFeatures) == 0 {
+		return nil, nil
+	}
+	p := &nodePruner{excludeFeatures: map[string]bool{}}
+	for _, pat := range opts.ExcludePaths {
+		elems := strings.Split(strings.Trim(pat, "/"), "/")
+		for i, el := range elems {
+			// Elements may be prefixed, but are matched by name alone.
+			if _, name, ok := strings.Cut(el, ":"); ok && el != "**" {
+				elems[i] = name
+			}
+			if _, err := path.Match(elems[i], ""); err != nil {
+				return nil, fmt.Errorf("invalid exclude path %q: %v", pat, err)
+			}
+		}
+		p.excludePaths = append(p.excludePaths, elems)
+	}
+	if len(opts.Features) != 0 {
+		p.features = map[string]bool{}
+		for _, f := range opts.Features {
+			p.features[f] = true
+		}
+	}
+	for _, f := range opts.ExcludeFeatures {
+		p.excludeFeatures[f] = true
+	}
+	return p, nil
+}
+
+// prune removes the data nodes of the modules that match excludePaths, or
+// that are conditional on features that are not supported, along with their
+// descendants.
+func (p *nodePruner) prune(modules []*yang.Entry) util.Errors {
+	var errs util.Errors
+	for _, m := range modules {
+		if m != nil {
+			errs = util.AppendErrs(errs, p.pruneChildren(m, nil))
+		}
+	}
+	return errs
+}
+
+// pruneChildren removes the children of the directory e, whose data path
+// elements are dataPath, that match excludePaths or that are conditional
+// on features that are not supported, and prunes the children of those
+// that are retained.
+func (p *nodePruner) pruneChildren(e *yang.Entry, dataPath []string) util.Errors {
+	var errs util.Errors
+	for name, ch := range e.Dir {
+		chPath := dataPath
+		// Choice and case nodes are not data nodes, and so do not appear
+		// within data paths.
+		if !util.IsChoiceOrCase(ch) {
+			chPath = append(append([]string{}, dataPath...), ch.Name)
+			if p.excluded(chPath) {
+				delete(e.Dir, name)
+				continue
+			}
+		}
+		supported, err := p.supported(ch.Node)
+		if err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("%s: %v", ch.Path(), err))
+			continue
+		}
+		if !supported {
+			delete(e.Dir, name)
+			continue
+		}
+		if ch.Dir != nil {
+			errs = util.AppendErrs(errs, p.pruneChildren(ch, chPath))
+		}
+	}
+	return errs
+}
+
+// excluded returns true if the data path elems matches any of
+// excludePaths.
+func (p *nodePruner) excluded(elems []string) bool {
+	for _, pat := range p.excludePaths {
+		if matchPath(pat, elems) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPath returns true if the data path elems matches the pattern pat,
+// each of whose elements is matched against a single element of the path
+// using path.Match, except for "**", which matches any number of elements.
+func matchPath(pat, elems []string) bool {
+	switch {
+	case len(pat) == 0:
+		return len(elems) == 0
+	case pat[0] == "**":
+		for i := 0; i <= len(elems); i++ {
+			if matchPath(pat[1:], elems[i:]) {
+				return true
+			}
+		}
+		return false
+	case len(elems) == 0:
+		return false
+	}
+	if ok, _ := path.Match(pat[0], elems[0]); !ok {
+		return false
+	}
+	return matchPath(pat[1:], elems[1:])
+}
+
+// supported returns true if the if-feature statements of the node n, and
+// of the augment statement that n is a direct child of, are all satisfied
+// by the supported features.
+func (p *nodePruner) supported(n yang.Node) (bool, error) {
+	if n == nil {
+		return true, nil
+	}
+	conds := ifFeatures(n)
+	if a, ok := n.ParentNode().(*yang.Augment); ok {
+		conds = append(conds, a.IfFeature...)
+	}
+	for _, c := range conds {
+		ok, err := p.evalIfFeature(n, c.Name)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ifFeatures returns the if-feature statements of the node n.
+func ifFeatures(n yang.Node) []*yang.Value {
+	switch n := n.(type) {
+	case *yang.Container:
+		return n.IfFeature
+	case *yang.Leaf:
+		return n.IfFeature
+	case *yang.LeafList:
+		return n.IfFeature
+	case *yang.List:
+		return n.IfFeature
+	case *yang.Choice:
+		return n.IfFeature
+	case *yang.Case:
+		return n.IfFeature
+	case *yang.AnyData:
+		return n.IfFeature
+	case *yang.AnyXML:
+		return n.IfFeature
+	}
+	return nil
+}
+
+// evalIfFeature evaluates the argument expr of an if-feature statement of
+// the node n, which is a feature name or, as per RFC7950 Section 7.20.2, an
+// expression combining feature names with "not", "and", "or" and
+// parentheses.
+func (p *nodePruner) evalIfFeature(n yang.Node, expr string) (bool, error) {
+	e := &featureExpr{
+		tokens:    strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expr)),
+		node:      n,
+		supported: p.featureSupported,
+	}
+	v, err := e.or()
+	if err == nil && e.pos != len(e.tokens) {
+		err = fmt.Errorf("unexpected %q", e.tokens[e.pos])
+	}
+	if err != nil {
+		return false, fmt.Errorf("invalid if-feature expression %q: %v", expr, err)
+	}
+	return v, nil
+}
+
+// featureSupported returns true if the feature name of module mod is
+// supported.
+func (p *nodePruner) featureSupported(mod, name string) bool {
+	if p.excludeFeatures[mod+":"+name] || p.excludeFeatures[name] {
+		return false
+	}
+	return p.features == nil || p.features[mod+":"+name] || p.features[name]
+}
+
+// featureExpr is the state of the evaluation of an if-feature expression.
+type featureExpr struct {
+	// tokens are the tokens of the expression.
+	tokens []string
+	// pos is the index of the next token to be evaluated.
+	pos int
+	// node is the node whose if-feature statement is evaluated, which
+	// is used to resolve the prefixes of feature names.
+	node yang.Node
+	// supported returns true if the feature name of module mod is
+	// supported.
+	supported func(mod, name string) bool
+}
+
+// or evaluates an expression of the form term ("or" term)*.
+func (e *featureExpr) or() (bool, error) {
+	v, err := e.and()
+	for err == nil && e.pos < len(e.tokens) && e.tokens[e.pos] == "or" {
+		e.pos++
+		var t bool
+		t, err = e.and()
+		v = v || t
+	}
+	return v, err
+}
+
+// and evaluates an expression of the form factor ("and" factor)*.
+func (e *featureExpr) and() (bool, error) {
+	v, err := e.factor()
+	for err == nil && e.pos < len(e.tokens) && e.tokens[e.pos] == "and" {
+		e.pos++
+		var f bool
+		f, err = e.factor()
+		v = v && f
+	}
+	return v, err
+}
+
+// factor evaluates a negated factor, a parenthesised expression or a
+// feature name.
+func (e *featureExpr) factor() (bool, error) {
+	if e.pos == len(e.tokens) {
+		return false, fmt.Errorf("unexpected end of expression")
+	}
+	tok := e.tokens[e.pos]
+	e.pos++
+	switch tok {
+	case "not":
+		v, err := e.factor()
+		return !v, err
+	case "(":
+		v, err := e.or()
+		if err != nil {
+			return false, err
+		}
+		if e.pos == len(e.tokens) || e.tokens[e.pos] != ")" {
+			return false, fmt.Errorf("missing )")
+		}
+		e.pos++
+		return v, nil
+	case ")", "and", "or":
+		return false, fmt.Errorf("unexpected %q", tok)
+	}
+
+	prefix, name, ok := strings.Cut(tok, ":")
+	if !ok {
+		prefix, name = "", tok
+	}
+	var m *yang.Module
+	if prefix == "" {
+		m = yang.RootNode(e.node)
+	} else {
+		m = yang.FindModuleByPrefix(e.node, prefix)
+	}
+	if m == nil {
+		return false, fmt.Errorf("unknown prefix %q", prefix)
+	}
+	mod := m.Name
+	if m.BelongsTo != nil {
+		mod = m.BelongsTo.Name
+	}
+	return e.supported(mod, name), nil
+}
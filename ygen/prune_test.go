@@ -0,0 +1,250 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
+
+const pruneTestModule = `module prune {
+  yang-version 1.1;
+  namespace "urn:prune";
+  prefix "p";
+
+  feature alpha;
+  feature beta;
+
+  container top {
+    leaf plain { type string; }
+    leaf a { if-feature alpha; type string; }
+    leaf a-and-b { if-feature "p:alpha and beta"; type string; }
+    leaf not-a-or-b { if-feature "not alpha or (beta)"; type string; }
+    container state {
+      leaf counter { type uint32; }
+    }
+    list item {
+      key "name";
+      leaf name { type string; }
+      container state {
+        leaf counter { type uint32; }
+      }
+    }
+    choice mode {
+      case fast {
+        if-feature beta;
+        leaf fast { type boolean; }
+      }
+      leaf slow { type boolean; }
+    }
+  }
+
+  augment "/p:top" {
+    if-feature alpha;
+    leaf augmented { type string; }
+  }
+}
+`
+
+// prunedLeaves returns the paths of the data nodes of the module prune
+// that remain after the options opts are applied.
+func prunedLeaves(t *testing.T, opts ParseOpts) ([]string, util.Errors) {
+	t.Helper()
+	fn := filepath.Join(t.TempDir(), "prune.yang")
+	if err := os.WriteFile(fn, []byte(pruneTestModule), 0o644); err != nil {
+		t.Fatalf("cannot write module: %v", err)
+	}
+	mdef, errs := mappedDefinitions([]string{fn}, nil, IROptions{ParseOptions: opts})
+	if errs != nil {
+		return nil, errs
+	}
+	var got []string
+	var walk func(e *yang.Entry)
+	walk = func(e *yang.Entry) {
+		for _, ch := range util.Children(e) {
+			if !util.IsChoiceOrCase(ch) {
+				got = append(got, util.SchemaTreePathNoModule(ch))
+			}
+			walk(ch)
+		}
+	}
+	for _, m := range mdef.modules {
+		walk(m)
+	}
+	sort.Strings(got)
+	return got, nil
+}
+
+func TestPruneEntries(t *testing.T) {
+	all := []string{
+		"/top",
+		"/top/a",
+		"/top/a-and-b",
+		"/top/augmented",
+		"/top/fast",
+		"/top/item",
+		"/top/item/name",
+		"/top/item/state",
+		"/top/item/state/counter",
+		"/top/not-a-or-b",
+		"/top/plain",
+		"/top/slow",
+		"/top/state",
+		"/top/state/counter",
+	}
+	tests := []struct {
+		desc    string
+		inOpts  ParseOpts
+		want    []string
+		wantErr string
+	}{{
+		desc:   "no options",
+		inOpts: ParseOpts{},
+		want:   all,
+	}, {
+		desc:   "excluded features",
+		inOpts: ParseOpts{ExcludeFeatures: []string{"prune:alpha"}},
+		want: []string{
+			"/top",
+			"/top/fast",
+			"/top/item",
+			"/top/item/name",
+			"/top/item/state",
+			"/top/item/state/counter",
+			"/top/not-a-or-b",
+			"/top/plain",
+			"/top/slow",
+			"/top/state",
+			"/top/state/counter",
+		},
+	}, {
+		desc:   "supported features",
+		inOpts: ParseOpts{Features: []string{"alpha"}},
+		want: []string{
+			"/top",
+			"/top/a",
+			"/top/augmented",
+			"/top/item",
+			"/top/item/name",
+			"/top/item/state",
+			"/top/item/state/counter",
+			"/top/plain",
+			"/top/slow",
+			"/top/state",
+			"/top/state/counter",
+		},
+	}, {
+		desc:   "excluded path with wildcards",
+		inOpts: ParseOpts{ExcludePaths: []string{"/**/state", "/p:top/a*"}},
+		want: []string{
+			"/top",
+			"/top/fast",
+			"/top/item",
+			"/top/item/name",
+			"/top/not-a-or-b",
+			"/top/plain",
+			"/top/slow",
+		},
+	}, {
+		desc:   "excluded path within choice",
+		inOpts: ParseOpts{ExcludePaths: []string{"top/fast", "/top/item/*/counter"}},
+		want: []string{
+			"/top",
+			"/top/a",
+			"/top/a-and-b",
+			"/top/augmented",
+			"/top/item",
+			"/top/item/name",
+			"/top/item/state",
+			"/top/not-a-or-b",
+			"/top/plain",
+			"/top/slow",
+			"/top/state",
+			"/top/state/counter",
+		},
+	}, {
+		desc:    "invalid pattern",
+		inOpts:  ParseOpts{ExcludePaths: []string{"/top/["}},
+		wantErr: `invalid exclude path "/top/["`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, errs := prunedLeaves(t, tt.inOpts)
+			if tt.wantErr != "" {
+				if errs == nil || !strings.Contains(errs.Error(), tt.wantErr) {
+					t.Fatalf("got errors %v, want error containing %q", errs, tt.wantErr)
+				}
+				return
+			}
+			if errs != nil {
+				t.Fatalf("got unexpected errors: %v", errs)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("did not get expected data nodes (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEvalIfFeature(t *testing.T) {
+	ms := yang.NewModules()
+	if err := ms.Parse(pruneTestModule, "prune.yang"); err != nil {
+		t.Fatalf("cannot parse module: %v", err)
+	}
+	m, ok := ms.Modules["prune"]
+	if !ok {
+		t.Fatalf("module prune not found")
+	}
+	p := &nodePruner{features: map[string]bool{"alpha": true}, excludeFeatures: map[string]bool{}}
+
+	tests := []struct {
+		in      string
+		want    bool
+		wantErr string
+	}{
+		{in: "alpha", want: true},
+		{in: "p:beta", want: false},
+		{in: "not beta and (alpha or beta)", want: true},
+		{in: "not (alpha)", want: false},
+		{in: "alpha and", wantErr: "unexpected end of expression"},
+		{in: "(alpha", wantErr: "missing )"},
+		{in: "alpha beta", wantErr: `unexpected "beta"`},
+		{in: "x:alpha", wantErr: `unknown prefix "x"`},
+	}
+	for _, tt := range tests {
+		got, err := p.evalIfFeature(m, tt.in)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("evalIfFeature(%q): got error %v, want error containing %q", tt.in, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("evalIfFeature(%q): got unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("evalIfFeature(%q): got %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
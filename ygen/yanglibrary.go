@@ -0,0 +1,130 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// YANGLibrary returns the ietf-yang-library (RFC 8525) description of the
+// modules from which the IR was generated, sorted by module name. Each
+// module is reported with its most recent revision, all of the features
+// that it defines, the modules that contain deviations of it, and the
+// submodules that it includes.
+func (ir *IR) YANGLibrary() ([]*ygot.YANGLibraryModule, error) {
+	return FindYANGLibraryModules(ir.parsedModules)
+}
+
+// FindYANGLibraryModules takes an input slice of yang.Entry pointers, which
+// are assumed to represent YANG modules, and returns the ietf-yang-library
+// description of each of the input modules, sorted by module name.
+func FindYANGLibraryModules(mods []*yang.Entry) ([]*ygot.YANGLibraryModule, error) {
+	deviations := map[string]map[string]bool{}
+	var modules []*ygot.YANGLibraryModule
+	for _, mod := range mods {
+		mNode, ok := mod.Node.(*yang.Module)
+		if !ok || mNode == nil {
+			return nil, fmt.Errorf("nil node, or not a module for node %s", mod.Name)
+		}
+		m := &ygot.YANGLibraryModule{Name: ygot.String(mod.Name)}
+		if mNode.Namespace != nil {
+			m.Namespace = ygot.String(mNode.Namespace.Name)
+		}
+		if rev := mNode.Current(); rev != "" {
+			m.Revision = ygot.String(rev)
+		}
+
+		features := map[string]bool{}
+		for _, f := range mNode.Feature {
+			features[f.Name] = true
+		}
+		for _, inc := range mNode.Include {
+			if inc.Module == nil {
+				continue
+			}
+			if m.Submodule == nil {
+				m.Submodule = map[string]*ygot.YANGLibrarySubmodule{}
+			}
+			sm := &ygot.YANGLibrarySubmodule{Name: ygot.String(inc.Module.Name)}
+			if rev := inc.Module.Current(); rev != "" {
+				sm.Revision = ygot.String(rev)
+			}
+			m.Submodule[inc.Module.Name] = sm
+			for _, f := range inc.Module.Feature {
+				features[f.Name] = true
+			}
+		}
+		m.Feature = sortedKeys(features)
+
+		for _, d := range deviationTargets(mNode) {
+			if d == mod.Name {
+				continue
+			}
+			if deviations[d] == nil {
+				deviations[d] = map[string]bool{}
+			}
+			deviations[d][mod.Name] = true
+		}
+		modules = append(modules, m)
+	}
+
+	for _, m := range modules {
+		m.Deviation = sortedKeys(deviations[*m.Name])
+	}
+	sort.Slice(modules, func(i, j int) bool { return *modules[i].Name < *modules[j].Name })
+	return modules, nil
+}
+
+// deviationTargets returns the names of the modules that are the targets of
+// the deviation statements within the module m, or its submodules.
+func deviationTargets(m *yang.Module) []string {
+	devs := append([]*yang.Deviation{}, m.Deviation...)
+	for _, inc := range m.Include {
+		if inc.Module != nil {
+			devs = append(devs, inc.Module.Deviation...)
+		}
+	}
+	var targets []string
+	for _, d := range devs {
+		first, _, _ := strings.Cut(strings.TrimPrefix(d.Name, "/"), "/")
+		pfx, _, ok := strings.Cut(first, ":")
+		if !ok {
+			continue
+		}
+		if t := yang.FindModuleByPrefix(d, pfx); t != nil {
+			targets = append(targets, t.Name)
+		}
+	}
+	return targets
+}
+
+// sortedKeys returns the keys of the map m in sorted order, or nil if m is
+// empty.
+func sortedKeys(m map[string]bool) []string {
+	if len(m) == 0 {
+		return nil
+	}
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,47 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+)
+
+// EncodeBinary writes the contents of r to w using the base64 encoding that
+// is used for YANG binary values in JSON (RFC7951 Section 6.6), returning
+// the number of bytes that were read from r. The contents are encoded
+// incrementally as they are read, such that large binary values, such as
+// certificates or packet captures, can be encoded without holding either
+// their contents or their encoded form in memory.
+func EncodeBinary(w io.Writer, r io.Reader) (int64, error) {
+	enc := base64.NewEncoder(base64.StdEncoding, w)
+	n, err := io.Copy(enc, r)
+	if err != nil {
+		return n, fmt.Errorf("cannot encode binary value: %v", err)
+	}
+	// Close flushes any partially encoded block, along with its padding.
+	if err := enc.Close(); err != nil {
+		return n, fmt.Errorf("cannot encode binary value: %v", err)
+	}
+	return n, nil
+}
+
+// DecodeBinary returns a reader of the contents of the binary value whose
+// base64 encoding, as used in JSON, is read from r. The value is decoded
+// incrementally as it is read.
+func DecodeBinary(r io.Reader) io.Reader {
+	return base64.NewDecoder(base64.StdEncoding, r)
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+// errReader is an io.Reader that returns an error once its contents have
+// been read.
+type errReader struct {
+	r io.Reader
+}
+
+func (e *errReader) Read(p []byte) (int, error) {
+	n, err := e.r.Read(p)
+	if err == io.EOF {
+		return n, errors.New("read failed")
+	}
+	return n, err
+}
+
+func TestEncodeBinary(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               io.Reader
+		want             string
+		wantN            int64
+		wantErrSubstring string
+	}{{
+		desc:  "empty",
+		in:    strings.NewReader(""),
+		want:  "",
+		wantN: 0,
+	}, {
+		desc:  "padded value",
+		in:    strings.NewReader("ygot"),
+		want:  "eWdvdA==",
+		wantN: 4,
+	}, {
+		desc:  "value larger than the copy buffer",
+		in:    bytes.NewReader(bytes.Repeat([]byte{0xff}, 3*40000)),
+		want:  strings.Repeat("////", 40000),
+		wantN: 3 * 40000,
+	}, {
+		desc:             "read error",
+		in:               &errReader{r: strings.NewReader("ygot")},
+		wantErrSubstring: "read failed",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var b strings.Builder
+			n, err := EncodeBinary(&b, tt.in)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("EncodeBinary: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if got := b.String(); got != tt.want || n != tt.wantN {
+				t.Errorf("EncodeBinary: got (%q, %d), want (%q, %d)", got, n, tt.want, tt.wantN)
+			}
+
+			got, err := io.ReadAll(DecodeBinary(strings.NewReader(b.String())))
+			if err != nil {
+				t.Fatalf("DecodeBinary: got unexpected error: %v", err)
+			}
+			if int64(len(got)) != tt.wantN {
+				t.Errorf("DecodeBinary: got %d bytes, want %d", len(got), tt.wantN)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// BitsTypeName is the name of the type that is used for YANG bits fields in
+// the output structs. Unlike the Binary and YANGEmpty types, the type is
+// defined in this package rather than in the generated code.
+const BitsTypeName string = "Bits"
+
+// Bits is the type used to represent the value of a YANG bits leaf, which is
+// the set of the names of the bits that are set (RFC7950 Section 9.7). The
+// names are stored in sorted order, without duplicates, such that two Bits
+// values that have the same bits set are equal.
+type Bits []string
+
+// bitsType is the reflect.Type of Bits.
+var bitsType = reflect.TypeOf(Bits(nil))
+
+// ParseBits returns the Bits whose set bits are named in the space-separated
+// string s, which is the lexical representation of a YANG bits value.
+func ParseBits(s string) Bits {
+	var b Bits
+	for _, name := range strings.Fields(s) {
+		b.Set(name)
+	}
+	return b
+}
+
+// Has reports whether the bit with the specified name is set.
+func (b Bits) Has(name string) bool {
+	i := sort.SearchStrings(b, name)
+	return i < len(b) && b[i] == name
+}
+
+// Set sets the bit with the specified name.
+func (b *Bits) Set(name string) {
+	i := sort.SearchStrings(*b, name)
+	if i < len(*b) && (*b)[i] == name {
+		return
+	}
+	*b = append(*b, "")
+	copy((*b)[i+1:], (*b)[i:])
+	(*b)[i] = name
+}
+
+// Clear unsets the bit with the specified name.
+func (b *Bits) Clear(name string) {
+	i := sort.SearchStrings(*b, name)
+	if i < len(*b) && (*b)[i] == name {
+		*b = append((*b)[:i], (*b)[i+1:]...)
+	}
+	if len(*b) == 0 {
+		*b = nil
+	}
+}
+
+// String returns the lexical representation of b, which is the
+// space-separated names of the bits that are set.
+func (b Bits) String() string {
+	return strings.Join(b, " ")
+}
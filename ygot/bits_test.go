@@ -0,0 +1,110 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestBits(t *testing.T) {
+	b := ParseBits(" SYN  ACK SYN ")
+	if diff := cmp.Diff(Bits{"ACK", "SYN"}, b); diff != "" {
+		t.Errorf("ParseBits: (-want, +got):\n%s", diff)
+	}
+
+	b.Set("FIN")
+	b.Set("ACK")
+	if diff := cmp.Diff(Bits{"ACK", "FIN", "SYN"}, b); diff != "" {
+		t.Errorf("Set: (-want, +got):\n%s", diff)
+	}
+	if !b.Has("FIN") || b.Has("RST") {
+		t.Errorf("Has: got (FIN: %v, RST: %v), want (true, false)", b.Has("FIN"), b.Has("RST"))
+	}
+	if got, want := b.String(), "ACK FIN SYN"; got != want {
+		t.Errorf("String: got %q, want %q", got, want)
+	}
+
+	b.Clear("RST")
+	b.Clear("FIN")
+	b.Clear("ACK")
+	if diff := cmp.Diff(Bits{"SYN"}, b); diff != "" {
+		t.Errorf("Clear: (-want, +got):\n%s", diff)
+	}
+	b.Clear("SYN")
+	if b != nil {
+		t.Errorf("Clear of last bit: got %v, want nil", b)
+	}
+
+	var unset Bits
+	unset.Set("RST")
+	if diff := cmp.Diff(Bits{"RST"}, unset); diff != "" {
+		t.Errorf("Set on nil Bits: (-want, +got):\n%s", diff)
+	}
+}
+
+// bitsTestStruct is a GoStruct containing bits leaves.
+type bitsTestStruct struct {
+	Flags    Bits   `path:"flags"`
+	FlagSets []Bits `path:"flag-sets"`
+}
+
+func (*bitsTestStruct) IsYANGGoStruct() {}
+
+func TestEncodeBits(t *testing.T) {
+	s := &bitsTestStruct{
+		Flags:    Bits{"ACK", "SYN"},
+		FlagSets: []Bits{{"FIN"}, {"ACK", "RST"}},
+	}
+
+	gotTV, err := EncodeTypedValue(s.Flags, gnmipb.Encoding_JSON)
+	if err != nil {
+		t.Fatalf("EncodeTypedValue(%v): got unexpected error: %v", s.Flags, err)
+	}
+	wantTV := &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "ACK SYN"}}
+	if diff := cmp.Diff(wantTV, gotTV, protocmp.Transform()); diff != "" {
+		t.Errorf("EncodeTypedValue(%v): (-want, +got):\n%s", s.Flags, diff)
+	}
+
+	gotTV, err = EncodeTypedValue(s.FlagSets, gnmipb.Encoding_JSON)
+	if err != nil {
+		t.Fatalf("EncodeTypedValue(%v): got unexpected error: %v", s.FlagSets, err)
+	}
+	wantTV = &gnmipb.TypedValue{Value: &gnmipb.TypedValue_LeaflistVal{LeaflistVal: &gnmipb.ScalarArray{
+		Element: []*gnmipb.TypedValue{
+			{Value: &gnmipb.TypedValue_StringVal{StringVal: "FIN"}},
+			{Value: &gnmipb.TypedValue_StringVal{StringVal: "ACK RST"}},
+		},
+	}}}
+	if diff := cmp.Diff(wantTV, gotTV, protocmp.Transform()); diff != "" {
+		t.Errorf("EncodeTypedValue(%v): (-want, +got):\n%s", s.FlagSets, diff)
+	}
+
+	gotJSON, err := ConstructIETFJSON(s, nil)
+	if err != nil {
+		t.Fatalf("ConstructIETFJSON: got unexpected error: %v", err)
+	}
+	wantJSON := map[string]any{
+		"flags":     "ACK SYN",
+		"flag-sets": []any{"FIN", "ACK RST"},
+	}
+	if diff := cmp.Diff(wantJSON, gotJSON); diff != "" {
+		t.Errorf("ConstructIETFJSON: (-want, +got):\n%s", diff)
+	}
+}
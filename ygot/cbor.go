@@ -0,0 +1,378 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
+
+// CBOR tags that are used within YANG-CBOR, as per RFC9254 section 9.3.
+const (
+	// CBORTagDecimalFraction is the tag of decimal64 values.
+	CBORTagDecimalFraction uint64 = 4
+	// CBORTagBits is the tag of bits values that are encoded as their names
+	// within a union.
+	CBORTagBits uint64 = 43
+	// CBORTagEnumeration is the tag of enumeration values that are encoded
+	// as their names within a union.
+	CBORTagEnumeration uint64 = 44
+)
+
+// CBORKeyEncoding specifies how the members of the CBOR maps that represent
+// containers and list entries are identified within YANG-CBOR.
+type CBORKeyEncoding int
+
+const (
+	// CBORNameKeys specifies that members are identified by their names, as
+	// per RFC9254 section 3.3. The name of a member is qualified by the name
+	// of its module where its namespace differs from that of its parent.
+	CBORNameKeys CBORKeyEncoding = iota
+	// CBORSIDKeys specifies that members are identified by their YANG
+	// Schema Item iDentifiers (SIDs), as per RFC9254 section 3.2. The SID
+	// of a member is delta-encoded, such that it is the difference between
+	// its SID and the SID of its parent. Members of the root are identified
+	// by their absolute SIDs.
+	CBORSIDKeys
+)
+
+// EmitCBORConfig specifies how YANG-CBOR should be created by the EmitCBOR
+// function.
+type EmitCBORConfig struct {
+	// Schema is the schema of the GoStruct that is emitted. It is required
+	// in order to determine the YANG-CBOR encoding of each leaf.
+	Schema *yang.Entry
+	// KeyEncoding specifies how the members of containers and list entries
+	// are identified.
+	KeyEncoding CBORKeyEncoding
+	// SIDs maps the schema node identifiers of data nodes to their SIDs,
+	// and must contain the SID of each data node that is emitted when
+	// KeyEncoding is CBORSIDKeys. Schema node identifiers are of the form
+	// used within SID files, e.g.,
+	// "/openconfig-interfaces:interfaces/interface/config/mtu", where the
+	// name of a node is qualified by the name of its module where its
+	// namespace differs from that of its parent.
+	SIDs map[string]uint64
+	// SkipValidation specifies whether the GoStruct supplied to EmitCBOR
+	// should be validated before emitting its content. Validation is skipped
+	// when it is set to true.
+	SkipValidation bool
+	// ValidationOpts is the set of options that should be used to determine
+	// how the schema should be validated.
+	ValidationOpts []ValidationOption
+}
+
+// EmitCBOR takes an input GoStruct (produced by ygen with validation enabled)
+// and serialises it to YANG-CBOR, as per RFC9254. Integers, booleans,
+// strings and binary values are encoded as the corresponding CBOR major
+// types, decimal64 values are encoded as decimal fractions, enumerations are
+// encoded as their integer values, bits are encoded as byte strings in which
+// the bit at each position is set, and empty leaves are encoded as null.
+// identityref values are always encoded as their names. Within a union,
+// enumeration and bits values are encoded as their names, enclosed within
+// the tags of RFC9254 section 9.3. The YANG-CBOR can be unmarshalled into a
+// GoStruct using ytypes.UnmarshalCBOR.
+func EmitCBOR(gs GoStruct, opts *EmitCBORConfig) ([]byte, error) {
+	if opts == nil || opts.Schema == nil {
+		return nil, fmt.Errorf("EmitCBOR requires the schema of the GoStruct")
+	}
+	if !opts.SkipValidation {
+		if err := ValidateGoStruct(gs, opts.ValidationOpts...); err != nil {
+			return nil, fmt.Errorf("validation err: %v", err)
+		}
+	}
+
+	v, err := ConstructIETFJSON(gs, &RFC7951JSONConfig{AppendModuleName: true})
+	if err != nil {
+		return nil, fmt.Errorf("ConstructIETFJSON error: %v", err)
+	}
+	nv, err := normalizeJSONValue(v)
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := nv.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid JSON rendering of %T: %T", gs, nv)
+	}
+
+	enc := &cborEncoder{sids: opts.KeyEncoding == CBORSIDKeys, sidMap: opts.SIDs}
+	c, err := enc.object(opts.Schema, "", 0, obj)
+	if err != nil {
+		return nil, err
+	}
+	return util.MarshalCBOR(c)
+}
+
+// cborEncoder converts the RFC7951 JSON rendering of a data tree, in which
+// names are qualified by the name of their module, to YANG-CBOR.
+type cborEncoder struct {
+	// sids specifies that members are identified by their SIDs.
+	sids bool
+	// sidMap maps schema node identifiers to SIDs.
+	sidMap map[string]uint64
+}
+
+// object returns the CBOR map representing obj, which is the JSON rendering
+// of the contents of the directory e, whose schema node identifier is id,
+// and whose SID is sid.
+func (c *cborEncoder) object(e *yang.Entry, id string, sid uint64, obj map[string]any) (map[any]any, error) {
+	m := make(map[any]any, len(obj))
+	for k, v := range obj {
+		ch := childSchema(e, util.StripModulePrefix(k))
+		if ch == nil {
+			return nil, fmt.Errorf("cannot find schema for %s/%s", id, k)
+		}
+		chID := id + "/" + k
+		var key any = k
+		var chSID uint64
+		if c.sids {
+			var ok bool
+			if chSID, ok = c.sidMap[chID]; !ok {
+				return nil, fmt.Errorf("no SID is specified for %s", chID)
+			}
+			key = int64(chSID - sid)
+		}
+		cv, err := c.node(ch, chID, chSID, v)
+		if err != nil {
+			return nil, err
+		}
+		m[key] = cv
+	}
+	return m, nil
+}
+
+// node returns the CBOR data item representing v, which is the JSON
+// rendering of the data node e, whose schema node identifier is id, and
+// whose SID is sid.
+func (c *cborEncoder) node(e *yang.Entry, id string, sid uint64, v any) (any, error) {
+	switch {
+	case e.IsList(), e.IsLeafList():
+		arr, ok := v.([]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid JSON value for %s: got %T, want array", id, v)
+		}
+		entries := make([]any, 0, len(arr))
+		for _, ev := range arr {
+			var (
+				ce  any
+				err error
+			)
+			if obj, ok := ev.(map[string]any); ok && e.IsList() {
+				ce, err = c.object(e, id, sid, obj)
+			} else {
+				ce, err = c.leaf(e, id, ev)
+			}
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, ce)
+		}
+		return entries, nil
+	case e.IsDir():
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid JSON value for %s: got %T, want object", id, v)
+		}
+		return c.object(e, id, sid, obj)
+	}
+	return c.leaf(e, id, v)
+}
+
+// leaf returns the CBOR data item representing v, which is the JSON
+// rendering of a value of the leaf, or leaf-list, e, whose schema node
+// identifier is id.
+func (c *cborEncoder) leaf(e *yang.Entry, id string, v any) (any, error) {
+	if e.IsList() {
+		return nil, fmt.Errorf("invalid JSON value for %s: got %T, want object", id, v)
+	}
+	cv, err := cborLeafValue(e, e.Type, v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %s: %v", id, err)
+	}
+	return cv, nil
+}
+
+// cborLeafValue returns the YANG-CBOR encoding of v, which is the RFC7951
+// JSON rendering of a value of the type t of the leaf, or leaf-list, e.
+func cborLeafValue(e *yang.Entry, t *yang.YangType, v any) (any, error) {
+	t, err := resolveLeafRefType(e, t)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t.Kind {
+	case yang.Yint8, yang.Yint16, yang.Yint32:
+		f, ok := v.(float64)
+		if !ok {
+			return nil, fmt.Errorf("got %T, want number", v)
+		}
+		return int64(f), nil
+	case yang.Yuint8, yang.Yuint16, yang.Yuint32:
+		f, ok := v.(float64)
+		if !ok {
+			return nil, fmt.Errorf("got %T, want number", v)
+		}
+		return uint64(f), nil
+	case yang.Yint64:
+		s, _ := v.(string)
+		return strconv.ParseInt(s, 10, 64)
+	case yang.Yuint64:
+		s, _ := v.(string)
+		return strconv.ParseUint(s, 10, 64)
+	case yang.Ydecimal64:
+		s, _ := v.(string)
+		n, err := util.ValidateFractionDigits(t, s)
+		if err != nil {
+			return nil, err
+		}
+		m := int64(n.Value)
+		if n.Negative {
+			m = -m
+		}
+		return util.CBORTag{Number: CBORTagDecimalFraction, Content: []any{-int64(n.FractionDigits), m}}, nil
+	case yang.Yempty:
+		return nil, nil
+	case yang.Ybinary:
+		s, _ := v.(string)
+		return base64.StdEncoding.DecodeString(s)
+	case yang.Ybits:
+		s, _ := v.(string)
+		return cborBits(t, s)
+	case yang.Yenum:
+		s, _ := v.(string)
+		if t.Enum == nil || !t.Enum.IsDefined(s) {
+			return nil, fmt.Errorf("%q is not a valid enumeration value", s)
+		}
+		return t.Enum.Value(s), nil
+	case yang.Yunion:
+		return cborUnionValue(e, t, v)
+	}
+	return v, nil
+}
+
+// cborUnionValue returns the YANG-CBOR encoding of v, which is the RFC7951
+// JSON rendering of a value of the union type t of the leaf e. v is encoded
+// as the first member type of t that it is a valid value of.
+func cborUnionValue(e *yang.Entry, t *yang.YangType, v any) (any, error) {
+	for _, mt := range t.Type {
+		mt, err := resolveLeafRefType(e, mt)
+		if err != nil {
+			return nil, err
+		}
+		if !jsonUnionMember(mt, v) {
+			continue
+		}
+		switch mt.Kind {
+		case yang.Yenum:
+			return util.CBORTag{Number: CBORTagEnumeration, Content: v}, nil
+		case yang.Ybits:
+			return util.CBORTag{Number: CBORTagBits, Content: v}, nil
+		}
+		return cborLeafValue(e, mt, v)
+	}
+	return nil, fmt.Errorf("%v does not match any union member type", v)
+}
+
+// jsonUnionMember reports whether v, which is an RFC7951 JSON value, is a
+// valid value of the union member type t.
+func jsonUnionMember(t *yang.YangType, v any) bool {
+	switch v := v.(type) {
+	case float64:
+		switch t.Kind {
+		case yang.Yint8, yang.Yint16, yang.Yint32:
+			return util.ValidateIntRange(t, int64(v)) == nil
+		case yang.Yuint8, yang.Yuint16, yang.Yuint32:
+			return v >= 0 && util.ValidateUintRange(t, uint64(v)) == nil
+		}
+	case bool:
+		return t.Kind == yang.Ybool
+	case []any:
+		return t.Kind == yang.Yempty
+	case string:
+		switch t.Kind {
+		case yang.Yint64:
+			i, err := strconv.ParseInt(v, 10, 64)
+			return err == nil && util.ValidateIntRange(t, i) == nil
+		case yang.Yuint64:
+			u, err := strconv.ParseUint(v, 10, 64)
+			return err == nil && util.ValidateUintRange(t, u) == nil
+		case yang.Ydecimal64:
+			return util.ValidateDecimalString(t, v) == nil
+		case yang.Yenum:
+			return t.Enum != nil && t.Enum.IsDefined(v)
+		case yang.Ybits:
+			_, err := cborBits(t, v)
+			return err == nil
+		case yang.Yidentityref:
+			if t.IdentityBase == nil {
+				return true
+			}
+			for _, id := range t.IdentityBase.Values {
+				if id.Name == util.StripModulePrefix(v) {
+					return true
+				}
+			}
+		case yang.Ybinary:
+			_, err := base64.StdEncoding.DecodeString(v)
+			return err == nil
+		case yang.Ystring, yang.YinstanceIdentifier:
+			return util.ValidateStringRestrictions(t, v) == nil
+		}
+	}
+	return false
+}
+
+// cborBits returns the byte string encoding of the space-separated names of
+// the bits of the bits type t within s, as per RFC9254 section 6.7, in which
+// the bit at position p is the bit (p mod 8) of the byte at index (p div 8),
+// counting from the least significant bit.
+func cborBits(t *yang.YangType, s string) ([]byte, error) {
+	b := []byte{}
+	for _, name := range strings.Fields(s) {
+		if t.Bit == nil || !t.Bit.IsDefined(name) {
+			return nil, fmt.Errorf("%q is not a valid bit name", name)
+		}
+		p := t.Bit.Value(name)
+		for int64(len(b)) <= p/8 {
+			b = append(b, 0)
+		}
+		b[p/8] |= 1 << (p % 8)
+	}
+	return b, nil
+}
+
+// resolveLeafRefType returns the type of the leaf referenced by the leafref
+// type t of the leaf e, or t if it is not a leafref.
+func resolveLeafRefType(e *yang.Entry, t *yang.YangType) (*yang.YangType, error) {
+	if t == nil {
+		return nil, fmt.Errorf("no type is specified for %s", e.Name)
+	}
+	if t.Kind != yang.Yleafref {
+		return t, nil
+	}
+	target, err := util.FindLeafRefSchema(e, t.Path)
+	if err != nil {
+		return nil, err
+	}
+	if target.Type == nil {
+		return nil, fmt.Errorf("no type is specified for %s", target.Name)
+	}
+	return target.Type, nil
+}
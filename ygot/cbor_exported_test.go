@@ -0,0 +1,131 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestEmitCBOR(t *testing.T) {
+	d := &exampleoc.Device{}
+	i := d.GetOrCreateInterface("eth0")
+	i.Mtu = ygot.Uint16(1500)
+	i.Type = exampleoc.IETFInterfaces_InterfaceType_ethernetCsmacd
+
+	sids := map[string]uint64{
+		"/openconfig-interfaces:interfaces":                       1000,
+		"/openconfig-interfaces:interfaces/interface":             1001,
+		"/openconfig-interfaces:interfaces/interface/name":        1002,
+		"/openconfig-interfaces:interfaces/interface/config":      1003,
+		"/openconfig-interfaces:interfaces/interface/config/name": 1004,
+		"/openconfig-interfaces:interfaces/interface/config/mtu":  1005,
+		"/openconfig-interfaces:interfaces/interface/config/type": 1006,
+	}
+
+	tests := []struct {
+		desc             string
+		in               ygot.GoStruct
+		inOpts           *ygot.EmitCBORConfig
+		want             any
+		wantErrSubstring string
+	}{{
+		desc:   "name keys",
+		in:     d,
+		inOpts: &ygot.EmitCBORConfig{Schema: exampleoc.SchemaTree["Device"]},
+		want: map[any]any{
+			"openconfig-interfaces:interfaces": map[any]any{
+				"interface": []any{
+					map[any]any{
+						"name": "eth0",
+						"config": map[any]any{
+							"name": "eth0",
+							"mtu":  uint64(1500),
+							"type": "iana-if-type:ethernetCsmacd",
+						},
+					},
+				},
+			},
+		},
+	}, {
+		desc: "delta-encoded SID keys",
+		in:   d,
+		inOpts: &ygot.EmitCBORConfig{
+			Schema:      exampleoc.SchemaTree["Device"],
+			KeyEncoding: ygot.CBORSIDKeys,
+			SIDs:        sids,
+		},
+		want: map[any]any{
+			uint64(1000): map[any]any{
+				uint64(1): []any{
+					map[any]any{
+						uint64(1): "eth0",
+						uint64(2): map[any]any{
+							uint64(1): "eth0",
+							uint64(2): uint64(1500),
+							uint64(3): "iana-if-type:ethernetCsmacd",
+						},
+					},
+				},
+			},
+		},
+	}, {
+		desc: "missing SID",
+		in:   d,
+		inOpts: &ygot.EmitCBORConfig{
+			Schema:      exampleoc.SchemaTree["Device"],
+			KeyEncoding: ygot.CBORSIDKeys,
+			SIDs:        map[string]uint64{"/openconfig-interfaces:interfaces": 1000},
+		},
+		wantErrSubstring: "no SID is specified for /openconfig-interfaces:interfaces/interface",
+	}, {
+		desc:             "missing schema",
+		in:               d,
+		wantErrSubstring: "requires the schema",
+	}, {
+		desc: "validation failure",
+		in: &exampleoc.Device{
+			Interface: map[string]*exampleoc.Interface{
+				"eth0": {Name: ygot.String("eth1")},
+			},
+		},
+		inOpts:           &ygot.EmitCBORConfig{Schema: exampleoc.SchemaTree["Device"]},
+		wantErrSubstring: "validation err",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ygot.EmitCBOR(tt.in, tt.inOpts)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("EmitCBOR: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			decoded, err := util.UnmarshalCBOR(got)
+			if err != nil {
+				t.Fatalf("cannot decode output of EmitCBOR %x: %v", got, err)
+			}
+			if diff := cmp.Diff(tt.want, decoded); diff != "" {
+				t.Errorf("EmitCBOR: did not get expected output, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
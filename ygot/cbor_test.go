@@ -0,0 +1,173 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
+
+func TestCBORLeafValue(t *testing.T) {
+	enumType := yang.NewEnumType()
+	enumType.Set("UP", 1)
+	enumType.Set("DOWN", 2)
+	bitsType := yang.NewBitfield()
+	bitsType.Set("A", 0)
+	bitsType.Set("B", 3)
+	bitsType.Set("C", 9)
+
+	tests := []struct {
+		desc             string
+		inType           *yang.YangType
+		inValue          any
+		want             any
+		wantErrSubstring string
+	}{{
+		desc:    "int8",
+		inType:  &yang.YangType{Kind: yang.Yint8},
+		inValue: float64(-5),
+		want:    int64(-5),
+	}, {
+		desc:    "uint32",
+		inType:  &yang.YangType{Kind: yang.Yuint32},
+		inValue: float64(4294967295),
+		want:    uint64(4294967295),
+	}, {
+		desc:    "int64",
+		inType:  &yang.YangType{Kind: yang.Yint64},
+		inValue: "-9223372036854775808",
+		want:    int64(-9223372036854775808),
+	}, {
+		desc:    "uint64",
+		inType:  &yang.YangType{Kind: yang.Yuint64},
+		inValue: "18446744073709551615",
+		want:    uint64(18446744073709551615),
+	}, {
+		desc:    "decimal64",
+		inType:  &yang.YangType{Kind: yang.Ydecimal64, FractionDigits: 2},
+		inValue: "-2.5",
+		want:    util.CBORTag{Number: 4, Content: []any{int64(-2), int64(-250)}},
+	}, {
+		desc:             "invalid decimal64",
+		inType:           &yang.YangType{Kind: yang.Ydecimal64, FractionDigits: 1},
+		inValue:          "2.55",
+		wantErrSubstring: "invalid decimal64 value",
+	}, {
+		desc:    "empty",
+		inType:  &yang.YangType{Kind: yang.Yempty},
+		inValue: []any{nil},
+		want:    nil,
+	}, {
+		desc:    "binary",
+		inType:  &yang.YangType{Kind: yang.Ybinary},
+		inValue: "AQID",
+		want:    []byte{1, 2, 3},
+	}, {
+		desc:    "bits",
+		inType:  &yang.YangType{Kind: yang.Ybits, Bit: bitsType},
+		inValue: "A B C",
+		want:    []byte{0x09, 0x02},
+	}, {
+		desc:             "unknown bit",
+		inType:           &yang.YangType{Kind: yang.Ybits, Bit: bitsType},
+		inValue:          "D",
+		wantErrSubstring: `"D" is not a valid bit name`,
+	}, {
+		desc:    "enumeration",
+		inType:  &yang.YangType{Kind: yang.Yenum, Enum: enumType},
+		inValue: "DOWN",
+		want:    int64(2),
+	}, {
+		desc:    "identityref",
+		inType:  &yang.YangType{Kind: yang.Yidentityref},
+		inValue: "mod:ID",
+		want:    "mod:ID",
+	}, {
+		desc: "union of uint64 and enumeration with integer",
+		inType: &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{
+				{Kind: yang.Yenum, Enum: enumType},
+				{Kind: yang.Yuint64},
+			},
+		},
+		inValue: "42",
+		want:    uint64(42),
+	}, {
+		desc: "union of uint64 and enumeration with enumeration",
+		inType: &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{
+				{Kind: yang.Yuint64},
+				{Kind: yang.Yenum, Enum: enumType},
+			},
+		},
+		inValue: "UP",
+		want:    util.CBORTag{Number: 44, Content: "UP"},
+	}, {
+		desc: "union with bits",
+		inType: &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{
+				{Kind: yang.Yuint8},
+				{Kind: yang.Ybits, Bit: bitsType},
+			},
+		},
+		inValue: "A C",
+		want:    util.CBORTag{Number: 43, Content: "A C"},
+	}, {
+		desc: "union with number outside member range",
+		inType: &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{
+				{Kind: yang.Yuint8, Range: yang.YangRange{{Min: yang.FromInt(0), Max: yang.FromInt(10)}}},
+				{Kind: yang.Yint16},
+			},
+		},
+		inValue: float64(20),
+		want:    int64(20),
+	}, {
+		desc: "union matching no member",
+		inType: &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{
+				{Kind: yang.Yuint8},
+				{Kind: yang.Ybool},
+			},
+		},
+		inValue:          "abc",
+		wantErrSubstring: "does not match any union member type",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			e := &yang.Entry{Name: "leaf", Kind: yang.LeafEntry, Type: tt.inType}
+			got, err := cborLeafValue(e, tt.inType, tt.inValue)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("cborLeafValue(%v): %s", tt.inValue, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("cborLeafValue(%v): did not get expected value, diff(-want, +got):\n%s", tt.inValue, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,462 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/openconfig/ygot/util"
+	"google.golang.org/protobuf/proto"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// CoalesceOpt is an option that can be supplied to NewCoalescer.
+type CoalesceOpt interface {
+	// IsCoalesceOpt is a marker method for each CoalesceOpt.
+	IsCoalesceOpt()
+}
+
+// CoalesceWindow is a CoalesceOpt that specifies the duration for which
+// updates are buffered before they are forwarded, such that a burst of
+// updates to a path within the window is forwarded as a single update with
+// the latest value. By default, updates are forwarded immediately.
+type CoalesceWindow time.Duration
+
+// IsCoalesceOpt implements the CoalesceOpt interface.
+func (CoalesceWindow) IsCoalesceOpt() {}
+
+// CoalesceHeartbeat is a CoalesceOpt that specifies the heartbeat interval,
+// as per the heartbeat_interval field of a gNMI Subscription. An update
+// whose value has not changed is forwarded if the interval has elapsed
+// since the value of its path was last forwarded.
+type CoalesceHeartbeat time.Duration
+
+// IsCoalesceOpt implements the CoalesceOpt interface.
+func (CoalesceHeartbeat) IsCoalesceOpt() {}
+
+// CoalesceKeepRedundant is a CoalesceOpt that specifies that updates whose
+// value has not changed are forwarded, such that only coalescing within
+// the CoalesceWindow is performed.
+type CoalesceKeepRedundant struct{}
+
+// IsCoalesceOpt implements the CoalesceOpt interface.
+func (*CoalesceKeepRedundant) IsCoalesceOpt() {}
+
+// Coalescer processes the responses of a gNMI Subscribe stream before they
+// are forwarded to a consumer. It suppresses updates whose value has not
+// changed since it was last forwarded, coalesces bursts of updates within a
+// window, and honours a heartbeat interval for unchanged values.
+//
+// Updates are tracked by their absolute path, including its target and
+// origin, such that a stream that carries notifications for several targets
+// or origins is handled correctly. The forwarded notifications carry the
+// target and origin in their prefix, with one notification per target and
+// origin. A delete removes the tracked values of the deleted path and its
+// descendants, such that a subsequent update to them is always forwarded. Atomic notifications, such as those
+// that are produced by DiffWithAtomic for lists that are ordered by the
+// user, are forwarded as a unit and are never coalesced. A SyncResponse
+// causes buffered updates to be forwarded before it.
+//
+// The Coalescer compares paths and TypedValues as they are received, and
+// does not consult the schema of the data; in particular, values that are
+// encoded differently, e.g., as JSON and as scalar values, are not treated
+// as equal.
+//
+// A Coalescer is safe for concurrent use.
+type Coalescer struct {
+	// out is the function that responses are forwarded to.
+	out func(*gnmipb.SubscribeResponse)
+	// window is the duration for which updates are buffered.
+	window time.Duration
+	// heartbeat is the heartbeat interval, or zero if there is none.
+	heartbeat time.Duration
+	// keepRedundant indicates that unchanged updates are not suppressed.
+	keepRedundant bool
+	// now returns the current time, and is overridden in tests.
+	now func() time.Time
+
+	// mu protects the fields below, and serialises calls to out.
+	mu sync.Mutex
+	// sent is the last forwarded value of each path.
+	sent map[coalesceKey]*sentValue
+	// pending is the set of buffered updates, keyed by their path.
+	pending map[coalesceKey]*gnmipb.Update
+	// pendingDeletes is the set of buffered deletes, keyed by their path.
+	pendingDeletes map[coalesceKey]*gnmipb.Path
+	// pendingTimestamp is the latest timestamp of a buffered
+	// notification.
+	pendingTimestamp int64
+	// timer is the timer that flushes the buffered notifications.
+	timer *time.Timer
+	// closed indicates that the Coalescer has been closed.
+	closed bool
+}
+
+// coalesceKey identifies an absolute path within a Subscribe stream.
+type coalesceKey struct {
+	// target and origin are the target and origin of the path.
+	target, origin string
+	// path is the string form of the elements of the path.
+	path string
+}
+
+// newCoalesceKey returns the coalesceKey of the absolute path p.
+func newCoalesceKey(p *gnmipb.Path) (coalesceKey, error) {
+	s, err := PathToString(&gnmipb.Path{Elem: p.GetElem()})
+	if err != nil {
+		return coalesceKey{}, err
+	}
+	return coalesceKey{target: p.GetTarget(), origin: p.GetOrigin(), path: s}, nil
+}
+
+// less reports whether k sorts before o, by target, origin and path.
+func (k coalesceKey) less(o coalesceKey) bool {
+	switch {
+	case k.target != o.target:
+		return k.target < o.target
+	case k.origin != o.origin:
+		return k.origin < o.origin
+	}
+	return k.path < o.path
+}
+
+// contains reports whether the path with key o is k, or a descendant of k.
+func (k coalesceKey) contains(o coalesceKey) (bool, error) {
+	if k.target != o.target || k.origin != o.origin {
+		return false, nil
+	}
+	kp, err := StringToStructuredPath(k.path)
+	if err != nil {
+		return false, err
+	}
+	op, err := StringToStructuredPath(o.path)
+	if err != nil {
+		return false, err
+	}
+	return util.PathMatchesPathElemPrefix(op, kp), nil
+}
+
+// keyedPath is a path that is forwarded, with its key.
+type keyedPath struct {
+	k coalesceKey
+	p *gnmipb.Path
+}
+
+// keyedUpdate is an update that is forwarded, with the key of its path.
+type keyedUpdate struct {
+	k coalesceKey
+	u *gnmipb.Update
+}
+
+// sentValue is the last forwarded value of a path.
+type sentValue struct {
+	// val is the value.
+	val *gnmipb.TypedValue
+	// at is the time at which it was forwarded.
+	at time.Time
+}
+
+// NewCoalescer returns a Coalescer that forwards the responses that it
+// processes to out, according to the supplied options.
+func NewCoalescer(out func(*gnmipb.SubscribeResponse), opts ...CoalesceOpt) *Coalescer {
+	c := &Coalescer{
+		out:            out,
+		now:            time.Now,
+		sent:           map[coalesceKey]*sentValue{},
+		pending:        map[coalesceKey]*gnmipb.Update{},
+		pendingDeletes: map[coalesceKey]*gnmipb.Path{},
+	}
+	for _, o := range opts {
+		switch v := o.(type) {
+		case CoalesceWindow:
+			c.window = time.Duration(v)
+		case CoalesceHeartbeat:
+			c.heartbeat = time.Duration(v)
+		case *CoalesceKeepRedundant:
+			c.keepRedundant = true
+		}
+	}
+	return c
+}
+
+// Process processes a response received from a gNMI Subscribe stream.
+func (c *Coalescer) Process(resp *gnmipb.SubscribeResponse) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return fmt.Errorf("coalescer is closed")
+	}
+
+	n := resp.GetUpdate()
+	switch {
+	case n == nil:
+		// SyncResponses and errors are forwarded once the buffered
+		// updates have been forwarded, such that they retain their
+		// position in the stream.
+		c.flushLocked()
+		c.out(resp)
+		return nil
+	case n.GetAtomic():
+		c.flushLocked()
+		return c.processAtomicLocked(n)
+	}
+
+	if n.GetTimestamp() > c.pendingTimestamp {
+		c.pendingTimestamp = n.GetTimestamp()
+	}
+
+	var (
+		deletes []keyedPath
+		updates []keyedUpdate
+	)
+	for _, d := range n.GetDelete() {
+		p, err := util.JoinPaths(n.GetPrefix(), d)
+		if err != nil {
+			return err
+		}
+		dp, err := c.deleteLocked(p)
+		if err != nil {
+			return err
+		}
+		if dp != nil {
+			deletes = append(deletes, *dp)
+		}
+	}
+	for _, u := range n.GetUpdate() {
+		p, err := util.JoinPaths(n.GetPrefix(), u.GetPath())
+		if err != nil {
+			return err
+		}
+		k, err := newCoalesceKey(p)
+		if err != nil {
+			return err
+		}
+		if c.redundantLocked(k, u.GetVal()) {
+			delete(c.pending, k)
+			continue
+		}
+		nu := proto.Clone(u).(*gnmipb.Update)
+		nu.Path = &gnmipb.Path{Elem: p.GetElem()}
+		if c.window == 0 {
+			c.recordSentLocked(k, nu.GetVal())
+			updates = append(updates, keyedUpdate{k: k, u: nu})
+			continue
+		}
+		c.pending[k] = nu
+	}
+
+	if c.window == 0 {
+		c.emitLocked(deletes, updates)
+		return nil
+	}
+	if c.timer == nil && (len(c.pending) != 0 || len(c.pendingDeletes) != 0) {
+		c.timer = time.AfterFunc(c.window, c.Flush)
+	}
+	return nil
+}
+
+// processAtomicLocked forwards the atomic notification n, recording the
+// values of its updates as forwarded. The caller must hold c.mu.
+func (c *Coalescer) processAtomicLocked(n *gnmipb.Notification) error {
+	for _, d := range n.GetDelete() {
+		p, err := util.JoinPaths(n.GetPrefix(), d)
+		if err != nil {
+			return err
+		}
+		k, err := newCoalesceKey(p)
+		if err != nil {
+			return err
+		}
+		if err := c.forgetLocked(k); err != nil {
+			return err
+		}
+	}
+	for _, u := range n.GetUpdate() {
+		p, err := util.JoinPaths(n.GetPrefix(), u.GetPath())
+		if err != nil {
+			return err
+		}
+		k, err := newCoalesceKey(p)
+		if err != nil {
+			return err
+		}
+		c.recordSentLocked(k, u.GetVal())
+	}
+	c.out(&gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_Update{Update: n}})
+	return nil
+}
+
+// deleteLocked processes the delete of the absolute path p. It returns the
+// path to be forwarded immediately if there is no window, and otherwise
+// buffers the delete and returns nil. The caller must hold c.mu.
+func (c *Coalescer) deleteLocked(p *gnmipb.Path) (*keyedPath, error) {
+	k, err := newCoalesceKey(p)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.forgetLocked(k); err != nil {
+		return nil, err
+	}
+	dp := &gnmipb.Path{Elem: p.GetElem()}
+	if c.window == 0 {
+		return &keyedPath{k: k, p: dp}, nil
+	}
+	// Buffered updates to the deleted subtree are superseded by the
+	// delete.
+	for pk := range c.pending {
+		ok, err := k.contains(pk)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			delete(c.pending, pk)
+		}
+	}
+	c.pendingDeletes[k] = dp
+	return nil, nil
+}
+
+// forgetLocked removes the forwarded values of the path with key k and its
+// descendants. The caller must hold c.mu.
+func (c *Coalescer) forgetLocked(k coalesceKey) error {
+	for sk := range c.sent {
+		ok, err := k.contains(sk)
+		if err != nil {
+			return err
+		}
+		if ok {
+			delete(c.sent, sk)
+		}
+	}
+	return nil
+}
+
+// redundantLocked reports whether an update of the path with key k to val
+// should be suppressed. The caller must hold c.mu.
+func (c *Coalescer) redundantLocked(k coalesceKey, val *gnmipb.TypedValue) bool {
+	if c.keepRedundant {
+		return false
+	}
+	s, ok := c.sent[k]
+	if !ok || !proto.Equal(s.val, val) {
+		return false
+	}
+	return c.heartbeat == 0 || c.now().Sub(s.at) < c.heartbeat
+}
+
+// recordSentLocked records that val was forwarded for the path with key k.
+// The caller must hold c.mu.
+func (c *Coalescer) recordSentLocked(k coalesceKey, val *gnmipb.TypedValue) {
+	c.sent[k] = &sentValue{val: val, at: c.now()}
+}
+
+// emitLocked forwards the deletes and updates, if there are any, as a
+// notification for each of their targets and origins, sorted by target and
+// origin. The target and origin are set in the
+// prefix of each notification. The caller must hold c.mu.
+func (c *Coalescer) emitLocked(deletes []keyedPath, updates []keyedUpdate) {
+	type source struct{ target, origin string }
+	var (
+		order   []source
+		notifs  = map[source]*gnmipb.Notification{}
+		notifOf = func(k coalesceKey) *gnmipb.Notification {
+			s := source{target: k.target, origin: k.origin}
+			n, ok := notifs[s]
+			if !ok {
+				n = &gnmipb.Notification{Timestamp: c.pendingTimestamp}
+				if s.target != "" || s.origin != "" {
+					n.Prefix = &gnmipb.Path{Target: s.target, Origin: s.origin}
+				}
+				notifs[s] = n
+				order = append(order, s)
+			}
+			return n
+		}
+	)
+	for _, d := range deletes {
+		n := notifOf(d.k)
+		n.Delete = append(n.Delete, d.p)
+	}
+	for _, u := range updates {
+		n := notifOf(u.k)
+		n.Update = append(n.Update, u.u)
+	}
+	sort.Slice(order, func(i, j int) bool {
+		if order[i].target != order[j].target {
+			return order[i].target < order[j].target
+		}
+		return order[i].origin < order[j].origin
+	})
+	for _, s := range order {
+		c.out(&gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_Update{Update: notifs[s]}})
+	}
+}
+
+// Flush forwards the buffered updates and deletes immediately.
+func (c *Coalescer) Flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.flushLocked()
+}
+
+// flushLocked forwards the buffered updates and deletes as a single
+// notification for each target and origin, sorted by path. The caller must hold c.mu.
+func (c *Coalescer) flushLocked() {
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+	if len(c.pending) == 0 && len(c.pendingDeletes) == 0 {
+		return
+	}
+	var (
+		deletes []keyedPath
+		updates []keyedUpdate
+	)
+	for _, k := range sortedMapKeys(c.pendingDeletes) {
+		deletes = append(deletes, keyedPath{k: k, p: c.pendingDeletes[k]})
+	}
+	for _, k := range sortedMapKeys(c.pending) {
+		u := c.pending[k]
+		c.recordSentLocked(k, u.GetVal())
+		updates = append(updates, keyedUpdate{k: k, u: u})
+	}
+	c.pending = map[coalesceKey]*gnmipb.Update{}
+	c.pendingDeletes = map[coalesceKey]*gnmipb.Path{}
+	c.emitLocked(deletes, updates)
+}
+
+// Close forwards the buffered updates and deletes, after which the
+// Coalescer cannot be used.
+func (c *Coalescer) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.flushLocked()
+	c.closed = true
+}
+
+// sortedMapKeys returns the keys of the map m in sorted order.
+func sortedMapKeys[V any](m map[coalesceKey]V) []coalesceKey {
+	keys := make([]coalesceKey, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].less(keys[j]) })
+	return keys
+}
@@ -0,0 +1,265 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// coalesceTestPath returns the path with string form s, failing the test if
+// it cannot be parsed.
+func coalesceTestPath(t *testing.T, s string) *gnmipb.Path {
+	t.Helper()
+	p, err := StringToStructuredPath(s)
+	if err != nil {
+		t.Fatalf("cannot parse path %s: %v", s, err)
+	}
+	return p
+}
+
+// updateResp returns a SubscribeResponse containing a notification with an
+// update of each path within paths to the corresponding string value.
+func updateResp(t *testing.T, ts int64, paths, vals []string) *gnmipb.SubscribeResponse {
+	t.Helper()
+	n := &gnmipb.Notification{Timestamp: ts}
+	for i, p := range paths {
+		n.Update = append(n.Update, &gnmipb.Update{
+			Path: coalesceTestPath(t, p),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: vals[i]}},
+		})
+	}
+	return &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_Update{Update: n}}
+}
+
+// deleteResp returns a SubscribeResponse containing a notification that
+// deletes each path within paths.
+func deleteResp(t *testing.T, ts int64, paths ...string) *gnmipb.SubscribeResponse {
+	t.Helper()
+	n := &gnmipb.Notification{Timestamp: ts}
+	for _, p := range paths {
+		n.Delete = append(n.Delete, coalesceTestPath(t, p))
+	}
+	return &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_Update{Update: n}}
+}
+
+// withPrefix returns r, whose notification has its prefix set to the
+// supplied target and origin.
+func withPrefix(r *gnmipb.SubscribeResponse, target, origin string) *gnmipb.SubscribeResponse {
+	r.GetUpdate().Prefix = &gnmipb.Path{Target: target, Origin: origin}
+	return r
+}
+
+var syncResp = &gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_SyncResponse{SyncResponse: true}}
+
+func TestCoalescer(t *testing.T) {
+	tests := []struct {
+		desc   string
+		inOpts []CoalesceOpt
+		// inResps returns the responses that are processed, with the
+		// clock advanced by advance before each response.
+		inResps func(t *testing.T) []*gnmipb.SubscribeResponse
+		advance time.Duration
+		want    func(t *testing.T) []*gnmipb.SubscribeResponse
+	}{{
+		desc: "redundant updates suppressed",
+		inResps: func(t *testing.T) []*gnmipb.SubscribeResponse {
+			return []*gnmipb.SubscribeResponse{
+				updateResp(t, 1, []string{"/a/b", "/a/c"}, []string{"x", "y"}),
+				updateResp(t, 2, []string{"/a/b", "/a/c"}, []string{"x", "z"}),
+				updateResp(t, 3, []string{"/a/b"}, []string{"x"}),
+			}
+		},
+		want: func(t *testing.T) []*gnmipb.SubscribeResponse {
+			return []*gnmipb.SubscribeResponse{
+				updateResp(t, 1, []string{"/a/b", "/a/c"}, []string{"x", "y"}),
+				updateResp(t, 2, []string{"/a/c"}, []string{"z"}),
+			}
+		},
+	}, {
+		desc:   "redundant updates kept",
+		inOpts: []CoalesceOpt{&CoalesceKeepRedundant{}},
+		inResps: func(t *testing.T) []*gnmipb.SubscribeResponse {
+			return []*gnmipb.SubscribeResponse{
+				updateResp(t, 1, []string{"/a/b"}, []string{"x"}),
+				updateResp(t, 2, []string{"/a/b"}, []string{"x"}),
+			}
+		},
+		want: func(t *testing.T) []*gnmipb.SubscribeResponse {
+			return []*gnmipb.SubscribeResponse{
+				updateResp(t, 1, []string{"/a/b"}, []string{"x"}),
+				updateResp(t, 2, []string{"/a/b"}, []string{"x"}),
+			}
+		},
+	}, {
+		desc:    "heartbeat re-sends unchanged value",
+		inOpts:  []CoalesceOpt{CoalesceHeartbeat(10 * time.Second)},
+		advance: 4 * time.Second,
+		inResps: func(t *testing.T) []*gnmipb.SubscribeResponse {
+			return []*gnmipb.SubscribeResponse{
+				updateResp(t, 1, []string{"/a/b"}, []string{"x"}),
+				updateResp(t, 2, []string{"/a/b"}, []string{"x"}),
+				updateResp(t, 3, []string{"/a/b"}, []string{"x"}),
+				updateResp(t, 4, []string{"/a/b"}, []string{"x"}),
+			}
+		},
+		want: func(t *testing.T) []*gnmipb.SubscribeResponse {
+			return []*gnmipb.SubscribeResponse{
+				updateResp(t, 1, []string{"/a/b"}, []string{"x"}),
+				updateResp(t, 4, []string{"/a/b"}, []string{"x"}),
+			}
+		},
+	}, {
+		desc: "delete forgets subtree",
+		inResps: func(t *testing.T) []*gnmipb.SubscribeResponse {
+			return []*gnmipb.SubscribeResponse{
+				updateResp(t, 1, []string{"/a[k=1]/b", "/c"}, []string{"x", "y"}),
+				deleteResp(t, 2, "/a[k=1]"),
+				updateResp(t, 3, []string{"/a[k=1]/b", "/c"}, []string{"x", "y"}),
+			}
+		},
+		want: func(t *testing.T) []*gnmipb.SubscribeResponse {
+			return []*gnmipb.SubscribeResponse{
+				updateResp(t, 1, []string{"/a[k=1]/b", "/c"}, []string{"x", "y"}),
+				deleteResp(t, 2, "/a[k=1]"),
+				updateResp(t, 3, []string{"/a[k=1]/b"}, []string{"x"}),
+			}
+		},
+	}, {
+		desc:   "window coalesces burst",
+		inOpts: []CoalesceOpt{CoalesceWindow(time.Hour)},
+		inResps: func(t *testing.T) []*gnmipb.SubscribeResponse {
+			return []*gnmipb.SubscribeResponse{
+				updateResp(t, 1, []string{"/a/b", "/a/c"}, []string{"x", "y"}),
+				updateResp(t, 2, []string{"/a/b"}, []string{"z"}),
+				updateResp(t, 3, []string{"/d[k=1]/e"}, []string{"w"}),
+				deleteResp(t, 4, "/d[k=1]"),
+				syncResp,
+				updateResp(t, 5, []string{"/a/b"}, []string{"z"}),
+				updateResp(t, 6, []string{"/a/c"}, []string{"v"}),
+			}
+		},
+		want: func(t *testing.T) []*gnmipb.SubscribeResponse {
+			first := updateResp(t, 4, []string{"/a/b", "/a/c"}, []string{"z", "y"})
+			first.GetUpdate().Delete = []*gnmipb.Path{coalesceTestPath(t, "/d[k=1]")}
+			return []*gnmipb.SubscribeResponse{
+				first,
+				syncResp,
+				updateResp(t, 6, []string{"/a/c"}, []string{"v"}),
+			}
+		},
+	}, {
+		desc: "targets tracked separately",
+		inResps: func(t *testing.T) []*gnmipb.SubscribeResponse {
+			return []*gnmipb.SubscribeResponse{
+				withPrefix(updateResp(t, 1, []string{"/a/b"}, []string{"x"}), "dev1", ""),
+				withPrefix(updateResp(t, 2, []string{"/a/b"}, []string{"x"}), "dev2", ""),
+				withPrefix(updateResp(t, 3, []string{"/a/b"}, []string{"x"}), "dev1", ""),
+				withPrefix(deleteResp(t, 4, "/a"), "dev2", ""),
+				withPrefix(updateResp(t, 5, []string{"/a/b"}, []string{"x"}), "dev1", ""),
+			}
+		},
+		want: func(t *testing.T) []*gnmipb.SubscribeResponse {
+			return []*gnmipb.SubscribeResponse{
+				withPrefix(updateResp(t, 1, []string{"/a/b"}, []string{"x"}), "dev1", ""),
+				withPrefix(updateResp(t, 2, []string{"/a/b"}, []string{"x"}), "dev2", ""),
+				withPrefix(deleteResp(t, 4, "/a"), "dev2", ""),
+			}
+		},
+	}, {
+		desc:   "window keeps targets and origins apart",
+		inOpts: []CoalesceOpt{CoalesceWindow(time.Hour)},
+		inResps: func(t *testing.T) []*gnmipb.SubscribeResponse {
+			return []*gnmipb.SubscribeResponse{
+				withPrefix(updateResp(t, 1, []string{"/a/b"}, []string{"x"}), "dev2", ""),
+				withPrefix(updateResp(t, 2, []string{"/a/b"}, []string{"y"}), "dev1", ""),
+				withPrefix(updateResp(t, 3, []string{"/a/b"}, []string{"z"}), "dev1", "openconfig"),
+				withPrefix(deleteResp(t, 4, "/a"), "dev2", ""),
+			}
+		},
+		want: func(t *testing.T) []*gnmipb.SubscribeResponse {
+			return []*gnmipb.SubscribeResponse{
+				withPrefix(updateResp(t, 4, []string{"/a/b"}, []string{"y"}), "dev1", ""),
+				withPrefix(updateResp(t, 4, []string{"/a/b"}, []string{"z"}), "dev1", "openconfig"),
+				withPrefix(deleteResp(t, 4, "/a"), "dev2", ""),
+			}
+		},
+	}, {
+		desc: "atomic notification forwarded as unit",
+		inResps: func(t *testing.T) []*gnmipb.SubscribeResponse {
+			r := updateResp(t, 2, []string{"/a/b", "/a/c"}, []string{"x", "y"})
+			r.GetUpdate().Atomic = true
+			return []*gnmipb.SubscribeResponse{
+				updateResp(t, 1, []string{"/a/b"}, []string{"x"}),
+				r,
+				updateResp(t, 3, []string{"/a/c"}, []string{"y"}),
+			}
+		},
+		want: func(t *testing.T) []*gnmipb.SubscribeResponse {
+			r := updateResp(t, 2, []string{"/a/b", "/a/c"}, []string{"x", "y"})
+			r.GetUpdate().Atomic = true
+			return []*gnmipb.SubscribeResponse{
+				updateResp(t, 1, []string{"/a/b"}, []string{"x"}),
+				r,
+			}
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var got []*gnmipb.SubscribeResponse
+			c := NewCoalescer(func(r *gnmipb.SubscribeResponse) { got = append(got, r) }, tt.inOpts...)
+			now := time.Unix(0, 0)
+			c.now = func() time.Time { return now }
+			for _, r := range tt.inResps(t) {
+				if err := c.Process(r); err != nil {
+					t.Fatalf("Process(%v): got unexpected error: %v", r, err)
+				}
+				now = now.Add(tt.advance)
+			}
+			c.Close()
+			if diff := cmp.Diff(tt.want(t), got, protocmp.Transform()); diff != "" {
+				t.Errorf("did not get expected responses (-want, +got):\n%s", diff)
+			}
+			if err := c.Process(syncResp); err == nil {
+				t.Errorf("Process after Close: got nil error, want error")
+			}
+		})
+	}
+}
+
+func TestCoalescerWindowTimer(t *testing.T) {
+	ch := make(chan *gnmipb.SubscribeResponse, 1)
+	c := NewCoalescer(func(r *gnmipb.SubscribeResponse) { ch <- r }, CoalesceWindow(10*time.Millisecond))
+	defer c.Close()
+	for _, v := range []string{"x", "y"} {
+		if err := c.Process(updateResp(t, 1, []string{"/a/b"}, []string{v})); err != nil {
+			t.Fatalf("Process: got unexpected error: %v", err)
+		}
+	}
+	select {
+	case got := <-ch:
+		if diff := cmp.Diff(updateResp(t, 1, []string{"/a/b"}, []string{"y"}), got, protocmp.Transform()); diff != "" {
+			t.Errorf("did not get expected response (-want, +got):\n%s", diff)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("buffered updates were not flushed at the end of the window")
+	}
+}
@@ -225,6 +225,11 @@ func toStringPathMap(pathMap map[*pathSpec]interface{}) (map[string]*pathInfo, e
 	return strPathMap, nil
 }
 
+// presenceContainer is the value recorded by findSetLeaves for a YANG
+// presence container that exists within the data tree, such that the
+// existence of the container can be compared independently of its leaves.
+type presenceContainer struct{}
+
 // findSetLeaves iteratively walks the fields of the supplied GoStruct, s, and
 // returns a map, keyed by the path of the leaves that are set, with a the value
 // that the leaf is set to. YANG lists (Go maps), and containers (Go structs) are
@@ -236,11 +241,23 @@ func toStringPathMap(pathMap map[*pathSpec]interface{}) (map[string]*pathInfo, e
 // A specific Annotation is used to store the absolute path of the entity during
 // the walk.
 //
+// Presence containers (fields tagged with yangPresence) that are non-nil are
+// included in the returned map with a presenceContainer value, since their
+// existence is meaningful even when none of their leaves are set.
+//
 // - orderedMapAsLeaf=true specifies that ordered maps (GoOrderedMap
 // interface) will be treated as a leaf and will be returned as-is instead of
 // being walked and its leaves populated.
 func findSetLeaves(s GoStruct, orderedMapAsLeaf bool, opts ...DiffOpt) (map[*pathSpec]interface{}, error) {
+	return findSetNodes(s, orderedMapAsLeaf, nil, opts...)
+}
+
+// findSetNodes returns the leaves that are set within s, as per
+// findSetLeaves. If structs is non-nil, the containers and list entries
+// within s are additionally stored within it, keyed by their paths.
+func findSetNodes(s GoStruct, orderedMapAsLeaf bool, structs map[*pathSpec]GoStruct, opts ...DiffOpt) (map[*pathSpec]interface{}, error) {
 	pathOpt := hasDiffPathOpt(opts)
+	preferShadowPath := hasDiffPreferShadowPath(opts)
 	processedPaths := map[string]bool{}
 
 	findSetIterFunc := func(ni *util.NodeInfo, in, out interface{}) (action util.IterationAction, errs util.Errors) {
@@ -255,7 +272,7 @@ func findSetLeaves(s GoStruct, orderedMapAsLeaf bool, opts ...DiffOpt) (map[*pat
 		}
 
 		var sp [][]string
-		if pathOpt != nil && pathOpt.PreferShadowPath {
+		if preferShadowPath {
 			// Try the shadow-path tag first to see if it exists.
 			sp = util.ShadowSchemaPaths(ni.StructField)
 		}
@@ -310,6 +327,14 @@ func findSetLeaves(s GoStruct, orderedMapAsLeaf bool, opts ...DiffOpt) (map[*pat
 		if util.IsNilOrInvalidValue(ni.FieldValue) || util.IsValueNilOrDefault(ni.FieldValue.Interface()) || util.IsValueMap(ni.FieldValue) {
 			return
 		}
+		if gs, ok := ival.(GoStruct); ok && structs != nil && !isOrderedMap {
+			structs[vp] = gs
+		}
+		if util.IsYangPresence(ni.StructField) && util.IsValueStructPtr(ni.FieldValue) {
+			outs := out.(map[*pathSpec]interface{})
+			outs[vp] = presenceContainer{}
+			return
+		}
 		// Ignore structs unless it is an ordered map and we're
 		// treating it as a leaf (since it is assumed to be
 		// telemetry-atomic in order to preserve ordering of entries).
@@ -388,7 +413,7 @@ func leastSpecificPath(paths [][]string) []string {
 // appendUpdate adds an update to the supplied gNMI Notification message corresponding
 // to the path and value supplied. path is the string version of the path in pathInfo.
 func appendUpdate(n *gnmipb.Notification, path string, pathInfo *pathInfo) error {
-	v, err := EncodeTypedValue(pathInfo.val, gnmipb.Encoding_PROTO)
+	v, err := diffTypedValue(pathInfo.val)
 	if err != nil {
 		return fmt.Errorf("cannot represent field value %v as TypedValue for path %v: %v", pathInfo.val, path, err)
 	}
@@ -399,6 +424,17 @@ func appendUpdate(n *gnmipb.Notification, path string, pathInfo *pathInfo) error
 	return nil
 }
 
+// diffTypedValue returns the value of a leaf returned by findSetLeaves as a
+// gNMI TypedValue.
+func diffTypedValue(val interface{}) (*gnmipb.TypedValue, error) {
+	if _, ok := val.(presenceContainer); ok {
+		// An empty presence container is created by an update with an
+		// empty JSON object as its value.
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte("{}")}}, nil
+	}
+	return EncodeTypedValue(val, gnmipb.Encoding_PROTO)
+}
+
 // DiffOpt is an interface that is implemented by the options to the Diff
 // function. It allows user specified options to be propagated to the diff
 // method.
@@ -445,12 +481,69 @@ type DiffPathOpt struct {
 	// This option is used when GoStructs are generated with the
 	// -ignore_shadow_schema_paths flag, and therefore have the
 	// "shadow-path" tag.
+	//
+	// Deprecated: supply the PreferShadowPath option, which is shared with
+	// the other functions of the ygot and ytypes packages, instead.
 	PreferShadowPath bool
 }
 
 // IsDiffOpt marks DiffPathOpt as a diff option.
 func (*DiffPathOpt) IsDiffOpt() {}
 
+// hasDiffPreferShadowPath determines whether the supplied opts specify that
+// the "shadow-path" struct tag should be preferred, either through the
+// PreferShadowPath option, or the PreferShadowPath field of a DiffPathOpt.
+func hasDiffPreferShadowPath(opts []DiffOpt) bool {
+	for _, o := range opts {
+		switch v := o.(type) {
+		case *PreferShadowPath:
+			return true
+		case *DiffPathOpt:
+			if v.PreferShadowPath {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ValidateDiffPaths is a DiffOpt that indicates that each path within the
+// Notifications returned by Diff should be checked to be well-formed, as per
+// util.ValidateGNMIPath, with an error returned if any path is malformed.
+type ValidateDiffPaths struct{}
+
+// IsDiffOpt marks ValidateDiffPaths as a diff option.
+func (*ValidateDiffPaths) IsDiffOpt() {}
+
+// hasValidateDiffPaths returns the first ValidateDiffPaths from an opts
+// slice, or nil if there isn't one.
+func hasValidateDiffPaths(opts []DiffOpt) *ValidateDiffPaths {
+	for _, o := range opts {
+		if v, ok := o.(*ValidateDiffPaths); ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// validateNotificationPaths checks that each of the update and delete paths
+// within the supplied notifications are well-formed.
+func validateNotificationPaths(notifs []*gnmipb.Notification) error {
+	for _, n := range notifs {
+		for _, u := range n.GetUpdate() {
+			if err := util.ValidateGNMIPath(u.GetPath()); err != nil {
+				return fmt.Errorf("invalid update path in diff: %w", err)
+			}
+		}
+		for _, d := range n.GetDelete() {
+			if err := util.ValidateGNMIPath(d); err != nil {
+				return fmt.Errorf("invalid delete path in diff: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
 // Diff takes an original and modified GoStruct, which must be of the same type
 // and returns a gNMI Notification that contains the diff between them. The original
 // struct is considered as the "from" data, with the modified struct the "to" such that:
@@ -460,11 +553,22 @@ func (*DiffPathOpt) IsDiffOpt() {}
 //     field value.
 //   - The paths within the Delete field of the notification indicate that the
 //     field was not present in the modified struct, but was set in the original.
+//   - YANG presence containers (fields tagged with yangPresence) are
+//     compared by their existence. A presence container that is removed in
+//     modified is reported as a single deletion of the container, rather
+//     than deletions of its leaves. A presence container that is added in
+//     modified without any leaves is reported as an update with an empty
+//     JSON_IETF object as its value.
 //   - NOTE: For `ordered-by user` nodes, which are represented by ordered maps
 //     in the ygot-generated code, the output Notification cannot be directly
 //     unmarshalling into original to arrive at modified since updates are
 //     granular. For generating atomic:true Notifications, use
 //     ygot.DiffWithAtomic instead.
+//   - Leaf-lists are compared as sequences, such that reordering the elements
+//     of a leaf-list, which is significant for `ordered-by user` leaf-lists,
+//     is reported as an update. The update contains the entire leaf-list in
+//     its modified order, and hence replaces the original leaf-list when it
+//     is applied.
 //
 // Annotation fields that are contained within the supplied original or modified
 // GoStruct are skipped.
@@ -489,6 +593,24 @@ func Diff(original, modified GoStruct, opts ...DiffOpt) (*gnmipb.Notification, e
 	}
 }
 
+// InverseDiff takes an original and modified GoStruct, which must be of the
+// same type, and returns a gNMI Notification containing the updates and
+// deletes that restore original from modified, such that a change that was
+// made to a data tree can be rolled back without retaining a full copy of
+// the tree prior to the change.
+//
+// It is equivalent to calling Diff with the arguments reversed: the Update
+// field of the notification contains the fields that were changed or removed
+// in modified, with their values in original, and the Delete field contains
+// the fields that were added in modified. The IgnoreAdditions option cannot
+// be supplied, since the resulting Notification would not restore original.
+func InverseDiff(original, modified GoStruct, opts ...DiffOpt) (*gnmipb.Notification, error) {
+	if hasIgnoreAdditions(opts) != nil {
+		return nil, fmt.Errorf("IgnoreAdditions cannot be used with InverseDiff")
+	}
+	return Diff(modified, original, opts...)
+}
+
 // FormatDiff formats the output of ygot.Diff as a multiline string. This
 // function is only intended for human consumption and ignores errors. Do not
 // depend on the output being stable. It may change over time across different
@@ -646,23 +768,69 @@ func createAtomicNotif(atomicLeaves []*pathval, ts int64, subtreePfx *gnmiPath)
 	return no, nil
 }
 
-// diff produces a slice of notifications given two GoStructs.
-//
-// See documentation for Diff and DiffWithAtomic for more information.
-//
-//   - withAtomic indicates that atomic notifications should be generated
-//     (currently this is only supported for `ordered-by user` lists)
-func diff(original, modified GoStruct, withAtomic bool, opts ...DiffOpt) ([]*gnmipb.Notification, error) {
+// diffLeaves returns the leaves that are set within the original and
+// modified GoStructs, which must be of the same type, keyed by the string
+// form of their paths, after the with-defaults mode and normalizers within
+// opts are applied to each. withAtomic specifies that ordered maps are
+// returned as leaves rather than being walked.
+func diffLeaves(original, modified GoStruct, withAtomic bool, opts []DiffOpt) (map[string]*pathInfo, map[string]*pathInfo, error) {
+	t, err := diffNodes(original, modified, withAtomic, false, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return t.origLeaves, t.modLeaves, nil
+}
+
+// diffTrees contains the nodes that are set within the original and
+// modified GoStructs that are compared, keyed by the string form of their
+// paths.
+type diffTrees struct {
+	// origLeaves and modLeaves are the leaves that are set within the
+	// original and modified GoStructs.
+	origLeaves, modLeaves map[string]*pathInfo
+	// origStructs and modStructs are the containers and list entries that
+	// are present within the original and modified GoStructs.
+	origStructs, modStructs map[string]*pathInfo
+}
+
+// diffNodes returns the nodes that are set within the original and modified
+// GoStructs, as per diffLeaves. The containers and list entries within them
+// are additionally returned if withStructs is true.
+func diffNodes(original, modified GoStruct, withAtomic, withStructs bool, opts []DiffOpt) (*diffTrees, error) {
 	if reflect.TypeOf(original) != reflect.TypeOf(modified) {
 		return nil, fmt.Errorf("cannot diff structs of different types, original: %T, modified: %T", original, modified)
 	}
 
-	origLeaves, err := findSetLeaves(original, withAtomic, opts...)
+	if wd := hasDiffWithDefaults(opts); wd != nil && wd.Mode != WithDefaultsExplicit {
+		var err error
+		if original, err = ApplyWithDefaults(wd.Schema, original, wd.Mode); err != nil {
+			return nil, fmt.Errorf("cannot apply with-defaults mode to original struct: %v", err)
+		}
+		if modified, err = ApplyWithDefaults(wd.Schema, modified, wd.Mode); err != nil {
+			return nil, fmt.Errorf("cannot apply with-defaults mode to modified struct: %v", err)
+		}
+	}
+
+	if nz := hasNormalizers(opts); nz != nil {
+		var err error
+		if original, err = nz.normalizedCopy(original); err != nil {
+			return nil, fmt.Errorf("cannot normalize original struct: %v", err)
+		}
+		if modified, err = nz.normalizedCopy(modified); err != nil {
+			return nil, fmt.Errorf("cannot normalize modified struct: %v", err)
+		}
+	}
+
+	var origStructs, modStructs map[*pathSpec]GoStruct
+	if withStructs {
+		origStructs, modStructs = map[*pathSpec]GoStruct{}, map[*pathSpec]GoStruct{}
+	}
+	origLeaves, err := findSetNodes(original, withAtomic, origStructs, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("could not extract set leaves from original struct: %v", err)
 	}
 
-	modLeaves, err := findSetLeaves(modified, withAtomic, opts...)
+	modLeaves, err := findSetNodes(modified, withAtomic, modStructs, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("could not extract set leaves from modified struct: %v", err)
 	}
@@ -675,13 +843,46 @@ func diff(original, modified GoStruct, withAtomic bool, opts ...DiffOpt) ([]*gnm
 	if err != nil {
 		return nil, fmt.Errorf("could not convert leaf path map to string path map: %v", err)
 	}
+	t := &diffTrees{origLeaves: origLeavesStr, modLeaves: modLeavesStr}
+	if withStructs {
+		if t.origStructs, err = toStringPathMap(toValuePathMap(origStructs)); err != nil {
+			return nil, fmt.Errorf("could not convert struct path map to string path map: %v", err)
+		}
+		if t.modStructs, err = toStringPathMap(toValuePathMap(modStructs)); err != nil {
+			return nil, fmt.Errorf("could not convert struct path map to string path map: %v", err)
+		}
+	}
+	return t, nil
+}
+
+// toValuePathMap returns the pathSpec-GoStruct map m as a pathSpec-value map.
+func toValuePathMap(m map[*pathSpec]GoStruct) map[*pathSpec]interface{} {
+	vm := make(map[*pathSpec]interface{}, len(m))
+	for p, gs := range m {
+		vm[p] = gs
+	}
+	return vm
+}
+
+// diff produces a slice of notifications given two GoStructs.
+//
+// See documentation for Diff and DiffWithAtomic for more information.
+//
+//   - withAtomic indicates that atomic notifications should be generated
+//     (currently this is only supported for `ordered-by user` lists)
+func diff(original, modified GoStruct, withAtomic bool, opts ...DiffOpt) (_ []*gnmipb.Notification, err error) {
+	done := util.StartOperation(util.OpDiff)
+	defer func() { done(err) }()
+	origLeavesStr, modLeavesStr, err := diffLeaves(original, modified, withAtomic, opts)
+	if err != nil {
+		return nil, err
+	}
 
 	var atomicNotifs []*gnmipb.Notification
 	n := &gnmipb.Notification{}
 	processUpdate := func(path string, modVal *pathInfo) error {
 		if orderedMap, isOrderedMap := modVal.val.(GoOrderedMap); isOrderedMap {
-			diffopts := hasDiffPathOpt(opts)
-			preferShadowPath := diffopts != nil && diffopts.PreferShadowPath
+			preferShadowPath := hasDiffPreferShadowPath(opts)
 			notif, err := orderedMapNotif(orderedMap, newPathElemGNMIPath(modVal.path.GetElem()), 0, preferShadowPath)
 			if err != nil {
 				return err
@@ -697,6 +898,18 @@ func diff(original, modified GoStruct, withAtomic bool, opts ...DiffOpt) ([]*gnm
 		return nil
 	}
 
+	// hasChildren reports whether any of the paths in leaves are descendants
+	// of path.
+	hasChildren := func(leaves map[string]*pathInfo, path *gnmipb.Path) bool {
+		for _, l := range leaves {
+			if len(l.path.GetElem()) > len(path.GetElem()) && util.PathMatchesPathElemPrefix(l.path, path) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var removedPresence []*gnmipb.Path
 	for origPath, origVal := range origLeavesStr {
 		if modVal, ok := modLeavesStr[origPath]; ok {
 			if !reflect.DeepEqual(origVal.val, modVal.val) {
@@ -712,17 +925,44 @@ func diff(original, modified GoStruct, withAtomic bool, opts ...DiffOpt) ([]*gnm
 				}
 				origVal.path.Elem = origVal.path.Elem[:pathLen-1]
 			}
+			if _, isPresence := origVal.val.(presenceContainer); isPresence {
+				removedPresence = append(removedPresence, origVal.path)
+			}
 			// This leaf was set in the original struct, but not in the modified
 			// struct, therefore it has been deleted.
 			n.Delete = append(n.Delete, origVal.path)
 		}
 	}
 
+	// A deleted presence container removes all of its descendants, hence the
+	// deletion of the leaves within it is subsumed by that of the container.
+	if len(removedPresence) != 0 {
+		var dels []*gnmipb.Path
+		for _, d := range n.Delete {
+			subsumed := false
+			for _, p := range removedPresence {
+				if len(d.GetElem()) > len(p.GetElem()) && util.PathMatchesPathElemPrefix(d, p) {
+					subsumed = true
+					break
+				}
+			}
+			if !subsumed {
+				dels = append(dels, d)
+			}
+		}
+		n.Delete = dels
+	}
+
 	if hasIgnoreAdditions(opts) == nil {
 		// Check that all paths that are in the modified struct have been examined, if
 		// not they are updates.
 		for modPath, modVal := range modLeavesStr {
 			if _, ok := origLeavesStr[modPath]; !ok {
+				// A presence container that is created along with leaves
+				// within it does not require an explicit update.
+				if _, isPresence := modVal.val.(presenceContainer); isPresence && hasChildren(modLeavesStr, modVal.path) {
+					continue
+				}
 				if err := processUpdate(modPath, modVal); err != nil {
 					return nil, err
 				}
@@ -730,9 +970,22 @@ func diff(original, modified GoStruct, withAtomic bool, opts ...DiffOpt) ([]*gnm
 		}
 	}
 
-	if len(n.Delete)+len(n.Update) == 0 {
-		return atomicNotifs, nil
+	notifs := atomicNotifs
+	if len(n.Delete)+len(n.Update) != 0 {
+		notifs = append([]*gnmipb.Notification{n}, atomicNotifs...)
 	}
 
-	return append([]*gnmipb.Notification{n}, atomicNotifs...), nil
+	if hasValidateDiffPaths(opts) != nil {
+		if err := validateNotificationPaths(notifs); err != nil {
+			return nil, err
+		}
+	}
+	// Values are redacted after they are compared, such that a change to
+	// a sensitive leaf is reported without its value.
+	if rd := hasRedactor(opts); rd != nil {
+		for i, n := range notifs {
+			notifs[i] = rd.RedactNotification(n)
+		}
+	}
+	return notifs, nil
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/exampleoc"
 	"github.com/openconfig/ygot/integration_tests/schemaops/ctestschema"
 	"github.com/openconfig/ygot/integration_tests/schemaops/utestschema"
 	"github.com/openconfig/ygot/internal/ytestutil"
@@ -795,3 +796,137 @@ func TestDiffOrderedMap(t *testing.T) {
 		})
 	}
 }
+
+// orderedMapOf returns an ordered map containing entries with the keys
+// supplied, in order.
+func orderedMapOf(t *testing.T, keys ...string) *ctestschema.OrderedList_OrderedMap {
+	om := &ctestschema.OrderedList_OrderedMap{}
+	for _, k := range keys {
+		v, err := om.AppendNew(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v.Value = ygot.String(k + "-val")
+	}
+	return om
+}
+
+func TestDiffOrderedListInserts(t *testing.T) {
+	tests := []struct {
+		name          string
+		inOrig, inMod ygot.GoStruct
+		want          []*ygot.ListInsert
+		wantErrSubstr string
+	}{{
+		name:   "no change",
+		inOrig: &ctestschema.Device{OrderedList: orderedMapOf(t, "foo", "bar")},
+		inMod:  &ctestschema.Device{OrderedList: orderedMapOf(t, "foo", "bar")},
+	}, {
+		name:   "swapped entries",
+		inOrig: &ctestschema.Device{OrderedList: orderedMapOf(t, "foo", "bar")},
+		inMod:  &ctestschema.Device{OrderedList: orderedMapOf(t, "bar", "foo")},
+		want: []*ygot.ListInsert{{
+			Path:     mustPath(`/ordered-lists/ordered-list[key=bar]`),
+			Position: ygot.InsertFirst,
+		}},
+	}, {
+		name:   "entry moved to middle",
+		inOrig: &ctestschema.Device{OrderedList: orderedMapOf(t, "foo", "bar", "baz")},
+		inMod:  &ctestschema.Device{OrderedList: orderedMapOf(t, "foo", "baz", "bar", "wee")},
+		want: []*ygot.ListInsert{{
+			Path:     mustPath(`/ordered-lists/ordered-list[key=baz]`),
+			Position: ygot.InsertAfter,
+			Point:    mustPath(`/ordered-lists/ordered-list[key=foo]`),
+		}, {
+			Path:     mustPath(`/ordered-lists/ordered-list[key=wee]`),
+			Position: ygot.InsertLast,
+		}},
+	}, {
+		name:   "created entries",
+		inOrig: &ctestschema.Device{OrderedList: orderedMapOf(t, "foo", "bar")},
+		inMod:  &ctestschema.Device{OrderedList: orderedMapOf(t, "wee", "foo", "woo", "bar")},
+		want: []*ygot.ListInsert{{
+			Path:     mustPath(`/ordered-lists/ordered-list[key=wee]`),
+			Position: ygot.InsertFirst,
+		}, {
+			Path:     mustPath(`/ordered-lists/ordered-list[key=woo]`),
+			Position: ygot.InsertAfter,
+			Point:    mustPath(`/ordered-lists/ordered-list[key=foo]`),
+		}},
+	}, {
+		name:   "deleted entry",
+		inOrig: &ctestschema.Device{OrderedList: orderedMapOf(t, "foo", "bar", "baz")},
+		inMod:  &ctestschema.Device{OrderedList: orderedMapOf(t, "foo", "baz")},
+	}, {
+		name:   "list created",
+		inOrig: &ctestschema.Device{},
+		inMod:  &ctestschema.Device{OrderedList: orderedMapOf(t, "foo", "bar")},
+		want: []*ygot.ListInsert{{
+			Path:     mustPath(`/ordered-lists/ordered-list[key=foo]`),
+			Position: ygot.InsertFirst,
+		}, {
+			Path:     mustPath(`/ordered-lists/ordered-list[key=bar]`),
+			Position: ygot.InsertLast,
+		}},
+	}, {
+		name:          "different types",
+		inOrig:        &ctestschema.Device{},
+		inMod:         &utestschema.Device{},
+		wantErrSubstr: "cannot diff structs of different types",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ygot.DiffOrderedListInserts(tt.inOrig, tt.inMod)
+			if diff := errdiff.Substring(err, tt.wantErrSubstr); diff != "" {
+				t.Fatalf("DiffOrderedListInserts: %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("DiffOrderedListInserts: (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInverseDiffRoundTrip(t *testing.T) {
+	newOrig := func() *exampleoc.Device {
+		d := &exampleoc.Device{}
+		i := d.GetOrCreateInterface("eth0")
+		i.Description = ygot.String("uplink")
+		i.Mtu = ygot.Uint16(1500)
+		d.GetOrCreateInterface("eth1").Description = ygot.String("spare")
+		d.GetOrCreateSystem().Hostname = ygot.String("r1")
+		return d
+	}
+	// Within eth0, the description is removed, the MTU is changed and
+	// enabled is added. eth1 is removed, eth2 is added, and the hostname is
+	// changed.
+	mod := &exampleoc.Device{}
+	mi := mod.GetOrCreateInterface("eth0")
+	mi.Mtu = ygot.Uint16(9000)
+	mi.Enabled = ygot.Bool(true)
+	mod.GetOrCreateInterface("eth2").Description = ygot.String("new")
+	mod.GetOrCreateSystem().Hostname = ygot.String("r2")
+
+	got, err := ygot.InverseDiff(newOrig(), mod)
+	if err != nil {
+		t.Fatalf("InverseDiff: got unexpected error: %v", err)
+	}
+
+	// Applying the inverse diff to modified restores original.
+	schema := exampleoc.SchemaTree["Device"]
+	for _, p := range got.GetDelete() {
+		if err := ytypes.DeleteNode(schema, mod, p); err != nil {
+			t.Fatalf("cannot delete %v: %v", p, err)
+		}
+	}
+	for _, u := range got.GetUpdate() {
+		if err := ytypes.SetNode(schema, mod, u.GetPath(), u.GetVal(), &ytypes.InitMissingElements{}); err != nil {
+			t.Fatalf("cannot update %v: %v", u.GetPath(), err)
+		}
+	}
+	ygot.PruneEmptyBranches(mod)
+	if diff := cmp.Diff(newOrig(), mod); diff != "" {
+		t.Errorf("InverseDiff: applying result to modified did not restore original (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/openconfig/ygot/util"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// DiffReplace is a DiffOpt that specifies that DiffSetRequest should
+// classify the differences between the original and modified GoStructs into
+// containers and list entries that are replaced, and leaves that are
+// updated. It has no effect on Diff.
+type DiffReplace struct{}
+
+// IsDiffOpt marks DiffReplace as a valid DiffOpt.
+func (*DiffReplace) IsDiffOpt() {}
+
+// hasDiffReplace returns the first DiffReplace from an opts slice, or nil if
+// there isn't one.
+func hasDiffReplace(opts []DiffOpt) *DiffReplace {
+	for _, o := range opts {
+		switch v := o.(type) {
+		case *DiffReplace:
+			return v
+		}
+	}
+	return nil
+}
+
+// DiffSetRequest takes an original and modified GoStruct, which must be of
+// the same type, and returns a gNMI SetRequest that transforms original into
+// modified when it is applied. The deletes, replaces and updates of the
+// SetRequest are each sorted by path, as per util.SortPaths.
+//
+// By default, the SetRequest contains the same deletes and updates as the
+// Notification returned by Diff. If the DiffReplace option is supplied, the
+// differences are classified as follows:
+//   - A container or list entry that is present in modified, but has no
+//     leaves set in original, is replaced with its modified contents. This
+//     is the case for a newly created list entry. Such nodes are not
+//     reported if the IgnoreAdditions option is supplied.
+//   - A container or list entry in which leaves are both deleted and
+//     updated is replaced with its modified contents. Where such changes
+//     are nested, the innermost container or list entry is replaced.
+//     If the IgnoreAdditions option is supplied, a node in which leaves are
+//     added is not replaced, since the replace would create them.
+//   - A container or list entry that has leaves set in original, but none
+//     in modified, is deleted as a whole, rather than each of its leaves.
+//   - The remaining differences are reported as updates and deletes of
+//     individual leaves.
+//
+// In each case, only the outermost of the affected nodes is reported. The
+// value of a replace is the JSON_IETF rendering of the modified container or
+// list entry.
+//
+// The other supplied DiffOpts modify the comparison in the same manner as for
+// Diff. The paths of the SetRequest are relative to the GoStructs supplied,
+// and no prefix is set.
+func DiffSetRequest(original, modified GoStruct, opts ...DiffOpt) (*gnmipb.SetRequest, error) {
+	notifs, err := diff(original, modified, false, opts...)
+	if err != nil {
+		return nil, err
+	}
+	req := &gnmipb.SetRequest{}
+	for _, n := range notifs {
+		req.Delete = append(req.Delete, n.GetDelete()...)
+		req.Update = append(req.Update, n.GetUpdate()...)
+	}
+
+	if hasDiffReplace(opts) != nil {
+		if req, err = classifyReplaces(original, modified, req, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	sortSetRequest(req)
+	return req, nil
+}
+
+// classifyReplaces returns the SetRequest req, which contains the leaf-level
+// differences between original and modified, with the changes within
+// containers and list entries that are replaced or deleted as a whole
+// substituted by replaces and deletes of those nodes.
+func classifyReplaces(original, modified GoStruct, req *gnmipb.SetRequest, opts []DiffOpt) (*gnmipb.SetRequest, error) {
+	t, err := diffNodes(original, modified, false, true, opts)
+	if err != nil {
+		return nil, err
+	}
+	ignoreAdditions := hasIgnoreAdditions(opts) != nil
+
+	var origLeaves, modLeaves, deleted, updated, added pathIndex
+	for p, o := range t.origLeaves {
+		if err := origLeaves.add(o.path); err != nil {
+			return nil, err
+		}
+		if _, ok := t.modLeaves[p]; !ok {
+			if err := deleted.add(o.path); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for p, m := range t.modLeaves {
+		if err := modLeaves.add(m.path); err != nil {
+			return nil, err
+		}
+		o, ok := t.origLeaves[p]
+		switch {
+		case !ok:
+			if err := added.add(m.path); err != nil {
+				return nil, err
+			}
+			if !ignoreAdditions {
+				if err := updated.add(m.path); err != nil {
+					return nil, err
+				}
+			}
+		case !reflect.DeepEqual(o.val, m.val):
+			if err := updated.add(m.path); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var structDels, replaces []*gnmipb.Path
+	replaceVals := map[*gnmipb.Path]GoStruct{}
+	for p, o := range t.origStructs {
+		if len(o.path.GetElem()) == 0 || !origLeaves.hasDescendant(o.path) {
+			continue
+		}
+		if _, ok := t.modStructs[p]; !ok || !modLeaves.hasDescendant(o.path) {
+			structDels = append(structDels, o.path)
+		}
+	}
+	if !ignoreAdditions {
+		for p, m := range t.modStructs {
+			if len(m.path.GetElem()) == 0 || !modLeaves.hasDescendant(m.path) {
+				continue
+			}
+			if _, ok := t.origStructs[p]; !ok || !origLeaves.hasDescendant(m.path) {
+				replaces = append(replaces, m.path)
+				replaceVals[m.path] = m.val.(GoStruct)
+			}
+		}
+	}
+
+	// Nodes in which leaves are both deleted and updated are replaced, with
+	// the innermost of such nodes being chosen, hence they are examined in
+	// order of decreasing depth. Where additions are ignored, a node in
+	// which leaves are added is not replaced, since its replacement would
+	// create them.
+	var mixed []*pathInfo
+	for _, m := range t.modStructs {
+		if len(m.path.GetElem()) == 0 || !deleted.hasDescendant(m.path) || !updated.hasDescendant(m.path) {
+			continue
+		}
+		if ignoreAdditions && added.hasDescendant(m.path) {
+			continue
+		}
+		mixed = append(mixed, m)
+	}
+	sort.Slice(mixed, func(i, j int) bool { return len(mixed[i].path.GetElem()) > len(mixed[j].path.GetElem()) })
+	var inner pathIndex
+	for _, m := range mixed {
+		if inner.hasDescendant(m.path) {
+			continue
+		}
+		if err := inner.add(m.path); err != nil {
+			return nil, err
+		}
+		replaces = append(replaces, m.path)
+		replaceVals[m.path] = m.val.(GoStruct)
+	}
+
+	// A node that is within another that is deleted or replaced is
+	// subsumed by it.
+	var covered pathIndex
+	for _, p := range append(append([]*gnmipb.Path{}, structDels...), replaces...) {
+		if err := covered.add(p); err != nil {
+			return nil, err
+		}
+	}
+	outermost := func(paths []*gnmipb.Path) []*gnmipb.Path {
+		var out []*gnmipb.Path
+		for _, p := range paths {
+			if !covered.hasAncestor(p) {
+				out = append(out, p)
+			}
+		}
+		return out
+	}
+	structDels, replaces = outermost(structDels), outermost(replaces)
+
+	out := &gnmipb.SetRequest{Delete: structDels}
+	for _, d := range req.GetDelete() {
+		if !covered.covers(d) {
+			out.Delete = append(out.Delete, d)
+		}
+	}
+	for _, u := range req.GetUpdate() {
+		if !covered.covers(u.GetPath()) {
+			out.Update = append(out.Update, u)
+		}
+	}
+
+	rd := hasRedactor(opts)
+	for _, p := range replaces {
+		v, err := EncodeTypedValue(replaceVals[p], gnmipb.Encoding_JSON_IETF, &RFC7951JSONConfig{AppendModuleName: true})
+		if err != nil {
+			return nil, fmt.Errorf("cannot represent node at %v as TypedValue: %v", p, err)
+		}
+		if rd != nil {
+			v = rd.redactValue(p, v)
+		}
+		out.Replace = append(out.Replace, &gnmipb.Update{Path: p, Val: v})
+	}
+	return out, nil
+}
+
+// pathIndex is a set of gNMI paths, indexed by their prefixes, such that the
+// ancestors and descendants of a path within the set can be found without
+// examining each path in the set. The zero value is an empty set.
+type pathIndex struct {
+	// paths contains the string forms of the paths within the set.
+	paths map[string]bool
+	// ancestors contains the string forms of the strict prefixes of the
+	// paths within the set.
+	ancestors map[string]bool
+}
+
+// add adds the path p to the set.
+func (x *pathIndex) add(p *gnmipb.Path) error {
+	keys, err := prefixKeys(p)
+	if err != nil {
+		return err
+	}
+	if x.paths == nil {
+		x.paths, x.ancestors = map[string]bool{}, map[string]bool{}
+	}
+	x.paths[keys[len(keys)-1]] = true
+	for _, k := range keys[:len(keys)-1] {
+		x.ancestors[k] = true
+	}
+	return nil
+}
+
+// hasDescendant reports whether the set contains a descendant of p.
+func (x *pathIndex) hasDescendant(p *gnmipb.Path) bool {
+	keys, err := prefixKeys(p)
+	return err == nil && x.ancestors[keys[len(keys)-1]]
+}
+
+// hasAncestor reports whether the set contains an ancestor of p.
+func (x *pathIndex) hasAncestor(p *gnmipb.Path) bool {
+	keys, err := prefixKeys(p)
+	if err != nil {
+		return false
+	}
+	for _, k := range keys[:len(keys)-1] {
+		if x.paths[k] {
+			return true
+		}
+	}
+	return false
+}
+
+// covers reports whether the set contains p, or an ancestor of p.
+func (x *pathIndex) covers(p *gnmipb.Path) bool {
+	keys, err := prefixKeys(p)
+	if err != nil {
+		return false
+	}
+	for _, k := range keys {
+		if x.paths[k] {
+			return true
+		}
+	}
+	return false
+}
+
+// prefixKeys returns the string forms of each of the prefixes of p, in order
+// of increasing length, starting with the empty path and ending with p.
+func prefixKeys(p *gnmipb.Path) ([]string, error) {
+	keys := make([]string, 1, len(p.GetElem())+1)
+	for _, e := range p.GetElem() {
+		s, err := PathToString(&gnmipb.Path{Elem: []*gnmipb.PathElem{e}})
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert path %v to string: %v", p, err)
+		}
+		keys = append(keys, keys[len(keys)-1]+s)
+	}
+	return keys, nil
+}
+
+// sortSetRequest sorts the deletes, replaces and updates of req by path, in
+// the order defined by util.ComparePathOrder.
+func sortSetRequest(req *gnmipb.SetRequest) {
+	util.SortPaths(req.Delete)
+	for _, us := range [][]*gnmipb.Update{req.Replace, req.Update} {
+		sort.SliceStable(us, func(i, j int) bool { return util.ComparePathOrder(us[i].GetPath(), us[j].GetPath()) < 0 })
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestDiffSetRequest(t *testing.T) {
+	strVal := func(s string) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: s}}
+	}
+	uintVal := func(u uint64) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: u}}
+	}
+	jsonVal := func(gs ygot.GoStruct) *gnmipb.TypedValue {
+		v, err := ygot.EncodeTypedValue(gs, gnmipb.Encoding_JSON_IETF, &ygot.RFC7951JSONConfig{AppendModuleName: true})
+		if err != nil {
+			t.Fatalf("cannot encode %v: %v", gs, err)
+		}
+		return v
+	}
+
+	orig := &exampleoc.Device{}
+	oi := orig.GetOrCreateInterface("eth0")
+	oi.Description = ygot.String("uplink")
+	oi.Mtu = ygot.Uint16(1500)
+	ol := orig.GetOrCreateInterface("eth1")
+	ol.Description = ygot.String("spare")
+	orig.GetOrCreateSystem().Hostname = ygot.String("r1")
+
+	// eth0 has its description removed and its MTU changed, eth1 is removed,
+	// eth2 is added, and the hostname is changed.
+	mod := &exampleoc.Device{}
+	mi := mod.GetOrCreateInterface("eth0")
+	mi.Mtu = ygot.Uint16(9000)
+	ni := mod.GetOrCreateInterface("eth2")
+	ni.Description = ygot.String("new")
+	mod.GetOrCreateSystem().Hostname = ygot.String("r2")
+
+	// Only the hostname is changed.
+	leafOnly := &exampleoc.Device{}
+	li := leafOnly.GetOrCreateInterface("eth0")
+	li.Description = ygot.String("uplink")
+	li.Mtu = ygot.Uint16(1500)
+	leafOnly.GetOrCreateInterface("eth1").Description = ygot.String("spare")
+	leafOnly.GetOrCreateSystem().Hostname = ygot.String("r2")
+
+	// eth0 additionally has a leaf added alongside the removed description
+	// and the changed MTU.
+	withAddition := &exampleoc.Device{}
+	ai := withAddition.GetOrCreateInterface("eth0")
+	ai.Mtu = ygot.Uint16(9000)
+	ai.Enabled = ygot.Bool(true)
+	withAddition.GetOrCreateInterface("eth1").Description = ygot.String("spare")
+	withAddition.GetOrCreateSystem().Hostname = ygot.String("r1")
+
+	tests := []struct {
+		desc             string
+		inOrig, inMod    ygot.GoStruct
+		inOpts           []ygot.DiffOpt
+		want             *gnmipb.SetRequest
+		wantErrSubstring string
+	}{{
+		desc:   "without replace classification",
+		inOrig: orig,
+		inMod:  mod,
+		want: &gnmipb.SetRequest{
+			Delete: []*gnmipb.Path{
+				mustPath("/interfaces/interface[name=eth0]/config/description"),
+				mustPath("/interfaces/interface[name=eth1]/config/description"),
+				mustPath("/interfaces/interface[name=eth1]/config/name"),
+				mustPath("/interfaces/interface[name=eth1]/name"),
+			},
+			Update: []*gnmipb.Update{{
+				Path: mustPath("/interfaces/interface[name=eth0]/config/mtu"),
+				Val:  uintVal(9000),
+			}, {
+				Path: mustPath("/interfaces/interface[name=eth2]/config/description"),
+				Val:  strVal("new"),
+			}, {
+				Path: mustPath("/interfaces/interface[name=eth2]/config/name"),
+				Val:  strVal("eth2"),
+			}, {
+				Path: mustPath("/interfaces/interface[name=eth2]/name"),
+				Val:  strVal("eth2"),
+			}, {
+				Path: mustPath("/system/config/hostname"),
+				Val:  strVal("r2"),
+			}},
+		},
+	}, {
+		desc:   "replaced, created and deleted list entries",
+		inOrig: orig,
+		inMod:  mod,
+		inOpts: []ygot.DiffOpt{&ygot.DiffReplace{}},
+		want: &gnmipb.SetRequest{
+			Delete: []*gnmipb.Path{
+				mustPath("/interfaces/interface[name=eth1]"),
+			},
+			Replace: []*gnmipb.Update{{
+				Path: mustPath("/interfaces/interface[name=eth0]"),
+				Val:  jsonVal(mi),
+			}, {
+				Path: mustPath("/interfaces/interface[name=eth2]"),
+				Val:  jsonVal(ni),
+			}},
+			Update: []*gnmipb.Update{{
+				Path: mustPath("/system/config/hostname"),
+				Val:  strVal("r2"),
+			}},
+		},
+	}, {
+		desc:   "leaf updates only",
+		inOrig: orig,
+		inMod:  leafOnly,
+		inOpts: []ygot.DiffOpt{&ygot.DiffReplace{}},
+		want: &gnmipb.SetRequest{
+			Update: []*gnmipb.Update{{
+				Path: mustPath("/system/config/hostname"),
+				Val:  strVal("r2"),
+			}},
+		},
+	}, {
+		desc:   "ignore additions",
+		inOrig: orig,
+		inMod:  mod,
+		inOpts: []ygot.DiffOpt{&ygot.DiffReplace{}, &ygot.IgnoreAdditions{}},
+		want: &gnmipb.SetRequest{
+			Delete: []*gnmipb.Path{
+				mustPath("/interfaces/interface[name=eth1]"),
+			},
+			Replace: []*gnmipb.Update{{
+				Path: mustPath("/interfaces/interface[name=eth0]"),
+				Val:  jsonVal(mi),
+			}},
+			Update: []*gnmipb.Update{{
+				Path: mustPath("/system/config/hostname"),
+				Val:  strVal("r2"),
+			}},
+		},
+	}, {
+		desc:   "node with additions is replaced",
+		inOrig: orig,
+		inMod:  withAddition,
+		inOpts: []ygot.DiffOpt{&ygot.DiffReplace{}},
+		want: &gnmipb.SetRequest{
+			Replace: []*gnmipb.Update{{
+				Path: mustPath("/interfaces/interface[name=eth0]"),
+				Val:  jsonVal(ai),
+			}},
+		},
+	}, {
+		desc:   "node with ignored additions is not replaced",
+		inOrig: orig,
+		inMod:  withAddition,
+		inOpts: []ygot.DiffOpt{&ygot.DiffReplace{}, &ygot.IgnoreAdditions{}},
+		want: &gnmipb.SetRequest{
+			Delete: []*gnmipb.Path{
+				mustPath("/interfaces/interface[name=eth0]/config/description"),
+			},
+			Update: []*gnmipb.Update{{
+				Path: mustPath("/interfaces/interface[name=eth0]/config/mtu"),
+				Val:  uintVal(9000),
+			}},
+		},
+	}, {
+		desc:             "different types",
+		inOrig:           orig,
+		inMod:            mi,
+		inOpts:           []ygot.DiffOpt{&ygot.DiffReplace{}},
+		wantErrSubstring: "cannot diff structs of different types",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ygot.DiffSetRequest(tt.inOrig, tt.inMod, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("DiffSetRequest: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("DiffSetRequest: did not get expected SetRequest, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// DiffOp is the operation that a DiffEntry describes.
+type DiffOp int64
+
+const (
+	// DiffCreate indicates that a leaf that is not set in the original
+	// struct is set in the modified struct.
+	DiffCreate DiffOp = iota
+	// DiffUpdate indicates that the value of a leaf differs between the
+	// original and modified structs.
+	DiffUpdate
+	// DiffDelete indicates that a leaf that is set in the original struct
+	// is not set in the modified struct.
+	DiffDelete
+)
+
+// String returns the name of the operation.
+func (o DiffOp) String() string {
+	switch o {
+	case DiffCreate:
+		return "create"
+	case DiffUpdate:
+		return "update"
+	case DiffDelete:
+		return "delete"
+	}
+	return fmt.Sprintf("DiffOp(%d)", int64(o))
+}
+
+// DiffEntry is a single difference between two GoStructs, as returned by
+// DiffStructured.
+type DiffEntry struct {
+	// Path is the path of the leaf that differs.
+	Path *gnmipb.Path
+	// Op is the operation that transforms the leaf in the original struct
+	// into the leaf in the modified struct.
+	Op DiffOp
+	// OldValue is the value of the leaf in the original struct, or nil if
+	// Op is DiffCreate.
+	OldValue *gnmipb.TypedValue
+	// NewValue is the value of the leaf in the modified struct, or nil if
+	// Op is DiffDelete.
+	NewValue *gnmipb.TypedValue
+	// Schema is the schema of the leaf, which is populated only when the
+	// DiffSchema option is supplied, and the leaf is found within it.
+	Schema *yang.Entry
+}
+
+// String returns a human-readable form of the entry.
+func (d *DiffEntry) String() string {
+	p, err := PathToString(d.Path)
+	if err != nil {
+		p = d.Path.String()
+	}
+	switch d.Op {
+	case DiffCreate:
+		return fmt.Sprintf("%s %s: %s", d.Op, p, d.NewValue)
+	case DiffDelete:
+		return fmt.Sprintf("%s %s: %s", d.Op, p, d.OldValue)
+	}
+	return fmt.Sprintf("%s %s: %s -> %s", d.Op, p, d.OldValue, d.NewValue)
+}
+
+// DiffSchema is a DiffOpt that supplies the schema of the root of the
+// GoStructs that are compared by DiffStructured, such that the schema of
+// each leaf is returned within its DiffEntry.
+type DiffSchema struct {
+	// Root is the schema of the root of the GoStructs.
+	Root *yang.Entry
+}
+
+// IsDiffOpt marks DiffSchema as a valid DiffOpt.
+func (*DiffSchema) IsDiffOpt() {}
+
+// hasDiffSchema returns the first DiffSchema from an opts slice, or nil if
+// there isn't one.
+func hasDiffSchema(opts []DiffOpt) *DiffSchema {
+	for _, o := range opts {
+		switch v := o.(type) {
+		case *DiffSchema:
+			return v
+		}
+	}
+	return nil
+}
+
+// DiffStructured takes an original and modified GoStruct, which must be of
+// the same type, and returns the differences between them as a slice of
+// DiffEntry, sorted by path in the order defined by util.ComparePathOrder,
+// which is the order of the paths within the SetRequest returned by
+// DiffSetRequest. Unlike Diff, each entry carries both the value of the leaf
+// in the original struct and its value in the modified struct, such that the
+// original values do not need to be recovered by a second diff in the
+// reverse direction.
+//
+// The options to Diff are supported, with the exception of ValidateDiffPaths;
+// the schema of each leaf is returned when the DiffSchema option is supplied.
+// When a Redactor is supplied, the values of sensitive leaves are redacted
+// after the structs are compared, such that a change to a sensitive leaf is
+// reported without revealing its old or new value.
+func DiffStructured(original, modified GoStruct, opts ...DiffOpt) ([]*DiffEntry, error) {
+	origLeaves, modLeaves, err := diffLeaves(original, modified, false, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	byPath := map[string]*DiffEntry{}
+	newEntry := func(path string, op DiffOp, origVal, modVal *pathInfo) error {
+		d := &DiffEntry{Op: op}
+		var err error
+		if origVal != nil {
+			d.Path = origVal.path
+			if d.OldValue, err = diffTypedValue(origVal.val); err != nil {
+				return fmt.Errorf("cannot represent field value %v as TypedValue for path %v: %v", origVal.val, path, err)
+			}
+		}
+		if modVal != nil {
+			d.Path = modVal.path
+			if d.NewValue, err = diffTypedValue(modVal.val); err != nil {
+				return fmt.Errorf("cannot represent field value %v as TypedValue for path %v: %v", modVal.val, path, err)
+			}
+		}
+		byPath[path] = d
+		return nil
+	}
+
+	for path, origVal := range origLeaves {
+		var err error
+		switch modVal, ok := modLeaves[path]; {
+		case !ok:
+			err = newEntry(path, DiffDelete, origVal, nil)
+		case !reflect.DeepEqual(origVal.val, modVal.val):
+			err = newEntry(path, DiffUpdate, origVal, modVal)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if hasIgnoreAdditions(opts) == nil {
+		for path, modVal := range modLeaves {
+			if _, ok := origLeaves[path]; !ok {
+				if err := newEntry(path, DiffCreate, nil, modVal); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	entries := make([]*DiffEntry, 0, len(byPath))
+	for _, d := range byPath {
+		entries = append(entries, d)
+	}
+	sort.Slice(entries, func(i, j int) bool { return util.ComparePathOrder(entries[i].Path, entries[j].Path) < 0 })
+
+	schema := hasDiffSchema(opts)
+	rd := hasRedactor(opts)
+	for _, d := range entries {
+		if schema != nil {
+			d.Schema = schemaAtPath(schema.Root, d.Path)
+		}
+		if rd != nil {
+			if d.OldValue != nil {
+				d.OldValue = rd.redactValue(d.Path, d.OldValue)
+			}
+			if d.NewValue != nil {
+				d.NewValue = rd.redactValue(d.Path, d.NewValue)
+			}
+		}
+	}
+
+	return entries, nil
+}
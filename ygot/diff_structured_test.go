@@ -0,0 +1,233 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestDiffStructured(t *testing.T) {
+	strVal := func(s string) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: s}}
+	}
+	uintVal := func(u uint64) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: u}}
+	}
+
+	orig := &exampleoc.Device{}
+	oi := orig.GetOrCreateInterface("eth0")
+	oi.Description = ygot.String("old")
+	oi.Mtu = ygot.Uint16(1500)
+	orig.GetOrCreateSystem().Hostname = ygot.String("r1")
+
+	mod := &exampleoc.Device{}
+	mi := mod.GetOrCreateInterface("eth0")
+	mi.Description = ygot.String("new")
+	mi.Mtu = ygot.Uint16(1500)
+	mod.GetOrCreateSystem().DomainName = ygot.String("example.com")
+
+	tests := []struct {
+		desc             string
+		inOrig, inMod    ygot.GoStruct
+		inOpts           []ygot.DiffOpt
+		want             []*ygot.DiffEntry
+		wantSchemaNames  []string
+		wantErrSubstring string
+	}{{
+		desc:   "create, update and delete",
+		inOrig: orig,
+		inMod:  mod,
+		want: []*ygot.DiffEntry{{
+			Path:     mustPath("/interfaces/interface[name=eth0]/config/description"),
+			Op:       ygot.DiffUpdate,
+			OldValue: strVal("old"),
+			NewValue: strVal("new"),
+		}, {
+			Path:     mustPath("/system/config/domain-name"),
+			Op:       ygot.DiffCreate,
+			NewValue: strVal("example.com"),
+		}, {
+			Path:     mustPath("/system/config/hostname"),
+			Op:       ygot.DiffDelete,
+			OldValue: strVal("r1"),
+		}},
+	}, {
+		desc:   "ignore additions",
+		inOrig: orig,
+		inMod:  mod,
+		inOpts: []ygot.DiffOpt{&ygot.IgnoreAdditions{}},
+		want: []*ygot.DiffEntry{{
+			Path:     mustPath("/interfaces/interface[name=eth0]/config/description"),
+			Op:       ygot.DiffUpdate,
+			OldValue: strVal("old"),
+			NewValue: strVal("new"),
+		}, {
+			Path:     mustPath("/system/config/hostname"),
+			Op:       ygot.DiffDelete,
+			OldValue: strVal("r1"),
+		}},
+	}, {
+		desc:   "added list entry",
+		inOrig: &exampleoc.Device{},
+		inMod: func() *exampleoc.Device {
+			d := &exampleoc.Device{}
+			d.GetOrCreateInterface("eth1").Mtu = ygot.Uint16(9000)
+			return d
+		}(),
+		want: []*ygot.DiffEntry{{
+			Path:     mustPath("/interfaces/interface[name=eth1]/config/mtu"),
+			Op:       ygot.DiffCreate,
+			NewValue: uintVal(9000),
+		}, {
+			Path:     mustPath("/interfaces/interface[name=eth1]/config/name"),
+			Op:       ygot.DiffCreate,
+			NewValue: strVal("eth1"),
+		}, {
+			Path:     mustPath("/interfaces/interface[name=eth1]/name"),
+			Op:       ygot.DiffCreate,
+			NewValue: strVal("eth1"),
+		}},
+	}, {
+		desc: "integer keys ordered numerically",
+		inOrig: func() *exampleoc.Device {
+			d := &exampleoc.Device{}
+			d.GetOrCreateInterface("eth0")
+			return d
+		}(),
+		inMod: func() *exampleoc.Device {
+			d := &exampleoc.Device{}
+			i := d.GetOrCreateInterface("eth0")
+			i.GetOrCreateSubinterface(10)
+			i.GetOrCreateSubinterface(2)
+			return d
+		}(),
+		want: []*ygot.DiffEntry{{
+			Path:     mustPath("/interfaces/interface[name=eth0]/subinterfaces/subinterface[index=2]/config/index"),
+			Op:       ygot.DiffCreate,
+			NewValue: uintVal(2),
+		}, {
+			Path:     mustPath("/interfaces/interface[name=eth0]/subinterfaces/subinterface[index=2]/index"),
+			Op:       ygot.DiffCreate,
+			NewValue: uintVal(2),
+		}, {
+			Path:     mustPath("/interfaces/interface[name=eth0]/subinterfaces/subinterface[index=10]/config/index"),
+			Op:       ygot.DiffCreate,
+			NewValue: uintVal(10),
+		}, {
+			Path:     mustPath("/interfaces/interface[name=eth0]/subinterfaces/subinterface[index=10]/index"),
+			Op:       ygot.DiffCreate,
+			NewValue: uintVal(10),
+		}},
+	}, {
+		desc:   "with schema",
+		inOrig: orig,
+		inMod:  mod,
+		inOpts: []ygot.DiffOpt{
+			&ygot.IgnoreAdditions{},
+			&ygot.DiffSchema{Root: exampleoc.SchemaTree["Device"]},
+		},
+		want: []*ygot.DiffEntry{{
+			Path:     mustPath("/interfaces/interface[name=eth0]/config/description"),
+			Op:       ygot.DiffUpdate,
+			OldValue: strVal("old"),
+			NewValue: strVal("new"),
+		}, {
+			Path:     mustPath("/system/config/hostname"),
+			Op:       ygot.DiffDelete,
+			OldValue: strVal("r1"),
+		}},
+		wantSchemaNames: []string{"description", "hostname"},
+	}, {
+		desc:   "redacted",
+		inOrig: newRedactDevice("old"),
+		inMod:  newRedactDevice("new"),
+		inOpts: []ygot.DiffOpt{newRedactor()},
+		want: []*ygot.DiffEntry{{
+			Path:     mustPath("/interfaces/interface[name=eth0]/config/description"),
+			Op:       ygot.DiffUpdate,
+			OldValue: strVal(ygot.RedactedValue),
+			NewValue: strVal(ygot.RedactedValue),
+		}},
+	}, {
+		desc:             "different types",
+		inOrig:           &exampleoc.Device{},
+		inMod:            &exampleoc.Interface{},
+		wantErrSubstring: "cannot diff structs of different types",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ygot.DiffStructured(tt.inOrig, tt.inMod, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("DiffStructured: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform(), cmpopts.IgnoreFields(ygot.DiffEntry{}, "Schema")); diff != "" {
+				t.Errorf("DiffStructured: did not get expected entries, diff(-want, +got):\n%s", diff)
+			}
+			var gotSchemaNames []string
+			for _, d := range got {
+				if d.Schema != nil {
+					gotSchemaNames = append(gotSchemaNames, d.Schema.Name)
+				}
+			}
+			if diff := cmp.Diff(tt.wantSchemaNames, gotSchemaNames); diff != "" {
+				t.Errorf("DiffStructured: did not get expected schemas, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiffEntryString(t *testing.T) {
+	tests := []struct {
+		in   *ygot.DiffEntry
+		want string
+	}{{
+		in: &ygot.DiffEntry{
+			Path:     mustPath("/system/config/hostname"),
+			Op:       ygot.DiffUpdate,
+			OldValue: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "r1"}},
+			NewValue: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "r2"}},
+		},
+		want: "update /system/config/hostname",
+	}, {
+		in: &ygot.DiffEntry{
+			Path:     mustPath("/system/config/hostname"),
+			Op:       ygot.DiffDelete,
+			OldValue: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "r1"}},
+		},
+		want: "delete /system/config/hostname",
+	}}
+
+	for _, tt := range tests {
+		if got := tt.in.String(); len(got) < len(tt.want) || got[:len(tt.want)] != tt.want {
+			t.Errorf("String(): got %q, want prefix %q", got, tt.want)
+		}
+	}
+	if got, want := ygot.DiffOp(42).String(), "DiffOp(42)"; got != want {
+		t.Errorf("String(): got %q, want %q", got, want)
+	}
+}
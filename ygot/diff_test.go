@@ -15,6 +15,7 @@
 package ygot
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -865,6 +866,25 @@ func TestDiff(t *testing.T) {
 				Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: 42}},
 			}},
 		},
+	}, {
+		desc:   "path additions with shared PreferShadowPath option",
+		inOrig: &renderExample{},
+		inMod: &renderExample{
+			Str: String("cabernet-sauvignon"),
+		},
+		inOpts: []DiffOpt{
+			&PreferShadowPath{},
+		},
+		want: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: &gnmipb.Path{
+					Elem: []*gnmipb.PathElem{{
+						Name: "srt",
+					}},
+				},
+				Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{"cabernet-sauvignon"}},
+			}},
+		},
 	}, {
 		desc: "one path each modified, deleted, and added with IgnoreNewPaths set",
 		inOrig: &renderExample{
@@ -1599,6 +1619,236 @@ func TestDiff(t *testing.T) {
 	}
 }
 
+// presenceStruct is a GoStruct that stores scalar leaves as values, with
+// their presence recorded in a presence bitmap.
+type presenceStruct struct {
+	Name      string    `path:"name" presence:"0"`
+	Mtu       uint16    `path:"mtu" presence:"1"`
+	Enabled   bool      `path:"enabled" presence:"2"`
+	ΛPresence [1]uint64 `ygotPresence:"true"`
+}
+
+func (*presenceStruct) IsYANGGoStruct() {}
+
+func TestDiffPresenceBitmaps(t *testing.T) {
+	// Leaves that are set to their zero value differ from those that are
+	// unset, and the values of unset leaves are ignored.
+	orig := &presenceStruct{Name: "eth0", Mtu: 1500, ΛPresence: [1]uint64{0b011}}
+	mod := &presenceStruct{Mtu: 9000, ΛPresence: [1]uint64{0b110}}
+
+	got, err := Diff(orig, mod)
+	if err != nil {
+		t.Fatalf("Diff: got unexpected error: %v", err)
+	}
+	want := &gnmipb.Notification{
+		Delete: []*gnmipb.Path{{
+			Elem: []*gnmipb.PathElem{{Name: "name"}},
+		}},
+		Update: []*gnmipb.Update{{
+			Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "mtu"}}},
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 9000}},
+		}, {
+			Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "enabled"}}},
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{BoolVal: false}},
+		}},
+	}
+	if !testutil.NotificationSetEqual([]*gnmipb.Notification{want}, []*gnmipb.Notification{got}) {
+		t.Errorf("Diff: did not get expected Notification, diff(-want, +got):\n%s", cmp.Diff(want, got, protocmp.Transform()))
+	}
+}
+
+// emptyPresenceStruct is a GoStruct that records the presence of its empty
+// leaf in a presence bitmap.
+type emptyPresenceStruct struct {
+	Enabled   YANGEmpty `path:"enabled" presence:"0"`
+	ΛPresence [1]uint64 `ygotPresence:"true"`
+}
+
+func (*emptyPresenceStruct) IsYANGGoStruct() {}
+
+func TestEmptyLeafPresence(t *testing.T) {
+	enabledPath := &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "enabled"}}}
+	present := &emptyPresenceStruct{Enabled: true, ΛPresence: [1]uint64{1}}
+	// The presence bitmap, rather than the value of the leaf, determines
+	// whether the leaf is present.
+	absent := &emptyPresenceStruct{Enabled: true}
+
+	got, err := Diff(present, absent)
+	if err != nil {
+		t.Fatalf("Diff: got unexpected error: %v", err)
+	}
+	want := &gnmipb.Notification{Delete: []*gnmipb.Path{enabledPath}}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Diff: did not get expected Notification, diff(-want, +got):\n%s", diff)
+	}
+
+	got, err = Diff(absent, present)
+	if err != nil {
+		t.Fatalf("Diff: got unexpected error: %v", err)
+	}
+	want = &gnmipb.Notification{Update: []*gnmipb.Update{{
+		Path: enabledPath,
+		Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{BoolVal: true}},
+	}}}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Diff: did not get expected Notification, diff(-want, +got):\n%s", diff)
+	}
+
+	for _, tt := range []struct {
+		desc string
+		in   *emptyPresenceStruct
+		want map[string]any
+	}{{
+		desc: "present",
+		in:   present,
+		want: map[string]any{"enabled": []any{nil}},
+	}, {
+		desc: "absent",
+		in:   absent,
+		want: map[string]any{},
+	}} {
+		gotJSON, err := ConstructIETFJSON(tt.in, nil)
+		if err != nil {
+			t.Fatalf("%s: ConstructIETFJSON: got unexpected error: %v", tt.desc, err)
+		}
+		if diff := cmp.Diff(tt.want, gotJSON); diff != "" {
+			t.Errorf("%s: ConstructIETFJSON: (-want, +got):\n%s", tt.desc, diff)
+		}
+	}
+}
+
+// presenceContainerParent is a GoStruct that contains a YANG presence
+// container, along with an ordinary container.
+type presenceContainerParent struct {
+	Overload *presenceContainerChild `path:"overload" yangPresence:"true"`
+	Config   *presenceContainerChild `path:"config"`
+}
+
+func (*presenceContainerParent) IsYANGGoStruct() {}
+
+type presenceContainerChild struct {
+	Timeout *uint32 `path:"timeout"`
+	Reason  *string `path:"reason"`
+}
+
+func (*presenceContainerChild) IsYANGGoStruct() {}
+
+func TestDiffPresenceContainer(t *testing.T) {
+	overloadPath := &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "overload"}}}
+	emptyJSON := &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte("{}")}}
+
+	tests := []struct {
+		desc   string
+		inOrig *presenceContainerParent
+		inMod  *presenceContainerParent
+		want   *gnmipb.Notification
+	}{{
+		desc:   "empty presence container created",
+		inOrig: &presenceContainerParent{},
+		inMod:  &presenceContainerParent{Overload: &presenceContainerChild{}},
+		want: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{Path: overloadPath, Val: emptyJSON}},
+		},
+	}, {
+		desc:   "empty presence container deleted",
+		inOrig: &presenceContainerParent{Overload: &presenceContainerChild{}},
+		inMod:  &presenceContainerParent{},
+		want:   &gnmipb.Notification{Delete: []*gnmipb.Path{overloadPath}},
+	}, {
+		desc:   "presence container created with leaves",
+		inOrig: &presenceContainerParent{},
+		inMod:  &presenceContainerParent{Overload: &presenceContainerChild{Timeout: Uint32(42)}},
+		want: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "overload"}, {Name: "timeout"}}},
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 42}},
+			}},
+		},
+	}, {
+		desc:   "presence container with leaves deleted",
+		inOrig: &presenceContainerParent{Overload: &presenceContainerChild{Timeout: Uint32(42), Reason: String("maintenance")}},
+		inMod:  &presenceContainerParent{},
+		want:   &gnmipb.Notification{Delete: []*gnmipb.Path{overloadPath}},
+	}, {
+		desc:   "leaves removed from presence container",
+		inOrig: &presenceContainerParent{Overload: &presenceContainerChild{Timeout: Uint32(42)}},
+		inMod:  &presenceContainerParent{Overload: &presenceContainerChild{}},
+		want: &gnmipb.Notification{
+			Delete: []*gnmipb.Path{{Elem: []*gnmipb.PathElem{{Name: "overload"}, {Name: "timeout"}}}},
+		},
+	}, {
+		desc:   "empty ordinary container is ignored",
+		inOrig: &presenceContainerParent{},
+		inMod:  &presenceContainerParent{Config: &presenceContainerChild{}},
+		want:   &gnmipb.Notification{},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := Diff(tt.inOrig, tt.inMod)
+			if err != nil {
+				t.Fatalf("Diff: got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Diff: did not get expected Notification, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// orderedLeafListStruct is a GoStruct that has an `ordered-by user`
+// leaf-list.
+type orderedLeafListStruct struct {
+	Members []string `path:"members" orderedByUser:"true"`
+}
+
+func (*orderedLeafListStruct) IsYANGGoStruct()                         {}
+func (*orderedLeafListStruct) ΛValidate(...ValidationOption) error     { return nil }
+func (*orderedLeafListStruct) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*orderedLeafListStruct) ΛBelongingModule() string                { return "" }
+
+func TestDiffOrderedByUserLeafList(t *testing.T) {
+	membersPath := &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "members"}}}
+	tests := []struct {
+		desc   string
+		inOrig *orderedLeafListStruct
+		inMod  *orderedLeafListStruct
+		want   *gnmipb.Notification
+	}{{
+		desc:   "same order",
+		inOrig: &orderedLeafListStruct{Members: []string{"10.0.0.0/8", "192.0.2.0/24"}},
+		inMod:  &orderedLeafListStruct{Members: []string{"10.0.0.0/8", "192.0.2.0/24"}},
+		want:   &gnmipb.Notification{},
+	}, {
+		desc:   "reordered",
+		inOrig: &orderedLeafListStruct{Members: []string{"10.0.0.0/8", "192.0.2.0/24"}},
+		inMod:  &orderedLeafListStruct{Members: []string{"192.0.2.0/24", "10.0.0.0/8"}},
+		want: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: membersPath,
+				Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_LeaflistVal{LeaflistVal: &gnmipb.ScalarArray{
+					Element: []*gnmipb.TypedValue{
+						{Value: &gnmipb.TypedValue_StringVal{StringVal: "192.0.2.0/24"}},
+						{Value: &gnmipb.TypedValue_StringVal{StringVal: "10.0.0.0/8"}},
+					},
+				}}},
+			}},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := Diff(tt.inOrig, tt.inMod)
+			if err != nil {
+				t.Fatalf("Diff: got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Diff: did not get expected Notification, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestFormatDiff(t *testing.T) {
 	tests := []struct {
 		desc    string
@@ -1643,6 +1893,38 @@ new/updated /int-val: int_val:10`,
 	}
 }
 
+func TestInverseDiff(t *testing.T) {
+	orig := &renderExample{
+		Str:    String("before"),
+		IntVal: Int32(42),
+	}
+	mod := &renderExample{
+		Str: String("after"),
+		Ch:  &renderExampleChild{Val: Uint64(1)},
+	}
+	want := &gnmipb.Notification{
+		Update: []*gnmipb.Update{{
+			Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "str"}}},
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "before"}},
+		}, {
+			Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "int-val"}}},
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: 42}},
+		}},
+		Delete: []*gnmipb.Path{{Elem: []*gnmipb.PathElem{{Name: "ch"}, {Name: "val"}}}},
+	}
+	got, err := InverseDiff(orig, mod)
+	if err != nil {
+		t.Fatalf("InverseDiff: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update", "delete")); diff != "" {
+		t.Errorf("InverseDiff: did not get expected Notification (-want, +got):\n%s", diff)
+	}
+
+	if _, err := InverseDiff(orig, mod, &IgnoreAdditions{}); err == nil {
+		t.Errorf("InverseDiff with IgnoreAdditions: got nil error, want error")
+	}
+}
+
 func TestLeastSpecificPath(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1680,3 +1962,29 @@ func TestLeastSpecificPath(t *testing.T) {
 		}
 	}
 }
+
+type malformedPathStruct struct {
+	Value *string `path:"bad name"`
+}
+
+func (*malformedPathStruct) IsYANGGoStruct() {}
+
+func TestDiffValidatePaths(t *testing.T) {
+	orig, mod := &malformedPathStruct{}, &malformedPathStruct{Value: String("v")}
+
+	if _, err := Diff(orig, mod); err != nil {
+		t.Fatalf("Diff: got unexpected error without ValidateDiffPaths: %v", err)
+	}
+
+	_, err := Diff(orig, mod, &ValidateDiffPaths{})
+	if diff := errdiff.Substring(err, `invalid update path in diff`); diff != "" {
+		t.Fatalf("Diff: did not get expected error, %s", diff)
+	}
+	if !errors.Is(err, util.ErrInvalidPath) {
+		t.Errorf("Diff: got error %v, want error of kind ErrInvalidPath", err)
+	}
+
+	if _, err := Diff(&basicStruct{}, &basicStruct{StringValue: String("v")}, &ValidateDiffPaths{}); err != nil {
+		t.Errorf("Diff: got unexpected error for well-formed paths: %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
+
+// ExtensionStruct is implemented by the extension structs of a package that
+// is generated for YANG modules that augment the schema of a separately
+// generated base package. An extension struct holds the fields of the nodes
+// that augment a container or list of the base package, and is attached at
+// runtime to the GoStruct generated for that container or list, which must
+// have been generated with extension points, such that the base package
+// does not need to be regenerated along with every module that augments it.
+type ExtensionStruct interface {
+	GoStruct
+	// ΛExtensionSchema returns the schema of the node that the extension
+	// struct extends, including the nodes that augment it.
+	ΛExtensionSchema() *yang.Entry
+}
+
+// Extensions is the type of the field of a GoStruct generated with
+// extension points that holds the extension structs attached to it, keyed
+// by the name of the type of each, as returned by ExtensionName. The fields
+// of the extension structs are handled as if they were fields of the
+// GoStruct by functions that reflect upon it, such as those that render,
+// unmarshal, copy, merge, diff and validate GoStructs.
+type Extensions map[string]ExtensionStruct
+
+// ExtensionName returns the name by which the extension struct ext is keyed
+// within Extensions, which is the import path of its package, followed by a
+// dot and the name of its type.
+func ExtensionName(ext ExtensionStruct) string {
+	return extensionTypeName(reflect.TypeOf(ext))
+}
+
+// extensionTypeName returns the name of the extension struct type t, which
+// must be a pointer to a struct.
+func extensionTypeName(t reflect.Type) string {
+	t = t.Elem()
+	return t.PkgPath() + "." + t.Name()
+}
+
+// extensionRegistry is the set of registered extension struct types, keyed
+// by the type of the GoStruct that they extend.
+var extensionRegistry = struct {
+	mu sync.RWMutex
+	m  map[reflect.Type][]reflect.Type
+}{m: map[reflect.Type][]reflect.Type{}}
+
+// RegisterExtension registers the type of the extension struct ext as
+// extending the type of the GoStruct base, such that an extension struct of
+// that type is created and attached to a GoStruct of the type of base when
+// data for the nodes of its fields is unmarshalled into it. It is called
+// when the package of ext is initialised, and panics if base does not have
+// an extensions field.
+func RegisterExtension(base GoStruct, ext ExtensionStruct) {
+	bt, et := reflect.TypeOf(base), reflect.TypeOf(ext)
+	if !util.IsTypeStructPtr(bt) || !util.IsTypeStructPtr(et) {
+		panic(fmt.Sprintf("cannot register extension %v of %v, both must be struct pointers", et, bt))
+	}
+	if sf, ok := bt.Elem().FieldByName(util.ExtensionsFieldName); !ok || !util.IsYgotExtensions(sf) {
+		panic(fmt.Sprintf("cannot register extension %v of %v, which was not generated with extension points", et, bt))
+	}
+
+	extensionRegistry.mu.Lock()
+	defer extensionRegistry.mu.Unlock()
+	for _, t := range extensionRegistry.m[bt] {
+		if t == et {
+			return
+		}
+	}
+	ts := append(extensionRegistry.m[bt], et)
+	sort.Slice(ts, func(i, j int) bool { return extensionTypeName(ts[i]) < extensionTypeName(ts[j]) })
+	extensionRegistry.m[bt] = ts
+}
+
+// RegisteredExtensions returns a new, empty, extension struct of each of the
+// types registered as extending the type of the GoStruct base, sorted by
+// their name.
+func RegisteredExtensions(base GoStruct) []ExtensionStruct {
+	extensionRegistry.mu.RLock()
+	defer extensionRegistry.mu.RUnlock()
+	var exts []ExtensionStruct
+	for _, t := range extensionRegistry.m[reflect.TypeOf(base)] {
+		exts = append(exts, reflect.New(t.Elem()).Interface().(ExtensionStruct))
+	}
+	return exts
+}
+
+// extensionsField returns the extensions field of the GoStruct s.
+func extensionsField(s GoStruct) (reflect.Value, error) {
+	v := reflect.ValueOf(s)
+	if !util.IsValueStructPtr(v) || v.IsNil() {
+		return reflect.Value{}, fmt.Errorf("%T is not a non-nil struct pointer", s)
+	}
+	sf, ok := v.Elem().Type().FieldByName(util.ExtensionsFieldName)
+	if !ok || !util.IsYgotExtensions(sf) {
+		return reflect.Value{}, fmt.Errorf("%T was not generated with extension points", s)
+	}
+	return v.Elem().FieldByIndex(sf.Index), nil
+}
+
+// AttachExtension attaches the extension struct ext to the GoStruct base,
+// replacing any extension struct of the same type that is already attached.
+// It returns an error if base was not generated with extension points.
+func AttachExtension(base GoStruct, ext ExtensionStruct) error {
+	fv, err := extensionsField(base)
+	if err != nil {
+		return err
+	}
+	if fv.IsNil() {
+		fv.Set(reflect.MakeMap(fv.Type()))
+	}
+	fv.SetMapIndex(reflect.ValueOf(ExtensionName(ext)), reflect.ValueOf(ext))
+	return nil
+}
+
+// GetExtension returns the extension struct of type T that is attached to
+// the GoStruct base, and whether it is attached.
+func GetExtension[T ExtensionStruct](base GoStruct) (T, bool) {
+	var zero T
+	fv, err := extensionsField(base)
+	if err != nil || fv.IsNil() {
+		return zero, false
+	}
+	ev := fv.MapIndex(reflect.ValueOf(extensionTypeName(reflect.TypeOf(zero))))
+	if !ev.IsValid() {
+		return zero, false
+	}
+	ext, ok := ev.Interface().(T)
+	return ext, ok
+}
+
+// GetOrCreateExtension returns the extension struct of type T that is
+// attached to the GoStruct base, creating and attaching it if it is not
+// attached. It returns an error if base was not generated with extension
+// points.
+func GetOrCreateExtension[T ExtensionStruct](base GoStruct) (T, error) {
+	if ext, ok := GetExtension[T](base); ok {
+		return ext, nil
+	}
+	var zero T
+	t := reflect.TypeOf(zero)
+	if !util.IsTypeStructPtr(t) {
+		return zero, fmt.Errorf("extension type %v is not a struct pointer", t)
+	}
+	ext := reflect.New(t.Elem()).Interface().(T)
+	if err := AttachExtension(base, ext); err != nil {
+		return zero, err
+	}
+	return ext, nil
+}
+
+// extensionsByName returns the extension structs attached to the struct
+// pointer v, keyed by their name.
+func extensionsByName(v reflect.Value) map[string]reflect.Value {
+	m := map[string]reflect.Value{}
+	for _, ext := range util.AttachedExtensions(v) {
+		m[ext.Name] = ext.Value
+	}
+	return m
+}
+
+// extensionNames returns the sorted union of the names of the extension
+// structs within each of exts.
+func extensionNames(exts ...map[string]reflect.Value) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, x := range exts {
+		for n := range x {
+			if !seen[n] {
+				seen[n] = true
+				names = append(names, n)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,211 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// extBase is a GoStruct generated with extension points.
+type extBase struct {
+	Name        *string    `path:"name" module:"ext-base"`
+	ΛExtensions Extensions `ygotExtensions:"true"`
+}
+
+func (*extBase) IsYANGGoStruct() {}
+
+// extVendor is an extension struct that extends extBase.
+type extVendor struct {
+	VendorName *string           `path:"vendor-name" module:"ext-vendor"`
+	Counters   *extVendorCounter `path:"counters" module:"ext-vendor"`
+}
+
+func (*extVendor) IsYANGGoStruct()               {}
+func (*extVendor) ΛExtensionSchema() *yang.Entry { return nil }
+
+// extVendorCounter is a container within extVendor.
+type extVendorCounter struct {
+	Drops *uint64 `path:"drops" module:"ext-vendor"`
+}
+
+func (*extVendorCounter) IsYANGGoStruct() {}
+
+// extNoPoints is a GoStruct generated without extension points.
+type extNoPoints struct {
+	Name *string `path:"name" module:"ext-base"`
+}
+
+func (*extNoPoints) IsYANGGoStruct() {}
+
+func TestRegisterExtension(t *testing.T) {
+	RegisterExtension(&extBase{}, &extVendor{})
+	// Registering the same extension again is a no-op.
+	RegisterExtension(&extBase{}, &extVendor{})
+
+	got := RegisteredExtensions(&extBase{})
+	if len(got) != 1 {
+		t.Fatalf("RegisteredExtensions: got %d extensions, want 1", len(got))
+	}
+	if _, ok := got[0].(*extVendor); !ok {
+		t.Errorf("RegisteredExtensions: got extension of type %T, want *extVendor", got[0])
+	}
+	if again := RegisteredExtensions(&extBase{}); again[0] == got[0] {
+		t.Errorf("RegisteredExtensions: got the same extension struct twice, want a new one each call")
+	}
+	if got := RegisteredExtensions(&extNoPoints{}); got != nil {
+		t.Errorf("RegisteredExtensions(unregistered): got %v, want nil", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("RegisterExtension(struct without extension points): did not panic")
+		}
+	}()
+	RegisterExtension(&extNoPoints{}, &extVendor{})
+}
+
+func TestAttachExtension(t *testing.T) {
+	b := &extBase{}
+	if _, ok := GetExtension[*extVendor](b); ok {
+		t.Errorf("GetExtension: got attached extension on new struct")
+	}
+
+	ext, err := GetOrCreateExtension[*extVendor](b)
+	if err != nil {
+		t.Fatalf("GetOrCreateExtension: unexpected error: %v", err)
+	}
+	ext.VendorName = String("v")
+	got, ok := GetExtension[*extVendor](b)
+	if !ok || got != ext {
+		t.Errorf("GetExtension: got (%v, %v), want (%v, true)", got, ok, ext)
+	}
+	if again, _ := GetOrCreateExtension[*extVendor](b); again != ext {
+		t.Errorf("GetOrCreateExtension: created a new extension struct when one was attached")
+	}
+
+	replacement := &extVendor{}
+	if err := AttachExtension(b, replacement); err != nil {
+		t.Fatalf("AttachExtension: unexpected error: %v", err)
+	}
+	if got, _ := GetExtension[*extVendor](b); got != replacement {
+		t.Errorf("AttachExtension: did not replace the attached extension struct")
+	}
+
+	err = AttachExtension(&extNoPoints{}, &extVendor{})
+	if diff := errdiff.Substring(err, "was not generated with extension points"); diff != "" {
+		t.Errorf("AttachExtension(struct without extension points): %s", diff)
+	}
+	if _, err := GetOrCreateExtension[*extVendor](&extNoPoints{}); err == nil {
+		t.Errorf("GetOrCreateExtension(struct without extension points): did not get error")
+	}
+}
+
+// newExtBase returns an extBase with an extVendor attached.
+func newExtBase() *extBase {
+	b := &extBase{Name: String("base")}
+	if err := AttachExtension(b, &extVendor{
+		VendorName: String("vendor"),
+		Counters:   &extVendorCounter{Drops: Uint64(42)},
+	}); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestExtensionRender(t *testing.T) {
+	b := newExtBase()
+
+	gotJSON, err := ConstructIETFJSON(b, &RFC7951JSONConfig{AppendModuleName: true})
+	if err != nil {
+		t.Fatalf("ConstructIETFJSON: unexpected error: %v", err)
+	}
+	wantJSON := map[string]any{
+		"ext-base:name":          "base",
+		"ext-vendor:vendor-name": "vendor",
+		"ext-vendor:counters": map[string]any{
+			"drops": "42",
+		},
+	}
+	if diff := cmp.Diff(wantJSON, gotJSON); diff != "" {
+		t.Errorf("ConstructIETFJSON: did not get expected JSON, diff(-want, +got):\n%s", diff)
+	}
+
+	gotNotifs, err := TogNMINotifications(b, 0, GNMINotificationsConfig{UsePathElem: true})
+	if err != nil {
+		t.Fatalf("TogNMINotifications: unexpected error: %v", err)
+	}
+	if len(gotNotifs) != 1 {
+		t.Fatalf("TogNMINotifications: got %d notifications, want 1", len(gotNotifs))
+	}
+	wantUpdates := []*gnmipb.Update{{
+		Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "counters"}, {Name: "drops"}}},
+		Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 42}},
+	}, {
+		Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "name"}}},
+		Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "base"}},
+	}, {
+		Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "vendor-name"}}},
+		Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "vendor"}},
+	}}
+	if diff := cmp.Diff(&gnmipb.Notification{Update: wantUpdates}, gotNotifs[0], protocmp.Transform(), protocmp.SortRepeatedFields(&gnmipb.Notification{}, "update")); diff != "" {
+		t.Errorf("TogNMINotifications: did not get expected updates, diff(-want, +got):\n%s", diff)
+	}
+}
+
+func TestExtensionCopyAndMerge(t *testing.T) {
+	b := newExtBase()
+	c, err := DeepCopy(b)
+	if err != nil {
+		t.Fatalf("DeepCopy: unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(b, c); diff != "" {
+		t.Errorf("DeepCopy: did not get a copy, diff(-want, +got):\n%s", diff)
+	}
+	orig, _ := GetExtension[*extVendor](b)
+	copied, _ := GetExtension[*extVendor](c.(*extBase))
+	if orig == copied {
+		t.Errorf("DeepCopy: extension struct was not copied")
+	}
+
+	src := &extBase{}
+	if err := AttachExtension(src, &extVendor{Counters: &extVendorCounter{Drops: Uint64(42)}}); err != nil {
+		t.Fatalf("AttachExtension: unexpected error: %v", err)
+	}
+	dst := &extBase{Name: String("base")}
+	if err := AttachExtension(dst, &extVendor{VendorName: String("vendor")}); err != nil {
+		t.Fatalf("AttachExtension: unexpected error: %v", err)
+	}
+	if err := MergeStructInto(dst, src); err != nil {
+		t.Fatalf("MergeStructInto: unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(newExtBase(), dst); diff != "" {
+		t.Errorf("MergeStructInto: did not merge extension structs, diff(-want, +got):\n%s", diff)
+	}
+
+	conflict := &extBase{}
+	if err := AttachExtension(conflict, &extVendor{VendorName: String("other")}); err != nil {
+		t.Fatalf("AttachExtension: unexpected error: %v", err)
+	}
+	if err := MergeStructInto(dst, conflict); err == nil {
+		t.Errorf("MergeStructInto(conflicting extension structs): did not get error")
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/gnmi/errlist"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Filter determines the nodes of a GoStruct that are included in the copy
+// of the GoStruct that is returned by CloneFiltered.
+type Filter interface {
+	// Includes reports whether the leaf, leaf-list or ordered list at
+	// path, which uses the PathElem format and is relative to the root of
+	// the GoStruct, is included.
+	Includes(path *gnmipb.Path) bool
+}
+
+// PathFilter returns a Filter that includes the nodes whose path has a
+// prefix within allow, or all nodes if allow is empty, and whose path does
+// not have a prefix within deny, such that deny takes precedence over allow.
+// The allow and deny paths may contain wildcards, as per
+// util.PathMatchesQuery, and must use the PathElem format.
+func PathFilter(allow, deny []*gnmipb.Path) Filter {
+	return &pathFilter{allow: allow, deny: deny}
+}
+
+// ConfigFilter returns a Filter that includes the nodes that are
+// configuration (config true) within the schema root, which is the schema of
+// the root of the GoStruct. Nodes that are not found within root are not
+// included.
+func ConfigFilter(root *yang.Entry) Filter {
+	return &schemaFilter{root: root, includes: util.IsConfig}
+}
+
+// StateFilter returns a Filter that includes the nodes that are state
+// (config false) within the schema root, which is the schema of the root of
+// the GoStruct. Nodes that are not found within root are not included.
+func StateFilter(root *yang.Entry) Filter {
+	return &schemaFilter{root: root, includes: func(e *yang.Entry) bool { return !util.IsConfig(e) }}
+}
+
+// FilterByPaths returns a deep copy of root that contains only the nodes
+// that are permitted by the allow and deny paths, such that a view of a data
+// tree can be restricted according to the paths that a user is authorised
+// to access.
+//
+// A node is permitted if it is included by PathFilter(allow, deny). The
+// copy is created as per CloneFiltered.
+func FilterByPaths(root GoStruct, allow, deny []*gnmipb.Path) (GoStruct, error) {
+	for _, p := range append(append([]*gnmipb.Path{}, allow...), deny...) {
+		if err := util.ValidateGNMIPath(p); err != nil {
+			return nil, fmt.Errorf("invalid filter path: %w", err)
+		}
+	}
+	return CloneFiltered(root, PathFilter(allow, deny))
+}
+
+// CloneFiltered returns a deep copy of s that contains only the nodes that
+// are included by filter. Unlike a DeepCopy of s that is subsequently
+// pruned, the nodes that are not included are never copied.
+//
+// Containers and list entries that do not contain any included nodes are
+// omitted from the copy. The key leaves of list entries that contain an
+// included node are copied regardless of whether they are themselves
+// included, since they are required to identify the entry. Lists that are
+// ordered by the user are copied or omitted as a whole, depending on
+// whether the path of the list is included. A node is included if any of
+// the paths within its path struct tag is included. s itself is not
+// modified.
+func CloneFiltered(s GoStruct, filter Filter) (GoStruct, error) {
+	if util.IsNilOrInvalidValue(reflect.ValueOf(s)) {
+		return nil, fmt.Errorf("invalid input to CloneFiltered, got nil value: %v", s)
+	}
+	c, _, err := cloneStruct(s, newPathElemGNMIPath(nil), nil, filter)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// pathFilter is a Filter that includes paths according to a set of allow
+// and deny paths.
+type pathFilter struct {
+	// allow is the set of paths whose descendants are permitted. All
+	// paths are permitted if it is empty.
+	allow []*gnmipb.Path
+	// deny is the set of paths whose descendants are not permitted.
+	deny []*gnmipb.Path
+}
+
+// Includes reports whether the path p is permitted by f.
+func (f *pathFilter) Includes(p *gnmipb.Path) bool {
+	for _, d := range f.deny {
+		if util.PathMatchesQuery(p, d) {
+			return false
+		}
+	}
+	if len(f.allow) == 0 {
+		return true
+	}
+	for _, a := range f.allow {
+		if util.PathMatchesQuery(p, a) {
+			return true
+		}
+	}
+	return false
+}
+
+// schemaFilter is a Filter that includes paths according to their schema.
+type schemaFilter struct {
+	// root is the schema of the root of the GoStruct.
+	root *yang.Entry
+	// includes reports whether a node with the schema e is included.
+	includes func(e *yang.Entry) bool
+}
+
+// Includes reports whether the schema of the path p is included by f.
+func (f *schemaFilter) Includes(p *gnmipb.Path) bool {
+	e := schemaAtPath(f.root, p)
+	return e != nil && f.includes(e)
+}
+
+// filterIncludes reports whether any of the paths is included by f.
+func filterIncludes(f Filter, paths []*gnmiPath) (bool, error) {
+	for _, p := range paths {
+		pp, err := p.ToProto()
+		if err != nil {
+			return false, err
+		}
+		if f.Includes(pp) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// cloneStruct returns a copy of the GoStruct s, whose path is parent, that
+// contains only the fields that are included by f. If s is a list entry,
+// keys specifies the keys of the entry. It returns whether the copy contains
+// any included nodes.
+func cloneStruct(s GoStruct, parent *gnmiPath, keys map[string]string, f Filter) (GoStruct, bool, error) {
+	sval := reflect.ValueOf(s)
+	if !util.IsValueStructPtr(sval) || util.IsValueNil(sval) {
+		return nil, false, fmt.Errorf("input struct for %v was not valid", parent)
+	}
+	sval = sval.Elem()
+	stype := sval.Type()
+	c := reflect.New(stype)
+	cval := c.Elem()
+
+	var (
+		errs errlist.List
+		keep bool
+	)
+	for i := 0; i < sval.NumField(); i++ {
+		fval, ftype := util.FieldValue(sval, i), stype.Field(i)
+		if fval.IsZero() || util.IsYgotPresence(ftype) || util.IsYgotExtensions(ftype) {
+			continue
+		}
+		if util.IsYgotAnnotation(ftype) {
+			errs.Add(copyField(cval, sval, i, ""))
+			continue
+		}
+
+		mapPaths, err := structTagToLibPaths(ftype, parent, false)
+		if err != nil {
+			errs.Add(fmt.Errorf("%v->%s: %v", parent, ftype.Name, err))
+			continue
+		}
+
+		switch {
+		case fval.Kind() == reflect.Map:
+			m := reflect.MakeMapWithSize(fval.Type(), fval.Len())
+			for _, k := range fval.MapKeys() {
+				v := fval.MapIndex(k)
+				childPath, err := mapValuePath(k, v, mapPaths[0])
+				if err != nil {
+					errs.Add(err)
+					continue
+				}
+				gs, ok := v.Interface().(GoStruct)
+				if !ok {
+					errs.Add(fmt.Errorf("%v: was not a valid GoStruct", mapPaths[0]))
+					continue
+				}
+				e, err := childPath.LastPathElem()
+				if err != nil {
+					errs.Add(err)
+					continue
+				}
+				entry, kept, err := cloneStruct(gs, childPath, e.GetKey(), f)
+				if err != nil {
+					errs.Add(err)
+					continue
+				}
+				if kept {
+					m.SetMapIndex(k, reflect.ValueOf(entry))
+				}
+			}
+			if m.Len() != 0 {
+				cval.Field(i).Set(m)
+				keep = true
+			}
+		case fval.Kind() == reflect.Ptr && fval.Elem().Kind() == reflect.Struct && !isOrderedMap(fval):
+			gs, ok := fval.Interface().(GoStruct)
+			if !ok {
+				errs.Add(fmt.Errorf("%v: was not a valid GoStruct", mapPaths[0]))
+				continue
+			}
+			child, kept, err := cloneStruct(gs, mapPaths[0], nil, f)
+			if err != nil {
+				errs.Add(err)
+				continue
+			}
+			if kept {
+				cval.Field(i).Set(reflect.ValueOf(child))
+				keep = true
+			}
+		default:
+			// Leaves, leaf-lists and ordered lists are copied or
+			// omitted as a whole.
+			ok, err := filterIncludes(f, mapPaths)
+			if err != nil {
+				errs.Add(err)
+				continue
+			}
+			// Key leaves are copied, but do not cause the list
+			// entry to be retained.
+			if ok || isKeyField(mapPaths, keys) {
+				errs.Add(copyField(cval, sval, i, ""))
+			}
+			keep = keep || ok
+		}
+	}
+	// The fields of extension structs have the same parent path as the
+	// fields of the struct.
+	for _, ext := range util.AttachedExtensions(sval) {
+		ec, kept, err := cloneStruct(ext.Value.Interface().(GoStruct), parent, nil, f)
+		if err != nil {
+			errs.Add(err)
+			continue
+		}
+		if kept {
+			errs.Add(AttachExtension(c.Interface().(GoStruct), ec.(ExtensionStruct)))
+			keep = true
+		}
+	}
+	return c.Interface().(GoStruct), keep, errs.Err()
+}
+
+// isOrderedMap reports whether v is a GoOrderedMap.
+func isOrderedMap(v reflect.Value) bool {
+	_, ok := v.Interface().(GoOrderedMap)
+	return ok
+}
+
+// isKeyField reports whether the field with the supplied paths is a key leaf
+// of a list entry with the supplied keys, i.e., whether the name of the last
+// element of any of its paths is the name of a key.
+func isKeyField(paths []*gnmiPath, keys map[string]string) bool {
+	if len(keys) == 0 {
+		return false
+	}
+	for _, p := range paths {
+		e, err := p.LastPathElem()
+		if err != nil {
+			continue
+		}
+		if _, ok := keys[e.GetName()]; ok {
+			return true
+		}
+	}
+	return false
+}
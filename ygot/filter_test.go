@@ -0,0 +1,222 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// filterTestRoot returns the GoStruct that is filtered within
+// TestFilterByPaths.
+func filterTestRoot() *renderExample {
+	return &renderExample{
+		Str:    String("hello"),
+		IntVal: Int32(42),
+		Ch: &renderExampleChild{
+			Val:  Uint64(84),
+			Enum: EnumTestVALONE,
+		},
+		List: map[uint32]*renderExampleList{
+			1: {Val: String("one")},
+			2: {Val: String("two")},
+		},
+		LeafList: []string{"a", "b"},
+	}
+}
+
+func TestFilterByPaths(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inAllow          []*gnmipb.Path
+		inDeny           []*gnmipb.Path
+		want             *renderExample
+		wantErrSubstring string
+	}{{
+		desc: "no filters",
+		want: filterTestRoot(),
+	}, {
+		desc:    "allow single leaf",
+		inAllow: []*gnmipb.Path{&gnmipb.Path{Elem: mustPathElem("/str")}},
+		want:    &renderExample{Str: String("hello")},
+	}, {
+		desc:    "allow container",
+		inAllow: []*gnmipb.Path{&gnmipb.Path{Elem: mustPathElem("/ch")}},
+		want: &renderExample{
+			Ch: &renderExampleChild{
+				Val:  Uint64(84),
+				Enum: EnumTestVALONE,
+			},
+		},
+	}, {
+		desc:   "deny container leaf",
+		inDeny: []*gnmipb.Path{&gnmipb.Path{Elem: mustPathElem("/ch/val")}, &gnmipb.Path{Elem: mustPathElem("/list")}},
+		want: &renderExample{
+			Str:      String("hello"),
+			IntVal:   Int32(42),
+			Ch:       &renderExampleChild{Enum: EnumTestVALONE},
+			LeafList: []string{"a", "b"},
+		},
+	}, {
+		desc:    "deny takes precedence over allow",
+		inAllow: []*gnmipb.Path{&gnmipb.Path{Elem: mustPathElem("/ch")}},
+		inDeny:  []*gnmipb.Path{&gnmipb.Path{Elem: mustPathElem("/ch")}},
+		want:    &renderExample{},
+	}, {
+		desc:    "allow list entry by key",
+		inAllow: []*gnmipb.Path{&gnmipb.Path{Elem: mustPathElem("/list[val=two]")}},
+		want: &renderExample{
+			List: map[uint32]*renderExampleList{
+				2: {Val: String("two")},
+			},
+		},
+	}, {
+		desc:    "allow non-key leaf of list entry retains key",
+		inAllow: []*gnmipb.Path{&gnmipb.Path{Elem: mustPathElem("/list[val=one]/state/val")}},
+		want: &renderExample{
+			List: map[uint32]*renderExampleList{
+				1: {Val: String("one")},
+			},
+		},
+	}, {
+		desc:    "wildcard allow",
+		inAllow: []*gnmipb.Path{&gnmipb.Path{Elem: mustPathElem("/*/enum")}},
+		want:    &renderExample{Ch: &renderExampleChild{Enum: EnumTestVALONE}},
+	}, {
+		desc:             "invalid filter path",
+		inAllow:          []*gnmipb.Path{{Elem: []*gnmipb.PathElem{{Name: "a b"}}}},
+		wantErrSubstring: "invalid filter path",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			in := filterTestRoot()
+			got, err := FilterByPaths(in, tt.inAllow, tt.inDeny)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("FilterByPaths: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				if !errors.Is(err, util.ErrInvalidPath) {
+					t.Errorf("FilterByPaths: got error %v, want error of kind ErrInvalidPath", err)
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("FilterByPaths: did not get expected result (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(filterTestRoot(), in); diff != "" {
+				t.Errorf("FilterByPaths: input was modified (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// filterTestSchema returns a schema for the GoStruct returned by
+// filterTestRoot in which int-val and ch/val are state.
+func filterTestSchema() *yang.Entry {
+	root := &yang.Entry{Name: "root", Kind: yang.DirectoryEntry, Dir: map[string]*yang.Entry{}}
+	for _, n := range []string{"str", "leaf-list"} {
+		root.Dir[n] = &yang.Entry{Name: n, Kind: yang.LeafEntry, Parent: root}
+	}
+	root.Dir["int-val"] = &yang.Entry{Name: "int-val", Kind: yang.LeafEntry, Parent: root, Config: yang.TSFalse}
+	ch := &yang.Entry{Name: "ch", Kind: yang.DirectoryEntry, Parent: root, Dir: map[string]*yang.Entry{}}
+	root.Dir["ch"] = ch
+	ch.Dir["val"] = &yang.Entry{Name: "val", Kind: yang.LeafEntry, Parent: ch, Config: yang.TSFalse}
+	ch.Dir["enum"] = &yang.Entry{Name: "enum", Kind: yang.LeafEntry, Parent: ch}
+	return root
+}
+
+func TestCloneFiltered(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               GoStruct
+		inFilter         Filter
+		want             GoStruct
+		wantErrSubstring string
+	}{{
+		desc:     "config only",
+		in:       filterTestRoot(),
+		inFilter: ConfigFilter(filterTestSchema()),
+		want: &renderExample{
+			Str:      String("hello"),
+			Ch:       &renderExampleChild{Enum: EnumTestVALONE},
+			LeafList: []string{"a", "b"},
+		},
+	}, {
+		desc:     "state only",
+		in:       filterTestRoot(),
+		inFilter: StateFilter(filterTestSchema()),
+		want: &renderExample{
+			IntVal: Int32(42),
+			Ch:     &renderExampleChild{Val: Uint64(84)},
+		},
+	}, {
+		desc:     "path set",
+		in:       filterTestRoot(),
+		inFilter: PathFilter([]*gnmipb.Path{{Elem: mustPathElem("/ch")}, {Elem: mustPathElem("/str")}}, nil),
+		want: &renderExample{
+			Str: String("hello"),
+			Ch: &renderExampleChild{
+				Val:  Uint64(84),
+				Enum: EnumTestVALONE,
+			},
+		},
+	}, {
+		desc:             "nil input",
+		in:               (*renderExample)(nil),
+		inFilter:         PathFilter(nil, nil),
+		wantErrSubstring: "got nil value",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := CloneFiltered(tt.in, tt.inFilter)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("CloneFiltered: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("CloneFiltered: did not get expected result (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(filterTestRoot(), tt.in); diff != "" {
+				t.Errorf("CloneFiltered: input was modified (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCloneFilteredCopiesValues(t *testing.T) {
+	in := filterTestRoot()
+	got, err := CloneFiltered(in, PathFilter(nil, nil))
+	if err != nil {
+		t.Fatalf("CloneFiltered: got unexpected error: %v", err)
+	}
+	c := got.(*renderExample)
+	*c.Str = "changed"
+	c.LeafList[0] = "changed"
+	c.List[1].Val = String("changed")
+	if diff := cmp.Diff(filterTestRoot(), in); diff != "" {
+		t.Errorf("CloneFiltered: copy shares values with input (-want, +got):\n%s", diff)
+	}
+}
@@ -58,6 +58,9 @@ func PruneConfigFalse(schema *yang.Entry, s GoStruct) error {
 		if ni.Parent == nil {
 			return nil
 		}
+		if _, ok := util.PresenceBit(ni.StructField); ok {
+			return util.NewErrs(util.SetLeafPresence(ni.Parent.FieldValue.Elem(), ni.StructField, false))
+		}
 		ni.FieldValue.Set(reflect.Zero(ni.FieldValue.Type()))
 		return nil
 	}
@@ -0,0 +1,538 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/gnmi/errlist"
+	"github.com/openconfig/ygot/internal/yreflect"
+	"github.com/openconfig/ygot/util"
+)
+
+// EncodeJSON serialises the GoStruct gs as JSON, writing it to w. The options
+// supplied, and the JSON that is written, are the same as for
+// EmitJSONWithOpts, such that the output is identical, other than being
+// terminated by a newline.
+//
+// Unlike EmitJSONWithOpts, which builds the entire JSON tree in memory before
+// marshalling it, EncodeJSON walks gs and writes each node as it is reached,
+// such that the memory that is used is proportional to the depth of gs
+// rather than its size. This makes it suitable for very large GoStructs, such
+// as full-table RIB snapshots. Where the WithDefaults option is supplied, a
+// copy of gs is made to which the defaults are applied. The Redactor option,
+// and the WithDefaultsReportAllTagged mode, are not supported, since they
+// require the JSON tree.
+//
+// Since output is written incrementally, w may have been written to when an
+// error is returned.
+func EncodeJSON(w io.Writer, gs GoStruct, opts ...EmitJSONOpt) (err error) {
+	done := util.StartOperation(util.OpEmitJSON)
+	defer func() { done(err) }()
+
+	c := NewEmitJSONConfig(opts...)
+	if c.Redactor != nil {
+		return errors.New("EncodeJSON does not support the Redactor option")
+	}
+	if c.WithDefaults == WithDefaultsReportAllTagged {
+		return fmt.Errorf("EncodeJSON does not support with-defaults mode %s", c.WithDefaults)
+	}
+	if c.WithDefaults != WithDefaultsExplicit {
+		if gs, err = ApplyWithDefaults(c.WithDefaultsSchema, gs, c.WithDefaults); err != nil {
+			return err
+		}
+	}
+	if !c.SkipValidation {
+		if err := ValidateGoStruct(gs, c.ValidationOpts...); err != nil {
+			return fmt.Errorf("validation err: %v", err)
+		}
+	}
+
+	args := jsonOutputConfig{jType: c.Format}
+	if c.Format == RFC7951 {
+		args.rfc7951Config = c.RFC7951Config
+	}
+	indent := indentString
+	if c.Indent != "" {
+		indent = c.Indent
+	}
+
+	bw := bufio.NewWriter(w)
+	e := &jsonStreamEncoder{
+		w:          bw,
+		args:       args,
+		indent:     indent,
+		escapeHTML: c.EscapeHTML,
+	}
+	if err := e.beginObject("", true); err != nil {
+		return err
+	}
+	if err := e.structMembers(gs, ""); err != nil {
+		return err
+	}
+	e.end()
+	if e.err != nil {
+		return e.err
+	}
+	if _, err := bw.WriteString("\n"); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// jsonStreamFrame is an object or array that is being written by a
+// jsonStreamEncoder.
+type jsonStreamFrame struct {
+	// header is the text that opens the frame, including the key of the
+	// frame within its parent object.
+	header string
+	// close is the character that closes the frame.
+	close string
+	// open indicates that the header of the frame has been written.
+	open bool
+	// members indicates that the frame has at least one member.
+	members bool
+}
+
+// jsonStreamEncoder writes JSON to an io.Writer incrementally, in the same
+// format as a json.Encoder with the indentation of EmitJSON. Objects and
+// arrays are only written once they have a member, or if they are forced,
+// such that empty containers are omitted from the output without them
+// needing to be rendered in advance.
+type jsonStreamEncoder struct {
+	w          *bufio.Writer
+	args       jsonOutputConfig
+	indent     string
+	escapeHTML bool
+	// stack is the set of objects and arrays that are being written,
+	// outermost first.
+	stack []*jsonStreamFrame
+	// err is the first error encountered when writing to w.
+	err error
+}
+
+// write writes s to the output.
+func (e *jsonStreamEncoder) write(s string) {
+	if e.err != nil {
+		return
+	}
+	_, e.err = e.w.WriteString(s)
+}
+
+// marshal returns the JSON representation of v, indented to be written at
+// the current depth of the output.
+func (e *jsonStreamEncoder) marshal(v any) (string, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(e.escapeHTML)
+	enc.SetIndent(strings.Repeat(e.indent, len(e.stack)), e.indent)
+	if err := enc.Encode(v); err != nil {
+		return "", fmt.Errorf("JSON marshalling error: %v", err)
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+// open writes the headers of the frames that have not yet been opened.
+func (e *jsonStreamEncoder) open() {
+	for i, f := range e.stack {
+		if f.open {
+			continue
+		}
+		if i > 0 {
+			e.separate(i - 1)
+		}
+		e.write(f.header)
+		f.open = true
+	}
+}
+
+// prefix returns the text that precedes a new member of the innermost frame,
+// with the key of the member, if any, opening any frames that have not yet
+// been opened.
+func (e *jsonStreamEncoder) prefix(key string) (string, error) {
+	e.open()
+	e.separate(len(e.stack) - 1)
+	if key == "" {
+		return "", nil
+	}
+	k, err := e.marshal(key)
+	if err != nil {
+		return "", err
+	}
+	return k + ": ", nil
+}
+
+// separate writes the separator that precedes a new member of the frame at
+// index i of the stack.
+func (e *jsonStreamEncoder) separate(i int) {
+	f := e.stack[i]
+	if f.members {
+		e.write(",")
+	}
+	e.write("\n" + strings.Repeat(e.indent, i+1))
+	f.members = true
+}
+
+// begin starts a new frame with the given key, which is opened by open and
+// closed by close. If force is set, the frame is written even if it has no
+// members.
+func (e *jsonStreamEncoder) begin(key, open, close string, force bool) error {
+	var header string
+	if len(e.stack) != 0 && key != "" {
+		k, err := e.marshal(key)
+		if err != nil {
+			return err
+		}
+		header = k + ": "
+	}
+	e.stack = append(e.stack, &jsonStreamFrame{header: header + open, close: close})
+	if force {
+		e.open()
+	}
+	return nil
+}
+
+// beginObject starts a new object with the given key, which is empty for the
+// root object and the entries of arrays.
+func (e *jsonStreamEncoder) beginObject(key string, force bool) error {
+	return e.begin(key, "{", "}", force)
+}
+
+// beginArray starts a new array with the given key.
+func (e *jsonStreamEncoder) beginArray(key string, force bool) error {
+	return e.begin(key, "[", "]", force)
+}
+
+// end ends the innermost frame.
+func (e *jsonStreamEncoder) end() {
+	f := e.stack[len(e.stack)-1]
+	e.stack = e.stack[:len(e.stack)-1]
+	if !f.open {
+		return
+	}
+	if f.members {
+		e.write("\n" + strings.Repeat(e.indent, len(e.stack)))
+	}
+	e.write(f.close)
+}
+
+// member writes the member of the innermost object with the given key and
+// value.
+func (e *jsonStreamEncoder) member(key string, v any) error {
+	p, err := e.prefix(key)
+	if err != nil {
+		return err
+	}
+	js, err := e.marshal(v)
+	if err != nil {
+		return err
+	}
+	e.write(p + js)
+	return nil
+}
+
+// jsonStreamField is a field of a GoStruct that is written at a particular
+// path relative to the object of the GoStruct.
+type jsonStreamField struct {
+	field reflect.Value
+	fType reflect.StructField
+	chMod string
+}
+
+// jsonStreamNode is a node of the tree of members of the object of a
+// GoStruct, which has either children or a field.
+type jsonStreamNode struct {
+	children map[string]*jsonStreamNode
+	field    *jsonStreamField
+}
+
+// structMembers writes the members of the object of the GoStruct s, whose
+// parent is defined within the module parentMod, to the innermost object.
+func (e *jsonStreamEncoder) structMembers(s GoStruct, parentMod string) error {
+	root := &jsonStreamNode{children: map[string]*jsonStreamNode{}}
+	if err := e.addStructFields(root, s, parentMod); err != nil {
+		return err
+	}
+	return e.nodeMembers(root)
+}
+
+// addStructFields adds the fields of the GoStruct s to the tree of members
+// rooted at root, following the same rules as structJSON.
+func (e *jsonStreamEncoder) addStructFields(root *jsonStreamNode, s GoStruct, parentMod string) error {
+	var errs errlist.List
+	args := e.args
+
+	sval := reflect.ValueOf(s).Elem()
+	stype := sval.Type()
+	for i := 0; i < sval.NumField(); i++ {
+		field := util.FieldValue(sval, i)
+		fType := stype.Field(i)
+
+		if util.IsYgotPresence(fType) || util.IsYgotExtensions(fType) {
+			continue
+		}
+
+		var prependmods [][]string
+		var chMod string
+		if args.jType == RFC7951 && args.rfc7951Config != nil && args.rfc7951Config.AppendModuleName {
+			var err error
+			if prependmods, chMod, err = prependmodsJSON(fType, parentMod, args); err != nil {
+				errs.Add(err)
+				continue
+			}
+		}
+
+		mapPaths, err := structTagToLibPaths(fType, newStringSliceGNMIPath([]string{}), args.rfc7951Config != nil && args.rfc7951Config.PreferShadowPath)
+		if err != nil {
+			errs.Add(fmt.Errorf("%s: %v", fType.Name, err))
+			continue
+		}
+
+		if len(mapPaths) == 1 && mapPaths[0].Len() == 0 {
+			// The members of the fake root are those of its child.
+			if isNilValue(field) {
+				continue
+			}
+			ch, ok := field.Interface().(GoStruct)
+			if !ok {
+				errs.Add(fmt.Errorf("empty path specified for non-root entity"))
+				continue
+			}
+			if err := e.addStructFields(root, ch, parentMod); err != nil {
+				errs.Add(err)
+			}
+			continue
+		}
+
+		if prependmods != nil && len(mapPaths) != len(prependmods) {
+			errs.Add(fmt.Errorf("%s: number of paths and modules in struct tag not the same: (paths: %v, modules: %v)", fType.Name, len(mapPaths), len(prependmods)))
+			continue
+		}
+
+		for i, p := range mapPaths {
+			if prependmods != nil && p.Len() != len(prependmods[i]) {
+				errs.Add(fmt.Errorf("number of paths and modules elements not the same: (paths: %v, modules: %v)", p, prependmods[i]))
+				continue
+			}
+			n := root
+			for j := 0; j != p.Len(); j++ {
+				k, err := p.StringElemAt(j)
+				if err != nil {
+					errs.Add(err)
+					break
+				}
+				if prependmods != nil && prependmods[i][j] != "" {
+					k = fmt.Sprintf("%s:%s", prependmods[i][j], k)
+				}
+				if j == p.Len()-1 {
+					n.children[k] = &jsonStreamNode{field: &jsonStreamField{field: field, fType: fType, chMod: chMod}}
+					break
+				}
+				ch, ok := n.children[k]
+				if !ok {
+					ch = &jsonStreamNode{children: map[string]*jsonStreamNode{}}
+					n.children[k] = ch
+				}
+				if ch.field != nil {
+					errs.Add(fmt.Errorf("%s: path element %s is both a leaf and a container", fType.Name, k))
+					break
+				}
+				n = ch
+			}
+		}
+	}
+
+	// The fields of extension structs are children of the same node.
+	for _, ext := range util.AttachedExtensions(sval) {
+		if err := e.addStructFields(root, ext.Value.Interface().(GoStruct), parentMod); err != nil {
+			errs.Add(err)
+		}
+	}
+	return errs.Err()
+}
+
+// isNilValue reports whether the value v is a nil map, slice, pointer or
+// interface.
+func isNilValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+// nodeMembers writes the children of n as members of the innermost object,
+// in the sorted order of their keys.
+func (e *jsonStreamEncoder) nodeMembers(n *jsonStreamNode) error {
+	var errs errlist.List
+	keys := make([]string, 0, len(n.children))
+	for k := range n.children {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		ch := n.children[k]
+		if ch.field != nil {
+			errs.Add(e.fieldMember(k, ch.field))
+			continue
+		}
+		if err := e.beginObject(k, false); err != nil {
+			errs.Add(err)
+			continue
+		}
+		errs.Add(e.nodeMembers(ch))
+		e.end()
+	}
+	return errs.Err()
+}
+
+// fieldMember writes the member of the innermost object with the given key
+// whose value is that of the field f. Containers and lists are written
+// incrementally, whereas the values of other fields are rendered by
+// jsonValue.
+func (e *jsonStreamEncoder) fieldMember(key string, f *jsonStreamField) error {
+	field := f.field
+	if isNilValue(field) {
+		return nil
+	}
+
+	switch {
+	case field.Kind() == reflect.Map:
+		var errs errlist.List
+		var pairs []mapValuePair
+		iter := field.MapRange()
+		for iter.Next() {
+			kn, err := mapKeyToJSONString(iter.Key(), e.args)
+			if err != nil {
+				errs.Add(err)
+				continue
+			}
+			pairs = append(pairs, mapValuePair{k: kn, v: iter.Value()})
+		}
+		if errs.Err() != nil {
+			return errs.Err()
+		}
+		slices.SortFunc(pairs, func(a, b mapValuePair) int { return strings.Compare(a.k, b.k) })
+		return e.listMember(key, pairs, f.chMod)
+	case field.Kind() == reflect.Ptr:
+		if om, ok := field.Interface().(GoOrderedMap); ok {
+			var errs errlist.List
+			var pairs []mapValuePair
+			if err := yreflect.RangeOrderedMap(om, func(k reflect.Value, v reflect.Value) bool {
+				kn, err := mapKeyToJSONString(k, e.args)
+				if err != nil {
+					errs.Add(err)
+					return true
+				}
+				pairs = append(pairs, mapValuePair{k: kn, v: v})
+				return true
+			}); err != nil {
+				errs.Add(err)
+			}
+			if errs.Err() != nil {
+				return errs.Err()
+			}
+			if e.args.jType == Internal {
+				// Internal format lists are objects, whose members are
+				// written in the sorted order of their keys.
+				slices.SortStableFunc(pairs, func(a, b mapValuePair) int { return strings.Compare(a.k, b.k) })
+			}
+			return e.listMember(key, pairs, f.chMod)
+		}
+		if field.Elem().Kind() == reflect.Struct {
+			goStruct, ok := field.Interface().(GoStruct)
+			if !ok {
+				return fmt.Errorf("cannot map struct (%T, %v), invalid GoStruct", field.Interface(), field)
+			}
+			if err := e.beginObject(key, util.IsYangPresence(f.fType)); err != nil {
+				return err
+			}
+			err := e.structMembers(goStruct, f.chMod)
+			e.end()
+			return err
+		}
+	}
+
+	value, err := jsonValue(field, f.chMod, e.args)
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		return nil
+	}
+	if e.args.jType != Internal {
+		if value, err = normalizeJSONValue(value); err != nil {
+			return err
+		}
+	}
+	return e.member(key, value)
+}
+
+// listMember writes the member of the innermost object with the given key
+// whose value is the keyed list with the supplied entries, which are
+// written in order.
+func (e *jsonStreamEncoder) listMember(key string, pairs []mapValuePair, parentMod string) error {
+	if len(pairs) == 0 {
+		// An empty list is rendered as an empty array in RFC7951 format,
+		// and is omitted in Internal format.
+		if e.args.jType == RFC7951 {
+			return e.member(key, []any{})
+		}
+		return nil
+	}
+
+	var err error
+	switch e.args.jType {
+	case RFC7951:
+		err = e.beginArray(key, true)
+	case Internal:
+		err = e.beginObject(key, true)
+	default:
+		return fmt.Errorf("invalid JSON format specified: %v", e.args.jType)
+	}
+	if err != nil {
+		return err
+	}
+
+	var errs errlist.List
+	for _, pair := range pairs {
+		goStruct, ok := pair.v.Interface().(GoStruct)
+		if !ok {
+			errs.Add(fmt.Errorf("cannot map struct %v, invalid GoStruct", pair.v.Interface()))
+			continue
+		}
+		entryKey := pair.k
+		if e.args.jType == RFC7951 {
+			entryKey = ""
+		}
+		if err := e.beginObject(entryKey, true); err != nil {
+			errs.Add(err)
+			continue
+		}
+		errs.Add(e.structMembers(goStruct, parentMod))
+		e.end()
+	}
+	e.end()
+	return errs.Err()
+}
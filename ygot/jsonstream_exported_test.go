@@ -0,0 +1,138 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/integration_tests/schemaops/ctestschema"
+	"github.com/openconfig/ygot/testutil"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestEncodeJSON(t *testing.T) {
+	d := &exampleoc.Device{}
+	i := d.GetOrCreateInterface("eth0")
+	i.Description = ygot.String("uplink <core>")
+	i.Mtu = ygot.Uint16(9000)
+	i.Type = exampleoc.IETFInterfaces_InterfaceType_ethernetCsmacd
+	i.GetOrCreateCounters().InOctets = ygot.Uint64(1 << 60)
+	i.GetOrCreateSubinterface(0).Description = ygot.String("untagged")
+	i.GetOrCreateSubinterface(10).Enabled = ygot.Bool(true)
+	i.GetOrCreateSubinterface(2)
+	d.GetOrCreateInterface("eth1").GetOrCreateHoldTime()
+	dns := d.GetOrCreateSystem().GetOrCreateDns()
+	dns.Search = []string{"example.com", "example.net"}
+	for _, a := range []string{"192.0.2.2", "192.0.2.1"} {
+		if _, err := dns.AppendNewServer(a); err != nil {
+			t.Fatalf("cannot append DNS server: %v", err)
+		}
+	}
+
+	om := &ctestschema.Device{
+		OrderedList: ctestschema.GetOrderedMap(t),
+	}
+
+	tests := []struct {
+		desc   string
+		in     ygot.GoStruct
+		inOpts []ygot.EmitJSONOpt
+	}{{
+		desc:   "internal format",
+		in:     d,
+		inOpts: []ygot.EmitJSONOpt{&ygot.SkipValidation{}},
+	}, {
+		desc:   "RFC7951 format",
+		in:     d,
+		inOpts: []ygot.EmitJSONOpt{ygot.RFC7951, &ygot.SkipValidation{}},
+	}, {
+		desc: "RFC7951 format with module names and indentation",
+		in:   d,
+		inOpts: []ygot.EmitJSONOpt{
+			&ygot.RFC7951JSONConfig{AppendModuleName: true},
+			ygot.JSONIndent("\t"),
+			&ygot.EscapeHTML{},
+			&ygot.SkipValidation{},
+		},
+	}, {
+		desc:   "shadow paths",
+		in:     d,
+		inOpts: []ygot.EmitJSONOpt{&ygot.PreferShadowPath{}, &ygot.SkipValidation{}},
+	}, {
+		desc:   "empty struct",
+		in:     &exampleoc.Device{},
+		inOpts: []ygot.EmitJSONOpt{ygot.RFC7951},
+	}, {
+		desc:   "ordered map internal format",
+		in:     om,
+		inOpts: []ygot.EmitJSONOpt{&ygot.SkipValidation{}},
+	}, {
+		desc:   "ordered map RFC7951 format",
+		in:     om,
+		inOpts: []ygot.EmitJSONOpt{&ygot.RFC7951JSONConfig{AppendModuleName: true}, &ygot.SkipValidation{}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			want, err := ygot.EmitJSONWithOpts(tt.in, tt.inOpts...)
+			if err != nil {
+				t.Fatalf("EmitJSONWithOpts: got unexpected error: %v", err)
+			}
+
+			var sb strings.Builder
+			if err := ygot.EncodeJSON(&sb, tt.in, tt.inOpts...); err != nil {
+				t.Fatalf("EncodeJSON: got unexpected error: %v", err)
+			}
+			if got := strings.TrimSuffix(sb.String(), "\n"); got != want {
+				diff, _ := testutil.GenerateUnifiedDiff(want, got)
+				t.Errorf("EncodeJSON: did not get output of EmitJSONWithOpts, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEncodeJSONErrors(t *testing.T) {
+	d := &exampleoc.Device{
+		Interface: map[string]*exampleoc.Interface{
+			"eth0": {Name: ygot.String("eth1")},
+		},
+	}
+
+	tests := []struct {
+		desc             string
+		inOpts           []ygot.EmitJSONOpt
+		wantErrSubstring string
+	}{{
+		desc:             "validation failure",
+		wantErrSubstring: "validation err",
+	}, {
+		desc:             "redactor",
+		inOpts:           []ygot.EmitJSONOpt{&ygot.Redactor{}, &ygot.SkipValidation{}},
+		wantErrSubstring: "does not support the Redactor option",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var sb strings.Builder
+			err := ygot.EncodeJSON(&sb, d, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("EncodeJSON: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"iter"
+	"reflect"
+	"sort"
+)
+
+// KeyedList is the type of a keyed YANG list within a generated GoStruct,
+// when code is generated with keyed list helper types, mapping the key of
+// each list entry to the entry. The key is the type of the single key of
+// the list, or the generated key struct of a list with multiple keys.
+//
+// KeyedList is a map type, such that it is handled in the same way as the
+// maps that are otherwise generated for keyed lists by functions that
+// reflect upon a GoStruct, and such that a KeyedList may be assigned to and
+// from such a map.
+type KeyedList[K comparable, V any] map[K]V
+
+// Get returns the entry of the list with the key k, and whether the entry
+// exists.
+func (l KeyedList[K, V]) Get(k K) (V, bool) {
+	v, ok := l[k]
+	return v, ok
+}
+
+// GetOrCreate returns the entry of the list with the key k. If the entry
+// does not exist, it is created using the function newEntry and added to
+// the list, which is initialised if it is nil.
+func (l *KeyedList[K, V]) GetOrCreate(k K, newEntry func(K) V) V {
+	if v, ok := (*l)[k]; ok {
+		return v
+	}
+	if *l == nil {
+		*l = KeyedList[K, V]{}
+	}
+	v := newEntry(k)
+	(*l)[k] = v
+	return v
+}
+
+// Delete removes the entry with the key k from the list, if it exists.
+func (l KeyedList[K, V]) Delete(k K) {
+	delete(l, k)
+}
+
+// Len returns the number of entries of the list.
+func (l KeyedList[K, V]) Len() int {
+	return len(l)
+}
+
+// Keys returns the keys of the entries of the list in sorted order. Numeric
+// keys are sorted numerically, string keys lexically, and the keys of lists
+// with multiple keys are sorted by each key in turn.
+func (l KeyedList[K, V]) Keys() []K {
+	keys := make([]K, 0, len(l))
+	for k := range l {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return queryKeyLess(reflect.ValueOf(keys[i]), reflect.ValueOf(keys[j]))
+	})
+	return keys
+}
+
+// All returns an iterator over the entries of the list, in the order of
+// their keys as returned by Keys.
+func (l KeyedList[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, k := range l.Keys() {
+			if !yield(k, l[k]) {
+				return
+			}
+		}
+	}
+}
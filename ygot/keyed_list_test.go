@@ -0,0 +1,120 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type keyedListTestKey struct {
+	Name string
+	ID   uint32
+}
+
+func TestKeyedList(t *testing.T) {
+	var l KeyedList[keyedListTestKey, *string]
+	if got := l.Len(); got != 0 {
+		t.Errorf("Len: got %d for nil list, want 0", got)
+	}
+	if _, ok := l.Get(keyedListTestKey{"a", 1}); ok {
+		t.Errorf("Get: got entry for nil list")
+	}
+
+	created := 0
+	newEntry := func(k keyedListTestKey) *string {
+		created++
+		return String(k.Name)
+	}
+	for _, k := range []keyedListTestKey{{"b", 1}, {"a", 10}, {"a", 2}, {"b", 1}} {
+		l.GetOrCreate(k, newEntry)
+	}
+	if created != 3 {
+		t.Errorf("GetOrCreate: created %d entries, want 3", created)
+	}
+	if got := l.Len(); got != 3 {
+		t.Errorf("Len: got %d, want 3", got)
+	}
+	if v, ok := l.Get(keyedListTestKey{"a", 10}); !ok || *v != "a" {
+		t.Errorf("Get: got (%v, %v), want (a, true)", v, ok)
+	}
+
+	wantKeys := []keyedListTestKey{{"a", 2}, {"a", 10}, {"b", 1}}
+	if diff := cmp.Diff(wantKeys, l.Keys()); diff != "" {
+		t.Errorf("Keys: (-want, +got):\n%s", diff)
+	}
+	var gotKeys []keyedListTestKey
+	for k := range l.All() {
+		gotKeys = append(gotKeys, k)
+		if len(gotKeys) == 2 {
+			break
+		}
+	}
+	if diff := cmp.Diff(wantKeys[:2], gotKeys); diff != "" {
+		t.Errorf("All: (-want, +got):\n%s", diff)
+	}
+
+	l.Delete(keyedListTestKey{"a", 2})
+	if _, ok := l.Get(keyedListTestKey{"a", 2}); ok || l.Len() != 2 {
+		t.Errorf("Delete: entry not deleted, got %d entries", l.Len())
+	}
+}
+
+type keyedListTestStruct struct {
+	Neighbor KeyedList[string, *exampleBgpNeighbor] `path:"neighbors/neighbor"`
+}
+
+func (*keyedListTestStruct) IsYANGGoStruct()                         {}
+func (*keyedListTestStruct) ΛValidate(...ValidationOption) error     { return nil }
+func (*keyedListTestStruct) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*keyedListTestStruct) ΛBelongingModule() string                { return "" }
+
+func TestKeyedListGoStruct(t *testing.T) {
+	orig := &keyedListTestStruct{}
+	orig.Neighbor.GetOrCreate("192.0.2.1", func(k string) *exampleBgpNeighbor {
+		return &exampleBgpNeighbor{NeighborAddress: String(k)}
+	})
+
+	gotJSON, err := ConstructIETFJSON(orig, nil)
+	if err != nil {
+		t.Fatalf("ConstructIETFJSON: got unexpected error: %v", err)
+	}
+	wantJSON := map[string]any{
+		"neighbors": map[string]any{
+			"neighbor": []any{
+				map[string]any{
+					"neighbor-address": "192.0.2.1",
+					"config":           map[string]any{"neighbor-address": "192.0.2.1"},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(wantJSON, gotJSON); diff != "" {
+		t.Errorf("ConstructIETFJSON: (-want, +got):\n%s", diff)
+	}
+
+	got, err := DeepCopy(orig)
+	if err != nil {
+		t.Fatalf("DeepCopy: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(orig, got); diff != "" {
+		t.Errorf("DeepCopy: (-want, +got):\n%s", diff)
+	}
+	if got.(*keyedListTestStruct).Neighbor["192.0.2.1"] == orig.Neighbor["192.0.2.1"] {
+		t.Errorf("DeepCopy: list entry was not copied")
+	}
+}
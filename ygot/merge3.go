@@ -0,0 +1,398 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/openconfig/ygot/util"
+	"google.golang.org/protobuf/proto"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Merge3Opt is an option that can be supplied to Merge3.
+type Merge3Opt interface {
+	// IsMerge3Opt is a marker method for each Merge3Opt.
+	IsMerge3Opt()
+}
+
+// Merge3PreferOurs is a Merge3Opt that specifies that conflicts are resolved
+// by taking the value of ours, rather than being returned as an error.
+type Merge3PreferOurs struct{}
+
+// IsMerge3Opt marks Merge3PreferOurs as a Merge3Opt.
+func (*Merge3PreferOurs) IsMerge3Opt() {}
+
+// Merge3PreferTheirs is a Merge3Opt that specifies that conflicts are
+// resolved by taking the value of theirs, rather than being returned as an
+// error.
+type Merge3PreferTheirs struct{}
+
+// IsMerge3Opt marks Merge3PreferTheirs as a Merge3Opt.
+func (*Merge3PreferTheirs) IsMerge3Opt() {}
+
+// Merge3Conflict describes a conflict found by Merge3, where ours and theirs
+// both changed the node at Path from its value in base, to different values.
+type Merge3Conflict struct {
+	// Path is the data tree path of the conflicting node.
+	Path *gnmipb.Path
+	// Base, Ours and Theirs are the values of the node within each of the
+	// merged GoStructs, which are nil where the node is not set. Where
+	// the node is a list entry, the values are the GoStructs of the entry.
+	Base, Ours, Theirs interface{}
+}
+
+// String returns a human-readable description of the conflict.
+func (c *Merge3Conflict) String() string {
+	p, err := PathToString(c.Path)
+	if err != nil {
+		p = c.Path.String()
+	}
+	return fmt.Sprintf("%s: base: %v, ours: %v, theirs: %v", p, merge3ConflictValue(c.Base), merge3ConflictValue(c.Ours), merge3ConflictValue(c.Theirs))
+}
+
+// merge3ConflictValue returns a human-readable form of a conflicting value.
+func merge3ConflictValue(v interface{}) string {
+	switch {
+	case v == nil:
+		return "<unset>"
+	case reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).Elem().Kind() != reflect.Struct:
+		return fmt.Sprintf("%v", reflect.ValueOf(v).Elem().Interface())
+	case reflect.ValueOf(v).Kind() == reflect.Ptr:
+		return "<entry>"
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// Merge3ConflictError is the error returned by Merge3 when conflicts are
+// found and no conflict resolution option is specified.
+type Merge3ConflictError struct {
+	// Conflicts is the set of conflicts that were found.
+	Conflicts []*Merge3Conflict
+}
+
+// Error implements the error interface.
+func (e *Merge3ConflictError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d conflict(s) found in three-way merge:", len(e.Conflicts))
+	for _, c := range e.Conflicts {
+		fmt.Fprintf(&b, "\n\t%s", c)
+	}
+	return b.String()
+}
+
+// Merge3 performs a three-way merge of the GoStructs ours and theirs, which
+// are both modifications of the common ancestor base, returning a new
+// GoStruct that contains the changes made by both. It can be used to
+// reconcile the intended configuration of a controller (ours) with the
+// configuration of a device that was changed out-of-band (theirs), where
+// base is the configuration that was last pushed to the device.
+//
+// Changes are merged at leaf and list entry granularity:
+//   - Where a leaf or leaf-list is changed by only one side, or is changed
+//     identically by both, the change is taken. Where it is changed to
+//     different values by both sides, including where one side unsets it,
+//     it is a conflict.
+//   - Where a list entry is removed by one side and any leaf within it is
+//     changed by the other, it is a conflict for the entire entry. Entries
+//     that are added by both sides are merged leaf by leaf.
+//   - Lists that are ordered by the user are merged as a single value, since
+//     their order cannot be merged.
+//
+// Unless Merge3PreferOurs or Merge3PreferTheirs is supplied, a
+// *Merge3ConflictError listing all conflicts is returned if any are found.
+// The supplied GoStructs are not modified.
+func Merge3(base, ours, theirs GoStruct, opts ...Merge3Opt) (GoStruct, error) {
+	t := reflect.TypeOf(base)
+	if t != reflect.TypeOf(ours) || t != reflect.TypeOf(theirs) {
+		return nil, fmt.Errorf("cannot merge structs that are not of matching types, base: %T, ours: %T, theirs: %T", base, ours, theirs)
+	}
+
+	m := &merger3{}
+	for _, o := range opts {
+		switch o.(type) {
+		case *Merge3PreferOurs:
+			m.preferOurs = true
+		case *Merge3PreferTheirs:
+			m.preferTheirs = true
+		}
+	}
+	if m.preferOurs && m.preferTheirs {
+		return nil, fmt.Errorf("Merge3PreferOurs and Merge3PreferTheirs cannot both be specified")
+	}
+
+	r, err := m.mergeStruct(reflect.ValueOf(base), reflect.ValueOf(ours), reflect.ValueOf(theirs), t, &gnmipb.Path{})
+	if err != nil {
+		return nil, err
+	}
+	if len(m.conflicts) != 0 && !m.preferOurs && !m.preferTheirs {
+		return nil, &Merge3ConflictError{Conflicts: m.conflicts}
+	}
+	if r.IsNil() {
+		r = reflect.New(t.Elem())
+	}
+	// The merged struct shares values with the inputs, so is copied such
+	// that it is independent of them.
+	return DeepCopy(r.Interface().(GoStruct))
+}
+
+// merger3 holds the state of a three-way merge.
+type merger3 struct {
+	// preferOurs and preferTheirs specify how conflicts are resolved.
+	preferOurs, preferTheirs bool
+	// conflicts is the set of conflicts found.
+	conflicts []*Merge3Conflict
+}
+
+// resolve records a conflict at path between the values b, o and t, and
+// returns the value that is resolved to.
+func (m *merger3) resolve(path *gnmipb.Path, b, o, t reflect.Value) reflect.Value {
+	m.conflicts = append(m.conflicts, &Merge3Conflict{
+		Path:   proto.Clone(path).(*gnmipb.Path),
+		Base:   merge3Interface(b),
+		Ours:   merge3Interface(o),
+		Theirs: merge3Interface(t),
+	})
+	if m.preferTheirs {
+		return t
+	}
+	return o
+}
+
+// merge3Interface returns the value of v as an interface, or nil if it is
+// unset.
+func merge3Interface(v reflect.Value) interface{} {
+	if !v.IsValid() || v.IsZero() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// merge3Equal reports whether the values a and b are equal, where unset
+// values are equal to each other.
+func merge3Equal(a, b reflect.Value) bool {
+	az, bz := !a.IsValid() || a.IsZero(), !b.IsValid() || b.IsZero()
+	if az || bz {
+		return az == bz
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// mergeStruct merges the struct pointers b, o and t, of type typ, any of
+// which may be nil, returning the merged struct pointer, which is nil if no
+// fields are set. path is the data tree path of the struct.
+func (m *merger3) mergeStruct(b, o, t reflect.Value, typ reflect.Type, path *gnmipb.Path) (reflect.Value, error) {
+	elem := func(v reflect.Value) reflect.Value {
+		if v.IsNil() {
+			return reflect.Zero(typ.Elem())
+		}
+		return v.Elem()
+	}
+	bv, ov, tv := elem(b), elem(o), elem(t)
+	r := reflect.New(typ.Elem())
+	var set bool
+	var errs util.Errors
+	for i := 0; i < typ.Elem().NumField(); i++ {
+		ft := typ.Elem().Field(i)
+		if util.IsYgotAnnotation(ft) || util.IsYgotPresence(ft) || util.IsYgotExtensions(ft) {
+			continue
+		}
+		sp, err := util.SchemaPaths(ft)
+		if err != nil {
+			errs = util.AppendErr(errs, err)
+			continue
+		}
+		fpath := proto.Clone(path).(*gnmipb.Path)
+		for _, e := range leastSpecificPath(sp) {
+			fpath.Elem = append(fpath.Elem, &gnmipb.PathElem{Name: e})
+		}
+
+		bf, of, tf := util.FieldValue(bv, i), util.FieldValue(ov, i), util.FieldValue(tv, i)
+		var rf reflect.Value
+		switch {
+		case ft.Type.Kind() == reflect.Map:
+			rf, err = m.mergeMap(bf, of, tf, fpath)
+		case util.IsTypeStructPtr(ft.Type) && !ft.Type.Implements(reflect.TypeOf((*GoOrderedMap)(nil)).Elem()):
+			rf, err = m.mergeStruct(bf, of, tf, ft.Type, fpath)
+		default:
+			rf = m.mergeLeaf(bf, of, tf, fpath)
+		}
+		if err != nil {
+			errs = util.AppendErr(errs, err)
+			continue
+		}
+		if rf.IsValid() && !rf.IsZero() {
+			if err := util.SetFieldValue(r.Elem(), i, rf); err != nil {
+				errs = util.AppendErr(errs, err)
+				continue
+			}
+			set = true
+		}
+	}
+	// Extension structs of the same type are merged with each other, at
+	// the path of the struct.
+	bx, ox, tx := extensionsByName(b), extensionsByName(o), extensionsByName(t)
+	for _, name := range extensionNames(bx, ox, tx) {
+		var et reflect.Type
+		for _, x := range []map[string]reflect.Value{bx, ox, tx} {
+			if v, ok := x[name]; ok {
+				et = v.Type()
+			}
+		}
+		ext := func(x map[string]reflect.Value) reflect.Value {
+			if v, ok := x[name]; ok {
+				return v
+			}
+			return reflect.Zero(et)
+		}
+		rx, err := m.mergeStruct(ext(bx), ext(ox), ext(tx), et, path)
+		if err != nil {
+			errs = util.AppendErr(errs, err)
+			continue
+		}
+		if !rx.IsNil() {
+			if err := AttachExtension(r.Interface().(GoStruct), rx.Interface().(ExtensionStruct)); err != nil {
+				errs = util.AppendErr(errs, err)
+				continue
+			}
+			set = true
+		}
+	}
+	if errs != nil {
+		return reflect.Value{}, errs
+	}
+	if !set {
+		return reflect.Zero(typ), nil
+	}
+	return r, nil
+}
+
+// mergeLeaf merges the leaf values b, o and t, which is also used for lists
+// that are ordered by the user, returning the merged value.
+func (m *merger3) mergeLeaf(b, o, t reflect.Value, path *gnmipb.Path) reflect.Value {
+	switch {
+	case merge3Equal(o, t), merge3Equal(b, t):
+		return o
+	case merge3Equal(b, o):
+		return t
+	}
+	return m.resolve(path, b, o, t)
+}
+
+// mergeMap merges the maps b, o and t, which represent a keyed YANG list at
+// path, entry by entry, returning the merged map.
+func (m *merger3) mergeMap(b, o, t reflect.Value, path *gnmipb.Path) (reflect.Value, error) {
+	typ := o.Type()
+	r := reflect.MakeMap(typ)
+	keys := map[interface{}]reflect.Value{}
+	for _, mv := range []reflect.Value{b, o, t} {
+		for _, k := range mv.MapKeys() {
+			keys[k.Interface()] = k
+		}
+	}
+
+	var errs util.Errors
+	for _, k := range keys {
+		be, oe, te := b.MapIndex(k), o.MapIndex(k), t.MapIndex(k)
+		present := func(v reflect.Value) bool { return v.IsValid() && !v.IsNil() }
+		entry := func(v reflect.Value) reflect.Value {
+			if !present(v) {
+				return reflect.Zero(typ.Elem())
+			}
+			return v
+		}
+		epath, err := merge3EntryPath(path, k, be, oe, te)
+		if err != nil {
+			errs = util.AppendErr(errs, err)
+			continue
+		}
+
+		var re reflect.Value
+		switch {
+		case !present(oe) && !present(te):
+			continue
+		case !present(oe) || !present(te):
+			// The entry is removed by one side, or added by one
+			// side where it is not within base.
+			kept := oe
+			if !present(oe) {
+				kept = te
+			}
+			switch {
+			case !present(be):
+				re = kept
+			case reflect.DeepEqual(be.Interface(), kept.Interface()):
+				// The entry is removed by one side and unchanged by
+				// the other.
+			default:
+				re = m.resolve(epath, be, entry(oe), entry(te))
+			}
+		default:
+			if re, err = m.mergeStruct(entry(be), oe, te, typ.Elem(), epath); err != nil {
+				errs = util.AppendErr(errs, err)
+				continue
+			}
+		}
+		if present(re) {
+			r.SetMapIndex(k, re)
+		}
+	}
+	if errs != nil {
+		return reflect.Value{}, errs
+	}
+	if r.Len() == 0 {
+		return reflect.Zero(typ), nil
+	}
+	return r, nil
+}
+
+// merge3EntryPath returns the data tree path of the list entry with key k
+// of the list at path, using the first of the supplied entries that is
+// present to determine the names of its keys.
+func merge3EntryPath(path *gnmipb.Path, k reflect.Value, entries ...reflect.Value) (*gnmipb.Path, error) {
+	p := proto.Clone(path).(*gnmipb.Path)
+	for _, e := range entries {
+		if !e.IsValid() || e.IsNil() {
+			continue
+		}
+		kh, ok := e.Interface().(KeyHelperGoStruct)
+		if !ok {
+			break
+		}
+		keys, err := kh.ΛListKeyMap()
+		if err != nil {
+			return nil, err
+		}
+		strkeys, err := keyMapAsStrings(keys)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert keys to map[string]string: %v", err)
+		}
+		if len(p.Elem) != 0 {
+			p.Elem[len(p.Elem)-1].Key = strkeys
+		}
+		return p, nil
+	}
+	ks, err := KeyValueAsString(k.Interface())
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Elem) != 0 {
+		p.Elem[len(p.Elem)-1].Key = map[string]string{"key": ks}
+	}
+	return p, nil
+}
@@ -0,0 +1,238 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot_test
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestMerge3(t *testing.T) {
+	base := func() *exampleoc.Device {
+		d := &exampleoc.Device{}
+		i := d.GetOrCreateInterface("eth0")
+		i.Mtu = ygot.Uint16(1500)
+		i.Description = ygot.String("uplink")
+		d.GetOrCreateInterface("eth1").Mtu = ygot.Uint16(9000)
+		d.GetOrCreateSystem().Hostname = ygot.String("dev1")
+		return d
+	}
+
+	tests := []struct {
+		desc             string
+		inOurs           func() *exampleoc.Device
+		inTheirs         func() *exampleoc.Device
+		inOpts           []ygot.Merge3Opt
+		want             func() *exampleoc.Device
+		wantConflicts    []string
+		wantErrSubstring string
+	}{{
+		desc: "non-overlapping leaf changes",
+		inOurs: func() *exampleoc.Device {
+			d := base()
+			d.GetInterface("eth0").Mtu = ygot.Uint16(9216)
+			return d
+		},
+		inTheirs: func() *exampleoc.Device {
+			d := base()
+			d.GetSystem().Hostname = ygot.String("dev2")
+			d.GetInterface("eth0").Description = nil
+			return d
+		},
+		want: func() *exampleoc.Device {
+			d := base()
+			d.GetInterface("eth0").Mtu = ygot.Uint16(9216)
+			d.GetInterface("eth0").Description = nil
+			d.GetSystem().Hostname = ygot.String("dev2")
+			return d
+		},
+	}, {
+		desc: "identical changes",
+		inOurs: func() *exampleoc.Device {
+			d := base()
+			d.GetOrCreateInterface("eth2").Mtu = ygot.Uint16(1400)
+			return d
+		},
+		inTheirs: func() *exampleoc.Device {
+			d := base()
+			d.GetOrCreateInterface("eth2").Mtu = ygot.Uint16(1400)
+			return d
+		},
+		want: func() *exampleoc.Device {
+			d := base()
+			d.GetOrCreateInterface("eth2").Mtu = ygot.Uint16(1400)
+			return d
+		},
+	}, {
+		desc: "entries added by both sides merged leaf by leaf",
+		inOurs: func() *exampleoc.Device {
+			d := base()
+			d.GetOrCreateInterface("eth2").Mtu = ygot.Uint16(1400)
+			return d
+		},
+		inTheirs: func() *exampleoc.Device {
+			d := base()
+			d.GetOrCreateInterface("eth2").Description = ygot.String("new")
+			return d
+		},
+		want: func() *exampleoc.Device {
+			d := base()
+			i := d.GetOrCreateInterface("eth2")
+			i.Mtu = ygot.Uint16(1400)
+			i.Description = ygot.String("new")
+			return d
+		},
+	}, {
+		desc: "entry removed by one side and unchanged by other",
+		inOurs: func() *exampleoc.Device {
+			d := base()
+			delete(d.Interface, "eth1")
+			return d
+		},
+		inTheirs: base,
+		want: func() *exampleoc.Device {
+			d := base()
+			delete(d.Interface, "eth1")
+			return d
+		},
+	}, {
+		desc: "leaf conflict",
+		inOurs: func() *exampleoc.Device {
+			d := base()
+			d.GetInterface("eth0").Mtu = ygot.Uint16(9216)
+			d.GetSystem().Hostname = ygot.String("dev2")
+			return d
+		},
+		inTheirs: func() *exampleoc.Device {
+			d := base()
+			d.GetInterface("eth0").Mtu = ygot.Uint16(1400)
+			d.GetSystem().Hostname = nil
+			return d
+		},
+		wantConflicts: []string{
+			"/interfaces/interface[name=eth0]/config/mtu: base: 1500, ours: 9216, theirs: 1400",
+			"/system/config/hostname: base: dev1, ours: dev2, theirs: <unset>",
+		},
+	}, {
+		desc: "entry removed by one side and changed by other",
+		inOurs: func() *exampleoc.Device {
+			d := base()
+			delete(d.Interface, "eth1")
+			return d
+		},
+		inTheirs: func() *exampleoc.Device {
+			d := base()
+			d.GetInterface("eth1").Mtu = ygot.Uint16(1500)
+			return d
+		},
+		wantConflicts: []string{
+			"/interfaces/interface[name=eth1]: base: <entry>, ours: <unset>, theirs: <entry>",
+		},
+	}, {
+		desc: "conflict resolved with ours",
+		inOurs: func() *exampleoc.Device {
+			d := base()
+			delete(d.Interface, "eth1")
+			d.GetInterface("eth0").Mtu = ygot.Uint16(9216)
+			return d
+		},
+		inTheirs: func() *exampleoc.Device {
+			d := base()
+			d.GetInterface("eth1").Mtu = ygot.Uint16(1500)
+			d.GetInterface("eth0").Mtu = ygot.Uint16(1400)
+			return d
+		},
+		inOpts: []ygot.Merge3Opt{&ygot.Merge3PreferOurs{}},
+		want: func() *exampleoc.Device {
+			d := base()
+			delete(d.Interface, "eth1")
+			d.GetInterface("eth0").Mtu = ygot.Uint16(9216)
+			return d
+		},
+	}, {
+		desc: "conflict resolved with theirs",
+		inOurs: func() *exampleoc.Device {
+			d := base()
+			delete(d.Interface, "eth1")
+			d.GetInterface("eth0").Mtu = ygot.Uint16(9216)
+			return d
+		},
+		inTheirs: func() *exampleoc.Device {
+			d := base()
+			d.GetInterface("eth1").Mtu = ygot.Uint16(1500)
+			d.GetInterface("eth0").Mtu = ygot.Uint16(1400)
+			return d
+		},
+		inOpts: []ygot.Merge3Opt{&ygot.Merge3PreferTheirs{}},
+		want: func() *exampleoc.Device {
+			d := base()
+			d.GetInterface("eth1").Mtu = ygot.Uint16(1500)
+			d.GetInterface("eth0").Mtu = ygot.Uint16(1400)
+			return d
+		},
+	}, {
+		desc:             "conflicting options",
+		inOurs:           base,
+		inTheirs:         base,
+		inOpts:           []ygot.Merge3Opt{&ygot.Merge3PreferOurs{}, &ygot.Merge3PreferTheirs{}},
+		wantErrSubstring: "cannot both be specified",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b, o, th := base(), tt.inOurs(), tt.inTheirs()
+			got, err := ygot.Merge3(b, o, th, tt.inOpts...)
+			if len(tt.wantConflicts) != 0 {
+				var cerr *ygot.Merge3ConflictError
+				if !errors.As(err, &cerr) {
+					t.Fatalf("Merge3: got error %v, want *Merge3ConflictError", err)
+				}
+				var gotConflicts []string
+				for _, c := range cerr.Conflicts {
+					gotConflicts = append(gotConflicts, c.String())
+				}
+				sort.Strings(gotConflicts)
+				if diff := cmp.Diff(tt.wantConflicts, gotConflicts); diff != "" {
+					t.Errorf("Merge3: did not get expected conflicts (-want, +got):\n%s", diff)
+				}
+				return
+			}
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("Merge3: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want(), got); diff != "" {
+				t.Errorf("Merge3: did not get expected result (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.inOurs(), o); diff != "" {
+				t.Errorf("Merge3: ours was modified (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMerge3MismatchedTypes(t *testing.T) {
+	if _, err := ygot.Merge3(&exampleoc.Device{}, &exampleoc.Device{}, &exampleoc.Interface{}); err == nil {
+		t.Errorf("Merge3 with mismatched types: got nil error, want error")
+	}
+}
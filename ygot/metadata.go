@@ -0,0 +1,170 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// OriginAnnotation is the name of the metadata annotation defined by
+	// the ietf-origin module (RFC 8342) that indicates the origin of a
+	// data node within the operational datastore.
+	OriginAnnotation = "ietf-origin:origin"
+	// LastModifiedAnnotation is the name of the metadata annotation that
+	// is used by RESTCONF servers to indicate the time at which a data
+	// node was last modified, in the yang:date-and-time format.
+	LastModifiedAnnotation = "ietf-restconf:last-modified"
+)
+
+// Metadata is an Annotation that stores the standard metadata annotations
+// of a data node, as defined by RFC 7952. Each annotation is keyed by its
+// module-qualified name, e.g., "ietf-origin:origin", and its value is the
+// value of the annotation as it is represented in RFC 7951 JSON.
+//
+// When a Metadata is the only annotation within an annotation field of a
+// GoStruct, it is rendered to JSON as the RFC 7952 object named by the
+// field, e.g., "@" for the metadata of a container or "@mtu" for that of
+// the leaf mtu, rather than as an array of annotations. Such objects are
+// unmarshalled into annotation fields as a Metadata.
+type Metadata struct {
+	// Values is the set of metadata annotations, keyed by their
+	// module-qualified names.
+	Values map[string]any
+}
+
+// MarshalJSON marshals the annotations of m to an RFC 7952 metadata object.
+// It returns an error if the name of an annotation is not module-qualified.
+func (m *Metadata) MarshalJSON() ([]byte, error) {
+	for _, n := range m.names() {
+		if err := validateMetadataName(n); err != nil {
+			return nil, err
+		}
+	}
+	if m.Values == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(m.Values)
+}
+
+// UnmarshalJSON unmarshals the RFC 7952 metadata object d into m, replacing
+// its existing annotations. It returns an error if the name of an
+// annotation is not module-qualified.
+func (m *Metadata) UnmarshalJSON(d []byte) error {
+	var vals map[string]any
+	if err := json.Unmarshal(d, &vals); err != nil {
+		return fmt.Errorf("cannot unmarshal metadata object: %v", err)
+	}
+	for n := range vals {
+		if err := validateMetadataName(n); err != nil {
+			return err
+		}
+	}
+	m.Values = vals
+	return nil
+}
+
+// names returns the names of the annotations of m in sorted order.
+func (m *Metadata) names() []string {
+	var names []string
+	for n := range m.Values {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the value of the annotation with the module-qualified name
+// supplied, and whether it is set.
+func (m *Metadata) Get(name string) (any, bool) {
+	if m == nil {
+		return nil, false
+	}
+	v, ok := m.Values[name]
+	return v, ok
+}
+
+// Set sets the value of the annotation with the module-qualified name
+// supplied.
+func (m *Metadata) Set(name string, v any) {
+	if m.Values == nil {
+		m.Values = map[string]any{}
+	}
+	m.Values[name] = v
+}
+
+// Origin returns the value of the ietf-origin:origin annotation, which is
+// the module-qualified name of an identity such as "ietf-origin:intended",
+// or the empty string if it is not set.
+func (m *Metadata) Origin() string {
+	v, _ := m.Get(OriginAnnotation)
+	s, _ := v.(string)
+	return s
+}
+
+// SetOrigin sets the ietf-origin:origin annotation to the module-qualified
+// identity name supplied.
+func (m *Metadata) SetOrigin(origin string) {
+	m.Set(OriginAnnotation, origin)
+}
+
+// LastModified returns the time stored in the last-modified annotation,
+// and whether it is set. An error is returned if the annotation is not a
+// valid yang:date-and-time value.
+func (m *Metadata) LastModified() (time.Time, bool, error) {
+	v, ok := m.Get(LastModifiedAnnotation)
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return time.Time{}, false, fmt.Errorf("invalid %s annotation type %T, expected string", LastModifiedAnnotation, v)
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid %s annotation %q: %v", LastModifiedAnnotation, s, err)
+	}
+	return t, true, nil
+}
+
+// SetLastModified sets the last-modified annotation to the time supplied.
+func (m *Metadata) SetLastModified(t time.Time) {
+	m.Set(LastModifiedAnnotation, t.Format(time.RFC3339Nano))
+}
+
+// FindMetadata returns the first Metadata within the annotations supplied,
+// or nil if there is none.
+func FindMetadata(annotations []Annotation) *Metadata {
+	for _, a := range annotations {
+		if m, ok := a.(*Metadata); ok {
+			return m
+		}
+	}
+	return nil
+}
+
+// validateMetadataName returns an error if name is not a module-qualified
+// annotation name, as required by RFC 7952 for the JSON encoding.
+func validateMetadataName(name string) error {
+	mod, n, ok := strings.Cut(name, ":")
+	if !ok || mod == "" || n == "" {
+		return fmt.Errorf("metadata annotation name %q is not module-qualified", name)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+func TestMetadataJSON(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               *Metadata
+		want             string
+		wantErrSubstring string
+	}{{
+		desc: "empty",
+		in:   &Metadata{},
+		want: `{}`,
+	}, {
+		desc: "module-qualified names",
+		in: &Metadata{Values: map[string]any{
+			OriginAnnotation: "ietf-origin:learned",
+			"m:count":        float64(2),
+		}},
+		want: `{"ietf-origin:origin":"ietf-origin:learned","m:count":2}`,
+	}, {
+		desc:             "unqualified name",
+		in:               &Metadata{Values: map[string]any{"origin": "learned"}},
+		wantErrSubstring: `metadata annotation name "origin" is not module-qualified`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := tt.in.MarshalJSON()
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("MarshalJSON: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if string(got) != tt.want {
+				t.Fatalf("MarshalJSON: got %s, want %s", got, tt.want)
+			}
+
+			md := &Metadata{}
+			if err := md.UnmarshalJSON(got); err != nil {
+				t.Fatalf("UnmarshalJSON: got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.in.Values, md.Values, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("UnmarshalJSON: (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMetadataAccessors(t *testing.T) {
+	md := &Metadata{}
+	if got := md.Origin(); got != "" {
+		t.Errorf("Origin: got %q for unset annotation, want empty string", got)
+	}
+	if _, ok, err := md.LastModified(); ok || err != nil {
+		t.Errorf("LastModified: got (%v, %v) for unset annotation, want (false, nil)", ok, err)
+	}
+
+	md.SetOrigin("ietf-origin:intended")
+	if got, want := md.Origin(), "ietf-origin:intended"; got != want {
+		t.Errorf("Origin: got %q, want %q", got, want)
+	}
+
+	ts := time.Date(2026, 10, 15, 12, 30, 0, 0, time.UTC)
+	md.SetLastModified(ts)
+	got, ok, err := md.LastModified()
+	if err != nil || !ok {
+		t.Fatalf("LastModified: got unexpected result (%v, %v)", ok, err)
+	}
+	if !got.Equal(ts) {
+		t.Errorf("LastModified: got %v, want %v", got, ts)
+	}
+
+	md.Set(LastModifiedAnnotation, "yesterday")
+	if _, _, err := md.LastModified(); err == nil {
+		t.Errorf("LastModified: did not get expected error for invalid timestamp")
+	}
+
+	if got := FindMetadata([]Annotation{&testAnnotation{}, md}); got != md {
+		t.Errorf("FindMetadata: got %v, want %v", got, md)
+	}
+	if got := FindMetadata([]Annotation{&testAnnotation{}}); got != nil {
+		t.Errorf("FindMetadata: got %v, want nil", got)
+	}
+}
+
+func TestConstructIETFJSONMetadata(t *testing.T) {
+	in := &annotatedJSONTestStruct{
+		Field: String("russian-river"),
+		ΛField: []Annotation{
+			&Metadata{Values: map[string]any{OriginAnnotation: "ietf-origin:intended"}},
+			&Metadata{Values: map[string]any{"m:tag": "sonoma"}},
+		},
+		ΛFieldTwo: []Annotation{&Metadata{}},
+	}
+	got, err := ConstructIETFJSON(in, nil)
+	if err != nil {
+		t.Fatalf("ConstructIETFJSON: got unexpected error: %v", err)
+	}
+	want := map[string]any{
+		"field": "russian-river",
+		"@field": map[string]any{
+			OriginAnnotation: "ietf-origin:intended",
+			"m:tag":          "sonoma",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ConstructIETFJSON: (-want, +got):\n%s", diff)
+	}
+}
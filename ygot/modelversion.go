@@ -0,0 +1,203 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// VersionedSchema is the interface to the data tree of a generated package
+// that is common to each version of the models it was generated from, such
+// that a single binary can manage devices that run different releases of
+// the models. It is implemented by ytypes.Schema.
+type VersionedSchema interface {
+	// NewRoot returns a new, empty root GoStruct of the schema.
+	NewRoot() GoStruct
+	// Get returns the values of the nodes within root that match path,
+	// which may contain wildcards.
+	Get(root GoStruct, path *gnmipb.Path) ([]any, error)
+	// Set sets the node at path within root to val, creating any
+	// missing parent nodes.
+	Set(root GoStruct, path *gnmipb.Path, val *gnmipb.TypedValue) error
+	// Delete deletes the node at path within root.
+	Delete(root GoStruct, path *gnmipb.Path) error
+}
+
+// ModelSet is a generated package that is registered with a ModelRegistry.
+type ModelSet struct {
+	// Name is the name that the package was registered with.
+	Name string
+	// Models is the set of models, and their versions, from which the
+	// package was generated, as stored in the ΓModelData variable that is
+	// generated when model data is included in generated code.
+	Models []*gnmipb.ModelData
+	// Schema is the interface to the data tree of the package.
+	Schema VersionedSchema
+}
+
+// ModelRegistry is a set of generated packages, each of which was generated
+// from a different version of a set of models, from which the package that
+// is most compatible with the models supported by a device can be
+// selected. It is safe for concurrent use.
+type ModelRegistry struct {
+	// mu protects sets.
+	mu sync.RWMutex
+	// sets is the set of registered packages, in the order in which they
+	// were registered.
+	sets []*ModelSet
+}
+
+// NewModelRegistry returns an empty ModelRegistry.
+func NewModelRegistry() *ModelRegistry {
+	return &ModelRegistry{}
+}
+
+// Register adds the generated package with the supplied name, models and
+// schema to the registry. The name must be unique within the registry.
+func (r *ModelRegistry) Register(name string, models []*gnmipb.ModelData, schema VersionedSchema) error {
+	if len(models) == 0 {
+		return fmt.Errorf("model set %s must specify at least one model", name)
+	}
+	if schema == nil {
+		return fmt.Errorf("model set %s must specify a schema", name)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range r.sets {
+		if s.Name == name {
+			return fmt.Errorf("model set %s is already registered", name)
+		}
+	}
+	r.sets = append(r.sets, &ModelSet{Name: name, Models: models, Schema: schema})
+	return nil
+}
+
+// SelectForCapabilities selects the registered package that is most
+// compatible with the models supported by a device, as reported in its
+// gNMI CapabilityResponse, as per Select.
+func (r *ModelRegistry) SelectForCapabilities(c *gnmipb.CapabilityResponse) (*ModelSet, error) {
+	return r.Select(c.GetSupportedModels())
+}
+
+// SelectForVersions selects the registered package that is most compatible
+// with the supplied map of module name to version, as reported by the
+// openconfig-version extension of each module, as per Select.
+func (r *ModelRegistry) SelectForVersions(versions map[string]string) (*ModelSet, error) {
+	var models []*gnmipb.ModelData
+	for n, v := range versions {
+		models = append(models, &gnmipb.ModelData{Name: n, Version: v})
+	}
+	return r.Select(models)
+}
+
+// Select returns the registered package that is most compatible with the
+// supplied models. A model of a package is compatible with a supplied model
+// of the same name if their versions are equal, or are semantic versions
+// that are compatible, i.e., have the same major version and, where the
+// major version is 0, the same minor version. Packages that have a model
+// that is incompatible with a supplied model are not selected.
+//
+// The package with the most models whose versions are equal to a supplied
+// model is selected, followed by that with the most compatible models. Where
+// packages are equally compatible, that which was registered first is
+// selected. An error is returned if no package has a model that is
+// compatible with a supplied model.
+func (r *ModelRegistry) Select(models []*gnmipb.ModelData) (*ModelSet, error) {
+	supported := map[string]string{}
+	for _, m := range models {
+		supported[m.GetName()] = m.GetVersion()
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var (
+		best                  *ModelSet
+		bestExact, bestCompat int
+	)
+	for _, s := range r.sets {
+		exact, compat, ok := modelSetCompatibility(s, supported)
+		if !ok || exact+compat == 0 {
+			continue
+		}
+		if best == nil || exact > bestExact || exact == bestExact && compat > bestCompat {
+			best, bestExact, bestCompat = s, exact, compat
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no registered model set is compatible with the supplied models")
+	}
+	return best, nil
+}
+
+// modelSetCompatibility returns the number of models within s whose version
+// is equal to, and compatible with, that of the model with the same name in
+// supported, which maps model name to version. The bool return value is
+// false if a model within s is incompatible with that in supported.
+func modelSetCompatibility(s *ModelSet, supported map[string]string) (int, int, bool) {
+	var exact, compat int
+	for _, m := range s.Models {
+		v, ok := supported[m.GetName()]
+		if !ok {
+			continue
+		}
+		switch {
+		case v == m.GetVersion():
+			exact++
+		case semverCompatible(v, m.GetVersion()):
+			compat++
+		default:
+			return 0, 0, false
+		}
+	}
+	return exact, compat, true
+}
+
+// semverCompatible reports whether a and b are semantic versions of the form
+// major.minor.patch that are compatible, as per the semantic versioning
+// specification.
+func semverCompatible(a, b string) bool {
+	av, aok := parseSemver(a)
+	bv, bok := parseSemver(b)
+	if !aok || !bok || av[0] != bv[0] {
+		return false
+	}
+	return av[0] != 0 || av[1] == bv[1]
+}
+
+// parseSemver parses the semantic version v, returning its major, minor and
+// patch versions. The bool return value indicates whether v is a valid
+// semantic version. Any pre-release or build metadata suffix is ignored.
+func parseSemver(v string) ([3]int, bool) {
+	var out [3]int
+	v, _, _ = strings.Cut(v, "+")
+	v, _, _ = strings.Cut(v, "-")
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return out, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return out, false
+		}
+		out[i] = n
+	}
+	return out, true
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// fakeVersionedSchema is a VersionedSchema used in tests of the
+// ModelRegistry.
+type fakeVersionedSchema struct{}
+
+func (*fakeVersionedSchema) NewRoot() GoStruct                                    { return &renderExample{} }
+func (*fakeVersionedSchema) Get(GoStruct, *gnmipb.Path) ([]any, error)            { return nil, nil }
+func (*fakeVersionedSchema) Set(GoStruct, *gnmipb.Path, *gnmipb.TypedValue) error { return nil }
+func (*fakeVersionedSchema) Delete(GoStruct, *gnmipb.Path) error                  { return nil }
+
+func TestModelRegistrySelect(t *testing.T) {
+	r := NewModelRegistry()
+	for _, s := range []struct {
+		name   string
+		models []*gnmipb.ModelData
+	}{
+		{"oc-2.0", []*gnmipb.ModelData{{Name: "openconfig-interfaces", Version: "2.0.0"}, {Name: "openconfig-system", Version: "0.10.0"}}},
+		{"oc-3.0", []*gnmipb.ModelData{{Name: "openconfig-interfaces", Version: "3.0.1"}, {Name: "openconfig-system", Version: "1.0.0"}}},
+		{"oc-3.1", []*gnmipb.ModelData{{Name: "openconfig-interfaces", Version: "3.1.0"}, {Name: "openconfig-system", Version: "1.1.0"}}},
+	} {
+		if err := r.Register(s.name, s.models, &fakeVersionedSchema{}); err != nil {
+			t.Fatalf("Register(%s): got unexpected error: %v", s.name, err)
+		}
+	}
+	if err := r.Register("oc-2.0", []*gnmipb.ModelData{{Name: "m"}}, &fakeVersionedSchema{}); err == nil {
+		t.Errorf("Register: did not get expected error for duplicate name")
+	}
+
+	tests := []struct {
+		desc             string
+		inModels         []*gnmipb.ModelData
+		want             string
+		wantErrSubstring string
+	}{{
+		desc:     "exact match",
+		inModels: []*gnmipb.ModelData{{Name: "openconfig-interfaces", Version: "3.0.1"}, {Name: "openconfig-system", Version: "1.0.0"}},
+		want:     "oc-3.0",
+	}, {
+		desc:     "exact match preferred over compatible",
+		inModels: []*gnmipb.ModelData{{Name: "openconfig-interfaces", Version: "3.1.0"}, {Name: "openconfig-system", Version: "1.0.5"}},
+		want:     "oc-3.1",
+	}, {
+		desc:     "compatible minor version, first registered selected",
+		inModels: []*gnmipb.ModelData{{Name: "openconfig-interfaces", Version: "3.2.0"}},
+		want:     "oc-3.0",
+	}, {
+		desc:     "major version 0 requires same minor version",
+		inModels: []*gnmipb.ModelData{{Name: "openconfig-interfaces", Version: "2.1.0"}, {Name: "openconfig-system", Version: "0.10.2"}},
+		want:     "oc-2.0",
+	}, {
+		desc:             "incompatible major version",
+		inModels:         []*gnmipb.ModelData{{Name: "openconfig-interfaces", Version: "4.0.0"}},
+		wantErrSubstring: "no registered model set is compatible",
+	}, {
+		desc:             "incompatible 0.x minor version",
+		inModels:         []*gnmipb.ModelData{{Name: "openconfig-system", Version: "0.11.0"}},
+		wantErrSubstring: "no registered model set is compatible",
+	}, {
+		desc:             "no shared models",
+		inModels:         []*gnmipb.ModelData{{Name: "openconfig-bgp", Version: "1.0.0"}},
+		wantErrSubstring: "no registered model set is compatible",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := r.SelectForCapabilities(&gnmipb.CapabilityResponse{SupportedModels: tt.inModels})
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("SelectForCapabilities: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if got.Name != tt.want {
+				t.Errorf("SelectForCapabilities: got model set %s, want %s", got.Name, tt.want)
+			}
+		})
+	}
+
+	got, err := r.SelectForVersions(map[string]string{"openconfig-system": "1.1.0"})
+	if err != nil {
+		t.Fatalf("SelectForVersions: got unexpected error: %v", err)
+	}
+	if got.Name != "oc-3.1" {
+		t.Errorf("SelectForVersions: got model set %s, want oc-3.1", got.Name)
+	}
+}
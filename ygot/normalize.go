@@ -0,0 +1,410 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"net/netip"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/internal/yreflect"
+	"github.com/openconfig/ygot/util"
+	"google.golang.org/protobuf/proto"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// NormalizerFunc returns the canonical form of the string value v of a leaf,
+// or an error if v is not valid. Values that the function does not apply to
+// should be returned unchanged.
+type NormalizerFunc func(v string) (string, error)
+
+// pathNormalizer is a NormalizerFunc that is registered for a path.
+type pathNormalizer struct {
+	// path is the path, which may contain wildcards, that fn applies to.
+	path *gnmipb.Path
+	// fn is the normalization function.
+	fn NormalizerFunc
+}
+
+// Normalizers is a registry of functions that canonicalize the values of
+// leaves, such that values which differ only in their representation, such
+// as upper and lower case MAC addresses, are stored and compared as the same
+// value. Each function is registered either for a YANG type, or for a path.
+// Only string values are normalized, including those of string members of
+// unions, and the elements of leaf-lists.
+//
+// Normalizers can be supplied as an option to Diff, in which case the leaves
+// of the compared GoStructs are normalized before they are compared, and to
+// the Unmarshal, UnmarshalSetRequest and SetNode functions of the ytypes
+// package, in which case the values that they store are normalized.
+//
+// Normalizers is safe for concurrent use.
+type Normalizers struct {
+	// root is the schema of the root of the data trees that are compared
+	// by Diff.
+	root *yang.Entry
+
+	// mu protects the registered functions.
+	mu sync.RWMutex
+	// byType is the set of functions registered for each YANG type name.
+	byType map[string][]NormalizerFunc
+	// byPath is the set of functions registered for paths, in the order
+	// in which they were registered.
+	byPath []*pathNormalizer
+}
+
+// NewNormalizers returns an empty set of Normalizers. root is the schema of
+// the root of the data trees that are compared by Diff, and is used to find
+// the types of the leaves that are compared. It may be nil, in which case
+// Diff applies only the functions that are registered for paths.
+func NewNormalizers(root *yang.Entry) *Normalizers {
+	return &Normalizers{root: root, byType: map[string][]NormalizerFunc{}}
+}
+
+// IsDiffOpt marks Normalizers as a valid Diff option.
+func (*Normalizers) IsDiffOpt() {}
+
+// IsUnmarshalOpt marks Normalizers as a valid ytypes.Unmarshal option.
+func (*Normalizers) IsUnmarshalOpt() {}
+
+// IsSetNodeOpt marks Normalizers as a valid ytypes.SetNode option.
+func (*Normalizers) IsSetNodeOpt() {}
+
+// RegisterType registers fn for the YANG type with the supplied name, which
+// is either the name of a typedef, e.g., "mac-address", or that of a
+// built-in type, e.g., "string", which applies to all types that are
+// derived from it. Where a leaf is a union, the functions registered for
+// each of its member types are applied.
+func (n *Normalizers) RegisterType(name string, fn NormalizerFunc) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.byType[name] = append(n.byType[name], fn)
+}
+
+// RegisterPath registers fn for the leaves at path, which is relative to the
+// root of the data tree and may contain wildcards. Functions registered for
+// paths are applied after those registered for types.
+func (n *Normalizers) RegisterPath(path *gnmipb.Path, fn NormalizerFunc) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.byPath = append(n.byPath, &pathNormalizer{path: proto.Clone(path).(*gnmipb.Path), fn: fn})
+}
+
+// funcs returns the functions that apply to the leaf with schema e, which
+// may be nil, at path.
+func (n *Normalizers) funcs(e *yang.Entry, path *gnmipb.Path) []NormalizerFunc {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	var fns []NormalizerFunc
+	if e != nil {
+		if r, err := util.ResolveIfLeafRef(e); err == nil && r != nil {
+			e = r
+		}
+	}
+	if e != nil && e.Type != nil {
+		types := []*yang.YangType{e.Type}
+		if util.IsUnionType(e.Type) {
+			types = append(types, util.FlattenedTypes(e.Type.Type)...)
+		}
+		seen := map[string]bool{}
+		for _, t := range types {
+			for _, name := range []string{t.Kind.String(), t.Name} {
+				if !seen[name] {
+					seen[name] = true
+					fns = append(fns, n.byType[name]...)
+				}
+			}
+		}
+	}
+	for _, pn := range n.byPath {
+		if len(pn.path.GetElem()) == len(path.GetElem()) && util.PathMatchesQuery(path, pn.path) {
+			fns = append(fns, pn.fn)
+		}
+	}
+	return fns
+}
+
+// NormalizeString returns the canonical form of the value v of the leaf with
+// schema e, which may be nil, at path.
+func (n *Normalizers) NormalizeString(e *yang.Entry, path *gnmipb.Path, v string) (string, error) {
+	for _, fn := range n.funcs(e, path) {
+		var err error
+		if v, err = fn(v); err != nil {
+			return "", err
+		}
+	}
+	return v, nil
+}
+
+// NormalizeTypedValue returns the TypedValue v of the leaf at path, which
+// is relative to the data tree whose root has the schema root, with its
+// string values normalized. v is not modified. Values that are not scalar,
+// and the values of list keys, are returned unchanged.
+func (n *Normalizers) NormalizeTypedValue(root *yang.Entry, path *gnmipb.Path, v *gnmipb.TypedValue) (*gnmipb.TypedValue, error) {
+	if elems := path.GetElem(); len(elems) != 0 {
+		for i := len(elems) - 2; i >= 0; i-- {
+			if keys := elems[i].GetKey(); len(keys) != 0 {
+				if _, isKey := keys[elems[len(elems)-1].GetName()]; isKey {
+					return v, nil
+				}
+				break
+			}
+		}
+	}
+	e := schemaAtPath(root, path)
+	switch tv := v.GetValue().(type) {
+	case *gnmipb.TypedValue_StringVal:
+		s, err := n.NormalizeString(e, path, tv.StringVal)
+		if err != nil {
+			return nil, err
+		}
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: s}}, nil
+	case *gnmipb.TypedValue_LeaflistVal:
+		nv := proto.Clone(v).(*gnmipb.TypedValue)
+		for _, el := range nv.GetLeaflistVal().GetElement() {
+			if sv, ok := el.GetValue().(*gnmipb.TypedValue_StringVal); ok {
+				s, err := n.NormalizeString(e, path, sv.StringVal)
+				if err != nil {
+					return nil, err
+				}
+				sv.StringVal = s
+			}
+		}
+		return nv, nil
+	}
+	return v, nil
+}
+
+// schemaAtPath returns the schema of the node at path, relative to the node
+// with schema root, or nil if it is not found.
+func schemaAtPath(root *yang.Entry, path *gnmipb.Path) *yang.Entry {
+	e := root
+	for _, pe := range path.GetElem() {
+		if e == nil {
+			return nil
+		}
+		name := pe.GetName()
+		if i := strings.Index(name, ":"); i != -1 {
+			name = name[i+1:]
+		}
+		next := e.Dir[name]
+		if next == nil || util.IsChoiceOrCase(next) {
+			next = nil
+			for _, ch := range util.FindFirstNonChoiceOrCase(e) {
+				if ch.Name == name {
+					next = ch
+					break
+				}
+			}
+		}
+		e = next
+	}
+	return e
+}
+
+// Normalize normalizes the values of the leaves of s, whose schema is
+// schema, in place. schema may be nil, in which case only the functions
+// registered for paths are applied. Paths are relative to s. The keys of
+// list entries are not normalized, since this would require the entries to
+// be moved within their list.
+func (n *Normalizers) Normalize(schema *yang.Entry, s GoStruct) error {
+	v := reflect.ValueOf(s)
+	if !util.IsValueStructPtr(v) {
+		return fmt.Errorf("%T is not a struct pointer", s)
+	}
+	return n.normalizeStruct(v, schema, &gnmipb.Path{}, nil)
+}
+
+// normalizeStruct normalizes the leaves of the struct pointer v, with
+// schema e, at path. keys is the set of names of the list keys of v, where
+// it is a list entry.
+func (n *Normalizers) normalizeStruct(v reflect.Value, e *yang.Entry, path *gnmipb.Path, keys map[string]interface{}) error {
+	if v.IsNil() {
+		return nil
+	}
+	sv := v.Elem()
+	var errs util.Errors
+	for i := 0; i < sv.NumField(); i++ {
+		ft, fv := sv.Type().Field(i), sv.Field(i)
+		if util.IsYgotAnnotation(ft) || util.IsYgotPresence(ft) || util.IsYgotExtensions(ft) || fv.IsZero() {
+			continue
+		}
+		// Leaves whose presence is recorded in the presence bitmap are
+		// normalized in place, if they are set.
+		if _, ok := util.PresenceBit(ft); ok && !util.IsLeafSet(sv, ft) {
+			continue
+		}
+		sp, err := util.SchemaPaths(ft)
+		if err != nil {
+			errs = util.AppendErr(errs, err)
+			continue
+		}
+		elems := leastSpecificPath(sp)
+		fpath := proto.Clone(path).(*gnmipb.Path)
+		for _, el := range elems {
+			fpath.Elem = append(fpath.Elem, &gnmipb.PathElem{Name: el})
+		}
+		var fe *yang.Entry
+		if e != nil {
+			fe, _ = util.ChildSchema(e, ft)
+		}
+
+		if om, ok := fv.Interface().(GoOrderedMap); ok {
+			errs = util.AppendErr(errs, yreflect.RangeOrderedMap(om, func(k, ev reflect.Value) bool {
+				errs = util.AppendErr(errs, n.normalizeEntry(k, ev, fe, fpath))
+				return true
+			}))
+			continue
+		}
+		switch {
+		case fv.Kind() == reflect.Map:
+			for _, k := range fv.MapKeys() {
+				errs = util.AppendErr(errs, n.normalizeEntry(k, fv.MapIndex(k), fe, fpath))
+			}
+		case util.IsValueStructPtr(fv):
+			errs = util.AppendErr(errs, n.normalizeStruct(fv, fe, fpath, nil))
+		default:
+			if _, isKey := keys[elems[len(elems)-1]]; isKey {
+				continue
+			}
+			errs = util.AppendErr(errs, n.normalizeLeaf(fv, fe, fpath))
+		}
+	}
+	for _, ext := range util.AttachedExtensions(v) {
+		errs = util.AppendErr(errs, n.normalizeStruct(ext.Value, ext.Schema, path, nil))
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// normalizeEntry normalizes the leaves of the list entry ev, whose map key
+// is k, of the list with schema e at path.
+func (n *Normalizers) normalizeEntry(k, ev reflect.Value, e *yang.Entry, path *gnmipb.Path) error {
+	epath, err := merge3EntryPath(path, k, ev)
+	if err != nil {
+		return err
+	}
+	var keys map[string]interface{}
+	if kh, ok := ev.Interface().(KeyHelperGoStruct); ok {
+		if keys, err = kh.ΛListKeyMap(); err != nil {
+			return err
+		}
+	}
+	return n.normalizeStruct(ev, e, epath, keys)
+}
+
+// normalizeLeaf normalizes the value of the leaf or leaf-list field v, with
+// schema e, at path.
+func (n *Normalizers) normalizeLeaf(v reflect.Value, e *yang.Entry, path *gnmipb.Path) error {
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			// Binary values are not normalized.
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := n.normalizeLeaf(v.Index(i), e, path); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		ev := v.Elem()
+		if ev.Kind() != reflect.String {
+			return nil
+		}
+		s, err := n.NormalizeString(e, path, ev.String())
+		if err != nil {
+			return fmt.Errorf("cannot normalize value of %v: %v", path, err)
+		}
+		if s == ev.String() {
+			return nil
+		}
+		if v.Kind() == reflect.Ptr {
+			nv := reflect.New(ev.Type())
+			nv.Elem().SetString(s)
+			v.Set(nv)
+			return nil
+		}
+		v.Set(reflect.ValueOf(s).Convert(ev.Type()))
+		return nil
+	case reflect.String:
+		s, err := n.NormalizeString(e, path, v.String())
+		if err != nil {
+			return fmt.Errorf("cannot normalize value of %v: %v", path, err)
+		}
+		v.SetString(s)
+	}
+	return nil
+}
+
+// normalizedCopy returns a copy of s, which is the root of a data tree, in
+// which the values of the leaves are normalized.
+func (n *Normalizers) normalizedCopy(s GoStruct) (GoStruct, error) {
+	c, err := DeepCopy(s)
+	if err != nil {
+		return nil, err
+	}
+	if err := n.Normalize(n.root, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// hasNormalizers returns the first Normalizers within opts, or nil if there
+// is none.
+func hasNormalizers(opts []DiffOpt) *Normalizers {
+	for _, o := range opts {
+		if v, ok := o.(*Normalizers); ok && v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+// LowerCaseNormalizer is a NormalizerFunc that returns v in lower case, as is
+// canonical for values such as MAC addresses.
+func LowerCaseNormalizer(v string) (string, error) {
+	return strings.ToLower(v), nil
+}
+
+// TrimSpaceNormalizer is a NormalizerFunc that removes leading and trailing
+// white space from v.
+func TrimSpaceNormalizer(v string) (string, error) {
+	return strings.TrimSpace(v), nil
+}
+
+// IPAddressNormalizer is a NormalizerFunc that returns the canonical form
+// of an IP address or prefix, as per RFC5952 for IPv6, such that IPv6
+// addresses are compressed and in lower case. Values that are not IP
+// addresses or prefixes are returned unchanged.
+func IPAddressNormalizer(v string) (string, error) {
+	if a, err := netip.ParseAddr(v); err == nil {
+		return a.String(), nil
+	}
+	if p, err := netip.ParsePrefix(v); err == nil {
+		return p.String(), nil
+	}
+	return v, nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot_test
+
+import (
+	"testing"
+
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func newNormalizers(t *testing.T) *ygot.Normalizers {
+	n := ygot.NewNormalizers(exampleoc.SchemaTree["Device"])
+	n.RegisterType("mac-address", ygot.LowerCaseNormalizer)
+	n.RegisterPath(mustPath("/interfaces/interface[name=*]/config/description"), ygot.TrimSpaceNormalizer)
+	return n
+}
+
+func TestNormalizersDiff(t *testing.T) {
+	newDevice := func(mac, desc string) *exampleoc.Device {
+		d := &exampleoc.Device{}
+		i := d.GetOrCreateInterface("eth0")
+		i.Description = ygot.String(desc)
+		i.GetOrCreateEthernet().MacAddress = ygot.String(mac)
+		return d
+	}
+	a := newDevice("AA:BB:CC:DD:EE:FF", " uplink ")
+	b := newDevice("aa:bb:cc:dd:ee:ff", "uplink")
+
+	n, err := ygot.Diff(a, b)
+	if err != nil {
+		t.Fatalf("Diff: got unexpected error: %v", err)
+	}
+	if got := len(n.GetUpdate()); got != 2 {
+		t.Errorf("Diff without Normalizers: got %d updates, want 2:\n%s", got, ygot.FormatDiff(n))
+	}
+
+	if n, err = ygot.Diff(a, b, newNormalizers(t)); err != nil {
+		t.Fatalf("Diff: got unexpected error: %v", err)
+	}
+	if len(n.GetUpdate())+len(n.GetDelete()) != 0 {
+		t.Errorf("Diff with Normalizers: got diff, want none:\n%s", ygot.FormatDiff(n))
+	}
+	if got := a.GetInterface("eth0").GetEthernet().GetMacAddress(); got != "AA:BB:CC:DD:EE:FF" {
+		t.Errorf("Diff with Normalizers modified its input, got MAC address %s", got)
+	}
+
+	c := newDevice("aa:bb:cc:dd:ee:00", "uplink")
+	if n, err = ygot.Diff(a, c, newNormalizers(t)); err != nil {
+		t.Fatalf("Diff: got unexpected error: %v", err)
+	}
+	if got := len(n.GetUpdate()); got != 1 {
+		t.Errorf("Diff with Normalizers of different values: got %d updates, want 1:\n%s", got, ygot.FormatDiff(n))
+	}
+}
+
+func TestNormalizersUnmarshalAndSet(t *testing.T) {
+	d := &exampleoc.Device{}
+	if err := exampleoc.Unmarshal([]byte(`{
+  "openconfig-interfaces:interfaces": {
+    "interface": [{
+      "name": "eth0",
+      "config": {"name": "eth0", "description": "  uplink\n"},
+      "openconfig-if-ethernet:ethernet": {"config": {"mac-address": "AA:BB:CC:DD:EE:FF"}}
+    }]
+  }
+}`), d, newNormalizers(t)); err != nil {
+		t.Fatalf("Unmarshal: got unexpected error: %v", err)
+	}
+	i := d.GetInterface("eth0")
+	if got, want := i.GetDescription(), "uplink"; got != want {
+		t.Errorf("Unmarshal: got description %q, want %q", got, want)
+	}
+	if got, want := i.GetEthernet().GetMacAddress(), "aa:bb:cc:dd:ee:ff"; got != want {
+		t.Errorf("Unmarshal: got MAC address %q, want %q", got, want)
+	}
+
+	mac := mustPath("/interfaces/interface[name=eth1]/ethernet/config/mac-address")
+	val := &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "00:11:22:AA:BB:CC"}}
+	if err := ytypes.SetNode(exampleoc.SchemaTree["Device"], d, mac, val, &ytypes.InitMissingElements{}, newNormalizers(t)); err != nil {
+		t.Fatalf("SetNode: got unexpected error: %v", err)
+	}
+	if got, want := d.GetInterface("eth1").GetEthernet().GetMacAddress(), "00:11:22:aa:bb:cc"; got != want {
+		t.Errorf("SetNode: got MAC address %q, want %q", got, want)
+	}
+	if got := val.GetStringVal(); got != "00:11:22:AA:BB:CC" {
+		t.Errorf("SetNode modified its input value, got %q", got)
+	}
+}
+
+func TestIPAddressNormalizer(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"2001:0DB8:0000:0000::0001", "2001:db8::1"},
+		{"2001:DB8::/32", "2001:db8::/32"},
+		{"192.0.2.1", "192.0.2.1"},
+		{"not-an-address", "not-an-address"},
+	}
+	for _, tt := range tests {
+		got, err := ygot.IPAddressNormalizer(tt.in)
+		if err != nil {
+			t.Errorf("IPAddressNormalizer(%q): got unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("IPAddressNormalizer(%q): got %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
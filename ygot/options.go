@@ -0,0 +1,128 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+// EmitJSONOpt is an interface implemented by options to the EmitJSONWithOpts
+// function.
+type EmitJSONOpt interface {
+	// IsEmitJSONOpt is a marker method for each EmitJSONOpt.
+	IsEmitJSONOpt()
+}
+
+// IsEmitJSONOpt marks JSONFormat as a valid EmitJSONWithOpts option,
+// specifying the format of the JSON that is output.
+func (JSONFormat) IsEmitJSONOpt() {}
+
+// IsEmitJSONOpt marks JSONIndent as a valid EmitJSONWithOpts option,
+// specifying the string used for indentation within the JSON output.
+func (JSONIndent) IsEmitJSONOpt() {}
+
+// IsEmitJSONOpt marks RFC7951JSONConfig as a valid EmitJSONWithOpts option.
+// Supplying the option implies that RFC7951 JSON is output.
+func (*RFC7951JSONConfig) IsEmitJSONOpt() {}
+
+// EscapeHTML is an EmitJSONOpt that specifies that characters are escaped in
+// the marshalled JSON for safety in HTML embedding.
+type EscapeHTML struct{}
+
+// IsEmitJSONOpt marks EscapeHTML as a valid EmitJSONWithOpts option.
+func (*EscapeHTML) IsEmitJSONOpt() {}
+
+// SkipValidation is an EmitJSONOpt that specifies that the GoStruct supplied
+// should not be validated before emitting its content.
+type SkipValidation struct{}
+
+// IsEmitJSONOpt marks SkipValidation as a valid EmitJSONWithOpts option.
+func (*SkipValidation) IsEmitJSONOpt() {}
+
+// EmitValidationOpts is an EmitJSONOpt that specifies the options that are
+// used when validating the GoStruct supplied before emitting its content.
+type EmitValidationOpts []ValidationOption
+
+// IsEmitJSONOpt marks EmitValidationOpts as a valid EmitJSONWithOpts option.
+func (EmitValidationOpts) IsEmitJSONOpt() {}
+
+// PreferShadowPath specifies that the "shadow-path" struct tag of a GoStruct
+// field is used instead of its "path" tag, whenever the former is present.
+// It is shared by the functions of the ygot package that are affected by the
+// paths of fields, and can be supplied as an option to:
+//   - EmitJSONWithOpts, where it implies that RFC7951 JSON is output.
+//   - Marshal7951.
+//   - Diff and DiffWithAtomic.
+//
+// The functions of the ytypes package accept the equivalent
+// ytypes.PreferShadowPath option.
+type PreferShadowPath struct{}
+
+// IsEmitJSONOpt marks PreferShadowPath as a valid EmitJSONWithOpts option.
+func (*PreferShadowPath) IsEmitJSONOpt() {}
+
+// IsMarshal7951Arg marks PreferShadowPath as a valid Marshal7951 argument.
+func (*PreferShadowPath) IsMarshal7951Arg() {}
+
+// IsDiffOpt marks PreferShadowPath as a valid Diff option.
+func (*PreferShadowPath) IsDiffOpt() {}
+
+// NewEmitJSONConfig returns the EmitJSONConfig that corresponds to the
+// supplied options. Where an option is supplied more than once, the last
+// instance takes precedence.
+func NewEmitJSONConfig(opts ...EmitJSONOpt) *EmitJSONConfig {
+	c := &EmitJSONConfig{}
+	var preferShadowPath bool
+	for _, o := range opts {
+		switch v := o.(type) {
+		case JSONFormat:
+			c.Format = v
+		case JSONIndent:
+			c.Indent = string(v)
+		case *RFC7951JSONConfig:
+			c.Format = RFC7951
+			c.RFC7951Config = v
+		case *EscapeHTML:
+			c.EscapeHTML = true
+		case *SkipValidation:
+			c.SkipValidation = true
+		case EmitValidationOpts:
+			c.ValidationOpts = v
+		case *PreferShadowPath:
+			preferShadowPath = true
+		case *WithDefaults:
+			c.WithDefaults = v.Mode
+			c.WithDefaultsSchema = v.Schema
+		case *Redactor:
+			c.Redactor = v
+		}
+	}
+	if preferShadowPath {
+		// The supplied RFC7951JSONConfig is copied such that the caller's
+		// instance is not modified.
+		rc := &RFC7951JSONConfig{}
+		if c.RFC7951Config != nil {
+			*rc = *c.RFC7951Config
+		}
+		rc.PreferShadowPath = true
+		c.Format = RFC7951
+		c.RFC7951Config = rc
+	}
+	return c
+}
+
+// EmitJSONWithOpts takes an input GoStruct (produced by ygen with validation
+// enabled) and serialises it to a JSON string. By default, the GoStruct is
+// validated, and Internal format JSON is produced. The supplied options
+// control how the JSON is created.
+func EmitJSONWithOpts(gs GoStruct, opts ...EmitJSONOpt) (string, error) {
+	return emitJSON(gs, NewEmitJSONConfig(opts...))
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewEmitJSONConfig(t *testing.T) {
+	rfcCfg := &RFC7951JSONConfig{AppendModuleName: true}
+
+	tests := []struct {
+		desc   string
+		inOpts []EmitJSONOpt
+		want   *EmitJSONConfig
+	}{{
+		desc: "no options",
+		want: &EmitJSONConfig{},
+	}, {
+		desc:   "format, indent and validation options",
+		inOpts: []EmitJSONOpt{RFC7951, JSONIndent("  "), &EscapeHTML{}, &SkipValidation{}},
+		want: &EmitJSONConfig{
+			Format:         RFC7951,
+			Indent:         "  ",
+			EscapeHTML:     true,
+			SkipValidation: true,
+		},
+	}, {
+		desc:   "RFC7951 config implies format",
+		inOpts: []EmitJSONOpt{rfcCfg},
+		want: &EmitJSONConfig{
+			Format:        RFC7951,
+			RFC7951Config: &RFC7951JSONConfig{AppendModuleName: true},
+		},
+	}, {
+		desc:   "prefer shadow path with RFC7951 config",
+		inOpts: []EmitJSONOpt{&PreferShadowPath{}, rfcCfg},
+		want: &EmitJSONConfig{
+			Format:        RFC7951,
+			RFC7951Config: &RFC7951JSONConfig{AppendModuleName: true, PreferShadowPath: true},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, NewEmitJSONConfig(tt.inOpts...)); diff != "" {
+				t.Errorf("NewEmitJSONConfig: did not get expected config (-want, +got):\n%s", diff)
+			}
+		})
+	}
+
+	if rfcCfg.PreferShadowPath {
+		t.Errorf("NewEmitJSONConfig: supplied RFC7951JSONConfig was modified")
+	}
+}
+
+func TestPreferShadowPathOption(t *testing.T) {
+	in := &renderExample{Str: String("test-string")}
+	want := `{
+  "srt": "test-string"
+}`
+
+	got, err := EmitJSONWithOpts(in, &SkipValidation{}, &PreferShadowPath{}, JSONIndent("  "))
+	if err != nil {
+		t.Fatalf("EmitJSONWithOpts: got unexpected error: %v", err)
+	}
+	if got != want {
+		t.Errorf("EmitJSONWithOpts: did not get expected JSON, got: %s, want: %s", got, want)
+	}
+
+	b, err := Marshal7951(in, &PreferShadowPath{}, JSONIndent("  "))
+	if err != nil {
+		t.Fatalf("Marshal7951: got unexpected error: %v", err)
+	}
+	if string(b) != want {
+		t.Errorf("Marshal7951: did not get expected JSON, got: %s, want: %s", b, want)
+	}
+}
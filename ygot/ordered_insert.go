@@ -0,0 +1,254 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/openconfig/gnmi/errlist"
+	"github.com/openconfig/ygot/internal/yreflect"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// InsertPosition is the value of the YANG insert attribute, as defined in
+// RFC 7950 Section 7.8.6, which specifies where an entry of an
+// `ordered-by user` list is placed when it is created or moved.
+type InsertPosition int64
+
+const (
+	// InsertFirst specifies that the entry is placed first in the list.
+	InsertFirst InsertPosition = iota
+	// InsertLast specifies that the entry is placed last in the list.
+	InsertLast
+	// InsertBefore specifies that the entry is placed immediately before
+	// the entry specified by the point of the insert.
+	InsertBefore
+	// InsertAfter specifies that the entry is placed immediately after
+	// the entry specified by the point of the insert.
+	InsertAfter
+)
+
+// String returns the value of the insert attribute, or the RESTCONF insert
+// query parameter, corresponding to p.
+func (p InsertPosition) String() string {
+	switch p {
+	case InsertFirst:
+		return "first"
+	case InsertLast:
+		return "last"
+	case InsertBefore:
+		return "before"
+	case InsertAfter:
+		return "after"
+	}
+	return fmt.Sprintf("InsertPosition(%d)", int64(p))
+}
+
+// ListInsert describes the placement of an entry of an `ordered-by user`
+// list that is created or moved, such that it can be expressed using the
+// YANG insert attribute of a NETCONF edit-config, or the insert and point
+// query parameters of a RESTCONF request.
+type ListInsert struct {
+	// Path is the path of the list entry, including its keys.
+	Path *gnmipb.Path
+	// Position is the position at which the entry is placed.
+	Position InsertPosition
+	// Point is the path of the existing entry that the entry is placed
+	// relative to. It is set only when Position is InsertBefore or
+	// InsertAfter.
+	Point *gnmipb.Path
+}
+
+// DiffOrderedListInserts takes an original and modified GoStruct, which must
+// be of the same type, and returns the inserts that place the entries of
+// each `ordered-by user` list in the order that they have in modified.
+//
+// Entries that keep their position relative to one another are not
+// included, such that reordering a list results in inserts that move only
+// the entries whose relative order changed, rather than the deletion and
+// recreation of the list. Each entry that is created in modified is
+// included, such that its position is specified when it is created. The
+// inserts must be applied in the order that they are returned, after the
+// deletions of entries that are removed in modified, as reported by Diff.
+//
+// Nested `ordered-by user` lists, which are not supported by
+// DiffWithAtomic, are not examined.
+func DiffOrderedListInserts(original, modified GoStruct, opts ...DiffOpt) ([]*ListInsert, error) {
+	if reflect.TypeOf(original) != reflect.TypeOf(modified) {
+		return nil, fmt.Errorf("cannot diff structs of different types, original: %T, modified: %T", original, modified)
+	}
+	origLists, err := orderedListEntries(original, opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not extract ordered lists from original struct: %v", err)
+	}
+	modLists, err := orderedListEntries(modified, opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not extract ordered lists from modified struct: %v", err)
+	}
+
+	// Lists are processed in order of their paths such that the output is
+	// deterministic.
+	var listPaths []string
+	for p := range modLists {
+		listPaths = append(listPaths, p)
+	}
+	sort.Strings(listPaths)
+
+	var inserts []*ListInsert
+	for _, p := range listPaths {
+		inserts = append(inserts, listInserts(origLists[p], modLists[p])...)
+	}
+	return inserts, nil
+}
+
+// orderedListEntry is an entry of an `ordered-by user` list.
+type orderedListEntry struct {
+	// key is the string form of path, which uniquely identifies the entry.
+	key  string
+	path *gnmipb.Path
+}
+
+// orderedListEntries returns the entries of each non-empty `ordered-by user`
+// list within s, in the order of the list, keyed by the string path of the
+// list.
+func orderedListEntries(s GoStruct, opts []DiffOpt) (map[string][]*orderedListEntry, error) {
+	leaves, err := findSetLeaves(s, true, opts...)
+	if err != nil {
+		return nil, err
+	}
+	leavesStr, err := toStringPathMap(leaves)
+	if err != nil {
+		return nil, err
+	}
+
+	lists := map[string][]*orderedListEntry{}
+	for p, l := range leavesStr {
+		orderedMap, ok := l.val.(GoOrderedMap)
+		if !ok {
+			continue
+		}
+		parent := newPathElemGNMIPath(l.path.GetElem())
+		var errs errlist.List
+		if err := yreflect.RangeOrderedMap(orderedMap, func(k reflect.Value, v reflect.Value) bool {
+			childPath, err := mapValuePath(k, v, parent)
+			if err != nil {
+				errs.Add(err)
+				return false
+			}
+			ep, err := childPath.ToProto()
+			if err != nil {
+				errs.Add(err)
+				return false
+			}
+			ep.Origin = l.path.GetOrigin()
+			key, err := PathToString(ep)
+			if err != nil {
+				errs.Add(err)
+				return false
+			}
+			lists[p] = append(lists[p], &orderedListEntry{key: key, path: ep})
+			return true
+		}); err != nil {
+			errs.Add(err)
+		}
+		if err := errs.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return lists, nil
+}
+
+// listInserts returns the inserts that order the entries of a list as they
+// are in mod, given that they are in the order orig before the inserts are
+// applied. The entries of orig that are not within mod are assumed to have
+// been deleted.
+//
+// The entries that are common to orig and mod, and whose relative order is
+// unchanged, are determined by finding the longest increasing subsequence
+// of the indices within orig of the entries of mod. All other entries of mod
+// are inserted, in the order of mod, after the entry that precedes them.
+func listInserts(orig, mod []*orderedListEntry) []*ListInsert {
+	origIndex := map[string]int{}
+	for i, e := range orig {
+		origIndex[e.key] = i
+	}
+
+	// common holds the indices within mod of the entries that are also
+	// within orig.
+	var common []int
+	for i, e := range mod {
+		if _, ok := origIndex[e.key]; ok {
+			common = append(common, i)
+		}
+	}
+	stable := map[int]bool{}
+	for _, i := range longestIncreasingSubsequence(common, func(i int) int { return origIndex[mod[i].key] }) {
+		stable[i] = true
+	}
+
+	var inserts []*ListInsert
+	for i, e := range mod {
+		if stable[i] {
+			continue
+		}
+		ins := &ListInsert{Path: e.path}
+		switch {
+		case i == 0:
+			ins.Position = InsertFirst
+		case i == len(mod)-1:
+			ins.Position = InsertLast
+		default:
+			ins.Position = InsertAfter
+			ins.Point = mod[i-1].path
+		}
+		inserts = append(inserts, ins)
+	}
+	return inserts
+}
+
+// longestIncreasingSubsequence returns the longest subsequence of s for
+// which the values returned by val are strictly increasing.
+func longestIncreasingSubsequence(s []int, val func(int) int) []int {
+	if len(s) == 0 {
+		return nil
+	}
+	// tails[l] is the index within s of the smallest final element of an
+	// increasing subsequence of length l+1, and prev links each element to
+	// its predecessor within such a subsequence.
+	var tails []int
+	prev := make([]int, len(s))
+	for i, e := range s {
+		l := sort.Search(len(tails), func(j int) bool { return val(s[tails[j]]) >= val(e) })
+		if l > 0 {
+			prev[i] = tails[l-1]
+		} else {
+			prev[i] = -1
+		}
+		if l == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[l] = i
+		}
+	}
+
+	lis := make([]int, len(tails))
+	for i, j := len(tails)-1, tails[len(tails)-1]; i >= 0; i, j = i-1, prev[j] {
+		lis[i] = s[j]
+	}
+	return lis
+}
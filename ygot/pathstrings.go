@@ -46,6 +46,126 @@ func PathToString(path *gnmipb.Path) (string, error) {
 	return "/" + stdpath.Join(s...), err
 }
 
+// PathStringOpt is an interface implemented by options to the
+// PathToStringWithOpts and PathToStringsWithOpts functions.
+type PathStringOpt interface {
+	// IsPathStringOpt is a marker method for each PathStringOpt.
+	IsPathStringOpt()
+}
+
+// QualifyModuleNames is a PathStringOpt that specifies that element names
+// are rendered qualified by the name of the YANG module that defines them,
+// in the form "module:name". As per RFC7951, an element is only qualified
+// where its module differs from that of its parent, and the first element
+// is always qualified.
+type QualifyModuleNames struct {
+	// Module returns the name of the module defining the element at index
+	// i of elems. Where it returns the empty string, the element is not
+	// qualified, and is treated as being defined in the same module as its
+	// parent.
+	Module func(elems []*gnmipb.PathElem, i int) string
+	// All specifies that every element is qualified, rather than only those
+	// at which the module changes.
+	All bool
+}
+
+// IsPathStringOpt marks QualifyModuleNames as a valid PathStringOpt.
+func (*QualifyModuleNames) IsPathStringOpt() {}
+
+// EscapeKeyValues is a PathStringOpt that specifies that all characters
+// within unquoted key values that are significant to the path parser are
+// escaped, such that the output can be parsed by StringToPath to recover
+// the original values. In addition to the "=" and "]" characters that are
+// escaped by default, backslash and "/" characters are escaped, along with a
+// leading quote character, which would otherwise be parsed as the start of
+// a quoted value.
+type EscapeKeyValues struct{}
+
+// IsPathStringOpt marks EscapeKeyValues as a valid PathStringOpt.
+func (*EscapeKeyValues) IsPathStringOpt() {}
+
+// QuoteKeyValues is a PathStringOpt that specifies that key values are
+// rendered within double quotes, using the quoting scheme accepted by
+// StringToPath, where backslash and double quote characters within the value
+// are escaped.
+// Quoted output can always be parsed back to the original values.
+type QuoteKeyValues struct {
+	// Always specifies that every value is quoted. When false, only those
+	// values that could not otherwise be parsed back unchanged, or that
+	// contain a "/" character, are quoted.
+	Always bool
+}
+
+// IsPathStringOpt marks QuoteKeyValues as a valid PathStringOpt.
+func (*QuoteKeyValues) IsPathStringOpt() {}
+
+// pathStringConfig is the resolved set of PathStringOpt supplied to
+// PathToStringsWithOpts.
+type pathStringConfig struct {
+	qualify *QualifyModuleNames
+	escape  bool
+	quote   *QuoteKeyValues
+}
+
+// PathToStringWithOpts is like PathToString, but renders the path according
+// to the supplied options. The options only affect paths using the 'elem'
+// field, pre-0.4.0 "element"-based paths are returned unchanged.
+func PathToStringWithOpts(path *gnmipb.Path, opts ...PathStringOpt) (string, error) {
+	s, err := PathToStringsWithOpts(path, opts...)
+	return "/" + strings.Join(s, "/"), err
+}
+
+// PathToStringsWithOpts is like PathToStrings, but renders each element of
+// the path according to the supplied options.
+func PathToStringsWithOpts(path *gnmipb.Path, opts ...PathStringOpt) ([]string, error) {
+	if path == nil {
+		return nil, fmt.Errorf("received nil path in PathToStringsWithOpts")
+	}
+
+	//lint:ignore SA1019 Specifically handling deprecated gNMI Element fields.
+	if path.Element != nil {
+		//lint:ignore SA1019 Specifically handling deprecated gNMI Element fields.
+		return elementsToString(path.Element)
+	}
+
+	cfg := &pathStringConfig{}
+	for _, o := range opts {
+		switch o := o.(type) {
+		case *QualifyModuleNames:
+			cfg.qualify = o
+		case *EscapeKeyValues:
+			cfg.escape = true
+		case *QuoteKeyValues:
+			cfg.quote = o
+		}
+	}
+
+	var p []string
+	var parentMod string
+	for i, e := range path.Elem {
+		if e.Name == "" {
+			return nil, util.KindErrorf(util.ErrInvalidPath, "empty name for PathElem at index %d", i)
+		}
+
+		name := e.Name
+		if cfg.qualify != nil && cfg.qualify.Module != nil && !strings.Contains(name, ":") {
+			if mod := cfg.qualify.Module(path.Elem, i); mod != "" {
+				if mod != parentMod || cfg.qualify.All {
+					name = mod + ":" + name
+				}
+				parentMod = mod
+			}
+		}
+
+		elem, err := formatElem(name, e.Key, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed formatting PathElem at index %d: %v", i, err)
+		}
+		p = append(p, elem)
+	}
+	return p, nil
+}
+
 // PathToSchemaPath returns the supplied Path as its corresponding schema path.
 // The YANG schema path removes any keys (i.e., predicates) from the path, using
 // only the name.
@@ -72,7 +192,7 @@ func PathToSchemaPath(path *gnmipb.Path) (string, error) {
 	var p []string
 	for i, e := range path.Elem {
 		if e.Name == "" {
-			return "", fmt.Errorf("empty name for PathElem at index %d", i)
+			return "", util.KindErrorf(util.ErrInvalidPath, "empty name for PathElem at index %d", i)
 		}
 		p = append(p, e.Name)
 	}
@@ -100,7 +220,7 @@ func PathToStrings(path *gnmipb.Path) ([]string, error) {
 	var p []string
 	for i, e := range path.Elem {
 		if e.Name == "" {
-			return nil, fmt.Errorf("empty name for PathElem at index %d", i)
+			return nil, util.KindErrorf(util.ErrInvalidPath, "empty name for PathElem at index %d", i)
 		}
 
 		elem, err := elemToString(e.Name, e.Key)
@@ -153,6 +273,68 @@ func elemToString(name string, kv map[string]string) (string, error) {
 	return name, nil
 }
 
+// formatElem returns a formatted string representation of a single Path.Elem
+// item, rendering its key values according to cfg. Where no key value
+// options are specified, the output is identical to that of elemToString.
+func formatElem(name string, kv map[string]string, cfg *pathStringConfig) (string, error) {
+	if !cfg.escape && cfg.quote == nil {
+		return elemToString(name, kv)
+	}
+	if name == "" {
+		return "", errors.New("empty name for PathElem")
+	}
+
+	var keys []string
+	for k, v := range kv {
+		if k == "" {
+			return "", fmt.Errorf("empty key name (value: %s) in element %s", v, name)
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(name)
+	for _, k := range keys {
+		v := kv[k]
+		switch {
+		case cfg.quote != nil && (cfg.quote.Always || needsQuoting(v)):
+			v = `"` + escapeChars(v, `\"`) + `"`
+		case cfg.escape:
+			v = escapeChars(v, `\=]/`)
+			if strings.HasPrefix(v, `"`) || strings.HasPrefix(v, `'`) {
+				v = `\` + v
+			}
+		default:
+			v = escapeChars(v, `=]`)
+		}
+		fmt.Fprintf(&b, "[%s=%s]", k, v)
+	}
+	return b.String(), nil
+}
+
+// needsQuoting reports whether the key value v cannot be parsed back
+// unchanged when rendered unquoted with the default escaping.
+func needsQuoting(v string) bool {
+	return v == "" || strings.ContainsAny(v, `\/`) || strings.HasPrefix(v, `"`) || strings.HasPrefix(v, `'`)
+}
+
+// escapeChars returns s with each character within chars preceded by a
+// backslash.
+func escapeChars(s, chars string) string {
+	if !strings.ContainsAny(s, chars) {
+		return s
+	}
+	var b strings.Builder
+	for _, ch := range s {
+		if strings.ContainsRune(chars, ch) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(ch)
+	}
+	return b.String()
+}
+
 // StringToPath takes an input string representing a path in gNMI, and converts
 // it to a gNMI Path message, populated with the specified path encodings.
 func StringToPath(path string, pathTypes ...PathType) (*gnmipb.Path, error) {
@@ -167,14 +349,14 @@ func StringToPath(path string, pathTypes ...PathType) (*gnmipb.Path, error) {
 		case StructuredPath:
 			gp, err := StringToStructuredPath(path)
 			if err != nil {
-				errs = util.AppendErr(errs, fmt.Errorf("error building structured path: %v", err))
+				errs = util.AppendErr(errs, fmt.Errorf("error building structured path: %w", err))
 				continue
 			}
 			pmsg.Elem = gp.Elem
 		case StringSlicePath:
 			gp, err := StringToStringSlicePath(path)
 			if err != nil {
-				errs = util.AppendErr(errs, fmt.Errorf("error building string slice path: %v", err))
+				errs = util.AppendErr(errs, fmt.Errorf("error building string slice path: %w", err))
 				continue
 			}
 			//lint:ignore SA1019 Specifically handling deprecated gNMI Element fields.
@@ -196,17 +378,15 @@ func StringToPath(path string, pathTypes ...PathType) (*gnmipb.Path, error) {
 // contents is left unchanged. This implements the legacy string slice path that are
 // used in gNMI pre-0.4.0. The specification for these paths is at https://goo.gl/uD6g6z.
 func StringToStringSlicePath(path string) (*gnmipb.Path, error) {
-	parts := util.PathStringToElements(path)
+	sp, err := util.ParseGNMIPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing path %q: %w", path, err)
+	}
 	gpath := new(gnmipb.Path)
-	for _, p := range parts {
-		// Run through extractKV to ensure that the path is valid.
-		name, kv, err := extractKV(p)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing path %q: %v", path, err)
-		}
-		fpath, err := elemToString(name, kv)
+	for _, e := range sp.Elem {
+		fpath, err := elemToString(e.Name, e.Key)
 		if err != nil {
-			return nil, fmt.Errorf("error formatting path %q: %v", path, err)
+			return nil, util.KindErrorf(util.ErrInvalidPath, "error formatting path %q: %v", path, err)
 		}
 		//lint:ignore SA1019 Specifically handling deprecated gNMI Element fields.
 		gpath.Element = append(gpath.Element, fpath)
@@ -217,19 +397,12 @@ func StringToStringSlicePath(path string) (*gnmipb.Path, error) {
 
 // StringToStructuredPath takes a string representing a path, and converts it to
 // a gnmi.Path, using the PathElem element message that is defined in gNMI 0.4.0.
+// The path is parsed using util.ParseGNMIPath, such that key values may be
+// quoted, or contain escaped characters.
 func StringToStructuredPath(path string) (*gnmipb.Path, error) {
-	parts := util.PathStringToElements(path)
-
-	gpath := &gnmipb.Path{}
-	for _, p := range parts {
-		name, kv, err := extractKV(p)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing path %s: %v", path, err)
-		}
-		gpath.Elem = append(gpath.Elem, &gnmipb.PathElem{
-			Name: name,
-			Key:  kv,
-		})
+	gpath, err := util.ParseGNMIPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing path %s: %w", path, err)
 	}
 	return gpath, nil
 }
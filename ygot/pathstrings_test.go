@@ -15,12 +15,14 @@
 package ygot
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/openconfig/gnmi/errdiff"
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/openconfig/ygot/util"
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
 )
@@ -133,6 +135,109 @@ func TestPathToStrings(t *testing.T) {
 	}
 }
 
+func TestPathToStringWithOpts(t *testing.T) {
+	modules := func(elems []*gnmipb.PathElem, i int) string {
+		switch elems[i].Name {
+		case "interfaces", "interface", "state":
+			return "openconfig-interfaces"
+		case "ipv4":
+			return "openconfig-if-ip"
+		}
+		return ""
+	}
+
+	tests := []struct {
+		name          string
+		in            *gnmipb.Path
+		inOpts        []PathStringOpt
+		want          string
+		wantErr       string
+		wantRoundTrip bool
+	}{{
+		name: "no options",
+		in:   MustStringToPath("/a[k=v\\]]/b"),
+		want: `/a[k=v\]]/b`,
+	}, {
+		name: "module qualified at module changes",
+		in: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": "eth0"}},
+			{Name: "ipv4"},
+			{Name: "state"},
+			{Name: "unknown"},
+		}},
+		inOpts: []PathStringOpt{&QualifyModuleNames{Module: modules}},
+		want:   "/openconfig-interfaces:interfaces/interface[name=eth0]/openconfig-if-ip:ipv4/openconfig-interfaces:state/unknown",
+	}, {
+		name: "all elements module qualified",
+		in: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "interfaces"},
+			{Name: "interface"},
+			{Name: "oc-ip:ipv4"},
+		}},
+		inOpts: []PathStringOpt{&QualifyModuleNames{Module: modules, All: true}},
+		want:   "/openconfig-interfaces:interfaces/openconfig-interfaces:interface/oc-ip:ipv4",
+	}, {
+		name: "escaped key values",
+		in: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "a", Key: map[string]string{"k": `x/y]z\w=`}},
+			{Name: "b", Key: map[string]string{"k": `'quoted'`}},
+		}},
+		inOpts:        []PathStringOpt{&EscapeKeyValues{}},
+		want:          `/a[k=x\/y\]z\\w\=]/b[k=\'quoted']`,
+		wantRoundTrip: true,
+	}, {
+		name: "always quoted key values",
+		in: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "a", Key: map[string]string{"k": `x"y\z`, "l": "plain"}},
+		}},
+		inOpts:        []PathStringOpt{&QuoteKeyValues{Always: true}},
+		want:          `/a[k="x\"y\\z"][l="plain"]`,
+		wantRoundTrip: true,
+	}, {
+		name: "quoted where required",
+		in: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "a", Key: map[string]string{"k": "10.0.0.0/8", "l": "x]y", "m": ""}},
+		}},
+		inOpts:        []PathStringOpt{&QuoteKeyValues{}},
+		want:          `/a[k="10.0.0.0/8"][l=x\]y][m=""]`,
+		wantRoundTrip: true,
+	}, {
+		name:    "empty key name",
+		in:      &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "a", Key: map[string]string{"": "v"}}}},
+		inOpts:  []PathStringOpt{&QuoteKeyValues{}},
+		wantErr: "empty key name (value: v) in element a",
+	}, {
+		name:    "nil path",
+		wantErr: "received nil path",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PathToStringWithOpts(tt.in, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("PathToStringWithOpts(%v): did not get expected error, %s", tt.in, diff)
+			}
+			if err != nil {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PathToStringWithOpts(%v): got: %s, want: %s", tt.in, got, tt.want)
+			}
+			if !tt.wantRoundTrip {
+				return
+			}
+			rt, err := StringToStructuredPath(got)
+			if err != nil {
+				t.Fatalf("StringToStructuredPath(%s): cannot parse rendered path, %v", got, err)
+			}
+			if !proto.Equal(rt, tt.in) {
+				t.Errorf("StringToStructuredPath(%s): did not round trip, got: %s, want: %s", got, prototext.Format(rt), prototext.Format(tt.in))
+			}
+		})
+	}
+}
+
 func TestStringToPath(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -415,6 +520,17 @@ func TestStringToPath(t *testing.T) {
 				Name: "neighbor-address",
 			}},
 		},
+	}, {
+		name:                "quoted key value containing ] and /",
+		in:                  "/interfaces/interface[name='Ethernet1/1]']/state",
+		wantStringSlicePath: &gnmipb.Path{Element: []string{"interfaces", `interface[name=Ethernet1/1\]]`, "state"}},
+		wantStructuredPath: &gnmipb.Path{
+			Elem: []*gnmipb.PathElem{
+				{Name: "interfaces"},
+				{Name: "interface", Key: map[string]string{"name": "Ethernet1/1]"}},
+				{Name: "state"},
+			},
+		},
 	}}
 
 	for _, tt := range tests {
@@ -422,6 +538,9 @@ func TestStringToPath(t *testing.T) {
 		if sliceErr != nil && !strings.Contains(sliceErr.Error(), tt.wantSliceErr) {
 			t.Errorf("%s: StringToStringSlicePath(%v): did not get expected error, got:\n%v\nwant:\n%v", tt.name, tt.in, sliceErr, tt.wantSliceErr)
 		}
+		if sliceErr != nil && !errors.Is(sliceErr, util.ErrInvalidPath) {
+			t.Errorf("%s: StringToStringSlicePath(%v): got error %v, want error of kind util.ErrInvalidPath", tt.name, tt.in, sliceErr)
+		}
 
 		if sliceErr == nil && !proto.Equal(gotSlicePath, tt.wantStringSlicePath) {
 			t.Errorf("%s: StringToStringSlicePath(%v): did not get expected string slice path, got:\n%v\nwant:\n%v", tt.name, tt.in, gotSlicePath, tt.wantStringSlicePath)
@@ -431,6 +550,9 @@ func TestStringToPath(t *testing.T) {
 		if strErr != nil && !strings.Contains(strErr.Error(), tt.wantStructuredErr) {
 			t.Errorf("%s: StringToStructuredPath(%v): did not get expected error, got: %v, want: %v", tt.name, tt.in, strErr, tt.wantStructuredErr)
 		}
+		if strErr != nil && !errors.Is(strErr, util.ErrInvalidPath) {
+			t.Errorf("%s: StringToStructuredPath(%v): got error %v, want error of kind util.ErrInvalidPath", tt.name, tt.in, strErr)
+		}
 
 		if strErr == nil && !proto.Equal(gotStructuredPath, tt.wantStructuredPath) {
 			t.Errorf("%s: StringToStructuredPath(%v): did not get expected structured path, got: %v, want: %v", tt.name, tt.in, prototext.Format(gotStructuredPath), prototext.Format(tt.wantStructuredPath))
@@ -0,0 +1,164 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathtranslate
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
+
+// keyTypes returns the types of the key leaves of the keyed list schema,
+// indexed by the key name with any module prefix removed. Leafref keys are
+// resolved to the type of the leaf that they reference. Keys whose type
+// cannot be determined are omitted.
+func keyTypes(schema *yang.Entry) map[string]*yang.YangType {
+	types := map[string]*yang.YangType{}
+	for _, k := range strings.Fields(schema.Key) {
+		name := util.StripModulePrefix(k)
+		leaf, ok := schema.Dir[name]
+		if !ok || leaf.Type == nil {
+			continue
+		}
+		t := leaf.Type
+		if t.Kind == yang.Yleafref {
+			target, err := util.FindLeafRefSchema(leaf, t.Path)
+			if err != nil || target.Type == nil {
+				continue
+			}
+			t = target.Type
+		}
+		types[name] = t
+	}
+	return types
+}
+
+// canonicalKeyValue checks that the value v of a key satisfies the key leaf
+// type t, and returns the canonical representation of v as per RFC7950. In
+// addition, values of the mac-address, phys-address and hex-string types are
+// lowercased, and values of IPv6 address and prefix types are normalised to
+// the form described in RFC5952. The wildcard value "*" is returned
+// unchanged.
+func canonicalKeyValue(t *yang.YangType, v string) (string, error) {
+	if v == wildcard {
+		return v, nil
+	}
+
+	switch t.Kind {
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yint64:
+		i, err := strconv.ParseInt(v, 10, intBits(t.Kind))
+		if err != nil {
+			return "", fmt.Errorf("invalid %v value %q", t.Kind, v)
+		}
+		if err := util.ValidateIntRange(t, i); err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(i, 10), nil
+	case yang.Yuint8, yang.Yuint16, yang.Yuint32, yang.Yuint64:
+		u, err := strconv.ParseUint(v, 10, intBits(t.Kind))
+		if err != nil {
+			return "", fmt.Errorf("invalid %v value %q", t.Kind, v)
+		}
+		if err := util.ValidateUintRange(t, u); err != nil {
+			return "", err
+		}
+		return strconv.FormatUint(u, 10), nil
+	case yang.Ydecimal64:
+		if err := util.ValidateDecimalString(t, v); err != nil {
+			return "", err
+		}
+		n, err := util.ValidateFractionDigits(t, v)
+		if err != nil {
+			return "", err
+		}
+		s := strings.TrimRight(n.String(), "0")
+		if strings.HasSuffix(s, ".") {
+			s += "0"
+		}
+		return s, nil
+	case yang.Ybool:
+		if v != "true" && v != "false" {
+			return "", fmt.Errorf("invalid boolean value %q", v)
+		}
+		return v, nil
+	case yang.Yenum:
+		if t.Enum == nil || !t.Enum.IsDefined(v) {
+			return "", fmt.Errorf("%q is not a valid enumeration value", v)
+		}
+		return v, nil
+	case yang.Yidentityref:
+		if t.IdentityBase == nil {
+			return v, nil
+		}
+		name := util.StripModulePrefix(v)
+		for _, id := range t.IdentityBase.Values {
+			if id.Name == name {
+				return v, nil
+			}
+		}
+		return "", fmt.Errorf("%q is not a valid identity derived from %s", v, t.IdentityBase.Name)
+	case yang.Ystring:
+		if err := util.ValidateStringRestrictions(t, v); err != nil {
+			return "", err
+		}
+		return canonicalString(t, v), nil
+	case yang.Yunion:
+		var errs util.Errors
+		for _, ut := range t.Type {
+			c, err := canonicalKeyValue(ut, v)
+			if err == nil {
+				return c, nil
+			}
+			errs = util.AppendErr(errs, err)
+		}
+		return "", fmt.Errorf("%q does not match any union member type: %v", v, errs)
+	}
+	return v, nil
+}
+
+// canonicalString returns the canonical representation of the string v of
+// the type t, which is determined by the name of the typedef of t.
+func canonicalString(t *yang.YangType, v string) string {
+	switch t.Name {
+	case "mac-address", "phys-address", "hex-string":
+		return strings.ToLower(v)
+	case "ipv6-address", "ipv6-address-no-zone", "ip-address", "ip-address-no-zone":
+		if a, err := netip.ParseAddr(v); err == nil && a.Is6() {
+			return a.String()
+		}
+	case "ipv6-prefix", "ip-prefix":
+		if p, err := netip.ParsePrefix(v); err == nil && p.Addr().Is6() {
+			return p.String()
+		}
+	}
+	return v
+}
+
+// intBits returns the size in bits of the integer type kind k.
+func intBits(k yang.TypeKind) int {
+	switch k {
+	case yang.Yint8, yang.Yuint8:
+		return 8
+	case yang.Yint16, yang.Yuint16:
+		return 16
+	case yang.Yint32, yang.Yuint32:
+		return 32
+	}
+	return 64
+}
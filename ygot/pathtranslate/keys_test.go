@@ -0,0 +1,172 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathtranslate
+
+import (
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+func TestCanonicalKeyValue(t *testing.T) {
+	enumType := yang.NewEnumType()
+	enumType.Set("UP", 0)
+	enumType.Set("DOWN", 1)
+
+	tests := []struct {
+		desc             string
+		inType           *yang.YangType
+		inValue          string
+		want             string
+		wantErrSubstring string
+	}{{
+		desc:    "wildcard",
+		inType:  &yang.YangType{Kind: yang.Yuint16},
+		inValue: "*",
+		want:    "*",
+	}, {
+		desc:    "uint16 with leading zeros",
+		inType:  &yang.YangType{Kind: yang.Yuint16},
+		inValue: "0080",
+		want:    "80",
+	}, {
+		desc:             "uint16 not a number",
+		inType:           &yang.YangType{Kind: yang.Yuint16},
+		inValue:          "abc",
+		wantErrSubstring: `invalid uint16 value "abc"`,
+	}, {
+		desc:             "uint8 overflow",
+		inType:           &yang.YangType{Kind: yang.Yuint8},
+		inValue:          "256",
+		wantErrSubstring: `invalid uint8 value "256"`,
+	}, {
+		desc:    "int32 with sign",
+		inType:  &yang.YangType{Kind: yang.Yint32},
+		inValue: "+42",
+		want:    "42",
+	}, {
+		desc: "int32 outside range",
+		inType: &yang.YangType{
+			Kind:  yang.Yint32,
+			Range: yang.YangRange{{Min: yang.FromInt(1), Max: yang.FromInt(10)}},
+		},
+		inValue:          "11",
+		wantErrSubstring: "outside specified ranges",
+	}, {
+		desc:    "decimal64 with trailing zeros",
+		inType:  &yang.YangType{Kind: yang.Ydecimal64, FractionDigits: 3},
+		inValue: "1.500",
+		want:    "1.5",
+	}, {
+		desc:    "decimal64 integer value",
+		inType:  &yang.YangType{Kind: yang.Ydecimal64, FractionDigits: 2},
+		inValue: "2",
+		want:    "2.0",
+	}, {
+		desc:             "invalid boolean",
+		inType:           &yang.YangType{Kind: yang.Ybool},
+		inValue:          "yes",
+		wantErrSubstring: `invalid boolean value "yes"`,
+	}, {
+		desc:    "enumeration",
+		inType:  &yang.YangType{Kind: yang.Yenum, Enum: enumType},
+		inValue: "UP",
+		want:    "UP",
+	}, {
+		desc:             "unknown enumeration value",
+		inType:           &yang.YangType{Kind: yang.Yenum, Enum: enumType},
+		inValue:          "TESTING",
+		wantErrSubstring: `"TESTING" is not a valid enumeration value`,
+	}, {
+		desc: "identityref",
+		inType: &yang.YangType{
+			Kind: yang.Yidentityref,
+			IdentityBase: &yang.Identity{
+				Name:   "BASE",
+				Values: []*yang.Identity{{Name: "DERIVED"}},
+			},
+		},
+		inValue: "mod:DERIVED",
+		want:    "mod:DERIVED",
+	}, {
+		desc: "unknown identity",
+		inType: &yang.YangType{
+			Kind:         yang.Yidentityref,
+			IdentityBase: &yang.Identity{Name: "BASE"},
+		},
+		inValue:          "OTHER",
+		wantErrSubstring: `"OTHER" is not a valid identity derived from BASE`,
+	}, {
+		desc:    "mac address lowercased",
+		inType:  &yang.YangType{Kind: yang.Ystring, Name: "mac-address"},
+		inValue: "00:1A:2B:3C:4D:5E",
+		want:    "00:1a:2b:3c:4d:5e",
+	}, {
+		desc:    "ipv6 address normalised",
+		inType:  &yang.YangType{Kind: yang.Ystring, Name: "ipv6-address"},
+		inValue: "2001:DB8:0:0:0:0:0:1",
+		want:    "2001:db8::1",
+	}, {
+		desc:    "ipv6 prefix normalised",
+		inType:  &yang.YangType{Kind: yang.Ystring, Name: "ipv6-prefix"},
+		inValue: "2001:0db8:0000::/64",
+		want:    "2001:db8::/64",
+	}, {
+		desc:    "ipv4 address in ip-address unchanged",
+		inType:  &yang.YangType{Kind: yang.Ystring, Name: "ip-address"},
+		inValue: "192.0.2.1",
+		want:    "192.0.2.1",
+	}, {
+		desc:             "string not matching pattern",
+		inType:           &yang.YangType{Kind: yang.Ystring, Pattern: []string{"[a-z]+"}},
+		inValue:          "ABC",
+		wantErrSubstring: "does not match regular expression pattern",
+	}, {
+		desc: "union matching second member",
+		inType: &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{
+				{Kind: yang.Yuint32},
+				{Kind: yang.Ystring, Name: "ipv6-address"},
+			},
+		},
+		inValue: "2001:DB8::1",
+		want:    "2001:db8::1",
+	}, {
+		desc: "union matching no member",
+		inType: &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{
+				{Kind: yang.Yuint32},
+				{Kind: yang.Ybool},
+			},
+		},
+		inValue:          "abc",
+		wantErrSubstring: `"abc" does not match any union member type`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := canonicalKeyValue(tt.inType, tt.inValue)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("canonicalKeyValue(%v, %q): %s", tt.inType, tt.inValue, diff)
+			}
+			if got != tt.want {
+				t.Errorf("canonicalKeyValue(%v, %q): got %q, want %q", tt.inType, tt.inValue, got, tt.want)
+			}
+		})
+	}
+}
@@ -18,48 +18,226 @@ package pathtranslate
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
 const separator = "/"
 
+const (
+	// wildcard is the path element that matches any single element.
+	wildcard = "*"
+	// multiLevelWildcard is the path element that matches any number of
+	// elements.
+	multiLevelWildcard = "..."
+)
+
 // PathTranslator stores the rules required to rewrite a given path as gNMI PathElem.
 type PathTranslator struct {
 	rules map[string][]string
+	// paths is the set of full paths of the schemas that have been added,
+	// their ancestors and their descendants.
+	paths map[string]bool
+	// keyTypes stores the types of the key leaves of the keyed lists within
+	// rules, indexed by the full path of the list and the name of the key.
+	keyTypes map[string]map[string]*yang.YangType
+	// keyValidation indicates that key values are checked against, and
+	// canonicalised according to, the types within keyTypes.
+	keyValidation bool
+	// strictValidation indicates that paths containing elements that are
+	// not within paths are rejected.
+	strictValidation bool
+	// preserveModulePrefixes indicates that the module prefixes of element
+	// and key names are retained in the paths returned by the translator.
+	preserveModulePrefixes bool
 }
 
+// PathTranslatorOpt is an interface implemented by the options that can be
+// supplied to NewPathTranslator.
+type PathTranslatorOpt interface {
+	// IsPathTranslatorOpt is a marker method for each PathTranslatorOpt.
+	IsPathTranslatorOpt()
+}
+
+// PreserveModulePrefixes is a PathTranslatorOpt that indicates that the
+// module prefixes of namespace-qualified element and key names, e.g.,
+// "openconfig-interfaces:interface", are retained in the paths returned by
+// the translator. By default, such prefixes are removed. In both cases,
+// prefixes are ignored when matching paths against the rules.
+type PreserveModulePrefixes struct{}
+
+// IsPathTranslatorOpt marks PreserveModulePrefixes as a PathTranslatorOpt.
+func (*PreserveModulePrefixes) IsPathTranslatorOpt() {}
+
+// StrictValidation is a PathTranslatorOpt that indicates that paths
+// containing elements that are not within the schemas supplied to the
+// translator are rejected, rather than being passed through unchanged. The
+// elements within the schemas are the schemas themselves, their ancestors,
+// and their descendants. Wildcard elements are accepted where they match at
+// least one element within the schemas.
+type StrictValidation struct{}
+
+// IsPathTranslatorOpt marks StrictValidation as a PathTranslatorOpt.
+func (*StrictValidation) IsPathTranslatorOpt() {}
+
+// KeyValidation is a PathTranslatorOpt that indicates that the values of the
+// keys of keyed lists are checked against the types of the key leaves within
+// the schemas supplied to the translator, and are replaced with their
+// canonical representation, e.g., "0010" is returned as "10" for an integer
+// key. Keys whose leaves are not within the Dir of the keyed list schema are
+// not checked.
+type KeyValidation struct{}
+
+// IsPathTranslatorOpt marks KeyValidation as a PathTranslatorOpt.
+func (*KeyValidation) IsPathTranslatorOpt() {}
+
 // NewPathTranslator instantiates a PathTranslator with the given slice of schemas.
 // It returns an error if any of the keyed list schemas have the similar full path.
-func NewPathTranslator(schemaTree []*yang.Entry) (*PathTranslator, error) {
+// Module prefixes within the names and keys of the schemas are ignored.
+func NewPathTranslator(schemaTree []*yang.Entry, opts ...PathTranslatorOpt) (*PathTranslator, error) {
 	r := &PathTranslator{
-		rules: map[string][]string{},
+		rules:    map[string][]string{},
+		paths:    map[string]bool{},
+		keyTypes: map[string]map[string]*yang.YangType{},
 	}
+	for _, o := range opts {
+		switch o.(type) {
+		case *PreserveModulePrefixes:
+			r.preserveModulePrefixes = true
+		case *StrictValidation:
+			r.strictValidation = true
+		case *KeyValidation:
+			r.keyValidation = true
+		}
+	}
+	if err := r.AddSchemas(schemaTree); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// NewPathTranslatorFromSchema instantiates a PathTranslator with the schema
+// map of generated code, as returned by the UnzipSchema function, or
+// ygot.GzipToSchema, keyed by the name of each generated struct. All keyed
+// lists within the schema trees that the map refers to are discovered,
+// such that the schemas do not need to be selected by the caller. The
+// entries of the map must be linked to their parents, as is the case for the
+// map returned by ygot.GzipToSchema.
+func NewPathTranslatorFromSchema(schema map[string]*yang.Entry, opts ...PathTranslatorOpt) (*PathTranslator, error) {
+	roots := map[*yang.Entry]bool{}
+	for _, e := range schema {
+		root := e
+		for root.Parent != nil {
+			root = root.Parent
+		}
+		roots[root] = true
+	}
+
+	var entries []*yang.Entry
+	for root := range roots {
+		for _, name := range sortedDirNames(root) {
+			ch := root.Dir[name]
+			if ch.Key == "" {
+				// Added such that the paths of all descendants are known,
+				// keyed lists are added by collectKeyedLists.
+				entries = append(entries, ch)
+			}
+			entries = collectKeyedLists(ch, entries)
+		}
+	}
+	return NewPathTranslator(entries, opts...)
+}
+
+// collectKeyedLists appends schema and its descendants that are keyed lists
+// to entries, returning the updated slice.
+func collectKeyedLists(schema *yang.Entry, entries []*yang.Entry) []*yang.Entry {
+	if schema.Key != "" {
+		entries = append(entries, schema)
+	}
+	for _, name := range sortedDirNames(schema) {
+		entries = collectKeyedLists(schema.Dir[name], entries)
+	}
+	return entries
+}
+
+// sortedDirNames returns the names of the children of schema in sorted
+// order.
+func sortedDirNames(schema *yang.Entry) []string {
+	var names []string
+	for name := range schema.Dir {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AddSchemas adds the rules for the keyed lists within the given slice of
+// schemas to the PathTranslator. It returns an error if any of the keyed list
+// schemas have the similar full path to each other, or to a keyed list schema
+// that was previously added, in which case no rules are added.
+func (r *PathTranslator) AddSchemas(schemaTree []*yang.Entry) error {
+	rules := map[string][]string{}
+	types := map[string]map[string]*yang.YangType{}
 	for _, v := range schemaTree {
 		if v.Key == "" {
 			continue
 		}
 		fullPath := resolveUntilRoot(v)
 		if _, ok := r.rules[fullPath]; ok {
-			return nil, fmt.Errorf("got %v path multiple times", fullPath)
+			return fmt.Errorf("got %v path multiple times", fullPath)
 		}
-		r.rules[fullPath] = strings.Fields(v.Key)
+		if _, ok := rules[fullPath]; ok {
+			return fmt.Errorf("got %v path multiple times", fullPath)
+		}
+		var keyNames []string
+		for _, k := range strings.Fields(v.Key) {
+			keyNames = append(keyNames, util.StripModulePrefix(k))
+		}
+		rules[fullPath] = keyNames
+		types[fullPath] = keyTypes(v)
+	}
+	for path, keyNames := range rules {
+		r.rules[path] = keyNames
+		r.keyTypes[path] = types[path]
+	}
+	for _, v := range schemaTree {
+		if v.Parent == nil {
+			continue
+		}
+		fullPath := resolveUntilRoot(v)
+		for i := len(separator); i < len(fullPath); i++ {
+			if strings.HasPrefix(fullPath[i:], separator) {
+				r.paths[fullPath[:i]] = true
+			}
+		}
+		r.addDescendantPaths(fullPath, v)
+	}
+	return nil
+}
+
+// addDescendantPaths adds the given full path of the schema, and the full
+// paths of all of its descendants, to the set of known paths.
+func (r *PathTranslator) addDescendantPaths(fullPath string, schema *yang.Entry) {
+	r.paths[fullPath] = true
+	for name, e := range schema.Dir {
+		r.addDescendantPaths(appendPath(fullPath, name), e)
 	}
-	return r, nil
 }
 
 // resolveUntilRoot concatenates the schema names of the given schema and its
-// ancestors. '/' is used as separator. The root schema in the tree is ignored
-// as it is an artificially inserted schema.
+// ancestors, with any module prefixes removed. '/' is used as separator. The
+// root schema in the tree is ignored as it is an artificially inserted schema.
 func resolveUntilRoot(schema *yang.Entry) string {
 	path := []string{}
 	// The schema with nil Parent is assumed to be root schema. Root schema is't
 	// appended into string slice.
 	for e := schema; e.Parent != nil; e = e.Parent {
-		path = append(path, e.Name)
+		path = append(path, util.StripModulePrefix(e.Name))
 	}
 	// Append an empty string to get a concatenated string starting with "/".
 	path = append(path, "")
@@ -72,9 +250,48 @@ func resolveUntilRoot(schema *yang.Entry) string {
 	return strings.Join(path, separator)
 }
 
+// appendPath appends the name of an element to the path used to look up the
+// rules, with any module prefix removed.
+func appendPath(pathSoFar, name string) string {
+	return pathSoFar + separator + util.StripModulePrefix(name)
+}
+
+// name returns the name of an element or key as it is returned by the
+// translator.
+func (r *PathTranslator) name(n string) string {
+	if r.preserveModulePrefixes {
+		return n
+	}
+	return util.StripModulePrefix(n)
+}
+
+// elem returns a copy of e in which the element and key names are those
+// returned by the translator.
+func (r *PathTranslator) elem(e *gnmipb.PathElem) *gnmipb.PathElem {
+	ne := &gnmipb.PathElem{Name: r.name(e.GetName())}
+	for k, v := range e.GetKey() {
+		if ne.Key == nil {
+			ne.Key = map[string]string{}
+		}
+		ne.Key[r.name(k)] = v
+	}
+	return ne
+}
+
 // PathElem receives a path as string slice and generates slice of gNMI PathElem
 // based on stored rewrite rules. It returns an error if there are less elements
 // following the element's name in the path than the number of keys of the list.
+//
+// The path may contain the gNMI wildcard elements "*", matching any single
+// element, and "...", matching any number of elements. Once a wildcard
+// element has been seen, the keys of keyed lists are no longer consumed from
+// the elements that follow their name; instead, each keyed list that the path
+// may traverse has all of its keys set to "*". An error is returned if the
+// keyed lists that an element may match have different keys.
+//
+// Elements that are not within the schemas are passed through unchanged,
+// unless the StrictValidation option was supplied to NewPathTranslator, in
+// which case an error is returned.
 func (r *PathTranslator) PathElem(p []string) ([]*gnmipb.PathElem, error) {
 	// Keeps track of whether element in the p slice is consumed or not.
 	// When keys are consumed, they are set as true in "used" slice.
@@ -83,13 +300,35 @@ func (r *PathTranslator) PathElem(p []string) ([]*gnmipb.PathElem, error) {
 	// Keeps the path elements seen so far by appending with a separator.
 	var pathSoFar string
 
+	// Keeps the schema paths that the path so far may match once a
+	// wildcard element has been seen, it is nil otherwise.
+	var wildcardPaths map[string]bool
+
 	var res []*gnmipb.PathElem
 	for i := 0; i < len(p); i++ {
 		// this must be a key element which was considered in prior iterations.
 		if used[i] {
 			continue
 		}
-		pathSoFar = pathSoFar + separator + p[i]
+		if p[i] == wildcard || p[i] == multiLevelWildcard {
+			if wildcardPaths == nil {
+				wildcardPaths = map[string]bool{pathSoFar: true}
+			}
+			wildcardPaths = r.expandWildcard(wildcardPaths, p[i] == multiLevelWildcard)
+			res = append(res, &gnmipb.PathElem{Name: p[i]})
+			continue
+		}
+		if wildcardPaths != nil {
+			var e *gnmipb.PathElem
+			var err error
+			e, wildcardPaths, err = r.wildcardElem(wildcardPaths, p[i])
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, e)
+			continue
+		}
+		pathSoFar = appendPath(pathSoFar, p[i])
 
 		keyNames, ok := r.rules[pathSoFar]
 		// If pathSoFar isn't in rule list, this can be an arbitrary element or
@@ -97,21 +336,204 @@ func (r *PathTranslator) PathElem(p []string) ([]*gnmipb.PathElem, error) {
 		// Note that this isn't a check to decide whether arbitrary element is
 		// schema compliant.
 		if !ok {
-			res = append(res, &gnmipb.PathElem{Name: p[i]})
+			res = append(res, &gnmipb.PathElem{Name: r.name(p[i])})
 			continue
 		}
 		keysStartPos := i + 1
 		if len(keyNames) > len(p)-keysStartPos {
-			return nil, fmt.Errorf("got %d, want %d keys for %s", len(p)-keysStartPos, len(keyNames), pathSoFar)
+			return nil, util.KindErrorf(util.ErrInvalidKey, "got %d, want %d keys for %s", len(p)-keysStartPos, len(keyNames), pathSoFar)
 		}
 		keys := map[string]string{}
 		for j, k := range keyNames {
 			used[keysStartPos+j] = true
 			keys[k] = p[keysStartPos+j]
 		}
-		res = append(res, &gnmipb.PathElem{Name: p[i], Key: keys})
+		if err := r.canonicalKeys(pathSoFar, keys); err != nil {
+			return nil, err
+		}
+		res = append(res, &gnmipb.PathElem{Name: r.name(p[i]), Key: keys})
+	}
+
+	if err := r.validate(res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// expandWildcard returns the schema paths that are matched by a wildcard
+// element following any of the given paths. If multiLevel is set, the
+// wildcard matches any number of elements, including none, otherwise it
+// matches exactly one element. Only paths within the schemas supplied to the
+// translator are returned.
+func (r *PathTranslator) expandWildcard(paths map[string]bool, multiLevel bool) map[string]bool {
+	expanded := map[string]bool{}
+	for path := range paths {
+		if multiLevel {
+			expanded[path] = true
+		}
+		for k := range r.paths {
+			rest := strings.TrimPrefix(k, path+separator)
+			if rest == k {
+				continue
+			}
+			if multiLevel || !strings.Contains(rest, separator) {
+				expanded[k] = true
+			}
+		}
+	}
+	return expanded
+}
+
+// wildcardElem returns the PathElem for the element with the given name
+// following a path that may match any of the given schema paths, along with
+// the schema paths that may be matched including the element. If the element
+// may be a keyed list within the rules, all of its keys are set to "*".
+func (r *PathTranslator) wildcardElem(paths map[string]bool, name string) (*gnmipb.PathElem, map[string]bool, error) {
+	var keyNames []string
+	var keyPath string
+	next := map[string]bool{}
+	for path := range paths {
+		path = appendPath(path, name)
+		next[path] = true
+		kn, ok := r.rules[path]
+		if !ok {
+			continue
+		}
+		if keyNames != nil && strings.Join(kn, " ") != strings.Join(keyNames, " ") {
+			return nil, nil, util.KindErrorf(util.ErrInvalidKey, "got ambiguous keys for %s, keys %v for %s and %v for %s", name, keyNames, keyPath, kn, path)
+		}
+		keyNames, keyPath = kn, path
+	}
+
+	e := &gnmipb.PathElem{Name: r.name(name)}
+	if keyNames != nil {
+		e.Key = map[string]string{}
+		for _, k := range keyNames {
+			e.Key[k] = wildcard
+		}
+	}
+	return e, next, nil
+}
+
+// canonicalKeys checks the values of the keys of the keyed list with the
+// given full path against the types of its key leaves, if key validation is
+// enabled, replacing each value in place with its canonical representation.
+func (r *PathTranslator) canonicalKeys(path string, keys map[string]string) error {
+	if !r.keyValidation {
+		return nil
+	}
+	for k, v := range keys {
+		t, ok := r.keyTypes[path][util.StripModulePrefix(k)]
+		if !ok {
+			continue
+		}
+		c, err := canonicalKeyValue(t, v)
+		if err != nil {
+			return util.KindErrorf(util.ErrInvalidKey, "invalid value %q for key %s of %s: %v", v, k, path, err)
+		}
+		keys[k] = c
+	}
+	return nil
+}
+
+// validate returns an error if strict validation is enabled, and the path
+// described by elems contains an element that is not within the schemas
+// supplied to the translator.
+func (r *PathTranslator) validate(elems []*gnmipb.PathElem) error {
+	if !r.strictValidation {
+		return nil
+	}
+	paths := map[string]bool{"": true}
+	for _, e := range elems {
+		switch name := e.GetName(); name {
+		case wildcard, multiLevelWildcard:
+			paths = r.expandWildcard(paths, name == multiLevelWildcard)
+		default:
+			next := map[string]bool{}
+			for path := range paths {
+				if path = appendPath(path, name); r.paths[path] {
+					next[path] = true
+				}
+			}
+			paths = next
+		}
+		if len(paths) == 0 {
+			return util.KindErrorf(util.ErrPathNotFound, "element %s of path %v is not within the schema", e.GetName(), elems)
+		}
+	}
+	return nil
+}
+
+// PathElemFromXPath receives an XPath-like string path, with the keys of
+// keyed lists specified in the bracketed form, e.g.,
+// "/a/b/list[k1=v1][k2=v2]/leaf", and generates the equivalent slice of gNMI
+// PathElem. The path is parsed according to the gNMI path conventions, as
+// described in util.ParseGNMIPath. Keyed lists within the rules may be
+// specified without keys, in which case the returned PathElem has no keys.
+//
+// It returns an error if the path cannot be parsed, or if the key names of a
+// keyed list do not match those within the rules.
+func (r *PathTranslator) PathElemFromXPath(path string) ([]*gnmipb.PathElem, error) {
+	p, err := util.ParseGNMIPath(path)
+	if err != nil {
+		return nil, err
 	}
 
+	var pathSoFar string
+	var res []*gnmipb.PathElem
+	for _, e := range p.GetElem() {
+		pathSoFar = appendPath(pathSoFar, e.GetName())
+		res = append(res, r.elem(e))
+		keyNames, ok := r.rules[pathSoFar]
+		if !ok || len(e.GetKey()) == 0 {
+			continue
+		}
+		if !sameKeyNames(e.GetKey(), keyNames) {
+			return nil, util.KindErrorf(util.ErrInvalidKey, "got keys %v, want keys %v for %s", e.GetKey(), keyNames, pathSoFar)
+		}
+		if err := r.canonicalKeys(pathSoFar, res[len(res)-1].Key); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.validate(res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// StringSlice receives a slice of gNMI PathElem and generates the equivalent
+// path as a string slice based on stored rewrite rules. It is the inverse of
+// PathElem: the keys of a keyed list within the rules are flattened into the
+// elements that follow its name, in the order in which they are specified in
+// the schema.
+//
+// It returns an error if the keys of a keyed list do not match those within
+// the rules, or if an element that is not a keyed list within the rules has
+// keys, since such keys cannot be represented positionally.
+func (r *PathTranslator) StringSlice(elems []*gnmipb.PathElem) ([]string, error) {
+	if err := r.validate(elems); err != nil {
+		return nil, err
+	}
+	var pathSoFar string
+	var res []string
+	for _, elem := range elems {
+		pathSoFar = appendPath(pathSoFar, elem.GetName())
+		res = append(res, r.name(elem.GetName()))
+
+		keyNames, ok := r.rules[pathSoFar]
+		if !ok {
+			if len(elem.GetKey()) != 0 {
+				return nil, util.KindErrorf(util.ErrInvalidKey, "got keys %v for %s, which is not a keyed list", elem.GetKey(), pathSoFar)
+			}
+			continue
+		}
+		if !sameKeyNames(elem.GetKey(), keyNames) {
+			return nil, util.KindErrorf(util.ErrInvalidKey, "got keys %v, want keys %v for %s", elem.GetKey(), keyNames, pathSoFar)
+		}
+		for _, k := range keyNames {
+			res = append(res, keyValue(elem.GetKey(), k))
+		}
+	}
 	return res, nil
 }
 
@@ -122,13 +544,13 @@ func (r *PathTranslator) SetWildcardKeys(elems []*gnmipb.PathElem) (bool, error)
 	var pathSoFar string
 	var updated bool
 	for _, elem := range elems {
-		pathSoFar = pathSoFar + separator + elem.GetName()
+		pathSoFar = appendPath(pathSoFar, elem.GetName())
 		keyNames, ok := r.rules[pathSoFar]
 		if !ok {
 			continue
 		}
 		if elem.GetKey() != nil {
-			return false, fmt.Errorf("path %v already has keys", elems)
+			return false, util.KindErrorf(util.ErrInvalidKey, "path %v already has keys", elems)
 		}
 		elem.Key = map[string]string{}
 		for _, key := range keyNames {
@@ -138,3 +560,110 @@ func (r *PathTranslator) SetWildcardKeys(elems []*gnmipb.PathElem) (bool, error)
 	}
 	return updated, nil
 }
+
+// StructuredPath receives a gNMI path that uses the deprecated Element field
+// and returns the equivalent path using PathElem, based on stored rewrite
+// rules. Each element may either be a name, with the keys of a keyed list
+// specified as the elements that follow its name, or a name followed by its
+// keys in the bracketed form, e.g., "simpleKeyedList[k1=key1]". The origin
+// and target of the path are retained. A path that already uses PathElem is
+// returned unchanged.
+//
+// It returns an error if the path populates both the Element and Elem
+// fields, if an element cannot be parsed, or if the keys of a keyed list do
+// not match those within the rules.
+func (r *PathTranslator) StructuredPath(p *gnmipb.Path) (*gnmipb.Path, error) {
+	//lint:ignore SA1019 Specifically handling deprecated gNMI Element fields.
+	element := p.GetElement()
+	if len(element) == 0 {
+		return p, nil
+	}
+	if len(p.GetElem()) != 0 {
+		return nil, util.KindErrorf(util.ErrInvalidPath, "path %v populates both the element and elem fields", p)
+	}
+
+	var pathSoFar string
+	var elems []*gnmipb.PathElem
+	for i := 0; i < len(element); i++ {
+		e := &gnmipb.PathElem{Name: r.name(element[i])}
+		if strings.Contains(element[i], "[") {
+			bp, err := util.ParseGNMIPath(element[i])
+			if err != nil {
+				return nil, err
+			}
+			if len(bp.GetElem()) != 1 {
+				return nil, util.KindErrorf(util.ErrInvalidPath, "element %q does not describe a single path element", element[i])
+			}
+			e = r.elem(bp.GetElem()[0])
+		}
+		pathSoFar = appendPath(pathSoFar, e.GetName())
+		elems = append(elems, e)
+
+		keyNames, ok := r.rules[pathSoFar]
+		if !ok {
+			continue
+		}
+		if len(e.GetKey()) != 0 {
+			if !sameKeyNames(e.GetKey(), keyNames) {
+				return nil, util.KindErrorf(util.ErrInvalidKey, "got keys %v, want keys %v for %s", e.GetKey(), keyNames, pathSoFar)
+			}
+			if err := r.canonicalKeys(pathSoFar, e.Key); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		keysStartPos := i + 1
+		if len(keyNames) > len(element)-keysStartPos {
+			return nil, util.KindErrorf(util.ErrInvalidKey, "got %d, want %d keys for %s", len(element)-keysStartPos, len(keyNames), pathSoFar)
+		}
+		e.Key = map[string]string{}
+		for j, k := range keyNames {
+			e.Key[k] = element[keysStartPos+j]
+		}
+		if err := r.canonicalKeys(pathSoFar, e.Key); err != nil {
+			return nil, err
+		}
+		i += len(keyNames)
+	}
+
+	if err := r.validate(elems); err != nil {
+		return nil, err
+	}
+	return &gnmipb.Path{
+		Origin: p.GetOrigin(),
+		Target: p.GetTarget(),
+		Elem:   elems,
+	}, nil
+}
+
+// sameKeyNames reports whether the names of the keys, with any module
+// prefixes removed, are exactly those within names.
+func sameKeyNames(keys map[string]string, names []string) bool {
+	if len(keys) != len(names) {
+		return false
+	}
+	got := map[string]bool{}
+	for k := range keys {
+		got[util.StripModulePrefix(k)] = true
+	}
+	for _, n := range names {
+		if !got[n] {
+			return false
+		}
+	}
+	return true
+}
+
+// keyValue returns the value of the key with the given name, ignoring any
+// module prefix of the key names within keys.
+func keyValue(keys map[string]string, name string) string {
+	if v, ok := keys[name]; ok {
+		return v
+	}
+	for k, v := range keys {
+		if util.StripModulePrefix(k) == name {
+			return v
+		}
+	}
+	return ""
+}
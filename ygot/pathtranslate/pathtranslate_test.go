@@ -15,11 +15,13 @@
 package pathtranslate
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/openconfig/gnmi/errdiff"
 	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
 	"google.golang.org/protobuf/proto"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
@@ -106,6 +108,132 @@ func TestInstantiationOfTranslator(t *testing.T) {
 	}
 }
 
+func TestNewPathTranslatorFromSchema(t *testing.T) {
+	device := &yang.Entry{Name: "device", Dir: map[string]*yang.Entry{}}
+	interfaces := &yang.Entry{Name: "interfaces", Parent: device, Dir: map[string]*yang.Entry{}}
+	iface := &yang.Entry{Name: "interface", Key: "name", Parent: interfaces, Dir: map[string]*yang.Entry{}}
+	iface.Dir["name"] = &yang.Entry{Name: "name", Parent: iface}
+	subinterfaces := &yang.Entry{Name: "subinterfaces", Parent: iface, Dir: map[string]*yang.Entry{}}
+	subinterface := &yang.Entry{Name: "subinterface", Key: "index", Parent: subinterfaces, Dir: map[string]*yang.Entry{}}
+	subinterface.Dir["index"] = &yang.Entry{Name: "index", Parent: subinterface}
+	system := &yang.Entry{Name: "system", Parent: device, Dir: map[string]*yang.Entry{}}
+	system.Dir["hostname"] = &yang.Entry{Name: "hostname", Parent: system}
+	subinterfaces.Dir["subinterface"] = subinterface
+	iface.Dir["subinterfaces"] = subinterfaces
+	interfaces.Dir["interface"] = iface
+	device.Dir["interfaces"] = interfaces
+	device.Dir["system"] = system
+
+	schema := map[string]*yang.Entry{
+		"Device":                 device,
+		"Interface":              iface,
+		"Interface_Subinterface": subinterface,
+		"System":                 system,
+	}
+
+	r, err := NewPathTranslatorFromSchema(schema, &StrictValidation{})
+	if err != nil {
+		t.Fatalf("NewPathTranslatorFromSchema: got unexpected error: %v", err)
+	}
+
+	wantRules := map[string][]string{
+		"/interfaces/interface":                            {"name"},
+		"/interfaces/interface/subinterfaces/subinterface": {"index"},
+	}
+	if diff := cmp.Diff(wantRules, r.rules); diff != "" {
+		t.Errorf("rules (-want, +got):\n%s", diff)
+	}
+
+	for _, p := range [][]string{
+		{"interfaces", "interface", "eth0", "subinterfaces", "subinterface", "0", "index"},
+		{"system", "hostname"},
+	} {
+		if _, err := r.PathElem(p); err != nil {
+			t.Errorf("PathElem(%v): got unexpected error: %v", p, err)
+		}
+	}
+	if _, err := r.PathElem([]string{"system", "domain-name"}); err == nil {
+		t.Errorf("PathElem for element not within the schema: did not get expected error")
+	}
+}
+
+func TestAddSchemas(t *testing.T) {
+	simpleSchema := &yang.Entry{
+		Name: "simpleKeyedList",
+		Key:  "k1",
+		Parent: &yang.Entry{
+			Name: "simpleKeyedLists",
+			Parent: &yang.Entry{
+				Name:   "a",
+				Parent: &yang.Entry{Name: "root"},
+			},
+		},
+	}
+
+	otherSchema := &yang.Entry{
+		Name: "otherKeyedList",
+		Key:  "k1 k2",
+		Parent: &yang.Entry{
+			Name: "otherKeyedLists",
+			Parent: &yang.Entry{
+				Name:   "b",
+				Parent: &yang.Entry{Name: "root"},
+			},
+		},
+	}
+
+	tests := []struct {
+		inDesc           string
+		inInitial        []*yang.Entry
+		inSchemas        []*yang.Entry
+		wantRules        map[string][]string
+		wantErrSubstring string
+	}{{
+		inDesc:    "success adding to empty translator",
+		inSchemas: []*yang.Entry{simpleSchema},
+		wantRules: map[string][]string{
+			"/a/simpleKeyedLists/simpleKeyedList": {"k1"},
+		},
+	}, {
+		inDesc:    "success adding new keyed list",
+		inInitial: []*yang.Entry{simpleSchema},
+		inSchemas: []*yang.Entry{otherSchema},
+		wantRules: map[string][]string{
+			"/a/simpleKeyedLists/simpleKeyedList": {"k1"},
+			"/b/otherKeyedLists/otherKeyedList":   {"k1", "k2"},
+		},
+	}, {
+		inDesc:           "fail adding previously added keyed list",
+		inInitial:        []*yang.Entry{simpleSchema},
+		inSchemas:        []*yang.Entry{otherSchema, simpleSchema},
+		wantErrSubstring: "got /a/simpleKeyedLists/simpleKeyedList path multiple times",
+		wantRules: map[string][]string{
+			"/a/simpleKeyedLists/simpleKeyedList": {"k1"},
+		},
+	}, {
+		inDesc:           "fail adding keyed list multiple times",
+		inSchemas:        []*yang.Entry{otherSchema, otherSchema},
+		wantErrSubstring: "got /b/otherKeyedLists/otherKeyedList path multiple times",
+		wantRules:        map[string][]string{},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.inDesc, func(t *testing.T) {
+			r, err := NewPathTranslator(tt.inInitial)
+			if err != nil {
+				t.Fatalf("failed to create path translator; %v", err)
+			}
+			err = r.AddSchemas(tt.inSchemas)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("diff: %v", diff)
+			}
+			if diff := cmp.Diff(tt.wantRules, r.rules); diff != "" {
+				t.Errorf("(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestPathElem(t *testing.T) {
 	schemas := []*yang.Entry{
 		{Name: "root"},
@@ -143,6 +271,17 @@ func TestPathElem(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "simpleKeyedList",
+			Key:  "k2",
+			Parent: &yang.Entry{
+				Name: "c",
+				Parent: &yang.Entry{
+					Name:   "a",
+					Parent: &yang.Entry{Name: "root"},
+				},
+			},
+		},
 	}
 
 	tests := []struct {
@@ -219,6 +358,55 @@ func TestPathElem(t *testing.T) {
 				{Name: "structKeyedList", Key: map[string]string{"k1": "key1", "k2": "key2", "k3": "key3"}},
 			},
 		},
+		{
+			inDesc: "success path with wildcard key",
+			inPath: []string{"a", "b", "simpleKeyedLists", "simpleKeyedList", "*", "leaf"},
+			wantPath: []*gnmipb.PathElem{
+				{Name: "a"},
+				{Name: "b"},
+				{Name: "simpleKeyedLists"},
+				{Name: "simpleKeyedList", Key: map[string]string{"k1": "*"}},
+				{Name: "leaf"},
+			},
+		},
+		{
+			inDesc: "success path with single level wildcard before keyed list",
+			inPath: []string{"a", "*", "simpleKeyedLists", "simpleKeyedList", "leaf"},
+			wantPath: []*gnmipb.PathElem{
+				{Name: "a"},
+				{Name: "*"},
+				{Name: "simpleKeyedLists"},
+				{Name: "simpleKeyedList", Key: map[string]string{"k1": "*"}},
+				{Name: "leaf"},
+			},
+		},
+		{
+			inDesc: "success path with multi level wildcard before keyed lists",
+			inPath: []string{"...", "simpleKeyedLists", "simpleKeyedList", "structKeyedLists", "structKeyedList", "leaf"},
+			wantPath: []*gnmipb.PathElem{
+				{Name: "..."},
+				{Name: "simpleKeyedLists"},
+				{Name: "simpleKeyedList", Key: map[string]string{"k1": "*"}},
+				{Name: "structKeyedLists"},
+				{Name: "structKeyedList", Key: map[string]string{"k1": "*", "k2": "*", "k3": "*"}},
+				{Name: "leaf"},
+			},
+		},
+		{
+			inDesc: "success wildcard matching no keyed list",
+			inPath: []string{"x", "y", "*", "simpleKeyedList"},
+			wantPath: []*gnmipb.PathElem{
+				{Name: "x"},
+				{Name: "y"},
+				{Name: "*"},
+				{Name: "simpleKeyedList"},
+			},
+		},
+		{
+			inDesc:           "fail wildcard matching keyed lists with different keys",
+			inPath:           []string{"a", "...", "simpleKeyedList"},
+			wantErrSubstring: "got ambiguous keys for simpleKeyedList",
+		},
 		{
 			inDesc:           "fail path due to insufficient keys to fill the key struct",
 			inPath:           []string{"a", "b", "simpleKeyedLists", "simpleKeyedList", "key1", "structKeyedLists", "structKeyedList", "key1", "key2"},
@@ -246,6 +434,570 @@ func TestPathElem(t *testing.T) {
 	}
 }
 
+func TestStructuredPath(t *testing.T) {
+	schemas := []*yang.Entry{
+		{Name: "root"},
+		{
+			Name: "simpleKeyedList",
+			Key:  "k1",
+			Parent: &yang.Entry{
+				Name: "simpleKeyedLists",
+				Parent: &yang.Entry{
+					Name:   "a",
+					Parent: &yang.Entry{Name: "root"},
+				},
+			},
+		},
+		{
+			Name: "structKeyedList",
+			Key:  "k1 k2",
+			Parent: &yang.Entry{
+				Name: "structKeyedLists",
+				Parent: &yang.Entry{
+					Name:   "a",
+					Parent: &yang.Entry{Name: "root"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		inDesc           string
+		inPath           *gnmipb.Path
+		wantPath         *gnmipb.Path
+		wantErrSubstring string
+		wantErr          error
+	}{{
+		inDesc:   "success path without elements",
+		inPath:   &gnmipb.Path{Target: "dev"},
+		wantPath: &gnmipb.Path{Target: "dev"},
+	}, {
+		inDesc:   "success path already using PathElem",
+		inPath:   &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "a"}}},
+		wantPath: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "a"}}},
+	}, {
+		inDesc: "success keys as separate elements",
+		inPath: &gnmipb.Path{
+			Origin:  "openconfig",
+			Target:  "dev",
+			Element: []string{"a", "structKeyedLists", "structKeyedList", "key1", "key/2", "leaf"},
+		},
+		wantPath: &gnmipb.Path{
+			Origin: "openconfig",
+			Target: "dev",
+			Elem: []*gnmipb.PathElem{
+				{Name: "a"},
+				{Name: "structKeyedLists"},
+				{Name: "structKeyedList", Key: map[string]string{"k1": "key1", "k2": "key/2"}},
+				{Name: "leaf"},
+			},
+		},
+	}, {
+		inDesc: "success keys in bracketed form",
+		inPath: &gnmipb.Path{Element: []string{"a", "simpleKeyedLists", "simpleKeyedList[k1=key1]", "leaf"}},
+		wantPath: &gnmipb.Path{
+			Elem: []*gnmipb.PathElem{
+				{Name: "a"},
+				{Name: "simpleKeyedLists"},
+				{Name: "simpleKeyedList", Key: map[string]string{"k1": "key1"}},
+				{Name: "leaf"},
+			},
+		},
+	}, {
+		inDesc: "success bracketed keys of list not within rules",
+		inPath: &gnmipb.Path{Element: []string{"b", "list[name=eth0]"}},
+		wantPath: &gnmipb.Path{
+			Elem: []*gnmipb.PathElem{
+				{Name: "b"},
+				{Name: "list", Key: map[string]string{"name": "eth0"}},
+			},
+		},
+	}, {
+		inDesc:           "fail both element and elem populated",
+		inPath:           &gnmipb.Path{Element: []string{"a"}, Elem: []*gnmipb.PathElem{{Name: "a"}}},
+		wantErrSubstring: "populates both the element and elem fields",
+		wantErr:          util.ErrInvalidPath,
+	}, {
+		inDesc:           "fail invalid bracketed element",
+		inPath:           &gnmipb.Path{Element: []string{"a", "simpleKeyedLists", "simpleKeyedList[k1=key1"}},
+		wantErrSubstring: "simpleKeyedList[k1=key1",
+		wantErr:          util.ErrInvalidPath,
+	}, {
+		inDesc:           "fail mismatched bracketed keys",
+		inPath:           &gnmipb.Path{Element: []string{"a", "simpleKeyedLists", "simpleKeyedList[k2=key1]"}},
+		wantErrSubstring: "want keys [k1] for /a/simpleKeyedLists/simpleKeyedList",
+		wantErr:          util.ErrInvalidKey,
+	}, {
+		inDesc:           "fail insufficient keys",
+		inPath:           &gnmipb.Path{Element: []string{"a", "structKeyedLists", "structKeyedList", "key1"}},
+		wantErrSubstring: "got 1, want 2 keys for /a/structKeyedLists/structKeyedList",
+		wantErr:          util.ErrInvalidKey,
+	}}
+
+	r, err := NewPathTranslator(schemas)
+	if err != nil {
+		t.Fatalf("failed to create path translator; %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.inDesc, func(t *testing.T) {
+			gotPath, err := r.StructuredPath(tt.inPath)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("diff: %v", diff)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error %v, want error of kind %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !proto.Equal(gotPath, tt.wantPath) {
+				t.Errorf("got %v, want %v", gotPath, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestPathElemFromXPath(t *testing.T) {
+	schemas := []*yang.Entry{
+		{Name: "root"},
+		{
+			Name: "simpleKeyedList",
+			Key:  "k1",
+			Parent: &yang.Entry{
+				Name: "simpleKeyedLists",
+				Parent: &yang.Entry{
+					Name:   "a",
+					Parent: &yang.Entry{Name: "root"},
+				},
+			},
+		},
+		{
+			Name: "structKeyedList",
+			Key:  "k1 k2",
+			Parent: &yang.Entry{
+				Name: "structKeyedLists",
+				Parent: &yang.Entry{
+					Name:   "a",
+					Parent: &yang.Entry{Name: "root"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		inDesc           string
+		inPath           string
+		wantPath         []*gnmipb.PathElem
+		wantErrSubstring string
+		wantErr          error
+	}{{
+		inDesc: "success empty path",
+		inPath: "/",
+	}, {
+		inDesc: "success path with keyed list followed by a leaf",
+		inPath: "/a/simpleKeyedLists/simpleKeyedList[k1=key1]/leaf",
+		wantPath: []*gnmipb.PathElem{
+			{Name: "a"},
+			{Name: "simpleKeyedLists"},
+			{Name: "simpleKeyedList", Key: map[string]string{"k1": "key1"}},
+			{Name: "leaf"},
+		},
+	}, {
+		inDesc: "success path with multiple keys in any order",
+		inPath: "/a/structKeyedLists/structKeyedList[k2=key/2][k1=key1]",
+		wantPath: []*gnmipb.PathElem{
+			{Name: "a"},
+			{Name: "structKeyedLists"},
+			{Name: "structKeyedList", Key: map[string]string{"k1": "key1", "k2": "key/2"}},
+		},
+	}, {
+		inDesc: "success keyed list without keys",
+		inPath: "a/simpleKeyedLists/simpleKeyedList",
+		wantPath: []*gnmipb.PathElem{
+			{Name: "a"},
+			{Name: "simpleKeyedLists"},
+			{Name: "simpleKeyedList"},
+		},
+	}, {
+		inDesc: "success keys of list not within rules",
+		inPath: "/b/list[name=eth0]",
+		wantPath: []*gnmipb.PathElem{
+			{Name: "b"},
+			{Name: "list", Key: map[string]string{"name": "eth0"}},
+		},
+	}, {
+		inDesc:           "fail invalid path",
+		inPath:           "/a/simpleKeyedLists/simpleKeyedList[k1=key1",
+		wantErrSubstring: "invalid path",
+		wantErr:          util.ErrInvalidPath,
+	}, {
+		inDesc:           "fail unknown key name",
+		inPath:           "/a/simpleKeyedLists/simpleKeyedList[k2=key1]",
+		wantErrSubstring: "want keys [k1] for /a/simpleKeyedLists/simpleKeyedList",
+		wantErr:          util.ErrInvalidKey,
+	}, {
+		inDesc:           "fail missing key",
+		inPath:           "/a/structKeyedLists/structKeyedList[k1=key1]",
+		wantErrSubstring: "want keys [k1 k2] for /a/structKeyedLists/structKeyedList",
+		wantErr:          util.ErrInvalidKey,
+	}}
+
+	r, err := NewPathTranslator(schemas)
+	if err != nil {
+		t.Fatalf("failed to create path translator; %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.inDesc, func(t *testing.T) {
+			gotPath, err := r.PathElemFromXPath(tt.inPath)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("diff: %v", diff)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error %v, want error of kind %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !cmp.Equal(gotPath, tt.wantPath, cmp.Comparer(proto.Equal)) {
+				t.Errorf("got %v, want %v", gotPath, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestStringSlice(t *testing.T) {
+	schemas := []*yang.Entry{
+		{Name: "root"},
+		{
+			Name: "simpleKeyedList",
+			Key:  "k1",
+			Parent: &yang.Entry{
+				Name: "simpleKeyedLists",
+				Parent: &yang.Entry{
+					Name:   "a",
+					Parent: &yang.Entry{Name: "root"},
+				},
+			},
+		},
+		{
+			Name: "structKeyedList",
+			Key:  "k2 k1",
+			Parent: &yang.Entry{
+				Name: "structKeyedLists",
+				Parent: &yang.Entry{
+					Name:   "a",
+					Parent: &yang.Entry{Name: "root"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		inDesc           string
+		inElems          []*gnmipb.PathElem
+		wantPath         []string
+		wantErrSubstring string
+		wantErr          error
+	}{{
+		inDesc: "success empty path",
+	}, {
+		inDesc:   "success path with no keyed list",
+		inElems:  []*gnmipb.PathElem{{Name: "a"}, {Name: "b"}},
+		wantPath: []string{"a", "b"},
+	}, {
+		inDesc: "success path with keyed list followed by arbitrary elements",
+		inElems: []*gnmipb.PathElem{
+			{Name: "a"},
+			{Name: "simpleKeyedLists"},
+			{Name: "simpleKeyedList", Key: map[string]string{"k1": "key1"}},
+			{Name: "leaf"},
+		},
+		wantPath: []string{"a", "simpleKeyedLists", "simpleKeyedList", "key1", "leaf"},
+	}, {
+		inDesc: "success keys in schema order",
+		inElems: []*gnmipb.PathElem{
+			{Name: "a"},
+			{Name: "structKeyedLists"},
+			{Name: "structKeyedList", Key: map[string]string{"k1": "key1", "k2": "key2"}},
+		},
+		wantPath: []string{"a", "structKeyedLists", "structKeyedList", "key2", "key1"},
+	}, {
+		inDesc: "fail missing keys",
+		inElems: []*gnmipb.PathElem{
+			{Name: "a"},
+			{Name: "simpleKeyedLists"},
+			{Name: "simpleKeyedList"},
+		},
+		wantErrSubstring: "want keys [k1] for /a/simpleKeyedLists/simpleKeyedList",
+		wantErr:          util.ErrInvalidKey,
+	}, {
+		inDesc: "fail mismatched keys",
+		inElems: []*gnmipb.PathElem{
+			{Name: "a"},
+			{Name: "structKeyedLists"},
+			{Name: "structKeyedList", Key: map[string]string{"k1": "key1", "k3": "key3"}},
+		},
+		wantErrSubstring: "want keys [k2 k1] for /a/structKeyedLists/structKeyedList",
+		wantErr:          util.ErrInvalidKey,
+	}, {
+		inDesc:           "fail keys for list not within rules",
+		inElems:          []*gnmipb.PathElem{{Name: "b"}, {Name: "list", Key: map[string]string{"name": "eth0"}}},
+		wantErrSubstring: "/b/list, which is not a keyed list",
+		wantErr:          util.ErrInvalidKey,
+	}}
+
+	r, err := NewPathTranslator(schemas)
+	if err != nil {
+		t.Fatalf("failed to create path translator; %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.inDesc, func(t *testing.T) {
+			gotPath, err := r.StringSlice(tt.inElems)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("diff: %v", diff)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error %v, want error of kind %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.wantPath, gotPath); diff != "" {
+				t.Errorf("(-want, +got):\n%s", diff)
+			}
+
+			// Check that the path round-trips through PathElem.
+			gotElems, err := r.PathElem(gotPath)
+			if err != nil {
+				t.Fatalf("PathElem(%v): got unexpected error: %v", gotPath, err)
+			}
+			if !cmp.Equal(gotElems, tt.inElems, cmp.Comparer(proto.Equal)) {
+				t.Errorf("PathElem(%v): got %v, want %v", gotPath, gotElems, tt.inElems)
+			}
+		})
+	}
+}
+
+func TestStrictValidation(t *testing.T) {
+	root := &yang.Entry{Name: "root", Dir: map[string]*yang.Entry{}}
+	lists := &yang.Entry{Name: "simpleKeyedLists", Parent: root, Dir: map[string]*yang.Entry{}}
+	list := &yang.Entry{Name: "simpleKeyedList", Key: "k1", Parent: lists, Dir: map[string]*yang.Entry{}}
+	config := &yang.Entry{Name: "config", Parent: list, Dir: map[string]*yang.Entry{}}
+	config.Dir["leaf"] = &yang.Entry{Name: "leaf", Parent: config}
+	list.Dir["config"] = config
+	lists.Dir["simpleKeyedList"] = list
+	root.Dir["simpleKeyedLists"] = lists
+
+	r, err := NewPathTranslator([]*yang.Entry{root, list}, &StrictValidation{})
+	if err != nil {
+		t.Fatalf("failed to create path translator; %v", err)
+	}
+
+	tests := []struct {
+		inDesc           string
+		inPath           []string
+		wantPath         []*gnmipb.PathElem
+		wantErrSubstring string
+	}{{
+		inDesc: "success path within schema",
+		inPath: []string{"simpleKeyedLists", "simpleKeyedList", "key1", "config", "leaf"},
+		wantPath: []*gnmipb.PathElem{
+			{Name: "simpleKeyedLists"},
+			{Name: "simpleKeyedList", Key: map[string]string{"k1": "key1"}},
+			{Name: "config"},
+			{Name: "leaf"},
+		},
+	}, {
+		inDesc: "success path with wildcards within schema",
+		inPath: []string{"*", "simpleKeyedList", "...", "leaf"},
+		wantPath: []*gnmipb.PathElem{
+			{Name: "*"},
+			{Name: "simpleKeyedList", Key: map[string]string{"k1": "*"}},
+			{Name: "..."},
+			{Name: "leaf"},
+		},
+	}, {
+		inDesc:           "fail unknown element",
+		inPath:           []string{"simpleKeyedLists", "simpleKeyedList", "key1", "state"},
+		wantErrSubstring: "element state of path",
+	}, {
+		inDesc:           "fail unknown element after wildcard",
+		inPath:           []string{"*", "unknown"},
+		wantErrSubstring: "element unknown of path",
+	}, {
+		inDesc:           "fail wildcard matching no element",
+		inPath:           []string{"simpleKeyedLists", "simpleKeyedList", "key1", "config", "leaf", "*"},
+		wantErrSubstring: "element * of path",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.inDesc, func(t *testing.T) {
+			gotPath, err := r.PathElem(tt.inPath)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("diff: %v", diff)
+			}
+			if err != nil {
+				if !errors.Is(err, util.ErrPathNotFound) {
+					t.Errorf("got error %v, want error of kind %v", err, util.ErrPathNotFound)
+				}
+				return
+			}
+			if !cmp.Equal(gotPath, tt.wantPath, cmp.Comparer(proto.Equal)) {
+				t.Errorf("got %v, want %v", gotPath, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestKeyValidation(t *testing.T) {
+	root := &yang.Entry{Name: "root", Dir: map[string]*yang.Entry{}}
+	lists := &yang.Entry{Name: "ports", Parent: root, Dir: map[string]*yang.Entry{}}
+	list := &yang.Entry{Name: "port", Key: "id", Parent: lists, Dir: map[string]*yang.Entry{}}
+	config := &yang.Entry{Name: "config", Parent: list, Dir: map[string]*yang.Entry{}}
+	config.Dir["id"] = &yang.Entry{Name: "id", Parent: config, Type: &yang.YangType{Kind: yang.Yuint16}}
+	list.Dir["id"] = &yang.Entry{Name: "id", Parent: list, Type: &yang.YangType{Kind: yang.Yleafref, Path: "../config/id"}}
+	list.Dir["config"] = config
+	lists.Dir["port"] = list
+	root.Dir["ports"] = lists
+
+	r, err := NewPathTranslator([]*yang.Entry{list}, &KeyValidation{})
+	if err != nil {
+		t.Fatalf("failed to create path translator; %v", err)
+	}
+
+	tests := []struct {
+		inDesc           string
+		inPath           []string
+		inXPath          string
+		wantPath         []*gnmipb.PathElem
+		wantErrSubstring string
+	}{{
+		inDesc:  "success canonicalised key",
+		inPath:  []string{"ports", "port", "080", "config"},
+		inXPath: "/ports/port[id=080]/config",
+		wantPath: []*gnmipb.PathElem{
+			{Name: "ports"},
+			{Name: "port", Key: map[string]string{"id": "80"}},
+			{Name: "config"},
+		},
+	}, {
+		inDesc:  "success wildcard key",
+		inPath:  []string{"ports", "port", "*"},
+		inXPath: "/ports/port[id=*]",
+		wantPath: []*gnmipb.PathElem{
+			{Name: "ports"},
+			{Name: "port", Key: map[string]string{"id": "*"}},
+		},
+	}, {
+		inDesc:           "fail key of wrong type",
+		inPath:           []string{"ports", "port", "abc"},
+		inXPath:          "/ports/port[id=abc]",
+		wantErrSubstring: `invalid value "abc" for key id of /ports/port`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.inDesc, func(t *testing.T) {
+			gotPath, err := r.PathElem(tt.inPath)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("PathElem(%v): %s", tt.inPath, diff)
+			}
+			if err != nil && !errors.Is(err, util.ErrInvalidKey) {
+				t.Errorf("PathElem(%v): got error %v, want error of kind %v", tt.inPath, err, util.ErrInvalidKey)
+			}
+			if !cmp.Equal(gotPath, tt.wantPath, cmp.Comparer(proto.Equal)) {
+				t.Errorf("PathElem(%v): got %v, want %v", tt.inPath, gotPath, tt.wantPath)
+			}
+
+			gotXPath, err := r.PathElemFromXPath(tt.inXPath)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("PathElemFromXPath(%q): %s", tt.inXPath, diff)
+			}
+			if !cmp.Equal(gotXPath, tt.wantPath, cmp.Comparer(proto.Equal)) {
+				t.Errorf("PathElemFromXPath(%q): got %v, want %v", tt.inXPath, gotXPath, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestModulePrefixes(t *testing.T) {
+	schemas := []*yang.Entry{
+		{Name: "root"},
+		{
+			Name: "oc-if:interface",
+			Key:  "oc-if:name",
+			Parent: &yang.Entry{
+				Name: "interfaces",
+				Parent: &yang.Entry{
+					Name: "root",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		inDesc       string
+		inOpts       []PathTranslatorOpt
+		inPath       []string
+		inXPath      string
+		wantPath     []*gnmipb.PathElem
+		wantStrSlice []string
+	}{{
+		inDesc:  "prefixes removed",
+		inPath:  []string{"openconfig-interfaces:interfaces", "interface", "eth0", "oc-if:state"},
+		inXPath: "/openconfig-interfaces:interfaces/interface[oc-if:name=eth0]/oc-if:state",
+		wantPath: []*gnmipb.PathElem{
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": "eth0"}},
+			{Name: "state"},
+		},
+		wantStrSlice: []string{"interfaces", "interface", "eth0", "state"},
+	}, {
+		inDesc:  "prefixes preserved",
+		inOpts:  []PathTranslatorOpt{&PreserveModulePrefixes{}},
+		inPath:  []string{"openconfig-interfaces:interfaces", "interface", "eth0", "oc-if:state"},
+		inXPath: "/openconfig-interfaces:interfaces/interface[name=eth0]/oc-if:state",
+		wantPath: []*gnmipb.PathElem{
+			{Name: "openconfig-interfaces:interfaces"},
+			{Name: "interface", Key: map[string]string{"name": "eth0"}},
+			{Name: "oc-if:state"},
+		},
+		wantStrSlice: []string{"openconfig-interfaces:interfaces", "interface", "eth0", "oc-if:state"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.inDesc, func(t *testing.T) {
+			r, err := NewPathTranslator(schemas, tt.inOpts...)
+			if err != nil {
+				t.Fatalf("failed to create path translator; %v", err)
+			}
+
+			gotPath, err := r.PathElem(tt.inPath)
+			if err != nil {
+				t.Fatalf("PathElem(%v): got unexpected error: %v", tt.inPath, err)
+			}
+			if !cmp.Equal(gotPath, tt.wantPath, cmp.Comparer(proto.Equal)) {
+				t.Errorf("PathElem(%v): got %v, want %v", tt.inPath, gotPath, tt.wantPath)
+			}
+
+			gotXPath, err := r.PathElemFromXPath(tt.inXPath)
+			if err != nil {
+				t.Fatalf("PathElemFromXPath(%q): got unexpected error: %v", tt.inXPath, err)
+			}
+			if !cmp.Equal(gotXPath, tt.wantPath, cmp.Comparer(proto.Equal)) {
+				t.Errorf("PathElemFromXPath(%q): got %v, want %v", tt.inXPath, gotXPath, tt.wantPath)
+			}
+
+			gotStrSlice, err := r.StringSlice(gotPath)
+			if err != nil {
+				t.Fatalf("StringSlice(%v): got unexpected error: %v", gotPath, err)
+			}
+			if diff := cmp.Diff(tt.wantStrSlice, gotStrSlice); diff != "" {
+				t.Errorf("StringSlice(%v): (-want, +got):\n%s", gotPath, diff)
+			}
+		})
+	}
+}
+
 func TestSetWildcardKeys(t *testing.T) {
 	schemas := []*yang.Entry{
 		{Name: "root"},
@@ -291,6 +1043,7 @@ func TestSetWildcardKeys(t *testing.T) {
 		wantPath         []*gnmipb.PathElem
 		wantUpdated      bool
 		wantErrSubstring string
+		wantErrIs        error
 	}{
 		{
 			inDesc:      "success empty path",
@@ -384,6 +1137,7 @@ func TestSetWildcardKeys(t *testing.T) {
 			},
 			wantUpdated:      false,
 			wantErrSubstring: "already has keys",
+			wantErrIs:        util.ErrInvalidKey,
 		},
 	}
 	r, err := NewPathTranslator(schemas)
@@ -397,6 +1151,9 @@ func TestSetWildcardKeys(t *testing.T) {
 				t.Errorf("diff: %v", diff)
 				return
 			}
+			if tc.wantErrIs != nil && !errors.Is(err, tc.wantErrIs) {
+				t.Errorf("got error %v, want error of kind %v", err, tc.wantErrIs)
+			}
 			if updated != tc.wantUpdated {
 				t.Errorf("got matched %v, want %v", updated, tc.wantUpdated)
 			}
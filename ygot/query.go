@@ -0,0 +1,880 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/openconfig/ygot/internal/yreflect"
+	"github.com/openconfig/ygot/util"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// QueryNode is a node of a data tree that is selected by Query.
+type QueryNode struct {
+	// Path is the path of the node from the root of the data tree.
+	Path *gnmipb.Path
+	// Value is the value of the node. For containers and list entries it
+	// is the GoStruct representing the node, or, where the container has
+	// been compressed out of the generated code, the GoStruct holding its
+	// fields. For leaves it is the value of the leaf, with pointers to
+	// scalar values dereferenced, and for leaf-lists it is the value of a
+	// single member of the leaf-list.
+	Value any
+}
+
+// Query returns the nodes of the data tree rooted at root that are selected
+// by the XPath expression expr, in the order in which they appear in the
+// data tree. List entries are ordered by their keys, unless the list is
+// ordered by the user.
+//
+// A subset of XPath 1.0 is supported: location paths consisting of node
+// names, which may have module prefixes, the "*", "." and ".." steps, and
+// predicates; the "and", "or", "=", "!=", "<", "<=", ">" and ">="
+// operators; string and number literals; and the count, current, not,
+// true, false, contains, starts-with, position and last functions. A
+// numeric predicate selects the node at that position. Paths are relative
+// to root, which is also the node returned by current(). Nodes are named as
+// per the schema paths of the fields of the GoStructs, such that a query
+// can navigate to a leaf through a config or state container that has been
+// compressed out of the generated code. For example:
+//
+//	Query(d, "interfaces/interface[state/oper-status='UP']/name")
+//
+// returns the name leaf of each interface whose operational status is UP.
+// It is an error for expr not to evaluate to a set of nodes.
+func Query(root GoStruct, expr string) ([]*QueryNode, error) {
+	rv := reflect.ValueOf(root)
+	if !util.IsValueStructPtr(rv) || util.IsValueNil(rv) {
+		return nil, fmt.Errorf("invalid root %T: must be a non-nil struct pointer", root)
+	}
+	e, err := parseQuery(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query %q: %v", expr, err)
+	}
+	rn := &queryNode{s: rv}
+	v, err := e.eval(&queryContext{node: rn, pos: 1, size: 1, current: rn})
+	if err != nil {
+		return nil, fmt.Errorf("cannot evaluate query %q: %v", expr, err)
+	}
+	nodes, ok := v.([]*queryNode)
+	if !ok {
+		return nil, fmt.Errorf("query %q does not select nodes, got %T result %v", expr, v, v)
+	}
+	var out []*QueryNode
+	for _, n := range nodes {
+		out = append(out, &QueryNode{Path: n.path(), Value: n.value()})
+	}
+	return out, nil
+}
+
+// queryNode is a node of the data tree that a query is evaluated against.
+// Nodes are either containers or list entries, including those that are
+// compressed out of the generated code, or leaves.
+type queryNode struct {
+	// parent is the parent of the node, or nil for the root.
+	parent *queryNode
+	// elem is the path element of the node, or nil for the root.
+	elem *gnmipb.PathElem
+	// s is the GoStruct that holds the fields of the children of a
+	// container or list entry.
+	s reflect.Value
+	// prefix is the path of the node relative to s, which is non-empty
+	// for containers that are compressed out of the generated code.
+	prefix []string
+	// leaf is the value of a leaf, or of a single member of a leaf-list.
+	// It is invalid for containers and list entries.
+	leaf reflect.Value
+	// children caches the children of the node once they are computed.
+	children []*queryNode
+	// expanded indicates whether children has been computed.
+	expanded bool
+}
+
+// path returns the path of n from the root.
+func (n *queryNode) path() *gnmipb.Path {
+	var elems []*gnmipb.PathElem
+	for c := n; c.parent != nil; c = c.parent {
+		elems = append([]*gnmipb.PathElem{c.elem}, elems...)
+	}
+	return &gnmipb.Path{Elem: elems}
+}
+
+// value returns the value of n, as returned within a QueryNode.
+func (n *queryNode) value() any {
+	if !n.leaf.IsValid() {
+		return n.s.Interface()
+	}
+	v := n.leaf
+	if v.Kind() == reflect.Ptr && v.Elem().Kind() != reflect.Struct {
+		v = v.Elem()
+	}
+	return v.Interface()
+}
+
+// stringValue returns the string value of n, as per XPath, which is the
+// value of a leaf, or the empty string for containers and list entries.
+func (n *queryNode) stringValue() string {
+	if !n.leaf.IsValid() {
+		return ""
+	}
+	v := n.leaf
+	if v.Kind() == reflect.Ptr && v.Elem().Kind() != reflect.Struct {
+		v = v.Elem()
+	}
+	s, err := KeyValueAsString(v.Interface())
+	if err != nil {
+		return fmt.Sprint(v.Interface())
+	}
+	return s
+}
+
+// kids returns the children of n, computing them if required.
+func (n *queryNode) kids() ([]*queryNode, error) {
+	if n.expanded || n.leaf.IsValid() {
+		return n.children, nil
+	}
+	n.expanded = true
+	virtual := map[string]bool{}
+	concrete := map[string]bool{}
+	// The fields of extension structs are children of the node in the same
+	// way as the fields of the struct.
+	structs := []reflect.Value{n.s}
+	for _, ext := range util.AttachedExtensions(n.s) {
+		structs = append(structs, ext.Value)
+	}
+	for _, s := range structs {
+		sv := s.Elem()
+		st := sv.Type()
+		for i := 0; i < sv.NumField(); i++ {
+			fv, ft := util.FieldValue(sv, i), st.Field(i)
+			if fv.IsZero() || util.IsYgotAnnotation(ft) || util.IsYgotPresence(ft) || util.IsYgotExtensions(ft) {
+				continue
+			}
+			paths, err := util.SchemaPaths(ft)
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range append(paths, util.ShadowSchemaPaths(ft)...) {
+				if len(p) <= len(n.prefix) || !hasStringPrefix(p, n.prefix) {
+					continue
+				}
+				name := p[len(n.prefix)]
+				if len(p) > len(n.prefix)+1 {
+					if !virtual[name] {
+						virtual[name] = true
+						n.children = append(n.children, &queryNode{
+							parent: n,
+							elem:   &gnmipb.PathElem{Name: name},
+							s:      n.s,
+							prefix: append(append([]string{}, n.prefix...), name),
+						})
+					}
+					continue
+				}
+				if concrete[name] {
+					continue
+				}
+				concrete[name] = true
+				ch, err := n.fieldNodes(name, fv)
+				if err != nil {
+					return nil, err
+				}
+				n.children = append(n.children, ch...)
+			}
+		}
+	}
+	return n.children, nil
+}
+
+// fieldNodes returns the nodes that are children of n named name, whose
+// value is the field v.
+func (n *queryNode) fieldNodes(name string, v reflect.Value) ([]*queryNode, error) {
+	entry := func(ev reflect.Value) (*queryNode, error) {
+		keys, err := PathKeyFromStruct(ev)
+		if err != nil {
+			return nil, fmt.Errorf("cannot extract keys of %s: %v", name, err)
+		}
+		return &queryNode{parent: n, elem: &gnmipb.PathElem{Name: name, Key: keys}, s: ev}, nil
+	}
+
+	var out []*queryNode
+	switch {
+	case v.Kind() == reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return queryKeyLess(keys[i], keys[j]) })
+		for _, k := range keys {
+			c, err := entry(v.MapIndex(k))
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, c)
+		}
+	case isOrderedMap(v):
+		var err error
+		if rerr := yreflect.RangeOrderedMap(v.Interface().(GoOrderedMap), func(_ reflect.Value, ev reflect.Value) bool {
+			var c *queryNode
+			if c, err = entry(ev); err != nil {
+				return false
+			}
+			out = append(out, c)
+			return true
+		}); rerr != nil {
+			return nil, rerr
+		}
+		if err != nil {
+			return nil, err
+		}
+	case v.Kind() == reflect.Ptr && v.Elem().Kind() == reflect.Struct && !isUnionValue(v):
+		out = append(out, &queryNode{parent: n, elem: &gnmipb.PathElem{Name: name}, s: v})
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8:
+		for i := 0; i < v.Len(); i++ {
+			out = append(out, &queryNode{parent: n, elem: &gnmipb.PathElem{Name: name}, leaf: v.Index(i)})
+		}
+	default:
+		if v.Kind() == reflect.Interface {
+			v = v.Elem()
+		}
+		out = append(out, &queryNode{parent: n, elem: &gnmipb.PathElem{Name: name}, leaf: v})
+	}
+	return out, nil
+}
+
+// hasStringPrefix reports whether the first elements of p are prefix.
+func hasStringPrefix(p, prefix []string) bool {
+	if len(p) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if p[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// queryKeyLess reports whether the map key a sorts before b. Numeric keys
+// are sorted numerically, and the keys of lists with multiple keys are
+// sorted by each key in turn. Keys of differing kinds, such as the values of
+// a union, are sorted by their string representation.
+func queryKeyLess(a, b reflect.Value) bool {
+	if a.Kind() != b.Kind() {
+		return fmt.Sprint(a.Interface()) < fmt.Sprint(b.Interface())
+	}
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	case reflect.String:
+		return a.String() < b.String()
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			af, bf := a.Field(i), b.Field(i)
+			if queryKeyLess(af, bf) {
+				return true
+			}
+			if queryKeyLess(bf, af) {
+				return false
+			}
+		}
+		return false
+	}
+	return fmt.Sprint(a.Interface()) < fmt.Sprint(b.Interface())
+}
+
+// isUnionValue reports whether v, which is a pointer to a struct, is the
+// value of a union leaf rather than a GoStruct.
+func isUnionValue(v reflect.Value) bool {
+	_, ok := v.Interface().(GoStruct)
+	return !ok
+}
+
+// queryContext is the context within which an expression is evaluated.
+type queryContext struct {
+	// node is the context node.
+	node *queryNode
+	// pos and size are the context position and size.
+	pos, size int
+	// current is the node returned by current().
+	current *queryNode
+}
+
+// queryExpr is a parsed XPath expression.
+type queryExpr interface {
+	// eval evaluates the expression, returning a []*queryNode, string,
+	// float64 or bool.
+	eval(c *queryContext) (any, error)
+}
+
+// queryBinary is an expression consisting of a binary operator.
+type queryBinary struct {
+	op   string
+	l, r queryExpr
+}
+
+// queryLiteral is a string or number literal.
+type queryLiteral struct {
+	v any
+}
+
+// queryFunc is a function call.
+type queryFunc struct {
+	name string
+	args []queryExpr
+}
+
+// queryPath is a location path, which is relative to the result of base,
+// if it is set, otherwise to the root if abs is set, otherwise to the
+// context node.
+type queryPath struct {
+	base  queryExpr
+	abs   bool
+	steps []*queryStep
+}
+
+// queryStep is a step of a location path.
+type queryStep struct {
+	// name is the name of the nodes that are selected, or "*", "." or "..".
+	name  string
+	preds []queryExpr
+}
+
+func (e *queryLiteral) eval(*queryContext) (any, error) { return e.v, nil }
+
+func (e *queryBinary) eval(c *queryContext) (any, error) {
+	l, err := e.l.eval(c)
+	if err != nil {
+		return nil, err
+	}
+	// The right operand of and and or is only evaluated if required.
+	switch e.op {
+	case "and":
+		if !queryBool(l) {
+			return false, nil
+		}
+	case "or":
+		if queryBool(l) {
+			return true, nil
+		}
+	}
+	r, err := e.r.eval(c)
+	if err != nil {
+		return nil, err
+	}
+	switch e.op {
+	case "and", "or":
+		return queryBool(r), nil
+	}
+	return queryCompare(e.op, l, r), nil
+}
+
+func (e *queryFunc) eval(c *queryContext) (any, error) {
+	var args []any
+	for _, a := range e.args {
+		v, err := a.eval(c)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+	}
+	nargs := map[string]int{
+		"count": 1, "current": 0, "not": 1, "true": 0, "false": 0,
+		"contains": 2, "starts-with": 2, "position": 0, "last": 0,
+	}
+	n, ok := nargs[e.name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported function %s", e.name)
+	}
+	if len(args) != n {
+		return nil, fmt.Errorf("function %s takes %d arguments, got %d", e.name, n, len(args))
+	}
+	switch e.name {
+	case "count":
+		ns, ok := args[0].([]*queryNode)
+		if !ok {
+			return nil, fmt.Errorf("argument of count is not a node-set")
+		}
+		return float64(len(ns)), nil
+	case "current":
+		return []*queryNode{c.current}, nil
+	case "not":
+		return !queryBool(args[0]), nil
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "contains":
+		return strings.Contains(queryString(args[0]), queryString(args[1])), nil
+	case "starts-with":
+		return strings.HasPrefix(queryString(args[0]), queryString(args[1])), nil
+	case "position":
+		return float64(c.pos), nil
+	default: // last
+		return float64(c.size), nil
+	}
+}
+
+func (e *queryPath) eval(c *queryContext) (any, error) {
+	var set []*queryNode
+	switch {
+	case e.base != nil:
+		v, err := e.base.eval(c)
+		if err != nil {
+			return nil, err
+		}
+		ns, ok := v.([]*queryNode)
+		if !ok {
+			return nil, fmt.Errorf("cannot apply path to non node-set %v", v)
+		}
+		set = ns
+	case e.abs:
+		root := c.node
+		for root.parent != nil {
+			root = root.parent
+		}
+		set = []*queryNode{root}
+	default:
+		set = []*queryNode{c.node}
+	}
+
+	for _, s := range e.steps {
+		var next []*queryNode
+		seen := map[*queryNode]bool{}
+		for _, n := range set {
+			cands, err := s.candidates(n)
+			if err != nil {
+				return nil, err
+			}
+			if cands, err = s.filter(c, cands); err != nil {
+				return nil, err
+			}
+			for _, cn := range cands {
+				if !seen[cn] {
+					seen[cn] = true
+					next = append(next, cn)
+				}
+			}
+		}
+		set = next
+	}
+	return set, nil
+}
+
+// candidates returns the nodes that are selected by the step s from n,
+// prior to the application of its predicates.
+func (s *queryStep) candidates(n *queryNode) ([]*queryNode, error) {
+	switch s.name {
+	case ".":
+		return []*queryNode{n}, nil
+	case "..":
+		if n.parent == nil {
+			return nil, nil
+		}
+		return []*queryNode{n.parent}, nil
+	}
+	kids, err := n.kids()
+	if err != nil {
+		return nil, err
+	}
+	if s.name == "*" {
+		return kids, nil
+	}
+	var out []*queryNode
+	for _, k := range kids {
+		if k.elem.GetName() == s.name {
+			out = append(out, k)
+		}
+	}
+	return out, nil
+}
+
+// filter returns the nodes within set for which each predicate of s is
+// true.
+func (s *queryStep) filter(c *queryContext, set []*queryNode) ([]*queryNode, error) {
+	for _, p := range s.preds {
+		var out []*queryNode
+		for i, n := range set {
+			v, err := p.eval(&queryContext{node: n, pos: i + 1, size: len(set), current: c.current})
+			if err != nil {
+				return nil, err
+			}
+			keep := queryBool(v)
+			if f, ok := v.(float64); ok {
+				keep = f == float64(i+1)
+			}
+			if keep {
+				out = append(out, n)
+			}
+		}
+		set = out
+	}
+	return set, nil
+}
+
+// queryBool converts v to a boolean, as per the XPath boolean function.
+func queryBool(v any) bool {
+	switch v := v.(type) {
+	case []*queryNode:
+		return len(v) != 0
+	case string:
+		return v != ""
+	case float64:
+		return v != 0 && !math.IsNaN(v)
+	case bool:
+		return v
+	}
+	return false
+}
+
+// queryString converts v to a string, as per the XPath string function.
+func queryString(v any) string {
+	switch v := v.(type) {
+	case []*queryNode:
+		if len(v) == 0 {
+			return ""
+		}
+		return v[0].stringValue()
+	case string:
+		return v
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
+
+// queryNumber converts v to a number, as per the XPath number function.
+func queryNumber(v any) float64 {
+	switch v := v.(type) {
+	case float64:
+		return v
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(queryString(v)), 64)
+	if err != nil {
+		return math.NaN()
+	}
+	return f
+}
+
+// queryCompare compares l and r using the comparison operator op, as per
+// XPath, where a comparison involving a node-set is true if it is true for
+// any node within the set.
+func queryCompare(op string, l, r any) bool {
+	if ln, ok := l.([]*queryNode); ok {
+		if _, ok := r.(bool); ok {
+			return queryCompare(op, queryBool(ln), r)
+		}
+		for _, n := range ln {
+			if queryCompare(op, n.stringValue(), r) {
+				return true
+			}
+		}
+		return false
+	}
+	if rn, ok := r.([]*queryNode); ok {
+		if _, ok := l.(bool); ok {
+			return queryCompare(op, l, queryBool(rn))
+		}
+		for _, n := range rn {
+			if queryCompare(op, l, n.stringValue()) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if op == "=" || op == "!=" {
+		var eq bool
+		_, lb := l.(bool)
+		_, rb := r.(bool)
+		_, lf := l.(float64)
+		_, rf := r.(float64)
+		switch {
+		case lb || rb:
+			eq = queryBool(l) == queryBool(r)
+		case lf || rf:
+			eq = queryNumber(l) == queryNumber(r)
+		default:
+			eq = queryString(l) == queryString(r)
+		}
+		return eq == (op == "=")
+	}
+	lf, rf := queryNumber(l), queryNumber(r)
+	switch op {
+	case "<":
+		return lf < rf
+	case "<=":
+		return lf <= rf
+	case ">":
+		return lf > rf
+	default: // >=
+		return lf >= rf
+	}
+}
+
+// queryParser is a recursive descent parser for the supported subset of
+// XPath.
+type queryParser struct {
+	toks []string
+	pos  int
+}
+
+// parseQuery parses the XPath expression expr.
+func parseQuery(expr string) (queryExpr, error) {
+	toks, err := lexQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{toks: toks}
+	e, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.toks) {
+		return nil, fmt.Errorf("unexpected %q", p.toks[p.pos])
+	}
+	return e, nil
+}
+
+// peek returns the next token, or the empty string at the end of the
+// expression.
+func (p *queryParser) peek() string {
+	if p.pos >= len(p.toks) {
+		return ""
+	}
+	return p.toks[p.pos]
+}
+
+// expect consumes the next token, returning an error if it is not t.
+func (p *queryParser) expect(t string) error {
+	if got := p.peek(); got != t {
+		if got == "" {
+			return fmt.Errorf("expected %q, got end of query", t)
+		}
+		return fmt.Errorf("expected %q, got %q", t, got)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *queryParser) or() (queryExpr, error) {
+	return p.binary(p.and, "or")
+}
+
+func (p *queryParser) and() (queryExpr, error) {
+	return p.binary(p.equality, "and")
+}
+
+func (p *queryParser) equality() (queryExpr, error) {
+	return p.binary(p.relational, "=", "!=")
+}
+
+func (p *queryParser) relational() (queryExpr, error) {
+	return p.binary(p.primary, "<", "<=", ">", ">=")
+}
+
+// binary parses a left-associative sequence of operands, parsed by next,
+// separated by any of ops.
+func (p *queryParser) binary(next func() (queryExpr, error), ops ...string) (queryExpr, error) {
+	l, err := next()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		if !containsString(ops, op) {
+			return l, nil
+		}
+		p.pos++
+		r, err := next()
+		if err != nil {
+			return nil, err
+		}
+		l = &queryBinary{op: op, l: l, r: r}
+	}
+}
+
+// primary parses a literal, parenthesised expression, function call or
+// location path.
+func (p *queryParser) primary() (queryExpr, error) {
+	t := p.peek()
+	switch {
+	case t == "":
+		return nil, fmt.Errorf("unexpected end of query")
+	case t[0] == '\'' || t[0] == '"':
+		p.pos++
+		return &queryLiteral{v: t[1 : len(t)-1]}, nil
+	case t[0] >= '0' && t[0] <= '9':
+		p.pos++
+		f, err := strconv.ParseFloat(t, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", t)
+		}
+		return &queryLiteral{v: f}, nil
+	case t == "(":
+		p.pos++
+		e, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return e, p.expect(")")
+	case isQueryName(t) && p.pos+1 < len(p.toks) && p.toks[p.pos+1] == "(":
+		p.pos += 2
+		f := &queryFunc{name: t}
+		for p.peek() != ")" {
+			if len(f.args) != 0 {
+				if err := p.expect(","); err != nil {
+					return nil, err
+				}
+			}
+			a, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			f.args = append(f.args, a)
+		}
+		p.pos++
+		if p.peek() != "/" {
+			return f, nil
+		}
+		p.pos++
+		return p.path(&queryPath{base: f})
+	case t == "/":
+		p.pos++
+		qp := &queryPath{abs: true}
+		if !isQueryStep(p.peek()) {
+			return qp, nil
+		}
+		return p.path(qp)
+	case isQueryStep(t):
+		return p.path(&queryPath{})
+	}
+	return nil, fmt.Errorf("unexpected %q", t)
+}
+
+// path parses the steps of a location path into qp.
+func (p *queryParser) path(qp *queryPath) (queryExpr, error) {
+	for {
+		t := p.peek()
+		if !isQueryStep(t) {
+			return nil, fmt.Errorf("expected path step, got %q", t)
+		}
+		p.pos++
+		s := &queryStep{name: util.StripModulePrefix(t)}
+		for p.peek() == "[" {
+			p.pos++
+			e, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			s.preds = append(s.preds, e)
+		}
+		qp.steps = append(qp.steps, s)
+		if p.peek() != "/" {
+			return qp, nil
+		}
+		p.pos++
+	}
+}
+
+// isQueryName reports whether the token t is a name.
+func isQueryName(t string) bool {
+	return t != "" && t != "." && t != ".." && (unicode.IsLetter(rune(t[0])) || t[0] == '_')
+}
+
+// isQueryStep reports whether the token t is a step of a location path.
+func isQueryStep(t string) bool {
+	return isQueryName(t) || t == "*" || t == "." || t == ".."
+}
+
+// lexQuery splits the XPath expression expr into tokens.
+func lexQuery(expr string) ([]string, error) {
+	var toks []string
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case strings.HasPrefix(expr[i:], "!=") || strings.HasPrefix(expr[i:], "<=") || strings.HasPrefix(expr[i:], ">="):
+			toks = append(toks, expr[i:i+2])
+			i += 2
+		case strings.ContainsRune("/[]()=<>*,", rune(c)):
+			toks = append(toks, string(c))
+			i++
+		case c == '\'' || c == '"':
+			j := strings.IndexByte(expr[i+1:], c)
+			if j < 0 {
+				return nil, fmt.Errorf("unterminated string literal at offset %d", i)
+			}
+			toks = append(toks, expr[i:i+j+2])
+			i += j + 2
+		case c == '.' && strings.HasPrefix(expr[i:], ".."):
+			toks = append(toks, "..")
+			i += 2
+		case c == '.' && (i+1 == len(expr) || expr[i+1] < '0' || expr[i+1] > '9'):
+			toks = append(toks, ".")
+			i++
+		case c >= '0' && c <= '9' || c == '.':
+			j := i
+			for j < len(expr) && (expr[j] >= '0' && expr[j] <= '9' || expr[j] == '.') {
+				j++
+			}
+			toks = append(toks, expr[i:j])
+			i = j
+		case unicode.IsLetter(rune(c)) || c == '_':
+			j := i
+			for j < len(expr) && (unicode.IsLetter(rune(expr[j])) || unicode.IsDigit(rune(expr[j])) || strings.ContainsRune("-_.:", rune(expr[j]))) {
+				j++
+			}
+			toks = append(toks, expr[i:j])
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q at offset %d", c, i)
+		}
+	}
+	return toks, nil
+}
+
+// containsString reports whether s is within ss.
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
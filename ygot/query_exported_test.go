@@ -0,0 +1,160 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func queryTestDevice(t *testing.T) *exampleoc.Device {
+	d := &exampleoc.Device{}
+	for _, i := range []struct {
+		name   string
+		status exampleoc.E_Interface_OperStatus
+		mtu    uint16
+		subifs []uint32
+	}{
+		{"eth0", exampleoc.Interface_OperStatus_UP, 1500, []uint32{0, 1, 10}},
+		{"eth1", exampleoc.Interface_OperStatus_DOWN, 9000, []uint32{0}},
+		{"lo0", exampleoc.Interface_OperStatus_UP, 1500, nil},
+	} {
+		intf := d.GetOrCreateInterface(i.name)
+		intf.OperStatus = i.status
+		intf.Mtu = ygot.Uint16(i.mtu)
+		for _, s := range i.subifs {
+			intf.GetOrCreateSubinterface(s)
+		}
+	}
+	d.GetOrCreateSystem().Hostname = ygot.String("eth1")
+	if _, err := d.GetOrCreateSystem().GetOrCreateDns().GetOrCreateServerMap().AppendNew("192.0.2.2"); err != nil {
+		t.Fatalf("cannot append DNS server: %v", err)
+	}
+	if _, err := d.GetOrCreateSystem().GetOrCreateDns().GetOrCreateServerMap().AppendNew("192.0.2.1"); err != nil {
+		t.Fatalf("cannot append DNS server: %v", err)
+	}
+	return d
+}
+
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inQuery          string
+		wantPaths        []string
+		wantValues       []any
+		wantErrSubstring string
+	}{{
+		desc:       "predicate on descendant leaf",
+		inQuery:    "interfaces/interface[state/oper-status='UP']/name",
+		wantPaths:  []string{"/interfaces/interface[name=eth0]/name", "/interfaces/interface[name=lo0]/name"},
+		wantValues: []any{"eth0", "lo0"},
+	}, {
+		desc:       "leaf through compressed container",
+		inQuery:    "/interfaces/interface[name='eth1']/config/mtu",
+		wantPaths:  []string{"/interfaces/interface[name=eth1]/config/mtu"},
+		wantValues: []any{uint16(9000)},
+	}, {
+		desc:       "enumerated value",
+		inQuery:    "interfaces/interface[config/mtu > 1500]/state/oper-status",
+		wantPaths:  []string{"/interfaces/interface[name=eth1]/state/oper-status"},
+		wantValues: []any{exampleoc.Interface_OperStatus_DOWN},
+	}, {
+		desc:      "count",
+		inQuery:   "interfaces/interface[count(subinterfaces/subinterface) > 1]",
+		wantPaths: []string{"/interfaces/interface[name=eth0]"},
+	}, {
+		desc:      "list entries ordered numerically by key",
+		inQuery:   "interfaces/interface[name='eth0']/subinterfaces/subinterface",
+		wantPaths: []string{"/interfaces/interface[name=eth0]/subinterfaces/subinterface[index=0]", "/interfaces/interface[name=eth0]/subinterfaces/subinterface[index=1]", "/interfaces/interface[name=eth0]/subinterfaces/subinterface[index=10]"},
+	}, {
+		desc:      "last",
+		inQuery:   "interfaces/interface[last()]",
+		wantPaths: []string{"/interfaces/interface[name=lo0]"},
+	}, {
+		desc:      "position",
+		inQuery:   "interfaces/interface[name='eth0']/subinterfaces/subinterface[position() = 2]",
+		wantPaths: []string{"/interfaces/interface[name=eth0]/subinterfaces/subinterface[index=1]"},
+	}, {
+		desc:      "numeric predicate",
+		inQuery:   "interfaces/interface[2]",
+		wantPaths: []string{"/interfaces/interface[name=eth1]"},
+	}, {
+		desc:      "current",
+		inQuery:   "interfaces/interface[name = current()/system/config/hostname]",
+		wantPaths: []string{"/interfaces/interface[name=eth1]"},
+	}, {
+		desc:      "and, or and not",
+		inQuery:   "interfaces/interface[(config/mtu = 1500 and not(starts-with(name, 'lo'))) or contains(name, '1')]",
+		wantPaths: []string{"/interfaces/interface[name=eth0]", "/interfaces/interface[name=eth1]"},
+	}, {
+		desc:      "parent and wildcard",
+		inQuery:   "interfaces/*/subinterfaces/subinterface[index=10]/../..",
+		wantPaths: []string{"/interfaces/interface[name=eth0]"},
+	}, {
+		desc:       "ordered list with module prefixes",
+		inQuery:    "/oc-sys:system/dns/servers/server/address",
+		wantPaths:  []string{"/system/dns/servers/server[address=192.0.2.2]/address", "/system/dns/servers/server[address=192.0.2.1]/address"},
+		wantValues: []any{"192.0.2.2", "192.0.2.1"},
+	}, {
+		desc:    "no match",
+		inQuery: "interfaces/interface[name='eth9']",
+	}, {
+		desc:             "not a node-set",
+		inQuery:          "count(interfaces/interface)",
+		wantErrSubstring: "does not select nodes",
+	}, {
+		desc:             "unsupported function",
+		inQuery:          "interfaces/interface[translate(name, 'e', 'E')]",
+		wantErrSubstring: "unsupported function translate",
+	}, {
+		desc:             "unterminated predicate",
+		inQuery:          "interfaces/interface[name='eth0'",
+		wantErrSubstring: `expected "]"`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ygot.Query(queryTestDevice(t), tt.inQuery)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("Query(%s): did not get expected error, %s", tt.inQuery, diff)
+			}
+			if err != nil {
+				return
+			}
+			var gotPaths []string
+			var gotValues []any
+			for _, n := range got {
+				p, err := ygot.PathToString(n.Path)
+				if err != nil {
+					t.Fatalf("PathToString(%v): got unexpected error: %v", n.Path, err)
+				}
+				gotPaths = append(gotPaths, p)
+				gotValues = append(gotValues, n.Value)
+			}
+			if diff := cmp.Diff(tt.wantPaths, gotPaths); diff != "" {
+				t.Errorf("Query(%s): did not get expected paths, diff(-want, +got):\n%s", tt.inQuery, diff)
+			}
+			if tt.wantValues != nil {
+				if diff := cmp.Diff(tt.wantValues, gotValues); diff != "" {
+					t.Errorf("Query(%s): did not get expected values, diff(-want, +got):\n%s", tt.inQuery, diff)
+				}
+			}
+		})
+	}
+}
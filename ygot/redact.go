@@ -0,0 +1,355 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"google.golang.org/protobuf/proto"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// RedactedValue is the value that the values of sensitive leaves are
+// replaced with by a Redactor.
+const RedactedValue = "<redacted>"
+
+// redactExtension is a YANG extension that marks nodes as sensitive.
+type redactExtension struct {
+	// module is the name of the module that defines the extension, or the
+	// prefix used to refer to it.
+	module string
+	// name is the name of the extension.
+	name string
+}
+
+// Redactor identifies sensitive leaves, such as passwords and SNMP
+// communities, and replaces their values with RedactedValue such that they
+// are not written to logs or debugging output. A leaf is sensitive if it,
+// or one of its ancestors, is marked by a registered YANG extension, or if
+// its path matches a registered path.
+//
+// A Redactor can be supplied as an option to EmitJSONWithOpts and Diff, or
+// within the EmitJSONConfig and GNMINotificationsConfig used by EmitJSON and
+// TogNMINotifications, in which case the values of sensitive leaves within
+// their output are redacted. The data trees that they are supplied are not
+// modified.
+//
+// Redactor is safe for concurrent use.
+type Redactor struct {
+	// root is the schema of the root of the data trees that are redacted.
+	root *yang.Entry
+
+	// mu protects the registered extensions and paths.
+	mu sync.RWMutex
+	// exts is the set of extensions that mark nodes as sensitive.
+	exts []*redactExtension
+	// paths is the set of paths, which may contain wildcards, of sensitive
+	// nodes.
+	paths []*gnmipb.Path
+}
+
+// NewRedactor returns a Redactor that redacts no leaves. root is the schema
+// of the root of the data trees that are redacted, and is used to find the
+// extensions that are used within the schema of each leaf. It may be nil, in
+// which case only leaves whose paths are registered are redacted.
+func NewRedactor(root *yang.Entry) *Redactor {
+	return &Redactor{root: root}
+}
+
+// IsDiffOpt marks Redactor as a valid Diff option.
+func (*Redactor) IsDiffOpt() {}
+
+// IsEmitJSONOpt marks Redactor as a valid EmitJSONWithOpts option.
+func (*Redactor) IsEmitJSONOpt() {}
+
+// RegisterExtension registers the YANG extension named name, defined by
+// module, as marking the nodes that it is used within as sensitive. module
+// may either be the name of the defining module, or the prefix used to refer
+// to it; the schema that is stored within generated code retains only the
+// prefix.
+func (r *Redactor) RegisterExtension(module, name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exts = append(r.exts, &redactExtension{module: module, name: name})
+}
+
+// RegisterPath registers the node at path, which is relative to the root of
+// the data tree and may contain wildcards, as sensitive, along with its
+// descendants.
+func (r *Redactor) RegisterPath(path *gnmipb.Path) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.paths = append(r.paths, util.StripGNMIPathModulePrefixes(path))
+}
+
+// IsSensitive reports whether the node at path, which is relative to the root
+// of the data tree, is sensitive.
+func (r *Redactor) IsSensitive(path *gnmipb.Path) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	path = util.StripGNMIPathModulePrefixes(path)
+	_, marked := r.schema(path)
+	return marked || r.matches(path)
+}
+
+// RedactJSON replaces the values of the sensitive leaves within v, which is
+// the JSON rendering of the root of a data tree in either RFC7951 or
+// Internal format, as returned by ConstructIETFJSON and
+// ConstructInternalJSON, with RedactedValue. v is modified in place.
+func (r *Redactor) RedactJSON(v map[string]any) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	r.redactObject(r.root, &gnmipb.Path{}, false, v)
+}
+
+// RedactNotification returns a copy of n in which the values of the updates
+// of sensitive leaves are replaced with RedactedValue. Where the value of an
+// update is a JSON document, the sensitive leaves within it are redacted.
+// The paths of the updates, when joined to the prefix of n, must be relative
+// to the root of the data tree. Updates whose paths use the deprecated
+// element field, rather than PathElem messages, cannot be matched, hence
+// are always redacted.
+func (r *Redactor) RedactNotification(n *gnmipb.Notification) *gnmipb.Notification {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := proto.Clone(n).(*gnmipb.Notification)
+	for _, u := range out.GetUpdate() {
+		if len(u.GetPath().GetElement()) != 0 || len(out.GetPrefix().GetElement()) != 0 {
+			u.Val = redactedTypedValue()
+			continue
+		}
+		path, err := util.JoinPaths(out.GetPrefix(), u.GetPath())
+		if err != nil {
+			u.Val = redactedTypedValue()
+			continue
+		}
+		path = util.StripGNMIPathModulePrefixes(path)
+		u.Val = r.redactTypedValue(path, u.GetVal())
+	}
+	return out
+}
+
+// redactValue returns the value v of the node at path, which is relative
+// to the root of the data tree, with the values of sensitive leaves
+// redacted.
+func (r *Redactor) redactValue(path *gnmipb.Path, v *gnmipb.TypedValue) *gnmipb.TypedValue {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.redactTypedValue(util.StripGNMIPathModulePrefixes(path), v)
+}
+
+// redactTypedValue returns the value v of the node at path, with the
+// values of sensitive leaves redacted.
+func (r *Redactor) redactTypedValue(path *gnmipb.Path, v *gnmipb.TypedValue) *gnmipb.TypedValue {
+	e, marked := r.schema(path)
+	var b []byte
+	switch tv := v.GetValue().(type) {
+	case *gnmipb.TypedValue_JsonIetfVal:
+		b = tv.JsonIetfVal
+	case *gnmipb.TypedValue_JsonVal:
+		b = tv.JsonVal
+	default:
+		if marked || r.matches(path) {
+			return redactedTypedValue()
+		}
+		return v
+	}
+
+	var jv any
+	if err := json.Unmarshal(b, &jv); err != nil {
+		// A value that cannot be inspected is redacted, since it may
+		// contain a sensitive leaf.
+		return redactedTypedValue()
+	}
+	switch jt := jv.(type) {
+	case map[string]any:
+		if e != nil && e.IsList() && len(path.GetElem()) != 0 && len(path.GetElem()[len(path.GetElem())-1].GetKey()) == 0 {
+			// An Internal format list, keyed by the keys of its
+			// entries.
+			r.redactList(e, &gnmipb.Path{Elem: path.GetElem()[:len(path.GetElem())-1]}, marked, jt)
+		} else {
+			r.redactObject(e, path, marked, jt)
+		}
+	case []any:
+		if e == nil || !e.IsList() {
+			if marked || r.matches(path) {
+				return redactedTypedValue()
+			}
+			return v
+		}
+		r.redactList(e, &gnmipb.Path{Elem: path.GetElem()[:len(path.GetElem())-1]}, marked, jt)
+	default:
+		if marked || r.matches(path) {
+			return redactedTypedValue()
+		}
+		return v
+	}
+	nb, err := json.Marshal(jv)
+	if err != nil {
+		return redactedTypedValue()
+	}
+	if _, ok := v.GetValue().(*gnmipb.TypedValue_JsonIetfVal); ok {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: nb}}
+	}
+	return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonVal{JsonVal: nb}}
+}
+
+// redactObject redacts the sensitive leaves within obj, which is the JSON
+// rendering of the contents of the directory e, which may be nil if it is
+// unknown, at path. marked indicates that the directory is marked as
+// sensitive by an extension.
+func (r *Redactor) redactObject(e *yang.Entry, path *gnmipb.Path, marked bool, obj map[string]any) {
+	for k, v := range obj {
+		name := util.StripModulePrefix(k)
+		ch := childSchema(e, name)
+		chMarked := marked || r.marked(ch)
+		switch {
+		case ch != nil && ch.IsList():
+			r.redactList(ch, path, chMarked, v)
+		case ch != nil && ch.IsDir(), ch == nil && isJSONObject(v):
+			if m, ok := v.(map[string]any); ok {
+				r.redactObject(ch, appendPathElem(path, name, nil), chMarked, m)
+			}
+		default:
+			if chMarked || r.matches(appendPathElem(path, name, nil)) {
+				obj[k] = RedactedValue
+			}
+		}
+	}
+}
+
+// redactList redacts the sensitive leaves within v, which is the JSON
+// rendering of the list e whose parent is at path. In RFC7951 format, v is
+// an array of the entries of the list, whereas in Internal format it is an
+// object whose members are the entries of the list, keyed by their keys.
+func (r *Redactor) redactList(e *yang.Entry, path *gnmipb.Path, marked bool, v any) {
+	var entries []any
+	switch v := v.(type) {
+	case []any:
+		entries = v
+	case map[string]any:
+		for _, ev := range v {
+			entries = append(entries, ev)
+		}
+	}
+	for _, ev := range entries {
+		obj, ok := ev.(map[string]any)
+		if !ok {
+			continue
+		}
+		r.redactObject(e, appendPathElem(path, e.Name, entryKeys(e, obj)), marked, obj)
+	}
+}
+
+// schema returns the schema of the node at path, or nil if it cannot be
+// found, and whether it, or one of its ancestors, is marked as sensitive by
+// an extension.
+func (r *Redactor) schema(path *gnmipb.Path) (*yang.Entry, bool) {
+	e := r.root
+	marked := r.marked(e)
+	for _, pe := range path.GetElem() {
+		if e = childSchema(e, pe.GetName()); e == nil {
+			return nil, marked
+		}
+		marked = marked || r.marked(e)
+	}
+	return e, marked
+}
+
+// marked reports whether the schema entry e, which may be nil, is marked as
+// sensitive by a registered extension.
+func (r *Redactor) marked(e *yang.Entry) bool {
+	if e == nil {
+		return false
+	}
+	for _, x := range r.exts {
+		if util.HasExtension(e, x.module, x.name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether path matches a registered path.
+func (r *Redactor) matches(path *gnmipb.Path) bool {
+	for _, q := range r.paths {
+		if util.PathMatchesQuery(path, q) {
+			return true
+		}
+	}
+	return false
+}
+
+// childSchema returns the schema of the data node child of the directory e
+// named name, or nil if e is nil or has no such child.
+func childSchema(e *yang.Entry, name string) *yang.Entry {
+	if e == nil || !e.IsDir() {
+		return nil
+	}
+	for _, ch := range util.FindFirstNonChoiceOrCase(e) {
+		if ch.Name == name {
+			return ch
+		}
+	}
+	return nil
+}
+
+// entryKeys returns the keys of obj, which is the JSON rendering of an entry
+// of the list e, as they are used within a gNMI path.
+func entryKeys(e *yang.Entry, obj map[string]any) map[string]string {
+	keys := map[string]string{}
+	for _, k := range strings.Fields(e.Key) {
+		for n, v := range obj {
+			if util.StripModulePrefix(n) == k {
+				keys[k] = fmt.Sprint(v)
+			}
+		}
+	}
+	return keys
+}
+
+// appendPathElem returns a copy of path with an element appended to it.
+func appendPathElem(path *gnmipb.Path, name string, keys map[string]string) *gnmipb.Path {
+	elems := append(append([]*gnmipb.PathElem{}, path.GetElem()...), &gnmipb.PathElem{Name: name, Key: keys})
+	return &gnmipb.Path{Elem: elems}
+}
+
+// isJSONObject reports whether v is a JSON object.
+func isJSONObject(v any) bool {
+	_, ok := v.(map[string]any)
+	return ok
+}
+
+// redactedTypedValue returns a TypedValue containing RedactedValue.
+func redactedTypedValue() *gnmipb.TypedValue {
+	return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: RedactedValue}}
+}
+
+// hasRedactor returns the first Redactor from an opts slice, or nil if
+// there isn't one.
+func hasRedactor(opts []DiffOpt) *Redactor {
+	for _, o := range opts {
+		if v, ok := o.(*Redactor); ok && v != nil {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func newRedactor() *ygot.Redactor {
+	r := ygot.NewRedactor(exampleoc.SchemaTree["Device"])
+	r.RegisterPath(mustPath("/interfaces/interface[name=*]/config/description"))
+	return r
+}
+
+func newRedactDevice(desc string) *exampleoc.Device {
+	d := &exampleoc.Device{}
+	i := d.GetOrCreateInterface("eth0")
+	i.Description = ygot.String(desc)
+	i.Mtu = ygot.Uint16(1500)
+	return d
+}
+
+func TestRedactorEmitJSON(t *testing.T) {
+	for _, format := range []ygot.JSONFormat{ygot.RFC7951, ygot.Internal} {
+		d := newRedactDevice("secret")
+		got, err := ygot.EmitJSONWithOpts(d, format, newRedactor())
+		if err != nil {
+			t.Fatalf("EmitJSONWithOpts(format %v): got unexpected error: %v", format, err)
+		}
+		if strings.Contains(got, "secret") {
+			t.Errorf("EmitJSONWithOpts(format %v): got unredacted value:\n%s", format, got)
+		}
+		if !strings.Contains(got, ygot.RedactedValue) || !strings.Contains(got, "1500") {
+			t.Errorf("EmitJSONWithOpts(format %v): got %s, want redacted description and unredacted MTU", format, got)
+		}
+		if d.GetInterface("eth0").GetDescription() != "secret" {
+			t.Errorf("EmitJSONWithOpts(format %v) modified its input", format)
+		}
+	}
+}
+
+func TestRedactorDiff(t *testing.T) {
+	n, err := ygot.Diff(newRedactDevice("old"), newRedactDevice("new"), newRedactor())
+	if err != nil {
+		t.Fatalf("Diff: got unexpected error: %v", err)
+	}
+	want := &gnmipb.Notification{
+		Update: []*gnmipb.Update{{
+			Path: mustPath("/interfaces/interface[name=eth0]/config/description"),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: ygot.RedactedValue}},
+		}},
+	}
+	if diff := cmp.Diff(want, n, protocmp.Transform()); diff != "" {
+		t.Errorf("Diff: did not get expected notification, diff(-want, +got):\n%s", diff)
+	}
+}
+
+func TestRedactorTogNMINotifications(t *testing.T) {
+	ns, err := ygot.TogNMINotifications(newRedactDevice("secret"), 42, ygot.GNMINotificationsConfig{UsePathElem: true, Redactor: newRedactor()})
+	if err != nil {
+		t.Fatalf("TogNMINotifications: got unexpected error: %v", err)
+	}
+	var redacted, mtu bool
+	for _, n := range ns {
+		for _, u := range n.GetUpdate() {
+			p, err := ygot.PathToString(&gnmipb.Path{Elem: append(append([]*gnmipb.PathElem{}, n.GetPrefix().GetElem()...), u.GetPath().GetElem()...)})
+			if err != nil {
+				t.Fatalf("PathToString: got unexpected error: %v", err)
+			}
+			switch p {
+			case "/interfaces/interface[name=eth0]/config/description":
+				redacted = u.GetVal().GetStringVal() == ygot.RedactedValue
+			case "/interfaces/interface[name=eth0]/config/mtu":
+				mtu = u.GetVal().GetUintVal() == 1500
+			}
+		}
+	}
+	if !redacted || !mtu {
+		t.Errorf("TogNMINotifications: got %v, want redacted description and unredacted MTU", ns)
+	}
+}
+
+// sensitiveSchema returns a schema in which the password leaf, and the
+// keys container, are marked by the ex:sensitive extension.
+func sensitiveSchema() *yang.Entry {
+	sensitive := []*yang.Statement{{Keyword: "ex:sensitive"}}
+	root := &yang.Entry{Name: "device", Kind: yang.DirectoryEntry, Dir: map[string]*yang.Entry{}}
+	system := &yang.Entry{Name: "system", Kind: yang.DirectoryEntry, Parent: root, Dir: map[string]*yang.Entry{}}
+	root.Dir["system"] = system
+	system.Dir["hostname"] = &yang.Entry{Name: "hostname", Kind: yang.LeafEntry, Parent: system}
+	system.Dir["password"] = &yang.Entry{Name: "password", Kind: yang.LeafEntry, Parent: system, Exts: sensitive}
+	keys := &yang.Entry{Name: "keys", Kind: yang.DirectoryEntry, Parent: system, Exts: sensitive, Dir: map[string]*yang.Entry{}}
+	system.Dir["keys"] = keys
+	keys.Dir["key"] = &yang.Entry{Name: "key", Kind: yang.LeafEntry, Parent: keys}
+	return root
+}
+
+func TestRedactorExtension(t *testing.T) {
+	r := ygot.NewRedactor(sensitiveSchema())
+	r.RegisterExtension("ex", "sensitive")
+
+	v := map[string]any{
+		"ex:system": map[string]any{
+			"hostname": "r1",
+			"password": "hunter2",
+			"keys":     map[string]any{"key": "abc"},
+		},
+	}
+	r.RedactJSON(v)
+	want := map[string]any{
+		"ex:system": map[string]any{
+			"hostname": "r1",
+			"password": ygot.RedactedValue,
+			"keys":     map[string]any{"key": ygot.RedactedValue},
+		},
+	}
+	if diff := cmp.Diff(want, v); diff != "" {
+		t.Errorf("RedactJSON: did not get expected JSON, diff(-want, +got):\n%s", diff)
+	}
+
+	for path, want := range map[string]bool{
+		"/system/hostname": false,
+		"/system/password": true,
+		"/system/keys/key": true,
+	} {
+		if got := r.IsSensitive(mustPath(path)); got != want {
+			t.Errorf("IsSensitive(%s): got %v, want %v", path, got, want)
+		}
+	}
+
+	n := &gnmipb.Notification{
+		Prefix: mustPath("/system"),
+		Update: []*gnmipb.Update{{
+			Path: mustPath("/hostname"),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "r1"}},
+		}, {
+			Path: mustPath("/password"),
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "hunter2"}},
+		}, {
+			Path: &gnmipb.Path{},
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"hostname":"r1","password":"hunter2"}`)}},
+		}},
+	}
+	got := r.RedactNotification(n)
+	if s := got.GetUpdate()[0].GetVal().GetStringVal(); s != "r1" {
+		t.Errorf("RedactNotification: got hostname %q, want r1", s)
+	}
+	if s := got.GetUpdate()[1].GetVal().GetStringVal(); s != ygot.RedactedValue {
+		t.Errorf("RedactNotification: got password %q, want %q", s, ygot.RedactedValue)
+	}
+	var obj map[string]any
+	if err := json.Unmarshal(got.GetUpdate()[2].GetVal().GetJsonIetfVal(), &obj); err != nil {
+		t.Fatalf("RedactNotification: got invalid JSON value: %v", err)
+	}
+	if diff := cmp.Diff(map[string]any{"hostname": "r1", "password": ygot.RedactedValue}, obj); diff != "" {
+		t.Errorf("RedactNotification: did not get expected JSON value, diff(-want, +got):\n%s", diff)
+	}
+	if s := n.GetUpdate()[1].GetVal().GetStringVal(); s != "hunter2" {
+		t.Errorf("RedactNotification modified its input, got password %q", s)
+	}
+}
@@ -354,6 +354,11 @@ type GNMINotificationsConfig struct {
 	// prefix that concatenates the given prefix with the relative path of
 	// the ordered map from the given node.
 	PathElemPrefix []*gnmipb.PathElem
+	// Redactor, if set, specifies that the values of the sensitive leaves
+	// that it identifies are replaced with RedactedValue within the
+	// output notifications. The paths of the notifications, including
+	// their prefix, must then be relative to the root of the data tree.
+	Redactor *Redactor
 }
 
 // TogNMINotifications takes an input GoStruct and renders it to slice of
@@ -391,6 +396,11 @@ func TogNMINotifications(s GoStruct, ts int64, cfg GNMINotificationsConfig) ([]*
 		return nil, err
 	}
 
+	if cfg.Redactor != nil {
+		for i, m := range msgs {
+			msgs[i] = cfg.Redactor.RedactNotification(m)
+		}
+	}
 	return msgs, nil
 }
 
@@ -481,9 +491,16 @@ func findUpdatedLeaves(leaves any, s GoStruct, parent *gnmiPath, preferShadowPat
 	stype := sval.Type()
 
 	for i := 0; i < sval.NumField(); i++ {
-		fval := sval.Field(i)
+		fval := util.FieldValue(sval, i)
 		ftype := stype.Field(i)
 
+		// The presence bitmap is not data, and has been used to determine
+		// whether the fields that it covers are set. Extension structs are
+		// handled once the fields of the struct have been.
+		if util.IsYgotPresence(ftype) || util.IsYgotExtensions(ftype) {
+			continue
+		}
+
 		// Handle nil values, and enumerations specifically.
 		switch fval.Kind() {
 		case reflect.Map, reflect.Slice, reflect.Ptr, reflect.Interface:
@@ -580,6 +597,11 @@ func findUpdatedLeaves(leaves any, s GoStruct, parent *gnmiPath, preferShadowPat
 			}
 		}
 	}
+	// The leaves of extension structs have the same parent path as the
+	// leaves of the struct.
+	for _, ext := range util.AttachedExtensions(sval) {
+		errs.Add(findUpdatedLeaves(leaves, ext.Value.Interface().(GoStruct), parent, preferShadowPath))
+	}
 	return errs.Err()
 }
 
@@ -709,7 +731,7 @@ func KeyValueAsString(v any) (string, error) {
 	if _, isEnum := v.(GoEnum); isEnum {
 		name, _, err := enumFieldToString(kv, false)
 		if err != nil {
-			return "", fmt.Errorf("cannot resolve enumerated type in key, got err: %v", err)
+			return "", util.KindErrorf(util.ErrInvalidKey, "cannot resolve enumerated type in key, got err: %v", err)
 		}
 		return name, nil
 	}
@@ -733,10 +755,10 @@ func KeyValueAsString(v any) (string, error) {
 		if kv.Type().Elem().Kind() == reflect.Uint8 {
 			return binaryBase64(kv.Bytes()), nil
 		}
-		return "", fmt.Errorf("cannot convert slice of type %v to a string for use in a key: %v", kv.Type().Elem().Kind(), v)
+		return "", util.KindErrorf(util.ErrInvalidKey, "cannot convert slice of type %v to a string for use in a key: %v", kv.Type().Elem().Kind(), v)
 	}
 
-	return "", fmt.Errorf("cannot convert type %v to a string for use in a key: %v", kv.Kind(), v)
+	return "", util.KindErrorf(util.ErrInvalidKey, "cannot convert type %v to a string for use in a key: %v", kv.Kind(), v)
 }
 
 // sliceToScalarArray takes an input slice of empty interfaces and converts it to
@@ -874,6 +896,9 @@ func EncodeTypedValue(val any, enc gnmipb.Encoding, opts ...EncodeTypedValueOpt)
 		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BytesVal{BytesVal: vv.Bytes()}}, nil
 	case vv.Type().Name() == EmptyTypeName:
 		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{BoolVal: vv.Bool()}}, nil
+	case vv.Type() == bitsType:
+		// Bits are encoded using their lexical representation.
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: val.(Bits).String()}}, nil
 	case vv.Kind() == reflect.Slice:
 		sval, err := leaflistToSlice(vv, false)
 		if err != nil {
@@ -900,6 +925,12 @@ func EncodeTypedValue(val any, enc gnmipb.Encoding, opts ...EncodeTypedValueOpt)
 		if util.IsNilOrInvalidValue(vv) {
 			return nil, nil
 		}
+		if vv.Type().Name() == EmptyTypeName {
+			// A pointer to an empty value is used for an empty leaf whose
+			// presence is recorded explicitly, and indicates that the leaf
+			// is present.
+			return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{BoolVal: true}}, nil
+		}
 	default:
 		if underlyingType, ok := unionSingletonUnderlyingTypes[vv.Type().Name()]; ok {
 			if !vv.Type().ConvertibleTo(underlyingType) {
@@ -1032,7 +1063,12 @@ func leaflistToSlice(val reflect.Value, prependModuleNameIref bool) ([]any, erro
 			}
 		case reflect.Slice:
 			// The only time we can have a slice within a leaf-list is when
-			// the type of the field is a binary - such that we have a [][]byte field.
+			// the type of the field is a binary or bits - such that we have
+			// a [][]byte or []Bits field.
+			if e.Type() == bitsType {
+				sval = append(sval, e.Interface().(Bits).String())
+				continue
+			}
 			if e.Type().Name() != BinaryTypeName {
 				return nil, fmt.Errorf("unknown type within a slice: %v", e.Type().Name())
 			}
@@ -1085,6 +1121,9 @@ func appendTypedValue(l []any, v reflect.Value, prependModuleNameIref bool) ([]a
 	case reflect.Bool:
 		return append(l, ival.(bool)), nil
 	case reflect.Slice:
+		if v.Type() == bitsType {
+			return append(l, ival.(Bits).String()), nil
+		}
 		if v.Type().Name() != BinaryTypeName {
 			return nil, fmt.Errorf("unknown type within a slice: %v", v.Type().Name())
 		}
@@ -1177,8 +1216,9 @@ func (JSONIndent) IsMarshal7951Arg() {}
 // The rendered JSON is returned as a byte slice - in common with json.Marshal.
 func Marshal7951(d any, args ...Marshal7951Arg) ([]byte, error) {
 	var (
-		rfcCfg *RFC7951JSONConfig
-		indent string
+		rfcCfg           *RFC7951JSONConfig
+		indent           string
+		preferShadowPath bool
 	)
 	for _, a := range args {
 		switch v := a.(type) {
@@ -1186,8 +1226,18 @@ func Marshal7951(d any, args ...Marshal7951Arg) ([]byte, error) {
 			rfcCfg = v
 		case JSONIndent:
 			indent = string(v)
+		case *PreferShadowPath:
+			preferShadowPath = true
 		}
 	}
+	if preferShadowPath {
+		c := &RFC7951JSONConfig{}
+		if rfcCfg != nil {
+			*c = *rfcCfg
+		}
+		c.PreferShadowPath = true
+		rfcCfg = c
+	}
 	j, err := jsonValue(reflect.ValueOf(d), "", jsonOutputConfig{
 		jType:         RFC7951,
 		rfc7951Config: rfcCfg,
@@ -1303,9 +1353,13 @@ func structJSON(s GoStruct, parentMod string, args jsonOutputConfig) (map[string
 	jsonout := map[string]any{}
 
 	for i := 0; i < sval.NumField(); i++ {
-		field := sval.Field(i)
+		field := util.FieldValue(sval, i)
 		fType := stype.Field(i)
 
+		if util.IsYgotPresence(fType) || util.IsYgotExtensions(fType) {
+			continue
+		}
+
 		// Module names to prepend to the path in RFC7951 output mode.
 		var prependmods [][]string
 		var chMod string
@@ -1403,6 +1457,19 @@ func structJSON(s GoStruct, parentMod string, args jsonOutputConfig) (map[string
 		}
 	}
 
+	// The JSON of extension structs is merged into that of the struct,
+	// since their fields are children of the same node.
+	for _, ext := range util.AttachedExtensions(sval) {
+		ej, err := structJSON(ext.Value.Interface().(GoStruct), parentMod, args)
+		if err != nil {
+			errs.Add(err)
+			continue
+		}
+		if jsonout, err = MergeJSON(jsonout, ej); err != nil {
+			errs.Add(err)
+		}
+	}
+
 	if errs.Err() != nil {
 		return nil, errs.Err()
 	}
@@ -1645,6 +1712,16 @@ func jsonValue(field reflect.Value, parentMod string, args jsonOutputConfig) (an
 				errs.Add(err)
 			}
 		default:
+			if field.Elem().Type().Name() == EmptyTypeName {
+				// A pointer to an empty value is used for an empty leaf
+				// whose presence is recorded explicitly, and indicates
+				// that the leaf is present.
+				value = true
+				if args.jType == RFC7951 {
+					value = []any{nil}
+				}
+				break
+			}
 			value = field.Elem().Interface()
 			if args.jType == RFC7951 {
 				value = writeIETFScalarJSON(value)
@@ -1794,6 +1871,11 @@ func jsonSlice(field reflect.Value, parentMod string, args jsonOutputConfig) (an
 		// which must be returned as a JSON string.
 		return binaryBase64(field.Bytes()), nil
 	}
+	if field.Type() == bitsType {
+		// Bits are represented by a JSON string containing their
+		// space-separated names.
+		return field.Interface().(Bits).String(), nil
+	}
 
 	// In the case that the field is a slice of struct pointers then this
 	// was an unkeyed YANG list.
@@ -1834,12 +1916,25 @@ func jsonSlice(field reflect.Value, parentMod string, args jsonOutputConfig) (an
 
 // jsonAnnotationSlice takes a reflect.Value which must represent a
 // ygot Annotation field ([]ygot.Annotation), and marshals it to JSON to be
-// included in the output JSON.
+// included in the output JSON. A field that contains only Metadata is
+// marshalled as a single RFC 7952 metadata object.
 func jsonAnnotationSlice(v reflect.Value) (any, error) {
 	if v.Len() == 0 {
 		return nil, nil
 	}
 
+	if md, ok := mergedMetadata(v); ok {
+		jv, err := md.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("cannot marshal metadata annotation: %v", err)
+		}
+		var nv any
+		if err := json.Unmarshal(jv, &nv); err != nil {
+			return nil, fmt.Errorf("metadata annotation could not be unmarshalled from JSON: %v", err)
+		}
+		return nv, nil
+	}
+
 	vals := []any{}
 	for i := 0; i < v.Len(); i++ {
 		fv := v.Index(i).Interface().(Annotation)
@@ -1860,6 +1955,24 @@ func jsonAnnotationSlice(v reflect.Value) (any, error) {
 	return vals, nil
 }
 
+// mergedMetadata returns a Metadata containing the annotations of each
+// Metadata within v, which must represent a ygot Annotation field, and true
+// if v contains only Metadata. Where multiple Metadata set the same
+// annotation, the value within the last is used.
+func mergedMetadata(v reflect.Value) (*Metadata, bool) {
+	md := &Metadata{}
+	for i := 0; i < v.Len(); i++ {
+		m, ok := v.Index(i).Interface().(*Metadata)
+		if !ok || m == nil {
+			return nil, false
+		}
+		for n, val := range m.Values {
+			md.Set(n, val)
+		}
+	}
+	return md, true
+}
+
 // unwrapUnionInterfaceValue takes an input reflect.Value which must contain
 // an interface Value, and resolves it from the generated wrapper union struct
 // to the value which should be used for the YANG leaf.
@@ -30,6 +30,7 @@ import (
 	"strings"
 
 	"github.com/openconfig/gnmi/errlist"
+	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/internal/yreflect"
 	"github.com/openconfig/ygot/util"
 )
@@ -400,19 +401,48 @@ type EmitJSONConfig struct {
 	// validation rules in the case that a partially populated data instance is
 	// to be emitted.
 	ValidationOpts []ValidationOption
+	// WithDefaults specifies the mode in which leaves whose value is their
+	// default are emitted, as per ApplyWithDefaults. By default, the
+	// GoStruct is emitted as it is stored.
+	WithDefaults WithDefaultsMode
+	// WithDefaultsSchema is the schema of the GoStruct supplied to EmitJSON,
+	// from which the defaults that are applied by WithDefaults are read.
+	// If it is nil, the defaults are populated by PopulateDefaults.
+	WithDefaultsSchema *yang.Entry
+	// Redactor, if set, specifies that the values of the sensitive leaves
+	// that it identifies are replaced with RedactedValue within the JSON
+	// output.
+	Redactor *Redactor
 }
 
 // EmitJSON takes an input GoStruct (produced by ygen with validation enabled)
 // and serialises it to a JSON string. By default, produces the Internal format JSON.
+//
+// EmitJSONWithOpts accepts the same configuration as a set of options.
 func EmitJSON(gs GoStruct, opts *EmitJSONConfig) (string, error) {
+	return emitJSON(gs, opts)
+}
+
+// emitJSON serialises the GoStruct gs to a JSON string according to the
+// configuration opts, which may be nil.
+func emitJSON(gs GoStruct, opts *EmitJSONConfig) (_ string, err error) {
+	done := util.StartOperation(util.OpEmitJSON)
+	defer func() { done(err) }()
 	var (
 		vopts          []ValidationOption
 		skipValidation bool
+		stored         = gs
 	)
 
 	if opts != nil {
 		vopts = opts.ValidationOpts
 		skipValidation = opts.SkipValidation
+		if opts.WithDefaults != WithDefaultsExplicit {
+			var err error
+			if gs, err = ApplyWithDefaults(opts.WithDefaultsSchema, gs, opts.WithDefaults); err != nil {
+				return "", err
+			}
+		}
 	}
 
 	if !skipValidation {
@@ -425,6 +455,20 @@ func EmitJSON(gs GoStruct, opts *EmitJSONConfig) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if opts != nil && opts.WithDefaults == WithDefaultsReportAllTagged {
+		trimmed, err := ApplyWithDefaults(opts.WithDefaultsSchema, stored, WithDefaultsTrim)
+		if err != nil {
+			return "", err
+		}
+		tv, err := makeJSON(trimmed, opts)
+		if err != nil {
+			return "", err
+		}
+		tagJSONDefaults(v, tv)
+	}
+	if opts != nil && opts.Redactor != nil {
+		opts.Redactor.RedactJSON(v)
+	}
 
 	sb := &strings.Builder{}
 	enc := json.NewEncoder(sb)
@@ -572,6 +616,37 @@ type MergeEmptyMaps struct{}
 // IsMergeOpt marks MergeEmptyMaps as a MergeOpt.
 func (*MergeEmptyMaps) IsMergeOpt() {}
 
+// MergeAnnotationMode specifies how the annotations of a struct are
+// combined by the MergeStructs and MergeStructInto functions.
+type MergeAnnotationMode int64
+
+const (
+	// MergeAnnotationsAppend specifies that the annotations of the source
+	// struct are appended to those of the destination struct. It is the
+	// behaviour used when no MergeAnnotations option is supplied.
+	MergeAnnotationsAppend MergeAnnotationMode = iota
+	// MergeAnnotationsKeepDestination specifies that the annotations of the
+	// destination struct are retained, and those of the source struct are
+	// used only when the destination struct has none.
+	MergeAnnotationsKeepDestination
+	// MergeAnnotationsKeepSource specifies that the annotations of the
+	// source struct replace those of the destination struct, which are
+	// retained only when the source struct has none.
+	MergeAnnotationsKeepSource
+)
+
+// MergeAnnotations is a MergeOpt that allows control of how the fields of
+// the MergeStructs and MergeStructInto functions that hold ygot.Annotation
+// values are combined when they are populated in both the destination and
+// source structs.
+type MergeAnnotations struct {
+	// Mode is the way in which the annotations are combined.
+	Mode MergeAnnotationMode
+}
+
+// IsMergeOpt marks MergeAnnotations as a MergeOpt.
+func (*MergeAnnotations) IsMergeOpt() {}
+
 // MergeStructs takes two input GoStruct and merges their contents,
 // returning a new GoStruct. If the input structs a and b are of
 // different types, an error is returned.
@@ -659,6 +734,18 @@ func mergeEmptyMapsEnabled(opts []MergeOpt) bool {
 	return false
 }
 
+// mergeAnnotationMode returns the mode of the first MergeAnnotations in
+// the slice of MergeOpt, or MergeAnnotationsAppend if there is none.
+func mergeAnnotationMode(opts []MergeOpt) MergeAnnotationMode {
+	for _, o := range opts {
+		switch v := o.(type) {
+		case *MergeAnnotations:
+			return v.Mode
+		}
+	}
+	return MergeAnnotationsAppend
+}
+
 // copyStruct copies the fields of srcVal into the dstVal struct in-place.
 //
 // - accessPath is the programmatic access path to the struct. It is used for
@@ -678,44 +765,70 @@ func copyStruct(dstVal, srcVal reflect.Value, accessPath string, opts ...MergeOp
 	var errs errlist.Error
 	errs.Separator = "\n"
 	for i := 0; i < srcVal.NumField(); i++ {
-		srcField := srcVal.Field(i)
-		dstField := dstVal.Field(i)
-		accessPath := accessPath + "." + srcVal.Type().Field(i).Name
-
-		orderedMap, isOrderedMap := srcField.Interface().(GoOrderedMap)
-		switch srcField.Kind() {
-		case reflect.Ptr:
-			if isOrderedMap {
-				errs.Add(copyOrderedMap(dstField, orderedMap, accessPath, opts...))
-			} else {
-				errs.Add(copyPtrField(dstField, srcField, accessPath, opts...))
-			}
-		case reflect.Interface:
-			errs.Add(copyInterfaceField(dstField, srcField, accessPath, opts...))
-		case reflect.Map:
-			errs.Add(copyMapField(dstField, srcField, accessPath, opts...))
-		case reflect.Slice:
-			errs.Add(copySliceField(dstField, srcField, accessPath, opts...))
-		case reflect.Int64:
-			// In the case of an int64 field, which represents a YANG enumeration
-			// we should only set the value in the destination if it is not set
-			// to the default value in the source.
-			vSrc, vDst := srcField.Int(), dstField.Int()
-			switch {
-			case vSrc != 0 && vDst != 0 && vSrc != vDst:
-				if !fieldOverwriteEnabled(opts) {
-					errs.Add(fmt.Errorf("%s: destination and source values were set when merging enum field, dst: %d, src: %d", accessPath, vSrc, vDst))
-					break
-				}
-				dstField.Set(srcField)
-			case vSrc != 0 && vDst == 0:
-				dstField.Set(srcField)
-			}
+		errs.Add(copyField(dstVal, srcVal, i, accessPath, opts...))
+	}
+	return errs.Err()
+}
+
+// copyField copies the field with index i of the struct srcVal into the
+// same field of the struct dstVal, which must be of the same type.
+// accessPath is the programmatic access path to the struct, as per
+// copyStruct.
+func copyField(dstVal, srcVal reflect.Value, i int, accessPath string, opts ...MergeOpt) error {
+	srcField := srcVal.Field(i)
+	dstField := dstVal.Field(i)
+	sf := srcVal.Type().Field(i)
+	accessPath = accessPath + "." + sf.Name
+
+	// The presence bitmap is copied along with the leaves that it covers.
+	if util.IsYgotPresence(sf) {
+		return nil
+	}
+	if _, ok := util.PresenceBit(sf); ok {
+		return copyPresenceField(dstVal, srcVal, sf, accessPath, opts...)
+	}
+	if util.IsYgotExtensions(sf) {
+		return copyExtensionsField(dstField, srcField, accessPath, opts...)
+	}
+
+	orderedMap, isOrderedMap := srcField.Interface().(GoOrderedMap)
+	switch srcField.Kind() {
+	case reflect.Ptr:
+		if isOrderedMap {
+			return copyOrderedMap(dstField, orderedMap, accessPath, opts...)
+		}
+		return copyPtrField(dstField, srcField, accessPath, opts...)
+	case reflect.Interface:
+		return copyInterfaceField(dstField, srcField, accessPath, opts...)
+	case reflect.Map:
+		return copyMapField(dstField, srcField, accessPath, opts...)
+	case reflect.Slice:
+		switch {
+		case util.IsYgotAnnotation(sf):
+			return copyAnnotationField(dstField, srcField, accessPath, opts...)
+		case util.IsOrderedByUser(sf):
+			return copyOrderedLeafListField(dstField, srcField, accessPath, opts...)
 		default:
+			return copySliceField(dstField, srcField, accessPath, opts...)
+		}
+	case reflect.Int64:
+		// In the case of an int64 field, which represents a YANG enumeration
+		// we should only set the value in the destination if it is not set
+		// to the default value in the source.
+		vSrc, vDst := srcField.Int(), dstField.Int()
+		switch {
+		case vSrc != 0 && vDst != 0 && vSrc != vDst:
+			if !fieldOverwriteEnabled(opts) {
+				return fmt.Errorf("%s: destination and source values were set when merging enum field, dst: %d, src: %d", accessPath, vSrc, vDst)
+			}
+			dstField.Set(srcField)
+		case vSrc != 0 && vDst == 0:
 			dstField.Set(srcField)
 		}
+	default:
+		dstField.Set(srcField)
 	}
-	return errs.Err()
+	return nil
 }
 
 // copyPtrField copies srcField to dstField. srcField and dstField must be
@@ -768,6 +881,62 @@ func copyPtrField(dstField, srcField reflect.Value, accessPath string, opts ...M
 	return nil
 }
 
+// copyPresenceField copies the leaf stored in field sf of the struct srcVal,
+// whose presence is recorded in the struct's presence bitmap, to the struct
+// dstVal if it is set. If the leaf is set in both structs, an error is
+// returned unless the values are equal or overwriting is enabled.
+func copyPresenceField(dstVal, srcVal reflect.Value, sf reflect.StructField, accessPath string, opts ...MergeOpt) error {
+	if !util.IsLeafSet(srcVal, sf) {
+		return nil
+	}
+	srcField, dstField := srcVal.FieldByIndex(sf.Index), dstVal.FieldByIndex(sf.Index)
+	if util.IsLeafSet(dstVal, sf) {
+		s, d := srcField.Interface(), dstField.Interface()
+		if !fieldOverwriteEnabled(opts) && !reflect.DeepEqual(s, d) {
+			return fmt.Errorf("%s: destination value was set, but was not equal to source value when merging field, src: %v, dst: %v", accessPath, s, d)
+		}
+	}
+	dstField.Set(srcField)
+	return util.SetLeafPresence(dstVal, sf, true)
+}
+
+// copyExtensionsField copies the extension structs attached to a struct,
+// held in srcField, into dstField. An extension struct that is attached in
+// both is merged with that of the same type in dstField.
+func copyExtensionsField(dstField, srcField reflect.Value, accessPath string, opts ...MergeOpt) error {
+	src, ok := srcField.Interface().(Extensions)
+	if !ok {
+		return fmt.Errorf("%s: received a non-extensions type in extensions field: %v", accessPath, srcField.Type())
+	}
+	if len(src) == 0 {
+		return nil
+	}
+	dst, _ := dstField.Interface().(Extensions)
+	if dst == nil {
+		dst = Extensions{}
+	}
+	errs := &errlist.Error{}
+	errs.Separator = "\n"
+	for name, ext := range src {
+		sv := reflect.ValueOf(ext)
+		if util.IsNilOrInvalidValue(sv) {
+			errs.Add(fmt.Errorf("%s[%q]: got nil extension", accessPath, name))
+			continue
+		}
+		d := reflect.New(sv.Type().Elem())
+		if de, ok := dst[name]; ok && !util.IsNilOrInvalidValue(reflect.ValueOf(de)) {
+			d = reflect.ValueOf(de)
+		}
+		if err := copyStruct(d.Elem(), sv.Elem(), fmt.Sprintf("%s[%q]", accessPath, name), opts...); err != nil {
+			errs.Add(err)
+			continue
+		}
+		dst[name] = d.Interface().(ExtensionStruct)
+	}
+	dstField.Set(reflect.ValueOf(dst).Convert(dstField.Type()))
+	return errs.Err()
+}
+
 // copyInterfaceField copies srcField into dstField. Both srcField and dstField
 // are reflect.Value structs which contain an interface value.
 func copyInterfaceField(dstField, srcField reflect.Value, accessPath string, opts ...MergeOpt) error {
@@ -1079,6 +1248,52 @@ func copySliceField(dstField, srcField reflect.Value, accessPath string, opts ..
 	return errs.Err()
 }
 
+// copyAnnotationField copies srcField, which is a slice of annotations, into
+// dstField. Where both fields are populated, they are combined according to
+// the mode of the MergeAnnotations option within opts, such that the
+// annotations of srcField are appended to those of dstField by default.
+// The annotations themselves are not copied.
+func copyAnnotationField(dstField, srcField reflect.Value, accessPath string, opts ...MergeOpt) error {
+	if srcField.Len() == 0 {
+		return nil
+	}
+
+	switch mode := mergeAnnotationMode(opts); mode {
+	case MergeAnnotationsAppend:
+	case MergeAnnotationsKeepDestination:
+		if dstField.Len() != 0 {
+			return nil
+		}
+	case MergeAnnotationsKeepSource:
+		dstField.Set(reflect.MakeSlice(srcField.Type(), 0, srcField.Len()))
+	default:
+		return fmt.Errorf("%s: invalid annotation merge mode %d", accessPath, mode)
+	}
+
+	dstField.Set(reflect.AppendSlice(dstField, srcField))
+	return nil
+}
+
+// copyOrderedLeafListField copies srcField, which represents an `ordered-by
+// user` leaf-list, to dstField. Since the order of the elements of such a
+// leaf-list is significant, it is merged as a single value rather than as a
+// set, such that its order is preserved: if dstField is unset, or overwriting
+// existing fields is enabled, it is replaced with a copy of srcField, and
+// otherwise an error is returned unless both contain the same elements in the
+// same order.
+func copyOrderedLeafListField(dstField, srcField reflect.Value, accessPath string, opts ...MergeOpt) error {
+	if srcField.Len() == 0 || reflect.DeepEqual(srcField.Interface(), dstField.Interface()) {
+		return nil
+	}
+
+	if dstField.Len() != 0 && !fieldOverwriteEnabled(opts) {
+		return fmt.Errorf("%s: destination and source values were set, but were not equal when merging ordered-by user leaf-list field, src: %v, dst: %v", accessPath, srcField, dstField)
+	}
+
+	dstField.Set(reflect.AppendSlice(reflect.MakeSlice(srcField.Type(), 0, srcField.Len()), srcField))
+	return nil
+}
+
 // uniqueSlices takes two reflect.Values which must represent slices, and determines
 // whether a and b are disjoint. It returns true if the slices have unique
 // members, and false if not.
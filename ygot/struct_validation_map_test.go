@@ -1892,6 +1892,75 @@ var mergeStructTests = []struct {
 			&ExampleAnnotation{ConfigSource: "devicedemo"},
 		},
 	},
+}, {
+	name: "merge annotations: keep destination",
+	inA: &validatedMergeTestWithAnnotationSlice{
+		SliceField: []Annotation{&ExampleAnnotation{ConfigSource: "a"}},
+	},
+	inB: &validatedMergeTestWithAnnotationSlice{
+		SliceField: []Annotation{&ExampleAnnotation{ConfigSource: "b"}},
+	},
+	inOpts: []MergeOpt{&MergeAnnotations{Mode: MergeAnnotationsKeepDestination}},
+	want: &validatedMergeTestWithAnnotationSlice{
+		SliceField: []Annotation{&ExampleAnnotation{ConfigSource: "a"}},
+	},
+}, {
+	name: "merge annotations: keep destination, unset in destination",
+	inA:  &validatedMergeTestWithAnnotationSlice{},
+	inB: &validatedMergeTestWithAnnotationSlice{
+		SliceField: []Annotation{&ExampleAnnotation{ConfigSource: "b"}},
+	},
+	inOpts: []MergeOpt{&MergeAnnotations{Mode: MergeAnnotationsKeepDestination}},
+	want: &validatedMergeTestWithAnnotationSlice{
+		SliceField: []Annotation{&ExampleAnnotation{ConfigSource: "b"}},
+	},
+}, {
+	name: "merge annotations: keep source",
+	inA: &validatedMergeTestWithAnnotationSlice{
+		SliceField: []Annotation{&ExampleAnnotation{ConfigSource: "a"}},
+	},
+	inB: &validatedMergeTestWithAnnotationSlice{
+		SliceField: []Annotation{&ExampleAnnotation{ConfigSource: "b"}},
+	},
+	inOpts: []MergeOpt{&MergeAnnotations{Mode: MergeAnnotationsKeepSource}},
+	want: &validatedMergeTestWithAnnotationSlice{
+		SliceField: []Annotation{&ExampleAnnotation{ConfigSource: "b"}},
+	},
+}, {
+	name: "merge annotations: keep source, unset in source",
+	inA: &validatedMergeTestWithAnnotationSlice{
+		SliceField: []Annotation{&ExampleAnnotation{ConfigSource: "a"}},
+	},
+	inB:    &validatedMergeTestWithAnnotationSlice{},
+	inOpts: []MergeOpt{&MergeAnnotations{Mode: MergeAnnotationsKeepSource}},
+	want: &validatedMergeTestWithAnnotationSlice{
+		SliceField: []Annotation{&ExampleAnnotation{ConfigSource: "a"}},
+	},
+}, {
+	name: "merge annotations: append",
+	inA: &validatedMergeTestWithAnnotationSlice{
+		SliceField: []Annotation{&ExampleAnnotation{ConfigSource: "a"}},
+	},
+	inB: &validatedMergeTestWithAnnotationSlice{
+		SliceField: []Annotation{&ExampleAnnotation{ConfigSource: "b"}},
+	},
+	inOpts: []MergeOpt{&MergeAnnotations{Mode: MergeAnnotationsAppend}},
+	want: &validatedMergeTestWithAnnotationSlice{
+		SliceField: []Annotation{
+			&ExampleAnnotation{ConfigSource: "a"},
+			&ExampleAnnotation{ConfigSource: "b"},
+		},
+	},
+}, {
+	name: "error - merge annotations: invalid mode",
+	inA: &validatedMergeTestWithAnnotationSlice{
+		SliceField: []Annotation{&ExampleAnnotation{ConfigSource: "a"}},
+	},
+	inB: &validatedMergeTestWithAnnotationSlice{
+		SliceField: []Annotation{&ExampleAnnotation{ConfigSource: "b"}},
+	},
+	inOpts:  []MergeOpt{&MergeAnnotations{Mode: 42}},
+	wantErr: "invalid annotation merge mode 42",
 }, {
 	name: "error - merge fields with slice with duplicate strings",
 	inA: &validatedMergeTestWithSlice{
@@ -2185,6 +2254,87 @@ func TestMergeStructs(t *testing.T) {
 	}
 }
 
+// mergeLeafListTest is a GoStruct that has both an `ordered-by user` and an
+// `ordered-by system` leaf-list.
+type mergeLeafListTest struct {
+	Ordered   []string `path:"ordered" orderedByUser:"true"`
+	Unordered []string `path:"unordered"`
+}
+
+func (*mergeLeafListTest) ΛValidate(...ValidationOption) error     { return nil }
+func (*mergeLeafListTest) IsYANGGoStruct()                         {}
+func (*mergeLeafListTest) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*mergeLeafListTest) ΛBelongingModule() string                { return "" }
+
+func TestMergeOrderedByUserLeafList(t *testing.T) {
+	tests := []struct {
+		name    string
+		inA     *mergeLeafListTest
+		inB     *mergeLeafListTest
+		inOpts  []MergeOpt
+		want    *mergeLeafListTest
+		wantErr string
+	}{{
+		name: "ordered leaf-list set in source only",
+		inA:  &mergeLeafListTest{},
+		inB:  &mergeLeafListTest{Ordered: []string{"c", "a", "b"}},
+		want: &mergeLeafListTest{Ordered: []string{"c", "a", "b"}},
+	}, {
+		name: "ordered leaf-list set in destination only",
+		inA:  &mergeLeafListTest{Ordered: []string{"c", "a", "b"}},
+		inB:  &mergeLeafListTest{},
+		want: &mergeLeafListTest{Ordered: []string{"c", "a", "b"}},
+	}, {
+		name: "equal ordered leaf-lists",
+		inA:  &mergeLeafListTest{Ordered: []string{"c", "a"}},
+		inB:  &mergeLeafListTest{Ordered: []string{"c", "a"}},
+		want: &mergeLeafListTest{Ordered: []string{"c", "a"}},
+	}, {
+		name:    "reordered leaf-lists",
+		inA:     &mergeLeafListTest{Ordered: []string{"a", "c"}},
+		inB:     &mergeLeafListTest{Ordered: []string{"c", "a"}},
+		wantErr: "were not equal when merging ordered-by user leaf-list field",
+	}, {
+		name:    "disjoint ordered leaf-lists",
+		inA:     &mergeLeafListTest{Ordered: []string{"a"}},
+		inB:     &mergeLeafListTest{Ordered: []string{"b"}},
+		wantErr: "were not equal when merging ordered-by user leaf-list field",
+	}, {
+		name:   "reordered leaf-lists with overwrite",
+		inA:    &mergeLeafListTest{Ordered: []string{"a", "c"}},
+		inB:    &mergeLeafListTest{Ordered: []string{"c", "b", "a"}},
+		inOpts: []MergeOpt{&MergeOverwriteExistingFields{}},
+		want:   &mergeLeafListTest{Ordered: []string{"c", "b", "a"}},
+	}, {
+		name: "disjoint unordered leaf-lists are appended",
+		inA:  &mergeLeafListTest{Unordered: []string{"a"}},
+		inB:  &mergeLeafListTest{Unordered: []string{"b"}},
+		want: &mergeLeafListTest{Unordered: []string{"a", "b"}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergeStructs(tt.inA, tt.inB, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("MergeStructs(%v, %v): did not get expected error status, %s", tt.inA, tt.inB, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("MergeStructs(%v, %v): did not get expected returned struct, diff(-want,+got):\n%s", tt.inA, tt.inB, diff)
+			}
+			// The source must not share its backing array with the result.
+			if len(tt.inB.Ordered) != 0 {
+				tt.inB.Ordered[0] = "modified"
+				if diff := cmp.Diff(tt.want, got); diff != "" {
+					t.Errorf("MergeStructs(%v, %v): result was changed by modifying the source, diff(-want,+got):\n%s", tt.inA, tt.inB, diff)
+				}
+			}
+		})
+	}
+}
+
 func TestMergeStructInto(t *testing.T) {
 	for _, tt := range mergeStructTests {
 		// Make a copy of inA here since it will get mutated.
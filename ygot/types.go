@@ -167,6 +167,22 @@ type EnumDefinition struct {
 	// ordering of the identityref name, starting with 0 to be consistent
 	// with goyang's enumeration numbering.
 	Value int
+	// Description is the argument of the description statement of the
+	// value in the YANG schema. It is populated within generated code only
+	// when enumeration descriptions are generated.
+	Description string
+	// Status is the argument of the status statement of the value in the
+	// YANG schema, i.e., "current", "deprecated" or "obsolete", or the
+	// empty string if the value has no status statement. It is populated
+	// within generated code only when enumeration descriptions are
+	// generated.
+	Status string
+}
+
+// Deprecated reports whether the status of the enumerated value is
+// "deprecated" or "obsolete".
+func (e EnumDefinition) Deprecated() bool {
+	return e.Status == "deprecated" || e.Status == "obsolete"
 }
 
 // Annotation defines an interface that is implemented by optional metadata
@@ -183,8 +199,9 @@ type EnumDefinition struct {
 // arbitrary types implementing the Annotation interface.
 //
 // Each Annotation must implement the MarshalJSON and UnmarshalJSON methods,
-// such that its content can be serialised and deserialised from JSON. Using
-// the approach described in RFC7952 can be used to store metadata within
+// such that its content can be serialised and deserialised from JSON. The
+// Metadata type implements Annotation using the approach described in
+// RFC7952, such that standard metadata can be stored within
 // RFC7951-serialised JSON.
 type Annotation interface {
 	// MarshalJSON is used to marshal the annotation to JSON. It ensures that
@@ -0,0 +1,588 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/gnmi/errlist"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/internal/yreflect"
+	"github.com/openconfig/ygot/util"
+)
+
+// WithDefaultsMode specifies how leaves whose value is their schema default
+// are reported, as per the with-defaults modes of RFC 6243.
+type WithDefaultsMode int
+
+const (
+	// WithDefaultsExplicit reports the leaves that are set within a
+	// GoStruct, regardless of whether their value is the default. Since a
+	// GoStruct does not distinguish a leaf that is explicitly set to its
+	// default from one that is set by PopulateDefaults, it is equivalent
+	// to reporting the GoStruct as it is stored.
+	WithDefaultsExplicit WithDefaultsMode = iota
+	// WithDefaultsTrim omits the leaves whose value is their default.
+	WithDefaultsTrim
+	// WithDefaultsReportAll reports leaves that are not set with their
+	// default value.
+	WithDefaultsReportAll
+	// WithDefaultsReportAllTagged reports leaves in the same manner as
+	// WithDefaultsReportAll. Where the output is JSON or YAML, each leaf
+	// whose value is its default is additionally tagged with the
+	// "ietf-netconf-with-defaults:default" metadata annotation of RFC 6243
+	// section 6, encoded as per RFC 7952.
+	WithDefaultsReportAllTagged
+)
+
+// withDefaultsAnnotation is the name of the metadata annotation with which
+// leaves whose value is their default are tagged in the
+// WithDefaultsReportAllTagged mode.
+const withDefaultsAnnotation = "ietf-netconf-with-defaults:default"
+
+// String returns the RFC 6243 name of the WithDefaultsMode.
+func (m WithDefaultsMode) String() string {
+	switch m {
+	case WithDefaultsExplicit:
+		return "explicit"
+	case WithDefaultsTrim:
+		return "trim"
+	case WithDefaultsReportAll:
+		return "report-all"
+	case WithDefaultsReportAllTagged:
+		return "report-all-tagged"
+	}
+	return fmt.Sprintf("unknown(%d)", int(m))
+}
+
+// WithDefaults specifies the mode in which default values are reported. The
+// defaults of a GoStruct are read from the default statements of Schema,
+// which is the schema of the GoStruct to which the option is applied. If
+// Schema is nil, the defaults are determined by the PopulateDefaults method
+// of the GoStruct, such that it must be generated with the
+// generate_populate_defaults flag. It can be supplied as an option to:
+//   - EmitJSONWithOpts, where the emitted JSON is that of the GoStruct with
+//     the mode applied by ApplyWithDefaults.
+//   - Diff and DiffWithAtomic, where the mode is applied to both the
+//     original and modified GoStructs, such that a leaf that is unset is
+//     equal to one that is set to its default. Where the mode is
+//     WithDefaultsTrim, a leaf that is changed to its default is reported
+//     as deleted, and otherwise it is reported as updated.
+//
+// ytypes.GetNode accepts the equivalent ytypes.WithDefaults option.
+type WithDefaults struct {
+	Mode WithDefaultsMode
+	// Schema is the schema of the GoStruct, from which its defaults are
+	// read.
+	Schema *yang.Entry
+}
+
+// IsEmitJSONOpt marks WithDefaults as a valid EmitJSONWithOpts option.
+func (*WithDefaults) IsEmitJSONOpt() {}
+
+// IsDiffOpt marks WithDefaults as a valid Diff option.
+func (*WithDefaults) IsDiffOpt() {}
+
+// hasDiffWithDefaults returns the first WithDefaults from the supplied
+// DiffOpt slice, or nil if there isn't one.
+func hasDiffWithDefaults(opts []DiffOpt) *WithDefaults {
+	for _, o := range opts {
+		if v, ok := o.(*WithDefaults); ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// defaultsPopulator is the interface implemented by GoStructs that are
+// generated with the generate_populate_defaults flag.
+type defaultsPopulator interface {
+	// PopulateDefaults recursively populates the unset leaves of the
+	// GoStruct with their default values.
+	PopulateDefaults()
+}
+
+// ApplyWithDefaults returns a deep copy of the GoStruct s, whose schema is
+// schema, with the with-defaults mode applied, such that:
+//   - where mode is WithDefaultsExplicit, the copy is unmodified.
+//   - where mode is WithDefaultsTrim, leaves whose value is their default
+//     are unset.
+//   - where mode is WithDefaultsReportAll or WithDefaultsReportAllTagged,
+//     leaves that are unset are set to their default, and containers that
+//     are empty once defaults are populated are removed. Presence
+//     containers that are unset are not created. Since a GoStruct cannot
+//     carry the tags of WithDefaultsReportAllTagged, they are added by the
+//     functions that emit the GoStruct.
+//
+// The defaults are read from the default statements of schema, including
+// those of the types of the leaves. If schema is nil, the GoStruct must
+// implement the PopulateDefaults method that is generated with the
+// generate_populate_defaults flag, unless mode is WithDefaultsExplicit. The
+// supplied GoStruct is not modified.
+func ApplyWithDefaults(schema *yang.Entry, s GoStruct, mode WithDefaultsMode) (GoStruct, error) {
+	if mode != WithDefaultsExplicit && schema == nil {
+		if _, ok := s.(defaultsPopulator); !ok {
+			return nil, fmt.Errorf("%T does not support with-defaults mode %s without a schema, it must be generated with PopulateDefaults methods", s, mode)
+		}
+	}
+	c, err := DeepCopy(s)
+	if err != nil {
+		return nil, fmt.Errorf("cannot copy GoStruct: %v", err)
+	}
+	switch mode {
+	case WithDefaultsExplicit:
+	case WithDefaultsTrim:
+		v := reflect.ValueOf(c)
+		d, err := newDefaults(v.Type(), schema)
+		if err != nil {
+			return nil, err
+		}
+		if err := trimDefaults(v, d, schema); err != nil {
+			return nil, err
+		}
+	case WithDefaultsReportAll, WithDefaultsReportAllTagged:
+		if schema == nil {
+			c.(defaultsPopulator).PopulateDefaults()
+		} else if err := populateDefaults(reflect.ValueOf(c), schema); err != nil {
+			return nil, err
+		}
+		PruneEmptyBranches(c)
+	default:
+		return nil, fmt.Errorf("invalid with-defaults mode %s", mode)
+	}
+	return c, nil
+}
+
+// newDefaults returns a new instance of the struct pointer type t, whose
+// schema is e, with its defaults populated. If e is nil, the defaults are
+// populated by PopulateDefaults, if t implements it.
+func newDefaults(t reflect.Type, e *yang.Entry) (reflect.Value, error) {
+	d := reflect.New(t.Elem())
+	if e != nil {
+		return d, populateDefaults(d, e)
+	}
+	if p, ok := d.Interface().(defaultsPopulator); ok {
+		p.PopulateDefaults()
+	}
+	return d, nil
+}
+
+// trimDefaults unsets the leaves of the struct pointer s, whose schema is e,
+// whose value is equal to that of the corresponding leaf of the struct
+// pointer d, which is of the same type as s with its defaults populated.
+func trimDefaults(s, d reflect.Value, e *yang.Entry) error {
+	sval, dval := s.Elem(), d.Elem()
+	stype := sval.Type()
+	var errs errlist.List
+	for i := 0; i < sval.NumField(); i++ {
+		fval, ftype := util.FieldValue(sval, i), stype.Field(i)
+		if fval.IsZero() || util.IsYgotAnnotation(ftype) || util.IsYgotPresence(ftype) {
+			continue
+		}
+		if util.IsYgotExtensions(ftype) {
+			for _, ext := range util.AttachedExtensions(s) {
+				var es *yang.Entry
+				if e != nil {
+					es = ext.Schema
+				}
+				errs.Add(trimChild(ext.Value, es))
+			}
+			continue
+		}
+		var fe *yang.Entry
+		if e != nil {
+			var err error
+			if fe, err = util.ChildSchema(e, ftype); err != nil {
+				errs.Add(err)
+				continue
+			}
+		}
+		switch {
+		case fval.Kind() == reflect.Map:
+			for _, k := range fval.MapKeys() {
+				errs.Add(trimChild(fval.MapIndex(k), fe))
+			}
+		case fval.Kind() == reflect.Ptr && fval.Elem().Kind() == reflect.Struct:
+			if om, ok := fval.Interface().(GoOrderedMap); ok {
+				errs.Add(yreflect.RangeOrderedMap(om, func(_ reflect.Value, v reflect.Value) bool {
+					errs.Add(trimChild(v, fe))
+					return true
+				}))
+				continue
+			}
+			dchild := dval.Field(i)
+			if dchild.IsNil() {
+				errs.Add(trimChild(fval, fe))
+				continue
+			}
+			errs.Add(trimDefaults(fval, dchild, fe))
+		default:
+			if reflect.DeepEqual(fval.Interface(), util.FieldValue(dval, i).Interface()) {
+				errs.Add(util.ClearField(sval, i))
+			}
+		}
+	}
+	return errs.Err()
+}
+
+// trimChild unsets the leaves of the struct pointer s, whose schema is e,
+// whose value is their default.
+func trimChild(s reflect.Value, e *yang.Entry) error {
+	d, err := newDefaults(s.Type(), e)
+	if err != nil {
+		return err
+	}
+	return trimDefaults(s, d, e)
+}
+
+// populateDefaults recursively sets the unset leaves of the struct pointer
+// v, whose schema is e, to the values of their default statements,
+// instantiating any nil containers other than presence containers.
+func populateDefaults(v reflect.Value, e *yang.Entry) error {
+	sv := v.Elem()
+	var errs errlist.List
+	for i := 0; i < sv.NumField(); i++ {
+		ft := sv.Type().Field(i)
+		if util.IsYgotAnnotation(ft) || util.IsYgotPresence(ft) || util.IsYgotExtensions(ft) {
+			continue
+		}
+		fe, err := util.ChildSchema(e, ft)
+		if err != nil {
+			errs.Add(err)
+			continue
+		}
+		if fe == nil {
+			continue
+		}
+		fv := util.FieldValue(sv, i)
+		if om, ok := fv.Interface().(GoOrderedMap); ok {
+			errs.Add(yreflect.RangeOrderedMap(om, func(_ reflect.Value, ev reflect.Value) bool {
+				errs.Add(populateDefaults(ev, fe))
+				return true
+			}))
+			continue
+		}
+		switch {
+		case fv.Kind() == reflect.Map:
+			for _, k := range fv.MapKeys() {
+				errs.Add(populateDefaults(fv.MapIndex(k), fe))
+			}
+		case fv.Kind() == reflect.Slice && util.IsTypeStructPtr(fv.Type().Elem()):
+			for j := 0; j < fv.Len(); j++ {
+				errs.Add(populateDefaults(fv.Index(j), fe))
+			}
+		case fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct:
+			if fv.IsNil() {
+				if util.IsYangPresence(ft) {
+					continue
+				}
+				fv = reflect.New(fv.Type().Elem())
+				sv.Field(i).Set(fv)
+			}
+			errs.Add(populateDefaults(fv, fe))
+		default:
+			if !fv.IsZero() {
+				continue
+			}
+			dv, err := schemaDefault(v.Type(), ft, fe)
+			switch {
+			case err != nil:
+				errs.Add(err)
+			case dv.IsValid():
+				errs.Add(util.SetFieldValue(sv, i, dv))
+			}
+		}
+	}
+	for _, ext := range util.AttachedExtensions(v) {
+		errs.Add(populateDefaults(ext.Value, ext.Schema))
+	}
+	return errs.Err()
+}
+
+// schemaDefault returns the default value of the leaf or leaf-list with
+// schema e, which is stored in the field ft of the struct pointer type
+// parent, in the form that is returned by util.FieldValue. It returns an
+// invalid value if the node has no default.
+func schemaDefault(parent reflect.Type, ft reflect.StructField, e *yang.Entry) (reflect.Value, error) {
+	if !e.IsLeaf() && !e.IsLeafList() {
+		return reflect.Value{}, nil
+	}
+	dvals := schemaDefaults(e)
+	if len(dvals) == 0 {
+		return reflect.Value{}, nil
+	}
+	t := util.ZeroFieldValue(ft).Type()
+	if e.IsLeaf() || t.Kind() != reflect.Slice {
+		v, err := defaultValue(parent, t, e, dvals[0])
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid default %q for %s: %v", dvals[0], e.Path(), err)
+		}
+		return v, nil
+	}
+	v := reflect.MakeSlice(t, 0, len(dvals))
+	for _, d := range dvals {
+		ev, err := defaultValue(parent, t.Elem(), e, d)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid default %q for %s: %v", d, e.Path(), err)
+		}
+		v = reflect.Append(v, ev)
+	}
+	return v, nil
+}
+
+// schemaDefaults returns the default values of the leaf or leaf-list e. If
+// e has no default statement, the default of its type is returned, unless e
+// is mandatory, or is a leaf-list with a minimum number of elements.
+func schemaDefaults(e *yang.Entry) []string {
+	switch {
+	case len(e.Default) > 0:
+		return e.Default
+	case e.Type == nil || !e.Type.HasDefault || e.Mandatory == yang.TSTrue:
+		return nil
+	case e.IsLeafList() && e.ListAttr != nil && e.ListAttr.MinElements > 0:
+		return nil
+	}
+	return []string{e.Type.Default}
+}
+
+// defaultValue returns the value of type t of the default d of the leaf, or
+// leaf-list, with schema e that is a field of the struct pointer type parent.
+func defaultValue(parent, t reflect.Type, e *yang.Entry, d string) (reflect.Value, error) {
+	switch {
+	case t.Kind() == reflect.Ptr:
+		ev, err := defaultValue(parent, t.Elem(), e, d)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v := reflect.New(t.Elem())
+		v.Elem().Set(ev)
+		return v, nil
+	case t.Kind() == reflect.Interface:
+		return unionDefault(parent, t, e, d)
+	case t.Implements(reflect.TypeOf((*GoEnum)(nil)).Elem()):
+		return enumValue(t, d)
+	case t.Name() == BinaryTypeName:
+		b, err := base64.StdEncoding.DecodeString(d)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(b).Convert(t), nil
+	case t == bitsType:
+		b := ParseBits(d)
+		if bt, err := resolveLeafRefType(e, e.Type); err == nil && bt.Bit != nil {
+			for _, name := range b {
+				if !bt.Bit.IsDefined(name) {
+					return reflect.Value{}, fmt.Errorf("%q is not a bit of %s", name, bt.Name)
+				}
+			}
+		}
+		return reflect.ValueOf(b), nil
+	}
+	return scalarValue(t, d)
+}
+
+// scalarValue returns the value of the scalar type t that is represented by
+// the string s.
+func scalarValue(t reflect.Type, s string) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetBool(b)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := yangInt(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		i, err := strconv.ParseInt(n, 0, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetInt(i)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := yangInt(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		u, err := strconv.ParseUint(n, 0, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetUint(u)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetFloat(f)
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported type %v", t)
+	}
+	return v, nil
+}
+
+// yangInt returns the lexical representation s of a YANG integer, as per
+// RFC7950 section 9.2.1, in the form that is parsed by the strconv functions
+// with base 0. s has an optional sign, and is decimal, hexadecimal with a
+// "0x" prefix, or octal with a "0" prefix. The binary and underscored forms
+// that are also parsed by strconv are rejected.
+func yangInt(s string) (string, error) {
+	sign, u := "", s
+	if len(u) > 0 && (u[0] == '+' || u[0] == '-') {
+		sign, u = u[:1], u[1:]
+	}
+	switch {
+	case u == "" || u[0] == '+' || u[0] == '-' || strings.Contains(u, "_"):
+		return "", fmt.Errorf("invalid integer %q", s)
+	case len(u) > 1 && u[0] == '0' && strings.ContainsRune("bBoO", rune(u[1])):
+		return "", fmt.Errorf("invalid integer %q, only decimal, hexadecimal and octal forms are allowed", s)
+	}
+	if sign == "+" {
+		sign = ""
+	}
+	return sign + u, nil
+}
+
+// enumValue returns the value of the enumerated type t whose name is the
+// identifier s, which may be prefixed with a module name or prefix.
+func enumValue(t reflect.Type, s string) (reflect.Value, error) {
+	name := util.StripModulePrefix(s)
+	for i, def := range reflect.Zero(t).Interface().(GoEnum).ΛMap()[t.Name()] {
+		if def.Name == name {
+			return reflect.ValueOf(i).Convert(t), nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("%q is not a value of %v", s, t)
+}
+
+// scalarKindTypes maps the YANG types of the members of a union to the Go
+// types of the values that are accepted by the union conversion functions of
+// GoStructs.
+var scalarKindTypes = map[yang.TypeKind]reflect.Type{
+	yang.Yint8:      reflect.TypeOf(int8(0)),
+	yang.Yint16:     reflect.TypeOf(int16(0)),
+	yang.Yint32:     reflect.TypeOf(int32(0)),
+	yang.Yint64:     reflect.TypeOf(int64(0)),
+	yang.Yuint8:     reflect.TypeOf(uint8(0)),
+	yang.Yuint16:    reflect.TypeOf(uint16(0)),
+	yang.Yuint32:    reflect.TypeOf(uint32(0)),
+	yang.Yuint64:    reflect.TypeOf(uint64(0)),
+	yang.Ybool:      reflect.TypeOf(false),
+	yang.Ydecimal64: reflect.TypeOf(float64(0)),
+}
+
+// unionDefault returns the value of the union type t, which is the type of
+// the leaf with schema e within the struct pointer type parent, that
+// represents the default d. d is converted to the first member type of the
+// union of which it is a valid value, using the To_ conversion function of
+// parent for t.
+func unionDefault(parent, t reflect.Type, e *yang.Entry, d string) (reflect.Value, error) {
+	conv := reflect.New(parent.Elem()).MethodByName("To_" + t.Name())
+	if !conv.IsValid() {
+		return reflect.Value{}, fmt.Errorf("%v does not have a To_%s function", parent, t.Name())
+	}
+	var enumTypes []reflect.Type
+	if em, ok := reflect.Zero(parent).Interface().(interface {
+		ΛEnumTypeMap() map[string][]reflect.Type
+	}); ok {
+		for _, ts := range em.ΛEnumTypeMap() {
+			enumTypes = append(enumTypes, ts...)
+		}
+	}
+	for _, mt := range util.FlattenedTypes([]*yang.YangType{e.Type}) {
+		mt, err := resolveLeafRefType(e, mt)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		var cands []reflect.Value
+		switch mt.Kind {
+		case yang.Yenum, yang.Yidentityref:
+			for _, et := range enumTypes {
+				if ev, err := enumValue(et, d); err == nil {
+					cands = append(cands, ev)
+				}
+			}
+		case yang.Ybinary, yang.Yempty:
+		default:
+			st, ok := scalarKindTypes[mt.Kind]
+			if !ok {
+				st = reflect.TypeOf("")
+			}
+			if sv, err := scalarValue(st, d); err == nil {
+				cands = append(cands, sv)
+			}
+		}
+		for _, c := range cands {
+			if out := conv.Call([]reflect.Value{c}); out[1].IsNil() {
+				return out[0], nil
+			}
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("no member of union type %v accepts the value", t)
+}
+
+// tagJSONDefaults tags each leaf and leaf-list within the JSON tree all, which
+// is the tree of a GoStruct with the WithDefaultsReportAll mode applied, that
+// is not present in the JSON tree trimmed, which is the tree of the same
+// GoStruct with the WithDefaultsTrim mode applied, with the with-defaults
+// metadata annotation, as per RFC 7952. trimmed may be nil.
+func tagJSONDefaults(all, trimmed map[string]any) {
+	for k, v := range all {
+		if len(k) > 0 && k[0] == '@' {
+			continue
+		}
+		tv, ok := trimmed[k]
+		switch v := v.(type) {
+		case map[string]any:
+			tm, _ := tv.(map[string]any)
+			tagJSONDefaults(v, tm)
+			continue
+		case []any:
+			if len(v) > 0 {
+				if _, isList := v[0].(map[string]any); isList {
+					ta, _ := tv.([]any)
+					for i, ev := range v {
+						var tm map[string]any
+						if i < len(ta) {
+							tm, _ = ta[i].(map[string]any)
+						}
+						tagJSONDefaults(ev.(map[string]any), tm)
+					}
+					continue
+				}
+			}
+			if !ok {
+				tags := make([]any, len(v))
+				for i := range tags {
+					tags[i] = map[string]any{withDefaultsAnnotation: true}
+				}
+				all["@"+k] = tags
+			}
+			continue
+		}
+		if !ok {
+			all["@"+k] = map[string]any{withDefaultsAnnotation: true}
+		}
+	}
+}
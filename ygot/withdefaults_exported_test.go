@@ -0,0 +1,314 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/exampleoc/wrapperunionoc"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// noDefaultsStruct is a GoStruct that does not implement PopulateDefaults.
+type noDefaultsStruct struct {
+	Val *string `path:"val"`
+}
+
+func (*noDefaultsStruct) IsYANGGoStruct() {}
+
+func TestApplyWithDefaults(t *testing.T) {
+	newDevice := func() *exampleoc.Device {
+		d := &exampleoc.Device{}
+		i := d.GetOrCreateInterface("eth0")
+		i.Enabled = ygot.Bool(true)
+		i.Mtu = ygot.Uint16(1500)
+		i.LoopbackMode = exampleoc.TransportTypes_LoopbackModeType_NONE
+		d.GetOrCreateInterface("eth1").Enabled = ygot.Bool(false)
+		return d
+	}
+
+	tests := []struct {
+		desc             string
+		inMode           ygot.WithDefaultsMode
+		want             func() *exampleoc.Device
+		wantErrSubstring string
+	}{{
+		desc:   "explicit",
+		inMode: ygot.WithDefaultsExplicit,
+		want:   newDevice,
+	}, {
+		desc:   "trim",
+		inMode: ygot.WithDefaultsTrim,
+		want: func() *exampleoc.Device {
+			d := &exampleoc.Device{}
+			d.GetOrCreateInterface("eth0").Mtu = ygot.Uint16(1500)
+			d.GetOrCreateInterface("eth1").Enabled = ygot.Bool(false)
+			return d
+		},
+	}, {
+		desc:             "invalid mode",
+		inMode:           ygot.WithDefaultsMode(42),
+		wantErrSubstring: "invalid with-defaults mode",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			in := newDevice()
+			got, err := ygot.ApplyWithDefaults(nil, in, tt.inMode)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("ApplyWithDefaults: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want(), got); diff != "" {
+				t.Errorf("ApplyWithDefaults: did not get expected result (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(newDevice(), in); diff != "" {
+				t.Errorf("ApplyWithDefaults: input was modified (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyWithDefaultsReportAll(t *testing.T) {
+	d := &exampleoc.Device{}
+	d.GetOrCreateInterface("eth0").Enabled = ygot.Bool(false)
+	got, err := ygot.ApplyWithDefaults(nil, d, ygot.WithDefaultsReportAll)
+	if err != nil {
+		t.Fatalf("ApplyWithDefaults: got unexpected error: %v", err)
+	}
+	i := got.(*exampleoc.Device).GetInterface("eth0")
+	if i.GetEnabled() {
+		t.Errorf("ApplyWithDefaults: explicitly set enabled leaf was overwritten")
+	}
+	if got, want := i.LoopbackMode, exampleoc.TransportTypes_LoopbackModeType_NONE; got != want {
+		t.Errorf("ApplyWithDefaults: got loopback-mode %v, want default %v", got, want)
+	}
+	if d.GetInterface("eth0").LoopbackMode != exampleoc.TransportTypes_LoopbackModeType_UNSET {
+		t.Errorf("ApplyWithDefaults: input was modified")
+	}
+
+	if _, err := ygot.ApplyWithDefaults(nil, &noDefaultsStruct{}, ygot.WithDefaultsReportAll); err == nil {
+		t.Errorf("ApplyWithDefaults with struct without defaults: got nil error, want error")
+	}
+}
+
+func TestDiffWithDefaults(t *testing.T) {
+	orig := &exampleoc.Device{}
+	orig.GetOrCreateInterface("eth0").Mtu = ygot.Uint16(1500)
+	mod := &exampleoc.Device{}
+	mi := mod.GetOrCreateInterface("eth0")
+	mi.Mtu = ygot.Uint16(1500)
+	mi.Enabled = ygot.Bool(true)
+
+	got, err := ygot.Diff(orig, mod)
+	if err != nil {
+		t.Fatalf("Diff: got unexpected error: %v", err)
+	}
+	if len(got.GetUpdate()) != 1 {
+		t.Errorf("Diff without WithDefaults: got %d updates, want 1", len(got.GetUpdate()))
+	}
+
+	for _, mode := range []ygot.WithDefaultsMode{ygot.WithDefaultsTrim, ygot.WithDefaultsReportAll} {
+		got, err := ygot.Diff(orig, mod, &ygot.WithDefaults{Mode: mode})
+		if err != nil {
+			t.Fatalf("Diff with mode %s: got unexpected error: %v", mode, err)
+		}
+		if diff := cmp.Diff(&gnmipb.Notification{}, got, protocmp.Transform()); diff != "" {
+			t.Errorf("Diff with mode %s: default-equal values were not equal (-want, +got):\n%s", mode, diff)
+		}
+	}
+}
+
+func TestEmitJSONWithDefaults(t *testing.T) {
+	d := &exampleoc.Device{}
+	i := d.GetOrCreateInterface("eth0")
+	i.Enabled = ygot.Bool(true)
+	i.Mtu = ygot.Uint16(1500)
+
+	trimmed, err := ygot.EmitJSONWithOpts(d, &ygot.SkipValidation{}, &ygot.WithDefaults{Mode: ygot.WithDefaultsTrim})
+	if err != nil {
+		t.Fatalf("EmitJSONWithOpts: got unexpected error: %v", err)
+	}
+	if strings.Contains(trimmed, "enabled") || !strings.Contains(trimmed, "mtu") {
+		t.Errorf("EmitJSONWithOpts with trim: got %s, want mtu without enabled", trimmed)
+	}
+
+	all, err := ygot.EmitJSONWithOpts(d, &ygot.SkipValidation{}, &ygot.WithDefaults{Mode: ygot.WithDefaultsReportAll})
+	if err != nil {
+		t.Fatalf("EmitJSONWithOpts: got unexpected error: %v", err)
+	}
+	if !strings.Contains(all, "loopback-mode") {
+		t.Errorf("EmitJSONWithOpts with report-all: got %s, want loopback-mode", all)
+	}
+}
+
+// exampleocRoot returns the schema of the exampleoc Device.
+func exampleocRoot(t *testing.T) *yang.Entry {
+	t.Helper()
+	s, err := exampleoc.Schema()
+	if err != nil {
+		t.Fatalf("cannot load schema: %v", err)
+	}
+	return s.RootSchema()
+}
+
+func TestApplyWithDefaultsSchema(t *testing.T) {
+	schema := exampleocRoot(t)
+	newDevice := func() *exampleoc.Device {
+		d := &exampleoc.Device{}
+		i := d.GetOrCreateInterface("eth0")
+		i.Enabled = ygot.Bool(true)
+		i.Mtu = ygot.Uint16(1500)
+		d.GetOrCreateInterface("eth1").Enabled = ygot.Bool(false)
+		d.GetOrCreateNetworkInstance("default").
+			GetOrCreateProtocol(exampleoc.PolicyTypes_INSTALL_PROTOCOL_TYPE_ISIS, "isis").
+			GetOrCreateIsis().GetOrCreateInterface("eth0").GetOrCreateWeightedEcmp()
+		return d
+	}
+
+	// The defaults that are read from the schema are the same as those that
+	// are set by the generated PopulateDefaults methods.
+	for _, mode := range []ygot.WithDefaultsMode{ygot.WithDefaultsTrim, ygot.WithDefaultsReportAll} {
+		want, err := ygot.ApplyWithDefaults(nil, newDevice(), mode)
+		if err != nil {
+			t.Fatalf("ApplyWithDefaults without schema, mode %s: got unexpected error: %v", mode, err)
+		}
+		got, err := ygot.ApplyWithDefaults(schema, newDevice(), mode)
+		if err != nil {
+			t.Fatalf("ApplyWithDefaults with schema, mode %s: got unexpected error: %v", mode, err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("ApplyWithDefaults with schema, mode %s: did not get PopulateDefaults result (-want, +got):\n%s", mode, diff)
+		}
+	}
+
+	got, err := ygot.ApplyWithDefaults(schema, newDevice(), ygot.WithDefaultsReportAll)
+	if err != nil {
+		t.Fatalf("ApplyWithDefaults: got unexpected error: %v", err)
+	}
+	we := got.(*exampleoc.Device).GetNetworkInstance("default").
+		GetProtocol(exampleoc.PolicyTypes_INSTALL_PROTOCOL_TYPE_ISIS, "isis").
+		GetIsis().GetInterface("eth0").GetWeightedEcmp()
+	if got, want := we.LoadBalancingWeight, exampleoc.WeightedEcmp_LoadBalancingWeight_Enum_auto; got != want {
+		t.Errorf("ApplyWithDefaults: got union leaf %v, want default %v", got, want)
+	}
+}
+
+func TestApplyWithDefaultsWithoutPopulateDefaults(t *testing.T) {
+	s, err := wrapperunionoc.Schema()
+	if err != nil {
+		t.Fatalf("cannot load schema: %v", err)
+	}
+	schema := s.RootSchema()
+	d := &wrapperunionoc.Device{}
+	d.GetOrCreateInterface("eth0").Enabled = ygot.Bool(true)
+	d.GetOrCreateInterface("eth1").Enabled = ygot.Bool(false)
+
+	if _, err := ygot.ApplyWithDefaults(nil, d, ygot.WithDefaultsReportAll); err == nil {
+		t.Errorf("ApplyWithDefaults without schema: got nil error, want error")
+	}
+
+	got, err := ygot.ApplyWithDefaults(schema, d, ygot.WithDefaultsReportAll)
+	if err != nil {
+		t.Fatalf("ApplyWithDefaults with report-all: got unexpected error: %v", err)
+	}
+	i := got.(*wrapperunionoc.Device).GetInterface("eth1")
+	if i.GetEnabled() {
+		t.Errorf("ApplyWithDefaults with report-all: explicitly set enabled leaf was overwritten")
+	}
+	if i.LoopbackMode == nil || *i.LoopbackMode {
+		t.Errorf("ApplyWithDefaults with report-all: got loopback-mode %v, want default false", i.LoopbackMode)
+	}
+
+	got, err = ygot.ApplyWithDefaults(schema, d, ygot.WithDefaultsTrim)
+	if err != nil {
+		t.Fatalf("ApplyWithDefaults with trim: got unexpected error: %v", err)
+	}
+	if got := got.(*wrapperunionoc.Device).GetInterface("eth0").Enabled; got != nil {
+		t.Errorf("ApplyWithDefaults with trim: got enabled %v, want it to be trimmed", *got)
+	}
+}
+
+func TestEmitJSONWithDefaultsTagged(t *testing.T) {
+	d := &exampleoc.Device{}
+	i := d.GetOrCreateInterface("eth0")
+	i.Enabled = ygot.Bool(true)
+	i.Mtu = ygot.Uint16(1500)
+	opts := []ygot.EmitJSONOpt{
+		&ygot.SkipValidation{},
+		&ygot.RFC7951JSONConfig{},
+		&ygot.WithDefaults{Mode: ygot.WithDefaultsReportAllTagged, Schema: exampleocRoot(t)},
+	}
+
+	js, err := ygot.EmitJSONWithOpts(d, opts...)
+	if err != nil {
+		t.Fatalf("EmitJSONWithOpts: got unexpected error: %v", err)
+	}
+	var v struct {
+		Interfaces struct {
+			Interface []struct {
+				Config map[string]any `json:"config"`
+			} `json:"interface"`
+		} `json:"interfaces"`
+	}
+	if err := json.Unmarshal([]byte(js), &v); err != nil {
+		t.Fatalf("cannot unmarshal emitted JSON: %v", err)
+	}
+	if len(v.Interfaces.Interface) != 1 {
+		t.Fatalf("EmitJSONWithOpts: got JSON %s, want one interface", js)
+	}
+	tag := map[string]any{"ietf-netconf-with-defaults:default": true}
+	want := map[string]any{
+		"name":           "eth0",
+		"enabled":        true,
+		"@enabled":       tag,
+		"mtu":            float64(1500),
+		"loopback-mode":  "NONE",
+		"@loopback-mode": tag,
+		"tpid":           "TPID_0X8100",
+		"@tpid":          tag,
+	}
+	if diff := cmp.Diff(want, v.Interfaces.Interface[0].Config); diff != "" {
+		t.Errorf("EmitJSONWithOpts: did not get expected tagged config (-want, +got):\n%s", diff)
+	}
+
+	y, err := ygot.EmitYAML(d, &ygot.EmitYAMLConfig{
+		SkipValidation:     true,
+		WithDefaults:       ygot.WithDefaultsReportAllTagged,
+		WithDefaultsSchema: exampleocRoot(t),
+	})
+	if err != nil {
+		t.Fatalf("EmitYAML: got unexpected error: %v", err)
+	}
+	if !strings.Contains(y, "ietf-netconf-with-defaults:default") {
+		t.Errorf("EmitYAML with report-all-tagged: got %s, want with-defaults tags", y)
+	}
+
+	if err := ygot.EncodeJSON(&bytes.Buffer{}, d, opts...); err == nil {
+		t.Errorf("EncodeJSON with report-all-tagged: got nil error, want error")
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// schemaDefaultStruct is a struct whose fields hold leaves and leaf-lists
+// that have schema defaults.
+type schemaDefaultStruct struct {
+	Int8     *int8    `path:"int8"`
+	Int64    *int64   `path:"int64"`
+	Uint16   *uint16  `path:"uint16"`
+	Int32s   []int32  `path:"int32s"`
+	Uint8s   []uint8  `path:"uint8s"`
+	Bits     Bits     `path:"bits"`
+	BitsList []Bits   `path:"bits-list"`
+	Strings  []string `path:"strings"`
+}
+
+func TestSchemaDefault(t *testing.T) {
+	flags := yang.NewBitfield()
+	for i, name := range []string{"up", "running", "loopback"} {
+		if err := flags.Set(name, int64(i)); err != nil {
+			t.Fatalf("cannot set bit %s: %v", name, err)
+		}
+	}
+	leaf := func(kind yang.TypeKind, defaults ...string) *yang.Entry {
+		e := &yang.Entry{Name: "leaf", Kind: yang.LeafEntry, Default: defaults, Type: &yang.YangType{Kind: kind}}
+		if kind == yang.Ybits {
+			e.Type.Name = "flags"
+			e.Type.Bit = flags
+		}
+		return e
+	}
+	leafList := func(kind yang.TypeKind, defaults ...string) *yang.Entry {
+		e := leaf(kind, defaults...)
+		e.ListAttr = &yang.ListAttr{}
+		return e
+	}
+
+	tests := []struct {
+		desc             string
+		inField          string
+		inSchema         *yang.Entry
+		want             any
+		wantErrSubstring string
+	}{{
+		desc:     "decimal",
+		inField:  "Int8",
+		inSchema: leaf(yang.Yint8, "-12"),
+		want:     Int8(-12),
+	}, {
+		desc:     "explicit positive sign",
+		inField:  "Int8",
+		inSchema: leaf(yang.Yint8, "+12"),
+		want:     Int8(12),
+	}, {
+		desc:     "hexadecimal",
+		inField:  "Uint16",
+		inSchema: leaf(yang.Yuint16, "0x1F"),
+		want:     Uint16(31),
+	}, {
+		desc:     "negative hexadecimal",
+		inField:  "Int64",
+		inSchema: leaf(yang.Yint64, "-0xff"),
+		want:     Int64(-255),
+	}, {
+		desc:     "octal",
+		inField:  "Uint16",
+		inSchema: leaf(yang.Yuint16, "017"),
+		want:     Uint16(15),
+	}, {
+		desc:     "zero",
+		inField:  "Uint16",
+		inSchema: leaf(yang.Yuint16, "0"),
+		want:     Uint16(0),
+	}, {
+		desc:             "binary",
+		inField:          "Uint16",
+		inSchema:         leaf(yang.Yuint16, "0b101"),
+		wantErrSubstring: "only decimal, hexadecimal and octal forms are allowed",
+	}, {
+		desc:             "octal with prefix",
+		inField:          "Uint16",
+		inSchema:         leaf(yang.Yuint16, "0o17"),
+		wantErrSubstring: "only decimal, hexadecimal and octal forms are allowed",
+	}, {
+		desc:             "underscore",
+		inField:          "Int64",
+		inSchema:         leaf(yang.Yint64, "1_000"),
+		wantErrSubstring: "invalid integer",
+	}, {
+		desc:             "repeated sign",
+		inField:          "Int8",
+		inSchema:         leaf(yang.Yint8, "+-1"),
+		wantErrSubstring: "invalid integer",
+	}, {
+		desc:             "negative unsigned",
+		inField:          "Uint16",
+		inSchema:         leaf(yang.Yuint16, "-1"),
+		wantErrSubstring: "invalid default",
+	}, {
+		desc:             "out of range",
+		inField:          "Int8",
+		inSchema:         leaf(yang.Yint8, "0x80"),
+		wantErrSubstring: "out of range",
+	}, {
+		desc:     "leaf-list of integers",
+		inField:  "Int32s",
+		inSchema: leafList(yang.Yint32, "10", "0x10", "010", "-0X10"),
+		want:     []int32{10, 16, 8, -16},
+	}, {
+		desc:     "leaf-list of unsigned integers",
+		inField:  "Uint8s",
+		inSchema: leafList(yang.Yuint8, "0xFF", "+7"),
+		want:     []uint8{255, 7},
+	}, {
+		desc:     "leaf-list of strings",
+		inField:  "Strings",
+		inSchema: leafList(yang.Ystring, "a", "b"),
+		want:     []string{"a", "b"},
+	}, {
+		desc:     "bits",
+		inField:  "Bits",
+		inSchema: leaf(yang.Ybits, "running up"),
+		want:     Bits{"running", "up"},
+	}, {
+		desc:     "leaf-list of bits",
+		inField:  "BitsList",
+		inSchema: leafList(yang.Ybits, "up", "loopback running"),
+		want:     []Bits{{"up"}, {"loopback", "running"}},
+	}, {
+		desc:             "undefined bit",
+		inField:          "Bits",
+		inSchema:         leaf(yang.Ybits, "up down"),
+		wantErrSubstring: `"down" is not a bit of flags`,
+	}}
+
+	parent := reflect.TypeOf(&schemaDefaultStruct{})
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ft, ok := parent.Elem().FieldByName(tt.inField)
+			if !ok {
+				t.Fatalf("schemaDefaultStruct has no field %s", tt.inField)
+			}
+			got, err := schemaDefault(parent, ft, tt.inSchema)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("schemaDefault: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got.Interface()); diff != "" {
+				t.Errorf("schemaDefault: did not get expected value, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
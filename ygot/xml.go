@@ -0,0 +1,493 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// NETCONFBaseNamespace is the XML namespace of the NETCONF protocol, which
+// qualifies the operation attribute within <edit-config> bodies.
+const NETCONFBaseNamespace = "urn:ietf:params:xml:ns:netconf:base:1.0"
+
+// NETCONFOperation is an operation that is specified for a node within the
+// configuration of a NETCONF <edit-config>, as per RFC6241 section 7.2.
+type NETCONFOperation string
+
+const (
+	// NETCONFMerge specifies that the node is merged with the existing
+	// configuration.
+	NETCONFMerge NETCONFOperation = "merge"
+	// NETCONFReplace specifies that the node replaces the existing
+	// configuration.
+	NETCONFReplace NETCONFOperation = "replace"
+	// NETCONFCreate specifies that the node is created, failing if it
+	// already exists.
+	NETCONFCreate NETCONFOperation = "create"
+	// NETCONFDelete specifies that the node is deleted, failing if it does
+	// not exist.
+	NETCONFDelete NETCONFOperation = "delete"
+	// NETCONFRemove specifies that the node is deleted if it exists.
+	NETCONFRemove NETCONFOperation = "remove"
+)
+
+// XMLOperation specifies the NETCONF operation attribute of a node within
+// the XML emitted by EmitXML.
+type XMLOperation struct {
+	// Path is the path of the node, relative to the root of the GoStruct
+	// that is emitted. The node, and the nodes along its path, are added
+	// to the XML if they are not present in the GoStruct, in which case
+	// the names of the elements of Path must be qualified by the name of
+	// their module, e.g., "openconfig-interfaces:interfaces", where their
+	// namespace differs from that of their parent.
+	Path *gnmipb.Path
+	// Operation is the operation that is specified for the node.
+	Operation NETCONFOperation
+}
+
+// GetPath returns the path of o, or nil if o is nil.
+func (o *XMLOperation) GetPath() *gnmipb.Path {
+	if o == nil {
+		return nil
+	}
+	return o.Path
+}
+
+// EmitXMLConfig specifies how XML should be created by the EmitXML function.
+type EmitXMLConfig struct {
+	// Schema is the schema of the GoStruct that is emitted. It is required
+	// in order to order the children of list entries, and to qualify
+	// identityref values.
+	Schema *yang.Entry
+	// Namespaces maps the names of modules to their XML namespaces, and
+	// must contain the namespace of each module of the nodes and identities
+	// that are emitted. It can be constructed from the YANGLibraryModule
+	// entries that are generated for the schema.
+	Namespaces map[string]string
+	// Operations specifies the NETCONF operation attributes of nodes.
+	Operations []*XMLOperation
+	// Indent is the string used for indentation within the XML output. If
+	// it and Prefix are empty, the output is not indented and contains no
+	// newlines.
+	Indent string
+	// Prefix is written at the start of each line of indented output, as
+	// it is by the Indent method of xml.Encoder.
+	Prefix string
+	// SkipValidation specifies whether the GoStruct supplied to EmitXML
+	// should be validated before emitting its content. Validation is skipped
+	// when it is set to true.
+	SkipValidation bool
+	// ValidationOpts is the set of options that should be used to determine
+	// how the schema should be validated.
+	ValidationOpts []ValidationOption
+}
+
+// EmitXML takes an input GoStruct (produced by ygen with validation enabled)
+// and serialises it to YANG-modelled XML, as per RFC7950 section 7, such
+// that it can be used as the body of the <config> element of a NETCONF
+// <edit-config>, or compared to the body of a <get-config> reply. The output
+// is the sequence of elements representing the top-level nodes of the
+// GoStruct. Each element whose namespace differs from that of its parent
+// declares its namespace as the default namespace, and identityref values
+// are qualified by the name of the module that defines the identity, which
+// is declared as a prefix. The keys of list entries are emitted first, in
+// the order of the list's key statement, followed by the remaining children
+// of the entry ordered by name.
+func EmitXML(gs GoStruct, opts *EmitXMLConfig) (string, error) {
+	if opts == nil || opts.Schema == nil {
+		return "", fmt.Errorf("EmitXML requires the schema of the GoStruct")
+	}
+	if !opts.SkipValidation {
+		if err := ValidateGoStruct(gs, opts.ValidationOpts...); err != nil {
+			return "", fmt.Errorf("validation err: %v", err)
+		}
+	}
+
+	v, err := ConstructIETFJSON(gs, &RFC7951JSONConfig{AppendModuleName: true})
+	if err != nil {
+		return "", fmt.Errorf("ConstructIETFJSON error: %v", err)
+	}
+	return EmitXMLFromJSON(v, opts)
+}
+
+// EmitXMLFromJSON serialises v, which is the RFC7951 JSON rendering, with
+// module-qualified names, of the contents of the root opts.Schema, to XML as
+// EmitXML does. It allows data that is not held within a GoStruct, such as
+// a decoded RFC7951 JSON document, to be emitted. v may be any value that is
+// marshalled to a JSON object by the encoding/json package. The validation
+// options of opts are ignored.
+func EmitXMLFromJSON(v any, opts *EmitXMLConfig) (string, error) {
+	if opts == nil || opts.Schema == nil {
+		return "", fmt.Errorf("EmitXMLFromJSON requires the schema of the data")
+	}
+	nv, err := normalizeJSONValue(v)
+	if err != nil {
+		return "", err
+	}
+	obj, ok := nv.(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("invalid JSON rendering of data: %T", nv)
+	}
+
+	root := &xmlNode{schema: opts.Schema}
+	if root.children, err = xmlChildren(opts.Schema, obj); err != nil {
+		return "", err
+	}
+	for _, o := range opts.Operations {
+		if err := root.setOperation(o); err != nil {
+			return "", err
+		}
+	}
+
+	w := &xmlWriter{namespaces: opts.Namespaces, indent: opts.Indent, prefix: opts.Prefix}
+	for _, n := range root.children {
+		if err := w.write(n, 0); err != nil {
+			return "", err
+		}
+	}
+	return w.sb.String(), nil
+}
+
+// xmlNode is an element of the XML rendering of a data tree.
+type xmlNode struct {
+	// name is the local name of the element.
+	name string
+	// module is the name of the module of the element, which is set where
+	// its namespace differs from that of its parent.
+	module string
+	// schema is the schema of the data node represented by the element.
+	schema *yang.Entry
+	// text is the content of an element that represents a leaf, or an
+	// entry of a leaf-list. It is nil for elements that have no content.
+	text *string
+	// prefixModule is the name of the module that qualifies the
+	// identityref value within text, if any.
+	prefixModule string
+	// children are the child elements of the element.
+	children []*xmlNode
+	// op is the NETCONF operation specified for the element.
+	op NETCONFOperation
+}
+
+// xmlChildren returns the elements representing obj, which is the RFC7951
+// JSON rendering, with module-qualified names, of the contents of the
+// directory e. Where e is a list, its keys are returned first.
+func xmlChildren(e *yang.Entry, obj map[string]any) ([]*xmlNode, error) {
+	keyRank := map[string]int{}
+	if e.IsList() {
+		keys := strings.Fields(e.Key)
+		for i, k := range keys {
+			keyRank[k] = i - len(keys)
+		}
+	}
+	names := make([]string, 0, len(obj))
+	for k := range obj {
+		names = append(names, k)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		li, lj := util.StripModulePrefix(names[i]), util.StripModulePrefix(names[j])
+		if keyRank[li] != keyRank[lj] {
+			return keyRank[li] < keyRank[lj]
+		}
+		return li < lj
+	})
+
+	var nodes []*xmlNode
+	for _, k := range names {
+		module, name := splitQualifiedName(k)
+		ch := childSchema(e, name)
+		if ch == nil {
+			return nil, fmt.Errorf("cannot find schema for %s within %s", k, e.Name)
+		}
+		var values []any
+		if ch.IsList() || ch.IsLeafList() {
+			arr, ok := obj[k].([]any)
+			if !ok {
+				return nil, fmt.Errorf("invalid JSON value for %s: got %T, want array", k, obj[k])
+			}
+			values = arr
+		} else {
+			values = []any{obj[k]}
+		}
+		for _, v := range values {
+			n := &xmlNode{name: name, module: module, schema: ch}
+			if ch.IsDir() {
+				o, ok := v.(map[string]any)
+				if !ok {
+					return nil, fmt.Errorf("invalid JSON value for %s: got %T, want object", k, v)
+				}
+				var err error
+				if n.children, err = xmlChildren(ch, o); err != nil {
+					return nil, err
+				}
+			} else {
+				text, module, err := xmlLeafText(ch, ch.Type, v)
+				if err != nil {
+					return nil, fmt.Errorf("invalid value for %s: %v", k, err)
+				}
+				n.text, n.prefixModule = text, module
+			}
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes, nil
+}
+
+// xmlLeafText returns the content of the element representing v, which is
+// the RFC7951 JSON rendering of a value of the type t of the leaf, or
+// leaf-list, e, along with the module that qualifies it if it is an
+// identityref value. The content is nil for empty values.
+func xmlLeafText(e *yang.Entry, t *yang.YangType, v any) (*string, string, error) {
+	t, err := resolveLeafRefType(e, t)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch t.Kind {
+	case yang.Yempty:
+		return nil, "", nil
+	case yang.Yidentityref:
+		s, _ := v.(string)
+		module, _ := splitQualifiedName(s)
+		if module == "" {
+			return nil, "", fmt.Errorf("identityref value %q is not qualified by its module", s)
+		}
+		return &s, module, nil
+	case yang.Yunion:
+		for _, mt := range t.Type {
+			mt, err := resolveLeafRefType(e, mt)
+			if err != nil {
+				return nil, "", err
+			}
+			if jsonUnionMember(mt, v) {
+				return xmlLeafText(e, mt, v)
+			}
+		}
+		return nil, "", fmt.Errorf("%v does not match any union member type", v)
+	}
+
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		s = strconv.FormatBool(v)
+	default:
+		return nil, "", fmt.Errorf("unexpected JSON value of type %T", v)
+	}
+	return &s, "", nil
+}
+
+// setOperation sets the operation of the element at the path of o, relative
+// to n, adding the element and the elements along its path if they are not
+// present. Where the path identifies a leaf-list, the operation is set for
+// each of its entries.
+func (n *xmlNode) setOperation(o *XMLOperation) error {
+	switch o.Operation {
+	case NETCONFMerge, NETCONFReplace, NETCONFCreate, NETCONFDelete, NETCONFRemove:
+	default:
+		return fmt.Errorf("invalid NETCONF operation %q", o.Operation)
+	}
+	elems := o.GetPath().GetElem()
+	if len(elems) == 0 {
+		return fmt.Errorf("cannot set operation %s for the root", o.Operation)
+	}
+	nodes := []*xmlNode{n}
+	for i, pe := range elems {
+		var err error
+		if nodes, err = nodes[0].child(pe, i == 0); err != nil {
+			return fmt.Errorf("cannot set operation %s for %v: %v", o.Operation, o.GetPath(), err)
+		}
+	}
+	for _, c := range nodes {
+		c.op = o.Operation
+	}
+	return nil
+}
+
+// child returns the child elements of n that are identified by the path
+// element pe, adding the element if it is not present. top specifies that n
+// is the root, such that the name of pe must be qualified by its module in
+// order to add the element.
+func (n *xmlNode) child(pe *gnmipb.PathElem, top bool) ([]*xmlNode, error) {
+	module, name := splitQualifiedName(pe.GetName())
+	ch := childSchema(n.schema, name)
+	if ch == nil {
+		return nil, fmt.Errorf("cannot find schema for %s", pe.GetName())
+	}
+	if ch.IsList() && len(pe.GetKey()) != len(strings.Fields(ch.Key)) {
+		return nil, fmt.Errorf("list %s requires keys %q, got %v", name, ch.Key, pe.GetKey())
+	}
+
+	var matches []*xmlNode
+	for _, c := range n.children {
+		if c.name == name && (!ch.IsList() || c.hasKeys(pe.GetKey())) {
+			matches = append(matches, c)
+		}
+	}
+	if len(matches) > 0 {
+		return matches, nil
+	}
+
+	if top && module == "" {
+		return nil, fmt.Errorf("%s must be qualified by its module name", name)
+	}
+	c := &xmlNode{name: name, module: module, schema: ch}
+	if ch.IsList() {
+		for _, k := range strings.Fields(ch.Key) {
+			v := pe.GetKey()[k]
+			c.children = append(c.children, &xmlNode{name: k, schema: childSchema(ch, k), text: &v})
+		}
+	}
+	n.children = append(n.children, c)
+	return []*xmlNode{c}, nil
+}
+
+// hasKeys reports whether the element n, which represents a list entry, has
+// key leaves whose values are those of keys.
+func (n *xmlNode) hasKeys(keys map[string]string) bool {
+	for k, v := range keys {
+		found := false
+		for _, c := range n.children {
+			if c.name == util.StripModulePrefix(k) && c.text != nil && *c.text == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// splitQualifiedName splits the module-qualified name s into the name of its
+// module, which is empty if it is not qualified, and its local name.
+func splitQualifiedName(s string) (string, string) {
+	if i := strings.Index(s, ":"); i >= 0 {
+		return s[:i], s[i+1:]
+	}
+	return "", s
+}
+
+// xmlWriter writes the XML rendering of elements.
+type xmlWriter struct {
+	// sb is the output.
+	sb strings.Builder
+	// namespaces maps the names of modules to their XML namespaces.
+	namespaces map[string]string
+	// indent is the string used for indentation.
+	indent string
+	// prefix is written at the start of each line.
+	prefix string
+}
+
+// write writes the element n, which is nested within depth elements.
+func (w *xmlWriter) write(n *xmlNode, depth int) error {
+	w.writeIndent(depth)
+	w.sb.WriteString("<" + n.name)
+	if n.module != "" {
+		if err := w.writeNamespace("xmlns", n.module); err != nil {
+			return err
+		}
+	}
+	if n.prefixModule != "" {
+		if err := w.writeNamespace("xmlns:"+n.prefixModule, n.prefixModule); err != nil {
+			return err
+		}
+	}
+	if n.op != "" {
+		w.writeAttr("xmlns:nc", NETCONFBaseNamespace)
+		w.writeAttr("nc:operation", string(n.op))
+	}
+
+	switch {
+	case n.text != nil:
+		w.sb.WriteString(">")
+		w.writeText(*n.text)
+	case len(n.children) > 0:
+		w.sb.WriteString(">")
+		w.newline()
+		for _, c := range n.children {
+			if err := w.write(c, depth+1); err != nil {
+				return err
+			}
+		}
+		w.writeIndent(depth)
+	default:
+		w.sb.WriteString("/>")
+		w.newline()
+		return nil
+	}
+	w.sb.WriteString("</" + n.name + ">")
+	w.newline()
+	return nil
+}
+
+// writeNamespace writes the attribute attr, which declares the namespace of
+// module.
+func (w *xmlWriter) writeNamespace(attr, module string) error {
+	ns, ok := w.namespaces[module]
+	if !ok {
+		return fmt.Errorf("no namespace is specified for module %s", module)
+	}
+	w.writeAttr(attr, ns)
+	return nil
+}
+
+// writeAttr writes the attribute attr with the value v.
+func (w *xmlWriter) writeAttr(attr, v string) {
+	w.sb.WriteString(" " + attr + `="`)
+	w.writeText(v)
+	w.sb.WriteString(`"`)
+}
+
+// writeText writes the escaped text s.
+func (w *xmlWriter) writeText(s string) {
+	// EscapeText only returns errors from the underlying writer, which
+	// cannot fail.
+	_ = xml.EscapeText(&w.sb, []byte(s))
+}
+
+// writeIndent writes the prefix and indentation of a line whose element is
+// nested within depth elements, if the output is indented.
+func (w *xmlWriter) writeIndent(depth int) {
+	if w.indented() {
+		w.sb.WriteString(w.prefix + strings.Repeat(w.indent, depth))
+	}
+}
+
+// newline writes a newline if the output is indented.
+func (w *xmlWriter) newline() {
+	if w.indented() {
+		w.sb.WriteString("\n")
+	}
+}
+
+// indented reports whether the output is indented.
+func (w *xmlWriter) indented() bool {
+	return w.indent != "" || w.prefix != ""
+}
@@ -0,0 +1,322 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/testutil"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestEmitXML(t *testing.T) {
+	d := &exampleoc.Device{}
+	i := d.GetOrCreateInterface("eth0")
+	i.Description = ygot.String("uplink <core> & more")
+	i.Mtu = ygot.Uint16(9000)
+	i.Type = exampleoc.IETFInterfaces_InterfaceType_ethernetCsmacd
+	i.GetOrCreateSubinterface(0).GetOrCreateIpv4().GetOrCreateAddress("192.0.2.1").PrefixLength = ygot.Uint8(24)
+	d.GetOrCreateSystem().GetOrCreateDns().Search = []string{"example.com", "example.net"}
+
+	acl := &exampleoc.Device{}
+	ae := acl.GetOrCreateAcl().GetOrCreateAclSet("acl", exampleoc.Acl_ACL_TYPE_ACL_IPV4).GetOrCreateAclEntry(10)
+	ae.GetOrCreateIpv4().Protocol = exampleoc.PacketMatchTypes_IP_PROTOCOL_IP_TCP
+	ae.GetOrCreateTransport().SourcePort = exampleoc.UnionUint16(22)
+
+	namespaces := map[string]string{
+		"openconfig-interfaces": "http://openconfig.net/yang/interfaces",
+		"openconfig-if-ip":      "http://openconfig.net/yang/interfaces/ip",
+		"openconfig-system":     "http://openconfig.net/yang/system",
+		"iana-if-type":          "urn:ietf:params:xml:ns:yang:iana-if-type",
+	}
+	schema := exampleoc.SchemaTree["Device"]
+
+	tests := []struct {
+		desc             string
+		in               ygot.GoStruct
+		inOpts           *ygot.EmitXMLConfig
+		want             string
+		wantErrSubstring string
+	}{{
+		desc: "indented",
+		in:   d,
+		inOpts: &ygot.EmitXMLConfig{
+			Schema:     schema,
+			Namespaces: namespaces,
+			Indent:     "  ",
+		},
+		want: `<interfaces xmlns="http://openconfig.net/yang/interfaces">
+  <interface>
+    <name>eth0</name>
+    <config>
+      <description>uplink &lt;core&gt; &amp; more</description>
+      <mtu>9000</mtu>
+      <name>eth0</name>
+      <type xmlns:iana-if-type="urn:ietf:params:xml:ns:yang:iana-if-type">iana-if-type:ethernetCsmacd</type>
+    </config>
+    <subinterfaces>
+      <subinterface>
+        <index>0</index>
+        <config>
+          <index>0</index>
+        </config>
+        <ipv4 xmlns="http://openconfig.net/yang/interfaces/ip">
+          <addresses>
+            <address>
+              <ip>192.0.2.1</ip>
+              <config>
+                <ip>192.0.2.1</ip>
+                <prefix-length>24</prefix-length>
+              </config>
+            </address>
+          </addresses>
+        </ipv4>
+      </subinterface>
+    </subinterfaces>
+  </interface>
+</interfaces>
+<system xmlns="http://openconfig.net/yang/system">
+  <dns>
+    <config>
+      <search>example.com</search>
+      <search>example.net</search>
+    </config>
+  </dns>
+</system>
+`,
+	}, {
+		desc: "operations",
+		in:   d,
+		inOpts: &ygot.EmitXMLConfig{
+			Schema:     schema,
+			Namespaces: namespaces,
+			Operations: []*ygot.XMLOperation{{
+				Path:      mustPath("/interfaces/interface[name=eth0]/subinterfaces/subinterface[index=0]"),
+				Operation: ygot.NETCONFReplace,
+			}, {
+				Path:      mustPath("/system/dns/config/search"),
+				Operation: ygot.NETCONFMerge,
+			}, {
+				Path:      mustPath("/interfaces/interface[name=eth1]"),
+				Operation: ygot.NETCONFDelete,
+			}, {
+				Path:      mustPath("/openconfig-system:system/config/hostname"),
+				Operation: ygot.NETCONFRemove,
+			}},
+		},
+		want: `<interfaces xmlns="http://openconfig.net/yang/interfaces">` +
+			`<interface><name>eth0</name>` +
+			`<config><description>uplink &lt;core&gt; &amp; more</description><mtu>9000</mtu><name>eth0</name>` +
+			`<type xmlns:iana-if-type="urn:ietf:params:xml:ns:yang:iana-if-type">iana-if-type:ethernetCsmacd</type></config>` +
+			`<subinterfaces><subinterface xmlns:nc="urn:ietf:params:xml:ns:netconf:base:1.0" nc:operation="replace"><index>0</index><config><index>0</index></config>` +
+			`<ipv4 xmlns="http://openconfig.net/yang/interfaces/ip"><addresses><address><ip>192.0.2.1</ip>` +
+			`<config><ip>192.0.2.1</ip><prefix-length>24</prefix-length></config></address></addresses></ipv4>` +
+			`</subinterface></subinterfaces></interface>` +
+			`<interface xmlns:nc="urn:ietf:params:xml:ns:netconf:base:1.0" nc:operation="delete"><name>eth1</name></interface>` +
+			`</interfaces>` +
+			`<system xmlns="http://openconfig.net/yang/system"><dns><config>` +
+			`<search xmlns:nc="urn:ietf:params:xml:ns:netconf:base:1.0" nc:operation="merge">example.com</search>` +
+			`<search xmlns:nc="urn:ietf:params:xml:ns:netconf:base:1.0" nc:operation="merge">example.net</search>` +
+			`</config></dns>` +
+			`<config><hostname xmlns:nc="urn:ietf:params:xml:ns:netconf:base:1.0" nc:operation="remove"/></config></system>`,
+	}, {
+		desc: "operation for node not in the GoStruct",
+		in:   &exampleoc.Device{},
+		inOpts: &ygot.EmitXMLConfig{
+			Schema:     schema,
+			Namespaces: namespaces,
+			Operations: []*ygot.XMLOperation{{
+				Path:      mustPath("/openconfig-interfaces:interfaces/interface[name=eth0]/config/mtu"),
+				Operation: ygot.NETCONFDelete,
+			}},
+		},
+		want: `<interfaces xmlns="http://openconfig.net/yang/interfaces"><interface><name>eth0</name>` +
+			`<config><mtu xmlns:nc="urn:ietf:params:xml:ns:netconf:base:1.0" nc:operation="delete"/></config>` +
+			`</interface></interfaces>`,
+	}, {
+		desc: "unqualified top-level node not in the GoStruct",
+		in:   &exampleoc.Device{},
+		inOpts: &ygot.EmitXMLConfig{
+			Schema:     schema,
+			Namespaces: namespaces,
+			Operations: []*ygot.XMLOperation{{
+				Path:      mustPath("/interfaces"),
+				Operation: ygot.NETCONFDelete,
+			}},
+		},
+		wantErrSubstring: "interfaces must be qualified by its module name",
+	}, {
+		desc: "list without keys",
+		in:   d,
+		inOpts: &ygot.EmitXMLConfig{
+			Schema:     schema,
+			Namespaces: namespaces,
+			Operations: []*ygot.XMLOperation{{
+				Path:      mustPath("/interfaces/interface"),
+				Operation: ygot.NETCONFDelete,
+			}},
+		},
+		wantErrSubstring: `list interface requires keys "name"`,
+	}, {
+		desc: "unknown node",
+		in:   d,
+		inOpts: &ygot.EmitXMLConfig{
+			Schema:     schema,
+			Namespaces: namespaces,
+			Operations: []*ygot.XMLOperation{{
+				Path:      mustPath("/interfaces/not-a-node"),
+				Operation: ygot.NETCONFMerge,
+			}},
+		},
+		wantErrSubstring: "cannot find schema for not-a-node",
+	}, {
+		desc: "invalid operation",
+		in:   d,
+		inOpts: &ygot.EmitXMLConfig{
+			Schema:     schema,
+			Namespaces: namespaces,
+			Operations: []*ygot.XMLOperation{{
+				Path:      mustPath("/interfaces"),
+				Operation: "overwrite",
+			}},
+		},
+		wantErrSubstring: `invalid NETCONF operation "overwrite"`,
+	}, {
+		desc: "missing namespace",
+		in:   d,
+		inOpts: &ygot.EmitXMLConfig{
+			Schema:     schema,
+			Namespaces: map[string]string{"openconfig-interfaces": "http://openconfig.net/yang/interfaces"},
+		},
+		wantErrSubstring: "no namespace is specified for module",
+	}, {
+		desc: "identityref within union",
+		in:   acl,
+		inOpts: &ygot.EmitXMLConfig{
+			Schema: schema,
+			Namespaces: map[string]string{
+				"openconfig-acl":                "http://openconfig.net/yang/acl",
+				"openconfig-packet-match-types": "http://openconfig.net/yang/packet-match-types",
+			},
+		},
+		want: `<acl xmlns="http://openconfig.net/yang/acl"><acl-sets><acl-set>` +
+			`<name>acl</name><type xmlns:openconfig-acl="http://openconfig.net/yang/acl">openconfig-acl:ACL_IPV4</type>` +
+			`<acl-entries><acl-entry><sequence-id>10</sequence-id><config><sequence-id>10</sequence-id></config>` +
+			`<ipv4><config><protocol xmlns:openconfig-packet-match-types="http://openconfig.net/yang/packet-match-types">openconfig-packet-match-types:IP_TCP</protocol></config></ipv4>` +
+			`<transport><config><source-port>22</source-port></config></transport></acl-entry></acl-entries>` +
+			`<config><name>acl</name><type xmlns:openconfig-acl="http://openconfig.net/yang/acl">openconfig-acl:ACL_IPV4</type></config>` +
+			`</acl-set></acl-sets></acl>`,
+	}, {
+		desc:             "missing schema",
+		in:               d,
+		wantErrSubstring: "requires the schema",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ygot.EmitXML(tt.in, tt.inOpts)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("EmitXML: %s", diff)
+			}
+			if got != tt.want {
+				diff, _ := testutil.GenerateUnifiedDiff(tt.want, got)
+				t.Errorf("EmitXML: did not get expected output, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEmitXMLFromJSON(t *testing.T) {
+	namespaces := map[string]string{
+		"openconfig-system": "http://openconfig.net/yang/system",
+	}
+	schema := exampleoc.SchemaTree["Device"]
+
+	tests := []struct {
+		desc             string
+		in               any
+		inOpts           *ygot.EmitXMLConfig
+		want             string
+		wantErrSubstring string
+	}{{
+		desc: "prefixed",
+		in: map[string]any{
+			"openconfig-system:system": map[string]any{
+				"config": map[string]any{"hostname": "a"},
+			},
+		},
+		inOpts: &ygot.EmitXMLConfig{
+			Schema:     schema,
+			Namespaces: namespaces,
+			Prefix:     "> ",
+			Indent:     "  ",
+		},
+		want: `> <system xmlns="http://openconfig.net/yang/system">
+>   <config>
+>     <hostname>a</hostname>
+>   </config>
+> </system>
+`,
+	}, {
+		desc: "JSON numbers",
+		in: map[string]any{
+			"openconfig-system:system": map[string]any{
+				"dns": map[string]any{
+					"servers": map[string]any{
+						"server": []any{map[string]any{
+							"address": "192.0.2.1",
+							"config":  map[string]any{"address": "192.0.2.1", "port": json.Number("53")},
+						}},
+					},
+				},
+			},
+		},
+		inOpts: &ygot.EmitXMLConfig{
+			Schema:     schema,
+			Namespaces: namespaces,
+		},
+		want: `<system xmlns="http://openconfig.net/yang/system"><dns><servers><server>` +
+			`<address>192.0.2.1</address><config><address>192.0.2.1</address><port>53</port></config>` +
+			`</server></servers></dns></system>`,
+	}, {
+		desc: "not an object",
+		in:   []any{},
+		inOpts: &ygot.EmitXMLConfig{
+			Schema:     schema,
+			Namespaces: namespaces,
+		},
+		wantErrSubstring: "invalid JSON rendering",
+	}, {
+		desc:             "missing schema",
+		in:               map[string]any{},
+		wantErrSubstring: "requires the schema",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ygot.EmitXMLFromJSON(tt.in, tt.inOpts)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("EmitXMLFromJSON: %s", diff)
+			}
+			if got != tt.want {
+				diff, _ := testutil.GenerateUnifiedDiff(tt.want, got)
+				t.Errorf("EmitXMLFromJSON: did not get expected output, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// defaultYAMLIndent is the default number of spaces used for
+	// indentation within YAML output.
+	defaultYAMLIndent = 2
+)
+
+// EmitYAMLConfig specifies how YAML should be created by the EmitYAML
+// function.
+type EmitYAMLConfig struct {
+	// RFC7951Config specifies the configuration options for the RFC7951
+	// JSON tree from which the YAML is rendered, e.g., whether module names
+	// are prepended to the names of nodes.
+	RFC7951Config *RFC7951JSONConfig
+	// Indent is the number of spaces used for indentation within the YAML
+	// output. The default value is two spaces.
+	Indent int
+	// SkipValidation specifies whether the GoStruct supplied to EmitYAML
+	// should be validated before emitting its content. Validation is skipped
+	// when it is set to true.
+	SkipValidation bool
+	// ValidationOpts is the set of options that should be used to determine
+	// how the schema should be validated.
+	ValidationOpts []ValidationOption
+	// WithDefaults specifies the mode in which leaves whose value is their
+	// default are emitted, as per ApplyWithDefaults. By default, the
+	// GoStruct is emitted as it is stored.
+	WithDefaults WithDefaultsMode
+	// WithDefaultsSchema is the schema of the GoStruct supplied to EmitYAML,
+	// from which the defaults that are applied by WithDefaults are read.
+	// If it is nil, the defaults are populated by PopulateDefaults.
+	WithDefaultsSchema *yang.Entry
+	// Redactor, if set, specifies that the values of the sensitive leaves
+	// that it identifies are replaced with RedactedValue within the YAML
+	// output.
+	Redactor *Redactor
+}
+
+// EmitYAML takes an input GoStruct (produced by ygen with validation enabled)
+// and serialises it to a YAML string. The YAML document has the same
+// structure as the RFC7951 JSON that is produced by EmitJSON, such that the
+// names of nodes, and the representation of their values, follow the
+// conventions of RFC7951. In particular, 64-bit integer and decimal64 values
+// are rendered as strings. The YAML can be unmarshalled into a GoStruct using
+// ytypes.UnmarshalYAML.
+func EmitYAML(gs GoStruct, opts *EmitYAMLConfig) (string, error) {
+	if opts == nil {
+		opts = &EmitYAMLConfig{}
+	}
+	stored := gs
+	if opts.WithDefaults != WithDefaultsExplicit {
+		var err error
+		if gs, err = ApplyWithDefaults(opts.WithDefaultsSchema, gs, opts.WithDefaults); err != nil {
+			return "", err
+		}
+	}
+	if !opts.SkipValidation {
+		if err := ValidateGoStruct(gs, opts.ValidationOpts...); err != nil {
+			return "", fmt.Errorf("validation err: %v", err)
+		}
+	}
+
+	v, err := ConstructIETFJSON(gs, opts.RFC7951Config)
+	if err != nil {
+		return "", fmt.Errorf("ConstructIETFJSON error: %v", err)
+	}
+	if opts.WithDefaults == WithDefaultsReportAllTagged {
+		trimmed, err := ApplyWithDefaults(opts.WithDefaultsSchema, stored, WithDefaultsTrim)
+		if err != nil {
+			return "", err
+		}
+		tv, err := ConstructIETFJSON(trimmed, opts.RFC7951Config)
+		if err != nil {
+			return "", fmt.Errorf("ConstructIETFJSON error: %v", err)
+		}
+		tagJSONDefaults(v, tv)
+	}
+	if opts.Redactor != nil {
+		opts.Redactor.RedactJSON(v)
+	}
+	nv, err := yamlValue(v)
+	if err != nil {
+		return "", err
+	}
+
+	indent := defaultYAMLIndent
+	if opts.Indent > 0 {
+		indent = opts.Indent
+	}
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(indent)
+	if err := enc.Encode(nv); err != nil {
+		return "", fmt.Errorf("YAML marshalling error: %v", err)
+	}
+	if err := enc.Close(); err != nil {
+		return "", fmt.Errorf("YAML marshalling error: %v", err)
+	}
+	return b.String(), nil
+}
+
+// yamlValue returns the JSON object v with its values normalised to the Go
+// types that are rendered as the equivalent YAML scalars. Numbers are
+// converted to int64 where they are integral, such that they are not emitted
+// in exponent notation.
+func yamlValue(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal JSON value: %v", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var nv any
+	if err := dec.Decode(&nv); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal JSON value: %v", err)
+	}
+	return yamlNumbers(nv), nil
+}
+
+// yamlNumbers replaces the json.Number values within the JSON value v with
+// int64 or float64 values.
+func yamlNumbers(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			v[k] = yamlNumbers(e)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = yamlNumbers(e)
+		}
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+	}
+	return v
+}
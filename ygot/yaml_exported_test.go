@@ -0,0 +1,128 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot_test
+
+import (
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/testutil"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestEmitYAML(t *testing.T) {
+	d := &exampleoc.Device{}
+	i := d.GetOrCreateInterface("eth0")
+	i.Description = ygot.String("uplink: core")
+	i.Mtu = ygot.Uint16(9000)
+	i.Type = exampleoc.IETFInterfaces_InterfaceType_ethernetCsmacd
+	i.GetOrCreateCounters().InOctets = ygot.Uint64(1 << 60)
+	i.GetOrCreateSubinterface(0).Enabled = ygot.Bool(true)
+	d.GetOrCreateSystem().GetOrCreateDns().Search = []string{"example.com", "example.net"}
+
+	tests := []struct {
+		desc             string
+		in               ygot.GoStruct
+		inOpts           *ygot.EmitYAMLConfig
+		want             string
+		wantErrSubstring string
+	}{{
+		desc:   "default options",
+		in:     d,
+		inOpts: &ygot.EmitYAMLConfig{SkipValidation: true},
+		want: `interfaces:
+  interface:
+    - config:
+        description: 'uplink: core'
+        mtu: 9000
+        name: eth0
+        type: ethernetCsmacd
+      name: eth0
+      state:
+        counters:
+          in-octets: "1152921504606846976"
+      subinterfaces:
+        subinterface:
+          - config:
+              enabled: true
+              index: 0
+            index: 0
+system:
+  dns:
+    config:
+      search:
+        - example.com
+        - example.net
+`,
+	}, {
+		desc: "module names and indentation",
+		in:   d,
+		inOpts: &ygot.EmitYAMLConfig{
+			RFC7951Config:  &ygot.RFC7951JSONConfig{AppendModuleName: true},
+			Indent:         4,
+			SkipValidation: true,
+		},
+		want: `openconfig-interfaces:interfaces:
+    interface:
+        - config:
+            description: 'uplink: core'
+            mtu: 9000
+            name: eth0
+            type: iana-if-type:ethernetCsmacd
+          name: eth0
+          state:
+            counters:
+                in-octets: "1152921504606846976"
+          subinterfaces:
+            subinterface:
+                - config:
+                    enabled: true
+                    index: 0
+                  index: 0
+openconfig-system:system:
+    dns:
+        config:
+            search:
+                - example.com
+                - example.net
+`,
+	}, {
+		desc: "empty struct",
+		in:   &exampleoc.Device{},
+		want: "{}\n",
+	}, {
+		desc: "validation failure",
+		in: &exampleoc.Device{
+			Interface: map[string]*exampleoc.Interface{
+				"eth0": {Name: ygot.String("eth1")},
+			},
+		},
+		wantErrSubstring: "validation err",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ygot.EmitYAML(tt.in, tt.inOpts)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("EmitYAML: %s", diff)
+			}
+			if got != tt.want {
+				diff, _ := testutil.GenerateUnifiedDiff(tt.want, got)
+				t.Errorf("EmitYAML: did not get expected output, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,343 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/gnmi/errlist"
+)
+
+const (
+	// yangLibraryModule is the name of the module that defines the YANG
+	// library.
+	yangLibraryModule = "ietf-yang-library"
+)
+
+// YANGLibraryDatastores is the set of datastores that are reported by
+// NewYANGLibrary, as identities defined in the ietf-datastores module.
+var YANGLibraryDatastores = []string{
+	"ietf-datastores:running",
+	"ietf-datastores:intended",
+	"ietf-datastores:operational",
+}
+
+// YANGLibrary is a GoStruct that represents the /yang-library container of
+// the ietf-yang-library module, as defined in RFC 8525. It describes the
+// modules that are implemented by a server, such that clients can determine
+// the schema of the data that it serves. It can be constructed from the
+// ΓYANGLibraryModules variable that is generated when the YANG library is
+// included in generated code using NewYANGLibrary, and rendered using the
+// functions that accept a ValidatedGoStruct, e.g., EmitJSON.
+//
+// Only the module-set, schema and datastore lists of the YANG library are
+// represented. Import-only modules are reported as implemented modules.
+type YANGLibrary struct {
+	ContentId *string                          `path:"yang-library/content-id" module:"ietf-yang-library/ietf-yang-library"`
+	Datastore map[string]*YANGLibraryDatastore `path:"yang-library/datastore" module:"ietf-yang-library/ietf-yang-library"`
+	ModuleSet map[string]*YANGLibraryModuleSet `path:"yang-library/module-set" module:"ietf-yang-library/ietf-yang-library"`
+	Schema    map[string]*YANGLibrarySchema    `path:"yang-library/schema" module:"ietf-yang-library/ietf-yang-library"`
+}
+
+// YANGLibraryModuleSet represents an entry of the
+// /yang-library/module-set list of the ietf-yang-library module.
+type YANGLibraryModuleSet struct {
+	Module map[string]*YANGLibraryModule `path:"module" module:"ietf-yang-library"`
+	Name   *string                       `path:"name" module:"ietf-yang-library"`
+}
+
+// YANGLibraryModule represents an entry of the
+// /yang-library/module-set/module list of the ietf-yang-library module,
+// describing a single implemented module.
+type YANGLibraryModule struct {
+	Deviation []string                         `path:"deviation" module:"ietf-yang-library"`
+	Feature   []string                         `path:"feature" module:"ietf-yang-library"`
+	Name      *string                          `path:"name" module:"ietf-yang-library"`
+	Namespace *string                          `path:"namespace" module:"ietf-yang-library"`
+	Revision  *string                          `path:"revision" module:"ietf-yang-library"`
+	Submodule map[string]*YANGLibrarySubmodule `path:"submodule" module:"ietf-yang-library"`
+}
+
+// YANGLibrarySubmodule represents an entry of the
+// /yang-library/module-set/module/submodule list of the ietf-yang-library
+// module.
+type YANGLibrarySubmodule struct {
+	Name     *string `path:"name" module:"ietf-yang-library"`
+	Revision *string `path:"revision" module:"ietf-yang-library"`
+}
+
+// YANGLibrarySchema represents an entry of the /yang-library/schema list of
+// the ietf-yang-library module.
+type YANGLibrarySchema struct {
+	ModuleSet []string `path:"module-set" module:"ietf-yang-library"`
+	Name      *string  `path:"name" module:"ietf-yang-library"`
+}
+
+// YANGLibraryDatastore represents an entry of the /yang-library/datastore
+// list of the ietf-yang-library module.
+type YANGLibraryDatastore struct {
+	Name   *string `path:"name" module:"ietf-yang-library"`
+	Schema *string `path:"schema" module:"ietf-yang-library"`
+}
+
+// NewYANGLibrary returns a YANGLibrary that reports the supplied modules, as
+// a single module set and schema with the supplied name, which is used by
+// each of the YANGLibraryDatastores. The content-id of the library is
+// derived from its contents, such that it changes if and only if the
+// modules change. The modules are copied, such that the returned library
+// does not share state with the supplied modules.
+func NewYANGLibrary(name string, modules []*YANGLibraryModule) (*YANGLibrary, error) {
+	set := &YANGLibraryModuleSet{Name: String(name), Module: map[string]*YANGLibraryModule{}}
+	for _, m := range modules {
+		if m.Name == nil {
+			return nil, fmt.Errorf("module within YANG library %s has no name", name)
+		}
+		if _, ok := set.Module[*m.Name]; ok {
+			return nil, fmt.Errorf("duplicate module %s within YANG library %s", *m.Name, name)
+		}
+		c, err := DeepCopy(m)
+		if err != nil {
+			return nil, fmt.Errorf("cannot copy module %s: %v", *m.Name, err)
+		}
+		set.Module[*m.Name] = c.(*YANGLibraryModule)
+	}
+
+	lib := &YANGLibrary{
+		ModuleSet: map[string]*YANGLibraryModuleSet{name: set},
+		Schema: map[string]*YANGLibrarySchema{
+			name: {Name: String(name), ModuleSet: []string{name}},
+		},
+		Datastore: map[string]*YANGLibraryDatastore{},
+	}
+	for _, ds := range YANGLibraryDatastores {
+		lib.Datastore[ds] = &YANGLibraryDatastore{Name: String(ds), Schema: String(name)}
+	}
+	lib.ContentId = String(yangLibraryContentID(set))
+	if err := lib.Validate(); err != nil {
+		return nil, err
+	}
+	return lib, nil
+}
+
+// yangLibraryContentID returns an identifier for the contents of the module
+// set s, which is a hash of the name, revision, features, deviations and
+// submodules of each of its modules.
+func yangLibraryContentID(s *YANGLibraryModuleSet) string {
+	var names []string
+	for n := range s.Module {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	for _, n := range names {
+		m := s.Module[n]
+		fmt.Fprintf(h, "%s@%s;%s;", n, m.GetRevision(), m.GetNamespace())
+		fmt.Fprintf(h, "f=%s;", strings.Join(sortedCopy(m.Feature), ","))
+		fmt.Fprintf(h, "d=%s;", strings.Join(sortedCopy(m.Deviation), ","))
+		var subs []string
+		for sn, sm := range m.Submodule {
+			subs = append(subs, sn+"@"+sm.GetRevision())
+		}
+		fmt.Fprintf(h, "s=%s\n", strings.Join(sortedCopy(subs), ","))
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// sortedCopy returns a sorted copy of s.
+func sortedCopy(s []string) []string {
+	c := append([]string{}, s...)
+	sort.Strings(c)
+	return c
+}
+
+// GetName returns the value of the Name field of the module, or the empty
+// string if it is unset.
+func (m *YANGLibraryModule) GetName() string {
+	if m == nil || m.Name == nil {
+		return ""
+	}
+	return *m.Name
+}
+
+// GetRevision returns the value of the Revision field of the module, or the
+// empty string if it is unset.
+func (m *YANGLibraryModule) GetRevision() string {
+	if m == nil || m.Revision == nil {
+		return ""
+	}
+	return *m.Revision
+}
+
+// GetNamespace returns the value of the Namespace field of the module, or
+// the empty string if it is unset.
+func (m *YANGLibraryModule) GetNamespace() string {
+	if m == nil || m.Namespace == nil {
+		return ""
+	}
+	return *m.Namespace
+}
+
+// GetRevision returns the value of the Revision field of the submodule, or
+// the empty string if it is unset.
+func (m *YANGLibrarySubmodule) GetRevision() string {
+	if m == nil || m.Revision == nil {
+		return ""
+	}
+	return *m.Revision
+}
+
+// Validate checks that the mandatory leaves of the YANG library are
+// populated, that list entries are keyed by their names, and that its
+// references between datastores, schemas, module sets and deviation modules
+// refer to existing entries.
+func (l *YANGLibrary) Validate(...ValidationOption) error {
+	var errs errlist.List
+	if l.ContentId == nil {
+		errs.Add(fmt.Errorf("YANG library has no content-id"))
+	}
+	for k, s := range l.ModuleSet {
+		if s.Name == nil || *s.Name != k {
+			errs.Add(fmt.Errorf("module-set %s has mismatched name %v", k, s.Name))
+		}
+		for mk, m := range s.Module {
+			if m.Name == nil || *m.Name != mk {
+				errs.Add(fmt.Errorf("module %s in module-set %s has mismatched name %v", mk, k, m.Name))
+			}
+			if m.Namespace == nil {
+				errs.Add(fmt.Errorf("module %s in module-set %s has no namespace", mk, k))
+			}
+			for _, d := range m.Deviation {
+				if _, ok := s.Module[d]; !ok {
+					errs.Add(fmt.Errorf("module %s in module-set %s is deviated by unknown module %s", mk, k, d))
+				}
+			}
+			for sk, sm := range m.Submodule {
+				if sm.Name == nil || *sm.Name != sk {
+					errs.Add(fmt.Errorf("submodule %s of module %s has mismatched name %v", sk, mk, sm.Name))
+				}
+			}
+		}
+	}
+	for k, s := range l.Schema {
+		if s.Name == nil || *s.Name != k {
+			errs.Add(fmt.Errorf("schema %s has mismatched name %v", k, s.Name))
+		}
+		for _, ms := range s.ModuleSet {
+			if _, ok := l.ModuleSet[ms]; !ok {
+				errs.Add(fmt.Errorf("schema %s refers to unknown module-set %s", k, ms))
+			}
+		}
+	}
+	for k, d := range l.Datastore {
+		if d.Name == nil || *d.Name != k {
+			errs.Add(fmt.Errorf("datastore %s has mismatched name %v", k, d.Name))
+		}
+		if d.Schema == nil {
+			errs.Add(fmt.Errorf("datastore %s has no schema", k))
+		} else if _, ok := l.Schema[*d.Schema]; !ok {
+			errs.Add(fmt.Errorf("datastore %s refers to unknown schema %s", k, *d.Schema))
+		}
+	}
+	return errs.Err()
+}
+
+// IsYANGGoStruct implements the GoStruct interface.
+func (*YANGLibrary) IsYANGGoStruct() {}
+
+// ΛValidate implements the validatedGoStruct interface.
+func (l *YANGLibrary) ΛValidate(opts ...ValidationOption) error { return l.Validate(opts...) }
+
+// ΛEnumTypeMap implements the ValidatedGoStruct interface.
+func (*YANGLibrary) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+
+// ΛBelongingModule implements the ValidatedGoStruct interface. The
+// YANGLibrary represents a root, and hence belongs to no module.
+func (*YANGLibrary) ΛBelongingModule() string { return "" }
+
+// IsYANGGoStruct implements the GoStruct interface.
+func (*YANGLibraryModuleSet) IsYANGGoStruct() {}
+
+// ΛBelongingModule implements the ValidatedGoStruct interface.
+func (*YANGLibraryModuleSet) ΛBelongingModule() string { return yangLibraryModule }
+
+// ΛListKeyMap returns the keys of the YANGLibraryModuleSet as a map.
+func (s *YANGLibraryModuleSet) ΛListKeyMap() (map[string]any, error) {
+	if s.Name == nil {
+		return nil, fmt.Errorf("nil value for key Name")
+	}
+	return map[string]any{"name": *s.Name}, nil
+}
+
+// IsYANGGoStruct implements the GoStruct interface.
+func (*YANGLibraryModule) IsYANGGoStruct() {}
+
+// ΛBelongingModule implements the ValidatedGoStruct interface.
+func (*YANGLibraryModule) ΛBelongingModule() string { return yangLibraryModule }
+
+// ΛListKeyMap returns the keys of the YANGLibraryModule as a map.
+func (m *YANGLibraryModule) ΛListKeyMap() (map[string]any, error) {
+	if m.Name == nil {
+		return nil, fmt.Errorf("nil value for key Name")
+	}
+	return map[string]any{"name": *m.Name}, nil
+}
+
+// IsYANGGoStruct implements the GoStruct interface.
+func (*YANGLibrarySubmodule) IsYANGGoStruct() {}
+
+// ΛBelongingModule implements the ValidatedGoStruct interface.
+func (*YANGLibrarySubmodule) ΛBelongingModule() string { return yangLibraryModule }
+
+// ΛListKeyMap returns the keys of the YANGLibrarySubmodule as a map.
+func (m *YANGLibrarySubmodule) ΛListKeyMap() (map[string]any, error) {
+	if m.Name == nil {
+		return nil, fmt.Errorf("nil value for key Name")
+	}
+	return map[string]any{"name": *m.Name}, nil
+}
+
+// IsYANGGoStruct implements the GoStruct interface.
+func (*YANGLibrarySchema) IsYANGGoStruct() {}
+
+// ΛBelongingModule implements the ValidatedGoStruct interface.
+func (*YANGLibrarySchema) ΛBelongingModule() string { return yangLibraryModule }
+
+// ΛListKeyMap returns the keys of the YANGLibrarySchema as a map.
+func (s *YANGLibrarySchema) ΛListKeyMap() (map[string]any, error) {
+	if s.Name == nil {
+		return nil, fmt.Errorf("nil value for key Name")
+	}
+	return map[string]any{"name": *s.Name}, nil
+}
+
+// IsYANGGoStruct implements the GoStruct interface.
+func (*YANGLibraryDatastore) IsYANGGoStruct() {}
+
+// ΛBelongingModule implements the ValidatedGoStruct interface.
+func (*YANGLibraryDatastore) ΛBelongingModule() string { return yangLibraryModule }
+
+// ΛListKeyMap returns the keys of the YANGLibraryDatastore as a map.
+func (d *YANGLibraryDatastore) ΛListKeyMap() (map[string]any, error) {
+	if d.Name == nil {
+		return nil, fmt.Errorf("nil value for key Name")
+	}
+	return map[string]any{"name": *d.Name}, nil
+}
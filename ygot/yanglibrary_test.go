@@ -0,0 +1,149 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+func testYANGLibraryModules() []*YANGLibraryModule {
+	return []*YANGLibraryModule{{
+		Name:      String("mod-a"),
+		Namespace: String("urn:a"),
+		Revision:  String("2026-01-02"),
+		Feature:   []string{"alpha"},
+		Deviation: []string{"mod-b"},
+		Submodule: map[string]*YANGLibrarySubmodule{
+			"sub-a": {Name: String("sub-a"), Revision: String("2025-12-01")},
+		},
+	}, {
+		Name:      String("mod-b"),
+		Namespace: String("urn:b"),
+	}}
+}
+
+func TestNewYANGLibrary(t *testing.T) {
+	lib, err := NewYANGLibrary("ygot", testYANGLibraryModules())
+	if err != nil {
+		t.Fatalf("NewYANGLibrary: got unexpected error: %v", err)
+	}
+
+	js, err := ConstructIETFJSON(lib, &RFC7951JSONConfig{AppendModuleName: true})
+	if err != nil {
+		t.Fatalf("ConstructIETFJSON: got unexpected error: %v", err)
+	}
+	b, err := json.Marshal(js)
+	if err != nil {
+		t.Fatalf("json.Marshal: got unexpected error: %v", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("json.Unmarshal: got unexpected error: %v", err)
+	}
+	want := map[string]any{
+		"ietf-yang-library:yang-library": map[string]any{
+			"content-id": *lib.ContentId,
+			"datastore": []any{
+				map[string]any{"name": "ietf-datastores:intended", "schema": "ygot"},
+				map[string]any{"name": "ietf-datastores:operational", "schema": "ygot"},
+				map[string]any{"name": "ietf-datastores:running", "schema": "ygot"},
+			},
+			"module-set": []any{
+				map[string]any{
+					"name": "ygot",
+					"module": []any{
+						map[string]any{
+							"name":      "mod-a",
+							"namespace": "urn:a",
+							"revision":  "2026-01-02",
+							"feature":   []any{"alpha"},
+							"deviation": []any{"mod-b"},
+							"submodule": []any{
+								map[string]any{"name": "sub-a", "revision": "2025-12-01"},
+							},
+						},
+						map[string]any{"name": "mod-b", "namespace": "urn:b"},
+					},
+				},
+			},
+			"schema": []any{
+				map[string]any{"name": "ygot", "module-set": []any{"ygot"}},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("did not get expected JSON (-want, +got):\n%s", diff)
+	}
+}
+
+func TestYANGLibraryContentID(t *testing.T) {
+	a, err := NewYANGLibrary("ygot", testYANGLibraryModules())
+	if err != nil {
+		t.Fatalf("NewYANGLibrary: got unexpected error: %v", err)
+	}
+	b, err := NewYANGLibrary("ygot", testYANGLibraryModules())
+	if err != nil {
+		t.Fatalf("NewYANGLibrary: got unexpected error: %v", err)
+	}
+	if *a.ContentId != *b.ContentId {
+		t.Errorf("content-id differs for equal libraries: %s != %s", *a.ContentId, *b.ContentId)
+	}
+
+	mods := testYANGLibraryModules()
+	mods[1].Revision = String("2026-02-03")
+	c, err := NewYANGLibrary("ygot", mods)
+	if err != nil {
+		t.Fatalf("NewYANGLibrary: got unexpected error: %v", err)
+	}
+	if *a.ContentId == *c.ContentId {
+		t.Errorf("content-id is equal for libraries with different revisions: %s", *a.ContentId)
+	}
+}
+
+func TestNewYANGLibraryErrors(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               []*YANGLibraryModule
+		wantErrSubstring string
+	}{{
+		desc:             "module without name",
+		in:               []*YANGLibraryModule{{Namespace: String("urn:a")}},
+		wantErrSubstring: "has no name",
+	}, {
+		desc:             "duplicate module",
+		in:               []*YANGLibraryModule{{Name: String("a"), Namespace: String("urn:a")}, {Name: String("a"), Namespace: String("urn:a")}},
+		wantErrSubstring: "duplicate module a",
+	}, {
+		desc:             "module without namespace",
+		in:               []*YANGLibraryModule{{Name: String("a")}},
+		wantErrSubstring: "has no namespace",
+	}, {
+		desc:             "unknown deviation module",
+		in:               []*YANGLibraryModule{{Name: String("a"), Namespace: String("urn:a"), Deviation: []string{"b"}}},
+		wantErrSubstring: "unknown module b",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := NewYANGLibrary("ygot", tt.in)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("NewYANGLibrary: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary ygotconvert converts a YANG instance document between RFC7951 JSON,
+// as used by RESTCONF, XML, as used by NETCONF, and YANG-CBOR, as used by
+// CORECONF, against a schema that is loaded from YANG modules at runtime,
+// without generating code. The document is validated against the schema,
+// which determines the types of its values, such that values are written
+// in their canonical form within the target encoding.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/openconfig/ygot/yinstance"
+)
+
+// formatAuto is the input format in which documents whose names end in
+// ".xml" are XML, those whose names end in ".cbor" are YANG-CBOR, and other
+// documents are JSON.
+const formatAuto = "auto"
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run converts the instance document specified by args, which is read from
+// stdin if it is "-", writing the result to stdout unless an output file is
+// specified, and errors to stderr. It returns the exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("ygotconvert", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: ygotconvert -yang <modules> -to <format> [flags] <document>\n")
+		fs.PrintDefaults()
+	}
+	yangFiles := fs.String("yang", "", "Comma separated list of the YANG modules that describe the schema of the instance document.")
+	includePaths := fs.String("include", "", "Comma separated list of the paths in which imported and included YANG modules are found.")
+	from := fs.String("from", formatAuto, "The format of the instance document, json, xml, cbor or auto. If auto, documents whose names end in .xml are XML, those whose names end in .cbor are YANG-CBOR, and other documents are RFC7951 JSON.")
+	to := fs.String("to", "", "The format to convert the instance document to, json, xml or cbor.")
+	output := fs.String("o", "", "The file to write the converted document to. If unset, it is written to stdout.")
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	switch {
+	case *yangFiles == "":
+		fmt.Fprintln(stderr, "Error: no YANG modules specified, use -yang")
+		return 1
+	case fs.NArg() != 1:
+		fmt.Fprintf(stderr, "Error: got %d instance documents, want 1\n", fs.NArg())
+		return 1
+	case *from != formatAuto && !isEncoding(*from):
+		fmt.Fprintf(stderr, "Error: unknown format %q, must be %s or %s\n", *from, formatAuto, encodingList())
+		return 1
+	case !isEncoding(*to):
+		fmt.Fprintf(stderr, "Error: unknown output format %q, must be %s\n", *to, encodingList())
+		return 1
+	}
+
+	s, err := yinstance.LoadSchema(splitList(*yangFiles), splitList(*includePaths))
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: cannot load schema: %v\n", err)
+		return 1
+	}
+
+	fn := fs.Arg(0)
+	var b []byte
+	if fn == "-" {
+		b, err = io.ReadAll(stdin)
+	} else {
+		b, err = os.ReadFile(fn)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: cannot read %s: %v\n", fn, err)
+		return 1
+	}
+
+	enc := yinstance.Encoding(*from)
+	if *from == formatAuto {
+		enc = encodingOf(fn)
+	}
+	out, err := s.Convert(b, enc, yinstance.Encoding(*to))
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s: %v\n", fn, err)
+		return 1
+	}
+	if yinstance.Encoding(*to) == yinstance.EncodingJSON {
+		out = append(out, '\n')
+	}
+
+	if *output != "" {
+		err = os.WriteFile(*output, out, 0644)
+	} else {
+		_, err = stdout.Write(out)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: cannot write output: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// encodingOf returns the encoding of the document in the file fn, as
+// determined by its extension.
+func encodingOf(fn string) yinstance.Encoding {
+	switch strings.ToLower(filepath.Ext(fn)) {
+	case ".xml":
+		return yinstance.EncodingXML
+	case ".cbor":
+		return yinstance.EncodingCBOR
+	}
+	return yinstance.EncodingJSON
+}
+
+// isEncoding reports whether s is the name of a supported encoding.
+func isEncoding(s string) bool {
+	for _, enc := range yinstance.Encodings {
+		if string(enc) == s {
+			return true
+		}
+	}
+	return false
+}
+
+// encodingList returns the names of the supported encodings, separated by
+// commas.
+func encodingList() string {
+	var names []string
+	for _, enc := range yinstance.Encodings {
+		names = append(names, string(enc))
+	}
+	return strings.Join(names, ", ")
+}
+
+// splitList returns the elements of the comma separated list s.
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	testYANG    = "../yinstance/testdata/example.yang,../yinstance/testdata/example-aug.yang"
+	testInclude = "../yinstance/testdata"
+	testJSON    = "../yinstance/testdata/instance.json"
+	testXML     = "../yinstance/testdata/instance.xml"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	cborFile := filepath.Join(dir, "instance.cbor")
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"example:system": {"hostname": ""}}`), 0644); err != nil {
+		t.Fatalf("cannot write %s: %v", invalid, err)
+	}
+
+	tests := []struct {
+		desc       string
+		inArgs     []string
+		inStdin    string
+		wantStatus int
+		wantOut    []string
+		wantErr    string
+	}{{
+		desc:    "JSON to XML",
+		inArgs:  []string{"-yang", testYANG, "-include", testInclude, "-to", "xml", testJSON},
+		wantOut: []string{`<data xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">`, `<location xmlns="urn:example-aug">lab</location>`},
+	}, {
+		desc:   "XML to CBOR file",
+		inArgs: []string{"-yang", testYANG, "-include", testInclude, "-to", "cbor", "-o", cborFile, testXML},
+	}, {
+		desc:    "CBOR file to JSON",
+		inArgs:  []string{"-yang", testYANG, "-include", testInclude, "-to", "json", cborFile},
+		wantOut: []string{`"example:system": {`, `"uptime": "12345"`, `"weight": "1.50"`},
+	}, {
+		desc:    "explicit format from stdin",
+		inArgs:  []string{"-yang", testYANG, "-from", "xml", "-to", "json", "-"},
+		inStdin: `<system xmlns="urn:example"><hostname>dev1</hostname></system>`,
+		wantOut: []string{`"hostname": "dev1"`},
+	}, {
+		desc:       "invalid document",
+		inArgs:     []string{"-yang", testYANG, "-to", "xml", invalid},
+		wantStatus: 1,
+		wantErr:    invalid + ": /system/hostname: ",
+	}, {
+		desc:       "no modules",
+		inArgs:     []string{"-to", "xml", testJSON},
+		wantStatus: 1,
+		wantErr:    "no YANG modules specified",
+	}, {
+		desc:       "wrong number of documents",
+		inArgs:     []string{"-yang", testYANG, "-to", "xml", testJSON, testXML},
+		wantStatus: 1,
+		wantErr:    "got 2 instance documents, want 1",
+	}, {
+		desc:       "unknown input format",
+		inArgs:     []string{"-yang", testYANG, "-from", "yaml", "-to", "xml", testJSON},
+		wantStatus: 1,
+		wantErr:    `unknown format "yaml"`,
+	}, {
+		desc:       "missing output format",
+		inArgs:     []string{"-yang", testYANG, testJSON},
+		wantStatus: 1,
+		wantErr:    `unknown output format ""`,
+	}}
+
+	// The tests are run in order, since they share the CBOR file.
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if got := run(tt.inArgs, strings.NewReader(tt.inStdin), &stdout, &stderr); got != tt.wantStatus {
+				t.Errorf("run(%v): got status %d, want %d, stderr:\n%s", tt.inArgs, got, tt.wantStatus, stderr.String())
+			}
+			for _, want := range tt.wantOut {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("run(%v): got output:\n%s\nwant it to contain %q", tt.inArgs, stdout.String(), want)
+				}
+			}
+			if !strings.Contains(stderr.String(), tt.wantErr) {
+				t.Errorf("run(%v): got stderr %q, want it to contain %q", tt.inArgs, stderr.String(), tt.wantErr)
+			}
+		})
+	}
+}
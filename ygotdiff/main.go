@@ -0,0 +1,174 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary ygotdiff compares two YANG instance documents, encoded as RFC7951
+// JSON or as XML, against a schema that is loaded from YANG modules at
+// runtime, without generating code. Since the documents are compared leaf by
+// leaf after they are validated against the schema, the comparison is
+// semantic: values are compared by their type rather than by their encoding,
+// list entries are matched by their keys regardless of their order, and
+// leaves that are set to their default values can be treated as equal to
+// leaves that are not set. This makes it suitable for reviewing exports of
+// device configuration.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/openconfig/ygot/gnmidiff"
+	"github.com/openconfig/ygot/yinstance"
+)
+
+// Exit statuses of ygotdiff, which follow those of diff(1).
+const (
+	// exitSame indicates that the instance documents are equivalent.
+	exitSame = 0
+	// exitDifferent indicates that the instance documents differ.
+	exitDifferent = 1
+	// exitError indicates that the arguments are invalid, or that the
+	// schema or either document cannot be loaded.
+	exitError = 2
+)
+
+// Formats of instance documents.
+const (
+	// formatAuto is the format in which documents whose names end in
+	// ".xml" are XML, and other documents are JSON.
+	formatAuto = "auto"
+	// formatJSON is the format of RFC7951 JSON documents.
+	formatJSON = "json"
+	// formatXML is the format of XML documents, encoded as per RFC7950.
+	formatXML = "xml"
+)
+
+// Output formats of the diff.
+const (
+	// outputText is the output format of gnmidiff.StructuredDiff.Format.
+	outputText = "text"
+	// outputUnified is the unified diff output format.
+	outputUnified = "unified"
+	// outputHTML is the output format in which the diff is written as an
+	// HTML fragment containing a tree view of the leaves.
+	outputHTML = "html"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run compares the two instance documents specified by args, writing the
+// diff to stdout and errors to stderr, and returns the exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("ygotdiff", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: ygotdiff -yang <modules> [flags] <a> <b>\n")
+		fs.PrintDefaults()
+	}
+	yangFiles := fs.String("yang", "", "Comma separated list of the YANG modules that describe the schema of the instance documents.")
+	includePaths := fs.String("include", "", "Comma separated list of the paths in which imported and included YANG modules are found.")
+	format := fs.String("format", formatAuto, "The format of the instance documents, json, xml or auto. If auto, documents whose names end in .xml are XML, and other documents are RFC7951 JSON.")
+	defaults := fs.Bool("defaults", true, "If set to true, a leaf that is set to its default value within one document is equal to the leaf being unset within the other.")
+	full := fs.Bool("full", false, "If set to true, leaves that are common to both documents are also written in the text output format.")
+	output := fs.String("output", outputText, "The format of the output, text, unified or html.")
+	context := fs.Int("context", 3, "The number of unchanged leaves that are written around each change in the unified output format.")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+
+	switch {
+	case *yangFiles == "":
+		fmt.Fprintln(stderr, "Error: no YANG modules specified, use -yang")
+		return exitError
+	case fs.NArg() != 2:
+		fmt.Fprintf(stderr, "Error: got %d instance documents, want 2\n", fs.NArg())
+		return exitError
+	case *format != formatAuto && *format != formatJSON && *format != formatXML:
+		fmt.Fprintf(stderr, "Error: unknown format %q, must be %s, %s or %s\n", *format, formatAuto, formatJSON, formatXML)
+		return exitError
+	case *output != outputText && *output != outputUnified && *output != outputHTML:
+		fmt.Fprintf(stderr, "Error: unknown output format %q, must be %s, %s or %s\n", *output, outputText, outputUnified, outputHTML)
+		return exitError
+	}
+
+	s, err := yinstance.LoadSchema(splitList(*yangFiles), splitList(*includePaths))
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: cannot load schema: %v\n", err)
+		return exitError
+	}
+
+	aName, bName := fs.Arg(0), fs.Arg(1)
+	a, err := loadInstance(s, aName, *format)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s: %v\n", aName, err)
+		return exitError
+	}
+	b, err := loadInstance(s, bName, *format)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s: %v\n", bName, err)
+		return exitError
+	}
+
+	var opts []yinstance.DiffOpt
+	if *defaults {
+		opts = append(opts, &yinstance.IgnoreDefaults{})
+	}
+	d := yinstance.Diff(a, b, opts...)
+
+	rf := gnmidiff.RenderFormat{AName: aName, BName: bName, Context: *context}
+	var out string
+	switch *output {
+	case outputUnified:
+		out = d.Unified(rf)
+	case outputHTML:
+		out = d.HTML(rf)
+	default:
+		out = d.Format(gnmidiff.Format{Full: *full})
+	}
+	if _, err := io.WriteString(stdout, out); err != nil {
+		fmt.Fprintf(stderr, "Error: cannot write output: %v\n", err)
+		return exitError
+	}
+
+	if len(d.MissingUpdates)+len(d.ExtraUpdates)+len(d.MismatchedUpdates) != 0 {
+		return exitDifferent
+	}
+	return exitSame
+}
+
+// loadInstance parses and validates the instance document in the file fn,
+// which is in the supplied format.
+func loadInstance(s *yinstance.Schema, fn, format string) (*yinstance.Instance, error) {
+	b, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+	if format == formatXML || format == formatAuto && strings.EqualFold(filepath.Ext(fn), ".xml") {
+		return s.ParseXML(b)
+	}
+	return s.ParseJSON(b)
+}
+
+// splitList returns the elements of the comma separated list s.
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	testYANG    = "../yinstance/testdata/example.yang,../yinstance/testdata/example-aug.yang"
+	testInclude = "../yinstance/testdata"
+	testJSON    = "../yinstance/testdata/instance.json"
+	testXML     = "../yinstance/testdata/instance.xml"
+)
+
+func writeTestFile(t *testing.T, name, contents string) string {
+	t.Helper()
+	fn := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(fn, []byte(contents), 0644); err != nil {
+		t.Fatalf("cannot write %s: %v", fn, err)
+	}
+	return fn
+}
+
+func TestRun(t *testing.T) {
+	// other differs from testJSON only in the order of list entries, the
+	// encodings of values, the mtu of eth0, and the default cost of lo0.
+	other := writeTestFile(t, "other.json", `{
+  "example:routing": {"primary": "eth0"},
+  "example:interfaces": {"interface": [
+    {"name": "lo0", "type": "LOOPBACK", "speed": 100, "example-aug:cost": 10},
+    {"name": "eth0", "mtu": 9000, "mac": "AA:BB:CC:DD:EE:FF", "enabled": true, "type": "ETHERNET",
+     "protocol": "EBGP", "weight": "1.5", "speed": "AUTO", "loopback-mode": [null]}
+  ]},
+  "example:system": {"hostname": "dev1", "uptime": "12345", "domain": ["example.com", "example.net"], "example-aug:location": "lab"}
+}`)
+	otherXML := writeTestFile(t, "other.data", `<system xmlns="urn:example"><hostname>dev1</hostname></system>`)
+	invalid := writeTestFile(t, "invalid.json", `{"example:system": {"hostname": ""}}`)
+
+	tests := []struct {
+		desc       string
+		inArgs     []string
+		wantStatus int
+		wantOut    []string
+		wantErr    string
+	}{{
+		desc:       "JSON and XML documents are equivalent",
+		inArgs:     []string{"-yang", testYANG, "-include", testInclude, testJSON, testXML},
+		wantStatus: exitSame,
+	}, {
+		desc:       "semantic differences",
+		inArgs:     []string{"-yang", testYANG, "-include", testInclude, testXML, other},
+		wantStatus: exitDifferent,
+		wantOut:    []string{"m /interfaces/interface[name=eth0]/mtu:", "- 1500", "+ 9000"},
+	}, {
+		desc:       "defaults not ignored",
+		inArgs:     []string{"-yang", testYANG, "-include", testInclude, "-defaults=false", testJSON, other},
+		wantStatus: exitDifferent,
+		wantOut:    []string{"+ /interfaces/interface[name=lo0]/cost: 10"},
+	}, {
+		desc:       "unified output",
+		inArgs:     []string{"-yang", testYANG, "-include", testInclude, "-output", "unified", testJSON, other},
+		wantStatus: exitDifferent,
+		wantOut:    []string{"--- " + testJSON, "+++ " + other, "-/interfaces/interface[name=eth0]/mtu: 1500"},
+	}, {
+		desc:       "html output",
+		inArgs:     []string{"-yang", testYANG, "-include", testInclude, "-output", "html", testJSON, other},
+		wantStatus: exitDifferent,
+		wantOut:    []string{`<div class="gnmidiff">`},
+	}, {
+		desc:       "explicit XML format",
+		inArgs:     []string{"-yang", testYANG, "-include", testInclude, "-format", "xml", otherXML, otherXML},
+		wantStatus: exitSame,
+	}, {
+		desc:       "invalid document",
+		inArgs:     []string{"-yang", testYANG, "-include", testInclude, testJSON, invalid},
+		wantStatus: exitError,
+		wantErr:    invalid + ": /system/hostname: ",
+	}, {
+		desc:       "wrong number of documents",
+		inArgs:     []string{"-yang", testYANG, testJSON},
+		wantStatus: exitError,
+		wantErr:    "got 1 instance documents, want 2",
+	}, {
+		desc:       "no modules",
+		inArgs:     []string{testJSON, other},
+		wantStatus: exitError,
+		wantErr:    "no YANG modules specified",
+	}, {
+		desc:       "unknown format",
+		inArgs:     []string{"-yang", testYANG, "-format", "yaml", testJSON, other},
+		wantStatus: exitError,
+		wantErr:    `unknown format "yaml"`,
+	}, {
+		desc:       "unknown output format",
+		inArgs:     []string{"-yang", testYANG, "-output", "json", testJSON, other},
+		wantStatus: exitError,
+		wantErr:    `unknown output format "json"`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if got := run(tt.inArgs, &stdout, &stderr); got != tt.wantStatus {
+				t.Errorf("run(%v): got status %d, want %d, stderr:\n%s", tt.inArgs, got, tt.wantStatus, stderr.String())
+			}
+			for _, want := range tt.wantOut {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("run(%v): got output:\n%s\nwant it to contain %q", tt.inArgs, stdout.String(), want)
+				}
+			}
+			if !strings.Contains(stderr.String(), tt.wantErr) {
+				t.Errorf("run(%v): got stderr %q, want it to contain %q", tt.inArgs, stderr.String(), tt.wantErr)
+			}
+		})
+	}
+}
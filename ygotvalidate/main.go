@@ -0,0 +1,216 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary ygotvalidate validates RFC7951 JSON instance documents against a
+// schema that is loaded from YANG modules at runtime, without generating
+// code. It reports each error that is found along with the path at which it
+// was found, and exits with a status that indicates whether the documents
+// are valid, such that it is suitable for gating changes to repositories of
+// configuration in CI.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/yinstance"
+)
+
+// Exit statuses of ygotvalidate.
+const (
+	// exitValid indicates that all of the instance documents are valid.
+	exitValid = 0
+	// exitInvalid indicates that at least one instance document is
+	// invalid, or cannot be read.
+	exitInvalid = 1
+	// exitUsage indicates that the arguments are invalid, or that the
+	// schema cannot be loaded, such that no documents were validated.
+	exitUsage = 2
+)
+
+const (
+	// outputText is the output format in which each error is written on
+	// a separate line.
+	outputText = "text"
+	// outputJSON is the output format in which the results are written as
+	// a single JSON document.
+	outputJSON = "json"
+)
+
+// report is the result of validating a set of instance documents, which is
+// written in the JSON output format.
+type report struct {
+	// Files is the result of validating each document, in the order in
+	// which they were supplied.
+	Files []*fileReport `json:"files"`
+}
+
+// fileReport is the result of validating a single instance document.
+type fileReport struct {
+	// File is the name of the document.
+	File string `json:"file"`
+	// Valid indicates whether the document is valid.
+	Valid bool `json:"valid"`
+	// Errors is the set of errors found within the document.
+	Errors []*errorReport `json:"errors,omitempty"`
+}
+
+// errorReport is a single error found within an instance document.
+type errorReport struct {
+	// Path is the path of the data node at which the error was found, or
+	// the empty string if the error is not specific to a data node, e.g.,
+	// if the document is not valid JSON.
+	Path string `json:"path,omitempty"`
+	// Message describes the error.
+	Message string `json:"message"`
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run validates the instance documents specified by args, writing the
+// results to stdout and usage errors to stderr, and returns the exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("ygotvalidate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: ygotvalidate -yang <modules> [flags] <instance.json>...\n")
+		fs.PrintDefaults()
+	}
+	yangFiles := fs.String("yang", "", "Comma separated list of the YANG modules that describe the schema of the instance documents.")
+	includePaths := fs.String("include", "", "Comma separated list of the paths in which imported and included YANG modules are found.")
+	output := fs.String("output", outputText, "The format of the output, text or json. In the text format, each error is written on a separate line prefixed by the name of the document and the path at which it was found.")
+	leafrefs := fs.Bool("leafrefs", true, "If set to true, the existence of the targets of leafrefs is checked.")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	switch {
+	case *yangFiles == "":
+		fmt.Fprintln(stderr, "Error: no YANG modules specified, use -yang")
+		return exitUsage
+	case fs.NArg() == 0:
+		fmt.Fprintln(stderr, "Error: no instance documents specified")
+		return exitUsage
+	case *output != outputText && *output != outputJSON:
+		fmt.Fprintf(stderr, "Error: unknown output format %q, must be %s or %s\n", *output, outputText, outputJSON)
+		return exitUsage
+	}
+
+	s, err := yinstance.LoadSchema(splitList(*yangFiles), splitList(*includePaths))
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: cannot load schema: %v\n", err)
+		return exitUsage
+	}
+
+	r := &report{}
+	status := exitValid
+	for _, f := range fs.Args() {
+		fr := validateFile(s, f, *leafrefs)
+		if !fr.Valid {
+			status = exitInvalid
+		}
+		r.Files = append(r.Files, fr)
+	}
+
+	if err := writeReport(stdout, r, *output); err != nil {
+		fmt.Fprintf(stderr, "Error: cannot write output: %v\n", err)
+		return exitUsage
+	}
+	return status
+}
+
+// validateFile validates the instance document in the file fn against the
+// schema s, checking the targets of leafrefs if leafrefs is true.
+func validateFile(s *yinstance.Schema, fn string, leafrefs bool) *fileReport {
+	fr := &fileReport{File: fn}
+	b, err := os.ReadFile(fn)
+	if err != nil {
+		fr.Errors = errorReports(err)
+		return fr
+	}
+	i, err := s.ParseJSON(b)
+	if err == nil && leafrefs {
+		err = i.ValidateLeafrefs()
+	}
+	fr.Errors = errorReports(err)
+	fr.Valid = len(fr.Errors) == 0
+	return fr
+}
+
+// errorReports returns the reports of the errors within err, which is
+// either a single error, or util.Errors.
+func errorReports(err error) []*errorReport {
+	if err == nil {
+		return nil
+	}
+	errs := util.Errors{err}
+	if es, ok := err.(util.Errors); ok {
+		errs = es
+	}
+	var reports []*errorReport
+	for _, err := range errs {
+		var pe *yinstance.PathError
+		if errors.As(err, &pe) {
+			reports = append(reports, &errorReport{Path: pe.Path, Message: pe.Err.Error()})
+			continue
+		}
+		reports = append(reports, &errorReport{Message: err.Error()})
+	}
+	return reports
+}
+
+// writeReport writes r to w in the output format.
+func writeReport(w io.Writer, r *report, format string) error {
+	if format == outputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	}
+	for _, fr := range r.Files {
+		if fr.Valid {
+			if _, err := fmt.Fprintf(w, "%s: valid\n", fr.File); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, e := range fr.Errors {
+			var err error
+			if e.Path != "" {
+				_, err = fmt.Fprintf(w, "%s: %s: %s\n", fr.File, e.Path, e.Message)
+			} else {
+				_, err = fmt.Fprintf(w, "%s: %s\n", fr.File, e.Message)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// splitList returns the elements of the comma separated list s.
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	testYANG    = "../yinstance/testdata/example.yang,../yinstance/testdata/example-aug.yang"
+	testInclude = "../yinstance/testdata"
+)
+
+func writeTestFile(t *testing.T, name, contents string) string {
+	t.Helper()
+	fn := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(fn, []byte(contents), 0644); err != nil {
+		t.Fatalf("cannot write %s: %v", fn, err)
+	}
+	return fn
+}
+
+func TestRun(t *testing.T) {
+	valid := "../yinstance/testdata/instance.json"
+	invalid := writeTestFile(t, "invalid.json", `{"example:interfaces": {"interface": [{"name": "eth0", "type": "ETHERNET", "mtu": 10000}]}, "example:routing": {"primary": "eth0"}}`)
+	dangling := writeTestFile(t, "dangling.json", `{"example:routing": {"primary": "eth1"}}`)
+	malformed := writeTestFile(t, "malformed.json", `{`)
+
+	tests := []struct {
+		desc       string
+		inArgs     []string
+		wantStatus int
+		wantOut    []string
+		wantErr    string
+	}{{
+		desc:       "valid",
+		inArgs:     []string{"-yang", testYANG, "-include", testInclude, valid},
+		wantStatus: exitValid,
+		wantOut:    []string{valid + ": valid"},
+	}, {
+		desc:       "invalid value",
+		inArgs:     []string{"-yang", testYANG, "-include", testInclude, valid, invalid},
+		wantStatus: exitInvalid,
+		wantOut: []string{
+			valid + ": valid",
+			invalid + ": /interfaces/interface[name=eth0]/mtu: ",
+		},
+	}, {
+		desc:       "dangling leafref",
+		inArgs:     []string{"-yang", testYANG, "-include", testInclude, dangling},
+		wantStatus: exitInvalid,
+		wantOut:    []string{dangling + ": /routing/primary: leafref target /interfaces/interface/name with value eth1 does not exist"},
+	}, {
+		desc:       "leafrefs not checked",
+		inArgs:     []string{"-yang", testYANG, "-include", testInclude, "-leafrefs=false", dangling},
+		wantStatus: exitValid,
+		wantOut:    []string{dangling + ": valid"},
+	}, {
+		desc:       "malformed document",
+		inArgs:     []string{"-yang", testYANG, "-include", testInclude, malformed},
+		wantStatus: exitInvalid,
+		wantOut:    []string{malformed + ": cannot decode JSON"},
+	}, {
+		desc:       "missing document",
+		inArgs:     []string{"-yang", testYANG, "-include", testInclude, "missing.json"},
+		wantStatus: exitInvalid,
+		wantOut:    []string{"missing.json: open missing.json"},
+	}, {
+		desc:       "no modules",
+		inArgs:     []string{valid},
+		wantStatus: exitUsage,
+		wantErr:    "no YANG modules specified",
+	}, {
+		desc:       "no documents",
+		inArgs:     []string{"-yang", testYANG},
+		wantStatus: exitUsage,
+		wantErr:    "no instance documents specified",
+	}, {
+		desc:       "invalid schema",
+		inArgs:     []string{"-yang", "missing.yang", valid},
+		wantStatus: exitUsage,
+		wantErr:    "cannot load schema",
+	}, {
+		desc:       "unknown output format",
+		inArgs:     []string{"-yang", testYANG, "-output", "xml", valid},
+		wantStatus: exitUsage,
+		wantErr:    `unknown output format "xml"`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if got := run(tt.inArgs, &stdout, &stderr); got != tt.wantStatus {
+				t.Errorf("run(%v): got status %d, want %d, stderr:\n%s", tt.inArgs, got, tt.wantStatus, stderr.String())
+			}
+			lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+			if stdout.Len() == 0 {
+				lines = nil
+			}
+			if len(lines) != len(tt.wantOut) {
+				t.Fatalf("run(%v): got output:\n%s\nwant %d lines", tt.inArgs, stdout.String(), len(tt.wantOut))
+			}
+			for i, want := range tt.wantOut {
+				if !strings.HasPrefix(lines[i], want) {
+					t.Errorf("run(%v): got output line %q, want prefix %q", tt.inArgs, lines[i], want)
+				}
+			}
+			if !strings.Contains(stderr.String(), tt.wantErr) {
+				t.Errorf("run(%v): got stderr %q, want it to contain %q", tt.inArgs, stderr.String(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunJSONOutput(t *testing.T) {
+	valid := "../yinstance/testdata/instance.json"
+	invalid := writeTestFile(t, "invalid.json", `{"example:system": {"hostname": ""}, "example:routing": {"primary": "eth9"}}`)
+
+	var stdout, stderr bytes.Buffer
+	if got := run([]string{"-yang", testYANG, "-include", testInclude, "-output", "json", valid, invalid}, &stdout, &stderr); got != exitInvalid {
+		t.Fatalf("run: got status %d, want %d, stderr:\n%s", got, exitInvalid, stderr.String())
+	}
+	got := &report{}
+	if err := json.Unmarshal(stdout.Bytes(), got); err != nil {
+		t.Fatalf("run: invalid JSON output: %v\n%s", err, stdout.String())
+	}
+
+	// The leafrefs are only checked once the document is otherwise valid.
+	want := &report{Files: []*fileReport{{
+		File:  valid,
+		Valid: true,
+	}, {
+		File: invalid,
+		Errors: []*errorReport{{
+			Path: "/system/hostname",
+		}},
+	}}}
+	for _, fr := range got.Files {
+		for _, e := range fr.Errors {
+			if e.Message == "" {
+				t.Errorf("run: got error without message for path %s", e.Path)
+			}
+			e.Message = ""
+		}
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("run: did not get expected report (-want, +got):\n%s", diff)
+	}
+}
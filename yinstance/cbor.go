@@ -0,0 +1,423 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yinstance
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// CBOR returns the YANG-CBOR document, encoded as per RFC9254 using names
+// rather than SIDs, that contains the instance data. Members are qualified
+// by their module where it differs from that of their parent, as they are
+// within RFC7951 JSON documents, and map keys are sorted as per the core
+// deterministic encoding of RFC8949.
+func (i *Instance) CBOR() ([]byte, error) {
+	root, err := i.tree()
+	if err != nil {
+		return nil, err
+	}
+	m, err := cborMembers(i.schema.Root, root)
+	if err != nil {
+		return nil, err
+	}
+	return util.MarshalCBOR(m)
+}
+
+// cborMembers returns the CBOR map whose members are those of obj, which is
+// the content of the directory e.
+func cborMembers(e *yang.Entry, obj map[string]interface{}) (map[any]any, error) {
+	chs := children(e)
+	m := make(map[any]any, len(obj))
+	for n, v := range obj {
+		_, name := splitName(n)
+		ch, ok := chs[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown member %q of %s", n, e.Path())
+		}
+		var err error
+		switch v := v.(type) {
+		case map[string]interface{}:
+			m[n], err = cborMembers(ch, v)
+		case []interface{}:
+			if !ch.IsList() && !ch.IsLeafList() {
+				// A leaf of type empty.
+				m[n], err = cborScalar(ch, v)
+				break
+			}
+			arr := make([]any, 0, len(v))
+			for _, ev := range v {
+				var item any
+				if entry, ok := ev.(map[string]interface{}); ok && ch.IsList() {
+					item, err = cborMembers(ch, entry)
+				} else {
+					item, err = cborScalar(ch, ev)
+				}
+				if err != nil {
+					return nil, err
+				}
+				arr = append(arr, item)
+			}
+			m[n] = arr
+		default:
+			m[n], err = cborScalar(ch, v)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// cborScalar returns the CBOR data item of the canonical value v of the
+// leaf, or leaf-list element, e.
+func cborScalar(e *yang.Entry, v interface{}) (any, error) {
+	t, inUnion := valueType(e, v)
+	s := keyString(v)
+	switch t.Kind {
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yint64:
+		return strconv.ParseInt(s, 10, 64)
+	case yang.Yuint8, yang.Yuint16, yang.Yuint32, yang.Yuint64:
+		return strconv.ParseUint(s, 10, 64)
+	case yang.Ydecimal64:
+		// A decimal fraction, whose exponent is the negation of the
+		// number of fraction digits.
+		exp := 0
+		if i := strings.Index(s, "."); i != -1 {
+			exp = len(s) - i - 1
+			s = s[:i] + s[i+1:]
+		}
+		m, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return util.CBORTag{Number: ygot.CBORTagDecimalFraction, Content: []any{int64(-exp), m}}, nil
+	case yang.Yempty:
+		return nil, nil
+	case yang.Yenum:
+		if inUnion {
+			return util.CBORTag{Number: ygot.CBORTagEnumeration, Content: s}, nil
+		}
+		return t.Enum.Value(s), nil
+	case yang.Ybits:
+		if inUnion {
+			return util.CBORTag{Number: ygot.CBORTagBits, Content: bitsBytes(t, s)}, nil
+		}
+		return bitsBytes(t, s), nil
+	case yang.Ybinary:
+		return base64.StdEncoding.DecodeString(s)
+	}
+	switch v := v.(type) {
+	case bool:
+		return v, nil
+	case json.Number:
+		// A number of a type that could not be determined.
+		if i, err := v.Int64(); err == nil {
+			return i, nil
+		}
+		return v.Float64()
+	}
+	return s, nil
+}
+
+// bitsBytes returns the bitmap of the set bits s, which is a space
+// separated list of bits of the type t. The bit at position n is the bit
+// n%8 of the byte n/8, where bit 0 is the least significant, and trailing
+// zero bytes are omitted.
+func bitsBytes(t *yang.YangType, s string) []byte {
+	var b []byte
+	for _, name := range strings.Fields(s) {
+		pos := t.Bit.Value(name)
+		for int64(len(b)) <= pos/8 {
+			b = append(b, 0)
+		}
+		b[pos/8] |= 1 << (pos % 8)
+	}
+	return b
+}
+
+// bitsString returns the space separated list of the bits of the type t
+// that are set within the bitmap b.
+func bitsString(t *yang.YangType, b []byte) (string, error) {
+	var names []string
+	for i, by := range b {
+		for j := 0; j < 8; j++ {
+			if by&(1<<j) == 0 {
+				continue
+			}
+			pos := int64(i*8 + j)
+			name := t.Bit.Name(pos)
+			if name == "" {
+				return "", fmt.Errorf("position %d is not a bit of %s", pos, t.Name)
+			}
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, " "), nil
+}
+
+// jsonItem returns the data item v, as decoded by util.UnmarshalCBOR, with
+// its numbers as json.Numbers and its maps keyed by their text string keys.
+func jsonItem(v any) (interface{}, error) {
+	switch v := v.(type) {
+	case uint64:
+		return json.Number(strconv.FormatUint(v, 10)), nil
+	case int64:
+		return json.Number(strconv.FormatInt(v, 10)), nil
+	case float64:
+		return json.Number(strconv.FormatFloat(v, 'f', -1, 64)), nil
+	case []any:
+		elems := make([]interface{}, 0, len(v))
+		for _, el := range v {
+			j, err := jsonItem(el)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, j)
+		}
+		return elems, nil
+	case map[any]any:
+		obj := make(map[string]interface{}, len(v))
+		for k, el := range v {
+			name, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("got map key %v, want text string, SID-based encoding is not supported", k)
+			}
+			j, err := jsonItem(el)
+			if err != nil {
+				return nil, err
+			}
+			obj[name] = j
+		}
+		return obj, nil
+	case util.CBORTag:
+		c, err := jsonItem(v.Content)
+		if err != nil {
+			return nil, err
+		}
+		return util.CBORTag{Number: v.Number, Content: c}, nil
+	}
+	return v, nil
+}
+
+// cborConverter converts the data items of a YANG-CBOR document to the form
+// of an RFC7951 JSON document.
+type cborConverter struct {
+	// errs is the set of errors found within the document.
+	errs util.Errors
+}
+
+// ParseCBOR parses and validates the YANG-CBOR document b, which is encoded
+// as per RFC9254 using names rather than SIDs, returning the Instance that
+// it describes. All errors that are found within the document are returned
+// together.
+//
+// The document is validated as it is by ParseJSON, except that the
+// encodings of values that are the same within YANG-CBOR and RFC7951 JSON,
+// such as those of 64-bit integers, are not enforced.
+func (s *Schema) ParseCBOR(b []byte) (*Instance, error) {
+	d, err := util.UnmarshalCBOR(b)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode CBOR: %v", err)
+	}
+	v, err := jsonItem(d)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode CBOR: %v", err)
+	}
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("CBOR document is a %T, want map", v)
+	}
+
+	c := &cborConverter{}
+	obj = c.members(s.Root, &gpb.Path{}, obj)
+	if c.errs != nil {
+		return nil, c.errs
+	}
+
+	p := &parser{inst: s.NewInstance(), strict: true, lenient: true}
+	p.members(s.Root, &gpb.Path{}, obj)
+	if p.errs != nil {
+		return nil, p.errs
+	}
+	return p.inst, nil
+}
+
+// members returns the members of the JSON object that represents the map
+// obj, which is the content of the directory e at path.
+func (c *cborConverter) members(e *yang.Entry, path *gpb.Path, obj map[string]interface{}) map[string]interface{} {
+	chs := children(e)
+	out := map[string]interface{}{}
+	for n, v := range obj {
+		_, name := splitName(n)
+		ch, ok := chs[name]
+		switch {
+		case !ok:
+			// The parser reports the unknown member.
+			out[n] = v
+		case ch.IsList():
+			entries, ok := v.([]interface{})
+			if !ok {
+				out[n] = v
+				continue
+			}
+			var conv []interface{}
+			for _, ev := range entries {
+				if entry, ok := ev.(map[string]interface{}); ok {
+					ev = c.members(ch, appendElem(path, ch.Name, nil), entry)
+				}
+				conv = append(conv, ev)
+			}
+			out[n] = conv
+		case ch.IsDir():
+			if content, ok := v.(map[string]interface{}); ok {
+				v = c.members(ch, appendElem(path, ch.Name, nil), content)
+			}
+			out[n] = v
+		case ch.IsLeafList():
+			elems, ok := v.([]interface{})
+			if !ok {
+				out[n] = v
+				continue
+			}
+			var conv []interface{}
+			for _, el := range elems {
+				conv = append(conv, c.value(ch, appendElem(path, ch.Name, nil), el))
+			}
+			out[n] = conv
+		default:
+			out[n] = c.value(ch, appendElem(path, ch.Name, nil), v)
+		}
+	}
+	return out
+}
+
+// value returns the JSON value of the data item v, which is the value of
+// the leaf or leaf-list element e at path.
+func (c *cborConverter) value(e *yang.Entry, path *gpb.Path, v interface{}) interface{} {
+	t := e.Type
+	if t.Kind == yang.Yleafref {
+		if target, err := util.ResolveIfLeafRef(e); err == nil {
+			t = target.Type
+		}
+	}
+	var bits *yang.YangType
+	binary := false
+	for _, mt := range util.FlattenedTypes([]*yang.YangType{t}) {
+		switch mt.Kind {
+		case yang.Ybits:
+			bits = mt
+		case yang.Ybinary:
+			binary = true
+		}
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return []interface{}{nil}
+	case []byte:
+		if bits != nil && !binary {
+			return c.bits(bits, path, v)
+		}
+		return base64.StdEncoding.EncodeToString(v)
+	case json.Number:
+		if t.Kind == yang.Yenum {
+			i, err := v.Int64()
+			if err != nil || t.Enum.Name(i) == "" {
+				c.errs = util.AppendErr(c.errs, pathErrorf(path, "%v is not a value of enumeration %s", v, t.Name))
+				return nil
+			}
+			return t.Enum.Name(i)
+		}
+	case util.CBORTag:
+		switch v.Number {
+		case ygot.CBORTagDecimalFraction:
+			d, err := decimalString(v.Content)
+			if err != nil {
+				c.errs = util.AppendErr(c.errs, pathErrorf(path, "invalid decimal fraction: %v", err))
+				return nil
+			}
+			return d
+		case ygot.CBORTagBits:
+			b, ok := v.Content.([]byte)
+			if !ok || bits == nil {
+				c.errs = util.AppendErr(c.errs, pathErrorf(path, "invalid bits value %v", v.Content))
+				return nil
+			}
+			return c.bits(bits, path, b)
+		case ygot.CBORTagEnumeration:
+			return v.Content
+		}
+		c.errs = util.AppendErr(c.errs, pathErrorf(path, "unsupported tag %d", v.Number))
+		return nil
+	}
+	return v
+}
+
+// bits returns the JSON value of the bitmap b of the bits type t, of the
+// leaf at path.
+func (c *cborConverter) bits(t *yang.YangType, path *gpb.Path, b []byte) interface{} {
+	s, err := bitsString(t, b)
+	if err != nil {
+		c.errs = util.AppendErr(c.errs, &PathError{Path: pathString(path), Err: err})
+		return nil
+	}
+	return s
+}
+
+// decimalString returns the decimal form of the content of a decimal
+// fraction, which is an array of its exponent and mantissa.
+func decimalString(v interface{}) (string, error) {
+	a, ok := v.([]interface{})
+	if !ok || len(a) != 2 {
+		return "", fmt.Errorf("got %v, want [exponent, mantissa]", v)
+	}
+	expN, ok1 := a[0].(json.Number)
+	mN, ok2 := a[1].(json.Number)
+	if !ok1 || !ok2 {
+		return "", fmt.Errorf("got %v, want integer exponent and mantissa", v)
+	}
+	exp, err := expN.Int64()
+	if err != nil || exp > 0 || exp < -18 {
+		return "", fmt.Errorf("invalid exponent %v", expN)
+	}
+	m, ok := new(big.Int).SetString(mN.String(), 10)
+	if !ok {
+		return "", fmt.Errorf("invalid mantissa %v", mN)
+	}
+	digits := new(big.Int).Abs(m).String()
+	sign := ""
+	if m.Sign() < 0 {
+		sign = "-"
+	}
+	fd := int(-exp)
+	if fd == 0 {
+		return sign + digits, nil
+	}
+	if len(digits) <= fd {
+		digits = strings.Repeat("0", fd-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-fd] + "." + digits[len(digits)-fd:], nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/protobuf/encoding/prototext"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func newConvertCmd() *cobra.Command {
+	convert := &cobra.Command{
+		Use:   "convert",
+		RunE:  convert,
+		Short: "Converts an instance file between formats, writing the result to stdout.",
+		Args:  cobra.ExactArgs(1),
+	}
+
+	convert.Flags().String("from", formatJSON, "Format of the input instance file, json or notification.")
+	convert.Flags().String("to", formatNotification, "Format of the output, json or notification.")
+
+	return convert
+}
+
+func convert(cmd *cobra.Command, args []string) error {
+	s, err := loadSchema()
+	if err != nil {
+		return err
+	}
+	inst, err := loadInstance(s, args[0], viper.GetString("from"))
+	if err != nil {
+		return err
+	}
+
+	switch to := viper.GetString("to"); to {
+	case formatJSON:
+		b, err := inst.JSON()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stdout, string(b))
+	case formatNotification:
+		n, err := inst.Notification(time.Now().UnixNano())
+		if err != nil {
+			return err
+		}
+		resp := &gpb.SubscribeResponse{Response: &gpb.SubscribeResponse_Update{Update: n}}
+		fmt.Fprint(os.Stdout, prototext.MarshalOptions{Multiline: true}.Format(resp))
+	default:
+		return fmt.Errorf("unknown format %q, must be %s or %s", to, formatJSON, formatNotification)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/openconfig/ygot/gnmidiff"
+	"github.com/openconfig/ygot/yinstance"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func newDiffCmd() *cobra.Command {
+	diff := &cobra.Command{
+		Use:   "diff",
+		RunE:  diff,
+		Short: "Diffs the leaves of two instance files.",
+		Args:  cobra.ExactArgs(2),
+	}
+
+	diff.Flags().String("format", formatJSON, "Format of the instance files, json or notification.")
+	diff.Flags().Bool("full", false, "Whether diff shows common values.")
+
+	return diff
+}
+
+func diff(cmd *cobra.Command, args []string) error {
+	s, err := loadSchema()
+	if err != nil {
+		return err
+	}
+	a, err := loadInstance(s, args[0], viper.GetString("format"))
+	if err != nil {
+		return fmt.Errorf("%s: %v", args[0], err)
+	}
+	b, err := loadInstance(s, args[1], viper.GetString("format"))
+	if err != nil {
+		return fmt.Errorf("%s: %v", args[1], err)
+	}
+
+	fmt.Fprint(os.Stdout, yinstance.Diff(a, b).Format(gnmidiff.Format{
+		Full: viper.GetBool("full"),
+	}))
+	return nil
+}
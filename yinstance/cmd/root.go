@@ -0,0 +1,94 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cmd contains a CLI utility to access yinstance functionality.
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/openconfig/ygot/gnmidiff/gnmiparse"
+	"github.com/openconfig/ygot/yinstance"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	// formatJSON is the format of RFC7951 JSON instance files.
+	formatJSON = "json"
+	// formatNotification is the format of instance files that contain
+	// gNMI Notifications, within a sequence of SubscribeResponses or a
+	// GetResponse, in textproto format.
+	formatNotification = "notification"
+)
+
+func RootCmd() *cobra.Command {
+	rootCmd := &cobra.Command{
+		Use:           "ygot",
+		Short:         "ygot is a utility for working with YANG instance data without generating code",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cfgFile := rootCmd.PersistentFlags().String("config_file", "", "Path to config file.")
+	rootCmd.PersistentFlags().StringSlice("yang", nil, "YANG modules that describe the schema of the instance data.")
+	rootCmd.PersistentFlags().StringSlice("include", nil, "Paths in which imported and included YANG modules are found.")
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if *cfgFile != "" {
+			viper.SetConfigFile(*cfgFile)
+			if err := viper.ReadInConfig(); err != nil {
+				return fmt.Errorf("error reading config: %w", err)
+			}
+		}
+		viper.BindPFlags(cmd.Flags())
+		viper.AutomaticEnv()
+		return nil
+	}
+
+	rootCmd.AddCommand(newValidateCmd())
+	rootCmd.AddCommand(newConvertCmd())
+	rootCmd.AddCommand(newDiffCmd())
+	rootCmd.AddCommand(newTreeCmd())
+
+	return rootCmd
+}
+
+// loadSchema loads the schema from the YANG modules supplied by flags.
+func loadSchema() (*yinstance.Schema, error) {
+	files := viper.GetStringSlice("yang")
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no YANG modules specified, use --yang")
+	}
+	return yinstance.LoadSchema(files, viper.GetStringSlice("include"))
+}
+
+// loadInstance parses the instance file in the supplied format.
+func loadInstance(s *yinstance.Schema, file, format string) (*yinstance.Instance, error) {
+	switch format {
+	case formatJSON:
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		return s.ParseJSON(b)
+	case formatNotification:
+		ns, err := gnmiparse.NotifsFromFile(file)
+		if err != nil {
+			return nil, err
+		}
+		return s.ParseNotifications(ns)
+	}
+	return nil, fmt.Errorf("unknown format %q, must be %s or %s", format, formatJSON, formatNotification)
+}
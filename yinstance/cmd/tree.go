@@ -0,0 +1,38 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func newTreeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "tree",
+		RunE:  tree,
+		Short: "Prints the data tree of the schema.",
+		Args:  cobra.NoArgs,
+	}
+}
+
+func tree(cmd *cobra.Command, args []string) error {
+	s, err := loadSchema()
+	if err != nil {
+		return err
+	}
+	return s.WriteTree(os.Stdout)
+}
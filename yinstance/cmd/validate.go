@@ -0,0 +1,57 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func newValidateCmd() *cobra.Command {
+	validate := &cobra.Command{
+		Use:   "validate",
+		RunE:  validate,
+		Short: "Validates instance files against the schema.",
+		Args:  cobra.MinimumNArgs(1),
+	}
+
+	validate.Flags().String("format", formatJSON, "Format of the instance files, json or notification.")
+
+	return validate
+}
+
+func validate(cmd *cobra.Command, args []string) error {
+	s, err := loadSchema()
+	if err != nil {
+		return err
+	}
+
+	var invalid int
+	for _, f := range args {
+		if _, err := loadInstance(s, f, viper.GetString("format")); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: invalid:\n%v\n", f, err)
+			invalid++
+			continue
+		}
+		fmt.Printf("%s: valid\n", f)
+	}
+	if invalid != 0 {
+		return fmt.Errorf("%d of %d instance files are invalid", invalid, len(args))
+	}
+	return nil
+}
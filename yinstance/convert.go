@@ -0,0 +1,76 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yinstance
+
+import (
+	"fmt"
+)
+
+// Encoding is an encoding of YANG instance documents.
+type Encoding string
+
+const (
+	// EncodingJSON is the RFC7951 JSON encoding, as used by RESTCONF.
+	EncodingJSON Encoding = "json"
+	// EncodingXML is the XML encoding of RFC7950 Section 7, as used by
+	// NETCONF.
+	EncodingXML Encoding = "xml"
+	// EncodingCBOR is the name-based YANG-CBOR encoding of RFC9254, as
+	// used by CORECONF.
+	EncodingCBOR Encoding = "cbor"
+)
+
+// Encodings is the set of supported encodings.
+var Encodings = []Encoding{EncodingJSON, EncodingXML, EncodingCBOR}
+
+// Parse parses and validates the document b, which is in the encoding enc,
+// returning the Instance that it describes.
+func (s *Schema) Parse(b []byte, enc Encoding) (*Instance, error) {
+	switch enc {
+	case EncodingJSON:
+		return s.ParseJSON(b)
+	case EncodingXML:
+		return s.ParseXML(b)
+	case EncodingCBOR:
+		return s.ParseCBOR(b)
+	}
+	return nil, fmt.Errorf("unknown encoding %q", enc)
+}
+
+// Marshal returns the document that contains the instance data, in the
+// encoding enc.
+func (i *Instance) Marshal(enc Encoding) ([]byte, error) {
+	switch enc {
+	case EncodingJSON:
+		return i.JSON()
+	case EncodingXML:
+		return i.XML()
+	case EncodingCBOR:
+		return i.CBOR()
+	}
+	return nil, fmt.Errorf("unknown encoding %q", enc)
+}
+
+// Convert converts the document b from the encoding from to the encoding
+// to. The document is validated against the schema, and the types of its
+// values are determined by the schema, such that values are written in
+// their canonical form within the target encoding.
+func (s *Schema) Convert(b []byte, from, to Encoding) ([]byte, error) {
+	inst, err := s.Parse(b, from)
+	if err != nil {
+		return nil, err
+	}
+	return inst.Marshal(to)
+}
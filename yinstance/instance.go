@@ -0,0 +1,626 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yinstance
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/gnmidiff"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/proto"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Leaf is the value of a leaf or leaf-list within an Instance.
+type Leaf struct {
+	// Path is the absolute path of the leaf, whose elements are not
+	// qualified by their module.
+	Path *gpb.Path
+	// Value is the canonical RFC7951 JSON value of the leaf, which is a
+	// string, json.Number or bool, a slice of these for leaf-lists, or
+	// []interface{}{nil} for leaves of type empty. 64-bit integers and
+	// decimal64 values are strings, and identities are always qualified
+	// by the name of their module.
+	Value interface{}
+
+	// entry is the schema of the leaf.
+	entry *yang.Entry
+}
+
+// Instance is a set of YANG instance data, which is held as the values of
+// its leaves.
+type Instance struct {
+	// schema is the schema of the instance data.
+	schema *Schema
+	// leaves is the set of leaves of the instance, keyed by the string
+	// form of their path.
+	leaves map[string]*Leaf
+}
+
+// NewInstance returns an empty Instance of the schema s.
+func (s *Schema) NewInstance() *Instance {
+	return &Instance{schema: s, leaves: map[string]*Leaf{}}
+}
+
+// Leaves returns the leaves of the instance, sorted by path.
+func (i *Instance) Leaves() []*Leaf {
+	var keys []string
+	for k := range i.leaves {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var ls []*Leaf
+	for _, k := range keys {
+		ls = append(ls, i.leaves[k])
+	}
+	return ls
+}
+
+// PathError is an error that is found within instance data, at a
+// particular path.
+type PathError struct {
+	// Path is the string form of the absolute path of the data node at
+	// which the error was found.
+	Path string
+	// Err is the error that was found.
+	Err error
+}
+
+// Error implements the error#Error method.
+func (e *PathError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error of e.
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// pathErrorf returns a PathError for the node at path, with a message
+// formatted according to format, as per fmt.Errorf.
+func pathErrorf(path *gpb.Path, format string, a ...interface{}) error {
+	return &PathError{Path: pathString(path), Err: fmt.Errorf(format, a...)}
+}
+
+// parser holds the state of the parsing of instance data.
+type parser struct {
+	// inst is the instance that is being populated.
+	inst *Instance
+	// strict specifies that the members of objects must be qualified by
+	// their module as per RFC7951, and that mandatory leaves must be
+	// present.
+	strict bool
+	// lenient specifies that values that are encoded as they are within
+	// gNMI paths and scalar TypedValues, rather than as per RFC7951, are
+	// accepted.
+	lenient bool
+	// errs is the set of errors found within the instance data.
+	errs util.Errors
+}
+
+// ParseJSON parses and validates the RFC7951 JSON document b, returning the
+// Instance that it describes. All errors that are found within the document
+// are returned together.
+//
+// The values of leaves are validated against their types and restrictions,
+// the members of each object must be data nodes of the schema, qualified by
+// their module as required by RFC7951, list entries must have unique keys,
+// and mandatory leaves must be present. Other constraints, such as those
+// of must and when statements, are not checked, and the existence of the
+// targets of leafrefs is checked only by ValidateLeafrefs. Errors that are
+// found at a particular path within the document are PathErrors.
+func (s *Schema) ParseJSON(b []byte) (*Instance, error) {
+	v, err := decodeJSON(b)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode JSON: %v", err)
+	}
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("JSON document is a %T, want object", v)
+	}
+	p := &parser{inst: s.NewInstance(), strict: true}
+	p.members(s.Root, &gpb.Path{}, obj)
+	if p.errs != nil {
+		return nil, p.errs
+	}
+	return p.inst, nil
+}
+
+// ParseNotifications parses and validates the updates within ns, returning
+// the Instance that they describe. Deletes are applied to the leaves of the
+// preceding notifications. Update values may be scalar, or JSON documents
+// for which the RFC7951 encodings of values are not enforced, and mandatory
+// leaves need not be present.
+func (s *Schema) ParseNotifications(ns []*gpb.Notification) (*Instance, error) {
+	p := &parser{inst: s.NewInstance(), lenient: true}
+	for _, n := range ns {
+		for _, d := range n.GetDelete() {
+			dp, err := util.JoinPaths(n.GetPrefix(), d)
+			if err != nil {
+				p.errs = util.AppendErr(p.errs, err)
+				continue
+			}
+			dp = unqualified(dp)
+			for k, l := range p.inst.leaves {
+				if util.PathMatchesQuery(l.Path, dp) {
+					delete(p.inst.leaves, k)
+				}
+			}
+		}
+		for _, u := range n.GetUpdate() {
+			up, err := util.JoinPaths(n.GetPrefix(), u.GetPath())
+			if err != nil {
+				p.errs = util.AppendErr(p.errs, err)
+				continue
+			}
+			p.update(unqualified(up), u.GetVal())
+		}
+	}
+	if p.errs != nil {
+		return nil, p.errs
+	}
+	return p.inst, nil
+}
+
+// Set sets the value of the leaf or leaf-list at path, which is absolute, to
+// v, along with the key leaves of the list entries within path. The value is
+// validated as it is within ParseNotifications, such that either its RFC7951
+// form, or the form that it has within a gNMI path, is accepted. The list
+// entries within path must be identified by exactly their keys.
+func (i *Instance) Set(path *gpb.Path, v interface{}) error {
+	path = unqualified(path)
+	entries, err := i.schema.entryAt(path)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 || (!entries[len(entries)-1].IsLeaf() && !entries[len(entries)-1].IsLeafList()) {
+		return pathErrorf(path, "not a leaf or leaf-list")
+	}
+	for j, e := range entries {
+		if !e.IsList() {
+			continue
+		}
+		keys := path.GetElem()[j].GetKey()
+		names := strings.Fields(e.Key)
+		ok := len(keys) == len(names)
+		for _, k := range names {
+			if _, present := keys[k]; !present {
+				ok = false
+			}
+		}
+		if !ok {
+			return pathErrorf(path, "list %s must be identified by keys %v", e.Name, names)
+		}
+	}
+	// The leaves are added to the instance only if they are all valid.
+	p := &parser{inst: i.schema.NewInstance(), lenient: true}
+	p.pathKeys(path, entries)
+	p.leaf(entries[len(entries)-1], path, v)
+	if p.errs != nil {
+		return p.errs
+	}
+	for k, l := range p.inst.leaves {
+		i.leaves[k] = l
+	}
+	return nil
+}
+
+// unqualified returns a copy of the absolute path p without its target and
+// origin, and whose elements are not qualified by their module.
+func unqualified(p *gpb.Path) *gpb.Path {
+	np := &gpb.Path{}
+	for _, pe := range p.GetElem() {
+		_, name := splitName(pe.GetName())
+		np.Elem = append(np.Elem, &gpb.PathElem{Name: name, Key: pe.GetKey()})
+	}
+	return np
+}
+
+// update adds the leaves of the update of the node at path to value v.
+func (p *parser) update(path *gpb.Path, v *gpb.TypedValue) {
+	entries, err := p.inst.schema.entryAt(path)
+	if err != nil {
+		p.errs = util.AppendErr(p.errs, err)
+		return
+	}
+	jv, err := jsonValue(v)
+	if err != nil {
+		p.errs = util.AppendErr(p.errs, &PathError{Path: pathString(path), Err: err})
+		return
+	}
+	if len(entries) == 0 {
+		p.content(p.inst.schema.Root, path, jv)
+		return
+	}
+	p.pathKeys(path, entries)
+	e := entries[len(entries)-1]
+	switch {
+	case e.IsList() && len(path.GetElem()[len(entries)-1].GetKey()) == 0:
+		// An update to a list is an update of each of its entries.
+		p.node(e, &gpb.Path{Elem: path.GetElem()[:len(entries)-1]}, jv)
+	case e.IsDir():
+		p.content(e, path, jv)
+	default:
+		p.leaf(e, path, jv)
+	}
+}
+
+// content adds the leaves of the JSON value v, which is the content of the
+// directory e at path.
+func (p *parser) content(e *yang.Entry, path *gpb.Path, v interface{}) {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		p.errs = util.AppendErr(p.errs, pathErrorf(path, "got %T, want object", v))
+		return
+	}
+	p.members(e, path, obj)
+}
+
+// pathKeys adds the key leaves of the list entries within path, whose
+// elements have the schema entries.
+func (p *parser) pathKeys(path *gpb.Path, entries []*yang.Entry) {
+	for i, e := range entries {
+		if !e.IsList() || len(path.GetElem()[i].GetKey()) == 0 {
+			continue
+		}
+		entryPath := &gpb.Path{Elem: path.GetElem()[:i+1]}
+		for name, kv := range path.GetElem()[i].GetKey() {
+			if ke, ok := children(e)[name]; ok {
+				p.leaf(ke, appendElem(entryPath, name, nil), kv)
+			}
+		}
+	}
+}
+
+// members adds the leaves of the members of obj, which is the content of
+// the directory e at path.
+func (p *parser) members(e *yang.Entry, path *gpb.Path, obj map[string]interface{}) {
+	chs := children(e)
+	mod := moduleOf(e)
+	present := map[string]bool{}
+	var names []string
+	for n := range obj {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		m, name := splitName(n)
+		ch, ok := chs[name]
+		switch {
+		case !ok:
+			p.errs = util.AppendErr(p.errs, pathErrorf(path, "unknown member %q", n))
+			continue
+		case m != "" && m != moduleOf(ch):
+			p.errs = util.AppendErr(p.errs, pathErrorf(path, "member %q is not within module %s", n, m))
+			continue
+		case m == "" && p.strict && moduleOf(ch) != mod:
+			p.errs = util.AppendErr(p.errs, pathErrorf(path, "member %q must be qualified by module %s", n, moduleOf(ch)))
+			continue
+		}
+		present[name] = true
+		p.node(ch, path, obj[n])
+	}
+	if !p.strict || e == p.inst.schema.Root {
+		return
+	}
+	var missing []string
+	for name, ch := range chs {
+		if ch.IsLeaf() && ch.Mandatory == yang.TSTrue && !present[name] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	for _, name := range missing {
+		p.errs = util.AppendErr(p.errs, pathErrorf(path, "mandatory leaf %s is missing", name))
+	}
+}
+
+// node adds the leaves of the JSON value v of the data node e, which is a
+// child of the directory at parent.
+func (p *parser) node(e *yang.Entry, parent *gpb.Path, v interface{}) {
+	switch {
+	case e.IsList():
+		entries, ok := v.([]interface{})
+		if !ok {
+			p.errs = util.AppendErr(p.errs, pathErrorf(appendElem(parent, e.Name, nil), "got %T, want array for list", v))
+			return
+		}
+		seen := map[string]bool{}
+		for i, ev := range entries {
+			obj, ok := ev.(map[string]interface{})
+			if !ok {
+				p.errs = util.AppendErr(p.errs, pathErrorf(appendElem(parent, e.Name, nil), "got %T, want object for entry %d", ev, i))
+				continue
+			}
+			keys, err := p.entryKeys(e, obj)
+			if err != nil {
+				p.errs = util.AppendErr(p.errs, pathErrorf(appendElem(parent, e.Name, nil), "entry %d: %v", i, err))
+				continue
+			}
+			ep := appendElem(parent, e.Name, keys)
+			k := pathString(ep)
+			if seen[k] {
+				p.errs = util.AppendErr(p.errs, pathErrorf(ep, "duplicate list entry"))
+				continue
+			}
+			seen[k] = true
+			p.members(e, ep, obj)
+		}
+	case e.IsDir():
+		p.content(e, appendElem(parent, e.Name, nil), v)
+	case e.IsLeaf(), e.IsLeafList():
+		p.leaf(e, appendElem(parent, e.Name, nil), v)
+	default:
+		p.errs = util.AppendErr(p.errs, pathErrorf(appendElem(parent, e.Name, nil), "unsupported node kind %v", e.Kind))
+	}
+}
+
+// entryKeys returns the keys of the entry obj of the list e, as used within
+// a gNMI path.
+func (p *parser) entryKeys(e *yang.Entry, obj map[string]interface{}) (map[string]string, error) {
+	if e.Key == "" {
+		return nil, fmt.Errorf("keyless list %s is not supported", e.Name)
+	}
+	chs := children(e)
+	keys := map[string]string{}
+	for _, k := range strings.Fields(e.Key) {
+		kv, ok := obj[k]
+		if !ok {
+			kv, ok = obj[moduleOf(e)+":"+k]
+		}
+		if !ok {
+			return nil, fmt.Errorf("key %s is missing", k)
+		}
+		cv, err := leafValue(chs[k], kv, p.lenient)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %s: %v", k, err)
+		}
+		keys[k] = keyString(cv)
+	}
+	return keys, nil
+}
+
+// leaf adds the leaf e at path, whose JSON value is v.
+func (p *parser) leaf(e *yang.Entry, path *gpb.Path, v interface{}) {
+	cv, err := leafValue(e, v, p.lenient)
+	if err != nil {
+		p.errs = util.AppendErr(p.errs, &PathError{Path: pathString(path), Err: err})
+		return
+	}
+	p.inst.leaves[pathString(path)] = &Leaf{Path: path, Value: cv, entry: e}
+}
+
+// appendElem returns a copy of path with an element appended to it.
+func appendElem(path *gpb.Path, name string, keys map[string]string) *gpb.Path {
+	np := proto.Clone(path).(*gpb.Path)
+	np.Elem = append(np.Elem, &gpb.PathElem{Name: name, Key: keys})
+	return np
+}
+
+// pathString returns the string form of path, or its text representation if
+// it cannot be converted.
+func pathString(path *gpb.Path) string {
+	s, err := ygot.PathToString(path)
+	if err != nil {
+		return path.String()
+	}
+	if s == "" {
+		return "/"
+	}
+	return s
+}
+
+// listBuilder accumulates the entries of a list while an RFC7951 JSON
+// document is rendered.
+type listBuilder struct {
+	// order is the keys of the entries, in the order in which they were
+	// added.
+	order []string
+	// entries is the content of each entry, keyed by its keys.
+	entries map[string]map[string]interface{}
+}
+
+// JSON returns the RFC7951 JSON document that contains the instance data.
+// Members are qualified by their module where it differs from that of their
+// parent.
+func (i *Instance) JSON() ([]byte, error) {
+	root, err := i.tree()
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(root, "", "  ")
+}
+
+// tree returns the instance data as the content of an RFC7951 JSON object,
+// whose members are qualified by their module where it differs from that
+// of their parent. Lists are arrays of their entries, which are in the
+// order of the leaves of the instance.
+func (i *Instance) tree() (map[string]interface{}, error) {
+	root := map[string]interface{}{}
+	for _, l := range i.Leaves() {
+		entries, err := i.schema.entryAt(l.Path)
+		if err != nil {
+			return nil, err
+		}
+		obj, mod := root, ""
+		for j, pe := range l.Path.GetElem() {
+			e := entries[j]
+			name := e.Name
+			if m := moduleOf(e); m != mod {
+				name = m + ":" + e.Name
+				mod = m
+			}
+			switch {
+			case j == len(entries)-1:
+				obj[name] = l.Value
+			case e.IsList():
+				lb, ok := obj[name].(*listBuilder)
+				if !ok {
+					lb = &listBuilder{entries: map[string]map[string]interface{}{}}
+					obj[name] = lb
+				}
+				k := pathString(&gpb.Path{Elem: []*gpb.PathElem{pe}})
+				if _, ok := lb.entries[k]; !ok {
+					lb.order = append(lb.order, k)
+					lb.entries[k] = map[string]interface{}{}
+				}
+				obj = lb.entries[k]
+			default:
+				c, ok := obj[name].(map[string]interface{})
+				if !ok {
+					c = map[string]interface{}{}
+					obj[name] = c
+				}
+				obj = c
+			}
+		}
+	}
+	buildLists(root)
+	return root, nil
+}
+
+// buildLists returns v with each listBuilder within it replaced by the array
+// of its entries.
+func buildLists(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, c := range v {
+			v[k] = buildLists(c)
+		}
+	case *listBuilder:
+		var entries []interface{}
+		for _, k := range v.order {
+			entries = append(entries, buildLists(v.entries[k]))
+		}
+		return entries
+	}
+	return v
+}
+
+// Notification returns a gNMI Notification with timestamp ts, whose updates
+// are the leaves of the instance.
+func (i *Instance) Notification(ts int64) (*gpb.Notification, error) {
+	n := &gpb.Notification{Timestamp: ts}
+	for _, l := range i.Leaves() {
+		v, err := typedValue(l.entry, l.Value)
+		if err != nil {
+			return nil, &PathError{Path: pathString(l.Path), Err: err}
+		}
+		n.Update = append(n.Update, &gpb.Update{Path: l.Path, Val: v})
+	}
+	return n, nil
+}
+
+// DiffOpt is an option for Diff.
+type DiffOpt interface {
+	// IsDiffOpt is a marker method for each DiffOpt.
+	IsDiffOpt()
+}
+
+// IgnoreDefaults is a DiffOpt that specifies that a leaf that is present in
+// only one of the instances is common to both if its value is the default
+// value of the leaf within the schema, since the leaf then has that value
+// within both instances. Leaves within list entries are only treated as such
+// where the list entry is present in both instances.
+type IgnoreDefaults struct{}
+
+// IsDiffOpt marks IgnoreDefaults as a DiffOpt.
+func (*IgnoreDefaults) IsDiffOpt() {}
+
+// Diff returns the differences between the leaves of the instances a and
+// b. Since the values of leaves are canonical, the encodings of a and b,
+// such as whether identities are qualified, do not affect the result.
+func Diff(a, b *Instance, opts ...DiffOpt) gnmidiff.StructuredDiff {
+	var ignoreDefaults bool
+	for _, o := range opts {
+		if _, ok := o.(*IgnoreDefaults); ok {
+			ignoreDefaults = true
+		}
+	}
+
+	d := gnmidiff.StructuredDiff{
+		UpdateDiff: gnmidiff.UpdateDiff{
+			MissingUpdates:    map[string]interface{}{},
+			ExtraUpdates:      map[string]interface{}{},
+			CommonUpdates:     map[string]interface{}{},
+			MismatchedUpdates: map[string]gnmidiff.MismatchedUpdate{},
+		},
+	}
+	for k, la := range a.leaves {
+		lb, ok := b.leaves[k]
+		switch {
+		case !ok && ignoreDefaults && isDefault(la) && b.hasEntries(la.Path):
+			d.CommonUpdates[k] = la.Value
+		case !ok:
+			d.MissingUpdates[k] = la.Value
+		case reflect.DeepEqual(la.Value, lb.Value):
+			d.CommonUpdates[k] = la.Value
+		default:
+			d.MismatchedUpdates[k] = gnmidiff.MismatchedUpdate{A: la.Value, B: lb.Value}
+		}
+	}
+	for k, lb := range b.leaves {
+		if _, ok := a.leaves[k]; ok {
+			continue
+		}
+		if ignoreDefaults && isDefault(lb) && a.hasEntries(lb.Path) {
+			d.CommonUpdates[k] = lb.Value
+			continue
+		}
+		d.ExtraUpdates[k] = lb.Value
+	}
+	return d
+}
+
+// isDefault reports whether the value of the leaf l is the default value of
+// its schema.
+func isDefault(l *Leaf) bool {
+	defs := l.entry.DefaultValues()
+	if len(defs) == 0 {
+		return false
+	}
+	var v interface{} = defs[0]
+	if l.entry.IsLeafList() {
+		var vs []interface{}
+		for _, d := range defs {
+			vs = append(vs, d)
+		}
+		v = vs
+	}
+	dv, err := leafValue(l.entry, v, true)
+	return err == nil && reflect.DeepEqual(dv, l.Value)
+}
+
+// hasEntries reports whether each of the list entries that enclose the leaf
+// at path is present within the instance, which it is if the keys of the
+// entry are.
+func (i *Instance) hasEntries(path *gpb.Path) bool {
+	for n, el := range path.GetElem() {
+		for k := range el.GetKey() {
+			kp := &gpb.Path{Elem: append(append([]*gpb.PathElem{}, path.Elem[:n+1]...), &gpb.PathElem{Name: k})}
+			if _, ok := i.leaves[pathString(kp)]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,761 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yinstance
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/gnmidiff"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func mustPath(t *testing.T, s string) *gpb.Path {
+	t.Helper()
+	p, err := ygot.StringToStructuredPath(s)
+	if err != nil {
+		t.Fatalf("cannot parse path %s: %v", s, err)
+	}
+	return p
+}
+
+func loadTestSchema(t *testing.T) *Schema {
+	t.Helper()
+	s, err := LoadSchema([]string{"testdata/example.yang", "testdata/example-aug.yang"}, []string{"testdata"})
+	if err != nil {
+		t.Fatalf("LoadSchema: got unexpected error: %v", err)
+	}
+	return s
+}
+
+func parseTestInstance(t *testing.T, s *Schema) *Instance {
+	t.Helper()
+	b, err := os.ReadFile("testdata/instance.json")
+	if err != nil {
+		t.Fatalf("cannot read instance: %v", err)
+	}
+	i, err := s.ParseJSON(b)
+	if err != nil {
+		t.Fatalf("ParseJSON: got unexpected error: %v", err)
+	}
+	return i
+}
+
+func leafValues(t *testing.T, i *Instance) map[string]interface{} {
+	t.Helper()
+	vals := map[string]interface{}{}
+	for _, l := range i.Leaves() {
+		vals[pathString(l.Path)] = l.Value
+	}
+	return vals
+}
+
+func isEmptyDiff(d gnmidiff.StructuredDiff) bool {
+	return len(d.MissingUpdates)+len(d.ExtraUpdates)+len(d.MismatchedUpdates) == 0
+}
+
+func TestParseJSON(t *testing.T) {
+	s := loadTestSchema(t)
+	got := leafValues(t, parseTestInstance(t, s))
+	want := map[string]interface{}{
+		"/system/hostname":                               "dev1",
+		"/system/uptime":                                 "12345",
+		"/system/domain":                                 []interface{}{"example.com", "example.net"},
+		"/system/location":                               "lab",
+		"/interfaces/interface[name=eth0]/name":          "eth0",
+		"/interfaces/interface[name=eth0]/mtu":           json.Number("1500"),
+		"/interfaces/interface[name=eth0]/mac":           "AA:BB:CC:DD:EE:FF",
+		"/interfaces/interface[name=eth0]/enabled":       true,
+		"/interfaces/interface[name=eth0]/type":          "ETHERNET",
+		"/interfaces/interface[name=eth0]/protocol":      "example:EBGP",
+		"/interfaces/interface[name=eth0]/weight":        "1.50",
+		"/interfaces/interface[name=eth0]/speed":         "AUTO",
+		"/interfaces/interface[name=eth0]/loopback-mode": []interface{}{nil},
+		"/interfaces/interface[name=lo0]/name":           "lo0",
+		"/interfaces/interface[name=lo0]/type":           "LOOPBACK",
+		"/interfaces/interface[name=lo0]/speed":          json.Number("100"),
+		"/routing/primary":                               "eth0",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParseJSON: did not get expected leaves, diff(-want, +got):\n%s", diff)
+	}
+}
+
+func TestParseJSONErrors(t *testing.T) {
+	s := loadTestSchema(t)
+	tests := []struct {
+		desc    string
+		in      string
+		wantErr string
+	}{{
+		desc:    "unknown member",
+		in:      `{"example:system": {"serial": "x"}}`,
+		wantErr: `unknown member "serial"`,
+	}, {
+		desc:    "unqualified top-level member",
+		in:      `{"system": {"hostname": "x"}}`,
+		wantErr: "must be qualified by module example",
+	}, {
+		desc:    "unqualified augmented member",
+		in:      `{"example:system": {"location": "x"}}`,
+		wantErr: "must be qualified by module example-aug",
+	}, {
+		desc:    "member of wrong module",
+		in:      `{"example-aug:system": {}}`,
+		wantErr: "is not within module example-aug",
+	}, {
+		desc:    "value outside range",
+		in:      `{"example:interfaces": {"interface": [{"name": "eth0", "type": "ETHERNET", "mtu": 10000}]}}`,
+		wantErr: "/interfaces/interface[name=eth0]/mtu",
+	}, {
+		desc:    "uint16 encoded as string",
+		in:      `{"example:interfaces": {"interface": [{"name": "eth0", "type": "ETHERNET", "mtu": "1500"}]}}`,
+		wantErr: "encoded as number",
+	}, {
+		desc:    "uint64 encoded as number",
+		in:      `{"example:system": {"uptime": 10}}`,
+		wantErr: "encoded as string",
+	}, {
+		desc:    "string not matching pattern",
+		in:      `{"example:interfaces": {"interface": [{"name": "eth0", "type": "ETHERNET", "mac": "aa:bb"}]}}`,
+		wantErr: "/interfaces/interface[name=eth0]/mac",
+	}, {
+		desc:    "string too long",
+		in:      `{"example:system": {"hostname": ""}}`,
+		wantErr: "/system/hostname",
+	}, {
+		desc:    "unknown enumeration value",
+		in:      `{"example:interfaces": {"interface": [{"name": "eth0", "type": "TUNNEL"}]}}`,
+		wantErr: `"TUNNEL" is not a value of enumeration`,
+	}, {
+		desc:    "identity not derived from base",
+		in:      `{"example:interfaces": {"interface": [{"name": "eth0", "type": "ETHERNET", "protocol": "example:PROTOCOL"}]}}`,
+		wantErr: "is not derived from identity PROTOCOL",
+	}, {
+		desc:    "value not matching union",
+		in:      `{"example:interfaces": {"interface": [{"name": "eth0", "type": "ETHERNET", "speed": "FAST"}]}}`,
+		wantErr: "does not match any member of union",
+	}, {
+		desc:    "missing key",
+		in:      `{"example:interfaces": {"interface": [{"type": "ETHERNET"}]}}`,
+		wantErr: "key name is missing",
+	}, {
+		desc:    "duplicate entry",
+		in:      `{"example:interfaces": {"interface": [{"name": "eth0", "type": "ETHERNET"}, {"name": "eth0", "type": "ETHERNET"}]}}`,
+		wantErr: "duplicate list entry",
+	}, {
+		desc:    "missing mandatory leaf",
+		in:      `{"example:interfaces": {"interface": [{"name": "eth0"}]}}`,
+		wantErr: "mandatory leaf type is missing",
+	}, {
+		desc:    "duplicate leaf-list element",
+		in:      `{"example:system": {"domain": ["a", "a"]}}`,
+		wantErr: "duplicate element a",
+	}, {
+		desc:    "not an object",
+		in:      `[]`,
+		wantErr: "want object",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := s.ParseJSON([]byte(tt.in))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseJSON(%s): got error %v, want error containing %q", tt.in, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	s := loadTestSchema(t)
+	want := parseTestInstance(t, s)
+
+	b, err := want.JSON()
+	if err != nil {
+		t.Fatalf("JSON: got unexpected error: %v", err)
+	}
+	got, err := s.ParseJSON(b)
+	if err != nil {
+		t.Fatalf("ParseJSON of rendered JSON: got unexpected error: %v\n%s", err, b)
+	}
+	if d := Diff(want, got); !isEmptyDiff(d) {
+		t.Errorf("ParseJSON(JSON()): did not get expected instance:\n%s", d.Format(gnmidiff.Format{}))
+	}
+
+	for _, enc := range []Encoding{EncodingXML, EncodingCBOR} {
+		b, err := want.Marshal(enc)
+		if err != nil {
+			t.Fatalf("Marshal(%s): got unexpected error: %v", enc, err)
+		}
+		got, err := s.Parse(b, enc)
+		if err != nil {
+			t.Fatalf("Parse(%s) of rendered document: got unexpected error: %v\n%q", enc, err, b)
+		}
+		if d := Diff(want, got); !isEmptyDiff(d) {
+			t.Errorf("Parse(Marshal(%s)): did not get expected instance:\n%s", enc, d.Format(gnmidiff.Format{}))
+		}
+	}
+
+	n, err := want.Notification(42)
+	if err != nil {
+		t.Fatalf("Notification: got unexpected error: %v", err)
+	}
+	if got, err = s.ParseNotifications([]*gpb.Notification{n}); err != nil {
+		t.Fatalf("ParseNotifications: got unexpected error: %v", err)
+	}
+	if d := Diff(want, got); !isEmptyDiff(d) {
+		t.Errorf("ParseNotifications(Notification()): did not get expected instance:\n%s", d.Format(gnmidiff.Format{}))
+	}
+}
+
+func TestParseNotifications(t *testing.T) {
+	s := loadTestSchema(t)
+	got, err := s.ParseNotifications([]*gpb.Notification{{
+		Prefix: &gpb.Path{Target: "dev1", Elem: []*gpb.PathElem{{Name: "interfaces"}}},
+		Update: []*gpb.Update{{
+			Path: mustPath(t, "/interface[name=eth1]/mtu"),
+			Val:  &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: 9000}},
+		}, {
+			Path: mustPath(t, "/interface[name=eth2]"),
+			Val:  &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"weight": 2.5, "protocol": "BGP"}`)}},
+		}},
+	}, {
+		Update: []*gpb.Update{{
+			Path: mustPath(t, "/example:system"),
+			Val:  &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"hostname": "dev1", "location": "lab"}`)}},
+		}},
+		Delete: []*gpb.Path{mustPath(t, "/interfaces/interface[name=eth1]/mtu")},
+	}})
+	if err != nil {
+		t.Fatalf("ParseNotifications: got unexpected error: %v", err)
+	}
+	want := map[string]interface{}{
+		"/interfaces/interface[name=eth1]/name":     "eth1",
+		"/interfaces/interface[name=eth2]/name":     "eth2",
+		"/interfaces/interface[name=eth2]/weight":   "2.50",
+		"/interfaces/interface[name=eth2]/protocol": "example:BGP",
+		"/system/hostname":                          "dev1",
+		"/system/location":                          "lab",
+	}
+	if diff := cmp.Diff(want, leafValues(t, got)); diff != "" {
+		t.Errorf("ParseNotifications: did not get expected leaves, diff(-want, +got):\n%s", diff)
+	}
+
+	if _, err := s.ParseNotifications([]*gpb.Notification{{
+		Update: []*gpb.Update{{
+			Path: mustPath(t, "/interfaces/interface[name=eth1]/mtu"),
+			Val:  &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: 10}},
+		}},
+	}}); err == nil {
+		t.Errorf("ParseNotifications with invalid value: got nil error, want error")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	s := loadTestSchema(t)
+	a := parseTestInstance(t, s)
+	b, err := s.ParseJSON([]byte(`{
+  "example:system": {"hostname": "dev2"},
+  "example:interfaces": {"interface": [{"name": "eth0", "type": "ETHERNET", "protocol": "EBGP"}]}
+}`))
+	if err != nil {
+		t.Fatalf("ParseJSON: got unexpected error: %v", err)
+	}
+	d := Diff(a, b)
+	if got, want := d.MismatchedUpdates, map[string]gnmidiff.MismatchedUpdate{
+		"/system/hostname": {A: "dev1", B: "dev2"},
+	}; !cmp.Equal(got, want) {
+		t.Errorf("Diff: did not get expected mismatched updates, diff(-want, +got):\n%s", cmp.Diff(want, got))
+	}
+	// The unqualified identity is equal to the qualified identity of a.
+	if _, ok := d.CommonUpdates["/interfaces/interface[name=eth0]/protocol"]; !ok {
+		t.Errorf("Diff: identity is not common to both instances, got diff:\n%s", d.Format(gnmidiff.Format{}))
+	}
+	if len(d.ExtraUpdates) != 0 || len(d.MissingUpdates) != 13 {
+		t.Errorf("Diff: got %d extra and %d missing updates, want 0 and 13:\n%s", len(d.ExtraUpdates), len(d.MissingUpdates), d.Format(gnmidiff.Format{}))
+	}
+}
+
+func TestWriteTree(t *testing.T) {
+	var b strings.Builder
+	if err := loadTestSchema(t).WriteTree(&b); err != nil {
+		t.Fatalf("WriteTree: got unexpected error: %v", err)
+	}
+	want := `module: example
+  +--rw interfaces
+  |  +--rw interface* [name]
+  |     +--rw cost?   uint32
+  |     +--rw enabled?   boolean
+  |     +--rw loopback-mode?   empty
+  |     +--rw mac?   mac-address
+  |     +--rw mtu?   uint16
+  |     +--rw name   string
+  |     +--rw protocol?   identityref
+  |     +--rw speed?   union
+  |     +--rw type   enumeration
+  |     +--rw weight?   decimal64
+  +--rw routing
+  |  +--rw primary?   -> /interfaces/interface/name
+  +--rw system
+     +--rw domain*   string
+     +--rw hostname?   string
+     +--rw location?   string
+     +--ro uptime?   uint64
+`
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("WriteTree: did not get expected tree, diff(-want, +got):\n%s", diff)
+	}
+}
+
+func TestSet(t *testing.T) {
+	s := loadTestSchema(t)
+	i := s.NewInstance()
+	if err := i.Set(mustPath(t, "/example:interfaces/interface[name=eth0]/mtu"), "1500"); err != nil {
+		t.Fatalf("Set: got unexpected error: %v", err)
+	}
+	want := map[string]interface{}{
+		"/interfaces/interface[name=eth0]/mtu":  json.Number("1500"),
+		"/interfaces/interface[name=eth0]/name": "eth0",
+	}
+	if diff := cmp.Diff(want, leafValues(t, i)); diff != "" {
+		t.Errorf("Set: did not get expected leaves (-want, +got):\n%s", diff)
+	}
+
+	for _, tt := range []struct {
+		path    string
+		val     interface{}
+		wantErr string
+	}{
+		{"/interfaces/interface[name=eth1]/mtu", "10", "range"},
+		{"/interfaces/interface/mtu", json.Number("1500"), "must be identified by keys"},
+		{"/interfaces/interface[name=eth1]", json.Number("1500"), "not a leaf"},
+		{"/interfaces/fish", "x", "unknown node"},
+	} {
+		if err := i.Set(mustPath(t, tt.path), tt.val); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Set(%s, %v): got error %v, want error containing %q", tt.path, tt.val, err, tt.wantErr)
+		}
+	}
+	// Invalid values do not add the key leaves of their list entries.
+	if diff := cmp.Diff(want, leafValues(t, i)); diff != "" {
+		t.Errorf("Set of invalid values changed leaves (-want, +got):\n%s", diff)
+	}
+}
+
+func TestParseJSONPathErrors(t *testing.T) {
+	s := loadTestSchema(t)
+	_, err := s.ParseJSON([]byte(`{"example:interfaces": {"interface": [{"name": "eth0", "type": "ETHERNET", "mtu": 10000, "mac": "x"}]}}`))
+	var errs util.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("ParseJSON: got error %v, want util.Errors", err)
+	}
+	var got []string
+	for _, err := range errs {
+		var pe *PathError
+		if !errors.As(err, &pe) {
+			t.Fatalf("ParseJSON: got error %v, want PathError", err)
+		}
+		got = append(got, pe.Path)
+	}
+	want := []string{"/interfaces/interface[name=eth0]/mac", "/interfaces/interface[name=eth0]/mtu"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParseJSON: did not get expected error paths (-want, +got):\n%s", diff)
+	}
+}
+
+func TestValidateLeafrefs(t *testing.T) {
+	s := loadTestSchema(t)
+	tests := []struct {
+		desc     string
+		in       string
+		wantErrs []string
+	}{{
+		desc: "target exists",
+		in:   `{"example:interfaces": {"interface": [{"name": "eth0", "type": "ETHERNET"}]}, "example:routing": {"primary": "eth0"}}`,
+	}, {
+		desc:     "target missing",
+		in:       `{"example:interfaces": {"interface": [{"name": "eth0", "type": "ETHERNET"}]}, "example:routing": {"primary": "eth1"}}`,
+		wantErrs: []string{"/routing/primary: leafref target /interfaces/interface/name with value eth1 does not exist"},
+	}, {
+		desc:     "no targets",
+		in:       `{"example:routing": {"primary": "eth0"}}`,
+		wantErrs: []string{"/routing/primary: leafref target /interfaces/interface/name with value eth0 does not exist"},
+	}, {
+		desc: "no leafrefs",
+		in:   `{"example:interfaces": {"interface": [{"name": "eth0", "type": "ETHERNET"}]}}`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			i, err := s.ParseJSON([]byte(tt.in))
+			if err != nil {
+				t.Fatalf("ParseJSON: got unexpected error: %v", err)
+			}
+			var got []string
+			if err := i.ValidateLeafrefs(); err != nil {
+				for _, err := range err.(util.Errors) {
+					var pe *PathError
+					if !errors.As(err, &pe) {
+						t.Errorf("ValidateLeafrefs: got error %v, want PathError", err)
+					}
+					got = append(got, err.Error())
+				}
+			}
+			if diff := cmp.Diff(tt.wantErrs, got); diff != "" {
+				t.Errorf("ValidateLeafrefs: did not get expected errors (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseXML(t *testing.T) {
+	s := loadTestSchema(t)
+	b, err := os.ReadFile("testdata/instance.xml")
+	if err != nil {
+		t.Fatalf("cannot read instance: %v", err)
+	}
+	got, err := s.ParseXML(b)
+	if err != nil {
+		t.Fatalf("ParseXML: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(leafValues(t, parseTestInstance(t, s)), leafValues(t, got)); diff != "" {
+		t.Errorf("ParseXML: did not get leaves of equivalent JSON instance (-want, +got):\n%s", diff)
+	}
+
+	// Top-level elements need not be wrapped.
+	got, err = s.ParseXML([]byte(`<system xmlns="urn:example"><hostname>dev1</hostname></system>`))
+	if err != nil {
+		t.Fatalf("ParseXML of unwrapped document: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string]interface{}{"/system/hostname": "dev1"}, leafValues(t, got)); diff != "" {
+		t.Errorf("ParseXML of unwrapped document: did not get expected leaves (-want, +got):\n%s", diff)
+	}
+}
+
+func TestParseXMLErrors(t *testing.T) {
+	s := loadTestSchema(t)
+	tests := []struct {
+		desc    string
+		in      string
+		wantErr string
+	}{{
+		desc:    "malformed",
+		in:      `<system xmlns="urn:example">`,
+		wantErr: "cannot decode XML",
+	}, {
+		desc:    "unknown namespace",
+		in:      `<data><system xmlns="urn:other"/></data>`,
+		wantErr: `element system has unknown namespace "urn:other"`,
+	}, {
+		desc:    "unknown element",
+		in:      `<system xmlns="urn:example"><name>dev1</name></system>`,
+		wantErr: `/system: unknown member "example:name"`,
+	}, {
+		desc:    "invalid value",
+		in:      `<interfaces xmlns="urn:example"><interface><name>eth0</name><type>ETHERNET</type><mtu>10</mtu></interface></interfaces>`,
+		wantErr: "/interfaces/interface[name=eth0]/mtu: ",
+	}, {
+		desc:    "missing mandatory leaf",
+		in:      `<interfaces xmlns="urn:example"><interface><name>eth0</name></interface></interfaces>`,
+		wantErr: "mandatory leaf type is missing",
+	}, {
+		desc:    "unknown identity prefix",
+		in:      `<interfaces xmlns="urn:example"><interface><name>eth0</name><type>ETHERNET</type><protocol>x:EBGP</protocol></interface></interfaces>`,
+		wantErr: "/interfaces/interface[name=eth0]/protocol: ",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if _, err := s.ParseXML([]byte(tt.in)); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseXML: got error %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDiffIgnoreDefaults(t *testing.T) {
+	s := loadTestSchema(t)
+	a, err := s.ParseJSON([]byte(`{"example:interfaces": {"interface": [
+  {"name": "eth0", "type": "ETHERNET", "example-aug:cost": 10},
+  {"name": "eth1", "type": "ETHERNET", "example-aug:cost": 10},
+  {"name": "eth2", "type": "ETHERNET", "example-aug:cost": 20}
+]}}`))
+	if err != nil {
+		t.Fatalf("ParseJSON: got unexpected error: %v", err)
+	}
+	b, err := s.ParseJSON([]byte(`{"example:interfaces": {"interface": [
+  {"name": "eth0", "type": "ETHERNET"},
+  {"name": "eth2", "type": "ETHERNET"}
+]}}`))
+	if err != nil {
+		t.Fatalf("ParseJSON: got unexpected error: %v", err)
+	}
+
+	d := Diff(a, b, &IgnoreDefaults{})
+	// The default cost of eth1 is missing since the entry is missing.
+	wantMissing := map[string]interface{}{
+		"/interfaces/interface[name=eth1]/cost": json.Number("10"),
+		"/interfaces/interface[name=eth1]/name": "eth1",
+		"/interfaces/interface[name=eth1]/type": "ETHERNET",
+		"/interfaces/interface[name=eth2]/cost": json.Number("20"),
+	}
+	if diff := cmp.Diff(wantMissing, d.MissingUpdates); diff != "" {
+		t.Errorf("Diff: did not get expected missing updates (-want, +got):\n%s", diff)
+	}
+	if _, ok := d.CommonUpdates["/interfaces/interface[name=eth0]/cost"]; !ok {
+		t.Errorf("Diff: default cost is not common to both instances, got diff:\n%s", d.Format(gnmidiff.Format{}))
+	}
+
+	// Without the option, the default cost of eth0 is missing.
+	if _, ok := Diff(a, b).MissingUpdates["/interfaces/interface[name=eth0]/cost"]; !ok {
+		t.Errorf("Diff without IgnoreDefaults: default cost is not missing")
+	}
+	// Extra defaults are ignored in the same way.
+	if d := Diff(b, a, &IgnoreDefaults{}); len(d.ExtraUpdates) != 4 {
+		t.Errorf("Diff: got %d extra updates, want 4:\n%s", len(d.ExtraUpdates), d.Format(gnmidiff.Format{}))
+	}
+}
+
+func TestXML(t *testing.T) {
+	s := loadTestSchema(t)
+	inst, err := s.ParseJSON([]byte(`{
+  "example:interfaces": {"interface": [
+    {"type": "ETHERNET", "name": "eth0", "protocol": "example:EBGP", "loopback-mode": [null], "example-aug:cost": 5}
+  ]}
+}`))
+	if err != nil {
+		t.Fatalf("ParseJSON: got unexpected error: %v", err)
+	}
+	got, err := inst.XML()
+	if err != nil {
+		t.Fatalf("XML: got unexpected error: %v", err)
+	}
+	want := `<data xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">
+  <interfaces xmlns="urn:example">
+    <interface>
+      <name>eth0</name>
+      <cost xmlns="urn:example-aug">5</cost>
+      <loopback-mode/>
+      <protocol xmlns:example="urn:example">example:EBGP</protocol>
+      <type>ETHERNET</type>
+    </interface>
+  </interfaces>
+</data>
+`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("XML: did not get expected document (-want, +got):\n%s", diff)
+	}
+}
+
+// textHex returns the hex encoding of the CBOR text string s, which is
+// shorter than 24 bytes.
+func textHex(s string) string {
+	return fmt.Sprintf("%02x", 0x60+len(s)) + hex.EncodeToString([]byte(s))
+}
+
+func TestCBOR(t *testing.T) {
+	s := loadTestSchema(t)
+	tests := []struct {
+		desc    string
+		inJSON  string
+		wantHex string
+	}{{
+		desc:   "container with augmented leaf",
+		inJSON: `{"example:system": {"hostname": "a", "example-aug:location": "b", "uptime": "1"}}`,
+		// {"example:system": {"uptime": 1, "hostname": "a", "example-aug:location": "b"}}
+		wantHex: "a1" + textHex("example:system") + "a3" +
+			textHex("uptime") + "01" +
+			textHex("hostname") + textHex("a") +
+			textHex("example-aug:location") + textHex("b"),
+	}, {
+		desc:   "list entry with typed values",
+		inJSON: `{"example:interfaces": {"interface": [{"name": "e", "type": "LOOPBACK", "weight": "-0.05", "speed": "AUTO", "loopback-mode": [null]}]}}`,
+		// {"example:interfaces": {"interface": [{"name": "e", "type": 1,
+		// "speed": 44("AUTO"), "weight": 4([-2, -5]), "loopback-mode": null}]}}
+		wantHex: "a1" + textHex("example:interfaces") + "a1" +
+			textHex("interface") + "81" + "a5" +
+			textHex("name") + textHex("e") +
+			textHex("type") + "01" +
+			textHex("speed") + "d82c" + textHex("AUTO") +
+			textHex("weight") + "c4" + "82" + "21" + "24" +
+			textHex("loopback-mode") + "f6",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			inst, err := s.ParseJSON([]byte(tt.inJSON))
+			if err != nil {
+				t.Fatalf("ParseJSON: got unexpected error: %v", err)
+			}
+			got, err := inst.CBOR()
+			if err != nil {
+				t.Fatalf("CBOR: got unexpected error: %v", err)
+			}
+			want, err := hex.DecodeString(tt.wantHex)
+			if err != nil {
+				t.Fatalf("invalid test hex: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("CBOR: got %x, want %x", got, want)
+			}
+		})
+	}
+}
+
+func TestParseCBOR(t *testing.T) {
+	s := loadTestSchema(t)
+	tests := []struct {
+		desc  string
+		inHex string
+		want  map[string]interface{}
+	}{{
+		desc: "indefinite length items",
+		// {_ "example:system": {_ "domain": [_ "a", "b"], "hostname": (_ "de", "v")}}
+		inHex: "bf" + textHex("example:system") + "bf" +
+			textHex("domain") + "9f" + textHex("a") + textHex("b") + "ff" +
+			textHex("hostname") + "7f" + textHex("de") + textHex("v") + "ff" +
+			"ff" + "ff",
+		want: map[string]interface{}{
+			"/system/domain":   []interface{}{"a", "b"},
+			"/system/hostname": "dev",
+		},
+	}, {
+		desc: "enumeration, decimal fraction and 64-bit integer",
+		// {"example:system": {"uptime": 12345}, "example:interfaces":
+		// {"interface": [{"name": "e", "type": 0, "weight": 4([-1, 15])}]}}
+		inHex: "a2" + textHex("example:system") + "a1" +
+			textHex("uptime") + "193039" +
+			textHex("example:interfaces") + "a1" +
+			textHex("interface") + "81" + "a3" +
+			textHex("name") + textHex("e") +
+			textHex("type") + "00" +
+			textHex("weight") + "c4" + "82" + "20" + "0f",
+		want: map[string]interface{}{
+			"/system/uptime":                       "12345",
+			"/interfaces/interface[name=e]/name":   "e",
+			"/interfaces/interface[name=e]/type":   "ETHERNET",
+			"/interfaces/interface[name=e]/weight": "1.50",
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b, err := hex.DecodeString(tt.inHex)
+			if err != nil {
+				t.Fatalf("invalid test hex: %v", err)
+			}
+			got, err := s.ParseCBOR(b)
+			if err != nil {
+				t.Fatalf("ParseCBOR: got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, leafValues(t, got)); diff != "" {
+				t.Errorf("ParseCBOR: did not get expected leaves (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseCBORErrors(t *testing.T) {
+	s := loadTestSchema(t)
+	// ifaceEntry returns {"example:interfaces": {"interface": [{"name":
+	// "e", <members>}]}}.
+	ifaceEntry := func(members ...string) string {
+		return "a1" + textHex("example:interfaces") + "a1" +
+			textHex("interface") + "81" + fmt.Sprintf("a%d", len(members)+1) +
+			textHex("name") + textHex("e") + strings.Join(members, "")
+	}
+	typeEthernet := textHex("type") + "00"
+	tests := []struct {
+		desc    string
+		inHex   string
+		wantErr string
+	}{{
+		desc:    "truncated",
+		inHex:   "a1" + textHex("example:system"),
+		wantErr: "cannot decode CBOR: unexpected end of CBOR data",
+	}, {
+		desc:    "trailing data",
+		inHex:   "a000",
+		wantErr: "unexpected 1 trailing bytes",
+	}, {
+		desc:    "not a map",
+		inHex:   "80",
+		wantErr: "want map",
+	}, {
+		desc:    "SID keys",
+		inHex:   "a1190fa0a0",
+		wantErr: "SID-based encoding is not supported",
+	}, {
+		desc:    "unknown enumeration value",
+		inHex:   ifaceEntry(textHex("type") + "07"),
+		wantErr: "/interfaces/interface/type: 7 is not a value of enumeration",
+	}, {
+		desc:    "unsupported tag",
+		inHex:   ifaceEntry(typeEthernet, textHex("speed")+"d82d"+"00"),
+		wantErr: "/interfaces/interface/speed: unsupported tag 45",
+	}, {
+		desc:    "invalid value",
+		inHex:   ifaceEntry(typeEthernet, textHex("mtu")+"0a"),
+		wantErr: "/interfaces/interface[name=e]/mtu: ",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b, err := hex.DecodeString(tt.inHex)
+			if err != nil {
+				t.Fatalf("invalid test hex: %v", err)
+			}
+			if _, err := s.ParseCBOR(b); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseCBOR: got error %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	s := loadTestSchema(t)
+	in, err := os.ReadFile("testdata/instance.xml")
+	if err != nil {
+		t.Fatalf("cannot read instance: %v", err)
+	}
+	want := leafValues(t, parseTestInstance(t, s))
+	for _, to := range Encodings {
+		b, err := s.Convert(in, EncodingXML, to)
+		if err != nil {
+			t.Fatalf("Convert(xml, %s): got unexpected error: %v", to, err)
+		}
+		got, err := s.Parse(b, to)
+		if err != nil {
+			t.Fatalf("Parse(%s): got unexpected error: %v", to, err)
+		}
+		if diff := cmp.Diff(want, leafValues(t, got)); diff != "" {
+			t.Errorf("Convert(xml, %s): did not get expected leaves (-want, +got):\n%s", to, diff)
+		}
+	}
+
+	if _, err := s.Convert(in, "yaml", EncodingJSON); err == nil || !strings.Contains(err.Error(), `unknown encoding "yaml"`) {
+		t.Errorf("Convert from unknown encoding: got error %v, want unknown encoding", err)
+	}
+}
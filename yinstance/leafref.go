@@ -0,0 +1,85 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yinstance
+
+import (
+	"fmt"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
+
+// ValidateLeafrefs checks that the target of each of the leaves of type
+// leafref within the instance exists, unless the leafref specifies
+// require-instance false. All errors that are found are returned together,
+// each as a PathError.
+//
+// The predicates within the paths of leafrefs are not evaluated, such that a
+// leafref is satisfied by a leaf with its value at any instance of its target
+// schema node, e.g., within any entry of a list. Leafrefs that are members
+// of unions are not checked.
+func (i *Instance) ValidateLeafrefs() error {
+	// targets caches the set of values of each target schema node.
+	targets := map[*yang.Entry]map[string]bool{}
+	values := func(target *yang.Entry) map[string]bool {
+		if vs, ok := targets[target]; ok {
+			return vs
+		}
+		vs := map[string]bool{}
+		for _, l := range i.leaves {
+			if l.entry != target {
+				continue
+			}
+			for _, v := range leafElements(l) {
+				vs[keyString(v)] = true
+			}
+		}
+		targets[target] = vs
+		return vs
+	}
+
+	var errs util.Errors
+	for _, l := range i.Leaves() {
+		if l.entry.Type == nil || l.entry.Type.Kind != yang.Yleafref || l.entry.Type.OptionalInstance {
+			continue
+		}
+		target, targetPath, err := util.ResolveLeafrefTarget(l.entry)
+		if err != nil {
+			errs = util.AppendErr(errs, &PathError{Path: pathString(l.Path), Err: fmt.Errorf("cannot resolve leafref: %v", err)})
+			continue
+		}
+		vs := values(target)
+		for _, v := range leafElements(l) {
+			if k := keyString(v); !vs[k] {
+				errs = util.AppendErr(errs, &PathError{Path: pathString(l.Path), Err: fmt.Errorf("leafref target %s with value %s does not exist", targetPath, k)})
+			}
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// leafElements returns the values of the elements of the leaf-list l, or
+// the value of the leaf l.
+func leafElements(l *Leaf) []interface{} {
+	if l.entry.IsLeafList() {
+		if vs, ok := l.Value.([]interface{}); ok {
+			return vs
+		}
+	}
+	return []interface{}{l.Value}
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package yinstance works with YANG instance data using a schema that is
+// loaded directly from YANG modules, without generating code. Instance data
+// in RFC7951 JSON, XML, YANG-CBOR, or as gNMI Notifications, is parsed into
+// a set of leaves, whose values are validated against the schema, and which
+// can be rendered in any of these encodings, or compared with those of
+// another instance.
+package yinstance
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Schema is the schema of a data tree that is described by a set of YANG
+// modules.
+type Schema struct {
+	// Root is a synthesised directory entry whose children are the
+	// top-level data nodes of each module.
+	Root *yang.Entry
+	// Modules is the set of entries of the modules that were loaded,
+	// sorted by name.
+	Modules []*yang.Entry
+}
+
+// LoadSchema parses and processes the YANG modules within files, for which
+// included and imported modules are found within includePaths, returning
+// the Schema of the data tree that they describe.
+func LoadSchema(files, includePaths []string) (*Schema, error) {
+	ms := yang.NewModules()
+	for _, p := range includePaths {
+		ms.AddPath(p)
+	}
+	var errs util.Errors
+	for _, f := range files {
+		errs = util.AppendErr(errs, ms.Read(f))
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	if errs := ms.Process(); errs != nil {
+		return nil, util.Errors(errs)
+	}
+
+	// Modules are indexed by both their name and their revision, so are
+	// deduplicated by name.
+	mods := map[string]*yang.Module{}
+	for _, m := range ms.Modules {
+		mods[m.Name] = m
+	}
+	var names []string
+	for n := range mods {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	s := &Schema{Root: &yang.Entry{Name: "", Kind: yang.DirectoryEntry, Dir: map[string]*yang.Entry{}}}
+	for _, n := range names {
+		e := yang.ToEntry(mods[n])
+		if errs := e.GetErrors(); len(errs) != 0 {
+			return nil, util.Errors(errs)
+		}
+		s.Modules = append(s.Modules, e)
+		for name, ch := range e.Dir {
+			if ch.RPC != nil || ch.Kind == yang.NotificationEntry {
+				continue
+			}
+			if prev, ok := s.Root.Dir[name]; ok && prev != ch {
+				return nil, fmt.Errorf("top-level node %s is defined by modules %s and %s", name, moduleOf(prev), n)
+			}
+			s.Root.Dir[name] = ch
+		}
+	}
+	return s, nil
+}
+
+// moduleOf returns the name of the module whose namespace the data node e
+// is within, or the empty string if it cannot be determined.
+func moduleOf(e *yang.Entry) string {
+	if e == nil || e.Node == nil {
+		return ""
+	}
+	m, err := e.InstantiatingModule()
+	if err != nil {
+		return ""
+	}
+	return m
+}
+
+// children returns the data node children of the directory entry e, keyed
+// by their name. Choice and case nodes are transparent.
+func children(e *yang.Entry) map[string]*yang.Entry {
+	m := map[string]*yang.Entry{}
+	for _, ch := range util.FindFirstNonChoiceOrCase(e) {
+		m[ch.Name] = ch
+	}
+	return m
+}
+
+// splitName splits the member name of an RFC7951 JSON object, or of a path
+// element, into its module prefix, which is empty if it is unqualified, and
+// its name.
+func splitName(n string) (string, string) {
+	if i := strings.Index(n, ":"); i != -1 {
+		return n[:i], n[i+1:]
+	}
+	return "", n
+}
+
+// entryAt returns the schema entries of the nodes at each of the elements of
+// path, which is absolute.
+func (s *Schema) entryAt(path *gpb.Path) ([]*yang.Entry, error) {
+	var entries []*yang.Entry
+	e := s.Root
+	for _, pe := range path.GetElem() {
+		if !e.IsDir() {
+			return nil, fmt.Errorf("%s is not a directory in path %v", e.Name, path)
+		}
+		mod, name := splitName(pe.GetName())
+		ch, ok := children(e)[name]
+		if !ok || (mod != "" && mod != moduleOf(ch)) {
+			return nil, fmt.Errorf("unknown node %s in path %v", pe.GetName(), path)
+		}
+		entries = append(entries, ch)
+		e = ch
+	}
+	return entries, nil
+}
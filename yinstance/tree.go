@@ -0,0 +1,136 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yinstance
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/genutil"
+)
+
+// WriteTree writes the data tree of the schema to w, in the format of the
+// YANG tree diagrams of RFC8340. The top-level nodes of each module are
+// written beneath the name of the module, and the nodes that a module
+// augments into another module are written within the tree of the latter.
+// Children are written in alphabetical order.
+func (s *Schema) WriteTree(w io.Writer) error {
+	for _, m := range s.Modules {
+		var top []*yang.Entry
+		for _, k := range genutil.GetOrderedEntryKeys(m.Dir) {
+			if ch := m.Dir[k]; s.Root.Dir[k] == ch {
+				top = append(top, ch)
+			}
+		}
+		if len(top) == 0 {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "module: %s\n", m.Name); err != nil {
+			return err
+		}
+		if err := writeNodes(w, top, "  "); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeNodes writes the tree diagram lines of the sibling nodes entries, and
+// of their descendants, with each line prefixed by indent.
+func writeNodes(w io.Writer, entries []*yang.Entry, indent string) error {
+	for i, e := range entries {
+		if _, err := fmt.Fprintf(w, "%s+--%s\n", indent, nodeLine(e)); err != nil {
+			return err
+		}
+		if !e.IsDir() {
+			continue
+		}
+		var chs []*yang.Entry
+		for _, k := range genutil.GetOrderedEntryKeys(e.Dir) {
+			chs = append(chs, e.Dir[k])
+		}
+		next := indent + "|  "
+		if i == len(entries)-1 {
+			next = indent + "   "
+		}
+		if err := writeNodes(w, chs, next); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nodeLine returns the tree diagram line of the node e, without its
+// indentation.
+func nodeLine(e *yang.Entry) string {
+	flags := "rw"
+	if e.ReadOnly() {
+		flags = "ro"
+	}
+	switch {
+	case e.IsCase():
+		return fmt.Sprintf(":(%s)", e.Name)
+	case e.IsChoice():
+		opt := "?"
+		if e.Mandatory == yang.TSTrue {
+			opt = ""
+		}
+		return fmt.Sprintf("%s (%s)%s", flags, e.Name, opt)
+	case e.IsList():
+		if e.Key == "" {
+			return fmt.Sprintf("%s %s*", flags, e.Name)
+		}
+		return fmt.Sprintf("%s %s* [%s]", flags, e.Name, e.Key)
+	case e.IsContainer():
+		if e.Extra["presence"] != nil {
+			return fmt.Sprintf("%s %s!", flags, e.Name)
+		}
+		return fmt.Sprintf("%s %s", flags, e.Name)
+	case e.IsLeafList():
+		return fmt.Sprintf("%s %s*   %s", flags, e.Name, typeName(e.Type))
+	}
+	opt := "?"
+	if e.Mandatory == yang.TSTrue || isKey(e) {
+		opt = ""
+	}
+	return fmt.Sprintf("%s %s%s   %s", flags, e.Name, opt, typeName(e.Type))
+}
+
+// isKey returns whether the leaf e is a key of its parent list.
+func isKey(e *yang.Entry) bool {
+	if e.Parent == nil || !e.Parent.IsList() {
+		return false
+	}
+	for _, k := range strings.Fields(e.Parent.Key) {
+		if k == e.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// typeName returns the name of the type t as it is written in a tree
+// diagram.
+func typeName(t *yang.YangType) string {
+	switch {
+	case t == nil:
+		return ""
+	case t.Kind == yang.Yleafref:
+		return "-> " + t.Path
+	}
+	return t.Name
+}
@@ -0,0 +1,389 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yinstance
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ytypes"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// leafValue validates the JSON value v of the leaf or leaf-list e, returning
+// its canonical RFC7951 form. Where lenient is set, values that are encoded
+// as they are within gNMI paths and scalar TypedValues, such as 64-bit
+// integers that are JSON numbers, are also accepted.
+func leafValue(e *yang.Entry, v interface{}, lenient bool) (interface{}, error) {
+	if !e.IsLeafList() {
+		return scalarValue(e, e.Type, v, lenient)
+	}
+	elems, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("got %T, want array for leaf-list", v)
+	}
+	seen := map[string]bool{}
+	var vals []interface{}
+	for i, el := range elems {
+		cv, err := scalarValue(e, e.Type, el, lenient)
+		if err != nil {
+			return nil, fmt.Errorf("invalid element at index %d: %v", i, err)
+		}
+		// Only the elements of config leaf-lists must be unique.
+		k := keyString(cv)
+		if seen[k] && !e.ReadOnly() {
+			return nil, fmt.Errorf("duplicate element %v", k)
+		}
+		seen[k] = true
+		vals = append(vals, cv)
+	}
+	return vals, nil
+}
+
+// scalarValue validates the JSON value v of type t, of the leaf or leaf-list
+// e, returning its canonical RFC7951 form.
+func scalarValue(e *yang.Entry, t *yang.YangType, v interface{}, lenient bool) (interface{}, error) {
+	switch t.Kind {
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yint64:
+		s, err := numberString(t, v, lenient)
+		if err != nil {
+			return nil, err
+		}
+		i, err := strconv.ParseInt(s, 10, bitSize(t.Kind))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v value %q", t.Kind, s)
+		}
+		if err := ytypes.ValidateIntRestrictions(t, i); err != nil {
+			return nil, err
+		}
+		if t.Kind == yang.Yint64 {
+			return strconv.FormatInt(i, 10), nil
+		}
+		return json.Number(strconv.FormatInt(i, 10)), nil
+	case yang.Yuint8, yang.Yuint16, yang.Yuint32, yang.Yuint64:
+		s, err := numberString(t, v, lenient)
+		if err != nil {
+			return nil, err
+		}
+		u, err := strconv.ParseUint(s, 10, bitSize(t.Kind))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v value %q", t.Kind, s)
+		}
+		if err := ytypes.ValidateUintRestrictions(t, u); err != nil {
+			return nil, err
+		}
+		if t.Kind == yang.Yuint64 {
+			return strconv.FormatUint(u, 10), nil
+		}
+		return json.Number(strconv.FormatUint(u, 10)), nil
+	case yang.Ydecimal64:
+		s, err := numberString(t, v, lenient)
+		if err != nil {
+			return nil, err
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, fmt.Errorf("invalid decimal64 value %q", s)
+		}
+		if err := ytypes.ValidateDecimalRestrictions(t, f); err != nil {
+			return nil, err
+		}
+		return strconv.FormatFloat(f, 'f', t.FractionDigits, 64), nil
+	case yang.Ybool:
+		switch b := v.(type) {
+		case bool:
+			return b, nil
+		case string:
+			if lenient && (b == "true" || b == "false") {
+				return b == "true", nil
+			}
+		}
+		return nil, fmt.Errorf("got %v, want boolean", v)
+	case yang.Yempty:
+		if a, ok := v.([]interface{}); ok && len(a) == 1 && a[0] == nil {
+			return []interface{}{nil}, nil
+		}
+		if b, ok := v.(bool); ok && b && lenient {
+			return []interface{}{nil}, nil
+		}
+		return nil, fmt.Errorf("got %v, want [null] for empty", v)
+	case yang.Yleafref:
+		target, err := util.ResolveIfLeafRef(e)
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve leafref: %v", err)
+		}
+		return scalarValue(target, target.Type, v, lenient)
+	case yang.Yunion:
+		var errs []string
+		for _, mt := range util.FlattenedTypes(t.Type) {
+			cv, err := scalarValue(e, mt, v, lenient)
+			if err == nil {
+				return cv, nil
+			}
+			errs = append(errs, err.Error())
+		}
+		return nil, fmt.Errorf("value %v does not match any member of union: %s", v, strings.Join(errs, "; "))
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("got %v, want string for %v", v, t.Kind)
+	}
+	switch t.Kind {
+	case yang.Ystring:
+		if err := ytypes.ValidateStringRestrictions(t, s); err != nil {
+			return nil, err
+		}
+	case yang.Yenum:
+		if !t.Enum.IsDefined(s) {
+			return nil, fmt.Errorf("%q is not a value of enumeration %s", s, t.Name)
+		}
+	case yang.Ybits:
+		for _, b := range strings.Fields(s) {
+			if !t.Bit.IsDefined(b) {
+				return nil, fmt.Errorf("%q is not a bit of %s", b, t.Name)
+			}
+		}
+		return strings.Join(strings.Fields(s), " "), nil
+	case yang.Yidentityref:
+		return identityValue(t, s)
+	case yang.Ybinary:
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 value %q: %v", s, err)
+		}
+		if err := ytypes.ValidateBinaryRestrictions(t, b); err != nil {
+			return nil, err
+		}
+		return base64.StdEncoding.EncodeToString(b), nil
+	}
+	return s, nil
+}
+
+// numberString returns the string form of the JSON value v of the numeric
+// type t. RFC7951 encodes 64-bit integers and decimal64 values as strings,
+// and other numbers as JSON numbers.
+func numberString(t *yang.YangType, v interface{}, lenient bool) (string, error) {
+	wantString := t.Kind == yang.Yint64 || t.Kind == yang.Yuint64 || t.Kind == yang.Ydecimal64
+	switch n := v.(type) {
+	case json.Number:
+		if !wantString || lenient {
+			return n.String(), nil
+		}
+	case string:
+		if wantString || lenient {
+			return n, nil
+		}
+	}
+	if wantString {
+		return "", fmt.Errorf("got %v, want %v encoded as string", v, t.Kind)
+	}
+	return "", fmt.Errorf("got %v, want %v encoded as number", v, t.Kind)
+}
+
+// bitSize returns the size in bits of the integer kind k.
+func bitSize(k yang.TypeKind) int {
+	switch k {
+	case yang.Yint8, yang.Yuint8:
+		return 8
+	case yang.Yint16, yang.Yuint16:
+		return 16
+	case yang.Yint32, yang.Yuint32:
+		return 32
+	}
+	return 64
+}
+
+// identityValue returns the canonical form of s, which is the name of an
+// identity of the identityref type t, optionally prefixed by the name of
+// its module. Canonical identities are always prefixed.
+func identityValue(t *yang.YangType, s string) (string, error) {
+	mod, name := splitName(s)
+	var find func(ids []*yang.Identity) *yang.Identity
+	find = func(ids []*yang.Identity) *yang.Identity {
+		for _, id := range ids {
+			if id.Name == name && (mod == "" || genutil.ParentModuleName(id) == mod) {
+				return id
+			}
+			if f := find(id.Values); f != nil {
+				return f
+			}
+		}
+		return nil
+	}
+	if t.IdentityBase == nil {
+		return "", fmt.Errorf("identityref %s has no base", t.Name)
+	}
+	id := find(t.IdentityBase.Values)
+	if id == nil {
+		return "", fmt.Errorf("%q is not derived from identity %s", s, t.IdentityBase.Name)
+	}
+	return genutil.ParentModuleName(id) + ":" + id.Name, nil
+}
+
+// valueType returns the type of the canonical value v of the leaf or
+// leaf-list e, resolving leafrefs, and whether it is a member of a union.
+// The member of a union is the first that v is valid for, as per
+// scalarValue.
+func valueType(e *yang.Entry, v interface{}) (*yang.YangType, bool) {
+	t := e.Type
+	if t.Kind == yang.Yleafref {
+		if target, err := util.ResolveIfLeafRef(e); err == nil {
+			t = target.Type
+		}
+	}
+	if t.Kind != yang.Yunion {
+		return t, false
+	}
+	for _, mt := range util.FlattenedTypes(t.Type) {
+		if _, err := scalarValue(e, mt, v, false); err == nil {
+			return mt, true
+		}
+	}
+	return t, true
+}
+
+// keyString returns the form of the canonical value v that is used within
+// the keys of gNMI paths.
+func keyString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	}
+	return fmt.Sprint(v)
+}
+
+// typedValue returns the TypedValue of the canonical value v of the leaf or
+// leaf-list e.
+func typedValue(e *yang.Entry, v interface{}) (*gpb.TypedValue, error) {
+	if !e.IsLeafList() {
+		return scalarTypedValue(e, e.Type, v)
+	}
+	ll := &gpb.ScalarArray{}
+	for _, el := range v.([]interface{}) {
+		tv, err := scalarTypedValue(e, e.Type, el)
+		if err != nil {
+			return nil, err
+		}
+		ll.Element = append(ll.Element, tv)
+	}
+	return &gpb.TypedValue{Value: &gpb.TypedValue_LeaflistVal{LeaflistVal: ll}}, nil
+}
+
+// scalarTypedValue returns the TypedValue of the canonical value v of type
+// t, of the leaf or leaf-list e.
+func scalarTypedValue(e *yang.Entry, t *yang.YangType, v interface{}) (*gpb.TypedValue, error) {
+	switch t.Kind {
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yint64:
+		i, err := strconv.ParseInt(keyString(v), 10, 64)
+		return &gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: i}}, err
+	case yang.Yuint8, yang.Yuint16, yang.Yuint32, yang.Yuint64:
+		u, err := strconv.ParseUint(keyString(v), 10, 64)
+		return &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: u}}, err
+	case yang.Ydecimal64:
+		f, err := strconv.ParseFloat(keyString(v), 64)
+		return &gpb.TypedValue{Value: &gpb.TypedValue_DoubleVal{DoubleVal: f}}, err
+	case yang.Yempty:
+		return &gpb.TypedValue{Value: &gpb.TypedValue_BoolVal{BoolVal: true}}, nil
+	case yang.Ybinary:
+		b, err := base64.StdEncoding.DecodeString(keyString(v))
+		return &gpb.TypedValue{Value: &gpb.TypedValue_BytesVal{BytesVal: b}}, err
+	case yang.Yleafref:
+		target, err := util.ResolveIfLeafRef(e)
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve leafref: %v", err)
+		}
+		return scalarTypedValue(target, target.Type, v)
+	}
+	switch v := v.(type) {
+	case bool:
+		return &gpb.TypedValue{Value: &gpb.TypedValue_BoolVal{BoolVal: v}}, nil
+	case json.Number:
+		// A number that is a member of a union.
+		if i, err := v.Int64(); err == nil {
+			return &gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: i}}, nil
+		}
+		f, err := v.Float64()
+		return &gpb.TypedValue{Value: &gpb.TypedValue_DoubleVal{DoubleVal: f}}, err
+	case []interface{}:
+		// An empty value that is a member of a union.
+		return &gpb.TypedValue{Value: &gpb.TypedValue_BoolVal{BoolVal: true}}, nil
+	}
+	return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: keyString(v)}}, nil
+}
+
+// decodeJSON decodes the JSON document b, retaining the representation of
+// numbers.
+func decodeJSON(b []byte) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// jsonValue returns the TypedValue v as a JSON value, which is validated
+// leniently.
+func jsonValue(v *gpb.TypedValue) (interface{}, error) {
+	switch tv := v.GetValue().(type) {
+	case *gpb.TypedValue_StringVal:
+		return tv.StringVal, nil
+	case *gpb.TypedValue_AsciiVal:
+		return tv.AsciiVal, nil
+	case *gpb.TypedValue_IntVal:
+		return json.Number(strconv.FormatInt(tv.IntVal, 10)), nil
+	case *gpb.TypedValue_UintVal:
+		return json.Number(strconv.FormatUint(tv.UintVal, 10)), nil
+	case *gpb.TypedValue_BoolVal:
+		return tv.BoolVal, nil
+	case *gpb.TypedValue_DoubleVal:
+		return json.Number(strconv.FormatFloat(tv.DoubleVal, 'f', -1, 64)), nil
+	case *gpb.TypedValue_FloatVal:
+		return json.Number(strconv.FormatFloat(float64(tv.FloatVal), 'f', -1, 32)), nil
+	case *gpb.TypedValue_DecimalVal:
+		f := float64(tv.DecimalVal.GetDigits()) / math.Pow10(int(tv.DecimalVal.GetPrecision()))
+		return json.Number(strconv.FormatFloat(f, 'f', int(tv.DecimalVal.GetPrecision()), 64)), nil
+	case *gpb.TypedValue_BytesVal:
+		return base64.StdEncoding.EncodeToString(tv.BytesVal), nil
+	case *gpb.TypedValue_LeaflistVal:
+		var elems []interface{}
+		for _, el := range tv.LeaflistVal.GetElement() {
+			ev, err := jsonValue(el)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, ev)
+		}
+		return elems, nil
+	case *gpb.TypedValue_JsonIetfVal:
+		return decodeJSON(tv.JsonIetfVal)
+	case *gpb.TypedValue_JsonVal:
+		return decodeJSON(tv.JsonVal)
+	}
+	return nil, fmt.Errorf("unsupported TypedValue type %T", v.GetValue())
+}
@@ -0,0 +1,249 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yinstance
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// xmlElement is an element of an XML document.
+type xmlElement struct {
+	// name is the name of the element, whose space is the URI of its
+	// namespace.
+	name xml.Name
+	// prefixes is the set of namespace prefixes that are in scope for the
+	// element, mapped to the URIs of their namespaces.
+	prefixes map[string]string
+	// children is the set of child elements of the element.
+	children []*xmlElement
+	// text is the character data of the element.
+	text string
+}
+
+// decodeXML decodes the XML document b, returning its top-level elements.
+func decodeXML(b []byte) ([]*xmlElement, error) {
+	d := xml.NewDecoder(bytes.NewReader(b))
+	var roots []*xmlElement
+	stack := []*xmlElement{{prefixes: map[string]string{}}}
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		parent := stack[len(stack)-1]
+		switch t := tok.(type) {
+		case xml.StartElement:
+			el := &xmlElement{name: t.Name, prefixes: parent.prefixes}
+			copied := false
+			for _, a := range t.Attr {
+				if a.Name.Space == "xmlns" || a.Name.Space == "" && a.Name.Local == "xmlns" {
+					if !copied {
+						copied = true
+						el.prefixes = map[string]string{}
+						for k, v := range parent.prefixes {
+							el.prefixes[k] = v
+						}
+					}
+					prefix := a.Name.Local
+					if a.Name.Space == "" {
+						// The default namespace has no prefix.
+						prefix = ""
+					}
+					el.prefixes[prefix] = a.Value
+				}
+			}
+			if len(stack) == 1 {
+				roots = append(roots, el)
+			} else {
+				parent.children = append(parent.children, el)
+			}
+			stack = append(stack, el)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 1 {
+				parent.text += string(t)
+			}
+		}
+	}
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("document contains no elements")
+	}
+	return roots, nil
+}
+
+// xmlConverter converts the elements of an XML document to the form of an
+// RFC7951 JSON document.
+type xmlConverter struct {
+	// modules maps the URI of the namespace of each module of the schema
+	// to the name of the module.
+	modules map[string]string
+	// errs is the set of errors found within the document.
+	errs util.Errors
+}
+
+// ParseXML parses and validates the XML document b, which is encoded as
+// per RFC7950 Section 7, returning the Instance that it describes. The top
+// level elements of the document are either data nodes of the schema, or
+// the children of a single element, such as the <data> or <config> element
+// of a NETCONF reply, that is not. All errors that are found within the
+// document are returned together.
+//
+// The document is validated as it is by ParseJSON, except that since XML
+// does not distinguish the types of values, the encodings of values are not
+// enforced.
+func (s *Schema) ParseXML(b []byte) (*Instance, error) {
+	roots, err := decodeXML(b)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode XML: %v", err)
+	}
+
+	c := &xmlConverter{modules: map[string]string{}}
+	for mod, ns := range s.namespaces() {
+		c.modules[ns] = mod
+	}
+	if len(roots) == 1 {
+		if _, ok := children(s.Root)[roots[0].name.Local]; !ok || c.modules[roots[0].name.Space] == "" {
+			roots = roots[0].children
+		}
+	}
+	obj := c.members(s.Root, &gpb.Path{}, roots)
+	if c.errs != nil {
+		return nil, c.errs
+	}
+
+	p := &parser{inst: s.NewInstance(), strict: true, lenient: true}
+	p.members(s.Root, &gpb.Path{}, obj)
+	if p.errs != nil {
+		return nil, p.errs
+	}
+	return p.inst, nil
+}
+
+// members returns the members of the JSON object that represents the
+// elements elems, which are the children of the directory e at path.
+func (c *xmlConverter) members(e *yang.Entry, path *gpb.Path, elems []*xmlElement) map[string]interface{} {
+	chs := children(e)
+	obj := map[string]interface{}{}
+	for _, el := range elems {
+		mod, ok := c.modules[el.name.Space]
+		if !ok {
+			c.errs = util.AppendErr(c.errs, pathErrorf(path, "element %s has unknown namespace %q", el.name.Local, el.name.Space))
+			continue
+		}
+		name := mod + ":" + el.name.Local
+		ch, ok := chs[el.name.Local]
+		switch {
+		case !ok:
+			// The parser reports the unknown member.
+			obj[name] = nil
+		case ch.IsList():
+			entries, _ := obj[name].([]interface{})
+			obj[name] = append(entries, c.members(ch, appendElem(path, ch.Name, nil), el.children))
+		case ch.IsDir():
+			obj[name] = c.members(ch, appendElem(path, ch.Name, nil), el.children)
+		case ch.IsLeafList():
+			vals, _ := obj[name].([]interface{})
+			obj[name] = append(vals, c.value(ch, el))
+		default:
+			obj[name] = c.value(ch, el)
+		}
+	}
+	return obj
+}
+
+// value returns the JSON value of the element el, which is an instance of
+// the leaf or leaf-list e.
+func (c *xmlConverter) value(e *yang.Entry, el *xmlElement) interface{} {
+	kinds := map[yang.TypeKind]bool{}
+	t := e.Type
+	if t.Kind == yang.Yleafref {
+		if target, err := util.ResolveIfLeafRef(e); err == nil {
+			t = target.Type
+		}
+	}
+	for _, mt := range util.FlattenedTypes([]*yang.YangType{t}) {
+		kinds[mt.Kind] = true
+	}
+
+	text := el.text
+	if !kinds[yang.Ystring] {
+		text = strings.TrimSpace(text)
+	}
+	switch {
+	case kinds[yang.Yempty] && text == "":
+		return true
+	case kinds[yang.Yidentityref]:
+		// Identities are qualified by the prefix of their namespace within
+		// the document, rather than by the name of their module.
+		if i := strings.Index(text, ":"); i != -1 {
+			if mod, ok := c.modules[el.prefixes[text[:i]]]; ok {
+				return mod + text[i:]
+			}
+		} else if mod, ok := c.modules[el.prefixes[""]]; ok && el.prefixes[""] != "" {
+			return mod + ":" + text
+		}
+	}
+	return text
+}
+
+// namespaces returns the URIs of the namespaces of the modules of the
+// schema, keyed by the name of the module.
+func (s *Schema) namespaces() map[string]string {
+	nss := map[string]string{}
+	for _, m := range s.Modules {
+		if mod, ok := m.Node.(*yang.Module); ok && mod.Namespace != nil {
+			nss[m.Name] = mod.Namespace.Name
+		}
+	}
+	return nss
+}
+
+// XML returns the XML document, encoded as per RFC7950 Section 7, that
+// contains the instance data. Since the document must have a single root
+// element, the top-level elements are wrapped within a NETCONF <data>
+// element, which is unwrapped by ParseXML. The elements are written by
+// ygot.EmitXMLFromJSON, such that the keys of list entries are written
+// before their other children, and identities are qualified by a prefix
+// that is the name of their module.
+func (i *Instance) XML() ([]byte, error) {
+	root, err := i.tree()
+	if err != nil {
+		return nil, err
+	}
+	body, err := ygot.EmitXMLFromJSON(root, &ygot.EmitXMLConfig{
+		Schema:     i.schema.Root,
+		Namespaces: i.schema.namespaces(),
+		Prefix:     "  ",
+		Indent:     "  ",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf("<data xmlns=%q>\n%s</data>\n", ygot.NETCONFBaseNamespace, body)), nil
+}
@@ -0,0 +1,389 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package yintent implements a framework for compiling high-level,
+// declarative intents into device-level ygot-generated GoStructs.
+//
+// Users register a CompileFunc for each type of intent, which populates a
+// device GoStruct from an intent. A Compiler holds the current set of
+// intents, and the output of compiling each of them, which is merged into a
+// single device GoStruct. When intents are added, changed or removed, only
+// the affected intents are recompiled, and the change to the device GoStruct
+// is returned as a gNMI Notification, as produced by ygot.Diff, that can be
+// pushed to the device.
+//
+// The Compiler tracks the dependencies between intents, where the
+// compilation of an intent looks up another intent through the Context that
+// is supplied to its CompileFunc, such that an intent is recompiled when an
+// intent that it depends on changes. It also tracks the paths that each
+// intent generates, such that the intents responsible for a path of the
+// device GoStruct can be found.
+package yintent
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Intent is a high-level, declarative intent that is compiled into a device
+// GoStruct.
+type Intent interface {
+	// IntentID returns the identifier of the intent, which is unique
+	// amongst the intents held by a Compiler.
+	IntentID() string
+}
+
+// CompileFunc is a function that compiles the intent in into the device
+// GoStruct dev, which is a new GoStruct that is dedicated to the intent.
+// Other intents that the compilation depends on must be looked up through
+// ctx.
+type CompileFunc func(ctx *Context, in Intent, dev ygot.GoStruct) error
+
+// Context is supplied to a CompileFunc, and records the intents that the
+// intent being compiled depends on.
+type Context struct {
+	// intents is the set of intents that can be looked up.
+	intents map[string]Intent
+	// deps is the set of identifiers of the intents that were looked up.
+	deps map[string]bool
+}
+
+// Intent returns the intent with the identifier id, or nil if there is no
+// such intent. The intent being compiled is recorded as depending on id,
+// such that it is recompiled when the intent with the identifier id is set
+// or deleted, including where it does not currently exist.
+func (c *Context) Intent(id string) Intent {
+	c.deps[id] = true
+	return c.intents[id]
+}
+
+// compiled is the output of compiling an intent.
+type compiled struct {
+	// dev is the device GoStruct compiled from the intent.
+	dev ygot.GoStruct
+	// paths is the set of leaf paths that are set within dev, keyed by
+	// their string form.
+	paths map[string]*gpb.Path
+	// deps is the set of identifiers of the intents that the intent
+	// depends on.
+	deps map[string]bool
+}
+
+// Compiler compiles a set of intents into a device GoStruct. It is safe
+// for concurrent use.
+type Compiler struct {
+	// newDevice returns a new, empty device GoStruct.
+	newDevice func() ygot.GoStruct
+	// funcs is the set of registered CompileFuncs, keyed by the type of
+	// intent that they compile.
+	funcs map[reflect.Type]CompileFunc
+
+	// mu protects the fields below.
+	mu sync.Mutex
+	// intents is the current set of intents, keyed by their identifier.
+	intents map[string]Intent
+	// outputs is the output of compiling each intent, keyed by the
+	// identifier of the intent.
+	outputs map[string]*compiled
+	// device is the device GoStruct that is merged from all outputs.
+	device ygot.GoStruct
+}
+
+// New returns a Compiler that compiles intents into device GoStructs that
+// are created by newDevice, which must return a new, empty GoStruct each time
+// it is called, e.g., the root GoStruct of a generated schema.
+func New(newDevice func() ygot.GoStruct) (*Compiler, error) {
+	if newDevice == nil {
+		return nil, fmt.Errorf("newDevice must be specified")
+	}
+	return &Compiler{
+		newDevice: newDevice,
+		funcs:     map[reflect.Type]CompileFunc{},
+		intents:   map[string]Intent{},
+		outputs:   map[string]*compiled{},
+		device:    newDevice(),
+	}, nil
+}
+
+// Register registers fn as the CompileFunc for intents whose type is that of
+// the supplied intent, which is otherwise unused. It returns an error if a
+// CompileFunc is already registered for the type.
+func (c *Compiler) Register(intent Intent, fn CompileFunc) error {
+	if intent == nil || fn == nil {
+		return fmt.Errorf("intent and fn must be specified")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := reflect.TypeOf(intent)
+	if _, ok := c.funcs[t]; ok {
+		return fmt.Errorf("CompileFunc already registered for %v", t)
+	}
+	c.funcs[t] = fn
+	return nil
+}
+
+// Result is the result of changing the intents held by a Compiler.
+type Result struct {
+	// Diff is the change to the device GoStruct, as produced by
+	// ygot.Diff.
+	Diff *gpb.Notification
+	// Recompiled is the sorted set of identifiers of the intents that were
+	// recompiled.
+	Recompiled []string
+}
+
+// Set adds the supplied intents to the Compiler, replacing any intents with
+// the same identifiers. Intents that are added or changed, and the intents
+// that depend on them, are recompiled. If any compilation fails, or the
+// outputs of the intents conflict, an error is returned and the Compiler is
+// unchanged.
+func (c *Compiler) Set(intents ...Intent) (*Result, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	next := make(map[string]Intent, len(c.intents)+len(intents))
+	for id, in := range c.intents {
+		next[id] = in
+	}
+	changed := map[string]bool{}
+	for _, in := range intents {
+		if in == nil {
+			return nil, fmt.Errorf("nil intent")
+		}
+		id := in.IntentID()
+		if _, ok := c.funcs[reflect.TypeOf(in)]; !ok {
+			return nil, fmt.Errorf("no CompileFunc registered for intent %s of type %T", id, in)
+		}
+		if old, ok := next[id]; ok && reflect.DeepEqual(old, in) {
+			continue
+		}
+		next[id] = in
+		changed[id] = true
+	}
+	return c.update(next, changed)
+}
+
+// Delete removes the intents with the supplied identifiers from the
+// Compiler, recompiling the intents that depend on them. Identifiers of
+// intents that do not exist are ignored.
+func (c *Compiler) Delete(ids ...string) (*Result, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	next := make(map[string]Intent, len(c.intents))
+	for id, in := range c.intents {
+		next[id] = in
+	}
+	changed := map[string]bool{}
+	for _, id := range ids {
+		if _, ok := next[id]; ok {
+			delete(next, id)
+			changed[id] = true
+		}
+	}
+	return c.update(next, changed)
+}
+
+// update recompiles the intents within next that are within changed, or
+// that transitively depend on them, and replaces the state of the Compiler
+// with the result. The caller must hold c.mu.
+func (c *Compiler) update(next map[string]Intent, changed map[string]bool) (*Result, error) {
+	stale := c.dependents(changed)
+
+	outputs := make(map[string]*compiled, len(next))
+	var recompiled []string
+	var errs util.Errors
+	for id, in := range next {
+		if !stale[id] {
+			outputs[id] = c.outputs[id]
+			continue
+		}
+		out, err := c.compile(next, in)
+		if err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("cannot compile intent %s: %v", id, err))
+			continue
+		}
+		outputs[id] = out
+		recompiled = append(recompiled, id)
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	sort.Strings(recompiled)
+
+	device, err := c.merge(outputs)
+	if err != nil {
+		return nil, err
+	}
+	diff, err := ygot.Diff(c.device, device)
+	if err != nil {
+		return nil, fmt.Errorf("cannot compute change to device: %v", err)
+	}
+
+	c.intents, c.outputs, c.device = next, outputs, device
+	return &Result{Diff: diff, Recompiled: recompiled}, nil
+}
+
+// dependents returns the identifiers within changed, along with those of
+// the intents that transitively depend on them. The caller must hold c.mu.
+func (c *Compiler) dependents(changed map[string]bool) map[string]bool {
+	stale := map[string]bool{}
+	queue := make([]string, 0, len(changed))
+	for id := range changed {
+		queue = append(queue, id)
+	}
+	for len(queue) != 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if stale[id] {
+			continue
+		}
+		stale[id] = true
+		for dep, out := range c.outputs {
+			if out.deps[id] {
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return stale
+}
+
+// compile compiles the intent in, where intents is the set of intents that
+// it can depend on. The caller must hold c.mu.
+func (c *Compiler) compile(intents map[string]Intent, in Intent) (*compiled, error) {
+	ctx := &Context{intents: intents, deps: map[string]bool{}}
+	dev := c.newDevice()
+	if err := c.funcs[reflect.TypeOf(in)](ctx, in, dev); err != nil {
+		return nil, err
+	}
+	notifs, err := ygot.TogNMINotifications(dev, 0, ygot.GNMINotificationsConfig{UsePathElem: true})
+	if err != nil {
+		return nil, fmt.Errorf("cannot determine generated paths: %v", err)
+	}
+	paths := map[string]*gpb.Path{}
+	for _, n := range notifs {
+		for _, u := range n.GetUpdate() {
+			p, err := util.JoinPaths(n.GetPrefix(), u.GetPath())
+			if err != nil {
+				return nil, err
+			}
+			k, err := ygot.PathToString(p)
+			if err != nil {
+				return nil, err
+			}
+			paths[k] = p
+		}
+	}
+	return &compiled{dev: dev, paths: paths, deps: ctx.deps}, nil
+}
+
+// merge returns the device GoStruct that is merged from outputs, in the
+// order of the identifiers of their intents. It returns an error if the
+// outputs set the same leaf to different values.
+func (c *Compiler) merge(outputs map[string]*compiled) (ygot.GoStruct, error) {
+	ids := make([]string, 0, len(outputs))
+	for id := range outputs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	device := c.newDevice()
+	for _, id := range ids {
+		if err := ygot.MergeStructInto(device, outputs[id].dev); err != nil {
+			return nil, fmt.Errorf("output of intent %s conflicts with other intents: %v", id, err)
+		}
+	}
+	return device, nil
+}
+
+// Device returns a copy of the device GoStruct compiled from the current
+// set of intents.
+func (c *Compiler) Device() (ygot.GoStruct, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return ygot.DeepCopy(c.device)
+}
+
+// Intents returns the sorted identifiers of the current set of intents.
+func (c *Compiler) Intents() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ids := make([]string, 0, len(c.intents))
+	for id := range c.intents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Paths returns the leaf paths generated by the intent with the identifier
+// id, sorted by their string form, or nil if there is no such intent.
+func (c *Compiler) Paths(id string) []*gpb.Path {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out, ok := c.outputs[id]
+	if !ok {
+		return nil
+	}
+	keys := make([]string, 0, len(out.paths))
+	for k := range out.paths {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	paths := make([]*gpb.Path, 0, len(keys))
+	for _, k := range keys {
+		paths = append(paths, out.paths[k])
+	}
+	return paths
+}
+
+// Owners returns the sorted identifiers of the intents that generate a leaf
+// path that matches path, as per util.PathMatchesQuery, such that the path
+// may be that of a leaf or of a subtree, and may contain wildcards.
+func (c *Compiler) Owners(path *gpb.Path) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var ids []string
+	for id, out := range c.outputs {
+		for _, p := range out.paths {
+			if util.PathMatchesQuery(p, path) {
+				ids = append(ids, id)
+				break
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// DependsOn returns the sorted identifiers of the intents that the intent
+// with the identifier id depended on when it was last compiled.
+func (c *Compiler) DependsOn(id string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out, ok := c.outputs[id]
+	if !ok {
+		return nil
+	}
+	ids := make([]string, 0, len(out.deps))
+	for dep := range out.deps {
+		ids = append(ids, dep)
+	}
+	sort.Strings(ids)
+	return ids
+}
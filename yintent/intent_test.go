@@ -0,0 +1,196 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yintent
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// linkIntent is an intent for an interface with an MTU.
+type linkIntent struct {
+	Name string
+	MTU  uint16
+}
+
+func (l *linkIntent) IntentID() string { return "link-" + l.Name }
+
+// labelIntent is an intent for the description of an interface, which
+// depends on the linkIntent of the interface.
+type labelIntent struct {
+	Name  string
+	Label string
+}
+
+func (l *labelIntent) IntentID() string { return "label-" + l.Name }
+
+func newTestCompiler(t *testing.T) *Compiler {
+	t.Helper()
+	c, err := New(func() ygot.GoStruct { return &exampleoc.Device{} })
+	if err != nil {
+		t.Fatalf("New: got unexpected error: %v", err)
+	}
+	if err := c.Register(&linkIntent{}, func(_ *Context, in Intent, dev ygot.GoStruct) error {
+		l := in.(*linkIntent)
+		if l.MTU == 0 {
+			return fmt.Errorf("MTU must be specified")
+		}
+		dev.(*exampleoc.Device).GetOrCreateInterface(l.Name).Mtu = ygot.Uint16(l.MTU)
+		return nil
+	}); err != nil {
+		t.Fatalf("Register: got unexpected error: %v", err)
+	}
+	if err := c.Register(&labelIntent{}, func(ctx *Context, in Intent, dev ygot.GoStruct) error {
+		l := in.(*labelIntent)
+		desc := l.Label
+		if link, ok := ctx.Intent("link-" + l.Name).(*linkIntent); ok {
+			desc = fmt.Sprintf("%s (mtu %d)", l.Label, link.MTU)
+		}
+		dev.(*exampleoc.Device).GetOrCreateInterface(l.Name).Description = ygot.String(desc)
+		return nil
+	}); err != nil {
+		t.Fatalf("Register: got unexpected error: %v", err)
+	}
+	return c
+}
+
+func mustPath(t *testing.T, s string) *gpb.Path {
+	t.Helper()
+	p, err := ygot.StringToStructuredPath(s)
+	if err != nil {
+		t.Fatalf("cannot parse path %s: %v", s, err)
+	}
+	return p
+}
+
+func TestCompiler(t *testing.T) {
+	c := newTestCompiler(t)
+
+	res, err := c.Set(&linkIntent{Name: "eth0", MTU: 1500}, &labelIntent{Name: "eth0", Label: "uplink"})
+	if err != nil {
+		t.Fatalf("Set: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"label-eth0", "link-eth0"}, res.Recompiled); diff != "" {
+		t.Errorf("Set: did not recompile expected intents (-want, +got):\n%s", diff)
+	}
+	if got, want := len(res.Diff.GetUpdate()), 4; got != want {
+		t.Errorf("Set: got %d updates, want %d: %v", got, want, res.Diff)
+	}
+	dev, err := c.Device()
+	if err != nil {
+		t.Fatalf("Device: got unexpected error: %v", err)
+	}
+	if got, want := dev.(*exampleoc.Device).GetInterface("eth0").GetDescription(), "uplink (mtu 1500)"; got != want {
+		t.Errorf("Device: got description %q, want %q", got, want)
+	}
+	if diff := cmp.Diff([]string{"link-eth0"}, c.DependsOn("label-eth0")); diff != "" {
+		t.Errorf("DependsOn: did not get expected dependencies (-want, +got):\n%s", diff)
+	}
+
+	// Setting an unchanged intent recompiles nothing.
+	res, err = c.Set(&linkIntent{Name: "eth0", MTU: 1500})
+	if err != nil {
+		t.Fatalf("Set: got unexpected error: %v", err)
+	}
+	if len(res.Recompiled) != 0 || len(res.Diff.GetUpdate())+len(res.Diff.GetDelete()) != 0 {
+		t.Errorf("Set with unchanged intent: got %v, want no recompilation or changes", res)
+	}
+
+	// Changing an intent recompiles its dependents.
+	res, err = c.Set(&linkIntent{Name: "eth0", MTU: 9000})
+	if err != nil {
+		t.Fatalf("Set: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"label-eth0", "link-eth0"}, res.Recompiled); diff != "" {
+		t.Errorf("Set: did not recompile expected intents (-want, +got):\n%s", diff)
+	}
+	if got, want := len(res.Diff.GetUpdate()), 2; got != want {
+		t.Errorf("Set: got %d updates, want %d: %v", got, want, res.Diff)
+	}
+
+	if diff := cmp.Diff([]string{"label-eth0", "link-eth0"}, c.Owners(mustPath(t, "/interfaces/interface[name=eth0]"))); diff != "" {
+		t.Errorf("Owners: did not get expected owners (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"link-eth0"}, c.Owners(mustPath(t, "/interfaces/interface[name=*]/config/mtu"))); diff != "" {
+		t.Errorf("Owners: did not get expected owners (-want, +got):\n%s", diff)
+	}
+	if got := len(c.Paths("link-eth0")); got != 3 {
+		t.Errorf("Paths: got %d paths, want 3", got)
+	}
+
+	// Deleting an intent removes its paths and recompiles its dependents.
+	res, err = c.Delete("link-eth0")
+	if err != nil {
+		t.Fatalf("Delete: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"label-eth0"}, res.Recompiled); diff != "" {
+		t.Errorf("Delete: did not recompile expected intents (-want, +got):\n%s", diff)
+	}
+	if got, want := len(res.Diff.GetDelete()), 1; got != want {
+		t.Errorf("Delete: got %d deletes, want %d: %v", got, want, res.Diff)
+	}
+	if diff := cmp.Diff([]string{"label-eth0"}, c.Intents()); diff != "" {
+		t.Errorf("Intents: did not get expected intents (-want, +got):\n%s", diff)
+	}
+}
+
+func TestCompilerErrors(t *testing.T) {
+	c := newTestCompiler(t)
+	if _, err := c.Set(&linkIntent{Name: "eth0", MTU: 1500}); err != nil {
+		t.Fatalf("Set: got unexpected error: %v", err)
+	}
+
+	if err := c.Register(&linkIntent{}, func(*Context, Intent, ygot.GoStruct) error { return nil }); err == nil {
+		t.Errorf("Register with duplicate type: got nil error, want error")
+	}
+	if _, err := c.Set(&linkIntent{Name: "eth1"}); err == nil {
+		t.Errorf("Set with failing compilation: got nil error, want error")
+	}
+
+	// The intents conflict since they set the MTU differently.
+	if err := c.Register(&conflictIntent{}, func(_ *Context, in Intent, dev ygot.GoStruct) error {
+		dev.(*exampleoc.Device).GetOrCreateInterface("eth0").Mtu = ygot.Uint16(1400)
+		return nil
+	}); err != nil {
+		t.Fatalf("Register: got unexpected error: %v", err)
+	}
+	if _, err := c.Set(&conflictIntent{}); err == nil {
+		t.Errorf("Set with conflicting intents: got nil error, want error")
+	}
+
+	// Failed changes leave the Compiler unchanged.
+	if diff := cmp.Diff([]string{"link-eth0"}, c.Intents()); diff != "" {
+		t.Errorf("Intents after failed Set (-want, +got):\n%s", diff)
+	}
+	dev, err := c.Device()
+	if err != nil {
+		t.Fatalf("Device: got unexpected error: %v", err)
+	}
+	if got := dev.(*exampleoc.Device).GetInterface("eth0").GetMtu(); got != 1500 {
+		t.Errorf("Device after failed Set: got MTU %d, want 1500", got)
+	}
+}
+
+// conflictIntent is an intent whose output conflicts with that of a
+// linkIntent.
+type conflictIntent struct{}
+
+func (*conflictIntent) IntentID() string { return "conflict" }
@@ -0,0 +1,441 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ymigrate migrates YANG instance data between two versions of a
+// schema, such as persisted configuration when the OpenConfig models that it
+// is described by are updated. Each leaf of the instance data is carried
+// over to the node at the same path within the new schema, or to the node
+// that it is moved to by a Rule. Leaves that cannot be carried over, since
+// they no longer exist or their values are no longer valid, are dropped,
+// whilst leaves that are new within the new schema are set to their default
+// values; both are recorded within a Report.
+package ymigrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/yinstance"
+	"github.com/openconfig/ygot/ytypes"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Rule moves the data node at the schema path From within the old schema,
+// along with its descendants, to the schema path To within the new schema.
+// Schema paths do not contain keys, for example
+// "/interfaces/interface/config/description". A rule that moves a node
+// within its parent renames it. The keys of the list entries along From are
+// carried over to the list entries along To, hence both must contain the
+// same number of lists.
+type Rule struct {
+	From, To string
+}
+
+// rule is a Rule whose schema paths have been split into their elements.
+type rule struct {
+	from, to []string
+}
+
+// Dropped is a leaf or leaf-list of the instance data that was not carried
+// over to the new schema.
+type Dropped struct {
+	// Path is the path of the leaf within the old schema.
+	Path string
+	// Reason describes why the leaf was dropped.
+	Reason string
+}
+
+// Report describes the changes that were made to instance data other than
+// moving it from the old schema to the new schema.
+type Report struct {
+	// Dropped are the leaves of the instance data that do not exist
+	// within the new schema, or whose values are not valid within it,
+	// sorted by path.
+	Dropped []*Dropped
+	// Defaulted are the paths of the leaves that do not exist within the
+	// old schema and were set to their default values, sorted. Defaults
+	// are set only within containers and list entries that hold data.
+	Defaulted []string
+}
+
+// Migrator migrates instance data from one schema to another.
+type Migrator struct {
+	// from and to are the old and new schemas.
+	from, to *yinstance.Schema
+	// rules are the rules that move nodes from the old schema to the new
+	// schema.
+	rules []*rule
+}
+
+// New returns a Migrator of instance data from the schema from to the
+// schema to, which moves nodes according to rules.
+func New(from, to *yinstance.Schema, rules ...Rule) (*Migrator, error) {
+	m := &Migrator{from: from, to: to}
+	var errs util.Errors
+	for _, r := range rules {
+		mr := &rule{from: splitPath(r.From), to: splitPath(r.To)}
+		fe, te := find(from.Root, mr.from), find(to.Root, mr.to)
+		switch {
+		case fe == nil:
+			errs = util.AppendErr(errs, fmt.Errorf("rule %s -> %s: %s does not exist in the old schema", r.From, r.To, r.From))
+		case te == nil:
+			errs = util.AppendErr(errs, fmt.Errorf("rule %s -> %s: %s does not exist in the new schema", r.From, r.To, r.To))
+		case kind(fe) != kind(te):
+			errs = util.AppendErr(errs, fmt.Errorf("rule %s -> %s: cannot move a %s to a %s", r.From, r.To, kind(fe), kind(te)))
+		case lists(from.Root, mr.from) != lists(to.Root, mr.to):
+			errs = util.AppendErr(errs, fmt.Errorf("rule %s -> %s: paths contain different numbers of lists", r.From, r.To))
+		default:
+			m.rules = append(m.rules, mr)
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return m, nil
+}
+
+// MigrateJSON migrates the RFC7951 JSON instance data b of the old schema,
+// returning the RFC7951 JSON instance data of the new schema.
+func (m *Migrator) MigrateJSON(b []byte) ([]byte, *Report, error) {
+	in, err := m.from.ParseJSON(b)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid instance data of the old schema: %v", err)
+	}
+	out, r, err := m.Migrate(in)
+	if err != nil {
+		return nil, nil, err
+	}
+	nb, err := out.JSON()
+	if err != nil {
+		return nil, nil, err
+	}
+	return nb, r, nil
+}
+
+// MigrateGoStruct migrates the data tree s, which is a GoStruct that was
+// generated for the old schema, returning a data tree of the generated
+// package whose schema is to, which must have been generated for the new
+// schema. The data trees are converted via RFC7951 JSON.
+func (m *Migrator) MigrateGoStruct(s ygot.GoStruct, to *ytypes.Schema) (ygot.GoStruct, *Report, error) {
+	if to == nil || !to.IsValid() {
+		return nil, nil, fmt.Errorf("invalid schema %v", to)
+	}
+	j, err := ygot.ConstructIETFJSON(s, &ygot.RFC7951JSONConfig{AppendModuleName: true})
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot render data tree: %v", err)
+	}
+	b, err := json.Marshal(j)
+	if err != nil {
+		return nil, nil, err
+	}
+	nb, r, err := m.MigrateJSON(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	root := to.NewRoot()
+	if err := to.Unmarshal(nb, root); err != nil {
+		return nil, nil, fmt.Errorf("cannot unmarshal migrated data tree: %v", err)
+	}
+	return root, r, nil
+}
+
+// Migrate migrates the instance data in, of the old schema, returning the
+// instance data of the new schema.
+func (m *Migrator) Migrate(in *yinstance.Instance) (*yinstance.Instance, *Report, error) {
+	out := m.to.NewInstance()
+	r := &Report{}
+	for _, l := range in.Leaves() {
+		np := m.movePath(l.Path)
+		if find(m.to.Root, names(np.GetElem())) == nil {
+			r.Dropped = append(r.Dropped, &Dropped{
+				Path:   pathString(l.Path),
+				Reason: fmt.Sprintf("%s does not exist in the new schema", schemaPath(names(np.GetElem()))),
+			})
+			continue
+		}
+		if err := out.Set(np, l.Value); err != nil {
+			r.Dropped = append(r.Dropped, &Dropped{Path: pathString(l.Path), Reason: err.Error()})
+		}
+	}
+	if err := m.setDefaults(out, r); err != nil {
+		return nil, nil, err
+	}
+	return out, r, nil
+}
+
+// setDefaults sets the leaves of the containers and list entries of out that
+// hold data, and which do not exist within the old schema, to their default
+// values, recording them within r.
+func (m *Migrator) setDefaults(out *yinstance.Instance, r *Report) error {
+	set := map[string]bool{}
+	dirs := map[string]*gpb.Path{}
+	for _, l := range out.Leaves() {
+		set[pathString(l.Path)] = true
+		for i := 1; i < len(l.Path.GetElem()); i++ {
+			dp := &gpb.Path{Elem: l.Path.GetElem()[:i]}
+			dirs[pathString(dp)] = dp
+		}
+	}
+	var keys []string
+	for k := range dirs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		dp := dirs[k]
+		e := find(m.to.Root, names(dp.GetElem()))
+		var chs []string
+		for n := range e.Dir {
+			chs = append(chs, n)
+		}
+		sort.Strings(chs)
+		for _, n := range chs {
+			// Choices are not descended into, since the default of a
+			// leaf within a case applies only if its case is selected.
+			ch := e.Dir[n]
+			defs := ch.DefaultValues()
+			if (!ch.IsLeaf() && !ch.IsLeafList()) || len(defs) == 0 {
+				continue
+			}
+			lp := &gpb.Path{Elem: append(append([]*gpb.PathElem{}, dp.GetElem()...), &gpb.PathElem{Name: ch.Name})}
+			if set[pathString(lp)] || find(m.from.Root, m.unmove(names(lp.GetElem()))) != nil {
+				continue
+			}
+			if err := setDefault(out, lp, ch, defs); err != nil {
+				return fmt.Errorf("cannot set default of %s: %v", pathString(lp), err)
+			}
+			r.Defaulted = append(r.Defaulted, pathString(lp))
+		}
+	}
+	sort.Strings(r.Defaulted)
+	return nil
+}
+
+// setDefault sets the leaf or leaf-list e at path within out to its default
+// values defs. Identities within defaults are qualified by the prefix of
+// their module, rather than its name, hence are unqualified if they are
+// not otherwise valid.
+func setDefault(out *yinstance.Instance, path *gpb.Path, e *yang.Entry, defs []string) error {
+	value := func(unqualify bool) interface{} {
+		var vals []interface{}
+		for _, d := range defs {
+			if i := strings.Index(d, ":"); unqualify && i != -1 {
+				d = d[i+1:]
+			}
+			vals = append(vals, d)
+		}
+		if e.IsLeafList() {
+			return vals
+		}
+		return vals[0]
+	}
+	err := out.Set(path, value(false))
+	if err != nil && out.Set(path, value(true)) == nil {
+		return nil
+	}
+	return err
+}
+
+// movePath returns the path within the new schema of the node at path p
+// within the old schema.
+func (m *Migrator) movePath(p *gpb.Path) *gpb.Path {
+	elems := p.GetElem()
+	ns := names(elems)
+	np := &gpb.Path{}
+	r := m.match(ns, false)
+	if r == nil {
+		for i, pe := range elems {
+			np.Elem = append(np.Elem, &gpb.PathElem{Name: pe.GetName(), Key: m.moveKeys(ns[:i+1], pe.GetKey())})
+		}
+		return np
+	}
+	var keys []map[string]string
+	for i, pe := range elems[:len(r.from)] {
+		if len(pe.GetKey()) != 0 {
+			keys = append(keys, m.moveKeys(ns[:i+1], pe.GetKey()))
+		}
+	}
+	for i, n := range r.to {
+		pe := &gpb.PathElem{Name: n}
+		if e := find(m.to.Root, r.to[:i+1]); e != nil && e.IsList() && len(keys) != 0 {
+			pe.Key, keys = keys[0], keys[1:]
+		}
+		np.Elem = append(np.Elem, pe)
+	}
+	for i, pe := range elems[len(r.from):] {
+		np.Elem = append(np.Elem, &gpb.PathElem{Name: pe.GetName(), Key: m.moveKeys(ns[:len(r.from)+i+1], pe.GetKey())})
+	}
+	return np
+}
+
+// moveKeys returns the keys of the entry of the list at the schema path
+// list within the old schema, with each key renamed if its key leaf is
+// renamed by a rule.
+func (m *Migrator) moveKeys(list []string, keys map[string]string) map[string]string {
+	if len(keys) == 0 {
+		return nil
+	}
+	nl := m.move(list)
+	nk := map[string]string{}
+	for k, v := range keys {
+		kp := m.move(append(append([]string{}, list...), k))
+		if len(kp) == len(nl)+1 && equal(kp[:len(nl)], nl) {
+			k = kp[len(nl)]
+		}
+		nk[k] = v
+	}
+	return nk
+}
+
+// move returns the schema path within the new schema of the node at the
+// schema path p within the old schema.
+func (m *Migrator) move(p []string) []string {
+	r := m.match(p, false)
+	if r == nil {
+		return p
+	}
+	return append(append([]string{}, r.to...), p[len(r.from):]...)
+}
+
+// unmove returns the schema path within the old schema of the node at the
+// schema path p within the new schema.
+func (m *Migrator) unmove(p []string) []string {
+	r := m.match(p, true)
+	if r == nil {
+		return p
+	}
+	return append(append([]string{}, r.from...), p[len(r.to):]...)
+}
+
+// match returns the rule whose From path, or To path if reverse is set, is
+// the longest prefix of the schema path p, or nil if there is no such rule.
+func (m *Migrator) match(p []string, reverse bool) *rule {
+	var best *rule
+	bestLen := -1
+	for _, r := range m.rules {
+		rp := r.from
+		if reverse {
+			rp = r.to
+		}
+		if len(rp) <= len(p) && len(rp) > bestLen && equal(p[:len(rp)], rp) {
+			best, bestLen = r, len(rp)
+		}
+	}
+	return best
+}
+
+// splitPath returns the elements of the schema path p, without module
+// prefixes.
+func splitPath(p string) []string {
+	var elems []string
+	for _, e := range strings.Split(strings.Trim(p, "/"), "/") {
+		if e != "" {
+			elems = append(elems, util.StripModulePrefix(e))
+		}
+	}
+	return elems
+}
+
+// schemaPath returns the string form of the schema path p.
+func schemaPath(p []string) string {
+	return "/" + strings.Join(p, "/")
+}
+
+// names returns the names of elems, without module prefixes.
+func names(elems []*gpb.PathElem) []string {
+	var ns []string
+	for _, pe := range elems {
+		ns = append(ns, util.StripModulePrefix(pe.GetName()))
+	}
+	return ns
+}
+
+// find returns the data node at the schema path p beneath the directory
+// root, or nil if there is no such node. Choice and case nodes are
+// transparent.
+func find(root *yang.Entry, p []string) *yang.Entry {
+	e := root
+	for _, n := range p {
+		if !e.IsDir() {
+			return nil
+		}
+		var next *yang.Entry
+		for _, ch := range util.FindFirstNonChoiceOrCase(e) {
+			if ch.Name == n {
+				next = ch
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		e = next
+	}
+	return e
+}
+
+// lists returns the number of lists along the schema path p beneath the
+// directory root, each of which must exist.
+func lists(root *yang.Entry, p []string) int {
+	n := 0
+	for i := range p {
+		if find(root, p[:i+1]).IsList() {
+			n++
+		}
+	}
+	return n
+}
+
+// kind returns the kind of the data node e, as it is named in YANG.
+func kind(e *yang.Entry) string {
+	switch {
+	case e.IsList():
+		return "list"
+	case e.IsLeafList():
+		return "leaf-list"
+	case e.IsLeaf():
+		return "leaf"
+	}
+	return "container"
+}
+
+// equal returns whether the schema paths a and b are equal.
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// pathString returns the string form of path, or its text representation if
+// it cannot be converted.
+func pathString(path *gpb.Path) string {
+	s, err := ygot.PathToString(path)
+	if err != nil {
+		return path.String()
+	}
+	return s
+}
@@ -0,0 +1,241 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ymigrate
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/yinstance"
+	"github.com/openconfig/ygot/ytypes"
+)
+
+// testRules are the rules that migrate instance data of example-v1.yang to
+// example-v2.yang.
+var testRules = []Rule{
+	{From: "/system/domain-name", To: "/system/dns/search"},
+	{From: "/interfaces/interface/name", To: "/interfaces/interface/id"},
+	{From: "/example:interfaces/interface/description", To: "/example:interfaces/interface/alias"},
+}
+
+const testInstance = `{
+  "example:system": {
+    "hostname": "dev1",
+    "domain-name": "example.com",
+    "motd": "hello"
+  },
+  "example:interfaces": {
+    "interface": [
+      {"name": "eth0", "mtu": 1500, "description": "uplink"},
+      {"name": "eth1", "mtu": 65000}
+    ]
+  }
+}`
+
+// diffJSON returns the differences between the JSON documents want and got.
+func diffJSON(t *testing.T, want string, got []byte) string {
+	t.Helper()
+	var wantV, gotV any
+	if err := json.Unmarshal([]byte(want), &wantV); err != nil {
+		t.Fatalf("cannot decode %s: %v", want, err)
+	}
+	if err := json.Unmarshal(got, &gotV); err != nil {
+		t.Fatalf("cannot decode %s: %v", got, err)
+	}
+	return cmp.Diff(wantV, gotV)
+}
+
+func loadTestSchemas(t *testing.T) (*yinstance.Schema, *yinstance.Schema) {
+	t.Helper()
+	from, err := yinstance.LoadSchema([]string{"testdata/example-v1.yang"}, nil)
+	if err != nil {
+		t.Fatalf("cannot load old schema: %v", err)
+	}
+	to, err := yinstance.LoadSchema([]string{"testdata/example-v2.yang"}, nil)
+	if err != nil {
+		t.Fatalf("cannot load new schema: %v", err)
+	}
+	return from, to
+}
+
+func newTestMigrator(t *testing.T) *Migrator {
+	t.Helper()
+	from, to := loadTestSchemas(t)
+	m, err := New(from, to, testRules...)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return m
+}
+
+func TestMigrateJSON(t *testing.T) {
+	m := newTestMigrator(t)
+	got, r, err := m.MigrateJSON([]byte(testInstance))
+	if err != nil {
+		t.Fatalf("MigrateJSON: %v", err)
+	}
+
+	want := `{
+  "example:system": {
+    "hostname": "dev1",
+    "timezone": "UTC",
+    "dns": {"search": "example.com"}
+  },
+  "example:interfaces": {
+    "interface": [
+      {"id": "eth0", "mtu": 1500, "alias": "uplink", "enabled": true},
+      {"id": "eth1", "enabled": true}
+    ]
+  }
+}`
+	if diff := diffJSON(t, want, got); diff != "" {
+		t.Errorf("MigrateJSON (-want, +got):\n%s", diff)
+	}
+
+	var dropped []string
+	for _, d := range r.Dropped {
+		dropped = append(dropped, d.Path)
+	}
+	if diff := cmp.Diff([]string{"/interfaces/interface[name=eth1]/mtu", "/system/motd"}, dropped); diff != "" {
+		t.Errorf("dropped (-want, +got):\n%s", diff)
+	}
+	if got := r.Dropped[1].Reason; got != "/system/motd does not exist in the new schema" {
+		t.Errorf("reason for dropping /system/motd: got %q", got)
+	}
+	wantDefaulted := []string{
+		"/interfaces/interface[id=eth0]/enabled",
+		"/interfaces/interface[id=eth1]/enabled",
+		"/system/timezone",
+	}
+	if diff := cmp.Diff(wantDefaulted, r.Defaulted); diff != "" {
+		t.Errorf("defaulted (-want, +got):\n%s", diff)
+	}
+}
+
+func TestMigrateUnchanged(t *testing.T) {
+	_, to := loadTestSchemas(t)
+	m, err := New(to, to)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	in := `{"example:system": {"hostname": "dev1", "timezone": "CET"}}`
+	got, r, err := m.MigrateJSON([]byte(in))
+	if err != nil {
+		t.Fatalf("MigrateJSON: %v", err)
+	}
+	if diff := diffJSON(t, in, got); diff != "" {
+		t.Errorf("MigrateJSON (-want, +got):\n%s", diff)
+	}
+	if len(r.Dropped) != 0 || len(r.Defaulted) != 0 {
+		t.Errorf("got report %+v, want empty", r)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	from, to := loadTestSchemas(t)
+	tests := []struct {
+		desc    string
+		rule    Rule
+		wantErr string
+	}{{
+		desc:    "missing from",
+		rule:    Rule{From: "/system/fish", To: "/system/hostname"},
+		wantErr: "/system/fish does not exist in the old schema",
+	}, {
+		desc:    "missing to",
+		rule:    Rule{From: "/system/motd", To: "/system/fish"},
+		wantErr: "/system/fish does not exist in the new schema",
+	}, {
+		desc:    "different kinds",
+		rule:    Rule{From: "/system/motd", To: "/system/dns"},
+		wantErr: "cannot move a leaf to a container",
+	}, {
+		desc:    "different numbers of lists",
+		rule:    Rule{From: "/interfaces/interface/description", To: "/system/hostname"},
+		wantErr: "different numbers of lists",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if _, err := New(from, to, tt.rule); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("New: got error %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// v1Device and v1Interface are GoStructs of example-v1.yang.
+type v1Device struct {
+	Interface map[string]*v1Interface `path:"interfaces/interface" module:"example/example"`
+}
+
+func (*v1Device) IsYANGGoStruct() {}
+
+type v1Interface struct {
+	Name        *string `path:"name" module:"example"`
+	Description *string `path:"description" module:"example"`
+}
+
+func (*v1Interface) IsYANGGoStruct() {}
+
+// v2Device and v2Interface are GoStructs of example-v2.yang.
+type v2Device struct {
+	Interface map[string]*v2Interface `path:"interfaces/interface" module:"example/example"`
+}
+
+func (*v2Device) IsYANGGoStruct() {}
+
+type v2Interface struct {
+	Id      *string `path:"id" module:"example"`
+	Alias   *string `path:"alias" module:"example"`
+	Enabled *bool   `path:"enabled" module:"example"`
+}
+
+func (*v2Interface) IsYANGGoStruct() {}
+
+func TestMigrateGoStruct(t *testing.T) {
+	m := newTestMigrator(t)
+	to := &ytypes.Schema{
+		Root:       &v2Device{},
+		SchemaTree: map[string]*yang.Entry{"v2Device": m.to.Root},
+		Unmarshal: func(b []byte, root ygot.GoStruct, opts ...ytypes.UnmarshalOpt) error {
+			var j any
+			if err := json.Unmarshal(b, &j); err != nil {
+				return err
+			}
+			return ytypes.Unmarshal(m.to.Root, root, j, opts...)
+		},
+	}
+	in := &v1Device{Interface: map[string]*v1Interface{
+		"eth0": {Name: ygot.String("eth0"), Description: ygot.String("uplink")},
+	}}
+
+	got, r, err := m.MigrateGoStruct(in, to)
+	if err != nil {
+		t.Fatalf("MigrateGoStruct: %v", err)
+	}
+	want := &v2Device{Interface: map[string]*v2Interface{
+		"eth0": {Id: ygot.String("eth0"), Alias: ygot.String("uplink"), Enabled: ygot.Bool(true)},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("MigrateGoStruct (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"/interfaces/interface[id=eth0]/enabled"}, r.Defaulted); diff != "" {
+		t.Errorf("defaulted (-want, +got):\n%s", diff)
+	}
+}
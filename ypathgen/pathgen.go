@@ -144,6 +144,16 @@ type GenConfig struct {
 	// only applies when useDefiningModuleForTypedefEnumNames is also set
 	// to true.
 	AppendEnumSuffixForSimpleUnionEnums bool
+	// FlattenEnumerationUnions specifies that unions whose members are all
+	// enumerations are represented by a single merged enumerated type.
+	// This is the same flag used by ygen: they must match for pathgen's
+	// generated code to be compatible with it.
+	FlattenEnumerationUnions bool
+	// TypedefUnionNames specifies that the types representing unions that
+	// are defined by a typedef are named after the typedef.
+	// This is the same flag used by ygen: they must match for pathgen's
+	// generated code to be compatible with it.
+	TypedefUnionNames bool
 	// IgnoreUnsupportedStatements ignores unsupported YANG statements when
 	// parsing, such that they do not show up errors during IR generation.
 	IgnoreUnsupportedStatements bool
@@ -152,6 +162,16 @@ type GenConfig struct {
 	// code generation. This is due to the fact that some schemas (e.g., OpenConfig
 	// interfaces) currently result in overlapping entities (e.g., /interfaces).
 	ExcludeModules []string
+	// ExcludePaths specifies patterns of the schema paths of data nodes
+	// that are excluded from code generation, as per
+	// ygen.ParseOpts.ExcludePaths.
+	ExcludePaths []string
+	// Features specifies the features that are supported, as per
+	// ygen.ParseOpts.Features.
+	Features []string
+	// ExcludeFeatures specifies the features that are not supported, as
+	// per ygen.ParseOpts.ExcludeFeatures.
+	ExcludeFeatures []string
 	// YANGParseOptions provides the options that should be handed to the
 	// github.com/openconfig/goyang/pkg/yang library. These specify how the
 	// input YANG files should be parsed.
@@ -193,6 +213,9 @@ type GenConfig struct {
 	BaseImportPath string
 	// PackageString is the string to apppend to the generated Go package names.
 	PackageSuffix string
+	// PhaseTimer, if non-nil, records the time spent in each phase of
+	// path struct generation.
+	PhaseTimer *genutil.PhaseTimer
 }
 
 // GoImports contains package import options.
@@ -256,6 +279,9 @@ func (cg *GenConfig) GeneratePathCode(yangFiles, includePaths []string) (map[str
 			IgnoreUnsupportedStatements: cg.IgnoreUnsupportedStatements,
 			YANGParseOptions:            cg.YANGParseOptions,
 			ExcludeModules:              cg.ExcludeModules,
+			ExcludePaths:                cg.ExcludePaths,
+			Features:                    cg.Features,
+			ExcludeFeatures:             cg.ExcludeFeatures,
 		},
 		TransformationOptions: ygen.TransformationOpts{
 			CompressBehaviour:                    compressBehaviour,
@@ -266,10 +292,13 @@ func (cg *GenConfig) GeneratePathCode(yangFiles, includePaths []string) (map[str
 			EnumOrgPrefixesToTrim:                cg.EnumOrgPrefixesToTrim,
 			UseDefiningModuleForTypedefEnumNames: cg.UseDefiningModuleForTypedefEnumNames,
 			EnumerationsUseUnderscores:           true,
+			FlattenEnumerationUnions:             cg.FlattenEnumerationUnions,
+			TypedefUnionNames:                    cg.TypedefUnionNames,
 		},
 		NestedDirectories:                   false,
 		AbsoluteMapPaths:                    false,
 		AppendEnumSuffixForSimpleUnionEnums: cg.AppendEnumSuffixForSimpleUnionEnums,
+		PhaseTimer:                          cg.PhaseTimer,
 	}
 
 	var errs util.Errors
@@ -277,6 +306,7 @@ func (cg *GenConfig) GeneratePathCode(yangFiles, includePaths []string) (map[str
 	if err != nil {
 		return nil, nil, util.AppendErr(errs, err)
 	}
+	defer cg.PhaseTimer.Start(genutil.PhaseCodegen)()
 
 	var schemaStructPkgAccessor string
 	if cg.GoImports.SchemaStructPkgPath != "" {
@@ -0,0 +1,193 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package yreplay records gNMI traffic into portable fixtures, and replays
+// them from a fake gNMI target that is backed by a ygot-generated GoStruct.
+// A Recorder wraps a gNMI client, such that the Capabilities, Get, Set and
+// Subscribe RPCs made with it, and their responses and timing, are recorded
+// within a Fixture. A Fixture can be stored as JSON alongside tests, and
+// loaded into a Target, which implements the gNMI service, such that
+// integration tests can be run offline against the captured behaviour of a
+// device.
+package yreplay
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// RPC is the name of a gNMI RPC.
+type RPC string
+
+const (
+	// Capabilities is the gNMI Capabilities RPC.
+	Capabilities RPC = "Capabilities"
+	// Get is the gNMI Get RPC.
+	Get RPC = "Get"
+	// Set is the gNMI Set RPC.
+	Set RPC = "Set"
+	// Subscribe is the gNMI Subscribe RPC.
+	Subscribe RPC = "Subscribe"
+)
+
+// Fixture is a recording of gNMI traffic between a client and a target.
+type Fixture struct {
+	// Events is the set of recorded events, in the order in which they
+	// occurred.
+	Events []*Event
+}
+
+// Event is a single message, or error, that was sent or received within an
+// RPC.
+type Event struct {
+	// Offset is the time at which the event occurred, relative to the
+	// start of the recording.
+	Offset time.Duration
+	// RPC is the RPC that the event occurred within.
+	RPC RPC
+	// Call identifies the invocation of the RPC that the event occurred
+	// within, such that the events of a single Subscribe stream, or the
+	// request and response of a single unary RPC, share the same Call.
+	Call int64
+	// Message is the request or response message. It is nil for the
+	// events that end an RPC without a response.
+	Message proto.Message
+	// Err is the error that ended the RPC, for events with no Message. It
+	// is nil if a stream ended without an error.
+	Err *Error
+}
+
+// Error is a gRPC status that was returned by an RPC.
+type Error struct {
+	// Code is the gRPC status code.
+	Code codes.Code
+	// Message is the status message.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.status().Error()
+}
+
+// status returns the error as a gRPC status error.
+func (e *Error) status() error {
+	return status.Error(e.Code, e.Message)
+}
+
+// errorFromStatus returns the Error describing err.
+func errorFromStatus(err error) *Error {
+	s := status.Convert(err)
+	return &Error{Code: s.Code(), Message: s.Message()}
+}
+
+// jsonEvent is the JSON representation of an Event.
+type jsonEvent struct {
+	Offset  string          `json:"offset"`
+	RPC     RPC             `json:"rpc"`
+	Call    int64           `json:"call"`
+	Type    string          `json:"type,omitempty"`
+	Message json.RawMessage `json:"message,omitempty"`
+	Err     *jsonError      `json:"error,omitempty"`
+}
+
+// jsonError is the JSON representation of an Error.
+type jsonError struct {
+	Code    codes.Code `json:"code"`
+	Message string     `json:"message,omitempty"`
+}
+
+// jsonFixture is the JSON representation of a Fixture.
+type jsonFixture struct {
+	Events []*jsonEvent `json:"events"`
+}
+
+// Marshal returns the JSON representation of the fixture, in which messages
+// use the protobuf JSON mapping.
+func (f *Fixture) Marshal() ([]byte, error) {
+	jf := &jsonFixture{Events: []*jsonEvent{}}
+	for i, e := range f.Events {
+		je := &jsonEvent{Offset: e.Offset.String(), RPC: e.RPC, Call: e.Call}
+		if e.Message != nil {
+			m, err := protojson.Marshal(e.Message)
+			if err != nil {
+				return nil, fmt.Errorf("cannot marshal message of event %d: %v", i, err)
+			}
+			je.Type, je.Message = string(e.Message.ProtoReflect().Descriptor().FullName()), m
+		}
+		if e.Err != nil {
+			je.Err = &jsonError{Code: e.Err.Code, Message: e.Err.Message}
+		}
+		jf.Events = append(jf.Events, je)
+	}
+	return json.MarshalIndent(jf, "", "  ")
+}
+
+// Unmarshal returns the Fixture described by the JSON b, as returned by
+// Marshal.
+func Unmarshal(b []byte) (*Fixture, error) {
+	jf := &jsonFixture{}
+	if err := json.Unmarshal(b, jf); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal fixture: %v", err)
+	}
+	f := &Fixture{}
+	for i, je := range jf.Events {
+		off, err := time.ParseDuration(je.Offset)
+		if err != nil {
+			return nil, fmt.Errorf("invalid offset of event %d: %v", i, err)
+		}
+		e := &Event{Offset: off, RPC: je.RPC, Call: je.Call}
+		if je.Type != "" {
+			mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(je.Type))
+			if err != nil {
+				return nil, fmt.Errorf("unknown message type %s of event %d: %v", je.Type, i, err)
+			}
+			e.Message = mt.New().Interface()
+			if err := protojson.Unmarshal(je.Message, e.Message); err != nil {
+				return nil, fmt.Errorf("cannot unmarshal message of event %d: %v", i, err)
+			}
+		}
+		if je.Err != nil {
+			e.Err = &Error{Code: je.Err.Code, Message: je.Err.Message}
+		}
+		f.Events = append(f.Events, e)
+	}
+	return f, nil
+}
+
+// calls returns the events of the fixture grouped by the RPC call that they
+// occurred within, in the order in which the calls were made.
+func (f *Fixture) calls() [][]*Event {
+	var order []int64
+	byCall := map[int64][]*Event{}
+	for _, e := range f.Events {
+		if _, ok := byCall[e.Call]; !ok {
+			order = append(order, e.Call)
+		}
+		byCall[e.Call] = append(byCall[e.Call], e)
+	}
+	calls := make([][]*Event, 0, len(order))
+	for _, c := range order {
+		calls = append(calls, byCall[c])
+	}
+	return calls
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yreplay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func mustPath(t *testing.T, s string) *gpb.Path {
+	t.Helper()
+	p, err := ygot.StringToStructuredPath(s)
+	if err != nil {
+		t.Fatalf("cannot parse path %s: %v", s, err)
+	}
+	return p
+}
+
+func TestFixtureMarshal(t *testing.T) {
+	hostname := mustPath(t, "/system/config/hostname")
+	f := &Fixture{Events: []*Event{{
+		Offset:  0,
+		RPC:     Get,
+		Call:    1,
+		Message: &gpb.GetRequest{Path: []*gpb.Path{hostname}},
+	}, {
+		Offset: 5 * time.Millisecond,
+		RPC:    Get,
+		Call:   1,
+		Message: &gpb.GetResponse{Notification: []*gpb.Notification{{
+			Timestamp: 42,
+			Update:    []*gpb.Update{{Path: hostname, Val: &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "dev1"}}}},
+		}}},
+	}, {
+		Offset:  time.Second,
+		RPC:     Set,
+		Call:    2,
+		Message: &gpb.SetRequest{Delete: []*gpb.Path{hostname}},
+	}, {
+		Offset: 2 * time.Second,
+		RPC:    Set,
+		Call:   2,
+		Err:    &Error{Code: codes.PermissionDenied, Message: "denied"},
+	}}}
+
+	b, err := f.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: got unexpected error: %v", err)
+	}
+	got, err := Unmarshal(b)
+	if err != nil {
+		t.Fatalf("Unmarshal: got unexpected error: %v\n%s", err, b)
+	}
+	if diff := cmp.Diff(f, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Unmarshal(Marshal()): did not get expected fixture, diff(-want, +got):\n%s", diff)
+	}
+
+	if _, err := Unmarshal([]byte(`{"events": [{"offset": "1s", "rpc": "Get", "call": 1, "type": "gnmi.Unknown", "message": {}}]}`)); err == nil {
+		t.Errorf("Unmarshal with unknown message type: got nil error, want error")
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yreplay
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Recorder is a gNMI client that records the RPCs that are made with it,
+// which are forwarded to an underlying client, within a Fixture. It is safe
+// for concurrent use.
+type Recorder struct {
+	// client is the client that RPCs are forwarded to.
+	client gpb.GNMIClient
+	// now returns the current time, and can be overridden in tests.
+	now func() time.Time
+
+	// mu protects the fields below.
+	mu sync.Mutex
+	// start is the time at which recording started.
+	start time.Time
+	// calls is the number of RPCs that have been made.
+	calls int64
+	// events is the set of recorded events.
+	events []*Event
+}
+
+var _ gpb.GNMIClient = (*Recorder)(nil)
+
+// NewRecorder returns a Recorder that forwards RPCs to client, whose
+// recording starts at the time that it is created.
+func NewRecorder(client gpb.GNMIClient) *Recorder {
+	return &Recorder{client: client, now: time.Now, start: time.Now()}
+}
+
+// Fixture returns a Fixture holding the events that have been recorded.
+// The messages within the Fixture are copies, such that it is not modified
+// by subsequent RPCs.
+func (r *Recorder) Fixture() *Fixture {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f := &Fixture{}
+	for _, e := range r.events {
+		c := *e
+		if e.Message != nil {
+			c.Message = proto.Clone(e.Message)
+		}
+		f.Events = append(f.Events, &c)
+	}
+	return f
+}
+
+// newCall returns the identifier of a new RPC call.
+func (r *Recorder) newCall() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls++
+	return r.calls
+}
+
+// record records an event of call within rpc, holding either the message m
+// or, if m is nil, the error err.
+func (r *Recorder) record(rpc RPC, call int64, m proto.Message, err error) {
+	e := &Event{RPC: rpc, Call: call}
+	switch {
+	case m != nil:
+		e.Message = proto.Clone(m)
+	case err != nil && !errors.Is(err, io.EOF):
+		e.Err = errorFromStatus(err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e.Offset = r.now().Sub(r.start)
+	r.events = append(r.events, e)
+}
+
+// unary records the request and response, or error, of a unary RPC.
+func (r *Recorder) unary(rpc RPC, req, resp proto.Message, err error) {
+	call := r.newCall()
+	r.record(rpc, call, req, nil)
+	if err != nil {
+		r.record(rpc, call, nil, err)
+		return
+	}
+	r.record(rpc, call, resp, nil)
+}
+
+// Capabilities forwards a Capabilities RPC, recording it.
+func (r *Recorder) Capabilities(ctx context.Context, req *gpb.CapabilityRequest, opts ...grpc.CallOption) (*gpb.CapabilityResponse, error) {
+	resp, err := r.client.Capabilities(ctx, req, opts...)
+	r.unary(Capabilities, req, resp, err)
+	return resp, err
+}
+
+// Get forwards a Get RPC, recording it.
+func (r *Recorder) Get(ctx context.Context, req *gpb.GetRequest, opts ...grpc.CallOption) (*gpb.GetResponse, error) {
+	resp, err := r.client.Get(ctx, req, opts...)
+	r.unary(Get, req, resp, err)
+	return resp, err
+}
+
+// Set forwards a Set RPC, recording it.
+func (r *Recorder) Set(ctx context.Context, req *gpb.SetRequest, opts ...grpc.CallOption) (*gpb.SetResponse, error) {
+	resp, err := r.client.Set(ctx, req, opts...)
+	r.unary(Set, req, resp, err)
+	return resp, err
+}
+
+// Subscribe forwards a Subscribe RPC, recording the requests that are sent
+// and the responses that are received on the returned stream, along with
+// the error, or end of stream, that ends it.
+func (r *Recorder) Subscribe(ctx context.Context, opts ...grpc.CallOption) (gpb.GNMI_SubscribeClient, error) {
+	call := r.newCall()
+	sc, err := r.client.Subscribe(ctx, opts...)
+	if err != nil {
+		r.record(Subscribe, call, nil, err)
+		return nil, err
+	}
+	return &recordingSubscribeClient{GNMI_SubscribeClient: sc, r: r, call: call}, nil
+}
+
+// recordingSubscribeClient is a Subscribe stream that records the messages
+// that are sent and received on it.
+type recordingSubscribeClient struct {
+	gpb.GNMI_SubscribeClient
+	// r is the Recorder that messages are recorded within.
+	r *Recorder
+	// call is the identifier of the stream.
+	call int64
+	// done indicates that the end of the stream has been recorded.
+	done bool
+}
+
+// Send sends req on the stream, recording it if it is sent successfully.
+func (s *recordingSubscribeClient) Send(req *gpb.SubscribeRequest) error {
+	if err := s.GNMI_SubscribeClient.Send(req); err != nil {
+		return err
+	}
+	s.r.record(Subscribe, s.call, req, nil)
+	return nil
+}
+
+// Recv receives a response from the stream, recording it, or the error
+// that ends the stream.
+func (s *recordingSubscribeClient) Recv() (*gpb.SubscribeResponse, error) {
+	resp, err := s.GNMI_SubscribeClient.Recv()
+	switch {
+	case err == nil:
+		s.r.record(Subscribe, s.call, resp, nil)
+	case !s.done:
+		s.done = true
+		s.r.record(Subscribe, s.call, nil, err)
+	}
+	return resp, err
+}
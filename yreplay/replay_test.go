@@ -0,0 +1,200 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yreplay
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/ytypes"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func exampleSchema() *ytypes.Schema {
+	return &ytypes.Schema{
+		Root:       &exampleoc.Device{},
+		SchemaTree: exampleoc.SchemaTree,
+		Unmarshal:  exampleoc.Unmarshal,
+	}
+}
+
+func stringVal(s string) *gpb.TypedValue {
+	return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: s}}
+}
+
+// serve serves target on an in-memory connection, returning a client of it.
+func serve(t *testing.T, target *Target) gpb.GNMIClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	gpb.RegisterGNMIServer(s, target)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("cannot connect to target: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return gpb.NewGNMIClient(conn)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	hostname := mustPath(t, "/system/config/hostname")
+	mtu := mustPath(t, "/interfaces/interface[name=eth0]/config/mtu")
+	badSet := &gpb.SetRequest{Delete: []*gpb.Path{mustPath(t, "/system")}}
+	subReq := &gpb.SubscribeRequest{Request: &gpb.SubscribeRequest_Subscribe{Subscribe: &gpb.SubscriptionList{
+		Subscription: []*gpb.Subscription{{Path: mustPath(t, "/interfaces")}},
+	}}}
+	mtuUpdate := &gpb.SubscribeResponse{Response: &gpb.SubscribeResponse_Update{Update: &gpb.Notification{
+		Timestamp: 100,
+		Update:    []*gpb.Update{{Path: mtu, Val: &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: 9000}}}},
+	}}}
+	syncResp := &gpb.SubscribeResponse{Response: &gpb.SubscribeResponse_SyncResponse{SyncResponse: true}}
+
+	captured := &Fixture{Events: []*Event{
+		{RPC: Capabilities, Call: 1, Message: &gpb.CapabilityRequest{}},
+		{RPC: Capabilities, Call: 1, Message: &gpb.CapabilityResponse{GNMIVersion: "0.10.0"}},
+		{RPC: Get, Call: 2, Message: &gpb.GetRequest{Path: []*gpb.Path{{}}}},
+		{RPC: Get, Call: 2, Message: &gpb.GetResponse{Notification: []*gpb.Notification{{
+			Update: []*gpb.Update{{Path: hostname, Val: stringVal("dev1")}},
+		}}}},
+		{RPC: Set, Call: 3, Message: badSet},
+		{RPC: Set, Call: 3, Err: &Error{Code: codes.FailedPrecondition, Message: "system cannot be deleted"}},
+		{Offset: time.Second, RPC: Subscribe, Call: 4, Message: subReq},
+		{Offset: 2 * time.Second, RPC: Subscribe, Call: 4, Message: mtuUpdate},
+		{Offset: 3 * time.Second, RPC: Subscribe, Call: 4, Message: syncResp},
+		{Offset: 4 * time.Second, RPC: Subscribe, Call: 4, Err: &Error{Code: codes.Canceled}},
+	}}
+
+	target, err := NewTarget(exampleSchema(), captured, TimeScale(0))
+	if err != nil {
+		t.Fatalf("NewTarget: got unexpected error: %v", err)
+	}
+	rec := NewRecorder(serve(t, target))
+	ctx := context.Background()
+
+	caps, err := rec.Capabilities(ctx, &gpb.CapabilityRequest{})
+	if err != nil || caps.GetGNMIVersion() != "0.10.0" {
+		t.Errorf("Capabilities: got %v, %v, want recorded response", caps, err)
+	}
+
+	getHostname := func(c gpb.GNMIClient) string {
+		t.Helper()
+		resp, err := c.Get(ctx, &gpb.GetRequest{Path: []*gpb.Path{hostname}})
+		if err != nil {
+			t.Fatalf("Get: got unexpected error: %v", err)
+		}
+		if len(resp.GetNotification()) != 1 || len(resp.GetNotification()[0].GetUpdate()) != 1 {
+			t.Fatalf("Get: got %v, want a single update", resp)
+		}
+		return resp.GetNotification()[0].GetUpdate()[0].GetVal().GetStringVal()
+	}
+	if got := getHostname(rec); got != "dev1" {
+		t.Errorf("Get of seeded hostname: got %q, want dev1", got)
+	}
+
+	if _, err := rec.Set(ctx, &gpb.SetRequest{Update: []*gpb.Update{{Path: hostname, Val: stringVal("dev2")}}}); err != nil {
+		t.Fatalf("Set: got unexpected error: %v", err)
+	}
+	if got := getHostname(rec); got != "dev2" {
+		t.Errorf("Get after Set: got %q, want dev2", got)
+	}
+	if _, err := rec.Set(ctx, badSet); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Set of recorded failing request: got error %v, want code %v", err, codes.FailedPrecondition)
+	}
+
+	sctx, cancel := context.WithCancel(ctx)
+	sc, err := rec.Subscribe(sctx)
+	if err != nil {
+		t.Fatalf("Subscribe: got unexpected error: %v", err)
+	}
+	if err := sc.Send(subReq); err != nil {
+		t.Fatalf("Subscribe: cannot send request: %v", err)
+	}
+	for _, want := range []*gpb.SubscribeResponse{mtuUpdate, syncResp} {
+		got, err := sc.Recv()
+		if err != nil {
+			t.Fatalf("Subscribe: got unexpected error: %v", err)
+		}
+		if !proto.Equal(got, want) {
+			t.Errorf("Subscribe: got response %v, want %v", got, want)
+		}
+	}
+	cancel()
+	if _, err := sc.Recv(); status.Code(err) != codes.Canceled {
+		t.Errorf("Subscribe after cancellation: got error %v, want code %v", err, codes.Canceled)
+	}
+
+	root, err := target.Root()
+	if err != nil {
+		t.Fatalf("Root: got unexpected error: %v", err)
+	}
+	d := root.(*exampleoc.Device)
+	if got := d.GetInterface("eth0").GetMtu(); got != 9000 {
+		t.Errorf("mtu after Subscribe: got %d, want 9000", got)
+	}
+
+	sc, err = rec.Subscribe(ctx)
+	if err != nil {
+		t.Fatalf("Subscribe: got unexpected error: %v", err)
+	}
+	if err := sc.Send(&gpb.SubscribeRequest{}); err != nil {
+		t.Fatalf("Subscribe: cannot send request: %v", err)
+	}
+	if _, err := sc.Recv(); status.Code(err) != codes.NotFound {
+		t.Errorf("Subscribe with unrecorded request: got error %v, want code %v", err, codes.NotFound)
+	}
+
+	// Replay the recording of the session against a new target.
+	recorded := rec.Fixture()
+	var last time.Duration
+	for _, e := range recorded.Events {
+		if e.Offset < last {
+			t.Errorf("recorded event %v has an offset earlier than its predecessor", e)
+		}
+		last = e.Offset
+	}
+	b, err := recorded.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: got unexpected error: %v", err)
+	}
+	loaded, err := Unmarshal(b)
+	if err != nil {
+		t.Fatalf("Unmarshal: got unexpected error: %v", err)
+	}
+	replay, err := NewTarget(exampleSchema(), loaded, TimeScale(0))
+	if err != nil {
+		t.Fatalf("NewTarget of recorded fixture: got unexpected error: %v", err)
+	}
+	client := serve(t, replay)
+	if got := getHostname(client); got != "dev2" {
+		t.Errorf("Get of hostname from recorded fixture: got %q, want dev2", got)
+	}
+	if _, err := client.Set(ctx, badSet); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Set of recorded failing request: got error %v, want code %v", err, codes.FailedPrecondition)
+	}
+}
@@ -0,0 +1,312 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yreplay
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// TargetOpt is an option that can be supplied to NewTarget.
+type TargetOpt interface {
+	// IsTargetOpt is a marker method for each TargetOpt.
+	IsTargetOpt()
+}
+
+// TimeScale is a TargetOpt that specifies the factor by which the recorded
+// intervals between the responses of Subscribe streams are scaled when they
+// are replayed. A TimeScale of 0 replays responses without delay. By
+// default, responses are replayed with their recorded timing.
+type TimeScale float64
+
+// IsTargetOpt implements the TargetOpt interface.
+func (TimeScale) IsTargetOpt() {}
+
+// Target is a fake gNMI target that replays a Fixture. Its data tree, which
+// is a GoStruct of a schema, is seeded with the notifications of the
+// responses to the Get RPCs within the Fixture, and is served by the Get
+// RPC, modified by the Set RPC, and updated by the notifications that are
+// sent by Subscribe RPCs. The Capabilities RPC returns the recorded
+// response, and Set RPCs that failed when recorded return the recorded
+// error. Subscribe RPCs replay the recorded stream whose initial request
+// matches that of the client. A Target is safe for concurrent use.
+type Target struct {
+	gpb.UnimplementedGNMIServer
+
+	// schema is the schema of the data tree.
+	schema *ytypes.Schema
+	// calls is the set of events of the Fixture, grouped by call.
+	calls [][]*Event
+	// timeScale is the factor applied to recorded intervals.
+	timeScale float64
+
+	// mu protects root.
+	mu sync.RWMutex
+	// root is the data tree of the target.
+	root ygot.GoStruct
+}
+
+var _ gpb.GNMIServer = (*Target)(nil)
+
+// NewTarget returns a Target that replays f, whose data tree is a GoStruct
+// of schema. The schema root is not modified. Paths within the recorded
+// notifications that are not within the schema are ignored.
+func NewTarget(schema *ytypes.Schema, f *Fixture, opts ...TargetOpt) (*Target, error) {
+	if schema == nil || schema.Root == nil || schema.RootSchema() == nil {
+		return nil, fmt.Errorf("invalid schema: root and its schema must be populated")
+	}
+	t := &Target{schema: schema, calls: f.calls(), timeScale: 1, root: schema.NewRoot()}
+	for _, o := range opts {
+		switch v := o.(type) {
+		case TimeScale:
+			t.timeScale = float64(v)
+		}
+	}
+	for _, c := range t.calls {
+		for _, e := range c {
+			if resp, ok := e.Message.(*gpb.GetResponse); ok {
+				if err := t.apply(resp.GetNotification()); err != nil {
+					return nil, fmt.Errorf("cannot apply recorded Get response at %v: %v", e.Offset, err)
+				}
+			}
+		}
+	}
+	return t, nil
+}
+
+// Root returns a copy of the data tree of the target.
+func (t *Target) Root() (ygot.GoStruct, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return ygot.DeepCopy(t.root)
+}
+
+// apply applies the notifications ns to the data tree of the target.
+func (t *Target) apply(ns []*gpb.Notification) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s := *t.schema
+	s.Root = t.root
+	return ytypes.UnmarshalNotifications(&s, ns, &ytypes.IgnoreExtraFields{})
+}
+
+// recorded returns the events of the first recorded call of rpc whose
+// request is equal to req, or nil if there is no such call.
+func (t *Target) recorded(rpc RPC, req proto.Message) []*Event {
+	for _, c := range t.calls {
+		if c[0].RPC == rpc && proto.Equal(c[0].Message, req) {
+			return c
+		}
+	}
+	return nil
+}
+
+// Capabilities returns the recorded response to the first Capabilities RPC.
+func (t *Target) Capabilities(ctx context.Context, req *gpb.CapabilityRequest) (*gpb.CapabilityResponse, error) {
+	for _, c := range t.calls {
+		if c[0].RPC != Capabilities || len(c) < 2 {
+			continue
+		}
+		if c[1].Err != nil {
+			return nil, c[1].Err.status()
+		}
+		if resp, ok := c[1].Message.(*gpb.CapabilityResponse); ok {
+			return resp, nil
+		}
+	}
+	return nil, status.Errorf(codes.Unimplemented, "no Capabilities RPC is recorded")
+}
+
+// Get returns the contents of the data tree of the target at the paths of
+// req. Leaves are returned as scalar values, regardless of the requested
+// encoding.
+func (t *Target) Get(ctx context.Context, req *gpb.GetRequest) (*gpb.GetResponse, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	ts := time.Now().UnixNano()
+	resp := &gpb.GetResponse{}
+	for _, p := range req.GetPath() {
+		path, err := util.JoinPaths(req.GetPrefix(), p)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid path %v: %v", p, err)
+		}
+		nodes, err := ytypes.GetNode(t.schema.RootSchema(), t.root, path, &ytypes.GetHandleWildcards{}, &ytypes.GetPartialKeyMatch{})
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "cannot retrieve %v: %v", path, err)
+		}
+		for _, n := range nodes {
+			ns, err := nodeNotifications(n, ts)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "cannot render %v: %v", n.Path, err)
+			}
+			resp.Notification = append(resp.Notification, ns...)
+		}
+	}
+	return resp, nil
+}
+
+// nodeNotifications returns the notifications describing the data tree node
+// n, with timestamp ts.
+func nodeNotifications(n *ytypes.TreeNode, ts int64) ([]*gpb.Notification, error) {
+	if gs, ok := n.Data.(ygot.GoStruct); ok {
+		ns, err := ygot.TogNMINotifications(gs, ts, ygot.GNMINotificationsConfig{UsePathElem: true})
+		if err != nil {
+			return nil, err
+		}
+		for _, notif := range ns {
+			if notif.Prefix, err = util.JoinPaths(n.Path, notif.GetPrefix()); err != nil {
+				return nil, err
+			}
+		}
+		return ns, nil
+	}
+	if util.IsValueNil(n.Data) {
+		return nil, nil
+	}
+	v, err := ygot.EncodeTypedValue(n.Data, gpb.Encoding_JSON)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, nil
+	}
+	return []*gpb.Notification{{
+		Timestamp: ts,
+		Update:    []*gpb.Update{{Path: n.Path, Val: v}},
+	}}, nil
+}
+
+// Set applies req to the data tree of the target, unless an equal request
+// failed when it was recorded, in which case the recorded error is
+// returned. The data tree is unchanged if req cannot be applied.
+func (t *Target) Set(ctx context.Context, req *gpb.SetRequest) (*gpb.SetResponse, error) {
+	if c := t.recorded(Set, req); len(c) > 1 && c[1].Err != nil {
+		return nil, c[1].Err.status()
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	nr, err := ygot.DeepCopy(t.root)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot copy data tree: %v", err)
+	}
+	s := *t.schema
+	s.Root = nr
+	if err := ytypes.UnmarshalSetRequest(&s, req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot apply SetRequest: %v", err)
+	}
+	t.root = nr
+
+	resp := &gpb.SetResponse{Prefix: req.GetPrefix(), Timestamp: time.Now().UnixNano()}
+	for _, p := range req.GetDelete() {
+		resp.Response = append(resp.Response, &gpb.UpdateResult{Path: p, Op: gpb.UpdateResult_DELETE})
+	}
+	for _, u := range req.GetReplace() {
+		resp.Response = append(resp.Response, &gpb.UpdateResult{Path: u.GetPath(), Op: gpb.UpdateResult_REPLACE})
+	}
+	for _, u := range req.GetUpdate() {
+		resp.Response = append(resp.Response, &gpb.UpdateResult{Path: u.GetPath(), Op: gpb.UpdateResult_UPDATE})
+	}
+	return resp, nil
+}
+
+// Subscribe replays the first recorded Subscribe stream whose initial
+// request is equal to that received from the client. Recorded responses are
+// sent with the recorded intervals between them, scaled by the TimeScale of
+// the target, and subsequent recorded requests, such as polls, are awaited
+// before the responses that follow them are sent. The stream ends with the
+// recorded error, or is held open until the client cancels it if the
+// recording ended before the stream did.
+func (t *Target) Subscribe(stream gpb.GNMI_SubscribeServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	c := t.recorded(Subscribe, req)
+	if c == nil {
+		return status.Errorf(codes.NotFound, "no recorded Subscribe stream matches request %v", req)
+	}
+	ctx := stream.Context()
+	last := c[0].Offset
+	for _, e := range c[1:] {
+		switch m := e.Message.(type) {
+		case *gpb.SubscribeRequest:
+			got, err := stream.Recv()
+			if err != nil {
+				return err
+			}
+			if !proto.Equal(got, m) {
+				return status.Errorf(codes.InvalidArgument, "got request %v, recorded request was %v", got, m)
+			}
+		case *gpb.SubscribeResponse:
+			if err := t.wait(ctx, e.Offset-last); err != nil {
+				return err
+			}
+			if n := m.GetUpdate(); n != nil {
+				if err := t.apply([]*gpb.Notification{n}); err != nil {
+					return status.Errorf(codes.Internal, "cannot apply recorded notification: %v", err)
+				}
+			}
+			if err := stream.Send(m); err != nil {
+				return err
+			}
+		default:
+			// The event ends the recorded stream.
+			if err := t.wait(ctx, e.Offset-last); err != nil {
+				return err
+			}
+			switch {
+			case e.Err == nil:
+				return nil
+			case e.Err.Code == codes.Canceled:
+				// A stream that was cancelled by the client when it
+				// was recorded is held open until it is cancelled.
+				<-ctx.Done()
+				return nil
+			}
+			return e.Err.status()
+		}
+		last = e.Offset
+	}
+	<-ctx.Done()
+	return nil
+}
+
+// wait waits for the recorded interval d, scaled by the TimeScale of the
+// target, returning an error if ctx is done first.
+func (t *Target) wait(ctx context.Context, d time.Duration) error {
+	d = time.Duration(float64(d) * t.timeScale)
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yrestconf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// ErrorTypeProtocol is the error-type of errors that occur in the
+	// RESTCONF protocol operation.
+	ErrorTypeProtocol = "protocol"
+	// ErrorTypeApplication is the error-type of errors that occur in the
+	// handling of the data of a request.
+	ErrorTypeApplication = "application"
+)
+
+// Error is a RESTCONF error, which is written to the client as an errors
+// object, as described by RFC8040 Section 7.1.
+type Error struct {
+	// Status is the HTTP status code of the response.
+	Status int
+	// Type is the error-type of the error, ErrorTypeProtocol or
+	// ErrorTypeApplication.
+	Type string
+	// Tag is the error-tag of the error, as listed in RFC8040 Section 7,
+	// such as "invalid-value" or "data-missing".
+	Tag string
+	// Path is the error-path of the error, which is the instance-identifier
+	// of the data node that the error relates to. It is omitted if empty.
+	Path string
+	// Message is the error-message of the error.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("%s: %s", e.Tag, e.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", e.Tag, e.Path, e.Message)
+}
+
+// errorf returns an Error of the type t, with the HTTP status code status
+// and the error-tag tag, whose message is formatted from format and args.
+func errorf(status int, t, tag, format string, args ...any) *Error {
+	return &Error{Status: status, Type: t, Tag: tag, Message: fmt.Sprintf(format, args...)}
+}
+
+// withPath returns err as an Error with the error-path path. An err that
+// is not an Error is returned as an Error with the error-tag
+// operation-failed.
+func withPath(err error, path string) *Error {
+	var e *Error
+	if !errors.As(err, &e) {
+		e = errorf(http.StatusInternalServerError, ErrorTypeApplication, "operation-failed", "%v", err)
+	}
+	if e.Path == "" {
+		c := *e
+		c.Path = path
+		e = &c
+	}
+	return e
+}
+
+// writeError writes the errors object of err to w, with the HTTP status
+// code of err.
+func writeError(w http.ResponseWriter, err *Error) {
+	type jsonError struct {
+		Type    string `json:"error-type"`
+		Tag     string `json:"error-tag"`
+		Path    string `json:"error-path,omitempty"`
+		Message string `json:"error-message,omitempty"`
+	}
+	b, _ := json.MarshalIndent(map[string]any{
+		"ietf-restconf:errors": map[string]any{
+			"error": []jsonError{{Type: err.Type, Tag: err.Tag, Path: err.Path, Message: err.Message}},
+		},
+	}, "", "  ")
+	w.Header().Set("Content-Type", MediaType)
+	w.WriteHeader(err.Status)
+	w.Write(b)
+}
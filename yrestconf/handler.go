@@ -0,0 +1,435 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package yrestconf implements the data resource of a RESTCONF (RFC8040)
+// server for a data tree that is a ygot-generated GoStruct. The Handler
+// translates the URIs of requests into paths within the data tree, and
+// serves GET, PUT, PATCH and DELETE requests using RFC7951 JSON, whilst the
+// storage of the data tree is left to an implementation of the Store
+// interface that is supplied by the user. Code that creates a Handler for
+// the generated schema can be generated by the ygot generator.
+package yrestconf
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	// DataPath is the path of the RESTCONF datastore resource, beneath
+	// which the data resources are served.
+	DataPath = "/restconf/data"
+	// MediaType is the media type of the RFC7951 JSON encoded data of
+	// requests and responses.
+	MediaType = "application/yang-data+json"
+)
+
+// Store stores the data tree that is served by a Handler. It is
+// implemented by the user to load the data tree from, and commit it to, the
+// storage of their choice.
+type Store interface {
+	// Load returns the current data tree. The returned GoStruct is not
+	// modified by the Handler.
+	Load(ctx context.Context) (ygot.GoStruct, error)
+	// Commit replaces the current data tree with root, which has been
+	// modified by a request. If an *Error is returned, it is written to
+	// the client, otherwise an error is reported as an operation-failed
+	// error.
+	Commit(ctx context.Context, root ygot.GoStruct) error
+}
+
+// MemoryStore is a Store that holds the data tree in memory.
+type MemoryStore struct {
+	mu   sync.RWMutex
+	root ygot.GoStruct
+}
+
+// NewMemoryStore returns a MemoryStore whose data tree is initially root.
+func NewMemoryStore(root ygot.GoStruct) *MemoryStore {
+	return &MemoryStore{root: root}
+}
+
+// Load implements the Store interface.
+func (m *MemoryStore) Load(context.Context) (ygot.GoStruct, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.root, nil
+}
+
+// Commit implements the Store interface.
+func (m *MemoryStore) Commit(_ context.Context, root ygot.GoStruct) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.root = root
+	return nil
+}
+
+// HandlerOpt is an option that can be supplied to NewHandler.
+type HandlerOpt interface {
+	// IsHandlerOpt is a marker method for each HandlerOpt.
+	IsHandlerOpt()
+}
+
+// SkipValidation is a HandlerOpt that specifies that the data tree is not
+// validated against the schema before it is committed to the Store.
+type SkipValidation struct{}
+
+// IsHandlerOpt implements the HandlerOpt interface.
+func (*SkipValidation) IsHandlerOpt() {}
+
+// Handler is an http.Handler that serves the RESTCONF datastore resource,
+// at DataPath, and the data resources beneath it.
+type Handler struct {
+	// schema is the schema of the data tree.
+	schema *ytypes.Schema
+	// store stores the data tree.
+	store Store
+	// validate is whether the data tree is validated before it is
+	// committed.
+	validate bool
+	// mu serialises the requests that modify the data tree, such that
+	// each is applied to the data tree committed by the last.
+	mu sync.Mutex
+}
+
+// NewHandler returns a Handler that serves the data tree stored in store,
+// which is described by schema.
+func NewHandler(schema *ytypes.Schema, store Store, opts ...HandlerOpt) (*Handler, error) {
+	if schema == nil || !schema.IsValid() {
+		return nil, fmt.Errorf("invalid schema %v", schema)
+	}
+	if store == nil {
+		return nil, fmt.Errorf("nil store")
+	}
+	h := &Handler{schema: schema, store: store, validate: true}
+	for _, o := range opts {
+		switch o.(type) {
+		case *SkipValidation:
+			h.validate = false
+		}
+	}
+	return h, nil
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p := r.URL.EscapedPath()
+	if p != DataPath && !strings.HasPrefix(p, DataPath+"/") {
+		writeError(w, errorf(http.StatusNotFound, ErrorTypeProtocol, "invalid-value", "unknown resource %s", p))
+		return
+	}
+	segs, err := parsePath(h.schema.RootSchema(), strings.TrimPrefix(p, DataPath))
+	if err != nil {
+		writeError(w, withPath(err, ""))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		err = h.get(w, r, segs)
+	case http.MethodPut, http.MethodPatch:
+		err = h.write(w, r, segs)
+	case http.MethodDelete:
+		err = h.delete(w, r, segs)
+	default:
+		w.Header().Set("Allow", "GET, PUT, PATCH, DELETE")
+		err = errorf(http.StatusMethodNotAllowed, ErrorTypeProtocol, "operation-not-supported", "method %s is not supported", r.Method)
+	}
+	if err != nil {
+		writeError(w, withPath(err, instanceIdentifier(segs)))
+	}
+}
+
+// get writes the data node identified by segs to w.
+func (h *Handler) get(w http.ResponseWriter, r *http.Request, segs []*segment) error {
+	root, err := h.store.Load(r.Context())
+	if err != nil {
+		return err
+	}
+	tree, err := ygot.ConstructIETFJSON(root, &ygot.RFC7951JSONConfig{AppendModuleName: true})
+	if err != nil {
+		return err
+	}
+	var out any = tree
+	if len(segs) != 0 {
+		v, ok := find(tree, segs)
+		if !ok {
+			return errorf(http.StatusNotFound, ErrorTypeProtocol, "invalid-value", "data node does not exist")
+		}
+		out = map[string]any{memberName(segs): v}
+	}
+	b, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", MediaType)
+	w.Write(b)
+	return nil
+}
+
+// write replaces, for a PUT request, or merges, for a PATCH request, the
+// content of the body of r into the data node identified by segs. A PUT
+// request creates the data node if it does not already exist, whereas a
+// PATCH request requires it to exist.
+func (h *Handler) write(w http.ResponseWriter, r *http.Request, segs []*segment) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return errorf(http.StatusBadRequest, ErrorTypeProtocol, "malformed-message", "cannot read request body: %v", err)
+	}
+	replace := r.Method == http.MethodPut
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	root, tree, err := h.load(r.Context())
+	if err != nil {
+		return err
+	}
+	exists := true
+	if len(segs) == 0 {
+		if replace {
+			root = reflect.New(reflect.TypeOf(h.schema.Root).Elem()).Interface().(ygot.GoStruct)
+		}
+		if err := h.schema.Unmarshal(body, root); err != nil {
+			return errorf(http.StatusBadRequest, ErrorTypeApplication, "invalid-value", "%v", err)
+		}
+	} else {
+		if _, exists = find(tree, segs); !exists && !replace {
+			return errorf(http.StatusNotFound, ErrorTypeProtocol, "invalid-value", "data node does not exist")
+		}
+		val, err := content(body, segs)
+		if err != nil {
+			return err
+		}
+		u := []*gpb.Update{{Path: gnmiPath(segs), Val: &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: val}}}}
+		req := &gpb.SetRequest{Update: u}
+		if replace {
+			req = &gpb.SetRequest{Replace: u}
+		}
+		if err := ytypes.UnmarshalSetRequest(&ytypes.Schema{Root: root, SchemaTree: h.schema.SchemaTree, Unmarshal: h.schema.Unmarshal}, req); err != nil {
+			return errorf(http.StatusBadRequest, ErrorTypeApplication, "invalid-value", "%v", err)
+		}
+	}
+	if err := h.commit(r.Context(), root); err != nil {
+		return err
+	}
+	if !exists {
+		w.WriteHeader(http.StatusCreated)
+		return nil
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// delete deletes the data node identified by segs, which must exist. If
+// segs is empty, the content of the datastore is deleted.
+func (h *Handler) delete(w http.ResponseWriter, r *http.Request, segs []*segment) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	root, tree, err := h.load(r.Context())
+	if err != nil {
+		return err
+	}
+	if len(segs) == 0 {
+		root = reflect.New(reflect.TypeOf(h.schema.Root).Elem()).Interface().(ygot.GoStruct)
+	} else {
+		if _, ok := find(tree, segs); !ok {
+			return errorf(http.StatusNotFound, ErrorTypeProtocol, "data-missing", "data node does not exist")
+		}
+		if err := ytypes.DeleteNode(h.schema.RootSchema(), root, gnmiPath(segs)); err != nil {
+			code := http.StatusBadRequest
+			if status.Code(err) == codes.NotFound {
+				code = http.StatusNotFound
+			}
+			return errorf(code, ErrorTypeApplication, "operation-failed", "%v", err)
+		}
+	}
+	if err := h.commit(r.Context(), root); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// load returns a copy of the data tree of the Store, which can be modified,
+// along with its RFC7951 JSON encoding.
+func (h *Handler) load(ctx context.Context) (ygot.GoStruct, map[string]any, error) {
+	root, err := h.store.Load(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if root, err = ygot.DeepCopy(root); err != nil {
+		return nil, nil, err
+	}
+	tree, err := ygot.ConstructIETFJSON(root, &ygot.RFC7951JSONConfig{AppendModuleName: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	return root, tree, nil
+}
+
+// commit validates root, unless validation is skipped, and commits it to
+// the Store.
+func (h *Handler) commit(ctx context.Context, root ygot.GoStruct) error {
+	if vs, ok := root.(ygot.ValidatedGoStruct); ok && h.validate {
+		if err := vs.Validate(); err != nil {
+			return errorf(http.StatusBadRequest, ErrorTypeApplication, "invalid-value", "%v", err)
+		}
+	}
+	return h.store.Commit(ctx, root)
+}
+
+// memberName returns the name of the member of an RFC7951 JSON object
+// that encodes the data node identified by segs, which is qualified by its
+// module.
+func memberName(segs []*segment) string {
+	last := segs[len(segs)-1]
+	return fmt.Sprintf("%s:%s", last.module, last.name)
+}
+
+// find returns the value within the RFC7951 JSON encoded data tree tree of
+// the data node identified by segs. A list entry is returned as an array
+// that contains only the entry. It returns false if the data node does not
+// exist.
+func find(tree map[string]any, segs []*segment) (any, bool) {
+	var v any = tree
+	for _, s := range segs {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = member(obj, s); !ok {
+			return nil, false
+		}
+		if s.keys == nil {
+			continue
+		}
+		entries, ok := v.([]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = listEntry(entries, s); !ok {
+			return nil, false
+		}
+	}
+	if segs[len(segs)-1].keys != nil {
+		return []any{v}, true
+	}
+	return v, true
+}
+
+// member returns the value of the member of the JSON object obj that
+// encodes the data node of the segment s.
+func member(obj map[string]any, s *segment) (any, bool) {
+	for k, v := range obj {
+		m, n, ok := strings.Cut(k, ":")
+		if !ok {
+			m, n = "", k
+		}
+		if n == s.name && (m == "" || m == s.module) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// listEntry returns the entry of the list whose JSON encoded entries are
+// entries, whose keys are those of the segment s.
+func listEntry(entries []any, s *segment) (any, bool) {
+	names := strings.Fields(s.entry.Key)
+	for _, e := range entries {
+		obj, ok := e.(map[string]any)
+		if !ok {
+			continue
+		}
+		match := true
+		for i, n := range names {
+			v, ok := member(obj, &segment{module: s.module, name: n})
+			if !ok || keyString(v) != s.keys[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return obj, true
+		}
+	}
+	return nil, false
+}
+
+// keyString returns the value v of a key leaf, in its RFC7951 JSON
+// encoding, as it is encoded in a URI.
+func keyString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []any:
+		// The empty type is encoded as [null].
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// content returns the JSON encoded value of the data node identified by
+// segs, which is the content of the request body body. The body must be a
+// JSON object with a single member, for the data node. The value of a list
+// entry is an array containing the single entry, whose keys must match
+// those of the URI.
+func content(body []byte, segs []*segment) ([]byte, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return nil, errorf(http.StatusBadRequest, ErrorTypeProtocol, "malformed-message", "invalid JSON body: %v", err)
+	}
+	last := segs[len(segs)-1]
+	if len(obj) != 1 {
+		return nil, errorf(http.StatusBadRequest, ErrorTypeProtocol, "malformed-message", "body must contain only the data node %s, got %d members", last.name, len(obj))
+	}
+	var val json.RawMessage
+	for k, v := range obj {
+		if _, ok := member(map[string]any{k: nil}, last); !ok {
+			return nil, errorf(http.StatusBadRequest, ErrorTypeProtocol, "malformed-message", "body contains data node %s, want %s", k, memberName(segs))
+		}
+		val = v
+	}
+	if last.keys == nil {
+		return val, nil
+	}
+
+	var entries []map[string]any
+	d := json.NewDecoder(bytes.NewReader(val))
+	d.UseNumber()
+	if err := d.Decode(&entries); err != nil || len(entries) != 1 {
+		return nil, errorf(http.StatusBadRequest, ErrorTypeProtocol, "malformed-message", "list entry %s must be an array with a single entry", last.name)
+	}
+	for i, n := range strings.Fields(last.entry.Key) {
+		v, ok := member(entries[0], &segment{module: last.module, name: n})
+		if ok && keyString(v) != last.keys[i] {
+			return nil, errorf(http.StatusBadRequest, ErrorTypeProtocol, "invalid-value", "key %s of list entry is %v, want %s", n, v, last.keys[i])
+		}
+	}
+	return json.Marshal(entries[0])
+}
@@ -0,0 +1,292 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yrestconf
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+)
+
+func newTestHandler(t *testing.T) (*Handler, *MemoryStore) {
+	t.Helper()
+	d := &exampleoc.Device{}
+	i := d.GetOrCreateInterface("eth0")
+	i.Mtu = ygot.Uint16(1500)
+	i.Description = ygot.String("uplink")
+	store := NewMemoryStore(d)
+	h, err := NewHandler(&ytypes.Schema{Root: &exampleoc.Device{}, SchemaTree: exampleoc.SchemaTree, Unmarshal: exampleoc.Unmarshal}, store)
+	if err != nil {
+		t.Fatalf("NewHandler: %v", err)
+	}
+	return h, store
+}
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		desc       string
+		method     string
+		uri        string
+		body       string
+		wantStatus int
+		// wantBody is compared to the decoded response body, if set.
+		wantBody string
+		// wantErrorTag is the error-tag of the errors object that is
+		// expected in the response.
+		wantErrorTag string
+		// check checks the stored data tree after the request, if set.
+		check func(*testing.T, *exampleoc.Device)
+	}{{
+		desc:       "get leaf",
+		method:     http.MethodGet,
+		uri:        "/restconf/data/openconfig-interfaces:interfaces/interface=eth0/config/mtu",
+		wantStatus: http.StatusOK,
+		wantBody:   `{"openconfig-interfaces:mtu": 1500}`,
+	}, {
+		desc:       "get container",
+		method:     http.MethodGet,
+		uri:        "/restconf/data/openconfig-interfaces:interfaces/interface=eth0/config",
+		wantStatus: http.StatusOK,
+		wantBody:   `{"openconfig-interfaces:config": {"name": "eth0", "mtu": 1500, "description": "uplink"}}`,
+	}, {
+		desc:         "get missing list entry",
+		method:       http.MethodGet,
+		uri:          "/restconf/data/openconfig-interfaces:interfaces/interface=eth1",
+		wantStatus:   http.StatusNotFound,
+		wantErrorTag: "invalid-value",
+	}, {
+		desc:         "unqualified top-level node",
+		method:       http.MethodGet,
+		uri:          "/restconf/data/interfaces",
+		wantStatus:   http.StatusBadRequest,
+		wantErrorTag: "invalid-value",
+	}, {
+		desc:         "unknown node",
+		method:       http.MethodGet,
+		uri:          "/restconf/data/openconfig-interfaces:interfaces/fish",
+		wantStatus:   http.StatusBadRequest,
+		wantErrorTag: "invalid-value",
+	}, {
+		desc:         "wrong number of keys",
+		method:       http.MethodGet,
+		uri:          "/restconf/data/openconfig-interfaces:interfaces/interface=eth0,eth1",
+		wantStatus:   http.StatusBadRequest,
+		wantErrorTag: "invalid-value",
+	}, {
+		desc:       "put creates list entry",
+		method:     http.MethodPut,
+		uri:        "/restconf/data/openconfig-interfaces:interfaces/interface=eth%2F1",
+		body:       `{"openconfig-interfaces:interface": [{"name": "eth/1", "config": {"name": "eth/1", "mtu": 9000}}]}`,
+		wantStatus: http.StatusCreated,
+		check: func(t *testing.T, d *exampleoc.Device) {
+			if got := d.GetInterface("eth/1").GetMtu(); got != 9000 {
+				t.Errorf("mtu of eth/1: got %d, want 9000", got)
+			}
+		},
+	}, {
+		desc:       "put replaces leaf",
+		method:     http.MethodPut,
+		uri:        "/restconf/data/openconfig-interfaces:interfaces/interface=eth0/config/mtu",
+		body:       `{"openconfig-interfaces:mtu": 9000}`,
+		wantStatus: http.StatusNoContent,
+		check: func(t *testing.T, d *exampleoc.Device) {
+			if got := d.GetInterface("eth0").GetMtu(); got != 9000 {
+				t.Errorf("mtu of eth0: got %d, want 9000", got)
+			}
+		},
+	}, {
+		desc:         "put with mismatched key",
+		method:       http.MethodPut,
+		uri:          "/restconf/data/openconfig-interfaces:interfaces/interface=eth1",
+		body:         `{"openconfig-interfaces:interface": [{"name": "eth2"}]}`,
+		wantStatus:   http.StatusBadRequest,
+		wantErrorTag: "invalid-value",
+	}, {
+		desc:         "put with wrong data node",
+		method:       http.MethodPut,
+		uri:          "/restconf/data/openconfig-interfaces:interfaces/interface=eth0/config/mtu",
+		body:         `{"openconfig-interfaces:description": "x"}`,
+		wantStatus:   http.StatusBadRequest,
+		wantErrorTag: "malformed-message",
+	}, {
+		desc:       "patch merges into list entry",
+		method:     http.MethodPatch,
+		uri:        "/restconf/data/openconfig-interfaces:interfaces/interface=eth0",
+		body:       `{"openconfig-interfaces:interface": [{"name": "eth0", "config": {"enabled": false}}]}`,
+		wantStatus: http.StatusNoContent,
+		check: func(t *testing.T, d *exampleoc.Device) {
+			i := d.GetInterface("eth0")
+			if i.Enabled == nil || *i.Enabled || i.GetMtu() != 1500 {
+				t.Errorf("eth0: got enabled %v, mtu %d, want false, 1500", i.Enabled, i.GetMtu())
+			}
+		},
+	}, {
+		desc:         "patch missing list entry",
+		method:       http.MethodPatch,
+		uri:          "/restconf/data/openconfig-interfaces:interfaces/interface=eth1",
+		body:         `{"openconfig-interfaces:interface": [{"name": "eth1"}]}`,
+		wantStatus:   http.StatusNotFound,
+		wantErrorTag: "invalid-value",
+	}, {
+		desc:         "invalid value",
+		method:       http.MethodPut,
+		uri:          "/restconf/data/openconfig-interfaces:interfaces/interface=eth0/config/mtu",
+		body:         `{"openconfig-interfaces:mtu": "fish"}`,
+		wantStatus:   http.StatusBadRequest,
+		wantErrorTag: "invalid-value",
+	}, {
+		desc:       "delete leaf",
+		method:     http.MethodDelete,
+		uri:        "/restconf/data/openconfig-interfaces:interfaces/interface=eth0/config/description",
+		wantStatus: http.StatusNoContent,
+		check: func(t *testing.T, d *exampleoc.Device) {
+			if i := d.GetInterface("eth0"); i.Description != nil || i.GetMtu() != 1500 {
+				t.Errorf("eth0: got description %v, mtu %d, want nil, 1500", i.Description, i.GetMtu())
+			}
+		},
+	}, {
+		desc:       "delete list entry",
+		method:     http.MethodDelete,
+		uri:        "/restconf/data/openconfig-interfaces:interfaces/interface=eth0",
+		wantStatus: http.StatusNoContent,
+		check: func(t *testing.T, d *exampleoc.Device) {
+			if i := d.GetInterface("eth0"); i != nil {
+				t.Errorf("eth0: got %v, want deleted", i)
+			}
+		},
+	}, {
+		desc:         "delete missing leaf",
+		method:       http.MethodDelete,
+		uri:          "/restconf/data/openconfig-interfaces:interfaces/interface=eth0/config/type",
+		wantStatus:   http.StatusNotFound,
+		wantErrorTag: "data-missing",
+	}, {
+		desc:         "unsupported method",
+		method:       http.MethodPost,
+		uri:          "/restconf/data/openconfig-interfaces:interfaces",
+		wantStatus:   http.StatusMethodNotAllowed,
+		wantErrorTag: "operation-not-supported",
+	}, {
+		desc:         "not a data resource",
+		method:       http.MethodGet,
+		uri:          "/restconf/operations",
+		wantStatus:   http.StatusNotFound,
+		wantErrorTag: "invalid-value",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			h, store := newTestHandler(t)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.uri, strings.NewReader(tt.body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d, body: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantBody != "" {
+				var got, want any
+				if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+					t.Fatalf("cannot decode response body %s: %v", rec.Body, err)
+				}
+				if err := json.Unmarshal([]byte(tt.wantBody), &want); err != nil {
+					t.Fatalf("cannot decode wantBody: %v", err)
+				}
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("response body (-want, +got):\n%s", diff)
+				}
+			}
+			if tt.wantErrorTag != "" {
+				var got struct {
+					Errors struct {
+						Error []struct {
+							Tag  string `json:"error-tag"`
+							Path string `json:"error-path"`
+						} `json:"error"`
+					} `json:"ietf-restconf:errors"`
+				}
+				if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+					t.Fatalf("cannot decode errors object %s: %v", rec.Body, err)
+				}
+				if len(got.Errors.Error) != 1 || got.Errors.Error[0].Tag != tt.wantErrorTag {
+					t.Errorf("got errors %s, want error-tag %s", rec.Body, tt.wantErrorTag)
+				}
+			}
+			if tt.check != nil {
+				root, _ := store.Load(context.Background())
+				tt.check(t, root.(*exampleoc.Device))
+			}
+		})
+	}
+}
+
+func TestDatastore(t *testing.T) {
+	h, store := newTestHandler(t)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/restconf/data", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET datastore: got status %d, body: %s", rec.Code, rec.Body)
+	}
+	if got := rec.Header().Get("Content-Type"); got != MediaType {
+		t.Errorf("GET datastore: got Content-Type %s, want %s", got, MediaType)
+	}
+	body := rec.Body.String()
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/restconf/data", nil))
+	root, _ := store.Load(context.Background())
+	if got := root.(*exampleoc.Device).GetInterface("eth0"); got != nil {
+		t.Fatalf("DELETE datastore: eth0 not deleted")
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/restconf/data", strings.NewReader(body)))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("PUT datastore: got status %d, body: %s", rec.Code, rec.Body)
+	}
+	root, _ = store.Load(context.Background())
+	if got := root.(*exampleoc.Device).GetInterface("eth0").GetDescription(); got != "uplink" {
+		t.Errorf("PUT datastore: got description %q, want uplink", got)
+	}
+}
+
+func TestErrorPath(t *testing.T) {
+	h, _ := newTestHandler(t)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/restconf/data/openconfig-interfaces:interfaces/interface=eth1/config", nil))
+
+	want := `{
+  "ietf-restconf:errors": {
+    "error": [
+      {
+        "error-type": "protocol",
+        "error-tag": "invalid-value",
+        "error-path": "/openconfig-interfaces:interfaces/interface[name='eth1']/config",
+        "error-message": "data node does not exist"
+      }
+    ]
+  }
+}`
+	if diff := cmp.Diff(want, rec.Body.String()); diff != "" {
+		t.Errorf("errors object (-want, +got):\n%s", diff)
+	}
+}
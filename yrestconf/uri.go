@@ -0,0 +1,173 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yrestconf
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// segment is a segment of the path of a RESTCONF data resource URI, which
+// identifies a data node.
+type segment struct {
+	// module is the name of the module of the data node, which is set for
+	// every segment, whether or not the node is qualified in the URI.
+	module string
+	// qualified is whether the node is qualified by its module in the URI.
+	qualified bool
+	// name is the name of the data node.
+	name string
+	// keys is the set of values of the keys of a list entry, in the order
+	// of the key statement of the list, or nil if the segment does not
+	// identify a list entry.
+	keys []string
+	// entry is the schema entry of the data node.
+	entry *yang.Entry
+}
+
+// parsePath parses the escaped path p of a data resource, relative to the
+// datastore resource, into its segments, as described by RFC8040 Section
+// 3.5.3. The data nodes are resolved against the children of the schema
+// entry root. An empty p identifies the datastore itself, for which no
+// segments are returned.
+func parsePath(root *yang.Entry, p string) ([]*segment, error) {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil, nil
+	}
+	var segs []*segment
+	e := root
+	module := ""
+	for _, s := range strings.Split(p, "/") {
+		name, keys, hasKeys := strings.Cut(s, "=")
+		name, err := url.PathUnescape(name)
+		if err != nil {
+			return nil, errorf(http.StatusBadRequest, ErrorTypeProtocol, "invalid-value", "invalid path segment %q: %v", s, err)
+		}
+		seg := &segment{name: name}
+		if m, n, ok := strings.Cut(name, ":"); ok {
+			seg.module, seg.name, seg.qualified = m, n, true
+			module = m
+		}
+		if module == "" {
+			return nil, errorf(http.StatusBadRequest, ErrorTypeProtocol, "invalid-value", "top-level node %s is not qualified by its module", name)
+		}
+		seg.module = module
+
+		if !e.IsDir() {
+			return nil, errorf(http.StatusBadRequest, ErrorTypeProtocol, "invalid-value", "%s is not a container or list, so has no child %s", e.Name, seg.name)
+		}
+		if seg.entry = child(e, seg.name); seg.entry == nil {
+			return nil, errorf(http.StatusBadRequest, ErrorTypeProtocol, "invalid-value", "unknown node %s", name)
+		}
+
+		if hasKeys {
+			if !seg.entry.IsList() || seg.entry.Key == "" {
+				return nil, errorf(http.StatusBadRequest, ErrorTypeProtocol, "invalid-value", "%s is not a keyed list", name)
+			}
+			names := strings.Fields(seg.entry.Key)
+			vals := strings.Split(keys, ",")
+			if len(vals) != len(names) {
+				return nil, errorf(http.StatusBadRequest, ErrorTypeProtocol, "invalid-value", "list %s has %d keys, got %d values", name, len(names), len(vals))
+			}
+			for _, v := range vals {
+				uv, err := url.PathUnescape(v)
+				if err != nil {
+					return nil, errorf(http.StatusBadRequest, ErrorTypeProtocol, "invalid-value", "invalid key value %q of list %s: %v", v, name, err)
+				}
+				seg.keys = append(seg.keys, uv)
+			}
+		}
+		segs = append(segs, seg)
+		e = seg.entry
+	}
+	// Only the last segment may identify a list, rather than an entry of
+	// it.
+	for _, s := range segs[:len(segs)-1] {
+		if s.entry.IsList() && s.keys == nil {
+			return nil, errorf(http.StatusBadRequest, ErrorTypeProtocol, "invalid-value", "no keys are specified for list %s", s.name)
+		}
+	}
+	return segs, nil
+}
+
+// child returns the data node child of the directory entry e with the name
+// name, for which choice and case nodes are transparent, or nil if there is
+// no such child.
+func child(e *yang.Entry, name string) *yang.Entry {
+	if ch, ok := e.Dir[name]; ok && !ch.IsChoice() && !ch.IsCase() {
+		return ch
+	}
+	for _, ch := range util.FindFirstNonChoiceOrCase(e) {
+		if ch.Name == name {
+			return ch
+		}
+	}
+	return nil
+}
+
+// gnmiPath returns the gNMI path of the data node identified by segs.
+func gnmiPath(segs []*segment) *gpb.Path {
+	p := &gpb.Path{}
+	for _, s := range segs {
+		pe := &gpb.PathElem{Name: s.name}
+		if s.keys != nil {
+			pe.Key = map[string]string{}
+			for i, k := range strings.Fields(s.entry.Key) {
+				pe.Key[k] = s.keys[i]
+			}
+		}
+		p.Elem = append(p.Elem, pe)
+	}
+	return p
+}
+
+// instanceIdentifier returns the instance-identifier of the data node
+// identified by segs, as it is encoded in RFC7951 JSON, which is used as
+// the error-path of errors.
+func instanceIdentifier(segs []*segment) string {
+	if len(segs) == 0 {
+		return "/"
+	}
+	var b strings.Builder
+	for _, s := range segs {
+		b.WriteString("/")
+		if s.qualified {
+			fmt.Fprintf(&b, "%s:", s.module)
+		}
+		b.WriteString(s.name)
+		for i, k := range strings.Fields(s.entry.Key) {
+			if i < len(s.keys) {
+				fmt.Fprintf(&b, "[%s=%s]", k, quote(s.keys[i]))
+			}
+		}
+	}
+	return b.String()
+}
+
+// quote returns v quoted as a string of an instance-identifier predicate.
+func quote(v string) string {
+	if strings.Contains(v, "'") {
+		return `"` + v + `"`
+	}
+	return "'" + v + "'"
+}
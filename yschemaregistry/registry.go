@@ -0,0 +1,415 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package yschemaregistry provides a registry of parsed YANG schemas, such
+// that a set of YANG modules is parsed once and the resulting schema tree is
+// shared between processes, rather than each process parsing the same
+// modules at startup.
+//
+// Schemas are keyed by a hash of the content of the YANG modules that they
+// are parsed from, along with the options used to parse them. A Registry
+// caches each schema in memory and, optionally, in a directory on disk, in
+// the gzipped JSON format that is used by generated code, such that it can
+// be loaded using ygot.GzipToSchema. A Registry can also serve its schemas
+// over HTTP, and they can be retrieved by other processes using Fetch.
+package yschemaregistry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/gogen"
+	"github.com/openconfig/ygot/ygen"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// schemaFileSuffix is the suffix of the name of the files in which schemas
+// are cached on disk.
+const schemaFileSuffix = ".json.gz"
+
+// Schema is a parsed YANG schema.
+type Schema struct {
+	// Key is the key that identifies the schema within a registry.
+	Key string
+	// Tree is the schema tree, keyed by the name of the generated struct
+	// that corresponds to each entry, as returned by ygot.GzipToSchema.
+	Tree map[string]*yang.Entry
+	// Gzipped is the gzipped JSON serialisation of the schema tree, in the
+	// format that is embedded within generated code.
+	Gzipped []byte
+}
+
+// Registry is a cache of parsed YANG schemas. It is safe for concurrent
+// use.
+type Registry struct {
+	// dir is the directory in which schemas are cached on disk, or the
+	// empty string if they are cached only in memory.
+	dir string
+	// opts are the options used to generate the IR from which each
+	// schema is produced.
+	opts ygen.IROptions
+	// simpleUnions specifies whether the struct names within the schema
+	// tree are those of code generated with simple unions.
+	simpleUnions bool
+
+	// mu protects schemas.
+	mu sync.Mutex
+	// schemas is the set of schemas that have been loaded, or are being
+	// loaded, keyed by their key.
+	schemas map[string]*entry
+}
+
+// entry is a schema that has been loaded, or is being loaded, by a
+// Registry.
+type entry struct {
+	// once ensures that the schema is loaded only once.
+	once   sync.Once
+	schema *Schema
+	err    error
+	// loaded is true once the schema has been loaded successfully. It is
+	// protected by the mu field of the Registry.
+	loaded bool
+}
+
+// New returns a Registry that parses YANG modules using opts, producing
+// schema trees whose struct names match those of code generated by the Go
+// code generator with the supplied simple unions setting. If dir is not the
+// empty string, schemas are cached within it, such that they are shared
+// with other Registries using the same directory, including those in other
+// processes.
+func New(dir string, opts ygen.IROptions, simpleUnions bool) *Registry {
+	opts.PhaseTimer = nil
+	return &Registry{
+		dir:          dir,
+		opts:         opts,
+		simpleUnions: simpleUnions,
+		schemas:      map[string]*entry{},
+	}
+}
+
+// Load returns the schema for the YANG modules in yangFiles, with any
+// modules that they import or include searched for in includePaths. The
+// modules are parsed only if the schema is not already cached, in memory or
+// on disk. The key of the schema is computed from the content of yangFiles,
+// and of all files with the ".yang" extension beneath includePaths, such
+// that a change to any of them results in the modules being parsed again.
+func (r *Registry) Load(yangFiles, includePaths []string) (*Schema, error) {
+	key, err := r.Key(yangFiles, includePaths)
+	if err != nil {
+		return nil, err
+	}
+	return r.load(key, func() (*Schema, error) {
+		if s, err := r.readCache(key); err == nil {
+			return s, nil
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+		s, err := r.parse(key, yangFiles, includePaths)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.writeCache(s); err != nil {
+			return nil, err
+		}
+		return s, nil
+	})
+}
+
+// Get returns the schema with the supplied key, which must have been
+// loaded by the Registry, or by another Registry that shares its cache
+// directory. It returns an error that satisfies os.IsNotExist if the schema
+// is not found.
+func (r *Registry) Get(key string) (*Schema, error) {
+	if !validKey(key) {
+		return nil, fmt.Errorf("invalid schema key %q", key)
+	}
+	return r.load(key, func() (*Schema, error) { return r.readCache(key) })
+}
+
+// Keys returns the keys of the schemas that are held by the Registry,
+// including those that are cached on disk, in sorted order.
+func (r *Registry) Keys() ([]string, error) {
+	keys := map[string]bool{}
+	r.mu.Lock()
+	for k, e := range r.schemas {
+		if e.loaded {
+			keys[k] = true
+		}
+	}
+	r.mu.Unlock()
+
+	if r.dir != "" {
+		files, err := os.ReadDir(r.dir)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		for _, f := range files {
+			if k, ok := strings.CutSuffix(f.Name(), schemaFileSuffix); ok && validKey(k) {
+				keys[k] = true
+			}
+		}
+	}
+
+	out := make([]string, 0, len(keys))
+	for k := range keys {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// load returns the schema with the supplied key, calling fn to load it if
+// it is not held in memory. Concurrent callers for the same key share a
+// single call to fn. Failures are not retained, such that the load is
+// retried by subsequent callers.
+func (r *Registry) load(key string, fn func() (*Schema, error)) (*Schema, error) {
+	r.mu.Lock()
+	e, ok := r.schemas[key]
+	if !ok {
+		e = &entry{}
+		r.schemas[key] = e
+	}
+	r.mu.Unlock()
+
+	e.once.Do(func() { e.schema, e.err = fn() })
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if e.err != nil {
+		if r.schemas[key] == e {
+			delete(r.schemas, key)
+		}
+		return nil, e.err
+	}
+	e.loaded = true
+	return e.schema, nil
+}
+
+// Key returns the key of the schema for the YANG modules in yangFiles,
+// with any modules that they import or include searched for in
+// includePaths, without parsing them.
+func (r *Registry) Key(yangFiles, includePaths []string) (string, error) {
+	h := sha256.New()
+	opts, err := json.Marshal(struct {
+		IROptions    ygen.IROptions
+		SimpleUnions bool
+	}{r.opts, r.simpleUnions})
+	if err != nil {
+		return "", fmt.Errorf("cannot serialise options: %v", err)
+	}
+	h.Write(opts)
+
+	files := append([]string{}, yangFiles...)
+	for _, p := range includePaths {
+		// Include paths may use the "/..." suffix to indicate that
+		// their subdirectories are also searched, which is always the
+		// case here.
+		err := filepath.WalkDir(strings.TrimSuffix(p, "/..."), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && filepath.Ext(path) == ".yang" {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return "", fmt.Errorf("cannot read include path %s: %v", p, err)
+		}
+	}
+
+	// Files are hashed by their base name and content, such that the key
+	// does not depend upon where the modules are located.
+	type file struct{ name, digest string }
+	var hashed []file
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			return "", err
+		}
+		d := sha256.Sum256(b)
+		hashed = append(hashed, file{filepath.Base(f), hex.EncodeToString(d[:])})
+	}
+	sort.Slice(hashed, func(i, j int) bool {
+		if hashed[i].name != hashed[j].name {
+			return hashed[i].name < hashed[j].name
+		}
+		return hashed[i].digest < hashed[j].digest
+	})
+	for i, f := range hashed {
+		if i != 0 && f == hashed[i-1] {
+			continue
+		}
+		fmt.Fprintf(h, "%s %s\n", f.name, f.digest)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// parse parses the YANG modules, returning the schema with the supplied
+// key.
+func (r *Registry) parse(key string, yangFiles, includePaths []string) (*Schema, error) {
+	ir, err := ygen.GenerateIR(yangFiles, includePaths, gogen.NewGoLangMapper(r.simpleUnions), r.opts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse YANG modules: %v", err)
+	}
+	raw, err := ir.SchemaTree(false)
+	if err != nil {
+		return nil, fmt.Errorf("cannot serialise schema tree: %v", err)
+	}
+	gz, err := ygen.WriteGzippedByteSlice(raw)
+	if err != nil {
+		return nil, fmt.Errorf("cannot compress schema tree: %v", err)
+	}
+	return newSchema(key, gz)
+}
+
+// newSchema returns the schema with the supplied key whose gzipped JSON
+// serialisation is gz.
+func newSchema(key string, gz []byte) (*Schema, error) {
+	tree, err := ygot.GzipToSchema(gz)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load schema %s: %v", key, err)
+	}
+	return &Schema{Key: key, Tree: tree, Gzipped: gz}, nil
+}
+
+// readCache reads the schema with the supplied key from the cache
+// directory. It returns an error that satisfies os.IsNotExist if the schema
+// is not cached.
+func (r *Registry) readCache(key string) (*Schema, error) {
+	if r.dir == "" {
+		return nil, os.ErrNotExist
+	}
+	gz, err := os.ReadFile(filepath.Join(r.dir, key+schemaFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+	return newSchema(key, gz)
+}
+
+// writeCache writes s to the cache directory. The schema is written to a
+// temporary file that is renamed into place, such that concurrent readers
+// never observe a partially written schema.
+func (r *Registry) writeCache(s *Schema) error {
+	if r.dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return fmt.Errorf("cannot create cache directory: %v", err)
+	}
+	f, err := os.CreateTemp(r.dir, s.Key+".tmp*")
+	if err != nil {
+		return fmt.Errorf("cannot cache schema %s: %v", s.Key, err)
+	}
+	if _, err := f.Write(s.Gzipped); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return fmt.Errorf("cannot cache schema %s: %v", s.Key, err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("cannot cache schema %s: %v", s.Key, err)
+	}
+	if err := os.Rename(f.Name(), filepath.Join(r.dir, s.Key+schemaFileSuffix)); err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("cannot cache schema %s: %v", s.Key, err)
+	}
+	return nil
+}
+
+// validKey reports whether key is a well-formed schema key, such that it
+// can safely be used as a file name.
+func validKey(key string) bool {
+	if len(key) != 2*sha256.Size {
+		return false
+	}
+	_, err := hex.DecodeString(key)
+	return err == nil && strings.ToLower(key) == key
+}
+
+// Handler returns an HTTP handler that serves the schemas held by the
+// Registry. A GET request for "/" returns a JSON array of the keys of the
+// available schemas, and a GET request for "/<key>" returns the gzipped JSON
+// serialisation of the schema with that key. The handler may be mounted
+// under a prefix using http.StripPrefix.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		key := strings.TrimPrefix(req.URL.Path, "/")
+		if key == "" {
+			keys, err := r.Keys()
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(keys)
+			return
+		}
+		s, err := r.Get(key)
+		switch {
+		case os.IsNotExist(err):
+			http.NotFound(w, req)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Write(s.Gzipped)
+	})
+}
+
+// Fetch retrieves the schema with the supplied key from the Registry
+// served by Handler at baseURL, using client, or http.DefaultClient if it
+// is nil.
+func Fetch(ctx context.Context, client *http.Client, baseURL, key string) (*Schema, error) {
+	if !validKey(key) {
+		return nil, fmt.Errorf("invalid schema key %q", key)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(baseURL, "/")+"/"+key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch schema %s: %v", key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cannot fetch schema %s: %s", key, resp.Status)
+	}
+	gz, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch schema %s: %v", key, err)
+	}
+	return newSchema(key, gz)
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yschemaregistry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/ygen"
+)
+
+// testModules copies the YANG modules used by the tests to a temporary
+// directory, returning the path of the module to be parsed and the include
+// path.
+func testModules(t *testing.T) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	for _, m := range []string{"openconfig-simple.yang", "openconfig-remote.yang"} {
+		b, err := os.ReadFile(filepath.Join("..", "testdata", "modules", m))
+		if err != nil {
+			t.Fatalf("cannot read test module: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, m), b, 0o644); err != nil {
+			t.Fatalf("cannot write test module: %v", err)
+		}
+	}
+	return filepath.Join(dir, "openconfig-simple.yang"), dir
+}
+
+func testOpts() ygen.IROptions {
+	return ygen.IROptions{
+		TransformationOptions: ygen.TransformationOpts{
+			CompressBehaviour: genutil.PreferIntendedConfig,
+			GenerateFakeRoot:  true,
+			FakeRootName:      "device",
+		},
+	}
+}
+
+func TestLoad(t *testing.T) {
+	file, include := testModules(t)
+	cache := t.TempDir()
+
+	r := New(cache, testOpts(), true)
+	s, err := r.Load([]string{file}, []string{include})
+	if err != nil {
+		t.Fatalf("Load: got unexpected error: %v", err)
+	}
+	for _, name := range []string{"Device", "Parent_Child"} {
+		if _, ok := s.Tree[name]; !ok {
+			t.Errorf("Load: schema tree does not contain %s", name)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(cache, s.Key+schemaFileSuffix)); err != nil {
+		t.Errorf("Load: schema was not cached on disk: %v", err)
+	}
+
+	again, err := r.Load([]string{file}, []string{include})
+	if err != nil {
+		t.Fatalf("second Load: got unexpected error: %v", err)
+	}
+	if again != s {
+		t.Errorf("second Load: got a different schema, want the schema cached in memory")
+	}
+
+	// A Registry sharing the cache directory retrieves the schema from
+	// disk.
+	other := New(cache, testOpts(), true)
+	got, err := other.Get(s.Key)
+	if err != nil {
+		t.Fatalf("Get from disk: got unexpected error: %v", err)
+	}
+	if !bytes.Equal(got.Gzipped, s.Gzipped) {
+		t.Errorf("Get from disk: got different serialised schema")
+	}
+	keys, err := other.Keys()
+	if err != nil {
+		t.Fatalf("Keys: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{s.Key}, keys); diff != "" {
+		t.Errorf("Keys: (-want, +got):\n%s", diff)
+	}
+
+	// Changes to options or to the content of the modules change the key.
+	opts := testOpts()
+	opts.TransformationOptions.FakeRootName = "root"
+	if k, err := New(cache, opts, true).Key([]string{file}, []string{include}); err != nil || k == s.Key {
+		t.Errorf("Key with different options: got (%s, %v), want a new key", k, err)
+	}
+	if err := os.WriteFile(filepath.Join(include, "openconfig-remote.yang"), []byte("// changed\n"), 0o644); err != nil {
+		t.Fatalf("cannot modify test module: %v", err)
+	}
+	if k, err := r.Key([]string{file}, []string{include}); err != nil || k == s.Key {
+		t.Errorf("Key with changed module: got (%s, %v), want a new key", k, err)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	r := New("", testOpts(), true)
+	if _, err := r.Load([]string{filepath.Join(t.TempDir(), "missing.yang")}, nil); err == nil {
+		t.Errorf("Load of missing module: got nil error, want error")
+	}
+
+	dir := t.TempDir()
+	bad := filepath.Join(dir, "bad.yang")
+	if err := os.WriteFile(bad, []byte("module bad {"), 0o644); err != nil {
+		t.Fatalf("cannot write test module: %v", err)
+	}
+	if _, err := r.Load([]string{bad}, nil); err == nil || !strings.Contains(err.Error(), "cannot parse YANG modules") {
+		t.Errorf("Load of invalid module: got %v, want parse error", err)
+	}
+	if keys, err := r.Keys(); err != nil || len(keys) != 0 {
+		t.Errorf("Keys after failed Load: got (%v, %v), want no keys", keys, err)
+	}
+
+	if _, err := r.Get("../../etc/passwd"); err == nil || !strings.Contains(err.Error(), "invalid schema key") {
+		t.Errorf("Get with invalid key: got %v, want invalid key error", err)
+	}
+	if _, err := r.Get(strings.Repeat("0", 64)); !os.IsNotExist(err) {
+		t.Errorf("Get of unknown key: got %v, want not exist error", err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	file, include := testModules(t)
+	r := New("", testOpts(), true)
+	s, err := r.Load([]string{file}, []string{include})
+	if err != nil {
+		t.Fatalf("Load: got unexpected error: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/schemas/", http.StripPrefix("/schemas", r.Handler()))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	got, err := Fetch(context.Background(), srv.Client(), srv.URL+"/schemas", s.Key)
+	if err != nil {
+		t.Fatalf("Fetch: got unexpected error: %v", err)
+	}
+	if !bytes.Equal(got.Gzipped, s.Gzipped) {
+		t.Errorf("Fetch: got different serialised schema")
+	}
+	if _, ok := got.Tree["Parent_Child"]; !ok {
+		t.Errorf("Fetch: schema tree does not contain Parent_Child")
+	}
+
+	if _, err := Fetch(context.Background(), srv.Client(), srv.URL+"/schemas", strings.Repeat("0", 64)); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Fetch of unknown key: got %v, want not found error", err)
+	}
+
+	resp, err := srv.Client().Get(srv.URL + "/schemas/")
+	if err != nil {
+		t.Fatalf("cannot list schemas: %v", err)
+	}
+	defer resp.Body.Close()
+	var keys []string
+	if err := json.NewDecoder(resp.Body).Decode(&keys); err != nil {
+		t.Fatalf("cannot decode schema list: %v", err)
+	}
+	if diff := cmp.Diff([]string{s.Key}, keys); diff != "" {
+		t.Errorf("schema list: (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ysnapshot maintains a ygot-generated GoStruct data tree from which
+// consistent, read-only snapshots can be taken cheaply whilst the tree
+// continues to be written to, such that a gNMI server can serve a Get or
+// Subscribe request from a snapshot without copying the data tree, or
+// blocking writers.
+//
+// Snapshots are immutable. Writes are made within a transaction, which
+// copies each container or list entry that it modifies, along with its
+// ancestors, rather than modifying it in place; all other containers are
+// shared between the new data tree and the snapshots of earlier versions of
+// it. The cost of a write is hence proportional to the size of the
+// containers along the paths that it modifies, rather than to the size of
+// the data tree.
+package ysnapshot
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+)
+
+// Snapshot is a read-only version of the data tree of a Store.
+type Snapshot struct {
+	// root is the root of the data tree.
+	root ygot.GoStruct
+	// version is the number of transactions that had been committed to
+	// the Store when the snapshot was taken.
+	version uint64
+}
+
+// Root returns the root of the data tree of the snapshot. Its contents, and
+// those of all of its descendants, must not be modified.
+func (s *Snapshot) Root() ygot.GoStruct {
+	return s.root
+}
+
+// Version returns the number of transactions that had been committed to the
+// Store when the snapshot was taken.
+func (s *Snapshot) Version() uint64 {
+	return s.version
+}
+
+// Store holds a data tree that is written to by transactions, and from
+// which snapshots can be taken. A Store is safe for concurrent use.
+// Transactions are serialised, whereas snapshots can be taken, and read,
+// concurrently with transactions.
+type Store struct {
+	// schema is the schema of the data tree.
+	schema *ytypes.Schema
+	// mu serialises transactions.
+	mu sync.Mutex
+	// current is the snapshot of the latest version of the data tree.
+	current atomic.Pointer[Snapshot]
+}
+
+// New returns a Store of the data tree root, which is described by schema.
+// The Store takes ownership of root, which must not be modified after it is
+// supplied. If root is nil, the data tree is initially empty.
+func New(schema *ytypes.Schema, root ygot.GoStruct) (*Store, error) {
+	if schema == nil || !schema.IsValid() {
+		return nil, fmt.Errorf("invalid schema %v", schema)
+	}
+	if root == nil {
+		root = schema.NewRoot()
+	}
+	s := &Store{schema: schema}
+	s.current.Store(&Snapshot{root: root})
+	return s, nil
+}
+
+// Snapshot returns the snapshot of the latest version of the data tree.
+func (s *Store) Snapshot() *Snapshot {
+	return s.current.Load()
+}
+
+// Update runs fn within a transaction on the latest version of the data
+// tree. If fn returns nil, the modifications made by the transaction are
+// committed, and the snapshot of the new version of the data tree is
+// returned. Otherwise, the modifications are discarded, and the error
+// returned by fn is returned.
+func (s *Store) Update(fn func(*Txn) error) (*Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.current.Load()
+	t := newTxn(s.schema, prev.root)
+	if err := fn(t); err != nil {
+		return nil, err
+	}
+	if !t.modified() {
+		return prev, nil
+	}
+	next := &Snapshot{root: t.root.Interface().(ygot.GoStruct), version: prev.version + 1}
+	s.current.Store(next)
+	return next, nil
+}
@@ -0,0 +1,237 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ysnapshot
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func mustPath(t *testing.T, s string) *gpb.Path {
+	t.Helper()
+	p, err := ygot.StringToStructuredPath(s)
+	if err != nil {
+		t.Fatalf("cannot parse path %s: %v", s, err)
+	}
+	return p
+}
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	d := &exampleoc.Device{}
+	for _, n := range []string{"eth0", "eth1"} {
+		d.GetOrCreateInterface(n).Mtu = ygot.Uint16(1500)
+	}
+	d.GetOrCreateSystem().Hostname = ygot.String("dev1")
+	if _, err := d.GetOrCreateSystem().GetOrCreateDns().GetOrCreateServerMap().AppendNew("192.0.2.1"); err != nil {
+		t.Fatalf("cannot append DNS server: %v", err)
+	}
+	s, err := New(&ytypes.Schema{Root: &exampleoc.Device{}, SchemaTree: exampleoc.SchemaTree, Unmarshal: exampleoc.Unmarshal}, d)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return s
+}
+
+func device(s *Snapshot) *exampleoc.Device {
+	return s.Root().(*exampleoc.Device)
+}
+
+func TestUpdate(t *testing.T) {
+	s := newTestStore(t)
+	before := s.Snapshot()
+
+	after, err := s.Update(func(tx *Txn) error {
+		if err := tx.SetNode(mustPath(t, "/interfaces/interface[name=eth0]/config/mtu"), &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: 9000}}); err != nil {
+			return err
+		}
+		d, err := tx.Mutable(&gpb.Path{})
+		if err != nil {
+			return err
+		}
+		if _, err := d.(*exampleoc.Device).NewInterface("eth2"); err != nil {
+			return err
+		}
+		return tx.SetNode(mustPath(t, "/system/dns/servers/server[address=192.0.2.1]/config/port"), &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: 5353}})
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	if got, want := after.Version(), before.Version()+1; got != want {
+		t.Errorf("version: got %d, want %d", got, want)
+	}
+	if got := device(s.Snapshot()); got != device(after) {
+		t.Errorf("Snapshot did not return the latest version")
+	}
+
+	// The earlier snapshot is unchanged.
+	b, a := device(before), device(after)
+	if got := b.GetInterface("eth0").GetMtu(); got != 1500 {
+		t.Errorf("mtu of eth0 before update: got %d, want 1500", got)
+	}
+	if got := b.GetInterface("eth2"); got != nil {
+		t.Errorf("eth2 before update: got %v, want nil", got)
+	}
+	if got := b.GetSystem().GetDns().Server.Get("192.0.2.1").Port; got != nil {
+		t.Errorf("DNS server port before update: got %d, want nil", *got)
+	}
+	if got := a.GetInterface("eth0").GetMtu(); got != 9000 {
+		t.Errorf("mtu of eth0 after update: got %d, want 9000", got)
+	}
+	if got := a.GetInterface("eth2"); got == nil {
+		t.Errorf("eth2 after update: got nil, want interface")
+	}
+	if got := a.GetSystem().GetDns().Server.Get("192.0.2.1").GetPort(); got != 5353 {
+		t.Errorf("DNS server port after update: got %d, want 5353", got)
+	}
+
+	// Containers that were not modified are shared.
+	if b.GetInterface("eth1") != a.GetInterface("eth1") {
+		t.Errorf("eth1 was copied, want shared")
+	}
+	if b.GetInterface("eth0") == a.GetInterface("eth0") {
+		t.Errorf("eth0 was shared, want copied")
+	}
+	if b.GetSystem() == a.GetSystem() {
+		t.Errorf("system was shared, want copied")
+	}
+}
+
+func TestUpdateSharing(t *testing.T) {
+	s := newTestStore(t)
+	before := s.Snapshot()
+	after, err := s.Update(func(tx *Txn) error {
+		return tx.SetNode(mustPath(t, "/interfaces/interface[name=eth0]/config/description"), &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "uplink"}})
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if device(before).GetSystem() != device(after).GetSystem() {
+		t.Errorf("system was copied, want shared")
+	}
+}
+
+func TestUpdateDelete(t *testing.T) {
+	s := newTestStore(t)
+	before := s.Snapshot()
+	after, err := s.Update(func(tx *Txn) error {
+		if err := tx.DeleteNode(mustPath(t, "/interfaces/interface[name=eth1]")); err != nil {
+			return err
+		}
+		return tx.DeleteNode(mustPath(t, "/system/dns/servers/server[address=192.0.2.1]"))
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if device(before).GetInterface("eth1") == nil || device(before).GetSystem().GetDns().Server.Len() != 1 {
+		t.Errorf("deletion modified the earlier snapshot")
+	}
+	if device(after).GetInterface("eth1") != nil || device(after).GetSystem().GetDns().Server.Len() != 0 {
+		t.Errorf("deletion not applied, got interfaces %v, DNS servers %v", device(after).Interface, device(after).GetSystem().GetDns().Server.Keys())
+	}
+}
+
+func TestUpdateError(t *testing.T) {
+	s := newTestStore(t)
+	before := s.Snapshot()
+	wantErr := errors.New("abort")
+	_, err := s.Update(func(tx *Txn) error {
+		i, err := tx.Mutable(mustPath(t, "/interfaces/interface[name=eth0]"))
+		if err != nil {
+			return err
+		}
+		i.(*exampleoc.Interface).Mtu = ygot.Uint16(9000)
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("Update: got error %v, want %v", err, wantErr)
+	}
+	if got := s.Snapshot(); got != before {
+		t.Errorf("failed transaction changed the snapshot to version %d", got.Version())
+	}
+	if got := device(s.Snapshot()).GetInterface("eth0").GetMtu(); got != 1500 {
+		t.Errorf("mtu of eth0: got %d, want 1500", got)
+	}
+
+	if got, err := s.Update(func(*Txn) error { return nil }); err != nil || got != before {
+		t.Errorf("empty transaction: got version %d, err %v, want unchanged snapshot", got.Version(), err)
+	}
+}
+
+func TestMutable(t *testing.T) {
+	s := newTestStore(t)
+	_, err := s.Update(func(tx *Txn) error {
+		c, err := tx.Mutable(mustPath(t, "/interfaces/interface[name=eth0]/subinterfaces/subinterface[index=0]"))
+		if err == nil {
+			return fmt.Errorf("Mutable of missing list entry: got %v, want error", c)
+		}
+		r, err := tx.Mutable(mustPath(t, "/system/clock"))
+		if err != nil {
+			return fmt.Errorf("Mutable of missing container: %v", err)
+		}
+		r.(*exampleoc.System_Clock).TimezoneName = ygot.String("UTC")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got := device(s.Snapshot()).GetSystem().GetClock().GetTimezoneName(); got != "UTC" {
+		t.Errorf("timezone: got %q, want UTC", got)
+	}
+}
+
+func TestConcurrentSnapshots(t *testing.T) {
+	s := newTestStore(t)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				snap := s.Snapshot()
+				d := device(snap)
+				// Each snapshot is consistent: both interfaces were
+				// updated by the same transaction.
+				if m0, m1 := d.GetInterface("eth0").GetMtu(), d.GetInterface("eth1").GetMtu(); m0 != m1 {
+					t.Errorf("inconsistent snapshot %d: mtu %d and %d", snap.Version(), m0, m1)
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		_, err := s.Update(func(tx *Txn) error {
+			for _, n := range []string{"eth0", "eth1"} {
+				if err := tx.SetNode(mustPath(t, fmt.Sprintf("/interfaces/interface[name=%s]/config/mtu", n)), &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: uint64(1500 + i)}}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+	}
+	wg.Wait()
+}
@@ -0,0 +1,335 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ysnapshot
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Txn is a transaction that modifies the data tree of a Store. The
+// containers and list entries of the data tree are shared with snapshots,
+// so must not be modified unless they are returned by Root or Mutable,
+// which return copies of them that are private to the transaction. Leaves
+// are modified by replacing the value of their field, rather than by
+// modifying the value that it points to, which may also be shared. A Txn
+// must not be used after the function that it was supplied to returns.
+type Txn struct {
+	// schema is the schema of the data tree.
+	schema *ytypes.Schema
+	// root is the root of the data tree that is being modified.
+	root reflect.Value
+	// owned is the set of containers and list entries that have been
+	// copied by the transaction, and hence can be modified in place.
+	owned map[any]bool
+}
+
+// newTxn returns a transaction that modifies the data tree root, which is
+// described by schema.
+func newTxn(schema *ytypes.Schema, root ygot.GoStruct) *Txn {
+	return &Txn{schema: schema, root: reflect.ValueOf(root), owned: map[any]bool{}}
+}
+
+// modified returns whether the transaction has copied any part of the data
+// tree, and hence may have modified it.
+func (t *Txn) modified() bool {
+	return len(t.owned) != 0
+}
+
+// Root returns the root of the data tree, which can be modified.
+func (t *Txn) Root() ygot.GoStruct {
+	t.root = t.own(t.root)
+	return t.root.Interface().(ygot.GoStruct)
+}
+
+// Mutable returns the container or list entry at path, which can be
+// modified. Containers along path that do not exist are created, whereas
+// list entries along path must already exist.
+func (t *Txn) Mutable(path *gpb.Path) (ygot.GoStruct, error) {
+	v, rest := t.walk(path, true, false)
+	if len(rest) != 0 {
+		ps, _ := ygot.PathToString(&gpb.Path{Elem: rest})
+		return nil, status.Errorf(codes.NotFound, "no container or list entry at %s within %s", ps, v.Type())
+	}
+	return v.Interface().(ygot.GoStruct), nil
+}
+
+// SetNode sets the value of the node at path within the data tree to val,
+// as ytypes.SetNode does.
+func (t *Txn) SetNode(path *gpb.Path, val any, opts ...ytypes.SetNodeOpt) error {
+	v, rest := t.walk(path, false, true)
+	schema, err := t.schemaOf(v)
+	if err != nil {
+		return err
+	}
+	return ytypes.SetNode(schema, v.Interface(), &gpb.Path{Elem: rest}, val, opts...)
+}
+
+// DeleteNode deletes the node at path within the data tree, as
+// ytypes.DeleteNode does.
+func (t *Txn) DeleteNode(path *gpb.Path, opts ...ytypes.DelNodeOpt) error {
+	v, rest := t.walk(path, false, true)
+	schema, err := t.schemaOf(v)
+	if err != nil {
+		return err
+	}
+	return ytypes.DeleteNode(schema, v.Interface(), &gpb.Path{Elem: rest}, opts...)
+}
+
+// schemaOf returns the schema of the GoStruct v.
+func (t *Txn) schemaOf(v reflect.Value) (*yang.Entry, error) {
+	n := v.Elem().Type().Name()
+	s, ok := t.schema.SchemaTree[n]
+	if !ok {
+		return nil, fmt.Errorf("no schema for type %s", n)
+	}
+	return s, nil
+}
+
+// walk returns the deepest container or list entry along path that can be
+// reached, having copied it and each of its ancestors, along with the
+// elements of path beneath it. If create is true, containers that do not
+// exist are created. If parent is true, the node at path itself is not
+// copied, rather the walk stops at its parent.
+func (t *Txn) walk(path *gpb.Path, create, parent bool) (reflect.Value, []*gpb.PathElem) {
+	v := reflect.ValueOf(t.Root())
+	elems := path.GetElem()
+	for len(elems) != 0 {
+		next, n := t.child(v, elems, create, parent)
+		if n == 0 {
+			break
+		}
+		v, elems = next, elems[n:]
+	}
+	return v, elems
+}
+
+// child returns a copy of the child container or list entry of the owned
+// GoStruct v at the leading elements of elems, which replaces the original
+// within v, along with the number of elements of elems that it is at. It
+// returns 0 if there is no such child, which is the case if the elements
+// identify a leaf, or a list entry that does not exist. If create is true,
+// a child container that does not exist is created. If parent is true, no
+// child is returned if it is at all of elems.
+func (t *Txn) child(v reflect.Value, elems []*gpb.PathElem, create, parent bool) (reflect.Value, int) {
+	sv := v.Elem()
+	for i := 0; i < sv.NumField(); i++ {
+		paths, err := util.SchemaPaths(sv.Type().Field(i))
+		if err != nil {
+			continue
+		}
+		for _, p := range paths {
+			if !hasPrefix(elems, p) {
+				continue
+			}
+			n := len(p)
+			fv := sv.Field(i)
+			if _, ok := fv.Interface().(ygot.GoOrderedMap); ok && !fv.IsNil() {
+				// The ordered map is copied even if it is the parent of
+				// the path, since it is then modified by ytypes.
+				var keys map[string]string
+				if !(parent && n == len(elems)) && !hasKeys(elems[:n-1]) {
+					keys = elems[n-1].GetKey()
+				}
+				m, e := t.ownOrderedMap(fv, keys)
+				fv.Set(m)
+				if !e.IsValid() {
+					return v, 0
+				}
+				return e, n
+			}
+			if parent && n == len(elems) {
+				return v, 0
+			}
+			switch {
+			case util.IsTypeStructPtr(fv.Type()) && !hasKeys(elems[:n]):
+				if fv.IsNil() {
+					if !create {
+						return v, 0
+					}
+					fv.Set(reflect.New(fv.Type().Elem()))
+					t.owned[fv.Interface()] = true
+				}
+				fv.Set(t.own(fv))
+				return fv, n
+			case util.IsValueMap(fv) && !hasKeys(elems[:n-1]) && len(elems[n-1].GetKey()) != 0:
+				k, ok := mapKey(fv, elems[n-1].GetKey())
+				if !ok {
+					return v, 0
+				}
+				c := t.own(fv.MapIndex(k))
+				fv.SetMapIndex(k, c)
+				return c, n
+			}
+			return v, 0
+		}
+	}
+	return v, 0
+}
+
+// own returns the GoStruct v if it is owned by the transaction, or else a
+// copy of it that is owned by the transaction. The maps and slices of the
+// fields of the copy, which hold lists and leaf-lists, are also copied,
+// whereas the values within them are shared with v.
+func (t *Txn) own(v reflect.Value) reflect.Value {
+	if t.owned[v.Interface()] {
+		return v
+	}
+	c := reflect.New(v.Elem().Type())
+	c.Elem().Set(v.Elem())
+	cv := c.Elem()
+	for i := 0; i < cv.NumField(); i++ {
+		fv := cv.Field(i)
+		if !fv.CanSet() || fv.IsZero() {
+			continue
+		}
+		switch fv.Kind() {
+		case reflect.Map:
+			m := reflect.MakeMapWithSize(fv.Type(), fv.Len())
+			iter := fv.MapRange()
+			for iter.Next() {
+				m.SetMapIndex(iter.Key(), iter.Value())
+			}
+			fv.Set(m)
+		case reflect.Slice:
+			fv.Set(reflect.AppendSlice(reflect.MakeSlice(fv.Type(), 0, fv.Len()), fv))
+		}
+	}
+	t.owned[c.Interface()] = true
+	return c
+}
+
+// ownOrderedMap returns a copy of the ordered map m, which holds the
+// entries of a list that is ordered by the user, along with a copy of its
+// entry whose keys are keys, if there is one, which replaces the original
+// within the copied map. The other entries are shared with m.
+func (t *Txn) ownOrderedMap(m reflect.Value, keys map[string]string) (reflect.Value, reflect.Value) {
+	c := reflect.New(m.Type().Elem())
+	var entry reflect.Value
+	vals := m.MethodByName("Values").Call(nil)[0]
+	for i := 0; i < vals.Len(); i++ {
+		e := vals.Index(i)
+		if len(keys) != 0 && !entry.IsValid() && entryMatches(e, keys) {
+			e = t.own(e)
+			entry = e
+		}
+		// Append only fails for a duplicate key, which cannot occur as
+		// the entries are those of an ordered map.
+		c.MethodByName("Append").Call([]reflect.Value{e})
+	}
+	return c, entry
+}
+
+// hasPrefix returns whether the names of the leading elements of elems are
+// those of the schema path p.
+func hasPrefix(elems []*gpb.PathElem, p []string) bool {
+	if len(p) == 0 || len(p) > len(elems) {
+		return false
+	}
+	for i, name := range p {
+		if util.StripModulePrefix(elems[i].GetName()) != name {
+			return false
+		}
+	}
+	return true
+}
+
+// hasKeys returns whether any of elems has keys.
+func hasKeys(elems []*gpb.PathElem) bool {
+	for _, e := range elems {
+		if len(e.GetKey()) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// mapKey returns the key of the map m, which holds the entries of a keyed
+// list, of the entry whose keys are keys. It returns false if there is no
+// such entry.
+func mapKey(m reflect.Value, keys map[string]string) (reflect.Value, bool) {
+	iter := m.MapRange()
+	for iter.Next() {
+		if keyMatches(iter.Key(), keys) {
+			return iter.Key(), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// keyMatches returns whether the map key k of a list entry has the values
+// of keys. A key that is a struct holds the value of each key leaf in a
+// field, whereas a key of a list with a single key leaf is its value.
+func keyMatches(k reflect.Value, keys map[string]string) bool {
+	if k.Kind() != reflect.Struct {
+		if len(keys) != 1 {
+			return false
+		}
+		s, err := ygot.KeyValueAsString(k.Interface())
+		if err != nil {
+			return false
+		}
+		for _, v := range keys {
+			return s == v
+		}
+	}
+	if k.NumField() != len(keys) {
+		return false
+	}
+	for i := 0; i < k.NumField(); i++ {
+		name, ok := k.Type().Field(i).Tag.Lookup("path")
+		if !ok {
+			return false
+		}
+		v, ok := keys[name]
+		if !ok {
+			return false
+		}
+		s, err := ygot.KeyValueAsString(k.Field(i).Interface())
+		if err != nil || s != v {
+			return false
+		}
+	}
+	return true
+}
+
+// entryMatches returns whether the keys of the list entry e, which is a
+// GoStruct, have the values of keys.
+func entryMatches(e reflect.Value, keys map[string]string) bool {
+	kh, ok := e.Interface().(ygot.KeyHelperGoStruct)
+	if !ok {
+		return false
+	}
+	km, err := kh.ΛListKeyMap()
+	if err != nil || len(km) != len(keys) {
+		return false
+	}
+	for k, v := range km {
+		s, err := ygot.KeyValueAsString(v)
+		if err != nil || s != keys[k] {
+			return false
+		}
+	}
+	return true
+}
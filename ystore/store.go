@@ -0,0 +1,364 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ystore implements the persistence of ygot-generated GoStructs to
+// key-value stores. A GoStruct is flattened into a set of records, one per
+// leaf, each of which is keyed by the string form of the leaf's path and
+// holds its value as a serialised gNMI TypedValue. Changes to a GoStruct are
+// persisted by writing only the records of the leaves that changed, and a
+// GoStruct can be reconstructed from all of its records, or from those
+// beneath a path prefix.
+//
+// Key-value stores are accessed through the Backend interface, which can be
+// implemented for stores such as Redis, etcd or BoltDB. An in-memory
+// implementation is provided by MemoryBackend.
+package ystore
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+	"google.golang.org/protobuf/proto"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Backend is the interface to a key-value store in which records are
+// persisted. Implementations must be safe for concurrent use.
+type Backend interface {
+	// Write sets the records within puts, and removes the records with
+	// the keys within deletes. Backends that support transactions should
+	// apply the writes atomically. Removing a record that does not exist
+	// is not an error.
+	Write(puts map[string][]byte, deletes []string) error
+	// Scan calls fn for each record whose key has the supplied prefix,
+	// which is empty to scan all records. Scanning stops if fn returns an
+	// error, which is returned by Scan.
+	Scan(prefix string, fn func(key string, value []byte) error) error
+}
+
+// Store persists the contents of GoStructs of a single schema to a Backend.
+type Store struct {
+	// backend is the key-value store that records are persisted to.
+	backend Backend
+	// schema is the schema of the GoStructs that are persisted.
+	schema *ytypes.Schema
+	// namespace is prepended to the key of each record.
+	namespace string
+}
+
+// StoreOpt is an option that can be supplied to New.
+type StoreOpt interface {
+	// IsStoreOpt is a marker method for each StoreOpt.
+	IsStoreOpt()
+}
+
+// Namespace is a StoreOpt that specifies a string that is prepended to the
+// key of each record, such that several GoStructs can be persisted to the
+// same Backend.
+type Namespace string
+
+// IsStoreOpt implements the StoreOpt interface.
+func (Namespace) IsStoreOpt() {}
+
+// New returns a Store that persists GoStructs whose type is that of the
+// root of schema to backend. The schema tree for the root type must be
+// populated.
+func New(backend Backend, schema *ytypes.Schema, opts ...StoreOpt) (*Store, error) {
+	if backend == nil {
+		return nil, fmt.Errorf("backend must be specified")
+	}
+	if schema == nil || schema.Root == nil || schema.RootSchema() == nil {
+		return nil, fmt.Errorf("invalid schema: root and its schema must be populated")
+	}
+	s := &Store{backend: backend, schema: schema}
+	for _, o := range opts {
+		if ns, ok := o.(Namespace); ok {
+			s.namespace = string(ns)
+		}
+	}
+	return s, nil
+}
+
+// Flatten returns the records that represent the leaves of the GoStruct gs,
+// keyed by the string form of their path.
+func Flatten(gs ygot.GoStruct) (map[string][]byte, error) {
+	notifs, err := ygot.TogNMINotifications(gs, 0, ygot.GNMINotificationsConfig{UsePathElem: true})
+	if err != nil {
+		return nil, fmt.Errorf("cannot flatten GoStruct: %v", err)
+	}
+	records := map[string][]byte{}
+	for _, n := range notifs {
+		for _, u := range n.GetUpdate() {
+			k, v, err := record(n.GetPrefix(), u)
+			if err != nil {
+				return nil, err
+			}
+			records[k] = v
+		}
+	}
+	return records, nil
+}
+
+// record returns the key and value of the record for the update u, whose
+// path is relative to prefix.
+func record(prefix *gpb.Path, u *gpb.Update) (string, []byte, error) {
+	p, err := util.JoinPaths(prefix, u.GetPath())
+	if err != nil {
+		return "", nil, err
+	}
+	k, err := ygot.PathToString(p)
+	if err != nil {
+		return "", nil, fmt.Errorf("cannot form key for path %v: %v", p, err)
+	}
+	v, err := proto.Marshal(u.GetVal())
+	if err != nil {
+		return "", nil, fmt.Errorf("cannot serialise value of %s: %v", k, err)
+	}
+	return k, v, nil
+}
+
+// Save persists the contents of the GoStruct gs, replacing any records that
+// were previously persisted.
+func (s *Store) Save(gs ygot.GoStruct) error {
+	records, err := Flatten(gs)
+	if err != nil {
+		return err
+	}
+	var deletes []string
+	if err := s.scan(&gpb.Path{}, func(key string, _ *gpb.Path, _ []byte) error {
+		if _, ok := records[strings.TrimPrefix(key, s.namespace)]; !ok {
+			deletes = append(deletes, key)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("cannot scan existing records: %v", err)
+	}
+	return s.backend.Write(s.namespaced(records), deletes)
+}
+
+// Commit persists the changes between the GoStructs original and modified,
+// where original is the GoStruct that was most recently persisted, such
+// that only the records of the leaves that changed are written.
+func (s *Store) Commit(original, modified ygot.GoStruct) error {
+	n, err := ygot.Diff(original, modified)
+	if err != nil {
+		return fmt.Errorf("cannot compute changes: %v", err)
+	}
+	return s.Apply(n)
+}
+
+// Apply persists the updates and deletes within the Notification n, as
+// produced by ygot.Diff. Updates must be to leaves, and deletes remove the
+// record of the deleted path and of all paths beneath it.
+func (s *Store) Apply(n *gpb.Notification) error {
+	puts := map[string][]byte{}
+	for _, u := range n.GetUpdate() {
+		k, v, err := record(n.GetPrefix(), u)
+		if err != nil {
+			return err
+		}
+		puts[s.namespace+k] = v
+	}
+	var deletes []string
+	for _, d := range n.GetDelete() {
+		p, err := util.JoinPaths(n.GetPrefix(), d)
+		if err != nil {
+			return err
+		}
+		if err := s.scan(p, func(key string, _ *gpb.Path, _ []byte) error {
+			deletes = append(deletes, key)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return s.backend.Write(puts, deletes)
+}
+
+// Load returns a new GoStruct of the type of the root of the schema that is
+// reconstructed from all persisted records.
+func (s *Store) Load() (ygot.GoStruct, error) {
+	return s.LoadPrefix(&gpb.Path{})
+}
+
+// LoadPrefix returns a new GoStruct of the type of the root of the schema
+// that is reconstructed from the persisted records whose path matches
+// prefix, as per util.PathMatchesQuery, such that a subtree can be loaded
+// without loading the entire GoStruct. The prefix may contain wildcards.
+//
+// The entries of lists that are ordered by the user are reconstructed in
+// the order of their keys, since the order of entries is not persisted.
+func (s *Store) LoadPrefix(prefix *gpb.Path) (ygot.GoStruct, error) {
+	root := s.schema.NewRoot()
+	rootSchema := s.schema.RootSchema()
+	if err := s.scan(prefix, func(key string, p *gpb.Path, value []byte) error {
+		tv := &gpb.TypedValue{}
+		if err := proto.Unmarshal(value, tv); err != nil {
+			return fmt.Errorf("cannot deserialise value of %s: %v", key, err)
+		}
+		if err := ytypes.SetNode(rootSchema, root, p, tv, &ytypes.InitMissingElements{}); err != nil {
+			return fmt.Errorf("cannot set %s: %v", key, err)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// Delete removes the records of all paths that match prefix, as per
+// util.PathMatchesQuery.
+func (s *Store) Delete(prefix *gpb.Path) error {
+	var deletes []string
+	if err := s.scan(prefix, func(key string, _ *gpb.Path, _ []byte) error {
+		deletes = append(deletes, key)
+		return nil
+	}); err != nil {
+		return err
+	}
+	return s.backend.Write(nil, deletes)
+}
+
+// scan calls fn for each persisted record whose path matches query, as per
+// util.PathMatchesQuery, with the namespaced key, path and value of the
+// record.
+func (s *Store) scan(query *gpb.Path, fn func(key string, p *gpb.Path, value []byte) error) error {
+	// The backend is scanned for the longest prefix of the query that does
+	// not contain a wildcard, and the records are then filtered, since the
+	// string form of a path is a prefix of that of paths which are not its
+	// descendants, e.g., where a key value is a prefix of another.
+	var fixed []*gpb.PathElem
+	for _, e := range query.GetElem() {
+		if hasWildcard(e) {
+			break
+		}
+		fixed = append(fixed, e)
+	}
+	scanPrefix := s.namespace
+	if len(fixed) != 0 {
+		pfx, err := ygot.PathToString(&gpb.Path{Elem: fixed})
+		if err != nil {
+			return fmt.Errorf("invalid prefix: %v", err)
+		}
+		scanPrefix += pfx
+	}
+	return s.backend.Scan(scanPrefix, func(key string, value []byte) error {
+		k := strings.TrimPrefix(key, s.namespace)
+		if !strings.HasPrefix(k, "/") {
+			// The record is within another namespace of which the
+			// namespace of the store is a prefix.
+			return nil
+		}
+		p, err := ygot.StringToStructuredPath(k)
+		if err != nil {
+			return fmt.Errorf("invalid key %s: %v", key, err)
+		}
+		if !util.PathMatchesQuery(p, query) {
+			return nil
+		}
+		return fn(key, p, value)
+	})
+}
+
+// hasWildcard reports whether the PathElem e contains a wildcard.
+func hasWildcard(e *gpb.PathElem) bool {
+	if e.GetName() == "*" || e.GetName() == "..." {
+		return true
+	}
+	for _, v := range e.GetKey() {
+		if v == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// namespaced returns a copy of records with the namespace of the store
+// prepended to each key.
+func (s *Store) namespaced(records map[string][]byte) map[string][]byte {
+	if s.namespace == "" {
+		return records
+	}
+	out := make(map[string][]byte, len(records))
+	for k, v := range records {
+		out[s.namespace+k] = v
+	}
+	return out
+}
+
+// MemoryBackend is a Backend that holds records in memory. It is safe for
+// concurrent use.
+type MemoryBackend struct {
+	// mu protects records.
+	mu sync.RWMutex
+	// records is the set of records, keyed by their key.
+	records map[string][]byte
+}
+
+// NewMemoryBackend returns an empty MemoryBackend.
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{records: map[string][]byte{}}
+}
+
+// Write implements the Backend interface. The writes are applied
+// atomically.
+func (m *MemoryBackend) Write(puts map[string][]byte, deletes []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, k := range deletes {
+		delete(m.records, k)
+	}
+	for k, v := range puts {
+		m.records[k] = append([]byte{}, v...)
+	}
+	return nil
+}
+
+// Scan implements the Backend interface. Records are scanned in the order
+// of their keys.
+func (m *MemoryBackend) Scan(prefix string, fn func(key string, value []byte) error) error {
+	m.mu.RLock()
+	var keys []string
+	for k := range m.records {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	values := make([][]byte, len(keys))
+	for i, k := range keys {
+		values[i] = m.records[k]
+	}
+	m.mu.RUnlock()
+
+	for i, k := range keys {
+		if err := fn(k, values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Len returns the number of records within the MemoryBackend.
+func (m *MemoryBackend) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.records)
+}
@@ -0,0 +1,193 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ystore
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/integration_tests/schemaops/ctestschema"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func mustPath(t *testing.T, s string) *gpb.Path {
+	t.Helper()
+	p, err := ygot.StringToStructuredPath(s)
+	if err != nil {
+		t.Fatalf("cannot parse path %s: %v", s, err)
+	}
+	return p
+}
+
+func testDevice() *ctestschema.Device {
+	d := &ctestschema.Device{
+		OtherData: &ctestschema.OtherData{Motd: ygot.String("hello")},
+	}
+	d.GetOrCreateUnorderedList("a").Value = ygot.String("va")
+	d.GetOrCreateUnorderedList("ab").Value = ygot.String("vab")
+	return d
+}
+
+// recordingBackend is a Backend that records the keys written to it.
+type recordingBackend struct {
+	*MemoryBackend
+	puts, deletes []string
+}
+
+func (r *recordingBackend) Write(puts map[string][]byte, deletes []string) error {
+	for k := range puts {
+		r.puts = append(r.puts, k)
+	}
+	r.deletes = append(r.deletes, deletes...)
+	return r.MemoryBackend.Write(puts, deletes)
+}
+
+func newTestStore(t *testing.T, b Backend, opts ...StoreOpt) *Store {
+	t.Helper()
+	s, err := New(b, &ytypes.Schema{Root: &ctestschema.Device{}, SchemaTree: ctestschema.SchemaTree}, opts...)
+	if err != nil {
+		t.Fatalf("New: got unexpected error: %v", err)
+	}
+	return s
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	b := NewMemoryBackend()
+	s := newTestStore(t, b)
+	if err := s.Save(testDevice()); err != nil {
+		t.Fatalf("Save: got unexpected error: %v", err)
+	}
+	got, err := s.Load()
+	if err != nil {
+		t.Fatalf("Load: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(testDevice(), got); diff != "" {
+		t.Errorf("Load: did not get saved GoStruct (-want, +got):\n%s", diff)
+	}
+
+	// Saving a GoStruct with fewer leaves removes stale records.
+	n := b.Len()
+	d := testDevice()
+	delete(d.UnorderedList, "ab")
+	if err := s.Save(d); err != nil {
+		t.Fatalf("Save: got unexpected error: %v", err)
+	}
+	if b.Len() >= n {
+		t.Errorf("Save: got %d records, want fewer than %d", b.Len(), n)
+	}
+	got, err = s.Load()
+	if err != nil {
+		t.Fatalf("Load: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(d, got); diff != "" {
+		t.Errorf("Load: did not get saved GoStruct (-want, +got):\n%s", diff)
+	}
+}
+
+func TestLoadPrefix(t *testing.T) {
+	s := newTestStore(t, NewMemoryBackend())
+	if err := s.Save(testDevice()); err != nil {
+		t.Fatalf("Save: got unexpected error: %v", err)
+	}
+
+	got, err := s.LoadPrefix(mustPath(t, "/unordered-lists/unordered-list[key=a]"))
+	if err != nil {
+		t.Fatalf("LoadPrefix: got unexpected error: %v", err)
+	}
+	want := &ctestschema.Device{}
+	want.GetOrCreateUnorderedList("a").Value = ygot.String("va")
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("LoadPrefix: did not get expected subtree (-want, +got):\n%s", diff)
+	}
+
+	got, err = s.LoadPrefix(mustPath(t, "/unordered-lists/unordered-list[key=*]/config/value"))
+	if err != nil {
+		t.Fatalf("LoadPrefix: got unexpected error: %v", err)
+	}
+	if l := len(got.(*ctestschema.Device).UnorderedList); l != 2 {
+		t.Errorf("LoadPrefix with wildcard: got %d list entries, want 2", l)
+	}
+	if got.(*ctestschema.Device).OtherData != nil {
+		t.Errorf("LoadPrefix with wildcard: loaded records outside of prefix")
+	}
+}
+
+func TestCommit(t *testing.T) {
+	b := &recordingBackend{MemoryBackend: NewMemoryBackend()}
+	s := newTestStore(t, b)
+	orig := testDevice()
+	if err := s.Save(orig); err != nil {
+		t.Fatalf("Save: got unexpected error: %v", err)
+	}
+	b.puts, b.deletes = nil, nil
+
+	mod := testDevice()
+	mod.OtherData.Motd = ygot.String("goodbye")
+	delete(mod.UnorderedList, "ab")
+	if err := s.Commit(orig, mod); err != nil {
+		t.Fatalf("Commit: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"/other-data/config/motd"}, b.puts); diff != "" {
+		t.Errorf("Commit: did not write only changed leaves (-want, +got):\n%s", diff)
+	}
+	for _, k := range b.deletes {
+		if k == "/unordered-lists/unordered-list[key=a]/config/value" {
+			t.Errorf("Commit: deleted record of unchanged list entry a")
+		}
+	}
+
+	got, err := s.Load()
+	if err != nil {
+		t.Fatalf("Load: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(mod, got); diff != "" {
+		t.Errorf("Load after Commit (-want, +got):\n%s", diff)
+	}
+}
+
+func TestDeleteAndNamespace(t *testing.T) {
+	b := NewMemoryBackend()
+	s1 := newTestStore(t, b, Namespace("s1"))
+	s2 := newTestStore(t, b, Namespace("s10"))
+	if err := s1.Save(testDevice()); err != nil {
+		t.Fatalf("Save: got unexpected error: %v", err)
+	}
+	if err := s2.Save(testDevice()); err != nil {
+		t.Fatalf("Save: got unexpected error: %v", err)
+	}
+
+	if err := s1.Delete(mustPath(t, "/unordered-lists")); err != nil {
+		t.Fatalf("Delete: got unexpected error: %v", err)
+	}
+	got, err := s1.Load()
+	if err != nil {
+		t.Fatalf("Load: got unexpected error: %v", err)
+	}
+	want := &ctestschema.Device{OtherData: &ctestschema.OtherData{Motd: ygot.String("hello")}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Load after Delete (-want, +got):\n%s", diff)
+	}
+
+	got, err = s2.Load()
+	if err != nil {
+		t.Fatalf("Load: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(testDevice(), got); diff != "" {
+		t.Errorf("Delete modified another namespace (-want, +got):\n%s", diff)
+	}
+}
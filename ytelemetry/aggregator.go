@@ -0,0 +1,429 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ytelemetry aggregates the telemetry that is streamed by gNMI
+// targets into ygot-generated GoStructs. The notifications received from
+// any number of Subscribe streams are merged into a single data tree per
+// target, and the time at which each leaf was last updated is tracked, such
+// that leaves that have not been updated recently can be found, and leaves
+// that are no longer being updated can be expired from the data tree.
+package ytelemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+	"google.golang.org/protobuf/proto"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// AggregatorOpt is an option that can be supplied to New.
+type AggregatorOpt interface {
+	// IsAggregatorOpt is a marker method for each AggregatorOpt.
+	IsAggregatorOpt()
+}
+
+// Timeout is an AggregatorOpt that specifies the duration after its last
+// update at which a leaf is expired, where no PathTimeout applies to it. By
+// default, leaves do not expire.
+type Timeout time.Duration
+
+// IsAggregatorOpt implements the AggregatorOpt interface.
+func (Timeout) IsAggregatorOpt() {}
+
+// PathTimeout is an AggregatorOpt that specifies the duration after its last
+// update at which a leaf at or beneath Path, which may contain wildcards, is
+// expired. Where several PathTimeouts apply to a leaf, that with the longest
+// Path is used. A Timeout of 0 specifies that the leaves do not expire.
+type PathTimeout struct {
+	// Path is the path of the leaves, or of their ancestor.
+	Path *gpb.Path
+	// Timeout is the duration after which the leaves expire.
+	Timeout time.Duration
+}
+
+// IsAggregatorOpt implements the AggregatorOpt interface.
+func (*PathTimeout) IsAggregatorOpt() {}
+
+// Aggregator merges the notifications received from gNMI targets into a
+// data tree per target, which is a GoStruct of a schema, and tracks the time
+// at which each leaf of each data tree was last updated. An Aggregator is
+// safe for concurrent use, such that the notifications of several Subscribe
+// streams can be merged into the same data tree.
+type Aggregator struct {
+	// schema is the schema of the data trees.
+	schema *ytypes.Schema
+	// timeout is the default duration after which leaves expire, or 0 if
+	// they do not.
+	timeout time.Duration
+	// pathTimeouts is the set of durations after which the leaves beneath
+	// specific paths expire.
+	pathTimeouts []*PathTimeout
+	// now returns the current time.
+	now func() time.Time
+
+	// mu protects targets.
+	mu sync.RWMutex
+	// targets is the state of each target, keyed by its name.
+	targets map[string]*target
+}
+
+// target is the aggregated state of a single target.
+type target struct {
+	// root is the data tree of the target.
+	root ygot.GoStruct
+	// leaves is the set of leaves of root, keyed by the string form of
+	// their path.
+	leaves map[string]*leaf
+}
+
+// leaf is a leaf of the data tree of a target.
+type leaf struct {
+	// path is the path of the leaf.
+	path *gpb.Path
+	// updated is the time at which the leaf was last updated.
+	updated time.Time
+}
+
+// New returns an Aggregator whose data trees are GoStructs of the type of
+// the root of schema. The schema tree for the root type must be populated.
+func New(schema *ytypes.Schema, opts ...AggregatorOpt) (*Aggregator, error) {
+	if schema == nil || schema.Root == nil || schema.RootSchema() == nil {
+		return nil, fmt.Errorf("invalid schema: root and its schema must be populated")
+	}
+	a := &Aggregator{schema: schema, now: time.Now, targets: map[string]*target{}}
+	for _, o := range opts {
+		switch v := o.(type) {
+		case Timeout:
+			a.timeout = time.Duration(v)
+		case *PathTimeout:
+			a.pathTimeouts = append(a.pathTimeouts, v)
+		}
+	}
+	return a, nil
+}
+
+// Update merges the notification n, received from the named target, into
+// the data tree of the target. The time of the update of each leaf within n
+// is the timestamp of n, or the current time if it has none. Paths that
+// are deleted by n, and the leaves beneath them, are no longer tracked.
+// Paths within n that are not within the schema are ignored.
+func (a *Aggregator) Update(name string, n *gpb.Notification) error {
+	if n == nil {
+		return nil
+	}
+	ts := a.now()
+	if n.GetTimestamp() != 0 {
+		ts = time.Unix(0, n.GetTimestamp())
+	}
+	// The target within the prefix is not part of the path of the leaves.
+	var prefix *gpb.Path
+	if n.GetPrefix() != nil {
+		prefix = proto.Clone(n.GetPrefix()).(*gpb.Path)
+		prefix.Target = ""
+	}
+
+	// Find the leaves that are updated by n, which may be to non-leaf
+	// nodes, by applying its updates to an empty data tree.
+	s := *a.schema
+	s.Root = a.schema.NewRoot()
+	if err := ytypes.UnmarshalSetRequest(&s, &gpb.SetRequest{Prefix: prefix, Update: n.GetUpdate()}, &ytypes.IgnoreExtraFields{}); err != nil {
+		return fmt.Errorf("cannot unmarshal notification: %v", err)
+	}
+	updated, err := leafPaths(s.Root)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	t, ok := a.targets[name]
+	if !ok {
+		t = &target{root: a.schema.NewRoot(), leaves: map[string]*leaf{}}
+		a.targets[name] = t
+	}
+	s.Root = t.root
+	deletes := n.GetDelete()
+	if n.GetAtomic() {
+		deletes = append(deletes, &gpb.Path{})
+	}
+	if err := ytypes.UnmarshalSetRequest(&s, &gpb.SetRequest{Prefix: prefix, Delete: deletes, Update: n.GetUpdate()}, &ytypes.IgnoreExtraFields{}); err != nil {
+		return fmt.Errorf("cannot apply notification: %v", err)
+	}
+	for _, d := range deletes {
+		p, err := util.JoinPaths(prefix, d)
+		if err != nil {
+			return err
+		}
+		for k, l := range t.leaves {
+			if util.PathMatchesQuery(l.path, p) {
+				delete(t.leaves, k)
+			}
+		}
+	}
+	for k, p := range updated {
+		t.leaves[k] = &leaf{path: p, updated: ts}
+	}
+	return nil
+}
+
+// leafPaths returns the paths of the populated leaves of gs, keyed by their
+// string form.
+func leafPaths(gs ygot.GoStruct) (map[string]*gpb.Path, error) {
+	ns, err := ygot.TogNMINotifications(gs, 0, ygot.GNMINotificationsConfig{UsePathElem: true})
+	if err != nil {
+		return nil, fmt.Errorf("cannot render updated leaves: %v", err)
+	}
+	paths := map[string]*gpb.Path{}
+	for _, n := range ns {
+		for _, u := range n.GetUpdate() {
+			p, err := util.JoinPaths(n.GetPrefix(), u.GetPath())
+			if err != nil {
+				return nil, err
+			}
+			k, err := ygot.PathToString(p)
+			if err != nil {
+				return nil, err
+			}
+			paths[k] = p
+		}
+	}
+	return paths, nil
+}
+
+// HandleResponse merges the notification within resp, which is a response
+// to a Subscribe RPC of the named target, into the data tree of the target.
+// Responses that do not contain a notification are ignored.
+func (a *Aggregator) HandleResponse(name string, resp *gpb.SubscribeResponse) error {
+	if n := resp.GetUpdate(); n != nil {
+		return a.Update(name, n)
+	}
+	return nil
+}
+
+// Consume merges the responses received from the Subscribe stream sc, whose
+// subscription request has already been sent to the named target, until the
+// stream ends. It returns nil if the stream ends cleanly, or the error with
+// which it ended, or with which a response could not be merged.
+func (a *Aggregator) Consume(name string, sc gpb.GNMI_SubscribeClient) error {
+	for {
+		resp, err := sc.Recv()
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+		case err != nil:
+			return err
+		}
+		if err := a.HandleResponse(name, resp); err != nil {
+			return err
+		}
+	}
+}
+
+// Targets returns the sorted names of the targets from which notifications
+// have been received.
+func (a *Aggregator) Targets() []string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	var names []string
+	for n := range a.targets {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Root returns a copy of the data tree of the named target.
+func (a *Aggregator) Root(name string) (ygot.GoStruct, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	t, ok := a.targets[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown target %q", name)
+	}
+	return ygot.DeepCopy(t.root)
+}
+
+// LastUpdate returns the time at which the leaf at path within the data
+// tree of the named target was last updated, and whether the leaf exists.
+func (a *Aggregator) LastUpdate(name string, path *gpb.Path) (time.Time, bool) {
+	k, err := ygot.PathToString(path)
+	if err != nil {
+		return time.Time{}, false
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	t, ok := a.targets[name]
+	if !ok {
+		return time.Time{}, false
+	}
+	l, ok := t.leaves[k]
+	if !ok {
+		return time.Time{}, false
+	}
+	return l.updated, true
+}
+
+// Stale returns the sorted paths of the leaves within the data tree of the
+// named target that have not been updated within the last age.
+func (a *Aggregator) Stale(name string, age time.Duration) []*gpb.Path {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	t, ok := a.targets[name]
+	if !ok {
+		return nil
+	}
+	now := a.now()
+	var keys []string
+	for k, l := range t.leaves {
+		if now.Sub(l.updated) > age {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	var paths []*gpb.Path
+	for _, k := range keys {
+		paths = append(paths, t.leaves[k].path)
+	}
+	return paths
+}
+
+// timeoutOf returns the duration after which the leaf at path expires, or 0
+// if it does not.
+func (a *Aggregator) timeoutOf(path *gpb.Path) time.Duration {
+	d, longest := a.timeout, -1
+	for _, pt := range a.pathTimeouts {
+		if l := len(pt.Path.GetElem()); l > longest && util.PathMatchesQuery(path, pt.Path) {
+			d, longest = pt.Timeout, l
+		}
+	}
+	return d
+}
+
+// Expire removes the leaves whose timeout has elapsed since their last
+// update from the data trees of all targets, returning the paths of the
+// removed leaves, sorted and keyed by the name of their target. The keys of
+// list entries are removed only when no other leaf of the entry remains.
+func (a *Aggregator) Expire() (map[string][]*gpb.Path, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := a.now()
+	expired := map[string][]*gpb.Path{}
+	var errs util.Errors
+	for name, t := range a.targets {
+		var keys []string
+		for k, l := range t.leaves {
+			if d := a.timeoutOf(l.path); d > 0 && now.Sub(l.updated) > d {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		entries := map[string]*gpb.Path{}
+		for _, k := range keys {
+			p := t.leaves[k].path
+			delete(t.leaves, k)
+			expired[name] = append(expired[name], p)
+			if entry := listEntry(p); entry != nil {
+				ek, err := ygot.PathToString(entry)
+				if err != nil {
+					errs = util.AppendErr(errs, err)
+					continue
+				}
+				entries[ek] = entry
+				if isKey(p, entry) {
+					continue
+				}
+			}
+			if err := ytypes.DeleteNode(a.schema.RootSchema(), t.root, p); err != nil {
+				errs = util.AppendErr(errs, fmt.Errorf("cannot remove expired leaf %v of target %s: %v", p, name, err))
+			}
+		}
+		for _, entry := range entries {
+			errs = util.AppendErr(errs, a.pruneEntry(t, entry))
+		}
+	}
+	if errs != nil {
+		return expired, errs
+	}
+	return expired, nil
+}
+
+// listEntry returns the path of the innermost list entry that contains the
+// leaf at path, or nil if it is not within a list.
+func listEntry(path *gpb.Path) *gpb.Path {
+	elems := path.GetElem()
+	for i := len(elems) - 2; i >= 0; i-- {
+		if len(elems[i].GetKey()) != 0 {
+			return &gpb.Path{Elem: elems[:i+1]}
+		}
+	}
+	return nil
+}
+
+// isKey returns whether the leaf at path is a key of the list entry at
+// entry.
+func isKey(path, entry *gpb.Path) bool {
+	keys := entry.GetElem()[len(entry.GetElem())-1].GetKey()
+	_, ok := keys[path.GetElem()[len(path.GetElem())-1].GetName()]
+	return ok
+}
+
+// pruneEntry removes the list entry at entry from the data tree of t if the
+// only leaves of it that remain are its keys.
+func (a *Aggregator) pruneEntry(t *target, entry *gpb.Path) error {
+	var keys []string
+	for k, l := range t.leaves {
+		if !util.PathMatchesPathElemPrefix(l.path, entry) {
+			continue
+		}
+		// Leaves of list entries nested within entry are also within it.
+		if inner := listEntry(l.path); len(inner.GetElem()) != len(entry.GetElem()) || !isKey(l.path, entry) {
+			return nil
+		}
+		keys = append(keys, k)
+	}
+	for _, k := range keys {
+		delete(t.leaves, k)
+	}
+	if err := ytypes.DeleteNode(a.schema.RootSchema(), t.root, entry); err != nil {
+		return fmt.Errorf("cannot remove expired list entry %v: %v", entry, err)
+	}
+	return nil
+}
+
+// Run calls Expire every interval until ctx is done, returning the first
+// error returned by Expire, or nil once ctx is done.
+func (a *Aggregator) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := a.Expire(); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytelemetry
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func mustPath(t *testing.T, s string) *gpb.Path {
+	t.Helper()
+	p, err := ygot.StringToStructuredPath(s)
+	if err != nil {
+		t.Fatalf("cannot parse path %s: %v", s, err)
+	}
+	return p
+}
+
+func pathStrings(t *testing.T, ps []*gpb.Path) []string {
+	t.Helper()
+	var ss []string
+	for _, p := range ps {
+		s, err := ygot.PathToString(p)
+		if err != nil {
+			t.Fatalf("cannot convert path %v to string: %v", p, err)
+		}
+		ss = append(ss, s)
+	}
+	return ss
+}
+
+func newTestAggregator(t *testing.T, now *time.Time, opts ...AggregatorOpt) *Aggregator {
+	t.Helper()
+	a, err := New(&ytypes.Schema{
+		Root:       &exampleoc.Device{},
+		SchemaTree: exampleoc.SchemaTree,
+		Unmarshal:  exampleoc.Unmarshal,
+	}, opts...)
+	if err != nil {
+		t.Fatalf("New: got unexpected error: %v", err)
+	}
+	a.now = func() time.Time { return *now }
+	return a
+}
+
+// fakeStream is a Subscribe stream that returns a fixed set of responses.
+type fakeStream struct {
+	gpb.GNMI_SubscribeClient
+	resps []*gpb.SubscribeResponse
+}
+
+func (f *fakeStream) Recv() (*gpb.SubscribeResponse, error) {
+	if len(f.resps) == 0 {
+		return nil, io.EOF
+	}
+	r := f.resps[0]
+	f.resps = f.resps[1:]
+	return r, nil
+}
+
+func TestAggregator(t *testing.T) {
+	start := time.Unix(1000, 0)
+	now := start
+	a := newTestAggregator(t, &now, Timeout(time.Minute), &PathTimeout{
+		Path:    mustPath(t, "/system"),
+		Timeout: 0,
+	})
+
+	hostname := mustPath(t, "/system/config/hostname")
+	mtu := mustPath(t, "/interfaces/interface[name=eth0]/config/mtu")
+	desc := mustPath(t, "/interfaces/interface[name=eth0]/config/description")
+	key := mustPath(t, "/interfaces/interface[name=eth0]/config/name")
+
+	// Two streams from the same target are merged into its data tree.
+	if err := a.Consume("dev1", &fakeStream{resps: []*gpb.SubscribeResponse{{
+		Response: &gpb.SubscribeResponse_Update{Update: &gpb.Notification{
+			Timestamp: start.UnixNano(),
+			Prefix:    &gpb.Path{Target: "dev1"},
+			Update: []*gpb.Update{
+				{Path: hostname, Val: &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "dev1"}}},
+				{Path: mustPath(t, "/interfaces/interface[name=eth0]"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{
+					JsonIetfVal: []byte(`{"name": "eth0", "config": {"name": "eth0", "description": "uplink"}}`),
+				}}},
+			},
+		}},
+	}, {
+		Response: &gpb.SubscribeResponse_SyncResponse{SyncResponse: true},
+	}}}); err != nil {
+		t.Fatalf("Consume: got unexpected error: %v", err)
+	}
+	if err := a.Update("dev1", &gpb.Notification{
+		Timestamp: start.Add(30 * time.Second).UnixNano(),
+		Update:    []*gpb.Update{{Path: mtu, Val: &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: 9000}}}},
+	}); err != nil {
+		t.Fatalf("Update: got unexpected error: %v", err)
+	}
+
+	if got, want := a.Targets(), []string{"dev1"}; !cmp.Equal(got, want) {
+		t.Errorf("Targets: got %v, want %v", got, want)
+	}
+	root, err := a.Root("dev1")
+	if err != nil {
+		t.Fatalf("Root: got unexpected error: %v", err)
+	}
+	d := root.(*exampleoc.Device)
+	if d.GetSystem().GetHostname() != "dev1" || d.GetInterface("eth0").GetMtu() != 9000 || d.GetInterface("eth0").GetDescription() != "uplink" {
+		t.Errorf("Root: did not get merged data tree, got: %+v", d)
+	}
+	if _, err := a.Root("dev2"); err == nil {
+		t.Errorf("Root of unknown target: got nil error, want error")
+	}
+
+	if got, ok := a.LastUpdate("dev1", desc); !ok || !got.Equal(start) {
+		t.Errorf("LastUpdate of leaf within non-leaf update: got %v, %v, want %v, true", got, ok, start)
+	}
+	if got, ok := a.LastUpdate("dev1", mtu); !ok || !got.Equal(start.Add(30*time.Second)) {
+		t.Errorf("LastUpdate: got %v, %v, want %v, true", got, ok, start.Add(30*time.Second))
+	}
+	if _, ok := a.LastUpdate("dev1", mustPath(t, "/interfaces/interface[name=eth1]/config/mtu")); ok {
+		t.Errorf("LastUpdate of unset leaf: got true, want false")
+	}
+
+	now = start.Add(45 * time.Second)
+	if got, want := pathStrings(t, a.Stale("dev1", 20*time.Second)), []string{
+		"/interfaces/interface[name=eth0]/config/description",
+		"/system/config/hostname",
+	}; !cmp.Equal(got, want) {
+		t.Errorf("Stale: did not get expected paths, diff(-want, +got):\n%s", cmp.Diff(want, got))
+	}
+
+	// The description expires, while the key of its entry, which was last
+	// updated with the mtu, remains, and the hostname does not expire.
+	now = start.Add(80 * time.Second)
+	expired, err := a.Expire()
+	if err != nil {
+		t.Fatalf("Expire: got unexpected error: %v", err)
+	}
+	if got, want := pathStrings(t, expired["dev1"]), []string{
+		"/interfaces/interface[name=eth0]/config/description",
+	}; !cmp.Equal(got, want) {
+		t.Errorf("Expire: did not get expected paths, diff(-want, +got):\n%s", cmp.Diff(want, got))
+	}
+	if root, err = a.Root("dev1"); err != nil {
+		t.Fatalf("Root: got unexpected error: %v", err)
+	}
+	d = root.(*exampleoc.Device)
+	if i := d.GetInterface("eth0"); i == nil || i.Description != nil || i.GetName() != "eth0" || i.GetMtu() != 9000 {
+		t.Errorf("Expire: did not get expected interface, got: %+v", i)
+	}
+
+	// Once the mtu expires, the entry is removed.
+	now = start.Add(100 * time.Second)
+	if _, err := a.Expire(); err != nil {
+		t.Fatalf("Expire: got unexpected error: %v", err)
+	}
+	if root, err = a.Root("dev1"); err != nil {
+		t.Fatalf("Root: got unexpected error: %v", err)
+	}
+	d = root.(*exampleoc.Device)
+	if i := d.GetInterface("eth0"); i != nil {
+		t.Errorf("Expire: got interface %+v, want it to be removed", i)
+	}
+	if _, ok := a.LastUpdate("dev1", key); ok {
+		t.Errorf("LastUpdate of removed key: got true, want false")
+	}
+	if d.GetSystem().GetHostname() != "dev1" {
+		t.Errorf("Expire: hostname without a timeout was removed")
+	}
+
+	if err := a.Update("dev1", &gpb.Notification{Delete: []*gpb.Path{mustPath(t, "/system")}}); err != nil {
+		t.Fatalf("Update with delete: got unexpected error: %v", err)
+	}
+	if _, ok := a.LastUpdate("dev1", hostname); ok {
+		t.Errorf("LastUpdate of deleted leaf: got true, want false")
+	}
+}
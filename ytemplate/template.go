@@ -0,0 +1,420 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ytemplate implements parameterised templates of ygot-generated
+// GoStructs. A template is a GoStruct skeleton in which fields are marked as
+// placeholders, using the annotation fields that ygen generates for each
+// field (e.g., ΛDescription for Description). The placeholders are bound to
+// the values of a set of variables when the template is instantiated, such
+// that a reusable service template (e.g., an L3VPN attachment) can be
+// rendered into concrete configuration.
+package ytemplate
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// annotationPrefix is the prefix of the name of the annotation field that
+// ygen generates for each field of a GoStruct.
+const annotationPrefix = "Λ"
+
+// Placeholder is a ygot.Annotation that marks the field of a GoStruct that
+// it annotates as a parameter of a template, whose value is bound from the
+// variable Name when the template is instantiated.
+type Placeholder struct {
+	// Name is the name of the variable that the field is bound to.
+	Name string `json:"name"`
+	// Optional specifies that the field is left unset when the variable
+	// is not supplied. By default, the variable must be supplied.
+	Optional bool `json:"optional,omitempty"`
+	// Description is a human-readable description of the variable.
+	Description string `json:"description,omitempty"`
+}
+
+// placeholderJSON is used to marshal and unmarshal a Placeholder without
+// recursing into its MarshalJSON and UnmarshalJSON methods.
+type placeholderJSON Placeholder
+
+// MarshalJSON implements the ygot.Annotation interface.
+func (p *Placeholder) MarshalJSON() ([]byte, error) {
+	return json.Marshal((*placeholderJSON)(p))
+}
+
+// UnmarshalJSON implements the ygot.Annotation interface.
+func (p *Placeholder) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, (*placeholderJSON)(p))
+}
+
+// SetPlaceholder marks the field named field of the GoStruct s as a
+// placeholder, by adding p to its annotation field. The field must have an
+// annotation field, as generated by ygen when annotations are enabled.
+func SetPlaceholder(s ygot.GoStruct, field string, p *Placeholder) error {
+	if p == nil || p.Name == "" {
+		return fmt.Errorf("placeholder for field %s must have a name", field)
+	}
+	v := reflect.ValueOf(s)
+	if !util.IsValueStructPtr(v) || util.IsValueNil(v) {
+		return fmt.Errorf("invalid GoStruct %T", s)
+	}
+	v = v.Elem()
+	if _, ok := v.Type().FieldByName(field); !ok {
+		return fmt.Errorf("%T has no field %s", s, field)
+	}
+	af, ok := v.Type().FieldByName(annotationPrefix + field)
+	if !ok || !util.IsYgotAnnotation(af) {
+		return fmt.Errorf("%T has no annotation field for %s", s, field)
+	}
+	a := v.FieldByIndex(af.Index)
+	a.Set(reflect.Append(a, reflect.ValueOf(ygot.Annotation(p))))
+	return nil
+}
+
+// Variable describes a variable of a Template.
+type Variable struct {
+	// Name is the name of the variable.
+	Name string
+	// Type is the type of the fields that the variable is bound to.
+	Type reflect.Type
+	// Optional indicates whether the variable may be omitted.
+	Optional bool
+	// Description is the description of the variable.
+	Description string
+}
+
+// Template is a parameterised GoStruct, which is instantiated by binding its
+// placeholders to the values of a set of variables.
+type Template struct {
+	// skeleton is the GoStruct that the template is instantiated from.
+	skeleton ygot.GoStruct
+	// vars is the set of variables of the template, keyed by name.
+	vars map[string]*Variable
+}
+
+// New returns a Template for the GoStruct skeleton, which contains fields
+// marked as placeholders using SetPlaceholder. Each variable must be bound
+// to fields of a single type. The skeleton is copied, such that it can be
+// modified once the Template is created.
+func New(skeleton ygot.GoStruct) (*Template, error) {
+	c, err := ygot.DeepCopy(skeleton)
+	if err != nil {
+		return nil, fmt.Errorf("cannot copy skeleton: %v", err)
+	}
+	t := &Template{skeleton: c, vars: map[string]*Variable{}}
+	err = walkPlaceholders(reflect.ValueOf(c), "", false, func(field reflect.Value, p *Placeholder, path string) error {
+		v, ok := t.vars[p.Name]
+		if !ok {
+			t.vars[p.Name] = &Variable{Name: p.Name, Type: field.Type(), Optional: p.Optional, Description: p.Description}
+			return nil
+		}
+		if v.Type != field.Type() {
+			return fmt.Errorf("%s: variable %s is bound to fields of type %v and %v", path, p.Name, v.Type, field.Type())
+		}
+		// A variable is only optional if each of its placeholders is
+		// optional.
+		v.Optional = v.Optional && p.Optional
+		if v.Description == "" {
+			v.Description = p.Description
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Variables returns the variables of the template, sorted by name.
+func (t *Template) Variables() []*Variable {
+	var vs []*Variable
+	for _, v := range t.vars {
+		vs = append(vs, v)
+	}
+	sort.Slice(vs, func(i, j int) bool { return vs[i].Name < vs[j].Name })
+	return vs
+}
+
+// Instantiate returns a copy of the template's skeleton in which each
+// placeholder is bound to the value of the corresponding variable within
+// vars, and the placeholder annotations are removed. List entries are
+// re-keyed according to the values bound to their key fields.
+//
+// The value of each variable must be of the type of the fields it is bound
+// to, or of its underlying type where the fields are pointers. Integer
+// values of another integer type are accepted where they are within the
+// range of the field's type, and enumerated fields may be bound to the
+// string name of an enumerated value. It is an error for a variable that is
+// not optional to be omitted, or for vars to contain a variable that is not
+// used by the template. The result is not validated against the schema.
+func (t *Template) Instantiate(vars map[string]any) (ygot.GoStruct, error) {
+	var errs util.Errors
+	var unknown []string
+	for n := range vars {
+		if _, ok := t.vars[n]; !ok {
+			unknown = append(unknown, n)
+		}
+	}
+	if len(unknown) != 0 {
+		sort.Strings(unknown)
+		errs = util.AppendErr(errs, fmt.Errorf("unknown variables: %s", strings.Join(unknown, ", ")))
+	}
+	for _, v := range t.Variables() {
+		if _, ok := vars[v.Name]; !ok && !v.Optional {
+			errs = util.AppendErr(errs, fmt.Errorf("missing value for variable %s", v.Name))
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+
+	c, err := ygot.DeepCopy(t.skeleton)
+	if err != nil {
+		return nil, fmt.Errorf("cannot copy skeleton: %v", err)
+	}
+	err = walkPlaceholders(reflect.ValueOf(c), "", true, func(field reflect.Value, p *Placeholder, path string) error {
+		val, ok := vars[p.Name]
+		if !ok {
+			return nil
+		}
+		if err := bind(field, val); err != nil {
+			return fmt.Errorf("%s: cannot bind variable %s: %v", path, p.Name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// walkPlaceholders calls fn for each field of the GoStruct v, and of the
+// GoStructs it contains, that is marked as a placeholder, supplying the
+// field, its placeholder, and a human-readable path to the field. If
+// instantiate is set, placeholder annotations are removed from the
+// GoStructs once fn has been called, and the entries of each list are
+// re-keyed according to their key fields.
+func walkPlaceholders(v reflect.Value, path string, instantiate bool, fn func(reflect.Value, *Placeholder, string) error) error {
+	if !util.IsValueStructPtr(v) || util.IsValueNil(v) {
+		return nil
+	}
+	sv := v.Elem()
+	st := sv.Type()
+	var errs util.Errors
+	for i := 0; i < sv.NumField(); i++ {
+		fv, ft := sv.Field(i), st.Field(i)
+		fpath := ft.Name
+		if path != "" {
+			fpath = path + "." + ft.Name
+		}
+		switch {
+		case util.IsYgotAnnotation(ft):
+			continue
+		case util.IsValueStructPtr(fv) && !util.IsValueNil(fv):
+			if _, ok := fv.Interface().(ygot.GoOrderedMap); ok {
+				// The values of ordered lists are not accessible
+				// through reflection, and hence cannot contain
+				// placeholders.
+				break
+			}
+			errs = util.AppendErr(errs, walkPlaceholders(fv, fpath, instantiate, fn))
+		case fv.Kind() == reflect.Map && fv.Len() != 0:
+			for _, k := range fv.MapKeys() {
+				errs = util.AppendErr(errs, walkPlaceholders(fv.MapIndex(k), fmt.Sprintf("%s[%v]", fpath, k.Interface()), instantiate, fn))
+			}
+			if instantiate {
+				errs = util.AppendErr(errs, rekeyMap(fv, fpath))
+			}
+		}
+
+		af, ok := st.FieldByName(annotationPrefix + ft.Name)
+		if !ok || !util.IsYgotAnnotation(af) {
+			continue
+		}
+		av := sv.FieldByIndex(af.Index)
+		var rest []ygot.Annotation
+		for _, a := range av.Interface().([]ygot.Annotation) {
+			p, ok := a.(*Placeholder)
+			if !ok {
+				rest = append(rest, a)
+				continue
+			}
+			errs = util.AppendErr(errs, fn(fv, p, fpath))
+		}
+		if instantiate {
+			av.Set(reflect.ValueOf(rest))
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// rekeyMap updates the keys of the map m, which represents a YANG list, such
+// that each entry is keyed according to the values of its key fields.
+func rekeyMap(m reflect.Value, path string) error {
+	nm := reflect.MakeMapWithSize(m.Type(), m.Len())
+	for _, k := range m.MapKeys() {
+		e := m.MapIndex(k)
+		nk, err := listKey(e, m.Type().Key())
+		if err != nil {
+			return fmt.Errorf("%s[%v]: %v", path, k.Interface(), err)
+		}
+		if nm.MapIndex(nk).IsValid() {
+			return fmt.Errorf("%s: duplicate key %v", path, nk.Interface())
+		}
+		nm.SetMapIndex(nk, e)
+	}
+	m.Set(nm)
+	return nil
+}
+
+// keyHelperGoStruct is implemented by GoStructs that are YANG list entries.
+type keyHelperGoStruct interface {
+	ΛListKeyMap() (map[string]any, error)
+}
+
+// listKey returns the key of type keyType for the list entry e. Where the
+// list has a single key, its value is retrieved using ΛListKeyMap. Where
+// the list has multiple keys, each field of the key struct is populated
+// from the field of e with the same name.
+func listKey(e reflect.Value, keyType reflect.Type) (reflect.Value, error) {
+	if keyType.Kind() == reflect.Struct {
+		k := reflect.New(keyType).Elem()
+		ev := e.Elem()
+		for i := 0; i < keyType.NumField(); i++ {
+			name := keyType.Field(i).Name
+			f := ev.FieldByName(name)
+			if !f.IsValid() {
+				return reflect.Value{}, fmt.Errorf("no field %s for key", name)
+			}
+			if f.Kind() == reflect.Ptr {
+				if f.IsNil() {
+					return reflect.Value{}, fmt.Errorf("nil value for key %s", name)
+				}
+				f = f.Elem()
+			}
+			k.Field(i).Set(f.Convert(k.Field(i).Type()))
+		}
+		return k, nil
+	}
+
+	kh, ok := e.Interface().(keyHelperGoStruct)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("%T is not a list entry", e.Interface())
+	}
+	km, err := kh.ΛListKeyMap()
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if len(km) != 1 {
+		return reflect.Value{}, fmt.Errorf("got %d keys for map with single key", len(km))
+	}
+	for _, v := range km {
+		kv := reflect.ValueOf(v)
+		if !kv.Type().ConvertibleTo(keyType) {
+			return reflect.Value{}, fmt.Errorf("key of type %T cannot be used as %v", v, keyType)
+		}
+		return kv.Convert(keyType), nil
+	}
+	return reflect.Value{}, nil
+}
+
+// bind sets the field f to val, checking that val is of a type that is
+// compatible with the field.
+func bind(f reflect.Value, val any) error {
+	v := reflect.ValueOf(val)
+	if !v.IsValid() {
+		return fmt.Errorf("nil value")
+	}
+	ft := f.Type()
+
+	switch {
+	case v.Type().AssignableTo(ft):
+		f.Set(v)
+		return nil
+	case ft.Kind() == reflect.Ptr && v.Type().AssignableTo(ft.Elem()):
+		p := reflect.New(ft.Elem())
+		p.Elem().Set(v)
+		f.Set(p)
+		return nil
+	}
+
+	// Enumerated values may be specified using their name.
+	e, isEnum := f.Interface().(ygot.GoEnum)
+	if s, ok := val.(string); ok && isEnum {
+		for i, d := range e.ΛMap()[ft.Name()] {
+			if d.Name == s {
+				f.SetInt(i)
+				return nil
+			}
+		}
+		return fmt.Errorf("%q is not a value of enumerated type %v", s, ft)
+	}
+
+	// Integers of other types are accepted if they are within the range of
+	// the field's type.
+	t := ft
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if isEnum || !isInteger(t.Kind()) || !isInteger(v.Kind()) {
+		return fmt.Errorf("value of type %T cannot be bound to field of type %v", val, ft)
+	}
+	n := reflect.New(t).Elem()
+	switch {
+	case v.CanInt() && n.CanInt():
+		if n.OverflowInt(v.Int()) {
+			return fmt.Errorf("value %v overflows %v", val, t)
+		}
+		n.SetInt(v.Int())
+	case v.CanInt() && n.CanUint():
+		if v.Int() < 0 || n.OverflowUint(uint64(v.Int())) {
+			return fmt.Errorf("value %v overflows %v", val, t)
+		}
+		n.SetUint(uint64(v.Int()))
+	case v.CanUint() && n.CanInt():
+		if v.Uint() > 1<<63-1 || n.OverflowInt(int64(v.Uint())) {
+			return fmt.Errorf("value %v overflows %v", val, t)
+		}
+		n.SetInt(int64(v.Uint()))
+	default:
+		if n.OverflowUint(v.Uint()) {
+			return fmt.Errorf("value %v overflows %v", val, t)
+		}
+		n.SetUint(v.Uint())
+	}
+	if ft.Kind() == reflect.Ptr {
+		f.Set(n.Addr())
+		return nil
+	}
+	f.Set(n)
+	return nil
+}
+
+// isInteger reports whether k is an integer kind.
+func isInteger(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
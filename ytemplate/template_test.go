@@ -0,0 +1,144 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytemplate
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// interfaceTemplate returns a template for a device with a single
+// interface, whose name, MTU, description and loopback mode are variables.
+func interfaceTemplate(t *testing.T) *Template {
+	t.Helper()
+	d := &exampleoc.Device{}
+	i := d.GetOrCreateInterface("$name")
+	i.Enabled = ygot.Bool(true)
+	for field, p := range map[string]*Placeholder{
+		"Name":         {Name: "name", Description: "interface name"},
+		"Mtu":          {Name: "mtu"},
+		"Description":  {Name: "description", Optional: true},
+		"LoopbackMode": {Name: "loopback", Optional: true},
+	} {
+		if err := SetPlaceholder(i, field, p); err != nil {
+			t.Fatalf("SetPlaceholder(%s): got unexpected error: %v", field, err)
+		}
+	}
+	tmpl, err := New(d)
+	if err != nil {
+		t.Fatalf("New: got unexpected error: %v", err)
+	}
+	return tmpl
+}
+
+func TestInstantiate(t *testing.T) {
+	tmpl := interfaceTemplate(t)
+
+	var names []string
+	for _, v := range tmpl.Variables() {
+		names = append(names, v.Name)
+	}
+	if diff := cmp.Diff([]string{"description", "loopback", "mtu", "name"}, names); diff != "" {
+		t.Errorf("Variables: did not get expected variables (-want, +got):\n%s", diff)
+	}
+
+	tests := []struct {
+		desc             string
+		inVars           map[string]any
+		want             *exampleoc.Interface
+		wantErrSubstring string
+	}{{
+		desc:   "required variables",
+		inVars: map[string]any{"name": "eth0", "mtu": uint16(9000)},
+		want: &exampleoc.Interface{
+			Name:    ygot.String("eth0"),
+			Enabled: ygot.Bool(true),
+			Mtu:     ygot.Uint16(9000),
+		},
+	}, {
+		desc:   "optional variables, enum name and integer conversion",
+		inVars: map[string]any{"name": "eth1", "mtu": 1500, "description": "uplink", "loopback": "FACILITY"},
+		want: &exampleoc.Interface{
+			Name:         ygot.String("eth1"),
+			Enabled:      ygot.Bool(true),
+			Mtu:          ygot.Uint16(1500),
+			Description:  ygot.String("uplink"),
+			LoopbackMode: exampleoc.TransportTypes_LoopbackModeType_FACILITY,
+		},
+	}, {
+		desc:             "missing and unknown variables",
+		inVars:           map[string]any{"nmae": "eth0"},
+		wantErrSubstring: "unknown variables: nmae",
+	}, {
+		desc:             "integer out of range",
+		inVars:           map[string]any{"name": "eth0", "mtu": 70000},
+		wantErrSubstring: "value 70000 overflows uint16",
+	}, {
+		desc:             "wrong type",
+		inVars:           map[string]any{"name": 42, "mtu": 1500},
+		wantErrSubstring: "cannot bind variable name",
+	}, {
+		desc:             "unknown enum value",
+		inVars:           map[string]any{"name": "eth0", "mtu": 1500, "loopback": "SIDEWAYS"},
+		wantErrSubstring: `"SIDEWAYS" is not a value`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := tmpl.Instantiate(tt.inVars)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("Instantiate: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			d := got.(*exampleoc.Device)
+			if len(d.Interface) != 1 {
+				t.Fatalf("Instantiate: got %d interfaces, want 1", len(d.Interface))
+			}
+			i := d.Interface[tt.want.GetName()]
+			if i == nil {
+				t.Fatalf("Instantiate: interface was not re-keyed to %s, got: %v", tt.want.GetName(), d.Interface)
+			}
+			if diff := cmp.Diff(tt.want, i); diff != "" {
+				t.Errorf("Instantiate: did not get expected interface (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	d := &exampleoc.Device{}
+	i := d.GetOrCreateInterface("eth0")
+	if err := SetPlaceholder(i, "NoSuchField", &Placeholder{Name: "x"}); err == nil {
+		t.Errorf("SetPlaceholder: did not get expected error for unknown field")
+	}
+	if err := SetPlaceholder(i, "Mtu", &Placeholder{}); err == nil {
+		t.Errorf("SetPlaceholder: did not get expected error for unnamed placeholder")
+	}
+	if err := SetPlaceholder(i, "Mtu", &Placeholder{Name: "x"}); err != nil {
+		t.Fatalf("SetPlaceholder: got unexpected error: %v", err)
+	}
+	if err := SetPlaceholder(i, "Description", &Placeholder{Name: "x"}); err != nil {
+		t.Fatalf("SetPlaceholder: got unexpected error: %v", err)
+	}
+	if _, err := New(d); err == nil {
+		t.Errorf("New: did not get expected error for variable bound to fields of different types")
+	}
+}
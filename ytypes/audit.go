@@ -0,0 +1,225 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/proto"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// AuditOp is the type of a mutation of a data tree that is recorded by
+// Audit.
+type AuditOp int
+
+const (
+	// AuditSet indicates that the mutation was a SetNode operation.
+	AuditSet AuditOp = iota
+	// AuditDelete indicates that the mutation was a DeleteNode operation.
+	AuditDelete
+)
+
+// String returns a human-readable name for the AuditOp.
+func (o AuditOp) String() string {
+	switch o {
+	case AuditSet:
+		return "set"
+	case AuditDelete:
+		return "delete"
+	}
+	return "unknown"
+}
+
+// AuditRecord is a record of a single mutation of a data tree.
+type AuditRecord struct {
+	// Time is the time at which the mutation was made.
+	Time time.Time
+	// Op is the type of the mutation.
+	Op AuditOp
+	// Path is the path of the node that was mutated.
+	Path *gpb.Path
+	// Principal is the caller-supplied identity on whose behalf the
+	// mutation was made.
+	Principal string
+	// Old is a copy of the value of the node before the mutation, or nil
+	// if the node did not exist.
+	Old any
+	// New is a copy of the value of the node after the mutation, or nil
+	// if the node does not exist.
+	New any
+	// Err is the error returned by the mutation, if it failed. Old and
+	// New may differ even if the mutation failed, since a failed mutation
+	// can modify the data tree.
+	Err error
+}
+
+// AuditSink is the interface implemented by destinations of AuditRecords.
+// Implementations must be safe for concurrent use if the data trees being
+// audited are mutated concurrently.
+type AuditSink interface {
+	// Record is called with the record of each audited mutation after
+	// the mutation has been made.
+	Record(*AuditRecord)
+}
+
+// AuditSinkFunc is an adapter that allows a function to be used as an
+// AuditSink.
+type AuditSinkFunc func(*AuditRecord)
+
+// Record implements the AuditSink interface.
+func (f AuditSinkFunc) Record(r *AuditRecord) { f(r) }
+
+// AuditLog is an AuditSink that retains the records that it receives in
+// memory. It is safe for concurrent use.
+type AuditLog struct {
+	// mu protects records.
+	mu sync.Mutex
+	// records is the set of records, in the order in which they were
+	// received.
+	records []*AuditRecord
+}
+
+// Record implements the AuditSink interface.
+func (l *AuditLog) Record(r *AuditRecord) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, r)
+}
+
+// Records returns the records received by the AuditLog, in the order in
+// which they were received.
+func (l *AuditLog) Records() []*AuditRecord {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]*AuditRecord{}, l.records...)
+}
+
+// Audit signals SetNode and DeleteNode to record the mutation that they
+// make, including the value of the node before and after it, to Sink. An
+// AuditRecord is recorded whether or not the mutation succeeds.
+type Audit struct {
+	// Sink is the destination of the records.
+	Sink AuditSink
+	// Principal is the identity on whose behalf the mutation is made,
+	// which is included in the record.
+	Principal string
+	// Now, if set, is used in place of time.Now to determine the time of
+	// the mutation.
+	Now func() time.Time
+}
+
+// IsSetNodeOpt implements the SetNodeOpt interface.
+func (*Audit) IsSetNodeOpt() {}
+
+// IsDelNodeOpt implements the DelNodeOpt interface.
+func (*Audit) IsDelNodeOpt() {}
+
+// setNodeAudit returns the Audit within the supplied SetNodeOpt slice, or
+// nil if there is none.
+func setNodeAudit(opts []SetNodeOpt) *Audit {
+	for _, o := range opts {
+		if a, ok := o.(*Audit); ok && a.Sink != nil {
+			return a
+		}
+	}
+	return nil
+}
+
+// delNodeAudit returns the Audit within the supplied DelNodeOpt slice, or
+// nil if there is none.
+func delNodeAudit(opts []DelNodeOpt) *Audit {
+	for _, o := range opts {
+		if a, ok := o.(*Audit); ok && a.Sink != nil {
+			return a
+		}
+	}
+	return nil
+}
+
+// begin returns a record for a mutation of type op of the node at path
+// within root, populated with the current value of the node.
+func (a *Audit) begin(op AuditOp, schema *yang.Entry, root any, path *gpb.Path, preferShadowPath bool) *AuditRecord {
+	return &AuditRecord{
+		Op:        op,
+		Path:      proto.Clone(path).(*gpb.Path),
+		Principal: a.Principal,
+		Old:       auditValue(schema, root, path, preferShadowPath),
+	}
+}
+
+// end completes the record r, of a mutation that returned err, with the
+// new value of the node, and sends it to the sink.
+func (a *Audit) end(r *AuditRecord, schema *yang.Entry, root any, preferShadowPath bool, err error) {
+	now := time.Now
+	if a.Now != nil {
+		now = a.Now
+	}
+	r.Time = now()
+	r.New = auditValue(schema, root, r.Path, preferShadowPath)
+	r.Err = err
+	a.Sink.Record(r)
+}
+
+// auditValue returns a copy of the value of the node at path within root,
+// or nil if it does not exist.
+func auditValue(schema *yang.Entry, root any, path *gpb.Path, preferShadowPath bool) any {
+	nodes, err := retrieveNode(schema, root, path, nil, retrieveNodeArgs{
+		tolerateNil:      true,
+		preferShadowPath: preferShadowPath,
+	})
+	if err != nil || len(nodes) != 1 || util.IsValueNil(nodes[0].Data) {
+		return nil
+	}
+	return copyAuditValue(nodes[0].Data)
+}
+
+// copyAuditValue returns a copy of v, such that subsequent mutations of the
+// data tree that v is within do not modify the copy. GoStructs are deep
+// copied, whereas maps and slices are copied one level deep.
+func copyAuditValue(v any) any {
+	if gs, ok := v.(ygot.GoStruct); ok {
+		c, err := ygot.DeepCopy(gs)
+		if err != nil {
+			return v
+		}
+		return c
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		c := reflect.New(rv.Type().Elem())
+		c.Elem().Set(rv.Elem())
+		return c.Interface()
+	case reflect.Slice:
+		c := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		reflect.Copy(c, rv)
+		return c.Interface()
+	case reflect.Map:
+		c := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), iter.Value())
+		}
+		return c.Interface()
+	}
+	return v
+}
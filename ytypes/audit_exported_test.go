@@ -0,0 +1,100 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/integration_tests/schemaops/ctestschema"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestAudit(t *testing.T) {
+	root := &ctestschema.Device{
+		OtherData: &ctestschema.OtherData{Motd: ygot.String("hello")},
+	}
+	schema := ctestschema.SchemaTree["Device"]
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	log := &ytypes.AuditLog{}
+	audit := &ytypes.Audit{Sink: log, Principal: "alice", Now: func() time.Time { return now }}
+
+	if err := ytypes.SetNode(schema, root, mustPath("/other-data/config/motd"), &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "goodbye"}}, audit); err != nil {
+		t.Fatalf("SetNode: got unexpected error: %v", err)
+	}
+	if err := ytypes.SetNode(schema, root, mustPath("/unordered-lists/unordered-list[key=a]/config/key"), &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "a"}}, &ytypes.InitMissingElements{}, audit); err != nil {
+		t.Fatalf("SetNode: got unexpected error: %v", err)
+	}
+	if err := ytypes.DeleteNode(schema, root, mustPath("/other-data/config/motd"), audit); err != nil {
+		t.Fatalf("DeleteNode: got unexpected error: %v", err)
+	}
+	if err := ytypes.SetNode(schema, root, mustPath("/other-data/config/motd"), &gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: 42}}); err == nil {
+		t.Fatalf("SetNode with invalid value: got nil error, want error")
+	}
+
+	want := []*ytypes.AuditRecord{{
+		Time:      now,
+		Op:        ytypes.AuditSet,
+		Path:      mustPath("/other-data/config/motd"),
+		Principal: "alice",
+		Old:       ygot.String("hello"),
+		New:       ygot.String("goodbye"),
+	}, {
+		Time:      now,
+		Op:        ytypes.AuditSet,
+		Path:      mustPath("/unordered-lists/unordered-list[key=a]/config/key"),
+		Principal: "alice",
+		New:       ygot.String("a"),
+	}, {
+		Time:      now,
+		Op:        ytypes.AuditDelete,
+		Path:      mustPath("/other-data/config/motd"),
+		Principal: "alice",
+		Old:       ygot.String("goodbye"),
+	}}
+	if diff := cmp.Diff(want, log.Records(), protocmp.Transform()); diff != "" {
+		t.Errorf("did not get expected audit records (-want, +got):\n%s", diff)
+	}
+}
+
+func TestAuditFailure(t *testing.T) {
+	root := &ctestschema.Device{
+		OtherData: &ctestschema.OtherData{Motd: ygot.String("hello")},
+	}
+	var got []*ytypes.AuditRecord
+	audit := &ytypes.Audit{Sink: ytypes.AuditSinkFunc(func(r *ytypes.AuditRecord) { got = append(got, r) })}
+
+	err := ytypes.SetNode(ctestschema.SchemaTree["Device"], root, mustPath("/other-data/config/motd"), &gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: 42}}, audit)
+	if err == nil {
+		t.Fatalf("SetNode with invalid value: got nil error, want error")
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d audit records, want 1", len(got))
+	}
+	if got[0].Err != err {
+		t.Errorf("audit record error: got %v, want %v", got[0].Err, err)
+	}
+	if got[0].Time.IsZero() {
+		t.Errorf("audit record time is not populated")
+	}
+	if diff := cmp.Diff(ygot.String("hello"), got[0].Old); diff != "" {
+		t.Errorf("audit record old value (-want, +got):\n%s", diff)
+	}
+}
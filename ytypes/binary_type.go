@@ -29,11 +29,7 @@ import (
 // schema's length restrictions (if any). It returns an error if the validation
 // fails.
 func ValidateBinaryRestrictions(schemaType *yang.YangType, binaryVal []byte) error {
-	allowedRanges := schemaType.Length
-	if !lengthOk(allowedRanges, uint64(len(binaryVal))) {
-		return fmt.Errorf("length %d is outside range %v", len(binaryVal), allowedRanges)
-	}
-	return nil
+	return util.ValidateLength(schemaType, uint64(len(binaryVal)))
 }
 
 // validateBinary validates value, which must be a Go string type, against the
@@ -56,7 +52,7 @@ func validateBinary(schema *yang.Entry, value interface{}) error {
 	binaryVal := reflect.ValueOf(value).Bytes()
 
 	if err := ValidateBinaryRestrictions(schema.Type, binaryVal); err != nil {
-		return fmt.Errorf("schema %q: %v", schema.Name, err)
+		return fmt.Errorf("schema %q: %w", schema.Name, err)
 	}
 	return nil
 }
@@ -80,7 +76,7 @@ func validateBinarySlice(schema *yang.Entry, value interface{}) error {
 	for i := 0; i < v.Len(); i++ {
 		val := v.Index(i)
 		if err := validateBinary(schema, val.Interface()); err != nil {
-			return fmt.Errorf("invalid element at index %d: %v", i, err)
+			return fmt.Errorf("invalid element at index %d: %w", i, err)
 		}
 		binaryVal := val.Bytes()
 		if tbl[string(binaryVal)] {
@@ -0,0 +1,140 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// BinarySizeLimit is an unmarshal option that limits the size of the values
+// of binary leaves and leaf-lists. A value whose decoded size exceeds
+// MaxBytes causes unmarshalling to fail before the value is decoded, such
+// that oversized inputs are not copied into memory.
+type BinarySizeLimit struct {
+	// MaxBytes is the maximum decoded size, in bytes, of a binary value.
+	MaxBytes int
+}
+
+// IsUnmarshalOpt marks BinarySizeLimit as a valid UnmarshalOpt.
+func (*BinarySizeLimit) IsUnmarshalOpt() {}
+
+// BinaryReferencer is an unmarshal option that allows the values of large
+// binary leaves and leaf-lists to be stored outside of the unmarshalled
+// GoStruct. Values whose decoded size is at least MinBytes are passed to
+// Reference as a reader, from which they are decoded incrementally, rather
+// than being copied into the GoStruct. The value returned by Reference,
+// such as a file name or an identifier within a blob store, is stored in
+// the leaf in place of the binary value.
+type BinaryReferencer struct {
+	// MinBytes is the minimum decoded size, in bytes, of a binary value
+	// that is passed to Reference.
+	MinBytes int
+	// Reference stores the binary value of size bytes that is read from r
+	// for the leaf described by schema, and returns the reference to it.
+	Reference func(schema *yang.Entry, r io.Reader, size int) ([]byte, error)
+}
+
+// IsUnmarshalOpt marks BinaryReferencer as a valid UnmarshalOpt.
+func (*BinaryReferencer) IsUnmarshalOpt() {}
+
+// binarySizeLimit returns the BinarySizeLimit within the supplied slice of
+// UnmarshalOpts, or nil if there is none.
+func binarySizeLimit(opts []UnmarshalOpt) *BinarySizeLimit {
+	for _, o := range opts {
+		if l, ok := o.(*BinarySizeLimit); ok && l != nil {
+			return l
+		}
+	}
+	return nil
+}
+
+// binaryReferencer returns the BinaryReferencer within the supplied slice of
+// UnmarshalOpts, or nil if there is none.
+func binaryReferencer(opts []UnmarshalOpt) *BinaryReferencer {
+	for _, o := range opts {
+		if r, ok := o.(*BinaryReferencer); ok && r != nil && r.Reference != nil {
+			return r
+		}
+	}
+	return nil
+}
+
+// base64DecodedLen returns the number of bytes that the padded base64
+// encoded string s decodes to.
+func base64DecodedLen(s string) int {
+	n := len(s) / 4 * 3
+	for i := 0; i < 2 && strings.HasSuffix(s[:len(s)-i], "="); i++ {
+		n--
+	}
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+// unmarshalBinary applies the BinarySizeLimit and BinaryReferencer options
+// within opts to the encoded binary value, which is to be unmarshalled into
+// the leaf described by schema. It returns the value to be stored in the
+// leaf and true if the value was stored by the BinaryReferencer, or false if
+// the value should be unmarshalled as normal.
+func unmarshalBinary(schema *yang.Entry, value interface{}, enc Encoding, opts []UnmarshalOpt) ([]byte, bool, error) {
+	limit, ref := binarySizeLimit(opts), binaryReferencer(opts)
+	if limit == nil && ref == nil {
+		return nil, false, nil
+	}
+
+	var (
+		size int
+		r    io.Reader
+	)
+	switch v := value.(type) {
+	case string:
+		if enc != JSONEncoding {
+			return nil, false, nil
+		}
+		size = base64DecodedLen(v)
+		r = base64.NewDecoder(base64.StdEncoding, strings.NewReader(v))
+	case *gpb.TypedValue:
+		b := v.GetBytesVal()
+		if b == nil {
+			// Let the normal unmarshalling path report the invalid value.
+			return nil, false, nil
+		}
+		size = len(b)
+		r = bytes.NewReader(b)
+	default:
+		return nil, false, nil
+	}
+
+	if limit != nil && size > limit.MaxBytes {
+		return nil, false, fmt.Errorf("binary value of %d bytes for schema %s exceeds the limit of %d bytes", size, schema.Name, limit.MaxBytes)
+	}
+	if ref == nil || size < ref.MinBytes {
+		return nil, false, nil
+	}
+	b, err := ref.Reference(schema, r, size)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot store reference to binary value for schema %s: %v", schema.Name, err)
+	}
+	return b, true, nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestBase64DecodedLen(t *testing.T) {
+	for _, s := range []string{"", "eQ==", "eWc=", "eWdv", "eWdvdA==", base64testStringEncoded} {
+		want := len(mustDecodeBase64(t, s))
+		if got := base64DecodedLen(s); got != want {
+			t.Errorf("base64DecodedLen(%q): got %d, want %d", s, got, want)
+		}
+	}
+}
+
+func mustDecodeBase64(t *testing.T, s string) []byte {
+	t.Helper()
+	v, err := sanitizeJSON(nil, typeToLeafSchema("binary-leaf", yang.Ybinary), "BinaryLeaf", s)
+	if err != nil {
+		t.Fatalf("cannot decode %q: %v", s, err)
+	}
+	return v.([]byte)
+}
+
+func TestUnmarshalBinaryOpts(t *testing.T) {
+	// stored records the values that are passed to the BinaryReferencer.
+	var stored []string
+	referencer := &BinaryReferencer{
+		MinBytes: 5,
+		Reference: func(schema *yang.Entry, r io.Reader, size int) ([]byte, error) {
+			b, err := io.ReadAll(r)
+			if err != nil {
+				return nil, err
+			}
+			if len(b) != size {
+				return nil, fmt.Errorf("read %d bytes, want %d", len(b), size)
+			}
+			stored = append(stored, string(b))
+			return []byte(fmt.Sprintf("ref-%d", len(stored))), nil
+		},
+	}
+	failingReferencer := &BinaryReferencer{
+		Reference: func(*yang.Entry, io.Reader, int) ([]byte, error) {
+			return nil, fmt.Errorf("store is full")
+		},
+	}
+
+	tests := []struct {
+		desc             string
+		inVal            interface{}
+		inEnc            Encoding
+		inOpts           []UnmarshalOpt
+		want             *LeafContainerStruct
+		wantStored       []string
+		wantErrSubstring string
+	}{{
+		desc:   "JSON value within size limit",
+		inVal:  base64testStringEncoded,
+		inEnc:  JSONEncoding,
+		inOpts: []UnmarshalOpt{&BinarySizeLimit{MaxBytes: len(base64testString)}},
+		want:   &LeafContainerStruct{BinaryLeaf: Binary(base64testString)},
+	}, {
+		desc:             "JSON value exceeding size limit",
+		inVal:            base64testStringEncoded,
+		inEnc:            JSONEncoding,
+		inOpts:           []UnmarshalOpt{&BinarySizeLimit{MaxBytes: 4}},
+		wantErrSubstring: "binary value of 9 bytes for schema binary-leaf exceeds the limit of 4 bytes",
+	}, {
+		desc: "gNMI value exceeding size limit",
+		inVal: &gpb.TypedValue{
+			Value: &gpb.TypedValue_BytesVal{BytesVal: []byte("value")},
+		},
+		inEnc:            GNMIEncoding,
+		inOpts:           []UnmarshalOpt{&BinarySizeLimit{MaxBytes: 4}},
+		wantErrSubstring: "exceeds the limit of 4 bytes",
+	}, {
+		desc:       "JSON value stored as reference",
+		inVal:      base64testStringEncoded,
+		inEnc:      JSONEncoding,
+		inOpts:     []UnmarshalOpt{referencer},
+		want:       &LeafContainerStruct{BinaryLeaf: Binary("ref-1")},
+		wantStored: []string{base64testString},
+	}, {
+		desc: "gNMI value stored as reference",
+		inVal: &gpb.TypedValue{
+			Value: &gpb.TypedValue_BytesVal{BytesVal: []byte("value")},
+		},
+		inEnc:      GNMIEncoding,
+		inOpts:     []UnmarshalOpt{referencer, &BinarySizeLimit{MaxBytes: 10}},
+		want:       &LeafContainerStruct{BinaryLeaf: Binary("ref-1")},
+		wantStored: []string{"value"},
+	}, {
+		desc: "value smaller than reference threshold",
+		inVal: &gpb.TypedValue{
+			Value: &gpb.TypedValue_BytesVal{BytesVal: []byte("val")},
+		},
+		inEnc:  GNMIEncoding,
+		inOpts: []UnmarshalOpt{referencer},
+		want:   &LeafContainerStruct{BinaryLeaf: Binary("val")},
+	}, {
+		desc: "nil gNMI value with options",
+		inVal: &gpb.TypedValue{
+			Value: &gpb.TypedValue_BytesVal{BytesVal: nil},
+		},
+		inEnc:            GNMIEncoding,
+		inOpts:           []UnmarshalOpt{referencer},
+		wantErrSubstring: "BytesVal is nil",
+	}, {
+		desc:             "reference error",
+		inVal:            base64testStringEncoded,
+		inEnc:            JSONEncoding,
+		inOpts:           []UnmarshalOpt{failingReferencer},
+		wantErrSubstring: "cannot store reference to binary value for schema binary-leaf: store is full",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			stored = nil
+			got := &LeafContainerStruct{}
+			err := unmarshalGeneric(typeToLeafSchema("binary-leaf", yang.Ybinary), got, tt.inVal, tt.inEnc, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("unmarshalGeneric: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unmarshalGeneric: (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantStored, stored); diff != "" {
+				t.Errorf("unmarshalGeneric: did not store expected values, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
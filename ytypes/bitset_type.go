@@ -16,15 +16,14 @@ package ytypes
 
 import (
 	"fmt"
-	"sort"
-	"strings"
 
 	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
 )
 
 // Refer to: https://tools.ietf.org/html/rfc6020#section-9.7.
 
-// validateBitset validates value, which must be a Go string type, against the
+// validateBitset validates value, which must be a ygot.Bits, against the
 // given schema.
 func validateBitset(schema *yang.Entry, value interface{}) error {
 	// Check that the schema itself is valid.
@@ -33,14 +32,13 @@ func validateBitset(schema *yang.Entry, value interface{}) error {
 	}
 
 	// Check that type of value is the type expected from the schema.
-	val, ok := value.(string)
+	val, ok := value.(ygot.Bits)
 	if !ok {
 		return fmt.Errorf("non bitset type %T with value %v for schema %s", value, value, schema.Name)
 	}
 
-	// Check that the bitset names are defined.
-	bitsetNames := strings.Split(val, " ")
-	for _, name := range bitsetNames {
+	// Check that the bit names are defined.
+	for _, name := range val {
 		if !schema.Type.Bit.IsDefined(name) {
 			return fmt.Errorf("nonexistent bit name: %q for schema %s", name, schema.Name)
 		}
@@ -48,8 +46,8 @@ func validateBitset(schema *yang.Entry, value interface{}) error {
 	return nil
 }
 
-// validateBitsetSlice validates value, which must be a Go string slice type,
-// against the given schema.
+// validateBitsetSlice validates value, which must be a []ygot.Bits, against
+// the given schema.
 func validateBitsetSlice(schema *yang.Entry, value interface{}) error {
 	// Check that the schema itself is valid.
 	if err := validateBitsetSchema(schema); err != nil {
@@ -57,28 +55,40 @@ func validateBitsetSlice(schema *yang.Entry, value interface{}) error {
 	}
 
 	// Check that type of value is the type expected from the schema.
-	slice, ok := value.([]string)
+	slice, ok := value.([]ygot.Bits)
 	if !ok {
-		return fmt.Errorf("non []string type %T with value: %v for schema %s", value, value, schema.Name)
+		return fmt.Errorf("non []ygot.Bits type %T with value: %v for schema %s", value, value, schema.Name)
 	}
 
-	// Each slice element must be valid and unique.
+	// Each slice element must be valid and unique. Since the names within a
+	// ygot.Bits are sorted, equal sets have the same string representation.
 	tbl := make(map[string]bool, len(slice))
 	for i, val := range slice {
 		if err := validateBitset(schema, val); err != nil {
-			return fmt.Errorf("invalid element at index %d: %v for schema %s", i, err, schema.Name)
+			return fmt.Errorf("invalid element at index %d: %w for schema %s", i, err, schema.Name)
 		}
-		bitsetSlice := strings.Split(val, " ")
-		sort.Strings(bitsetSlice)
-		str := strings.Join(bitsetSlice, " ")
-		if tbl[str] {
+		if tbl[val.String()] {
 			return fmt.Errorf("duplicate bit set: %v for schema %s", val, schema.Name)
 		}
-		tbl[str] = true
+		tbl[val.String()] = true
 	}
 	return nil
 }
 
+// parseBitset returns the ygot.Bits that is represented by the
+// space-separated bit names in s, checking that each bit name is defined in
+// the given schema.
+func parseBitset(schema *yang.Entry, s string) (ygot.Bits, error) {
+	if err := validateBitsetSchema(schema); err != nil {
+		return nil, err
+	}
+	b := ygot.ParseBits(s)
+	if err := validateBitset(schema, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
 // validateBitsetSchema validates the given Bitset type schema. This is a quick
 // check rather than a comprehensive validation against the RFC.
 // It is assumed that such a validation is done when the schema is parsed from
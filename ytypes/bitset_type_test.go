@@ -15,9 +15,15 @@
 package ytypes
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
 	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
 var validBitsetSchema = mapToBitsetSchema("valid-bitset-schema", map[string]int64{"name1": 0, "name2": 1, "name3": 2})
@@ -84,24 +90,24 @@ func TestValidateBitset(t *testing.T) {
 		{
 			desc:   "success",
 			schema: validBitsetSchema,
-			val:    "name1 name2",
+			val:    ygot.Bits{"name1", "name2"},
 		},
 		{
 			desc:    "bad schema",
 			schema:  nil,
-			val:     "",
+			val:     ygot.Bits{},
 			wantErr: true,
 		},
 		{
 			desc:    "non bitset type",
 			schema:  validBitsetSchema,
-			val:     "",
+			val:     "name1 name2",
 			wantErr: true,
 		},
 		{
 			desc:    "nonexistent bit name",
 			schema:  validBitsetSchema,
-			val:     "name0 name2",
+			val:     ygot.Bits{"name0", "name2"},
 			wantErr: true,
 		},
 	}
@@ -127,10 +133,10 @@ func TestValidateBitsetSlice(t *testing.T) {
 		{
 			desc:   "success",
 			schema: validBitsetSchema,
-			val:    []string{"name1 name2", "name1"},
+			val:    []ygot.Bits{{"name1", "name2"}, {"name1"}},
 		},
 		{
-			desc:    "non []string",
+			desc:    "non []ygot.Bits",
 			schema:  validBitsetSchema,
 			val:     []int32{1, 2},
 			wantErr: true,
@@ -138,13 +144,13 @@ func TestValidateBitsetSlice(t *testing.T) {
 		{
 			desc:    "invalid element",
 			schema:  validBitsetSchema,
-			val:     []string{"name0 name2", "name1"},
+			val:     []ygot.Bits{{"name0", "name2"}, {"name1"}},
 			wantErr: true,
 		},
 		{
 			desc:    "duplicate element",
 			schema:  validBitsetSchema,
-			val:     []string{"name1 name2", "name1", "name1"},
+			val:     []ygot.Bits{{"name1", "name2"}, {"name1"}, {"name1"}},
 			wantErr: true,
 		},
 	}
@@ -159,3 +165,142 @@ func TestValidateBitsetSlice(t *testing.T) {
 		})
 	}
 }
+
+func TestParseBitset(t *testing.T) {
+	tests := []struct {
+		desc             string
+		schema           *yang.Entry
+		in               string
+		want             ygot.Bits
+		wantErrSubstring string
+	}{{
+		desc:   "unsorted names with repeated separators",
+		schema: validBitsetSchema,
+		in:     "name3  name1 name3",
+		want:   ygot.Bits{"name1", "name3"},
+	}, {
+		desc:   "no bits set",
+		schema: validBitsetSchema,
+		in:     "",
+	}, {
+		desc:             "nonexistent bit name",
+		schema:           validBitsetSchema,
+		in:               "name1 name4",
+		wantErrSubstring: `nonexistent bit name: "name4"`,
+	}, {
+		desc:             "bad schema",
+		in:               "name1",
+		wantErrSubstring: "bitset schema is nil",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := parseBitset(tt.schema, tt.in)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("parseBitset(%q): %s", tt.in, diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("parseBitset(%q): (-want, +got):\n%s", tt.in, diff)
+			}
+		})
+	}
+}
+
+// bitsStruct is a struct containing bits leaves.
+type bitsStruct struct {
+	Flags    ygot.Bits   `path:"flags"`
+	FlagSets []ygot.Bits `path:"flag-sets"`
+}
+
+func (*bitsStruct) IsYANGGoStruct()                          {}
+func (*bitsStruct) ΛValidate(...ygot.ValidationOption) error { return nil }
+func (*bitsStruct) ΛEnumTypeMap() map[string][]reflect.Type  { return nil }
+func (*bitsStruct) ΛBelongingModule() string                 { return "" }
+
+func TestUnmarshalBits(t *testing.T) {
+	containerSchema := &yang.Entry{
+		Name: "container",
+		Kind: yang.DirectoryEntry,
+		Dir:  map[string]*yang.Entry{},
+	}
+	flagsSchema := mapToBitsetSchema("flags", map[string]int64{"SYN": 0, "ACK": 1, "FIN": 2})
+	flagSetsSchema := mapToBitsetSchema("flag-sets", map[string]int64{"SYN": 0, "ACK": 1, "FIN": 2})
+	flagSetsSchema.ListAttr = yang.NewDefaultListAttr()
+	for _, s := range []*yang.Entry{flagsSchema, flagSetsSchema} {
+		s.Parent = containerSchema
+		containerSchema.Dir[s.Name] = s
+	}
+
+	tests := []struct {
+		desc             string
+		inSchema         *yang.Entry
+		inVal            interface{}
+		inEnc            Encoding
+		want             *bitsStruct
+		wantErrSubstring string
+	}{{
+		desc:     "JSON leaf",
+		inSchema: flagsSchema,
+		inVal:    "SYN ACK",
+		inEnc:    JSONEncoding,
+		want:     &bitsStruct{Flags: ygot.Bits{"ACK", "SYN"}},
+	}, {
+		desc:     "JSON leaf-list",
+		inSchema: flagSetsSchema,
+		inVal:    []interface{}{"FIN", "SYN ACK"},
+		inEnc:    JSONEncoding,
+		want:     &bitsStruct{FlagSets: []ygot.Bits{{"FIN"}, {"ACK", "SYN"}}},
+	}, {
+		desc:     "gNMI leaf",
+		inSchema: flagsSchema,
+		inVal:    &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "FIN ACK"}},
+		inEnc:    GNMIEncoding,
+		want:     &bitsStruct{Flags: ygot.Bits{"ACK", "FIN"}},
+	}, {
+		desc:     "gNMI leaf-list",
+		inSchema: flagSetsSchema,
+		inVal: &gpb.TypedValue{Value: &gpb.TypedValue_LeaflistVal{LeaflistVal: &gpb.ScalarArray{
+			Element: []*gpb.TypedValue{
+				{Value: &gpb.TypedValue_StringVal{StringVal: "FIN"}},
+				{Value: &gpb.TypedValue_StringVal{StringVal: "SYN"}},
+			},
+		}}},
+		inEnc: GNMIEncoding,
+		want:  &bitsStruct{FlagSets: []ygot.Bits{{"FIN"}, {"SYN"}}},
+	}, {
+		desc:             "JSON unknown bit name",
+		inSchema:         flagsSchema,
+		inVal:            "SYN PSH",
+		inEnc:            JSONEncoding,
+		wantErrSubstring: `nonexistent bit name: "PSH"`,
+	}, {
+		desc:             "gNMI wrong type",
+		inSchema:         flagsSchema,
+		inVal:            &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: 1}},
+		inEnc:            GNMIEncoding,
+		wantErrSubstring: "failed to unmarshal",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := &bitsStruct{}
+			err := unmarshalGeneric(tt.inSchema, got, tt.inVal, tt.inEnc)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("unmarshalGeneric: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unmarshalGeneric: (-want, +got):\n%s", diff)
+			}
+			if errs := Validate(containerSchema, got); errs != nil {
+				t.Errorf("Validate: got unexpected error: %v", errs)
+			}
+		})
+	}
+
+	if errs := Validate(containerSchema, &bitsStruct{Flags: ygot.Bits{"PSH"}}); errs == nil {
+		t.Errorf("Validate: did not get expected error for unknown bit name")
+	}
+}
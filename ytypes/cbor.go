@@ -0,0 +1,460 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// CBORSIDs is an unmarshal option that specifies the YANG Schema Item
+// iDentifiers (SIDs) of the data nodes within YANG-CBOR that is supplied to
+// UnmarshalCBOR. It is required when the members of containers and list
+// entries are identified by their SIDs rather than their names.
+type CBORSIDs struct {
+	// SIDs maps the schema node identifiers of data nodes to their SIDs, in
+	// the form used by ygot.EmitCBORConfig.
+	SIDs map[string]uint64
+}
+
+// IsUnmarshalOpt marks CBORSIDs as a valid UnmarshalOpt.
+func (*CBORSIDs) IsUnmarshalOpt() {}
+
+// UnmarshalCBOR unmarshals the YANG-CBOR data item data, as per RFC9254,
+// into the parent, which must be a GoStruct described by the supplied
+// schema. The members of containers and list entries may be identified by
+// either their names or their delta-encoded SIDs, in which case the SIDs
+// must be supplied using the CBORSIDs option. The encodings of values that
+// are accepted are those produced by ygot.EmitCBOR. The supplied options
+// control the behaviour of unmarshalling in the same manner as for
+// Unmarshal.
+func UnmarshalCBOR(schema *yang.Entry, parent any, data []byte, opts ...UnmarshalOpt) error {
+	v, err := util.UnmarshalCBOR(data)
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal CBOR: %v", err)
+	}
+	m, ok := v.(map[any]any)
+	if !ok {
+		return fmt.Errorf("invalid YANG-CBOR: got %T, want map", v)
+	}
+
+	d := &cborDecoder{ignoreExtraFields: hasIgnoreExtraFields(opts)}
+	for _, o := range opts {
+		if s, ok := o.(*CBORSIDs); ok {
+			d.sidMap = s.SIDs
+		}
+	}
+	if d.sidMap != nil {
+		d.ids = make(map[uint64]string, len(d.sidMap))
+		for id, sid := range d.sidMap {
+			d.ids[sid] = id
+		}
+	}
+	jv, err := d.object(schema, "", 0, true, m)
+	if err != nil {
+		return err
+	}
+	return Unmarshal(schema, parent, jv, opts...)
+}
+
+// cborDecoder converts YANG-CBOR to the RFC7951 JSON rendering of a data
+// tree, such that it can be unmarshalled.
+type cborDecoder struct {
+	// sidMap maps schema node identifiers to SIDs.
+	sidMap map[string]uint64
+	// ids maps SIDs to schema node identifiers.
+	ids map[uint64]string
+	// ignoreExtraFields specifies that members that are not described by
+	// the schema are skipped.
+	ignoreExtraFields bool
+}
+
+// object returns the JSON object representing m, which is the CBOR map
+// representing the contents of the directory e, whose schema node
+// identifier is id. hasSID indicates whether the SID of e, sid, is known.
+func (d *cborDecoder) object(e *yang.Entry, id string, sid uint64, hasSID bool, m map[any]any) (map[string]any, error) {
+	obj := make(map[string]any, len(m))
+	for k, v := range m {
+		name, chSID, chHasSID, err := d.member(id, sid, hasSID, k)
+		if err != nil {
+			return nil, err
+		}
+		ch := cborChildSchema(e, util.StripModulePrefix(name))
+		if ch == nil {
+			if d.ignoreExtraFields {
+				continue
+			}
+			return nil, fmt.Errorf("cannot find schema for %s/%s", id, name)
+		}
+		jv, err := d.node(ch, id+"/"+name, chSID, chHasSID, v)
+		if err != nil {
+			return nil, err
+		}
+		obj[name] = jv
+	}
+	return obj, nil
+}
+
+// cborChildSchema returns the schema of the data node named name that is a
+// child of the directory e, or nil if there is no such node.
+func cborChildSchema(e *yang.Entry, name string) *yang.Entry {
+	for _, ch := range util.FindFirstNonChoiceOrCase(e) {
+		if ch.Name == name {
+			return ch
+		}
+	}
+	return nil
+}
+
+// member returns the name of the member of the directory with schema node
+// identifier id that is identified by the CBOR map key k, along with its SID
+// and whether its SID is known. sid is the SID of the directory, which is
+// known if hasSID is true.
+func (d *cborDecoder) member(id string, sid uint64, hasSID bool, k any) (string, uint64, bool, error) {
+	var delta int64
+	switch k := k.(type) {
+	case string:
+		chSID, ok := d.sidMap[id+"/"+k]
+		return k, chSID, ok, nil
+	case uint64:
+		if k > math.MaxInt64 {
+			return "", 0, false, fmt.Errorf("invalid SID delta %d within %s", k, id)
+		}
+		delta = int64(k)
+	case int64:
+		delta = k
+	}
+	if !hasSID {
+		return "", 0, false, fmt.Errorf("cannot resolve SID delta %d within %s, whose SID is unknown", delta, id)
+	}
+	chSID := sid + uint64(delta)
+	chID, ok := d.ids[chSID]
+	if !ok {
+		return "", 0, false, fmt.Errorf("unknown SID %d within %s", chSID, id)
+	}
+	name, ok := strings.CutPrefix(chID, id+"/")
+	if !ok || strings.Contains(name, "/") {
+		return "", 0, false, fmt.Errorf("SID %d of %s does not identify a child of %s", chSID, chID, id)
+	}
+	return name, chSID, true, nil
+}
+
+// node returns the JSON value representing v, which is the CBOR data item
+// representing the data node e, whose schema node identifier is id. hasSID
+// indicates whether the SID of e, sid, is known.
+func (d *cborDecoder) node(e *yang.Entry, id string, sid uint64, hasSID bool, v any) (any, error) {
+	switch {
+	case e.IsList(), e.IsLeafList():
+		arr, ok := v.([]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid YANG-CBOR value for %s: got %T, want array", id, v)
+		}
+		entries := make([]any, 0, len(arr))
+		for _, ev := range arr {
+			var (
+				je  any
+				err error
+			)
+			if m, ok := ev.(map[any]any); ok && e.IsList() {
+				je, err = d.object(e, id, sid, hasSID, m)
+			} else {
+				je, err = d.leaf(e, id, ev)
+			}
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, je)
+		}
+		return entries, nil
+	case e.IsDir():
+		m, ok := v.(map[any]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid YANG-CBOR value for %s: got %T, want map", id, v)
+		}
+		return d.object(e, id, sid, hasSID, m)
+	}
+	return d.leaf(e, id, v)
+}
+
+// leaf returns the JSON value representing v, which is the CBOR data item
+// representing a value of the leaf, or leaf-list, e, whose schema node
+// identifier is id.
+func (d *cborDecoder) leaf(e *yang.Entry, id string, v any) (any, error) {
+	if e.IsList() {
+		return nil, fmt.Errorf("invalid YANG-CBOR value for %s: got %T, want map", id, v)
+	}
+	jv, err := cborToJSONLeaf(e, e.Type, v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid YANG-CBOR value for %s: %v", id, err)
+	}
+	return jv, nil
+}
+
+// cborToJSONLeaf returns the RFC7951 JSON value representing v, which is the
+// YANG-CBOR encoding of a value of the type t of the leaf, or leaf-list, e.
+func cborToJSONLeaf(e *yang.Entry, t *yang.YangType, v any) (any, error) {
+	t, err := resolveCBORLeafRef(e, t)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t.Kind {
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yuint8, yang.Yuint16, yang.Yuint32:
+		switch v := v.(type) {
+		case uint64:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		}
+		return nil, fmt.Errorf("got %T, want integer", v)
+	case yang.Yint64, yang.Yuint64:
+		switch v := v.(type) {
+		case uint64:
+			return strconv.FormatUint(v, 10), nil
+		case int64:
+			return strconv.FormatInt(v, 10), nil
+		}
+		return nil, fmt.Errorf("got %T, want integer", v)
+	case yang.Ydecimal64:
+		return cborDecimal(v)
+	case yang.Yempty:
+		if v != nil {
+			return nil, fmt.Errorf("got %T, want null", v)
+		}
+		return []any{nil}, nil
+	case yang.Ybinary:
+		b, ok := v.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("got %T, want byte string", v)
+		}
+		return base64.StdEncoding.EncodeToString(b), nil
+	case yang.Ybits:
+		return cborBitNames(t, v)
+	case yang.Yenum:
+		var i int64
+		switch v := v.(type) {
+		case uint64:
+			if v > math.MaxInt64 {
+				return nil, fmt.Errorf("invalid enumeration value %d", v)
+			}
+			i = int64(v)
+		case int64:
+			i = v
+		case util.CBORTag:
+			if s, ok := v.Content.(string); ok && v.Number == ygot.CBORTagEnumeration {
+				return s, nil
+			}
+			return nil, fmt.Errorf("invalid tagged enumeration value %v", v)
+		default:
+			return nil, fmt.Errorf("got %T, want integer", v)
+		}
+		if t.Enum == nil {
+			return nil, fmt.Errorf("no enumeration values are specified")
+		}
+		name, ok := t.Enum.ValueMap()[i]
+		if !ok {
+			return nil, fmt.Errorf("%d is not a valid enumeration value", i)
+		}
+		return name, nil
+	case yang.Yunion:
+		return cborToJSONUnion(e, t, v)
+	}
+	return v, nil
+}
+
+// cborToJSONUnion returns the RFC7951 JSON value representing v, which is
+// the YANG-CBOR encoding of a value of the union type t of the leaf e. v is
+// decoded as the first member type of t that it is a valid encoding of.
+func cborToJSONUnion(e *yang.Entry, t *yang.YangType, v any) (any, error) {
+	if tag, ok := v.(util.CBORTag); ok {
+		switch tag.Number {
+		case ygot.CBORTagEnumeration, ygot.CBORTagBits:
+			if s, ok := tag.Content.(string); ok {
+				return s, nil
+			}
+			return nil, fmt.Errorf("invalid tagged union value %v", v)
+		}
+	}
+	for _, mt := range t.Type {
+		mt, err := resolveCBORLeafRef(e, mt)
+		if err != nil {
+			return nil, err
+		}
+		if !cborUnionMember(mt, v) {
+			continue
+		}
+		if jv, err := cborToJSONLeaf(e, mt, v); err == nil {
+			return jv, nil
+		}
+	}
+	return nil, fmt.Errorf("%v does not match any union member type", v)
+}
+
+// cborIntRanges is the range of values of each integer type.
+var cborIntRanges = map[yang.TypeKind]yang.YangRange{
+	yang.Yint8:   yang.Int8Range,
+	yang.Yint16:  yang.Int16Range,
+	yang.Yint32:  yang.Int32Range,
+	yang.Yint64:  yang.Int64Range,
+	yang.Yuint8:  yang.Uint8Range,
+	yang.Yuint16: yang.Uint16Range,
+	yang.Yuint32: yang.Uint32Range,
+	yang.Yuint64: yang.Uint64Range,
+}
+
+// cborUnionMember reports whether v, which is a YANG-CBOR value within a
+// union, may be a value of the union member type t.
+func cborUnionMember(t *yang.YangType, v any) bool {
+	switch v := v.(type) {
+	case uint64, int64:
+		var i yang.Number
+		if u, ok := v.(uint64); ok {
+			i = yang.FromUint(u)
+		} else {
+			i = yang.FromInt(v.(int64))
+		}
+		base, ok := cborIntRanges[t.Kind]
+		if !ok {
+			return false
+		}
+		return util.ValueInRanges(base, i) && (len(t.Range) == 0 || util.ValueInRanges(t.Range, i))
+	case util.CBORTag:
+		return t.Kind == yang.Ydecimal64 && v.Number == ygot.CBORTagDecimalFraction
+	case bool:
+		return t.Kind == yang.Ybool
+	case nil:
+		return t.Kind == yang.Yempty
+	case []byte:
+		return t.Kind == yang.Ybinary
+	case string:
+		switch t.Kind {
+		case yang.Ystring, yang.YinstanceIdentifier:
+			return util.ValidateStringRestrictions(t, v) == nil
+		case yang.Yidentityref:
+			return true
+		}
+	}
+	return false
+}
+
+// cborDecimal returns the RFC7951 JSON value representing v, which is the
+// YANG-CBOR encoding of a decimal64 value as a decimal fraction.
+func cborDecimal(v any) (string, error) {
+	tag, ok := v.(util.CBORTag)
+	if !ok || tag.Number != ygot.CBORTagDecimalFraction {
+		return "", fmt.Errorf("got %v, want decimal fraction", v)
+	}
+	parts, ok := tag.Content.([]any)
+	if !ok || len(parts) != 2 {
+		return "", fmt.Errorf("invalid decimal fraction %v", v)
+	}
+	exp, ok := parts[0].(int64)
+	if !ok {
+		if u, isUint := parts[0].(uint64); !isUint || u != 0 {
+			return "", fmt.Errorf("invalid decimal fraction exponent %v", parts[0])
+		}
+	}
+	if exp < -18 {
+		return "", fmt.Errorf("invalid decimal fraction exponent %d", exp)
+	}
+
+	var (
+		mant uint64
+		neg  bool
+	)
+	switch m := parts[1].(type) {
+	case uint64:
+		mant = m
+	case int64:
+		// The mantissa is negative, since unsigned integers are decoded
+		// as uint64.
+		mant, neg = uint64(-(m+1))+1, true
+	default:
+		return "", fmt.Errorf("invalid decimal fraction mantissa %v", parts[1])
+	}
+
+	digits := strconv.FormatUint(mant, 10)
+	fd := int(-exp)
+	if len(digits) <= fd {
+		digits = strings.Repeat("0", fd-len(digits)+1) + digits
+	}
+	s := digits
+	if fd > 0 {
+		s = digits[:len(digits)-fd] + "." + digits[len(digits)-fd:]
+	}
+	if neg {
+		s = "-" + s
+	}
+	return s, nil
+}
+
+// cborBitNames returns the RFC7951 JSON value representing v, which is the
+// YANG-CBOR encoding of a value of the bits type t as a byte string.
+func cborBitNames(t *yang.YangType, v any) (string, error) {
+	if tag, ok := v.(util.CBORTag); ok && tag.Number == ygot.CBORTagBits {
+		if s, ok := tag.Content.(string); ok {
+			return s, nil
+		}
+	}
+	b, ok := v.([]byte)
+	if !ok {
+		return "", fmt.Errorf("got %T, want byte string", v)
+	}
+	if t.Bit == nil {
+		return "", fmt.Errorf("no bits are specified")
+	}
+	positions := t.Bit.ValueMap()
+	var names []string
+	for i, c := range b {
+		for j := 0; j < 8; j++ {
+			if c&(1<<j) == 0 {
+				continue
+			}
+			p := int64(i*8 + j)
+			name, ok := positions[p]
+			if !ok {
+				return "", fmt.Errorf("bit at position %d is not defined", p)
+			}
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, " "), nil
+}
+
+// resolveCBORLeafRef returns the type of the leaf referenced by the leafref
+// type t of the leaf e, or t if it is not a leafref.
+func resolveCBORLeafRef(e *yang.Entry, t *yang.YangType) (*yang.YangType, error) {
+	if t == nil {
+		return nil, fmt.Errorf("no type is specified for %s", e.Name)
+	}
+	if t.Kind != yang.Yleafref {
+		return t, nil
+	}
+	target, err := util.FindLeafRefSchema(e, t.Path)
+	if err != nil {
+		return nil, err
+	}
+	if target.Type == nil {
+		return nil, fmt.Errorf("no type is specified for %s", target.Name)
+	}
+	return target.Type, nil
+}
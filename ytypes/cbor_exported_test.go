@@ -0,0 +1,170 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+)
+
+// cborTestSIDs are the SIDs of the data nodes of the interfaces used within
+// the YANG-CBOR tests.
+var cborTestSIDs = map[string]uint64{
+	"/openconfig-interfaces:interfaces":                          1000,
+	"/openconfig-interfaces:interfaces/interface":                1001,
+	"/openconfig-interfaces:interfaces/interface/name":           1002,
+	"/openconfig-interfaces:interfaces/interface/config":         1003,
+	"/openconfig-interfaces:interfaces/interface/config/name":    1004,
+	"/openconfig-interfaces:interfaces/interface/config/mtu":     1005,
+	"/openconfig-interfaces:interfaces/interface/config/type":    1006,
+	"/openconfig-interfaces:interfaces/interface/config/enabled": 990,
+}
+
+func TestUnmarshalCBOR(t *testing.T) {
+	full := &exampleoc.Device{}
+	i := full.GetOrCreateInterface("eth0")
+	i.Description = ygot.String("uplink")
+	i.Enabled = ygot.Bool(true)
+	i.Mtu = ygot.Uint16(9000)
+	i.Type = exampleoc.IETFInterfaces_InterfaceType_ethernetCsmacd
+	i.GetOrCreateCounters().InOctets = ygot.Uint64(1 << 60)
+	i.GetOrCreateSubinterface(10).Enabled = ygot.Bool(false)
+	i.OperStatus = exampleoc.Interface_OperStatus_DORMANT
+	full.GetOrCreateSystem().GetOrCreateDns().Search = []string{"example.com", "example.net"}
+	acl := full.GetOrCreateAcl().GetOrCreateAclSet("acl", exampleoc.Acl_ACL_TYPE_ACL_IPV4)
+	acl.GetOrCreateAclEntry(1).GetOrCreateIpv4().Protocol = exampleoc.UnionUint8(6)
+	acl.GetOrCreateAclEntry(2).GetOrCreateIpv4().Protocol = exampleoc.PacketMatchTypes_IP_PROTOCOL_IP_TCP
+
+	small := &exampleoc.Device{}
+	si := small.GetOrCreateInterface("eth0")
+	si.Enabled = ygot.Bool(false)
+	si.Mtu = ygot.Uint16(1500)
+	si.Type = exampleoc.IETFInterfaces_InterfaceType_ethernetCsmacd
+
+	schema := exampleoc.SchemaTree["Device"]
+	emit := func(gs ygot.GoStruct, opts *ygot.EmitCBORConfig) []byte {
+		t.Helper()
+		b, err := ygot.EmitCBOR(gs, opts)
+		if err != nil {
+			t.Fatalf("EmitCBOR: got unexpected error: %v", err)
+		}
+		return b
+	}
+	encode := func(v any) []byte {
+		t.Helper()
+		b, err := util.MarshalCBOR(v)
+		if err != nil {
+			t.Fatalf("MarshalCBOR: got unexpected error: %v", err)
+		}
+		return b
+	}
+
+	tests := []struct {
+		desc             string
+		in               []byte
+		inOpts           []ytypes.UnmarshalOpt
+		want             *exampleoc.Device
+		wantErrSubstring string
+	}{{
+		desc: "round trip with name keys",
+		in:   emit(full, &ygot.EmitCBORConfig{Schema: schema}),
+		want: full,
+	}, {
+		desc: "round trip with SID keys",
+		in: emit(small, &ygot.EmitCBORConfig{
+			Schema:      schema,
+			KeyEncoding: ygot.CBORSIDKeys,
+			SIDs:        cborTestSIDs,
+		}),
+		inOpts: []ytypes.UnmarshalOpt{&ytypes.CBORSIDs{SIDs: cborTestSIDs}},
+		want:   small,
+	}, {
+		desc: "SID keys below name keys",
+		in: encode(map[any]any{
+			"openconfig-interfaces:interfaces": map[any]any{
+				"interface": []any{map[any]any{
+					uint64(1): "eth0",
+					uint64(2): map[any]any{uint64(1): "eth0", int64(-13): false},
+				}},
+			},
+		}),
+		inOpts: []ytypes.UnmarshalOpt{&ytypes.CBORSIDs{SIDs: cborTestSIDs}},
+		want: &exampleoc.Device{
+			Interface: map[string]*exampleoc.Interface{
+				"eth0": {Name: ygot.String("eth0"), Enabled: ygot.Bool(false)},
+			},
+		},
+	}, {
+		desc:             "SID keys without SIDs",
+		in:               encode(map[any]any{uint64(1000): map[any]any{}}),
+		wantErrSubstring: "unknown SID 1000",
+	}, {
+		desc:             "SID of node that is not a child",
+		in:               encode(map[any]any{uint64(1001): []any{}}),
+		inOpts:           []ytypes.UnmarshalOpt{&ytypes.CBORSIDs{SIDs: cborTestSIDs}},
+		wantErrSubstring: "does not identify a child of",
+	}, {
+		desc:             "unknown member",
+		in:               encode(map[any]any{"not-a-node": uint64(1)}),
+		wantErrSubstring: "cannot find schema for /not-a-node",
+	}, {
+		desc:   "unknown member ignored",
+		in:     encode(map[any]any{"not-a-node": uint64(1)}),
+		inOpts: []ytypes.UnmarshalOpt{&ytypes.IgnoreExtraFields{}},
+		want:   &exampleoc.Device{},
+	}, {
+		desc:             "invalid leaf value",
+		in:               encode(map[any]any{"openconfig-system:system": map[any]any{"config": map[any]any{"login-banner": uint64(1)}}}),
+		wantErrSubstring: "expect string",
+	}, {
+		desc: "invalid integer value",
+		in: encode(map[any]any{
+			"openconfig-interfaces:interfaces": map[any]any{
+				"interface": []any{map[any]any{"name": "eth0", "config": map[any]any{"mtu": "1500"}}},
+			},
+		}),
+		wantErrSubstring: "invalid YANG-CBOR value for /openconfig-interfaces:interfaces/interface/config/mtu: got string, want integer",
+	}, {
+		desc:             "not a map",
+		in:               encode([]any{}),
+		wantErrSubstring: "want map",
+	}, {
+		desc:             "invalid CBOR",
+		in:               []byte{0x19},
+		wantErrSubstring: "cannot unmarshal CBOR",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := &exampleoc.Device{}
+			err := ytypes.UnmarshalCBOR(schema, got, tt.in, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("UnmarshalCBOR: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UnmarshalCBOR: did not get expected struct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
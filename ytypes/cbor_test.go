@@ -0,0 +1,188 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
+
+func TestCBORToJSONLeaf(t *testing.T) {
+	enumType := yang.NewEnumType()
+	enumType.Set("UP", 1)
+	enumType.Set("DOWN", 2)
+	bitsType := yang.NewBitfield()
+	bitsType.Set("A", 0)
+	bitsType.Set("B", 3)
+	bitsType.Set("C", 9)
+
+	tests := []struct {
+		desc             string
+		inType           *yang.YangType
+		inValue          any
+		want             any
+		wantErrSubstring string
+	}{{
+		desc:    "int8",
+		inType:  &yang.YangType{Kind: yang.Yint8},
+		inValue: int64(-5),
+		want:    float64(-5),
+	}, {
+		desc:    "uint32",
+		inType:  &yang.YangType{Kind: yang.Yuint32},
+		inValue: uint64(4294967295),
+		want:    float64(4294967295),
+	}, {
+		desc:             "uint16 as string",
+		inType:           &yang.YangType{Kind: yang.Yuint16},
+		inValue:          "10",
+		wantErrSubstring: "got string, want integer",
+	}, {
+		desc:    "int64",
+		inType:  &yang.YangType{Kind: yang.Yint64},
+		inValue: int64(-9223372036854775808),
+		want:    "-9223372036854775808",
+	}, {
+		desc:    "uint64",
+		inType:  &yang.YangType{Kind: yang.Yuint64},
+		inValue: uint64(18446744073709551615),
+		want:    "18446744073709551615",
+	}, {
+		desc:    "negative decimal64",
+		inType:  &yang.YangType{Kind: yang.Ydecimal64, FractionDigits: 2},
+		inValue: util.CBORTag{Number: 4, Content: []any{int64(-2), int64(-250)}},
+		want:    "-2.50",
+	}, {
+		desc:    "decimal64 smaller than one",
+		inType:  &yang.YangType{Kind: yang.Ydecimal64, FractionDigits: 3},
+		inValue: util.CBORTag{Number: 4, Content: []any{int64(-3), uint64(5)}},
+		want:    "0.005",
+	}, {
+		desc:    "decimal64 with zero exponent",
+		inType:  &yang.YangType{Kind: yang.Ydecimal64, FractionDigits: 1},
+		inValue: util.CBORTag{Number: 4, Content: []any{uint64(0), uint64(7)}},
+		want:    "7",
+	}, {
+		desc:             "decimal64 without tag",
+		inType:           &yang.YangType{Kind: yang.Ydecimal64, FractionDigits: 1},
+		inValue:          uint64(7),
+		wantErrSubstring: "want decimal fraction",
+	}, {
+		desc:    "empty",
+		inType:  &yang.YangType{Kind: yang.Yempty},
+		inValue: nil,
+		want:    []any{nil},
+	}, {
+		desc:    "binary",
+		inType:  &yang.YangType{Kind: yang.Ybinary},
+		inValue: []byte{1, 2, 3},
+		want:    "AQID",
+	}, {
+		desc:    "bits",
+		inType:  &yang.YangType{Kind: yang.Ybits, Bit: bitsType},
+		inValue: []byte{0x09, 0x02},
+		want:    "A B C",
+	}, {
+		desc:             "undefined bit",
+		inType:           &yang.YangType{Kind: yang.Ybits, Bit: bitsType},
+		inValue:          []byte{0x02},
+		wantErrSubstring: "bit at position 1 is not defined",
+	}, {
+		desc:    "enumeration",
+		inType:  &yang.YangType{Kind: yang.Yenum, Enum: enumType},
+		inValue: uint64(2),
+		want:    "DOWN",
+	}, {
+		desc:             "undefined enumeration value",
+		inType:           &yang.YangType{Kind: yang.Yenum, Enum: enumType},
+		inValue:          uint64(3),
+		wantErrSubstring: "3 is not a valid enumeration value",
+	}, {
+		desc: "union with integer",
+		inType: &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{
+				{Kind: yang.Yenum, Enum: enumType},
+				{Kind: yang.Yuint64},
+			},
+		},
+		inValue: uint64(42),
+		want:    "42",
+	}, {
+		desc: "union with tagged enumeration",
+		inType: &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{
+				{Kind: yang.Yuint64},
+				{Kind: yang.Yenum, Enum: enumType},
+			},
+		},
+		inValue: util.CBORTag{Number: 44, Content: "UP"},
+		want:    "UP",
+	}, {
+		desc: "union with integer outside member range",
+		inType: &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{
+				{Kind: yang.Yuint8, Range: yang.YangRange{{Min: yang.FromInt(0), Max: yang.FromInt(10)}}},
+				{Kind: yang.Yint16},
+			},
+		},
+		inValue: uint64(20),
+		want:    float64(20),
+	}, {
+		desc: "union with decimal64",
+		inType: &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{
+				{Kind: yang.Ystring},
+				{Kind: yang.Ydecimal64, FractionDigits: 1},
+			},
+		},
+		inValue: util.CBORTag{Number: 4, Content: []any{int64(-1), uint64(15)}},
+		want:    "1.5",
+	}, {
+		desc: "union matching no member",
+		inType: &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{
+				{Kind: yang.Yuint8},
+				{Kind: yang.Ybool},
+			},
+		},
+		inValue:          "abc",
+		wantErrSubstring: "does not match any union member type",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			e := &yang.Entry{Name: "leaf", Kind: yang.LeafEntry, Type: tt.inType}
+			got, err := cborToJSONLeaf(e, tt.inType, tt.inValue)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("cborToJSONLeaf(%v): %s", tt.inValue, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("cborToJSONLeaf(%v): did not get expected value, diff(-want, +got):\n%s", tt.inValue, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// ChangeSet accumulates a set of SetNode and DeleteNode operations against the
+// root of a schema, without modifying it. The operations are staged against a
+// copy of the root, such that their result can be previewed and validated
+// before they are applied, allowing dry-run semantics for configuration
+// APIs.
+//
+// A ChangeSet is not safe for concurrent use.
+type ChangeSet struct {
+	// schema is the schema whose root the operations are applied to.
+	schema *Schema
+	// ops is the set of operations, in the order in which they were added.
+	ops []*changeOp
+}
+
+// changeOp is a single operation within a ChangeSet.
+type changeOp struct {
+	// path is the path that the operation applies to.
+	path *gpb.Path
+	// del indicates that the operation is a deletion.
+	del bool
+	// val is the value that is set by the operation when it is not a
+	// deletion.
+	val any
+	// setOpts are the options supplied to SetNode.
+	setOpts []SetNodeOpt
+	// delOpts are the options supplied to DeleteNode.
+	delOpts []DelNodeOpt
+}
+
+// NewChangeSet returns an empty ChangeSet for the root of schema. The root,
+// and the schema tree for its type, must be populated.
+func NewChangeSet(schema *Schema) (*ChangeSet, error) {
+	if schema == nil || schema.Root == nil || schema.SchemaTree == nil {
+		return nil, errors.New("invalid schema: root and schema tree must be populated")
+	}
+	if schema.RootSchema() == nil {
+		return nil, fmt.Errorf("invalid schema: no schema for root type %T", schema.Root)
+	}
+	return &ChangeSet{schema: schema}, nil
+}
+
+// Set adds an operation that sets the node at path to val, as per SetNode,
+// to the ChangeSet. It returns the ChangeSet such that calls can be chained.
+func (c *ChangeSet) Set(path *gpb.Path, val any, opts ...SetNodeOpt) *ChangeSet {
+	c.ops = append(c.ops, &changeOp{path: path, val: val, setOpts: opts})
+	return c
+}
+
+// Delete adds an operation that deletes the node at path, as per DeleteNode,
+// to the ChangeSet. It returns the ChangeSet such that calls can be chained.
+func (c *ChangeSet) Delete(path *gpb.Path, opts ...DelNodeOpt) *ChangeSet {
+	c.ops = append(c.ops, &changeOp{path: path, del: true, delOpts: opts})
+	return c
+}
+
+// Len returns the number of operations within the ChangeSet.
+func (c *ChangeSet) Len() int {
+	return len(c.ops)
+}
+
+// Stage applies the operations within the ChangeSet, in the order in which
+// they were added, to a copy of the root of the schema, and returns the
+// copy. The root itself is not modified. The result is not validated.
+func (c *ChangeSet) Stage() (ygot.GoStruct, error) {
+	staged, err := ygot.DeepCopy(c.schema.Root)
+	if err != nil {
+		return nil, fmt.Errorf("cannot copy root: %v", err)
+	}
+	rootSchema := c.schema.RootSchema()
+	for i, op := range c.ops {
+		var err error
+		switch {
+		case op.del:
+			err = DeleteNode(rootSchema, staged, op.path, op.delOpts...)
+		default:
+			err = SetNode(rootSchema, staged, op.path, op.val, op.setOpts...)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("operation %d on path %s failed: %w", i, pathString(op.path), err)
+		}
+	}
+	return staged, nil
+}
+
+// Validate stages the ChangeSet, and validates the result against the
+// schema. Errors returned by validation are of kind util.ErrValidation.
+func (c *ChangeSet) Validate(opts ...ygot.ValidationOption) error {
+	_, err := c.stageAndValidate(opts...)
+	return err
+}
+
+// Preview stages the ChangeSet, and returns the changes that applying it
+// would make to the root of the schema, as a gNMI Notification produced by
+// ygot.Diff using the supplied opts. The result is not validated.
+func (c *ChangeSet) Preview(opts ...ygot.DiffOpt) (*gpb.Notification, error) {
+	staged, err := c.Stage()
+	if err != nil {
+		return nil, err
+	}
+	return ygot.Diff(c.schema.Root, staged, opts...)
+}
+
+// Apply stages and validates the ChangeSet and, only if the result is
+// valid, replaces the contents of the root of the schema with it. The
+// operations are removed from the ChangeSet once they have been applied.
+func (c *ChangeSet) Apply(opts ...ygot.ValidationOption) error {
+	staged, err := c.stageAndValidate(opts...)
+	if err != nil {
+		return err
+	}
+	reflect.ValueOf(c.schema.Root).Elem().Set(reflect.ValueOf(staged).Elem())
+	c.ops = nil
+	return nil
+}
+
+// stageAndValidate stages the ChangeSet, and returns the result if it is
+// valid according to the schema.
+func (c *ChangeSet) stageAndValidate(opts ...ygot.ValidationOption) (ygot.GoStruct, error) {
+	staged, err := c.Stage()
+	if err != nil {
+		return nil, err
+	}
+	if errs := Validate(c.schema.RootSchema(), staged, opts...); errs != nil {
+		return nil, util.WithKind(util.ErrValidation, errs)
+	}
+	return staged, nil
+}
+
+// pathString returns a human-readable representation of the path p for use
+// in error messages.
+func pathString(p *gpb.Path) string {
+	s, err := ygot.PathToString(p)
+	if err != nil {
+		return p.String()
+	}
+	return s
+}
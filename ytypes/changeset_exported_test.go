@@ -0,0 +1,120 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/integration_tests/schemaops/ctestschema"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestChangeSet(t *testing.T) {
+	root := &ctestschema.Device{
+		OtherData: &ctestschema.OtherData{Motd: ygot.String("hello")},
+	}
+	schema := &ytypes.Schema{Root: root, SchemaTree: ctestschema.SchemaTree}
+
+	cs, err := ytypes.NewChangeSet(schema)
+	if err != nil {
+		t.Fatalf("NewChangeSet: got unexpected error: %v", err)
+	}
+	cs.Set(mustPath("/other-data/config/motd"), &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "goodbye"}}).
+		Set(mustPath("/unordered-lists/unordered-list[key=a]/config/key"), &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "a"}}, &ytypes.InitMissingElements{})
+	if got := cs.Len(); got != 2 {
+		t.Errorf("Len: got %d, want 2", got)
+	}
+
+	got, err := cs.Preview()
+	if err != nil {
+		t.Fatalf("Preview: got unexpected error: %v", err)
+	}
+	want := &gpb.Notification{
+		Update: []*gpb.Update{{
+			Path: mustPath("/other-data/config/motd"),
+			Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "goodbye"}},
+		}, {
+			Path: mustPath("/unordered-lists/unordered-list[key=a]/config/key"),
+			Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "a"}},
+		}, {
+			Path: mustPath("/unordered-lists/unordered-list[key=a]/key"),
+			Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "a"}},
+		}},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform(), protocmp.SortRepeatedFields(&gpb.Notification{}, "update")); diff != "" {
+		t.Errorf("Preview: did not get expected diff (-want, +got):\n%s", diff)
+	}
+	if got := root.GetOtherData().GetMotd(); got != "hello" {
+		t.Errorf("root modified by Preview: got motd %q, want hello", got)
+	}
+
+	if err := cs.Apply(); err != nil {
+		t.Fatalf("Apply: got unexpected error: %v", err)
+	}
+	if got := root.GetOtherData().GetMotd(); got != "goodbye" {
+		t.Errorf("Apply: got motd %q, want goodbye", got)
+	}
+	if root.GetUnorderedList("a") == nil {
+		t.Errorf("Apply: list entry a was not created")
+	}
+	if got := cs.Len(); got != 0 {
+		t.Errorf("Len after Apply: got %d, want 0", got)
+	}
+
+	// An operation that fails to be staged is reported, and nothing is
+	// applied.
+	cs.Delete(mustPath("/other-data/config/motd")).
+		Set(mustPath("/other-data/config/no-such-leaf"), &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "x"}})
+	if err := cs.Apply(); err == nil {
+		t.Errorf("Apply: did not get expected error for invalid operation")
+	}
+	if got := root.GetOtherData().GetMotd(); got != "goodbye" {
+		t.Errorf("root modified by failed Apply: got motd %q, want goodbye", got)
+	}
+}
+
+func TestChangeSetValidation(t *testing.T) {
+	root := &ctestschema.Device{}
+	schema := &ytypes.Schema{Root: root, SchemaTree: ctestschema.SchemaTree}
+	cs, err := ytypes.NewChangeSet(schema)
+	if err != nil {
+		t.Fatalf("NewChangeSet: got unexpected error: %v", err)
+	}
+	// The key within the list entry does not match its key in the list.
+	cs.Set(mustPath("/unordered-lists/unordered-list[key=a]/config/key"), &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "b"}}, &ytypes.InitMissingElements{})
+	if _, err := cs.Stage(); err != nil {
+		t.Fatalf("Stage: got unexpected error: %v", err)
+	}
+	if err := cs.Validate(); !errors.Is(err, util.ErrValidation) {
+		t.Errorf("Validate: got error %v, want error of kind ErrValidation", err)
+	}
+	if err := cs.Apply(); !errors.Is(err, util.ErrValidation) {
+		t.Errorf("Apply: got error %v, want error of kind ErrValidation", err)
+	}
+	if len(root.UnorderedList) != 0 {
+		t.Errorf("root modified by invalid Apply: got %v", root.UnorderedList)
+	}
+
+	if _, err := ytypes.NewChangeSet(&ytypes.Schema{}); err == nil {
+		t.Errorf("NewChangeSet: did not get expected error for empty schema")
+	}
+}
@@ -55,10 +55,21 @@ func validateContainer(schema *yang.Entry, value ygot.GoStruct) util.Errors {
 		for i := 0; i < structElems.NumField(); i++ {
 			fieldType := structElems.Type().Field(i)
 			fieldName := fieldType.Name
-			fieldValue := structElems.Field(i).Interface()
+			fieldValue := util.FieldValue(structElems, i).Interface()
 
-			// Skip annotation fields when validating the schema.
-			if util.IsYgotAnnotation(fieldType) {
+			// Skip annotation fields and presence bitmaps when validating
+			// the schema.
+			if util.IsYgotAnnotation(fieldType) || util.IsYgotPresence(fieldType) {
+				continue
+			}
+			// Extension structs are validated against their own schema,
+			// which includes the nodes that augment the container.
+			if util.IsYgotExtensions(fieldType) {
+				for _, ext := range util.AttachedExtensions(structElems) {
+					if gs, ok := ext.Value.Interface().(ygot.GoStruct); ok {
+						errors = util.AppendErrs(errors, validateContainer(ext.Schema, gs))
+					}
+				}
 				continue
 			}
 
@@ -69,10 +80,10 @@ func validateContainer(schema *yang.Entry, value ygot.GoStruct) util.Errors {
 				continue
 			case cschema != nil:
 				// Regular named child.
-				if errs := Validate(cschema, fieldValue); errs != nil {
-					errors = util.AppendErrs(errors, util.PrefixErrors(errs, cschema.Path()))
+				if errs := validateChild(cschema, fieldValue); errs != nil {
+					errors = util.AppendErrs(errors, util.PrefixErrors(withNodeSchema(errs, cschema), cschema.Path()))
 				}
-			case !util.IsValueNilOrDefault(structElems.Field(i).Interface()):
+			case !util.IsValueNilOrDefault(fieldValue):
 				// Either an element in choice schema subtree, or bad field.
 				// If the former, it will be found in the choice check below.
 				extraFields[fieldName] = nil
@@ -147,6 +158,43 @@ func unmarshalContainer(schema *yang.Entry, parent interface{}, jsonTree interfa
 // - parent is the parent struct, which must be a struct ptr.
 // - jsonTree is a JSON data tree which must be a map[string]interface{}.
 func unmarshalStruct(schema *yang.Entry, parent interface{}, jsonTree map[string]interface{}, enc Encoding, opts ...UnmarshalOpt) error {
+	allSchemaPaths, err := unmarshalStructFields(schema, parent, jsonTree, enc, opts...)
+	if err != nil {
+		return err
+	}
+
+	// The nodes that augment the struct are unmarshalled into the extension
+	// structs that are attached to, or registered as extending, it. A new
+	// extension struct is attached only if the JSON tree populates it.
+	for _, c := range extensionCandidates(parent) {
+		sp, err := unmarshalStructFields(c.ext.ΛExtensionSchema(), c.ext, jsonTree, enc, opts...)
+		if err != nil {
+			return err
+		}
+		allSchemaPaths = append(allSchemaPaths, sp...)
+		if err := c.attach(parent); err != nil {
+			return err
+		}
+	}
+
+	// Only check for missing fields if the IgnoreExtraFields option isn't specified.
+	if !hasIgnoreExtraFields(opts) {
+		// Go over all JSON fields to make sure that each one is covered
+		// by a data path in the struct.
+		if err := checkDataTreeAgainstPaths(jsonTree, allSchemaPaths); err != nil {
+			return fmt.Errorf("parent container %s (type %T): %w", schema.Name, parent, err)
+		}
+	}
+
+	util.DbgPrint("container after unmarshal:\n%s\n", pretty.Sprint(reflect.ValueOf(parent).Elem().Interface()))
+	return nil
+}
+
+// unmarshalStructFields unmarshals the JSON tree into the fields of the
+// struct pointer parent, whose schema is schema, as per unmarshalStruct. It
+// returns the data tree paths of the fields, such that the caller can check
+// that the JSON tree does not have nodes that are not covered by them.
+func unmarshalStructFields(schema *yang.Entry, parent interface{}, jsonTree map[string]interface{}, enc Encoding, opts ...UnmarshalOpt) ([][]string, error) {
 	destv := reflect.ValueOf(parent).Elem()
 	var allSchemaPaths [][]string
 
@@ -156,14 +204,24 @@ func unmarshalStruct(schema *yang.Entry, parent interface{}, jsonTree map[string
 		f := destv.Field(i)
 		ft := destv.Type().Field(i)
 
-		// Skip annotation fields since they do not have a schema.
-		// TODO(robjs): Implement unmarshalling annotations.
+		// The presence bitmap is updated as the leaves that it covers are
+		// unmarshalled, and extension structs are unmarshalled into by
+		// unmarshalStruct.
+		if util.IsYgotPresence(ft) || util.IsYgotExtensions(ft) {
+			continue
+		}
+
+		// Annotation fields do not have a schema, and only RFC 7952
+		// metadata objects are unmarshalled into them.
 		if util.IsYgotAnnotation(ft) {
+			if err := unmarshalMetadata(f, ft, jsonTree); err != nil {
+				return nil, err
+			}
 			// We need to find the paths that we should have unmarshalled here to avoid
-			// throwing errors to users whilst there is a TODO above.
+			// throwing errors to users for annotations that are not unmarshalled.
 			paths, err := pathTagFromField(ft)
 			if err != nil {
-				return fmt.Errorf("cannot find JSON field names for annotation field %s, %v", ft.Name, err)
+				return nil, fmt.Errorf("cannot find JSON field names for annotation field %s, %v", ft.Name, err)
 			}
 
 			for _, s := range strings.Split(paths, "|") {
@@ -179,11 +237,11 @@ func unmarshalStruct(schema *yang.Entry, parent interface{}, jsonTree map[string
 		}
 		cschema, err := childSchemaFn(schema, ft)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if cschema == nil {
-			return fmt.Errorf("unmarshalContainer could not find schema for type %T, field name %s", parent, ft.Name)
+			return nil, fmt.Errorf("unmarshalContainer could not find schema for type %T, field name %s", parent, ft.Name)
 		}
 
 		// Store the data tree path of the current field. These will be used
@@ -191,7 +249,7 @@ func unmarshalStruct(schema *yang.Entry, parent interface{}, jsonTree map[string
 		// tree not covered by any data path.
 		sp, err := dataTreePaths(schema, cschema, ft)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		allSchemaPaths = append(allSchemaPaths, sp...)
 
@@ -204,13 +262,13 @@ func unmarshalStruct(schema *yang.Entry, parent interface{}, jsonTree map[string
 		// unmarshalled due to type mismatch.
 		ssp, err := shadowDataTreePaths(schema, cschema, ft)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		allSchemaPaths = append(allSchemaPaths, ssp...)
 
 		jsonValue, err := getJSONTreeValForField(schema, cschema, ft, jsonTree, hasPreferShadowPath(opts))
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if jsonValue == nil {
@@ -239,21 +297,10 @@ func unmarshalStruct(schema *yang.Entry, parent interface{}, jsonTree map[string
 			p = f.Interface()
 		}
 		if err := unmarshalGeneric(cschema, p, jsonValue, enc, opts...); err != nil {
-			return err
+			return nil, err
 		}
 	}
-
-	// Only check for missing fields if the IgnoreExtraFields option isn't specified.
-	if !hasIgnoreExtraFields(opts) {
-		// Go over all JSON fields to make sure that each one is covered
-		// by a data path in the struct.
-		if err := checkDataTreeAgainstPaths(jsonTree, allSchemaPaths); err != nil {
-			return fmt.Errorf("parent container %s (type %T): %s", schema.Name, parent, err)
-		}
-	}
-
-	util.DbgPrint("container after unmarshal:\n%s\n", pretty.Sprint(destv.Interface()))
-	return nil
+	return allSchemaPaths, nil
 }
 
 // validateContainerSchema validates the given container type schema. This is a
@@ -16,11 +16,13 @@ package ytypes
 
 import (
 	"encoding/json"
+	"errors"
 	"reflect"
 	"testing"
 
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
 	"github.com/openconfig/ygot/ygot"
 )
 
@@ -374,13 +376,14 @@ func TestUnmarshalContainer(t *testing.T) {
 	}
 
 	tests := []struct {
-		desc    string
-		schema  *yang.Entry
-		parent  interface{}
-		json    string
-		opts    []UnmarshalOpt
-		want    interface{}
-		wantErr string
+		desc      string
+		schema    *yang.Entry
+		parent    interface{}
+		json      string
+		opts      []UnmarshalOpt
+		want      interface{}
+		wantErr   string
+		wantErrIs error
 	}{
 		{
 			desc:   "success nil value",
@@ -446,11 +449,12 @@ func TestUnmarshalContainer(t *testing.T) {
 			wantErr: `nil schema for parent type *ytypes.ParentContainerStruct, value map[] (map[string]interface {})`,
 		},
 		{
-			desc:    "bad field name",
-			schema:  containerSchema,
-			parent:  &ParentContainerStruct{},
-			json:    `{ "container-field": { "bad-field": 42 } }`,
-			wantErr: `parent container container-field (type *ytypes.ContainerStruct): JSON contains unexpected field bad-field`,
+			desc:      "bad field name",
+			schema:    containerSchema,
+			parent:    &ParentContainerStruct{},
+			json:      `{ "container-field": { "bad-field": 42 } }`,
+			wantErr:   `parent container container-field (type *ytypes.ContainerStruct): JSON contains unexpected field bad-field`,
+			wantErrIs: util.ErrUnknownField,
 		},
 		{
 			desc:    "bad field type",
@@ -466,6 +470,23 @@ func TestUnmarshalContainer(t *testing.T) {
 			json:   `{"container-field": { "@": [ { "hello": "true" } ] } }`,
 			want:   &ParentContainerStruct{ContainerField: &ContainerStruct{}},
 		},
+		{
+			desc:   "RFC 7952 metadata",
+			schema: containerSchema,
+			parent: &ParentContainerStruct{},
+			json:   `{"container-field": { "@": { "ietf-origin:origin": "ietf-origin:intended" }, "@two": { "m:tag": 42 } } }`,
+			want: &ParentContainerStruct{ContainerField: &ContainerStruct{
+				Annotation:    []ygot.Annotation{&ygot.Metadata{Values: map[string]interface{}{"ietf-origin:origin": "ietf-origin:intended"}}},
+				AnnotationTwo: []ygot.Annotation{&ygot.Metadata{Values: map[string]interface{}{"m:tag": float64(42)}}},
+			}},
+		},
+		{
+			desc:    "RFC 7952 metadata with unqualified name",
+			schema:  containerSchema,
+			parent:  &ParentContainerStruct{},
+			json:    `{"container-field": { "@": { "origin": "intended" } } }`,
+			wantErr: `cannot unmarshal metadata for field Annotation: metadata annotation name "origin" is not module-qualified`,
+		},
 		{
 			desc:   "unknown field name with ignore",
 			schema: containerSchema,
@@ -533,6 +554,9 @@ func TestUnmarshalContainer(t *testing.T) {
 			if got, want := errToString(err), tt.wantErr; got != want {
 				t.Errorf("%s: got error: %v, want error: %v", tt.desc, got, want)
 			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("%s: got error: %v, want error of kind: %v", tt.desc, err, tt.wantErrIs)
+			}
 			testErrLog(t, tt.desc, err)
 			if err == nil {
 				if got, want := tt.parent, tt.want; !areEqual(got, want) {
@@ -558,3 +582,53 @@ func TestUnmarshalContainer(t *testing.T) {
 		t.Errorf("nil schema: got error: nil, want nil schema error")
 	}
 }
+
+// PresenceContainerStruct is a container that stores its scalar leaves as
+// values, with their presence recorded in a presence bitmap.
+type PresenceContainerStruct struct {
+	Leaf1Field int32     `path:"leaf1-field" presence:"0"`
+	Leaf2Field string    `path:"leaf2-field" presence:"1"`
+	ΛPresence  [1]uint64 `ygotPresence:"true"`
+}
+
+func (*PresenceContainerStruct) IsYANGGoStruct()                          {}
+func (*PresenceContainerStruct) ΛValidate(...ygot.ValidationOption) error { return nil }
+func (*PresenceContainerStruct) ΛEnumTypeMap() map[string][]reflect.Type  { return nil }
+func (*PresenceContainerStruct) ΛBelongingModule() string                 { return "" }
+
+func TestUnmarshalContainerPresenceBitmaps(t *testing.T) {
+	schema := &yang.Entry{
+		Name: "presence-container",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"leaf1-field": {
+				Kind: yang.LeafEntry,
+				Name: "leaf1-field",
+				Type: &yang.YangType{Kind: yang.Yint32},
+			},
+			"leaf2-field": {
+				Kind: yang.LeafEntry,
+				Name: "leaf2-field",
+				Type: &yang.YangType{Kind: yang.Ystring},
+			},
+		},
+	}
+	populateParentField(nil, schema)
+
+	var jsonTree interface{}
+	if err := json.Unmarshal([]byte(`{"leaf1-field": 0}`), &jsonTree); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	got := &PresenceContainerStruct{}
+	if err := Unmarshal(schema, got, jsonTree); err != nil {
+		t.Fatalf("Unmarshal: got unexpected error: %v", err)
+	}
+	// The leaf that is set to its zero value is recorded as being set.
+	want := &PresenceContainerStruct{ΛPresence: [1]uint64{1}}
+	if !areEqual(got, want) {
+		t.Errorf("Unmarshal: got:\n%v\nwant:\n%v\n", pretty.Sprint(got), pretty.Sprint(want))
+	}
+	if err := Validate(schema, got); err != nil {
+		t.Errorf("Validate: got unexpected error: %v", err)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
 )
 
 // Refer to: https://tools.ietf.org/html/rfc6020#section-9.3.
@@ -26,10 +27,7 @@ import (
 // schema's range restrictions (if any). It returns an error if the validation
 // fails.
 func ValidateDecimalRestrictions(schemaType *yang.YangType, floatVal float64) error {
-	if !isInRanges(schemaType.Range, yang.FromFloat(floatVal)) {
-		return fmt.Errorf("decimal value %v is outside specified ranges", floatVal)
-	}
-	return nil
+	return util.ValidateDecimalRange(schemaType, floatVal)
 }
 
 // validateDecimal validates value, which must be a Go float64 type, against the
@@ -47,7 +45,7 @@ func validateDecimal(schema *yang.Entry, value interface{}) error {
 	}
 
 	if err := ValidateDecimalRestrictions(schema.Type, f); err != nil {
-		return fmt.Errorf("schema %q: %v", schema.Name, err)
+		return fmt.Errorf("schema %q: %w", schema.Name, err)
 	}
 
 	return nil
@@ -71,7 +69,7 @@ func validateDecimalSlice(schema *yang.Entry, value interface{}) error {
 	tbl := make(map[float64]bool, len(slice))
 	for i, val := range slice {
 		if err := validateDecimal(schema, val); err != nil {
-			return fmt.Errorf("invalid element at index %d: %v for schema %s", i, err, schema.Name)
+			return fmt.Errorf("invalid element at index %d: %w for schema %s", i, err, schema.Name)
 		}
 		if tbl[val] {
 			return fmt.Errorf("duplicate decimal: %v for schema %s", val, schema.Name)
@@ -0,0 +1,82 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"reflect"
+
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// extensionCandidate is an extension struct into which the data of nodes
+// that augment a GoStruct may be stored.
+type extensionCandidate struct {
+	// ext is the extension struct.
+	ext ygot.ExtensionStruct
+	// attached indicates whether ext is already attached to the GoStruct.
+	attached bool
+}
+
+// extensionCandidates returns the extension structs that are attached to
+// the GoStruct parent, followed by a new extension struct of each type that
+// is registered as extending parent and is not attached to it. It returns
+// nil if parent is not a GoStruct.
+func extensionCandidates(parent interface{}) []extensionCandidate {
+	gs, ok := parent.(ygot.GoStruct)
+	if !ok || reflect.ValueOf(parent).IsNil() {
+		return nil
+	}
+	var cs []extensionCandidate
+	attached := map[string]bool{}
+	for _, ae := range util.AttachedExtensions(reflect.ValueOf(parent)) {
+		if ext, ok := ae.Value.Interface().(ygot.ExtensionStruct); ok {
+			attached[ae.Name] = true
+			cs = append(cs, extensionCandidate{ext: ext, attached: true})
+		}
+	}
+	for _, ext := range ygot.RegisteredExtensions(gs) {
+		if !attached[ygot.ExtensionName(ext)] {
+			cs = append(cs, extensionCandidate{ext: ext})
+		}
+	}
+	return cs
+}
+
+// attach attaches the extension struct of c to the GoStruct parent if it
+// is not already attached and has been populated.
+func (c extensionCandidate) attach(parent interface{}) error {
+	if c.attached || reflect.ValueOf(c.ext).Elem().IsZero() {
+		return nil
+	}
+	return ygot.AttachExtension(parent.(ygot.GoStruct), c.ext)
+}
+
+// detachIfEmpty detaches the extension struct of c from the GoStruct parent
+// if it has no populated fields, such that a GoStruct whose augmenting nodes
+// have all been deleted is empty.
+func (c extensionCandidate) detachIfEmpty(parent interface{}) {
+	if !c.attached || !reflect.ValueOf(c.ext).Elem().IsZero() {
+		return
+	}
+	fv := reflect.ValueOf(parent).Elem().FieldByName(util.ExtensionsFieldName)
+	if !fv.IsValid() || fv.IsNil() {
+		return
+	}
+	fv.SetMapIndex(reflect.ValueOf(ygot.ExtensionName(c.ext)), reflect.Value{})
+	if fv.Len() == 0 {
+		fv.Set(reflect.Zero(fv.Type()))
+	}
+}
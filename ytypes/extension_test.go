@@ -0,0 +1,169 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// extTop is a GoStruct generated with extension points.
+type extTop struct {
+	Name        *string         `path:"name"`
+	ΛExtensions ygot.Extensions `ygotExtensions:"true"`
+}
+
+func (*extTop) IsYANGGoStruct() {}
+
+// extTopVendor is an extension struct that extends extTop.
+type extTopVendor struct {
+	VendorName *string `path:"vendor-name"`
+}
+
+func (*extTopVendor) IsYANGGoStruct()               {}
+func (*extTopVendor) ΛExtensionSchema() *yang.Entry { return extTopVendorSchema }
+
+var (
+	// extTopSchema is the schema of the container extended by
+	// extTopVendor, as known to the package of extTop.
+	extTopSchema = &yang.Entry{
+		Name: "top",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"name": {Name: "name", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Ystring}},
+		},
+	}
+	// extTopVendorSchema is the schema of the same container, including
+	// the leaf that augments it, as known to the package of extTopVendor.
+	extTopVendorSchema = &yang.Entry{
+		Name: "top",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"name": {Name: "name", Kind: yang.LeafEntry, Type: &yang.YangType{Kind: yang.Ystring}},
+			"vendor-name": {
+				Name: "vendor-name",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{
+					Kind:   yang.Ystring,
+					Length: yang.YangRange{{Min: yang.FromInt(0), Max: yang.FromInt(3)}},
+				},
+			},
+		},
+	}
+)
+
+func init() {
+	for _, s := range []*yang.Entry{extTopSchema, extTopVendorSchema} {
+		for _, e := range s.Dir {
+			e.Parent = s
+		}
+	}
+	ygot.RegisterExtension(&extTop{}, &extTopVendor{})
+}
+
+// newExtTop returns an extTop with an extTopVendor attached whose
+// VendorName is vendorName.
+func newExtTop(name, vendorName string) *extTop {
+	t := &extTop{Name: ygot.String(name)}
+	if err := ygot.AttachExtension(t, &extTopVendor{VendorName: ygot.String(vendorName)}); err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestUnmarshalExtension(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               map[string]any
+		want             *extTop
+		wantErrSubstring string
+	}{{
+		desc: "leaf of extension",
+		in:   map[string]any{"name": "a", "vendor-name": "v"},
+		want: newExtTop("a", "v"),
+	}, {
+		desc: "extension not attached without its leaves",
+		in:   map[string]any{"name": "a"},
+		want: &extTop{Name: ygot.String("a")},
+	}, {
+		desc:             "leaf of neither struct",
+		in:               map[string]any{"name": "a", "bogus": "v"},
+		wantErrSubstring: "JSON contains unexpected field bogus",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := &extTop{}
+			err := Unmarshal(extTopSchema, got, tt.in)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("Unmarshal: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Unmarshal: did not get expected struct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateExtension(t *testing.T) {
+	if errs := Validate(extTopSchema, newExtTop("a", "v")); errs != nil {
+		t.Errorf("Validate(valid extension): unexpected errors: %v", errs)
+	}
+	errs := Validate(extTopSchema, newExtTop("a", "too long"))
+	if diff := errdiff.Substring(util.Errors(errs), "length"); diff != "" {
+		t.Errorf("Validate(invalid extension): %s", diff)
+	}
+}
+
+func TestNodeExtension(t *testing.T) {
+	path := &gpb.Path{Elem: []*gpb.PathElem{{Name: "vendor-name"}}}
+	got := &extTop{Name: ygot.String("a")}
+	if err := SetNode(extTopSchema, got, path, &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "v"}}, &InitMissingElements{}); err != nil {
+		t.Fatalf("SetNode: unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(newExtTop("a", "v"), got); diff != "" {
+		t.Errorf("SetNode: did not get expected struct, diff(-want, +got):\n%s", diff)
+	}
+
+	nodes, err := GetNode(extTopSchema, got, path)
+	if err != nil {
+		t.Fatalf("GetNode: unexpected error: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].Schema != extTopVendorSchema.Dir["vendor-name"] {
+		t.Errorf("GetNode: got %v, want the vendor-name leaf", nodes)
+	}
+
+	if err := DeleteNode(extTopSchema, got, path); err != nil {
+		t.Fatalf("DeleteNode: unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(&extTop{Name: ygot.String("a")}, got); diff != "" {
+		t.Errorf("DeleteNode: extension struct was not detached, diff(-want, +got):\n%s", diff)
+	}
+
+	_, err = GetNode(extTopSchema, got, &gpb.Path{Elem: []*gpb.PathElem{{Name: "bogus"}}})
+	if diff := errdiff.Substring(err, "no match found"); diff != "" {
+		t.Errorf("GetNode(unknown path): %s", diff)
+	}
+}
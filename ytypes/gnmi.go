@@ -25,6 +25,51 @@ import (
 	gpb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
+// Transactional is an unmarshal option that causes UnmarshalSetRequest and
+// UnmarshalNotifications to restore the root GoStruct to its value prior to
+// the call if an error occurs, such that the SetRequest, or the slice of
+// Notifications, is either applied in its entirety or not at all, as the
+// gNMI specification requires of a SetRequest. When it is used along with
+// BestEffortUnmarshal, the root GoStruct is restored if any error occurs.
+//
+// The value of the root GoStruct is restored in place, such that the root
+// pointer remains valid, but its fields are set to a copy of their original
+// values.
+type Transactional struct{}
+
+// IsUnmarshalOpt marks Transactional as a valid UnmarshalOpt.
+func (*Transactional) IsUnmarshalOpt() {}
+
+// hasTransactional determines whether the supplied slice of UnmarshalOpts
+// contains the Transactional option.
+func hasTransactional(opts []UnmarshalOpt) bool {
+	for _, o := range opts {
+		if _, ok := o.(*Transactional); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// transact calls fn, which modifies the root GoStruct specified by
+// "schema". If the Transactional option is supplied within opts and fn
+// returns an error, the root GoStruct is restored to its value prior to
+// calling fn.
+func transact(schema *Schema, opts []UnmarshalOpt, fn func() error) error {
+	if !hasTransactional(opts) {
+		return fn()
+	}
+	orig, err := ygot.DeepCopy(schema.Root)
+	if err != nil {
+		return fmt.Errorf("cannot copy root for rollback: %v", err)
+	}
+	if err := fn(); err != nil {
+		reflect.ValueOf(schema.Root).Elem().Set(reflect.ValueOf(orig).Elem())
+		return err
+	}
+	return nil
+}
+
 // UnmarshalNotifications unmarshals a slice of Notifications on the root
 // GoStruct specified by "schema". It *does not* perform validation after
 // unmarshalling is complete.
@@ -34,35 +79,53 @@ import (
 // to calling this function.
 //
 // If an error occurs during unmarshalling, schema.Root may already be
-// modified. A rollback is not performed.
+// modified. A rollback is only performed if the Transactional option is
+// supplied, in which case none of the Notifications are applied.
 func UnmarshalNotifications(schema *Schema, ns []*gpb.Notification, opts ...UnmarshalOpt) error {
-	for _, n := range ns {
-		deletePaths := n.Delete
-		if n.Atomic {
-			deletePaths = append(deletePaths, &gpb.Path{})
-		}
-		err := UnmarshalSetRequest(schema, &gpb.SetRequest{
-			Prefix: n.Prefix,
-			Delete: deletePaths,
-			Update: n.Update,
-		}, opts...)
-		if err != nil {
-			return err
+	return transact(schema, opts, func() error {
+		for _, n := range ns {
+			deletePaths := n.Delete
+			if n.Atomic {
+				deletePaths = append(deletePaths, &gpb.Path{})
+			}
+			err := unmarshalSetRequest(schema, &gpb.SetRequest{
+				Prefix: n.Prefix,
+				Delete: deletePaths,
+				Update: n.Update,
+			}, opts...)
+			if err != nil {
+				return err
+			}
 		}
-	}
-	return nil
+		return nil
+	})
 }
 
 // UnmarshalSetRequest applies a SetRequest on the root GoStruct specified by
 // "schema". It *does not* perform validation after unmarshalling is complete.
 //
+// As per the gNMI specification, the deletions of the SetRequest are
+// applied first, followed by its replacements, which delete the subtree at
+// each path before setting its value, then its union replacements, which
+// are applied in the same way as replacements, and finally its updates. The
+// prefix of the SetRequest is joined to the path of each operation.
+//
 // It does not make a copy and instead overwrites this value, so make a copy
 // using ygot.DeepCopy() if you wish to retain the value at schema.Root prior
 // to calling this function.
 //
 // If an error occurs during unmarshalling, schema.Root may already be
-// modified. A rollback is not performed.
+// modified. A rollback is only performed if the Transactional option is
+// supplied.
 func UnmarshalSetRequest(schema *Schema, req *gpb.SetRequest, opts ...UnmarshalOpt) error {
+	return transact(schema, opts, func() error {
+		return unmarshalSetRequest(schema, req, opts...)
+	})
+}
+
+// unmarshalSetRequest applies a SetRequest on the root GoStruct specified by
+// "schema", without rolling back on error.
+func unmarshalSetRequest(schema *Schema, req *gpb.SetRequest, opts ...UnmarshalOpt) error {
 	preferShadowPath := hasPreferShadowPath(opts)
 	ignoreExtraFields := hasIgnoreExtraFields(opts)
 	bestEffortUnmarshal := hasBestEffortUnmarshal(opts)
@@ -74,7 +137,7 @@ func UnmarshalSetRequest(schema *Schema, req *gpb.SetRequest, opts ...UnmarshalO
 
 	var complianceErrs *ComplianceErrors
 
-	// Process deletes, then replace, then updates.
+	// Process deletes, then replace, then union replace, then updates.
 	if err := deletePaths(schema.SchemaTree[rootName], root, req.Prefix, req.Delete, preferShadowPath, bestEffortUnmarshal); err != nil {
 		if bestEffortUnmarshal {
 			complianceErrs = complianceErrs.append(err.(*ComplianceErrors).Errors...)
@@ -82,11 +145,13 @@ func UnmarshalSetRequest(schema *Schema, req *gpb.SetRequest, opts ...UnmarshalO
 			return err
 		}
 	}
-	if err := replacePaths(schema.SchemaTree[rootName], root, req.Prefix, req.Replace, preferShadowPath, ignoreExtraFields, bestEffortUnmarshal); err != nil {
-		if bestEffortUnmarshal {
-			complianceErrs = complianceErrs.append(err.(*ComplianceErrors).Errors...)
-		} else {
-			return err
+	for _, replace := range [][]*gpb.Update{req.Replace, req.UnionReplace} {
+		if err := replacePaths(schema.SchemaTree[rootName], root, req.Prefix, replace, preferShadowPath, ignoreExtraFields, bestEffortUnmarshal); err != nil {
+			if bestEffortUnmarshal {
+				complianceErrs = complianceErrs.append(err.(*ComplianceErrors).Errors...)
+			} else {
+				return err
+			}
 		}
 	}
 	if err := updatePaths(schema.SchemaTree[rootName], root, req.Prefix, req.Update, preferShadowPath, ignoreExtraFields, bestEffortUnmarshal); err != nil {
@@ -97,6 +162,12 @@ func UnmarshalSetRequest(schema *Schema, req *gpb.SetRequest, opts ...UnmarshalO
 		}
 	}
 
+	if nz := unmarshalNormalizers(opts); nz != nil {
+		if err := nz.Normalize(schema.SchemaTree[rootName], root); err != nil {
+			return err
+		}
+	}
+
 	if bestEffortUnmarshal && complianceErrs != nil {
 		return complianceErrs
 	}
@@ -568,10 +568,130 @@ func TestUnmarshalSetRequest(t *testing.T) {
 		},
 		wantErr: true,
 		numErrs: 6,
+	}, {
+		desc: "union replace applied after replace",
+		inSchema: &Schema{
+			Root: &ListElemStruct1{
+				Key1: ygot.String("hello"),
+			},
+			SchemaTree: map[string]*yang.Entry{
+				"ListElemStruct1": simpleSchema(),
+			},
+		},
+		inReq: &gpb.SetRequest{
+			Prefix: mustPath("/outer"),
+			Replace: []*gpb.Update{{
+				Path: mustPath("/inner"),
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"string-leaf-field": "bear"}`)}},
+			}},
+			UnionReplace: []*gpb.Update{{
+				Path: mustPath("/inner"),
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"int32-leaf-list": [42]}`)}},
+			}},
+			Update: []*gpb.Update{{
+				Path: mustPath("/inner/int32-leaf-field"),
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: 43}},
+			}},
+		},
+		want: &ListElemStruct1{
+			Key1: ygot.String("hello"),
+			Outer: &OuterContainerType1{
+				Inner: &InnerContainerType1{
+					Int32LeafName:     ygot.Int32(43),
+					Int32LeafListName: []int32{42},
+				},
+			},
+		},
+	}, {
+		desc: "transactional set rolled back on error",
+		inSchema: &Schema{
+			Root: &ListElemStruct1{
+				Key1: ygot.String("hello"),
+				Outer: &OuterContainerType1{
+					Inner: &InnerContainerType1{
+						Int32LeafListName: []int32{100},
+						StringLeafName:    ygot.String("bear"),
+					},
+				},
+			},
+			SchemaTree: map[string]*yang.Entry{
+				"ListElemStruct1": simpleSchema(),
+			},
+		},
+		inReq: &gpb.SetRequest{
+			Delete: []*gpb.Path{
+				mustPath("/outer/inner/string-leaf-field"),
+			},
+			Replace: []*gpb.Update{{
+				Path: mustPath("/key1"),
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "world"}},
+			}},
+			Update: []*gpb.Update{{
+				Path: mustPath("/outer/error"),
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "failure"}},
+			}},
+		},
+		inUnmarshalOpts: []UnmarshalOpt{&Transactional{}},
+		want: &ListElemStruct1{
+			Key1: ygot.String("hello"),
+			Outer: &OuterContainerType1{
+				Inner: &InnerContainerType1{
+					Int32LeafListName: []int32{100},
+					StringLeafName:    ygot.String("bear"),
+				},
+			},
+		},
+		wantErr: true,
+	}, {
+		desc: "transactional best effort set rolled back on error",
+		inSchema: &Schema{
+			Root: &ListElemStruct1{
+				Key1: ygot.String("hello"),
+			},
+			SchemaTree: map[string]*yang.Entry{
+				"ListElemStruct1": simpleSchema(),
+			},
+		},
+		inReq: &gpb.SetRequest{
+			Update: []*gpb.Update{{
+				Path: mustPath("/key1"),
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "world"}},
+			}, {
+				Path: mustPath("/outer/error"),
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "failure"}},
+			}},
+		},
+		inUnmarshalOpts: []UnmarshalOpt{&Transactional{}, &BestEffortUnmarshal{}},
+		want: &ListElemStruct1{
+			Key1: ygot.String("hello"),
+		},
+		wantErr: true,
+		numErrs: 1,
+	}, {
+		desc: "transactional set applied without error",
+		inSchema: &Schema{
+			Root: &ListElemStruct1{
+				Key1: ygot.String("hello"),
+			},
+			SchemaTree: map[string]*yang.Entry{
+				"ListElemStruct1": simpleSchema(),
+			},
+		},
+		inReq: &gpb.SetRequest{
+			Update: []*gpb.Update{{
+				Path: mustPath("/key1"),
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "world"}},
+			}},
+		},
+		inUnmarshalOpts: []UnmarshalOpt{&Transactional{}},
+		want: &ListElemStruct1{
+			Key1: ygot.String("world"),
+		},
 	}}
 
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
+			root := tt.inSchema.Root
 			err := UnmarshalSetRequest(tt.inSchema, tt.inReq, tt.inUnmarshalOpts...)
 			if gotErr := err != nil; gotErr != tt.wantErr {
 				t.Fatalf("got error: %v, want: %v", err, tt.wantErr)
@@ -589,11 +709,14 @@ func TestUnmarshalSetRequest(t *testing.T) {
 					t.Fatalf("Error casting BestEffortUnmarshal result to compliance errors struct")
 				}
 			}
-			if !tt.wantErr {
+			if !tt.wantErr || hasTransactional(tt.inUnmarshalOpts) {
 				if diff := cmp.Diff(tt.inSchema.Root, tt.want); diff != "" {
 					t.Errorf("(-got, +want):\n%s", diff)
 				}
 			}
+			if tt.inSchema.Root != root {
+				t.Errorf("root GoStruct was replaced")
+			}
 		})
 	}
 }
@@ -919,6 +1042,32 @@ func TestUnmarshalNotifications(t *testing.T) {
 		},
 		wantErr: true,
 		numErrs: 4,
+	}, {
+		desc: "transactional notifications rolled back on error",
+		inSchema: &Schema{
+			Root: &ListElemStruct1{
+				Key1: ygot.String("hello"),
+			},
+			SchemaTree: map[string]*yang.Entry{
+				"ListElemStruct1": simpleSchema(),
+			},
+		},
+		inNotifications: []*gpb.Notification{{
+			Update: []*gpb.Update{{
+				Path: mustPath("/key1"),
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "world"}},
+			}},
+		}, {
+			Update: []*gpb.Update{{
+				Path: mustPath("/outer/error"),
+				Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "failure"}},
+			}},
+		}},
+		inUnmarshalOpts: []UnmarshalOpt{&Transactional{}},
+		want: &ListElemStruct1{
+			Key1: ygot.String("hello"),
+		},
+		wantErr: true,
 	}}
 
 	for _, tt := range tests {
@@ -941,7 +1090,7 @@ func TestUnmarshalNotifications(t *testing.T) {
 					t.Fatalf("Error casting BestEffortUnmarshal result to compliance errors struct")
 				}
 			}
-			if !tt.wantErr {
+			if !tt.wantErr || hasTransactional(tt.inUnmarshalOpts) {
 				if diff := cmp.Diff(tt.inSchema.Root, tt.want); diff != "" {
 					t.Errorf("(-got, +want):\n%s", diff)
 				}
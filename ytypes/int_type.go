@@ -60,20 +60,14 @@ var (
 // schema's range restrictions (if any). It returns an error if the validation
 // fails.
 func ValidateIntRestrictions(schemaType *yang.YangType, intVal int64) error {
-	if !isInRanges(schemaType.Range, yang.FromInt(intVal)) {
-		return fmt.Errorf("signed integer value %v is outside specified ranges", intVal)
-	}
-	return nil
+	return util.ValidateIntRange(schemaType, intVal)
 }
 
 // ValidateUintRestrictions checks that the given unsigned int matches the
 // schema's range restrictions (if any). It returns an error if the validation
 // fails.
 func ValidateUintRestrictions(schemaType *yang.YangType, uintVal uint64) error {
-	if !isInRanges(schemaType.Range, yang.FromUint(uintVal)) {
-		return fmt.Errorf("unsigned integer value %v is outside specified ranges", uintVal)
-	}
-	return nil
+	return util.ValidateUintRange(schemaType, uintVal)
 }
 
 // validateInt validates value, which must be a Go integer type, against the
@@ -96,11 +90,11 @@ func validateInt(schema *yang.Entry, value interface{}) error {
 	// Check that the value satisfies any range restrictions.
 	if isSigned(kind) {
 		if err := ValidateIntRestrictions(schema.Type, reflect.ValueOf(value).Int()); err != nil {
-			return fmt.Errorf("schema %q: %v", schema.Name, err)
+			return fmt.Errorf("schema %q: %w", schema.Name, err)
 		}
 	} else {
 		if err := ValidateUintRestrictions(schema.Type, reflect.ValueOf(value).Uint()); err != nil {
-			return fmt.Errorf("schema %q: %v", schema.Name, err)
+			return fmt.Errorf("schema %q: %w", schema.Name, err)
 		}
 	}
 
@@ -128,7 +122,7 @@ func validateIntSlice(schema *yang.Entry, value interface{}) error {
 	// Each slice element must be valid.
 	for i := 0; i < val.Len(); i++ {
 		if err := validateInt(schema, val.Index(i).Interface()); err != nil {
-			return fmt.Errorf("invalid element at index %d: %v for schema %s", i, err, schema.Name)
+			return fmt.Errorf("invalid element at index %d: %w for schema %s", i, err, schema.Name)
 		}
 	}
 
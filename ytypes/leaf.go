@@ -62,7 +62,7 @@ func validateLeaf(inSchema *yang.Entry, value interface{}) util.Errors {
 	case reflect.Ptr:
 		rv = reflect.ValueOf(value).Elem().Interface()
 	case reflect.Slice:
-		if ykind != yang.Ybinary && ykind != yang.Yunion {
+		if ykind != yang.Ybinary && ykind != yang.Ybits && ykind != yang.Yunion {
 			return util.NewErrs(fmt.Errorf("bad leaf type: expect []byte for binary value %v for schema %s, have type %v", value, schema.Name, ykind))
 		}
 	case reflect.Int64:
@@ -85,9 +85,7 @@ func validateLeaf(inSchema *yang.Entry, value interface{}) util.Errors {
 	case yang.Ybinary:
 		return util.NewErrs(validateBinary(schema, rv))
 	case yang.Ybits:
-		return nil
-		// TODO(mostrowski): restore when representation is decided.
-		//return util.NewErrs(validateBitset(schema, rv))
+		return util.NewErrs(validateBitset(schema, rv))
 	case yang.Ybool:
 		return util.NewErrs(validateBool(schema, rv))
 	case yang.Yempty:
@@ -373,21 +371,33 @@ func unmarshalLeaf(inSchema *yang.Entry, parent interface{}, value interface{},
 		return unmarshalUnion(schema, parent, fieldName, value, enc)
 	}
 
-	if ykind == yang.Ybits {
-		// TODO(mostrowski)
-		return nil
+	if t := tolerateUnknownEnums(opts); t != nil && (ykind == yang.Yenum || ykind == yang.Yidentityref) {
+		if t.ignoreUnknownEnum(schema, parent, fieldName, value, enc) {
+			return nil
+		}
 	}
 
-	v, err := unmarshalScalar(parent, schema, fieldName, value, enc)
-	if err != nil {
-		return err
+	var v interface{}
+	if ykind == yang.Ybinary {
+		ref, ok, err := unmarshalBinary(schema, value, enc, opts)
+		if err != nil {
+			return err
+		}
+		if ok {
+			v = ref
+		}
+	}
+	if v == nil {
+		if v, err = unmarshalScalar(parent, schema, fieldName, value, enc); err != nil {
+			return err
+		}
 	}
 	fieldIsSliceofSlice, err := isFieldSliceofSlice(parent, fieldName)
 	if err != nil {
 		return err
 	}
-	if ykind == yang.Ybinary && !fieldIsSliceofSlice {
-		// Binary is a slice field which is treated as a scalar.
+	if (ykind == yang.Ybinary || ykind == yang.Ybits) && !fieldIsSliceofSlice {
+		// Binary and bits are slice fields which are treated as scalars.
 		return util.InsertIntoStruct(parent, fieldName, v)
 	}
 
@@ -728,8 +738,7 @@ func sanitizeJSON(parent interface{}, schema *yang.Entry, fieldName string, valu
 		return true, nil
 
 	case yang.Ybits:
-		// TODO(mostrowski)
-		return nil, nil
+		return parseBitset(schema, value.(string))
 
 	case yang.Ybool:
 		return value.(bool), nil
@@ -805,6 +814,8 @@ func sanitizeGNMI(parent interface{}, schema *yang.Entry, fieldName string, tv *
 		return tv.GetBoolVal(), nil
 	case yang.Ystring:
 		return tv.GetStringVal(), nil
+	case yang.Ybits:
+		return parseBitset(schema, tv.GetStringVal())
 	case yang.Yenum, yang.Yidentityref:
 		return enumStringToValue(parent, fieldName, tv.GetStringVal())
 	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yint64:
@@ -864,7 +875,7 @@ func gNMIToYANGTypeMatches(ykind yang.TypeKind, tv *gpb.TypedValue, jsonToleranc
 	switch ykind {
 	case yang.Ybool:
 		_, ok = tv.GetValue().(*gpb.TypedValue_BoolVal)
-	case yang.Ystring, yang.Yenum, yang.Yidentityref:
+	case yang.Ystring, yang.Ybits, yang.Yenum, yang.Yidentityref:
 		_, ok = tv.GetValue().(*gpb.TypedValue_StringVal)
 	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yint64:
 		_, ok = tv.GetValue().(*gpb.TypedValue_IntVal)
@@ -49,11 +49,19 @@ func validateLeafList(schema *yang.Entry, value interface{}) util.Errors {
 
 			// Handle the case that this is a leaf-list of enumerated values, where we expect that the
 			// input to validateLeaf is a scalar value, rather than a pointer.
+			var errs util.Errors
 			if _, ok := cv.(ygot.GoEnum); ok {
-				errors = util.AppendErrs(errors, validateLeaf(schema, cv))
+				errs = validateLeaf(schema, cv)
 			} else {
-				errors = util.AppendErrs(errors, validateLeaf(schema, &cv))
+				errs = validateLeaf(schema, &cv)
 			}
+			// The members of a leaf-list of union type may each be of a
+			// different type of the union, and hence errors identify the
+			// member that is invalid.
+			if schema.Type.Kind == yang.Yunion {
+				errs = util.PrefixErrors(errs, fmt.Sprintf("member %d of %s", i, schema.Name))
+			}
+			errors = util.AppendErrs(errors, errs)
 
 		}
 	default:
@@ -127,9 +135,9 @@ func unmarshalLeafList(schema *yang.Entry, parent interface{}, value interface{}
 		}
 		// A new leaf-list update specifies the entire leaf-list, so we should clear its contents if it is non-nil.
 		clearSliceField(parent, fieldName)
-		for _, v := range sa.LeaflistVal.GetElement() {
+		for i, v := range sa.LeaflistVal.GetElement() {
 			if err := unmarshalGeneric(&leafSchema, parent, v, enc, opts...); err != nil {
-				return err
+				return leafListMemberError(schema, i, err)
 			}
 		}
 	case JSONEncoding:
@@ -140,9 +148,9 @@ func unmarshalLeafList(schema *yang.Entry, parent interface{}, value interface{}
 
 		// A new leaf-list update specifies the entire leaf-list, so we should clear its contents if it is non-nil.
 		clearSliceField(parent, fieldName)
-		for _, leaf := range leafList {
+		for i, leaf := range leafList {
 			if err := unmarshalGeneric(&leafSchema, parent, leaf, enc, opts...); err != nil {
-				return err
+				return leafListMemberError(schema, i, err)
 			}
 		}
 	default:
@@ -152,6 +160,17 @@ func unmarshalLeafList(schema *yang.Entry, parent interface{}, value interface{}
 	return nil
 }
 
+// leafListMemberError returns err, which was returned when unmarshalling the
+// member at index i of the leaf-list described by schema. Where the
+// leaf-list is of union type, the error identifies the member, since each
+// member is unmarshalled against the types of the union independently.
+func leafListMemberError(schema *yang.Entry, i int, err error) error {
+	if schema.Type.Kind != yang.Yunion {
+		return err
+	}
+	return fmt.Errorf("member %d of %s: %w", i, schema.Name, err)
+}
+
 // clearSliceField sets updates a field called fieldName (which must exist, but may be
 // nil) in parentStruct, with value nil.
 func clearSliceField(parentStruct interface{}, fieldName string) error {
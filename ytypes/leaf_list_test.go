@@ -86,6 +86,18 @@ func TestValidateLeafList(t *testing.T) {
 		Type:     &yang.YangType{Kind: yang.Ystring},
 		Name:     "leaf-list-schema",
 	}
+	unionLeafListSchema := &yang.Entry{
+		Kind:     yang.LeafEntry,
+		ListAttr: yang.NewDefaultListAttr(),
+		Type: &yang.YangType{
+			Kind: yang.Yunion,
+			Type: []*yang.YangType{
+				{Kind: yang.Ystring, Pattern: []string{"a+"}, POSIXPattern: []string{"^a+$"}},
+				{Kind: yang.Yuint32},
+			},
+		},
+		Name: "union-leaf-list-schema",
+	}
 	tests := []struct {
 		desc    string
 		schema  *yang.Entry
@@ -114,6 +126,12 @@ func TestValidateLeafList(t *testing.T) {
 			val:     []int32{1},
 			wantErr: `non string type int32 with value 1 for schema leaf-list-schema`,
 		},
+		{
+			desc:    "union member identified",
+			schema:  unionLeafListSchema,
+			val:     []UnionLeafTypeSimple{testutil.UnionUint32(42), testutil.UnionString("aa"), testutil.UnionString("bb")},
+			wantErr: `member 2 of union-leaf-list-schema: schema "": "bb" does not match regular expression pattern "^a+$"`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -414,6 +432,19 @@ func TestUnmarshalLeafListGNMIEncoding(t *testing.T) {
 			}},
 			wantErr: "could not find suitable union type to unmarshal value " + (&gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: 42}}).String(),
 		},
+		{
+			desc: "fail unionleaf member identified",
+			sch:  unionLeafListSchemaSimple,
+			val: &gpb.TypedValue{Value: &gpb.TypedValue_LeaflistVal{
+				LeaflistVal: &gpb.ScalarArray{
+					Element: []*gpb.TypedValue{
+						{Value: &gpb.TypedValue_StringVal{StringVal: "forty two"}},
+						{Value: &gpb.TypedValue_IntVal{IntVal: 42}},
+					},
+				},
+			}},
+			wantErr: "member 1 of union-leaflist-simple: could not find suitable union type",
+		},
 		{
 			desc: "unionleaf success (wrapper union)",
 			sch:  unionLeafListSchema,
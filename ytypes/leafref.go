@@ -82,10 +82,10 @@ func ValidateLeafRefData(schema *yang.Entry, value interface{}, opt *LeafrefOpti
 			return leafrefErrOrLog(util.NewErrs(err), opt)
 		}
 		if !match {
-			e := fmt.Errorf("field name %s value %s schema path %s has leafref path %s not equal to any target nodes",
+			e := util.ConstraintErrorf(util.ErrLeafrefConstraint, "field name %s value %s schema path %s has leafref path %s not equal to any target nodes",
 				ni.StructField.Name, util.ValueStr(ni.FieldValue.Interface()), ni.Schema.Path(), pathStr)
 			util.DbgPrint("ERR: %s", e)
-			return leafrefErrOrLog(util.NewErrs(e), opt)
+			return leafrefErrOrLog(util.NewErrs(&nodeError{schema: ni.Schema, err: e}), opt)
 		}
 
 		return nil
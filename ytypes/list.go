@@ -128,7 +128,7 @@ func checkBasicKeyValue(structElems reflect.Value, keyFieldSchemaName string, ke
 	}
 
 	if !structElems.FieldByName(keyFieldName).IsValid() {
-		return util.NewErrs(fmt.Errorf("missing key field %s in element %v", keyFieldName, structElems))
+		return util.NewErrs(util.KindErrorf(util.ErrInvalidKey, "missing key field %s in element %v", keyFieldName, structElems))
 	}
 	var elementKeyValue interface{}
 	if structElems.FieldByName(keyFieldName).Kind() == reflect.Ptr && !structElems.FieldByName(keyFieldName).IsNil() {
@@ -158,7 +158,7 @@ func checkStructKeyValues(structElems reflect.Value, keyStruct reflect.Value) ut
 		keyName := keyStruct.Type().Field(i).Name
 		keyValue := keyStruct.Field(i).Interface()
 		if !structElems.FieldByName(keyName).IsValid() {
-			errors = util.AppendErr(errors, fmt.Errorf("missing key field %s in %v", keyName, keyStruct))
+			errors = util.AppendErr(errors, util.KindErrorf(util.ErrInvalidKey, "missing key field %s in %v", keyName, keyStruct))
 			continue
 		}
 
@@ -191,14 +191,20 @@ func validateStructElems(schema *yang.Entry, value interface{}) util.Errors {
 	for i := 0; i < structElems.NumField(); i++ {
 		ft := structElems.Type().Field(i)
 
-		// If this is an annotation field, then skip it since it does not have
-		// a schema.
-		if util.IsYgotAnnotation(ft) {
+		// If this is an annotation field or presence bitmap, then skip it
+		// since it does not have a schema.
+		if util.IsYgotAnnotation(ft) || util.IsYgotPresence(ft) {
+			continue
+		}
+		if util.IsYgotExtensions(ft) {
+			for _, ext := range util.AttachedExtensions(structElems) {
+				errors = util.AppendErrs(errors, validateStructElems(ext.Schema, ext.Value.Interface()))
+			}
 			continue
 		}
 
 		fieldName := ft.Name
-		fieldValue := structElems.Field(i).Interface()
+		fieldValue := util.FieldValue(structElems, i).Interface()
 
 		cschema, err := util.ChildSchema(schema, structTypes.Field(i))
 		if err != nil {
@@ -208,7 +214,7 @@ func validateStructElems(schema *yang.Entry, value interface{}) util.Errors {
 		if cschema == nil {
 			errors = util.AppendErr(errors, fmt.Errorf("child schema not found for struct %s field %s", schema.Name, fieldName))
 		} else {
-			errors = util.AppendErrs(errors, Validate(cschema, fieldValue))
+			errors = util.AppendErrs(errors, withNodeSchema(validateChild(cschema, fieldValue), cschema))
 		}
 	}
 
@@ -252,6 +258,9 @@ func validateListSchema(schema *yang.Entry) error {
 // key field name.
 func schemaNameToFieldName(structElems reflect.Value, schemaKeyFieldName string) (string, error) {
 	for i := 0; i < structElems.NumField(); i++ {
+		if ft := structElems.Type().Field(i); util.IsYgotPresence(ft) || util.IsYgotExtensions(ft) {
+			continue
+		}
 		ps, err := util.RelativeSchemaPath(structElems.Type().Field(i))
 		if err != nil {
 			return "", err
@@ -422,7 +431,7 @@ func makeValForInsert(schema *yang.Entry, parent interface{}, keys map[string]st
 	setKey := func(keySchemaName string) error {
 		keyVal, ok := keys[keySchemaName]
 		if !ok {
-			return fmt.Errorf("missing %q key in %v", keySchemaName, keys)
+			return util.KindErrorf(util.ErrInvalidKey, "missing %q key in %v", keySchemaName, keys)
 		}
 		keySchema, ok := schema.Dir[keySchemaName]
 		if !ok {
@@ -585,7 +594,7 @@ func unmarshalContainerWithListSchema(schema *yang.Entry, parent interface{}, va
 	// with ListAttrs unset.
 	newSchema := *schema
 	newSchema.ListAttr = nil
-	return Unmarshal(&newSchema, parent, value, opts...)
+	return unmarshal(&newSchema, parent, value, opts...)
 }
 
 // getKeyValue returns the value from the structVal field whose last path
@@ -596,6 +605,9 @@ func unmarshalContainerWithListSchema(schema *yang.Entry, parent interface{}, va
 func getKeyValue(structVal reflect.Value, key string) (interface{}, error) {
 	for i := 0; i < structVal.NumField(); i++ {
 		f := structVal.Type().Field(i)
+		if util.IsYgotPresence(f) || util.IsYgotExtensions(f) {
+			continue
+		}
 		p, err := util.RelativeSchemaPath(f)
 		if err != nil {
 			return nil, err
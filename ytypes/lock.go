@@ -0,0 +1,216 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"sync"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// SubtreeLocker provides read/write locks over the subtrees of a data tree
+// that is shared between goroutines, such that mutations of disjoint
+// subtrees, e.g., by concurrent gNMI Set handlers, can proceed in parallel
+// rather than being serialized by a single mutex over the root.
+//
+// Each path that is locked is truncated to the number of elements supplied
+// to NewSubtreeLocker, which identify the subtree that is locked; with a
+// depth of 1, each top-level container is locked independently. Paths that
+// are shorter than the depth, including the root path, or that contain
+// wildcards within it, lock the entire data tree, as does locking no paths.
+//
+// Two subtrees overlap if their elements have the same names, and no key
+// of an element has a different value in the other. Since the locker has no
+// schema, an element that has no keys, or only some of them, is treated as
+// identifying each of the list entries that it matches, such that
+// /interfaces/interface overlaps /interfaces/interface[name=eth0]. A lock is
+// not granted while an overlapping lock is held for writing, or a lock for
+// writing while an overlapping lock is held. Readers also wait for writers
+// that are waiting for an overlapping lock, such that writers are not
+// starved.
+//
+// A SubtreeLocker is safe for concurrent use. It does not prevent access to
+// the data tree by callers that do not hold a lock.
+type SubtreeLocker struct {
+	// depth is the number of path elements that identify a subtree.
+	depth int
+	// root is held for reading by each subtree lock, and for writing by
+	// locks of the entire data tree.
+	root sync.RWMutex
+	// mu protects held and waiting.
+	mu sync.Mutex
+	// released is signalled, with mu held, when locks are released.
+	released *sync.Cond
+	// held is the set of subtree locks that are currently held.
+	held map[*subtreeLock]bool
+	// waiting is the set of subtree locks for writing that are awaited.
+	waiting map[*subtreeLock]bool
+}
+
+// subtreeLock is the lock of a single subtree.
+type subtreeLock struct {
+	// elems are the elements of the path of the subtree.
+	elems []*gpb.PathElem
+	// write specifies whether the subtree is locked for writing.
+	write bool
+}
+
+// NewSubtreeLocker returns a SubtreeLocker that locks the subtrees that are
+// identified by the first depth elements of each path. It returns an error
+// if depth is less than 1.
+func NewSubtreeLocker(depth int) (*SubtreeLocker, error) {
+	if depth < 1 {
+		return nil, fmt.Errorf("invalid subtree lock depth %d, must be at least 1", depth)
+	}
+	l := &SubtreeLocker{
+		depth:   depth,
+		held:    map[*subtreeLock]bool{},
+		waiting: map[*subtreeLock]bool{},
+	}
+	l.released = sync.NewCond(&l.mu)
+	return l, nil
+}
+
+// Lock locks the subtrees containing each of paths for writing, blocking
+// until they are available. It returns a function that unlocks them, which
+// must be called exactly once.
+func (l *SubtreeLocker) Lock(paths ...*gpb.Path) (func(), error) {
+	return l.lock(true, paths)
+}
+
+// RLock locks the subtrees containing each of paths for reading, blocking
+// until they are available. It returns a function that unlocks them, which
+// must be called exactly once.
+func (l *SubtreeLocker) RLock(paths ...*gpb.Path) (func(), error) {
+	return l.lock(false, paths)
+}
+
+// lock locks the subtrees containing each of paths, for writing if write
+// is true, and for reading otherwise. The subtrees are locked together once
+// none of them overlaps a conflicting lock, such that concurrent callers
+// that lock overlapping sets of subtrees cannot deadlock.
+func (l *SubtreeLocker) lock(write bool, paths []*gpb.Path) (func(), error) {
+	if len(paths) == 0 {
+		return l.lockRoot(), nil
+	}
+	var locks []*subtreeLock
+	for _, p := range paths {
+		elems, ok := l.subtree(p)
+		if !ok {
+			return l.lockRoot(), nil
+		}
+		locks = append(locks, &subtreeLock{elems: elems, write: write})
+	}
+
+	l.root.RLock()
+	l.mu.Lock()
+	for _, s := range locks {
+		if write {
+			l.waiting[s] = true
+		}
+	}
+	for l.conflicts(locks) {
+		l.released.Wait()
+	}
+	for _, s := range locks {
+		delete(l.waiting, s)
+		l.held[s] = true
+	}
+	l.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			for _, s := range locks {
+				delete(l.held, s)
+			}
+			l.released.Broadcast()
+			l.mu.Unlock()
+			l.root.RUnlock()
+		})
+	}, nil
+}
+
+// conflicts reports whether any of locks overlaps a held lock with which it
+// conflicts, or, where locks are for reading, an awaited lock for writing.
+// l.mu must be held.
+func (l *SubtreeLocker) conflicts(locks []*subtreeLock) bool {
+	for _, s := range locks {
+		for h := range l.held {
+			if (s.write || h.write) && overlaps(s.elems, h.elems) {
+				return true
+			}
+		}
+		if s.write {
+			continue
+		}
+		for w := range l.waiting {
+			if overlaps(s.elems, w.elems) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lockRoot locks the entire data tree. Since the root lock is held for
+// reading by each subtree lock, it is held for writing even by readers of
+// the entire tree, such that they exclude writers of any subtree.
+func (l *SubtreeLocker) lockRoot() func() {
+	l.root.Lock()
+	var once sync.Once
+	return func() { once.Do(l.root.Unlock) }
+}
+
+// subtree returns the elements of the path of the subtree of the data tree
+// that contains path. It returns false if path is not within a single
+// subtree.
+func (l *SubtreeLocker) subtree(path *gpb.Path) ([]*gpb.PathElem, bool) {
+	elems := path.GetElem()
+	if len(elems) < l.depth {
+		return nil, false
+	}
+	for _, e := range elems[:l.depth] {
+		if e.GetName() == "*" || e.GetName() == "..." {
+			return nil, false
+		}
+		for _, v := range e.GetKey() {
+			if v == "*" {
+				return nil, false
+			}
+		}
+	}
+	return elems[:l.depth], true
+}
+
+// overlaps reports whether the subtrees with the path elements a and b,
+// which are of equal length, overlap. Elements overlap if they have the same
+// name, and each key that they have in common has the same value, such that
+// an element without keys overlaps each of the list entries with its name.
+func overlaps(a, b []*gpb.PathElem) bool {
+	for i, e := range a {
+		if e.GetName() != b[i].GetName() {
+			return false
+		}
+		for k, v := range e.GetKey() {
+			if w, ok := b[i].GetKey()[k]; ok && w != v {
+				return false
+			}
+		}
+	}
+	return true
+}
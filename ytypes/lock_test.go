@@ -0,0 +1,269 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func mustLockPath(t *testing.T, s string) *gpb.Path {
+	t.Helper()
+	p, err := ygot.StringToStructuredPath(s)
+	if err != nil {
+		t.Fatalf("cannot parse path %s: %v", s, err)
+	}
+	return p
+}
+
+// acquired reports whether lock returns within a short timeout, in which
+// case the lock is released.
+func acquired(t *testing.T, lock func() (func(), error)) bool {
+	t.Helper()
+	done := make(chan func(), 1)
+	go func() {
+		unlock, err := lock()
+		if err != nil {
+			t.Errorf("cannot acquire lock: %v", err)
+			unlock = func() {}
+		}
+		done <- unlock
+	}()
+	select {
+	case unlock := <-done:
+		unlock()
+		return true
+	case <-time.After(50 * time.Millisecond):
+		// Release the lock once it is acquired, such that the held lock
+		// can be released by the test.
+		go func() { (<-done)() }()
+		return false
+	}
+}
+
+func TestSubtreeLocker(t *testing.T) {
+	tests := []struct {
+		desc      string
+		inDepth   int
+		inHeld    []string
+		inHeldW   bool
+		inPaths   []string
+		inWrite   bool
+		wantBlock bool
+	}{{
+		desc:    "disjoint top-level containers",
+		inDepth: 1,
+		inHeld:  []string{"/interfaces/interface[name=eth0]/config/mtu"},
+		inHeldW: true,
+		inPaths: []string{"/system/config/hostname"},
+		inWrite: true,
+	}, {
+		desc:      "same top-level container",
+		inDepth:   1,
+		inHeld:    []string{"/interfaces/interface[name=eth0]/config/mtu"},
+		inHeldW:   true,
+		inPaths:   []string{"/interfaces/interface[name=eth1]/config/mtu"},
+		inWrite:   true,
+		wantBlock: true,
+	}, {
+		desc:    "disjoint list entries",
+		inDepth: 2,
+		inHeld:  []string{"/interfaces/interface[name=eth0]/config/mtu"},
+		inHeldW: true,
+		inPaths: []string{"/interfaces/interface[name=eth1]/config/mtu"},
+		inWrite: true,
+	}, {
+		desc:    "concurrent readers",
+		inDepth: 1,
+		inHeld:  []string{"/system"},
+		inPaths: []string{"/system/config/hostname"},
+	}, {
+		desc:      "reader blocked by writer",
+		inDepth:   1,
+		inHeld:    []string{"/system"},
+		inHeldW:   true,
+		inPaths:   []string{"/system/config/hostname"},
+		wantBlock: true,
+	}, {
+		desc:      "one of several paths held",
+		inDepth:   1,
+		inHeld:    []string{"/system"},
+		inHeldW:   true,
+		inPaths:   []string{"/interfaces", "/system/config/hostname"},
+		inWrite:   true,
+		wantBlock: true,
+	}, {
+		desc:      "root blocked by subtree reader",
+		inDepth:   1,
+		inHeld:    []string{"/system"},
+		inPaths:   []string{"/"},
+		wantBlock: true,
+	}, {
+		desc:      "subtree blocked by root reader",
+		inDepth:   1,
+		inHeld:    []string{"/"},
+		inPaths:   []string{"/system"},
+		wantBlock: true,
+	}, {
+		desc:      "path shorter than depth",
+		inDepth:   2,
+		inHeld:    []string{"/interfaces/interface[name=eth0]"},
+		inHeldW:   true,
+		inPaths:   []string{"/interfaces"},
+		inWrite:   true,
+		wantBlock: true,
+	}, {
+		desc:      "list entry blocked by whole list",
+		inDepth:   2,
+		inHeld:    []string{"/interfaces/interface"},
+		inHeldW:   true,
+		inPaths:   []string{"/interfaces/interface[name=eth0]/config/mtu"},
+		inWrite:   true,
+		wantBlock: true,
+	}, {
+		desc:      "whole list blocked by list entry",
+		inDepth:   2,
+		inHeld:    []string{"/interfaces/interface[name=eth0]"},
+		inHeldW:   true,
+		inPaths:   []string{"/interfaces/interface/config/mtu"},
+		inWrite:   true,
+		wantBlock: true,
+	}, {
+		desc:      "top-level list entry blocked by whole list",
+		inDepth:   1,
+		inHeld:    []string{"/interface"},
+		inHeldW:   true,
+		inPaths:   []string{"/interface[name=eth0]"},
+		inWrite:   true,
+		wantBlock: true,
+	}, {
+		desc:      "partially keyed list entry",
+		inDepth:   4,
+		inHeld:    []string{"/network-instances/network-instance[name=default]/protocols/protocol[identifier=BGP]"},
+		inHeldW:   true,
+		inPaths:   []string{"/network-instances/network-instance[name=default]/protocols/protocol[identifier=BGP][name=bgp]"},
+		inWrite:   true,
+		wantBlock: true,
+	}, {
+		desc:    "list entries with different keys",
+		inDepth: 1,
+		inHeld:  []string{"/protocol[identifier=BGP][name=a]"},
+		inHeldW: true,
+		inPaths: []string{"/protocol[identifier=BGP][name=b]"},
+		inWrite: true,
+	}, {
+		desc:      "wildcard",
+		inDepth:   2,
+		inHeld:    []string{"/interfaces/interface[name=eth0]"},
+		inHeldW:   true,
+		inPaths:   []string{"/interfaces/interface[name=*]/config/mtu"},
+		wantBlock: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			l, err := NewSubtreeLocker(tt.inDepth)
+			if err != nil {
+				t.Fatalf("NewSubtreeLocker(%d): got unexpected error: %v", tt.inDepth, err)
+			}
+			lock := func(write bool, paths []string) func() (func(), error) {
+				var ps []*gpb.Path
+				for _, p := range paths {
+					ps = append(ps, mustLockPath(t, p))
+				}
+				if write {
+					return func() (func(), error) { return l.Lock(ps...) }
+				}
+				return func() (func(), error) { return l.RLock(ps...) }
+			}
+
+			unlock, err := lock(tt.inHeldW, tt.inHeld)()
+			if err != nil {
+				t.Fatalf("cannot acquire held lock: %v", err)
+			}
+			if got := !acquired(t, lock(tt.inWrite, tt.inPaths)); got != tt.wantBlock {
+				t.Errorf("lock of %v while %v is held: got blocked %v, want %v", tt.inPaths, tt.inHeld, got, tt.wantBlock)
+			}
+			unlock()
+			if !acquired(t, lock(tt.inWrite, tt.inPaths)) {
+				t.Errorf("lock of %v after %v is released: blocked", tt.inPaths, tt.inHeld)
+			}
+
+			// Wait for any blocked lock to be released before checking
+			// that no subtree locks are retained.
+			deadline := time.Now().Add(time.Second)
+			for {
+				l.mu.Lock()
+				n := len(l.held) + len(l.waiting)
+				l.mu.Unlock()
+				if n == 0 {
+					break
+				}
+				if time.Now().After(deadline) {
+					t.Fatalf("got %d retained subtree locks, want 0", n)
+				}
+				time.Sleep(time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestSubtreeLockerWaitingWriter(t *testing.T) {
+	l, err := NewSubtreeLocker(1)
+	if err != nil {
+		t.Fatalf("NewSubtreeLocker: got unexpected error: %v", err)
+	}
+	unlock, err := l.RLock(mustLockPath(t, "/system"))
+	if err != nil {
+		t.Fatalf("RLock: got unexpected error: %v", err)
+	}
+	if acquired(t, func() (func(), error) { return l.Lock(mustLockPath(t, "/system/config")) }) {
+		t.Fatalf("Lock while subtree is read: not blocked")
+	}
+	// The writer is now waiting, such that further readers wait for it.
+	if acquired(t, func() (func(), error) { return l.RLock(mustLockPath(t, "/system/state")) }) {
+		t.Errorf("RLock while writer is waiting: not blocked")
+	}
+	if !acquired(t, func() (func(), error) { return l.RLock(mustLockPath(t, "/interfaces")) }) {
+		t.Errorf("RLock of disjoint subtree while writer is waiting: blocked")
+	}
+	unlock()
+}
+
+func TestSubtreeLockerUnlockTwice(t *testing.T) {
+	l, err := NewSubtreeLocker(1)
+	if err != nil {
+		t.Fatalf("NewSubtreeLocker: got unexpected error: %v", err)
+	}
+	unlock, err := l.Lock(mustLockPath(t, "/system"))
+	if err != nil {
+		t.Fatalf("Lock: got unexpected error: %v", err)
+	}
+	unlock()
+	unlock()
+	if !acquired(t, func() (func(), error) { return l.Lock() }) {
+		t.Errorf("Lock of root after unlocking twice: blocked")
+	}
+}
+
+func TestNewSubtreeLockerInvalidDepth(t *testing.T) {
+	if _, err := NewSubtreeLocker(0); err == nil {
+		t.Errorf("NewSubtreeLocker(0): got nil error, want error")
+	}
+}
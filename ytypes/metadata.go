@@ -0,0 +1,78 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/openconfig/ygot/ygot"
+)
+
+// annotationSliceType is the type of the annotation fields of a GoStruct.
+var annotationSliceType = reflect.TypeOf([]ygot.Annotation{})
+
+// unmarshalMetadata unmarshals the RFC 7952 metadata object within jsonTree
+// that corresponds to the annotation field f, described by ft, into f as a
+// ygot.Metadata. Metadata that is encoded in another form, such as an array
+// of annotations, cannot be unmarshalled without knowledge of the type of
+// the annotations and is skipped, as are fields that are not of type
+// []ygot.Annotation.
+func unmarshalMetadata(f reflect.Value, ft reflect.StructField, jsonTree map[string]interface{}) error {
+	if ft.Type != annotationSliceType {
+		return nil
+	}
+	paths, err := pathTagFromField(ft)
+	if err != nil {
+		return fmt.Errorf("cannot find JSON field names for annotation field %s, %v", ft.Name, err)
+	}
+	for _, p := range strings.Split(paths, "|") {
+		obj, ok := jsonTreeValue(jsonTree, strings.Split(p, "/")).(map[string]interface{})
+		if !ok {
+			continue
+		}
+		// The metadata object is re-encoded such that it is validated by
+		// the Metadata type in the same way as when it is marshalled.
+		b, err := json.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("cannot marshal metadata object for field %s: %v", ft.Name, err)
+		}
+		md := &ygot.Metadata{}
+		if err := md.UnmarshalJSON(b); err != nil {
+			return fmt.Errorf("cannot unmarshal metadata for field %s: %v", ft.Name, err)
+		}
+		f.Set(reflect.ValueOf([]ygot.Annotation{md}))
+		return nil
+	}
+	return nil
+}
+
+// jsonTreeValue returns the value within the JSON tree at the path of
+// object member names supplied, or nil if there is no such value.
+func jsonTreeValue(tree map[string]interface{}, path []string) interface{} {
+	var v interface{} = tree
+	for _, e := range path {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		if v, ok = m[e]; !ok {
+			return nil
+		}
+	}
+	return v
+}
@@ -16,6 +16,7 @@ package ytypes
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 
@@ -99,7 +100,7 @@ func retrieveNode(schema *yang.Entry, root interface{}, path, traversedPath *gpb
 				if args.ignoreExtraFields {
 					opts = append(opts, &IgnoreExtraFields{})
 				}
-				if err := Unmarshal(schema, root, jsonTree, opts...); err != nil {
+				if err := unmarshal(schema, root, jsonTree, opts...); err != nil {
 					return nil, status.Errorf(codes.Unknown, "failed to update struct %T with value %v; %v", root, args.val, err)
 				}
 			} else {
@@ -127,7 +128,7 @@ func retrieveNode(schema *yang.Entry, root interface{}, path, traversedPath *gpb
 			// or in the case that tolerateNil is specified.
 			return nil, nil
 		}
-		return nil, status.Errorf(codes.NotFound, "could not find children %v at path %v", path, traversedPath)
+		return nil, util.WithKind(util.ErrPathNotFound, status.Errorf(codes.NotFound, "could not find children %v at path %v", path, traversedPath))
 	case schema == nil:
 		return nil, status.Errorf(codes.InvalidArgument, "schema is nil for type %T, path %v", root, path)
 	}
@@ -163,6 +164,13 @@ func retrieveNodeContainer(schema *yang.Entry, root interface{}, path *gpb.Path,
 	for i := 0; i < v.NumField(); i++ {
 		fv, ft := v.Field(i), v.Type().Field(i)
 
+		// The presence bitmap is updated along with the leaves that it
+		// covers, and extension structs are searched once the fields of
+		// the struct have not matched.
+		if util.IsYgotPresence(ft) || util.IsYgotExtensions(ft) {
+			continue
+		}
+
 		childSchemaFn := util.ChildSchema
 		if args.preferShadowPath {
 			childSchemaFn = util.ChildSchemaPreferShadow
@@ -220,6 +228,12 @@ func retrieveNodeContainer(schema *yang.Entry, root interface{}, path *gpb.Path,
 			// corresponding field to its zero value. The zero value is the unset value for
 			// any node type, whether leaf or non-leaf.
 			if args.delete && len(path.Elem) == to {
+				if _, ok := util.PresenceBit(ft); ok {
+					if err := util.SetLeafPresence(v, ft, false); err != nil {
+						return nil, status.Errorf(codes.Unknown, "failed to delete struct field %s in %T: %v", ft.Name, root, err)
+					}
+					return nil, nil
+				}
 				fv.Set(reflect.Zero(ft.Type))
 				return nil, nil
 			}
@@ -272,9 +286,17 @@ func retrieveNodeContainer(schema *yang.Entry, root interface{}, path *gpb.Path,
 				// Handling for this is forwarded to existing handling in retrieveNode
 				// since unlike leaf or leaf-list nodes, we can unmarshal directly into
 				// the struct rather than having to use the parent struct.
+				//
+				// A presence container is created by setting its path, such
+				// that it is initialized even if its ancestors are not.
+				if util.IsYangPresence(ft) && util.IsValueNil(fv.Interface()) {
+					if err := util.InitializeStructField(root, ft.Name, false); err != nil {
+						return nil, status.Errorf(codes.Unknown, "failed to initialize presence container %s in %T: %v", ft.Name, root, err)
+					}
+				}
 			}
 
-			matches, err := retrieveNode(cschema, fv.Interface(), util.TrimGNMIPathPrefix(path, p[0:to]), np, args)
+			matches, err := retrieveNode(cschema, util.FieldValue(v, i).Interface(), util.TrimGNMIPathPrefix(path, p[0:to]), np, args)
 			if err != nil {
 				return nil, err
 			}
@@ -287,7 +309,9 @@ func retrieveNodeContainer(schema *yang.Entry, root interface{}, path *gpb.Path,
 				case cschema == nil:
 					return nil, status.Errorf(codes.InvalidArgument, "could not find schema for path %v", np)
 				case cschema.IsContainer() || (cschema.IsList() && util.IsTypeStructPtr(reflect.TypeOf(fv.Interface()))):
-					if fv.Elem().IsZero() {
+					// The existence of a presence container is meaningful
+					// even when it has no children, hence it is retained.
+					if fv.Elem().IsZero() && !util.IsYangPresence(ft) {
 						fv.Set(reflect.Zero(ft.Type))
 					}
 				case cschema.IsList():
@@ -361,10 +385,32 @@ func retrieveNodeContainer(schema *yang.Entry, root interface{}, path *gpb.Path,
 		}
 	}
 
+	// The nodes that augment the struct are held by the extension structs
+	// that are attached to, or registered as extending, it. A new extension
+	// struct is attached only if it is populated by the traversal.
+	for _, c := range extensionCandidates(root) {
+		eargs := args
+		eargs.ignoreExtraFields = false
+		matches, err := retrieveNodeContainer(c.ext.ΛExtensionSchema(), c.ext, path, traversedPath, eargs)
+		switch {
+		case errors.Is(err, util.ErrPathNotFound):
+			continue
+		case err != nil:
+			return nil, err
+		}
+		if err := c.attach(root); err != nil {
+			return nil, status.Errorf(codes.Unknown, "failed to attach extension %T to %T: %v", c.ext, root, err)
+		}
+		if args.delete {
+			c.detachIfEmpty(root)
+		}
+		return matches, nil
+	}
+
 	if args.ignoreExtraFields {
 		return nil, nil
 	}
-	return nil, status.Errorf(codes.InvalidArgument, "no match found in %T, for path %v", root, path)
+	return nil, util.WithKind(util.ErrPathNotFound, status.Errorf(codes.InvalidArgument, "no match found in %T, for path %v", root, path))
 }
 
 // getKeyFields retrieves the key field values of the input key-value list
@@ -376,7 +422,7 @@ func getKeyFields(k, v reflect.Value, schemaKey string) (map[string]string, erro
 		kv := k.Interface()
 		keyAsString, err := ygot.KeyValueAsString(kv)
 		if err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "failed to convert %v of type %T to a string: %v", kv, v.Interface(), err)
+			return nil, util.WithKind(util.ErrInvalidKey, status.Errorf(codes.InvalidArgument, "failed to convert %v of type %T to a string: %v", kv, v.Interface(), err))
 		}
 		return map[string]string{schemaKey: keyAsString}, nil
 	}
@@ -625,7 +671,7 @@ func retrieveNodeList(schema *yang.Entry, root interface{}, path, traversedPath
 
 			keyAsString, err := ygot.KeyValueAsString(kv)
 			if err != nil {
-				return nil, status.Errorf(codes.InvalidArgument, "failed to convert %v to a string, path %v: %v", kv, path, err)
+				return nil, util.WithKind(util.ErrInvalidKey, status.Errorf(codes.InvalidArgument, "failed to convert %v to a string, path %v: %v", kv, path, err))
 			}
 			if keyAsString == pathKey {
 				remainingPath := util.PopGNMIPath(path)
@@ -777,6 +823,21 @@ type TreeNode struct {
 // also be supplied. It takes a set of options which can be used to specify get behaviours, such as
 // allowing partial match. If there are no matches for the path, an error is returned.
 func GetNode(schema *yang.Entry, root interface{}, path *gpb.Path, opts ...GetNodeOpt) ([]*TreeNode, error) {
+	if hasGetValidatePathSyntax(opts) {
+		if err := validatePathSyntax(path); err != nil {
+			return nil, err
+		}
+	}
+	if wd := getNodeWithDefaults(opts); wd != nil && wd.Mode != ygot.WithDefaultsExplicit {
+		gs, ok := root.(ygot.GoStruct)
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "with-defaults mode %s requires a GoStruct root, got %T", wd.Mode, root)
+		}
+		var err error
+		if root, err = ygot.ApplyWithDefaults(schema, gs, wd.Mode); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
 	return retrieveNode(schema, root, path, nil, retrieveNodeArgs{
 		// We never want to modify the input root, so we specify modifyRoot.
 		modifyRoot:       false,
@@ -848,6 +909,29 @@ func hasGetTolerateNil(opts []GetNodeOpt) bool {
 	return false
 }
 
+// WithDefaults signals GetNode to retrieve nodes as reported in the supplied
+// RFC 6243 with-defaults mode, as per ygot.ApplyWithDefaults. The defaults
+// are read from the schema supplied to GetNode. Where the mode is not
+// explicit, the nodes are retrieved from a copy of the root, such that the
+// returned data does not alias the root.
+type WithDefaults struct {
+	Mode ygot.WithDefaultsMode
+}
+
+// IsGetNodeOpt implements the GetNodeOpt interface.
+func (*WithDefaults) IsGetNodeOpt() {}
+
+// getNodeWithDefaults returns the first WithDefaults within the supplied
+// GetNodeOpt slice, or nil if there is none.
+func getNodeWithDefaults(opts []GetNodeOpt) *WithDefaults {
+	for _, o := range opts {
+		if v, ok := o.(*WithDefaults); ok {
+			return v
+		}
+	}
+	return nil
+}
+
 // appendElem adds the element e to the path p and returns the resulting
 // path.
 func appendElem(p *gpb.Path, e *gpb.PathElem) *gpb.Path {
@@ -864,7 +948,25 @@ func appendElem(p *gpb.Path, e *gpb.PathElem) *gpb.Path {
 // behaviours, such as whether or not to ensure that the node's ancestors are initialized.
 // Note that SetNode does not do a full validation -- e.g., it does not do the string
 // regex restriction validation done by ytypes.Validate().
-func SetNode(schema *yang.Entry, root interface{}, path *gpb.Path, val interface{}, opts ...SetNodeOpt) error {
+func SetNode(schema *yang.Entry, root interface{}, path *gpb.Path, val interface{}, opts ...SetNodeOpt) (err error) {
+	done := util.StartOperation(util.OpSetNode)
+	defer func() { done(err) }()
+	if hasSetValidatePathSyntax(opts) {
+		if err := validatePathSyntax(path); err != nil {
+			return err
+		}
+	}
+	if a := setNodeAudit(opts); a != nil {
+		r := a.begin(AuditSet, schema, root, path, hasSetNodePreferShadowPath(opts))
+		defer func() { a.end(r, schema, root, hasSetNodePreferShadowPath(opts), err) }()
+	}
+	if nz := setNodeNormalizers(opts); nz != nil {
+		if tv, ok := val.(*gpb.TypedValue); ok {
+			if val, err = nz.NormalizeTypedValue(schema, path, tv); err != nil {
+				return status.Errorf(codes.InvalidArgument, "cannot normalize value %v: %v", tv, err)
+			}
+		}
+	}
 	nodes, err := retrieveNode(schema, root, path, nil, retrieveNodeArgs{
 		modifyRoot:                        hasInitMissingElements(opts),
 		val:                               val,
@@ -941,6 +1043,50 @@ func hasTolerateJSONInconsistencies(opts []SetNodeOpt) bool {
 	return false
 }
 
+// ValidatePathSyntax signals GetNode and SetNode to check that the supplied
+// path is well-formed, as per util.ValidateGNMIPath, before it is used. It is
+// intended for use where paths are received from an untrusted source, such as
+// a gNMI client, such that malformed paths are rejected with a precise error
+// rather than failing to match the schema.
+type ValidatePathSyntax struct{}
+
+// IsGetNodeOpt implements the GetNodeOpt interface.
+func (*ValidatePathSyntax) IsGetNodeOpt() {}
+
+// IsSetNodeOpt implements the SetNodeOpt interface.
+func (*ValidatePathSyntax) IsSetNodeOpt() {}
+
+// hasGetValidatePathSyntax determines whether there is an instance of
+// ValidatePathSyntax within the supplied GetNodeOpt slice.
+func hasGetValidatePathSyntax(opts []GetNodeOpt) bool {
+	for _, o := range opts {
+		if _, ok := o.(*ValidatePathSyntax); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSetValidatePathSyntax determines whether there is an instance of
+// ValidatePathSyntax within the supplied SetNodeOpt slice.
+func hasSetValidatePathSyntax(opts []SetNodeOpt) bool {
+	for _, o := range opts {
+		if _, ok := o.(*ValidatePathSyntax); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// validatePathSyntax checks that path is well-formed, returning an
+// InvalidArgument status error of kind util.ErrInvalidPath if it is not.
+func validatePathSyntax(path *gpb.Path) error {
+	if err := util.ValidateGNMIPath(path); err != nil {
+		return util.WithKind(util.ErrInvalidPath, status.Error(codes.InvalidArgument, err.Error()))
+	}
+	return nil
+}
+
 // DelNodeOpt defines an interface that can be used to supply arguments to functions using DeleteNode.
 type DelNodeOpt interface {
 	// IsDelNodeOpt is a marker method that is used to identify an instance of DelNodeOpt.
@@ -1026,8 +1172,12 @@ func hasDelNodePreferShadowPath(opts []DelNodeOpt) bool {
 // Regardless of whether the deletion operation is executed, any intermediate
 // non-leaf nodes traversed by the path that is equal to the empty struct or
 // map will be set to nil, similar to the behaviour of ygot.PruneEmptyBranches.
-func DeleteNode(schema *yang.Entry, root interface{}, path *gpb.Path, opts ...DelNodeOpt) error {
-	_, err := retrieveNode(schema, root, path, nil, retrieveNodeArgs{
+func DeleteNode(schema *yang.Entry, root interface{}, path *gpb.Path, opts ...DelNodeOpt) (err error) {
+	if a := delNodeAudit(opts); a != nil {
+		r := a.begin(AuditDelete, schema, root, path, hasDelNodePreferShadowPath(opts))
+		defer func() { a.end(r, schema, root, hasDelNodePreferShadowPath(opts), err) }()
+	}
+	_, err = retrieveNode(schema, root, path, nil, retrieveNodeArgs{
 		delete:           true,
 		preferShadowPath: hasDelNodePreferShadowPath(opts),
 	})
@@ -1188,3 +1188,28 @@ func TestDeleteNodeOrderedMap(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNodeWithDefaults(t *testing.T) {
+	root := &ctestschema.Device{}
+	root.GetOrCreateUnorderedList("a")
+	schema := ctestschema.SchemaTree["Device"]
+	path := mustPath("/unordered-lists/unordered-list[key=a]/config/value")
+
+	got, err := ytypes.GetNode(schema, root, path, &ytypes.WithDefaults{Mode: ygot.WithDefaultsReportAll})
+	if err != nil {
+		t.Fatalf("GetNode with report-all: got unexpected error: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("GetNode with report-all: got %d nodes, want 1", len(got))
+	}
+	if diff := cmp.Diff(ygot.String("default-value"), got[0].Data); diff != "" {
+		t.Errorf("GetNode with report-all: did not get default value (-want, +got):\n%s", diff)
+	}
+	if root.GetUnorderedList("a").Value != nil {
+		t.Errorf("GetNode with report-all: root was modified")
+	}
+
+	if _, err := ytypes.GetNode(schema, &ctestschema.OrderedList{}, path, &ytypes.WithDefaults{Mode: ygot.WithDefaultsMode(42)}); err == nil {
+		t.Errorf("GetNode with invalid mode: got nil error, want error")
+	}
+}
@@ -24,7 +24,10 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/openconfig/gnmi/errdiff"
 	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
 	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
 
@@ -3579,3 +3582,131 @@ func TestRetrieveContainerListError(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePathSyntax(t *testing.T) {
+	malformed := &gpb.Path{Elem: []*gpb.PathElem{{Name: "outer"}, {Name: ""}}}
+
+	err := SetNode(simpleSchema(), &ListElemStruct1{}, malformed, &gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: 42}}, &InitMissingElements{}, &ValidatePathSyntax{})
+	if diff := errdiff.Substring(err, "elem 1: empty name"); diff != "" {
+		t.Errorf("SetNode: did not get expected error, %s", diff)
+	}
+	if !errors.Is(err, util.ErrInvalidPath) {
+		t.Errorf("SetNode: got error %v, want error of kind ErrInvalidPath", err)
+	}
+	if got := status.Code(err); got != codes.InvalidArgument {
+		t.Errorf("SetNode: got status code %v, want %v", got, codes.InvalidArgument)
+	}
+
+	_, err = GetNode(simpleSchema(), &ListElemStruct1{}, malformed, &ValidatePathSyntax{})
+	if diff := errdiff.Substring(err, "elem 1: empty name"); diff != "" {
+		t.Errorf("GetNode: did not get expected error, %s", diff)
+	}
+
+	root := &ListElemStruct1{}
+	if err := SetNode(simpleSchema(), root, mustPath("/outer/inner/int32-leaf-field"), &gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: 42}}, &InitMissingElements{}, &ValidatePathSyntax{}); err != nil {
+		t.Fatalf("SetNode: got unexpected error for well-formed path: %v", err)
+	}
+	if got := root.Outer.Inner.Int32LeafName; got == nil || *got != 42 {
+		t.Errorf("SetNode: did not set expected value, got: %v", got)
+	}
+}
+
+// presenceParent is a GoStruct containing a YANG presence container.
+type presenceParent struct {
+	Overload *presenceOverload `path:"overload" yangPresence:"true"`
+}
+
+func (*presenceParent) IsYANGGoStruct()                          {}
+func (*presenceParent) ΛValidate(...ygot.ValidationOption) error { return nil }
+func (*presenceParent) ΛEnumTypeMap() map[string][]reflect.Type  { return nil }
+func (*presenceParent) ΛBelongingModule() string                 { return "" }
+
+type presenceOverload struct {
+	Timeout *uint32 `path:"timeout"`
+}
+
+func (*presenceOverload) IsYANGGoStruct()                          {}
+func (*presenceOverload) ΛValidate(...ygot.ValidationOption) error { return nil }
+func (*presenceOverload) ΛEnumTypeMap() map[string][]reflect.Type  { return nil }
+func (*presenceOverload) ΛBelongingModule() string                 { return "" }
+
+func presenceSchema() *yang.Entry {
+	root := &yang.Entry{
+		Name: "root",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"overload": {
+				Name: "overload",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"timeout": {
+						Name: "timeout",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Yuint32},
+					},
+				},
+			},
+		},
+	}
+	addParents(root)
+	return root
+}
+
+func TestPresenceContainer(t *testing.T) {
+	emptyJSON := &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte("{}")}}
+	overloadPath := mustPath("/overload")
+	timeoutPath := mustPath("/overload/timeout")
+
+	tests := []struct {
+		desc   string
+		inRoot *presenceParent
+		inFn   func(*yang.Entry, *presenceParent) error
+		want   *presenceParent
+	}{{
+		desc:   "create empty presence container",
+		inRoot: &presenceParent{},
+		inFn: func(s *yang.Entry, r *presenceParent) error {
+			return SetNode(s, r, overloadPath, emptyJSON, &InitMissingElements{})
+		},
+		want: &presenceParent{Overload: &presenceOverload{}},
+	}, {
+		desc:   "create empty presence container without initializing missing elements",
+		inRoot: &presenceParent{},
+		inFn: func(s *yang.Entry, r *presenceParent) error {
+			return SetNode(s, r, overloadPath, emptyJSON)
+		},
+		want: &presenceParent{Overload: &presenceOverload{}},
+	}, {
+		desc:   "delete empty presence container",
+		inRoot: &presenceParent{Overload: &presenceOverload{}},
+		inFn: func(s *yang.Entry, r *presenceParent) error {
+			return DeleteNode(s, r, overloadPath)
+		},
+		want: &presenceParent{},
+	}, {
+		desc:   "delete presence container with leaves",
+		inRoot: &presenceParent{Overload: &presenceOverload{Timeout: ygot.Uint32(42)}},
+		inFn: func(s *yang.Entry, r *presenceParent) error {
+			return DeleteNode(s, r, overloadPath)
+		},
+		want: &presenceParent{},
+	}, {
+		desc:   "delete last leaf within presence container",
+		inRoot: &presenceParent{Overload: &presenceOverload{Timeout: ygot.Uint32(42)}},
+		inFn: func(s *yang.Entry, r *presenceParent) error {
+			return DeleteNode(s, r, timeoutPath)
+		},
+		want: &presenceParent{Overload: &presenceOverload{}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if err := tt.inFn(presenceSchema(), tt.inRoot); err != nil {
+				t.Fatalf("got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, tt.inRoot); diff != "" {
+				t.Errorf("did not get expected tree, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
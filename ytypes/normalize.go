@@ -0,0 +1,41 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"github.com/openconfig/ygot/ygot"
+)
+
+// setNodeNormalizers returns the ygot.Normalizers within the supplied
+// SetNodeOpt slice, or nil if there is none.
+func setNodeNormalizers(opts []SetNodeOpt) *ygot.Normalizers {
+	for _, o := range opts {
+		if n, ok := o.(*ygot.Normalizers); ok && n != nil {
+			return n
+		}
+	}
+	return nil
+}
+
+// unmarshalNormalizers returns the ygot.Normalizers within the supplied
+// UnmarshalOpt slice, or nil if there is none.
+func unmarshalNormalizers(opts []UnmarshalOpt) *ygot.Normalizers {
+	for _, o := range opts {
+		if n, ok := o.(*ygot.Normalizers); ok && n != nil {
+			return n
+		}
+	}
+	return nil
+}
@@ -20,6 +20,8 @@ import (
 
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
 // Schema specifies the common types that are part of a generated ygot schema, such that
@@ -30,6 +32,11 @@ type Schema struct {
 	Unmarshal  UnmarshalFunc          // Unmarshal is a function that can unmarshal RFC7951 JSON into the specified Root type.
 }
 
+// Schema implements the ygot.VersionedSchema interface, such that the data
+// trees of generated packages can be accessed independently of the version
+// of the models that they were generated from.
+var _ ygot.VersionedSchema = (*Schema)(nil)
+
 // IsValid determines whether all required fields of the UnmarshalIETFJSON struct
 // have been populated.
 func (s *Schema) IsValid() bool {
@@ -50,5 +57,39 @@ func (s *Schema) Validate(vopts ...ygot.ValidationOption) error {
 	return ygot.ValidateGoStruct(s.Root, vopts...)
 }
 
+// NewRoot returns a new, empty instance of the type of the schema root. It
+// implements the ygot.VersionedSchema interface.
+func (s *Schema) NewRoot() ygot.GoStruct {
+	return reflect.New(reflect.TypeOf(s.Root).Elem()).Interface().(ygot.GoStruct)
+}
+
+// Get returns the values of the nodes within root, which must be of the
+// type of the schema root, that match path. Wildcards within path are
+// expanded. It implements the ygot.VersionedSchema interface.
+func (s *Schema) Get(root ygot.GoStruct, path *gpb.Path) ([]any, error) {
+	nodes, err := GetNode(s.RootSchema(), root, path, &GetHandleWildcards{})
+	if err != nil {
+		return nil, err
+	}
+	var vals []any
+	for _, n := range nodes {
+		vals = append(vals, n.Data)
+	}
+	return vals, nil
+}
+
+// Set sets the node at path within root, which must be of the type of the
+// schema root, to val, creating any missing parent nodes. It implements the
+// ygot.VersionedSchema interface.
+func (s *Schema) Set(root ygot.GoStruct, path *gpb.Path, val *gpb.TypedValue) error {
+	return SetNode(s.RootSchema(), root, path, val, &InitMissingElements{})
+}
+
+// Delete deletes the node at path within root, which must be of the type of
+// the schema root. It implements the ygot.VersionedSchema interface.
+func (s *Schema) Delete(root ygot.GoStruct, path *gpb.Path) error {
+	return DeleteNode(s.RootSchema(), root, path)
+}
+
 // UnmarshalFunc defines a common signature for an RFC7951 to ygot.GoStruct unmarshalling function
 type UnmarshalFunc func([]byte, ygot.GoStruct, ...UnmarshalOpt) error
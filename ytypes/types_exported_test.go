@@ -0,0 +1,56 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes_test
+
+import (
+	"testing"
+
+	"github.com/openconfig/ygot/integration_tests/schemaops/ctestschema"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestSchemaVersionedSchema(t *testing.T) {
+	var s ygot.VersionedSchema = &ytypes.Schema{Root: &ctestschema.Device{}, SchemaTree: ctestschema.SchemaTree}
+
+	root := s.NewRoot()
+	if _, ok := root.(*ctestschema.Device); !ok {
+		t.Fatalf("NewRoot: got %T, want *ctestschema.Device", root)
+	}
+
+	for _, k := range []string{"a", "b"} {
+		p := mustPath("/unordered-lists/unordered-list[key=" + k + "]/config/value")
+		if err := s.Set(root, p, &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "val-" + k}}); err != nil {
+			t.Fatalf("Set(%s): got unexpected error: %v", k, err)
+		}
+	}
+
+	got, err := s.Get(root, mustPath("/unordered-lists/unordered-list[key=*]/config/value"))
+	if err != nil {
+		t.Fatalf("Get: got unexpected error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("Get: got %d values, want 2: %v", len(got), got)
+	}
+
+	if err := s.Delete(root, mustPath("/unordered-lists/unordered-list[key=a]")); err != nil {
+		t.Fatalf("Delete: got unexpected error: %v", err)
+	}
+	if got := root.(*ctestschema.Device).UnorderedList; len(got) != 1 || got["b"] == nil {
+		t.Errorf("Delete: did not get expected list, got: %v", got)
+	}
+}
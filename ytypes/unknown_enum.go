@@ -0,0 +1,113 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// UnknownEnumWarning records an enumeration or identityref value that was
+// not defined in the schema, and hence was ignored whilst unmarshalling.
+type UnknownEnumWarning struct {
+	// Path is the schema path of the leaf or leaf-list whose value was
+	// ignored.
+	Path string
+	// Value is the value that was ignored, as it was received.
+	Value string
+}
+
+// String returns a human-readable representation of the warning.
+func (w *UnknownEnumWarning) String() string {
+	return fmt.Sprintf("%s: unknown enumerated value %q", w.Path, w.Value)
+}
+
+// TolerateUnknownEnums is an unmarshal option that causes enumeration and
+// identityref values that are not defined in the schema, such as those that
+// are reported by a device that implements a newer revision of the schema,
+// to be ignored rather than causing the unmarshal to fail. An unknown value
+// of a leaf results in the leaf being set to UNSET, whereas an unknown value
+// within a leaf-list is omitted from the leaf-list. A warning is appended to
+// Warnings for each value that is ignored, such that the option can be
+// inspected once Unmarshal returns.
+//
+// Values within unions are not tolerated, since an unknown value of a union
+// may be a valid value of one of its other types.
+type TolerateUnknownEnums struct {
+	// Warnings are the values that were ignored whilst unmarshalling.
+	Warnings []*UnknownEnumWarning
+}
+
+// IsUnmarshalOpt marks TolerateUnknownEnums as a valid UnmarshalOpt.
+func (*TolerateUnknownEnums) IsUnmarshalOpt() {}
+
+// tolerateUnknownEnums returns the TolerateUnknownEnums within the supplied
+// slice of UnmarshalOpts, or nil if there is none.
+func tolerateUnknownEnums(opts []UnmarshalOpt) *TolerateUnknownEnums {
+	for _, o := range opts {
+		if t, ok := o.(*TolerateUnknownEnums); ok && t != nil {
+			return t
+		}
+	}
+	return nil
+}
+
+// ignoreUnknownEnum determines whether the encoded value, which is to be
+// unmarshalled into the enumerated field fieldName of parent described by
+// schema, is a value that is not defined in the schema. If so, the field is
+// set to UNSET, or left unchanged if it is a leaf-list, a warning is
+// recorded, and true is returned. If false is returned, the value should be
+// unmarshalled as normal, such that any error with it is reported.
+func (t *TolerateUnknownEnums) ignoreUnknownEnum(schema *yang.Entry, parent interface{}, fieldName string, value interface{}, enc Encoding) bool {
+	var s string
+	switch v := value.(type) {
+	case string:
+		if enc != JSONEncoding {
+			return false
+		}
+		s = v
+	case *gpb.TypedValue:
+		sv, ok := v.GetValue().(*gpb.TypedValue_StringVal)
+		if !ok {
+			return false
+		}
+		s = sv.StringVal
+	default:
+		return false
+	}
+
+	pv := reflect.ValueOf(parent)
+	if !util.IsValueStructPtr(pv) {
+		return false
+	}
+	fv := pv.Elem().FieldByName(fieldName)
+	if !fv.IsValid() {
+		return false
+	}
+	if ev, err := castToEnumValue(fv.Type(), s); err != nil || ev != nil {
+		return false
+	}
+
+	t.Warnings = append(t.Warnings, &UnknownEnumWarning{Path: util.SchemaTreePath(schema), Value: s})
+	if fv.Kind() != reflect.Slice {
+		fv.Set(reflect.Zero(fv.Type()))
+	}
+	return true
+}
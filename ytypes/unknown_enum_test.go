@@ -0,0 +1,149 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// unknownEnumStruct is a struct containing enumerated leaves.
+type unknownEnumStruct struct {
+	Leaf     EnumType   `path:"leaf"`
+	LeafList []EnumType `path:"leaf-list"`
+}
+
+func TestTolerateUnknownEnums(t *testing.T) {
+	containerSchema := &yang.Entry{
+		Name: "container",
+		Kind: yang.DirectoryEntry,
+		Dir:  map[string]*yang.Entry{},
+	}
+	leafSchema := &yang.Entry{
+		Name: "leaf",
+		Kind: yang.LeafEntry,
+		Type: &yang.YangType{Kind: yang.Yenum},
+	}
+	leafListSchema := &yang.Entry{
+		Name:     "leaf-list",
+		Kind:     yang.LeafEntry,
+		ListAttr: yang.NewDefaultListAttr(),
+		Type:     &yang.YangType{Kind: yang.Yidentityref},
+	}
+	for _, s := range []*yang.Entry{leafSchema, leafListSchema} {
+		s.Parent = containerSchema
+		containerSchema.Dir[s.Name] = s
+	}
+
+	tests := []struct {
+		desc             string
+		inSchema         *yang.Entry
+		inParent         *unknownEnumStruct
+		inVal            interface{}
+		inEnc            Encoding
+		inTolerate       bool
+		want             *unknownEnumStruct
+		wantWarnings     []*UnknownEnumWarning
+		wantErrSubstring string
+	}{{
+		desc:       "known JSON value",
+		inSchema:   leafSchema,
+		inParent:   &unknownEnumStruct{},
+		inVal:      "E_VALUE_FORTY_TWO",
+		inEnc:      JSONEncoding,
+		inTolerate: true,
+		want:       &unknownEnumStruct{Leaf: 42},
+	}, {
+		desc:       "unknown JSON value is set to UNSET",
+		inSchema:   leafSchema,
+		inParent:   &unknownEnumStruct{Leaf: 42},
+		inVal:      "E_VALUE_FORTY_FOUR",
+		inEnc:      JSONEncoding,
+		inTolerate: true,
+		want:       &unknownEnumStruct{},
+		wantWarnings: []*UnknownEnumWarning{{
+			Path:  "/container/leaf",
+			Value: "E_VALUE_FORTY_FOUR",
+		}},
+	}, {
+		desc:             "unknown JSON value without option",
+		inSchema:         leafSchema,
+		inParent:         &unknownEnumStruct{},
+		inVal:            "E_VALUE_FORTY_FOUR",
+		inEnc:            JSONEncoding,
+		wantErrSubstring: "E_VALUE_FORTY_FOUR is not a valid value for enum field Leaf",
+	}, {
+		desc:     "unknown gNMI value within leaf-list is omitted",
+		inSchema: leafListSchema,
+		inParent: &unknownEnumStruct{},
+		inVal: &gpb.TypedValue{Value: &gpb.TypedValue_LeaflistVal{LeaflistVal: &gpb.ScalarArray{
+			Element: []*gpb.TypedValue{
+				{Value: &gpb.TypedValue_StringVal{StringVal: "E_VALUE_FORTY_ONE"}},
+				{Value: &gpb.TypedValue_StringVal{StringVal: "new-module:E_VALUE_FORTY_FOUR"}},
+				{Value: &gpb.TypedValue_StringVal{StringVal: "E_VALUE_FORTY_TWO"}},
+			},
+		}}},
+		inEnc:      GNMIEncoding,
+		inTolerate: true,
+		want:       &unknownEnumStruct{LeafList: []EnumType{41, 42}},
+		wantWarnings: []*UnknownEnumWarning{{
+			Path:  "/container/leaf-list",
+			Value: "new-module:E_VALUE_FORTY_FOUR",
+		}},
+	}, {
+		desc:             "wrongly typed gNMI value is not tolerated",
+		inSchema:         leafSchema,
+		inParent:         &unknownEnumStruct{},
+		inVal:            &gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: 42}},
+		inEnc:            GNMIEncoding,
+		inTolerate:       true,
+		wantErrSubstring: "failed to unmarshal",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var opts []UnmarshalOpt
+			tolerate := &TolerateUnknownEnums{}
+			if tt.inTolerate {
+				opts = append(opts, tolerate)
+			}
+			err := unmarshalGeneric(tt.inSchema, tt.inParent, tt.inVal, tt.inEnc, opts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("unmarshalGeneric: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, tt.inParent); diff != "" {
+				t.Errorf("unmarshalGeneric: (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantWarnings, tolerate.Warnings); diff != "" {
+				t.Errorf("unmarshalGeneric: did not get expected warnings, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnknownEnumWarningString(t *testing.T) {
+	w := &UnknownEnumWarning{Path: "/interfaces/interface/state/oper-status", Value: "DORMANT_V2"}
+	if got, want := w.String(), `/interfaces/interface/state/oper-status: unknown enumerated value "DORMANT_V2"`; got != want {
+		t.Errorf("String: got %q, want %q", got, want)
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
 )
 
 // UnmarshalOpt is an interface used for any option to be supplied
@@ -91,8 +92,27 @@ func (*PreferShadowPath) IsUnmarshalOpt() {}
 // parent, using the given schema. Any values already in the parent that are
 // not present in value are preserved. If provided schema is a leaf or leaf
 // list, parent must be referencing the parent GoStruct.
-func Unmarshal(schema *yang.Entry, parent interface{}, value interface{}, opts ...UnmarshalOpt) error {
-	return unmarshalGeneric(schema, parent, value, JSONEncoding, opts...)
+//
+// If a ygot.Normalizers option is supplied, and schema is that of a
+// container or list, the leaves of parent are normalized once value has
+// been unmarshalled.
+func Unmarshal(schema *yang.Entry, parent interface{}, value interface{}, opts ...UnmarshalOpt) (err error) {
+	done := util.StartOperation(util.OpUnmarshal)
+	defer func() { done(err) }()
+	return unmarshal(schema, parent, value, opts...)
+}
+
+// unmarshal implements Unmarshal, without observing the call.
+func unmarshal(schema *yang.Entry, parent interface{}, value interface{}, opts ...UnmarshalOpt) error {
+	if err := unmarshalGeneric(schema, parent, value, JSONEncoding, opts...); err != nil {
+		return err
+	}
+	if nz := unmarshalNormalizers(opts); nz != nil && schema != nil && schema.IsDir() {
+		if gs, ok := parent.(ygot.GoStruct); ok {
+			return nz.Normalize(schema, gs)
+		}
+	}
+	return nil
 }
 
 // Encoding specifies how the value provided to UnmarshalGeneric function is encoded.
@@ -118,13 +138,15 @@ const (
 // encoding type into the parent with the provided schema. When encoding mode
 // is GNMIEncoding, the schema needs to be pointing to a leaf or leaf list
 // schema.
-func unmarshalGeneric(schema *yang.Entry, parent interface{}, value interface{}, enc Encoding, opts ...UnmarshalOpt) error {
+func unmarshalGeneric(schema *yang.Entry, parent interface{}, value interface{}, enc Encoding, opts ...UnmarshalOpt) (err error) {
 	util.Indent()
 	defer util.Dedent()
 
 	if schema == nil {
 		return fmt.Errorf("nil schema for parent type %T, value %v (%T)", parent, value, value)
 	}
+	span := util.StartSpan("Unmarshal", schema)
+	defer func() { span.End(err) }()
 	util.DbgPrint("Unmarshal value %v, type %T, into parent type %T, schema name %s", util.ValueStrDebug(value), value, parent, schema.Name)
 
 	if enc == GNMIEncoding && !(schema.IsLeaf() || schema.IsLeafList()) {
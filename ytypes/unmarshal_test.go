@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
 )
 
 func TestUnmarshal(t *testing.T) {
@@ -86,3 +87,64 @@ func TestUnmarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalTrace(t *testing.T) {
+	type ParentStruct struct {
+		Leaf *string `path:"leaf"`
+	}
+	schema := &yang.Entry{
+		Name: "leaf",
+		Kind: yang.LeafEntry,
+		Type: &yang.YangType{
+			Kind: yang.Ystring,
+		},
+	}
+
+	var events []util.TraceEvent
+	util.SetTracer(util.TracerFunc(func(ev util.TraceEvent) {
+		if ev.Type == util.TraceSpanStart || ev.Type == util.TraceSpanEnd {
+			events = append(events, ev)
+		}
+	}))
+	defer util.SetTracer(nil)
+
+	if err := Unmarshal(schema, &ParentStruct{}, int64(42)); err == nil {
+		t.Fatalf("Unmarshal: did not get expected error")
+	}
+	if len(events) != 2 {
+		t.Fatalf("Unmarshal: got %d span events, want 2: %v", len(events), events)
+	}
+	if got := events[0]; got.Type != util.TraceSpanStart || got.Op != "Unmarshal" || got.Path != "/leaf" {
+		t.Errorf("Unmarshal: did not get expected span start event, got: %+v", got)
+	}
+	if got := events[1]; got.Type != util.TraceSpanEnd || got.Err == nil {
+		t.Errorf("Unmarshal: did not get expected failed span end event, got: %+v", got)
+	}
+}
+
+func TestUnmarshalMetrics(t *testing.T) {
+	stats := &util.OperationStats{}
+	util.SetMetricsRecorder(stats)
+	defer util.SetMetricsRecorder(nil)
+
+	// The unmarshalling and validation of the leaves of the container are
+	// not observed separately.
+	got := &extTop{}
+	if err := Unmarshal(extTopSchema, got, map[string]any{"name": "a", "vendor-name": "v"}); err != nil {
+		t.Fatalf("Unmarshal: unexpected error: %v", err)
+	}
+	if errs := Validate(extTopSchema, got); errs != nil {
+		t.Fatalf("Validate: unexpected errors: %v", errs)
+	}
+	if err := Unmarshal(extTopSchema, got, map[string]any{"bogus": "v"}); err == nil {
+		t.Fatalf("Unmarshal: did not get expected error")
+	}
+
+	s := stats.Snapshot()
+	if st := s[util.OpUnmarshal]; st.Count != 2 || st.Errors != 1 {
+		t.Errorf("Unmarshal: got %+v, want 2 calls with 1 error", st)
+	}
+	if st := s[util.OpValidate]; st.Count != 1 || st.Errors != 0 {
+		t.Errorf("Validate: got %+v, want 1 call with no errors", st)
+	}
+}
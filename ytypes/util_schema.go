@@ -51,33 +51,6 @@ func validateLengthSchema(schema *yang.Entry) error {
 	return nil
 }
 
-// lengthOk reports whether the given value of length falls within the ranges
-// allowed by yrs. Always returns true is yrs is empty.
-func lengthOk(yrs yang.YangRange, val uint64) bool {
-	return isInRanges(yrs, yang.FromUint(val))
-}
-
-// isInRanges reports whether the given value falls within the ranges allowed by
-// yrs. Always returns true is yrs is empty.
-func isInRanges(yrs yang.YangRange, val yang.Number) bool {
-	if len(yrs) == 0 {
-		return true
-	}
-	for _, yr := range yrs {
-		if isInRange(yr, val) {
-			return true
-		}
-	}
-	return false
-}
-
-// isInRange reports whether the given value falls within the range allowed by
-// yr.
-func isInRange(yr yang.YRange, val yang.Number) bool {
-	return (val.Less(yr.Max) || val.Equal(yr.Max)) &&
-		(yr.Min.Less(val) || yr.Min.Equal(val))
-}
-
 // validateListAttr validates any attributes of value present in the schema,
 // such as min/max elements. The schema and value can be a container,
 // list, or leaf-list type.
@@ -108,13 +81,13 @@ func validateListAttr(schema *yang.Entry, value interface{}) util.Errors {
 	// leaf-list. Check that the data tree falls within the required size
 	// bounds.
 	if size < schema.ListAttr.MinElements {
-		errors = util.AppendErr(errors, fmt.Errorf("list %s contains fewer than min required elements: %d < %d", schema.Name, size, schema.ListAttr.MinElements))
+		errors = util.AppendErr(errors, util.ConstraintErrorf(util.ErrElementsConstraint, "list %s contains fewer than min required elements: %d < %d", schema.Name, size, schema.ListAttr.MinElements))
 	}
 	// 0 is an invalid value for MaxElements
 	// (https://tools.ietf.org/html/rfc7950#section-7.7.6).
 	// For useability it best represents the value "unbounded".
 	if schema.ListAttr.MaxElements != 0 && size > schema.ListAttr.MaxElements {
-		errors = util.AppendErr(errors, fmt.Errorf("list %s contains more than max allowed elements: %d > %d", schema.Name, size, schema.ListAttr.MaxElements))
+		errors = util.AppendErr(errors, util.ConstraintErrorf(util.ErrElementsConstraint, "list %s contains more than max allowed elements: %d > %d", schema.Name, size, schema.ListAttr.MaxElements))
 	}
 	return errors
 }
@@ -278,14 +251,14 @@ func checkDataTreeAgainstPaths(jsonTree map[string]interface{}, dataPaths [][]st
 	case 1:
 		// Retain backwards compatibility with previous implementation that reported
 		// only the first error key.
-		return fmt.Errorf("JSON contains unexpected field %s", missingKeys[0])
+		return util.KindErrorf(util.ErrUnknownField, "JSON contains unexpected field %s", missingKeys[0])
 	default:
 		sort.Strings(missingKeys)
-		return fmt.Errorf("JSON contains unexpected field %v", missingKeys)
+		return util.KindErrorf(util.ErrUnknownField, "JSON contains unexpected field %v", missingKeys)
 	}
 
 	if len(unexpectedLeafNodes) != 0 {
-		return fmt.Errorf("JSON contains unexpected leaf field(s) %v at non-leaf node", unexpectedLeafNodes)
+		return util.KindErrorf(util.ErrUnknownField, "JSON contains unexpected leaf field(s) %v at non-leaf node", unexpectedLeafNodes)
 	}
 	return nil
 }
@@ -315,6 +288,9 @@ func schemaToStructFieldName(schema *yang.Entry, parent interface{}, preferShado
 
 	for i := 0; i < t.NumField(); i++ {
 		f := t.Field(i)
+		if util.IsYgotPresence(f) || util.IsYgotExtensions(f) {
+			continue
+		}
 		fieldName := f.Name
 		relativeSchemaPathFn := util.RelativeSchemaPath
 		if preferShadowPath {
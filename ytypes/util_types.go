@@ -393,7 +393,7 @@ func yangToJSONType(t yang.TypeKind) reflect.Type {
 	case yang.Yint8, yang.Yint16, yang.Yint32,
 		yang.Yuint8, yang.Yuint16, yang.Yuint32:
 		return reflect.TypeOf(float64(0))
-	case yang.Ybinary, yang.Ydecimal64, yang.Yenum, yang.Yidentityref, yang.Yint64, yang.Yuint64, yang.Ystring:
+	case yang.Ybinary, yang.Ybits, yang.Ydecimal64, yang.Yenum, yang.Yidentityref, yang.Yint64, yang.Yuint64, yang.Ystring:
 		return reflect.TypeOf(string(""))
 	case yang.Ybool:
 		return reflect.TypeOf(bool(false))
@@ -402,7 +402,6 @@ func yangToJSONType(t yang.TypeKind) reflect.Type {
 	case yang.Yunion:
 		return reflect.TypeOf(nil)
 	default:
-		// TODO(mostrowski): handle bitset.
 		log.Errorf("unexpected type %v in yangToJSONType", t)
 	}
 	return reflect.TypeOf(nil)
@@ -55,8 +55,59 @@ type CustomValidationOptions struct {
 func (*CustomValidationOptions) IsValidationOption() {}
 
 // Validate recursively validates the value of the given data tree struct
-// against the given schema.
+// against the given schema. Each of the errors returned is of kind
+// util.ErrValidation. The validators of any ValidatorRegistry within opts
+// are called after the built-in checks. If a ValidationPolicy is supplied
+// within opts, the errors that it classifies as warnings are not returned.
 func Validate(schema *yang.Entry, value interface{}, opts ...ygot.ValidationOption) util.Errors {
+	errs, _ := ValidateWithWarnings(schema, value, opts...)
+	return errs
+}
+
+// ValidateWithWarnings validates the value of the given data tree struct
+// against the given schema as per Validate, returning the errors that the
+// ValidationPolicy within opts classifies as warnings separately from the
+// remaining errors. If there is no ValidationPolicy within opts, no
+// warnings are returned.
+func ValidateWithWarnings(schema *yang.Entry, value interface{}, opts ...ygot.ValidationOption) (util.Errors, util.Errors) {
+	done := util.StartOperation(util.OpValidate)
+	errs, warnings := validateWithWarnings(schema, value, opts...)
+	if len(errs) != 0 {
+		done(errs)
+	} else {
+		done(nil)
+	}
+	return errs, warnings
+}
+
+// validateChild validates the value of a child of the node being validated
+// against its schema, as per Validate, without observing the call.
+func validateChild(schema *yang.Entry, value interface{}) util.Errors {
+	errs, _ := validateWithWarnings(schema, value)
+	return errs
+}
+
+// validateWithWarnings implements ValidateWithWarnings, without observing
+// the call.
+func validateWithWarnings(schema *yang.Entry, value interface{}, opts ...ygot.ValidationOption) (util.Errors, util.Errors) {
+	span := util.StartSpan("Validate", schema)
+	verrs := validate(schema, value, opts...)
+	if schema != nil && !util.IsValueNil(value) {
+		for _, r := range hasValidatorRegistries(opts) {
+			verrs = util.AppendErrs(verrs, r.validate(schema, value))
+		}
+	}
+	errs := util.ErrsWithKind(util.ErrValidation, verrs)
+	var warnings util.Errors
+	if p := hasValidationPolicy(opts); p != nil {
+		errs, warnings = p.classify(errs)
+	}
+	span.EndErrs(errs)
+	return errs, warnings
+}
+
+// validate implements Validate.
+func validate(schema *yang.Entry, value interface{}, opts ...ygot.ValidationOption) util.Errors {
 	// Nil value means the field is unset.
 	if util.IsValueNil(value) {
 		return nil
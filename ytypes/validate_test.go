@@ -15,11 +15,13 @@
 package ytypes
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
 
 	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
 	"github.com/openconfig/ygot/ygot"
 )
 
@@ -301,6 +303,9 @@ func TestValidate(t *testing.T) {
 					t.Errorf("%s: Validate did not get expected number of errors, got: %d, want: %d", tt.desc, len(errs), tt.wantErrLen)
 				}
 			}
+			if errs != nil && !errors.Is(errs, util.ErrValidation) {
+				t.Errorf("%s: Validate got errors %v, want errors of kind util.ErrValidation", tt.desc, errs)
+			}
 
 			testErrLog(t, tt.desc, errs)
 		})
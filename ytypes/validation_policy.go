@@ -0,0 +1,191 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// ValidationPolicy is a ygot.ValidationOption that classifies validation
+// errors as warnings, rather than errors, according to the kind of YANG
+// constraint that they report as violated, the node that they relate to,
+// or both. It allows stricter validation to be phased in without causing
+// existing data trees to fail validation.
+//
+// When a ValidationPolicy is supplied to Validate, or to the Validate method
+// of a generated GoStruct, the errors that it classifies as warnings are
+// not returned. ValidateWithWarnings returns them separately.
+//
+// A ValidationPolicy is safe for concurrent use.
+type ValidationPolicy struct {
+	// mu protects rules.
+	mu sync.RWMutex
+	// rules is the set of rules that classify errors as warnings.
+	rules []*warningRule
+}
+
+// warningRule classifies the errors that match both its kind and path as
+// warnings.
+type warningRule struct {
+	// kind is the constraint kind of the matched errors, or nil if errors
+	// of any kind are matched.
+	kind error
+	// path is the schema path of the nodes whose errors are matched, or
+	// nil if errors for any node are matched.
+	path *gpb.Path
+}
+
+// IsValidationOption ensures that ValidationPolicy implements the
+// ValidationOption interface.
+func (*ValidationPolicy) IsValidationOption() {}
+
+// NewValidationPolicy returns a ValidationPolicy that classifies all
+// validation errors as errors.
+func NewValidationPolicy() *ValidationPolicy {
+	return &ValidationPolicy{}
+}
+
+// Warn classifies the validation errors of the constraint kind, such as
+// util.ErrPatternConstraint, for nodes that match path as warnings. kind
+// may be nil to match errors of any kind, and path may be nil to match any
+// node.
+//
+// path is a schema path, from the root of the schema tree, that may
+// contain wildcards as per util.PathMatchesQuery, and whose elements must
+// not specify keys, e.g., "/interfaces/interface/config/mtu". It matches
+// the errors for the node at the path and its descendants.
+func (p *ValidationPolicy) Warn(kind error, path *gpb.Path) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.rules = append(p.rules, &warningRule{kind: kind, path: path})
+}
+
+// classify splits errs into errors and warnings.
+func (p *ValidationPolicy) classify(errs util.Errors) (util.Errors, util.Errors) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if len(p.rules) == 0 {
+		return errs, nil
+	}
+	var rest, warns util.Errors
+	for _, err := range errs {
+		if p.isWarning(err) {
+			warns = append(warns, err)
+		} else {
+			rest = append(rest, err)
+		}
+	}
+	return rest, warns
+}
+
+// isWarning reports whether err is matched by any of the rules of p.
+func (p *ValidationPolicy) isWarning(err error) bool {
+	var path *gpb.Path
+	for _, r := range p.rules {
+		if r.kind != nil && !errors.Is(err, r.kind) {
+			continue
+		}
+		if r.path != nil {
+			if path == nil {
+				if path = schemaNodePath(errorSchema(err)); path == nil {
+					continue
+				}
+			}
+			if !util.PathMatchesQuery(path, r.path) {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// hasValidationPolicy returns the first ValidationPolicy within opts, or
+// nil if there is none.
+func hasValidationPolicy(opts []ygot.ValidationOption) *ValidationPolicy {
+	for _, o := range opts {
+		if p, ok := o.(*ValidationPolicy); ok {
+			return p
+		}
+	}
+	return nil
+}
+
+// nodeError is a validation error for the data tree node with the schema
+// schema. Its message is that of the underlying error.
+type nodeError struct {
+	schema *yang.Entry
+	err    error
+}
+
+// Error implements the error interface.
+func (e *nodeError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *nodeError) Unwrap() error {
+	return e.err
+}
+
+// withNodeSchema returns errs with each of its errors recorded as being for
+// the node with the supplied schema.
+func withNodeSchema(errs util.Errors, schema *yang.Entry) util.Errors {
+	if len(errs) == 0 {
+		return nil
+	}
+	out := make(util.Errors, 0, len(errs))
+	for _, err := range errs {
+		out = append(out, &nodeError{schema: schema, err: err})
+	}
+	return out
+}
+
+// errorSchema returns the schema of the most specific node that err is
+// recorded as being for, or nil if there is none.
+func errorSchema(err error) *yang.Entry {
+	var schema *yang.Entry
+	for {
+		var ne *nodeError
+		if !errors.As(err, &ne) {
+			return schema
+		}
+		schema, err = ne.schema, ne.err
+	}
+}
+
+// schemaNodePath returns the path of the data tree node with the schema e
+// from the root of its schema tree, excluding choice and case statements,
+// or nil if e is nil.
+func schemaNodePath(e *yang.Entry) *gpb.Path {
+	if e == nil {
+		return nil
+	}
+	var elems []*gpb.PathElem
+	for ; e.Parent != nil; e = e.Parent {
+		if util.IsChoiceOrCase(e) {
+			continue
+		}
+		elems = append([]*gpb.PathElem{{Name: e.Name}}, elems...)
+	}
+	return &gpb.Path{Elem: elems}
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// newPolicyDevice returns a device with an invalid hostname, which violates
+// a pattern constraint, and an invalid prefix length, which violates a
+// range constraint.
+func newPolicyDevice() *exampleoc.Device {
+	d := &exampleoc.Device{}
+	d.GetOrCreateSystem().Hostname = ygot.String("-invalid")
+	d.GetOrCreateInterface("eth0").GetOrCreateSubinterface(0).GetOrCreateIpv4().GetOrCreateAddress("192.0.2.1").PrefixLength = ygot.Uint8(42)
+	return d
+}
+
+func TestValidationPolicy(t *testing.T) {
+	tests := []struct {
+		desc         string
+		inRules      []func(*ytypes.ValidationPolicy)
+		wantErrs     []string
+		wantWarnings []string
+	}{{
+		desc:     "no rules",
+		wantErrs: []string{"does not match regular expression", "outside specified ranges"},
+	}, {
+		desc: "warn by kind",
+		inRules: []func(*ytypes.ValidationPolicy){
+			func(p *ytypes.ValidationPolicy) { p.Warn(util.ErrPatternConstraint, nil) },
+		},
+		wantErrs:     []string{"outside specified ranges"},
+		wantWarnings: []string{"does not match regular expression"},
+	}, {
+		desc: "warn by path",
+		inRules: []func(*ytypes.ValidationPolicy){
+			func(p *ytypes.ValidationPolicy) { p.Warn(nil, mustPath("/interfaces")) },
+		},
+		wantErrs:     []string{"does not match regular expression"},
+		wantWarnings: []string{"outside specified ranges"},
+	}, {
+		desc: "warn by kind and path",
+		inRules: []func(*ytypes.ValidationPolicy){
+			func(p *ytypes.ValidationPolicy) {
+				p.Warn(util.ErrRangeConstraint, mustPath("/interfaces/interface/subinterfaces/subinterface/ipv4/addresses/address/config/prefix-length"))
+			},
+			func(p *ytypes.ValidationPolicy) {
+				p.Warn(util.ErrRangeConstraint, mustPath("/system/config/hostname"))
+			},
+		},
+		wantErrs:     []string{"does not match regular expression"},
+		wantWarnings: []string{"outside specified ranges"},
+	}, {
+		desc: "warn by wildcard path",
+		inRules: []func(*ytypes.ValidationPolicy){
+			func(p *ytypes.ValidationPolicy) {
+				p.Warn(nil, &gpb.Path{Elem: []*gpb.PathElem{{Name: "..."}, {Name: "hostname"}}})
+			},
+		},
+		wantErrs:     []string{"outside specified ranges"},
+		wantWarnings: []string{"does not match regular expression"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			p := ytypes.NewValidationPolicy()
+			for _, r := range tt.inRules {
+				r(p)
+			}
+			d := newPolicyDevice()
+			errs, warnings := ytypes.ValidateWithWarnings(exampleoc.SchemaTree["Device"], d, p)
+			checkErrs(t, "errors", errs, tt.wantErrs)
+			checkErrs(t, "warnings", warnings, tt.wantWarnings)
+			for _, w := range warnings {
+				if !errors.Is(w, util.ErrValidation) {
+					t.Errorf("got warning %v, want warning of kind ErrValidation", w)
+				}
+			}
+
+			err := d.Validate(p)
+			if len(tt.wantErrs) == 0 && err != nil {
+				t.Errorf("Validate: got unexpected error: %v", err)
+			}
+			for _, w := range tt.wantWarnings {
+				if err != nil && strings.Contains(err.Error(), w) {
+					t.Errorf("Validate: got error %v, want %q to be a warning", err, w)
+				}
+			}
+		})
+	}
+}
+
+// checkErrs checks that errs contains exactly one error containing each
+// of the substrings in want.
+func checkErrs(t *testing.T, desc string, errs util.Errors, want []string) {
+	t.Helper()
+	if len(errs) != len(want) {
+		t.Errorf("got %d %s (%v), want %d", len(errs), desc, errs, len(want))
+		return
+	}
+	for _, w := range want {
+		if !strings.Contains(errs.Error(), w) {
+			t.Errorf("got %s %v, want an error containing %q", desc, errs, w)
+		}
+	}
+}
+
+func TestValidateConstraintKinds(t *testing.T) {
+	errs := ytypes.Validate(exampleoc.SchemaTree["Device"], newPolicyDevice())
+	var pattern, rng bool
+	for _, err := range errs {
+		pattern = pattern || errors.Is(err, util.ErrPatternConstraint)
+		rng = rng || errors.Is(err, util.ErrRangeConstraint)
+	}
+	if !pattern || !rng {
+		t.Errorf("Validate: got errors %v, want errors of kinds ErrPatternConstraint and ErrRangeConstraint", errs)
+	}
+}
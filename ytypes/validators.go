@@ -0,0 +1,220 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// ValidatorFunc is a custom validation function that is called for a data
+// tree node, returning an error if the node is not valid.
+type ValidatorFunc func(n *TreeNode) error
+
+// registeredValidator is a ValidatorFunc that is registered within a
+// ValidatorRegistry.
+type registeredValidator struct {
+	// name is the name that the validator is registered with.
+	name string
+	// path is the path that the validator is bound to, relative to the
+	// validated struct, or nil if it is bound to an extension.
+	path *gpb.Path
+	// module and extension identify the YANG extension that the validator
+	// is bound to, where path is nil.
+	module, extension string
+	// fn is the validation function.
+	fn ValidatorFunc
+}
+
+// ValidatorRegistry is a set of custom validators, each of which is bound to
+// either a schema path or a YANG extension. A ValidatorRegistry is a
+// ygot.ValidationOption, such that when it is supplied to Validate, or to the
+// Validate method of a generated GoStruct, its validators are called for each
+// matching data tree node alongside the built-in checks, and the errors that
+// they return are included within those returned by Validate.
+//
+// A ValidatorRegistry is safe for concurrent use.
+type ValidatorRegistry struct {
+	// mu protects validators.
+	mu sync.RWMutex
+	// validators is the set of registered validators, keyed by name.
+	validators map[string]*registeredValidator
+}
+
+// IsValidationOption ensures that ValidatorRegistry implements the
+// ValidationOption interface.
+func (*ValidatorRegistry) IsValidationOption() {}
+
+// NewValidatorRegistry returns an empty ValidatorRegistry.
+func NewValidatorRegistry() *ValidatorRegistry {
+	return &ValidatorRegistry{validators: map[string]*registeredValidator{}}
+}
+
+// RegisterPath registers fn with the supplied name, such that it is called
+// for each data tree node that matches path, which is relative to the
+// struct being validated and may contain wildcards, e.g.,
+// "/interfaces/interface[name=*]/config/description". fn is not called for
+// nodes that are not set. An error is returned if a validator is already
+// registered with name.
+func (r *ValidatorRegistry) RegisterPath(name string, path *gpb.Path, fn ValidatorFunc) error {
+	if path == nil || fn == nil {
+		return fmt.Errorf("path and fn must be specified for validator %s", name)
+	}
+	return r.register(&registeredValidator{name: name, path: path, fn: fn})
+}
+
+// RegisterExtension registers fn with the supplied name, such that it is
+// called for each data tree node whose schema uses the YANG extension named
+// extension, that is defined by module, which may be either the name of the
+// module or the prefix that is used to refer to it, as per
+// util.FindExtensions. The extension statement can be retrieved within fn
+// using util.FindExtension on the Schema of the node. fn is not called for
+// nodes that are not set. An error is returned if a validator is already
+// registered with name.
+func (r *ValidatorRegistry) RegisterExtension(name, module, extension string, fn ValidatorFunc) error {
+	if extension == "" || fn == nil {
+		return fmt.Errorf("extension and fn must be specified for validator %s", name)
+	}
+	return r.register(&registeredValidator{name: name, module: module, extension: extension, fn: fn})
+}
+
+// register adds v to the registry.
+func (r *ValidatorRegistry) register(v *registeredValidator) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.validators[v.name]; ok {
+		return fmt.Errorf("validator %s is already registered", v.name)
+	}
+	r.validators[v.name] = v
+	return nil
+}
+
+// Unregister removes the validator registered with name, if there is one.
+func (r *ValidatorRegistry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.validators, name)
+}
+
+// Names returns the sorted names of the registered validators.
+func (r *ValidatorRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.validators))
+	for n := range r.validators {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validate calls the registered validators for the data tree nodes of value,
+// whose schema is schema, returning the errors that they return.
+func (r *ValidatorRegistry) validate(schema *yang.Entry, value interface{}) util.Errors {
+	root, ok := value.(ygot.GoStruct)
+	if !ok {
+		// Validators are only bound to the descendants of structs.
+		return nil
+	}
+	r.mu.RLock()
+	vs := make([]*registeredValidator, 0, len(r.validators))
+	for _, v := range r.validators {
+		vs = append(vs, v)
+	}
+	r.mu.RUnlock()
+	sort.Slice(vs, func(i, j int) bool { return vs[i].name < vs[j].name })
+
+	var errs util.Errors
+	for _, v := range vs {
+		paths := []*gpb.Path{v.path}
+		if v.path == nil {
+			paths = extensionPaths(schema, v.module, v.extension)
+		}
+		for _, p := range paths {
+			nodes, err := GetNode(schema, root, p, &GetHandleWildcards{}, &GetPartialKeyMatch{})
+			switch {
+			case err != nil && (errors.Is(err, util.ErrPathNotFound) || status.Code(err) == codes.NotFound):
+				continue
+			case err != nil:
+				errs = util.AppendErr(errs, fmt.Errorf("validator %s: cannot retrieve nodes: %v", v.name, err))
+				continue
+			}
+			for _, n := range nodes {
+				if util.IsValueNil(n.Data) {
+					continue
+				}
+				if err := v.fn(n); err != nil {
+					ps, perr := ygot.PathToString(n.Path)
+					if perr != nil {
+						ps = n.Path.String()
+					}
+					errs = util.AppendErr(errs, fmt.Errorf("validator %s: %s: %v", v.name, ps, err))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// extensionPaths returns the paths, relative to root, of the schema entries
+// beneath root that use the extension named extension defined by module,
+// with wildcards for the keys of each list.
+func extensionPaths(root *yang.Entry, module, extension string) []*gpb.Path {
+	var paths []*gpb.Path
+	util.WalkSchema(root, func(e *yang.Entry, _ int) util.IterationAction {
+		if e == root || !util.HasExtension(e, module, extension) {
+			return util.ContinueIteration
+		}
+		var elems []*gpb.PathElem
+		for n := e; n != nil && n != root; n = n.Parent {
+			if util.IsChoiceOrCase(n) {
+				continue
+			}
+			pe := &gpb.PathElem{Name: n.Name}
+			if n.IsList() && n.Key != "" {
+				pe.Key = map[string]string{}
+				for _, k := range strings.Fields(n.Key) {
+					pe.Key[k] = "*"
+				}
+			}
+			elems = append([]*gpb.PathElem{pe}, elems...)
+		}
+		paths = append(paths, &gpb.Path{Elem: elems})
+		return util.ContinueIteration
+	})
+	return paths
+}
+
+// hasValidatorRegistries returns the ValidatorRegistry options within opts.
+func hasValidatorRegistries(opts []ygot.ValidationOption) []*ValidatorRegistry {
+	var rs []*ValidatorRegistry
+	for _, o := range opts {
+		if r, ok := o.(*ValidatorRegistry); ok && r != nil {
+			rs = append(rs, r)
+		}
+	}
+	return rs
+}
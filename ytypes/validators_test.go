@@ -0,0 +1,156 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+type validatorRoot struct {
+	Item map[string]*validatorItem `path:"item"`
+}
+
+func (*validatorRoot) IsYANGGoStruct() {}
+
+type validatorItem struct {
+	Name *string `path:"name"`
+	Tag  *string `path:"tag"`
+}
+
+func (*validatorItem) IsYANGGoStruct() {}
+
+func (i *validatorItem) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"name": *i.Name}, nil
+}
+
+func validatorSchema() *yang.Entry {
+	root := &yang.Entry{
+		Name:       "device",
+		Kind:       yang.DirectoryEntry,
+		Annotation: map[string]interface{}{"isFakeRoot": true},
+		Dir:        map[string]*yang.Entry{},
+	}
+	item := &yang.Entry{
+		Name:     "item",
+		Kind:     yang.DirectoryEntry,
+		ListAttr: yang.NewDefaultListAttr(),
+		Key:      "name",
+		Parent:   root,
+		Dir:      map[string]*yang.Entry{},
+	}
+	root.Dir["item"] = item
+	item.Dir["name"] = &yang.Entry{
+		Name:   "name",
+		Kind:   yang.LeafEntry,
+		Type:   &yang.YangType{Kind: yang.Ystring},
+		Parent: item,
+	}
+	item.Dir["tag"] = &yang.Entry{
+		Name:   "tag",
+		Kind:   yang.LeafEntry,
+		Type:   &yang.YangType{Kind: yang.Ystring},
+		Parent: item,
+		Exts:   []*yang.Statement{{Keyword: "ex:upper", Argument: "tag", HasArgument: true}},
+	}
+	return root
+}
+
+func TestValidatorRegistry(t *testing.T) {
+	schema := validatorSchema()
+	newRoot := func(tags map[string]string) *validatorRoot {
+		r := &validatorRoot{Item: map[string]*validatorItem{}}
+		for n, tag := range tags {
+			r.Item[n] = &validatorItem{Name: ygot.String(n), Tag: ygot.String(tag)}
+		}
+		return r
+	}
+
+	reg := NewValidatorRegistry()
+	if err := reg.RegisterExtension("upper", "ex", "upper", func(n *TreeNode) error {
+		ext, ok := util.FindExtension(n.Schema, "ex", "upper")
+		if !ok {
+			return fmt.Errorf("extension not found")
+		}
+		if s := *n.Data.(*string); s != strings.ToUpper(s) {
+			return fmt.Errorf("%s %q is not upper case", ext.Argument, s)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("RegisterExtension: got unexpected error: %v", err)
+	}
+	if err := reg.RegisterPath("short-name", &gpb.Path{Elem: []*gpb.PathElem{{Name: "item", Key: map[string]string{"name": "*"}}, {Name: "name"}}}, func(n *TreeNode) error {
+		if len(*n.Data.(*string)) > 3 {
+			return fmt.Errorf("name is too long")
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("RegisterPath: got unexpected error: %v", err)
+	}
+	if err := reg.RegisterPath("short-name", &gpb.Path{}, func(*TreeNode) error { return nil }); err == nil {
+		t.Errorf("RegisterPath with duplicate name: got nil error, want error")
+	}
+
+	tests := []struct {
+		desc       string
+		in         *validatorRoot
+		wantErrors []string
+	}{{
+		desc: "valid",
+		in:   newRoot(map[string]string{"a": "X", "b": "Y"}),
+	}, {
+		desc:       "extension validator fails",
+		in:         newRoot(map[string]string{"a": "X", "b": "y"}),
+		wantErrors: []string{`validator upper: /item[name=b]/tag: tag "y" is not upper case`},
+	}, {
+		desc: "path and extension validators fail",
+		in:   newRoot(map[string]string{"long": "x"}),
+		wantErrors: []string{
+			"validator short-name: /item[name=long]/name: name is too long",
+			`validator upper: /item[name=long]/tag: tag "x" is not upper case`,
+		},
+	}, {
+		desc: "no matching nodes",
+		in:   &validatorRoot{},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			errs := Validate(schema, tt.in, reg)
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.wantErrors, "\n") {
+				t.Errorf("Validate: got errors %q, want %q", got, tt.wantErrors)
+			}
+		})
+	}
+
+	reg.Unregister("upper")
+	if errs := Validate(schema, newRoot(map[string]string{"a": "x"}), reg); errs != nil {
+		t.Errorf("Validate after Unregister: got errors %v, want nil", errs)
+	}
+	if got := reg.Names(); len(got) != 1 || got[0] != "short-name" {
+		t.Errorf("Names: got %v, want [short-name]", got)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"gopkg.in/yaml.v3"
+)
+
+// UnmarshalYAML unmarshals the YAML document data into the parent, which
+// must be a GoStruct described by the supplied schema. The YAML document is
+// expected to follow the conventions of RFC7951 JSON, as produced by
+// ygot.EmitYAML, such that it has the same structure as the JSON that is
+// accepted by Unmarshal. The supplied options control the behaviour of
+// unmarshalling in the same manner as for Unmarshal.
+func UnmarshalYAML(schema *yang.Entry, parent any, data []byte, opts ...UnmarshalOpt) error {
+	var v any
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("cannot unmarshal YAML: %v", err)
+	}
+	if v == nil {
+		return nil
+	}
+
+	// The YAML value is converted to the Go types that are produced by
+	// encoding/json such that its scalar values are those expected by
+	// Unmarshal, e.g., numbers are float64 values.
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("cannot convert YAML to JSON: %v", err)
+	}
+	var jv any
+	if err := json.Unmarshal(b, &jv); err != nil {
+		return fmt.Errorf("cannot convert YAML to JSON: %v", err)
+	}
+	return Unmarshal(schema, parent, jv, opts...)
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ytypes_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/exampleoc"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+)
+
+func TestUnmarshalYAML(t *testing.T) {
+	want := &exampleoc.Device{}
+	i := want.GetOrCreateInterface("eth0")
+	i.Description = ygot.String("uplink: core")
+	i.Mtu = ygot.Uint16(9000)
+	i.Type = exampleoc.IETFInterfaces_InterfaceType_ethernetCsmacd
+	i.GetOrCreateCounters().InOctets = ygot.Uint64(1 << 60)
+	i.GetOrCreateSubinterface(0).Enabled = ygot.Bool(true)
+	want.GetOrCreateSystem().GetOrCreateDns().Search = []string{"example.com", "example.net"}
+
+	emitted, err := ygot.EmitYAML(want, &ygot.EmitYAMLConfig{
+		RFC7951Config: &ygot.RFC7951JSONConfig{AppendModuleName: true},
+	})
+	if err != nil {
+		t.Fatalf("EmitYAML: got unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		desc             string
+		in               string
+		inOpts           []ytypes.UnmarshalOpt
+		want             *exampleoc.Device
+		wantErrSubstring string
+	}{{
+		desc: "round trip of EmitYAML output",
+		in:   emitted,
+		want: want,
+	}, {
+		desc: "hand-written YAML",
+		in: `
+openconfig-system:system:
+  config:
+    hostname: router1
+  dns:
+    config:
+      search: [example.com]
+`,
+		want: &exampleoc.Device{
+			System: &exampleoc.System{
+				Hostname: ygot.String("router1"),
+				Dns:      &exampleoc.System_Dns{Search: []string{"example.com"}},
+			},
+		},
+	}, {
+		desc: "empty document",
+		in:   "",
+		want: &exampleoc.Device{},
+	}, {
+		desc:             "invalid YAML",
+		in:               "system: [",
+		wantErrSubstring: "cannot unmarshal YAML",
+	}, {
+		desc:             "unknown field",
+		in:               "not-a-field: 1\n",
+		wantErrSubstring: "JSON contains unexpected field not-a-field",
+	}, {
+		desc:   "unknown field ignored",
+		in:     "not-a-field: 1\n",
+		inOpts: []ytypes.UnmarshalOpt{&ytypes.IgnoreExtraFields{}},
+		want:   &exampleoc.Device{},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := &exampleoc.Device{}
+			err := ytypes.UnmarshalYAML(exampleoc.SchemaTree["Device"], got, []byte(tt.in), tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("UnmarshalYAML: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UnmarshalYAML: did not get expected struct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}